@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Defaults of KrknScenarioRun and KrknOperatorTarget specs. The defaulting webhook, the
+// REST API and the controllers all apply these, so runs and targets behave the same
+// whether they were created through the API or with kubectl.
+const (
+	// DefaultKubeconfigPath is where the target kubeconfig is mounted in scenario pods
+	DefaultKubeconfigPath = "/home/krkn/.kube/config"
+	// DefaultMaxRetries is the number of retries of a failed cluster job
+	DefaultMaxRetries = 3
+	// DefaultRetryBackoff is the backoff strategy between retries
+	DefaultRetryBackoff = "exponential"
+	// DefaultRetryDelay is the delay before the first retry
+	DefaultRetryDelay = "10s"
+	// DefaultImagePullPolicy is the pull policy of the scenario container
+	DefaultImagePullPolicy = "Always"
+	// DefaultSecretType is the authentication method of a target
+	DefaultSecretType = "kubeconfig"
+)
+
+// Default sets the unset fields of the run spec to their defaults
+func (r *KrknScenarioRun) Default() {
+	spec := &r.Spec
+	if spec.KubeconfigPath == "" {
+		spec.KubeconfigPath = DefaultKubeconfigPath
+	}
+	if spec.MaxRetries == 0 {
+		spec.MaxRetries = DefaultMaxRetries
+	}
+	if spec.RetryBackoff == "" {
+		spec.RetryBackoff = DefaultRetryBackoff
+	}
+	if spec.RetryDelay == "" {
+		spec.RetryDelay = DefaultRetryDelay
+	}
+	if spec.ImagePullPolicy == "" {
+		spec.ImagePullPolicy = DefaultImagePullPolicy
+	}
+}
+
+// Default sets the unset fields of the target spec to their defaults
+func (t *KrknOperatorTarget) Default() {
+	if t.Spec.SecretType == "" {
+		t.Spec.SecretType = DefaultSecretType
+	}
+}
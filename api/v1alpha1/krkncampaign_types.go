@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CampaignWindow is the time window a campaign is planned in
+// +kubebuilder:validation:XValidation:rule="self.end > self.start",message="end must be after start"
+type CampaignWindow struct {
+	// Start is when the campaign begins
+	Start metav1.Time `json:"start"`
+	// End is when the campaign ends
+	End metav1.Time `json:"end"`
+}
+
+// Contains reports whether t is within the window
+func (w *CampaignWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start.Time) && !t.After(w.End.Time)
+}
+
+// KrknCampaignSpec defines the desired state of KrknCampaign
+type KrknCampaignSpec struct {
+	// DisplayName is the human-friendly name of the campaign, e.g. "Q3 game day"
+	// +optional
+	// +kubebuilder:validation:MaxLength=128
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description is a human-readable description of the campaign
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Window is when the campaign is planned. Runs created outside of it are still part
+	// of the campaign and flagged in its report.
+	// +optional
+	Window *CampaignWindow `json:"window,omitempty"`
+
+	// Facilitator is the user ID of the user who created the campaign; besides the
+	// admins, only the facilitator may update or delete it
+	// +optional
+	Facilitator string `json:"facilitator,omitempty"`
+
+	// Participants are the user IDs of the users taking part in the campaign
+	// +optional
+	// +listType=set
+	Participants []string `json:"participants,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+// +kubebuilder:printcolumn:name="Start",type=date,JSONPath=`.spec.window.start`
+// +kubebuilder:printcolumn:name="End",type=date,JSONPath=`.spec.window.end`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=kcmp
+
+// KrknCampaign is the Schema for the krkncampaigns API.
+// It groups the KrknScenarioRuns of a game day, which reference it with
+// spec.campaignRef, so that facilitators follow and report on them as a whole.
+//
+// The progress of a campaign is aggregated from its runs when it is read, it has no
+// status of its own.
+type KrknCampaign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KrknCampaignSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KrknCampaignList contains a list of KrknCampaign
+type KrknCampaignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknCampaign `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknCampaign{}, &KrknCampaignList{})
+}
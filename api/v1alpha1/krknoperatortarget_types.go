@@ -19,9 +19,26 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package v1alpha1
 
 import (
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// TargetAuthPluginRequired is the condition of a target whose kubeconfig authenticates
+// with an exec credential plugin or an auth provider. Scenario images without the plugin
+// cannot reach the cluster.
+const TargetAuthPluginRequired = "AuthPluginRequired"
+
+// TargetClusterUnavailable is the condition of a discovered target whose cluster is being
+// deprovisioned or is hibernating. The target is not ready while it is true, even when the
+// cluster still answers.
+const TargetClusterUnavailable = "ClusterUnavailable"
+
+// OperatorTargetProvider is the provider name KrknOperatorTargets are contributed under
+const OperatorTargetProvider = "krkn-operator"
+
 // KrknOperatorTargetSpec defines the desired state of KrknOperatorTarget.
 type KrknOperatorTargetSpec struct {
 	// UUID is the unique identifier for this target
@@ -38,8 +55,15 @@ type KrknOperatorTargetSpec struct {
 	// +kubebuilder:validation:Enum=kubeconfig;token;credentials
 	SecretType string `json:"secretType"`
 
-	// SecretUUID is the UUID of the Secret containing the kubeconfig
-	SecretUUID string `json:"secretUUID"`
+	// SecretUUID is the UUID of the Secret containing the kubeconfig. Either SecretUUID
+	// or VaultPath is set.
+	// +optional
+	SecretUUID string `json:"secretUUID,omitempty"`
+
+	// VaultPath is the path of the credentials of the target in the Vault KV v2 store of
+	// the operator, when they are kept there instead of in a Secret
+	// +optional
+	VaultPath string `json:"vaultPath,omitempty"`
 
 	// CABundle is the base64-encoded CA certificate bundle for TLS verification
 	// Optional - if not provided and SecretType is not kubeconfig, TLS verification will be skipped
@@ -51,6 +75,53 @@ type KrknOperatorTargetSpec struct {
 	// +kubebuilder:default=false
 	// +optional
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// Metrics configures the read-through proxy to the target cluster's Prometheus
+	// +optional
+	Metrics *TargetMetricsConfig `json:"metrics,omitempty"`
+
+	// TokenRotation configures the re-minting of the token of a token target from a
+	// ServiceAccount of the target cluster
+	// +optional
+	TokenRotation *TargetTokenRotation `json:"tokenRotation,omitempty"`
+}
+
+// TargetTokenRotation describes the ServiceAccount the token of a token target is minted
+// for. The stored kubeconfig must be allowed to create tokens for it.
+type TargetTokenRotation struct {
+	// ServiceAccount is the ServiceAccount of the target cluster the token is minted for
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+
+	// ExpirationSeconds is the requested lifetime of the minted tokens
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:default=86400
+	// +optional
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+
+	// Automatic re-mints the token before it expires. Otherwise it is only rotated on request.
+	// +optional
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// ServiceAccountReference identifies a ServiceAccount of a target cluster
+type ServiceAccountReference struct {
+	// Namespace of the ServiceAccount
+	Namespace string `json:"namespace"`
+
+	// Name of the ServiceAccount
+	Name string `json:"name"`
+}
+
+// TargetMetricsConfig describes how to query the Prometheus of a target cluster.
+// The optional bearer token is stored in the target Secret under the prometheus-token key.
+type TargetMetricsConfig struct {
+	// PrometheusURL is the base URL of the Prometheus (or Thanos Querier) HTTP API
+	// +kubebuilder:validation:Pattern=`^https?://`
+	PrometheusURL string `json:"prometheusURL"`
+
+	// InsecureSkipTLSVerify skips TLS certificate verification of the Prometheus endpoint
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 // KrknOperatorTargetStatus defines the observed state of KrknOperatorTarget.
@@ -62,6 +133,107 @@ type KrknOperatorTargetStatus struct {
 	// LastUpdated is the timestamp of the last update
 	// +optional
 	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Archived indicates the target has been soft-deleted. Archived targets are
+	// hidden from target pickers and cannot be used for new scenario runs.
+	// +optional
+	Archived bool `json:"archived,omitempty"`
+
+	// ArchivedAt is when the target was archived
+	// +optional
+	ArchivedAt *metav1.Time `json:"archivedAt,omitempty"`
+
+	// PurgeAfter is when the archived target and its Secret become eligible for
+	// permanent deletion. The target can be restored until this time.
+	// +optional
+	PurgeAfter *metav1.Time `json:"purgeAfter,omitempty"`
+
+	// LastRunTime is when the last scenario job on this target finished
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// LastRunName is the KrknScenarioRun of the last scenario job on this target
+	// +optional
+	LastRunName string `json:"lastRunName,omitempty"`
+
+	// LastRunPhase is the outcome of the last scenario job on this target
+	// +kubebuilder:validation:Enum=Succeeded;Failed;Cancelled
+	// +optional
+	LastRunPhase string `json:"lastRunPhase,omitempty"`
+
+	// RecentRunPhases are the outcomes of the last scenario jobs on this target, oldest first
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	RecentRunPhases []string `json:"recentRunPhases,omitempty"`
+
+	// RecentSuccessRate is the percentage of succeeded jobs among the recent ones that
+	// succeeded or failed, unset when there are none
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	RecentSuccessRate *int32 `json:"recentSuccessRate,omitempty"`
+
+	// ConsecutiveFailures is the number of jobs that failed on this target since its last success
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastProbeTime is when the connectivity of the target cluster was last checked
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// ProbeLatencyMilliseconds is how long the last successful connectivity check took,
+	// unset while the cluster is unreachable
+	// +optional
+	ProbeLatencyMilliseconds *int64 `json:"probeLatencyMilliseconds,omitempty"`
+
+	// ProbeError is why the last connectivity check failed, empty when it succeeded
+	// +optional
+	ProbeError string `json:"probeError,omitempty"`
+
+	// AuthPlugins are the exec credential plugins and auth providers the kubeconfig of the
+	// target authenticates with, which scenario images must bundle
+	// +optional
+	AuthPlugins []string `json:"authPlugins,omitempty"`
+
+	// TokenExpiresAt is when the last token minted for the target expires
+	// +optional
+	TokenExpiresAt *metav1.Time `json:"tokenExpiresAt,omitempty"`
+
+	// LastTokenRotation is when the token of the target was last re-minted
+	// +optional
+	LastTokenRotation *metav1.Time `json:"lastTokenRotation,omitempty"`
+
+	// Conditions represent the latest available observations of the target:
+	// AuthPluginRequired, ClusterUnavailable
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SetAuthPlugins records the auth plugins of the kubeconfig of the target and sets its
+// AuthPluginRequired condition accordingly
+func (s *KrknOperatorTargetStatus) SetAuthPlugins(plugins []string, generation int64) {
+	s.AuthPlugins = plugins
+	condition := metav1.Condition{
+		Type:               TargetAuthPluginRequired,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "StaticCredentials",
+		Message:            "The kubeconfig authenticates without plugin",
+	}
+	if len(plugins) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AuthPluginInKubeconfig"
+		condition.Message = "The kubeconfig authenticates with " + strings.Join(plugins, ", ") +
+			", which the scenario images must bundle"
+	}
+	apimeta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// ClusterUnavailable reports whether the TargetClusterUnavailable condition is true
+func (s *KrknOperatorTargetStatus) ClusterUnavailable() bool {
+	return apimeta.IsStatusConditionTrue(s.Conditions, TargetClusterUnavailable)
 }
 
 // +kubebuilder:object:root=true
@@ -70,8 +242,13 @@ type KrknOperatorTargetStatus struct {
 // +kubebuilder:printcolumn:name="API URL",type=string,JSONPath=`.spec.clusterAPIURL`
 // +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.secretType`
 // +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Archived",type=boolean,JSONPath=`.status.archived`,priority=1
+// +kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.lastRunPhase`
+// +kubebuilder:printcolumn:name="Success Rate",type=integer,JSONPath=`.status.recentSuccessRate`,priority=1
+// +kubebuilder:printcolumn:name="Latency (ms)",type=integer,JSONPath=`.status.probeLatencyMilliseconds`,priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:shortName=kot
+// +kubebuilder:storageversion
 
 // KrknOperatorTarget is the Schema for the krknoperatortargets API.
 type KrknOperatorTarget struct {
@@ -91,6 +268,20 @@ type KrknOperatorTargetList struct {
 	Items           []KrknOperatorTarget `json:"items"`
 }
 
+// ArchivedClusters returns the sorted names of the clusters of targetClusters that are
+// archived targets of the list. Only the clusters of OperatorTargetProvider can be.
+func (l *KrknOperatorTargetList) ArchivedClusters(targetClusters map[string][]string) []string {
+	var archived []string
+	for _, target := range l.Items {
+		if target.Status.Archived && slices.Contains(targetClusters[OperatorTargetProvider], target.Spec.ClusterName) &&
+			!slices.Contains(archived, target.Spec.ClusterName) {
+			archived = append(archived, target.Spec.ClusterName)
+		}
+	}
+	slices.Sort(archived)
+	return archived
+}
+
 func init() {
 	SchemeBuilder.Register(&KrknOperatorTarget{}, &KrknOperatorTargetList{})
 }
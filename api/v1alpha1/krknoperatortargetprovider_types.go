@@ -22,6 +22,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ProviderActivationReasonAnnotation records why an admin last activated or deactivated a provider
+	ProviderActivationReasonAnnotation = "krkn.krkn-chaos.dev/activation-reason"
+	// ProviderActivationChangedByAnnotation records the admin who last activated or deactivated
+	// a provider. A provider deactivated by an admin stays inactive when it restarts.
+	ProviderActivationChangedByAnnotation = "krkn.krkn-chaos.dev/activation-changed-by"
+)
+
 // KrknOperatorTargetProviderSpec defines the desired state of KrknOperatorTargetProvider.
 type KrknOperatorTargetProviderSpec struct {
 	// OperatorName is the unique identifier for this operator instance
@@ -35,6 +43,12 @@ type KrknOperatorTargetProviderSpec struct {
 type KrknOperatorTargetProviderStatus struct {
 	// Timestamp represents the last heartbeat/update time from the operator
 	Timestamp metav1.Time `json:"timestamp,omitempty"`
+	// ObservedActive is the activation state last observed by the operator
+	// +optional
+	ObservedActive *bool `json:"observedActive,omitempty"`
+	// ActivationChangedAt is when the operator observed the last activation change
+	// +optional
+	ActivationChangedAt *metav1.Time `json:"activationChangedAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
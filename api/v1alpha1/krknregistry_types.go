@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RegistryTokenKey is the key of the credentials Secret of a KrknRegistry holding a
+// token, used instead of the username and password keys when set
+const RegistryTokenKey = "token"
+
+// KrknRegistrySpec defines the desired state of KrknRegistry.
+type KrknRegistrySpec struct {
+	// Description is a human-readable description of the registry
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// RegistryURL is the URL of the container registry, e.g. registry.example.com:5000
+	// +kubebuilder:validation:MinLength=1
+	RegistryURL string `json:"registryURL"`
+
+	// ScenarioRepository is the repository path of the scenario images in the registry
+	// +kubebuilder:validation:MinLength=1
+	ScenarioRepository string `json:"scenarioRepository"`
+
+	// CredentialsSecret is the Secret in the operator namespace holding the credentials:
+	// the username and password keys of a kubernetes.io/basic-auth Secret, or a token key.
+	// Without it the registry is accessed anonymously.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// SkipTLS skips the verification of the registry certificate
+	// +optional
+	SkipTLS bool `json:"skipTls,omitempty"`
+
+	// Insecure accesses the registry over plain HTTP
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Registry",type=string,JSONPath=`.spec.registryURL`
+// +kubebuilder:printcolumn:name="Repository",type=string,JSONPath=`.spec.scenarioRepository`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=kreg
+
+// KrknRegistry is the Schema for the krknregistries API.
+// It names a private scenario registry and the Secret holding its credentials, so the
+// scenario endpoints and KrknScenarioRuns reference it with registryRef instead of
+// carrying the credentials in request bodies and run specs.
+type KrknRegistry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KrknRegistrySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KrknRegistryList contains a list of KrknRegistry.
+type KrknRegistryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknRegistry `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknRegistry{}, &KrknRegistryList{})
+}
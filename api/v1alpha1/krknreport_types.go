@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Severities of a KrknReport
+const (
+	ReportSeverityInfo     = "info"
+	ReportSeverityWarning  = "warning"
+	ReportSeverityCritical = "critical"
+)
+
+// KrknReportSpec defines the content of KrknReport.
+type KrknReportSpec struct {
+	// Severity classifies the failure for whoever triages the reports
+	// +kubebuilder:validation:Enum=info;warning;critical
+	Severity string `json:"severity"`
+
+	// ScenarioRun is the name of the KrknScenarioRun the failed job belongs to
+	ScenarioRun string `json:"scenarioRun"`
+
+	// ScenarioName is the scenario the failed job ran
+	// +optional
+	ScenarioName string `json:"scenarioName,omitempty"`
+
+	// ClusterName is the cluster the failed job targeted
+	ClusterName string `json:"clusterName"`
+
+	// JobID is the ID of the failed job
+	JobID string `json:"jobId"`
+
+	// Hook is the name of the onFailure hook that created the report
+	Hook string `json:"hook"`
+
+	// FailureReason is the reason of the job failure
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// Message is the last status message of the failed job
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Severity",type=string,JSONPath=`.spec.severity`
+// +kubebuilder:printcolumn:name="Run",type=string,JSONPath=`.spec.scenarioRun`
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.failureReason`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=krep
+
+// KrknReport is the Schema for the krknreports API.
+// It records a cluster job that failed with no retry left, created by the report action
+// of the onFailure hooks of its run, so failures can be listed and triaged by severity.
+type KrknReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KrknReportSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KrknReportList contains a list of KrknReport.
+type KrknReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknReport{}, &KrknReportList{})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSensitiveEnvName(t *testing.T) {
+	tests := map[string]bool{
+		"ES_PASSWORD":                    true,
+		"AWS_SECRET_ACCESS_KEY":          true,
+		"GOOGLE_APPLICATION_CREDENTIALS": true,
+		"api-token":                      true,
+		"CAPTURE_METRICS":                false,
+		"DURATION":                       false,
+		"KEYBOARD_LAYOUT":                false,
+	}
+	for name, want := range tests {
+		if got := IsSensitiveEnvName(name); got != want {
+			t.Errorf("IsSensitiveEnvName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRedactedEnvironment(t *testing.T) {
+	spec := KrknScenarioRunSpec{
+		Environment: map[string]string{
+			"NAMESPACE":   "default",
+			"ES_PASSWORD": "hunter2",
+			"WEBHOOK_URL": "https://hooks.example.com/T000/B000/XXXX",
+		},
+		SecretEnvironment: []string{"WEBHOOK_URL"},
+	}
+	want := map[string]string{
+		"NAMESPACE":   "default",
+		"ES_PASSWORD": RedactedEnvValue,
+		"WEBHOOK_URL": RedactedEnvValue,
+	}
+	if got := spec.RedactedEnvironment(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactedEnvironment() = %v, want %v", got, want)
+	}
+	if spec.Environment["ES_PASSWORD"] != "hunter2" {
+		t.Error("RedactedEnvironment() must not change the spec")
+	}
+
+	// Variables held by the Secret of the run are listed too
+	spec.Environment = map[string]string{"NAMESPACE": "default"}
+	spec.SecretEnvironmentRef = "run-1-env"
+	want = map[string]string{"NAMESPACE": "default", "WEBHOOK_URL": RedactedEnvValue}
+	if got := spec.RedactedEnvironment(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactedEnvironment() = %v, want %v", got, want)
+	}
+}
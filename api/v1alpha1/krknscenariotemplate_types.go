@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KrknScenarioTemplateSpec defines the desired state of KrknScenarioTemplate.
+type KrknScenarioTemplateSpec struct {
+	// Description is a human-readable description of the template
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// ScenarioName is the name of the scenario the template runs
+	// +kubebuilder:validation:MinLength=1
+	ScenarioName string `json:"scenarioName"`
+
+	// ScenarioImage is the container image of the scenario
+	// +kubebuilder:validation:MinLength=1
+	ScenarioImage string `json:"scenarioImage"`
+
+	// Resources are the compute resource requests and limits of the scenario container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Files are mounted in the scenario pod, unless the run mounts a file at the same path
+	// +optional
+	Files []FileMount `json:"files,omitempty"`
+
+	// Environment holds the default values of the scenario variables, overridden by the
+	// variables the run sets
+	// +optional
+	Environment map[string]string `json:"environment,omitempty"`
+
+	// SecretEnvironment lists the variables of Environment holding credentials, passed to
+	// the scenario pod from a Secret
+	// +optional
+	// +listType=set
+	SecretEnvironment []string `json:"secretEnvironment,omitempty"`
+
+	// RegistryRef is the name of the KrknRegistry the scenario image is pulled from
+	// +optional
+	RegistryRef string `json:"registryRef,omitempty"`
+
+	// TimeoutSeconds is the default timeout of the scenario pods
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Scenario",type=string,JSONPath=`.spec.scenarioName`
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.scenarioImage`,priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=ktmpl
+
+// KrknScenarioTemplate is the Schema for the krknscenariotemplates API.
+// It captures a parameterized scenario run: KrknScenarioRuns reference it with
+// templateRef and only set the targets and the values they override.
+type KrknScenarioTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KrknScenarioTemplateSpec `json:"spec,omitempty"`
+}
+
+// Render fills the fields spec leaves unset from the template: the scenario, its image,
+// resources, registry and timeout, the environment variables spec does not set and the
+// files mounted at paths spec does not use. Values of spec always win, so rendering a
+// rendered spec again changes nothing.
+func (t *KrknScenarioTemplate) Render(spec *KrknScenarioRunSpec) {
+	template := &t.Spec
+	if spec.ScenarioName == "" {
+		spec.ScenarioName = template.ScenarioName
+	}
+	if spec.ScenarioImage == "" {
+		spec.ScenarioImage = template.ScenarioImage
+	}
+	if spec.Resources == nil && template.Resources != nil {
+		spec.Resources = template.Resources.DeepCopy()
+	}
+	if spec.TimeoutSeconds == nil && template.TimeoutSeconds != nil {
+		timeout := *template.TimeoutSeconds
+		spec.TimeoutSeconds = &timeout
+	}
+	// Inline registries of the run replace the one of the template
+	if spec.RegistryRef == "" && spec.RegistryURL == "" {
+		spec.RegistryRef = template.RegistryRef
+	}
+
+	for name, value := range template.Environment {
+		if _, set := spec.Environment[name]; set {
+			continue
+		}
+		if spec.Environment == nil {
+			spec.Environment = make(map[string]string, len(template.Environment))
+		}
+		spec.Environment[name] = value
+	}
+	for _, name := range template.SecretEnvironment {
+		if !slices.Contains(spec.SecretEnvironment, name) {
+			spec.SecretEnvironment = append(spec.SecretEnvironment, name)
+		}
+	}
+	for _, file := range template.Files {
+		if !slices.ContainsFunc(spec.Files, func(f FileMount) bool { return f.MountPath == file.MountPath }) {
+			spec.Files = append(spec.Files, file)
+		}
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// KrknScenarioTemplateList contains a list of KrknScenarioTemplate.
+type KrknScenarioTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknScenarioTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknScenarioTemplate{}, &KrknScenarioTemplateList{})
+}
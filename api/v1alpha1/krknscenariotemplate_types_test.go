@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestKrknScenarioTemplateRender(t *testing.T) {
+	templateTimeout, runTimeout := int64(600), int64(120)
+	template := &KrknScenarioTemplate{Spec: KrknScenarioTemplateSpec{
+		ScenarioName:  "pod-scenarios",
+		ScenarioImage: "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		Files: []FileMount{
+			{Name: "scenario.yaml", Content: "dGVtcGxhdGU=", MountPath: "/tmp/scenario.yaml"},
+			{Name: "extra.yaml", Content: "ZXh0cmE=", MountPath: "/tmp/extra.yaml"},
+		},
+		Environment:       map[string]string{"NAMESPACE": "default", "POD_LABEL": "app=nginx", "API_TOKEN": "t0ken"},
+		SecretEnvironment: []string{"API_TOKEN"},
+		RegistryRef:       "internal",
+		TimeoutSeconds:    &templateTimeout,
+	}}
+
+	spec := KrknScenarioRunSpec{
+		TemplateRef:    "pod-chaos",
+		Environment:    map[string]string{"NAMESPACE": "payments"},
+		Files:          []FileMount{{Name: "scenario.yaml", Content: "cnVu", MountPath: "/tmp/scenario.yaml"}},
+		TimeoutSeconds: &runTimeout,
+	}
+	template.Render(&spec)
+
+	want := KrknScenarioRunSpec{
+		TemplateRef:   "pod-chaos",
+		ScenarioName:  "pod-scenarios",
+		ScenarioImage: "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+		Resources:     template.Spec.Resources,
+		Files: []FileMount{
+			{Name: "scenario.yaml", Content: "cnVu", MountPath: "/tmp/scenario.yaml"},
+			{Name: "extra.yaml", Content: "ZXh0cmE=", MountPath: "/tmp/extra.yaml"},
+		},
+		Environment:       map[string]string{"NAMESPACE": "payments", "POD_LABEL": "app=nginx", "API_TOKEN": "t0ken"},
+		SecretEnvironment: []string{"API_TOKEN"},
+		RegistryRef:       "internal",
+		TimeoutSeconds:    &runTimeout,
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("Render() = %+v, want %+v", spec, want)
+	}
+	if spec.Resources == template.Spec.Resources {
+		t.Error("Render() shares the resources of the template")
+	}
+
+	// Rendering is idempotent
+	template.Render(&spec)
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("Render() of a rendered spec = %+v, want %+v", spec, want)
+	}
+
+	// Inline registries of the run are kept
+	inline := KrknScenarioRunSpec{RegistryURL: "registry.example.com"}
+	template.Render(&inline)
+	if inline.RegistryRef != "" {
+		t.Errorf("Render() set registryRef %q next to an inline registry", inline.RegistryRef)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// KrknTargetGroupSpec defines the desired state of KrknTargetGroup.
+// A target group is a named set of clusters, listed statically, selected by label, or both.
+// +kubebuilder:validation:XValidation:rule="has(self.targets) || has(self.selector)",message="targets or selector is required"
+type KrknTargetGroupSpec struct {
+	// Description is a human-readable description of the group
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Targets is a map of provider-name to list of cluster names that are always members
+	// Example: {"krkn-operator": ["cluster1", "cluster2"]}
+	// +optional
+	// +kubebuilder:validation:MinProperties=1
+	Targets map[string][]string `json:"targets,omitempty"`
+
+	// Selector is a label selector, e.g. "env=staging,region=eu". The targets of the
+	// target request of a run whose labels match it are members as well.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+}
+
+// Members returns the clusters of the group for the targets of a target request: its
+// static targets and the targets matching its selector, as provider-name to sorted
+// cluster names without duplicates.
+func (g *KrknTargetGroup) Members(status *KrknTargetRequestStatus) (map[string][]string, error) {
+	members := map[string][]string{}
+	for providerName, clusterNames := range g.Spec.Targets {
+		members[providerName] = append(members[providerName], clusterNames...)
+	}
+	if g.Spec.Selector != "" {
+		selector, err := labels.Parse(g.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		for providerName, clusterNames := range status.SelectTargets(selector) {
+			members[providerName] = append(members[providerName], clusterNames...)
+		}
+	}
+	for providerName, clusterNames := range members {
+		slices.Sort(clusterNames)
+		members[providerName] = slices.Compact(clusterNames)
+	}
+	return members, nil
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Selector",type=string,JSONPath=`.spec.selector`
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=ktg
+
+// KrknTargetGroup is the Schema for the krkntargetgroups API.
+// It names a reusable set of clusters that KrknScenarioRuns reference with
+// spec.targetGroupRef instead of listing the clusters themselves.
+//
+// Membership is resolved when a run starts, so a group with a selector follows the
+// labels of the targets; the members a run started with are kept in its status.
+type KrknTargetGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KrknTargetGroupSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KrknTargetGroupList contains a list of KrknTargetGroup.
+type KrknTargetGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknTargetGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknTargetGroup{}, &KrknTargetGroupList{})
+}
@@ -19,18 +19,52 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package v1alpha1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// TargetRequestDryRunLabel marks the sandbox requests of a provider verification,
+// which are deleted once the providers have been checked and cannot be used by runs
+const TargetRequestDryRunLabel = "krkn.krkn-chaos.dev/dry-run"
+
 // ClusterTarget represents the target cluster information
 type ClusterTarget struct {
 	// ClusterName is the name of the managed cluster
 	ClusterName string `json:"cluster-name"`
 	// ClusterAPIURL is the API server URL of the managed cluster
 	ClusterAPIURL string `json:"cluster-api-url"`
+	// Labels are the labels of the target, matched by the target selector of scenario runs
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// ClusterUID identifies the cluster at its provider, e.g. the UID of the object it is
+	// discovered from, and is kept when the cluster is renamed. Providers without such an
+	// identifier leave it empty.
+	// +optional
+	ClusterUID string `json:"cluster-uid,omitempty"`
+	// ClusterID is the stable identifier of the cluster, unique across providers, set by
+	// the operator with StableClusterID
+	// +optional
+	ClusterID string `json:"cluster-id,omitempty"`
+}
+
+// StableClusterID returns the stable identifier of a cluster of a provider: a hash of the
+// provider name and the cluster UID, or of the cluster name for providers without UIDs.
+// It does not change when a cluster with a UID is renamed, and clusters of the same name
+// at different providers get different identifiers.
+func StableClusterID(providerName, clusterUID, clusterName string) string {
+	key := clusterUID
+	if key == "" {
+		key = clusterName
+	}
+	sum := sha256.Sum256([]byte(providerName + "/" + key))
+	return "cl-" + hex.EncodeToString(sum[:8])
 }
 
 // KrknTargetRequestSpec defines the desired state of KrknTargetRequest.
@@ -39,6 +73,62 @@ type KrknTargetRequestSpec struct {
 	// The operator will automatically add a label 'krkn.krkn-chaos.dev/uuid' with this value
 	// for easy selection: kubectl get krkntargetrequests -l krkn.krkn-chaos.dev/uuid=<uuid>
 	UUID string `json:"uuid"`
+	// Providers restricts the request to the listed provider operator names.
+	// When empty, every active provider contributes its targets.
+	// +optional
+	Providers []string `json:"providers,omitempty"`
+}
+
+// AssignClusterIDs sets the ClusterID of the targets of every provider from their
+// provider name and ClusterUID, replacing the identifiers providers may have set
+func (s *KrknTargetRequestStatus) AssignClusterIDs() {
+	for providerName, targets := range s.TargetData {
+		for i := range targets {
+			targets[i].ClusterID = StableClusterID(providerName, targets[i].ClusterUID, targets[i].ClusterName)
+		}
+	}
+}
+
+// ClusterByID returns the provider and target of the cluster with the stable identifier
+// id, and whether there is one
+func (s *KrknTargetRequestStatus) ClusterByID(id string) (string, ClusterTarget, bool) {
+	for providerName, targets := range s.TargetData {
+		for _, target := range targets {
+			if StableClusterID(providerName, target.ClusterUID, target.ClusterName) == id {
+				return providerName, target, true
+			}
+		}
+	}
+	return "", ClusterTarget{}, false
+}
+
+// IncludesProvider reports whether the provider with the given operator name
+// should contribute targets to this request
+func (s *KrknTargetRequestSpec) IncludesProvider(operatorName string) bool {
+	if len(s.Providers) == 0 {
+		return true
+	}
+	for _, name := range s.Providers {
+		if name == operatorName {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectTargets returns the clusters of every provider whose labels match selector, as
+// provider-name to sorted cluster names. Providers without a match are left out.
+func (s *KrknTargetRequestStatus) SelectTargets(selector labels.Selector) map[string][]string {
+	selected := map[string][]string{}
+	for providerName, targets := range s.TargetData {
+		for _, target := range targets {
+			if selector.Matches(labels.Set(target.Labels)) {
+				selected[providerName] = append(selected[providerName], target.ClusterName)
+			}
+		}
+		slices.Sort(selected[providerName])
+	}
+	return selected
 }
 
 // KrknTargetRequestStatus defines the observed state of KrknTargetRequest.
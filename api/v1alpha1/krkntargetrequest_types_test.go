@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestSelectTargets(t *testing.T) {
+	status := KrknTargetRequestStatus{TargetData: map[string][]ClusterTarget{
+		"krkn-operator": {
+			{ClusterName: "staging-eu-2", Labels: map[string]string{"env": "staging", "region": "eu"}},
+			{ClusterName: "staging-us", Labels: map[string]string{"env": "staging", "region": "us"}},
+			{ClusterName: "staging-eu-1", Labels: map[string]string{"env": "staging", "region": "eu"}},
+			{ClusterName: "unlabeled"},
+		},
+		"krkn-operator-acm": {
+			{ClusterName: "acm-eu", Labels: map[string]string{"env": "staging", "region": "eu"}},
+			{ClusterName: "acm-prod", Labels: map[string]string{"env": "prod", "region": "eu"}},
+		},
+	}}
+
+	tests := []struct {
+		selector string
+		want     map[string][]string
+	}{
+		{"env=staging,region=eu", map[string][]string{
+			"krkn-operator":     {"staging-eu-1", "staging-eu-2"},
+			"krkn-operator-acm": {"acm-eu"},
+		}},
+		{"env in (prod)", map[string][]string{"krkn-operator-acm": {"acm-prod"}}},
+		{"!env", map[string][]string{"krkn-operator": {"unlabeled"}}},
+		{"env=dev", map[string][]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("Failed to parse selector: %v", err)
+			}
+			if got := status.SelectTargets(selector); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStableClusterID(t *testing.T) {
+	id := StableClusterID("krkn-operator-capi", "0b6f3c2e", "payments")
+	if id != StableClusterID("krkn-operator-capi", "0b6f3c2e", "payments-v2") {
+		t.Error("Expected clusters with a UID to keep their ID when renamed")
+	}
+	if StableClusterID("krkn-operator", "", "prod") == StableClusterID("krkn-operator-acm", "", "prod") {
+		t.Error("Expected clusters of the same name at different providers to get different IDs")
+	}
+
+	status := KrknTargetRequestStatus{TargetData: map[string][]ClusterTarget{
+		"krkn-operator-capi": {{ClusterName: "payments-v2", ClusterUID: "0b6f3c2e"}},
+		"krkn-operator":      {{ClusterName: "prod", ClusterID: "set-by-provider"}},
+	}}
+	status.AssignClusterIDs()
+	if got := status.TargetData["krkn-operator-capi"][0].ClusterID; got != id {
+		t.Errorf("Expected the ID %s to be assigned, got %s", id, got)
+	}
+	if got := status.TargetData["krkn-operator"][0].ClusterID; got != StableClusterID("krkn-operator", "", "prod") {
+		t.Errorf("Expected the ID set by the provider to be replaced, got %s", got)
+	}
+
+	providerName, target, ok := status.ClusterByID(id)
+	if !ok || providerName != "krkn-operator-capi" || target.ClusterName != "payments-v2" {
+		t.Errorf("ClusterByID() = %s, %+v, %t, expected the renamed cluster", providerName, target, ok)
+	}
+	if _, _, ok := status.ClusterByID("cl-0000000000000000"); ok {
+		t.Error("Expected unknown IDs not to be found")
+	}
+}
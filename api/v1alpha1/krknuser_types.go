@@ -63,6 +63,13 @@ type KrknUserStatus struct {
 	// LastLogin is the timestamp of the user's last successful login
 	// +optional
 	LastLogin metav1.Time `json:"lastLogin,omitempty"`
+
+	// FavoriteScenarios are the names of the scenarios the user starred, in the order they
+	// were starred
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=100
+	FavoriteScenarios []string `json:"favoriteScenarios,omitempty"`
 }
 
 // +kubebuilder:object:root=true
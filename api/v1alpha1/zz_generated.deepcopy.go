@@ -21,108 +21,964 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertProfile) DeepCopyInto(out *AlertProfile) {
+	*out = *in
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]AlertRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertProfile.
+func (in *AlertProfile) DeepCopy() *AlertProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertRule) DeepCopyInto(out *AlertRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRule.
+func (in *AlertRule) DeepCopy() *AlertRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactStorage) DeepCopyInto(out *ArtifactStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactStorage.
+func (in *ArtifactStorage) DeepCopy() *ArtifactStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CampaignWindow) DeepCopyInto(out *CampaignWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CampaignWindow.
+func (in *CampaignWindow) DeepCopy() *CampaignWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(CampaignWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupResult) DeepCopyInto(out *CleanupResult) {
+	*out = *in
+	if in.Issues != nil {
+		in, out := &in.Issues, &out.Issues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.CheckedAt.DeepCopyInto(&out.CheckedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupResult.
+func (in *CleanupResult) DeepCopy() *CleanupResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupVerification) DeepCopyInto(out *CleanupVerification) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupVerification.
+func (in *CleanupVerification) DeepCopy() *CleanupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterJobStatus) DeepCopyInto(out *ClusterJobStatus) {
 	*out = *in
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRetryTime != nil {
+		in, out := &in.LastRetryTime, &out.LastRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EffectiveSpec != nil {
+		in, out := &in.EffectiveSpec, &out.EffectiveSpec
+		*out = new(EffectiveSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Result != nil {
+		in, out := &in.Result, &out.Result
+		*out = new(ScenarioResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]UploadedArtifact, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(CleanupResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterJobStatus.
+func (in *ClusterJobStatus) DeepCopy() *ClusterJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPermissionSet) DeepCopyInto(out *ClusterPermissionSet) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPermissionSet.
+func (in *ClusterPermissionSet) DeepCopy() *ClusterPermissionSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPermissionSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTarget) DeepCopyInto(out *ClusterTarget) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTarget.
+func (in *ClusterTarget) DeepCopy() *ClusterTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveFile) DeepCopyInto(out *EffectiveFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveFile.
+func (in *EffectiveFile) DeepCopy() *EffectiveFile {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectivePodSummary) DeepCopyInto(out *EffectivePodSummary) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectivePodSummary.
+func (in *EffectivePodSummary) DeepCopy() *EffectivePodSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectivePodSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveSpec) DeepCopyInto(out *EffectiveSpec) {
+	*out = *in
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]EffectiveFile, len(*in))
+		copy(*out, *in)
+	}
+	in.Pod.DeepCopyInto(&out.Pod)
+	in.CapturedAt.DeepCopyInto(&out.CapturedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveSpec.
+func (in *EffectiveSpec) DeepCopy() *EffectiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTelemetry) DeepCopyInto(out *ElasticsearchTelemetry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTelemetry.
+func (in *ElasticsearchTelemetry) DeepCopy() *ElasticsearchTelemetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTelemetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureHook) DeepCopyInto(out *FailureHook) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(FailureHookImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(FailureHookWebhook)
+		**out = **in
+	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(FailureHookReport)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureHook.
+func (in *FailureHook) DeepCopy() *FailureHook {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureHookImage) DeepCopyInto(out *FailureHookImage) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureHookImage.
+func (in *FailureHookImage) DeepCopy() *FailureHookImage {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureHookImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureHookReport) DeepCopyInto(out *FailureHookReport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureHookReport.
+func (in *FailureHookReport) DeepCopy() *FailureHookReport {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureHookReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureHookWebhook) DeepCopyInto(out *FailureHookWebhook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureHookWebhook.
+func (in *FailureHookWebhook) DeepCopy() *FailureHookWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureHookWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileMount) DeepCopyInto(out *FileMount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileMount.
+func (in *FileMount) DeepCopy() *FileMount {
+	if in == nil {
+		return nil
+	}
+	out := new(FileMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknCampaign) DeepCopyInto(out *KrknCampaign) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknCampaign.
+func (in *KrknCampaign) DeepCopy() *KrknCampaign {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknCampaign)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknCampaign) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknCampaignList) DeepCopyInto(out *KrknCampaignList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KrknCampaign, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknCampaignList.
+func (in *KrknCampaignList) DeepCopy() *KrknCampaignList {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknCampaignList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknCampaignList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknCampaignSpec) DeepCopyInto(out *KrknCampaignSpec) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(CampaignWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Participants != nil {
+		in, out := &in.Participants, &out.Participants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknCampaignSpec.
+func (in *KrknCampaignSpec) DeepCopy() *KrknCampaignSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknCampaignSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTarget) DeepCopyInto(out *KrknOperatorTarget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTarget.
+func (in *KrknOperatorTarget) DeepCopy() *KrknOperatorTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknOperatorTarget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetList) DeepCopyInto(out *KrknOperatorTargetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KrknOperatorTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetList.
+func (in *KrknOperatorTargetList) DeepCopy() *KrknOperatorTargetList {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknOperatorTargetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProvider) DeepCopyInto(out *KrknOperatorTargetProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProvider.
+func (in *KrknOperatorTargetProvider) DeepCopy() *KrknOperatorTargetProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknOperatorTargetProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderConfig) DeepCopyInto(out *KrknOperatorTargetProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfig.
+func (in *KrknOperatorTargetProviderConfig) DeepCopy() *KrknOperatorTargetProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknOperatorTargetProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderConfigList) DeepCopyInto(out *KrknOperatorTargetProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KrknOperatorTargetProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfigList.
+func (in *KrknOperatorTargetProviderConfigList) DeepCopy() *KrknOperatorTargetProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknOperatorTargetProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderConfigSpec) DeepCopyInto(out *KrknOperatorTargetProviderConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfigSpec.
+func (in *KrknOperatorTargetProviderConfigSpec) DeepCopy() *KrknOperatorTargetProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderConfigStatus) DeepCopyInto(out *KrknOperatorTargetProviderConfigStatus) {
+	*out = *in
+	if in.ConfigData != nil {
+		in, out := &in.ConfigData, &out.ConfigData
+		*out = make(map[string]ProviderConfigData, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	if in.Completed != nil {
+		in, out := &in.Completed, &out.Completed
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfigStatus.
+func (in *KrknOperatorTargetProviderConfigStatus) DeepCopy() *KrknOperatorTargetProviderConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderList) DeepCopyInto(out *KrknOperatorTargetProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KrknOperatorTargetProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderList.
+func (in *KrknOperatorTargetProviderList) DeepCopy() *KrknOperatorTargetProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknOperatorTargetProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderSpec) DeepCopyInto(out *KrknOperatorTargetProviderSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderSpec.
+func (in *KrknOperatorTargetProviderSpec) DeepCopy() *KrknOperatorTargetProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetProviderStatus) DeepCopyInto(out *KrknOperatorTargetProviderStatus) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.ObservedActive != nil {
+		in, out := &in.ObservedActive, &out.ObservedActive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ActivationChangedAt != nil {
+		in, out := &in.ActivationChangedAt, &out.ActivationChangedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderStatus.
+func (in *KrknOperatorTargetProviderStatus) DeepCopy() *KrknOperatorTargetProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetSpec) DeepCopyInto(out *KrknOperatorTargetSpec) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(TargetMetricsConfig)
+		**out = **in
+	}
+	if in.TokenRotation != nil {
+		in, out := &in.TokenRotation, &out.TokenRotation
+		*out = new(TargetTokenRotation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetSpec.
+func (in *KrknOperatorTargetSpec) DeepCopy() *KrknOperatorTargetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknOperatorTargetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknOperatorTargetStatus) DeepCopyInto(out *KrknOperatorTargetStatus) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	if in.ArchivedAt != nil {
+		in, out := &in.ArchivedAt, &out.ArchivedAt
 		*out = (*in).DeepCopy()
 	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
+	if in.PurgeAfter != nil {
+		in, out := &in.PurgeAfter, &out.PurgeAfter
 		*out = (*in).DeepCopy()
 	}
-	if in.LastRetryTime != nil {
-		in, out := &in.LastRetryTime, &out.LastRetryTime
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecentRunPhases != nil {
+		in, out := &in.RecentRunPhases, &out.RecentRunPhases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecentSuccessRate != nil {
+		in, out := &in.RecentSuccessRate, &out.RecentSuccessRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ProbeLatencyMilliseconds != nil {
+		in, out := &in.ProbeLatencyMilliseconds, &out.ProbeLatencyMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AuthPlugins != nil {
+		in, out := &in.AuthPlugins, &out.AuthPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenExpiresAt != nil {
+		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
 		*out = (*in).DeepCopy()
 	}
+	if in.LastTokenRotation != nil {
+		in, out := &in.LastTokenRotation, &out.LastTokenRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterJobStatus.
-func (in *ClusterJobStatus) DeepCopy() *ClusterJobStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetStatus.
+func (in *KrknOperatorTargetStatus) DeepCopy() *KrknOperatorTargetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterJobStatus)
+	out := new(KrknOperatorTargetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPermissionSet) DeepCopyInto(out *ClusterPermissionSet) {
+func (in *KrknRegistry) DeepCopyInto(out *KrknRegistry) {
 	*out = *in
-	if in.Actions != nil {
-		in, out := &in.Actions, &out.Actions
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPermissionSet.
-func (in *ClusterPermissionSet) DeepCopy() *ClusterPermissionSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknRegistry.
+func (in *KrknRegistry) DeepCopy() *KrknRegistry {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPermissionSet)
+	out := new(KrknRegistry)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknRegistry) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTarget) DeepCopyInto(out *ClusterTarget) {
+func (in *KrknRegistryList) DeepCopyInto(out *KrknRegistryList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KrknRegistry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTarget.
-func (in *ClusterTarget) DeepCopy() *ClusterTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknRegistryList.
+func (in *KrknRegistryList) DeepCopy() *KrknRegistryList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTarget)
+	out := new(KrknRegistryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknRegistryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileMount) DeepCopyInto(out *FileMount) {
+func (in *KrknRegistrySpec) DeepCopyInto(out *KrknRegistrySpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileMount.
-func (in *FileMount) DeepCopy() *FileMount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknRegistrySpec.
+func (in *KrknRegistrySpec) DeepCopy() *KrknRegistrySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FileMount)
+	out := new(KrknRegistrySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTarget) DeepCopyInto(out *KrknOperatorTarget) {
+func (in *KrknReport) DeepCopyInto(out *KrknReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTarget.
-func (in *KrknOperatorTarget) DeepCopy() *KrknOperatorTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknReport.
+func (in *KrknReport) DeepCopy() *KrknReport {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTarget)
+	out := new(KrknReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknOperatorTarget) DeepCopyObject() runtime.Object {
+func (in *KrknReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -130,31 +986,31 @@ func (in *KrknOperatorTarget) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetList) DeepCopyInto(out *KrknOperatorTargetList) {
+func (in *KrknReportList) DeepCopyInto(out *KrknReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KrknOperatorTarget, len(*in))
+		*out = make([]KrknReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetList.
-func (in *KrknOperatorTargetList) DeepCopy() *KrknOperatorTargetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknReportList.
+func (in *KrknReportList) DeepCopy() *KrknReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetList)
+	out := new(KrknReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknOperatorTargetList) DeepCopyObject() runtime.Object {
+func (in *KrknReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -162,53 +1018,41 @@ func (in *KrknOperatorTargetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProvider) DeepCopyInto(out *KrknOperatorTargetProvider) {
+func (in *KrknReportSpec) DeepCopyInto(out *KrknReportSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProvider.
-func (in *KrknOperatorTargetProvider) DeepCopy() *KrknOperatorTargetProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknReportSpec.
+func (in *KrknReportSpec) DeepCopy() *KrknReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetProvider)
+	out := new(KrknReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknOperatorTargetProvider) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderConfig) DeepCopyInto(out *KrknOperatorTargetProviderConfig) {
+func (in *KrknScenarioRun) DeepCopyInto(out *KrknScenarioRun) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfig.
-func (in *KrknOperatorTargetProviderConfig) DeepCopy() *KrknOperatorTargetProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRun.
+func (in *KrknScenarioRun) DeepCopy() *KrknScenarioRun {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetProviderConfig)
+	out := new(KrknScenarioRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknOperatorTargetProviderConfig) DeepCopyObject() runtime.Object {
+func (in *KrknScenarioRun) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -216,31 +1060,31 @@ func (in *KrknOperatorTargetProviderConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderConfigList) DeepCopyInto(out *KrknOperatorTargetProviderConfigList) {
+func (in *KrknScenarioRunList) DeepCopyInto(out *KrknScenarioRunList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KrknOperatorTargetProviderConfig, len(*in))
+		*out = make([]KrknScenarioRun, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfigList.
-func (in *KrknOperatorTargetProviderConfigList) DeepCopy() *KrknOperatorTargetProviderConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRunList.
+func (in *KrknScenarioRunList) DeepCopy() *KrknScenarioRunList {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetProviderConfigList)
+	out := new(KrknScenarioRunList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknOperatorTargetProviderConfigList) DeepCopyObject() runtime.Object {
+func (in *KrknScenarioRunList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -248,76 +1092,209 @@ func (in *KrknOperatorTargetProviderConfigList) DeepCopyObject() runtime.Object
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderConfigSpec) DeepCopyInto(out *KrknOperatorTargetProviderConfigSpec) {
+func (in *KrknScenarioRunSpec) DeepCopyInto(out *KrknScenarioRunSpec) {
 	*out = *in
+	if in.TargetClusters != nil {
+		in, out := &in.TargetClusters, &out.TargetClusters
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TargetClusterIDs != nil {
+		in, out := &in.TargetClusterIDs, &out.TargetClusterIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Alternates != nil {
+		in, out := &in.Alternates, &out.Alternates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]FileMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretEnvironment != nil {
+		in, out := &in.SecretEnvironment, &out.SecretEnvironment
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OnFailure != nil {
+		in, out := &in.OnFailure, &out.OnFailure
+		*out = make([]FailureHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = new(ArtifactStorage)
+		**out = **in
+	}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(ElasticsearchTelemetry)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertProfile != nil {
+		in, out := &in.AlertProfile, &out.AlertProfile
+		*out = new(AlertProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanupVerification != nil {
+		in, out := &in.CleanupVerification, &out.CleanupVerification
+		*out = new(CleanupVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfigSpec.
-func (in *KrknOperatorTargetProviderConfigSpec) DeepCopy() *KrknOperatorTargetProviderConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRunSpec.
+func (in *KrknScenarioRunSpec) DeepCopy() *KrknScenarioRunSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetProviderConfigSpec)
+	out := new(KrknScenarioRunSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderConfigStatus) DeepCopyInto(out *KrknOperatorTargetProviderConfigStatus) {
+func (in *KrknScenarioRunStatus) DeepCopyInto(out *KrknScenarioRunStatus) {
 	*out = *in
-	if in.ConfigData != nil {
-		in, out := &in.ConfigData, &out.ConfigData
-		*out = make(map[string]ProviderConfigData, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterJobs != nil {
+		in, out := &in.ClusterJobs, &out.ClusterJobs
+		*out = make([]ClusterJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Created != nil {
-		in, out := &in.Created, &out.Created
-		*out = (*in).DeepCopy()
+	if in.RetryWaves != nil {
+		in, out := &in.RetryWaves, &out.RetryWaves
+		*out = make([]RetryWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Completed != nil {
-		in, out := &in.Completed, &out.Completed
-		*out = (*in).DeepCopy()
+	if in.ResolvedTargets != nil {
+		in, out := &in.ResolvedTargets, &out.ResolvedTargets
+		*out = new(TargetSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderConfigStatus.
-func (in *KrknOperatorTargetProviderConfigStatus) DeepCopy() *KrknOperatorTargetProviderConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRunStatus.
+func (in *KrknScenarioRunStatus) DeepCopy() *KrknScenarioRunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetProviderConfigStatus)
+	out := new(KrknScenarioRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KrknScenarioTemplate) DeepCopyInto(out *KrknScenarioTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioTemplate.
+func (in *KrknScenarioTemplate) DeepCopy() *KrknScenarioTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(KrknScenarioTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KrknScenarioTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderList) DeepCopyInto(out *KrknOperatorTargetProviderList) {
+func (in *KrknScenarioTemplateList) DeepCopyInto(out *KrknScenarioTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KrknOperatorTargetProvider, len(*in))
+		*out = make([]KrknScenarioTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderList.
-func (in *KrknOperatorTargetProviderList) DeepCopy() *KrknOperatorTargetProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioTemplateList.
+func (in *KrknScenarioTemplateList) DeepCopy() *KrknScenarioTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetProviderList)
+	out := new(KrknScenarioTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknOperatorTargetProviderList) DeepCopyObject() runtime.Object {
+func (in *KrknScenarioTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -325,88 +1302,67 @@ func (in *KrknOperatorTargetProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderSpec) DeepCopyInto(out *KrknOperatorTargetProviderSpec) {
+func (in *KrknScenarioTemplateSpec) DeepCopyInto(out *KrknScenarioTemplateSpec) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderSpec.
-func (in *KrknOperatorTargetProviderSpec) DeepCopy() *KrknOperatorTargetProviderSpec {
-	if in == nil {
-		return nil
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(KrknOperatorTargetProviderSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetProviderStatus) DeepCopyInto(out *KrknOperatorTargetProviderStatus) {
-	*out = *in
-	in.Timestamp.DeepCopyInto(&out.Timestamp)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetProviderStatus.
-func (in *KrknOperatorTargetProviderStatus) DeepCopy() *KrknOperatorTargetProviderStatus {
-	if in == nil {
-		return nil
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]FileMount, len(*in))
+		copy(*out, *in)
 	}
-	out := new(KrknOperatorTargetProviderStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetSpec) DeepCopyInto(out *KrknOperatorTargetSpec) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetSpec.
-func (in *KrknOperatorTargetSpec) DeepCopy() *KrknOperatorTargetSpec {
-	if in == nil {
-		return nil
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretEnvironment != nil {
+		in, out := &in.SecretEnvironment, &out.SecretEnvironment
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
 	}
-	out := new(KrknOperatorTargetSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknOperatorTargetStatus) DeepCopyInto(out *KrknOperatorTargetStatus) {
-	*out = *in
-	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknOperatorTargetStatus.
-func (in *KrknOperatorTargetStatus) DeepCopy() *KrknOperatorTargetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioTemplateSpec.
+func (in *KrknScenarioTemplateSpec) DeepCopy() *KrknScenarioTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknOperatorTargetStatus)
+	out := new(KrknScenarioTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknScenarioRun) DeepCopyInto(out *KrknScenarioRun) {
+func (in *KrknTargetGroup) DeepCopyInto(out *KrknTargetGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRun.
-func (in *KrknScenarioRun) DeepCopy() *KrknScenarioRun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknTargetGroup.
+func (in *KrknTargetGroup) DeepCopy() *KrknTargetGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknScenarioRun)
+	out := new(KrknTargetGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknScenarioRun) DeepCopyObject() runtime.Object {
+func (in *KrknTargetGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -414,31 +1370,31 @@ func (in *KrknScenarioRun) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknScenarioRunList) DeepCopyInto(out *KrknScenarioRunList) {
+func (in *KrknTargetGroupList) DeepCopyInto(out *KrknTargetGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KrknScenarioRun, len(*in))
+		*out = make([]KrknTargetGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRunList.
-func (in *KrknScenarioRunList) DeepCopy() *KrknScenarioRunList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknTargetGroupList.
+func (in *KrknTargetGroupList) DeepCopy() *KrknTargetGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknScenarioRunList)
+	out := new(KrknTargetGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KrknScenarioRunList) DeepCopyObject() runtime.Object {
+func (in *KrknTargetGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -446,10 +1402,10 @@ func (in *KrknScenarioRunList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknScenarioRunSpec) DeepCopyInto(out *KrknScenarioRunSpec) {
+func (in *KrknTargetGroupSpec) DeepCopyInto(out *KrknTargetGroupSpec) {
 	*out = *in
-	if in.TargetClusters != nil {
-		in, out := &in.TargetClusters, &out.TargetClusters
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
 		*out = make(map[string][]string, len(*in))
 		for key, val := range *in {
 			var outVal []string
@@ -464,55 +1420,14 @@ func (in *KrknScenarioRunSpec) DeepCopyInto(out *KrknScenarioRunSpec) {
 			(*out)[key] = outVal
 		}
 	}
-	if in.Files != nil {
-		in, out := &in.Files, &out.Files
-		*out = make([]FileMount, len(*in))
-		copy(*out, *in)
-	}
-	if in.Environment != nil {
-		in, out := &in.Environment, &out.Environment
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRunSpec.
-func (in *KrknScenarioRunSpec) DeepCopy() *KrknScenarioRunSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(KrknScenarioRunSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KrknScenarioRunStatus) DeepCopyInto(out *KrknScenarioRunStatus) {
-	*out = *in
-	if in.ClusterJobs != nil {
-		in, out := &in.ClusterJobs, &out.ClusterJobs
-		*out = make([]ClusterJobStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknScenarioRunStatus.
-func (in *KrknScenarioRunStatus) DeepCopy() *KrknScenarioRunStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknTargetGroupSpec.
+func (in *KrknTargetGroupSpec) DeepCopy() *KrknTargetGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KrknScenarioRunStatus)
+	out := new(KrknTargetGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -522,7 +1437,7 @@ func (in *KrknTargetRequest) DeepCopyInto(out *KrknTargetRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -579,6 +1494,11 @@ func (in *KrknTargetRequestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KrknTargetRequestSpec) DeepCopyInto(out *KrknTargetRequestSpec) {
 	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknTargetRequestSpec.
@@ -605,7 +1525,9 @@ func (in *KrknTargetRequestStatus) DeepCopyInto(out *KrknTargetRequestStatus) {
 				inVal := (*in)[key]
 				in, out := &inVal, &outVal
 				*out = make([]ClusterTarget, len(*in))
-				copy(*out, *in)
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
 			}
 			(*out)[key] = outVal
 		}
@@ -785,6 +1707,11 @@ func (in *KrknUserStatus) DeepCopyInto(out *KrknUserStatus) {
 	*out = *in
 	in.Created.DeepCopyInto(&out.Created)
 	in.LastLogin.DeepCopyInto(&out.LastLogin)
+	if in.FavoriteScenarios != nil {
+		in, out := &in.FavoriteScenarios, &out.FavoriteScenarios
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KrknUserStatus.
@@ -811,3 +1738,166 @@ func (in *ProviderConfigData) DeepCopy() *ProviderConfigData {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedTarget) DeepCopyInto(out *ResolvedTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedTarget.
+func (in *ResolvedTarget) DeepCopy() *ResolvedTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryWave) DeepCopyInto(out *RetryWave) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	in.ScheduledAt.DeepCopyInto(&out.ScheduledAt)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryWave.
+func (in *RetryWave) DeepCopy() *RetryWave {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioOutcome) DeepCopyInto(out *ScenarioOutcome) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioOutcome.
+func (in *ScenarioOutcome) DeepCopy() *ScenarioOutcome {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioOutcome)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioResult) DeepCopyInto(out *ScenarioResult) {
+	*out = *in
+	if in.Scenarios != nil {
+		in, out := &in.Scenarios, &out.Scenarios
+		*out = make([]ScenarioOutcome, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedChecks != nil {
+		in, out := &in.FailedChecks, &out.FailedChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioResult.
+func (in *ScenarioResult) DeepCopy() *ScenarioResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetMetricsConfig) DeepCopyInto(out *TargetMetricsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetMetricsConfig.
+func (in *TargetMetricsConfig) DeepCopy() *TargetMetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetMetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetSnapshot) DeepCopyInto(out *TargetSnapshot) {
+	*out = *in
+	in.ResolvedAt.DeepCopyInto(&out.ResolvedAt)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]ResolvedTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetSnapshot.
+func (in *TargetSnapshot) DeepCopy() *TargetSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetTokenRotation) DeepCopyInto(out *TargetTokenRotation) {
+	*out = *in
+	out.ServiceAccount = in.ServiceAccount
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetTokenRotation.
+func (in *TargetTokenRotation) DeepCopy() *TargetTokenRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetTokenRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UploadedArtifact) DeepCopyInto(out *UploadedArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UploadedArtifact.
+func (in *UploadedArtifact) DeepCopy() *UploadedArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(UploadedArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// TestKrknScenarioRunConversion tests that runs survive a round trip through v1beta1
+func TestKrknScenarioRunConversion(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name string
+		hub  *krknv1alpha1.KrknScenarioRun
+	}{
+		{
+			name: "run with retry policy and resources",
+			hub: &krknv1alpha1.KrknScenarioRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"},
+				Spec: krknv1alpha1.KrknScenarioRunSpec{
+					TargetRequestID:   "request-1",
+					RunNumber:         7,
+					DisplayName:       "Weekly pod chaos",
+					TargetClusters:    map[string][]string{"krkn-operator": {"cluster1"}},
+					TargetSelector:    "env=staging",
+					TargetGroupRef:    "staging-clusters",
+					CampaignRef:       "q3-game-day",
+					TemplateRef:       "pod-chaos",
+					ScenarioName:      "pod-scenarios",
+					ScenarioImage:     "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+					Environment:       map[string]string{"API_TOKEN": "s3cr3t"},
+					SecretEnvironment: []string{"API_TOKEN"},
+					RegistryRef:       "internal-registry",
+					MaxRetries:        3,
+					RetryBackoff:      "exponential",
+					RetryDelay:        "10s",
+					MaxConcurrency:    10,
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+					Files: []krknv1alpha1.FileMount{{Name: "config.yaml", Content: "a: b", MountPath: "/tmp/config.yaml"}},
+					OnFailure: []krknv1alpha1.FailureHook{
+						{Name: "notify", Webhook: &krknv1alpha1.FailureHookWebhook{URL: "https://hooks.example.com"}},
+						{Name: "report", Report: &krknv1alpha1.FailureHookReport{Severity: krknv1alpha1.ReportSeverityCritical}},
+					},
+					Artifacts: &krknv1alpha1.ArtifactStorage{
+						Endpoint:          "https://s3.example.com",
+						Bucket:            "chaos-artifacts",
+						Prefix:            "runs",
+						CredentialsSecret: "s3-credentials",
+					},
+					Elasticsearch: &krknv1alpha1.ElasticsearchTelemetry{
+						URL:               "https://es.example.com:9200",
+						Index:             "krkn-telemetry",
+						CredentialsSecret: "es-credentials",
+						VerifyCerts:       true,
+					},
+					HealthCheck: &krknv1alpha1.HealthCheck{
+						URL:             "https://app.example.com/health",
+						IntervalSeconds: ptr.To[int32](5),
+						BearerTokenSecret: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "app-token"},
+							Key:                  "token",
+						},
+						ExitOnFailure: true,
+					},
+					AlertProfile: &krknv1alpha1.AlertProfile{
+						Alerts: []krknv1alpha1.AlertRule{{
+							Expr:        "up == 0",
+							Description: "{{$labels.instance}} is down",
+							Severity:    "critical",
+						}},
+						FailOnCritical: true,
+					},
+					CleanupVerification: &krknv1alpha1.CleanupVerification{
+						Enabled:    ptr.To(true),
+						Namespaces: []string{"krkn-test"},
+					},
+				},
+				Status: krknv1alpha1.KrknScenarioRunStatus{
+					Phase:        "Running",
+					TotalTargets: 1,
+					ClusterJobs: []krknv1alpha1.ClusterJobStatus{{
+						ProviderName: "krkn-operator",
+						ClusterName:  "cluster1",
+						JobID:        "job-1",
+						Phase:        "Running",
+						StartTime:    &now,
+						EffectiveSpec: &krknv1alpha1.EffectiveSpec{
+							Image: "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+							Files: []krknv1alpha1.EffectiveFile{{Name: "config.yaml", MountPath: "/tmp/config.yaml"}},
+						},
+						Result: &krknv1alpha1.ScenarioResult{
+							ExitCode:           1,
+							Summary:            "1 of 1 scenarios failed",
+							Scenarios:          []krknv1alpha1.ScenarioOutcome{{Scenario: "pod.yaml", Type: "pod_disruption_scenarios", ExitStatus: 1}},
+							FailedChecks:       []string{"health check api failed"},
+							TelemetryCollected: true,
+						},
+						Artifacts: []krknv1alpha1.UploadedArtifact{{
+							Name: "scenario.log",
+							URL:  "https://s3.example.com/chaos-artifacts/runs/run-1/cluster1/job-1/scenario.log",
+						}},
+						Cleanup: &krknv1alpha1.CleanupResult{
+							Issues:    []string{"node worker-1 is still cordoned"},
+							CheckedAt: now,
+						},
+					}, {
+						ProviderName: "krkn-operator",
+						ClusterName:  "cluster2",
+						Phase:        "Pending",
+						Message:      "Queued, waiting for a free job slot",
+						Queued:       true,
+					}},
+					ResolvedTargets: &krknv1alpha1.TargetSnapshot{
+						Selector:    "env=staging",
+						TargetGroup: "staging-clusters",
+						ResolvedAt:  now,
+						Targets: []krknv1alpha1.ResolvedTarget{{
+							Provider:      "krkn-operator",
+							ClusterName:   "cluster1",
+							ClusterAPIURL: "https://cluster1:6443",
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "run without retry fields",
+			hub: &krknv1alpha1.KrknScenarioRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "run-2", Namespace: "default"},
+				Spec: krknv1alpha1.KrknScenarioRunSpec{
+					TargetRequestID: "request-2",
+					ScenarioName:    "node-scenarios",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spoke := &KrknScenarioRun{}
+			if err := spoke.ConvertFrom(tt.hub); err != nil {
+				t.Fatalf("ConvertFrom() error = %v", err)
+			}
+			if (tt.hub.Spec.MaxRetries != 0) != (spoke.Spec.RetryPolicy != nil) {
+				t.Errorf("Unexpected retry policy %+v", spoke.Spec.RetryPolicy)
+			}
+
+			got := &krknv1alpha1.KrknScenarioRun{}
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("ConvertTo() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.hub) {
+				t.Errorf("Round trip mismatch:\ngot  %+v\nwant %+v", got, tt.hub)
+			}
+		})
+	}
+}
+
+// TestKrknOperatorTargetConversion tests that targets survive a round trip through v1beta1
+func TestKrknOperatorTargetConversion(t *testing.T) {
+	tests := []struct {
+		name    string
+		hub     *krknv1alpha1.KrknOperatorTarget
+		wantTLS bool
+	}{
+		{
+			name: "target with TLS settings",
+			hub: &krknv1alpha1.KrknOperatorTarget{
+				ObjectMeta: metav1.ObjectMeta{Name: "target-1", Namespace: "default"},
+				Spec: krknv1alpha1.KrknOperatorTargetSpec{
+					UUID:                  "uuid-1",
+					ClusterName:           "cluster1",
+					ClusterAPIURL:         "https://api.cluster1.example.com:6443",
+					SecretType:            "token",
+					SecretUUID:            "uuid-1",
+					CABundle:              "Y2EtYnVuZGxl",
+					InsecureSkipTLSVerify: false,
+					Metrics:               &krknv1alpha1.TargetMetricsConfig{PrometheusURL: "https://prometheus.example.com"},
+					TokenRotation: &krknv1alpha1.TargetTokenRotation{
+						ServiceAccount:    krknv1alpha1.ServiceAccountReference{Namespace: "krkn", Name: "krkn-runner"},
+						ExpirationSeconds: 3600,
+						Automatic:         true,
+					},
+				},
+				Status: krknv1alpha1.KrknOperatorTargetStatus{Ready: true, LastRunName: "run-1", ProbeLatencyMilliseconds: ptr.To[int64](42)},
+			},
+			wantTLS: true,
+		},
+		{
+			name: "target with kubeconfig",
+			hub: &krknv1alpha1.KrknOperatorTarget{
+				ObjectMeta: metav1.ObjectMeta{Name: "target-2", Namespace: "default"},
+				Spec: krknv1alpha1.KrknOperatorTargetSpec{
+					UUID:        "uuid-2",
+					ClusterName: "cluster2",
+					SecretType:  "kubeconfig",
+					SecretUUID:  "uuid-2",
+				},
+			},
+			wantTLS: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spoke := &KrknOperatorTarget{}
+			if err := spoke.ConvertFrom(tt.hub); err != nil {
+				t.Fatalf("ConvertFrom() error = %v", err)
+			}
+			if spoke.Spec.Credentials.Type != tt.hub.Spec.SecretType ||
+				spoke.Spec.Credentials.SecretName != tt.hub.Spec.SecretUUID {
+				t.Errorf("Unexpected credentials %+v", spoke.Spec.Credentials)
+			}
+			if (spoke.Spec.TLS != nil) != tt.wantTLS {
+				t.Errorf("Expected TLS settings %v, got %+v", tt.wantTLS, spoke.Spec.TLS)
+			}
+
+			got := &krknv1alpha1.KrknOperatorTarget{}
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("ConvertTo() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.hub) {
+				t.Errorf("Round trip mismatch:\ngot  %+v\nwant %+v", got, tt.hub)
+			}
+		})
+	}
+}
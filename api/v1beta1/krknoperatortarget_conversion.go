@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this KrknOperatorTarget to the hub version (v1alpha1)
+func (src *KrknOperatorTarget) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*krknv1alpha1.KrknOperatorTarget)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = krknv1alpha1.KrknOperatorTargetSpec{
+		UUID:          src.Spec.UUID,
+		ClusterName:   src.Spec.ClusterName,
+		ClusterAPIURL: src.Spec.ClusterAPIURL,
+		SecretType:    src.Spec.Credentials.Type,
+		SecretUUID:    src.Spec.Credentials.SecretName,
+		VaultPath:     src.Spec.Credentials.VaultPath,
+	}
+	if src.Spec.TLS != nil {
+		dst.Spec.CABundle = src.Spec.TLS.CABundle
+		dst.Spec.InsecureSkipTLSVerify = src.Spec.TLS.InsecureSkipVerify
+	}
+	if src.Spec.Metrics != nil {
+		metrics := krknv1alpha1.TargetMetricsConfig(*src.Spec.Metrics)
+		dst.Spec.Metrics = &metrics
+	}
+	if rotation := src.Spec.Credentials.TokenRotation; rotation != nil {
+		dst.Spec.TokenRotation = &krknv1alpha1.TargetTokenRotation{
+			ServiceAccount:    krknv1alpha1.ServiceAccountReference(rotation.ServiceAccount),
+			ExpirationSeconds: rotation.ExpirationSeconds,
+			Automatic:         rotation.Automatic,
+		}
+	}
+
+	dst.Status = krknv1alpha1.KrknOperatorTargetStatus(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *KrknOperatorTarget) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*krknv1alpha1.KrknOperatorTarget)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = KrknOperatorTargetSpec{
+		UUID:          src.Spec.UUID,
+		ClusterName:   src.Spec.ClusterName,
+		ClusterAPIURL: src.Spec.ClusterAPIURL,
+		Credentials: TargetCredentials{
+			Type:       src.Spec.SecretType,
+			SecretName: src.Spec.SecretUUID,
+			VaultPath:  src.Spec.VaultPath,
+		},
+	}
+	if src.Spec.CABundle != "" || src.Spec.InsecureSkipTLSVerify {
+		dst.Spec.TLS = &TargetTLSConfig{
+			CABundle:           src.Spec.CABundle,
+			InsecureSkipVerify: src.Spec.InsecureSkipTLSVerify,
+		}
+	}
+	if src.Spec.Metrics != nil {
+		metrics := TargetMetricsConfig(*src.Spec.Metrics)
+		dst.Spec.Metrics = &metrics
+	}
+	if rotation := src.Spec.TokenRotation; rotation != nil {
+		dst.Spec.Credentials.TokenRotation = &TargetTokenRotation{
+			ServiceAccount:    ServiceAccountReference(rotation.ServiceAccount),
+			ExpirationSeconds: rotation.ExpirationSeconds,
+			Automatic:         rotation.Automatic,
+		}
+	}
+
+	dst.Status = KrknOperatorTargetStatus(src.Status)
+	return nil
+}
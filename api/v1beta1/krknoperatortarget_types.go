@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KrknOperatorTargetSpec defines the desired state of KrknOperatorTarget.
+type KrknOperatorTargetSpec struct {
+	// UUID is the unique identifier for this target
+	UUID string `json:"uuid"`
+
+	// ClusterName is the name of the target cluster
+	ClusterName string `json:"clusterName"`
+
+	// ClusterAPIURL is the Kubernetes API server URL
+	// +optional
+	ClusterAPIURL string `json:"clusterAPIURL,omitempty"`
+
+	// Credentials reference the Secret holding the kubeconfig of the target
+	Credentials TargetCredentials `json:"credentials"`
+
+	// TLS configures the verification of the API server certificate
+	// +optional
+	TLS *TargetTLSConfig `json:"tls,omitempty"`
+
+	// Metrics configures the read-through proxy to the target cluster's Prometheus
+	// +optional
+	Metrics *TargetMetricsConfig `json:"metrics,omitempty"`
+}
+
+// TargetCredentials reference the Secret holding the kubeconfig of a target
+type TargetCredentials struct {
+	// Type specifies the authentication method
+	// +kubebuilder:validation:Enum=kubeconfig;token;credentials
+	// +kubebuilder:default="kubeconfig"
+	Type string `json:"type"`
+
+	// SecretName is the name of the Secret containing the kubeconfig, a UUID. Either
+	// SecretName or VaultPath is set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// VaultPath is the path of the credentials in the Vault KV v2 store of the operator,
+	// when they are kept there instead of in a Secret
+	// +optional
+	VaultPath string `json:"vaultPath,omitempty"`
+
+	// TokenRotation configures the re-minting of the token of a token target from a
+	// ServiceAccount of the target cluster
+	// +optional
+	TokenRotation *TargetTokenRotation `json:"tokenRotation,omitempty"`
+}
+
+// TargetTokenRotation describes the ServiceAccount the token of a token target is minted
+// for. The stored kubeconfig must be allowed to create tokens for it.
+type TargetTokenRotation struct {
+	// ServiceAccount is the ServiceAccount of the target cluster the token is minted for
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+
+	// ExpirationSeconds is the requested lifetime of the minted tokens
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:default=86400
+	// +optional
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+
+	// Automatic re-mints the token before it expires. Otherwise it is only rotated on request.
+	// +optional
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// ServiceAccountReference identifies a ServiceAccount of a target cluster
+type ServiceAccountReference struct {
+	// Namespace of the ServiceAccount
+	Namespace string `json:"namespace"`
+
+	// Name of the ServiceAccount
+	Name string `json:"name"`
+}
+
+// TargetTLSConfig configures the verification of the API server certificate of a target
+type TargetTLSConfig struct {
+	// CABundle is the base64-encoded CA certificate bundle for TLS verification
+	// Optional - if not provided and the type is not kubeconfig, TLS verification will be skipped
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// InsecureSkipVerify skips TLS certificate verification
+	// Only used when CABundle is not provided
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// TargetMetricsConfig describes how to query the Prometheus of a target cluster.
+// The optional bearer token is stored in the target Secret under the prometheus-token key.
+type TargetMetricsConfig struct {
+	// PrometheusURL is the base URL of the Prometheus (or Thanos Querier) HTTP API
+	// +kubebuilder:validation:Pattern=`^https?://`
+	PrometheusURL string `json:"prometheusURL"`
+
+	// InsecureSkipTLSVerify skips TLS certificate verification of the Prometheus endpoint
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// KrknOperatorTargetStatus defines the observed state of KrknOperatorTarget.
+type KrknOperatorTargetStatus struct {
+	// Ready indicates whether the target is ready to be used
+	// +kubebuilder:default=true
+	Ready bool `json:"ready,omitempty"`
+
+	// LastUpdated is the timestamp of the last update
+	// +optional
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Archived indicates the target has been soft-deleted. Archived targets are
+	// hidden from target pickers and cannot be used for new scenario runs.
+	// +optional
+	Archived bool `json:"archived,omitempty"`
+
+	// ArchivedAt is when the target was archived
+	// +optional
+	ArchivedAt *metav1.Time `json:"archivedAt,omitempty"`
+
+	// PurgeAfter is when the archived target and its Secret become eligible for
+	// permanent deletion. The target can be restored until this time.
+	// +optional
+	PurgeAfter *metav1.Time `json:"purgeAfter,omitempty"`
+
+	// LastRunTime is when the last scenario job on this target finished
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// LastRunName is the KrknScenarioRun of the last scenario job on this target
+	// +optional
+	LastRunName string `json:"lastRunName,omitempty"`
+
+	// LastRunPhase is the outcome of the last scenario job on this target
+	// +kubebuilder:validation:Enum=Succeeded;Failed;Cancelled
+	// +optional
+	LastRunPhase string `json:"lastRunPhase,omitempty"`
+
+	// RecentRunPhases are the outcomes of the last scenario jobs on this target, oldest first
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	RecentRunPhases []string `json:"recentRunPhases,omitempty"`
+
+	// RecentSuccessRate is the percentage of succeeded jobs among the recent ones that
+	// succeeded or failed, unset when there are none
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	RecentSuccessRate *int32 `json:"recentSuccessRate,omitempty"`
+
+	// ConsecutiveFailures is the number of jobs that failed on this target since its last success
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastProbeTime is when the connectivity of the target cluster was last checked
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// ProbeLatencyMilliseconds is how long the last successful connectivity check took,
+	// unset while the cluster is unreachable
+	// +optional
+	ProbeLatencyMilliseconds *int64 `json:"probeLatencyMilliseconds,omitempty"`
+
+	// ProbeError is why the last connectivity check failed, empty when it succeeded
+	// +optional
+	ProbeError string `json:"probeError,omitempty"`
+
+	// AuthPlugins are the exec credential plugins and auth providers the kubeconfig of the
+	// target authenticates with, which scenario images must bundle
+	// +optional
+	AuthPlugins []string `json:"authPlugins,omitempty"`
+
+	// TokenExpiresAt is when the last token minted for the target expires
+	// +optional
+	TokenExpiresAt *metav1.Time `json:"tokenExpiresAt,omitempty"`
+
+	// LastTokenRotation is when the token of the target was last re-minted
+	// +optional
+	LastTokenRotation *metav1.Time `json:"lastTokenRotation,omitempty"`
+
+	// Conditions represent the latest available observations of the target:
+	// AuthPluginRequired
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+// +kubebuilder:printcolumn:name="API URL",type=string,JSONPath=`.spec.clusterAPIURL`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.credentials.type`
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Archived",type=boolean,JSONPath=`.status.archived`,priority=1
+// +kubebuilder:printcolumn:name="Last Run",type=string,JSONPath=`.status.lastRunPhase`
+// +kubebuilder:printcolumn:name="Success Rate",type=integer,JSONPath=`.status.recentSuccessRate`,priority=1
+// +kubebuilder:printcolumn:name="Latency (ms)",type=integer,JSONPath=`.status.probeLatencyMilliseconds`,priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=kot
+// +kubebuilder:unservedversion
+
+// KrknOperatorTarget is the Schema for the krknoperatortargets API.
+type KrknOperatorTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KrknOperatorTargetSpec   `json:"spec,omitempty"`
+	Status KrknOperatorTargetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KrknOperatorTargetList contains a list of KrknOperatorTarget.
+type KrknOperatorTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknOperatorTarget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknOperatorTarget{}, &KrknOperatorTargetList{})
+}
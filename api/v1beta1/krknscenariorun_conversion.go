@@ -0,0 +1,371 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this KrknScenarioRun to the hub version (v1alpha1)
+func (src *KrknScenarioRun) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*krknv1alpha1.KrknScenarioRun)
+	dst.ObjectMeta = src.ObjectMeta
+
+	spec := &src.Spec
+	dst.Spec = krknv1alpha1.KrknScenarioRunSpec{
+		TargetRequestID:         spec.TargetRequestID,
+		OwnerUserID:             spec.OwnerUserID,
+		RunNumber:               spec.RunNumber,
+		DisplayName:             spec.DisplayName,
+		TargetClusters:          spec.TargetClusters,
+		TargetClusterIDs:        spec.TargetClusterIDs,
+		TargetSelector:          spec.TargetSelector,
+		TargetGroupRef:          spec.TargetGroupRef,
+		CampaignRef:             spec.CampaignRef,
+		Alternates:              spec.Alternates,
+		TemplateRef:             spec.TemplateRef,
+		ScenarioName:            spec.ScenarioName,
+		ScenarioImage:           spec.ScenarioImage,
+		ImagePullPolicy:         spec.ImagePullPolicy,
+		Resources:               spec.Resources,
+		KubeconfigPath:          spec.KubeconfigPath,
+		Environment:             spec.Environment,
+		SecretEnvironment:       spec.SecretEnvironment,
+		SecretEnvironmentRef:    spec.SecretEnvironmentRef,
+		RegistryURL:             spec.RegistryURL,
+		ScenarioRepository:      spec.ScenarioRepository,
+		Token:                   spec.Token,
+		Username:                spec.Username,
+		Password:                spec.Password,
+		RegistryRef:             spec.RegistryRef,
+		TimeoutSeconds:          spec.TimeoutSeconds,
+		TTLSecondsAfterFinished: spec.TTLSecondsAfterFinished,
+		MaintenancePolicy:       spec.MaintenancePolicy,
+		MaxConcurrency:          spec.MaxConcurrency,
+		EvictionProtection:      spec.EvictionProtection,
+		CancelRequested:         spec.CancelRequested,
+	}
+	if spec.RetryPolicy != nil {
+		dst.Spec.MaxRetries = spec.RetryPolicy.MaxRetries
+		dst.Spec.RetryBackoff = spec.RetryPolicy.Backoff
+		dst.Spec.RetryDelay = spec.RetryPolicy.Delay
+	}
+	for _, file := range spec.Files {
+		dst.Spec.Files = append(dst.Spec.Files, krknv1alpha1.FileMount(file))
+	}
+	for _, hook := range spec.OnFailure {
+		converted := krknv1alpha1.FailureHook{Name: hook.Name}
+		if hook.Image != nil {
+			image := krknv1alpha1.FailureHookImage(*hook.Image)
+			converted.Image = &image
+		}
+		if hook.Webhook != nil {
+			webhook := krknv1alpha1.FailureHookWebhook(*hook.Webhook)
+			converted.Webhook = &webhook
+		}
+		if hook.Report != nil {
+			report := krknv1alpha1.FailureHookReport(*hook.Report)
+			converted.Report = &report
+		}
+		dst.Spec.OnFailure = append(dst.Spec.OnFailure, converted)
+	}
+	if spec.Artifacts != nil {
+		artifacts := krknv1alpha1.ArtifactStorage(*spec.Artifacts)
+		dst.Spec.Artifacts = &artifacts
+	}
+	if spec.Elasticsearch != nil {
+		elasticsearch := krknv1alpha1.ElasticsearchTelemetry(*spec.Elasticsearch)
+		dst.Spec.Elasticsearch = &elasticsearch
+	}
+	if spec.HealthCheck != nil {
+		healthCheck := krknv1alpha1.HealthCheck(*spec.HealthCheck)
+		dst.Spec.HealthCheck = &healthCheck
+	}
+	if spec.AlertProfile != nil {
+		profile := &krknv1alpha1.AlertProfile{FailOnCritical: spec.AlertProfile.FailOnCritical}
+		for _, alert := range spec.AlertProfile.Alerts {
+			profile.Alerts = append(profile.Alerts, krknv1alpha1.AlertRule(alert))
+		}
+		dst.Spec.AlertProfile = profile
+	}
+	if spec.CleanupVerification != nil {
+		verification := krknv1alpha1.CleanupVerification(*spec.CleanupVerification)
+		dst.Spec.CleanupVerification = &verification
+	}
+
+	status := &src.Status
+	dst.Status = krknv1alpha1.KrknScenarioRunStatus{
+		Phase:          status.Phase,
+		TotalTargets:   status.TotalTargets,
+		SuccessfulJobs: status.SuccessfulJobs,
+		FailedJobs:     status.FailedJobs,
+		RunningJobs:    status.RunningJobs,
+		CompletionTime: status.CompletionTime,
+		Conditions:     status.Conditions,
+	}
+	for _, job := range status.ClusterJobs {
+		converted := krknv1alpha1.ClusterJobStatus{
+			ProviderName:          job.ProviderName,
+			ClusterName:           job.ClusterName,
+			ClusterAPIURL:         job.ClusterAPIURL,
+			ClusterID:             job.ClusterID,
+			JobID:                 job.JobID,
+			PodName:               job.PodName,
+			KubeconfigSecret:      job.KubeconfigSecret,
+			KubeconfigConfigMap:   job.KubeconfigConfigMap,
+			Phase:                 job.Phase,
+			StartTime:             job.StartTime,
+			CompletionTime:        job.CompletionTime,
+			Message:               job.Message,
+			RetryCount:            job.RetryCount,
+			MaxRetries:            job.MaxRetries,
+			CancelRequested:       job.CancelRequested,
+			LastRetryTime:         job.LastRetryTime,
+			FailureReason:         job.FailureReason,
+			Evictions:             job.Evictions,
+			FailureHooksTriggered: job.FailureHooksTriggered,
+			MaintenanceReason:     job.MaintenanceReason,
+			Queued:                job.Queued,
+			SubstitutedFrom:       job.SubstitutedFrom,
+			ArtifactsUploadError:  job.ArtifactsUploadError,
+		}
+		if job.EffectiveSpec != nil {
+			effective := &krknv1alpha1.EffectiveSpec{
+				Image:       job.EffectiveSpec.Image,
+				ImageID:     job.EffectiveSpec.ImageID,
+				Environment: job.EffectiveSpec.Environment,
+				Pod:         krknv1alpha1.EffectivePodSummary(job.EffectiveSpec.Pod),
+				CapturedAt:  job.EffectiveSpec.CapturedAt,
+			}
+			for _, file := range job.EffectiveSpec.Files {
+				effective.Files = append(effective.Files, krknv1alpha1.EffectiveFile(file))
+			}
+			converted.EffectiveSpec = effective
+		}
+		if job.Result != nil {
+			result := &krknv1alpha1.ScenarioResult{
+				ExitCode:           job.Result.ExitCode,
+				Summary:            job.Result.Summary,
+				FailedChecks:       job.Result.FailedChecks,
+				TelemetryCollected: job.Result.TelemetryCollected,
+			}
+			for _, outcome := range job.Result.Scenarios {
+				result.Scenarios = append(result.Scenarios, krknv1alpha1.ScenarioOutcome(outcome))
+			}
+			converted.Result = result
+		}
+		for _, artifact := range job.Artifacts {
+			converted.Artifacts = append(converted.Artifacts, krknv1alpha1.UploadedArtifact(artifact))
+		}
+		if job.Cleanup != nil {
+			cleanup := krknv1alpha1.CleanupResult(*job.Cleanup)
+			converted.Cleanup = &cleanup
+		}
+		dst.Status.ClusterJobs = append(dst.Status.ClusterJobs, converted)
+	}
+	for _, wave := range status.RetryWaves {
+		dst.Status.RetryWaves = append(dst.Status.RetryWaves, krknv1alpha1.RetryWave(wave))
+	}
+	if snapshot := status.ResolvedTargets; snapshot != nil {
+		dst.Status.ResolvedTargets = &krknv1alpha1.TargetSnapshot{
+			Selector:    snapshot.Selector,
+			TargetGroup: snapshot.TargetGroup,
+			ResolvedAt:  snapshot.ResolvedAt,
+		}
+		for _, target := range snapshot.Targets {
+			dst.Status.ResolvedTargets.Targets = append(dst.Status.ResolvedTargets.Targets, krknv1alpha1.ResolvedTarget(target))
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *KrknScenarioRun) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*krknv1alpha1.KrknScenarioRun)
+	dst.ObjectMeta = src.ObjectMeta
+
+	spec := &src.Spec
+	dst.Spec = KrknScenarioRunSpec{
+		TargetRequestID:         spec.TargetRequestID,
+		OwnerUserID:             spec.OwnerUserID,
+		RunNumber:               spec.RunNumber,
+		DisplayName:             spec.DisplayName,
+		TargetClusters:          spec.TargetClusters,
+		TargetClusterIDs:        spec.TargetClusterIDs,
+		TargetSelector:          spec.TargetSelector,
+		TargetGroupRef:          spec.TargetGroupRef,
+		CampaignRef:             spec.CampaignRef,
+		Alternates:              spec.Alternates,
+		TemplateRef:             spec.TemplateRef,
+		ScenarioName:            spec.ScenarioName,
+		ScenarioImage:           spec.ScenarioImage,
+		ImagePullPolicy:         spec.ImagePullPolicy,
+		Resources:               spec.Resources,
+		KubeconfigPath:          spec.KubeconfigPath,
+		Environment:             spec.Environment,
+		SecretEnvironment:       spec.SecretEnvironment,
+		SecretEnvironmentRef:    spec.SecretEnvironmentRef,
+		RegistryURL:             spec.RegistryURL,
+		ScenarioRepository:      spec.ScenarioRepository,
+		Token:                   spec.Token,
+		Username:                spec.Username,
+		Password:                spec.Password,
+		RegistryRef:             spec.RegistryRef,
+		TimeoutSeconds:          spec.TimeoutSeconds,
+		TTLSecondsAfterFinished: spec.TTLSecondsAfterFinished,
+		MaintenancePolicy:       spec.MaintenancePolicy,
+		MaxConcurrency:          spec.MaxConcurrency,
+		EvictionProtection:      spec.EvictionProtection,
+		CancelRequested:         spec.CancelRequested,
+	}
+	if spec.MaxRetries != 0 || spec.RetryBackoff != "" || spec.RetryDelay != "" {
+		dst.Spec.RetryPolicy = &RetryPolicy{
+			MaxRetries: spec.MaxRetries,
+			Backoff:    spec.RetryBackoff,
+			Delay:      spec.RetryDelay,
+		}
+	}
+	for _, file := range spec.Files {
+		dst.Spec.Files = append(dst.Spec.Files, FileMount(file))
+	}
+	for _, hook := range spec.OnFailure {
+		converted := FailureHook{Name: hook.Name}
+		if hook.Image != nil {
+			image := FailureHookImage(*hook.Image)
+			converted.Image = &image
+		}
+		if hook.Webhook != nil {
+			webhook := FailureHookWebhook(*hook.Webhook)
+			converted.Webhook = &webhook
+		}
+		if hook.Report != nil {
+			report := FailureHookReport(*hook.Report)
+			converted.Report = &report
+		}
+		dst.Spec.OnFailure = append(dst.Spec.OnFailure, converted)
+	}
+	if spec.Artifacts != nil {
+		artifacts := ArtifactStorage(*spec.Artifacts)
+		dst.Spec.Artifacts = &artifacts
+	}
+	if spec.Elasticsearch != nil {
+		elasticsearch := ElasticsearchTelemetry(*spec.Elasticsearch)
+		dst.Spec.Elasticsearch = &elasticsearch
+	}
+	if spec.HealthCheck != nil {
+		healthCheck := HealthCheck(*spec.HealthCheck)
+		dst.Spec.HealthCheck = &healthCheck
+	}
+	if spec.AlertProfile != nil {
+		profile := &AlertProfile{FailOnCritical: spec.AlertProfile.FailOnCritical}
+		for _, alert := range spec.AlertProfile.Alerts {
+			profile.Alerts = append(profile.Alerts, AlertRule(alert))
+		}
+		dst.Spec.AlertProfile = profile
+	}
+	if spec.CleanupVerification != nil {
+		verification := CleanupVerification(*spec.CleanupVerification)
+		dst.Spec.CleanupVerification = &verification
+	}
+
+	status := &src.Status
+	dst.Status = KrknScenarioRunStatus{
+		Phase:          status.Phase,
+		TotalTargets:   status.TotalTargets,
+		SuccessfulJobs: status.SuccessfulJobs,
+		FailedJobs:     status.FailedJobs,
+		RunningJobs:    status.RunningJobs,
+		CompletionTime: status.CompletionTime,
+		Conditions:     status.Conditions,
+	}
+	for _, job := range status.ClusterJobs {
+		converted := ClusterJobStatus{
+			ProviderName:          job.ProviderName,
+			ClusterName:           job.ClusterName,
+			ClusterAPIURL:         job.ClusterAPIURL,
+			ClusterID:             job.ClusterID,
+			JobID:                 job.JobID,
+			PodName:               job.PodName,
+			KubeconfigSecret:      job.KubeconfigSecret,
+			KubeconfigConfigMap:   job.KubeconfigConfigMap,
+			Phase:                 job.Phase,
+			StartTime:             job.StartTime,
+			CompletionTime:        job.CompletionTime,
+			Message:               job.Message,
+			RetryCount:            job.RetryCount,
+			MaxRetries:            job.MaxRetries,
+			CancelRequested:       job.CancelRequested,
+			LastRetryTime:         job.LastRetryTime,
+			FailureReason:         job.FailureReason,
+			Evictions:             job.Evictions,
+			FailureHooksTriggered: job.FailureHooksTriggered,
+			MaintenanceReason:     job.MaintenanceReason,
+			Queued:                job.Queued,
+			SubstitutedFrom:       job.SubstitutedFrom,
+			ArtifactsUploadError:  job.ArtifactsUploadError,
+		}
+		if job.EffectiveSpec != nil {
+			effective := &EffectiveSpec{
+				Image:       job.EffectiveSpec.Image,
+				ImageID:     job.EffectiveSpec.ImageID,
+				Environment: job.EffectiveSpec.Environment,
+				Pod:         EffectivePodSummary(job.EffectiveSpec.Pod),
+				CapturedAt:  job.EffectiveSpec.CapturedAt,
+			}
+			for _, file := range job.EffectiveSpec.Files {
+				effective.Files = append(effective.Files, EffectiveFile(file))
+			}
+			converted.EffectiveSpec = effective
+		}
+		if job.Result != nil {
+			result := &ScenarioResult{
+				ExitCode:           job.Result.ExitCode,
+				Summary:            job.Result.Summary,
+				FailedChecks:       job.Result.FailedChecks,
+				TelemetryCollected: job.Result.TelemetryCollected,
+			}
+			for _, outcome := range job.Result.Scenarios {
+				result.Scenarios = append(result.Scenarios, ScenarioOutcome(outcome))
+			}
+			converted.Result = result
+		}
+		for _, artifact := range job.Artifacts {
+			converted.Artifacts = append(converted.Artifacts, UploadedArtifact(artifact))
+		}
+		if job.Cleanup != nil {
+			cleanup := CleanupResult(*job.Cleanup)
+			converted.Cleanup = &cleanup
+		}
+		dst.Status.ClusterJobs = append(dst.Status.ClusterJobs, converted)
+	}
+	for _, wave := range status.RetryWaves {
+		dst.Status.RetryWaves = append(dst.Status.RetryWaves, RetryWave(wave))
+	}
+	if snapshot := status.ResolvedTargets; snapshot != nil {
+		dst.Status.ResolvedTargets = &TargetSnapshot{
+			Selector:    snapshot.Selector,
+			TargetGroup: snapshot.TargetGroup,
+			ResolvedAt:  snapshot.ResolvedAt,
+		}
+		for _, target := range snapshot.Targets {
+			dst.Status.ResolvedTargets.Targets = append(dst.Status.ResolvedTargets.Targets, ResolvedTarget(target))
+		}
+	}
+	return nil
+}
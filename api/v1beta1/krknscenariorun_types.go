@@ -0,0 +1,737 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FileMount represents a file to be mounted in the scenario pod
+type FileMount struct {
+	// Name is the name of the file
+	Name string `json:"name"`
+	// Content is the base64-encoded content of the file
+	Content string `json:"content"`
+	// MountPath is the absolute path where the file should be mounted
+	MountPath string `json:"mountPath"`
+}
+
+// FailureHook is an action the controller takes once a cluster job has failed for good
+// (no retry left). Exactly one of Image, Webhook or Report must be set.
+type FailureHook struct {
+	// Name identifies the hook in pod names and logs
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	Name string `json:"name"`
+	// Image runs a remediation pod with the failed cluster's kubeconfig mounted
+	// +optional
+	Image *FailureHookImage `json:"image,omitempty"`
+	// Webhook notifies an external system, e.g. to open an incident
+	// +optional
+	Webhook *FailureHookWebhook `json:"webhook,omitempty"`
+	// Report records the failure in a KrknReport with a severity
+	// +optional
+	Report *FailureHookReport `json:"report,omitempty"`
+}
+
+// FailureHookImage describes a remediation pod
+type FailureHookImage struct {
+	// Image is the container image to run
+	Image string `json:"image"`
+	// Command overrides the image entrypoint
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are passed to the command
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Environment is a map of environment variables to set in the remediation pod
+	// +optional
+	Environment map[string]string `json:"environment,omitempty"`
+}
+
+// FailureHookWebhook describes a notification sent for a failed job
+type FailureHookWebhook struct {
+	// Type is the channel type: webhook (JSON payload) or slack
+	// +kubebuilder:validation:Enum=webhook;slack
+	// +kubebuilder:default="webhook"
+	Type string `json:"type,omitempty"`
+	// URL is the http(s) endpoint receiving the notification
+	URL string `json:"url"`
+}
+
+// FailureHookReport describes the KrknReport created for a failed job
+type FailureHookReport struct {
+	// Severity of the report
+	// +kubebuilder:validation:Enum=info;warning;critical
+	// +kubebuilder:default="warning"
+	Severity string `json:"severity,omitempty"`
+}
+
+// EffectiveFile is a file mounted in the scenario pod, recorded without its content
+type EffectiveFile struct {
+	// Name is the name of the file
+	Name string `json:"name"`
+	// MountPath is the path where the file was mounted
+	MountPath string `json:"mountPath"`
+}
+
+// EffectivePodSummary summarizes the pod spec a cluster job ran with
+type EffectivePodSummary struct {
+	// ServiceAccountName is the service account of the scenario pod
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// KubeconfigPath is where the target kubeconfig was mounted
+	// +optional
+	KubeconfigPath string `json:"kubeconfigPath,omitempty"`
+	// ImagePullPolicy is the pull policy of the scenario container
+	// +optional
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+	// ImagePullSecrets are the names of the pull secrets of the pod
+	// +optional
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+	// RunAsUser is the UID the scenario ran as
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// ActiveDeadlineSeconds is the deadline of the Job, from spec.timeoutSeconds
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// EffectiveSpec records what a cluster job actually ran. It is captured when the job's
+// Job is created, so it survives the deletion of the pod.
+type EffectiveSpec struct {
+	// Image is the scenario image reference
+	Image string `json:"image"`
+	// ImageID is the image digest reported by the container runtime once the pod started
+	// +optional
+	ImageID string `json:"imageId,omitempty"`
+	// Environment is the environment of the scenario container. Values of variables
+	// that look like credentials are redacted.
+	// +optional
+	Environment map[string]string `json:"environment,omitempty"`
+	// Files lists the files mounted in the scenario pod
+	// +optional
+	Files []EffectiveFile `json:"files,omitempty"`
+	// Pod summarizes the scenario pod spec
+	Pod EffectivePodSummary `json:"pod"`
+	// CapturedAt is when the spec was captured
+	CapturedAt metav1.Time `json:"capturedAt"`
+}
+
+// RetryPolicy decides how failed cluster jobs are retried
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times to retry failed jobs
+	// +optional
+	// +kubebuilder:default=3
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// Backoff determines the backoff strategy for retries (exponential or fixed)
+	// +optional
+	// +kubebuilder:validation:Enum=exponential;fixed
+	// +kubebuilder:default="exponential"
+	Backoff string `json:"backoff,omitempty"`
+
+	// Delay is the initial delay before retrying (e.g., "10s")
+	// +optional
+	// +kubebuilder:default="10s"
+	Delay string `json:"delay,omitempty"`
+}
+
+// ScenarioResult is the outcome krkn reported for a cluster job. The exit code is recorded
+// when the job finishes; the scenario outcomes and failed checks are parsed from the krkn
+// telemetry printed at the end of the scenario log, once the log is collected.
+type ScenarioResult struct {
+	// ExitCode is the exit code of the scenario container
+	ExitCode int32 `json:"exitCode"`
+	// Summary is a one-line summary of the result
+	// +optional
+	Summary string `json:"summary,omitempty"`
+	// Scenarios lists the outcome of each scenario krkn ran
+	// +optional
+	Scenarios []ScenarioOutcome `json:"scenarios,omitempty"`
+	// FailedChecks lists the health checks, critical alerts and unrecovered pods reported by krkn
+	// +optional
+	FailedChecks []string `json:"failedChecks,omitempty"`
+	// TelemetryCollected is set once the krkn telemetry was found in the scenario log
+	// +optional
+	TelemetryCollected bool `json:"telemetryCollected,omitempty"`
+}
+
+// ScenarioOutcome is the outcome of a single krkn scenario
+type ScenarioOutcome struct {
+	// Scenario is the scenario file krkn ran
+	Scenario string `json:"scenario"`
+	// Type is the krkn scenario type
+	// +optional
+	Type string `json:"type,omitempty"`
+	// ExitStatus is the exit status krkn reported for the scenario
+	ExitStatus int32 `json:"exitStatus"`
+}
+
+// ArtifactStorage is the S3-compatible bucket the artifacts of the run's jobs are uploaded to
+type ArtifactStorage struct {
+	// Endpoint is the base URL of the storage, e.g. https://s3.eu-west-1.amazonaws.com.
+	// Objects are addressed path-style: <endpoint>/<bucket>/<key>.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+	// Region is the signing region, us-east-1 when empty
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Bucket is the name of the bucket
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the object keys
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// CredentialsSecret is the Secret in the operator namespace holding AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and optionally AWS_SESSION_TOKEN
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// ElasticsearchTelemetry is the Elasticsearch krkn ships the telemetry, metrics and alerts
+// of the run's jobs to
+type ElasticsearchTelemetry struct {
+	// URL is the URL of Elasticsearch, e.g. https://es.example.com:9200. The port defaults
+	// to the one of the scheme.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Index is the index of the krkn telemetry
+	// +kubebuilder:validation:MinLength=1
+	Index string `json:"index"`
+	// MetricsIndex is the index of the metrics krkn collects, the krkn default when empty
+	// +optional
+	MetricsIndex string `json:"metricsIndex,omitempty"`
+	// AlertsIndex is the index of the alerts krkn collects, the krkn default when empty
+	// +optional
+	AlertsIndex string `json:"alertsIndex,omitempty"`
+	// CredentialsSecret is the Secret in the operator namespace holding the username and
+	// password keys of a kubernetes.io/basic-auth Secret
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// VerifyCerts verifies the TLS certificate of Elasticsearch
+	// +optional
+	VerifyCerts bool `json:"verifyCerts,omitempty"`
+}
+
+// HealthCheck is the URL krkn probes while the scenario runs, e.g. the route of the
+// application under test
+type HealthCheck struct {
+	// URL is the http(s) URL probed
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// IntervalSeconds is the time between two probes, the krkn default when unset
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+	// BearerTokenSecret is the key of a Secret in the operator namespace holding the bearer
+	// token sent with the probes
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+	// ExitOnFailure fails the scenario as soon as a probe fails
+	// +optional
+	ExitOnFailure bool `json:"exitOnFailure,omitempty"`
+	// VerifyCerts verifies the TLS certificate of the URL
+	// +optional
+	VerifyCerts bool `json:"verifyCerts,omitempty"`
+}
+
+// AlertRule is a Prometheus expression krkn evaluates once the scenario finished
+type AlertRule struct {
+	// Expr is the PromQL expression, the alert fires when it returns a result
+	// +kubebuilder:validation:MinLength=1
+	Expr string `json:"expr"`
+	// Description is the message logged when the alert fires, it may use the {{$labels}}
+	// and {{$value}} of the result
+	Description string `json:"description"`
+	// Severity is the level the alert is logged at; critical alerts fail the scenario
+	// when FailOnCritical is set
+	// +kubebuilder:validation:Enum=info;warning;error;critical
+	Severity string `json:"severity"`
+}
+
+// AlertProfile is the set of alerts krkn evaluates against the Prometheus of the target
+// cluster once the scenario finished
+type AlertProfile struct {
+	// Alerts are the rules of the profile
+	// +kubebuilder:validation:MinItems=1
+	Alerts []AlertRule `json:"alerts"`
+	// FailOnCritical fails the scenario when a critical alert fired
+	// +optional
+	FailOnCritical bool `json:"failOnCritical,omitempty"`
+}
+
+// CleanupVerification configures the check that the target clusters returned to a clean
+// state once the job of the run finished
+type CleanupVerification struct {
+	// Enabled turns the verification on or off. It runs by default after node and network
+	// scenarios, and after any scenario listing namespaces.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Namespaces are the test namespaces the scenario creates, that must have been removed
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// CleanupResult is the state a target cluster was left in by a cluster job
+type CleanupResult struct {
+	// Clean is true when nothing was left behind
+	Clean bool `json:"clean"`
+	// Issues are what was left behind and needs a manual remediation, e.g. cordoned nodes
+	// +optional
+	Issues []string `json:"issues,omitempty"`
+	// Error is why the cluster could not be verified, Clean is false then
+	// +optional
+	Error string `json:"error,omitempty"`
+	// CheckedAt is when the cluster was verified
+	CheckedAt metav1.Time `json:"checkedAt"`
+}
+
+// UploadedArtifact is a file of a cluster job uploaded to the run's artifact storage
+type UploadedArtifact struct {
+	// Name is the file name, e.g. scenario.log
+	Name string `json:"name"`
+	// URL is the URL of the uploaded object
+	URL string `json:"url"`
+}
+
+// ClusterJobStatus represents the status of a scenario job for a specific cluster
+type ClusterJobStatus struct {
+	// ProviderName is the name of the provider that owns this cluster
+	ProviderName string `json:"providerName"`
+	// ClusterName is the name of the target cluster
+	ClusterName string `json:"clusterName"`
+	// ClusterAPIURL is the API URL of the cluster for permission checks
+	// +optional
+	ClusterAPIURL string `json:"clusterApiUrl,omitempty"`
+	// ClusterID is the stable identifier of the cluster, see KrknTargetRequest target data
+	// +optional
+	ClusterID string `json:"clusterId,omitempty"`
+	// JobID is the unique identifier for this job
+	JobID string `json:"jobId"`
+	// PodName is the name of the pod running the scenario
+	PodName string `json:"podName,omitempty"`
+	// KubeconfigSecret is the content-addressed Secret mounting the cluster kubeconfig.
+	// It is shared by the jobs of the run targeting the same cluster.
+	// +optional
+	KubeconfigSecret string `json:"kubeconfigSecret,omitempty"`
+	// KubeconfigConfigMap is the ConfigMap that mounted the cluster kubeconfig of jobs
+	// started by earlier operator versions, released once they settle.
+	// Deprecated: kubeconfigs are mounted from KubeconfigSecret.
+	// +optional
+	KubeconfigConfigMap string `json:"kubeconfigConfigMap,omitempty"`
+	// Phase is the current phase of the job (Pending, Running, Succeeded, Failed, Retrying, Cancelled, MaxRetriesExceeded, SkippedMaintenance)
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Retrying;Cancelled;MaxRetriesExceeded;SkippedMaintenance
+	Phase string `json:"phase"`
+	// StartTime is when the job started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the job completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message contains additional information about the job status
+	Message string `json:"message,omitempty"`
+
+	// RetryCount is the number of times this job has been retried
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+	// MaxRetries is the maximum number of retries allowed for this job
+	// +optional
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// CancelRequested indicates if the user has requested cancellation
+	// +optional
+	CancelRequested bool `json:"cancelRequested,omitempty"`
+	// LastRetryTime is when the last retry was initiated
+	// +optional
+	LastRetryTime *metav1.Time `json:"lastRetryTime,omitempty"`
+	// FailureReason contains a categorized failure reason (OOMKilled, ContainerError, Evicted, etc.)
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+	// Evictions is the number of times the job was restarted because its pod was evicted
+	// from the hub. Evictions do not count against MaxRetries.
+	// +optional
+	Evictions int `json:"evictions,omitempty"`
+	// FailureHooksTriggered is set once the run's onFailure hooks were executed for this job
+	// +optional
+	FailureHooksTriggered bool `json:"failureHooksTriggered,omitempty"`
+	// MaintenanceReason is the planned maintenance of the target cluster that holds or
+	// skipped the job, cleared once the job is created
+	// +optional
+	MaintenanceReason string `json:"maintenanceReason,omitempty"`
+	// Queued is set while the job waits for a free slot of spec.maxConcurrency or of the
+	// operator-wide limit before its Job is created
+	// +optional
+	Queued bool `json:"queued,omitempty"`
+	// SubstitutedFrom is the target cluster the job replaced because it was unreachable
+	// at preflight, the job running on its alternate
+	// +optional
+	SubstitutedFrom string `json:"substitutedFrom,omitempty"`
+	// EffectiveSpec is the spec the current attempt of the job was created with
+	// +optional
+	EffectiveSpec *EffectiveSpec `json:"effectiveSpec,omitempty"`
+	// Result is the outcome krkn reported for the current attempt of the job
+	// +optional
+	Result *ScenarioResult `json:"result,omitempty"`
+	// Artifacts are the files of the job uploaded to the run's artifact storage
+	// +optional
+	Artifacts []UploadedArtifact `json:"artifacts,omitempty"`
+	// ArtifactsUploadError is why the artifacts of the job could not be uploaded
+	// +optional
+	ArtifactsUploadError string `json:"artifactsUploadError,omitempty"`
+	// Cleanup is the state the job left the cluster in, set once the job finished when
+	// cleanup verification applies to the run
+	// +optional
+	Cleanup *CleanupResult `json:"cleanup,omitempty"`
+}
+
+// KrknScenarioRunSpec defines the desired state of KrknScenarioRun
+// +kubebuilder:validation:XValidation:rule="has(self.targetClusters) || has(self.targetGroupRef)",message="targetClusters or targetGroupRef is required"
+// +kubebuilder:validation:XValidation:rule="has(self.templateRef) || (has(self.scenarioName) && has(self.scenarioImage))",message="scenarioName and scenarioImage are required without templateRef"
+type KrknScenarioRunSpec struct {
+	// TargetRequestID is the reference to the KrknTargetRequest CR
+	TargetRequestID string `json:"targetRequestId"`
+
+	// OwnerUserID is the email address of the user who created this scenario run
+	// +optional
+	OwnerUserID string `json:"ownerUserId,omitempty"`
+
+	// RunNumber is the number of the run among the runs of its scenario, assigned by the
+	// API when the run is created. The run name stays its stable key.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RunNumber int64 `json:"runNumber,omitempty"`
+
+	// DisplayName is an optional human-friendly name of the run
+	// +optional
+	// +kubebuilder:validation:MaxLength=128
+	DisplayName string `json:"displayName,omitempty"`
+
+	// TargetClusters is a map of provider-name to list of cluster names
+	// Example: {"krkn-operator": ["cluster1", "cluster2"], "krkn-operator-acm": ["cluster3"]}
+	// +optional
+	// +kubebuilder:validation:MinProperties=1
+	TargetClusters map[string][]string `json:"targetClusters,omitempty"`
+
+	// TargetClusterIDs lists clusters by their stable identifier, as found in the target
+	// data of the target request. The controller adds them to TargetClusters under their
+	// current provider and name when the run starts, so renamed clusters are still found;
+	// they are recorded in status.resolvedTargets.
+	// +optional
+	TargetClusterIDs []string `json:"targetClusterIds,omitempty"`
+
+	// TargetGroupRef is the name of a KrknTargetGroup in the namespace of the run. The
+	// controller adds its members to TargetClusters when the run starts; they are recorded
+	// in status.resolvedTargets.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	TargetGroupRef string `json:"targetGroupRef,omitempty"`
+
+	// CampaignRef is the name of the KrknCampaign in the namespace of the run the run is
+	// part of, if any
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	CampaignRef string `json:"campaignRef,omitempty"`
+
+	// TargetSelector is the label selector, e.g. "env=staging,region=eu", the API selected
+	// targets of the target request with when the run was created. The selected clusters
+	// are part of TargetClusters.
+	// +optional
+	TargetSelector string `json:"targetSelector,omitempty"`
+
+	// Alternates maps a target cluster to an alternate cluster of the same provider. When the
+	// target cluster is unreachable at preflight, before its first job is created, the job
+	// runs on the alternate instead, e.g. for resilience drills across redundant clusters.
+	// +optional
+	Alternates map[string]string `json:"alternates,omitempty"`
+
+	// TemplateRef is the name of a KrknScenarioTemplate in the namespace of the run. The
+	// controller renders it into the fields the run leaves unset when the run starts.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	TemplateRef string `json:"templateRef,omitempty"`
+
+	// ScenarioName is the name of the scenario to run, taken from the template when unset
+	// +optional
+	ScenarioName string `json:"scenarioName,omitempty"`
+
+	// ScenarioImage is the container image for the scenario, taken from the template when unset
+	// +optional
+	ScenarioImage string `json:"scenarioImage,omitempty"`
+
+	// ImagePullPolicy is the pull policy of the scenario container
+	// +optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +kubebuilder:default="Always"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Resources are the compute resource requests and limits of the scenario container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// KubeconfigPath is the path where kubeconfig will be mounted in the pod
+	// +optional
+	// +kubebuilder:default="/home/krkn/.kube/config"
+	KubeconfigPath string `json:"kubeconfigPath,omitempty"`
+
+	// Files is a list of files to mount in the scenario pod
+	// +optional
+	Files []FileMount `json:"files,omitempty"`
+
+	// Environment is a map of environment variables to set in the scenario pod
+	// +optional
+	Environment map[string]string `json:"environment,omitempty"`
+
+	// SecretEnvironment lists the variables holding credentials, set in Environment or held
+	// by SecretEnvironmentRef. They are passed to the scenario pod from a Secret instead of
+	// as values of the pod spec.
+	// +optional
+	// +listType=set
+	SecretEnvironment []string `json:"secretEnvironment,omitempty"`
+
+	// SecretEnvironmentRef names the Secret of the run holding the values of the variables
+	// of SecretEnvironment, keyed by variable name. The variables it holds are left out of
+	// Environment, so their values never appear in the run.
+	// +optional
+	SecretEnvironmentRef string `json:"secretEnvironmentRef,omitempty"`
+
+	// RegistryURL is the URL of the container registry
+	// +optional
+	RegistryURL string `json:"registryURL,omitempty"`
+
+	// ScenarioRepository is the repository path in the registry
+	// +optional
+	ScenarioRepository string `json:"scenarioRepository,omitempty"`
+
+	// Token is the authentication token for the registry
+	// +optional
+	Token string `json:"token,omitempty"`
+
+	// Username is the username for registry authentication
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Password is the password for registry authentication
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// RegistryRef is the name of the KrknRegistry in the operator namespace the scenario
+	// image is pulled from with the credentials of its Secret, instead of Token, Username
+	// and Password
+	// +optional
+	RegistryRef string `json:"registryRef,omitempty"`
+
+	// RetryPolicy decides how failed cluster jobs are retried
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// TimeoutSeconds is how long a scenario pod may run before it is killed and its
+	// cluster job marked Failed with reason DeadlineExceeded. Each retry gets the full timeout.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// TTLSecondsAfterFinished is how long the run is kept once it finished. The run is then
+	// deleted together with the Jobs, pods, ConfigMaps and Secrets created for it.
+	// Unset keeps the run until it is deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// MaintenancePolicy decides what happens to the job of a target cluster under planned
+	// maintenance: skip marks it SkippedMaintenance, wait holds it until the maintenance ends.
+	// Retries follow the same policy.
+	// +optional
+	// +kubebuilder:validation:Enum=skip;wait
+	// +kubebuilder:default="skip"
+	MaintenancePolicy string `json:"maintenancePolicy,omitempty"`
+
+	// MaxConcurrency caps the scenario jobs of the run running at the same time. The jobs of
+	// the other target clusters are queued until a running job finishes. Unset runs every
+	// cluster at once, within the operator-wide limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+
+	// EvictionProtection keeps voluntary disruptions of the hub, such as node drains and
+	// cluster autoscaler scale-downs, from evicting the scenario pods of the run: a
+	// PodDisruptionBudget owned by the run covers them and they are marked not safe to
+	// evict for the autoscaler.
+	// +optional
+	EvictionProtection bool `json:"evictionProtection,omitempty"`
+
+	// OnFailure hooks run when a cluster job fails with no retry left,
+	// e.g. to start a remediation job or open an incident
+	// +optional
+	OnFailure []FailureHook `json:"onFailure,omitempty"`
+
+	// Artifacts uploads the scenario log and the krkn output of each cluster job to
+	// S3-compatible storage once the job finishes
+	// +optional
+	Artifacts *ArtifactStorage `json:"artifacts,omitempty"`
+
+	// Elasticsearch ships the krkn telemetry of every cluster job to Elasticsearch, through
+	// the krkn environment variables set on the scenario container
+	// +optional
+	Elasticsearch *ElasticsearchTelemetry `json:"elasticsearch,omitempty"`
+
+	// HealthCheck is probed by krkn while the scenario runs, configured through the krkn-hub
+	// environment of every cluster job
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+
+	// AlertProfile is evaluated by krkn once the scenario finished. The profile file is
+	// generated and mounted in every cluster job.
+	// +optional
+	AlertProfile *AlertProfile `json:"alertProfile,omitempty"`
+
+	// CleanupVerification verifies through the data provider that each target cluster
+	// returned to a clean state once its job finished: no node left cordoned or not ready,
+	// no netem cleanup failure reported by krkn, the test namespaces removed
+	// +optional
+	CleanupVerification *CleanupVerification `json:"cleanupVerification,omitempty"`
+
+	// CancelRequested asks the controller to cancel the run: the pods of active jobs are
+	// deleted with a grace period, their jobs and the clusters not started yet end Cancelled.
+	// +optional
+	CancelRequested bool `json:"cancelRequested,omitempty"`
+}
+
+// RetryWave records a request to retry the failed clusters of a completed run
+type RetryWave struct {
+	// Number is the 1-based index of the wave within the run
+	Number int `json:"number"`
+	// RequestedBy is the user who requested the retry
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// RequestedAt is when the retry was requested
+	RequestedAt metav1.Time `json:"requestedAt"`
+	// ScheduledAt is when the controller creates the retry jobs
+	ScheduledAt metav1.Time `json:"scheduledAt"`
+	// Clusters are the names of the clusters to retry
+	Clusters []string `json:"clusters"`
+	// StartTime is when the controller created the retry jobs; unset while the wave is scheduled
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// ResolvedTarget is a cluster targeted by a run, as resolved when the run started
+type ResolvedTarget struct {
+	// Provider is the name of the provider of the cluster
+	Provider string `json:"provider"`
+	// ClusterName is the name of the cluster
+	ClusterName string `json:"clusterName"`
+	// ClusterAPIURL is the API server URL of the cluster when the run started
+	// +optional
+	ClusterAPIURL string `json:"clusterAPIURL,omitempty"`
+	// ClusterID is the stable identifier of the cluster
+	// +optional
+	ClusterID string `json:"clusterId,omitempty"`
+}
+
+// TargetSnapshot records the clusters of a run when it started, so that audits and reruns
+// use the original set even once target labels or the targets themselves changed
+type TargetSnapshot struct {
+	// Selector is the target selector the clusters were selected with, if any
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// TargetGroup is the KrknTargetGroup whose members were added to the clusters, if any
+	// +optional
+	TargetGroup string `json:"targetGroup,omitempty"`
+	// ResolvedAt is when the snapshot was taken
+	ResolvedAt metav1.Time `json:"resolvedAt"`
+	// Targets are the clusters of the run, sorted by provider and cluster name
+	Targets []ResolvedTarget `json:"targets"`
+}
+
+// KrknScenarioRunStatus defines the observed state of KrknScenarioRun
+type KrknScenarioRunStatus struct {
+	// Phase is the overall phase of the scenario run
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;PartiallyFailed;Failed
+	Phase string `json:"phase,omitempty"`
+
+	// TotalTargets is the total number of target clusters
+	TotalTargets int `json:"totalTargets,omitempty"`
+
+	// SuccessfulJobs is the number of successfully completed jobs
+	SuccessfulJobs int `json:"successfulJobs,omitempty"`
+
+	// FailedJobs is the number of failed jobs
+	FailedJobs int `json:"failedJobs,omitempty"`
+
+	// RunningJobs is the number of currently running jobs
+	RunningJobs int `json:"runningJobs,omitempty"`
+
+	// CompletionTime is when the run finished, cleared while failed clusters are retried
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ClusterJobs contains the status of each cluster job
+	// +optional
+	ClusterJobs []ClusterJobStatus `json:"clusterJobs,omitempty"`
+
+	// RetryWaves records the retries of failed clusters requested after the run completed
+	// +optional
+	RetryWaves []RetryWave `json:"retryWaves,omitempty"`
+
+	// ResolvedTargets is the snapshot of the clusters of the run taken when it started
+	// +optional
+	ResolvedTargets *TargetSnapshot `json:"resolvedTargets,omitempty"`
+
+	// Conditions represent the latest available observations of the scenario run's state:
+	// Ready, Progressing, Degraded and Completed
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Number",type=integer,JSONPath=`.spec.runNumber`
+// +kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`,priority=1
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Targets",type=integer,JSONPath=`.status.totalTargets`
+// +kubebuilder:printcolumn:name="Succeeded",type=integer,JSONPath=`.status.successfulJobs`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedJobs`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:shortName=ksr
+// +kubebuilder:unservedversion
+
+// KrknScenarioRun is the Schema for the krknscenrarioruns API
+type KrknScenarioRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KrknScenarioRunSpec   `json:"spec,omitempty"`
+	Status KrknScenarioRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KrknScenarioRunList contains a list of KrknScenarioRun
+type KrknScenarioRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KrknScenarioRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KrknScenarioRun{}, &KrknScenarioRunList{})
+}
@@ -20,14 +20,18 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -44,13 +48,26 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	// +kubebuilder:scaffold:imports
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	krknv1beta1 "github.com/krkn-chaos/krkn-operator/api/v1beta1"
 	"github.com/krkn-chaos/krkn-operator/internal/api"
 	"github.com/krkn-chaos/krkn-operator/internal/controller"
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	webhookv1alpha1 "github.com/krkn-chaos/krkn-operator/internal/webhook/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
 	"github.com/krkn-chaos/krkn-operator/pkg/configmap"
 	"github.com/krkn-chaos/krkn-operator/pkg/configstore"
+	"github.com/krkn-chaos/krkn-operator/pkg/crdcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/crdversions"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+	"github.com/krkn-chaos/krkn-operator/pkg/permcheck"
 	"github.com/krkn-chaos/krkn-operator/pkg/provider"
-	// +kubebuilder:scaffold:imports
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+	"github.com/krkn-chaos/krkn-operator/pkg/usage"
 )
 
 var (
@@ -62,6 +79,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(krknv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(krknv1beta1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -74,8 +93,22 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks, acmDiscovery, hiveDiscovery, capiProvider bool
+	var webhookServiceName, rancherProviderSecret string
+	var kubeconfigEncryptionSecret string
+	var vaultCredentialsSecret string
 	var apiPort int
 	var grpcServerAddr string
+	var grpcConfig grpcclient.Config
+	var auditLogFile, auditWebhookURL string
+	var usageTelemetry, usageReportURL string
+	var historyBackend, historyDSN string
+	var authorizerConfig auth.AuthorizerConfig
+	var fleetSize, kubeAPIBurst, maxConcurrentReconciles, maxConcurrentScenarioJobs int
+	var kubeAPIQPS float64
+	var controllerConcurrency string
+	var cacheSyncPeriod, orphanGCInterval, legacyPodRetention, artifactFlushTimeout, targetProbeInterval time.Duration
+	var registryProbeInterval, targetArchiveRetention time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -94,8 +127,104 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the defaulting, validation and conversion webhooks of KrknScenarioRun and KrknOperatorTarget "+
+			"are served. Requires the webhook certificate and the webhook configurations to be installed.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "",
+		"If set with --enable-webhooks, the CRDs served in several versions are configured to convert "+
+			"through this Service of the operator namespace, and their newer versions are served. "+
+			"The CA of the webhook certificate is read from ca.crt in --webhook-cert-path.")
+	flag.BoolVar(&acmDiscovery, "acm-discovery", false,
+		"If set, a KrknOperatorTarget is created for each ACM ManagedCluster of the hub from its admin "+
+			"kubeconfig, and deleted with it. Skipped when the ManagedCluster CRD is not installed.")
+	flag.BoolVar(&hiveDiscovery, "hive-discovery", false,
+		"If set, a KrknOperatorTarget is created for each installed Hive ClusterDeployment from its admin "+
+			"kubeconfig, not ready while the cluster is deprovisioned or hibernating. "+
+			"Skipped when the ClusterDeployment CRD is not installed.")
+	flag.BoolVar(&capiProvider, "capi-provider", false,
+		"If set, the provisioned Cluster API Clusters of the cluster are contributed to target requests "+
+			"as provider "+controller.CAPIProviderName+". Skipped when the Cluster CRD is not installed.")
+	flag.StringVar(&rancherProviderSecret, "rancher-provider-secret", "",
+		"If set, the active downstream clusters of the Rancher server configured in this Secret of the "+
+			"operator namespace (url, token and optional ca.crt) are contributed to target requests as provider "+
+			controller.RancherProviderName+".")
+	flag.StringVar(&kubeconfigEncryptionSecret, "kubeconfig-encryption-secret", "",
+		"If set, the kubeconfigs stored in target and managed-clusters Secrets are encrypted at rest with "+
+			"the key configured in this Secret of the operator namespace: a 32-byte master key (key), or a "+
+			"Vault Transit compatible KMS key (transit-url, transit-key, transit-token and optional ca.crt).")
+	flag.StringVar(&vaultCredentialsSecret, "vault-credentials-secret", "",
+		"If set, the kubeconfigs of the targets created through the API are written to the HashiCorp Vault "+
+			"KV v2 engine configured in this Secret of the operator namespace (vault-url, vault-token and "+
+			"optional vault-path-prefix and ca.crt); the targets only record their Vault path.")
 	flag.IntVar(&apiPort, "api-port", 8080, "The port for the REST API server")
 	flag.StringVar(&grpcServerAddr, "grpc-server-address", "localhost:50051", "The address of the gRPC data provider server")
+	flag.StringVar(&grpcConfig.Scheme, "grpc-server-scheme", grpcclient.SchemePlaintext,
+		"How to connect to the gRPC data provider server: plaintext, or tls to verify it and encrypt the connection")
+	flag.StringVar(&grpcConfig.TLSDir, "grpc-tls-dir", "",
+		"Directory of the mounted Secret with the TLS files of --grpc-server-scheme=tls: ca.crt verifies the "+
+			"server (the system roots when missing), tls.crt and tls.key enable mutual TLS when present")
+	flag.StringVar(&grpcConfig.ServerName, "grpc-tls-server-name", "",
+		"Name verified in the certificate of the gRPC data provider server (the host of --grpc-server-address when empty)")
+	flag.IntVar(&grpcConfig.MaxAttempts, "grpc-max-attempts", grpcclient.DefaultMaxAttempts,
+		"Attempts of a data provider call failing with Unavailable or DeadlineExceeded, with exponential backoff (1 to not retry)")
+	flag.IntVar(&grpcConfig.FailureThreshold, "grpc-circuit-breaker-threshold", grpcclient.DefaultFailureThreshold,
+		"Consecutive failed data provider calls after which calls fail fast for --grpc-circuit-breaker-cooldown")
+	flag.DurationVar(&grpcConfig.Cooldown, "grpc-circuit-breaker-cooldown", grpcclient.DefaultCooldown,
+		"Time data provider calls fail fast once the circuit breaker opened, before a call is let through again")
+	flag.StringVar(&auditLogFile, "audit-log-file", "",
+		"If set, audit records for state-changing API calls are appended to this file as JSON lines")
+	flag.StringVar(&auditWebhookURL, "audit-webhook-url", "",
+		"If set, audit records for state-changing API calls are POSTed to this URL")
+	flag.StringVar(&historyBackend, "history-backend", "",
+		"If set, finished and deleted scenario runs are recorded for GET /api/v1/history: file, sqlite or "+
+			"postgres. The SQL backends need the database/sql driver of the same name linked into the binary.")
+	flag.StringVar(&historyDSN, "history-dsn", "",
+		"The path of the JSON lines file of --history-backend=file, the data source name of the SQL backends")
+	flag.StringVar(&usageTelemetry, "usage-telemetry", usage.ModeOff,
+		"Opt-in anonymous usage telemetry: off, local to aggregate API calls per endpoint, run and target "+
+			"counts and enabled features for GET /api/v1/usage only, or report to also POST them daily to "+
+			"--usage-report-url. Reports hold counts, never names or URLs.")
+	flag.StringVar(&usageReportURL, "usage-report-url", "",
+		"The https URL usage reports are POSTed to with --usage-telemetry=report")
+	flag.StringVar(&authorizerConfig.Mode, "authorizer", auth.AuthorizerModeRBAC,
+		"Authorizer of the authenticated API calls: rbac for the built-in roles, "+
+			"webhook to ask an external policy engine at --authorization-webhook-url")
+	flag.StringVar(&authorizerConfig.WebhookURL, "authorization-webhook-url", "",
+		"URL the subject, action and resource of each API call are POSTed to with --authorizer=webhook")
+	flag.DurationVar(&authorizerConfig.WebhookTimeout, "authorization-webhook-timeout", 0,
+		"Timeout of each authorization webhook call (0 = 5s)")
+	flag.DurationVar(&authorizerConfig.CacheTTL, "authorization-cache-ttl", 0,
+		"Time authorization decisions are reused for the same subject, action and resource (0 = not cached)")
+	flag.IntVar(&fleetSize, "fleet-size", 0,
+		"Expected number of target clusters. Used to pick defaults for the client QPS/burst, "+
+			"reconcile concurrency and cache resync flags below when they are left at 0.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 0,
+		"Maximum QPS from the operator to the Kubernetes API server (0 = scaled by --fleet-size)")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 0,
+		"Maximum burst for throttling requests to the Kubernetes API server (0 = scaled by --fleet-size)")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 0,
+		"Default number of concurrent reconciles per controller (0 = scaled by --fleet-size)")
+	flag.IntVar(&maxConcurrentScenarioJobs, "max-concurrent-scenario-jobs", 0,
+		"Maximum number of scenario jobs running at the same time across all runs, "+
+			"the others are queued until a job finishes (0 = unlimited)")
+	flag.StringVar(&controllerConcurrency, "controller-concurrency", "",
+		"Per-controller concurrent reconciles overriding --max-concurrent-reconciles, "+
+			"e.g. krknscenariorun=8,krkntargetrequest=2")
+	flag.DurationVar(&cacheSyncPeriod, "cache-sync-period", 0,
+		"Minimum interval at which watched resources are reconciled again (0 = scaled by --fleet-size)")
+	flag.DurationVar(&orphanGCInterval, "orphan-gc-interval", controller.DefaultOrphanGCInterval,
+		"Interval at which ConfigMaps and Secrets of scenario jobs without Job or pod are deleted (0 = disabled)")
+	flag.DurationVar(&legacyPodRetention, "legacy-pod-retention", controller.DefaultLegacyPodRetention,
+		"Time finished ownerless scenario pods of the legacy API are kept after adoption, checked every --orphan-gc-interval")
+	flag.DurationVar(&targetArchiveRetention, "target-archive-retention", api.DefaultTargetArchiveRetention,
+		"Time an archived (soft-deleted) target can be restored before it and its credentials are purged")
+	flag.DurationVar(&targetProbeInterval, "target-probe-interval", controller.DefaultTargetProbeInterval,
+		"Interval at which the connectivity of each target cluster is checked through the data provider (0 = disabled)")
+	flag.DurationVar(&registryProbeInterval, "registry-probe-interval", api.DefaultRegistryProbeInterval,
+		"Interval at which the default scenario registry (quay.io) is probed to refresh its cached catalog "+
+			"and availability (0 = disabled, e.g. with private registries only)")
+	flag.DurationVar(&artifactFlushTimeout, "artifact-flush-timeout", controller.DefaultArtifactFlushTimeout,
+		"Time given on shutdown to collect the artifacts of finished jobs; the rest is collected after restart")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -104,6 +233,33 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	tuning := controller.DefaultTuning(fleetSize)
+	if kubeAPIQPS > 0 {
+		tuning.QPS = float32(kubeAPIQPS)
+	}
+	if kubeAPIBurst > 0 {
+		tuning.Burst = kubeAPIBurst
+	}
+	if maxConcurrentReconciles > 0 {
+		tuning.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+	if cacheSyncPeriod > 0 {
+		tuning.SyncPeriod = cacheSyncPeriod
+	}
+	overrides, err := controller.ParseControllerConcurrency(controllerConcurrency)
+	if err != nil {
+		setupLog.Error(err, "invalid --controller-concurrency")
+		os.Exit(1)
+	}
+	tuning.ControllerConcurrency = overrides
+	setupLog.Info("Controller tuning",
+		"fleetSize", fleetSize,
+		"qps", tuning.QPS,
+		"burst", tuning.Burst,
+		"maxConcurrentReconciles", tuning.MaxConcurrentReconciles,
+		"controllerConcurrency", tuning.ControllerConcurrency,
+		"syncPeriod", tuning.SyncPeriod)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -209,7 +365,10 @@ func main() {
 	}
 	setupLog.Info("KrknTargetRequest namespace", "namespace", krknNamespace)
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	restConfig := ctrl.GetConfigOrDie()
+	tuning.ApplyToConfig(restConfig)
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                  scheme,
 		Metrics:                 metricsServerOptions,
 		WebhookServer:           webhookServer,
@@ -229,6 +388,7 @@ func main() {
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
 		Cache: cache.Options{
+			SyncPeriod: &tuning.SyncPeriod,
 			DefaultNamespaces: map[string]cache.Config{
 				operatorNamespace: {}, // Watch only the operator's own namespace
 			},
@@ -240,18 +400,68 @@ func main() {
 	}
 
 	// Create Kubernetes clientset (needed by controller before API server creation)
-	config := ctrl.GetConfigOrDie()
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		setupLog.Error(err, "unable to create Kubernetes clientset")
 		os.Exit(1)
 	}
 
+	// Seals the stored kubeconfigs when encryption at rest is configured (nil leaves them in plaintext)
+	var kubeconfigCipher *envelope.Cipher
+	if kubeconfigEncryptionSecret != "" {
+		kubeconfigCipher, err = envelope.LoadCipher(context.Background(), mgr.GetAPIReader(), krknNamespace,
+			kubeconfigEncryptionSecret)
+		if err != nil {
+			setupLog.Error(err, "unable to load kubeconfig encryption key")
+			os.Exit(1)
+		}
+		setupLog.Info("Kubeconfig encryption at rest enabled", "secret", kubeconfigEncryptionSecret)
+	}
+
+	// One connection to the data provider is shared by the API and the controllers
+	dataProvider, err := grpcclient.NewClientWithConfig(grpcServerAddr, grpcConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to load gRPC TLS configuration")
+		os.Exit(1)
+	}
+	defer func() { _ = dataProvider.Close() }()
+
+	// Keeps the credentials of new targets in Vault when configured (nil keeps them in Secrets)
+	var credentialsStore secrets.Store
+	if vaultCredentialsSecret != "" {
+		vaultStore, err := secrets.LoadVaultKVStore(context.Background(), mgr.GetAPIReader(), krknNamespace,
+			vaultCredentialsSecret)
+		if err != nil {
+			setupLog.Error(err, "unable to load Vault credentials store")
+			os.Exit(1)
+		}
+		credentialsStore = vaultStore
+		setupLog.Info("Vault credentials store enabled", "secret", vaultCredentialsSecret)
+	}
+
+	// Collects the final log of finished jobs, flushed on shutdown and resumed on restart
+	artifactCollector := controller.NewArtifactCollector(mgr.GetClient(), clientset, krknNamespace, artifactFlushTimeout)
+	if err := mgr.Add(artifactCollector); err != nil {
+		setupLog.Error(err, "unable to add artifact collector to manager")
+		os.Exit(1)
+	}
+
 	if err = (&controller.KrknScenarioRunReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
 		Clientset: clientset,
 		Namespace: krknNamespace,
+		Notifications: notify.NewDispatcher(notify.NewHTTPNotifier(notify.PolicyFromEnv()),
+			notify.DefaultMaxInFlight, notify.DefaultDeliveryTimeout),
+		Artifacts: artifactCollector,
+		Recorder:  mgr.GetEventRecorderFor(controller.ScenarioRunControllerName),
+
+		HealthChecker:   &controller.APIServerHealthChecker{},
+		CleanupVerifier: &controller.DataProviderCleanupVerifier{Client: dataProvider},
+		Cipher:          kubeconfigCipher,
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.ScenarioRunControllerName),
+		MaxConcurrentJobs:       maxConcurrentScenarioJobs,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KrknScenarioRun")
 		os.Exit(1)
@@ -260,8 +470,13 @@ func main() {
 	if err = (&controller.KrknTargetRequestReconciler{
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
-		OperatorName:      "krkn-operator",
+		OperatorName:      krknv1alpha1.OperatorTargetProvider,
 		OperatorNamespace: krknNamespace,
+		Recorder:          mgr.GetEventRecorderFor(controller.TargetRequestControllerName),
+		Cipher:            kubeconfigCipher,
+		CredentialsStore:  credentialsStore,
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.TargetRequestControllerName),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KrknTargetRequest")
 		os.Exit(1)
@@ -270,17 +485,246 @@ func main() {
 	if err = (&controller.KrknOperatorTargetProviderConfigReconciler{
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
-		OperatorName:      "krkn-operator",
+		OperatorName:      krknv1alpha1.OperatorTargetProvider,
 		OperatorNamespace: krknNamespace,
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.TargetProviderConfigControllerName),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KrknOperatorTargetProviderConfig")
 		os.Exit(1)
 	}
+	if err = (&controller.KrknOperatorTargetProviderReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		OperatorNamespace: krknNamespace,
+		Recorder:          mgr.GetEventRecorderFor(controller.TargetProviderControllerName),
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.TargetProviderControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KrknOperatorTargetProvider")
+		os.Exit(1)
+	}
+	tokenRotator := &controller.TargetTokenRotator{
+		Client:           mgr.GetClient(),
+		CredentialsStore: credentialsStore,
+		Cipher:           kubeconfigCipher,
+	}
+	if err = (&controller.KrknOperatorTargetReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		OperatorNamespace: krknNamespace,
+		Prober:            &controller.DataProviderTargetProber{Client: dataProvider},
+		ProbeInterval:     targetProbeInterval,
+		Cipher:            kubeconfigCipher,
+		CredentialsStore:  credentialsStore,
+		TokenRotator:      tokenRotator,
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.OperatorTargetControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KrknOperatorTarget")
+		os.Exit(1)
+	}
+	if acmDiscovery {
+		if _, err := mgr.GetRESTMapper().RESTMapping(controller.ManagedClusterGVK.GroupKind(),
+			controller.ManagedClusterGVK.Version); err != nil {
+			setupLog.Info("ACM ManagedCluster CRD not found, ACM discovery disabled", "error", err.Error())
+		} else if err = (&controller.ACMDiscoveryReconciler{
+			Client:            mgr.GetClient(),
+			Scheme:            mgr.GetScheme(),
+			OperatorNamespace: krknNamespace,
+			APIReader:         mgr.GetAPIReader(),
+			Recorder:          mgr.GetEventRecorderFor(controller.ACMDiscoveryControllerName),
+			Cipher:            kubeconfigCipher,
+
+			MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.ACMDiscoveryControllerName),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ACMDiscovery")
+			os.Exit(1)
+		}
+	}
+	if hiveDiscovery {
+		if _, err := mgr.GetRESTMapper().RESTMapping(controller.ClusterDeploymentGVK.GroupKind(),
+			controller.ClusterDeploymentGVK.Version); err != nil {
+			setupLog.Info("Hive ClusterDeployment CRD not found, Hive discovery disabled", "error", err.Error())
+		} else {
+			discovery := controller.NewHiveDiscovery(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(),
+				krknNamespace, controller.DefaultHiveDiscoveryInterval)
+			discovery.Recorder = mgr.GetEventRecorderFor("hive-discovery")
+			discovery.Cipher = kubeconfigCipher
+			if err := mgr.Add(discovery); err != nil {
+				setupLog.Error(err, "unable to set up Hive discovery")
+				os.Exit(1)
+			}
+		}
+	}
+	if capiProvider {
+		if _, err := mgr.GetRESTMapper().RESTMapping(controller.CAPIClusterGVK.GroupKind(),
+			controller.CAPIClusterGVK.Version); err != nil {
+			setupLog.Info("Cluster API Cluster CRD not found, CAPI provider disabled", "error", err.Error())
+			capiProvider = false
+		} else if err = setupBuiltinProvider(mgr, controller.CAPIProviderName, "capi",
+			&controller.CAPITargetSource{Reader: mgr.GetAPIReader()}, krknNamespace, kubeconfigCipher, tuning); err != nil {
+			setupLog.Error(err, "unable to set up CAPI provider")
+			os.Exit(1)
+		}
+	}
+	if rancherProviderSecret != "" {
+		if err = setupBuiltinProvider(mgr, controller.RancherProviderName, "rancher", &controller.RancherTargetSource{
+			Reader:     mgr.GetAPIReader(),
+			Namespace:  krknNamespace,
+			SecretName: rancherProviderSecret,
+		}, krknNamespace, kubeconfigCipher, tuning); err != nil {
+			setupLog.Error(err, "unable to set up Rancher provider")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		// Break-glass exemptions are signed with the JWT secret of the API
+		if err = webhookv1alpha1.SetupKrknScenarioRunWebhookWithManager(mgr, auth.ScenarioPolicyFromEnv(),
+			func(ctx context.Context) (*auth.TokenGenerator, error) {
+				return api.LoadTokenGenerator(ctx, mgr.GetAPIReader(), krknNamespace)
+			}); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KrknScenarioRun")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupKrknOperatorTargetWebhookWithManager(mgr, kubeconfigCipher, credentialsStore); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KrknOperatorTarget")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Serve the newer API versions once they convert through the webhook server
+	if enableWebhooks && webhookServiceName != "" {
+		conversionInstaller := crdversions.NewConversionInstaller(mgr.GetAPIReader(), mgr.GetClient(),
+			crdversions.VersionedCRDs, crdversions.WebhookService{
+				Namespace: operatorNamespace,
+				Name:      webhookServiceName,
+				CertDir:   webhookCertPath,
+			})
+		if err := mgr.Add(conversionInstaller); err != nil {
+			setupLog.Error(err, "unable to add CRD conversion installer to manager")
+			os.Exit(1)
+		}
+	}
+
+	// Rewrite objects still stored in an older API version
+	if err := mgr.Add(crdversions.NewStorageMigrator(mgr.GetAPIReader(), mgr.GetClient(), crdversions.VersionedCRDs)); err != nil {
+		setupLog.Error(err, "unable to add storage version migrator to manager")
+		os.Exit(1)
+	}
+
+	// Compare installed CRDs with the compiled API types (uncached reader, no CRD watch needed)
+	crdChecker := crdcheck.NewChecker(mgr.GetAPIReader(), crdcheck.DefaultExpectations())
+	if err := mgr.Add(crdChecker); err != nil {
+		setupLog.Error(err, "unable to add CRD schema checker to manager")
+		os.Exit(1)
+	}
+
+	// Check the operator can use its resources in the configured namespace
+	permissionChecker := permcheck.NewChecker(clientset.AuthorizationV1().SelfSubjectAccessReviews(),
+		mgr.GetAPIReader(), krknNamespace, permcheck.DefaultRequirements(), permcheck.DefaultProbes())
+	if err := mgr.Add(permissionChecker); err != nil {
+		setupLog.Error(err, "unable to add permission checker to manager")
+		os.Exit(1)
+	}
+
+	// Delete job ConfigMaps and Secrets left behind by jobs that no longer exist
+	if orphanGCInterval > 0 {
+		if err := mgr.Add(controller.NewOrphanCollector(mgr.GetClient(), krknNamespace, orphanGCInterval)); err != nil {
+			setupLog.Error(err, "unable to add orphaned resource collector to manager")
+			os.Exit(1)
+		}
+
+		// Adopt and clean up the ownerless scenario pods created by the legacy API path
+		legacyPodAdopter := controller.NewLegacyPodAdopter(mgr.GetClient(), krknNamespace, orphanGCInterval, legacyPodRetention)
+		legacyPodAdopter.Recorder = mgr.GetEventRecorderFor("legacy-pod-adopter")
+		if err := mgr.Add(legacyPodAdopter); err != nil {
+			setupLog.Error(err, "unable to add legacy pod adopter to manager")
+			os.Exit(1)
+		}
+	}
+
 	// Setup and add REST API server
 	apiServer := api.NewServer(apiPort, mgr.GetClient(), clientset, krknNamespace, grpcServerAddr)
-	setupLog.Info("gRPC server address", "address", grpcServerAddr)
+	apiServer.SetDataProviderClient(dataProvider)
+	setupLog.Info("gRPC server address", "address", grpcServerAddr, "scheme", grpcConfig.Scheme)
+	authorizer, err := auth.NewAuthorizer(authorizerConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create API authorizer")
+		os.Exit(1)
+	}
+	apiServer.SetAuthorizer(authorizer)
+	if auditLogFile != "" {
+		fileSink, err := audit.NewFileSink(auditLogFile)
+		if err != nil {
+			setupLog.Error(err, "unable to open audit log file", "path", auditLogFile)
+			os.Exit(1)
+		}
+		defer func() { _ = fileSink.Close() }()
+		apiServer.AddAuditSink(fileSink)
+	}
+	if auditWebhookURL != "" {
+		apiServer.AddAuditSink(audit.NewWebhookSink(auditWebhookURL))
+	}
+	apiServer.SetCRDChecker(crdChecker)
+	apiServer.SetPermissionChecker(permissionChecker)
+	scenarioRunInformer, err := mgr.GetCache().GetInformer(context.Background(), &krknv1alpha1.KrknScenarioRun{})
+	if err != nil {
+		setupLog.Error(err, "unable to get KrknScenarioRun informer")
+		os.Exit(1)
+	}
+	apiServer.SetScenarioRunInformer(scenarioRunInformer)
+	apiServer.SetRegistryProbeInterval(registryProbeInterval)
+	apiServer.SetKubeconfigCipher(kubeconfigCipher)
+	apiServer.SetCredentialsStore(credentialsStore)
+	apiServer.SetTokenRotator(tokenRotator)
+	if targetArchiveRetention <= 0 {
+		setupLog.Error(nil, "invalid --target-archive-retention, expected a positive duration",
+			"targetArchiveRetention", targetArchiveRetention)
+		os.Exit(1)
+	}
+	apiServer.SetTargetArchiveRetention(targetArchiveRetention)
+	if historyBackend != "" {
+		historyStore, err := history.Open(context.Background(), historyBackend, historyDSN)
+		if err != nil {
+			setupLog.Error(err, "unable to open run history", "backend", historyBackend)
+			os.Exit(1)
+		}
+		defer func() { _ = historyStore.Close() }()
+		if err := history.NewRecorder(historyStore, krknNamespace).Register(scenarioRunInformer); err != nil {
+			setupLog.Error(err, "unable to record run history")
+			os.Exit(1)
+		}
+		apiServer.SetHistoryStore(historyStore)
+		setupLog.Info("Run history enabled", "backend", historyBackend)
+	}
+	if err := usage.ValidateMode(usageTelemetry, usageReportURL); err != nil {
+		setupLog.Error(err, "invalid usage telemetry configuration")
+		os.Exit(1)
+	}
+	if usageTelemetry != usage.ModeOff {
+		usageTracker := usage.NewTracker(mgr.GetClient(), krknNamespace, map[string]bool{
+			"webhooks":          enableWebhooks,
+			"acmDiscovery":      acmDiscovery,
+			"hiveDiscovery":     hiveDiscovery,
+			"capiProvider":      capiProvider,
+			"rancherProvider":   rancherProviderSecret != "",
+			"auditLog":          auditLogFile != "" || auditWebhookURL != "",
+			"authorizerWebhook": authorizerConfig.Mode == auth.AuthorizerModeWebhook,
+			"registryProbe":     registryProbeInterval > 0,
+			"runHistory":        historyBackend != "",
+			"jobConcurrency":    maxConcurrentScenarioJobs > 0,
+		})
+		apiServer.SetUsageTracker(usageTracker)
+		if usageTelemetry == usage.ModeReport {
+			if err := mgr.Add(usage.NewReporter(usageTracker, usageReportURL, usage.DefaultReportInterval)); err != nil {
+				setupLog.Error(err, "unable to add usage reporter to manager")
+				os.Exit(1)
+			}
+		}
+		setupLog.Info("Usage telemetry enabled", "mode", usageTelemetry)
+	}
 	if err := mgr.Add(apiServer); err != nil {
 		setupLog.Error(err, "unable to add REST API server to manager")
 		os.Exit(1)
@@ -293,6 +737,26 @@ func main() {
 		os.Exit(1)
 	}
 	setupLog.Info("Provider registration configured", "name", "krkn-operator", "namespace", krknNamespace)
+	if capiProvider {
+		if err := mgr.Add(provider.NewProviderRegistrationWithConfig(mgr.GetClient(), provider.Config{
+			ProviderName: controller.CAPIProviderName,
+			Namespace:    krknNamespace,
+		})); err != nil {
+			setupLog.Error(err, "unable to add CAPI provider registration to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Provider registration configured", "name", controller.CAPIProviderName, "namespace", krknNamespace)
+	}
+	if rancherProviderSecret != "" {
+		if err := mgr.Add(provider.NewProviderRegistrationWithConfig(mgr.GetClient(), provider.Config{
+			ProviderName: controller.RancherProviderName,
+			Namespace:    krknNamespace,
+		})); err != nil {
+			setupLog.Error(err, "unable to add Rancher provider registration to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Provider registration configured", "name", controller.RancherProviderName, "namespace", krknNamespace)
+	}
 
 	// Setup ConfigStore initializer (runs after manager cache is ready)
 	configStoreInit := NewConfigStoreInitializer(mgr.GetClient(), krknNamespace)
@@ -321,10 +785,20 @@ func main() {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+	// The readiness probe and the public /api/v1/readyz run the same checks
+	readyzChecks := map[string]healthz.Checker{
+		"readyz":      healthz.Ping,
+		"crd-schema":  crdChecker.ReadyzCheck,
+		"permissions": permissionChecker.ReadyzCheck,
+	}
+	maps.Copy(readyzChecks, apiServer.ReadyzChecks())
+	for name, check := range readyzChecks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to set up ready check", "check", name)
+			os.Exit(1)
+		}
 	}
+	apiServer.SetReadyzChecks(readyzChecks)
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -333,6 +807,39 @@ func main() {
 	}
 }
 
+// setupBuiltinProvider runs the target request and provider config controllers of a
+// built-in provider, which contribute the clusters of source under their own provider
+// name. Their controllers are named after suffix.
+func setupBuiltinProvider(mgr ctrl.Manager, name, suffix string, source controller.TargetSource,
+	namespace string, cipher *envelope.Cipher, tuning controller.Tuning) error {
+	if err := (&controller.KrknTargetRequestReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		OperatorName:      name,
+		OperatorNamespace: namespace,
+		Recorder:          mgr.GetEventRecorderFor(name),
+		TargetSource:      source,
+		ControllerName:    controller.TargetRequestControllerName + "-" + suffix,
+		Cipher:            cipher,
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.TargetRequestControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create KrknTargetRequest controller: %w", err)
+	}
+	if err := (&controller.KrknOperatorTargetProviderConfigReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		OperatorName:      name,
+		OperatorNamespace: namespace,
+		ControllerName:    controller.TargetProviderConfigControllerName + "-" + suffix,
+
+		MaxConcurrentReconciles: tuning.ConcurrencyFor(controller.TargetProviderConfigControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create KrknOperatorTargetProviderConfig controller: %w", err)
+	}
+	return nil
+}
+
 // ConfigStoreInitializer is a Runnable that initializes the kvstore from ConfigMap
 // after the manager cache is ready
 type ConfigStoreInitializer struct {
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// auditActions maps state-changing HTTP methods to audit actions
+var auditActions = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+// auditMiddleware records an audit entry for every state-changing request.
+// It must run inside the auth middleware so the JWT claims are available.
+func (h *Handler) auditMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action, audited := auditActions[r.Method]
+		if !audited || h.auditor == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		record := audit.Record{
+			User:       "anonymous",
+			Action:     action,
+			Resource:   r.URL.Path,
+			Result:     audit.ResultForStatus(rw.statusCode),
+			StatusCode: rw.statusCode,
+			ClientIP:   r.RemoteAddr,
+		}
+		if claims := auth.GetClaimsFromContext(r.Context()); claims != nil {
+			record.User = claims.UserID
+			record.Role = claims.Role
+		}
+
+		h.auditor.Record(r.Context(), record)
+	})
+}
+
+// GetAuditLog handles GET /api/v1/audit endpoint (admin only)
+// Supported query parameters: user, action, resource (path prefix), result,
+// since (RFC3339) and limit (default 100).
+func (h *Handler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgOnlyMethodAllowed,
+			i18n.Params{"method": http.MethodGet})
+		return
+	}
+
+	if !auth.IsAdmin(r.Context()) {
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := audit.Filter{
+		User:     query.Get("user"),
+		Action:   query.Get("action"),
+		Resource: query.Get("resource"),
+		Result:   query.Get("result"),
+		Limit:    100,
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidLimit, nil)
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidTimestamp, i18n.Params{"param": "since"})
+			return
+		}
+		filter.Since = parsed
+	}
+
+	entries := h.auditor.Recent(filter)
+	writeJSON(w, http.StatusOK, AuditLogResponse{
+		Entries: entries,
+		Count:   len(entries),
+	})
+}
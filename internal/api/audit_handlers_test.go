@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	handler := setupTestHandler()
+	handler.auditor = audit.NewRecorder(10)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	wrapped := handler.auditMiddleware(next)
+
+	ctx := context.WithValue(context.Background(), auth.UserClaimsKey, &auth.Claims{
+		UserID: "admin@example.com",
+		Role:   "admin",
+	})
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		req := httptest.NewRequest(method, OperatorTargetsPath+"/abc", nil).WithContext(ctx)
+		wrapped.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	records := handler.auditor.Recent(audit.Filter{})
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 audit records (GET is not audited), got %d", len(records))
+	}
+
+	deleteRecord := records[0]
+	if deleteRecord.Action != "delete" || deleteRecord.Result != audit.ResultFailure ||
+		deleteRecord.StatusCode != http.StatusNotFound {
+		t.Errorf("Unexpected delete record: %+v", deleteRecord)
+	}
+	if deleteRecord.User != "admin@example.com" || deleteRecord.Role != "admin" {
+		t.Errorf("Expected user from claims, got %s/%s", deleteRecord.User, deleteRecord.Role)
+	}
+
+	createRecord := records[1]
+	if createRecord.Action != "create" || createRecord.Result != audit.ResultSuccess {
+		t.Errorf("Unexpected create record: %+v", createRecord)
+	}
+}
+
+func TestGetAuditLog(t *testing.T) {
+	handler := setupTestHandler()
+	handler.auditor = audit.NewRecorder(10)
+	handler.auditor.Record(context.Background(), audit.Record{User: "alice", Action: "create", Resource: UsersPath})
+	handler.auditor.Record(context.Background(), audit.Record{User: "bob", Action: "delete", Resource: OperatorTargetsPath})
+
+	tests := []struct {
+		name           string
+		role           string
+		query          string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "admin lists all", role: "admin", expectedStatus: http.StatusOK, expectedCount: 2},
+		{name: "admin filters by user", role: "admin", query: "?user=bob", expectedStatus: http.StatusOK, expectedCount: 1},
+		{name: "invalid limit", role: "admin", query: "?limit=abc", expectedStatus: http.StatusBadRequest},
+		{name: "invalid since", role: "admin", query: "?since=yesterday", expectedStatus: http.StatusBadRequest},
+		{name: "user forbidden", role: "user", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), auth.UserClaimsKey, &auth.Claims{
+				UserID: "someone@example.com",
+				Role:   tt.role,
+			})
+			req := httptest.NewRequest(http.MethodGet, AuditPath+tt.query, nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.GetAuditLog(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response AuditLogResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Count != tt.expectedCount {
+				t.Errorf("Expected %d entries, got %d", tt.expectedCount, response.Count)
+			}
+		})
+	}
+}
@@ -20,7 +20,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -36,6 +35,7 @@ import (
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
 )
 
 const (
@@ -89,10 +89,8 @@ func getTokenDuration() time.Duration {
 // Returns whether at least one admin user is registered in the system
 func (h *Handler) IsRegistered(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only GET method is allowed",
-		})
+		writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgOnlyMethodAllowed,
+			i18n.Params{"method": http.MethodGet})
 		return
 	}
 
@@ -108,10 +106,7 @@ func (h *Handler) IsRegistered(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		logger.Error(err, "Failed to list admin users")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to check admin registration status",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgRegistrationCheckFailed, nil)
 		return
 	}
 
@@ -126,10 +121,8 @@ func (h *Handler) IsRegistered(w http.ResponseWriter, r *http.Request) {
 // Registers the FIRST admin user only. After that, use POST /api/v1/users (admin only).
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only POST method is allowed",
-		})
+		writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgOnlyMethodAllowed,
+			i18n.Params{"method": http.MethodPost})
 		return
 	}
 
@@ -138,38 +131,30 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "invalid_request", MsgMalformedRequestBody, nil)
 		return
 	}
 
 	// Validate required fields
 	if req.UserID == "" || req.Password == "" || req.Name == "" || req.Surname == "" || req.Role == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "UserID, password, name, surname, and role are required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgUserFieldsRequired, nil)
 		return
 	}
 
 	// Validate role
 	if req.Role != "user" && req.Role != "admin" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Role must be either 'user' or 'admin'",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgUserRoleInvalid, nil)
 		return
 	}
 
 	// Validate password
 	if err := auth.ValidatePassword(req.Password); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: fmt.Sprintf("Password validation failed: %s", err.Error()),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgPasswordInvalid,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -182,10 +167,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		logger.Error(err, "Failed to list admin users")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to verify admin status",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAdminCheckFailed, nil)
 		return
 	}
 
@@ -194,19 +176,13 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	// If no admins exist, allow first admin registration without authentication
 	// Otherwise, reject - new users must be created by admins via POST /api/v1/users
 	if hasAdmins {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "registration_closed",
-			Message: "Initial admin registration is complete. New users must be created by an admin using POST /api/v1/users",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "registration_closed", MsgRegistrationClosed, nil)
 		return
 	}
 
 	// First admin registration - must be admin role
 	if !hasAdmins && req.Role != "admin" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "First user must have admin role",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgFirstUserAdmin, nil)
 		return
 	}
 
@@ -215,19 +191,14 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	err = h.client.List(ctx, existingUsers, client.InNamespace(h.namespace))
 	if err != nil {
 		logger.Error(err, "Failed to list existing users")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to check existing users",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserCheckFailed, nil)
 		return
 	}
 
 	for _, user := range existingUsers.Items {
 		if user.Spec.UserID == req.UserID {
-			writeJSONError(w, http.StatusConflict, ErrorResponse{
-				Error:   "user_exists",
-				Message: fmt.Sprintf("User with email %s already exists", req.UserID),
-			})
+			writeLocalizedError(w, r, http.StatusConflict, "user_exists", MsgUserExists,
+				i18n.Params{"userId": req.UserID})
 			return
 		}
 	}
@@ -236,10 +207,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	passwordHash, err := auth.HashPassword(req.Password)
 	if err != nil {
 		logger.Error(err, "Failed to hash password")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to process password",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordHashFailed, nil)
 		return
 	}
 
@@ -268,27 +236,18 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 			logger.Info("Password secret already exists, deleting and recreating", "secret", secretName)
 			if delErr := h.client.Delete(ctx, secret); delErr != nil {
 				logger.Error(delErr, "Failed to delete existing password secret", "secret", secretName)
-				writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_error",
-					Message: "Failed to clean up existing credentials",
-				})
+				writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCredentialsCleanupFailed, nil)
 				return
 			}
 			// Recreate the secret
 			if createErr := h.client.Create(ctx, secret); createErr != nil {
 				logger.Error(createErr, "Failed to recreate password secret", "secret", secretName)
-				writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_error",
-					Message: "Failed to create user credentials",
-				})
+				writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCredentialsCreateFailed, nil)
 				return
 			}
 		} else {
 			logger.Error(err, "Failed to create password secret", "secret", secretName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to create user credentials",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCredentialsCreateFailed, nil)
 			return
 		}
 	}
@@ -318,10 +277,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		logger.Error(err, "Failed to create KrknUser", "user", userName)
 		// Clean up the secret we just created
 		_ = h.client.Delete(ctx, secret)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create user",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserCreateFailed, nil)
 		return
 	}
 
@@ -339,7 +295,8 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	logger.Info("User registered successfully", "userId", req.UserID, "role", req.Role)
 
 	writeJSON(w, http.StatusCreated, RegisterResponse{
-		Message: "User registered successfully",
+		Code:    MsgUserRegisterSucceeded,
+		Message: localizedMessage(w, r, MsgUserRegisterSucceeded, nil),
 		UserID:  req.UserID,
 		Role:    req.Role,
 	})
@@ -349,10 +306,8 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 // Authenticates a user and returns a JWT token
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only POST method is allowed",
-		})
+		writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgOnlyMethodAllowed,
+			i18n.Params{"method": http.MethodPost})
 		return
 	}
 
@@ -361,20 +316,17 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "invalid_request", MsgMalformedRequestBody, nil)
 		return
 	}
 
 	// Validate required fields
 	if req.UserID == "" || req.Password == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "UserID and password are required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgLoginFieldsRequired, nil)
 		return
 	}
 
@@ -383,10 +335,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	err := h.client.List(ctx, userList, client.InNamespace(h.namespace))
 	if err != nil {
 		logger.Error(err, "Failed to list users")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to authenticate",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAuthenticationFailed, nil)
 		return
 	}
 
@@ -399,19 +348,13 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if user == nil {
-		writeJSONError(w, http.StatusUnauthorized, ErrorResponse{
-			Error:   "invalid_credentials",
-			Message: "Invalid email or password",
-		})
+		writeLocalizedError(w, r, http.StatusUnauthorized, "invalid_credentials", MsgInvalidCredentials, nil)
 		return
 	}
 
 	// Check if user is active
 	if !user.Status.Active {
-		writeJSONError(w, http.StatusUnauthorized, ErrorResponse{
-			Error:   "account_disabled",
-			Message: "User account is disabled",
-		})
+		writeLocalizedError(w, r, http.StatusUnauthorized, "account_disabled", MsgAccountDisabled, nil)
 		return
 	}
 
@@ -424,29 +367,20 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Get(ctx, secretKey, secret); err != nil {
 		logger.Error(err, "Failed to get password secret", "secret", user.Spec.PasswordSecretRef)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to authenticate",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAuthenticationFailed, nil)
 		return
 	}
 
 	passwordHash, ok := secret.Data["passwordHash"]
 	if !ok {
 		logger.Error(fmt.Errorf("passwordHash not found in secret"), "Missing password hash")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to authenticate",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAuthenticationFailed, nil)
 		return
 	}
 
 	// Verify password
 	if !auth.VerifyPassword(req.Password, string(passwordHash)) {
-		writeJSONError(w, http.StatusUnauthorized, ErrorResponse{
-			Error:   "invalid_credentials",
-			Message: "Invalid email or password",
-		})
+		writeLocalizedError(w, r, http.StatusUnauthorized, "invalid_credentials", MsgInvalidCredentials, nil)
 		return
 	}
 
@@ -454,10 +388,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	jwtSecret, err := h.getOrCreateJWTSecret(ctx)
 	if err != nil {
 		logger.Error(err, "Failed to get JWT secret")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to generate token",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTokenGenerationFailed, nil)
 		return
 	}
 
@@ -466,10 +397,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	token, err := tokenGen.GenerateToken(user.Spec.UserID, user.Spec.Role, user.Spec.Name, user.Spec.Surname, user.Spec.Organization)
 	if err != nil {
 		logger.Error(err, "Failed to generate token")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to generate token",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTokenGenerationFailed, nil)
 		return
 	}
 
@@ -556,3 +484,18 @@ func (h *Handler) getOrCreateJWTSecret(ctx context.Context) ([]byte, error) {
 	logger.Info("Created new JWT secret")
 	return randomSecret, nil
 }
+
+// LoadTokenGenerator creates the TokenGenerator of the API from the JWT secret in
+// namespace, without creating the secret. The scenario run webhook uses it to verify
+// the break-glass exemptions the API issued.
+func LoadTokenGenerator(ctx context.Context, reader client.Reader, namespace string) (*auth.TokenGenerator, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: GetJWTSecretName()}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get JWT secret: %w", err)
+	}
+	jwtSecret, ok := secret.Data[JWTSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("jwt-secret key not found in secret")
+	}
+	return auth.NewTokenGenerator(jwtSecret, TokenDuration, "krkn-operator"), nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+const (
+	// AuthPluginImagesEnv is a JSON object mapping a scenario image prefix to the auth
+	// plugins the matching images bundle, e.g.
+	// {"quay.io/acme/krkn-hub-eks": ["aws-iam-authenticator"]}
+	AuthPluginImagesEnv = "API_AUTH_PLUGIN_IMAGES"
+	// AuthPluginPolicyEnv is what happens to a run whose scenario image is not known to
+	// bundle the auth plugins of a target kubeconfig: "warn" (default) creates it with a
+	// warning, "reject" rejects it
+	AuthPluginPolicyEnv = "API_AUTH_PLUGIN_POLICY"
+)
+
+// AuthPluginPolicy checks that the scenario images of runs can use the kubeconfigs of
+// their targets: one authenticating with an exec credential plugin or an auth provider
+// fails inside a container that lacks the plugin binary
+type AuthPluginPolicy struct {
+	// BundledPlugins maps a scenario image prefix to the auth plugins its images bundle
+	BundledPlugins map[string][]string
+	// Reject rejects the runs instead of warning
+	Reject bool
+}
+
+// AuthPluginPolicyFromEnv reads the auth plugin policy from the environment. An invalid
+// image map is ignored, so that every plugin is reported.
+func AuthPluginPolicyFromEnv() AuthPluginPolicy {
+	policy := AuthPluginPolicy{Reject: strings.EqualFold(os.Getenv(AuthPluginPolicyEnv), "reject")}
+	if value := os.Getenv(AuthPluginImagesEnv); value != "" {
+		if err := json.Unmarshal([]byte(value), &policy.BundledPlugins); err != nil {
+			log.Log.Error(err, "Invalid auth plugin images, ignored", "env", AuthPluginImagesEnv)
+		}
+	}
+	return policy
+}
+
+// missingPlugins returns the plugins among plugins that image is not known to bundle
+func (p AuthPluginPolicy) missingPlugins(image string, plugins []string) []string {
+	var missing []string
+	for _, plugin := range plugins {
+		bundled := false
+		for prefix, bundledPlugins := range p.BundledPlugins {
+			if strings.HasPrefix(image, prefix) && slices.Contains(bundledPlugins, plugin) {
+				bundled = true
+				break
+			}
+		}
+		if !bundled {
+			missing = append(missing, plugin)
+		}
+	}
+	return missing
+}
+
+// authPluginMismatch is a target cluster whose kubeconfig needs auth plugins the scenario
+// image is not known to bundle
+type authPluginMismatch struct {
+	cluster string
+	plugins []string
+}
+
+// params returns the parameters of the localized message of the mismatch
+func (m authPluginMismatch) params(image string) i18n.Params {
+	return i18n.Params{"cluster": m.cluster, "plugins": strings.Join(m.plugins, ", "), "image": image}
+}
+
+// authPluginMismatches returns the clusters, as provider-name to cluster names, whose
+// kubeconfig in the Secret of the target request needs auth plugins image is not known
+// to bundle, sorted by cluster
+func (h *Handler) authPluginMismatches(ctx context.Context, targetRequestID string, clusters map[string][]string, image string) ([]authPluginMismatch, error) {
+	var secret corev1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{Name: targetRequestID, Namespace: h.namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to fetch secret: %w", err)
+	}
+	var managedClusters map[string]map[string]struct {
+		Kubeconfig string `json:"kubeconfig"`
+	}
+	if err := json.Unmarshal(secret.Data["managed-clusters"], &managedClusters); err != nil {
+		return nil, fmt.Errorf("failed to parse managed-clusters JSON: %w", err)
+	}
+
+	var mismatches []authPluginMismatch
+	for providerName, clusterNames := range clusters {
+		for _, clusterName := range clusterNames {
+			cluster, exists := managedClusters[providerName][clusterName]
+			if !exists {
+				continue
+			}
+			// Unreadable kubeconfigs fail the job with their own error
+			kubeconfigBase64, err := h.openKubeconfig(ctx, cluster.Kubeconfig)
+			if err != nil {
+				continue
+			}
+			plugins, err := kubeconfig.AuthPlugins(kubeconfigBase64)
+			if err != nil {
+				continue
+			}
+			if missing := h.authPlugins.missingPlugins(image, plugins); len(missing) > 0 {
+				mismatches = append(mismatches, authPluginMismatch{cluster: clusterName, plugins: missing})
+			}
+		}
+	}
+	slices.SortFunc(mismatches, func(a, b authPluginMismatch) int { return strings.Compare(a.cluster, b.cluster) })
+	return mismatches, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAuthPluginPolicyFromEnv(t *testing.T) {
+	t.Setenv(AuthPluginImagesEnv, `{"quay.io/acme/krkn-hub-eks": ["aws-iam-authenticator", "aws"]}`)
+	t.Setenv(AuthPluginPolicyEnv, "Reject")
+
+	policy := AuthPluginPolicyFromEnv()
+	if !policy.Reject {
+		t.Error("Expected the reject policy")
+	}
+	plugins := []string{"aws-iam-authenticator", "kubelogin"}
+	if missing := policy.missingPlugins("quay.io/acme/krkn-hub-eks:pod-scenarios", plugins); !slices.Equal(missing, []string{"kubelogin"}) {
+		t.Errorf("Expected kubelogin to be missing, got %v", missing)
+	}
+	if missing := policy.missingPlugins("quay.io/krkn-chaos/krkn-hub:pod-scenarios", plugins); !slices.Equal(missing, plugins) {
+		t.Errorf("Expected every plugin to be missing from an unknown image, got %v", missing)
+	}
+
+	t.Setenv(AuthPluginImagesEnv, "not json")
+	if policy := AuthPluginPolicyFromEnv(); policy.BundledPlugins != nil {
+		t.Errorf("Expected an invalid image map to be ignored, got %v", policy.BundledPlugins)
+	}
+}
+
+func TestPostScenarioRun_AuthPlugins(t *testing.T) {
+	execKubeconfig := base64.StdEncoding.EncodeToString([]byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: eks
+  cluster:
+    server: https://eks.example.com
+contexts:
+- name: eks
+  context:
+    cluster: eks
+    user: eks
+current-context: eks
+users:
+- name: eks
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: aws-iam-authenticator
+`))
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{"eks": execKubeconfig})
+	ctx := context.Background()
+
+	var secret corev1.Secret
+	if err := handler.client.Get(ctx, client.ObjectKey{Name: "test-request-id", Namespace: "default"}, &secret); err != nil {
+		t.Fatalf("Failed to get secret: %v", err)
+	}
+	secret.Data["managed-clusters"], _ = json.Marshal(map[string]map[string]map[string]string{
+		"krkn-operator": {"eks": {"kubeconfig": execKubeconfig}},
+	})
+	if err := handler.client.Update(ctx, &secret); err != nil {
+		t.Fatalf("Failed to update secret: %v", err)
+	}
+
+	post := func(image string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["eks"]},
+			"scenarioImage": "`+image+`",
+			"scenarioName": "pod-delete"
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	// Warned by default
+	w := post("quay.io/krkn-chaos/krkn-hub:pod-scenarios")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Warnings) != 1 || response.Warnings[0].Code != MsgAuthPluginMissing ||
+		!strings.Contains(response.Warnings[0].Message, "aws-iam-authenticator") {
+		t.Errorf("Expected a warning about the missing plugin, got %+v", response.Warnings)
+	}
+
+	handler.authPlugins = AuthPluginPolicy{
+		BundledPlugins: map[string][]string{"quay.io/acme/krkn-hub-eks": {"aws-iam-authenticator"}},
+		Reject:         true,
+	}
+	if w := post("quay.io/krkn-chaos/krkn-hub:pod-scenarios"); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+	w = post("quay.io/acme/krkn-hub-eks:pod-scenarios")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	response = ScenarioRunCreateResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Warnings) != 0 {
+		t.Errorf("Expected no warning for an image bundling the plugin, got %+v", response.Warnings)
+	}
+}
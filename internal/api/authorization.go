@@ -20,13 +20,13 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/pkg/auth"
 	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -48,10 +48,7 @@ func (h *Handler) requireAdminForMethods(w http.ResponseWriter, r *http.Request,
 
 	// Check if user is admin
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return false
 	}
 
@@ -124,7 +121,7 @@ func (h *Handler) checkScenarioRunAccessWithAction(
 
 	// Defensive check - should never happen with RequireAuth middleware
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized","message":"No authentication claims found"}`, http.StatusUnauthorized)
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
 		return false
 	}
 
@@ -135,7 +132,7 @@ func (h *Handler) checkScenarioRunAccessWithAction(
 
 	// Reject runs without jobs (defensive - should not happen for new runs)
 	if len(scenarioRun.Status.ClusterJobs) == 0 {
-		http.Error(w, `{"error":"forbidden","message":"Access denied. This scenario run has no jobs"}`, http.StatusForbidden)
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunNoJobs, nil)
 		return false
 	}
 
@@ -151,13 +148,13 @@ func (h *Handler) checkScenarioRunAccessWithAction(
 		log.FromContext(ctx).Error(err, "Failed to check scenario run access",
 			"userID", claims.UserID,
 			"action", requiredAction)
-		http.Error(w, `{"error":"internal_error","message":"Failed to validate access"}`, http.StatusInternalServerError)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAccessCheckFailed, nil)
 		return false
 	}
 
 	if !hasAccess {
-		errorMsg := fmt.Sprintf(`{"error":"forbidden","message":"Access denied. You do not have permission to %s this scenario run"}`, actionName)
-		http.Error(w, errorMsg, http.StatusForbidden)
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunActionForbidden,
+			i18n.Params{"action": actionName})
 		return false
 	}
 
@@ -350,7 +347,7 @@ func (h *Handler) checkJobAccess(
 
 	// Defensive check
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized","message":"No authentication claims found"}`, http.StatusUnauthorized)
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
 		return false
 	}
 
@@ -361,7 +358,7 @@ func (h *Handler) checkJobAccess(
 
 	// Check if job has ClusterAPIURL
 	if job.ClusterAPIURL == "" {
-		http.Error(w, `{"error":"forbidden","message":"Access denied. Job has no cluster API URL"}`, http.StatusForbidden)
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgJobNoClusterURL, nil)
 		return false
 	}
 
@@ -380,13 +377,13 @@ func (h *Handler) checkJobAccess(
 			"userID", claims.UserID,
 			"jobID", job.JobID,
 			"action", requiredAction)
-		http.Error(w, `{"error":"internal_error","message":"Failed to validate access"}`, http.StatusInternalServerError)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAccessCheckFailed, nil)
 		return false
 	}
 
 	if !hasAccess {
-		errorMsg := fmt.Sprintf(`{"error":"forbidden","message":"Access denied. You do not have permission to %s this job"}`, actionName)
-		http.Error(w, errorMsg, http.StatusForbidden)
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgJobActionForbidden,
+			i18n.Params{"action": actionName})
 		return false
 	}
 
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// Audit actions of the break-glass exemptions, next to the create/update/delete ones
+// of auditMiddleware
+const (
+	auditActionBreakGlassIssue = "break-glass-issue"
+	auditActionBreakGlassUse   = "break-glass-use"
+)
+
+// IssueBreakGlass handles POST /api/v1/break-glass endpoint (admin only)
+// Signs a time-boxed exemption letting a restricted scenario run on the given clusters.
+// The exemption is sent in the X-Krkn-Break-Glass header of the scenario run request.
+func (h *Handler) IssueBreakGlass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	var req BreakGlassRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	switch {
+	case req.ScenarioName == "":
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "scenarioName"})
+		return
+	case len(req.TargetClusters) == 0:
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "targetClusters"})
+		return
+	case req.Reason == "":
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "reason"})
+		return
+	}
+	for _, cluster := range req.TargetClusters {
+		if cluster == "" {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgClusterNameEmpty, nil)
+			return
+		}
+	}
+
+	maxSeconds := int(auth.MaxBreakGlassDuration / time.Second)
+	if req.DurationSeconds < 0 || req.DurationSeconds > maxSeconds {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgBreakGlassDurationInvalid,
+			i18n.Params{"max": strconv.Itoa(maxSeconds)})
+		return
+	}
+
+	// An exemption for a scenario that runs anyway would only hide a typo
+	if !h.scenarioPolicy.IsRestricted(req.ScenarioName, "") {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgBreakGlassNotRestricted,
+			i18n.Params{"scenario": req.ScenarioName})
+		return
+	}
+
+	tokenGen, err := h.getTokenGenerator(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get token generator")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgBreakGlassIssueFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	issuedBy := ""
+	if claims := auth.GetClaimsFromContext(ctx); claims != nil {
+		issuedBy = claims.UserID
+	}
+	token, exemption, err := tokenGen.GenerateBreakGlassToken(issuedBy, auth.BreakGlassGrant{
+		Scenario: req.ScenarioName,
+		Clusters: req.TargetClusters,
+		Grantee:  req.UserID,
+		Reason:   req.Reason,
+		Duration: time.Duration(req.DurationSeconds) * time.Second,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to issue break-glass exemption")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgBreakGlassIssueFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	logger.Info("Issued break-glass exemption",
+		"exemption", exemption.ID,
+		"scenarioName", exemption.Scenario,
+		"clusters", exemption.Clusters,
+		"grantee", exemption.Grantee,
+		"expiresAt", exemption.ExpiresAt.Time)
+	h.recordBreakGlass(r, auditActionBreakGlassIssue, r.URL.Path, http.StatusCreated, exemption)
+
+	writeJSON(w, http.StatusCreated, BreakGlassResponse{
+		ID:             exemption.ID,
+		Token:          token,
+		ScenarioName:   exemption.Scenario,
+		TargetClusters: exemption.Clusters,
+		UserID:         exemption.Grantee,
+		ExpiresAt:      exemption.ExpiresAt.Time,
+	})
+}
+
+// checkBreakGlass lets a run of a restricted scenario through only under a break-glass
+// exemption covering its clusters, and writes the error response when not. It returns
+// the exemption of the request, nil when the scenario is not restricted. The scenario
+// image counts as well: running a restricted image under another name needs the
+// exemption of the image's scenario.
+//
+// The clusters of target groups and cluster IDs are resolved again when the run starts,
+// so restricted runs must list them explicitly for the exemption to keep covering them.
+func (h *Handler) checkBreakGlass(w http.ResponseWriter, r *http.Request, req *ScenarioRunRequest, clusters map[string][]string) (*auth.BreakGlassClaims, bool) {
+	restricted := h.scenarioPolicy.Restricted(req.ScenarioName, req.ScenarioImage)
+	if len(restricted) == 0 {
+		return nil, true
+	}
+	if req.TargetGroupRef != "" || len(req.TargetClusterIDs) > 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgBreakGlassStaticTargets, nil)
+		return nil, false
+	}
+
+	exemption := auth.GetBreakGlassFromContext(r.Context())
+	if exemption == nil {
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgScenarioRestricted,
+			i18n.Params{"scenario": strings.Join(restricted, ", ")})
+		return nil, false
+	}
+	for _, scenario := range restricted {
+		if err := exemption.Covers(scenario, clusters); err != nil {
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgBreakGlassMismatch,
+				i18n.Params{"error": err.Error()})
+			return nil, false
+		}
+	}
+	return exemption, true
+}
+
+// createBreakGlassRecord stores the exemption token of the run scenarioRunName in the
+// record Secret the validating webhook checks, before the run is created. The run only
+// carries the exemption ID, so the token cannot be copied from it into other runs.
+func (h *Handler) createBreakGlassRecord(ctx context.Context, scenarioRunName, token string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      auth.BreakGlassRecordName(scenarioRunName),
+			Namespace: h.namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{auth.BreakGlassRecordKey: []byte(token)},
+	}
+	if err := h.client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create break-glass record: %w", err)
+	}
+	return secret, nil
+}
+
+// recordBreakGlass records the issue or use of an exemption on resource in the audit log
+func (h *Handler) recordBreakGlass(r *http.Request, action, resource string, statusCode int, exemption *auth.BreakGlassClaims) {
+	if h.auditor == nil {
+		return
+	}
+	record := audit.Record{
+		User:       "anonymous",
+		Action:     action,
+		Resource:   resource,
+		Result:     audit.ResultForStatus(statusCode),
+		StatusCode: statusCode,
+		ClientIP:   r.RemoteAddr,
+		Detail: fmt.Sprintf("exemption=%s scenario=%s expiresAt=%s reason=%q",
+			exemption.ID, exemption.Scenario, exemption.ExpiresAt.Time.Format(time.RFC3339), exemption.Reason),
+	}
+	if claims := auth.GetClaimsFromContext(r.Context()); claims != nil {
+		record.User = claims.UserID
+		record.Role = claims.Role
+	}
+	h.auditor.Record(r.Context(), record)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+func TestIssueBreakGlass(t *testing.T) {
+	handler := setupTestHandler()
+	handler.scenarioPolicy = auth.ScenarioPolicy{RestrictedScenarios: []string{"node-scenarios"}}
+	handler.auditor = audit.NewRecorder(10)
+
+	tests := []struct {
+		name       string
+		body       string
+		admin      bool
+		wantStatus int
+	}{
+		{
+			name:       "issued",
+			body:       `{"scenarioName":"node-scenarios","targetClusters":["prod-1"],"userId":"user@example.com","reason":"failover drill","durationSeconds":600}`,
+			admin:      true,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "not an admin",
+			body:       `{"scenarioName":"node-scenarios","targetClusters":["prod-1"],"reason":"failover drill"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "scenario not restricted",
+			body:       `{"scenarioName":"pod-scenarios","targetClusters":["prod-1"],"reason":"failover drill"}`,
+			admin:      true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no reason",
+			body:       `{"scenarioName":"node-scenarios","targetClusters":["prod-1"]}`,
+			admin:      true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no cluster",
+			body:       `{"scenarioName":"node-scenarios","targetClusters":[],"reason":"failover drill"}`,
+			admin:      true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "duration too long",
+			body:       `{"scenarioName":"node-scenarios","targetClusters":["prod-1"],"reason":"failover drill","durationSeconds":86401}`,
+			admin:      true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, BreakGlassPath, strings.NewReader(tt.body))
+			if tt.admin {
+				req = withAdminClaims(req)
+			} else {
+				req = req.WithContext(context.WithValue(req.Context(), auth.UserClaimsKey, &auth.Claims{
+					UserID: "user@example.com",
+					Role:   "user",
+				}))
+			}
+			w := httptest.NewRecorder()
+			serveAPI(handler, w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var response BreakGlassResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			tokenGen, err := handler.getTokenGenerator(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to get token generator: %v", err)
+			}
+			exemption, err := tokenGen.ValidateBreakGlassToken(response.Token)
+			if err != nil {
+				t.Fatalf("Expected a valid exemption: %v", err)
+			}
+			if exemption.ID != response.ID || exemption.Subject != "admin@example.com" || exemption.Grantee != "user@example.com" ||
+				exemption.Reason != "failover drill" {
+				t.Errorf("Unexpected exemption %+v", exemption)
+			}
+
+			records := handler.auditor.Recent(audit.Filter{Action: auditActionBreakGlassIssue})
+			if len(records) != 1 || !strings.Contains(records[0].Detail, response.ID) {
+				t.Errorf("Expected the issue to be audited with the exemption ID, got %+v", records)
+			}
+		})
+	}
+}
+
+func TestPostScenarioRun_BreakGlass(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster":  "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+		"other-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	handler.scenarioPolicy = auth.ScenarioPolicy{RestrictedScenarios: []string{"node-*"}}
+	handler.auditor = audit.NewRecorder(10)
+
+	tokenGen, err := handler.getTokenGenerator(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get token generator: %v", err)
+	}
+	token, exemption, err := tokenGen.GenerateBreakGlassToken("admin@example.com", auth.BreakGlassGrant{
+		Scenario: "node-scenarios",
+		Clusters: []string{"test-cluster"},
+		Reason:   "failover drill",
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate exemption: %v", err)
+	}
+
+	post := func(scenarioName, cluster string, withExemption bool) *httptest.ResponseRecorder {
+		reqBody := `{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["` + cluster + `"]},
+			"scenarioImage": "quay.io/krkn-chaos/krkn-hub:` + scenarioName + `",
+			"scenarioName": "` + scenarioName + `"
+		}`
+		req := withAdminClaims(httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(reqBody)))
+		req.Header.Set("Content-Type", "application/json")
+		if withExemption {
+			// As set by the auth middleware once the header is verified
+			req.Header.Set(auth.BreakGlassHeader, token)
+			req = req.WithContext(context.WithValue(req.Context(), auth.BreakGlassKey, exemption))
+		}
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	if w := post("pod-delete", "test-cluster", false); w.Code != http.StatusCreated {
+		t.Errorf("Expected unrestricted scenarios to run, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if w := post("node-scenarios", "test-cluster", false); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d without exemption, got %d", http.StatusForbidden, w.Code)
+	}
+	if w := post("node-scenarios", "other-cluster", true); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for an uncovered cluster, got %d", http.StatusForbidden, w.Code)
+	}
+	if w := post("node-cpu-hog", "test-cluster", true); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for another restricted scenario, got %d", http.StatusForbidden, w.Code)
+	}
+
+	// A restricted image run under another name
+	reqBody := `{
+		"targetRequestID": "test-request-id",
+		"targetClusters": {"krkn-operator": ["test-cluster"]},
+		"scenarioImage": "quay.io/krkn-chaos/krkn-hub:node-cpu-hog",
+		"scenarioName": "pod-delete"
+	}`
+	req := withAdminClaims(httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(reqBody)))
+	w := httptest.NewRecorder()
+	handler.PostScenarioRun(w, req)
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "node-cpu-hog") {
+		t.Errorf("Expected a renamed restricted image to be rejected, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = post("node-scenarios", "test-cluster", true)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	// The run carries the exemption ID, the token stays in the record Secret it owns
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(context.Background(), client.ObjectKey{
+		Name: response.ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if scenarioRun.Annotations[auth.BreakGlassAnnotation] != exemption.ID {
+		t.Errorf("Expected the exemption ID to be recorded on the run, got %q", scenarioRun.Annotations[auth.BreakGlassAnnotation])
+	}
+	var record corev1.Secret
+	if err := handler.client.Get(context.Background(), client.ObjectKey{
+		Name: auth.BreakGlassRecordName(response.ScenarioRunName), Namespace: handler.namespace,
+	}, &record); err != nil {
+		t.Fatalf("Failed to get break-glass record: %v", err)
+	}
+	if string(record.Data[auth.BreakGlassRecordKey]) != token {
+		t.Error("Expected the exemption token in the break-glass record")
+	}
+	if len(record.OwnerReferences) != 1 || record.OwnerReferences[0].Name != response.ScenarioRunName {
+		t.Errorf("Expected the record to be owned by the run, got %+v", record.OwnerReferences)
+	}
+	records := handler.auditor.Recent(audit.Filter{Action: auditActionBreakGlassUse})
+	if len(records) != 1 || records[0].Resource != ScenariosRunPath+"/"+response.ScenarioRunName ||
+		!strings.Contains(records[0].Detail, exemption.ID) {
+		t.Errorf("Expected the use to be audited with the exemption ID, got %+v", records)
+	}
+}
+
+func TestCheckBreakGlass_StaticTargets(t *testing.T) {
+	handler := &Handler{scenarioPolicy: auth.ScenarioPolicy{RestrictedScenarios: []string{"node-scenarios"}}}
+
+	for _, req := range []ScenarioRunRequest{
+		{ScenarioName: "node-scenarios", TargetGroupRef: "prod"},
+		{ScenarioName: "node-scenarios", TargetClusterIDs: []string{"id-1"}},
+	} {
+		w := httptest.NewRecorder()
+		if _, ok := handler.checkBreakGlass(w, httptest.NewRequest(http.MethodPost, ScenariosRunPath, nil), &req, nil); ok {
+			t.Fatal("Expected the run to be rejected")
+		}
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), MsgBreakGlassStaticTargets) {
+			t.Errorf("Expected %s, got %d %s", MsgBreakGlassStaticTargets, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krkncampaigns,verbs=get;list;watch;create;update;patch;delete
+
+// ListCampaigns handles GET /api/v1/campaigns
+// Lists the campaigns with the progress of the runs the user can view
+func (h *Handler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx).WithName("list-campaigns")
+
+	var campaigns krknv1alpha1.KrknCampaignList
+	if err := h.client.List(ctx, &campaigns, client.InNamespace(h.namespace)); err != nil {
+		logger.Error(err, "Failed to list campaigns")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignListFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	runs, err := h.campaignRuns(r, "")
+	if err != nil {
+		logger.Error(err, "Failed to list scenario runs")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignListFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	responses := make([]CampaignResponse, 0, len(campaigns.Items))
+	for i := range campaigns.Items {
+		campaign := &campaigns.Items[i]
+		responses = append(responses, buildCampaignResponse(campaign, runs[campaign.Name]))
+	}
+	slices.SortFunc(responses, func(a, b CampaignResponse) int { return strings.Compare(a.Name, b.Name) })
+
+	writeJSON(w, http.StatusOK, ListCampaignsResponse{
+		Campaigns: responses,
+		Total:     len(responses),
+	})
+}
+
+// GetCampaign handles GET /api/v1/campaigns/{campaignName}
+// Returns a campaign with the progress of the runs the user can view
+func (h *Handler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := h.fetchCampaign(w, r)
+	if !ok {
+		return
+	}
+	runs, err := h.campaignRuns(r, campaign.Name)
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "Failed to list scenario runs")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, buildCampaignResponse(campaign, runs[campaign.Name]))
+}
+
+// CreateCampaign handles POST /api/v1/campaigns
+// Creates a campaign, facilitated by the calling user
+func (h *Handler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx).WithName("create-campaign")
+
+	var req CreateCampaignRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	if req.Name == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "name"})
+		return
+	}
+	if errs := validation.IsDNS1123Subdomain(req.Name); len(errs) > 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgCampaignNameInvalid,
+			i18n.Params{"name": req.Name, "error": strings.Join(errs, ", ")})
+		return
+	}
+	if req.Window != nil && !req.Window.End.After(req.Window.Start) {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgCampaignWindowInvalid, nil)
+		return
+	}
+
+	facilitator := ""
+	if claims := auth.GetClaimsFromContext(ctx); claims != nil {
+		facilitator = claims.UserID
+	}
+	campaign := &krknv1alpha1.KrknCampaign{
+		ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: h.namespace},
+		Spec: krknv1alpha1.KrknCampaignSpec{
+			DisplayName:  req.DisplayName,
+			Description:  req.Description,
+			Window:       campaignWindowSpec(req.Window),
+			Facilitator:  facilitator,
+			Participants: compactParticipants(req.Participants),
+		},
+	}
+	if err := h.client.Create(ctx, campaign); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgCampaignExists, i18n.Params{"name": req.Name})
+			return
+		}
+		logger.Error(err, "Failed to create campaign", "campaign", req.Name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignCreateFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	logger.Info("Created campaign", "campaign", campaign.Name, "facilitator", facilitator)
+	writeJSON(w, http.StatusCreated, buildCampaignResponse(campaign, nil))
+}
+
+// UpdateCampaign handles PATCH /api/v1/campaigns/{campaignName}
+// Updates the fields of a campaign present in the request (facilitator or admin only)
+func (h *Handler) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx).WithName("update-campaign")
+
+	campaign, ok := h.fetchCampaign(w, r)
+	if !ok || !authorizeCampaignChange(w, r, campaign) {
+		return
+	}
+
+	var req UpdateCampaignRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	if req.Window != nil && !req.Window.End.After(req.Window.Start) {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgCampaignWindowInvalid, nil)
+		return
+	}
+
+	if req.DisplayName != nil {
+		campaign.Spec.DisplayName = *req.DisplayName
+	}
+	if req.Description != nil {
+		campaign.Spec.Description = *req.Description
+	}
+	if req.Window != nil {
+		campaign.Spec.Window = campaignWindowSpec(req.Window)
+	}
+	if req.Participants != nil {
+		campaign.Spec.Participants = compactParticipants(*req.Participants)
+	}
+	if err := h.client.Update(ctx, campaign); err != nil {
+		logger.Error(err, "Failed to update campaign", "campaign", campaign.Name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignUpdateFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	runs, err := h.campaignRuns(r, campaign.Name)
+	if err != nil {
+		logger.Error(err, "Failed to list scenario runs")
+	}
+	logger.Info("Updated campaign", "campaign", campaign.Name)
+	writeJSON(w, http.StatusOK, buildCampaignResponse(campaign, runs[campaign.Name]))
+}
+
+// DeleteCampaign handles DELETE /api/v1/campaigns/{campaignName}
+// Deletes a campaign (facilitator or admin only). Its runs are kept and keep referencing it.
+func (h *Handler) DeleteCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx).WithName("delete-campaign")
+
+	campaign, ok := h.fetchCampaign(w, r)
+	if !ok || !authorizeCampaignChange(w, r, campaign) {
+		return
+	}
+	if err := h.client.Delete(ctx, campaign); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to delete campaign", "campaign", campaign.Name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignDeleteFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	logger.Info("Deleted campaign", "campaign", campaign.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCampaignReport handles GET /api/v1/campaigns/{campaignName}/report
+// Rolls up the runs of a campaign the user can view into a game day report
+func (h *Handler) GetCampaignReport(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := h.fetchCampaign(w, r)
+	if !ok {
+		return
+	}
+	runs, err := h.campaignRuns(r, campaign.Name)
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "Failed to list scenario runs")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, buildCampaignReport(campaign, runs[campaign.Name], time.Now().UTC()))
+}
+
+// fetchCampaign returns the campaign of the path, or writes the error
+func (h *Handler) fetchCampaign(w http.ResponseWriter, r *http.Request) (*krknv1alpha1.KrknCampaign, bool) {
+	name, err := pathParam(r, ParamCampaignName)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": ParamCampaignName})
+		return nil, false
+	}
+	campaign := &krknv1alpha1.KrknCampaign{}
+	if err := h.client.Get(r.Context(), client.ObjectKey{Name: name, Namespace: h.namespace}, campaign); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgCampaignNotFound, i18n.Params{"name": name})
+			return nil, false
+		}
+		log.FromContext(r.Context()).Error(err, "Failed to get campaign", "campaign", name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return nil, false
+	}
+	return campaign, true
+}
+
+// authorizeCampaignChange writes 403 unless the user is an admin or the facilitator of campaign
+func authorizeCampaignChange(w http.ResponseWriter, r *http.Request, campaign *krknv1alpha1.KrknCampaign) bool {
+	if auth.IsAdmin(r.Context()) {
+		return true
+	}
+	if claims := auth.GetClaimsFromContext(r.Context()); claims != nil && campaign.Spec.Facilitator != "" &&
+		claims.UserID == campaign.Spec.Facilitator {
+		return true
+	}
+	writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgCampaignForbidden, i18n.Params{"name": campaign.Name})
+	return false
+}
+
+// campaignRuns returns the runs the user can view by campaign, of campaign only when set
+func (h *Handler) campaignRuns(r *http.Request, campaign string) (map[string][]krknv1alpha1.KrknScenarioRun, error) {
+	var runList krknv1alpha1.KrknScenarioRunList
+	if err := h.client.List(r.Context(), &runList, client.InNamespace(h.namespace)); err != nil {
+		return nil, err
+	}
+	runs := map[string][]krknv1alpha1.KrknScenarioRun{}
+	for _, run := range h.filterScenarioRunsByGroupPermission(runList.Items, r.Context()) {
+		ref := run.Spec.CampaignRef
+		if ref == "" || (campaign != "" && ref != campaign) {
+			continue
+		}
+		runs[ref] = append(runs[ref], run)
+	}
+	return runs, nil
+}
+
+// campaignWindowSpec converts a request window to the KrknCampaign window
+func campaignWindowSpec(window *CampaignWindow) *krknv1alpha1.CampaignWindow {
+	if window == nil {
+		return nil
+	}
+	return &krknv1alpha1.CampaignWindow{
+		Start: metav1.NewTime(window.Start.UTC()),
+		End:   metav1.NewTime(window.End.UTC()),
+	}
+}
+
+// compactParticipants returns the participants sorted, without blanks and duplicates
+func compactParticipants(participants []string) []string {
+	compacted := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		if participant = strings.TrimSpace(participant); participant != "" {
+			compacted = append(compacted, participant)
+		}
+	}
+	slices.Sort(compacted)
+	return slices.Compact(compacted)
+}
+
+func buildCampaignResponse(campaign *krknv1alpha1.KrknCampaign, runs []krknv1alpha1.KrknScenarioRun) CampaignResponse {
+	response := CampaignResponse{
+		Name:         campaign.Name,
+		DisplayName:  campaign.Spec.DisplayName,
+		Description:  campaign.Spec.Description,
+		Facilitator:  campaign.Spec.Facilitator,
+		Participants: campaign.Spec.Participants,
+		CreatedAt:    campaign.CreationTimestamp.Time,
+		Progress:     campaignProgress(runs),
+	}
+	if response.Participants == nil {
+		response.Participants = []string{}
+	}
+	if window := campaign.Spec.Window; window != nil {
+		response.Window = &CampaignWindow{Start: window.Start.Time, End: window.End.Time}
+	}
+	return response
+}
+
+// campaignProgress aggregates the phases and job counts of runs
+func campaignProgress(runs []krknv1alpha1.KrknScenarioRun) CampaignProgress {
+	progress := CampaignProgress{TotalRuns: len(runs)}
+	finished := 0
+	for _, run := range runs {
+		switch run.Status.Phase {
+		case "Running":
+			progress.RunningRuns++
+		case "Succeeded":
+			progress.SucceededRuns++
+			finished++
+		case "PartiallyFailed":
+			progress.PartiallyFailedRuns++
+			finished++
+		case "Failed":
+			progress.FailedRuns++
+			finished++
+		default:
+			progress.PendingRuns++
+		}
+		progress.TotalTargets += run.Status.TotalTargets
+		progress.SuccessfulJobs += run.Status.SuccessfulJobs
+		progress.FailedJobs += run.Status.FailedJobs
+		progress.RunningJobs += run.Status.RunningJobs
+	}
+	if len(runs) > 0 {
+		progress.PercentComplete = finished * 100 / len(runs)
+	}
+	return progress
+}
+
+// buildCampaignReport rolls up the runs of a campaign, generated at now
+func buildCampaignReport(campaign *krknv1alpha1.KrknCampaign, runs []krknv1alpha1.KrknScenarioRun, now time.Time) CampaignReportResponse {
+	report := CampaignReportResponse{
+		CampaignResponse: buildCampaignResponse(campaign, runs),
+		RunsByOwner:      map[string]int{},
+		Runs:             make([]CampaignReportRun, 0, len(runs)),
+		GeneratedAt:      now,
+	}
+
+	allFinished := len(runs) > 0
+	for i := range runs {
+		run := &runs[i]
+		item := CampaignReportRun{
+			ScenarioRunListItem: ScenarioRunListItem{
+				ScenarioRunName: run.Name,
+				ScenarioName:    run.Spec.ScenarioName,
+				RunNumber:       run.Spec.RunNumber,
+				DisplayName:     run.Spec.DisplayName,
+				Phase:           run.Status.Phase,
+				TotalTargets:    run.Status.TotalTargets,
+				SuccessfulJobs:  run.Status.SuccessfulJobs,
+				FailedJobs:      run.Status.FailedJobs,
+				RunningJobs:     run.Status.RunningJobs,
+				CreatedAt:       run.CreationTimestamp.Time,
+				StartedAt:       scenarioRunStartTime(run),
+				OwnerUserID:     run.Spec.OwnerUserID,
+				Campaign:        run.Spec.CampaignRef,
+			},
+		}
+		if window := campaign.Spec.Window; window != nil {
+			item.OutsideWindow = !window.Contains(run.CreationTimestamp.Time)
+		}
+		for _, job := range run.Status.ClusterJobs {
+			if job.Phase == "Failed" || job.Phase == "MaxRetriesExceeded" {
+				item.FailedClusters = append(item.FailedClusters, job.ClusterName)
+			}
+		}
+		slices.Sort(item.FailedClusters)
+		item.FailedClusters = slices.Compact(item.FailedClusters)
+
+		if run.Status.CompletionTime != nil {
+			completedAt := run.Status.CompletionTime.Time
+			item.CompletedAt = &completedAt
+			if report.CompletedAt == nil || completedAt.After(*report.CompletedAt) {
+				report.CompletedAt = &completedAt
+			}
+		} else {
+			allFinished = false
+		}
+		if item.StartedAt != nil && (report.StartedAt == nil || item.StartedAt.Before(*report.StartedAt)) {
+			report.StartedAt = item.StartedAt
+		}
+		if run.Spec.OwnerUserID != "" {
+			report.RunsByOwner[run.Spec.OwnerUserID]++
+		}
+		report.Runs = append(report.Runs, item)
+	}
+	if !allFinished {
+		report.CompletedAt = nil
+	}
+
+	slices.SortFunc(report.Runs, func(a, b CampaignReportRun) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ScenarioRunName, b.ScenarioRunName)
+	})
+	return report
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+// campaignRequest builds a request on a campaign as userID, an admin when admin is set
+func campaignRequest(method, name, userID string, admin bool, body any) *http.Request {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	path := CampaignsPath
+	if name != "" {
+		path += "/" + name
+	}
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+	role := "user"
+	if admin {
+		role = "admin"
+	}
+	ctx := context.WithValue(req.Context(), auth.UserClaimsKey, &auth.Claims{UserID: userID, Role: role})
+	if name != "" {
+		routeContext := chi.NewRouteContext()
+		routeContext.URLParams.Add(ParamCampaignName, name)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, routeContext)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCampaign_FacilitatorManagesCampaign(t *testing.T) {
+	handler := setupTestHandler()
+	start := time.Date(2025, 9, 18, 9, 0, 0, 0, time.UTC)
+
+	w := httptest.NewRecorder()
+	handler.CreateCampaign(w, campaignRequest(http.MethodPost, "", "alice@example.com", false, CreateCampaignRequest{
+		Name:         "q3-game-day",
+		DisplayName:  "Q3 game day",
+		Window:       &CampaignWindow{Start: start, End: start.Add(8 * time.Hour)},
+		Participants: []string{"bob@example.com", "alice@example.com", "bob@example.com"},
+	}))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created CampaignResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if created.Facilitator != "alice@example.com" ||
+		!slices.Equal(created.Participants, []string{"alice@example.com", "bob@example.com"}) {
+		t.Errorf("Unexpected campaign %+v", created)
+	}
+
+	w = httptest.NewRecorder()
+	handler.CreateCampaign(w, campaignRequest(http.MethodPost, "", "bob@example.com", false, CreateCampaignRequest{
+		Name:   "bad-window",
+		Window: &CampaignWindow{Start: start, End: start},
+	}))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an empty window, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Only the facilitator and the admins change the campaign
+	description := "Zone failure drill"
+	update := UpdateCampaignRequest{Description: &description}
+	w = httptest.NewRecorder()
+	handler.UpdateCampaign(w, campaignRequest(http.MethodPatch, "q3-game-day", "bob@example.com", false, update))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a participant, got %d", http.StatusForbidden, w.Code)
+	}
+	w = httptest.NewRecorder()
+	handler.UpdateCampaign(w, campaignRequest(http.MethodPatch, "q3-game-day", "alice@example.com", false, update))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var updated CampaignResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if updated.Description != description || updated.DisplayName != "Q3 game day" || updated.Window == nil {
+		t.Errorf("Expected only the description to change, got %+v", updated)
+	}
+
+	w = httptest.NewRecorder()
+	handler.DeleteCampaign(w, campaignRequest(http.MethodDelete, "q3-game-day", "admin@example.com", true, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	w = httptest.NewRecorder()
+	handler.GetCampaign(w, campaignRequest(http.MethodGet, "q3-game-day", "alice@example.com", false, nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after deletion, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetCampaignReport_RollsUpRuns(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+	start := time.Date(2025, 9, 18, 9, 0, 0, 0, time.UTC)
+
+	campaign := &krknv1alpha1.KrknCampaign{
+		ObjectMeta: metav1.ObjectMeta{Name: "q3-game-day", Namespace: handler.namespace},
+		Spec: krknv1alpha1.KrknCampaignSpec{
+			Window: &krknv1alpha1.CampaignWindow{Start: metav1.NewTime(start), End: metav1.NewTime(start.Add(8 * time.Hour))},
+		},
+	}
+	if err := handler.client.Create(ctx, campaign); err != nil {
+		t.Fatalf("Failed to create campaign: %v", err)
+	}
+
+	runs := []struct {
+		name, owner, phase string
+		created            time.Time
+		jobs               []krknv1alpha1.ClusterJobStatus
+		completed          bool
+	}{
+		{"pods-run", "alice@example.com", "Succeeded", start.Add(time.Hour), []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "cluster1", Phase: "Succeeded", StartTime: &metav1.Time{Time: start.Add(time.Hour)}},
+		}, true},
+		{"nodes-run", "bob@example.com", "PartiallyFailed", start.Add(2 * time.Hour), []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "cluster1", Phase: "Succeeded"},
+			{ClusterName: "cluster2", Phase: "MaxRetriesExceeded"},
+		}, true},
+		{"zone-run", "alice@example.com", "Running", start.Add(9 * time.Hour), []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "cluster2", Phase: "Running"},
+		}, false},
+	}
+	for _, run := range runs {
+		scenarioRun := &krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              run.name,
+				Namespace:         handler.namespace,
+				CreationTimestamp: metav1.NewTime(run.created),
+			},
+			Spec: krknv1alpha1.KrknScenarioRunSpec{OwnerUserID: run.owner, CampaignRef: campaign.Name},
+			Status: krknv1alpha1.KrknScenarioRunStatus{
+				Phase:        run.phase,
+				TotalTargets: len(run.jobs),
+				ClusterJobs:  run.jobs,
+			},
+		}
+		if run.completed {
+			scenarioRun.Status.CompletionTime = &metav1.Time{Time: run.created.Add(30 * time.Minute)}
+		}
+		if err := handler.client.Create(ctx, scenarioRun); err != nil {
+			t.Fatalf("Failed to create run: %v", err)
+		}
+	}
+	// A run outside of the campaign is not part of the report
+	other := &krknv1alpha1.KrknScenarioRun{ObjectMeta: metav1.ObjectMeta{Name: "other-run", Namespace: handler.namespace}}
+	if err := handler.client.Create(ctx, other); err != nil {
+		t.Fatalf("Failed to create run: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetCampaignReport(w, campaignRequest(http.MethodGet, "q3-game-day", "admin@example.com", true, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var report CampaignReportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	progress := report.Progress
+	if progress.TotalRuns != 3 || progress.SucceededRuns != 1 || progress.PartiallyFailedRuns != 1 ||
+		progress.RunningRuns != 1 || progress.PercentComplete != 66 || progress.TotalTargets != 4 {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+	names := make([]string, 0, len(report.Runs))
+	for _, run := range report.Runs {
+		names = append(names, run.ScenarioRunName)
+	}
+	if !slices.Equal(names, []string{"pods-run", "nodes-run", "zone-run"}) {
+		t.Fatalf("Expected the campaign runs oldest first, got %v", names)
+	}
+	if !slices.Equal(report.Runs[1].FailedClusters, []string{"cluster2"}) {
+		t.Errorf("Expected the failed cluster, got %v", report.Runs[1].FailedClusters)
+	}
+	if report.Runs[0].OutsideWindow || !report.Runs[2].OutsideWindow {
+		t.Errorf("Expected only the last run outside of the window, got %+v", report.Runs)
+	}
+	if report.RunsByOwner["alice@example.com"] != 2 || report.RunsByOwner["bob@example.com"] != 1 {
+		t.Errorf("Unexpected runs by owner %v", report.RunsByOwner)
+	}
+	if report.StartedAt == nil || !report.StartedAt.Equal(start.Add(time.Hour)) || report.CompletedAt != nil {
+		t.Errorf("Expected the campaign to be in progress since the first job, got %v - %v", report.StartedAt, report.CompletedAt)
+	}
+}
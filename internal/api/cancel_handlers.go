@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// errClusterJobFinished is returned when the job of the cluster to cancel already ended
+var errClusterJobFinished = errors.New("cluster job already finished")
+
+// errClusterNotInRun is returned when the cluster to cancel is no target of the run
+var errClusterNotInRun = errors.New("cluster not in run")
+
+// scenarioRunActive reports whether a run still has jobs to run or to wait for
+func scenarioRunActive(scenarioRun *krknv1alpha1.KrknScenarioRun) bool {
+	switch scenarioRun.Status.Phase {
+	case "", "Pending", "Running":
+		return true
+	}
+	return false
+}
+
+// cancelScenarioRun sets spec.cancelRequested on an active run. The controller then deletes
+// the pods of its running jobs with a grace period and marks every unfinished job Cancelled.
+// It returns the clusters whose jobs are cancelled, those of finished jobs excluded.
+func (h *Handler) cancelScenarioRun(ctx context.Context, key client.ObjectKey) ([]string, error) {
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+			return err
+		}
+		if scenarioRun.Spec.CancelRequested {
+			return nil
+		}
+		scenarioRun.Spec.CancelRequested = true
+		return h.client.Update(ctx, &scenarioRun)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	finished := make(map[string]bool)
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		if statemachine.Jobs.IsTerminal(job.Phase) {
+			finished[job.ClusterName] = true
+			if job.SubstitutedFrom != "" {
+				finished[job.SubstitutedFrom] = true
+			}
+		}
+	}
+	clusters := make([]string, 0)
+	for _, clusterNames := range scenarioRun.Targets() {
+		for _, clusterName := range clusterNames {
+			if !finished[clusterName] {
+				clusters = append(clusters, clusterName)
+			}
+		}
+	}
+	return clusters, nil
+}
+
+// CancelClusterJob handles DELETE /api/v1/scenarios/run/{scenarioRunName}/clusters/{clusterName}
+// It requests the cancellation of the job of one cluster of a run. The controller deletes
+// its pods with a grace period and marks it Cancelled without retrying it; a cluster whose
+// job was not created yet is never started.
+func (h *Handler) CancelClusterJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	clusterName, err := pathParam(r, ParamClusterName)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "clusterName"})
+		return
+	}
+
+	key := client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+		h.writeScenarioRunFetchError(w, r, string(scenarioRunName), err)
+		return
+	}
+	job, ok := clusterJobForCancel(&scenarioRun, clusterName)
+	if !ok {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgCancelClusterNotInRun,
+			i18n.Params{"cluster": clusterName, "name": string(scenarioRunName)})
+		return
+	}
+	if !h.checkJobAccess(w, r, job, groupauth.ActionCancel, "cancel") {
+		return
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+			return err
+		}
+		if job, ok = clusterJobForCancel(&scenarioRun, clusterName); !ok {
+			return errClusterNotInRun
+		}
+		if statemachine.Jobs.IsTerminal(job.Phase) {
+			return errClusterJobFinished
+		}
+		if job.Phase == "" {
+			// Not created yet: the controller cancels the entry instead of creating its job
+			job.Phase = statemachine.Jobs.Initial()
+			job.CancelRequested = true
+			scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, *job)
+		} else {
+			job.CancelRequested = true
+		}
+		return h.client.Status().Update(ctx, &scenarioRun)
+	})
+	if errors.Is(err, errClusterNotInRun) {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgCancelClusterNotInRun,
+			i18n.Params{"cluster": clusterName, "name": string(scenarioRunName)})
+		return
+	}
+	if errors.Is(err, errClusterJobFinished) {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgJobAlreadyFinished,
+			i18n.Params{"cluster": clusterName, "phase": job.Phase})
+		return
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to cancel cluster job", "scenarioRunName", scenarioRunName, "clusterName", clusterName)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgRunCancelFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	log.FromContext(ctx).Info("requested cluster job cancellation",
+		"scenarioRunName", scenarioRunName,
+		"clusterName", clusterName,
+		"jobID", job.JobID,
+		"phase", job.Phase)
+
+	writeJSON(w, http.StatusAccepted, clusterJobStatusResponse(job))
+}
+
+// clusterJobForCancel returns the job of a target cluster of the run. A target cluster
+// without job yet gets a new entry with an empty phase, not stored in the run.
+// ok is false when the cluster is not a target of the run.
+func clusterJobForCancel(scenarioRun *krknv1alpha1.KrknScenarioRun, clusterName string) (*krknv1alpha1.ClusterJobStatus, bool) {
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if job.ClusterName == clusterName || job.SubstitutedFrom == clusterName {
+			return job, true
+		}
+	}
+	for providerName, clusterNames := range scenarioRun.Targets() {
+		for _, name := range clusterNames {
+			if name == clusterName {
+				return &krknv1alpha1.ClusterJobStatus{ProviderName: providerName, ClusterName: clusterName}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// clusterJobStatusResponse converts the status of a cluster job to its API response
+func clusterJobStatusResponse(job *krknv1alpha1.ClusterJobStatus) ClusterJobStatusResponse {
+	return ClusterJobStatusResponse{
+		ProviderName:         job.ProviderName,
+		ClusterName:          job.ClusterName,
+		ClusterID:            job.ClusterID,
+		JobID:                job.JobID,
+		PodName:              job.PodName,
+		Phase:                job.Phase,
+		Message:              job.Message,
+		StartTime:            convertMetaTime(job.StartTime),
+		CompletionTime:       convertMetaTime(job.CompletionTime),
+		RetryCount:           job.RetryCount,
+		MaxRetries:           job.MaxRetries,
+		CancelRequested:      job.CancelRequested,
+		FailureReason:        job.FailureReason,
+		Evictions:            job.Evictions,
+		MaintenanceReason:    job.MaintenanceReason,
+		Queued:               job.Queued,
+		SubstitutedFrom:      job.SubstitutedFrom,
+		Result:               job.Result,
+		Artifacts:            job.Artifacts,
+		ArtifactsUploadError: job.ArtifactsUploadError,
+		Cleanup:              job.Cleanup,
+	}
+}
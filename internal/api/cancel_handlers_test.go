@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// createActiveRun creates a running scenario run targeting the clusters of its jobs and cluster-z
+func createActiveRun(t *testing.T, handler *Handler, name string, jobs ...krknv1alpha1.ClusterJobStatus) {
+	t.Helper()
+	createCompletedRun(t, handler, name, "Running", jobs...)
+	var run krknv1alpha1.KrknScenarioRun
+	key := client.ObjectKey{Name: name, Namespace: "test-namespace"}
+	if err := handler.client.Get(context.Background(), key, &run); err != nil {
+		t.Fatalf("failed to get scenario run: %v", err)
+	}
+	clusters := []string{"cluster-z"}
+	for _, job := range jobs {
+		clusters = append(clusters, job.ClusterName)
+	}
+	run.Spec.TargetClusters = map[string][]string{"krkn-operator": clusters}
+	if err := handler.client.Update(context.Background(), &run); err != nil {
+		t.Fatalf("failed to set scenario run targets: %v", err)
+	}
+}
+
+func getRun(t *testing.T, handler *Handler, name string) krknv1alpha1.KrknScenarioRun {
+	t.Helper()
+	var run krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "test-namespace"}, &run); err != nil {
+		t.Fatalf("failed to get scenario run: %v", err)
+	}
+	return run
+}
+
+func TestDeleteScenarioRunComplete_CancelsActiveRun(t *testing.T) {
+	handler := setupRetryTestHandler()
+	createActiveRun(t, handler, "run-1",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobSucceeded},
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-b", JobID: "job-b", Phase: statemachine.JobRunning},
+	)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, ScenariosRunPath+"/run-1", nil)
+	serveAPI(handler, w, withAdminClaims(req))
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ScenarioRunCancelResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	slices.Sort(resp.CancelledClusters)
+	if !slices.Equal(resp.CancelledClusters, []string{"cluster-b", "cluster-z"}) {
+		t.Errorf("expected the unfinished clusters to be cancelled, got %v", resp.CancelledClusters)
+	}
+	if run := getRun(t, handler, "run-1"); !run.Spec.CancelRequested {
+		t.Error("expected spec.cancelRequested to be set and the run to be kept")
+	}
+
+	// A cancelled run cannot be retried
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/run-1"+ScenarioRunRetryFailedSuffix, nil)
+	serveAPI(handler, w, withAdminClaims(req))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 on the retry of a cancelled run, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDeleteScenarioRunComplete_Deletes(t *testing.T) {
+	handler := setupRetryTestHandler()
+	createCompletedRun(t, handler, "finished", "Failed",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobMaxRetriesExceeded})
+	createActiveRun(t, handler, "forced",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-b", Phase: statemachine.JobRunning})
+
+	for _, target := range []string{"/finished", "/forced?force=true"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, ScenariosRunPath+target, nil)
+		serveAPI(handler, w, withAdminClaims(req))
+		if w.Code != http.StatusNoContent {
+			t.Errorf("DELETE %s: expected 204, got %d: %s", target, w.Code, w.Body.String())
+		}
+	}
+
+	err := handler.client.Get(context.Background(), client.ObjectKey{Name: "forced", Namespace: "test-namespace"}, &krknv1alpha1.KrknScenarioRun{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the forced run to be deleted, got %v", err)
+	}
+}
+
+func TestCancelClusterJob(t *testing.T) {
+	handler := setupRetryTestHandler()
+	createActiveRun(t, handler, "run-1",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobSucceeded},
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-b", JobID: "job-b", Phase: statemachine.JobRunning},
+	)
+
+	tests := []struct {
+		name       string
+		cluster    string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "running job", cluster: "cluster-b", wantStatus: http.StatusAccepted},
+		{name: "job not created yet", cluster: "cluster-z", wantStatus: http.StatusAccepted},
+		{name: "finished job", cluster: "cluster-a", wantStatus: http.StatusConflict, wantCode: MsgJobAlreadyFinished},
+		{name: "not a target", cluster: "cluster-x", wantStatus: http.StatusNotFound, wantCode: MsgCancelClusterNotInRun},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, ScenariosRunPath+"/run-1"+ScenarioRunClustersSegment+"/"+tt.cluster, nil)
+			serveAPI(handler, w, withAdminClaims(req))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode != "" && !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("expected code %s, got %s", tt.wantCode, w.Body.String())
+			}
+		})
+	}
+
+	run := getRun(t, handler, "run-1")
+	if run.Spec.CancelRequested {
+		t.Error("expected the run itself not to be cancelled")
+	}
+	cancelled := make(map[string]bool)
+	for _, job := range run.Status.ClusterJobs {
+		cancelled[job.ClusterName] = job.CancelRequested
+	}
+	if !cancelled["cluster-b"] || !cancelled["cluster-z"] || cancelled["cluster-a"] {
+		t.Errorf("expected cluster-b and cluster-z to be flagged for cancellation, got %+v", run.Status.ClusterJobs)
+	}
+}
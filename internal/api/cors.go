@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/requestid"
+)
+
+// Environment variables configuring CORS
+const (
+	// CORSAllowedOriginsEnv is a comma-separated origin allowlist, "*" allows any origin.
+	// CORS is disabled when empty.
+	CORSAllowedOriginsEnv = "CORS_ALLOWED_ORIGINS"
+	// CORSAllowedMethodsEnv is a comma-separated list of methods allowed in preflight requests
+	CORSAllowedMethodsEnv = "CORS_ALLOWED_METHODS"
+	// CORSAllowedHeadersEnv is a comma-separated list of request headers allowed in preflight requests
+	CORSAllowedHeadersEnv = "CORS_ALLOWED_HEADERS"
+	// CORSExposedHeadersEnv is a comma-separated list of response headers browsers let the UI read
+	CORSExposedHeadersEnv = "CORS_EXPOSED_HEADERS"
+	// CORSMaxAgeEnv is how long, in seconds, browsers may cache a preflight response
+	CORSMaxAgeEnv = "CORS_MAX_AGE"
+)
+
+var (
+	defaultCORSMethods = []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}
+	defaultCORSHeaders = []string{"Authorization", "Content-Type", "Accept-Language", requestid.Header, auth.BreakGlassHeader}
+	// defaultCORSExposedHeaders are the response headers the UI reads besides the safelisted ones
+	defaultCORSExposedHeaders = []string{requestid.Header, CatalogStaleHeader, "Age", "Retry-After"}
+)
+
+const defaultCORSMaxAge = 600
+
+// CORSConfig is the cross-origin policy of the REST API
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
+	MaxAge         int
+}
+
+// CORSConfigFromEnv reads the CORS policy from the environment
+func CORSConfigFromEnv() CORSConfig {
+	cfg := CORSConfig{
+		AllowedOrigins: splitList(os.Getenv(CORSAllowedOriginsEnv)),
+		AllowedMethods: splitList(os.Getenv(CORSAllowedMethodsEnv)),
+		AllowedHeaders: splitList(os.Getenv(CORSAllowedHeadersEnv)),
+		ExposedHeaders: splitList(os.Getenv(CORSExposedHeadersEnv)),
+		MaxAge:         defaultCORSMaxAge,
+	}
+	if len(cfg.AllowedMethods) == 0 {
+		cfg.AllowedMethods = defaultCORSMethods
+	}
+	if len(cfg.AllowedHeaders) == 0 {
+		cfg.AllowedHeaders = defaultCORSHeaders
+	}
+	if len(cfg.ExposedHeaders) == 0 {
+		cfg.ExposedHeaders = defaultCORSExposedHeaders
+	}
+	if maxAge, err := strconv.Atoi(os.Getenv(CORSMaxAgeEnv)); err == nil && maxAge >= 0 {
+		cfg.MaxAge = maxAge
+	}
+	return cfg
+}
+
+// Enabled reports whether any cross-origin request is allowed
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+// AllowsOrigin reports whether origin is in the allowlist
+func (c CORSConfig) AllowsOrigin(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CORSConfig) allowsMethod(method string) bool {
+	return slices.ContainsFunc(c.AllowedMethods, func(m string) bool { return strings.EqualFold(m, method) })
+}
+
+func (c CORSConfig) allowsHeaders(requested string) bool {
+	for _, header := range splitList(requested) {
+		if !slices.ContainsFunc(c.AllowedHeaders, func(h string) bool { return strings.EqualFold(h, header) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWebSocketOrigin validates the Origin of a WebSocket upgrade.
+// Without an allowlist every origin is accepted, as before CORS support.
+func (c CORSConfig) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if !c.Enabled() || origin == "" {
+		return true
+	}
+	return c.AllowsOrigin(origin)
+}
+
+// corsMiddleware answers preflight requests and adds CORS headers for allowed origins.
+// Requests from other origins get no CORS headers, so browsers block the response.
+func corsMiddleware(cfg CORSConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !cfg.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if !cfg.AllowsOrigin(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if !preflight {
+				if len(cfg.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			if !cfg.allowsMethod(r.Header.Get("Access-Control-Request-Method")) ||
+				!cfg.allowsHeaders(r.Header.Get("Access-Control-Request-Headers")) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(cfg.AllowedMethods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
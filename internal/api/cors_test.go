@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCORSConfigFromEnv(t *testing.T) {
+	t.Setenv(CORSAllowedOriginsEnv, "https://ui.example.com, https://other.example.com")
+	t.Setenv(CORSAllowedMethodsEnv, "")
+	t.Setenv(CORSAllowedHeadersEnv, "Authorization,X-Custom")
+	t.Setenv(CORSExposedHeadersEnv, "")
+	t.Setenv(CORSMaxAgeEnv, "60")
+
+	cfg := CORSConfigFromEnv()
+
+	if want := []string{"https://ui.example.com", "https://other.example.com"}; !reflect.DeepEqual(cfg.AllowedOrigins, want) {
+		t.Errorf("expected origins %v, got %v", want, cfg.AllowedOrigins)
+	}
+	if !reflect.DeepEqual(cfg.AllowedMethods, defaultCORSMethods) {
+		t.Errorf("expected default methods, got %v", cfg.AllowedMethods)
+	}
+	if want := []string{"Authorization", "X-Custom"}; !reflect.DeepEqual(cfg.AllowedHeaders, want) {
+		t.Errorf("expected headers %v, got %v", want, cfg.AllowedHeaders)
+	}
+	if !reflect.DeepEqual(cfg.ExposedHeaders, defaultCORSExposedHeaders) {
+		t.Errorf("expected default exposed headers, got %v", cfg.ExposedHeaders)
+	}
+	if cfg.MaxAge != 60 {
+		t.Errorf("expected max age 60, got %d", cfg.MaxAge)
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	handler := setupTestHandler()
+	handler.cors = CORSConfig{
+		AllowedOrigins: []string{"https://ui.example.com"},
+		AllowedMethods: defaultCORSMethods,
+		AllowedHeaders: defaultCORSHeaders,
+		ExposedHeaders: defaultCORSExposedHeaders,
+		MaxAge:         defaultCORSMaxAge,
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		requestMethod   string
+		requestHeaders  string
+		expectedStatus  int
+		expectedOrigin  string
+		expectedMethods bool
+		expectedExposed bool
+	}{
+		{
+			name:            "preflight from allowed origin",
+			method:          http.MethodOptions,
+			origin:          "https://ui.example.com",
+			requestMethod:   http.MethodPost,
+			requestHeaders:  "authorization, content-type, x-request-id",
+			expectedStatus:  http.StatusNoContent,
+			expectedOrigin:  "https://ui.example.com",
+			expectedMethods: true,
+		},
+		{
+			name:           "preflight from unknown origin",
+			method:         http.MethodOptions,
+			origin:         "https://evil.example.com",
+			requestMethod:  http.MethodPost,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "preflight with disallowed header",
+			method:         http.MethodOptions,
+			origin:         "https://ui.example.com",
+			requestMethod:  http.MethodGet,
+			requestHeaders: "X-Not-Allowed",
+			expectedStatus: http.StatusForbidden,
+			expectedOrigin: "https://ui.example.com",
+		},
+		{
+			name:            "simple request from allowed origin",
+			method:          http.MethodGet,
+			origin:          "https://ui.example.com",
+			expectedStatus:  http.StatusOK,
+			expectedOrigin:  "https://ui.example.com",
+			expectedExposed: true,
+		},
+		{
+			name:           "simple request from unknown origin is served without CORS headers",
+			method:         http.MethodGet,
+			origin:         "https://evil.example.com",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, OpenAPIPath, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			if tt.requestHeaders != "" {
+				req.Header.Set("Access-Control-Request-Headers", tt.requestHeaders)
+			}
+			w := httptest.NewRecorder()
+
+			serveAPI(handler, w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.expectedOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin %q, got %q", tt.expectedOrigin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Methods") != ""; got != tt.expectedMethods {
+				t.Errorf("expected Access-Control-Allow-Methods present=%v, got %v", tt.expectedMethods, got)
+			}
+			exposed := w.Header().Get("Access-Control-Expose-Headers")
+			if got := strings.Contains(exposed, "X-Request-ID") && strings.Contains(exposed, CatalogStaleHeader); got != tt.expectedExposed {
+				t.Errorf("expected X-Request-ID and %s exposed=%v, got %q", CatalogStaleHeader, tt.expectedExposed, exposed)
+			}
+		})
+	}
+}
+
+func TestCORSCheckWebSocketOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, ScenariosRunPath+"/run/jobs/job/logs", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+
+	if !(CORSConfig{}).checkWebSocketOrigin(req) {
+		t.Errorf("expected any origin to be accepted without an allowlist")
+	}
+
+	cfg := CORSConfig{AllowedOrigins: []string{"https://ui.example.com"}}
+	if cfg.checkWebSocketOrigin(req) {
+		t.Errorf("expected origin outside the allowlist to be rejected")
+	}
+	req.Header.Set("Origin", "https://ui.example.com")
+	if !cfg.checkWebSocketOrigin(req) {
+		t.Errorf("expected allowlisted origin to be accepted")
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// StrictDecodingEnv enables strict request decoding for every request when "true".
+// Requests may still opt in or out with the strict query parameter.
+const StrictDecodingEnv = "API_STRICT_DECODING"
+
+// QueryStrict is the query parameter selecting strict decoding for a single request
+const QueryStrict = "strict"
+
+// StrictDecodingFromEnv reads the global strict decoding default from the environment
+func StrictDecodingFromEnv() bool {
+	strict, _ := strconv.ParseBool(os.Getenv(StrictDecodingEnv))
+	return strict
+}
+
+// UnknownFieldsError is returned when a strict request body holds fields the target type does not declare
+type UnknownFieldsError struct {
+	// Fields are the JSON paths of the unknown fields, e.g. "onFailure[0].imag"
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields: " + strings.Join(e.Fields, ", ")
+}
+
+// strictDecoding reports whether unknown fields are rejected for r.
+// ?strict=true|false overrides the global default.
+func (h *Handler) strictDecoding(r *http.Request) bool {
+	if value := r.URL.Query().Get(QueryStrict); value != "" {
+		if strict, err := strconv.ParseBool(value); err == nil {
+			return strict
+		}
+	}
+	return h.strictDecode
+}
+
+// decodeRequestBody decodes the JSON body of r into v. In strict mode it returns an
+// *UnknownFieldsError listing every field of the body that v does not declare.
+func (h *Handler) decodeRequestBody(r *http.Request, v any) error {
+	if !h.strictDecoding(r) {
+		return json.NewDecoder(r.Body).Decode(v)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return io.EOF
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+
+	var raw any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return err
+	}
+	var unknown []string
+	collectUnknownFields(raw, reflect.TypeOf(v), "", &unknown)
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return &UnknownFieldsError{Fields: unknown}
+	}
+	return nil
+}
+
+// writeUnknownFieldsError writes the 400 response for an *UnknownFieldsError and
+// reports whether err was one, so callers fall back to their own error otherwise
+func writeUnknownFieldsError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var unknownErr *UnknownFieldsError
+	if !errors.As(err, &unknownErr) {
+		return false
+	}
+	writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgUnknownFields,
+		i18n.Params{"fields": strings.Join(unknownErr.Fields, ", ")})
+	return true
+}
+
+// collectUnknownFields walks a generically decoded JSON value alongside the Go type it
+// was decoded into and appends the path of every object key that type does not declare
+func collectUnknownFields(value any, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		// Custom decoding, the type decides what it accepts
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range object {
+			fieldType, known := lookupJSONField(fields, key)
+			if !known {
+				*unknown = append(*unknown, joinFieldPath(path, key))
+				continue
+			}
+			collectUnknownFields(child, fieldType, joinFieldPath(path, key), unknown)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, child := range object {
+			collectUnknownFields(child, t.Elem(), joinFieldPath(path, key), unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for i, item := range items {
+			collectUnknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// jsonFields maps the JSON names of the fields of struct t, including promoted fields
+// of embedded structs, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					if _, shadowed := fields[embeddedName]; !shadowed {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupJSONField matches key like encoding/json does: exactly, then case-insensitively
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if fieldType, ok := fields[key]; ok {
+		return fieldType, true
+	}
+	for name, fieldType := range fields {
+		if strings.EqualFold(name, key) {
+			return fieldType, true
+		}
+	}
+	return nil, false
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeRequestBody_Strict(t *testing.T) {
+	handler := &Handler{}
+	body := `{
+		"scenarioImage": "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+		"scenarioName": "pod-scenarios",
+		"enviroment": {"NAMESPACE": "default"},
+		"environment": {"NAMESPACE": "default"},
+		"registryUrl": "quay.io",
+		"onFailure": [{"name": "restore", "imag": {"image": "busybox"}, "webhook": {"url": "https://example.com", "channel": "x"}}]
+	}`
+
+	var req ScenarioRunRequest
+	err := handler.decodeRequestBody(httptest.NewRequest(http.MethodPost, "/?strict=true", strings.NewReader(body)), &req)
+	var unknownErr *UnknownFieldsError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("expected an UnknownFieldsError, got %v", err)
+	}
+	expected := []string{"enviroment", "onFailure[0].imag", "onFailure[0].webhook.channel"}
+	if !slices.Equal(unknownErr.Fields, expected) {
+		t.Errorf("expected unknown fields %v, got %v", expected, unknownErr.Fields)
+	}
+
+	// Without strict mode unknown fields are ignored
+	req = ScenarioRunRequest{}
+	if err := handler.decodeRequestBody(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), &req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.RegistryURL != "quay.io" || req.OnFailure[0].Webhook == nil {
+		t.Errorf("expected the known fields to be decoded, got %+v", req)
+	}
+}
+
+func TestDecodeRequestBody_GlobalDefault(t *testing.T) {
+	handler := &Handler{strictDecode: true}
+	body := `{"clusterName": "cluster-1", "clustername": "cluster-1", "tokn": "x"}`
+
+	var req CreateTargetRequest
+	err := handler.decodeRequestBody(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), &req)
+	var unknownErr *UnknownFieldsError
+	if !errors.As(err, &unknownErr) || !slices.Equal(unknownErr.Fields, []string{"tokn"}) {
+		t.Fatalf("expected only tokn to be reported, got %v", err)
+	}
+
+	if err := handler.decodeRequestBody(httptest.NewRequest(http.MethodPost, "/?strict=false", strings.NewReader(body)), &req); err != nil {
+		t.Errorf("expected strict=false to override the global default, got %v", err)
+	}
+}
+
+func TestCreateTarget_StrictUnknownFields(t *testing.T) {
+	handler := setupTestHandler()
+
+	body := `{"clusterName": "cluster-1", "clusterAPIURL": "https://api.example.com:6443", "secretType": "token", "token": "x", "tokenn": "y"}`
+	w := httptest.NewRecorder()
+	handler.CreateTarget(w, httptest.NewRequest(http.MethodPost, OperatorTargetsPath+"?strict=true", strings.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Code != MsgUnknownFields || !strings.Contains(errResp.Message, "tokenn") {
+		t.Errorf("expected the unknown field to be listed, got %+v", errResp)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// Diagnostics status values
+const (
+	DiagnosticsStatusOK       = "ok"
+	DiagnosticsStatusDegraded = "degraded"
+	DiagnosticsStatusPending  = "pending"
+)
+
+// GetDiagnostics handles GET /api/v1/diagnostics endpoint
+// Reports whether the installed CRDs match the operator version and, if not,
+// the exact steps needed to upgrade them, and the permissions the operator is
+// missing in its namespace, and the availability of the scenario registries.
+func (h *Handler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgOnlyMethodAllowed,
+			i18n.Params{"method": http.MethodGet})
+		return
+	}
+
+	response := DiagnosticsResponse{Status: DiagnosticsStatusPending}
+
+	if report := h.crdChecker.Report(); report != nil {
+		response.CRDSchema = report
+		response.Status = DiagnosticsStatusOK
+		if !report.Compatible {
+			response.Status = DiagnosticsStatusDegraded
+		}
+	}
+	if report := h.permissionChecker.Report(); report != nil {
+		response.Permissions = report
+		if !report.OK || len(report.Hints) > 0 {
+			response.Status = DiagnosticsStatusDegraded
+		}
+	}
+	// An unavailable registry is an outage outside the operator, reported without degrading
+	response.Registries = h.registries.Statuses()
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/crdcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/permcheck"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	// No CRDs installed: every expectation is reported as missing
+	checker := crdcheck.NewChecker(fakeclient.NewClientBuilder().WithScheme(scheme).Build(), crdcheck.DefaultExpectations())
+
+	handler := setupTestHandler()
+
+	getDiagnostics := func() DiagnosticsResponse {
+		w := httptest.NewRecorder()
+		handler.GetDiagnostics(w, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		var response DiagnosticsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return response
+	}
+
+	// Without a checker the status is pending
+	if response := getDiagnostics(); response.Status != DiagnosticsStatusPending {
+		t.Errorf("Expected status %s, got %s", DiagnosticsStatusPending, response.Status)
+	}
+
+	handler.crdChecker = checker
+	checker.Check(context.Background())
+
+	response := getDiagnostics()
+	if response.Status != DiagnosticsStatusDegraded {
+		t.Errorf("Expected status %s, got %s", DiagnosticsStatusDegraded, response.Status)
+	}
+	if response.CRDSchema == nil || len(response.CRDSchema.Mismatches) != len(crdcheck.DefaultExpectations()) {
+		t.Fatalf("Expected a mismatch per CRD, got %+v", response.CRDSchema)
+	}
+	if len(response.CRDSchema.Instructions) == 0 {
+		t.Error("Expected upgrade instructions")
+	}
+}
+
+func TestGetDiagnostics_Permissions(t *testing.T) {
+	// Every access review is denied
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, action.(k8stesting.CreateAction).GetObject(), nil
+	})
+	checker := permcheck.NewChecker(clientset.AuthorizationV1().SelfSubjectAccessReviews(),
+		fakeclient.NewClientBuilder().Build(), "default", permcheck.DefaultRequirements(), nil)
+	checker.Check(context.Background())
+
+	handler := setupTestHandler()
+	handler.permissionChecker = checker
+
+	w := httptest.NewRecorder()
+	handler.GetDiagnostics(w, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+	var response DiagnosticsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Status != DiagnosticsStatusDegraded {
+		t.Errorf("Expected status %s, got %s", DiagnosticsStatusDegraded, response.Status)
+	}
+	if response.Permissions == nil || response.Permissions.Namespace != "default" ||
+		len(response.Permissions.Missing) != len(permcheck.DefaultRequirements()) {
+		t.Errorf("Expected every requirement to be reported missing, got %+v", response.Permissions)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// GetStateMachineDocs handles GET /api/v1/docs/state-machine endpoint
+// Publishes the job phase state machine enforced by the controller, so the UI can
+// align its phase handling. ?format=mermaid returns only the Mermaid diagram.
+func (h *Handler) GetStateMachineDocs(w http.ResponseWriter, r *http.Request) {
+	machine := statemachine.Jobs
+
+	if r.URL.Query().Get("format") == "mermaid" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(machine.Mermaid()))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StateMachineResponse{
+		Machines: []StateMachineDescription{{
+			Name:        machine.Name(),
+			Initial:     machine.Initial(),
+			States:      machine.States(),
+			Terminal:    machine.Terminal(),
+			Transitions: machine.Transitions(),
+			Mermaid:     machine.Mermaid(),
+		}},
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func TestGetStateMachineDocs(t *testing.T) {
+	handler := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, httptest.NewRequest(http.MethodGet, DocsStateMachinePath, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp StateMachineResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Machines) != 1 {
+		t.Fatalf("Expected 1 machine, got %d", len(resp.Machines))
+	}
+	machine := resp.Machines[0]
+	if machine.Initial != statemachine.JobPending {
+		t.Errorf("Expected initial phase %s, got %s", statemachine.JobPending, machine.Initial)
+	}
+	if len(machine.Transitions) != len(statemachine.Jobs.Transitions()) {
+		t.Errorf("Expected %d transitions, got %d", len(statemachine.Jobs.Transitions()), len(machine.Transitions))
+	}
+	if machine.Mermaid != statemachine.Jobs.Mermaid() {
+		t.Errorf("Expected generated Mermaid diagram")
+	}
+}
+
+func TestGetStateMachineDocs_Mermaid(t *testing.T) {
+	handler := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, httptest.NewRequest(http.MethodGet, DocsStateMachinePath+"?format=mermaid", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected text/plain, got %s", ct)
+	}
+	if !strings.HasPrefix(w.Body.String(), "stateDiagram-v2") {
+		t.Errorf("Expected Mermaid diagram, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// GetClusterEffectiveSpec handles GET /api/v1/scenarios/run/{scenarioRunName}/clusters/{clusterName}/effective-spec
+// It returns what the current job of the cluster was created with, as recorded by the
+// controller in the run status, so it can be inspected after the pod is gone.
+func (h *Handler) GetClusterEffectiveSpec(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	clusterName, err := pathParam(r, ParamClusterName)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "clusterName"})
+		return
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound, i18n.Params{"name": string(scenarioRunName)})
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	var job *krknv1alpha1.ClusterJobStatus
+	for i := range scenarioRun.Status.ClusterJobs {
+		if scenarioRun.Status.ClusterJobs[i].ClusterName == clusterName {
+			job = &scenarioRun.Status.ClusterJobs[i]
+			break
+		}
+	}
+	if job == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgMetricsClusterNotInRun, i18n.Params{"cluster": clusterName})
+		return
+	}
+	if !h.checkJobAccess(w, r, job, groupauth.ActionView, "view") {
+		return
+	}
+	if job.EffectiveSpec == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgEffectiveSpecNotCaptured, i18n.Params{"cluster": clusterName})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, EffectiveSpecResponse{
+		ScenarioRunName: scenarioRun.Name,
+		ClusterName:     job.ClusterName,
+		JobID:           job.JobID,
+		Phase:           job.Phase,
+		RetryCount:      job.RetryCount,
+		EffectiveSpec:   *job.EffectiveSpec,
+	})
+}
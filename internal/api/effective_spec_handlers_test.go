@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func effectiveSpecRequest(cluster string) *http.Request {
+	path := ScenariosRunPath + "/run-1" + ScenarioRunClustersSegment + "/" + cluster + ClusterEffectiveSpecSuffix
+	return withAdminClaims(httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+func TestGetClusterEffectiveSpec(t *testing.T) {
+	handler := setupTestHandler()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{
+					ProviderName: "krkn-operator",
+					ClusterName:  "cluster-1",
+					JobID:        "job-2",
+					Phase:        "Failed",
+					RetryCount:   1,
+					EffectiveSpec: &krknv1alpha1.EffectiveSpec{
+						Image:       "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+						ImageID:     "quay.io/krkn-chaos/krkn-hub@sha256:abc",
+						Environment: map[string]string{"NAMESPACE": "default", "ES_PASSWORD": "<redacted>"},
+						Files:       []krknv1alpha1.EffectiveFile{{Name: "scenario.yaml", MountPath: "/root/scenario.yaml"}},
+						Pod:         krknv1alpha1.EffectivePodSummary{ServiceAccountName: "krkn-operator-krkn-scenario-runner"},
+						CapturedAt:  metav1.Now(),
+					},
+				},
+				{ProviderName: "krkn-operator", ClusterName: "cluster-2", JobID: "job-3", Phase: "Running"},
+			},
+		},
+	}
+	if err := handler.client.Create(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, effectiveSpecRequest("cluster-1"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response EffectiveSpecResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.JobID != "job-2" || response.RetryCount != 1 {
+		t.Errorf("expected the current attempt, got job %s retry %d", response.JobID, response.RetryCount)
+	}
+	if response.EffectiveSpec.ImageID != "quay.io/krkn-chaos/krkn-hub@sha256:abc" {
+		t.Errorf("expected the image digest, got %q", response.EffectiveSpec.ImageID)
+	}
+	if response.EffectiveSpec.Environment["ES_PASSWORD"] != "<redacted>" {
+		t.Errorf("expected the redacted environment, got %v", response.EffectiveSpec.Environment)
+	}
+
+	tests := []struct {
+		name    string
+		cluster string
+	}{
+		{name: "cluster not in run", cluster: "cluster-9"},
+		{name: "spec not captured", cluster: "cluster-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			serveAPI(handler, w, effectiveSpecRequest(tt.cluster))
+			if w.Code != http.StatusNotFound {
+				t.Errorf("expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// maxFavoriteScenarios bounds the favorites kept in the status of a KrknUser
+const maxFavoriteScenarios = 100
+
+// errFavoritesLimit is returned by the star mutation when the user starred too many scenarios
+var errFavoritesLimit = errors.New("too many favorite scenarios")
+
+// ListFavoriteScenarios handles GET /api/v1/scenarios/favorites
+// It returns the scenarios the authenticated user starred with their catalog data, read
+// from the registry named by ?registryRef= or quay.io. The favorites are returned even
+// when the registry is unavailable, with the cached catalog or without catalog data.
+func (h *Handler) ListFavoriteScenarios(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	user, ok := h.authenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	registry, mode, err := h.resolveRegistry(ctx, ScenariosRequest{RegistryRef: r.URL.Query().Get("registryRef")})
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	response := FavoriteScenariosResponse{Favorites: make([]FavoriteScenario, 0, len(user.Status.FavoriteScenarios))}
+	catalog, err := h.fetchScenarios(registry, mode, refreshRequested(r))
+	if err != nil {
+		logger.Info("Listing favorite scenarios without a fresh catalog", "error", err.Error())
+		response.RegistryError = err.Error()
+		var unavailable *RegistryUnavailableError
+		if errors.As(err, &unavailable) {
+			if entry, ok := h.registries.lookup(catalogKey(registry, "scenarios")); ok {
+				if cached, ok := entry.response.(ScenariosResponse); ok {
+					cachedAt := entry.cachedAt.UTC()
+					catalog = &cached
+					response.Stale = true
+					response.CachedAt = &cachedAt
+					w.Header().Set(CatalogStaleHeader, "true")
+				}
+			}
+		}
+	}
+
+	for _, name := range user.Status.FavoriteScenarios {
+		favorite := FavoriteScenario{Name: name}
+		if catalog != nil {
+			if i := slices.IndexFunc(catalog.Scenarios, func(tag ScenarioTag) bool { return tag.Name == name }); i >= 0 {
+				favorite.Catalog = &catalog.Scenarios[i]
+			}
+		}
+		response.Favorites = append(response.Favorites, favorite)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// StarScenario handles PUT /api/v1/scenarios/favorites/{scenarioName}
+// Starring a scenario twice keeps it in place.
+func (h *Handler) StarScenario(w http.ResponseWriter, r *http.Request) {
+	h.updateFavoriteScenarios(w, r, func(favorites []string, name string) ([]string, error) {
+		if slices.Contains(favorites, name) {
+			return favorites, nil
+		}
+		if len(favorites) >= maxFavoriteScenarios {
+			return nil, errFavoritesLimit
+		}
+		return append(favorites, name), nil
+	})
+}
+
+// UnstarScenario handles DELETE /api/v1/scenarios/favorites/{scenarioName}
+// Unstarring a scenario that is not starred succeeds.
+func (h *Handler) UnstarScenario(w http.ResponseWriter, r *http.Request) {
+	h.updateFavoriteScenarios(w, r, func(favorites []string, name string) ([]string, error) {
+		return slices.DeleteFunc(favorites, func(favorite string) bool { return favorite == name }), nil
+	})
+}
+
+// updateFavoriteScenarios applies mutate to the favorites of the authenticated user for
+// the scenario of the path, retrying on conflicts, and answers with the favorites without
+// catalog data
+func (h *Handler) updateFavoriteScenarios(
+	w http.ResponseWriter,
+	r *http.Request,
+	mutate func(favorites []string, name string) ([]string, error),
+) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	name, err := pathParam(r, ParamScenarioName)
+	if err == nil {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			err = errors.New(strings.Join(errs, ", "))
+		}
+	}
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFavoriteNameInvalid,
+			i18n.Params{"name": name, "error": err.Error()})
+		return
+	}
+
+	user, ok := h.authenticatedUser(w, r)
+	if !ok {
+		return
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := h.fetchUserByEmail(ctx, user.Spec.UserID)
+		if err != nil {
+			return err
+		}
+		user = current
+		favorites, err := mutate(user.Status.FavoriteScenarios, name)
+		if err != nil {
+			return err
+		}
+		user.Status.FavoriteScenarios = favorites
+		return h.client.Status().Update(ctx, user)
+	})
+	if errors.Is(err, errFavoritesLimit) {
+		writeLocalizedError(w, r, http.StatusUnprocessableEntity, "favorites_limit", MsgFavoritesLimit,
+			i18n.Params{"limit": strconv.Itoa(maxFavoriteScenarios)})
+		return
+	}
+	if err != nil {
+		logger.Error(err, "Failed to update favorite scenarios", "userID", user.Spec.UserID)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgFavoritesUpdateFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	response := FavoriteScenariosResponse{Favorites: make([]FavoriteScenario, 0, len(user.Status.FavoriteScenarios))}
+	for _, favorite := range user.Status.FavoriteScenarios {
+		response.Favorites = append(response.Favorites, FavoriteScenario{Name: favorite})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// authenticatedUser returns the KrknUser of the authenticated user, writing the error
+// response when there is none
+func (h *Handler) authenticatedUser(w http.ResponseWriter, r *http.Request) (*krknv1alpha1.KrknUser, bool) {
+	claims := auth.GetClaimsFromContext(r.Context())
+	if claims == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
+		return nil, false
+	}
+	user, err := h.fetchUserByEmail(r.Context(), claims.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound,
+				i18n.Params{"userId": claims.UserID})
+			return nil, false
+		}
+		log.FromContext(r.Context()).Error(err, "Failed to fetch user", "userID", claims.UserID)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return nil, false
+	}
+	return user, true
+}
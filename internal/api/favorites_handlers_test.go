@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+func TestFavoriteScenarios(t *testing.T) {
+	scenarioProvider := &fakeScenarioProvider{}
+	handler := setupRegistryTestHandler(scenarioProvider)
+	if err := handler.client.Create(context.Background(), &krknv1alpha1.KrknUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "jane", Namespace: handler.namespace},
+		Spec:       krknv1alpha1.KrknUserSpec{UserID: "jane@example.com", Role: "user"},
+	}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	call := func(method, path string) (int, FavoriteScenariosResponse) {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserClaimsKey, &auth.Claims{
+			UserID: "jane@example.com",
+			Role:   "user",
+		}))
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, req)
+		var response FavoriteScenariosResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	for _, path := range []string{"/retired-scenarios", "/pod-scenarios", "/pod-scenarios", "/node-scenarios"} {
+		if code, _ := call(http.MethodPut, ScenariosFavoritesPath+path); code != http.StatusOK {
+			t.Fatalf("Expected status %d starring %s, got %d", http.StatusOK, path, code)
+		}
+	}
+	if code, response := call(http.MethodDelete, ScenariosFavoritesPath+"/node-scenarios"); code != http.StatusOK ||
+		len(response.Favorites) != 2 {
+		t.Fatalf("Unexpected unstar response %d %+v", code, response)
+	}
+	if code, _ := call(http.MethodPut, ScenariosFavoritesPath+"/Not_A_Scenario"); code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid name, got %d", http.StatusBadRequest, code)
+	}
+
+	code, response := call(http.MethodGet, ScenariosFavoritesPath)
+	if code != http.StatusOK || len(response.Favorites) != 2 {
+		t.Fatalf("Unexpected favorites %d %+v", code, response)
+	}
+	retired, pod := response.Favorites[0], response.Favorites[1]
+	if retired.Name != "retired-scenarios" || retired.Catalog != nil {
+		t.Errorf("Expected retired-scenarios without catalog data, got %+v", retired)
+	}
+	if pod.Name != "pod-scenarios" || pod.Catalog == nil || pod.Catalog.Name != "pod-scenarios" {
+		t.Errorf("Expected pod-scenarios with catalog data, got %+v", pod)
+	}
+
+	// Favorites outlive the registry
+	scenarioProvider.err = errors.New("dial tcp: i/o timeout")
+	code, response = call(http.MethodGet, ScenariosFavoritesPath+"?refresh=true")
+	if code != http.StatusOK || len(response.Favorites) != 2 || !response.Stale ||
+		response.Favorites[1].Catalog == nil || response.RegistryError == "" {
+		t.Errorf("Expected the favorites with the cached catalog, got %d %+v", code, response)
+	}
+}
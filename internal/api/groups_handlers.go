@@ -20,9 +20,9 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +34,7 @@ import (
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/pkg/auth"
 	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
 )
 
 // ListUserGroups handles GET /api/v1/groups
@@ -44,10 +45,7 @@ func (h *Handler) ListUserGroups(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
@@ -55,10 +53,7 @@ func (h *Handler) ListUserGroups(w http.ResponseWriter, r *http.Request) {
 	var groups krknv1alpha1.KrknUserGroupList
 	if err := h.client.List(ctx, &groups, client.InNamespace(h.namespace)); err != nil {
 		logger.Error(err, "Failed to list user groups")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list user groups",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupListFailed, nil)
 		return
 	}
 
@@ -84,20 +79,14 @@ func (h *Handler) GetUserGroup(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract groupName from path
-	groupName, err := extractPathSuffix(r.URL.Path, GroupsPath+"/")
+	groupName, err := pathParam(r, ParamGroupName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid groupName in path",
-		})
+		writeInvalidIDError(w, r, ParamGroupName, err)
 		return
 	}
 
@@ -108,16 +97,11 @@ func (h *Handler) GetUserGroup(w http.ResponseWriter, r *http.Request) {
 	group := &krknv1alpha1.KrknUserGroup{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: groupName, Namespace: h.namespace}, group); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User group '%s' not found", groupName),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserGroupNotFound,
+				i18n.Params{"name": groupName})
 		} else {
 			logger.Error(err, "Failed to get user group", "groupName", groupName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupFetchFailed, nil)
 		}
 		return
 	}
@@ -134,29 +118,24 @@ func (h *Handler) CreateUserGroup(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Parse request body
 	var req CreateUserGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	// Validate request
 	if req.Name == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Group name is required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "name"})
 		return
 	}
 
@@ -166,18 +145,13 @@ func (h *Handler) CreateUserGroup(w http.ResponseWriter, r *http.Request) {
 	sanitizedName := groupauth.SanitizeGroupName(req.Name)
 
 	if sanitizedName == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Group name contains only invalid characters",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgGroupNameInvalidChars, nil)
 		return
 	}
 
 	if len(sanitizedName) > 63 {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: fmt.Sprintf("Group name is too long. After sanitization, it must be 63 characters or less (current: %d). Use a shorter name.", len(sanitizedName)),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgGroupNameTooLong,
+			i18n.Params{"length": strconv.Itoa(len(sanitizedName))})
 		return
 	}
 
@@ -191,19 +165,14 @@ func (h *Handler) CreateUserGroup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(req.ClusterPermissions) == 0 {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "At least one cluster permission is required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgGroupPermissionsRequired, nil)
 		return
 	}
 
 	// Validate actions
 	if err := validateClusterPermissions(req.ClusterPermissions); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -231,16 +200,11 @@ func (h *Handler) CreateUserGroup(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Create(ctx, group); err != nil {
 		if apierrors.IsAlreadyExists(err) {
-			writeJSONError(w, http.StatusConflict, ErrorResponse{
-				Error:   "conflict",
-				Message: fmt.Sprintf("User group '%s' already exists", req.Name),
-			})
+			writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgUserGroupExists,
+				i18n.Params{"name": req.Name})
 		} else {
 			logger.Error(err, "Failed to create user group", "groupName", req.Name)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to create user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupCreateFailed, nil)
 		}
 		return
 	}
@@ -248,7 +212,8 @@ func (h *Handler) CreateUserGroup(w http.ResponseWriter, r *http.Request) {
 	logger.Info("Created user group", "groupName", req.Name, "clusterCount", len(req.ClusterPermissions))
 
 	writeJSON(w, http.StatusCreated, CreateUserGroupResponse{
-		Message: "User group created successfully",
+		Code:    MsgUserGroupCreateSucceeded,
+		Message: localizedMessage(w, r, MsgUserGroupCreateSucceeded, nil),
 		Name:    req.Name,
 	})
 }
@@ -261,40 +226,33 @@ func (h *Handler) UpdateUserGroup(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract groupName from path
-	groupName, err := extractPathSuffix(r.URL.Path, GroupsPath+"/")
+	groupName, err := pathParam(r, ParamGroupName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid groupName in path",
-		})
+		writeInvalidIDError(w, r, ParamGroupName, err)
 		return
 	}
 
 	// Parse request body
 	var req UpdateUserGroupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	// Validate actions if provided
 	if req.ClusterPermissions != nil {
 		if err := validateClusterPermissions(req.ClusterPermissions); err != nil {
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+				i18n.Params{"error": err.Error()})
 			return
 		}
 	}
@@ -303,16 +261,11 @@ func (h *Handler) UpdateUserGroup(w http.ResponseWriter, r *http.Request) {
 	group := &krknv1alpha1.KrknUserGroup{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: groupName, Namespace: h.namespace}, group); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User group '%s' not found", groupName),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserGroupNotFound,
+				i18n.Params{"name": groupName})
 		} else {
 			logger.Error(err, "Failed to get user group", "groupName", groupName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupFetchFailed, nil)
 		}
 		return
 	}
@@ -337,27 +290,22 @@ func (h *Handler) UpdateUserGroup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !updated {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "No fields to update",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgNoFieldsToUpdate, nil)
 		return
 	}
 
 	// Update group
 	if err := h.client.Update(ctx, group); err != nil {
 		logger.Error(err, "Failed to update user group", "groupName", groupName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update user group",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupUpdateFailed, nil)
 		return
 	}
 
 	logger.Info("Updated user group", "groupName", groupName)
 
 	writeJSON(w, http.StatusOK, UpdateUserGroupResponse{
-		Message: "User group updated successfully",
+		Code:    MsgUserGroupUpdateSucceeded,
+		Message: localizedMessage(w, r, MsgUserGroupUpdateSucceeded, nil),
 		Group:   buildUserGroupResponse(ctx, h.client, group, h.namespace),
 	})
 }
@@ -370,20 +318,14 @@ func (h *Handler) DeleteUserGroup(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract groupName from path
-	groupName, err := extractPathSuffix(r.URL.Path, GroupsPath+"/")
+	groupName, err := pathParam(r, ParamGroupName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid groupName in path",
-		})
+		writeInvalidIDError(w, r, ParamGroupName, err)
 		return
 	}
 
@@ -391,16 +333,11 @@ func (h *Handler) DeleteUserGroup(w http.ResponseWriter, r *http.Request) {
 	group := &krknv1alpha1.KrknUserGroup{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: groupName, Namespace: h.namespace}, group); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User group '%s' not found", groupName),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserGroupNotFound,
+				i18n.Params{"name": groupName})
 		} else {
 			logger.Error(err, "Failed to get user group", "groupName", groupName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupFetchFailed, nil)
 		}
 		return
 	}
@@ -414,17 +351,15 @@ func (h *Handler) DeleteUserGroup(w http.ResponseWriter, r *http.Request) {
 	// Delete group
 	if err := h.client.Delete(ctx, group); err != nil {
 		logger.Error(err, "Failed to delete user group", "groupName", groupName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete user group",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupDeleteFailed, nil)
 		return
 	}
 
 	logger.Info("Deleted user group", "groupName", groupName)
 
 	writeJSON(w, http.StatusOK, DeleteUserGroupResponse{
-		Message: "User group deleted successfully",
+		Code:    MsgUserGroupDeleteSucceeded,
+		Message: localizedMessage(w, r, MsgUserGroupDeleteSucceeded, nil),
 	})
 }
 
@@ -436,20 +371,14 @@ func (h *Handler) ListGroupMembers(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract groupName from path
-	groupName, err := extractGroupNameFromMembersPath(r.URL.Path)
+	groupName, err := pathParam(r, ParamGroupName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamGroupName, err)
 		return
 	}
 
@@ -457,16 +386,11 @@ func (h *Handler) ListGroupMembers(w http.ResponseWriter, r *http.Request) {
 	group := &krknv1alpha1.KrknUserGroup{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: groupName, Namespace: h.namespace}, group); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User group '%s' not found", groupName),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserGroupNotFound,
+				i18n.Params{"name": groupName})
 		} else {
 			logger.Error(err, "Failed to get user group", "groupName", groupName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupFetchFailed, nil)
 		}
 		return
 	}
@@ -481,10 +405,7 @@ func (h *Handler) ListGroupMembers(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		logger.Error(err, "Failed to list group members", "groupName", groupName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list group members",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgGroupMembersListFailed, nil)
 		return
 	}
 
@@ -511,38 +432,31 @@ func (h *Handler) AddGroupMember(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract groupName from path
-	groupName, err := extractGroupNameFromMembersPath(r.URL.Path)
+	groupName, err := pathParam(r, ParamGroupName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamGroupName, err)
 		return
 	}
 
 	// Parse request body
 	var req AddGroupMemberRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	if req.UserID == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UserID is required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired,
+			i18n.Params{"field": "userId"})
 		return
 	}
 
@@ -550,16 +464,11 @@ func (h *Handler) AddGroupMember(w http.ResponseWriter, r *http.Request) {
 	group := &krknv1alpha1.KrknUserGroup{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: groupName, Namespace: h.namespace}, group); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User group '%s' not found", groupName),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserGroupNotFound,
+				i18n.Params{"name": groupName})
 		} else {
 			logger.Error(err, "Failed to get user group", "groupName", groupName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupFetchFailed, nil)
 		}
 		return
 	}
@@ -569,16 +478,12 @@ func (h *Handler) AddGroupMember(w http.ResponseWriter, r *http.Request) {
 	user := &krknv1alpha1.KrknUser{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: userName, Namespace: h.namespace}, user); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User '%s' not found", req.UserID),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound,
+				i18n.Params{"userId": req.UserID})
 		} else {
 			logger.Error(err, "Failed to get user", "userID", req.UserID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -590,10 +495,8 @@ func (h *Handler) AddGroupMember(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if user.Labels[labelKey] == "true" {
-		writeJSONError(w, http.StatusConflict, ErrorResponse{
-			Error:   "conflict",
-			Message: fmt.Sprintf("User '%s' is already a member of group '%s'", req.UserID, groupName),
-		})
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgGroupMemberExists,
+			i18n.Params{"userId": req.UserID, "name": groupName})
 		return
 	}
 
@@ -601,17 +504,15 @@ func (h *Handler) AddGroupMember(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Update(ctx, user); err != nil {
 		logger.Error(err, "Failed to add group label to user", "userID", req.UserID, "groupName", groupName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to add user to group",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgGroupMemberAddFailed, nil)
 		return
 	}
 
 	logger.Info("Added user to group", "userID", req.UserID, "groupName", groupName)
 
 	writeJSON(w, http.StatusOK, AddGroupMemberResponse{
-		Message:   "User added to group successfully",
+		Code:      MsgGroupMemberAddSucceeded,
+		Message:   localizedMessage(w, r, MsgGroupMemberAddSucceeded, nil),
 		UserID:    req.UserID,
 		GroupName: groupName,
 	})
@@ -625,20 +526,19 @@ func (h *Handler) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract groupName and userID from path
-	groupName, userID, err := extractGroupNameAndUserIDFromPath(r.URL.Path)
+	groupName, err := pathParam(r, ParamGroupName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamGroupName, err)
+		return
+	}
+	userID, err := pathParam(r, ParamUserID)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamUserID, err)
 		return
 	}
 
@@ -646,16 +546,11 @@ func (h *Handler) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
 	group := &krknv1alpha1.KrknUserGroup{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: groupName, Namespace: h.namespace}, group); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User group '%s' not found", groupName),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserGroupNotFound,
+				i18n.Params{"name": groupName})
 		} else {
 			logger.Error(err, "Failed to get user group", "groupName", groupName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user group",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupFetchFailed, nil)
 		}
 		return
 	}
@@ -665,16 +560,11 @@ func (h *Handler) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
 	user := &krknv1alpha1.KrknUser{}
 	if err := h.client.Get(ctx, client.ObjectKey{Name: userName, Namespace: h.namespace}, user); err != nil {
 		if apierrors.IsNotFound(err) {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: fmt.Sprintf("User '%s' not found", userID),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound, i18n.Params{"userId": userID})
 		} else {
 			logger.Error(err, "Failed to get user", "userID", userID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get user",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -682,10 +572,8 @@ func (h *Handler) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
 	// Remove group label
 	labelKey := groupauth.GroupLabelKey(groupName)
 	if user.Labels == nil || user.Labels[labelKey] != "true" {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: fmt.Sprintf("User '%s' is not a member of group '%s'", userID, groupName),
-		})
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgGroupMemberNotFound,
+			i18n.Params{"userId": userID, "name": groupName})
 		return
 	}
 
@@ -693,108 +581,15 @@ func (h *Handler) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Update(ctx, user); err != nil {
 		logger.Error(err, "Failed to remove group label from user", "userID", userID, "groupName", groupName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to remove user from group",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgGroupMemberRemoveFailed, nil)
 		return
 	}
 
 	logger.Info("Removed user from group", "userID", userID, "groupName", groupName)
 
 	writeJSON(w, http.StatusOK, RemoveGroupMemberResponse{
-		Message: "User removed from group successfully",
-	})
-}
-
-// GroupsRouter routes requests to /api/v1/groups endpoints
-func (h *Handler) GroupsRouter(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
-
-	// Normalize path by removing trailing slash for root endpoint
-	normalizedPath := strings.TrimSuffix(path, "/")
-
-	// Root endpoint: /api/v1/groups or /api/v1/groups/
-	if normalizedPath == GroupsPath {
-		if r.Method == http.MethodGet {
-			h.ListUserGroups(w, r)
-			return
-		}
-
-		if r.Method == http.MethodPost {
-			h.CreateUserGroup(w, r)
-			return
-		}
-
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only GET and POST are allowed on " + GroupsPath,
-		})
-		return
-	}
-
-	// Group-specific endpoints: /api/v1/groups/:groupName
-	if strings.HasPrefix(path, GroupsPath+"/") {
-		// Members endpoints: /api/v1/groups/:groupName/members
-		// Use HasSuffix or segment matching to avoid matching group names containing "members"
-		pathAfterGroups := strings.TrimPrefix(path, GroupsPath+"/")
-		segments := strings.Split(pathAfterGroups, "/")
-
-		// Check if this is a members endpoint (second segment is "members")
-		if len(segments) >= 2 && segments[1] == "members" {
-			// DELETE /api/v1/groups/:groupName/members/:userId
-			if r.Method == http.MethodDelete && strings.Count(path, "/") == 6 {
-				h.RemoveGroupMember(w, r)
-				return
-			}
-
-			// GET /api/v1/groups/:groupName/members
-			if r.Method == http.MethodGet && strings.HasSuffix(path, "/members") {
-				h.ListGroupMembers(w, r)
-				return
-			}
-
-			// POST /api/v1/groups/:groupName/members
-			if r.Method == http.MethodPost && strings.HasSuffix(path, "/members") {
-				h.AddGroupMember(w, r)
-				return
-			}
-
-			writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-				Error:   "method_not_allowed",
-				Message: "Invalid method for members endpoint",
-			})
-			return
-		}
-
-		// GET /api/v1/groups/:groupName
-		if r.Method == http.MethodGet {
-			h.GetUserGroup(w, r)
-			return
-		}
-
-		// PATCH /api/v1/groups/:groupName
-		if r.Method == http.MethodPatch {
-			h.UpdateUserGroup(w, r)
-			return
-		}
-
-		// DELETE /api/v1/groups/:groupName
-		if r.Method == http.MethodDelete {
-			h.DeleteUserGroup(w, r)
-			return
-		}
-
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Invalid method for group endpoint",
-		})
-		return
-	}
-
-	writeJSONError(w, http.StatusNotFound, ErrorResponse{
-		Error:   "not_found",
-		Message: "Endpoint not found",
+		Code:    MsgGroupMemberRemoveSucceeded,
+		Message: localizedMessage(w, r, MsgGroupMemberRemoveSucceeded, nil),
 	})
 }
 
@@ -848,38 +643,6 @@ func validateClusterPermissions(permissions map[string]ClusterPermissionSet) err
 	return nil
 }
 
-// extractGroupNameFromMembersPath extracts group name from /api/v1/groups/:groupName/members
-func extractGroupNameFromMembersPath(path string) (string, error) {
-	// Remove prefix and suffix
-	trimmed := strings.TrimPrefix(path, GroupsPath+"/")
-	trimmed = strings.TrimSuffix(trimmed, "/members")
-
-	if trimmed == "" || strings.Contains(trimmed, "/") {
-		return "", fmt.Errorf("invalid path format")
-	}
-
-	return trimmed, nil
-}
-
-// extractGroupNameAndUserIDFromPath extracts group name and userID from /api/v1/groups/:groupName/members/:userId
-func extractGroupNameAndUserIDFromPath(path string) (string, string, error) {
-	// Expected format: /api/v1/groups/:groupName/members/:userId
-	parts := strings.Split(strings.TrimPrefix(path, GroupsPath+"/"), "/")
-
-	if len(parts) != 3 || parts[1] != "members" {
-		return "", "", fmt.Errorf("invalid path format. Expected: " + GroupsPath + "/:groupName/members/:userId")
-	}
-
-	groupName := parts[0]
-	userID := parts[2]
-
-	if groupName == "" || userID == "" {
-		return "", "", fmt.Errorf("groupName and userId cannot be empty")
-	}
-
-	return groupName, userID, nil
-}
-
 // removeGroupFromAllMembers removes the group label from all users
 func removeGroupFromAllMembers(ctx context.Context, k8sClient client.Client, groupName, namespace string) error {
 	labelKey := groupauth.GroupLabelKey(groupName)
@@ -158,7 +158,7 @@ func TestGetUserGroup_Success(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.GetUserGroup(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -186,7 +186,7 @@ func TestGetUserGroup_NotFound(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.GetUserGroup(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
@@ -333,7 +333,7 @@ func TestUpdateUserGroup_Success(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.UpdateUserGroup(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -402,7 +402,7 @@ func TestUpdateUserGroup_EmptyClusterPermissions(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.UpdateUserGroup(w, req)
+	serveAPI(handler, w, req)
 
 	// Should return 400 Bad Request, not 500 Internal Server Error
 	if w.Code != http.StatusBadRequest {
@@ -450,12 +450,24 @@ func TestDeleteUserGroup_Success(t *testing.T) {
 
 	req := httptest.NewRequest("DELETE", GroupsPath+"/dev-team", nil)
 	req = req.WithContext(createAdminContext())
+	req.Header.Set("Accept-Language", "it")
 	w := httptest.NewRecorder()
 
-	handler.DeleteUserGroup(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp DeleteUserGroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Code != MsgUserGroupDeleteSucceeded {
+		t.Errorf("Expected code %q, got %q", MsgUserGroupDeleteSucceeded, resp.Code)
+	}
+	if resp.Message != "Gruppo di utenti eliminato correttamente" {
+		t.Errorf("Expected an Italian message, got %q", resp.Message)
 	}
 }
 
@@ -518,7 +530,7 @@ func TestListGroupMembers_Success(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.ListGroupMembers(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -580,7 +592,7 @@ func TestAddGroupMember_Success(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.AddGroupMember(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -630,7 +642,7 @@ func TestRemoveGroupMember_Success(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.RemoveGroupMember(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -99,7 +99,7 @@ func TestGroupsRouterEdgeCases(t *testing.T) {
 			req = req.WithContext(ctx)
 			w := httptest.NewRecorder()
 
-			handler.GroupsRouter(w, req)
+			serveAPI(handler, w, req)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("%s: Expected status %d, got %d. Response: %s",
@@ -17,53 +17,157 @@ limitations under the License.
 package api
 
 import (
-	"bufio"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
-	"github.com/krkn-chaos/krknctl/pkg/config"
 	"github.com/krkn-chaos/krknctl/pkg/provider"
-	"github.com/krkn-chaos/krknctl/pkg/provider/factory"
 	"github.com/krkn-chaos/krknctl/pkg/provider/models"
 	"github.com/krkn-chaos/krknctl/pkg/typing"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
 	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/crdcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
 	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+	"github.com/krkn-chaos/krkn-operator/pkg/objectstore"
+	"github.com/krkn-chaos/krkn-operator/pkg/permcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/requestid"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+	"github.com/krkn-chaos/krkn-operator/pkg/usage"
 	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
 )
 
+// getNodesTimeout bounds a GetNodes call to the data provider
+const getNodesTimeout = 10 * time.Second
+
 // Handler contains the dependencies for API handlers
 type Handler struct {
-	client         client.Client
-	clientset      kubernetes.Interface
-	namespace      string
-	grpcServerAddr string
+	client       client.Client
+	clientset    kubernetes.Interface
+	namespace    string
+	dataProvider *grpcclient.Client
+	auditor      *audit.Recorder
+	crdChecker   *crdcheck.Checker
+	cors         CORSConfig
+	metricsProxy *metricsProxy
+	// permissionChecker reports the RBAC the operator misses in its namespace
+	permissionChecker *permcheck.Checker
+	// strictDecode rejects unknown request body fields unless a request opts out
+	strictDecode bool
+	// ui serves the embedded dashboard, nil when it is disabled
+	ui http.Handler
+	// logMaxLineLength is the longest log line streamed, 0 for no limit
+	logMaxLineLength int
+	// namespaceGuardrail defaults the namespace of namespaced scenarios
+	namespaceGuardrail NamespaceGuardrail
+	// lintMaxClusters is the cluster count above which the run lint warns, 0 to not check
+	lintMaxClusters int
+	// hubCapacity rejects runs the hub cannot take the scenario pods of
+	hubCapacity HubCapacityGuard
+	// authPlugins warns about or rejects runs whose image lacks the auth plugins of a target
+	authPlugins AuthPluginPolicy
+	// notifyPolicy restricts the URLs of observer channels and failure hook webhooks
+	notifyPolicy notify.Policy
+	// checkTargetConnection checks the credentials of new targets against their API
+	// server, nil to not check them
+	checkTargetConnection func(ctx context.Context, kubeconfigBase64 string) error
+	// scenarioProvider creates the registry client of the scenario endpoints
+	scenarioProvider func(mode provider.Mode) (provider.ScenarioDataProvider, error)
+	// registries serves the last scenario responses while their registry is unavailable
+	registries *registryCatalog
+	// readyzChecks are the checks of the operator readiness probe, run by the readiness
+	// endpoint; nil runs ReadyzChecks only
+	readyzChecks map[string]healthz.Checker
+	// registryCacheTTL is how long the scenario responses are served from registries
+	// before calling the registry again, 0 to always call it
+	registryCacheTTL time.Duration
+	// usage counts the API calls per endpoint for the usage report, nil when usage
+	// telemetry is off
+	usage *usage.Tracker
+	// kubeconfigCipher seals the kubeconfigs written to target Secrets and opens the
+	// sealed ones read back, nil when encryption at rest is off
+	kubeconfigCipher *envelope.Cipher
+	// credentialsStore holds the credentials of the targets the API creates instead of
+	// Secrets, nil when they are kept in Secrets
+	credentialsStore secrets.Store
+	// tokenRotator re-mints the tokens of token targets on request, nil when not available
+	tokenRotator TargetTokenRotator
+	// history holds the record of past runs served by the history endpoints, nil when
+	// the run history is off
+	history history.Store
+	// targetArchiveRetention is how long archived targets can be restored before they
+	// are purged
+	targetArchiveRetention time.Duration
+
+	scenarioRunInformer cache.Informer
+	// scenarioPolicy lists the scenarios that only run under a break-glass exemption
+	scenarioPolicy auth.ScenarioPolicy
+	// authMiddleware authorizes the WebSocket routes, which authenticate outside of it
+	// (nil skips authorization)
+	authMiddleware *auth.Middleware
 }
 
 // NewHandler creates a new Handler
 func NewHandler(client client.Client, clientset kubernetes.Interface, namespace string, grpcServerAddr string) *Handler {
 	return &Handler{
-		client:         client,
-		clientset:      clientset,
-		namespace:      namespace,
-		grpcServerAddr: grpcServerAddr,
+		client:       client,
+		clientset:    clientset,
+		namespace:    namespace,
+		dataProvider: grpcclient.NewClient(grpcServerAddr, nil),
+		auditor:      audit.NewRecorder(audit.DefaultRetention, audit.NewEventSink(clientset, namespace)),
+		cors:         CORSConfigFromEnv(),
+		metricsProxy: newMetricsProxy(MetricsProxyConfigFromEnv()),
+		strictDecode: StrictDecodingFromEnv(),
+		ui:           embeddedUIFromEnv(),
+
+		logMaxLineLength:   LogMaxLineLengthFromEnv(),
+		namespaceGuardrail: NamespaceGuardrailFromEnv(),
+		lintMaxClusters:    LintMaxClustersFromEnv(),
+		hubCapacity:        HubCapacityGuardFromEnv(),
+		authPlugins:        AuthPluginPolicyFromEnv(),
+		notifyPolicy:       notify.PolicyFromEnv(),
+
+		checkTargetConnection: checkTargetConnection,
+		scenarioProvider:      newScenarioProviderFactory(),
+		registries:            newRegistryCatalog(),
+		registryCacheTTL:      RegistryCacheTTLFromEnv(),
+
+		targetArchiveRetention: DefaultTargetArchiveRetention,
+		scenarioPolicy:         auth.ScenarioPolicyFromEnv(),
 	}
 }
 
@@ -80,48 +184,112 @@ func (h *Handler) getTokenGenerator(ctx context.Context) (*auth.TokenGenerator,
 // GetClusters handles GET /api/v1/clusters endpoint
 // It fetches the KrknTargetRequest CR by the provided ID and returns the target data
 func (h *Handler) GetClusters(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	id := r.URL.Query().Get("id")
+	id, err := queryID[TargetRequestID](r, "id")
+	if err != nil {
+		writeInvalidIDError(w, r, "id", err)
+		return
+	}
 	if id == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "id parameter is required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "id"})
+		return
+	}
+
+	targetRequest, targetData, ok := h.visibleTargetData(w, r, id)
+	if !ok {
 		return
 	}
 
+	// Return the target data (filtered for regular users, unfiltered for admins)
+	response := ClustersResponse{
+		TargetData: targetData,
+		Status:     targetRequest.Status.Status,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetClusterIDs handles GET /api/v1/clusters/ids endpoint
+// It maps the clusters of a target request to their stable identifiers, optionally
+// filtered by provider, cluster name or identifier
+func (h *Handler) GetClusterIDs(w http.ResponseWriter, r *http.Request) {
+	id, err := queryID[TargetRequestID](r, "id")
+	if err != nil {
+		writeInvalidIDError(w, r, "id", err)
+		return
+	}
+	if id == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "id"})
+		return
+	}
+	query := r.URL.Query()
+	providerFilter := query.Get("provider")
+	nameFilter := query.Get("cluster-name")
+	idFilter := query.Get("clusterId")
+
+	_, targetData, ok := h.visibleTargetData(w, r, id)
+	if !ok {
+		return
+	}
+
+	clusters := []ClusterIDMapping{}
+	for providerName, targets := range targetData {
+		if providerFilter != "" && providerName != providerFilter {
+			continue
+		}
+		for _, target := range targets {
+			if (nameFilter != "" && target.ClusterName != nameFilter) ||
+				(idFilter != "" && target.ClusterID != idFilter) {
+				continue
+			}
+			clusters = append(clusters, ClusterIDMapping{
+				ClusterID:     target.ClusterID,
+				Provider:      providerName,
+				ClusterName:   target.ClusterName,
+				ClusterAPIURL: target.ClusterAPIURL,
+			})
+		}
+	}
+	slices.SortFunc(clusters, func(a, b ClusterIDMapping) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.ClusterName, b.ClusterName))
+	})
+
+	writeJSON(w, http.StatusOK, ClusterIDsResponse{Clusters: clusters})
+}
+
+// visibleTargetData fetches the completed KrknTargetRequest id and returns its target
+// data, with the stable identifiers of the clusters, filtered for regular users. It
+// writes the error response and returns false when the request cannot be served.
+func (h *Handler) visibleTargetData(w http.ResponseWriter, r *http.Request, id TargetRequestID) (*krknv1alpha1.KrknTargetRequest, map[string][]krknv1alpha1.ClusterTarget, bool) {
+	ctx := r.Context()
+
 	// Fetch the KrknTargetRequest CR
 	var targetRequest krknv1alpha1.KrknTargetRequest
 	err := h.client.Get(ctx, types.NamespacedName{
-		Name:      id,
+		Name:      string(id),
 		Namespace: h.namespace,
 	}, &targetRequest)
 
 	if err != nil {
 		if client.IgnoreNotFound(err) == nil {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "KrknTargetRequest with id '" + id + "' not found",
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgTargetRequestNotFound,
+				i18n.Params{"id": string(id)})
 		} else {
 			log.FromContext(ctx).Error(err, "Failed to fetch KrknTargetRequest", "id", id)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to fetch KrknTargetRequest",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetRequestFetchFailed, nil)
 		}
-		return
+		return nil, nil, false
 	}
 
 	// Check if the request is completed
 	if targetRequest.Status.Status != "Completed" {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "KrknTargetRequest with id '" + id + "' is not completed",
-		})
-		return
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgTargetRequestNotCompleted,
+			i18n.Params{"id": string(id)})
+		return nil, nil, false
 	}
 
+	// Requests completed before identifiers were assigned get them here
+	targetRequest.Status.AssignClusterIDs()
+
 	// Filter clusters based on user permissions
 	// Admins see all clusters, regular users see only clusters they have 'run' permission for
 	// (this endpoint is used to select clusters for running scenarios)
@@ -145,14 +313,7 @@ func (h *Handler) GetClusters(w http.ResponseWriter, r *http.Request) {
 		}
 		targetData = filteredData
 	}
-
-	// Return the target data (filtered for regular users, unfiltered for admins)
-	response := ClustersResponse{
-		TargetData: targetData,
-		Status:     targetRequest.Status.Status,
-	}
-
-	writeJSON(w, http.StatusOK, response)
+	return &targetRequest, targetData, true
 }
 
 // GetNodes handles GET /api/v1/nodes endpoint
@@ -163,18 +324,23 @@ func (h *Handler) GetNodes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// New parameter (KrknOperatorTarget)
-	targetUUID := r.URL.Query().Get("targetUUID")
+	targetUUID, err := queryID[TargetUUID](r, "targetUUID")
+	if err != nil {
+		writeInvalidIDError(w, r, "targetUUID", err)
+		return
+	}
 
 	// Legacy parameters (KrknTargetRequest)
-	id := r.URL.Query().Get("id")
+	id, err := queryID[TargetRequestID](r, "id")
+	if err != nil {
+		writeInvalidIDError(w, r, "id", err)
+		return
+	}
 	clusterName := r.URL.Query().Get("cluster-name")
 
 	// Validate that at least one set of parameters is provided
 	if targetUUID == "" && (id == "" || clusterName == "") {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Either targetUUID (new) or id+cluster-name (legacy) parameters are required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgNodesParamsRequired, nil)
 		return
 	}
 
@@ -186,10 +352,7 @@ func (h *Handler) GetNodes(w http.ResponseWriter, r *http.Request) {
 		clusterAPIURL, err := h.getClusterAPIURL(ctx, targetUUID, id, clusterName)
 		if err != nil {
 			log.FromContext(ctx).Error(err, "Failed to get cluster API URL for permission check")
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to validate cluster permissions",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAccessCheckFailed, nil)
 			return
 		}
 
@@ -204,10 +367,7 @@ func (h *Handler) GetNodes(w http.ResponseWriter, r *http.Request) {
 		)
 		if err != nil {
 			log.FromContext(ctx).Error(err, "Failed to check cluster permissions", "userID", claims.UserID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to validate access permissions",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAccessCheckFailed, nil)
 			return
 		}
 
@@ -216,10 +376,7 @@ func (h *Handler) GetNodes(w http.ResponseWriter, r *http.Request) {
 				"userID", claims.UserID,
 				"clusterAPIURL", clusterAPIURL,
 			)
-			writeJSONError(w, http.StatusForbidden, ErrorResponse{
-				Error:   "forbidden",
-				Message: "You do not have permission to view nodes on this cluster",
-			})
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgNodesForbidden, nil)
 			return
 		}
 	}
@@ -228,27 +385,26 @@ func (h *Handler) GetNodes(w http.ResponseWriter, r *http.Request) {
 	kubeconfigBase64, err := h.getKubeconfig(ctx, targetUUID, id, clusterName)
 	if err != nil {
 		if client.IgnoreNotFound(err) == nil || strings.Contains(err.Error(), "not found") {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgTargetKubeconfigNotFound,
+				i18n.Params{"error": err.Error()})
 			return
 		}
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetKubeconfigFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	// Call gRPC service to get nodes
-	nodes, err := h.callGetNodesGRPC(kubeconfigBase64)
+	nodes, err := h.callGetNodesGRPC(ctx, kubeconfigBase64)
+	if status.Code(err) == codes.Unavailable {
+		// Still unreachable after the retries, or failing fast while its circuit is open
+		log.FromContext(ctx).Error(err, "Data provider unavailable")
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "service_unavailable", MsgDataProviderUnavailable, nil)
+		return
+	}
 	if err != nil {
 		log.FromContext(ctx).Error(err, "Failed to get nodes from gRPC service")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get nodes from gRPC service",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgNodesFetchFailed, nil)
 		return
 	}
 
@@ -260,39 +416,26 @@ func (h *Handler) GetNodes(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, response)
 }
 
-// HealthCheck handles GET /api/v1/health endpoint
-func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string]string{
-		"status": "healthy",
-	})
-}
-
 // GetTargetByUUID handles GET /api/v1/targets/{uuid} endpoint (legacy - checks KrknTargetRequest status)
 // This endpoint checks the status of a KrknTargetRequest CR created by krkn-operator-acm
 func (h *Handler) GetTargetByUUID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	uuid, err := extractPathSuffix(r.URL.Path, TargetsPath+"/")
+	uuid, err := pathID[TargetRequestID](r, ParamUUID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UUID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamUUID, err)
 		return
 	}
 
 	var targetRequest krknv1alpha1.KrknTargetRequest
 	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      uuid,
+		Name:      string(uuid),
 		Namespace: h.namespace,
 	}, &targetRequest); err != nil {
 		if client.IgnoreNotFound(err) == nil {
 			w.WriteHeader(http.StatusNotFound)
 		} else {
 			log.FromContext(ctx).Error(err, "Failed to fetch KrknTargetRequest", "uuid", uuid)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to fetch KrknTargetRequest",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetRequestFetchFailed, nil)
 		}
 		return
 	}
@@ -308,6 +451,33 @@ func (h *Handler) GetTargetByUUID(w http.ResponseWriter, r *http.Request) {
 // This endpoint triggers the krkn-operator-acm to discover and return target clusters
 func (h *Handler) PostTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+
+	// Optional body restricting the request to some providers
+	var req TargetRequestCreateRequest
+	if err := h.decodeRequestBody(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	if len(req.Providers) > 0 {
+		var providerList krknv1alpha1.KrknOperatorTargetProviderList
+		if err := h.client.List(ctx, &providerList); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list providers")
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderListFailed, nil)
+			return
+		}
+		for _, name := range req.Providers {
+			if !isProviderActive(&providerList, name) {
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderNotActive,
+					i18n.Params{"provider": name})
+				return
+			}
+		}
+	}
+
 	// Generate a new UUID
 	newUUID := uuid.New().String()
 
@@ -318,7 +488,8 @@ func (h *Handler) PostTarget(w http.ResponseWriter, r *http.Request) {
 			Namespace: h.namespace,
 		},
 		Spec: krknv1alpha1.KrknTargetRequestSpec{
-			UUID: newUUID,
+			UUID:      newUUID,
+			Providers: req.Providers,
 		},
 	}
 
@@ -326,10 +497,7 @@ func (h *Handler) PostTarget(w http.ResponseWriter, r *http.Request) {
 	err := h.client.Create(ctx, targetRequest)
 	if err != nil {
 		log.FromContext(ctx).Error(err, "Failed to create KrknTargetRequest", "uuid", newUUID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create KrknTargetRequest",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetRequestCreateFailed, nil)
 		return
 	}
 
@@ -340,19 +508,242 @@ func (h *Handler) PostTarget(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusAccepted, response)
 }
 
-// TargetsHandler handles both GET /api/v1/targets/{UUID} and POST /api/v1/targets endpoints
-// It routes to the appropriate handler based on the HTTP method
-func (h *Handler) TargetsHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method == http.MethodGet {
-		h.GetTargetByUUID(w, r)
-	} else if r.Method == http.MethodPost {
-		h.PostTarget(w, r)
-	} else {
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only GET and POST methods are allowed",
-		})
+// failureHookNamePattern matches hook names usable as a pod name suffix
+var failureHookNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// validateFailureHooks checks that hook names are valid and unique, that each hook
+// defines exactly one action and that policy accepts the webhook URLs
+func validateFailureHooks(hooks []FailureHook, policy notify.Policy) error {
+	names := make(map[string]bool, len(hooks))
+	for _, hook := range hooks {
+		if len(hook.Name) > 20 || !failureHookNamePattern.MatchString(hook.Name) {
+			return fmt.Errorf("onFailure hook name '%s' must be lowercase alphanumeric or '-', at most 20 characters", hook.Name)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("onFailure hook name '%s' is duplicated", hook.Name)
+		}
+		names[hook.Name] = true
+
+		actions := 0
+		for _, set := range []bool{hook.Image != nil, hook.Webhook != nil, hook.Report != nil} {
+			if set {
+				actions++
+			}
+		}
+		if actions != 1 {
+			return fmt.Errorf("onFailure hook '%s' must define exactly one of image, webhook or report", hook.Name)
+		}
+		if hook.Image != nil && hook.Image.Image == "" {
+			return fmt.Errorf("onFailure hook '%s': image is required", hook.Name)
+		}
+		if hook.Report != nil {
+			switch hook.Report.Severity {
+			case "", krknv1alpha1.ReportSeverityInfo, krknv1alpha1.ReportSeverityWarning, krknv1alpha1.ReportSeverityCritical:
+			default:
+				return fmt.Errorf("onFailure hook '%s': report severity must be info, warning or critical", hook.Name)
+			}
+		}
+		if hook.Webhook != nil {
+			channel := notify.Channel{Type: hook.Webhook.Type, URL: hook.Webhook.URL}
+			if channel.Type == "" {
+				channel.Type = notify.ChannelWebhook
+			}
+			if err := channel.Validate(policy); err != nil {
+				return fmt.Errorf("onFailure hook '%s': %w", hook.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// convertFailureHooks converts onFailure hooks from the API type to the CRD type
+func convertFailureHooks(hooks []FailureHook) []krknv1alpha1.FailureHook {
+	if len(hooks) == 0 {
+		return nil
+	}
+	result := make([]krknv1alpha1.FailureHook, 0, len(hooks))
+	for _, hook := range hooks {
+		converted := krknv1alpha1.FailureHook{Name: hook.Name}
+		if hook.Image != nil {
+			converted.Image = &krknv1alpha1.FailureHookImage{
+				Image:       hook.Image.Image,
+				Command:     hook.Image.Command,
+				Args:        hook.Image.Args,
+				Environment: hook.Image.Environment,
+			}
+		}
+		if hook.Webhook != nil {
+			converted.Webhook = &krknv1alpha1.FailureHookWebhook{
+				Type: hook.Webhook.Type,
+				URL:  hook.Webhook.URL,
+			}
+		}
+		if hook.Report != nil {
+			converted.Report = &krknv1alpha1.FailureHookReport{Severity: hook.Report.Severity}
+		}
+		result = append(result, converted)
 	}
+	return result
+}
+
+// s3BucketNamePattern matches S3 bucket names
+var s3BucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// validateArtifactStorage checks the artifact storage of a run, when it has one
+func validateArtifactStorage(storage *ArtifactStorage) error {
+	if storage == nil {
+		return nil
+	}
+	if _, err := objectstore.ObjectURL(storage.Endpoint, storage.Bucket, ""); err != nil {
+		return fmt.Errorf("artifacts: %w", err)
+	}
+	if !s3BucketNamePattern.MatchString(storage.Bucket) {
+		return fmt.Errorf("artifacts: bucket '%s' must be 3 to 63 lowercase alphanumeric, '.' or '-' characters", storage.Bucket)
+	}
+	if storage.CredentialsSecret == "" {
+		return errors.New("artifacts: credentialsSecret is required")
+	}
+	return nil
+}
+
+// validateElasticsearch checks the Elasticsearch telemetry of a run, when it has one. The
+// credentials Secret must hold the keys the scenario pods read, or they would not start.
+func (h *Handler) validateElasticsearch(ctx context.Context, es *ElasticsearchTelemetry) error {
+	if es == nil {
+		return nil
+	}
+	server, err := url.Parse(es.URL)
+	if err != nil || (server.Scheme != "http" && server.Scheme != "https") || server.Hostname() == "" {
+		return fmt.Errorf("elasticsearch: url '%s' must be http(s)://host[:port]", es.URL)
+	}
+	if strings.Trim(server.Path, "/") != "" || server.RawQuery != "" {
+		return fmt.Errorf("elasticsearch: url '%s' cannot have a path or query", es.URL)
+	}
+	if es.Index == "" {
+		return errors.New("elasticsearch: index is required")
+	}
+	if es.CredentialsSecret == "" {
+		return nil
+	}
+	var secret corev1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{Name: es.CredentialsSecret, Namespace: h.namespace}, &secret); err != nil {
+		return fmt.Errorf("elasticsearch: credentials secret '%s': %w", es.CredentialsSecret, err)
+	}
+	for _, key := range []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey} {
+		if _, ok := secret.Data[key]; !ok {
+			return fmt.Errorf("elasticsearch: credentials secret '%s' has no %s key", es.CredentialsSecret, key)
+		}
+	}
+	return nil
+}
+
+// defaultBearerTokenKey is the key of the health check bearer token in its Secret
+const defaultBearerTokenKey = "token"
+
+// alertSeverities are the severities krkn logs alerts at
+var alertSeverities = []string{"info", "warning", "error", "critical"}
+
+// bearerTokenKey returns the key of the bearer token of a health check in its Secret
+func bearerTokenKey(healthCheck *HealthCheck) string {
+	if healthCheck.BearerTokenKey != "" {
+		return healthCheck.BearerTokenKey
+	}
+	return defaultBearerTokenKey
+}
+
+// validateHealthCheck checks the health check of a run, when it has one. The bearer token
+// Secret must hold the key the scenario pods read, or they would not start.
+func (h *Handler) validateHealthCheck(ctx context.Context, healthCheck *HealthCheck) error {
+	if healthCheck == nil {
+		return nil
+	}
+	target, err := url.Parse(healthCheck.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return fmt.Errorf("healthCheck: url '%s' must be an http(s) URL", healthCheck.URL)
+	}
+	if healthCheck.IntervalSeconds != nil && *healthCheck.IntervalSeconds <= 0 {
+		return errors.New("healthCheck: intervalSeconds must be a positive number of seconds")
+	}
+	if healthCheck.BearerTokenSecret == "" {
+		return nil
+	}
+	var secret corev1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{Name: healthCheck.BearerTokenSecret, Namespace: h.namespace}, &secret); err != nil {
+		return fmt.Errorf("healthCheck: bearer token secret '%s': %w", healthCheck.BearerTokenSecret, err)
+	}
+	if _, ok := secret.Data[bearerTokenKey(healthCheck)]; !ok {
+		return fmt.Errorf("healthCheck: bearer token secret '%s' has no %s key", healthCheck.BearerTokenSecret, bearerTokenKey(healthCheck))
+	}
+	return nil
+}
+
+// validateAlertProfile checks the alert profile of a run, when it has one
+func validateAlertProfile(profile *AlertProfile) error {
+	if profile == nil {
+		return nil
+	}
+	if len(profile.Alerts) == 0 {
+		return errors.New("alertProfile: at least one alert is required")
+	}
+	for i, alert := range profile.Alerts {
+		if strings.TrimSpace(alert.Expr) == "" {
+			return fmt.Errorf("alertProfile: alert %d has no expr", i)
+		}
+		if !slices.Contains(alertSeverities, alert.Severity) {
+			return fmt.Errorf("alertProfile: alert %d severity '%s' must be one of %s", i, alert.Severity, strings.Join(alertSeverities, ", "))
+		}
+	}
+	return nil
+}
+
+// validateCleanupVerification checks the test namespaces of the cleanup verification of a
+// run, when it has one
+func validateCleanupVerification(verification *CleanupVerification) error {
+	if verification == nil {
+		return nil
+	}
+	for _, namespace := range verification.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("cleanupVerification: invalid namespace '%s': %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// mergeTargetClusters adds the selected clusters of each provider to the listed ones,
+// without duplicates
+func mergeTargetClusters(listed, selected map[string][]string) map[string][]string {
+	merged := make(map[string][]string, len(listed)+len(selected))
+	for providerName, clusterNames := range listed {
+		merged[providerName] = append([]string(nil), clusterNames...)
+	}
+	for providerName, clusterNames := range selected {
+		for _, clusterName := range clusterNames {
+			if !slices.Contains(merged[providerName], clusterName) {
+				merged[providerName] = append(merged[providerName], clusterName)
+			}
+		}
+	}
+	return merged
+}
+
+// archivedClusters returns the clusters of targetClusters that are archived KrknOperatorTargets
+func (h *Handler) archivedClusters(ctx context.Context, targetClusters map[string][]string) ([]string, error) {
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := h.client.List(ctx, &targets, client.InNamespace(h.namespace)); err != nil {
+		return nil, err
+	}
+	return targets.ArchivedClusters(targetClusters), nil
+}
+
+// isProviderActive reports whether the provider with the given operator name is registered and active
+func isProviderActive(providerList *krknv1alpha1.KrknOperatorTargetProviderList, operatorName string) bool {
+	for _, provider := range providerList.Items {
+		if provider.Spec.OperatorName == operatorName {
+			return provider.Spec.Active
+		}
+	}
+	return false
 }
 
 // convertInputFields converts krknctl InputField models to API InputFieldResponse format.
@@ -390,39 +781,33 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 
 // writeJSONError writes a JSON error response with the given status code
 func writeJSONError(w http.ResponseWriter, status int, err ErrorResponse) {
+	if err.RequestID == "" {
+		err.RequestID = requestid.FromResponse(w)
+	}
+
 	// Log internal server errors for debugging
 	if status >= 500 {
 		logger := log.Log.WithName("api")
-		logger.Error(fmt.Errorf("%s", err.Message), "Internal server error", "error_code", err.Error, "status", status)
+		logger.Error(fmt.Errorf("%s", err.Message), "Internal server error",
+			"error_code", err.Error, "status", status, "requestId", err.RequestID)
 	}
 	writeJSON(w, status, err)
 }
 
-// callGetNodesGRPC calls the data provider gRPC service to get nodes
-func (h *Handler) callGetNodesGRPC(kubeconfigBase64 string) ([]string, error) {
-	// Create gRPC connection
-	conn, err := grpc.NewClient(
-		h.grpcServerAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+// callGetNodesGRPC calls the data provider gRPC service to get nodes over the shared
+// connection, within getNodesTimeout of the RPC itself
+func (h *Handler) callGetNodesGRPC(ctx context.Context, kubeconfigBase64 string) ([]string, error) {
+	conn, err := h.dataProvider.Conn()
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
-	// Create context with timeout for RPC call
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, getNodesTimeout)
 	defer cancel()
 
-	// Create client
-	grpcClient := pb.NewDataProviderServiceClient(conn)
-
-	// Call GetNodes RPC
-	req := &pb.GetNodesRequest{
+	resp, err := pb.NewDataProviderServiceClient(conn).GetNodes(ctx, &pb.GetNodesRequest{
 		KubeconfigBase64: kubeconfigBase64,
-	}
-
-	resp, err := grpcClient.GetNodes(ctx, req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -432,15 +817,43 @@ func (h *Handler) callGetNodesGRPC(kubeconfigBase64 string) ([]string, error) {
 
 // parseRegistryRequest parses and validates the registry request from the HTTP body.
 // Returns the registry configuration, provider mode, and any error.
-func parseRegistryRequest(r *http.Request) (*models.RegistryV2, provider.Mode, error) {
+func (h *Handler) parseRegistryRequest(r *http.Request) (*models.RegistryV2, provider.Mode, error) {
 	if r.ContentLength == 0 {
 		return nil, provider.Quay, nil
 	}
 
 	var req ScenariosRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := h.decodeRequestBody(r, &req); err != nil {
 		return nil, provider.Quay, fmt.Errorf("invalid request body: %w", err)
 	}
+	return h.resolveRegistry(r.Context(), req)
+}
+
+// resolveRegistry returns the registry configuration of req and its provider mode:
+// the KrknRegistry named by registryRef, the inline private registry, or quay.io when
+// neither is set
+func (h *Handler) resolveRegistry(ctx context.Context, req ScenariosRequest) (*models.RegistryV2, provider.Mode, error) {
+	if req.RegistryRef != "" {
+		if req.RegistryURL != "" || req.ScenarioRepository != "" || req.Username != nil || req.Password != nil || req.Token != nil {
+			return nil, provider.Quay, fmt.Errorf("registryRef cannot be combined with an inline registry or credentials")
+		}
+		registry, credentials, err := secrets.Registry(ctx, h.client, h.namespace, req.RegistryRef)
+		if err != nil {
+			return nil, provider.Quay, err
+		}
+		resolved := &models.RegistryV2{
+			RegistryURL:        registry.Spec.RegistryURL,
+			ScenarioRepository: registry.Spec.ScenarioRepository,
+			SkipTLS:            registry.Spec.SkipTLS,
+			Insecure:           registry.Spec.Insecure,
+		}
+		if credentials.Token != "" {
+			resolved.Token = &credentials.Token
+		} else if credentials.Username != "" {
+			resolved.Username, resolved.Password = &credentials.Username, &credentials.Password
+		}
+		return resolved, provider.Private, nil
+	}
 
 	if req.RegistryURL == "" && req.ScenarioRepository == "" {
 		return nil, provider.Quay, nil
@@ -463,320 +876,416 @@ func parseRegistryRequest(r *http.Request) (*models.RegistryV2, provider.Mode, e
 	return registry, provider.Private, nil
 }
 
-// createScenarioProvider creates and returns a scenario provider instance.
-// Returns an error if config loading or provider creation fails.
-func createScenarioProvider(mode provider.Mode) (provider.ScenarioDataProvider, error) {
-	cfg, err := config.LoadConfig()
+// PostScenarios handles POST /api/v1/scenarios endpoint
+// It returns the list of available krkn scenarios from quay.io or a private registry,
+// from the cache unless the refresh query parameter is true
+func (h *Handler) PostScenarios(w http.ResponseWriter, r *http.Request) {
+	registry, mode, err := h.parseRegistryRequest(r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load krknctl config: %w", err)
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
+		return
 	}
 
-	providerFactory := factory.NewProviderFactory(&cfg)
-	scenarioProvider := providerFactory.NewInstance(mode)
-	if scenarioProvider == nil {
-		return nil, fmt.Errorf("failed to create scenario provider")
+	response, err := h.fetchScenarios(registry, mode, refreshRequested(r))
+	if err != nil {
+		h.writeRegistryFallback(w, r, catalogKey(registry, "scenarios"), err)
+		return
 	}
 
-	return scenarioProvider, nil
+	writeJSON(w, http.StatusOK, response)
 }
 
-// PostScenarios handles POST /api/v1/scenarios endpoint
-// It returns the list of available krkn scenarios from quay.io or a private registry
-func (h *Handler) PostScenarios(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	registry, mode, err := parseRegistryRequest(r)
+// PostScenarioDetail handles POST /api/v1/scenarios/detail/{scenario_name} endpoint
+// It returns detailed information about a specific scenario including input fields
+func (h *Handler) PostScenarioDetail(w http.ResponseWriter, r *http.Request) {
+	scenarioName, err := pathParam(r, ParamScenarioName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamScenarioName, err)
 		return
 	}
 
-	scenarioProvider, err := createScenarioProvider(mode)
+	registry, mode, err := h.parseRegistryRequest(r)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: err.Error(),
-		})
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
-	// Get registry images (scenario list)
-	scenarioTags, err := scenarioProvider.GetRegistryImages(registry)
-	if err != nil {
-		log.FromContext(ctx).Error(err, "Failed to get scenarios from registry", "registry", registry)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get scenarios from registry",
+	request := "detail/" + scenarioName
+	response, err := h.fetchScenarioDetail(registry, mode, request, refreshRequested(r),
+		func(scenarioProvider provider.ScenarioDataProvider) (*models.ScenarioDetail, error) {
+			return scenarioProvider.GetScenarioDetail(scenarioName, registry)
 		})
+	if err != nil {
+		h.writeRegistryFallback(w, r, catalogKey(registry, request), err)
 		return
 	}
 
-	scenarios := make([]ScenarioTag, 0)
-	if scenarioTags != nil {
-		for _, tag := range *scenarioTags {
-			scenarios = append(scenarios, ScenarioTag{
-				Name:         tag.Name,
-				Digest:       tag.Digest,
-				Size:         tag.Size,
-				LastModified: tag.LastModified,
-			})
-		}
-	}
-
-	// Return response
-	response := ScenariosResponse{
-		Scenarios: scenarios,
+	if response == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioNotFound,
+			i18n.Params{"name": scenarioName})
+		return
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
-// extractPathSuffix extracts a suffix from a URL path given a prefix.
-// Returns the suffix and an error if the path is invalid.
-func extractPathSuffix(path string, prefix string) (string, error) {
-	if len(path) <= len(prefix) {
-		return "", fmt.Errorf("path parameter is required")
+// PostScenarioGlobals handles POST /api/v1/scenarios/globals/{scenario_name} endpoint
+// It returns global environment fields for a specific scenario
+func (h *Handler) PostScenarioGlobals(w http.ResponseWriter, r *http.Request) {
+	scenarioName, err := pathParam(r, ParamScenarioName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioName, err)
+		return
 	}
 
-	suffix := path[len(prefix):]
-	if suffix == "" {
-		return "", fmt.Errorf("path parameter cannot be empty")
+	registry, mode, err := h.parseRegistryRequest(r)
+	if err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
+		return
 	}
 
-	return suffix, nil
-}
-
-// PostScenarioDetail handles POST /api/v1/scenarios/detail/{scenario_name} endpoint
-// It returns detailed information about a specific scenario including input fields
-func (h *Handler) PostScenarioDetail(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	scenarioName, err := extractPathSuffix(r.URL.Path, ScenariosDetailPath+"/")
-	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "scenario_name " + err.Error(),
+	request := "globals/" + scenarioName
+	response, err := h.fetchScenarioDetail(registry, mode, request, refreshRequested(r),
+		func(scenarioProvider provider.ScenarioDataProvider) (*models.ScenarioDetail, error) {
+			return scenarioProvider.GetGlobalEnvironment(registry, scenarioName)
 		})
+	if err != nil {
+		h.writeRegistryFallback(w, r, catalogKey(registry, request), err)
 		return
 	}
 
-	registry, mode, err := parseRegistryRequest(r)
-	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+	if response == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioGlobalsNotFound,
+			i18n.Params{"name": scenarioName})
 		return
 	}
 
-	scenarioProvider, err := createScenarioProvider(mode)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: err.Error(),
-		})
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	// Parse request body
+	var req ScenarioRunRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
-	// Get scenario detail
-	scenarioDetail, err := scenarioProvider.GetScenarioDetail(scenarioName, registry)
+	// Validate required fields
+	targetRequestID, err := ParseID[TargetRequestID](req.TargetRequestID)
 	if err != nil {
-		log.FromContext(ctx).Error(err, "Failed to get scenario detail", "scenarioName", scenarioName, "registry", registry)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get scenario detail",
-		})
+		writeInvalidIDError(w, r, "targetRequestId", err)
 		return
 	}
+	req.TargetRequestID = string(targetRequestID)
 
-	if scenarioDetail == nil {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Scenario '" + scenarioName + "' not found",
-		})
+	if len(req.TargetClusters) == 0 && len(req.TargetClusterIDs) == 0 && req.TargetSelector == "" && req.TargetGroupRef == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetsRequired, nil)
 		return
 	}
 
-	response := ScenarioDetailResponse{
-		Name:         scenarioDetail.Name,
-		Digest:       scenarioDetail.Digest,
-		Size:         scenarioDetail.Size,
-		LastModified: scenarioDetail.LastModified,
-		Title:        scenarioDetail.Title,
-		Description:  scenarioDetail.Description,
-		Fields:       convertInputFields(scenarioDetail.Fields),
+	if req.TargetGroupRef != "" {
+		if errs := validation.IsDNS1123Subdomain(req.TargetGroupRef); len(errs) > 0 {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetGroupRefInvalid,
+				i18n.Params{"name": req.TargetGroupRef, "error": strings.Join(errs, ", ")})
+			return
+		}
 	}
 
-	writeJSON(w, http.StatusOK, response)
-}
+	if req.CampaignRef != "" {
+		campaign := &krknv1alpha1.KrknCampaign{}
+		if err := h.client.Get(ctx, types.NamespacedName{Name: req.CampaignRef, Namespace: h.namespace}, campaign); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgCampaignNotFound,
+					i18n.Params{"name": req.CampaignRef})
+				return
+			}
+			logger.Error(err, "Failed to fetch campaign", "campaign", req.CampaignRef)
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCampaignFetchFailed,
+				i18n.Params{"error": err.Error()})
+			return
+		}
+	}
 
-// PostScenarioGlobals handles POST /api/v1/scenarios/globals/{scenario_name} endpoint
-// It returns global environment fields for a specific scenario
-func (h *Handler) PostScenarioGlobals(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	scenarioName, err := extractPathSuffix(r.URL.Path, ScenariosGlobalsPath+"/")
-	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "scenario_name " + err.Error(),
-		})
+	// The template fills in what the request leaves unset before anything is checked
+	var template *krknv1alpha1.KrknScenarioTemplate
+	if req.TemplateRef != "" {
+		template = &krknv1alpha1.KrknScenarioTemplate{}
+		if err := h.client.Get(ctx, types.NamespacedName{Name: req.TemplateRef, Namespace: h.namespace}, template); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTemplateNotFound,
+					i18n.Params{"name": req.TemplateRef})
+				return
+			}
+			logger.Error(err, "Failed to fetch scenario template", "template", req.TemplateRef)
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTemplateFetchFailed,
+				i18n.Params{"error": err.Error()})
+			return
+		}
+		applyTemplate(&req, template)
+	}
+
+	// The registry of the image is recorded in the run, its credentials stay in the
+	// Secret of the KrknRegistry
+	registryURL, scenarioRepository := req.RegistryURL, req.ScenarioRepository
+	if req.RegistryRef != "" {
+		registry, _, err := h.resolveRegistry(ctx, req.ScenariosRequest)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+				i18n.Params{"error": err.Error()})
+			return
+		}
+		registryURL, scenarioRepository = registry.RegistryURL, registry.ScenarioRepository
+	}
+
+	var targetSelector labels.Selector
+	if req.TargetSelector != "" {
+		if targetSelector, err = labels.Parse(req.TargetSelector); err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidLabelSelector,
+				i18n.Params{"param": "targetSelector", "error": err.Error()})
+			return
+		}
+	}
+
+	if req.ScenarioImage == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired,
+			i18n.Params{"field": "scenarioImage"})
 		return
 	}
 
-	registry, mode, err := parseRegistryRequest(r)
-	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+	if req.ScenarioName == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired,
+			i18n.Params{"field": "scenarioName"})
 		return
 	}
 
-	scenarioProvider, err := createScenarioProvider(mode)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: err.Error(),
-		})
+	if len(req.DisplayName) > maxDisplayNameLength {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgDisplayNameTooLong,
+			i18n.Params{"max": strconv.Itoa(maxDisplayNameLength)})
 		return
 	}
 
-	// Get global environment
-	globalDetail, err := scenarioProvider.GetGlobalEnvironment(registry, scenarioName)
-	if err != nil {
-		log.FromContext(ctx).Error(err, "Failed to get global environment", "registry", registry, "scenarioName", scenarioName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get global environment",
-		})
+	if err := validateFailureHooks(req.OnFailure, h.notifyPolicy); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
-	if globalDetail == nil {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Global environment for scenario '" + scenarioName + "' not found",
-		})
+	if err := validateArtifactStorage(req.Artifacts); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
-	response := ScenarioDetailResponse{
-		Name:         globalDetail.Name,
-		Digest:       globalDetail.Digest,
-		Size:         globalDetail.Size,
-		LastModified: globalDetail.LastModified,
-		Title:        globalDetail.Title,
-		Description:  globalDetail.Description,
-		Fields:       convertInputFields(globalDetail.Fields),
+	if err := h.validateElasticsearch(ctx, req.Elasticsearch); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
+		return
 	}
 
-	writeJSON(w, http.StatusOK, response)
-}
+	if err := h.validateHealthCheck(ctx, req.HealthCheck); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
 
-func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	logger := log.FromContext(ctx)
+	if err := validateAlertProfile(req.AlertProfile); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
 
-	// Parse request body
-	var req ScenarioRunRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+	if err := validateCleanupVerification(req.CleanupVerification); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
-	// Validate required fields
-	if req.TargetRequestID == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "targetRequestId is required",
-		})
+	if req.TimeoutSeconds != nil && *req.TimeoutSeconds <= 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTimeoutInvalid, nil)
 		return
 	}
 
-	if len(req.TargetClusters) == 0 {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "targetClusters is required and must contain at least one provider with clusters",
-		})
+	if req.TTLSecondsAfterFinished != nil && *req.TTLSecondsAfterFinished < 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTTLNegative, nil)
 		return
 	}
 
-	if req.ScenarioImage == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "scenarioImage is required",
-		})
+	if req.MaintenancePolicy != "" && req.MaintenancePolicy != "skip" && req.MaintenancePolicy != "wait" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgMaintenancePolicyInvalid, nil)
 		return
 	}
 
-	if req.ScenarioName == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "scenarioName is required",
-		})
+	if req.MaxConcurrency < 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgMaxConcurrencyNegative, nil)
+		return
+	}
+
+	switch corev1.PullPolicy(req.ImagePullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgImagePullPolicyInvalid, nil)
 		return
 	}
 
+	// Fetch KrknTargetRequest once: it resolves the selector, cluster IDs and target group,
+	// builds the cluster API URL mapping and is used to validate permissions
+	targetRequest := &krknv1alpha1.KrknTargetRequest{}
+	if err := h.client.Get(ctx, types.NamespacedName{
+		Name:      req.TargetRequestID,
+		Namespace: h.namespace,
+	}, targetRequest); err != nil {
+		logger.Error(err, "Failed to fetch target request", "targetRequestId", req.TargetRequestID)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetRequestFetchFailed, nil)
+		return
+	}
+
+	// Targets of the target request matching the selector join the listed ones
+	if targetSelector != nil {
+		selected := targetRequest.Status.SelectTargets(targetSelector)
+		if len(selected) == 0 {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetSelectorNoMatch,
+				i18n.Params{"selector": req.TargetSelector})
+			return
+		}
+		req.TargetClusters = mergeTargetClusters(req.TargetClusters, selected)
+	}
+
+	// The controller resolves the members of the target group again when the run starts:
+	// the current ones are validated and checked for permissions like the listed clusters
+	specClusters := req.TargetClusters
+
+	// Clusters listed by identifier are resolved again when the run starts as well, so the
+	// run still finds them once renamed
+	if len(req.TargetClusterIDs) > 0 {
+		byID := map[string][]string{}
+		for _, clusterID := range req.TargetClusterIDs {
+			providerName, cluster, ok := targetRequest.Status.ClusterByID(clusterID)
+			if !ok {
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetClusterIDUnknown,
+					i18n.Params{"clusterId": clusterID})
+				return
+			}
+			byID[providerName] = append(byID[providerName], cluster.ClusterName)
+		}
+		req.TargetClusters = mergeTargetClusters(req.TargetClusters, byID)
+	}
+
+	if req.TargetGroupRef != "" {
+		group := &krknv1alpha1.KrknTargetGroup{}
+		if err := h.client.Get(ctx, types.NamespacedName{Name: req.TargetGroupRef, Namespace: h.namespace}, group); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetGroupNotFound,
+					i18n.Params{"name": req.TargetGroupRef})
+				return
+			}
+			logger.Error(err, "Failed to fetch target group", "targetGroup", req.TargetGroupRef)
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetGroupFetchFailed, nil)
+			return
+		}
+		members, err := group.Members(&targetRequest.Status)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetGroupSelectorInvalid,
+				i18n.Params{"name": req.TargetGroupRef, "error": err.Error()})
+			return
+		}
+		if len(members) == 0 {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetGroupNoMembers,
+				i18n.Params{"name": req.TargetGroupRef})
+			return
+		}
+		req.TargetClusters = mergeTargetClusters(req.TargetClusters, members)
+	}
+
 	// Validate cluster names across all providers (no duplicates or empty strings)
 	seen := make(map[string]string) // map[clusterName]providerName
 	for providerName, clusterNames := range req.TargetClusters {
 		if providerName == "" {
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: "provider names cannot be empty",
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderNameEmpty, nil)
 			return
 		}
 		if len(clusterNames) == 0 {
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: "provider '" + providerName + "' must have at least one cluster",
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderNoClusters,
+				i18n.Params{"provider": providerName})
 			return
 		}
 		for _, clusterName := range clusterNames {
 			if clusterName == "" {
-				writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-					Error:   "bad_request",
-					Message: "cluster names cannot be empty",
-				})
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgClusterNameEmpty, nil)
 				return
 			}
 			if existingProvider, exists := seen[clusterName]; exists {
-				writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-					Error:   "bad_request",
-					Message: "cluster '" + clusterName + "' appears in multiple providers: '" + existingProvider + "' and '" + providerName + "'",
-				})
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgClusterInMultipleProviders,
+					i18n.Params{"cluster": clusterName, "provider": existingProvider, "otherProvider": providerName})
 				return
 			}
 			seen[clusterName] = providerName
 		}
 	}
 
-	// Fetch KrknTargetRequest to build cluster API URL mapping and validate permissions
-	targetRequest := &krknv1alpha1.KrknTargetRequest{}
-	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      req.TargetRequestID,
-		Namespace: h.namespace,
-	}, targetRequest); err != nil {
-		logger.Error(err, "Failed to fetch target request", "targetRequestId", req.TargetRequestID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to fetch target request",
-		})
-		return
+	// Alternates belong to the provider of their target cluster and are no targets themselves
+	accessClusters := req.TargetClusters
+	if len(req.Alternates) > 0 {
+		accessClusters = make(map[string][]string, len(req.TargetClusters))
+		for providerName, clusterNames := range req.TargetClusters {
+			accessClusters[providerName] = append([]string(nil), clusterNames...)
+		}
+	}
+	for clusterName, alternate := range req.Alternates {
+		providerName, ok := seen[clusterName]
+		if !ok {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgAlternateNotTarget,
+				i18n.Params{"cluster": clusterName})
+			return
+		}
+		if _, targeted := seen[alternate]; targeted || alternate == "" {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgAlternateIsTarget,
+				i18n.Params{"cluster": clusterName})
+			return
+		}
+		accessClusters[providerName] = append(accessClusters[providerName], alternate)
 	}
 
 	// Check if target request is completed
 	if targetRequest.Status.Status != "Completed" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Target request is not completed yet",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetRequestNotReady, nil)
+		return
+	}
+	if targetRequest.Labels[krknv1alpha1.TargetRequestDryRunLabel] == "true" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetRequestSandbox, nil)
+		return
+	}
+
+	// Archived targets are blocked from runs, alternates included
+	archived, err := h.archivedClusters(ctx, accessClusters)
+	if err != nil {
+		logger.Error(err, "Failed to list targets")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetListFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	if len(archived) > 0 {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetsArchived,
+			i18n.Params{"clusters": strings.Join(archived, ", ")})
 		return
 	}
 
@@ -790,17 +1299,15 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 			h.client,
 			userClaims.UserID,
 			h.namespace,
-			req.TargetClusters,
+			accessClusters,
 			targetRequest,
 		); err != nil {
 			logger.Info("User lacks permission to run scenarios on requested clusters",
 				"userID", userClaims.UserID,
 				"error", err.Error(),
 			)
-			writeJSONError(w, http.StatusForbidden, ErrorResponse{
-				Error:   "forbidden",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunClustersForbidden,
+				i18n.Params{"error": err.Error()})
 			return
 		}
 
@@ -810,17 +1317,86 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 
+	// Kubeconfigs with exec plugins only work in scenario images that bundle the plugin
+	var warnings []LintWarning
+	mismatches, err := h.authPluginMismatches(ctx, req.TargetRequestID, accessClusters, req.ScenarioImage)
+	if err != nil {
+		// Best effort: the jobs report kubeconfigs they cannot use anyway
+		logger.Error(err, "Failed to check the auth plugins of the target clusters")
+	}
+	for _, mismatch := range mismatches {
+		if h.authPlugins.Reject {
+			writeLocalizedError(w, r, http.StatusUnprocessableEntity, "incompatible_auth_plugin", MsgAuthPluginMissing,
+				mismatch.params(req.ScenarioImage))
+			return
+		}
+		warnings = append(warnings, LintWarning{
+			Code:    MsgAuthPluginMissing,
+			Field:   "scenarioImage",
+			Message: messageCatalog.Render(requestLanguage(r), MsgAuthPluginMissing, mismatch.params(req.ScenarioImage)),
+		})
+	}
+
+	// One scenario pod per target cluster runs on the hub
+	shortage, err := h.hubCapacity.check(ctx, h.client, h.clientset, h.namespace, len(seen))
+	if err != nil {
+		logger.Error(err, "Failed to check hub capacity")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgHubCapacityCheckFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	if shortage != nil {
+		logger.Info("Rejected scenario run exceeding hub capacity",
+			"scenarioName", req.ScenarioName,
+			"reason", shortage.code,
+			"requestedPods", shortage.requested,
+			"availablePods", shortage.available)
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "insufficient_capacity", shortage.code, shortage.params())
+		return
+	}
+
+	// Keep runs of namespaced scenarios without NAMESPACE off the whole cluster
+	environment, defaultedNamespace := h.namespaceGuardrail.apply(req.ScenarioName, req.Environment, req.AllowAllNamespaces)
+	if defaultedNamespace != "" {
+		logger.Info("Defaulted scenario namespace",
+			"scenarioName", req.ScenarioName,
+			"namespace", defaultedNamespace)
+	}
+
+	// Reject values the scenario would fail on only once its pods run
+	scenarioFields := h.scenarioFields(ctx, &req)
+	if fieldErrors := validateEnvironment(requestLanguage(r), scenarioFields, environment); len(fieldErrors) > 0 {
+		logger.Info("Rejected scenario run with an invalid environment",
+			"scenarioName", req.ScenarioName,
+			"invalidFields", len(fieldErrors))
+		writeEnvironmentError(w, r, req.ScenarioName, fieldErrors)
+		return
+	}
+
+	// Restricted scenarios only run under a break-glass exemption, admins included
+	exemption, ok := h.checkBreakGlass(w, r, &req, accessClusters)
+	if !ok {
+		return
+	}
+
 	// Generate scenario run name
 	scenarioRunName := fmt.Sprintf("%s-%s", req.ScenarioName, uuid.New().String()[:8])
 
+	// The run number is for humans, the name stays the key: a run is created without one
+	// rather than failed when the counters cannot be updated
+	runNumber, err := h.allocateRunNumber(ctx, req.ScenarioName)
+	if err != nil {
+		logger.Error(err, "Failed to allocate a run number", "scenarioName", req.ScenarioName)
+	}
+
 	// Create KrknScenarioRun CR
 	// Extract user claims for ownership tracking (defensive check for tests)
 	claims := auth.GetClaimsFromContext(ctx)
 
-	labels := make(map[string]string)
+	runLabels := make(map[string]string)
 	ownerUserID := ""
 	if claims != nil {
-		labels["krkn.krkn-chaos.dev/owner-user"] = sanitizeUserID(claims.UserID)
+		runLabels[joblabels.OwnerUser] = sanitizeUserID(claims.UserID)
 		ownerUserID = claims.UserID
 	}
 
@@ -828,18 +1404,34 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      scenarioRunName,
 			Namespace: h.namespace,
-			Labels:    labels,
+			Labels:    runLabels,
 		},
 		Spec: krknv1alpha1.KrknScenarioRunSpec{
-			TargetRequestID:    req.TargetRequestID,
-			OwnerUserID:        ownerUserID,
-			TargetClusters:     req.TargetClusters,
-			ScenarioName:       req.ScenarioName,
-			ScenarioImage:      req.ScenarioImage,
-			KubeconfigPath:     req.KubeconfigPath,
-			Environment:        req.Environment,
-			RegistryURL:        req.RegistryURL,
-			ScenarioRepository: req.ScenarioRepository,
+			TargetRequestID:         req.TargetRequestID,
+			OwnerUserID:             ownerUserID,
+			RunNumber:               runNumber,
+			DisplayName:             req.DisplayName,
+			TargetClusters:          specClusters,
+			TargetClusterIDs:        req.TargetClusterIDs,
+			TargetSelector:          req.TargetSelector,
+			TargetGroupRef:          req.TargetGroupRef,
+			CampaignRef:             req.CampaignRef,
+			Alternates:              req.Alternates,
+			TemplateRef:             req.TemplateRef,
+			ScenarioName:            req.ScenarioName,
+			ScenarioImage:           req.ScenarioImage,
+			ImagePullPolicy:         req.ImagePullPolicy,
+			KubeconfigPath:          req.KubeconfigPath,
+			Environment:             environment,
+			SecretEnvironment:       secretVariables(scenarioFields, environment, req.SecretEnvironment),
+			RegistryURL:             registryURL,
+			ScenarioRepository:      scenarioRepository,
+			RegistryRef:             req.RegistryRef,
+			TimeoutSeconds:          req.TimeoutSeconds,
+			MaintenancePolicy:       req.MaintenancePolicy,
+			MaxConcurrency:          req.MaxConcurrency,
+			EvictionProtection:      req.EvictionProtection,
+			TTLSecondsAfterFinished: req.TTLSecondsAfterFinished,
 		},
 	}
 
@@ -855,6 +1447,57 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if template != nil {
+		template.Render(&scenarioRun.Spec)
+	}
+
+	scenarioRun.Spec.OnFailure = convertFailureHooks(req.OnFailure)
+	if req.Artifacts != nil {
+		scenarioRun.Spec.Artifacts = &krknv1alpha1.ArtifactStorage{
+			Endpoint:          req.Artifacts.Endpoint,
+			Region:            req.Artifacts.Region,
+			Bucket:            req.Artifacts.Bucket,
+			Prefix:            req.Artifacts.Prefix,
+			CredentialsSecret: req.Artifacts.CredentialsSecret,
+		}
+	}
+	if req.Elasticsearch != nil {
+		scenarioRun.Spec.Elasticsearch = &krknv1alpha1.ElasticsearchTelemetry{
+			URL:               req.Elasticsearch.URL,
+			Index:             req.Elasticsearch.Index,
+			MetricsIndex:      req.Elasticsearch.MetricsIndex,
+			AlertsIndex:       req.Elasticsearch.AlertsIndex,
+			CredentialsSecret: req.Elasticsearch.CredentialsSecret,
+			VerifyCerts:       req.Elasticsearch.VerifyCerts,
+		}
+	}
+	if req.HealthCheck != nil {
+		scenarioRun.Spec.HealthCheck = &krknv1alpha1.HealthCheck{
+			URL:             req.HealthCheck.URL,
+			IntervalSeconds: req.HealthCheck.IntervalSeconds,
+			ExitOnFailure:   req.HealthCheck.ExitOnFailure,
+			VerifyCerts:     req.HealthCheck.VerifyCerts,
+		}
+		if req.HealthCheck.BearerTokenSecret != "" {
+			scenarioRun.Spec.HealthCheck.BearerTokenSecret = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: req.HealthCheck.BearerTokenSecret},
+				Key:                  bearerTokenKey(req.HealthCheck),
+			}
+		}
+	}
+	if req.AlertProfile != nil {
+		scenarioRun.Spec.AlertProfile = &krknv1alpha1.AlertProfile{FailOnCritical: req.AlertProfile.FailOnCritical}
+		for _, alert := range req.AlertProfile.Alerts {
+			scenarioRun.Spec.AlertProfile.Alerts = append(scenarioRun.Spec.AlertProfile.Alerts, krknv1alpha1.AlertRule(alert))
+		}
+	}
+	if req.CleanupVerification != nil {
+		scenarioRun.Spec.CleanupVerification = &krknv1alpha1.CleanupVerification{
+			Enabled:    req.CleanupVerification.Enabled,
+			Namespaces: req.CleanupVerification.Namespaces,
+		}
+	}
+
 	// Set optional registry auth fields
 	if req.Token != nil {
 		scenarioRun.Spec.Token = *req.Token
@@ -866,15 +1509,68 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 		scenarioRun.Spec.Password = *req.Password
 	}
 
+	// Same defaults as the defaulting webhook, which may not be installed
+	scenarioRun.Default()
+
+	// Secrets created for the run: deleted when the run cannot be created, owned by it otherwise
+	var runSecrets []*corev1.Secret
+	deleteRunSecrets := func() {
+		for _, secret := range runSecrets {
+			if err := h.client.Delete(ctx, secret); err != nil {
+				logger.Error(err, "Failed to delete secret of the run", "scenarioRunName", scenarioRunName, "secret", secret.Name)
+			}
+		}
+	}
+
+	// The values of the secret variables stay out of the run, which only names them
+	secretEnvironment, err := h.createSecretEnvironment(ctx, scenarioRun)
+	if err != nil {
+		logger.Error(err, "Failed to store secret environment", "scenarioRunName", scenarioRunName)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunCreateFailed, nil)
+		return
+	}
+	if secretEnvironment != nil {
+		runSecrets = append(runSecrets, secretEnvironment)
+	}
+
+	// The validating webhook checks the exemption again against its record
+	if exemption != nil {
+		if scenarioRun.Annotations == nil {
+			scenarioRun.Annotations = make(map[string]string)
+		}
+		scenarioRun.Annotations[auth.BreakGlassAnnotation] = exemption.ID
+		breakGlassRecord, err := h.createBreakGlassRecord(ctx, scenarioRunName, r.Header.Get(auth.BreakGlassHeader))
+		if err != nil {
+			logger.Error(err, "Failed to record break-glass exemption", "scenarioRunName", scenarioRunName)
+			deleteRunSecrets()
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunCreateFailed, nil)
+			return
+		}
+		runSecrets = append(runSecrets, breakGlassRecord)
+	}
+
 	// Create the CR
 	if err := h.client.Create(ctx, scenarioRun); err != nil {
 		logger.Error(err, "Failed to create scenario run", "scenarioRunName", scenarioRunName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create scenario run",
-		})
+		deleteRunSecrets()
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunCreateFailed, nil)
 		return
 	}
+	if exemption != nil {
+		logger.Info("Scenario run created under break-glass exemption",
+			"scenarioRunName", scenarioRunName,
+			"exemption", exemption.ID)
+		h.recordBreakGlass(r, auditActionBreakGlassUse, ScenariosRunPath+"/"+scenarioRunName, http.StatusCreated, exemption)
+	}
+
+	// The secrets go away with the run
+	for _, secret := range runSecrets {
+		if err := ctrl.SetControllerReference(scenarioRun, secret, h.client.Scheme()); err != nil {
+			logger.Error(err, "failed to set owner reference on secret of the run", "scenarioRun", scenarioRun.Name, "secret", secret.Name)
+		} else if err := h.client.Update(ctx, secret); err != nil {
+			logger.Error(err, "failed to update secret of the run with owner reference", "scenarioRun", scenarioRun.Name, "secret", secret.Name)
+		}
+	}
 
 	// Set owner reference: ScenarioRun owns KrknTargetRequest
 	// This ensures KrknTargetRequest (and its Secret) are cleaned up when ScenarioRun is deleted
@@ -904,10 +1600,14 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := ScenarioRunCreateResponse{
-		ScenarioRunName: scenarioRunName,
-		TargetClusters:  req.TargetClusters,
-		TotalTargets:    totalTargets,
-		OwnerUserID:     ownerUserID,
+		ScenarioRunName:    scenarioRunName,
+		RunNumber:          runNumber,
+		DisplayName:        req.DisplayName,
+		TargetClusters:     req.TargetClusters,
+		TotalTargets:       totalTargets,
+		OwnerUserID:        ownerUserID,
+		DefaultedNamespace: defaultedNamespace,
+		Warnings:           warnings,
 	}
 
 	writeJSON(w, http.StatusCreated, response)
@@ -916,12 +1616,9 @@ func (h *Handler) PostScenarioRun(w http.ResponseWriter, r *http.Request) {
 // GetScenarioRunStatus handles GET /api/v1/scenarios/run/{scenarioRunName} endpoint
 // It returns the current status of a scenario run
 func (h *Handler) GetScenarioRunStatus(w http.ResponseWriter, r *http.Request) {
-	scenarioRunName, err := extractPathSuffix(r.URL.Path, ScenariosRunPath+"/")
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "scenarioRunName " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
 		return
 	}
 
@@ -930,107 +1627,143 @@ func (h *Handler) GetScenarioRunStatus(w http.ResponseWriter, r *http.Request) {
 	// Fetch the KrknScenarioRun CR
 	var scenarioRun krknv1alpha1.KrknScenarioRun
 	err = h.client.Get(ctx, client.ObjectKey{
-		Name:      scenarioRunName,
+		Name:      string(scenarioRunName),
 		Namespace: h.namespace,
 	}, &scenarioRun)
 
 	if err != nil {
-		status := http.StatusInternalServerError
-		errMsg := "Failed to fetch scenario run: " + err.Error()
-		errCode := "internal_error"
-
 		if client.IgnoreNotFound(err) == nil {
-			status = http.StatusNotFound
-			errMsg = "Scenario run '" + scenarioRunName + "' not found"
-			errCode = "not_found"
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+				i18n.Params{"name": string(scenarioRunName)})
+			return
 		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
 
-		writeJSONError(w, status, ErrorResponse{Error: errCode, Message: errMsg})
+	response, err := h.scenarioRunStatusResponse(ctx, &scenarioRun)
+	if errors.Is(err, errScenarioRunForbidden) {
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunJobsForbidden, nil)
+		return
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to fetch user groups")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserGroupsFetchFailed, nil)
 		return
 	}
 
+	// A user sees no job only while the controller has not processed a new run yet
+	status := http.StatusOK
+	if !auth.IsAdmin(ctx) && auth.GetClaimsFromContext(ctx) != nil && len(response.ClusterJobs) == 0 {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, response)
+}
+
+// errScenarioRunForbidden is returned when the user may view none of the jobs of a run
+var errScenarioRunForbidden = errors.New("no permission on any job of the scenario run")
+
+// visibleJobs returns the jobs of a run visible to the caller: admins see every job,
+// users only the jobs on clusters they may view.
+// It returns errScenarioRunForbidden when the user may view none of the run's jobs.
+func (h *Handler) visibleJobs(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+) ([]krknv1alpha1.ClusterJobStatus, error) {
 	claims := auth.GetClaimsFromContext(ctx)
+	if claims == nil || auth.IsAdmin(ctx) {
+		return scenarioRun.Status.ClusterJobs, nil
+	}
 
-	// Filter jobs based on permissions (admins see all, users see only authorized jobs)
-	filteredJobs := scenarioRun.Status.ClusterJobs
-	if claims != nil && !auth.IsAdmin(ctx) {
-		// Fetch user groups
-		userGroups, err := groupauth.GetUserGroups(ctx, h.client, claims.UserID, h.namespace)
-		if err != nil {
-			log.FromContext(ctx).Error(err, "Failed to fetch user groups", "userID", claims.UserID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to fetch user groups",
-			})
-			return
-		}
+	// Fetch user groups
+	userGroups, err := groupauth.GetUserGroups(ctx, h.client, claims.UserID, h.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch groups of user %s: %w", claims.UserID, err)
+	}
 
-		// Count jobs with ClusterAPIURL populated (to detect newly created runs)
-		jobsWithClusterURL := 0
-		for _, job := range scenarioRun.Status.ClusterJobs {
-			if job.ClusterAPIURL != "" {
-				jobsWithClusterURL++
-			}
+	// Count jobs with ClusterAPIURL populated (to detect newly created runs)
+	jobsWithClusterURL := 0
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		if job.ClusterAPIURL != "" {
+			jobsWithClusterURL++
 		}
+	}
 
-		// Filter jobs to only those user has view permission for
-		filteredJobs = h.filterJobsByPermission(
-			scenarioRun.Status.ClusterJobs,
-			ctx,
-			userGroups,
-			groupauth.ActionView,
-		)
+	// Filter jobs to only those user has view permission for
+	filteredJobs := h.filterJobsByPermission(
+		scenarioRun.Status.ClusterJobs,
+		ctx,
+		userGroups,
+		groupauth.ActionView,
+	)
 
-		// Explicit authorization checks based on job state:
-		if len(filteredJobs) == 0 {
-			if jobsWithClusterURL == 0 {
-				// Case 1: No jobs have ClusterAPIURL (run just created, controller hasn't processed yet)
-				// Allow access and return 201 Created with empty jobs array
-				response := ScenarioRunStatusResponse{
-					ScenarioRunName: scenarioRunName,
-					Phase:           scenarioRun.Status.Phase,
-					TotalTargets:    scenarioRun.Status.TotalTargets,
-					SuccessfulJobs:  scenarioRun.Status.SuccessfulJobs,
-					FailedJobs:      scenarioRun.Status.FailedJobs,
-					RunningJobs:     scenarioRun.Status.RunningJobs,
-					ClusterJobs:     []ClusterJobStatusResponse{},
-					OwnerUserID:     scenarioRun.Spec.OwnerUserID,
-				}
-				writeJSON(w, http.StatusCreated, response)
-				return
-			} else {
-				// Case 2: Jobs have ClusterAPIURL but user has no permission on any
-				// Deny access with 403 Forbidden
-				writeJSONError(w, http.StatusForbidden, ErrorResponse{
-					Error:   "forbidden",
-					Message: "Access denied. You do not have permission to view jobs in this scenario run",
-				})
-				return
-			}
-		}
+	// Jobs have ClusterAPIURL but user has no permission on any.
+	// When no job has ClusterAPIURL yet (run just created, controller hasn't processed
+	// it yet), access is allowed with an empty jobs array.
+	if len(filteredJobs) == 0 && jobsWithClusterURL > 0 {
+		return nil, errScenarioRunForbidden
+	}
+	return filteredJobs, nil
+}
+
+// scenarioRunStatusResponse builds the status of a run as visible to the caller.
+// It returns errScenarioRunForbidden when the user may view none of the run's jobs.
+func (h *Handler) scenarioRunStatusResponse(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+) (ScenarioRunStatusResponse, error) {
+	filteredJobs, err := h.visibleJobs(ctx, scenarioRun)
+	if err != nil {
+		return ScenarioRunStatusResponse{}, err
 	}
 
 	// Convert filtered ClusterJobStatus to response type
 	clusterJobs := make([]ClusterJobStatusResponse, len(filteredJobs))
 	for i, job := range filteredJobs {
-		clusterJobs[i] = ClusterJobStatusResponse{
-			ProviderName:    job.ProviderName,
-			ClusterName:     job.ClusterName,
-			JobID:           job.JobID,
-			PodName:         job.PodName,
-			Phase:           job.Phase,
-			Message:         job.Message,
-			StartTime:       convertMetaTime(job.StartTime),
-			CompletionTime:  convertMetaTime(job.CompletionTime),
-			RetryCount:      job.RetryCount,
-			MaxRetries:      job.MaxRetries,
-			CancelRequested: job.CancelRequested,
-			FailureReason:   job.FailureReason,
-		}
-	}
-
-	response := ScenarioRunStatusResponse{
-		ScenarioRunName: scenarioRunName,
+		clusterJobs[i] = clusterJobStatusResponse(&job)
+	}
+
+	// Retry waves only list the clusters whose jobs are visible
+	visibleClusters := make(map[string]bool, len(filteredJobs))
+	for _, job := range filteredJobs {
+		visibleClusters[job.ClusterName] = true
+	}
+	var retryWaves []RetryWaveResponse
+	for _, wave := range scenarioRun.Status.RetryWaves {
+		waveResponse := retryWaveResponse(wave)
+		waveResponse.Clusters = nil
+		for _, clusterName := range wave.Clusters {
+			if visibleClusters[clusterName] {
+				waveResponse.Clusters = append(waveResponse.Clusters, clusterName)
+			}
+		}
+		if len(waveResponse.Clusters) > 0 {
+			retryWaves = append(retryWaves, waveResponse)
+		}
+	}
+
+	// The snapshot of the targets only lists the visible clusters as well
+	var resolvedTargets *krknv1alpha1.TargetSnapshot
+	if snapshot := scenarioRun.Status.ResolvedTargets; snapshot != nil {
+		resolvedTargets = &krknv1alpha1.TargetSnapshot{Selector: snapshot.Selector, ResolvedAt: snapshot.ResolvedAt}
+		for _, target := range snapshot.Targets {
+			if visibleClusters[target.ClusterName] {
+				resolvedTargets.Targets = append(resolvedTargets.Targets, target)
+			}
+		}
+	}
+
+	var expiresAt *time.Time
+	if completion, ttl := scenarioRun.Status.CompletionTime, scenarioRun.Spec.TTLSecondsAfterFinished; completion != nil && ttl != nil {
+		expiry := completion.Add(time.Duration(*ttl) * time.Second)
+		expiresAt = &expiry
+	}
+
+	return ScenarioRunStatusResponse{
+		ScenarioRunName: scenarioRun.Name,
+		RunNumber:       scenarioRun.Spec.RunNumber,
+		DisplayName:     scenarioRun.Spec.DisplayName,
 		Phase:           scenarioRun.Status.Phase,
 		TotalTargets:    scenarioRun.Status.TotalTargets,
 		SuccessfulJobs:  scenarioRun.Status.SuccessfulJobs,
@@ -1038,19 +1771,18 @@ func (h *Handler) GetScenarioRunStatus(w http.ResponseWriter, r *http.Request) {
 		RunningJobs:     scenarioRun.Status.RunningJobs,
 		ClusterJobs:     clusterJobs,
 		OwnerUserID:     scenarioRun.Spec.OwnerUserID,
-	}
-
-	writeJSON(w, http.StatusOK, response)
+		RetryWaves:      retryWaves,
+		ResolvedTargets: resolvedTargets,
+		CompletionTime:  convertMetaTime(scenarioRun.Status.CompletionTime),
+		ExpiresAt:       expiresAt,
+		Conditions:      scenarioRun.Status.Conditions,
+	}, nil
 }
 
 // WebSocket upgrader
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow all origins for now - in production you should validate the origin
-		return true
-	},
 	// Support "access_token" subprotocol for JWT authentication
 	Subprotocols: []string{"access_token"},
 }
@@ -1086,20 +1818,17 @@ func isWebSocketDisconnectError(err error) bool {
 	return false
 }
 
-// GetScenarioRunLogs handles GET /api/v1/scenarios/run/{scenarioRunName}/jobs/{jobID}/logs endpoint
-// It streams the stdout/stderr logs of a running or completed job via WebSocket
-func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
-	logger := log.Log.WithName("websocket-logs")
-
-	logger.Info("🔌 WebSocket connection request received",
-		"path", r.URL.Path,
-		"client_ip", r.RemoteAddr,
-		"user_agent", r.Header.Get("User-Agent"))
+// authenticateWebSocket validates the JWT a browser sends in the Sec-WebSocket-Protocol
+// header, since WebSocket clients cannot set Authorization: new WebSocket(url, `access_token.${jwt}`).
+// It returns the claims and the protocol to echo back on upgrade. On failure the error
+// response has been written and ok is false.
+func (h *Handler) authenticateWebSocket(w http.ResponseWriter, r *http.Request) (claims *auth.Claims, protocols string, ok bool) {
+	logger := log.Log.WithName("websocket-auth")
 
 	// Extract JWT token from WebSocket subprotocol header BEFORE upgrade
 	// Frontend sends: new WebSocket(url, `access_token.${jwt_token}`)
 	// Format: "access_token.<jwt_token>"
-	protocols := r.Header.Get("Sec-WebSocket-Protocol")
+	protocols = r.Header.Get("Sec-WebSocket-Protocol")
 	logger.V(1).Info("📋 Received WebSocket headers",
 		"Sec-WebSocket-Protocol", protocols,
 		"Sec-WebSocket-Version", r.Header.Get("Sec-WebSocket-Version"),
@@ -1111,7 +1840,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 			"client_ip", r.RemoteAddr,
 			"headers", r.Header)
 		http.Error(w, "Unauthorized: Missing Sec-WebSocket-Protocol header", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	// Parse protocol: split on first '.' to separate prefix from token
@@ -1144,7 +1873,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 			"expected_format", "access_token.<jwt>",
 			"client_ip", r.RemoteAddr)
 		http.Error(w, "Unauthorized: Invalid Sec-WebSocket-Protocol format. Expected: access_token.<jwt>", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	token := protocolParts[1]
@@ -1153,7 +1882,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 			"path", r.URL.Path,
 			"client_ip", r.RemoteAddr)
 		http.Error(w, "Unauthorized: Missing authentication token", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	// Mask token for logging (show first/last 10 chars)
@@ -1174,11 +1903,11 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		logger.Error(err, "❌ Failed to get TokenGenerator for WebSocket auth")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
+		return nil, "", false
 	}
 
 	logger.Info("🔐 Validating JWT token")
-	claims, err := tokenGen.ValidateToken(token)
+	claims, err = tokenGen.ValidateToken(token)
 	if err != nil {
 		logger.Info("❌ WebSocket authentication failed: invalid token",
 			"path", r.URL.Path,
@@ -1186,7 +1915,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 			"token_preview", maskedToken,
 			"client_ip", r.RemoteAddr)
 		http.Error(w, "Unauthorized: Invalid or expired token", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	logger.Info("✅ WebSocket authentication successful",
@@ -1195,6 +1924,67 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 		"path", r.URL.Path,
 		"client_ip", r.RemoteAddr)
 
+	// The authorizer decides on WebSocket routes like on the other authenticated ones
+	if h.authMiddleware != nil && !h.authMiddleware.Authorize(w, r, claims) {
+		return nil, "", false
+	}
+
+	return claims, protocols, true
+}
+
+// GetScenarioRunLogs handles GET /api/v1/scenarios/run/{scenarioRunName}/jobs/{jobID}/logs endpoint
+// It streams the stdout/stderr logs of a running or completed job via WebSocket.
+// With format=json every line is sent as a LogLineFrame and errors as LogControlFrames.
+// After a dropped connection, clients resume with sinceTime (the timestamp of the last
+// line received, with timestamps=true) or sinceSeconds instead of re-reading the whole log.
+// With followRun=true (implies follow=true), the stream does not end with a failed pod:
+// the pods of the retries of the job are streamed after an attempt frame, a
+// "--- attempt N ---" line in text mode, until the job reaches a terminal phase.
+func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
+	logger := log.Log.WithName("websocket-logs")
+
+	logger.Info("🔌 WebSocket connection request received",
+		"path", r.URL.Path,
+		"client_ip", r.RemoteAddr,
+		"user_agent", r.Header.Get("User-Agent"))
+
+	claims, protocols, ok := h.authenticateWebSocket(w, r)
+	if !ok {
+		return
+	}
+
+	// Invalid IDs and log options are rejected before upgrading, as a plain HTTP response
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	jobID, err := pathID[JobID](r, ParamJobID)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamJobID, err)
+		return
+	}
+	logOptions, code, err := scenarioLogOptions(r)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", code, i18n.Params{"param": "sinceTime"})
+		return
+	}
+	format, ok := parseLogFormat(r)
+	if !ok {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidLogFormat,
+			i18n.Params{"format": format})
+		return
+	}
+	if format == LogFormatJSON {
+		// JSON frames carry the timestamp of every line in their own field
+		logOptions.Timestamps = true
+	}
+	followRun := r.URL.Query().Get(QueryFollowRun) == "true"
+	if followRun && h.scenarioRunInformer == nil {
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "service_unavailable", MsgLogFollowRunUnavailable, nil)
+		return
+	}
+
 	// Upgrade to WebSocket with the FULL subprotocol in response
 	// WebSocket spec requires server to respond with one of the client's requested subprotocols
 	// Client sent: "access_token.<jwt_token>"
@@ -1202,7 +1992,10 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 	logger.Info("⬆️ Upgrading connection to WebSocket",
 		"response_protocol", protocols)
 
-	conn, err := upgrader.Upgrade(w, r, http.Header{
+	// Browsers do not preflight WebSocket upgrades, so the origin allowlist is enforced here
+	wsUpgrader := upgrader
+	wsUpgrader.CheckOrigin = h.cors.checkWebSocketOrigin
+	conn, err := wsUpgrader.Upgrade(w, r, http.Header{
 		"Sec-WebSocket-Protocol": []string{protocols}, // Echo back the full protocol
 	})
 	if err != nil {
@@ -1218,45 +2011,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 		"userId", claims.UserID,
 		"client_ip", r.RemoteAddr)
 
-	// Extract scenarioRunName and jobID from path
-	// Path format: /api/v1/scenarios/run/{scenarioRunName}/jobs/{jobID}/logs
-	path := r.URL.Path
-	prefix := ScenariosRunPath + "/"
-
-	if !strings.HasPrefix(path, prefix) {
-		logger.Error(nil, "Invalid logs endpoint path", "path", path)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte("ERROR: Invalid logs endpoint")) // Best-effort error reporting
-		return
-	}
-
-	// Remove prefix
-	remainder := path[len(prefix):]
-
-	// Split by "/jobs/" and "/logs"
-	parts := strings.Split(remainder, "/jobs/")
-	if len(parts) != 2 {
-		logger.Error(nil, "Invalid logs endpoint path format", "path", path)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Invalid path format. Expected: %s/{scenarioRunName}/jobs/{jobID}/logs", ScenariosRunPath))) // Best-effort error reporting
-		return
-	}
-
-	scenarioRunName := parts[0]
-	jobIDAndLogs := parts[1]
-
-	// Extract jobID (remove "/logs" suffix)
-	if !strings.HasSuffix(jobIDAndLogs, "/logs") {
-		logger.Error(nil, "Invalid logs endpoint path format", "path", path)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Invalid path format. Expected: %s/{scenarioRunName}/jobs/{jobID}/logs", ScenariosRunPath))) // Best-effort error reporting
-		return
-	}
-
-	jobID := strings.TrimSuffix(jobIDAndLogs, "/logs")
-
-	if scenarioRunName == "" || jobID == "" {
-		logger.Error(nil, "Empty scenarioRunName or jobID in request path", "path", path)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte("ERROR: scenarioRunName and jobID cannot be empty")) // Best-effort error reporting
-		return
-	}
+	framer := &logFramer{format: format, jobID: string(jobID)}
 
 	logger.Info("WebSocket connection established", "scenarioRunName", scenarioRunName, "jobID", jobID, "client_ip", r.RemoteAddr)
 
@@ -1266,18 +2021,18 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 	// Fetch the scenario run to check permissions
 	var scenarioRun krknv1alpha1.KrknScenarioRun
 	if err := h.client.Get(ctx, client.ObjectKey{
-		Name:      scenarioRunName,
+		Name:      string(scenarioRunName),
 		Namespace: h.namespace,
 	}, &scenarioRun); err != nil {
 		logger.Error(err, "Failed to fetch scenario run", "scenarioRunName", scenarioRunName)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Scenario run '%s' not found", scenarioRunName)))
+		_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Scenario run '%s' not found", scenarioRunName))
 		return
 	}
 
 	// Find the specific job for this jobID
 	var targetJob *krknv1alpha1.ClusterJobStatus
 	for i := range scenarioRun.Status.ClusterJobs {
-		if scenarioRun.Status.ClusterJobs[i].JobID == jobID {
+		if scenarioRun.Status.ClusterJobs[i].JobID == string(jobID) {
 			targetJob = &scenarioRun.Status.ClusterJobs[i]
 			break
 		}
@@ -1287,7 +2042,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, "Job not found in scenario run",
 			"scenarioRunName", scenarioRunName,
 			"jobID", jobID)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte("ERROR: Job not found in scenario run"))
+		_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Job not found in scenario run"))
 		return
 	}
 
@@ -1298,7 +2053,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 				"scenarioRunName", scenarioRunName,
 				"jobID", jobID,
 				"userID", claims.UserID)
-			_ = conn.WriteMessage(websocket.TextMessage, []byte("ERROR: Access denied. Job has no cluster API URL"))
+			_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Access denied. Job has no cluster API URL"))
 			return
 		}
 
@@ -1316,7 +2071,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 				"scenarioRunName", scenarioRunName,
 				"jobID", jobID,
 				"userID", claims.UserID)
-			_ = conn.WriteMessage(websocket.TextMessage, []byte("ERROR: Failed to validate access permissions"))
+			_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Failed to validate access permissions"))
 			return
 		}
 
@@ -1326,7 +2081,7 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 				"jobID", jobID,
 				"userID", claims.UserID,
 				"clusterAPIURL", targetJob.ClusterAPIURL)
-			_ = conn.WriteMessage(websocket.TextMessage, []byte("ERROR: Access denied. You do not have permission to view logs for this job"))
+			_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Access denied. You do not have permission to view logs for this job"))
 			return
 		}
 	}
@@ -1371,51 +2126,49 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	if followRun {
+		// Waiting for the next attempt stops when the client goes away
+		followCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+		send := func(message []byte) bool {
+			return conn.WriteMessage(websocket.TextMessage, message) == nil
+		}
+		h.followJobLogs(followCtx, logger, scenarioRunName, *targetJob, logOptions, framer, send)
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		return
+	}
+
 	// Find pod by jobID label (no need to fetch the CR)
-	var podList corev1.PodList
-	if err := h.client.List(ctx, &podList, client.InNamespace(h.namespace), client.MatchingLabels{
-		"krkn-job-id": jobID,
-	}); err != nil {
+	pod, err := h.findJobPod(ctx, string(jobID))
+	if err != nil {
 		logger.Error(err, "Failed to list pods", "jobID", jobID)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Failed to list pods: %s", err.Error()))) // Best-effort error reporting
+		_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Failed to list pods: %s", err.Error())) // Best-effort error reporting
 		return
 	}
 
-	if len(podList.Items) == 0 {
+	if pod == nil {
 		logger.Error(nil, "Job not found", "jobID", jobID)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Job with ID '%s' not found", jobID))) // Best-effort error reporting
+		_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Job with ID '%s' not found", jobID)) // Best-effort error reporting
 		return
 	}
 
-	pod := podList.Items[0]
+	framer.podName = pod.Name
 	logger.Info("Found pod for job", "scenarioRunName", scenarioRunName, "jobID", jobID, "podName", pod.Name, "podPhase", pod.Status.Phase)
 
-	// Parse query parameters
-	follow := r.URL.Query().Get("follow") == "true"
-	timestamps := r.URL.Query().Get("timestamps") == "true"
-	tailLinesStr := r.URL.Query().Get("tailLines")
-
-	// Build pod logs options
-	logOptions := &corev1.PodLogOptions{
-		Container:  "scenario",
-		Follow:     follow,
-		Timestamps: timestamps,
-	}
-
-	// Parse tailLines if provided
-	if tailLinesStr != "" {
-		tailLines, err := strconv.ParseInt(tailLinesStr, 10, 64)
-		if err == nil && tailLines > 0 {
-			logOptions.TailLines = &tailLines
-		}
-	}
-
 	logger.Info("Opening log stream",
 		"scenarioRunName", scenarioRunName,
 		"jobID", jobID,
 		"podName", pod.Name,
-		"follow", follow,
-		"timestamps", timestamps)
+		"follow", logOptions.Follow,
+		"timestamps", logOptions.Timestamps)
 
 	// Get log stream from Kubernetes API
 	req := h.clientset.CoreV1().Pods(h.namespace).GetLogs(pod.Name, logOptions)
@@ -1426,19 +2179,32 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 			"jobID", jobID,
 			"podName", pod.Name,
 			"namespace", h.namespace)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Failed to open log stream: %s", err.Error()))) // Best-effort error reporting
+		_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Failed to open log stream: %s", err.Error())) // Best-effort error reporting
 		return
 	}
 	defer stream.Close()
 
 	logger.Info("Streaming logs started", "scenarioRunName", scenarioRunName, "jobID", jobID, "podName", pod.Name)
 
-	// Read logs line by line and send via WebSocket
-	scanner := bufio.NewScanner(stream)
+	// Read logs line by line and send via WebSocket, long lines in several messages
+	lines := newLogLineReader(stream, h.logMaxLineLength)
 	lineCount := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		err := conn.WriteMessage(websocket.TextMessage, []byte(line))
+	for {
+		chunk, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logger.Error(err, "Log stream read error",
+				"scenarioRunName", scenarioRunName,
+				"jobID", jobID,
+				"podName", pod.Name,
+				"linesStreamed", lineCount)
+			_ = conn.WriteMessage(websocket.TextMessage, framer.errorf("Log stream error: %s", err.Error())) // Best-effort error reporting
+			return
+		}
+
+		err = conn.WriteMessage(websocket.TextMessage, framer.chunk(chunk))
 		if err != nil {
 			// Check if this is a normal client disconnection
 			if isWebSocketDisconnectError(err) {
@@ -1456,18 +2222,9 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 			}
 			return
 		}
-		lineCount++
-	}
-
-	// Check for scanner errors
-	if err := scanner.Err(); err != nil {
-		logger.Error(err, "Log stream scanner error",
-			"scenarioRunName", scenarioRunName,
-			"jobID", jobID,
-			"podName", pod.Name,
-			"linesStreamed", lineCount)
-		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("ERROR: Log stream error: %s", err.Error()))) // Best-effort error reporting
-		return
+		if chunk.last {
+			lineCount++
+		}
 	}
 
 	logger.Info("Log streaming completed",
@@ -1476,6 +2233,10 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 		"podName", pod.Name,
 		"totalLines", lineCount)
 
+	if end := framer.end(); end != nil {
+		_ = conn.WriteMessage(websocket.TextMessage, end) // Best-effort, the close message follows
+	}
+
 	// Send close message (ignore error if client already disconnected)
 	if err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
 		if !isWebSocketDisconnectError(err) {
@@ -1487,23 +2248,110 @@ func (h *Handler) GetScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// scenarioLogOptions builds the scenario container log options from the follow,
+// timestamps, tailLines, sinceTime and sinceSeconds query parameters. sinceTime (RFC3339)
+// or sinceSeconds let a client resume a dropped stream; on error the message code is returned.
+func scenarioLogOptions(r *http.Request) (*corev1.PodLogOptions, string, error) {
+	query := r.URL.Query()
+	logOptions := &corev1.PodLogOptions{
+		Container:  "scenario",
+		Follow:     query.Get("follow") == "true",
+		Timestamps: query.Get("timestamps") == "true",
+	}
+
+	// Parse tailLines if provided
+	if tailLinesStr := query.Get("tailLines"); tailLinesStr != "" {
+		tailLines, err := strconv.ParseInt(tailLinesStr, 10, 64)
+		if err == nil && tailLines > 0 {
+			logOptions.TailLines = &tailLines
+		}
+	}
+
+	sinceTime, err := parseTimeParam(r, "sinceTime")
+	if err != nil {
+		return nil, MsgInvalidTimestamp, err
+	}
+	if sinceTime != nil {
+		since := metav1.NewTime(*sinceTime)
+		logOptions.SinceTime = &since
+	}
+
+	if sinceSecondsStr := query.Get("sinceSeconds"); sinceSecondsStr != "" {
+		sinceSeconds, err := strconv.ParseInt(sinceSecondsStr, 10, 64)
+		if err != nil || sinceSeconds <= 0 {
+			return nil, MsgInvalidSinceSeconds, fmt.Errorf("invalid sinceSeconds %q", sinceSecondsStr)
+		}
+		logOptions.SinceSeconds = &sinceSeconds
+	}
+
+	if logOptions.SinceTime != nil && logOptions.SinceSeconds != nil {
+		return nil, MsgLogSinceConflict, fmt.Errorf("sinceTime and sinceSeconds are mutually exclusive")
+	}
+	return logOptions, "", nil
+}
+
 // ListScenarioRuns handles GET /api/v1/scenarios/run endpoint
 // It returns a list of all scenario runs (KrknScenarioRun CRs)
+//
+// Query parameters:
+//   - phase, scenarioName: exact-match filters
+//   - createdAfter, createdBefore: RFC3339 creation time bounds
+//   - sort: "createdAt" (default) or "startTime"
+//   - order: "desc" (default, newest first) or "asc"
+//   - limit / continue: pagination (no limit returns every run)
 func (h *Handler) ListScenarioRuns(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Parse query parameters for filtering
-	phaseFilter := r.URL.Query().Get("phase") // e.g., Running, Succeeded, Failed
-	scenarioNameFilter := r.URL.Query().Get("scenarioName")
+	query := r.URL.Query()
+	phaseFilter := query.Get("phase") // e.g., Running, Succeeded, Failed
+	scenarioNameFilter := query.Get("scenarioName")
+	campaignFilter := query.Get("campaign")
+
+	page, code, err := parsePageRequest(r)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", code, nil)
+		return
+	}
+
+	createdAfter, err := parseTimeParam(r, "createdAfter")
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidTimestamp,
+			i18n.Params{"param": "createdAfter"})
+		return
+	}
+	createdBefore, err := parseTimeParam(r, "createdBefore")
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidTimestamp,
+			i18n.Params{"param": "createdBefore"})
+		return
+	}
+
+	sortBy := query.Get("sort")
+	if sortBy == "" {
+		sortBy = "createdAt"
+	}
+	if sortBy != "createdAt" && sortBy != "startTime" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidSortField,
+			i18n.Params{"sort": sortBy, "allowed": "createdAt, startTime"})
+		return
+	}
+
+	order := query.Get("order")
+	if order == "" {
+		order = "desc"
+	}
+	if order != "asc" && order != "desc" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidSortOrder, nil)
+		return
+	}
 
 	// List all KrknScenarioRun CRs in the namespace
 	var scenarioRunList krknv1alpha1.KrknScenarioRunList
 	if err := h.client.List(ctx, &scenarioRunList, client.InNamespace(h.namespace)); err != nil {
 		log.FromContext(ctx).Error(err, "Failed to list scenario runs")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list scenario runs",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunListFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -1520,29 +2368,86 @@ func (h *Handler) ListScenarioRuns(w http.ResponseWriter, r *http.Request) {
 		if scenarioNameFilter != "" && sr.Spec.ScenarioName != scenarioNameFilter {
 			continue
 		}
+		if campaignFilter != "" && sr.Spec.CampaignRef != campaignFilter {
+			continue
+		}
+		if createdAfter != nil && sr.CreationTimestamp.Time.Before(*createdAfter) {
+			continue
+		}
+		if createdBefore != nil && !sr.CreationTimestamp.Time.Before(*createdBefore) {
+			continue
+		}
 
 		run := ScenarioRunListItem{
 			ScenarioRunName: sr.Name,
 			ScenarioName:    sr.Spec.ScenarioName,
+			RunNumber:       sr.Spec.RunNumber,
+			DisplayName:     sr.Spec.DisplayName,
 			Phase:           sr.Status.Phase,
 			TotalTargets:    sr.Status.TotalTargets,
 			SuccessfulJobs:  sr.Status.SuccessfulJobs,
 			FailedJobs:      sr.Status.FailedJobs,
 			RunningJobs:     sr.Status.RunningJobs,
 			CreatedAt:       sr.CreationTimestamp.Time,
+			StartedAt:       scenarioRunStartTime(&sr),
 			OwnerUserID:     sr.Spec.OwnerUserID,
+			Campaign:        sr.Spec.CampaignRef,
 		}
 
 		runs = append(runs, run)
 	}
 
+	// Sort with a name tie-breaker so pages are stable across requests
+	sort.SliceStable(runs, func(i, j int) bool {
+		a, b := runs[i], runs[j]
+		if order == "desc" {
+			a, b = b, a
+		}
+		ta, tb := a.CreatedAt, b.CreatedAt
+		if sortBy == "startTime" {
+			// Runs that haven't started yet sort as if they started at creation
+			if a.StartedAt != nil {
+				ta = *a.StartedAt
+			}
+			if b.StartedAt != nil {
+				tb = *b.StartedAt
+			}
+		}
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		return a.ScenarioRunName < b.ScenarioRunName
+	})
+
+	start, end, next := page.paginate(len(runs))
+
 	response := ScenarioRunListResponse{
-		ScenarioRuns: runs,
+		ScenarioRuns: runs[start:end],
+		Pagination: PaginationInfo{
+			Total:    len(runs),
+			Limit:    page.Limit,
+			Continue: next,
+		},
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
+// scenarioRunStartTime returns the earliest cluster job start time, or nil if no job has started
+func scenarioRunStartTime(sr *krknv1alpha1.KrknScenarioRun) *time.Time {
+	var earliest *time.Time
+	for _, job := range sr.Status.ClusterJobs {
+		if job.StartTime == nil {
+			continue
+		}
+		if earliest == nil || job.StartTime.Time.Before(*earliest) {
+			t := job.StartTime.Time
+			earliest = &t
+		}
+	}
+	return earliest
+}
+
 // GetActiveRunsOverview handles GET /api/v1/dashboard/active-runs endpoint
 // It returns an overview of currently running scenario runs
 // Accessible to all authenticated users - all users see all active runs (global dashboard)
@@ -1553,10 +2458,8 @@ func (h *Handler) GetActiveRunsOverview(w http.ResponseWriter, r *http.Request)
 	var scenarioRunList krknv1alpha1.KrknScenarioRunList
 	if err := h.client.List(ctx, &scenarioRunList, client.InNamespace(h.namespace)); err != nil {
 		log.FromContext(ctx).Error(err, "Failed to list scenario runs")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list scenario runs",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunListFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -1596,75 +2499,114 @@ func (h *Handler) GetActiveRunsOverview(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusOK, response)
 }
 
+// findJobPod returns the most recently created pod of a job, nil if it has none. The
+// Job running a scenario replaces pods lost to node failures, the newest one is current.
+func (h *Handler) findJobPod(ctx context.Context, jobID string) (*corev1.Pod, error) {
+	var podList corev1.PodList
+	if err := h.client.List(ctx, &podList, client.InNamespace(h.namespace), client.MatchingLabels{
+		joblabels.JobID: jobID,
+	}); err != nil {
+		return nil, err
+	}
+
+	var latest *corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if latest == nil || latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest = pod
+		}
+	}
+	return latest, nil
+}
+
+// scenarioJobDeleteOptions stops a scenario Job. Jobs orphan their pods by default,
+// background propagation deletes them with the Job.
+func scenarioJobDeleteOptions() *client.DeleteOptions {
+	gracePeriod := int64(5)
+	propagation := metav1.DeletePropagationBackground
+	return &client.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+		PropagationPolicy:  &propagation,
+	}
+}
+
+// backfillJobIdentity completes the identifiers of a job missing from its labels with
+// those recorded on its scenario run, for resources labeled by another creation path
+func backfillJobIdentity(identity *joblabels.Identity, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if identity.ScenarioName == "" {
+		identity.ScenarioName = scenarioRun.Spec.ScenarioName
+	}
+	if identity.TargetID == "" {
+		identity.TargetID = scenarioRun.Spec.TargetRequestID
+	}
+	if identity.ClusterName == "" {
+		for _, job := range scenarioRun.Status.ClusterJobs {
+			if job.JobID == identity.JobID {
+				identity.ClusterName = job.ClusterName
+				break
+			}
+		}
+	}
+}
+
 // DeleteScenarioRun handles DELETE /api/v1/scenarios/run/{jobID} endpoint
 // It stops and deletes a running job
 func (h *Handler) DeleteScenarioRun(w http.ResponseWriter, r *http.Request) {
-	jobID, err := extractPathSuffix(r.URL.Path, ScenariosRunPath+"/")
+	jobID, err := pathID[JobID](r, ParamJobID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "jobID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamJobID, err)
 		return
 	}
 
 	ctx := r.Context()
 
-	var podList corev1.PodList
-	if err := h.client.List(ctx, &podList, client.InNamespace(h.namespace), client.MatchingLabels{
-		"krkn-job-id": jobID,
+	var jobList batchv1.JobList
+	if err := h.client.List(ctx, &jobList, client.InNamespace(h.namespace), client.MatchingLabels{
+		joblabels.JobID: string(jobID),
 	}); err != nil {
-		log.FromContext(ctx).Error(err, "Failed to list pods", "jobID", jobID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list pods",
-		})
+		log.FromContext(ctx).Error(err, "Failed to list jobs", "jobID", jobID)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgJobListFailed, nil)
 		return
 	}
 
-	if len(podList.Items) == 0 {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Job with ID '" + jobID + "' not found",
-		})
+	if len(jobList.Items) == 0 {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgJobNotFound, i18n.Params{"jobId": string(jobID)})
 		return
 	}
 
-	pod := podList.Items[0]
+	batchJob := jobList.Items[0]
+	identity := joblabels.Read(batchJob.Labels)
 
 	// Find parent ScenarioRun and check access
-	scenarioRunName := pod.Labels["krkn-scenario-run"]
-	if scenarioRunName != "" {
+	if identity.ScenarioRun != "" {
 		var scenarioRun krknv1alpha1.KrknScenarioRun
 		if err := h.client.Get(ctx, client.ObjectKey{
-			Name:      scenarioRunName,
+			Name:      identity.ScenarioRun,
 			Namespace: h.namespace,
 		}, &scenarioRun); err == nil {
 			// Check access permissions on parent ScenarioRun
 			if !h.checkScenarioRunAccess(w, r, &scenarioRun) {
 				return
 			}
+			backfillJobIdentity(&identity, &scenarioRun)
 		}
 		// If ScenarioRun not found, continue anyway (might have been deleted)
 	}
 
-	gracePeriod := int64(5)
-	deleteOptions := client.DeleteOptions{
-		GracePeriodSeconds: &gracePeriod,
+	var podName string
+	if pod, err := h.findJobPod(ctx, string(jobID)); err == nil && pod != nil {
+		podName = pod.Name
 	}
 
-	if err := h.client.Delete(ctx, &pod, &deleteOptions); err != nil {
-		log.FromContext(ctx).Error(err, "Failed to delete pod", "podName", pod.Name, "jobID", jobID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete pod",
-		})
+	if err := h.client.Delete(ctx, &batchJob, scenarioJobDeleteOptions()); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to delete job", "job", batchJob.Name, "jobID", jobID)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgJobDeleteFailed, nil)
 		return
 	}
 
 	var configMapList corev1.ConfigMapList
 	if err := h.client.List(ctx, &configMapList, client.InNamespace(h.namespace), client.MatchingLabels{
-		"krkn-job-id": jobID,
+		joblabels.JobID: string(jobID),
 	}); err == nil {
 		for _, cm := range configMapList.Items {
 			_ = h.client.Delete(ctx, &cm) // Best-effort cleanup
@@ -1673,7 +2615,7 @@ func (h *Handler) DeleteScenarioRun(w http.ResponseWriter, r *http.Request) {
 
 	var secretList corev1.SecretList
 	if err := h.client.List(ctx, &secretList, client.InNamespace(h.namespace), client.MatchingLabels{
-		"krkn-job-id": jobID,
+		joblabels.JobID: string(jobID),
 	}); err == nil {
 		for _, secret := range secretList.Items {
 			_ = h.client.Delete(ctx, &secret) // Best-effort cleanup
@@ -1681,23 +2623,27 @@ func (h *Handler) DeleteScenarioRun(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := JobStatusResponse{
-		JobID:   jobID,
-		Status:  "Stopped",
-		Message: "Job stopped and deleted successfully",
+		JobID:        string(jobID),
+		TargetID:     identity.TargetID,
+		ClusterName:  identity.ClusterName,
+		ScenarioName: identity.ScenarioName,
+		Status:       "Stopped",
+		PodName:      podName,
+		Code:         MsgJobStopSucceeded,
+		Message:      localizedMessage(w, r, MsgJobStopSucceeded, nil),
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
 // DeleteScenarioRunComplete handles DELETE /api/v1/scenarios/run/{scenarioRunName}
-// It deletes the entire KrknScenarioRun CR (all jobs)
+// An active run is cancelled gracefully: spec.cancelRequested is set, the controller stops
+// its jobs and the run is kept for inspection (202). A finished run, or any run with
+// ?force=true, is deleted with all its jobs (204).
 func (h *Handler) DeleteScenarioRunComplete(w http.ResponseWriter, r *http.Request) {
-	scenarioRunName, err := extractPathSuffix(r.URL.Path, ScenariosRunPath+"/")
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "scenarioRunName " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
 		return
 	}
 
@@ -1706,19 +2652,15 @@ func (h *Handler) DeleteScenarioRunComplete(w http.ResponseWriter, r *http.Reque
 	// Fetch the KrknScenarioRun CR
 	var scenarioRun krknv1alpha1.KrknScenarioRun
 	if err := h.client.Get(ctx, client.ObjectKey{
-		Name:      scenarioRunName,
+		Name:      string(scenarioRunName),
 		Namespace: h.namespace,
 	}, &scenarioRun); err != nil {
 		if client.IgnoreNotFound(err) == nil {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Scenario run '" + scenarioRunName + "' not found",
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+				i18n.Params{"name": string(scenarioRunName)})
 		} else {
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get scenario run: " + err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -1727,10 +2669,7 @@ func (h *Handler) DeleteScenarioRunComplete(w http.ResponseWriter, r *http.Reque
 	// Admin can cancel anything, regular users must have 'cancel' permission on ALL jobs
 	claims := auth.GetClaimsFromContext(ctx)
 	if claims == nil {
-		writeJSONError(w, http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "No authentication claims found",
-		})
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
 		return
 	}
 
@@ -1742,17 +2681,31 @@ func (h *Handler) DeleteScenarioRunComplete(w http.ResponseWriter, r *http.Reque
 
 	if err != nil {
 		log.FromContext(ctx).Error(err, "Failed to validate cancel permissions", "scenarioRunName", scenarioRunName, "userID", claims.UserID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to validate cancel permissions",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAccessCheckFailed, nil)
 		return
 	}
 
 	if !hasAccess {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "Access denied. You must have cancel permission on all jobs in this run to delete it",
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunDeleteForbidden, nil)
+		return
+	}
+
+	if scenarioRunActive(&scenarioRun) && r.URL.Query().Get("force") != "true" {
+		clusters, err := h.cancelScenarioRun(ctx, client.ObjectKeyFromObject(&scenarioRun))
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to cancel scenario run", "scenarioRunName", scenarioRunName)
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgRunCancelFailed,
+				i18n.Params{"error": err.Error()})
+			return
+		}
+
+		log.Log.Info("requested scenario run cancellation",
+			"scenarioRunName", scenarioRunName,
+			"cancelledClusters", clusters)
+
+		writeJSON(w, http.StatusAccepted, ScenarioRunCancelResponse{
+			ScenarioRunName:   string(scenarioRunName),
+			CancelledClusters: clusters,
 		})
 		return
 	}
@@ -1765,10 +2718,7 @@ func (h *Handler) DeleteScenarioRunComplete(w http.ResponseWriter, r *http.Reque
 	// Delete the CR - owner references will cascade delete all pods/configmaps/secrets
 	if err := h.client.Delete(ctx, &scenarioRun); err != nil {
 		log.FromContext(ctx).Error(err, "Failed to delete scenario run", "scenarioRunName", scenarioRunName)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete scenario run",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunDeleteFailed, nil)
 		return
 	}
 
@@ -1782,12 +2732,9 @@ func (h *Handler) DeleteScenarioRunComplete(w http.ResponseWriter, r *http.Reque
 // It cancels a single job by setting CancelRequested flag and deleting the pod
 func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 	// Parse path: /api/v1/scenarios/run/jobs/{jobID}
-	jobID, err := extractPathSuffix(r.URL.Path, ScenariosRunJobsPath+"/")
+	jobID, err := pathID[JobID](r, ParamJobID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "jobID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamJobID, err)
 		return
 	}
 
@@ -1796,10 +2743,8 @@ func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 	// Find KrknScenarioRun containing this jobID
 	var scenarioRunList krknv1alpha1.KrknScenarioRunList
 	if err := h.client.List(ctx, &scenarioRunList, client.InNamespace(h.namespace)); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list scenario runs: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunListFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -1810,7 +2755,7 @@ func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 	for i := range scenarioRunList.Items {
 		sr := &scenarioRunList.Items[i]
 		for j, job := range sr.Status.ClusterJobs {
-			if job.JobID == jobID {
+			if job.JobID == string(jobID) {
 				foundScenarioRun = sr
 				foundJobIndex = j
 				break
@@ -1822,10 +2767,7 @@ func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if foundScenarioRun == nil {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Job '" + jobID + "' not found",
-		})
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgJobNotFound, i18n.Params{"jobId": string(jobID)})
 		return
 	}
 
@@ -1848,10 +2790,7 @@ func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 	// Update CR status
 	if err := h.client.Status().Update(ctx, foundScenarioRun); err != nil {
 		log.FromContext(ctx).Error(err, "Failed to update scenario run status", "scenarioRunName", foundScenarioRun.Name, "jobID", jobID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update scenario run status",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunStatusFailed, nil)
 		return
 	}
 
@@ -1859,27 +2798,22 @@ func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 		"scenarioRunName", foundScenarioRun.Name,
 		"jobID", jobID)
 
-	// Delete the pod (controller will see CancelRequested and not retry)
-	var podList corev1.PodList
-	if err := h.client.List(ctx, &podList, client.InNamespace(h.namespace), client.MatchingLabels{
-		"krkn-job-id": jobID,
-	}); err == nil && len(podList.Items) > 0 {
-		pod := podList.Items[0]
-		gracePeriod := int64(5)
-		deleteOptions := client.DeleteOptions{
-			GracePeriodSeconds: &gracePeriod,
-		}
-
-		if err := h.client.Delete(ctx, &pod, &deleteOptions); err != nil {
-			log.Log.Error(err, "failed to delete pod during job cancellation",
+	// Delete the Job and its pod (controller will see CancelRequested and not retry)
+	var jobList batchv1.JobList
+	if err := h.client.List(ctx, &jobList, client.InNamespace(h.namespace), client.MatchingLabels{
+		joblabels.JobID: string(jobID),
+	}); err == nil && len(jobList.Items) > 0 {
+		batchJob := jobList.Items[0]
+		if err := h.client.Delete(ctx, &batchJob, scenarioJobDeleteOptions()); err != nil {
+			log.Log.Error(err, "failed to delete job during job cancellation",
 				"scenarioRunName", foundScenarioRun.Name,
 				"jobID", jobID,
-				"podName", pod.Name)
+				"job", batchJob.Name)
 		} else {
-			log.Log.Info("deleted pod for cancelled job",
+			log.Log.Info("deleted job for cancelled job",
 				"scenarioRunName", foundScenarioRun.Name,
 				"jobID", jobID,
-				"podName", pod.Name)
+				"job", batchJob.Name)
 		}
 	}
 
@@ -1890,25 +2824,29 @@ func (h *Handler) DeleteSingleJob(w http.ResponseWriter, r *http.Request) {
 // It returns the status of a single job by jobID (jobID is unique across all scenario runs)
 func (h *Handler) GetSingleJob(w http.ResponseWriter, r *http.Request) {
 	// Parse path: /api/v1/scenarios/run/jobs/{jobID}
-	jobID, err := extractPathSuffix(r.URL.Path, ScenariosRunJobsPath+"/")
+	jobID, err := pathID[JobID](r, ParamJobID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "jobID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamJobID, err)
 		return
 	}
 
-	ctx := r.Context()
+	foundJob, ok := h.findViewableJob(w, r, jobID)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, clusterJobStatusResponse(foundJob))
+}
 
+// findViewableJob looks up a cluster job by ID across all scenario runs and checks the
+// caller may view it. On failure the error response has been written and ok is false.
+func (h *Handler) findViewableJob(w http.ResponseWriter, r *http.Request, jobID JobID) (*krknv1alpha1.ClusterJobStatus, bool) {
 	// Find KrknScenarioRun containing this jobID
 	var scenarioRunList krknv1alpha1.KrknScenarioRunList
-	if err := h.client.List(ctx, &scenarioRunList, client.InNamespace(h.namespace)); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list scenario runs: " + err.Error(),
-		})
-		return
+	if err := h.client.List(r.Context(), &scenarioRunList, client.InNamespace(h.namespace)); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunListFailed,
+			i18n.Params{"error": err.Error()})
+		return nil, false
 	}
 
 	// Search for job across all scenario runs
@@ -1917,7 +2855,7 @@ func (h *Handler) GetSingleJob(w http.ResponseWriter, r *http.Request) {
 	for i := range scenarioRunList.Items {
 		sr := &scenarioRunList.Items[i]
 		for j := range sr.Status.ClusterJobs {
-			if sr.Status.ClusterJobs[j].JobID == jobID {
+			if sr.Status.ClusterJobs[j].JobID == string(jobID) {
 				foundJob = &sr.Status.ClusterJobs[j]
 				break
 			}
@@ -1928,84 +2866,15 @@ func (h *Handler) GetSingleJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if foundJob == nil {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Job '" + jobID + "' not found",
-		})
-		return
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgJobNotFound, i18n.Params{"jobId": string(jobID)})
+		return nil, false
 	}
 
 	// Check if user has permission to view this specific job
 	if !h.checkJobAccess(w, r, foundJob, groupauth.ActionView, "view") {
-		return
-	}
-
-	// Convert to response type
-	response := ClusterJobStatusResponse{
-		ProviderName:    foundJob.ProviderName,
-		ClusterName:     foundJob.ClusterName,
-		JobID:           foundJob.JobID,
-		PodName:         foundJob.PodName,
-		Phase:           foundJob.Phase,
-		Message:         foundJob.Message,
-		StartTime:       convertMetaTime(foundJob.StartTime),
-		CompletionTime:  convertMetaTime(foundJob.CompletionTime),
-		RetryCount:      foundJob.RetryCount,
-		MaxRetries:      foundJob.MaxRetries,
-		CancelRequested: foundJob.CancelRequested,
-		FailureReason:   foundJob.FailureReason,
-	}
-
-	writeJSON(w, http.StatusOK, response)
-}
-
-func (h *Handler) ScenariosRunRouter(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
-
-	// Root endpoint: /api/v1/scenarios/run
-	if path == ScenariosRunPath {
-		switch r.Method {
-		case http.MethodPost:
-			h.PostScenarioRun(w, r)
-		case http.MethodGet:
-			h.ListScenarioRuns(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
-		return
-	}
-
-	// Nested endpoints
-	if strings.HasPrefix(path, ScenariosRunPath+"/") {
-		// Note: WebSocket logs endpoint (/jobs/{jobID}/logs) is handled in server.go
-		// before reaching this router, so no need to check for it here
-
-		// Check for /jobs/{jobID} pattern (GET or DELETE single job)
-		if strings.HasPrefix(path, ScenariosRunJobsPath+"/") {
-			switch r.Method {
-			case http.MethodGet:
-				h.GetSingleJob(w, r)
-			case http.MethodDelete:
-				h.DeleteSingleJob(w, r)
-			default:
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			}
-			return
-		}
-
-		// Single scenario run: /api/v1/scenarios/run/{scenarioRunName}
-		switch r.Method {
-		case http.MethodGet:
-			h.GetScenarioRunStatus(w, r)
-		case http.MethodDelete:
-			h.DeleteScenarioRunComplete(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
-		return
+		return nil, false
 	}
-
-	http.Error(w, "Not found", http.StatusNotFound)
+	return foundJob, true
 }
 
 // convertMetaTime converts metav1.Time to *time.Time
@@ -17,13 +17,19 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -31,6 +37,8 @@ import (
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
 )
 
 func TestGetClusters_Success(t *testing.T) {
@@ -120,6 +128,62 @@ func TestGetClusters_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetClusterIDs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	krknv1alpha1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	targetRequest := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-request", Namespace: "default"},
+		Status: krknv1alpha1.KrknTargetRequestStatus{
+			Status: "Completed",
+			TargetData: map[string][]krknv1alpha1.ClusterTarget{
+				"operator-1": {{ClusterName: "prod", ClusterAPIURL: "https://api.prod.example.com"}},
+				"operator-capi": {
+					{ClusterName: "prod", ClusterAPIURL: "https://api.capi.example.com", ClusterUID: "0b6f3c2e"},
+				},
+			},
+		},
+	}
+	fakeClient := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(targetRequest).Build()
+	handler := NewHandler(fakeClient, fake.NewSimpleClientset(), "default", "localhost:50051")
+
+	get := func(query string) ClusterIDsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.GetClusterIDs(w, httptest.NewRequest("GET", ClusterIDsPath+"?id=test-request"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d for %q, got %d. Body: %s", http.StatusOK, query, w.Code, w.Body.String())
+		}
+		var response ClusterIDsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return response
+	}
+
+	// Clusters of the same name at different providers get different IDs
+	capiID := krknv1alpha1.StableClusterID("operator-capi", "0b6f3c2e", "prod")
+	response := get("&cluster-name=prod")
+	if len(response.Clusters) != 2 || response.Clusters[0].Provider != "operator-1" ||
+		response.Clusters[1].ClusterID != capiID || response.Clusters[0].ClusterID == capiID {
+		t.Errorf("Expected both prod clusters with their IDs, got %+v", response.Clusters)
+	}
+	if response = get("&clusterId=" + capiID); len(response.Clusters) != 1 ||
+		response.Clusters[0].ClusterAPIURL != "https://api.capi.example.com" {
+		t.Errorf("Expected the cluster of the ID, got %+v", response.Clusters)
+	}
+	if response = get("&provider=operator-2"); len(response.Clusters) != 0 {
+		t.Errorf("Expected no cluster for an unknown provider, got %+v", response.Clusters)
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetClusterIDs(w, httptest.NewRequest("GET", ClusterIDsPath, nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d without id, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	scheme := runtime.NewScheme()
 	krknv1alpha1.AddToScheme(scheme)
@@ -131,7 +195,7 @@ func TestHealthCheck(t *testing.T) {
 
 	req := httptest.NewRequest("GET", HealthPath, nil)
 	w := httptest.NewRecorder()
-	handler.HealthCheck(w, req)
+	handler.Healthz(w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
@@ -189,6 +253,87 @@ func TestPostTarget_LegacyEndpoint(t *testing.T) {
 	}
 }
 
+func TestPostTarget_Providers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	krknv1alpha1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	providers := []client.Object{
+		&krknv1alpha1.KrknOperatorTargetProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "acm-operator", Namespace: "default"},
+			Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: "acm-operator", Active: true},
+		},
+		&krknv1alpha1.KrknOperatorTargetProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "capi-operator", Namespace: "default"},
+			Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: "capi-operator", Active: false},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedSpec   []string
+	}{
+		{
+			name:           "active provider",
+			body:           `{"providers":["acm-operator"]}`,
+			expectedStatus: http.StatusAccepted,
+			expectedSpec:   []string{"acm-operator"},
+		},
+		{
+			name:           "empty list fans out to all providers",
+			body:           `{"providers":[]}`,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "inactive provider",
+			body:           `{"providers":["capi-operator"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown provider",
+			body:           `{"providers":["missing"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed body",
+			body:           `{"providers":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(providers...).Build()
+			handler := NewHandler(fakeClient, fake.NewSimpleClientset(), "default", "localhost:50051")
+
+			req := httptest.NewRequest("POST", TargetsPath, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.PostTarget(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusAccepted {
+				return
+			}
+
+			var response map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			var targetRequest krknv1alpha1.KrknTargetRequest
+			if err := fakeClient.Get(req.Context(), client.ObjectKey{Name: response["uuid"], Namespace: "default"}, &targetRequest); err != nil {
+				t.Fatalf("Failed to get created KrknTargetRequest: %v", err)
+			}
+			if strings.Join(targetRequest.Spec.Providers, ",") != strings.Join(tt.expectedSpec, ",") {
+				t.Errorf("Expected providers %v, got %v", tt.expectedSpec, targetRequest.Spec.Providers)
+			}
+		})
+	}
+}
+
 func TestGetTargetByUUID_NotCompleted(t *testing.T) {
 	scheme := runtime.NewScheme()
 	krknv1alpha1.AddToScheme(scheme)
@@ -213,7 +358,7 @@ func TestGetTargetByUUID_NotCompleted(t *testing.T) {
 
 	req := httptest.NewRequest("GET", TargetsPath+"/test-uuid", nil)
 	w := httptest.NewRecorder()
-	handler.GetTargetByUUID(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusAccepted {
 		t.Errorf("Expected status code %d (Processing), got %d", http.StatusAccepted, w.Code)
@@ -244,7 +389,7 @@ func TestGetTargetByUUID_Completed(t *testing.T) {
 
 	req := httptest.NewRequest("GET", TargetsPath+"/test-uuid", nil)
 	w := httptest.NewRecorder()
-	handler.GetTargetByUUID(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status code %d (OK), got %d", http.StatusOK, w.Code)
@@ -262,7 +407,7 @@ func TestGetTargetByUUID_NotFound(t *testing.T) {
 
 	req := httptest.NewRequest("GET", TargetsPath+"/non-existent-uuid", nil)
 	w := httptest.NewRecorder()
-	handler.GetTargetByUUID(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status code %d (Not Found), got %d", http.StatusNotFound, w.Code)
@@ -324,7 +469,10 @@ func setupScenarioRunTestHandler(targetRequestID string, clusters map[string]str
 
 	fakeClient := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(secret, targetRequest).Build()
 	fakeClientset := fake.NewSimpleClientset()
-	return NewHandler(fakeClient, fakeClientset, "default", "localhost:50051")
+	handler := NewHandler(fakeClient, fakeClientset, "default", "localhost:50051")
+	// Runs are not validated against a registry unless a test sets a provider
+	handler.scenarioProvider = nil
+	return handler
 }
 
 func TestPostScenarioRun_SingleTarget_Success(t *testing.T) {
@@ -398,6 +546,315 @@ func TestPostScenarioRun_SingleTarget_Success(t *testing.T) {
 	}
 }
 
+func TestPostScenarioRun_AppliesDefaults(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+
+	post := func(pullPolicy string) *httptest.ResponseRecorder {
+		reqBody := `{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["test-cluster"]},
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete",
+			"imagePullPolicy": "` + pullPolicy + `"
+		}`
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	if w := post("Sometimes"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for an invalid pull policy, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w := post("IfNotPresent")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(context.Background(), client.ObjectKey{
+		Name: response.ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	spec := scenarioRun.Spec
+	if spec.KubeconfigPath != krknv1alpha1.DefaultKubeconfigPath || spec.MaxRetries != krknv1alpha1.DefaultMaxRetries ||
+		spec.RetryDelay != krknv1alpha1.DefaultRetryDelay || spec.ImagePullPolicy != "IfNotPresent" {
+		t.Errorf("Expected the run defaults and the requested pull policy, got %+v", spec)
+	}
+}
+
+func TestPostScenarioRun_Alternates(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+
+	post := func(alternates string) *httptest.ResponseRecorder {
+		reqBody := `{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["test-cluster"]},
+			"alternates": ` + alternates + `,
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete"
+		}`
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	for _, alternates := range []string{
+		`{"other-cluster": "standby"}`,
+		`{"test-cluster": "test-cluster"}`,
+		`{"test-cluster": ""}`,
+	} {
+		if w := post(alternates); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for alternates %s, got %d", http.StatusBadRequest, alternates, w.Code)
+		}
+	}
+
+	w := post(`{"test-cluster": "standby"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(context.Background(), client.ObjectKey{
+		Name: response.ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if scenarioRun.Spec.Alternates["test-cluster"] != "standby" {
+		t.Errorf("Expected the alternates on the run, got %v", scenarioRun.Spec.Alternates)
+	}
+}
+
+func TestPostScenarioRun_ArchivedTargets(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	archived := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "standby", Namespace: handler.namespace},
+		Spec:       krknv1alpha1.KrknOperatorTargetSpec{UUID: "uuid-standby", ClusterName: "standby"},
+		Status:     krknv1alpha1.KrknOperatorTargetStatus{Archived: true},
+	}
+	if err := handler.client.Create(context.Background(), archived); err != nil {
+		t.Fatalf("Failed to create target: %v", err)
+	}
+
+	for _, targets := range []string{
+		`"targetClusters": {"krkn-operator": ["test-cluster", "standby"]}`,
+		`"targetClusters": {"krkn-operator": ["test-cluster"]}, "alternates": {"test-cluster": "standby"}`,
+	} {
+		reqBody := `{
+			"targetRequestID": "test-request-id",
+			` + targets + `,
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete"
+		}`
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(reqBody))
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), MsgTargetsArchived) {
+			t.Errorf("Expected %d %s for %s, got %d: %s", http.StatusConflict, MsgTargetsArchived, targets, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestPostScenarioRun_TargetSelector(t *testing.T) {
+	kubeconfig := "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd"
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"staging-eu": kubeconfig,
+		"staging-us": kubeconfig,
+		"prod-eu":    kubeconfig,
+	})
+	ctx := context.Background()
+
+	var targetRequest krknv1alpha1.KrknTargetRequest
+	if err := handler.client.Get(ctx, client.ObjectKey{Name: "test-request-id", Namespace: "default"}, &targetRequest); err != nil {
+		t.Fatalf("Failed to get target request: %v", err)
+	}
+	targets := targetRequest.Status.TargetData["krkn-operator"]
+	for i := range targets {
+		env, region, _ := strings.Cut(targets[i].ClusterName, "-")
+		targets[i].Labels = map[string]string{"env": env, "region": region}
+	}
+	if err := handler.client.Update(ctx, &targetRequest); err != nil {
+		t.Fatalf("Failed to update target request: %v", err)
+	}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete",
+			`+body+`
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	for _, body := range []string{
+		`"targetSelector": "env in (staging"`,
+		`"targetSelector": "env=dev"`,
+	} {
+		if w := post(body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+
+	w := post(`"targetSelector": "region=eu", "targetClusters": {"krkn-operator": ["staging-us", "staging-eu"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	want := []string{"staging-us", "staging-eu", "prod-eu"}
+	if !slices.Equal(response.TargetClusters["krkn-operator"], want) || response.TotalTargets != 3 {
+		t.Errorf("Expected the listed and selected clusters %v, got %v", want, response.TargetClusters)
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(ctx, client.ObjectKey{
+		Name: response.ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if scenarioRun.Spec.TargetSelector != "region=eu" || len(scenarioRun.Spec.TargetClusters["krkn-operator"]) != 3 {
+		t.Errorf("Expected the selector and the selected clusters on the run, got %q %v",
+			scenarioRun.Spec.TargetSelector, scenarioRun.Spec.TargetClusters)
+	}
+}
+
+func TestPostScenarioRun_TargetGroupRef(t *testing.T) {
+	kubeconfig := "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd"
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"staging-eu": kubeconfig,
+		"prod-eu":    kubeconfig,
+	})
+	ctx := context.Background()
+
+	group := &krknv1alpha1.KrknTargetGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu-clusters", Namespace: handler.namespace},
+		Spec: krknv1alpha1.KrknTargetGroupSpec{
+			Targets: map[string][]string{"krkn-operator": {"staging-eu", "prod-eu"}},
+		},
+	}
+	if err := handler.client.Create(ctx, group); err != nil {
+		t.Fatalf("Failed to create target group: %v", err)
+	}
+
+	post := func(targetGroupRef string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete",
+			"targetGroupRef": "`+targetGroupRef+`"
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	for _, targetGroupRef := range []string{"missing", "Not_A_Name"} {
+		if w := post(targetGroupRef); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d for %q, got %d", http.StatusBadRequest, targetGroupRef, w.Code)
+		}
+	}
+
+	w := post("eu-clusters")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.TotalTargets != 2 {
+		t.Errorf("Expected the 2 members of the group, got %v", response.TargetClusters)
+	}
+
+	// The members are left to the controller to resolve when the run starts
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(ctx, client.ObjectKey{
+		Name: response.ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if scenarioRun.Spec.TargetGroupRef != "eu-clusters" || len(scenarioRun.Spec.TargetClusters) != 0 {
+		t.Errorf("Expected only the group reference on the run, got %q %v",
+			scenarioRun.Spec.TargetGroupRef, scenarioRun.Spec.TargetClusters)
+	}
+}
+
+func TestPostScenarioRun_TargetClusterIDs(t *testing.T) {
+	kubeconfig := "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd"
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"staging-eu": kubeconfig,
+		"prod-eu":    kubeconfig,
+	})
+	ctx := context.Background()
+
+	post := func(clusterID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete",
+			"targetClusterIds": ["`+clusterID+`"]
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	if w := post("cl-0000000000000000"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for an unknown cluster ID, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	clusterID := krknv1alpha1.StableClusterID("krkn-operator", "", "prod-eu")
+	w := post(clusterID)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !slices.Equal(response.TargetClusters["krkn-operator"], []string{"prod-eu"}) {
+		t.Errorf("Expected the cluster of the ID, got %v", response.TargetClusters)
+	}
+
+	// The IDs are left to the controller to resolve when the run starts
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(ctx, client.ObjectKey{
+		Name: response.ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if !slices.Equal(scenarioRun.Spec.TargetClusterIDs, []string{clusterID}) || len(scenarioRun.Spec.TargetClusters) != 0 {
+		t.Errorf("Expected only the cluster IDs on the run, got %v %v",
+			scenarioRun.Spec.TargetClusterIDs, scenarioRun.Spec.TargetClusters)
+	}
+}
+
 func TestPostScenarioRun_MissingTargetUUIDs(t *testing.T) {
 	handler := setupScenarioRunTestHandler("test-id", map[string]string{})
 
@@ -775,5 +1232,395 @@ func TestListScenarioRuns_FilterByScenarioName(t *testing.T) {
 	}
 }
 
+func TestListScenarioRuns_PaginationAndTimeFilters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	krknv1alpha1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	newRun := func(name string, created time.Duration, started *time.Duration) *krknv1alpha1.KrknScenarioRun {
+		run := &krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(base.Add(created)),
+			},
+			Spec: krknv1alpha1.KrknScenarioRunSpec{
+				ScenarioName: "pod-delete",
+			},
+		}
+		if started != nil {
+			startTime := metav1.NewTime(base.Add(*started))
+			run.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{{ClusterName: "c", StartTime: &startTime}}
+		}
+		return run
+	}
+	hour := func(h int) *time.Duration { d := time.Duration(h) * time.Hour; return &d }
+
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newRun("run-1", 1*time.Hour, hour(10)),
+			newRun("run-2", 2*time.Hour, hour(4)),
+			newRun("run-3", 3*time.Hour, nil),
+			newRun("run-4", 4*time.Hour, hour(5)),
+		).
+		Build()
+	handler := NewHandler(fakeClient, fake.NewSimpleClientset(), "default", "localhost:50051")
+
+	list := func(query string) (int, ScenarioRunListResponse) {
+		w := httptest.NewRecorder()
+		handler.ListScenarioRuns(w, httptest.NewRequest("GET", ScenariosRunPath+query, nil))
+		var response ScenarioRunListResponse
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+		}
+		return w.Code, response
+	}
+	names := func(response ScenarioRunListResponse) string {
+		var out []string
+		for _, run := range response.ScenarioRuns {
+			out = append(out, run.ScenarioRunName)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "default newest first", query: "", expected: "run-4,run-3,run-2,run-1"},
+		{name: "ascending", query: "?order=asc", expected: "run-1,run-2,run-3,run-4"},
+		{name: "by start time", query: "?sort=startTime&order=asc", expected: "run-3,run-2,run-4,run-1"},
+		{name: "created after", query: "?createdAfter=2025-06-01T14:00:00Z", expected: "run-4,run-3,run-2"},
+		{name: "created range", query: "?createdAfter=2025-06-01T14:00:00Z&createdBefore=2025-06-01T16:00:00Z", expected: "run-3,run-2"},
+		{name: "first page", query: "?limit=3", expected: "run-4,run-3,run-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, response := list(tt.query)
+			if code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, code)
+			}
+			if got := names(response); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	// Follow the continue token to the second page
+	_, firstPage := list("?limit=3")
+	if firstPage.Pagination.Total != 4 || firstPage.Pagination.Continue == "" {
+		t.Fatalf("Unexpected pagination metadata: %+v", firstPage.Pagination)
+	}
+	_, secondPage := list("?limit=3&continue=" + firstPage.Pagination.Continue)
+	if names(secondPage) != "run-1" || secondPage.Pagination.Continue != "" {
+		t.Errorf("Unexpected second page: %s %+v", names(secondPage), secondPage.Pagination)
+	}
+
+	for _, query := range []string{"?createdAfter=yesterday", "?sort=phase", "?order=sideways", "?limit=-1"} {
+		if code, _ := list(query); code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, query, code)
+		}
+	}
+}
+
 // NOTE: Tests for deleteTargetRequest were removed - KrknTargetRequest is now owned by ScenarioRun
 // and will be automatically deleted via Kubernetes garbage collection when ScenarioRun is deleted.
+
+func TestValidateFailureHooks(t *testing.T) {
+	image := &FailureHookImage{Image: "quay.io/example/remediate:latest"}
+	webhook := &FailureHookWebhook{URL: "https://incidents.example.com"}
+
+	tests := []struct {
+		name    string
+		hooks   []FailureHook
+		wantErr bool
+	}{
+		{name: "no hooks", hooks: nil},
+		{name: "image and webhook hooks", hooks: []FailureHook{{Name: "restart", Image: image}, {Name: "incident", Webhook: webhook}}},
+		{name: "slack webhook", hooks: []FailureHook{{Name: "page", Webhook: &FailureHookWebhook{Type: "slack", URL: "https://hooks.slack.com/x"}}}},
+		{name: "invalid name", hooks: []FailureHook{{Name: "Restart_Now", Image: image}}, wantErr: true},
+		{name: "duplicate name", hooks: []FailureHook{{Name: "restart", Image: image}, {Name: "restart", Webhook: webhook}}, wantErr: true},
+		{name: "no action", hooks: []FailureHook{{Name: "restart"}}, wantErr: true},
+		{name: "both actions", hooks: []FailureHook{{Name: "restart", Image: image, Webhook: webhook}}, wantErr: true},
+		{name: "report hook", hooks: []FailureHook{{Name: "report", Report: &FailureHookReport{Severity: "critical"}}}},
+		{name: "report with default severity", hooks: []FailureHook{{Name: "report", Report: &FailureHookReport{}}}},
+		{name: "report and webhook", hooks: []FailureHook{{Name: "report", Report: &FailureHookReport{}, Webhook: webhook}}, wantErr: true},
+		{name: "invalid report severity", hooks: []FailureHook{{Name: "report", Report: &FailureHookReport{Severity: "fatal"}}}, wantErr: true},
+		{name: "empty image", hooks: []FailureHook{{Name: "restart", Image: &FailureHookImage{}}}, wantErr: true},
+		{name: "invalid webhook url", hooks: []FailureHook{{Name: "incident", Webhook: &FailureHookWebhook{URL: "ftp://x"}}}, wantErr: true},
+		{name: "metadata endpoint webhook", hooks: []FailureHook{{Name: "incident", Webhook: &FailureHookWebhook{URL: "http://169.254.169.254/latest"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFailureHooks(tt.hooks, notify.Policy{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFailureHooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateArtifactStorage(t *testing.T) {
+	valid := ArtifactStorage{Endpoint: "https://s3.example.com", Bucket: "chaos-artifacts", CredentialsSecret: "s3-credentials"}
+	with := func(mutate func(*ArtifactStorage)) *ArtifactStorage {
+		storage := valid
+		mutate(&storage)
+		return &storage
+	}
+
+	tests := []struct {
+		name    string
+		storage *ArtifactStorage
+		wantErr bool
+	}{
+		{name: "no storage", storage: nil},
+		{name: "valid storage", storage: &valid},
+		{name: "http endpoint with prefix", storage: with(func(s *ArtifactStorage) { s.Endpoint = "http://minio:9000"; s.Prefix = "runs" })},
+		{name: "endpoint without scheme", storage: with(func(s *ArtifactStorage) { s.Endpoint = "s3.example.com" }), wantErr: true},
+		{name: "invalid bucket", storage: with(func(s *ArtifactStorage) { s.Bucket = "Chaos_Artifacts" }), wantErr: true},
+		{name: "missing credentials", storage: with(func(s *ArtifactStorage) { s.CredentialsSecret = "" }), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArtifactStorage(tt.storage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateArtifactStorage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateElasticsearch(t *testing.T) {
+	handler := setupTestHandler()
+	for name, data := range map[string]map[string][]byte{
+		"es-credentials": {corev1.BasicAuthUsernameKey: []byte("elastic"), corev1.BasicAuthPasswordKey: []byte("changeme")},
+		"es-token":       {"token": []byte("abc")},
+	} {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: handler.namespace}, Data: data}
+		if err := handler.client.Create(context.Background(), secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		es      *ElasticsearchTelemetry
+		wantErr bool
+	}{
+		{name: "not configured", es: nil},
+		{name: "without credentials", es: &ElasticsearchTelemetry{URL: "http://elasticsearch:9200", Index: "krkn"}},
+		{name: "with credentials", es: &ElasticsearchTelemetry{URL: "https://es.example.com", Index: "krkn", CredentialsSecret: "es-credentials"}},
+		{name: "no scheme", es: &ElasticsearchTelemetry{URL: "es.example.com:9200", Index: "krkn"}, wantErr: true},
+		{name: "url with path", es: &ElasticsearchTelemetry{URL: "https://es.example.com/es", Index: "krkn"}, wantErr: true},
+		{name: "missing index", es: &ElasticsearchTelemetry{URL: "https://es.example.com"}, wantErr: true},
+		{name: "missing secret", es: &ElasticsearchTelemetry{URL: "https://es.example.com", Index: "krkn", CredentialsSecret: "missing"}, wantErr: true},
+		{name: "secret without basic auth keys", es: &ElasticsearchTelemetry{URL: "https://es.example.com", Index: "krkn", CredentialsSecret: "es-token"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.validateElasticsearch(context.Background(), tt.es)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateElasticsearch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHealthCheck(t *testing.T) {
+	handler := setupTestHandler()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-token", Namespace: handler.namespace},
+		Data:       map[string][]byte{"token": []byte("abc")},
+	}
+	if err := handler.client.Create(context.Background(), secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	interval := func(seconds int32) *int32 { return &seconds }
+
+	tests := []struct {
+		name        string
+		healthCheck *HealthCheck
+		wantErr     bool
+	}{
+		{name: "not configured", healthCheck: nil},
+		{name: "url only", healthCheck: &HealthCheck{URL: "http://app.example.com/health"}},
+		{name: "with token", healthCheck: &HealthCheck{URL: "https://app.example.com", IntervalSeconds: interval(5), BearerTokenSecret: "app-token"}},
+		{name: "no scheme", healthCheck: &HealthCheck{URL: "app.example.com/health"}, wantErr: true},
+		{name: "zero interval", healthCheck: &HealthCheck{URL: "https://app.example.com", IntervalSeconds: interval(0)}, wantErr: true},
+		{name: "missing secret", healthCheck: &HealthCheck{URL: "https://app.example.com", BearerTokenSecret: "missing"}, wantErr: true},
+		{name: "missing key", healthCheck: &HealthCheck{URL: "https://app.example.com", BearerTokenSecret: "app-token", BearerTokenKey: "jwt"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.validateHealthCheck(context.Background(), tt.healthCheck)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateHealthCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAlertProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *AlertProfile
+		wantErr bool
+	}{
+		{name: "not configured", profile: nil},
+		{name: "valid", profile: &AlertProfile{Alerts: []AlertRule{{Expr: "up == 0", Description: "down", Severity: "critical"}}}},
+		{name: "no alert", profile: &AlertProfile{}, wantErr: true},
+		{name: "empty expr", profile: &AlertProfile{Alerts: []AlertRule{{Expr: " ", Severity: "error"}}}, wantErr: true},
+		{name: "unknown severity", profile: &AlertProfile{Alerts: []AlertRule{{Expr: "up == 0", Severity: "fatal"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAlertProfile(tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateAlertProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCleanupVerification(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification *CleanupVerification
+		wantErr      bool
+	}{
+		{name: "not configured", verification: nil},
+		{name: "namespaces", verification: &CleanupVerification{Namespaces: []string{"krkn-test", "chaos-1"}}},
+		{name: "invalid namespace", verification: &CleanupVerification{Namespaces: []string{"Krkn_Test"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCleanupVerification(tt.verification)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCleanupVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindJobPod_LatestPod(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+
+	// The Job replaced a pod lost with its node
+	now := time.Now()
+	for name, created := range map[string]time.Time{
+		"krkn-job-job-1-first":  now.Add(-time.Minute),
+		"krkn-job-job-1-second": now,
+	} {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         handler.namespace,
+			Labels:            map[string]string{"krkn-job-id": "job-1"},
+			CreationTimestamp: metav1.NewTime(created),
+		}}
+		if err := handler.client.Create(ctx, pod); err != nil {
+			t.Fatalf("failed to create pod: %v", err)
+		}
+	}
+
+	pod, err := handler.findJobPod(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod == nil || pod.Name != "krkn-job-job-1-second" {
+		t.Errorf("Expected the latest pod, got %v", pod)
+	}
+
+	if pod, err := handler.findJobPod(ctx, "job-2"); err != nil || pod != nil {
+		t.Errorf("Expected no pod for an unknown job, got %v, %v", pod, err)
+	}
+}
+
+func TestDeleteSingleJob_DeletesJob(t *testing.T) {
+	handler := setupRetryTestHandler()
+	ctx := context.Background()
+
+	createCompletedRun(t, handler, "run-1", "Running", krknv1alpha1.ClusterJobStatus{
+		ProviderName: "krkn-operator", ClusterName: "cluster-1", JobID: "job-1", Phase: "Running",
+	})
+	batchJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:      "krkn-job-job-1",
+		Namespace: handler.namespace,
+		Labels:    map[string]string{"krkn-job-id": "job-1", "krkn-scenario-run": "run-1"},
+	}}
+	if err := handler.client.Create(ctx, batchJob); err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, ScenariosRunPath+"/jobs/job-1", nil)
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(req))
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+	err := handler.client.Get(ctx, client.ObjectKey{Name: "krkn-job-job-1", Namespace: handler.namespace}, &batchv1.Job{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the Job to be deleted, got %v", err)
+	}
+
+	var run krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(ctx, client.ObjectKey{Name: "run-1", Namespace: handler.namespace}, &run); err != nil {
+		t.Fatalf("failed to get scenario run: %v", err)
+	}
+	if !run.Status.ClusterJobs[0].CancelRequested {
+		t.Error("Expected the job to be flagged for cancellation")
+	}
+}
+
+func TestDeleteScenarioRun_BackfillsIdentity(t *testing.T) {
+	handler := setupRetryTestHandler()
+	ctx := context.Background()
+
+	createCompletedRun(t, handler, "run-1", "Running", krknv1alpha1.ClusterJobStatus{
+		ProviderName: "krkn-operator", ClusterName: "cluster-1", JobID: "job-1", Phase: "Running",
+	})
+	// Labeled by the legacy path: no cluster, scenario name nor target request label
+	batchJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:      "krkn-job-job-1",
+		Namespace: handler.namespace,
+		Labels: map[string]string{
+			joblabels.JobID:          "job-1",
+			joblabels.ScenarioRun:    "run-1",
+			joblabels.LegacyTargetID: "target-1",
+		},
+	}}
+	if err := handler.client.Create(ctx, batchJob); err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add(ParamJobID, "job-1")
+	req := httptest.NewRequest(http.MethodDelete, ScenariosRunPath+"/job-1", nil)
+	req = withAdminClaims(req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)))
+	w := httptest.NewRecorder()
+	handler.DeleteScenarioRun(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response JobStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.TargetID != "target-1" || response.ClusterName != "cluster-1" || response.ScenarioName != "pod-scenarios" {
+		t.Errorf("Expected the identifiers to be back-filled, got %+v", response)
+	}
+}
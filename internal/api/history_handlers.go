@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// ListRunHistory handles GET /api/v1/history endpoint
+// Supported query parameters: scenarioName, phase, owner (admin only), since and until
+// (RFC3339, on the creation time) and limit (default 100). Users only see the runs they
+// own. 404 when the run history is off.
+func (h *Handler) ListRunHistory(w http.ResponseWriter, r *http.Request) {
+	if h.history == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgHistoryDisabled, nil)
+		return
+	}
+	claims := auth.GetClaimsFromContext(r.Context())
+	if claims == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := history.Query{
+		ScenarioName: query.Get("scenarioName"),
+		Phase:        query.Get("phase"),
+		OwnerUserID:  query.Get("owner"),
+	}
+	if !auth.IsAdmin(r.Context()) {
+		filter.OwnerUserID = claims.UserID
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidLimit, nil)
+			return
+		}
+		filter.Limit = parsed
+	}
+	for param, bound := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidTimestamp,
+				i18n.Params{"param": param})
+			return
+		}
+		*bound = &parsed
+	}
+
+	runs, err := h.history.List(r.Context(), filter)
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "Failed to query the run history")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgHistoryQueryFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, RunHistoryResponse{
+		Runs:  runs,
+		Count: len(runs),
+	})
+}
+
+// GetRunHistory handles GET /api/v1/history/{scenarioRunName} endpoint
+// Returns the recorded outcome of a run, also after its KrknScenarioRun was deleted.
+// Runs of other users are reported as not found to non-admins.
+func (h *Handler) GetRunHistory(w http.ResponseWriter, r *http.Request) {
+	if h.history == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgHistoryDisabled, nil)
+		return
+	}
+	claims := auth.GetClaimsFromContext(r.Context())
+	if claims == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
+		return
+	}
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	name := string(scenarioRunName)
+
+	run, err := h.history.Get(r.Context(), name)
+	if errors.Is(err, history.ErrNotFound) ||
+		(err == nil && !auth.IsAdmin(r.Context()) && run.OwnerUserID != claims.UserID) {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgHistoryNotFound, i18n.Params{"name": name})
+		return
+	}
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "Failed to read the run history", "scenarioRun", name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgHistoryQueryFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
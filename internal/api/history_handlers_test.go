@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+func TestRunHistoryEndpoints(t *testing.T) {
+	handler := setupTestHandler()
+
+	// History off
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d with the history off, got %d", http.StatusNotFound, w.Code)
+	}
+
+	store, err := history.NewFileStore(filepath.Join(t.TempDir(), "runs.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to open history: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	handler.history = store
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, run := range []history.Run{
+		{Name: "pod-scenarios-1", ScenarioName: "pod-scenarios", OwnerUserID: "alice@example.com", Phase: "Succeeded"},
+		{Name: "pod-scenarios-2", ScenarioName: "pod-scenarios", OwnerUserID: "bob@example.com", Phase: "Failed"},
+		{Name: "node-scenarios-1", ScenarioName: "node-scenarios", OwnerUserID: "alice@example.com", Phase: "Failed"},
+	} {
+		run.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		if err := store.Record(context.Background(), run); err != nil {
+			t.Fatalf("Failed to record run: %v", err)
+		}
+	}
+
+	asUser := func(req *http.Request, userID string) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), auth.UserClaimsKey, &auth.Claims{
+			UserID: userID,
+			Role:   "user",
+		}))
+	}
+	list := func(req *http.Request) []string {
+		t.Helper()
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response RunHistoryResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		names := make([]string, 0, len(response.Runs))
+		for _, run := range response.Runs {
+			names = append(names, run.Name)
+		}
+		if response.Count != len(names) {
+			t.Errorf("Expected count %d, got %d", len(names), response.Count)
+		}
+		return names
+	}
+	expect := func(what string, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%s: expected %v, got %v", what, want, got)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: expected %v, got %v", what, want, got)
+				return
+			}
+		}
+	}
+
+	expect("admin, all runs newest first",
+		list(withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath, nil))),
+		"node-scenarios-1", "pod-scenarios-2", "pod-scenarios-1")
+	expect("admin, by scenario and phase",
+		list(withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath+"?scenarioName=pod-scenarios&phase=Failed", nil))),
+		"pod-scenarios-2")
+	expect("admin, by owner",
+		list(withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath+"?owner=alice@example.com&limit=1", nil))),
+		"node-scenarios-1")
+	expect("admin, by time window",
+		list(withAdminClaims(httptest.NewRequest(http.MethodGet,
+			HistoryPath+"?since=2025-03-01T12:30:00Z&until=2025-03-01T14:00:00Z", nil))),
+		"pod-scenarios-2")
+	// Users only see their own runs, whatever owner they ask for
+	expect("user",
+		list(asUser(httptest.NewRequest(http.MethodGet, HistoryPath+"?owner=bob@example.com", nil), "alice@example.com")),
+		"node-scenarios-1", "pod-scenarios-1")
+
+	for _, query := range []string{"?limit=0", "?since=yesterday", "?until=2025-03-01"} {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath+query, nil)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+	}{
+		{
+			name:       "admin reads any run",
+			req:        withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath+"/pod-scenarios-2", nil)),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "owner reads the run",
+			req:        asUser(httptest.NewRequest(http.MethodGet, HistoryPath+"/pod-scenarios-2", nil), "bob@example.com"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "runs of other users are not found",
+			req:        asUser(httptest.NewRequest(http.MethodGet, HistoryPath+"/pod-scenarios-2", nil), "alice@example.com"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown run",
+			req:        withAdminClaims(httptest.NewRequest(http.MethodGet, HistoryPath+"/missing", nil)),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			serveAPI(handler, w, tt.req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var run history.Run
+			if err := json.NewDecoder(w.Body).Decode(&run); err != nil {
+				t.Fatalf("Failed to decode run: %v", err)
+			}
+			if run.Name != "pod-scenarios-2" || run.Phase != "Failed" {
+				t.Errorf("Unexpected run %+v", run)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// +kubebuilder:rbac:groups="",resources=nodes;resourcequotas,verbs=list
+
+const (
+	// MaxChaosPodsEnv is the budget of scenario pods the active runs may have on the hub.
+	// A run that would exceed it is rejected. 0 or unset disables the budget.
+	MaxChaosPodsEnv = "API_MAX_CHAOS_PODS"
+	// HubCapacityCheckEnv set to true rejects runs whose scenario pods do not fit in the
+	// pod quota of the operator namespace or on the schedulable nodes of the hub
+	HubCapacityCheckEnv = "API_HUB_CAPACITY_CHECK"
+)
+
+// HubCapacityGuard keeps the runs created through the API from destabilizing the hub with
+// their own scenario pods, one per target cluster
+type HubCapacityGuard struct {
+	// MaxChaosPods is the budget of scenario pods of the active runs, 0 for no budget
+	MaxChaosPods int
+	// CheckCapacity checks the namespace pod quotas and the free pod slots of the
+	// schedulable nodes
+	CheckCapacity bool
+}
+
+// HubCapacityGuardFromEnv reads the hub capacity guard from the environment
+func HubCapacityGuardFromEnv() HubCapacityGuard {
+	var guard HubCapacityGuard
+	if pods, err := strconv.Atoi(os.Getenv(MaxChaosPodsEnv)); err == nil && pods > 0 {
+		guard.MaxChaosPods = pods
+	}
+	guard.CheckCapacity, _ = strconv.ParseBool(os.Getenv(HubCapacityCheckEnv))
+	return guard
+}
+
+// capacityShortage is why the hub cannot take the scenario pods of a run
+type capacityShortage struct {
+	code      string
+	requested int
+	available int64
+	// details are the message parameters specific to code
+	details i18n.Params
+}
+
+// params returns the parameters of the localized message of the shortage
+func (s *capacityShortage) params() i18n.Params {
+	params := i18n.Params{
+		"requested": strconv.Itoa(s.requested),
+		"available": strconv.FormatInt(s.available, 10),
+	}
+	for key, value := range s.details {
+		params[key] = value
+	}
+	return params
+}
+
+// check returns the shortage preventing the hub from taking pods more scenario pods in
+// namespace, nil when they fit
+func (g HubCapacityGuard) check(ctx context.Context, c client.Client, clientset kubernetes.Interface, namespace string, pods int) (*capacityShortage, error) {
+	if g.MaxChaosPods > 0 {
+		active, err := activeChaosPods(ctx, c, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if active+pods > g.MaxChaosPods {
+			return &capacityShortage{
+				code:      MsgChaosPodBudgetExceeded,
+				requested: pods,
+				available: int64(max(g.MaxChaosPods-active, 0)),
+				details:   i18n.Params{"budget": strconv.Itoa(g.MaxChaosPods)},
+			}, nil
+		}
+	}
+	if !g.CheckCapacity {
+		return nil, nil
+	}
+
+	quotas, err := clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+	for _, quota := range quotas.Items {
+		hard, ok := quota.Status.Hard[corev1.ResourcePods]
+		if !ok {
+			continue
+		}
+		used := quota.Status.Used[corev1.ResourcePods]
+		if room := hard.Value() - used.Value(); room < int64(pods) {
+			return &capacityShortage{
+				code:      MsgNamespacePodQuotaExceeded,
+				requested: pods,
+				available: max(room, 0),
+				details:   i18n.Params{"quota": quota.Name, "namespace": namespace},
+			}, nil
+		}
+	}
+
+	free, err := schedulablePodSlots(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+	if free < int64(pods) {
+		return &capacityShortage{code: MsgHubNodeCapacityExceeded, requested: pods, available: free}, nil
+	}
+	return nil, nil
+}
+
+// activeChaosPods counts the scenario pods the active runs in namespace run or will
+// create: one per target cluster whose job did not end
+func activeChaosPods(ctx context.Context, c client.Client, namespace string) (int, error) {
+	var runs krknv1alpha1.KrknScenarioRunList
+	if err := c.List(ctx, &runs, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list scenario runs: %w", err)
+	}
+	active := 0
+	for _, run := range runs.Items {
+		if run.Status.CompletionTime != nil {
+			continue
+		}
+		pods := 0
+		for _, clusters := range run.Targets() {
+			pods += len(clusters)
+		}
+		for _, job := range run.Status.ClusterJobs {
+			if statemachine.Jobs.IsTerminal(job.Phase) {
+				pods--
+			}
+		}
+		active += max(pods, 0)
+	}
+	return active, nil
+}
+
+// schedulablePodSlots returns how many more pods the nodes scenario pods can be scheduled
+// on take: ready nodes that are not cordoned nor tainted, scenario pods tolerating no taint
+func schedulablePodSlots(ctx context.Context, clientset kubernetes.Interface) (int64, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	slots := make(map[string]int64)
+	for _, node := range nodes.Items {
+		if schedulableNode(&node) {
+			slots[node.Name] = node.Status.Allocatable.Pods().Value()
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermNotEqualSelector("spec.nodeName", ""),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		).String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if _, ok := slots[pod.Spec.NodeName]; ok {
+			slots[pod.Spec.NodeName]--
+		}
+	}
+
+	var free int64
+	for _, slot := range slots {
+		free += max(slot, 0)
+	}
+	return free, nil
+}
+
+// schedulableNode reports whether a pod without tolerations can be scheduled on node
+func schedulableNode(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
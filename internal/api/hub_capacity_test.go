@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func hubNode(name string, pods int64, ready bool, taints ...corev1.Taint) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(pods, resource.DecimalSI)},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func hubPod(name, node string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system"},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestHubCapacityGuard_Check(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+	now := metav1.Now()
+
+	// 2 clusters of the active run still need a pod, the finished run none
+	for _, run := range []*krknv1alpha1.KrknScenarioRun{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "active", Namespace: handler.namespace},
+			Spec: krknv1alpha1.KrknScenarioRunSpec{TargetClusters: map[string][]string{
+				"krkn-operator": {"cluster-1", "cluster-2", "cluster-3"},
+			}},
+			Status: krknv1alpha1.KrknScenarioRunStatus{ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ClusterName: "cluster-1", Phase: statemachine.JobSucceeded},
+				{ClusterName: "cluster-2", Phase: statemachine.JobRunning},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "finished", Namespace: handler.namespace},
+			Spec: krknv1alpha1.KrknScenarioRunSpec{TargetClusters: map[string][]string{
+				"krkn-operator": {"cluster-1"},
+			}},
+			Status: krknv1alpha1.KrknScenarioRunStatus{CompletionTime: &now},
+		},
+	} {
+		if err := handler.client.Create(ctx, run); err != nil {
+			t.Fatalf("failed to create scenario run: %v", err)
+		}
+	}
+
+	// Only worker-1 takes scenario pods: 3 free slots
+	clientset := fake.NewSimpleClientset(
+		hubNode("worker-1", 5, true),
+		hubNode("worker-2", 110, false),
+		hubNode("control-plane", 110, true, corev1.Taint{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}),
+		hubPod("dns", "worker-1"),
+		hubPod("proxy", "worker-1"),
+		hubPod("apiserver", "control-plane"),
+	)
+
+	tests := []struct {
+		name     string
+		guard    HubCapacityGuard
+		quota    int64
+		pods     int
+		wantCode string
+	}{
+		{name: "disabled", pods: 100},
+		{name: "within budget", guard: HubCapacityGuard{MaxChaosPods: 5}, pods: 3},
+		{name: "budget exceeded", guard: HubCapacityGuard{MaxChaosPods: 5}, pods: 4, wantCode: MsgChaosPodBudgetExceeded},
+		{name: "nodes fit", guard: HubCapacityGuard{CheckCapacity: true}, pods: 3},
+		{name: "nodes full", guard: HubCapacityGuard{CheckCapacity: true}, pods: 4, wantCode: MsgHubNodeCapacityExceeded},
+		{name: "quota exceeded", guard: HubCapacityGuard{CheckCapacity: true}, quota: 2, pods: 3, wantCode: MsgNamespacePodQuotaExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = clientset.CoreV1().ResourceQuotas(handler.namespace).Delete(ctx, "pods", metav1.DeleteOptions{})
+			if tt.quota > 0 {
+				quota := &corev1.ResourceQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: handler.namespace},
+					Status: corev1.ResourceQuotaStatus{
+						Hard: corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(tt.quota, resource.DecimalSI)},
+						Used: corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(0, resource.DecimalSI)},
+					},
+				}
+				if _, err := clientset.CoreV1().ResourceQuotas(handler.namespace).Create(ctx, quota, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("failed to create quota: %v", err)
+				}
+			}
+
+			shortage, err := tt.guard.check(ctx, handler.client, clientset, handler.namespace, tt.pods)
+			if err != nil {
+				t.Fatalf("check() error = %v", err)
+			}
+			switch {
+			case tt.wantCode == "" && shortage != nil:
+				t.Errorf("expected the pods to fit, got %+v", shortage)
+			case tt.wantCode != "" && (shortage == nil || shortage.code != tt.wantCode):
+				t.Errorf("expected shortage %s, got %+v", tt.wantCode, shortage)
+			}
+		})
+	}
+}
+
+func TestPostScenarioRun_HubCapacityExceeded(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"cluster-1": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+		"cluster-2": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	handler.hubCapacity = HubCapacityGuard{MaxChaosPods: 1}
+
+	reqBody := `{
+		"targetRequestID": "test-request-id",
+		"targetClusters": {"krkn-operator": ["cluster-1", "cluster-2"]},
+		"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+		"scenarioName": "pod-delete"
+	}`
+	req := httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.PostScenarioRun(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusServiceUnavailable, w.Code, w.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != MsgChaosPodBudgetExceeded || !strings.Contains(response.Message, "needs 2 scenario pods") {
+		t.Errorf("Unexpected error %+v", response)
+	}
+
+	var runs krknv1alpha1.KrknScenarioRunList
+	if err := handler.client.List(context.Background(), &runs); err != nil {
+		t.Fatalf("Failed to list scenario runs: %v", err)
+	}
+	if len(runs.Items) != 0 {
+		t.Errorf("Expected no run to be created, got %d", len(runs.Items))
+	}
+}
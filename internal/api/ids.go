@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// Typed IDs of the objects addressed by the API. IDs taken from paths, query parameters
+// and request bodies are used as object names, so they are parsed with ParseID before
+// reaching the API server: a garbage ID gets a 400 instead of a confusing apiserver error.
+type (
+	// TargetUUID identifies a KrknOperatorTarget
+	TargetUUID string
+	// TargetRequestID identifies a KrknTargetRequest
+	TargetRequestID string
+	// ProviderConfigUUID identifies a KrknOperatorTargetProviderConfig
+	ProviderConfigUUID string
+	// ScenarioRunName identifies a KrknScenarioRun
+	ScenarioRunName string
+	// JobID identifies a cluster job of a scenario run
+	JobID string
+)
+
+// ID is any typed ID
+type ID interface {
+	TargetUUID | TargetRequestID | ProviderConfigUUID | ScenarioRunName | JobID
+}
+
+// IDPattern documents the accepted IDs: a UUID, in any case, or an object name made of
+// lowercase alphanumerics, '-' and '.', starting and ending with an alphanumeric
+const IDPattern = `^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*)$`
+
+// errIDRequired is returned by ParseID for an empty ID
+var errIDRequired = errors.New("is required")
+
+// ParseID validates an ID. UUIDs are returned in lowercase, the form used in object
+// names; anything else must be a valid object name (at most 253 characters).
+func ParseID[T ID](value string) (T, error) {
+	if value == "" {
+		return "", errIDRequired
+	}
+	if len(value) == 36 {
+		if _, err := uuid.Parse(value); err == nil {
+			return T(strings.ToLower(value)), nil
+		}
+	}
+	if len(validation.IsDNS1123Subdomain(value)) > 0 {
+		return "", fmt.Errorf("'%s' must be a UUID or an object name: lowercase alphanumerics, "+
+			"'-' or '.', starting and ending with an alphanumeric, at most %d characters",
+			value, validation.DNS1123SubdomainMaxLength)
+	}
+	return T(value), nil
+}
+
+// pathID returns a path parameter parsed as an ID
+func pathID[T ID](r *http.Request, name string) (T, error) {
+	value, err := pathParam(r, name)
+	if err != nil {
+		return "", errIDRequired
+	}
+	return ParseID[T](value)
+}
+
+// queryID returns a query parameter parsed as an ID, or an empty ID when it is not set
+func queryID[T ID](r *http.Request, name string) (T, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return "", nil
+	}
+	return ParseID[T](value)
+}
+
+// writeInvalidIDError writes the 400 response of a missing or invalid ID parameter.
+// UUID path parameters keep their invalid_uuid code.
+func writeInvalidIDError(w http.ResponseWriter, r *http.Request, param string, err error) {
+	switch {
+	case param == ParamUUID:
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidUUID, i18n.Params{"error": err.Error()})
+	case errors.Is(err, errIDRequired):
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": param})
+	default:
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidID,
+			i18n.Params{"param": param, "error": err.Error()})
+	}
+}
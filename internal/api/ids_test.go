@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    TargetUUID
+		wantErr bool
+	}{
+		{name: "uuid", value: "550e8400-e29b-41d4-a716-446655440000", want: "550e8400-e29b-41d4-a716-446655440000"},
+		{name: "uppercase uuid is normalized", value: "550E8400-E29B-41D4-A716-446655440000", want: "550e8400-e29b-41d4-a716-446655440000"},
+		{name: "object name", value: "pod-scenarios-1a2b3c4d", want: "pod-scenarios-1a2b3c4d"},
+		{name: "dotted object name", value: "target.example.com", want: "target.example.com"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "uppercase name", value: "MyTarget", wantErr: true},
+		{name: "underscore", value: "my_target", wantErr: true},
+		{name: "path traversal", value: "../secrets", wantErr: true},
+		{name: "braced uuid", value: "{550e8400-e29b-41d4-a716-446655440000}", wantErr: true},
+		{name: "too long", value: strings.Repeat("a", 254), wantErr: true},
+	}
+
+	pattern := regexp.MustCompile(IDPattern)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseID[TargetUUID](tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseID(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseID(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+			// The documented pattern agrees with the parser, except for the length limit
+			if len(tt.value) <= 253 && pattern.MatchString(tt.value) == tt.wantErr {
+				t.Errorf("IDPattern match of %q = %v, want %v", tt.value, !tt.wantErr, !tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseID[JobID](""); !errors.Is(err, errIDRequired) {
+		t.Errorf("Expected errIDRequired for an empty ID, got %v", err)
+	}
+}
+
+func TestInvalidIDResponses(t *testing.T) {
+	handler := setupTestHandler()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode string
+	}{
+		{name: "target uuid", path: OperatorTargetsPath + "/Not_A_UUID", wantCode: MsgInvalidUUID},
+		{name: "provider config uuid", path: ProviderConfigPath + "/Not_A_UUID", wantCode: MsgInvalidUUID},
+		{name: "scenario run name", path: ScenariosRunPath + "/Bad_Run", wantCode: MsgInvalidID},
+		{name: "job id", path: ScenariosRunPath + "/jobs/bad%20job", wantCode: MsgInvalidID},
+		{name: "target request id query", path: ClustersPath + "?id=Bad_ID", wantCode: MsgInvalidID},
+		{name: "target uuid query", path: NodesPath + "?targetUUID=Bad_ID", wantCode: MsgInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, tt.path, nil)))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if errResp.Error != "bad_request" || errResp.Code != tt.wantCode {
+				t.Errorf("Expected bad_request/%s, got %s/%s", tt.wantCode, errResp.Error, errResp.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// Server-Sent Event names sent on the job events stream
+const (
+	JobEventStatus = "status"
+	JobEventEnd    = "end"
+)
+
+// jobEventsHeartbeatInterval keeps idle streams alive through proxies
+const jobEventsHeartbeatInterval = 15 * time.Second
+
+// jobPhaseForPod maps a pod phase to the job phase the controller derives from it
+func jobPhaseForPod(phase corev1.PodPhase) string {
+	switch phase {
+	case corev1.PodPending:
+		return statemachine.JobPending
+	case corev1.PodRunning:
+		return statemachine.JobRunning
+	case corev1.PodSucceeded:
+		return statemachine.JobSucceeded
+	case corev1.PodFailed, corev1.PodUnknown:
+		return statemachine.JobFailed
+	}
+	return ""
+}
+
+// podDisrupted reports whether pod was lost to a node failure, drain or eviction.
+// The Job replaces such pods without failing, so they do not end the job.
+func podDisrupted(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSSE writes a single Server-Sent Event and flushes it to the client
+func writeSSE(w http.ResponseWriter, rc *http.ResponseController, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// StreamJobEvents handles GET /api/v1/scenarios/run/jobs/{jobId}/events
+// It streams the job status as Server-Sent Events: a "status" event with the current
+// phase, one per phase transition of the job pod, and a final "end" event once the pod
+// has completed or was deleted.
+func (h *Handler) StreamJobEvents(w http.ResponseWriter, r *http.Request) {
+	jobID, err := pathID[JobID](r, ParamJobID)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamJobID, err)
+		return
+	}
+
+	job, ok := h.findViewableJob(w, r, jobID)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	current := JobStatusEvent{
+		JobID:     job.JobID,
+		PodName:   job.PodName,
+		Phase:     job.Phase,
+		Message:   job.Message,
+		Timestamp: time.Now().UTC(),
+	}
+
+	// Only pending and running jobs still have pod transitions to report
+	var watcher watch.Interface
+	if job.Phase == statemachine.JobPending || job.Phase == statemachine.JobRunning {
+		watcher, err = h.clientset.CoreV1().Pods(h.namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector: joblabels.JobID + "=" + string(jobID),
+		})
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgJobWatchFailed,
+				i18n.Params{"error": err.Error()})
+			return
+		}
+		defer watcher.Stop()
+	}
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server write timeout
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeSSE(w, rc, JobEventStatus, current); err != nil {
+		return
+	}
+	if watcher == nil {
+		_ = writeSSE(w, rc, JobEventEnd, current)
+		return
+	}
+
+	heartbeat := time.NewTicker(jobEventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case event, open := <-watcher.ResultChan():
+			if !open {
+				// The watch expired, the client reconnects and resumes from the current status
+				return
+			}
+			// Pods are selected by job label: the Job may replace the pod the job started with
+			pod, isPod := event.Object.(*corev1.Pod)
+			if !isPod || podDisrupted(pod) {
+				continue
+			}
+
+			if event.Type == watch.Deleted {
+				current.Message = "Pod deleted"
+				current.Timestamp = time.Now().UTC()
+				_ = writeSSE(w, rc, JobEventEnd, current)
+				return
+			}
+
+			phase := jobPhaseForPod(pod.Status.Phase)
+			if phase == "" || phase == current.Phase {
+				continue
+			}
+			current = JobStatusEvent{
+				JobID:         job.JobID,
+				PodName:       pod.Name,
+				Phase:         phase,
+				PreviousPhase: current.Phase,
+				Message:       pod.Status.Message,
+				Timestamp:     time.Now().UTC(),
+			}
+			if err := writeSSE(w, rc, JobEventStatus, current); err != nil {
+				logger.V(1).Info("job events client disconnected", "jobID", jobID, "error", err.Error())
+				return
+			}
+
+			if phase == statemachine.JobSucceeded || phase == statemachine.JobFailed {
+				_ = writeSSE(w, rc, JobEventEnd, current)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+type sseEvent struct {
+	name string
+	data JobStatusEvent
+}
+
+// readSSEEvent reads the next event from a Server-Sent Events stream, skipping comments
+func readSSEEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && event.name != "":
+			return event
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.data); err != nil {
+				t.Fatalf("invalid event data %q: %v", line, err)
+			}
+		}
+	}
+}
+
+// openJobEvents starts a test server for handler and opens the event stream of jobID
+func openJobEvents(t *testing.T, handler *Handler, jobID string) *http.Response {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveAPI(handler, w, withAdminClaims(r))
+	}))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + ScenariosRunJobsPath + "/" + jobID + JobEventsSuffix)
+	if err != nil {
+		t.Fatalf("failed to open event stream: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func createJobEventsRun(t *testing.T, handler *Handler, job krknv1alpha1.ClusterJobStatus) {
+	t.Helper()
+	run := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{job},
+		},
+	}
+	if err := handler.client.Create(context.Background(), run); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+}
+
+func TestStreamJobEvents_PodTransitions(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+
+	createJobEventsRun(t, handler, krknv1alpha1.ClusterJobStatus{
+		ClusterName: "cluster-1",
+		JobID:       "job-1",
+		PodName:     "krkn-job-job-1",
+		Phase:       statemachine.JobPending,
+	})
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "krkn-job-job-1",
+			Namespace: handler.namespace,
+			Labels:    map[string]string{"krkn-job-id": "job-1"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	if _, err := handler.clientset.CoreV1().Pods(handler.namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+
+	resp := openJobEvents(t, handler, "job-1")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream content type, got %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	initial := readSSEEvent(t, reader)
+	if initial.name != JobEventStatus || initial.data.Phase != statemachine.JobPending || initial.data.PreviousPhase != "" {
+		t.Fatalf("unexpected initial event: %+v", initial)
+	}
+
+	// The watch is established before the first event is sent
+	updatePodPhase := func(phase corev1.PodPhase) {
+		pod.Status.Phase = phase
+		if _, err := handler.clientset.CoreV1().Pods(handler.namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update pod: %v", err)
+		}
+	}
+
+	updatePodPhase(corev1.PodRunning)
+	running := readSSEEvent(t, reader)
+	if running.name != JobEventStatus || running.data.Phase != statemachine.JobRunning || running.data.PreviousPhase != statemachine.JobPending {
+		t.Fatalf("unexpected running event: %+v", running)
+	}
+
+	updatePodPhase(corev1.PodSucceeded)
+	succeeded := readSSEEvent(t, reader)
+	if succeeded.name != JobEventStatus || succeeded.data.Phase != statemachine.JobSucceeded || succeeded.data.PreviousPhase != statemachine.JobRunning {
+		t.Fatalf("unexpected succeeded event: %+v", succeeded)
+	}
+	if end := readSSEEvent(t, reader); end.name != JobEventEnd || end.data.Phase != statemachine.JobSucceeded {
+		t.Fatalf("unexpected end event: %+v", end)
+	}
+}
+
+func TestStreamJobEvents_CompletedJob(t *testing.T) {
+	handler := setupTestHandler()
+	createJobEventsRun(t, handler, krknv1alpha1.ClusterJobStatus{
+		ClusterName: "cluster-1",
+		JobID:       "job-1",
+		PodName:     "krkn-job-job-1",
+		Phase:       statemachine.JobFailed,
+		Message:     "Pod failed",
+	})
+
+	resp := openJobEvents(t, handler, "job-1")
+	reader := bufio.NewReader(resp.Body)
+
+	if status := readSSEEvent(t, reader); status.name != JobEventStatus || status.data.Phase != statemachine.JobFailed {
+		t.Fatalf("unexpected status event: %+v", status)
+	}
+	if end := readSSEEvent(t, reader); end.name != JobEventEnd {
+		t.Fatalf("expected the stream to end for a completed job, got %+v", end)
+	}
+}
+
+func TestStreamJobEvents_NotFound(t *testing.T) {
+	handler := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, ScenariosRunJobsPath+"/missing"+JobEventsSuffix, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
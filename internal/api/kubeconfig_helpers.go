@@ -28,31 +28,29 @@ import (
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
 )
 
 // getKubeconfigFromOperatorTarget retrieves kubeconfig from KrknOperatorTarget
 // Returns base64-encoded kubeconfig string
-func (h *Handler) getKubeconfigFromOperatorTarget(ctx context.Context, targetUUID string) (string, error) {
+func (h *Handler) getKubeconfigFromOperatorTarget(ctx context.Context, targetUUID TargetUUID) (string, error) {
 	// Fetch KrknOperatorTarget
 	var target krknv1alpha1.KrknOperatorTarget
 	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      targetUUID,
+		Name:      string(targetUUID),
 		Namespace: h.namespace,
 	}, &target); err != nil {
 		return "", fmt.Errorf("failed to fetch KrknOperatorTarget: %w", err)
 	}
 
-	// Fetch Secret
-	var secret corev1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      target.Spec.SecretUUID,
-		Namespace: h.namespace,
-	}, &secret); err != nil {
-		return "", fmt.Errorf("failed to fetch secret: %w", err)
+	// Fetch the Secret, or the credentials store for targets with a Vault path
+	credentials, err := secrets.TargetCredentials(ctx, h.client, h.credentialsStore, &target)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch credentials: %w", err)
 	}
 
-	// Extract kubeconfig from secret data
-	kubeconfigData, exists := secret.Data["kubeconfig"]
+	// Extract kubeconfig from the credentials
+	kubeconfigData, exists := credentials["kubeconfig"]
 	if !exists {
 		return "", fmt.Errorf("kubeconfig not found in secret")
 	}
@@ -63,17 +61,17 @@ func (h *Handler) getKubeconfigFromOperatorTarget(ctx context.Context, targetUUI
 		return "", fmt.Errorf("failed to unmarshal kubeconfig from secret: %w", err)
 	}
 
-	return kubeconfigBase64, nil
+	return h.openKubeconfig(ctx, kubeconfigBase64)
 }
 
 // getKubeconfigFromTargetRequest retrieves kubeconfig from KrknTargetRequest (legacy)
 // This is for backward compatibility with the old krkn-operator-acm flow
 // Returns base64-encoded kubeconfig string
-func (h *Handler) getKubeconfigFromTargetRequest(ctx context.Context, targetID string, clusterName string) (string, error) {
+func (h *Handler) getKubeconfigFromTargetRequest(ctx context.Context, targetID TargetRequestID, clusterName string) (string, error) {
 	// Fetch the secret with the same name as the KrknTargetRequest ID
 	var secret corev1.Secret
 	err := h.client.Get(ctx, types.NamespacedName{
-		Name:      targetID,
+		Name:      string(targetID),
 		Namespace: h.namespace,
 	}, &secret)
 
@@ -109,7 +107,17 @@ func (h *Handler) getKubeconfigFromTargetRequest(ctx context.Context, targetID s
 	}
 
 	// Return the base64-encoded kubeconfig
-	return clusterConfig.Kubeconfig, nil
+	return h.openKubeconfig(ctx, clusterConfig.Kubeconfig)
+}
+
+// openKubeconfig decrypts a kubeconfig sealed at rest; plaintext kubeconfigs are
+// returned as they are
+func (h *Handler) openKubeconfig(ctx context.Context, kubeconfigBase64 string) (string, error) {
+	opened, err := h.kubeconfigCipher.Open(ctx, kubeconfigBase64)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt kubeconfig: %w", err)
+	}
+	return opened, nil
 }
 
 // getClusterAPIURL retrieves the cluster API URL from either:
@@ -117,12 +125,12 @@ func (h *Handler) getKubeconfigFromTargetRequest(ctx context.Context, targetID s
 // 2. KrknTargetRequest (legacy) - if targetID and clusterName are provided
 //
 // Returns cluster API URL string for permission checks
-func (h *Handler) getClusterAPIURL(ctx context.Context, targetUUID string, targetID string, clusterName string) (string, error) {
+func (h *Handler) getClusterAPIURL(ctx context.Context, targetUUID TargetUUID, targetID TargetRequestID, clusterName string) (string, error) {
 	// Try new system first (KrknOperatorTarget)
 	if targetUUID != "" {
 		var target krknv1alpha1.KrknOperatorTarget
 		if err := h.client.Get(ctx, types.NamespacedName{
-			Name:      targetUUID,
+			Name:      string(targetUUID),
 			Namespace: h.namespace,
 		}, &target); err != nil {
 			// If KrknOperatorTarget not found but we have legacy params, try legacy
@@ -138,7 +146,7 @@ func (h *Handler) getClusterAPIURL(ctx context.Context, targetUUID string, targe
 	if targetID != "" && clusterName != "" {
 		var targetRequest krknv1alpha1.KrknTargetRequest
 		if err := h.client.Get(ctx, types.NamespacedName{
-			Name:      targetID,
+			Name:      string(targetID),
 			Namespace: h.namespace,
 		}, &targetRequest); err != nil {
 			return "", fmt.Errorf("failed to fetch KrknTargetRequest: %w", err)
@@ -164,7 +172,7 @@ func (h *Handler) getClusterAPIURL(ctx context.Context, targetUUID string, targe
 // 2. KrknTargetRequest (legacy) - if targetID and clusterName are provided
 //
 // Returns base64-encoded kubeconfig string
-func (h *Handler) getKubeconfig(ctx context.Context, targetUUID string, targetID string, clusterName string) (string, error) {
+func (h *Handler) getKubeconfig(ctx context.Context, targetUUID TargetUUID, targetID TargetRequestID, clusterName string) (string, error) {
 	// Try new system first (KrknOperatorTarget)
 	if targetUUID != "" {
 		kubeconfigBase64, err := h.getKubeconfigFromOperatorTarget(ctx, targetUUID)
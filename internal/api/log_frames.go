@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// QueryLogFormat is the query parameter selecting how log WebSockets frame lines
+const QueryLogFormat = "format"
+
+const (
+	// LogFormatText sends every log line as-is in a text message (default)
+	LogFormatText = "text"
+	// LogFormatJSON wraps every log line in a LogLineFrame and reports errors as LogControlFrames
+	LogFormatJSON = "json"
+)
+
+const (
+	// LogFrameLine is the type of a LogLineFrame
+	LogFrameLine = "log"
+	// LogFrameError is the type of a LogControlFrame reporting a failure
+	LogFrameError = "error"
+	// LogFrameEnd is the type of a LogControlFrame sent once the log stream of a job ended
+	LogFrameEnd = "end"
+	// LogFrameAttempt is the type of a LogControlFrame sent with followRun=true when the
+	// stream moves on to the pod of the next attempt of a job
+	LogFrameAttempt = "attempt"
+)
+
+// truncatedLineMarker ends a text message holding a log line cut at the maximum length
+const truncatedLineMarker = " [truncated]"
+
+// LogStreamAll is the stream of every log line: the pod log API interleaves stdout and stderr
+const LogStreamAll = "all"
+
+// LogLineFrame is the JSON envelope of a log line with format=json
+type LogLineFrame struct {
+	// Type is always LogFrameLine
+	Type string `json:"type"`
+	// JobID is the job that produced the line
+	JobID string `json:"jobId"`
+	// PodName is the pod of the job
+	PodName string `json:"podName"`
+	// Timestamp is when the container wrote the line, as recorded by the kubelet
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Stream is the container output stream the line was read from
+	Stream string `json:"stream"`
+	// Line is the log line, without its timestamp. A line longer than a frame is sent in
+	// several frames to be concatenated.
+	Line string `json:"line"`
+	// Chunk is the position of this part of a long line, 0 for the first part
+	Chunk int `json:"chunk,omitempty"`
+	// Partial is set on every part of a long line but the last one
+	Partial bool `json:"partial,omitempty"`
+	// Truncated is set on the last part of a line cut at the maximum log line length
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// LogControlFrame is a JSON message about the stream itself with format=json
+type LogControlFrame struct {
+	// Type is LogFrameError, LogFrameEnd or LogFrameAttempt
+	Type string `json:"type"`
+	// JobID is the job the message is about, empty if it concerns the whole stream. For an
+	// attempt frame, it is the job of the new attempt.
+	JobID string `json:"jobId,omitempty"`
+	// PodName is the pod of the job, when it was found
+	PodName string `json:"podName,omitempty"`
+	// Message describes the error
+	Message string `json:"message,omitempty"`
+	// Attempt is the number of the new attempt of an attempt frame, 1 for the first one
+	Attempt int `json:"attempt,omitempty"`
+	// PreviousJobID is the job of the attempt before an attempt frame
+	PreviousJobID string `json:"previousJobId,omitempty"`
+}
+
+// parseLogFormat reads the format query parameter. ok is false for an unknown format.
+func parseLogFormat(r *http.Request) (format string, ok bool) {
+	switch format := r.URL.Query().Get(QueryLogFormat); format {
+	case "", LogFormatText:
+		return LogFormatText, true
+	case LogFormatJSON:
+		return LogFormatJSON, true
+	default:
+		return format, false
+	}
+}
+
+// logFramer builds the WebSocket messages for the log lines of one job.
+// In text mode lines are sent as-is after prefix, and errors as "ERROR: " lines.
+type logFramer struct {
+	format  string
+	prefix  string
+	jobID   string
+	podName string
+	// clusterName is set when prefix is the jobLogPrefix of the job, so that it follows
+	// the job of the next attempt
+	clusterName string
+}
+
+// jobLogPrefix prefixes the text lines of a job in the logs of a whole run
+func jobLogPrefix(clusterName, jobID string) string {
+	return fmt.Sprintf("[%s/%s] ", clusterName, jobID)
+}
+
+// line frames a complete log line
+func (f *logFramer) line(text string) []byte {
+	return f.chunk(logChunk{text: text, last: true})
+}
+
+// chunk frames a part of a log line. In JSON mode the log options request timestamps,
+// which are moved from the start of the line to the Timestamp field. In text mode every
+// part is a message of its own and a truncated line ends with truncatedLineMarker.
+func (f *logFramer) chunk(chunk logChunk) []byte {
+	if f.format != LogFormatJSON {
+		if chunk.truncated {
+			return []byte(f.prefix + chunk.text + truncatedLineMarker)
+		}
+		return []byte(f.prefix + chunk.text)
+	}
+
+	frame := LogLineFrame{
+		Type:      LogFrameLine,
+		JobID:     f.jobID,
+		PodName:   f.podName,
+		Stream:    LogStreamAll,
+		Line:      chunk.text,
+		Chunk:     chunk.index,
+		Partial:   !chunk.last,
+		Truncated: chunk.truncated,
+	}
+	if chunk.index == 0 {
+		if stamp, rest, found := strings.Cut(chunk.text, " "); found {
+			if timestamp, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				frame.Timestamp = &timestamp
+				frame.Line = rest
+			}
+		}
+	}
+	return marshalLogFrame(frame)
+}
+
+// errorf frames an error about the log stream of the job
+func (f *logFramer) errorf(format string, args ...any) []byte {
+	message := fmt.Sprintf(format, args...)
+	if f.format != LogFormatJSON {
+		return []byte(f.prefix + "ERROR: " + message)
+	}
+	return marshalLogFrame(LogControlFrame{Type: LogFrameError, JobID: f.jobID, PodName: f.podName, Message: message})
+}
+
+// attempt moves the framer on to the pod of the next attempt of the job and frames the
+// boundary between the attempts, a "--- attempt N ---" line in text mode
+func (f *logFramer) attempt(attempt int, jobID, podName string) []byte {
+	previousJobID := f.jobID
+	f.jobID = jobID
+	f.podName = podName
+	if f.clusterName != "" {
+		f.prefix = jobLogPrefix(f.clusterName, jobID)
+	}
+	if f.format != LogFormatJSON {
+		return fmt.Appendf(nil, "%s--- attempt %d: job %s, pod %s ---", f.prefix, attempt, jobID, podName)
+	}
+	return marshalLogFrame(LogControlFrame{
+		Type:          LogFrameAttempt,
+		JobID:         jobID,
+		PodName:       podName,
+		Attempt:       attempt,
+		PreviousJobID: previousJobID,
+	})
+}
+
+// end frames the end of the log stream of the job, nil in text mode where the
+// socket closing marks the end
+func (f *logFramer) end() []byte {
+	if f.format != LogFormatJSON {
+		return nil
+	}
+	return marshalLogFrame(LogControlFrame{Type: LogFrameEnd, JobID: f.jobID, PodName: f.podName})
+}
+
+func marshalLogFrame(frame any) []byte {
+	// Frames only hold strings and times, marshalling cannot fail
+	data, _ := json.Marshal(frame)
+	return data
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseLogFormat(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		ok       bool
+	}{
+		{query: "", expected: LogFormatText, ok: true},
+		{query: "format=text", expected: LogFormatText, ok: true},
+		{query: "format=json", expected: LogFormatJSON, ok: true},
+		{query: "format=xml", expected: "xml", ok: false},
+	}
+
+	for _, tt := range tests {
+		format, ok := parseLogFormat(httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil))
+		if format != tt.expected || ok != tt.ok {
+			t.Errorf("%q: expected (%q, %v), got (%q, %v)", tt.query, tt.expected, tt.ok, format, ok)
+		}
+	}
+}
+
+func TestLogFramer_Text(t *testing.T) {
+	framer := &logFramer{format: LogFormatText, prefix: "[cluster-1/job-1] ", jobID: "job-1"}
+
+	if got := string(framer.line("scenario started")); got != "[cluster-1/job-1] scenario started" {
+		t.Errorf("unexpected line: %q", got)
+	}
+	if got := string(framer.errorf("No pod found for job")); got != "[cluster-1/job-1] ERROR: No pod found for job" {
+		t.Errorf("unexpected error line: %q", got)
+	}
+	if framer.end() != nil {
+		t.Error("expected no end message in text mode")
+	}
+}
+
+func TestLogFramer_JSON(t *testing.T) {
+	framer := &logFramer{format: LogFormatJSON, prefix: "[cluster-1/job-1] ", jobID: "job-1", podName: "krkn-job-1"}
+
+	var line LogLineFrame
+	if err := json.Unmarshal(framer.line("2025-01-02T03:04:05.123456789Z scenario started"), &line); err != nil {
+		t.Fatalf("failed to decode line frame: %v", err)
+	}
+	expectedTime := time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	if line.Type != LogFrameLine || line.JobID != "job-1" || line.PodName != "krkn-job-1" ||
+		line.Stream != LogStreamAll || line.Line != "scenario started" ||
+		line.Timestamp == nil || !line.Timestamp.Equal(expectedTime) {
+		t.Errorf("unexpected line frame: %+v", line)
+	}
+
+	// Lines without a timestamp are kept whole
+	line = LogLineFrame{}
+	_ = json.Unmarshal(framer.line("no timestamp here"), &line)
+	if line.Timestamp != nil || line.Line != "no timestamp here" {
+		t.Errorf("unexpected line frame: %+v", line)
+	}
+
+	var control LogControlFrame
+	if err := json.Unmarshal(framer.errorf("Log stream error: %s", "EOF"), &control); err != nil {
+		t.Fatalf("failed to decode control frame: %v", err)
+	}
+	if control.Type != LogFrameError || control.JobID != "job-1" || control.Message != "Log stream error: EOF" {
+		t.Errorf("unexpected error frame: %+v", control)
+	}
+
+	control = LogControlFrame{}
+	_ = json.Unmarshal(framer.end(), &control)
+	if control.Type != LogFrameEnd || control.JobID != "job-1" {
+		t.Errorf("unexpected end frame: %+v", control)
+	}
+
+	control = LogControlFrame{}
+	_ = json.Unmarshal(framer.attempt(2, "job-2", "krkn-job-2"), &control)
+	if control.Type != LogFrameAttempt || control.Attempt != 2 || control.JobID != "job-2" ||
+		control.PodName != "krkn-job-2" || control.PreviousJobID != "job-1" {
+		t.Errorf("unexpected attempt frame: %+v", control)
+	}
+	line = LogLineFrame{}
+	_ = json.Unmarshal(framer.line("retried"), &line)
+	if line.JobID != "job-2" || line.PodName != "krkn-job-2" {
+		t.Errorf("expected the lines of the next attempt to carry its job, got %+v", line)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"unicode/utf8"
+)
+
+// LogMaxLineLengthEnv sets the longest log line, in bytes, streamed over the log WebSockets.
+// The rest of a longer line is dropped and counted in krkn_api_log_lines_truncated_total.
+// 0 disables the limit.
+const LogMaxLineLengthEnv = "API_LOG_MAX_LINE_LENGTH"
+
+// DefaultLogMaxLineLength is the maximum log line length when LogMaxLineLengthEnv is not set
+const DefaultLogMaxLineLength = 1024 * 1024
+
+// logChunkSize is the largest part of a log line sent in one WebSocket message
+const logChunkSize = 32 * 1024
+
+// LogMaxLineLengthFromEnv reads the maximum log line length from the environment
+func LogMaxLineLengthFromEnv() int {
+	if length, err := strconv.Atoi(os.Getenv(LogMaxLineLengthEnv)); err == nil && length >= 0 {
+		return length
+	}
+	return DefaultLogMaxLineLength
+}
+
+// logChunk is a part of a log line. Lines up to logChunkSize are a single chunk.
+type logChunk struct {
+	text string
+	// index is the position of the chunk in its line, 0 for the first
+	index int
+	// last is set on the final chunk of the line
+	last bool
+	// truncated is set on the final chunk of a line cut at the maximum line length
+	truncated bool
+}
+
+// logLineReader splits a log stream in lines of any length. Unlike bufio.Scanner it has
+// no token limit: long lines are returned in chunks, cut at UTF-8 rune boundaries.
+type logLineReader struct {
+	reader  *bufio.Reader
+	maxLine int
+
+	// state of the current line
+	carry     []byte // incomplete rune at the end of the last chunk
+	index     int
+	length    int // bytes returned so far
+	truncated bool
+}
+
+func newLogLineReader(stream io.Reader, maxLine int) *logLineReader {
+	return &logLineReader{reader: bufio.NewReaderSize(stream, logChunkSize), maxLine: maxLine}
+}
+
+// next returns the next chunk of the stream, io.EOF once it ended
+func (l *logLineReader) next() (logChunk, error) {
+	for {
+		data, err := l.reader.ReadSlice('\n')
+		if err != nil && err != bufio.ErrBufferFull && (err != io.EOF || len(data) == 0) {
+			if err == io.EOF && len(l.carry) > 0 {
+				// Stream ended on an incomplete rune, send what is left
+				return l.endLine(nil), nil
+			}
+			return logChunk{}, err
+		}
+
+		if err != bufio.ErrBufferFull {
+			// A newline or the end of the stream completes the line, as for bufio.ScanLines
+			data = bytes.TrimSuffix(bytes.TrimSuffix(data, []byte("\n")), []byte("\r"))
+			return l.endLine(data), nil
+		}
+
+		text := l.limit(append(l.carry, data...))
+		cut := completeRunes(text)
+		l.carry = append([]byte(nil), text[cut:]...)
+		if cut == 0 {
+			// Only dropped bytes or the start of a rune so far
+			continue
+		}
+		chunk := logChunk{text: string(text[:cut]), index: l.index}
+		l.index++
+		l.length += cut
+		return chunk, nil
+	}
+}
+
+// endLine returns the final chunk of the current line and resets the line state
+func (l *logLineReader) endLine(data []byte) logChunk {
+	text := l.limit(append(l.carry, data...))
+	chunk := logChunk{text: string(text), index: l.index, last: true, truncated: l.truncated}
+	if chunk.truncated {
+		logLinesTruncated.Inc()
+	}
+	l.carry, l.index, l.length, l.truncated = nil, 0, 0, false
+	return chunk
+}
+
+// limit drops from text, the part of the current line not returned yet, what exceeds the
+// maximum line length
+func (l *logLineReader) limit(text []byte) []byte {
+	if room := max(l.maxLine-l.length, 0); l.maxLine > 0 && len(text) > room {
+		text = text[:completeRunes(text[:room])]
+		l.truncated = true
+	}
+	return text
+}
+
+// completeRunes returns the length of text without an incomplete rune at its end
+func completeRunes(text []byte) int {
+	for i := len(text) - 1; i >= 0 && i >= len(text)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(text[i]) {
+			if !utf8.FullRune(text[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(text)
+}
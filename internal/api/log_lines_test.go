@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// readLogLines reads every chunk of stream and reassembles the lines
+func readLogLines(t *testing.T, stream string, maxLine int) (lines []string, chunks []logChunk) {
+	t.Helper()
+	reader := newLogLineReader(strings.NewReader(stream), maxLine)
+	var current strings.Builder
+	for {
+		chunk, err := reader.next()
+		if err == io.EOF {
+			return lines, chunks
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !utf8.ValidString(chunk.text) {
+			t.Fatalf("chunk %d is not valid UTF-8", chunk.index)
+		}
+		chunks = append(chunks, chunk)
+		current.WriteString(chunk.text)
+		if chunk.last {
+			lines = append(lines, current.String())
+			current.Reset()
+		}
+	}
+}
+
+func TestLogLineReader_ShortLines(t *testing.T) {
+	lines, chunks := readLogLines(t, "first\r\nsecond\n\nlast without newline", DefaultLogMaxLineLength)
+
+	expected := []string{"first", "second", "", "last without newline"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected lines %q, got %q", expected, lines)
+	}
+	if len(chunks) != len(expected) {
+		t.Errorf("expected one chunk per line, got %d", len(chunks))
+	}
+}
+
+func TestLogLineReader_LongLine(t *testing.T) {
+	// Longer than the 64KB bufio.Scanner limit, with multi-byte runes across chunk borders
+	long := `{"msg":"` + strings.Repeat("é", 100*1024) + `"}`
+	lines, chunks := readLogLines(t, long+"\nnext\n", DefaultLogMaxLineLength)
+
+	if len(lines) != 2 || lines[0] != long || lines[1] != "next" {
+		t.Fatalf("expected the long line to be reassembled, got %d lines", len(lines))
+	}
+	if len(chunks) < 3 {
+		t.Fatalf("expected the long line to be chunked, got %d chunks", len(chunks))
+	}
+	for i, chunk := range chunks[:len(chunks)-1] {
+		if len(chunk.text) > logChunkSize || chunk.index != i {
+			t.Errorf("unexpected chunk %d: index %d, %d bytes", i, chunk.index, len(chunk.text))
+		}
+	}
+}
+
+func TestLogLineReader_Truncates(t *testing.T) {
+	before := testutil.ToFloat64(logLinesTruncated)
+	lines, chunks := readLogLines(t, strings.Repeat("a", 100*1024)+"\nshort\n", 40*1024)
+
+	if len(lines) != 2 || len(lines[0]) != 40*1024 || lines[1] != "short" {
+		t.Fatalf("expected the first line to be cut at 40KB, got %d lines", len(lines))
+	}
+	// The rest of the line is dropped, its final chunk only marks the truncation
+	end, short := chunks[len(chunks)-2], chunks[len(chunks)-1]
+	if !end.last || !end.truncated || short.truncated {
+		t.Errorf("expected only the first line to be marked truncated, got %v and %v", end.truncated, short.truncated)
+	}
+	if got := testutil.ToFloat64(logLinesTruncated) - before; got != 1 {
+		t.Errorf("expected one truncation to be counted, got %v", got)
+	}
+}
+
+func TestLogFramer_Chunks(t *testing.T) {
+	framer := &logFramer{format: LogFormatJSON, jobID: "job-1"}
+
+	var frame LogLineFrame
+	_ = json.Unmarshal(framer.chunk(logChunk{text: "2025-01-02T03:04:05Z {\"msg\":", index: 0}), &frame)
+	if frame.Timestamp == nil || frame.Line != `{"msg":` || !frame.Partial || frame.Chunk != 0 {
+		t.Errorf("unexpected first chunk frame: %+v", frame)
+	}
+
+	frame = LogLineFrame{}
+	_ = json.Unmarshal(framer.chunk(logChunk{text: "2025 \"x\"}", index: 1, last: true, truncated: true}), &frame)
+	if frame.Timestamp != nil || frame.Line != `2025 "x"}` || frame.Partial || frame.Chunk != 1 || !frame.Truncated {
+		t.Errorf("unexpected last chunk frame: %+v", frame)
+	}
+
+	text := &logFramer{format: LogFormatText, prefix: "[cluster-1/job-1] "}
+	if got := string(text.chunk(logChunk{text: "cut", last: true, truncated: true})); got != "[cluster-1/job-1] cut"+truncatedLineMarker {
+		t.Errorf("unexpected text message: %q", got)
+	}
+}
@@ -0,0 +1,974 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// Message codes returned in ErrorResponse.Code, LintWarning.Code and the Code of
+// the success responses.
+// Codes are part of the API contract: clients should switch on them instead of
+// parsing Message, which is localized according to Accept-Language.
+// Never rename or reuse a code; add a new one instead.
+const (
+	// Generic
+	MsgInvalidRequestBody   = "invalid_request_body"
+	MsgMalformedRequestBody = "malformed_request_body"
+	MsgUnknownFields        = "unknown_fields"
+	MsgValidationFailed     = "validation_failed"
+	MsgFieldRequired        = "field_required"
+	MsgInvalidUUID          = "invalid_uuid"
+	MsgInvalidID            = "invalid_id"
+	MsgAdminRequired        = "admin_required"
+	MsgMethodNotAllowed     = "method_not_allowed"
+	MsgOnlyMethodAllowed    = "only_method_allowed"
+	MsgEndpointNotFound     = "endpoint_not_found"
+	MsgInvalidLimit         = "invalid_limit"
+	MsgInvalidTimestamp     = "invalid_timestamp"
+	MsgInvalidContinueToken = "invalid_continue_token"
+	MsgInvalidBoolParam     = "invalid_bool_param"
+	MsgInvalidSortField     = "invalid_sort_field"
+	MsgInvalidSortOrder     = "invalid_sort_order"
+	MsgInvalidLabelSelector = "invalid_label_selector"
+
+	// Targets
+	MsgTargetSecretTypeRequired = "target_secret_type_required"
+	MsgTargetClusterNameExists  = "target_cluster_name_exists"
+	MsgTargetClusterURLExists   = "target_cluster_url_exists"
+	MsgTargetArchivedDuplicate  = "target_archived_duplicate"
+	MsgTargetArchived           = "target_archived"
+	MsgTargetAlreadyArchived    = "target_already_archived"
+	MsgTargetNotArchived        = "target_not_archived"
+	MsgTargetRetentionExpired   = "target_retention_expired"
+	MsgTargetFetchFailed        = "target_fetch_failed"
+	MsgTargetListFailed         = "target_list_failed"
+	MsgTargetCheckFailed        = "target_check_failed"
+	MsgTargetUnreachable        = "target_unreachable"
+	MsgTargetCreateFailed       = "target_create_failed"
+	MsgTargetUpdateFailed       = "target_update_failed"
+	MsgTargetStatusFailed       = "target_status_update_failed"
+	MsgTargetArchiveFailed      = "target_archive_failed"
+	MsgTargetRestoreFailed      = "target_restore_failed"
+	MsgTargetImportNoContext    = "target_import_no_context"
+	MsgTargetImportContext      = "target_import_context"
+	MsgSecretMarshalFailed      = "secret_marshal_failed"
+	MsgSecretGetFailed          = "secret_get_failed"
+	MsgSecretCreateFailed       = "secret_create_failed"
+	MsgSecretUpdateFailed       = "secret_update_failed"
+	MsgKubeconfigSealFailed     = "kubeconfig_seal_failed"
+	MsgCredentialsGetFailed     = "credentials_get_failed"
+	MsgCredentialsStoreFailed   = "credentials_store_failed"
+	MsgTokenRotationUnavailable = "token_rotation_unavailable"
+	MsgTokenRotationNotSet      = "token_rotation_not_set"
+	MsgDataProviderUnavailable  = "data_provider_unavailable"
+	MsgTokenRotationFailed      = "token_rotation_failed"
+
+	// Scenario runs
+	MsgScenarioRunNotFound         = "scenario_run_not_found"
+	MsgScenarioRunFetchFailed      = "scenario_run_fetch_failed"
+	MsgInvalidChannel              = "invalid_notification_channel"
+	MsgObserversUpdateFailed       = "observers_update_failed"
+	MsgNotSubscribed               = "not_subscribed"
+	MsgScenarioRunWatchUnavailable = "scenario_run_watch_unavailable"
+	MsgInvalidSinceSeconds         = "invalid_since_seconds"
+	MsgLogSinceConflict            = "log_since_conflict"
+	MsgInvalidLogFormat            = "invalid_log_format"
+	MsgLogFollowRunUnavailable     = "log_follow_run_unavailable"
+	MsgInvalidReportFormat         = "invalid_report_format"
+	MsgRetryRunNotCompleted        = "retry_run_not_completed"
+	MsgRetryNoFailedClusters       = "retry_no_failed_clusters"
+	MsgRetryWavePending            = "retry_wave_pending"
+	MsgRetryScheduleInvalid        = "retry_schedule_invalid"
+	MsgRetryForbidden              = "retry_forbidden"
+	MsgRetryScheduleFailed         = "retry_schedule_failed"
+
+	// Hub capacity guard
+	MsgChaosPodBudgetExceeded    = "chaos_pod_budget_exceeded"
+	MsgNamespacePodQuotaExceeded = "namespace_pod_quota_exceeded"
+	MsgHubNodeCapacityExceeded   = "hub_node_capacity_exceeded"
+	MsgHubCapacityCheckFailed    = "hub_capacity_check_failed"
+
+	// Target auth plugins
+	MsgAuthPluginMissing = "auth_plugin_missing"
+
+	// Scenario environment
+	MsgEnvironmentInvalid = "environment_invalid"
+
+	// Scenario favorites
+	MsgFavoriteNameInvalid   = "favorite_name_invalid"
+	MsgFavoritesLimit        = "favorites_limit"
+	MsgFavoritesUpdateFailed = "favorites_update_failed"
+
+	// Scenario templates
+	MsgTemplateNotFound    = "template_not_found"
+	MsgTemplateFetchFailed = "template_fetch_failed"
+
+	// Target metrics proxy
+	MsgMetricsQueryInvalid     = "metrics_query_invalid"
+	MsgMetricsQueryNotAllowed  = "metrics_query_not_allowed"
+	MsgMetricsRateLimited      = "metrics_rate_limited"
+	MsgMetricsClusterNotInRun  = "metrics_cluster_not_in_run"
+	MsgMetricsNotConfigured    = "metrics_not_configured"
+	MsgMetricsClusterAmbiguous = "metrics_cluster_ambiguous"
+	MsgMetricsUpstreamFailed   = "metrics_upstream_failed"
+
+	// Effective spec
+	MsgEffectiveSpecNotCaptured = "effective_spec_not_captured"
+
+	// Cancellation
+	MsgJobAlreadyFinished    = "job_already_finished"
+	MsgRunCancelFailed       = "run_cancel_failed"
+	MsgCancelClusterNotInRun = "cancel_cluster_not_in_run"
+	MsgRetryRunCancelled     = "retry_run_cancelled"
+
+	// Run spec lint warnings
+	MsgLintMissingTimeout  = "lint_missing_timeout"
+	MsgLintLatestTag       = "lint_latest_tag"
+	MsgLintNoSLOChecks     = "lint_no_slo_checks"
+	MsgLintTooManyClusters = "lint_too_many_clusters"
+	MsgLintPlainEnvSecret  = "lint_plain_env_secret"
+
+	// Providers
+	MsgProviderNameRequired  = "provider_name_required"
+	MsgProviderNotFound      = "provider_not_found"
+	MsgProviderListFailed    = "provider_list_failed"
+	MsgProviderQueryFailed   = "provider_query_failed"
+	MsgProviderUpdateFailed  = "provider_update_failed"
+	MsgProviderNotActive     = "provider_not_active"
+	MsgProviderReasonTooLong = "provider_reason_too_long"
+	MsgProviderVerifyNone    = "provider_verify_none"
+	MsgProviderVerifyTimeout = "provider_verify_timeout"
+	MsgProviderVerifyFailed  = "provider_verify_failed"
+
+	// Scenario registry
+	MsgRegistryUnavailable = "registry_unavailable"
+
+	// Campaigns
+	MsgCampaignNameInvalid   = "campaign_name_invalid"
+	MsgCampaignWindowInvalid = "campaign_window_invalid"
+	MsgCampaignNotFound      = "campaign_not_found"
+	MsgCampaignExists        = "campaign_exists"
+	MsgCampaignForbidden     = "campaign_forbidden"
+	MsgCampaignFetchFailed   = "campaign_fetch_failed"
+	MsgCampaignListFailed    = "campaign_list_failed"
+	MsgCampaignCreateFailed  = "campaign_create_failed"
+	MsgCampaignUpdateFailed  = "campaign_update_failed"
+	MsgCampaignDeleteFailed  = "campaign_delete_failed"
+
+	// Run history
+	MsgHistoryDisabled    = "history_disabled"
+	MsgHistoryNotFound    = "history_not_found"
+	MsgHistoryQueryFailed = "history_query_failed"
+
+	// Usage telemetry
+	MsgUsageDisabled     = "usage_disabled"
+	MsgUsageReportFailed = "usage_report_failed"
+
+	// Embedded UI
+	MsgUISessionInvalid = "ui_session_invalid"
+
+	// Authentication
+	MsgAuthClaimsMissing       = "auth_claims_missing"
+	MsgRegistrationCheckFailed = "registration_check_failed"
+	MsgRegistrationClosed      = "registration_closed"
+	MsgFirstUserAdmin          = "first_user_admin"
+	MsgAdminCheckFailed        = "admin_check_failed"
+	MsgLoginFieldsRequired     = "login_fields_required"
+	MsgAuthenticationFailed    = "authentication_failed"
+	MsgInvalidCredentials      = "invalid_credentials"
+	MsgAccountDisabled         = "account_disabled"
+	MsgTokenGenerationFailed   = "token_generation_failed"
+
+	// Users
+	MsgUserFieldsRequired     = "user_fields_required"
+	MsgUserRoleInvalid        = "user_role_invalid"
+	MsgUserCheckFailed        = "user_check_failed"
+	MsgUserExists             = "user_exists"
+	MsgUserNotFound           = "user_not_found"
+	MsgUserFetchFailed        = "user_fetch_failed"
+	MsgUserListFailed         = "user_list_failed"
+	MsgUserCreateFailed       = "user_create_failed"
+	MsgUserUpdateFailed       = "user_update_failed"
+	MsgUserDeleteFailed       = "user_delete_failed"
+	MsgNoFieldsToUpdate       = "no_fields_to_update"
+	MsgAdminCountFailed       = "admin_count_failed"
+	MsgProfileForbidden       = "profile_forbidden"
+	MsgProfileUpdateForbidden = "profile_update_forbidden"
+	MsgRoleChangeForbidden    = "role_change_forbidden"
+	MsgSelfDisableForbidden   = "self_disable_forbidden"
+	MsgSelfDeleteForbidden    = "self_delete_forbidden"
+	MsgLastAdminForbidden     = "last_admin_forbidden"
+
+	// Passwords and credentials
+	MsgPasswordInvalid           = "password_invalid"
+	MsgPasswordHashFailed        = "password_hash_failed"
+	MsgPasswordHashMissing       = "password_hash_missing"
+	MsgPasswordSecretFailed      = "password_secret_failed"
+	MsgPasswordSecretStoreFailed = "password_secret_store_failed"
+	MsgCredentialsCleanupFailed  = "credentials_cleanup_failed"
+	MsgCredentialsCreateFailed   = "credentials_create_failed"
+	MsgCurrentPasswordRequired   = "current_password_required"
+	MsgCurrentPasswordIncorrect  = "current_password_incorrect"
+	MsgPasswordUnchanged         = "password_unchanged"
+	MsgPasswordUpdateFailed      = "password_update_failed"
+	MsgPasswordChangeForbidden   = "password_change_forbidden"
+
+	// Access control
+	MsgAccessCheckFailed     = "access_check_failed"
+	MsgRunNoJobs             = "run_no_jobs"
+	MsgRunActionForbidden    = "run_action_forbidden"
+	MsgRunJobsForbidden      = "run_jobs_forbidden"
+	MsgRunLogsForbidden      = "run_logs_forbidden"
+	MsgRunDeleteForbidden    = "run_delete_forbidden"
+	MsgRunClustersForbidden  = "run_clusters_forbidden"
+	MsgJobNoClusterURL       = "job_no_cluster_url"
+	MsgJobActionForbidden    = "job_action_forbidden"
+	MsgNodesForbidden        = "nodes_forbidden"
+	MsgUserGroupsFetchFailed = "user_groups_fetch_failed"
+
+	// Target requests and nodes
+	MsgTargetRequestNotFound     = "target_request_not_found"
+	MsgTargetRequestNotCompleted = "target_request_not_completed"
+	MsgTargetRequestNotReady     = "target_request_not_ready"
+	MsgTargetRequestSandbox      = "target_request_sandbox"
+	MsgTargetRequestFetchFailed  = "target_request_fetch_failed"
+	MsgTargetRequestCreateFailed = "target_request_create_failed"
+	MsgTargetKubeconfigNotFound  = "target_kubeconfig_not_found"
+	MsgTargetKubeconfigFailed    = "target_kubeconfig_failed"
+	MsgNodesParamsRequired       = "nodes_params_required"
+	MsgNodesFetchFailed          = "nodes_fetch_failed"
+
+	// Scenarios
+	MsgScenarioNotFound        = "scenario_not_found"
+	MsgScenarioGlobalsNotFound = "scenario_globals_not_found"
+	MsgRegistryRequestFailed   = "registry_request_failed"
+
+	// Scenario run requests
+	MsgTargetsRequired            = "targets_required"
+	MsgTargetGroupRefInvalid      = "target_group_ref_invalid"
+	MsgTargetGroupNotFound        = "target_group_not_found"
+	MsgTargetGroupSelectorInvalid = "target_group_selector_invalid"
+	MsgTargetGroupNoMembers       = "target_group_no_members"
+	MsgTargetGroupFetchFailed     = "target_group_fetch_failed"
+	MsgTargetSelectorNoMatch      = "target_selector_no_match"
+	MsgTargetClusterIDUnknown     = "target_cluster_id_unknown"
+	MsgDisplayNameTooLong         = "display_name_too_long"
+	MsgTimeoutInvalid             = "timeout_invalid"
+	MsgTTLNegative                = "ttl_negative"
+	MsgMaintenancePolicyInvalid   = "maintenance_policy_invalid"
+	MsgMaxConcurrencyNegative     = "max_concurrency_negative"
+	MsgImagePullPolicyInvalid     = "image_pull_policy_invalid"
+	MsgProviderNameEmpty          = "provider_name_empty"
+	MsgProviderNoClusters         = "provider_no_clusters"
+	MsgClusterNameEmpty           = "cluster_name_empty"
+	MsgClusterInMultipleProviders = "cluster_in_multiple_providers"
+	MsgAlternateNotTarget         = "alternate_not_target"
+	MsgAlternateIsTarget          = "alternate_is_target"
+	MsgTargetsArchived            = "targets_archived"
+
+	// Scenario runs and jobs
+	MsgScenarioRunCreateFailed = "scenario_run_create_failed"
+	MsgScenarioRunListFailed   = "scenario_run_list_failed"
+	MsgScenarioRunDeleteFailed = "scenario_run_delete_failed"
+	MsgScenarioRunStatusFailed = "scenario_run_status_failed"
+	MsgJobNotFound             = "job_not_found"
+	MsgJobListFailed           = "job_list_failed"
+	MsgJobDeleteFailed         = "job_delete_failed"
+	MsgJobWatchFailed          = "job_watch_failed"
+
+	// User groups
+	MsgUserGroupNotFound        = "user_group_not_found"
+	MsgUserGroupExists          = "user_group_exists"
+	MsgUserGroupListFailed      = "user_group_list_failed"
+	MsgUserGroupFetchFailed     = "user_group_fetch_failed"
+	MsgUserGroupCreateFailed    = "user_group_create_failed"
+	MsgUserGroupUpdateFailed    = "user_group_update_failed"
+	MsgUserGroupDeleteFailed    = "user_group_delete_failed"
+	MsgGroupNameInvalidChars    = "group_name_invalid_chars"
+	MsgGroupNameTooLong         = "group_name_too_long"
+	MsgGroupPermissionsRequired = "group_permissions_required"
+	MsgGroupMembersListFailed   = "group_members_list_failed"
+	MsgGroupMemberExists        = "group_member_exists"
+	MsgGroupMemberNotFound      = "group_member_not_found"
+	MsgGroupMemberAddFailed     = "group_member_add_failed"
+	MsgGroupMemberRemoveFailed  = "group_member_remove_failed"
+
+	// Provider configuration
+	MsgProviderConfigNotFound     = "provider_config_not_found"
+	MsgProviderConfigCreateFailed = "provider_config_create_failed"
+	MsgProviderConfigFetchFailed  = "provider_config_fetch_failed"
+	MsgProviderConfigValuesEmpty  = "provider_config_values_empty"
+	MsgProviderConfigFieldUnknown = "provider_config_field_unknown"
+	MsgProviderTargetNotFound     = "provider_target_not_found"
+	MsgConfigMapWriteFailed       = "configmap_write_failed"
+	MsgConfigMapGetFailed         = "configmap_get_failed"
+	MsgConfigMapCreateFailed      = "configmap_create_failed"
+	MsgConfigMapUpdateFailed      = "configmap_update_failed"
+
+	// API documentation
+	MsgOpenAPIFailed = "openapi_failed"
+
+	// Break-glass exemptions
+	MsgScenarioRestricted        = "scenario_restricted"
+	MsgBreakGlassMismatch        = "break_glass_mismatch"
+	MsgBreakGlassStaticTargets   = "break_glass_static_targets"
+	MsgBreakGlassNotRestricted   = "break_glass_not_restricted"
+	MsgBreakGlassDurationInvalid = "break_glass_duration_invalid"
+	MsgBreakGlassIssueFailed     = "break_glass_issue_failed"
+
+	// Success messages
+	MsgTargetCreateSucceeded         = "target_create_succeeded"
+	MsgTargetUpdateSucceeded         = "target_update_succeeded"
+	MsgTargetArchiveSucceeded        = "target_archive_succeeded"
+	MsgTargetRestoreSucceeded        = "target_restore_succeeded"
+	MsgUserRegisterSucceeded         = "user_register_succeeded"
+	MsgUserCreateSucceeded           = "user_create_succeeded"
+	MsgUserUpdateSucceeded           = "user_update_succeeded"
+	MsgUserDeleteSucceeded           = "user_delete_succeeded"
+	MsgPasswordUpdateSucceeded       = "password_update_succeeded"
+	MsgUserGroupCreateSucceeded      = "user_group_create_succeeded"
+	MsgUserGroupUpdateSucceeded      = "user_group_update_succeeded"
+	MsgUserGroupDeleteSucceeded      = "user_group_delete_succeeded"
+	MsgGroupMemberAddSucceeded       = "group_member_add_succeeded"
+	MsgGroupMemberRemoveSucceeded    = "group_member_remove_succeeded"
+	MsgProviderConfigUpdateSucceeded = "provider_config_update_succeeded"
+	MsgProviderStatusUpdateSucceeded = "provider_status_update_succeeded"
+	MsgJobStopSucceeded              = "job_stop_succeeded"
+)
+
+// messageCatalog holds the localized templates for every message code.
+// English is the reference language and must contain every code.
+var messageCatalog = i18n.NewCatalog(map[string]map[string]string{
+	"en": {
+		MsgInvalidRequestBody:   "Invalid request body: {error}",
+		MsgMalformedRequestBody: "Invalid request body",
+		MsgUnknownFields:        "Unknown fields in request body: {fields}",
+		MsgValidationFailed:     "{error}",
+		MsgFieldRequired:        "{field} is required",
+		MsgInvalidUUID:          "UUID {error}",
+		MsgInvalidID:            "{param} {error}",
+		MsgAdminRequired:        "This operation requires admin privileges",
+		MsgMethodNotAllowed:     "Method {method} not allowed for path {path}",
+		MsgOnlyMethodAllowed:    "Only {method} is allowed",
+		MsgEndpointNotFound:     "Endpoint not found",
+		MsgInvalidLimit:         "limit must be a positive integer",
+		MsgInvalidTimestamp:     "{param} must be an RFC3339 timestamp",
+		MsgInvalidContinueToken: "continue token is malformed or expired",
+		MsgInvalidBoolParam:     "{param} must be true or false",
+		MsgInvalidSortField:     "Cannot sort by '{sort}', allowed values: {allowed}",
+		MsgInvalidSortOrder:     "order must be asc or desc",
+		MsgInvalidLabelSelector: "{param} is not a valid label selector: {error}",
+
+		MsgTargetSecretTypeRequired: "secretType is required (kubeconfig, token, or credentials)",
+		MsgTargetClusterNameExists:  "Target with clusterName '{clusterName}' already exists",
+		MsgTargetClusterURLExists:   "Target with clusterAPIURL '{clusterAPIURL}' already exists",
+		MsgTargetArchivedDuplicate:  "Archived target '{name}' uses the same cluster, restore it instead",
+		MsgTargetArchived:           "Target is archived, restore it before updating",
+		MsgTargetAlreadyArchived:    "Target is already archived",
+		MsgTargetNotArchived:        "Target is not archived",
+		MsgTargetRetentionExpired:   "Target retention window has expired and it is pending purge",
+		MsgTargetFetchFailed:        "{error}",
+		MsgTargetListFailed:         "Failed to list targets: {error}",
+		MsgTargetCheckFailed:        "Failed to check existing targets: {error}",
+		MsgTargetUnreachable:        "Cannot connect to the API server '{clusterAPIURL}' with the provided credentials: {error}",
+		MsgTargetCreateFailed:       "Failed to create target: {error}",
+		MsgTargetUpdateFailed:       "Failed to update target: {error}",
+		MsgTargetStatusFailed:       "Failed to update target status: {error}",
+		MsgTargetArchiveFailed:      "Failed to archive target: {error}",
+		MsgTargetRestoreFailed:      "Failed to restore target: {error}",
+		MsgTargetImportNoContext:    "Context '{context}' not found in kubeconfig",
+		MsgTargetImportContext:      "Context '{context}': {error}",
+		MsgSecretMarshalFailed:      "Failed to marshal secret data: {error}",
+		MsgSecretGetFailed:          "Failed to get secret: {error}",
+		MsgSecretCreateFailed:       "Failed to create secret: {error}",
+		MsgSecretUpdateFailed:       "Failed to update secret: {error}",
+		MsgKubeconfigSealFailed:     "Failed to encrypt the kubeconfig: {error}",
+		MsgCredentialsGetFailed:     "Failed to read the target credentials: {error}",
+		MsgCredentialsStoreFailed:   "Failed to store the target credentials: {error}",
+		MsgTokenRotationUnavailable: "Token rotation is not available",
+		MsgTokenRotationNotSet:      "Target is not a token target with token rotation configured",
+		MsgDataProviderUnavailable:  "The data provider is unavailable, retry later",
+		MsgTokenRotationFailed:      "Failed to rotate the target token: {error}",
+
+		MsgScenarioRunNotFound:         "Scenario run '{name}' not found",
+		MsgScenarioRunFetchFailed:      "Failed to fetch scenario run: {error}",
+		MsgInvalidChannel:              "Invalid notification channel: {error}",
+		MsgObserversUpdateFailed:       "Failed to update run observers: {error}",
+		MsgNotSubscribed:               "User '{userId}' is not subscribed to this scenario run",
+		MsgScenarioRunWatchUnavailable: "Scenario run status streaming is not available",
+		MsgInvalidSinceSeconds:         "sinceSeconds must be a positive integer",
+		MsgLogSinceConflict:            "sinceTime and sinceSeconds cannot be combined",
+		MsgInvalidLogFormat:            "Unsupported log format '{format}', expected text or json",
+		MsgInvalidReportFormat:         "Unsupported report format '{format}', expected json or html",
+		MsgLogFollowRunUnavailable:     "Following the retries of jobs in log streams is not available",
+		MsgRetryRunNotCompleted:        "Scenario run '{name}' is {phase}, only Failed or PartiallyFailed runs can be retried",
+		MsgRetryNoFailedClusters:       "Scenario run '{name}' has no failed clusters to retry",
+		MsgRetryWavePending:            "Retry wave {number} of scenario run '{name}' has not started yet",
+		MsgRetryScheduleInvalid:        "delay must be a non-negative duration and cannot be combined with scheduledAt",
+		MsgRetryForbidden:              "Access denied. You do not have permission to run scenarios on every failed cluster",
+		MsgRetryScheduleFailed:         "Failed to schedule the retry: {error}",
+
+		MsgChaosPodBudgetExceeded:    "The run needs {requested} scenario pods but {available} of the chaos pod budget of {budget} are left, retry once active runs finish",
+		MsgNamespacePodQuotaExceeded: "The run needs {requested} scenario pods but resource quota '{quota}' of namespace {namespace} leaves room for {available}",
+		MsgHubNodeCapacityExceeded:   "The run needs {requested} scenario pods but the schedulable nodes of the hub have room for {available}",
+		MsgHubCapacityCheckFailed:    "Failed to check the hub capacity: {error}",
+
+		MsgAuthPluginMissing: "Cluster '{cluster}' authenticates with {plugins}, which the scenario image '{image}' is not known to bundle",
+
+		MsgEnvironmentInvalid: "The environment does not match the input fields of scenario '{scenario}'",
+
+		MsgFavoriteNameInvalid:   "Invalid scenario name '{name}': {error}",
+		MsgFavoritesLimit:        "At most {limit} scenarios can be starred",
+		MsgFavoritesUpdateFailed: "Failed to update favorite scenarios: {error}",
+
+		MsgTemplateNotFound:    "Scenario template '{name}' not found",
+		MsgTemplateFetchFailed: "Failed to fetch scenario template: {error}",
+
+		MsgMetricsQueryInvalid:     "Invalid PromQL query: {error}",
+		MsgMetricsQueryNotAllowed:  "Metric '{metric}' is not in the metrics proxy allowlist",
+		MsgMetricsRateLimited:      "Too many metrics queries, retry later",
+		MsgMetricsClusterNotInRun:  "Cluster '{cluster}' is not a target of this scenario run",
+		MsgMetricsNotConfigured:    "No Prometheus endpoint is configured for cluster '{cluster}'",
+		MsgMetricsClusterAmbiguous: "Several providers of this scenario run have a cluster named '{cluster}', use its cluster ID",
+		MsgMetricsUpstreamFailed:   "Failed to query the cluster Prometheus: {error}",
+
+		MsgEffectiveSpecNotCaptured: "No effective spec was captured for cluster '{cluster}', its job was created before the operator recorded it",
+
+		MsgJobAlreadyFinished:    "The job of cluster '{cluster}' already ended {phase}",
+		MsgRunCancelFailed:       "Failed to cancel the scenario run: {error}",
+		MsgCancelClusterNotInRun: "Cluster '{cluster}' is not a target of scenario run '{name}', there is no job to cancel",
+		MsgRetryRunCancelled:     "Scenario run '{name}' was cancelled and cannot be retried",
+
+		MsgLintMissingTimeout:  "No timeoutSeconds: a hung scenario keeps its pod and the chaos running until it is cancelled",
+		MsgLintLatestTag:       "Image '{image}' is not pinned: the latest tag can change between runs, use a version tag or a digest",
+		MsgLintNoSLOChecks:     "No SLO checks configured: set {variables} so the run fails when the cluster degrades",
+		MsgLintTooManyClusters: "The run targets {clusters} clusters at once, more than {max}: consider a smaller first run",
+		MsgLintPlainEnvSecret:  "Environment variable '{name}' looks like a credential: it is stored in plain text in the run spec and pod spec",
+
+		MsgProviderNameRequired:  "Provider name is required",
+		MsgProviderNotFound:      "Provider not found",
+		MsgProviderListFailed:    "Failed to list providers",
+		MsgProviderQueryFailed:   "Failed to query providers",
+		MsgProviderUpdateFailed:  "Failed to update provider status",
+		MsgProviderNotActive:     "Provider {provider} is not registered or not active",
+		MsgProviderReasonTooLong: "The reason must be at most {max} characters",
+		MsgProviderVerifyNone:    "No active provider to verify",
+		MsgProviderVerifyTimeout: "timeoutSeconds must be between 1 and {max}",
+		MsgProviderVerifyFailed:  "Failed to create the verification request",
+
+		MsgRegistryUnavailable: "Scenario registry {registry} is unavailable and no cached catalog is available: {error}",
+
+		MsgCampaignNameInvalid:   "Invalid campaign name '{name}': {error}",
+		MsgCampaignWindowInvalid: "The end of the campaign window must be after its start",
+		MsgCampaignNotFound:      "Campaign '{name}' not found",
+		MsgCampaignExists:        "Campaign '{name}' already exists",
+		MsgCampaignForbidden:     "Only the facilitator of campaign '{name}' or an admin can change it",
+		MsgCampaignFetchFailed:   "Failed to fetch campaign: {error}",
+		MsgCampaignListFailed:    "Failed to list campaigns: {error}",
+		MsgCampaignCreateFailed:  "Failed to create campaign: {error}",
+		MsgCampaignUpdateFailed:  "Failed to update campaign: {error}",
+		MsgCampaignDeleteFailed:  "Failed to delete campaign: {error}",
+
+		MsgHistoryDisabled:    "Run history is not enabled on this operator",
+		MsgHistoryNotFound:    "Scenario run '{name}' not found in the run history",
+		MsgHistoryQueryFailed: "Failed to query the run history: {error}",
+
+		MsgUsageDisabled:     "Usage telemetry is not enabled on this operator",
+		MsgUsageReportFailed: "Failed to build the usage report: {error}",
+
+		MsgUISessionInvalid: "The UI session needs a valid bearer token",
+
+		MsgAuthClaimsMissing:       "No authentication claims found",
+		MsgRegistrationCheckFailed: "Failed to check admin registration status",
+		MsgRegistrationClosed:      "Initial admin registration is complete. New users must be created by an admin using POST /api/v1/users",
+		MsgFirstUserAdmin:          "First user must have admin role",
+		MsgAdminCheckFailed:        "Failed to verify admin status",
+		MsgLoginFieldsRequired:     "UserID and password are required",
+		MsgAuthenticationFailed:    "Failed to authenticate",
+		MsgInvalidCredentials:      "Invalid email or password",
+		MsgAccountDisabled:         "User account is disabled",
+		MsgTokenGenerationFailed:   "Failed to generate token",
+
+		MsgUserFieldsRequired:     "UserID, password, name, surname, and role are required",
+		MsgUserRoleInvalid:        "Role must be either 'user' or 'admin'",
+		MsgUserCheckFailed:        "Failed to check existing users",
+		MsgUserExists:             "User with email {userId} already exists",
+		MsgUserNotFound:           "User '{userId}' not found",
+		MsgUserFetchFailed:        "Failed to fetch user: {error}",
+		MsgUserListFailed:         "Failed to list users: {error}",
+		MsgUserCreateFailed:       "Failed to create user",
+		MsgUserUpdateFailed:       "Failed to update user: {error}",
+		MsgUserDeleteFailed:       "Failed to delete user: {error}",
+		MsgNoFieldsToUpdate:       "At least one field must be provided",
+		MsgAdminCountFailed:       "Failed to check admin count",
+		MsgProfileForbidden:       "You can only view your own profile",
+		MsgProfileUpdateForbidden: "You can only update your own profile",
+		MsgRoleChangeForbidden:    "Only admins can change role or active status",
+		MsgSelfDisableForbidden:   "You cannot disable your own account",
+		MsgSelfDeleteForbidden:    "You cannot delete your own account",
+		MsgLastAdminForbidden:     "Cannot delete the last active admin user",
+
+		MsgPasswordInvalid:           "Password validation failed: {error}",
+		MsgPasswordHashFailed:        "Failed to process password",
+		MsgPasswordHashMissing:       "Password hash not found",
+		MsgPasswordSecretFailed:      "Failed to get password secret: {error}",
+		MsgPasswordSecretStoreFailed: "Failed to create password secret: {error}",
+		MsgCredentialsCleanupFailed:  "Failed to clean up existing credentials",
+		MsgCredentialsCreateFailed:   "Failed to create user credentials",
+		MsgCurrentPasswordRequired:   "Current password is required when changing your own password",
+		MsgCurrentPasswordIncorrect:  "Current password is incorrect",
+		MsgPasswordUnchanged:         "New password must be different from current password",
+		MsgPasswordUpdateFailed:      "Failed to update password",
+		MsgPasswordChangeForbidden:   "You can only change your own password",
+
+		MsgAccessCheckFailed:     "Failed to validate access",
+		MsgRunNoJobs:             "Access denied. This scenario run has no jobs",
+		MsgRunActionForbidden:    "Access denied. You do not have permission to {action} this scenario run",
+		MsgRunJobsForbidden:      "Access denied. You do not have permission to view jobs in this scenario run",
+		MsgRunLogsForbidden:      "Access denied. You do not have permission to view logs in this scenario run",
+		MsgRunDeleteForbidden:    "Access denied. You must have cancel permission on all jobs in this run to delete it",
+		MsgRunClustersForbidden:  "Access denied: {error}",
+		MsgJobNoClusterURL:       "Access denied. Job has no cluster API URL",
+		MsgJobActionForbidden:    "Access denied. You do not have permission to {action} this job",
+		MsgNodesForbidden:        "You do not have permission to view nodes on this cluster",
+		MsgUserGroupsFetchFailed: "Failed to fetch user groups",
+
+		MsgTargetRequestNotFound:     "KrknTargetRequest with id '{id}' not found",
+		MsgTargetRequestNotCompleted: "KrknTargetRequest with id '{id}' is not completed",
+		MsgTargetRequestNotReady:     "Target request is not completed yet",
+		MsgTargetRequestSandbox:      "Target request is a provider verification sandbox",
+		MsgTargetRequestFetchFailed:  "Failed to fetch target request",
+		MsgTargetRequestCreateFailed: "Failed to create KrknTargetRequest",
+		MsgTargetKubeconfigNotFound:  "Kubeconfig not found: {error}",
+		MsgTargetKubeconfigFailed:    "Failed to get the kubeconfig: {error}",
+		MsgNodesParamsRequired:       "Either targetUUID (new) or id+cluster-name (legacy) parameters are required",
+		MsgNodesFetchFailed:          "Failed to get nodes from gRPC service",
+
+		MsgScenarioNotFound:        "Scenario '{name}' not found",
+		MsgScenarioGlobalsNotFound: "Global environment for scenario '{name}' not found",
+		MsgRegistryRequestFailed:   "Scenario registry request failed: {error}",
+
+		MsgTargetsRequired:            "targetClusters, targetClusterIds, targetSelector or targetGroupRef is required and must contain at least one provider with clusters",
+		MsgTargetGroupRefInvalid:      "targetGroupRef: invalid name '{name}': {error}",
+		MsgTargetGroupNotFound:        "targetGroupRef '{name}' does not exist",
+		MsgTargetGroupSelectorInvalid: "targetGroupRef '{name}' has an invalid selector: {error}",
+		MsgTargetGroupNoMembers:       "targetGroupRef '{name}' has no member among the targets of the target request",
+		MsgTargetGroupFetchFailed:     "Failed to fetch target group",
+		MsgTargetSelectorNoMatch:      "targetSelector '{selector}' matches no target of the target request",
+		MsgTargetClusterIDUnknown:     "targetClusterIds: cluster ID '{clusterId}' is not a target of the target request",
+		MsgDisplayNameTooLong:         "displayName must be at most {max} characters",
+		MsgTimeoutInvalid:             "timeoutSeconds must be a positive number of seconds",
+		MsgTTLNegative:                "ttlSecondsAfterFinished cannot be negative",
+		MsgMaintenancePolicyInvalid:   "maintenancePolicy must be skip or wait",
+		MsgMaxConcurrencyNegative:     "maxConcurrency cannot be negative",
+		MsgImagePullPolicyInvalid:     "imagePullPolicy must be Always, IfNotPresent or Never",
+		MsgProviderNameEmpty:          "provider names cannot be empty",
+		MsgProviderNoClusters:         "provider '{provider}' must have at least one cluster",
+		MsgClusterNameEmpty:           "cluster names cannot be empty",
+		MsgClusterInMultipleProviders: "cluster '{cluster}' appears in multiple providers: '{provider}' and '{otherProvider}'",
+		MsgAlternateNotTarget:         "alternates: cluster '{cluster}' is not a target cluster",
+		MsgAlternateIsTarget:          "alternates: the alternate of cluster '{cluster}' must be a cluster that is not a target",
+		MsgTargetsArchived:            "Targets {clusters} are archived and cannot be used for scenario runs, restore them first",
+
+		MsgScenarioRunCreateFailed: "Failed to create scenario run",
+		MsgScenarioRunListFailed:   "Failed to list scenario runs: {error}",
+		MsgScenarioRunDeleteFailed: "Failed to delete scenario run",
+		MsgScenarioRunStatusFailed: "Failed to update scenario run status",
+		MsgJobNotFound:             "Job '{jobId}' not found",
+		MsgJobListFailed:           "Failed to list jobs",
+		MsgJobDeleteFailed:         "Failed to delete job",
+		MsgJobWatchFailed:          "Failed to watch job pod: {error}",
+
+		MsgUserGroupNotFound:        "User group '{name}' not found",
+		MsgUserGroupExists:          "User group '{name}' already exists",
+		MsgUserGroupListFailed:      "Failed to list user groups",
+		MsgUserGroupFetchFailed:     "Failed to get user group",
+		MsgUserGroupCreateFailed:    "Failed to create user group",
+		MsgUserGroupUpdateFailed:    "Failed to update user group",
+		MsgUserGroupDeleteFailed:    "Failed to delete user group",
+		MsgGroupNameInvalidChars:    "Group name contains only invalid characters",
+		MsgGroupNameTooLong:         "Group name is too long. After sanitization, it must be 63 characters or less (current: {length}). Use a shorter name.",
+		MsgGroupPermissionsRequired: "At least one cluster permission is required",
+		MsgGroupMembersListFailed:   "Failed to list group members",
+		MsgGroupMemberExists:        "User '{userId}' is already a member of group '{name}'",
+		MsgGroupMemberNotFound:      "User '{userId}' is not a member of group '{name}'",
+		MsgGroupMemberAddFailed:     "Failed to add user to group",
+		MsgGroupMemberRemoveFailed:  "Failed to remove user from group",
+
+		MsgProviderConfigNotFound:     "KrknOperatorTargetProviderConfig not found",
+		MsgProviderConfigCreateFailed: "Failed to create KrknOperatorTargetProviderConfig: {error}",
+		MsgProviderConfigFetchFailed:  "Failed to fetch KrknOperatorTargetProviderConfig: {error}",
+		MsgProviderConfigValuesEmpty:  "values cannot be empty",
+		MsgProviderConfigFieldUnknown: "field {field} not found in schema",
+		MsgProviderTargetNotFound:     "target provider: {provider} not found",
+		MsgConfigMapWriteFailed:       "Failed to write ConfigMap data: {error}",
+		MsgConfigMapGetFailed:         "Failed to get ConfigMap",
+		MsgConfigMapCreateFailed:      "Failed to create ConfigMap",
+		MsgConfigMapUpdateFailed:      "Failed to update ConfigMap",
+
+		MsgOpenAPIFailed: "Failed to generate OpenAPI specification: {error}",
+
+		MsgScenarioRestricted:        "Scenario '{scenario}' is restricted and needs a break-glass exemption",
+		MsgBreakGlassMismatch:        "The break-glass exemption does not cover this run: {error}",
+		MsgBreakGlassStaticTargets:   "Runs of restricted scenarios must list their clusters in targetClusters or targetSelector",
+		MsgBreakGlassNotRestricted:   "Scenario '{scenario}' is not restricted and needs no break-glass exemption",
+		MsgBreakGlassDurationInvalid: "durationSeconds must be between 1 and {max}",
+		MsgBreakGlassIssueFailed:     "Failed to issue the break-glass exemption: {error}",
+
+		MsgTargetCreateSucceeded:         "Target created successfully",
+		MsgTargetUpdateSucceeded:         "Target updated successfully",
+		MsgTargetArchiveSucceeded:        "Target archived successfully, it can be restored until {purgeAfter}",
+		MsgTargetRestoreSucceeded:        "Target restored successfully",
+		MsgUserRegisterSucceeded:         "User registered successfully",
+		MsgUserCreateSucceeded:           "User created successfully",
+		MsgUserUpdateSucceeded:           "User updated successfully",
+		MsgUserDeleteSucceeded:           "User deleted successfully",
+		MsgPasswordUpdateSucceeded:       "Password updated successfully",
+		MsgUserGroupCreateSucceeded:      "User group created successfully",
+		MsgUserGroupUpdateSucceeded:      "User group updated successfully",
+		MsgUserGroupDeleteSucceeded:      "User group deleted successfully",
+		MsgGroupMemberAddSucceeded:       "User added to group successfully",
+		MsgGroupMemberRemoveSucceeded:    "User removed from group successfully",
+		MsgProviderConfigUpdateSucceeded: "Configuration updated successfully",
+		MsgProviderStatusUpdateSucceeded: "Provider status updated successfully",
+		MsgJobStopSucceeded:              "Job stopped and deleted successfully",
+	},
+	"it": {
+		MsgInvalidRequestBody:   "Corpo della richiesta non valido: {error}",
+		MsgMalformedRequestBody: "Corpo della richiesta non valido",
+		MsgUnknownFields:        "Campi sconosciuti nel corpo della richiesta: {fields}",
+		MsgFieldRequired:        "{field} è obbligatorio",
+		MsgInvalidUUID:          "UUID {error}",
+		MsgInvalidID:            "{param} {error}",
+		MsgAdminRequired:        "Questa operazione richiede privilegi di amministratore",
+		MsgMethodNotAllowed:     "Metodo {method} non consentito per il percorso {path}",
+		MsgOnlyMethodAllowed:    "È consentito solo {method}",
+		MsgEndpointNotFound:     "Endpoint non trovato",
+		MsgInvalidLimit:         "limit deve essere un intero positivo",
+		MsgInvalidTimestamp:     "{param} deve essere un timestamp RFC3339",
+		MsgInvalidContinueToken: "il token continue non è valido o è scaduto",
+		MsgInvalidBoolParam:     "{param} deve essere true o false",
+		MsgInvalidSortField:     "Impossibile ordinare per '{sort}', valori consentiti: {allowed}",
+		MsgInvalidSortOrder:     "order deve essere asc o desc",
+		MsgInvalidLabelSelector: "{param} non è un selettore di etichette valido: {error}",
+
+		MsgTargetSecretTypeRequired: "secretType è obbligatorio (kubeconfig, token o credentials)",
+		MsgTargetClusterNameExists:  "Esiste già un target con clusterName '{clusterName}'",
+		MsgTargetClusterURLExists:   "Esiste già un target con clusterAPIURL '{clusterAPIURL}'",
+		MsgTargetArchivedDuplicate:  "Il target archiviato '{name}' usa lo stesso cluster, ripristinalo",
+		MsgTargetArchived:           "Il target è archiviato, ripristinalo prima di modificarlo",
+		MsgTargetAlreadyArchived:    "Il target è già archiviato",
+		MsgTargetNotArchived:        "Il target non è archiviato",
+		MsgTargetRetentionExpired:   "Il periodo di conservazione del target è scaduto ed è in attesa di eliminazione",
+		MsgTargetListFailed:         "Impossibile elencare i target: {error}",
+		MsgTargetCheckFailed:        "Impossibile verificare i target esistenti: {error}",
+		MsgTargetUnreachable:        "Impossibile connettersi all'API server '{clusterAPIURL}' con le credenziali fornite: {error}",
+		MsgTargetCreateFailed:       "Impossibile creare il target: {error}",
+		MsgTargetUpdateFailed:       "Impossibile aggiornare il target: {error}",
+		MsgTargetStatusFailed:       "Impossibile aggiornare lo stato del target: {error}",
+		MsgTargetArchiveFailed:      "Impossibile archiviare il target: {error}",
+		MsgTargetRestoreFailed:      "Impossibile ripristinare il target: {error}",
+		MsgTargetImportNoContext:    "Contesto '{context}' non trovato nel kubeconfig",
+		MsgTargetImportContext:      "Contesto '{context}': {error}",
+		MsgSecretMarshalFailed:      "Impossibile serializzare i dati del secret: {error}",
+		MsgSecretGetFailed:          "Impossibile leggere il secret: {error}",
+		MsgSecretCreateFailed:       "Impossibile creare il secret: {error}",
+		MsgSecretUpdateFailed:       "Impossibile aggiornare il secret: {error}",
+		MsgKubeconfigSealFailed:     "Impossibile cifrare il kubeconfig: {error}",
+		MsgCredentialsGetFailed:     "Impossibile leggere le credenziali del target: {error}",
+		MsgCredentialsStoreFailed:   "Impossibile salvare le credenziali del target: {error}",
+		MsgTokenRotationUnavailable: "La rotazione dei token non è disponibile",
+		MsgTokenRotationNotSet:      "Il target non è un target con token e rotazione configurata",
+		MsgDataProviderUnavailable:  "Il data provider non è disponibile, riprovare più tardi",
+		MsgTokenRotationFailed:      "Impossibile ruotare il token del target: {error}",
+
+		MsgScenarioRunNotFound:         "Scenario run '{name}' non trovato",
+		MsgScenarioRunFetchFailed:      "Impossibile leggere lo scenario run: {error}",
+		MsgInvalidChannel:              "Canale di notifica non valido: {error}",
+		MsgObserversUpdateFailed:       "Impossibile aggiornare gli osservatori del run: {error}",
+		MsgNotSubscribed:               "L'utente '{userId}' non è iscritto a questo scenario run",
+		MsgScenarioRunWatchUnavailable: "Lo streaming dello stato degli scenario run non è disponibile",
+		MsgInvalidSinceSeconds:         "sinceSeconds deve essere un intero positivo",
+		MsgLogSinceConflict:            "sinceTime e sinceSeconds non possono essere combinati",
+		MsgInvalidLogFormat:            "Formato dei log '{format}' non supportato, atteso text o json",
+		MsgInvalidReportFormat:         "Formato del report '{format}' non supportato, atteso json o html",
+		MsgLogFollowRunUnavailable:     "Il follow dei retry dei job nei log non è disponibile",
+		MsgRetryRunNotCompleted:        "Lo scenario run '{name}' è {phase}, solo i run Failed o PartiallyFailed possono essere ripetuti",
+		MsgRetryNoFailedClusters:       "Lo scenario run '{name}' non ha cluster falliti da ripetere",
+		MsgRetryWavePending:            "L'ondata di retry {number} dello scenario run '{name}' non è ancora iniziata",
+		MsgRetryScheduleInvalid:        "delay deve essere una durata non negativa e non può essere combinato con scheduledAt",
+		MsgRetryForbidden:              "Accesso negato. Non hai il permesso di eseguire scenari su tutti i cluster falliti",
+		MsgRetryScheduleFailed:         "Impossibile pianificare il retry: {error}",
+
+		MsgChaosPodBudgetExceeded:    "Il run richiede {requested} pod di scenario ma restano {available} dei {budget} pod di chaos disponibili, riprova al termine dei run attivi",
+		MsgNamespacePodQuotaExceeded: "Il run richiede {requested} pod di scenario ma la resource quota '{quota}' del namespace {namespace} ne consente solo {available}",
+		MsgHubNodeCapacityExceeded:   "Il run richiede {requested} pod di scenario ma i nodi schedulabili dell'hub hanno spazio per {available}",
+		MsgHubCapacityCheckFailed:    "Impossibile verificare la capacità dell'hub: {error}",
+
+		MsgAuthPluginMissing: "Il cluster '{cluster}' si autentica con {plugins}, che l'immagine dello scenario '{image}' non risulta includere",
+
+		MsgEnvironmentInvalid: "L'ambiente non corrisponde ai campi di input dello scenario '{scenario}'",
+
+		MsgFavoriteNameInvalid:   "Nome di scenario '{name}' non valido: {error}",
+		MsgFavoritesLimit:        "Si possono aggiungere ai preferiti al massimo {limit} scenari",
+		MsgFavoritesUpdateFailed: "Impossibile aggiornare gli scenari preferiti: {error}",
+
+		MsgTemplateNotFound:    "Template di scenario '{name}' non trovato",
+		MsgTemplateFetchFailed: "Impossibile leggere il template di scenario: {error}",
+
+		MsgMetricsQueryInvalid:     "Query PromQL non valida: {error}",
+		MsgMetricsQueryNotAllowed:  "La metrica '{metric}' non è nella allowlist del proxy delle metriche",
+		MsgMetricsRateLimited:      "Troppe query di metriche, riprova più tardi",
+		MsgMetricsClusterNotInRun:  "Il cluster '{cluster}' non è un target di questo scenario run",
+		MsgMetricsNotConfigured:    "Nessun endpoint Prometheus configurato per il cluster '{cluster}'",
+		MsgMetricsClusterAmbiguous: "Più provider di questo scenario run hanno un cluster chiamato '{cluster}', usa il suo cluster ID",
+		MsgMetricsUpstreamFailed:   "Impossibile interrogare il Prometheus del cluster: {error}",
+
+		MsgEffectiveSpecNotCaptured: "Nessuna spec effettiva registrata per il cluster '{cluster}', il suo job è stato creato prima che l'operator la registrasse",
+
+		MsgJobAlreadyFinished:    "Il job del cluster '{cluster}' è già terminato {phase}",
+		MsgRunCancelFailed:       "Impossibile annullare lo scenario run: {error}",
+		MsgCancelClusterNotInRun: "Il cluster '{cluster}' non è un target dello scenario run '{name}', non c'è nessun job da annullare",
+		MsgRetryRunCancelled:     "Lo scenario run '{name}' è stato annullato e non può essere ripetuto",
+
+		MsgLintMissingTimeout:  "Nessun timeoutSeconds: uno scenario bloccato mantiene il pod e il chaos attivi finché non viene annullato",
+		MsgLintLatestTag:       "L'immagine '{image}' non è fissata: il tag latest può cambiare tra un run e l'altro, usa un tag di versione o un digest",
+		MsgLintNoSLOChecks:     "Nessun controllo SLO configurato: imposta {variables} perché il run fallisca quando il cluster degrada",
+		MsgLintTooManyClusters: "Il run colpisce {clusters} cluster contemporaneamente, più di {max}: valuta un primo run più piccolo",
+		MsgLintPlainEnvSecret:  "La variabile d'ambiente '{name}' sembra una credenziale: è salvata in chiaro nella spec del run e del pod",
+
+		MsgProviderNameRequired:  "Il nome del provider è obbligatorio",
+		MsgProviderNotFound:      "Provider non trovato",
+		MsgProviderListFailed:    "Impossibile elencare i provider",
+		MsgProviderQueryFailed:   "Impossibile interrogare i provider",
+		MsgProviderUpdateFailed:  "Impossibile aggiornare lo stato del provider",
+		MsgProviderNotActive:     "Il provider {provider} non è registrato o non è attivo",
+		MsgProviderReasonTooLong: "Il motivo deve avere al massimo {max} caratteri",
+		MsgProviderVerifyNone:    "Nessun provider attivo da verificare",
+		MsgProviderVerifyTimeout: "timeoutSeconds deve essere compreso tra 1 e {max}",
+		MsgProviderVerifyFailed:  "Impossibile creare la richiesta di verifica",
+
+		MsgRegistryUnavailable: "Il registry degli scenari {registry} non è raggiungibile e non c'è un catalogo in cache: {error}",
+
+		MsgCampaignNameInvalid:   "Nome della campagna '{name}' non valido: {error}",
+		MsgCampaignWindowInvalid: "La fine della finestra della campagna deve essere successiva all'inizio",
+		MsgCampaignNotFound:      "Campagna '{name}' non trovata",
+		MsgCampaignExists:        "La campagna '{name}' esiste già",
+		MsgCampaignForbidden:     "Solo il facilitatore della campagna '{name}' o un amministratore possono modificarla",
+		MsgCampaignFetchFailed:   "Impossibile leggere la campagna: {error}",
+		MsgCampaignListFailed:    "Impossibile elencare le campagne: {error}",
+		MsgCampaignCreateFailed:  "Impossibile creare la campagna: {error}",
+		MsgCampaignUpdateFailed:  "Impossibile aggiornare la campagna: {error}",
+		MsgCampaignDeleteFailed:  "Impossibile eliminare la campagna: {error}",
+
+		MsgHistoryDisabled:    "Lo storico dei run non è abilitato su questo operator",
+		MsgHistoryNotFound:    "Scenario run '{name}' non trovato nello storico dei run",
+		MsgHistoryQueryFailed: "Impossibile interrogare lo storico dei run: {error}",
+
+		MsgUsageDisabled:     "La telemetria di utilizzo non è abilitata su questo operator",
+		MsgUsageReportFailed: "Impossibile generare il report di utilizzo: {error}",
+
+		MsgUISessionInvalid: "La sessione della UI richiede un bearer token valido",
+
+		MsgAuthClaimsMissing:       "Nessuna credenziale di autenticazione trovata",
+		MsgRegistrationCheckFailed: "Impossibile verificare lo stato della registrazione dell'amministratore",
+		MsgRegistrationClosed:      "La registrazione iniziale dell'amministratore è completata. I nuovi utenti devono essere creati da un amministratore con POST /api/v1/users",
+		MsgFirstUserAdmin:          "Il primo utente deve avere il ruolo admin",
+		MsgAdminCheckFailed:        "Impossibile verificare lo stato dell'amministratore",
+		MsgLoginFieldsRequired:     "UserID e password sono obbligatori",
+		MsgAuthenticationFailed:    "Autenticazione non riuscita",
+		MsgInvalidCredentials:      "Email o password non validi",
+		MsgAccountDisabled:         "L'account utente è disabilitato",
+		MsgTokenGenerationFailed:   "Impossibile generare il token",
+
+		MsgUserFieldsRequired:     "UserID, password, nome, cognome e ruolo sono obbligatori",
+		MsgUserRoleInvalid:        "Il ruolo deve essere 'user' o 'admin'",
+		MsgUserCheckFailed:        "Impossibile verificare gli utenti esistenti",
+		MsgUserExists:             "Esiste già un utente con email {userId}",
+		MsgUserNotFound:           "Utente '{userId}' non trovato",
+		MsgUserFetchFailed:        "Impossibile leggere l'utente: {error}",
+		MsgUserListFailed:         "Impossibile elencare gli utenti: {error}",
+		MsgUserCreateFailed:       "Impossibile creare l'utente",
+		MsgUserUpdateFailed:       "Impossibile aggiornare l'utente: {error}",
+		MsgUserDeleteFailed:       "Impossibile eliminare l'utente: {error}",
+		MsgNoFieldsToUpdate:       "È necessario specificare almeno un campo",
+		MsgAdminCountFailed:       "Impossibile contare gli amministratori",
+		MsgProfileForbidden:       "Puoi visualizzare solo il tuo profilo",
+		MsgProfileUpdateForbidden: "Puoi modificare solo il tuo profilo",
+		MsgRoleChangeForbidden:    "Solo gli amministratori possono cambiare il ruolo o lo stato di attivazione",
+		MsgSelfDisableForbidden:   "Non puoi disabilitare il tuo account",
+		MsgSelfDeleteForbidden:    "Non puoi eliminare il tuo account",
+		MsgLastAdminForbidden:     "Impossibile eliminare l'ultimo amministratore attivo",
+
+		MsgPasswordInvalid:           "Validazione della password non riuscita: {error}",
+		MsgPasswordHashFailed:        "Impossibile elaborare la password",
+		MsgPasswordHashMissing:       "Hash della password non trovato",
+		MsgPasswordSecretFailed:      "Impossibile leggere il secret della password: {error}",
+		MsgPasswordSecretStoreFailed: "Impossibile creare il secret della password: {error}",
+		MsgCredentialsCleanupFailed:  "Impossibile rimuovere le credenziali esistenti",
+		MsgCredentialsCreateFailed:   "Impossibile creare le credenziali dell'utente",
+		MsgCurrentPasswordRequired:   "La password attuale è obbligatoria per cambiare la propria password",
+		MsgCurrentPasswordIncorrect:  "La password attuale non è corretta",
+		MsgPasswordUnchanged:         "La nuova password deve essere diversa da quella attuale",
+		MsgPasswordUpdateFailed:      "Impossibile aggiornare la password",
+		MsgPasswordChangeForbidden:   "Puoi cambiare solo la tua password",
+
+		MsgAccessCheckFailed:     "Impossibile verificare l'accesso",
+		MsgRunNoJobs:             "Accesso negato. Questo scenario run non ha job",
+		MsgRunActionForbidden:    "Accesso negato. Non hai il permesso per l'azione {action} su questo scenario run",
+		MsgRunJobsForbidden:      "Accesso negato. Non hai il permesso di visualizzare i job di questo scenario run",
+		MsgRunLogsForbidden:      "Accesso negato. Non hai il permesso di visualizzare i log di questo scenario run",
+		MsgRunDeleteForbidden:    "Accesso negato. Per eliminare il run serve il permesso di annullamento su tutti i suoi job",
+		MsgRunClustersForbidden:  "Accesso negato: {error}",
+		MsgJobNoClusterURL:       "Accesso negato. Il job non ha un URL dell'API del cluster",
+		MsgJobActionForbidden:    "Accesso negato. Non hai il permesso per l'azione {action} su questo job",
+		MsgNodesForbidden:        "Non hai il permesso di visualizzare i nodi di questo cluster",
+		MsgUserGroupsFetchFailed: "Impossibile leggere i gruppi dell'utente",
+
+		MsgTargetRequestNotFound:     "KrknTargetRequest con id '{id}' non trovata",
+		MsgTargetRequestNotCompleted: "La KrknTargetRequest con id '{id}' non è completata",
+		MsgTargetRequestNotReady:     "La target request non è ancora completata",
+		MsgTargetRequestSandbox:      "La target request è una sandbox di verifica dei provider",
+		MsgTargetRequestFetchFailed:  "Impossibile leggere la target request",
+		MsgTargetRequestCreateFailed: "Impossibile creare la KrknTargetRequest",
+		MsgTargetKubeconfigNotFound:  "Kubeconfig non trovato: {error}",
+		MsgTargetKubeconfigFailed:    "Impossibile leggere il kubeconfig: {error}",
+		MsgNodesParamsRequired:       "Sono obbligatori i parametri targetUUID (nuovo) oppure id e cluster-name (legacy)",
+		MsgNodesFetchFailed:          "Impossibile ottenere i nodi dal servizio gRPC",
+
+		MsgScenarioNotFound:        "Scenario '{name}' non trovato",
+		MsgScenarioGlobalsNotFound: "Ambiente globale dello scenario '{name}' non trovato",
+		MsgRegistryRequestFailed:   "Richiesta al registry degli scenari non riuscita: {error}",
+
+		MsgTargetsRequired:            "targetClusters, targetClusterIds, targetSelector o targetGroupRef è obbligatorio e deve contenere almeno un provider con cluster",
+		MsgTargetGroupRefInvalid:      "targetGroupRef: nome '{name}' non valido: {error}",
+		MsgTargetGroupNotFound:        "targetGroupRef '{name}' non esiste",
+		MsgTargetGroupSelectorInvalid: "targetGroupRef '{name}' ha un selettore non valido: {error}",
+		MsgTargetGroupNoMembers:       "targetGroupRef '{name}' non ha membri tra i target della target request",
+		MsgTargetGroupFetchFailed:     "Impossibile leggere il target group",
+		MsgTargetSelectorNoMatch:      "targetSelector '{selector}' non corrisponde ad alcun target della target request",
+		MsgTargetClusterIDUnknown:     "targetClusterIds: il cluster ID '{clusterId}' non è un target della target request",
+		MsgDisplayNameTooLong:         "displayName deve avere al massimo {max} caratteri",
+		MsgTimeoutInvalid:             "timeoutSeconds deve essere un numero positivo di secondi",
+		MsgTTLNegative:                "ttlSecondsAfterFinished non può essere negativo",
+		MsgMaintenancePolicyInvalid:   "maintenancePolicy deve essere skip o wait",
+		MsgMaxConcurrencyNegative:     "maxConcurrency non può essere negativo",
+		MsgImagePullPolicyInvalid:     "imagePullPolicy deve essere Always, IfNotPresent o Never",
+		MsgProviderNameEmpty:          "i nomi dei provider non possono essere vuoti",
+		MsgProviderNoClusters:         "il provider '{provider}' deve avere almeno un cluster",
+		MsgClusterNameEmpty:           "i nomi dei cluster non possono essere vuoti",
+		MsgClusterInMultipleProviders: "il cluster '{cluster}' compare in più provider: '{provider}' e '{otherProvider}'",
+		MsgAlternateNotTarget:         "alternates: il cluster '{cluster}' non è un cluster target",
+		MsgAlternateIsTarget:          "alternates: l'alternativa del cluster '{cluster}' deve essere un cluster che non è un target",
+		MsgTargetsArchived:            "I target {clusters} sono archiviati e non possono essere usati per gli scenario run, ripristinali prima",
+
+		MsgScenarioRunCreateFailed: "Impossibile creare lo scenario run",
+		MsgScenarioRunListFailed:   "Impossibile elencare gli scenario run: {error}",
+		MsgScenarioRunDeleteFailed: "Impossibile eliminare lo scenario run",
+		MsgScenarioRunStatusFailed: "Impossibile aggiornare lo stato dello scenario run",
+		MsgJobNotFound:             "Job '{jobId}' non trovato",
+		MsgJobListFailed:           "Impossibile elencare i job",
+		MsgJobDeleteFailed:         "Impossibile eliminare il job",
+		MsgJobWatchFailed:          "Impossibile osservare il pod del job: {error}",
+
+		MsgUserGroupNotFound:        "Gruppo di utenti '{name}' non trovato",
+		MsgUserGroupExists:          "Il gruppo di utenti '{name}' esiste già",
+		MsgUserGroupListFailed:      "Impossibile elencare i gruppi di utenti",
+		MsgUserGroupFetchFailed:     "Impossibile leggere il gruppo di utenti",
+		MsgUserGroupCreateFailed:    "Impossibile creare il gruppo di utenti",
+		MsgUserGroupUpdateFailed:    "Impossibile aggiornare il gruppo di utenti",
+		MsgUserGroupDeleteFailed:    "Impossibile eliminare il gruppo di utenti",
+		MsgGroupNameInvalidChars:    "Il nome del gruppo contiene solo caratteri non validi",
+		MsgGroupNameTooLong:         "Il nome del gruppo è troppo lungo. Dopo la normalizzazione deve avere al massimo 63 caratteri (attuali: {length}). Usa un nome più corto.",
+		MsgGroupPermissionsRequired: "È necessario almeno un permesso su un cluster",
+		MsgGroupMembersListFailed:   "Impossibile elencare i membri del gruppo",
+		MsgGroupMemberExists:        "L'utente '{userId}' è già membro del gruppo '{name}'",
+		MsgGroupMemberNotFound:      "L'utente '{userId}' non è membro del gruppo '{name}'",
+		MsgGroupMemberAddFailed:     "Impossibile aggiungere l'utente al gruppo",
+		MsgGroupMemberRemoveFailed:  "Impossibile rimuovere l'utente dal gruppo",
+
+		MsgProviderConfigNotFound:     "KrknOperatorTargetProviderConfig non trovata",
+		MsgProviderConfigCreateFailed: "Impossibile creare la KrknOperatorTargetProviderConfig: {error}",
+		MsgProviderConfigFetchFailed:  "Impossibile leggere la KrknOperatorTargetProviderConfig: {error}",
+		MsgProviderConfigValuesEmpty:  "values non può essere vuoto",
+		MsgProviderConfigFieldUnknown: "campo {field} non presente nello schema",
+		MsgProviderTargetNotFound:     "target provider: {provider} non trovato",
+		MsgConfigMapWriteFailed:       "Impossibile scrivere i dati della ConfigMap: {error}",
+		MsgConfigMapGetFailed:         "Impossibile leggere la ConfigMap",
+		MsgConfigMapCreateFailed:      "Impossibile creare la ConfigMap",
+		MsgConfigMapUpdateFailed:      "Impossibile aggiornare la ConfigMap",
+
+		MsgOpenAPIFailed: "Impossibile generare la specifica OpenAPI: {error}",
+
+		MsgScenarioRestricted:        "Lo scenario '{scenario}' è soggetto a restrizioni e richiede un'esenzione break-glass",
+		MsgBreakGlassMismatch:        "L'esenzione break-glass non copre questa esecuzione: {error}",
+		MsgBreakGlassStaticTargets:   "Le esecuzioni di scenari soggetti a restrizioni devono elencare i cluster in targetClusters o targetSelector",
+		MsgBreakGlassNotRestricted:   "Lo scenario '{scenario}' non è soggetto a restrizioni e non richiede un'esenzione break-glass",
+		MsgBreakGlassDurationInvalid: "durationSeconds deve essere compreso tra 1 e {max}",
+		MsgBreakGlassIssueFailed:     "Impossibile emettere l'esenzione break-glass: {error}",
+
+		MsgTargetCreateSucceeded:         "Target creato correttamente",
+		MsgTargetUpdateSucceeded:         "Target aggiornato correttamente",
+		MsgTargetArchiveSucceeded:        "Target archiviato correttamente, può essere ripristinato fino al {purgeAfter}",
+		MsgTargetRestoreSucceeded:        "Target ripristinato correttamente",
+		MsgUserRegisterSucceeded:         "Utente registrato correttamente",
+		MsgUserCreateSucceeded:           "Utente creato correttamente",
+		MsgUserUpdateSucceeded:           "Utente aggiornato correttamente",
+		MsgUserDeleteSucceeded:           "Utente eliminato correttamente",
+		MsgPasswordUpdateSucceeded:       "Password aggiornata correttamente",
+		MsgUserGroupCreateSucceeded:      "Gruppo di utenti creato correttamente",
+		MsgUserGroupUpdateSucceeded:      "Gruppo di utenti aggiornato correttamente",
+		MsgUserGroupDeleteSucceeded:      "Gruppo di utenti eliminato correttamente",
+		MsgGroupMemberAddSucceeded:       "Utente aggiunto al gruppo correttamente",
+		MsgGroupMemberRemoveSucceeded:    "Utente rimosso dal gruppo correttamente",
+		MsgProviderConfigUpdateSucceeded: "Configurazione aggiornata correttamente",
+		MsgProviderStatusUpdateSucceeded: "Stato del provider aggiornato correttamente",
+		MsgJobStopSucceeded:              "Job fermato ed eliminato correttamente",
+	},
+})
+
+// requestLanguage returns the catalog language negotiated from the Accept-Language header
+func requestLanguage(r *http.Request) string {
+	if r == nil {
+		return i18n.DefaultLanguage
+	}
+	return messageCatalog.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// writeLocalizedError writes an ErrorResponse whose message is rendered from the
+// catalog in the language requested by the client. errType and code stay stable
+// across languages.
+func writeLocalizedError(w http.ResponseWriter, r *http.Request, status int, errType, code string, params i18n.Params) {
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	writeJSONError(w, status, ErrorResponse{
+		Error:   errType,
+		Code:    code,
+		Message: messageCatalog.Render(lang, code, params),
+	})
+}
+
+// localizedMessage renders the catalog message for code in the language requested
+// by the client, for success responses that carry a Code and a Message.
+func localizedMessage(w http.ResponseWriter, r *http.Request, code string, params i18n.Params) string {
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	return messageCatalog.Render(lang, code, params)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLocalizedErrors(t *testing.T) {
+	handler := setupTestHandler()
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expectedLang   string
+		expectedMsg    string
+	}{
+		{
+			name:         "default english",
+			expectedLang: "en",
+			expectedMsg:  "Method PATCH not allowed for path " + OperatorTargetsPath,
+		},
+		{
+			name:           "italian",
+			acceptLanguage: "it-IT,it;q=0.9,en;q=0.8",
+			expectedLang:   "it",
+			expectedMsg:    "Metodo PATCH non consentito per il percorso " + OperatorTargetsPath,
+		},
+		{
+			name:           "unsupported language falls back to english",
+			acceptLanguage: "ja",
+			expectedLang:   "en",
+			expectedMsg:    "Method PATCH not allowed for path " + OperatorTargetsPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, OperatorTargetsPath, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+
+			serveAPI(handler, w, req)
+
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Fatalf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.expectedLang {
+				t.Errorf("Expected Content-Language %q, got %q", tt.expectedLang, got)
+			}
+
+			var errResp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			// Error and Code are stable regardless of language
+			if errResp.Error != "method_not_allowed" || errResp.Code != MsgMethodNotAllowed {
+				t.Errorf("Unexpected error/code: %s/%s", errResp.Error, errResp.Code)
+			}
+			if errResp.Message != tt.expectedMsg {
+				t.Errorf("Expected message %q, got %q", tt.expectedMsg, errResp.Message)
+			}
+		})
+	}
+}
+
+func TestLocalizedHandlerErrors(t *testing.T) {
+	handler := setupTestHandler()
+
+	req := httptest.NewRequest(http.MethodPost, AuthLogin, strings.NewReader(`{"userId":"[email protected]"}`))
+	req.Header.Set("Accept-Language", "it")
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var errResp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if errResp.Error != "validation_error" || errResp.Code != MsgLoginFieldsRequired {
+		t.Errorf("Unexpected error/code: %s/%s", errResp.Error, errResp.Code)
+	}
+	if errResp.Message != "UserID e password sono obbligatori" {
+		t.Errorf("Expected an Italian message, got %q", errResp.Message)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// unmatchedRoute labels requests that match no route, keeping label cardinality bounded
+const unmatchedRoute = "unmatched"
+
+var (
+	apiRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "krkn_api_requests_total",
+			Help: "Total number of REST API requests by route, method and status code.",
+		},
+		[]string{"route", "method", "code"},
+	)
+
+	apiRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "krkn_api_request_duration_seconds",
+			Help:    "REST API request latency by route and method.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method"},
+	)
+
+	apiRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "krkn_api_requests_in_flight",
+			Help: "Number of REST API requests currently being served, by route.",
+		},
+		[]string{"route"},
+	)
+
+	logLinesTruncated = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "krkn_api_log_lines_truncated_total",
+			Help: "Number of scenario log lines cut at the maximum log line length while streaming.",
+		},
+	)
+
+	registryAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "krkn_scenario_registry_available",
+			Help: "1 when the last call to the scenario registry succeeded, 0 when it failed.",
+		},
+		[]string{"registry"},
+	)
+
+	registryErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "krkn_scenario_registry_errors_total",
+			Help: "Number of failed calls to the scenario registry.",
+		},
+		[]string{"registry"},
+	)
+)
+
+func init() {
+	// Served by the controller-runtime metrics endpoint
+	metrics.Registry.MustRegister(apiRequestsTotal, apiRequestDuration, apiRequestsInFlight, logLinesTruncated,
+		registryAvailable, registryErrors)
+}
+
+// metricsMiddleware records request count, latency and in-flight requests per route.
+// Routes are labeled with their pattern (e.g. /api/v1/scenarios/run/{scenarioRunName}),
+// never with the raw path, so path parameters do not create new series.
+func metricsMiddleware(router *chi.Mux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := routePattern(router, r)
+			labels := prometheus.Labels{"route": route}
+
+			instrumented := promhttp.InstrumentHandlerInFlight(
+				apiRequestsInFlight.With(labels),
+				promhttp.InstrumentHandlerDuration(
+					apiRequestDuration.MustCurryWith(labels),
+					promhttp.InstrumentHandlerCounter(apiRequestsTotal.MustCurryWith(labels), next),
+				),
+			)
+			instrumented.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routePattern returns the pattern of the route serving r, or unmatchedRoute
+func routePattern(router *chi.Mux, r *http.Request) string {
+	path := r.URL.Path
+	if len(path) > 1 {
+		// Mirrors middleware.StripSlashes, which runs after this middleware
+		path = strings.TrimSuffix(path, "/")
+	}
+	if pattern := router.Find(chi.NewRouteContext(), r.Method, path); pattern != "" {
+		return pattern
+	}
+	return unmatchedRoute
+}
@@ -0,0 +1,497 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+)
+
+// Environment variables configuring the target metrics proxy
+const (
+	// MetricsProxyAllowedMetricsEnv is a comma-separated allowlist of the metric names a
+	// proxied query may reference. A trailing "*" matches every metric with that prefix.
+	MetricsProxyAllowedMetricsEnv = "METRICS_PROXY_ALLOWED_METRICS"
+	// MetricsProxyRateLimitEnv is the number of proxied queries allowed per user and minute
+	MetricsProxyRateLimitEnv = "METRICS_PROXY_RATE_LIMIT"
+)
+
+// PrometheusTokenSecretKey is the target Secret key holding the Prometheus bearer token
+const PrometheusTokenSecretKey = "prometheus-token"
+
+// defaultAllowedMetrics covers the usual cluster health metrics
+var defaultAllowedMetrics = []string{
+	"up", "node_*", "kube_*", "container_*", "apiserver_*", "etcd_*",
+	"cluster:*", "instance:*", "namespace:*", "node:*",
+}
+
+const (
+	defaultMetricsProxyRateLimit = 60
+	metricsProxyTimeout          = 30 * time.Second
+	metricsProxyMaxResponseSize  = 10 << 20
+)
+
+// MetricsProxyConfig is the policy of the target metrics proxy
+type MetricsProxyConfig struct {
+	AllowedMetrics []string
+	// RateLimit is the number of queries allowed per user and minute
+	RateLimit int
+}
+
+// MetricsProxyConfigFromEnv reads the metrics proxy policy from the environment
+func MetricsProxyConfigFromEnv() MetricsProxyConfig {
+	cfg := MetricsProxyConfig{
+		AllowedMetrics: splitList(os.Getenv(MetricsProxyAllowedMetricsEnv)),
+		RateLimit:      defaultMetricsProxyRateLimit,
+	}
+	if len(cfg.AllowedMetrics) == 0 {
+		cfg.AllowedMetrics = defaultAllowedMetrics
+	}
+	if limit, err := strconv.Atoi(os.Getenv(MetricsProxyRateLimitEnv)); err == nil && limit > 0 {
+		cfg.RateLimit = limit
+	}
+	return cfg
+}
+
+// AllowsMetric reports whether name is in the allowlist
+func (c MetricsProxyConfig) AllowsMetric(name string) bool {
+	for _, allowed := range c.AllowedMetrics {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// metricsProxy rate-limits proxied queries per user and holds the upstream clients
+type metricsProxy struct {
+	config   MetricsProxyConfig
+	secure   *http.Client
+	insecure *http.Client
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newMetricsProxy(cfg MetricsProxyConfig) *metricsProxy {
+	insecureTransport := http.DefaultTransport.(*http.Transport).Clone()
+	insecureTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // opt-in per target
+	return &metricsProxy{
+		config:   cfg,
+		secure:   &http.Client{Timeout: metricsProxyTimeout},
+		insecure: &http.Client{Timeout: metricsProxyTimeout, Transport: insecureTransport},
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// reserve takes a query from the user's budget. It returns how long the user must
+// wait before querying again, zero when the query is allowed.
+func (p *metricsProxy) reserve(user string) time.Duration {
+	p.mu.Lock()
+	limiter, ok := p.limiters[user]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(float64(p.config.RateLimit)/60), p.config.RateLimit)
+		p.limiters[user] = limiter
+	}
+	p.mu.Unlock()
+
+	reservation := limiter.Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return delay
+	}
+	return 0
+}
+
+// promQLLabelListKeywords are followed by a parenthesized list of label names
+var promQLLabelListKeywords = map[string]bool{
+	"by": true, "without": true, "on": true, "ignoring": true, "group_left": true, "group_right": true,
+}
+
+// promQLKeywords are PromQL words that are not metrics even without a following "(":
+// operators, modifiers, literals and aggregations, as in "sum by (pod) (...)"
+var promQLKeywords = map[string]bool{
+	"and": true, "or": true, "unless": true, "bool": true, "offset": true, "atan2": true, "inf": true, "nan": true,
+	"sum": true, "min": true, "max": true, "avg": true, "group": true, "stddev": true, "stdvar": true,
+	"count": true, "count_values": true, "bottomk": true, "topk": true, "quantile": true,
+	"limitk": true, "limit_ratio": true,
+}
+
+// queryMetricNames returns the metric names referenced by a PromQL expression.
+// It is a lexical scan, not a parser: it skips strings, label matchers, range selectors
+// and label lists, and treats every other identifier not followed by "(" as a metric.
+// Label matchers must follow a metric name: a bare selector such as {job=~".+"} selects
+// every metric and is rejected.
+func queryMetricNames(query string) ([]string, error) {
+	var names []string
+	afterMetric := false
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			end, err := skipPromQLString(query, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			afterMetric = false
+		case c == '{':
+			end, err := skipPromQLGroup(query, i, '}')
+			if err != nil {
+				return nil, err
+			}
+			if strings.Contains(query[i:end], "__name__") {
+				return nil, fmt.Errorf("selecting metrics by __name__ is not allowed")
+			}
+			if !afterMetric {
+				return nil, fmt.Errorf("label matchers without a metric name are not allowed")
+			}
+			i = end
+			afterMetric = false
+		case c == '[':
+			end, err := skipPromQLGroup(query, i, ']')
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			afterMetric = false
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c >= '0' && c <= '9' || c == '.':
+			// Numbers and durations, e.g. 0.5, 1e3, 0x1f, 5m
+			for i < len(query) && (isPromQLIdentChar(query[i]) || query[i] == '.') {
+				i++
+			}
+			afterMetric = false
+		case isPromQLIdentStart(c):
+			start := i
+			for i < len(query) && isPromQLIdentChar(query[i]) {
+				i++
+			}
+			word := query[start:i]
+
+			next := i
+			for next < len(query) && strings.ContainsRune(" \t\r\n", rune(query[next])) {
+				next++
+			}
+			followedByParen := next < len(query) && query[next] == '('
+
+			afterMetric = false
+			switch {
+			case promQLLabelListKeywords[word] && followedByParen:
+				end, err := skipPromQLGroup(query, next, ')')
+				if err != nil {
+					return nil, err
+				}
+				i = end
+			case followedByParen, promQLLabelListKeywords[word], promQLKeywords[strings.ToLower(word)]:
+				// Function, aggregation or keyword
+			default:
+				names = append(names, word)
+				afterMetric = true
+			}
+		default:
+			if !strings.ContainsRune(" \t\r\n", rune(c)) {
+				afterMetric = false
+			}
+			i++
+		}
+	}
+	return names, nil
+}
+
+// skipPromQLString returns the index after the string literal starting at start
+func skipPromQLString(query string, start int) (int, error) {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string literal")
+}
+
+// skipPromQLGroup returns the index after the closing delimiter of the group starting
+// at start, skipping string literals inside it
+func skipPromQLGroup(query string, start int, closing byte) (int, error) {
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '"', '\'', '`':
+			end, err := skipPromQLString(query, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end - 1
+		case closing:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("missing %q", closing)
+}
+
+func isPromQLIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':'
+}
+
+func isPromQLIdentChar(c byte) bool {
+	return isPromQLIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// targetMetricsConfig validates a metrics proxy request and stores its bearer token in the
+// target Secret. A nil request disables the proxy and removes the token.
+func targetMetricsConfig(req *TargetMetricsRequest, secret *corev1.Secret) (*krknv1alpha1.TargetMetricsConfig, error) {
+	if req == nil {
+		delete(secret.Data, PrometheusTokenSecretKey)
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(req.PrometheusURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("metrics.prometheusURL must be an http or https URL")
+	}
+
+	if req.BearerToken != "" {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[PrometheusTokenSecretKey] = []byte(req.BearerToken)
+	} else {
+		delete(secret.Data, PrometheusTokenSecretKey)
+	}
+
+	return &krknv1alpha1.TargetMetricsConfig{
+		PrometheusURL:         req.PrometheusURL,
+		InsecureSkipTLSVerify: req.InsecureSkipTLSVerify,
+	}, nil
+}
+
+// ProxyClusterMetrics handles GET /api/v1/scenarios/run/{scenarioRunName}/clusters/{clusterName}/metrics
+// It relays a PromQL query to the Prometheus of one of the run's target clusters, so the UI
+// can chart the cluster health next to the chaos timeline without direct cluster access.
+// clusterName is the name or the cluster ID of the target; the ID is required when
+// clusters of several providers share the name.
+//
+// Query parameters:
+//   - query: PromQL expression (required), it may only reference allowlisted metrics
+//   - start, end, step: run a range query instead of an instant query
+//   - time: evaluation time of an instant query
+func (h *Handler) ProxyClusterMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	clusterName, err := pathParam(r, ParamClusterName)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "clusterName"})
+		return
+	}
+
+	params := r.URL.Query()
+	promQuery := params.Get("query")
+	if promQuery == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "query"})
+		return
+	}
+
+	user := r.RemoteAddr
+	if claims := auth.GetClaimsFromContext(ctx); claims != nil {
+		user = claims.UserID
+	}
+	if delay := h.metricsProxy.reserve(user); delay > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		writeLocalizedError(w, r, http.StatusTooManyRequests, "too_many_requests", MsgMetricsRateLimited, nil)
+		return
+	}
+
+	metricNames, err := queryMetricNames(promQuery)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgMetricsQueryInvalid, i18n.Params{"error": err.Error()})
+		return
+	}
+	for _, name := range metricNames {
+		if !h.metricsProxy.config.AllowsMetric(name) {
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgMetricsQueryNotAllowed, i18n.Params{"metric": name})
+			return
+		}
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound, i18n.Params{"name": string(scenarioRunName)})
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	// The cluster is the ID or the name of a target of the run; names are only unique
+	// per provider
+	var job *krknv1alpha1.ClusterJobStatus
+	for i := range scenarioRun.Status.ClusterJobs {
+		candidate := &scenarioRun.Status.ClusterJobs[i]
+		if candidate.ClusterID != "" && candidate.ClusterID == clusterName {
+			job = candidate
+			break
+		}
+		if candidate.ClusterName != clusterName {
+			continue
+		}
+		if job != nil && job.ProviderName != candidate.ProviderName {
+			writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgMetricsClusterAmbiguous, i18n.Params{"cluster": clusterName})
+			return
+		}
+		job = candidate
+	}
+	if job == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgMetricsClusterNotInRun, i18n.Params{"cluster": clusterName})
+		return
+	}
+	if !h.checkJobAccess(w, r, job, groupauth.ActionView, "view") {
+		return
+	}
+
+	target, err := h.metricsTarget(ctx, &scenarioRun, job)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetListFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	if target == nil || target.Spec.Metrics == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgMetricsNotConfigured, i18n.Params{"cluster": clusterName})
+		return
+	}
+
+	upstream, err := prometheusQueryURL(target.Spec.Metrics.PrometheusURL, params)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgMetricsUpstreamFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream, nil)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgMetricsUpstreamFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	upstreamReq.Header.Set("Accept", "application/json")
+
+	credentials, err := secrets.TargetCredentials(ctx, h.client, h.credentialsStore, target)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCredentialsGetFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	if token := credentials[PrometheusTokenSecretKey]; len(token) > 0 {
+		upstreamReq.Header.Set("Authorization", "Bearer "+string(token))
+	}
+
+	httpClient := h.metricsProxy.secure
+	if target.Spec.Metrics.InsecureSkipTLSVerify {
+		httpClient = h.metricsProxy.insecure
+	}
+	resp, err := httpClient.Do(upstreamReq)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadGateway, "bad_gateway", MsgMetricsUpstreamFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Prometheus answers errors with the same JSON envelope, relay it as is
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, io.LimitReader(resp.Body, metricsProxyMaxResponseSize))
+}
+
+// prometheusQueryURL builds the Prometheus HTTP API URL for the proxied query parameters:
+// a range query when start, end and step are set, an instant query otherwise
+func prometheusQueryURL(base string, params url.Values) (string, error) {
+	forwarded := url.Values{"query": {params.Get("query")}}
+	endpoint := "/api/v1/query"
+	if params.Get("start") != "" || params.Get("end") != "" || params.Get("step") != "" {
+		endpoint = "/api/v1/query_range"
+		for _, name := range []string{"start", "end", "step"} {
+			forwarded.Set(name, params.Get(name))
+		}
+	} else if t := params.Get("time"); t != "" {
+		forwarded.Set("time", t)
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(base, "/") + endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = forwarded.Encode()
+	return u.String(), nil
+}
+
+// metricsTarget returns the active KrknOperatorTarget the job of scenarioRun ran against,
+// matched by the provider and stable cluster ID of the job, or nil when the cluster is
+// not a KrknOperatorTarget, e.g. of another provider, or the target was archived
+func (h *Handler) metricsTarget(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun,
+	job *krknv1alpha1.ClusterJobStatus) (*krknv1alpha1.KrknOperatorTarget, error) {
+	clusterID := job.ClusterID
+	if clusterID == "" {
+		clusterID = scenarioRun.TargetClusterID(job.ProviderName, job.ClusterName)
+	}
+
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := h.client.List(ctx, &targets, client.InNamespace(h.namespace)); err != nil {
+		return nil, err
+	}
+	for i := range targets.Items {
+		target := &targets.Items[i]
+		if target.Status.Archived {
+			continue
+		}
+		// KrknOperatorTargets are contributed with their UUID as cluster UID
+		if krknv1alpha1.StableClusterID(job.ProviderName, target.Spec.UUID, target.Spec.ClusterName) == clusterID {
+			return target, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestQueryMetricNames(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+		wantErr  bool
+	}{
+		{query: `up`, expected: []string{"up"}},
+		{query: `up{job="api", instance=~"10.0.*"}`, expected: []string{"up"}},
+		{query: `up {job="api"}`, expected: []string{"up"}},
+		{query: `sum by (node) (rate(node_cpu_seconds_total{mode!="idle"}[5m]))`, expected: []string{"node_cpu_seconds_total"}},
+		{query: `sum(rate(container_cpu_usage_seconds_total[1m])) without (pod) / on(namespace) group_left kube_pod_info offset 5m`,
+			expected: []string{"container_cpu_usage_seconds_total", "kube_pod_info"}},
+		{query: `label_replace(up, "dst", "$1", "instance", "(.*):.*") > bool 0.5`, expected: []string{"up"}},
+		{query: `histogram_quantile(0.99, sum by (le) (apiserver_request_duration_seconds_bucket))`,
+			expected: []string{"apiserver_request_duration_seconds_bucket"}},
+		{query: `cluster:node_cpu:ratio and instance:node_load1:ratio`, expected: []string{"cluster:node_cpu:ratio", "instance:node_load1:ratio"}},
+		{query: `vector(1)`},
+		{query: `{__name__=~".+"}`, wantErr: true},
+		{query: `{job=~".+"}`, wantErr: true},
+		{query: `count({namespace="x"})`, wantErr: true},
+		{query: `sum by (job) {job="api"}`, wantErr: true},
+		{query: `up + {job="api"}`, wantErr: true},
+		{query: `up{job="api}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		names, err := queryMetricNames(tt.query)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.query, err)
+			continue
+		}
+		if !slices.Equal(names, tt.expected) {
+			t.Errorf("%s: expected metrics %v, got %v", tt.query, tt.expected, names)
+		}
+	}
+}
+
+func TestMetricsProxyConfig_AllowsMetric(t *testing.T) {
+	cfg := MetricsProxyConfig{AllowedMetrics: []string{"up", "node_*"}}
+
+	for name, allowed := range map[string]bool{
+		"up":                     true,
+		"upstream":               false,
+		"node_cpu_seconds_total": true,
+		"kube_pod_info":          false,
+	} {
+		if got := cfg.AllowsMetric(name); got != allowed {
+			t.Errorf("AllowsMetric(%q) = %v, expected %v", name, got, allowed)
+		}
+	}
+}
+
+// setupMetricsProxyTarget creates a run targeting cluster-1 and a target whose
+// Prometheus is served by prometheus
+func setupMetricsProxyTarget(t *testing.T, handler *Handler, prometheus *httptest.Server) {
+	t.Helper()
+	ctx := context.Background()
+
+	objects := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-1", Namespace: handler.namespace},
+			Data:       map[string][]byte{PrometheusTokenSecretKey: []byte("prom-token")},
+		},
+		&krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "target-1", Namespace: handler.namespace},
+			Spec: krknv1alpha1.KrknOperatorTargetSpec{
+				UUID:        "target-1",
+				ClusterName: "cluster-1",
+				SecretUUID:  "secret-1",
+				Metrics:     &krknv1alpha1.TargetMetricsConfig{PrometheusURL: prometheus.URL + "/"},
+			},
+		},
+		&krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+			Status: krknv1alpha1.KrknScenarioRunStatus{
+				ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+					{
+						ProviderName: "krkn-operator",
+						ClusterName:  "cluster-1",
+						ClusterID:    krknv1alpha1.StableClusterID("krkn-operator", "target-1", "cluster-1"),
+						JobID:        "job-1",
+					},
+				},
+			},
+		},
+	}
+	for _, obj := range objects {
+		if err := handler.client.Create(ctx, obj); err != nil {
+			t.Fatalf("failed to create %T: %v", obj, err)
+		}
+	}
+}
+
+func metricsProxyRequest(cluster string, params url.Values) *http.Request {
+	path := ScenariosRunPath + "/run-1" + ScenarioRunClustersSegment + "/" + cluster + ClusterMetricsSuffix
+	return withAdminClaims(httptest.NewRequest(http.MethodGet, path+"?"+params.Encode(), nil))
+}
+
+func TestProxyClusterMetrics(t *testing.T) {
+	var received *http.Request
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+	}))
+	defer prometheus.Close()
+
+	handler := setupTestHandler()
+	setupMetricsProxyTarget(t, handler, prometheus)
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, metricsProxyRequest("cluster-1", url.Values{
+		"query": {`sum(rate(node_cpu_seconds_total[5m]))`},
+		"start": {"1700000000"},
+		"end":   {"1700003600"},
+		"step":  {"30s"},
+	}))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response PrometheusQueryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Status != "success" {
+		t.Fatalf("expected the Prometheus response to be relayed, got %s", w.Body.String())
+	}
+
+	if received == nil {
+		t.Fatal("expected the query to reach Prometheus")
+	}
+	if received.URL.Path != "/api/v1/query_range" {
+		t.Errorf("expected a range query, got %s", received.URL.Path)
+	}
+	if got := received.URL.Query().Get("step"); got != "30s" {
+		t.Errorf("expected step to be forwarded, got %q", got)
+	}
+	if got := received.Header.Get("Authorization"); got != "Bearer prom-token" {
+		t.Errorf("expected the target bearer token, got %q", got)
+	}
+}
+
+func TestProxyClusterMetrics_Rejections(t *testing.T) {
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected upstream query %s", r.URL)
+	}))
+	defer prometheus.Close()
+
+	handler := setupTestHandler()
+	setupMetricsProxyTarget(t, handler, prometheus)
+
+	tests := []struct {
+		name           string
+		cluster        string
+		query          string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"missing query", "cluster-1", "", http.StatusBadRequest, MsgFieldRequired},
+		{"metric not allowlisted", "cluster-1", `secret_metric_total`, http.StatusForbidden, MsgMetricsQueryNotAllowed},
+		{"invalid query", "cluster-1", `up{job="api}`, http.StatusBadRequest, MsgMetricsQueryInvalid},
+		{"cluster not in run", "cluster-2", `up`, http.StatusNotFound, MsgMetricsClusterNotInRun},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := url.Values{}
+			if tt.query != "" {
+				params.Set("query", tt.query)
+			}
+			w := httptest.NewRecorder()
+			serveAPI(handler, w, metricsProxyRequest(tt.cluster, params))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			var errResp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil || errResp.Code != tt.expectedCode {
+				t.Errorf("expected code %q, got %s", tt.expectedCode, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestProxyClusterMetrics_ClusterNameCollision(t *testing.T) {
+	var queried []string
+	prometheus := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queried = append(queried, name)
+			_, _ = w.Write([]byte(`{"status":"success"}`))
+		}))
+	}
+	active, archived := prometheus("active"), prometheus("archived")
+	defer active.Close()
+	defer archived.Close()
+
+	handler := setupTestHandler()
+	setupMetricsProxyTarget(t, handler, active)
+	ctx := context.Background()
+	// An archived target of the same name, and a run of a cluster of that name at
+	// another provider
+	archivedTarget := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-0", Namespace: handler.namespace},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{
+			UUID:        "target-0",
+			ClusterName: "cluster-1",
+			SecretUUID:  "secret-1",
+			Metrics:     &krknv1alpha1.TargetMetricsConfig{PrometheusURL: archived.URL + "/"},
+		},
+	}
+	if err := handler.client.Create(ctx, archivedTarget); err != nil {
+		t.Fatalf("failed to create target: %v", err)
+	}
+	archivedTarget.Status.Archived = true
+	if err := handler.client.Status().Update(ctx, archivedTarget); err != nil {
+		t.Fatalf("failed to archive target: %v", err)
+	}
+	otherProviderID := krknv1alpha1.StableClusterID("krkn-operator-capi", "0b6f3c2e", "cluster-1")
+	if err := handler.client.Create(ctx, &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-2", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ProviderName: "krkn-operator-capi", ClusterName: "cluster-1", ClusterID: otherProviderID, JobID: "job-2"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, metricsProxyRequest("cluster-1", url.Values{"query": {"up"}}))
+	if w.Code != http.StatusOK || !slices.Equal(queried, []string{"active"}) {
+		t.Fatalf("expected the Prometheus of the active target to be queried, got %d %v", w.Code, queried)
+	}
+
+	// The cluster of the other provider is not a KrknOperatorTarget of the same name
+	path := ScenariosRunPath + "/run-2" + ScenarioRunClustersSegment + "/cluster-1" + ClusterMetricsSuffix + "?query=up"
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, path, nil)))
+	if w.Code != http.StatusNotFound || len(queried) != 1 {
+		t.Fatalf("expected no metrics for the cluster of another provider, got %d %v", w.Code, queried)
+	}
+
+	// Clusters of two providers with the same name are told apart by their ID
+	var run krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(ctx, client.ObjectKey{Name: "run-1", Namespace: handler.namespace}, &run); err != nil {
+		t.Fatalf("failed to get run: %v", err)
+	}
+	run.Status.ClusterJobs = append(run.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{
+		ProviderName: "krkn-operator-capi", ClusterName: "cluster-1", ClusterID: otherProviderID, JobID: "job-3",
+	})
+	if err := handler.client.Update(ctx, &run); err != nil {
+		t.Fatalf("failed to update run: %v", err)
+	}
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, metricsProxyRequest("cluster-1", url.Values{"query": {"up"}}))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected an ambiguous cluster name to be rejected, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, metricsProxyRequest(run.Status.ClusterJobs[0].ClusterID, url.Values{"query": {"up"}}))
+	if w.Code != http.StatusOK || !slices.Equal(queried, []string{"active", "active"}) {
+		t.Fatalf("expected the cluster ID to select the target, got %d %v", w.Code, queried)
+	}
+}
+
+func TestProxyClusterMetrics_RateLimited(t *testing.T) {
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer prometheus.Close()
+
+	handler := setupTestHandler()
+	handler.metricsProxy = newMetricsProxy(MetricsProxyConfig{AllowedMetrics: []string{"up"}, RateLimit: 1})
+	setupMetricsProxyTarget(t, handler, prometheus)
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, metricsProxyRequest("cluster-1", url.Values{"query": {"up"}}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, metricsProxyRequest("cluster-1", url.Values{"query": {"up"}}))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+}
+
+func TestCreateTarget_WithMetrics(t *testing.T) {
+	handler := setupTestHandler()
+
+	reqBody := CreateTargetRequest{
+		ClusterName:   "metrics-cluster",
+		ClusterAPIURL: "https://api.metrics.example.com:6443",
+		SecretType:    "token",
+		Token:         "cluster-token",
+		Metrics: &TargetMetricsRequest{
+			PrometheusURL: "https://thanos-querier.example.com",
+			BearerToken:   "prom-token",
+		},
+	}
+	body, _ := json.Marshal(reqBody)
+	w := httptest.NewRecorder()
+	handler.CreateTarget(w, httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created CreateTargetResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	target, err := handler.fetchTarget(context.Background(), TargetUUID(created.UUID))
+	if err != nil {
+		t.Fatalf("failed to get created target: %v", err)
+	}
+	if target.Spec.Metrics == nil || target.Spec.Metrics.PrometheusURL != "https://thanos-querier.example.com" {
+		t.Fatalf("expected metrics configuration on the target, got %+v", target.Spec.Metrics)
+	}
+
+	var secret corev1.Secret
+	if err := handler.client.Get(context.Background(), client.ObjectKey{Name: target.Spec.SecretUUID, Namespace: handler.namespace}, &secret); err != nil {
+		t.Fatalf("failed to get target secret: %v", err)
+	}
+	if string(secret.Data[PrometheusTokenSecretKey]) != "prom-token" {
+		t.Errorf("expected the Prometheus token in the target secret")
+	}
+
+	reqBody.ClusterName = "metrics-cluster-2"
+	reqBody.ClusterAPIURL = "https://api.metrics-2.example.com:6443"
+	reqBody.Metrics.PrometheusURL = "thanos-querier:9091"
+	body, _ = json.Marshal(reqBody)
+	w = httptest.NewRecorder()
+	handler.CreateTarget(w, httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a URL without scheme, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRoutePattern(t *testing.T) {
+	router := setupTestHandler().Routes(noAuth).(*chi.Mux)
+
+	tests := []struct {
+		method string
+		path   string
+		route  string
+	}{
+		{http.MethodGet, OperatorTargetsPath, OperatorTargetsPath},
+		{http.MethodGet, OperatorTargetsPath + "/", OperatorTargetsPath},
+		{http.MethodGet, OperatorTargetsPath + "/abc-123", OperatorTargetsPath + "/{uuid}"},
+		{http.MethodGet, ScenariosRunPath + "/run-1/jobs/job-1/logs", ScenariosRunPath + "/{scenarioRunName}/jobs/{jobId}/logs"},
+		{http.MethodGet, "/api/v1/does-not-exist", unmatchedRoute},
+		{http.MethodPatch, OpenAPIPath, unmatchedRoute},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if got := routePattern(router, req); got != tt.route {
+			t.Errorf("%s %s: expected route %q, got %q", tt.method, tt.path, tt.route, got)
+		}
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	handler := setupTestHandler()
+	route := OperatorTargetsPath + "/{uuid}"
+
+	requests := apiRequestsTotal.WithLabelValues(route, "get", "404")
+	before := testutil.ToFloat64(requests)
+
+	for _, id := range []string{"missing-1", "missing-2"} {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, OperatorTargetsPath+"/"+id, nil)))
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	}
+
+	if got := testutil.ToFloat64(requests) - before; got != 2 {
+		t.Errorf("expected 2 requests counted for %s, got %v", route, got)
+	}
+	if testutil.CollectAndCount(apiRequestDuration, "krkn_api_request_duration_seconds") == 0 {
+		t.Errorf("expected a latency histogram for %s", route)
+	}
+	if got := testutil.ToFloat64(apiRequestsInFlight.WithLabelValues(route)); got != 0 {
+		t.Errorf("expected no requests in flight after completion, got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Environment variables configuring the default scenario namespace
+const (
+	// DefaultScenarioNamespaceEnv is the namespace regex set as NAMESPACE on runs of namespaced
+	// scenarios that leave it empty, which krkn would otherwise run against every namespace.
+	// The guardrail is disabled when empty.
+	DefaultScenarioNamespaceEnv = "API_DEFAULT_SCENARIO_NAMESPACE"
+	// NamespacedScenariosEnv is a comma-separated list of the scenarios the default namespace
+	// applies to, replacing defaultNamespacedScenarios
+	NamespacedScenariosEnv = "API_NAMESPACED_SCENARIOS"
+)
+
+// scenarioNamespaceEnvVar is the krkn-hub variable selecting the namespaces a scenario acts on
+const scenarioNamespaceEnvVar = "NAMESPACE"
+
+// defaultNamespacedScenarios are the krkn-hub scenarios whose targets are selected by NAMESPACE
+var defaultNamespacedScenarios = []string{
+	"pod-scenarios",
+	"container-scenarios",
+	"pod-network-chaos",
+	"application-outages",
+	"pvc-scenarios",
+	"time-scenarios",
+	"service-hijacking",
+	"service-disruption-scenarios",
+}
+
+// NamespaceGuardrail defaults the namespace of namespaced scenarios
+type NamespaceGuardrail struct {
+	// Namespace is the NAMESPACE regex injected, empty when the guardrail is disabled
+	Namespace string
+	// Scenarios are the scenario names the guardrail applies to
+	Scenarios []string
+}
+
+// NamespaceGuardrailFromEnv reads the default scenario namespace from the environment.
+// An invalid regex disables the guardrail.
+func NamespaceGuardrailFromEnv() NamespaceGuardrail {
+	guardrail := NamespaceGuardrail{
+		Namespace: os.Getenv(DefaultScenarioNamespaceEnv),
+		Scenarios: splitList(os.Getenv(NamespacedScenariosEnv)),
+	}
+	if len(guardrail.Scenarios) == 0 {
+		guardrail.Scenarios = defaultNamespacedScenarios
+	}
+	if _, err := regexp.Compile(guardrail.Namespace); err != nil {
+		log.Log.Error(err, "Invalid default scenario namespace, guardrail disabled",
+			"env", DefaultScenarioNamespaceEnv, "value", guardrail.Namespace)
+		guardrail.Namespace = ""
+	}
+	return guardrail
+}
+
+// apply returns the environment of a run of scenarioName with NAMESPACE set to the default
+// namespace when the request leaves it empty, and the namespace injected. allowAll is the
+// request's explicit opt-out. The request's map is never modified.
+func (g NamespaceGuardrail) apply(scenarioName string, environment map[string]string, allowAll bool) (map[string]string, string) {
+	if g.Namespace == "" || allowAll || !slices.Contains(g.Scenarios, scenarioName) {
+		return environment, ""
+	}
+	if environment[scenarioNamespaceEnvVar] != "" {
+		return environment, ""
+	}
+	defaulted := maps.Clone(environment)
+	if defaulted == nil {
+		defaulted = make(map[string]string, 1)
+	}
+	defaulted[scenarioNamespaceEnvVar] = g.Namespace
+	return defaulted, g.Namespace
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamespaceGuardrailFromEnv(t *testing.T) {
+	t.Setenv(DefaultScenarioNamespaceEnv, "^chaos-.*$")
+	t.Setenv(NamespacedScenariosEnv, "")
+	guardrail := NamespaceGuardrailFromEnv()
+	if guardrail.Namespace != "^chaos-.*$" || !reflect.DeepEqual(guardrail.Scenarios, defaultNamespacedScenarios) {
+		t.Errorf("unexpected guardrail: %+v", guardrail)
+	}
+
+	t.Setenv(NamespacedScenariosEnv, "pod-delete, custom")
+	if got := NamespaceGuardrailFromEnv().Scenarios; !reflect.DeepEqual(got, []string{"pod-delete", "custom"}) {
+		t.Errorf("expected the configured scenarios, got %v", got)
+	}
+
+	t.Setenv(DefaultScenarioNamespaceEnv, "chaos-(")
+	if got := NamespaceGuardrailFromEnv().Namespace; got != "" {
+		t.Errorf("expected an invalid regex to disable the guardrail, got %q", got)
+	}
+}
+
+func TestNamespaceGuardrailApply(t *testing.T) {
+	guardrail := NamespaceGuardrail{Namespace: "^chaos-.*$", Scenarios: []string{"pod-scenarios"}}
+
+	tests := []struct {
+		name          string
+		guardrail     NamespaceGuardrail
+		scenario      string
+		environment   map[string]string
+		allowAll      bool
+		wantNamespace string
+		wantDefaulted string
+	}{
+		{name: "namespace omitted", guardrail: guardrail, scenario: "pod-scenarios",
+			environment: map[string]string{"LABEL_SELECTOR": "app=web"}, wantNamespace: "^chaos-.*$", wantDefaulted: "^chaos-.*$"},
+		{name: "no environment", guardrail: guardrail, scenario: "pod-scenarios",
+			wantNamespace: "^chaos-.*$", wantDefaulted: "^chaos-.*$"},
+		{name: "empty namespace", guardrail: guardrail, scenario: "pod-scenarios",
+			environment: map[string]string{"NAMESPACE": ""}, wantNamespace: "^chaos-.*$", wantDefaulted: "^chaos-.*$"},
+		{name: "namespace set", guardrail: guardrail, scenario: "pod-scenarios",
+			environment: map[string]string{"NAMESPACE": "web"}, wantNamespace: "web"},
+		{name: "opt-out", guardrail: guardrail, scenario: "pod-scenarios", allowAll: true},
+		{name: "scenario without namespace", guardrail: guardrail, scenario: "node-scenarios"},
+		{name: "disabled", scenario: "pod-scenarios"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested, hasNamespace := tt.environment["NAMESPACE"]
+			environment, defaulted := tt.guardrail.apply(tt.scenario, tt.environment, tt.allowAll)
+			if environment["NAMESPACE"] != tt.wantNamespace || defaulted != tt.wantDefaulted {
+				t.Errorf("expected NAMESPACE %q defaulted %q, got %q and %q",
+					tt.wantNamespace, tt.wantDefaulted, environment["NAMESPACE"], defaulted)
+			}
+			if got, ok := tt.environment["NAMESPACE"]; got != requested || ok != hasNamespace {
+				t.Error("expected the request environment not to be modified")
+			}
+			if tt.environment != nil && environment["LABEL_SELECTOR"] != tt.environment["LABEL_SELECTOR"] {
+				t.Error("expected the other variables to be kept")
+			}
+		})
+	}
+}
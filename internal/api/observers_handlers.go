@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+)
+
+// errNotSubscribed is returned by the unsubscribe mutation when the user is not an observer
+var errNotSubscribed = errors.New("not subscribed")
+
+// SubscribeScenarioRun handles POST /api/v1/scenarios/run/{scenarioRunName}/subscribe
+// The authenticated user becomes an observer of the run and receives a notification
+// on the given channel every time the run changes phase. Subscribing again replaces
+// the previous channel.
+func (h *Handler) SubscribeScenarioRun(w http.ResponseWriter, r *http.Request) {
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+
+	var req SubscribeScenarioRunRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	if err := req.Channel.Validate(h.notifyPolicy); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidChannel,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	claims := auth.GetClaimsFromContext(r.Context())
+	if claims == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
+		return
+	}
+
+	subscription := notify.Subscription{
+		UserID:       claims.UserID,
+		Channel:      req.Channel,
+		SubscribedAt: time.Now().UTC(),
+	}
+	h.updateObservers(w, r, scenarioRunName, claims.UserID, true, func(run *krknv1alpha1.KrknScenarioRun) error {
+		return notify.Subscribe(run, subscription)
+	})
+}
+
+// UnsubscribeScenarioRun handles POST /api/v1/scenarios/run/{scenarioRunName}/unsubscribe
+// Users remove their own subscription; admins may pass ?userId= to remove someone else.
+func (h *Handler) UnsubscribeScenarioRun(w http.ResponseWriter, r *http.Request) {
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+
+	claims := auth.GetClaimsFromContext(r.Context())
+	if claims == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
+		return
+	}
+
+	userID := claims.UserID
+	if other := r.URL.Query().Get("userId"); other != "" && other != userID {
+		if !auth.IsAdmin(r.Context()) {
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
+			return
+		}
+		userID = other
+	}
+
+	h.updateObservers(w, r, scenarioRunName, userID, false, func(run *krknv1alpha1.KrknScenarioRun) error {
+		removed, err := notify.Unsubscribe(run, userID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return errNotSubscribed
+		}
+		return nil
+	})
+}
+
+// updateObservers applies mutate to the observers annotation of a scenario run,
+// retrying on conflicts, after checking the caller can view the run.
+func (h *Handler) updateObservers(
+	w http.ResponseWriter,
+	r *http.Request,
+	scenarioRunName ScenarioRunName,
+	userID string,
+	subscribed bool,
+	mutate func(run *krknv1alpha1.KrknScenarioRun) error,
+) {
+	ctx := r.Context()
+	key := client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+		h.writeScenarioRunFetchError(w, r, string(scenarioRunName), err)
+		return
+	}
+	if !h.checkScenarioRunAccess(w, r, &scenarioRun) {
+		return
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+			return err
+		}
+		if err := mutate(&scenarioRun); err != nil {
+			return err
+		}
+		return h.client.Update(ctx, &scenarioRun)
+	})
+	if errors.Is(err, errNotSubscribed) {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgNotSubscribed, i18n.Params{"userId": userID})
+		return
+	}
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgObserversUpdateFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	subs, _ := notify.Subscriptions(&scenarioRun)
+	writeJSON(w, http.StatusOK, ScenarioRunObserversResponse{
+		ScenarioRunName: scenarioRun.Name,
+		UserID:          userID,
+		Subscribed:      subscribed,
+		Observers:       len(subs),
+	})
+}
+
+// writeScenarioRunFetchError maps a Get error on a scenario run to an HTTP response
+func (h *Handler) writeScenarioRunFetchError(w http.ResponseWriter, r *http.Request, scenarioRunName string, err error) {
+	if client.IgnoreNotFound(err) == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+			i18n.Params{"name": scenarioRunName})
+		return
+	}
+	writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+		i18n.Params{"error": err.Error()})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+)
+
+func TestScenarioRunObservers_SubscribeAndUnsubscribe(t *testing.T) {
+	handler := setupTestHandler()
+	run := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "test-namespace"},
+		Spec:       krknv1alpha1.KrknScenarioRunSpec{ScenarioName: "pod-scenarios"},
+	}
+	if err := handler.client.Create(context.Background(), run); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+
+	body, _ := json.Marshal(SubscribeScenarioRunRequest{
+		Channel: notify.Channel{Type: notify.ChannelWebhook, URL: "https://hooks.example.com/krkn"},
+	})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/run-1"+ScenarioRunSubscribeSuffix, bytes.NewReader(body))
+	serveAPI(handler, w, withAdminClaims(req))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ScenarioRunObserversResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Subscribed || resp.Observers != 1 || resp.UserID != "admin@example.com" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	var stored krknv1alpha1.KrknScenarioRun
+	_ = handler.client.Get(context.Background(), client.ObjectKey{Name: "run-1", Namespace: "test-namespace"}, &stored)
+	subs, err := notify.Subscriptions(&stored)
+	if err != nil || len(subs) != 1 || subs[0].Channel.URL != "https://hooks.example.com/krkn" {
+		t.Fatalf("expected stored subscription, got %+v (err=%v)", subs, err)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/run-1"+ScenarioRunUnsubscribeSuffix, nil)
+	serveAPI(handler, w, withAdminClaims(req))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 on unsubscribe, got %d: %s", w.Code, w.Body.String())
+	}
+
+	_ = handler.client.Get(context.Background(), client.ObjectKey{Name: "run-1", Namespace: "test-namespace"}, &stored)
+	if _, ok := stored.Annotations[notify.ObserversAnnotation]; ok {
+		t.Error("expected observers annotation to be removed")
+	}
+
+	// Unsubscribing twice reports the user is not subscribed
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/run-1"+ScenarioRunUnsubscribeSuffix, nil)
+	serveAPI(handler, w, withAdminClaims(req))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestScenarioRunObservers_Validation(t *testing.T) {
+	handler := setupTestHandler()
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid channel type",
+			path:       ScenariosRunPath + "/run-1" + ScenarioRunSubscribeSuffix,
+			body:       `{"channel":{"type":"pager","url":"https://example.com"}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "relative channel url",
+			path:       ScenariosRunPath + "/run-1" + ScenarioRunSubscribeSuffix,
+			body:       `{"channel":{"type":"webhook","url":"/hook"}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown run",
+			path:       ScenariosRunPath + "/missing" + ScenarioRunSubscribeSuffix,
+			body:       `{"channel":{"type":"slack","url":"https://hooks.slack.com/services/x"}}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			serveAPI(handler, w, withAdminClaims(req))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
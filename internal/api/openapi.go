@@ -0,0 +1,595 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/usage"
+)
+
+// apiParam is a query parameter accepted by an operation
+type apiParam struct {
+	Name        string
+	Type        string
+	Description string
+	// Pattern restricts string values, e.g. IDPattern for IDs
+	Pattern string
+}
+
+// refreshParam bypasses the registry cache of the scenario endpoints
+var refreshParam = apiParam{Name: QueryRefresh, Type: "boolean", Description: "Call the registry instead of answering from the cache"}
+
+// apiOperation describes one method+path of the REST API for the OpenAPI spec.
+// Request and Response are zero values of the types in types.go; nil means no body.
+type apiOperation struct {
+	Method   string
+	Path     string
+	Tag      string
+	Summary  string
+	Public   bool
+	Admin    bool
+	Query    []apiParam
+	Request  any
+	Status   int
+	Response any
+	// ContentType of the success response, application/json when empty
+	ContentType string
+}
+
+var (
+	limitParam    = apiParam{Name: "limit", Type: "integer", Description: "Maximum number of items to return"}
+	continueParam = apiParam{Name: "continue", Type: "string", Description: "Token returned by the previous page"}
+	orderParam    = apiParam{Name: "order", Type: "string", Description: "Sort order: asc or desc"}
+)
+
+// apiOperations is the source of the generated OpenAPI document.
+// Keep it in sync with the routes registered in Routes.
+var apiOperations = []apiOperation{
+	// Auth
+	{Method: http.MethodGet, Path: AuthIsRegistered, Tag: "auth", Summary: "Check whether an admin user is registered", Public: true,
+		Status: http.StatusOK, Response: IsRegisteredResponse{}},
+	{Method: http.MethodPost, Path: AuthRegister, Tag: "auth", Summary: "Register a user", Public: true,
+		Request: RegisterRequest{}, Status: http.StatusCreated, Response: RegisterResponse{}},
+	{Method: http.MethodPost, Path: AuthLogin, Tag: "auth", Summary: "Log in and obtain a JWT", Public: true,
+		Request: LoginRequest{}, Status: http.StatusOK, Response: LoginResponse{}},
+
+	// Core
+	{Method: http.MethodGet, Path: HealthPath, Tag: "core", Summary: "Liveness check (deprecated alias of /api/v1/healthz)", Public: true,
+		Status: http.StatusOK, Response: map[string]string{}},
+	{Method: http.MethodGet, Path: HealthzPath, Tag: "core", Summary: "Liveness check", Public: true,
+		Status: http.StatusOK, Response: map[string]string{}},
+	{Method: http.MethodGet, Path: ReadyzPath, Tag: "core", Summary: "Readiness check, the checks of the operator readiness probe", Public: true,
+		Status: http.StatusOK, Response: ReadinessResponse{}},
+	{Method: http.MethodGet, Path: ClustersPath, Tag: "core", Summary: "Get clusters of a target request",
+		Query:  []apiParam{{Name: "id", Type: "string", Description: "Target request UUID", Pattern: IDPattern}},
+		Status: http.StatusOK, Response: ClustersResponse{}},
+	{Method: http.MethodGet, Path: ClusterIDsPath, Tag: "core", Summary: "Map the clusters of a target request to their stable IDs",
+		Query: []apiParam{
+			{Name: "id", Type: "string", Description: "Target request UUID", Pattern: IDPattern},
+			{Name: "provider", Type: "string", Description: "Filter by provider name"},
+			{Name: "cluster-name", Type: "string", Description: "Filter by cluster name"},
+			{Name: "clusterId", Type: "string", Description: "Filter by stable cluster ID"},
+		},
+		Status: http.StatusOK, Response: ClusterIDsResponse{}},
+	{Method: http.MethodGet, Path: NodesPath, Tag: "core", Summary: "Get nodes of a target cluster",
+		Query: []apiParam{
+			{Name: "targetUUID", Type: "string", Description: "Target UUID", Pattern: IDPattern},
+			{Name: "id", Type: "string", Description: "Target request UUID (legacy, with cluster-name)", Pattern: IDPattern},
+			{Name: "cluster-name", Type: "string", Description: "Cluster name"},
+		},
+		Status: http.StatusOK, Response: NodesResponse{}},
+	{Method: http.MethodPost, Path: TargetsPath, Tag: "core", Summary: "Create a target request (legacy)",
+		Request: TargetRequestCreateRequest{}, Status: http.StatusAccepted, Response: map[string]string{}},
+	{Method: http.MethodGet, Path: TargetsPath + "/{uuid}", Tag: "core", Summary: "Get target request status (legacy)",
+		Status: http.StatusOK},
+	{Method: http.MethodGet, Path: DiagnosticsPath, Tag: "core", Summary: "Operator diagnostics",
+		Status: http.StatusOK, Response: DiagnosticsResponse{}},
+	{Method: http.MethodGet, Path: AuditPath, Tag: "core", Summary: "Query the audit log", Admin: true,
+		Query: []apiParam{
+			{Name: "user", Type: "string", Description: "Filter by user ID"},
+			{Name: "action", Type: "string", Description: "Filter by action"},
+			{Name: "resource", Type: "string", Description: "Filter by resource path prefix"},
+			{Name: "result", Type: "string", Description: "Filter by result"},
+			{Name: "since", Type: "string", Description: "RFC3339 lower bound"},
+			limitParam,
+		},
+		Status: http.StatusOK, Response: AuditLogResponse{}},
+	{Method: http.MethodGet, Path: HistoryPath, Tag: "core", Summary: "Query the run history (run history enabled)",
+		Query: []apiParam{
+			{Name: "scenarioName", Type: "string", Description: "Filter by scenario"},
+			{Name: "phase", Type: "string", Description: "Filter by final phase"},
+			{Name: "owner", Type: "string", Description: "Filter by owner user ID (admin only)"},
+			{Name: "since", Type: "string", Description: "RFC3339 lower bound on creation time"},
+			{Name: "until", Type: "string", Description: "RFC3339 upper bound on creation time"},
+			limitParam,
+		},
+		Status: http.StatusOK, Response: RunHistoryResponse{}},
+	{Method: http.MethodGet, Path: HistoryPath + "/{scenarioRunName}", Tag: "core", Summary: "Get the recorded outcome of a run",
+		Status: http.StatusOK, Response: history.Run{}},
+	{Method: http.MethodGet, Path: UsagePath, Tag: "core", Summary: "Anonymous usage aggregate (usage telemetry enabled)",
+		Admin: true, Status: http.StatusOK, Response: usage.Report{}},
+	{Method: http.MethodGet, Path: DocsStateMachinePath, Tag: "core", Summary: "Job phase state machine", Public: true,
+		Query:  []apiParam{{Name: "format", Type: "string", Description: "mermaid returns only the diagram as text"}},
+		Status: http.StatusOK, Response: StateMachineResponse{}},
+
+	// Scenarios
+	{Method: http.MethodPost, Path: ScenariosPath, Tag: "scenarios", Summary: "List available scenarios",
+		Query:   []apiParam{refreshParam},
+		Request: ScenariosRequest{}, Status: http.StatusOK, Response: ScenariosResponse{}},
+	{Method: http.MethodPost, Path: ScenariosDetailPath + "/{scenarioName}", Tag: "scenarios", Summary: "Get scenario input fields",
+		Query:   []apiParam{refreshParam},
+		Request: ScenariosRequest{}, Status: http.StatusOK, Response: ScenarioDetailResponse{}},
+	{Method: http.MethodPost, Path: ScenariosGlobalsPath + "/{scenarioName}", Tag: "scenarios", Summary: "Get scenario global input fields",
+		Query:   []apiParam{refreshParam},
+		Request: ScenariosRequest{}, Status: http.StatusOK, Response: ScenarioDetailResponse{}},
+	{Method: http.MethodGet, Path: ScenariosFavoritesPath, Tag: "scenarios", Summary: "List the scenarios the user starred with their catalog data",
+		Query: []apiParam{
+			{Name: "registryRef", Type: "string", Description: "KrknRegistry to read the catalog from, quay.io when unset"},
+			refreshParam,
+		},
+		Status: http.StatusOK, Response: FavoriteScenariosResponse{}},
+	{Method: http.MethodPut, Path: ScenariosFavoritesPath + "/{scenarioName}", Tag: "scenarios", Summary: "Star a scenario",
+		Status: http.StatusOK, Response: FavoriteScenariosResponse{}},
+	{Method: http.MethodDelete, Path: ScenariosFavoritesPath + "/{scenarioName}", Tag: "scenarios", Summary: "Unstar a scenario",
+		Status: http.StatusOK, Response: FavoriteScenariosResponse{}},
+
+	// Scenario runs
+	{Method: http.MethodPost, Path: ScenariosRunPath, Tag: "scenario-runs", Summary: "Start a scenario run",
+		Request: ScenarioRunRequest{}, Status: http.StatusCreated, Response: ScenarioRunCreateResponse{}},
+	{Method: http.MethodPost, Path: ScenariosRunLintPath, Tag: "scenario-runs",
+		Summary: "Check a scenario run request against best practices, without starting it",
+		Request: ScenarioRunRequest{}, Status: http.StatusOK, Response: ScenarioRunLintResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunPath, Tag: "scenario-runs", Summary: "List scenario runs",
+		Query: []apiParam{
+			{Name: "phase", Type: "string", Description: "Filter by phase"},
+			{Name: "scenarioName", Type: "string", Description: "Filter by scenario name"},
+			{Name: "campaign", Type: "string", Description: "Filter by campaign"},
+			{Name: "createdAfter", Type: "string", Description: "RFC3339 lower bound on creation time"},
+			{Name: "createdBefore", Type: "string", Description: "RFC3339 upper bound on creation time"},
+			{Name: "sort", Type: "string", Description: "Sort field: createdAt or startTime"},
+			orderParam, limitParam, continueParam,
+		},
+		Status: http.StatusOK, Response: ScenarioRunListResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunPath + "/{scenarioRunName}", Tag: "scenario-runs", Summary: "Get scenario run status",
+		Status: http.StatusOK, Response: ScenarioRunStatusResponse{}},
+	{Method: http.MethodDelete, Path: ScenariosRunPath + "/{scenarioRunName}", Tag: "scenario-runs",
+		Summary: "Cancel an active scenario run, or delete a finished one (204)",
+		Query:   []apiParam{{Name: "force", Type: "boolean", Description: "Delete the run right away instead of cancelling it"}},
+		Status:  http.StatusAccepted, Response: ScenarioRunCancelResponse{}},
+	{Method: http.MethodPost, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunSubscribeSuffix, Tag: "scenario-runs",
+		Summary: "Observe a scenario run", Request: SubscribeScenarioRunRequest{},
+		Status: http.StatusOK, Response: ScenarioRunObserversResponse{}},
+	{Method: http.MethodPost, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunUnsubscribeSuffix, Tag: "scenario-runs",
+		Summary: "Stop observing a scenario run",
+		Query:   []apiParam{{Name: "userId", Type: "string", Description: "Observer to remove (admin only)"}},
+		Status:  http.StatusOK, Response: ScenarioRunObserversResponse{}},
+	{Method: http.MethodPost, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunRetryFailedSuffix, Tag: "scenario-runs",
+		Summary: "Retry the failed clusters of a completed scenario run", Request: RetryFailedRequest{},
+		Status: http.StatusAccepted, Response: RetryWaveResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunResultsSuffix, Tag: "scenario-runs",
+		Summary: "Get the krkn results (exit code, scenario outcomes, failed checks) of the cluster jobs",
+		Status:  http.StatusOK, Response: ScenarioRunResultsResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunReportSuffix, Tag: "scenario-runs",
+		Summary: "Export the report of a scenario run (parameters, per-cluster outcomes, log and artifact links)",
+		Query:   []apiParam{{Name: "format", Type: "string", Description: "json (default) or html for a self-contained page"}},
+		Status:  http.StatusOK, Response: ScenarioRunReport{}},
+	{Method: http.MethodGet, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunClustersSegment + "/{clusterName}" + ClusterEffectiveSpecSuffix,
+		Tag: "scenario-runs", Summary: "Show the environment, image, files and pod spec the cluster job was created with",
+		Status: http.StatusOK, Response: EffectiveSpecResponse{}},
+	{Method: http.MethodDelete, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunClustersSegment + "/{clusterName}",
+		Tag: "scenario-runs", Summary: "Cancel the job of one cluster of a scenario run",
+		Status: http.StatusAccepted, Response: ClusterJobStatusResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunPath + "/{scenarioRunName}" + ScenarioRunClustersSegment + "/{clusterName}" + ClusterMetricsSuffix,
+		Tag: "scenario-runs", Summary: "Query the Prometheus of a target cluster of the run",
+		Query: []apiParam{
+			{Name: "query", Type: "string", Description: "PromQL expression, only allowlisted metrics may be referenced"},
+			{Name: "start", Type: "string", Description: "Range query start (RFC3339 or Unix timestamp)"},
+			{Name: "end", Type: "string", Description: "Range query end (RFC3339 or Unix timestamp)"},
+			{Name: "step", Type: "string", Description: "Range query resolution, e.g. 30s"},
+			{Name: "time", Type: "string", Description: "Instant query evaluation time"},
+		},
+		Status: http.StatusOK, Response: PrometheusQueryResponse{}},
+	{Method: http.MethodPost, Path: BreakGlassPath, Tag: "scenario-runs",
+		Summary: "Issue a time-boxed exemption letting a restricted scenario run on the given clusters", Admin: true,
+		Request: BreakGlassRequest{}, Status: http.StatusCreated, Response: BreakGlassResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunJobsPath + "/{jobId}", Tag: "scenario-runs", Summary: "Get a single job",
+		Status: http.StatusOK, Response: ClusterJobStatusResponse{}},
+	{Method: http.MethodGet, Path: ScenariosRunJobsPath + "/{jobId}" + JobEventsSuffix, Tag: "scenario-runs",
+		Summary: "Stream job status changes as Server-Sent Events",
+		Status:  http.StatusOK, Response: JobStatusEvent{}, ContentType: "text/event-stream"},
+	{Method: http.MethodDelete, Path: ScenariosRunJobsPath + "/{jobId}", Tag: "scenario-runs", Summary: "Cancel a single job",
+		Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: DashboardActiveRunsPath, Tag: "scenario-runs", Summary: "Active runs overview",
+		Status: http.StatusOK, Response: ActiveRunsOverviewResponse{}},
+
+	// Campaigns
+	{Method: http.MethodGet, Path: CampaignsPath, Tag: "campaigns", Summary: "List campaigns with their progress",
+		Status: http.StatusOK, Response: ListCampaignsResponse{}},
+	{Method: http.MethodPost, Path: CampaignsPath, Tag: "campaigns", Summary: "Create a campaign",
+		Request: CreateCampaignRequest{}, Status: http.StatusCreated, Response: CampaignResponse{}},
+	{Method: http.MethodGet, Path: CampaignsPath + "/{campaignName}", Tag: "campaigns", Summary: "Get a campaign with its progress",
+		Status: http.StatusOK, Response: CampaignResponse{}},
+	{Method: http.MethodPatch, Path: CampaignsPath + "/{campaignName}", Tag: "campaigns", Summary: "Update a campaign (facilitator or admin)",
+		Request: UpdateCampaignRequest{}, Status: http.StatusOK, Response: CampaignResponse{}},
+	{Method: http.MethodDelete, Path: CampaignsPath + "/{campaignName}", Tag: "campaigns", Summary: "Delete a campaign (facilitator or admin)",
+		Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: CampaignsPath + "/{campaignName}" + CampaignReportSuffix, Tag: "campaigns",
+		Summary: "Report on the runs of a campaign",
+		Status:  http.StatusOK, Response: CampaignReportResponse{}},
+
+	// Users
+	{Method: http.MethodGet, Path: UsersPath, Tag: "users", Summary: "List users", Admin: true,
+		Query: []apiParam{
+			{Name: "role", Type: "string", Description: "Filter by role"},
+			{Name: "active", Type: "boolean", Description: "Filter by active flag"},
+			{Name: "search", Type: "string", Description: "Search by user ID, name or surname"},
+			{Name: "page", Type: "integer", Description: "Page number"},
+			limitParam,
+		},
+		Status: http.StatusOK, Response: ListUsersResponse{}},
+	{Method: http.MethodPost, Path: UsersPath, Tag: "users", Summary: "Create a user", Admin: true,
+		Request: CreateUserRequest{}, Status: http.StatusCreated, Response: CreateUserResponse{}},
+	{Method: http.MethodGet, Path: UsersPath + "/{userId}", Tag: "users", Summary: "Get a user",
+		Status: http.StatusOK, Response: UserResponse{}},
+	{Method: http.MethodPatch, Path: UsersPath + "/{userId}", Tag: "users", Summary: "Update a user",
+		Request: UpdateUserRequest{}, Status: http.StatusOK, Response: UpdateUserResponse{}},
+	{Method: http.MethodDelete, Path: UsersPath + "/{userId}", Tag: "users", Summary: "Delete a user", Admin: true,
+		Status: http.StatusOK, Response: DeleteUserResponse{}},
+	{Method: http.MethodPatch, Path: UsersPath + "/{userId}/password", Tag: "users", Summary: "Change password",
+		Request: ChangePasswordRequest{}, Status: http.StatusOK, Response: ChangePasswordResponse{}},
+
+	// Groups
+	{Method: http.MethodGet, Path: GroupsPath, Tag: "groups", Summary: "List user groups", Admin: true,
+		Status: http.StatusOK, Response: ListUserGroupsResponse{}},
+	{Method: http.MethodPost, Path: GroupsPath, Tag: "groups", Summary: "Create a user group", Admin: true,
+		Request: CreateUserGroupRequest{}, Status: http.StatusCreated, Response: CreateUserGroupResponse{}},
+	{Method: http.MethodGet, Path: GroupsPath + "/{groupName}", Tag: "groups", Summary: "Get a user group", Admin: true,
+		Status: http.StatusOK, Response: UserGroupResponse{}},
+	{Method: http.MethodPatch, Path: GroupsPath + "/{groupName}", Tag: "groups", Summary: "Update a user group", Admin: true,
+		Request: UpdateUserGroupRequest{}, Status: http.StatusOK, Response: UpdateUserGroupResponse{}},
+	{Method: http.MethodDelete, Path: GroupsPath + "/{groupName}", Tag: "groups", Summary: "Delete a user group", Admin: true,
+		Status: http.StatusOK, Response: DeleteUserGroupResponse{}},
+	{Method: http.MethodGet, Path: GroupsPath + "/{groupName}/members", Tag: "groups", Summary: "List group members", Admin: true,
+		Status: http.StatusOK, Response: ListGroupMembersResponse{}},
+	{Method: http.MethodPost, Path: GroupsPath + "/{groupName}/members", Tag: "groups", Summary: "Add a group member", Admin: true,
+		Request: AddGroupMemberRequest{}, Status: http.StatusOK, Response: AddGroupMemberResponse{}},
+	{Method: http.MethodDelete, Path: GroupsPath + "/{groupName}/members/{userId}", Tag: "groups", Summary: "Remove a group member", Admin: true,
+		Status: http.StatusOK, Response: RemoveGroupMemberResponse{}},
+
+	// Providers
+	{Method: http.MethodGet, Path: ProvidersPath, Tag: "providers", Summary: "List providers",
+		Status: http.StatusOK, Response: ListProvidersResponse{}},
+	{Method: http.MethodPatch, Path: ProvidersPath + "/{name}", Tag: "providers", Summary: "Activate or deactivate a provider", Admin: true,
+		Request: UpdateProviderStatusRequest{}, Status: http.StatusOK, Response: UpdateProviderStatusResponse{}},
+	{Method: http.MethodPost, Path: ProviderVerifyPath, Tag: "providers", Summary: "Verify the contract conformance of providers", Admin: true,
+		Request: ProviderVerifyRequest{}, Status: http.StatusOK, Response: ProviderVerifyResponse{}},
+	{Method: http.MethodPost, Path: ProviderConfigPath, Tag: "providers", Summary: "Request provider configuration", Admin: true,
+		Status: http.StatusProcessing, Response: map[string]string{}},
+	{Method: http.MethodGet, Path: ProviderConfigPath + "/{uuid}", Tag: "providers", Summary: "Get provider configuration",
+		Status: http.StatusOK, Response: map[string]any{}},
+	{Method: http.MethodPost, Path: ProviderConfigPath + "/{uuid}", Tag: "providers", Summary: "Update provider configuration values", Admin: true,
+		Request: ProviderConfigUpdateRequest{}, Status: http.StatusOK, Response: ProviderConfigUpdateResponse{}},
+
+	// Operator targets
+	{Method: http.MethodGet, Path: OperatorTargetsPath, Tag: "targets", Summary: "List targets",
+		Query: []apiParam{
+			{Name: "includeArchived", Type: "boolean", Description: "Include archived targets"},
+			{Name: "secretType", Type: "string", Description: "Filter by secret type"},
+			{Name: "ready", Type: "boolean", Description: "Filter by readiness"},
+			{Name: "sort", Type: "string", Description: "Sort field: createdAt or clusterName"},
+			orderParam, limitParam, continueParam,
+		},
+		Status: http.StatusOK, Response: ListTargetsResponse{}},
+	{Method: http.MethodPost, Path: OperatorTargetsPath, Tag: "targets", Summary: "Create a target", Admin: true,
+		Request: CreateTargetRequest{}, Status: http.StatusCreated, Response: CreateTargetResponse{}},
+	{Method: http.MethodPost, Path: OperatorTargetsPath + TargetImportSuffix, Tag: "targets", Summary: "Import a target per kubeconfig context", Admin: true,
+		Request: ImportTargetsRequest{}, Status: http.StatusCreated, Response: ImportTargetsResponse{}},
+	{Method: http.MethodGet, Path: OperatorTargetsPath + "/{uuid}", Tag: "targets", Summary: "Get a target",
+		Status: http.StatusOK, Response: TargetResponse{}},
+	{Method: http.MethodPut, Path: OperatorTargetsPath + "/{uuid}", Tag: "targets", Summary: "Update a target", Admin: true,
+		Request: UpdateTargetRequest{}, Status: http.StatusOK, Response: CreateTargetResponse{}},
+	{Method: http.MethodDelete, Path: OperatorTargetsPath + "/{uuid}", Tag: "targets", Summary: "Archive a target", Admin: true,
+		Status: http.StatusOK, Response: CreateTargetResponse{}},
+	{Method: http.MethodPost, Path: OperatorTargetsPath + "/{uuid}" + TargetRestoreSuffix, Tag: "targets", Summary: "Restore an archived target", Admin: true,
+		Status: http.StatusOK, Response: CreateTargetResponse{}},
+	{Method: http.MethodPost, Path: OperatorTargetsPath + "/{uuid}" + TargetRotateSuffix, Tag: "targets", Summary: "Rotate the token of a token target", Admin: true,
+		Status: http.StatusOK, Response: RotateTargetTokenResponse{}},
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// idPathParams are the path parameters parsed with ParseID
+var idPathParams = map[string]bool{ParamUUID: true, ParamScenarioRunName: true, ParamJobID: true}
+
+// schemaBuilder derives OpenAPI schemas from Go types by reflection.
+// Named struct types become components and are referenced with $ref.
+type schemaBuilder struct {
+	schemas map[string]any
+	names   map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		schemas: map[string]any{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	metaTimeType     = reflect.TypeOf(metav1.Time{})
+	metaDurationType = reflect.TypeOf(metav1.Duration{})
+)
+
+// schemaFor returns the schema (or $ref) describing t
+func (b *schemaBuilder) schemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType, metaTimeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case metaDurationType:
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + b.register(t)}
+	default:
+		// interface{} and anything else: free-form
+		return map[string]any{}
+	}
+}
+
+// register adds a named struct to the components, returning its component name
+func (b *schemaBuilder) register(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := b.schemas[name]; taken {
+		// Same type name from another package: qualify with the package name
+		parts := strings.Split(t.PkgPath(), "/")
+		name = parts[len(parts)-1] + "." + name
+	}
+	b.names[t] = name
+	b.schemas[name] = map[string]any{} // placeholder, breaks recursion
+	b.schemas[name] = b.structSchema(t)
+	return name
+}
+
+// structSchema describes a struct's JSON fields; embedded structs are flattened
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	b.collectProperties(t, properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (b *schemaBuilder) collectProperties(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.collectProperties(embedded, properties, required)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = b.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// buildOpenAPISpec generates the OpenAPI 3 document for apiOperations
+func buildOpenAPISpec() map[string]any {
+	builder := newSchemaBuilder()
+	errorRef := builder.schemaFor(reflect.TypeOf(ErrorResponse{}))
+
+	paths := map[string]any{}
+	for _, op := range apiOperations {
+		operation := map[string]any{
+			"summary":     op.Summary,
+			"tags":        []string{op.Tag},
+			"operationId": operationID(op),
+		}
+		if op.Admin {
+			operation["description"] = "Requires the admin role."
+		}
+		if !op.Public {
+			operation["security"] = []map[string][]string{{"bearerAuth": {}}}
+		}
+
+		var parameters []map[string]any
+		for _, match := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+			schema := map[string]any{"type": "string"}
+			if idPathParams[match[1]] {
+				schema["pattern"] = IDPattern
+			}
+			parameters = append(parameters, map[string]any{
+				"name": match[1], "in": "path", "required": true,
+				"schema": schema,
+			})
+		}
+		for _, param := range op.Query {
+			schema := map[string]any{"type": param.Type}
+			if param.Pattern != "" {
+				schema["pattern"] = param.Pattern
+			}
+			parameters = append(parameters, map[string]any{
+				"name": param.Name, "in": "query", "description": param.Description,
+				"schema": schema,
+			})
+		}
+		if op.Request != nil {
+			parameters = append(parameters, map[string]any{
+				"name": QueryStrict, "in": "query",
+				"description": "Reject unknown request body fields (true) or ignore them (false), overriding the server default",
+				"schema":      map[string]any{"type": "boolean"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if op.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": builder.schemaFor(reflect.TypeOf(op.Request))},
+				},
+			}
+		}
+
+		success := map[string]any{"description": http.StatusText(op.Status)}
+		if op.Response != nil {
+			contentType := op.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			success["content"] = map[string]any{
+				contentType: map[string]any{"schema": builder.schemaFor(reflect.TypeOf(op.Response))},
+			}
+		}
+		operation["responses"] = map[string]any{
+			strconv.Itoa(op.Status): success,
+			"default": map[string]any{
+				"description": "Error",
+				"content":     map[string]any{"application/json": map[string]any{"schema": errorRef}},
+			},
+		}
+
+		item, _ := paths[op.Path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[op.Path] = item
+		}
+		item[strings.ToLower(op.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "krkn-operator API",
+			"version":     APIVersion,
+			"description": "REST API of the krkn-operator. Generated from the request and response types of the server.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": builder.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+// operationID builds a stable identifier such as "get_operator_targets_uuid"
+func operationID(op apiOperation) string {
+	path := strings.TrimPrefix(op.Path, APIBasePath+"/")
+	path = strings.NewReplacer("{", "", "}", "", "/", "_", "-", "_").Replace(path)
+	return strings.ToLower(op.Method) + "_" + path
+}
+
+var (
+	openAPISpecOnce sync.Once
+	openAPISpecJSON []byte
+	openAPISpecErr  error
+)
+
+// GetOpenAPISpec handles GET /api/v1/openapi.json endpoint
+// The document is generated once from apiOperations and the types in types.go.
+func (h *Handler) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgOnlyMethodAllowed,
+			i18n.Params{"method": http.MethodGet})
+		return
+	}
+
+	openAPISpecOnce.Do(func() {
+		openAPISpecJSON, openAPISpecErr = json.MarshalIndent(buildOpenAPISpec(), "", "  ")
+	})
+	if openAPISpecErr != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgOpenAPIFailed,
+			i18n.Params{"error": openAPISpecErr.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openAPISpecJSON)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetOpenAPISpec(t *testing.T) {
+	handler := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	handler.GetOpenAPISpec(w, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var spec struct {
+		OpenAPI    string                               `json:"openapi"`
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("expected OpenAPI 3 document, got %q", spec.OpenAPI)
+	}
+
+	createTarget := spec.Paths[OperatorTargetsPath]["post"]
+	if createTarget == nil {
+		t.Fatal("missing POST operation for operator targets")
+	}
+	if _, ok := createTarget["security"]; !ok {
+		t.Error("authenticated operations must declare bearer security")
+	}
+	if _, ok := spec.Paths[AuthLogin]["post"]["security"]; ok {
+		t.Error("login must be public")
+	}
+
+	params, _ := spec.Paths[OperatorTargetsPath+"/{uuid}"]["get"]["parameters"].([]any)
+	if len(params) != 1 || params[0].(map[string]any)["in"] != "path" {
+		t.Errorf("expected uuid path parameter, got %v", params)
+	}
+
+	errorSchema := spec.Components.Schemas["ErrorResponse"]
+	props, _ := errorSchema["properties"].(map[string]any)
+	if _, ok := props["code"]; !ok {
+		t.Errorf("ErrorResponse schema missing code property: %v", errorSchema)
+	}
+
+	// Every $ref must resolve to a component
+	for _, ref := range findRefs(w.Body.String()) {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if _, ok := spec.Components.Schemas[name]; !ok {
+			t.Errorf("unresolved $ref %s", ref)
+		}
+	}
+}
+
+// TestOpenAPISpecCoversTypes guards against new request/response types being
+// added to types.go without registering the endpoint in apiOperations.
+func TestOpenAPISpecCoversTypes(t *testing.T) {
+	// Types that are not (or no longer) returned by any endpoint
+	unused := map[string]bool{
+		"JobsListResponse":    true,
+		"JobStatusResponse":   true,
+		"ScenarioRunResponse": true,
+		"TargetJobResult":     true,
+		"GlobalsRequest":      true,
+		"GlobalsResponse":     true,
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "types.go", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse types.go: %v", err)
+	}
+
+	spec := buildOpenAPISpec()
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gen.Specs {
+			name := s.(*ast.TypeSpec).Name.Name
+			if unused[name] {
+				continue
+			}
+			if _, ok := schemas[name]; !ok {
+				t.Errorf("type %s is not part of the OpenAPI spec; add its endpoint to apiOperations", name)
+			}
+		}
+	}
+}
+
+func findRefs(doc string) []string {
+	var refs []string
+	for _, part := range strings.Split(doc, `"$ref": "`)[1:] {
+		refs = append(refs, part[:strings.Index(part, `"`)])
+	}
+	return refs
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MaxPageLimit caps the page size accepted by list endpoints
+const MaxPageLimit = 500
+
+// pageRequest holds the limit/continue parameters of a list request.
+// A zero Limit means "return everything", which keeps list endpoints
+// backward compatible for clients that don't paginate.
+type pageRequest struct {
+	Limit  int
+	Offset int
+}
+
+// parsePageRequest reads limit and continue query parameters
+func parsePageRequest(r *http.Request) (pageRequest, string, error) {
+	var page pageRequest
+	query := r.URL.Query()
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			return page, MsgInvalidLimit, fmt.Errorf("invalid limit %q", limit)
+		}
+		if parsed > MaxPageLimit {
+			parsed = MaxPageLimit
+		}
+		page.Limit = parsed
+	}
+
+	if token := query.Get("continue"); token != "" {
+		offset, err := decodeContinueToken(token)
+		if err != nil {
+			return page, MsgInvalidContinueToken, err
+		}
+		page.Offset = offset
+	}
+
+	return page, "", nil
+}
+
+// paginate returns the [start, end) window of a total-sized list and the
+// continue token for the next page (empty on the last page)
+func (p pageRequest) paginate(total int) (int, int, string) {
+	start := p.Offset
+	if start > total {
+		start = total
+	}
+	if p.Limit == 0 {
+		return start, total, ""
+	}
+
+	end := start + p.Limit
+	if end >= total {
+		return start, total, ""
+	}
+	return start, end, encodeContinueToken(end)
+}
+
+// parseTimeParam parses an optional RFC3339 query parameter
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &parsed, nil
+}
+
+// encodeContinueToken produces an opaque token for the given list offset
+func encodeContinueToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeContinueToken reverses encodeContinueToken
+func decodeContinueToken(token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("malformed continue token")
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("malformed continue token")
+	}
+	return offset, nil
+}
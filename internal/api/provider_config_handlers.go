@@ -23,8 +23,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -37,6 +35,7 @@ import (
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/pkg/configmap"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
 	"github.com/krkn-chaos/krkn-operator/pkg/provider"
 )
 
@@ -51,10 +50,8 @@ func (h *Handler) PostProviderConfig(w http.ResponseWriter, r *http.Request) {
 		"", // Let the function generate the name
 	)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create KrknOperatorTargetProviderConfig: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderConfigCreateFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -68,30 +65,22 @@ func (h *Handler) PostProviderConfig(w http.ResponseWriter, r *http.Request) {
 // GetProviderConfigByUUID handles GET /api/v1/provider-config/{uuid} endpoint
 // Returns 100 Continue when pending, 200 OK with config_data when Completed
 func (h *Handler) GetProviderConfigByUUID(w http.ResponseWriter, r *http.Request) {
-	uuid, err := extractPathSuffix(r.URL.Path, ProviderConfigPath+"/")
+	uuid, err := pathID[ProviderConfigUUID](r, ParamUUID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UUID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamUUID, err)
 		return
 	}
 
 	var config krknv1alpha1.KrknOperatorTargetProviderConfig
 	if err := h.client.Get(context.Background(), types.NamespacedName{
-		Name:      uuid,
+		Name:      string(uuid),
 		Namespace: h.namespace,
 	}, &config); err != nil {
 		if client.IgnoreNotFound(err) == nil {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "KrknOperatorTargetProviderConfig not found",
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgProviderConfigNotFound, nil)
 		} else {
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to fetch KrknOperatorTargetProviderConfig: " + err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderConfigFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -121,22 +110,20 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 	logger := log.FromContext(ctx)
 
 	// Extract UUID from path
-	uuid := strings.TrimPrefix(r.URL.Path, ProviderConfigPath+"/")
-	if uuid == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UUID is required",
-		})
+	uuid, err := pathID[ProviderConfigUUID](r, ParamUUID)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamUUID, err)
 		return
 	}
 
 	// Parse request body
 	var req ProviderConfigUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: fmt.Sprintf("Invalid request body: %v", err),
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -148,39 +135,29 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 
 	// Validate request
 	if req.ProviderName == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "provider_name is required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired,
+			i18n.Params{"field": "provider_name"})
 		return
 	}
 
 	if len(req.Values) == 0 {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "values cannot be empty",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderConfigValuesEmpty, nil)
 		return
 	}
 
 	// Find KrknOperatorTargetProviderConfig by UUID using label selector
 	var configList krknv1alpha1.KrknOperatorTargetProviderConfigList
 	if err := h.client.List(ctx, &configList, client.MatchingLabels{
-		"krkn.krkn-chaos.dev/uuid": uuid,
+		"krkn.krkn-chaos.dev/uuid": string(uuid),
 	}, client.InNamespace(h.namespace)); err != nil {
 		logger.Error(err, "Failed to list KrknOperatorTargetProviderConfig")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to query config",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderConfigFetchFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	if len(configList.Items) == 0 {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "KrknOperatorTargetProviderConfig not found",
-		})
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgProviderConfigNotFound, nil)
 		return
 	}
 
@@ -188,10 +165,8 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 
 	// Get provider config data from status
 	if config.Status.ConfigData == nil {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: fmt.Sprintf("target provider: %s not found", req.ProviderName),
-		})
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgProviderTargetNotFound,
+			i18n.Params{"provider": req.ProviderName})
 		return
 	}
 
@@ -200,10 +175,8 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 		logger.Error(nil, "Provider not found in ConfigData",
 			"requested_provider", req.ProviderName,
 			"available_providers", getProviderNames(config.Status.ConfigData))
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: fmt.Sprintf("target provider: %s not found", req.ProviderName),
-		})
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgProviderTargetNotFound,
+			i18n.Params{"provider": req.ProviderName})
 		return
 	}
 
@@ -231,17 +204,13 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 
 			// Check if it's a "field not found" error
 			if strings.Contains(err.Error(), "not found in schema") {
-				writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-					Error:   "bad_request",
-					Message: fmt.Sprintf("field %s not found in schema", key),
-				})
+				writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderConfigFieldUnknown,
+					i18n.Params{"field": key})
 				return
 			}
 			// Validation error
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "bad_request",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+				i18n.Params{"error": err.Error()})
 			return
 		}
 		logger.V(1).Info("✅ Field validated successfully", "key", key)
@@ -253,7 +222,7 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 	configMapNamespace := providerData.Namespace
 
 	var configMap corev1.ConfigMap
-	err := h.client.Get(ctx, types.NamespacedName{
+	err = h.client.Get(ctx, types.NamespacedName{
 		Name:      configMapName,
 		Namespace: configMapNamespace,
 	}, &configMap)
@@ -271,27 +240,19 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 			// Use WriteConfigMapData to write values in native key-value format
 			if err := configmap.WriteConfigMapData(&configMap, req.Values); err != nil {
 				logger.Error(err, "Failed to write ConfigMap data")
-				writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_error",
-					Message: fmt.Sprintf("Failed to write ConfigMap data: %v", err),
-				})
+				writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgConfigMapWriteFailed,
+					i18n.Params{"error": err.Error()})
 				return
 			}
 
 			if err := h.client.Create(ctx, &configMap); err != nil {
 				logger.Error(err, "Failed to create ConfigMap")
-				writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_error",
-					Message: "Failed to create ConfigMap",
-				})
+				writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgConfigMapCreateFailed, nil)
 				return
 			}
 		} else {
 			logger.Error(err, "Failed to get ConfigMap")
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get ConfigMap",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgConfigMapGetFailed, nil)
 			return
 		}
 	} else {
@@ -299,19 +260,14 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 		// Use WriteConfigMapData to merge new values into existing ConfigMap
 		if err := configmap.WriteConfigMapData(&configMap, req.Values); err != nil {
 			logger.Error(err, "Failed to write ConfigMap data")
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: fmt.Sprintf("Failed to write ConfigMap data: %v", err),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgConfigMapWriteFailed,
+				i18n.Params{"error": err.Error()})
 			return
 		}
 
 		if err := h.client.Update(ctx, &configMap); err != nil {
 			logger.Error(err, "Failed to update ConfigMap")
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to update ConfigMap",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgConfigMapUpdateFailed, nil)
 			return
 		}
 	}
@@ -328,7 +284,8 @@ func (h *Handler) UpdateProviderConfigValues(w http.ResponseWriter, r *http.Requ
 	}
 
 	writeJSON(w, http.StatusOK, ProviderConfigUpdateResponse{
-		Message:       "Configuration updated successfully",
+		Code:          MsgProviderConfigUpdateSucceeded,
+		Message:       localizedMessage(w, r, MsgProviderConfigUpdateSucceeded, nil),
 		UpdatedFields: updatedFields,
 	})
 }
@@ -341,56 +298,3 @@ func getProviderNames(configData map[string]krknv1alpha1.ProviderConfigData) []s
 	}
 	return names
 }
-
-// ProviderConfigHandler handles both GET /api/v1/provider-config/{UUID} and POST /api/v1/provider-config endpoints
-// It routes to the appropriate handler based on the HTTP method and path
-func (h *Handler) ProviderConfigHandler(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
-
-	// Root endpoint: POST to create new config request (admin only)
-	if path == ProviderConfigPath {
-		if r.Method != http.MethodPost {
-			writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-				Error:   "method_not_allowed",
-				Message: "Only POST is allowed",
-			})
-			return
-		}
-
-		// POST requires admin
-		if !h.requireAdminForMethods(w, r, []string{http.MethodPost}) {
-			return
-		}
-
-		h.PostProviderConfig(w, r)
-		return
-	}
-
-	// Nested endpoints with UUID: GET for all, POST (update) for admin only
-	if strings.HasPrefix(path, ProviderConfigPath+"/") {
-		// POST requires admin
-		if r.Method == http.MethodPost {
-			if !h.requireAdminForMethods(w, r, []string{http.MethodPost}) {
-				return
-			}
-		}
-
-		switch r.Method {
-		case http.MethodGet:
-			h.GetProviderConfigByUUID(w, r)
-		case http.MethodPost:
-			h.UpdateProviderConfigValues(w, r)
-		default:
-			writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-				Error:   "method_not_allowed",
-				Message: "Only GET and POST are allowed",
-			})
-		}
-		return
-	}
-
-	writeJSONError(w, http.StatusNotFound, ErrorResponse{
-		Error:   "not_found",
-		Message: "Endpoint not found",
-	})
-}
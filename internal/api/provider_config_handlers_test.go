@@ -82,7 +82,7 @@ func TestUpdateProviderConfigValues_CreatesNativeKeyValueFormat(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, ProviderConfigPath+"/test-uuid", bytes.NewReader(bodyBytes))
 	w := httptest.NewRecorder()
 
-	handler.UpdateProviderConfigValues(w, req)
+	serveAPI(handler, w, withAdminClaims(req))
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
@@ -172,7 +172,7 @@ func TestUpdateProviderConfigValues_UpdatesExistingConfigMap(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, ProviderConfigPath+"/test-uuid", bytes.NewReader(bodyBytes))
 	w := httptest.NewRecorder()
 
-	handler.UpdateProviderConfigValues(w, req)
+	serveAPI(handler, w, withAdminClaims(req))
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
@@ -0,0 +1,307 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+const (
+	// defaultProviderVerifyTimeout is the wait for the providers when the request sets none
+	defaultProviderVerifyTimeout = 30 * time.Second
+
+	// maxProviderVerifyTimeoutSeconds bounds the wait, the request holds the connection meanwhile
+	maxProviderVerifyTimeoutSeconds = 120
+	// providerVerifyWriteMargin is the time left, after the wait, to check the contributions
+	// and write the response
+	providerVerifyWriteMargin = 30 * time.Second
+
+	// providerVerifyPollInterval is the resolution of the measured provider latencies
+	providerVerifyPollInterval = 250 * time.Millisecond
+
+	// providerVerifyNamePrefix names the sandbox requests so they stand out among real ones
+	providerVerifyNamePrefix = "verify-"
+)
+
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krkntargetrequests,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;delete
+
+// VerifyProviders handles POST /api/v1/providers/verify endpoint
+// Runs a sandbox KrknTargetRequest through the active providers and reports which ones
+// responded, how fast, and whether their contribution follows the managed-clusters contract.
+// The sandbox request and its Secret are deleted afterwards.
+func (h *Handler) VerifyProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	var req ProviderVerifyRequest
+	if err := h.decodeRequestBody(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody, i18n.Params{"error": err.Error()})
+		return
+	}
+	timeout := defaultProviderVerifyTimeout
+	if req.TimeoutSeconds != 0 {
+		if req.TimeoutSeconds < 1 || req.TimeoutSeconds > maxProviderVerifyTimeoutSeconds {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderVerifyTimeout,
+				i18n.Params{"max": strconv.Itoa(maxProviderVerifyTimeoutSeconds)})
+			return
+		}
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	// The wait may outlive the server write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + providerVerifyWriteMargin))
+
+	var providerList krknv1alpha1.KrknOperatorTargetProviderList
+	if err := h.client.List(ctx, &providerList); err != nil {
+		logger.Error(err, "Failed to list providers")
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderListFailed, nil)
+		return
+	}
+	for _, name := range req.Providers {
+		if !isProviderActive(&providerList, name) {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderNotActive, i18n.Params{"provider": name})
+			return
+		}
+	}
+	spec := krknv1alpha1.KrknTargetRequestSpec{Providers: req.Providers}
+	expected := []string{}
+	for _, provider := range providerList.Items {
+		if provider.Spec.Active && spec.IncludesProvider(provider.Spec.OperatorName) {
+			expected = append(expected, provider.Spec.OperatorName)
+		}
+	}
+	slices.Sort(expected)
+	expected = slices.Compact(expected)
+	if len(expected) == 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderVerifyNone, nil)
+		return
+	}
+
+	name := providerVerifyNamePrefix + uuid.New().String()
+	spec.UUID = name
+	targetRequest := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: h.namespace,
+			Labels:    map[string]string{krknv1alpha1.TargetRequestDryRunLabel: "true"},
+		},
+		Spec: spec,
+	}
+	if err := h.client.Create(ctx, targetRequest); err != nil {
+		logger.Error(err, "Failed to create verification request", "name", name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderVerifyFailed, nil)
+		return
+	}
+	// The sandbox is removed even when the caller goes away
+	defer h.deleteVerifyRequest(context.WithoutCancel(ctx), name)
+
+	started := time.Now()
+	respondedAt := h.awaitProviderContributions(ctx, name, expected, timeout)
+	duration := time.Since(started)
+
+	// The Secret is read once every provider had the chance to write its section
+	var current krknv1alpha1.KrknTargetRequest
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name, Namespace: h.namespace}, &current); err != nil {
+		logger.Error(err, "Failed to get verification request", "name", name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderVerifyFailed, nil)
+		return
+	}
+	var secret corev1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{Name: name, Namespace: h.namespace}, &secret); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to get verification Secret", "name", name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderVerifyFailed, nil)
+		return
+	}
+
+	results := verifyProviderContributions(ctx, h.kubeconfigCipher, expected, current.Status.TargetData, secret.Data["managed-clusters"])
+	response := ProviderVerifyResponse{
+		RequestID:  name,
+		Passed:     true,
+		DurationMs: duration.Milliseconds(),
+		Providers:  results,
+	}
+	for i := range response.Providers {
+		result := &response.Providers[i]
+		if at, ok := respondedAt[result.Name]; ok {
+			latency := at.Sub(started).Milliseconds()
+			result.LatencyMs = &latency
+		}
+		response.Passed = response.Passed && result.Responded && result.Conformant
+	}
+
+	logger.Info("Providers verified",
+		"name", name,
+		"providers", expected,
+		"passed", response.Passed,
+		"duration", duration)
+	writeJSON(w, http.StatusOK, response)
+}
+
+// awaitProviderContributions polls the sandbox request until every expected provider has
+// contributed or the timeout expires, and returns when each contribution was first seen
+func (h *Handler) awaitProviderContributions(ctx context.Context, name string, expected []string, timeout time.Duration) map[string]time.Time {
+	respondedAt := make(map[string]time.Time, len(expected))
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(providerVerifyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var current krknv1alpha1.KrknTargetRequest
+		if err := h.client.Get(ctx, types.NamespacedName{Name: name, Namespace: h.namespace}, &current); err == nil {
+			now := time.Now()
+			for provider := range current.Status.TargetData {
+				if _, seen := respondedAt[provider]; !seen && slices.Contains(expected, provider) {
+					respondedAt[provider] = now
+				}
+			}
+			if len(respondedAt) == len(expected) {
+				return respondedAt
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return respondedAt
+		case <-deadline.C:
+			return respondedAt
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteVerifyRequest removes a sandbox request and its managed-clusters Secret. The Secret
+// is deleted explicitly since providers are not required to make the request its owner.
+func (h *Handler) deleteVerifyRequest(ctx context.Context, name string) {
+	logger := log.FromContext(ctx)
+	objects := []client.Object{
+		&krknv1alpha1.KrknTargetRequest{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: h.namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: h.namespace}},
+	}
+	for _, obj := range objects {
+		if err := h.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete verification resource", "name", name)
+		}
+	}
+}
+
+// verifyProviderContributions checks the contribution of each expected provider against
+// the contract: targets named with a valid API URL, and a managed-clusters section holding
+// a valid kubeconfig for exactly those targets, pointing at the advertised API server.
+// Kubeconfigs sealed at rest are opened with cipher.
+func verifyProviderContributions(ctx context.Context, cipher *envelope.Cipher, expected []string, targetData map[string][]krknv1alpha1.ClusterTarget, managedClustersJSON []byte) []ProviderVerifyResult {
+	var managedClusters map[string]map[string]map[string]string
+	var payloadErr error
+	if len(managedClustersJSON) > 0 {
+		if err := json.Unmarshal(managedClustersJSON, &managedClusters); err != nil {
+			payloadErr = fmt.Errorf("managed-clusters payload is not valid JSON of provider, cluster and fields: %w", err)
+		}
+	}
+
+	results := make([]ProviderVerifyResult, 0, len(expected))
+	for _, name := range expected {
+		targets, responded := targetData[name]
+		result := ProviderVerifyResult{Name: name, Responded: responded, Clusters: len(targets)}
+		if !responded {
+			results = append(results, result)
+			continue
+		}
+
+		section, hasSection := managedClusters[name]
+		switch {
+		case payloadErr != nil:
+			result.Issues = append(result.Issues, payloadErr.Error())
+		case !hasSection && len(targets) > 0:
+			result.Issues = append(result.Issues, "no managed-clusters section for the contributed targets")
+		}
+
+		seen := make(map[string]bool, len(targets))
+		for _, target := range targets {
+			if target.ClusterName == "" {
+				result.Issues = append(result.Issues, "target without cluster-name")
+				continue
+			}
+			if seen[target.ClusterName] {
+				result.Issues = append(result.Issues, fmt.Sprintf("cluster %q contributed more than once", target.ClusterName))
+				continue
+			}
+			seen[target.ClusterName] = true
+			if _, err := kubeconfig.NormalizeAPIURL(target.ClusterAPIURL); err != nil {
+				result.Issues = append(result.Issues, fmt.Sprintf("cluster %q has an invalid cluster-api-url: %v", target.ClusterName, err))
+			}
+			if payloadErr != nil || !hasSection {
+				continue
+			}
+
+			entry, ok := section[target.ClusterName]
+			if !ok {
+				result.Issues = append(result.Issues, fmt.Sprintf("cluster %q has no managed-clusters entry", target.ClusterName))
+				continue
+			}
+			kubeconfigBase64, err := cipher.Open(ctx, entry["kubeconfig"])
+			if err == nil {
+				err = kubeconfig.Validate(kubeconfigBase64)
+			}
+			if err != nil {
+				result.Issues = append(result.Issues, fmt.Sprintf("cluster %q has an invalid kubeconfig: %v", target.ClusterName, err))
+				continue
+			}
+			if server, err := kubeconfig.ExtractAPIURL(kubeconfigBase64); err == nil && !kubeconfig.SameAPIURL(server, target.ClusterAPIURL) {
+				result.Issues = append(result.Issues, fmt.Sprintf("cluster %q kubeconfig points at %s instead of %s", target.ClusterName, server, target.ClusterAPIURL))
+			}
+		}
+		if payloadErr == nil {
+			extra := []string{}
+			for cluster := range section {
+				if !seen[cluster] {
+					extra = append(extra, cluster)
+				}
+			}
+			slices.Sort(extra)
+			for _, cluster := range extra {
+				result.Issues = append(result.Issues, fmt.Sprintf("managed-clusters entry %q is not a contributed target", cluster))
+			}
+		}
+
+		result.Conformant = len(result.Issues) == 0
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+)
+
+func TestVerifyProviderContributions(t *testing.T) {
+	valid, err := kubeconfig.GenerateFromToken("eu", "https://api.eu.example.com:6443", "", "", "token", false)
+	if err != nil {
+		t.Fatalf("Failed to generate kubeconfig: %v", err)
+	}
+	targetData := map[string][]krknv1alpha1.ClusterTarget{
+		"good": {{ClusterName: "eu", ClusterAPIURL: "https://api.eu.example.com:6443"}},
+		"bad": {
+			{ClusterName: "eu", ClusterAPIURL: "https://api.other.example.com:6443"},
+			{ClusterName: "us", ClusterAPIURL: ""},
+			{ClusterName: "broken", ClusterAPIURL: "https://api.broken.example.com:6443"},
+		},
+		"empty": {},
+	}
+	managedClusters, _ := json.Marshal(map[string]map[string]map[string]string{
+		"good": {"eu": {"kubeconfig": valid}},
+		"bad": {
+			"eu":     {"kubeconfig": valid},
+			"broken": {"kubeconfig": "not-base64"},
+			"stray":  {"kubeconfig": valid},
+		},
+	})
+
+	results := verifyProviderContributions(context.Background(), nil, []string{"bad", "empty", "good", "silent"}, targetData, managedClusters)
+	byName := map[string]ProviderVerifyResult{}
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+
+	if good := byName["good"]; !good.Responded || !good.Conformant || good.Clusters != 1 {
+		t.Errorf("Expected a conformant provider, got %+v", good)
+	}
+	if empty := byName["empty"]; !empty.Responded || !empty.Conformant {
+		t.Errorf("Expected a provider without targets to be conformant, got %+v", empty)
+	}
+	if silent := byName["silent"]; silent.Responded || silent.Conformant {
+		t.Errorf("Expected a provider without contribution to be reported, got %+v", silent)
+	}
+
+	bad := byName["bad"]
+	if bad.Conformant || len(bad.Issues) != 5 {
+		t.Fatalf("Expected 5 issues, got %v", bad.Issues)
+	}
+	for i, want := range []string{"points at", "invalid cluster-api-url", "no managed-clusters entry", "invalid kubeconfig", `"stray" is not a contributed target`} {
+		if !strings.Contains(bad.Issues[i], want) {
+			t.Errorf("Expected issue %d to mention %q, got %q", i, want, bad.Issues[i])
+		}
+	}
+
+	results = verifyProviderContributions(context.Background(), nil, []string{"good"}, targetData, []byte("{"))
+	if results[0].Conformant || len(results[0].Issues) != 1 {
+		t.Errorf("Expected an unparseable payload to be reported, got %+v", results[0])
+	}
+}
+
+func TestVerifyProviders(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+	for _, p := range []struct {
+		name   string
+		active bool
+	}{{"responsive", true}, {"silent", true}, {"inactive", false}} {
+		provider := &krknv1alpha1.KrknOperatorTargetProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: p.name, Namespace: "test-namespace"},
+			Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: p.name, Active: p.active},
+		}
+		if err := handler.client.Create(ctx, provider); err != nil {
+			t.Fatalf("Failed to create provider: %v", err)
+		}
+	}
+
+	// The responsive provider contributes a target to the sandbox request
+	valid, err := kubeconfig.GenerateFromToken("eu", "https://api.eu.example.com:6443", "", "", "token", false)
+	if err != nil {
+		t.Fatalf("Failed to generate kubeconfig: %v", err)
+	}
+	go func() {
+		for range 40 {
+			var requests krknv1alpha1.KrknTargetRequestList
+			if err := handler.client.List(ctx, &requests,
+				client.MatchingLabels{krknv1alpha1.TargetRequestDryRunLabel: "true"}); err == nil && len(requests.Items) == 1 {
+				request := &requests.Items[0]
+				request.Status.TargetData = map[string][]krknv1alpha1.ClusterTarget{
+					"responsive": {{ClusterName: "eu", ClusterAPIURL: "https://api.eu.example.com:6443"}},
+				}
+				managedClusters, _ := json.Marshal(map[string]map[string]map[string]string{
+					"responsive": {"eu": {"kubeconfig": valid}},
+				})
+				_ = handler.client.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: request.Spec.UUID, Namespace: "test-namespace"},
+					Data:       map[string][]byte{"managed-clusters": managedClusters},
+				})
+				_ = handler.client.Update(ctx, request)
+				return
+			}
+			time.Sleep(25 * time.Millisecond)
+		}
+	}()
+
+	req := httptest.NewRequest(http.MethodPost, ProviderVerifyPath, bytes.NewBufferString(`{"timeoutSeconds": 1}`))
+	w := httptest.NewRecorder()
+	handler.VerifyProviders(w, withAdminClaims(req))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response ProviderVerifyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Passed || len(response.Providers) != 2 {
+		t.Fatalf("Expected the active providers with a failure, got %+v", response)
+	}
+	responsive, silent := response.Providers[0], response.Providers[1]
+	if responsive.Name != "responsive" || !responsive.Responded || !responsive.Conformant || responsive.LatencyMs == nil {
+		t.Errorf("Expected the responsive provider to pass, got %+v", responsive)
+	}
+	if silent.Name != "silent" || silent.Responded || silent.LatencyMs != nil {
+		t.Errorf("Expected the silent provider to be reported, got %+v", silent)
+	}
+
+	// The sandbox is removed
+	var requests krknv1alpha1.KrknTargetRequestList
+	if err := handler.client.List(ctx, &requests); err != nil || len(requests.Items) != 0 {
+		t.Errorf("Expected the sandbox request to be deleted, got %d (%v)", len(requests.Items), err)
+	}
+	var secrets corev1.SecretList
+	if err := handler.client.List(ctx, &secrets); err != nil || len(secrets.Items) != 0 {
+		t.Errorf("Expected the sandbox Secret to be deleted, got %d (%v)", len(secrets.Items), err)
+	}
+
+	for _, body := range []string{`{"providers": ["inactive"]}`, `{"timeoutSeconds": 600}`} {
+		req := httptest.NewRequest(http.MethodPost, ProviderVerifyPath, bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.VerifyProviders(w, withAdminClaims(req))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+}
+
+func TestVerifyProviders_OutlivesWriteTimeout(t *testing.T) {
+	handler := setupTestHandler()
+	provider := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "silent", Namespace: "test-namespace"},
+		Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: "silent", Active: true},
+	}
+	if err := handler.client.Create(context.Background(), provider); err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+
+	// The silent provider keeps the request waiting past the write timeout of the server
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.VerifyProviders(w, withAdminClaims(r))
+	}))
+	server.Config.WriteTimeout = 200 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+ProviderVerifyPath, "application/json", bytes.NewBufferString(`{"timeoutSeconds": 1}`))
+	if err != nil {
+		t.Fatalf("Expected a response past the write timeout, got %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var response ProviderVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the verification report, got %d (%v)", resp.StatusCode, err)
+	}
+	if response.Passed || len(response.Providers) != 1 || response.Providers[0].Responded {
+		t.Errorf("Expected the silent provider to be reported, got %+v", response)
+	}
+}
@@ -19,14 +19,25 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package api
 
 import (
-	"encoding/json"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/provider"
+)
+
+const (
+	// providerHeartbeatTimeout is the heartbeat age after which a provider is reported
+	// unhealthy, three missed heartbeats at the default interval
+	providerHeartbeatTimeout = 3 * provider.DefaultHeartbeatInterval
+
+	// maxProviderReasonLength bounds the reason of an activation change
+	maxProviderReasonLength = 256
 )
 
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargetproviders,verbs=get;list;watch;update;patch
@@ -42,25 +53,14 @@ func (h *Handler) ListProviders(w http.ResponseWriter, r *http.Request) {
 	var providerList krknv1alpha1.KrknOperatorTargetProviderList
 	if err := h.client.List(ctx, &providerList); err != nil {
 		logger.Error(err, "Failed to list KrknOperatorTargetProvider CRs")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list providers",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderListFailed, nil)
 		return
 	}
 
 	// Build response
 	providers := make([]ProviderResponse, 0, len(providerList.Items))
-	for _, provider := range providerList.Items {
-		var lastHeartbeat *metav1.Time
-		if !provider.Status.Timestamp.IsZero() {
-			lastHeartbeat = &provider.Status.Timestamp
-		}
-		providers = append(providers, ProviderResponse{
-			Name:          provider.Spec.OperatorName,
-			Active:        provider.Spec.Active,
-			LastHeartbeat: lastHeartbeat,
-		})
+	for i := range providerList.Items {
+		providers = append(providers, buildProviderResponse(&providerList.Items[i], time.Now()))
 	}
 
 	writeJSON(w, http.StatusOK, ListProvidersResponse{
@@ -75,22 +75,19 @@ func (h *Handler) UpdateProviderStatus(w http.ResponseWriter, r *http.Request) {
 	logger := log.FromContext(ctx)
 
 	// Extract provider name from path
-	providerName := strings.TrimPrefix(r.URL.Path, ProvidersPath+"/")
-	if providerName == "" || providerName == ProvidersPath+"/" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Provider name is required",
-		})
+	providerName, err := pathParam(r, ParamProviderName)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderNameRequired, nil)
 		return
 	}
 
 	// Parse request body
 	var req UpdateProviderStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body",
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgMalformedRequestBody, nil)
 		return
 	}
 
@@ -98,10 +95,7 @@ func (h *Handler) UpdateProviderStatus(w http.ResponseWriter, r *http.Request) {
 	var providerList krknv1alpha1.KrknOperatorTargetProviderList
 	if err := h.client.List(ctx, &providerList); err != nil {
 		logger.Error(err, "Failed to list providers")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to query providers",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderQueryFailed, nil)
 		return
 	}
 
@@ -115,75 +109,71 @@ func (h *Handler) UpdateProviderStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if targetProvider == nil {
-		writeJSONError(w, http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Provider not found",
-		})
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgProviderNotFound, nil)
 		return
 	}
 
-	// Update the active status
+	if len(req.Reason) > maxProviderReasonLength {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgProviderReasonTooLong,
+			i18n.Params{"max": strconv.Itoa(maxProviderReasonLength)})
+		return
+	}
+
+	// Update the active status, recording who changed it and why. A provider
+	// deactivated here stays inactive when it restarts.
+	changedBy := "admin"
+	if claims := auth.GetClaimsFromContext(ctx); claims != nil {
+		changedBy = claims.UserID
+	}
+	if targetProvider.Annotations == nil {
+		targetProvider.Annotations = map[string]string{}
+	}
+	targetProvider.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation] = changedBy
+	if req.Reason != "" {
+		targetProvider.Annotations[krknv1alpha1.ProviderActivationReasonAnnotation] = req.Reason
+	} else {
+		delete(targetProvider.Annotations, krknv1alpha1.ProviderActivationReasonAnnotation)
+	}
 	targetProvider.Spec.Active = req.Active
 	if err := h.client.Update(ctx, targetProvider); err != nil {
 		logger.Error(err, "Failed to update provider",
 			"provider", providerName,
 			"active", req.Active)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update provider status",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgProviderUpdateFailed, nil)
 		return
 	}
 
 	logger.Info("Provider status updated",
 		"provider", providerName,
-		"active", req.Active)
+		"active", req.Active,
+		"changedBy", changedBy,
+		"reason", req.Reason)
 
 	writeJSON(w, http.StatusOK, UpdateProviderStatusResponse{
-		Message: "Provider status updated successfully",
+		Code:    MsgProviderStatusUpdateSucceeded,
+		Message: localizedMessage(w, r, MsgProviderStatusUpdateSucceeded, nil),
 		Name:    providerName,
 		Active:  req.Active,
+		Reason:  req.Reason,
 	})
 }
 
-// ProvidersRouter routes provider-related requests
-func (h *Handler) ProvidersRouter(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
-
-	// Root endpoint: GET to list all providers
-	if path == ProvidersPath {
-		if r.Method != http.MethodGet {
-			writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-				Error:   "method_not_allowed",
-				Message: "Only GET is allowed",
-			})
-			return
-		}
-		h.ListProviders(w, r)
-		return
+// buildProviderResponse converts a provider to its API representation, with the age of
+// its last heartbeat at now
+func buildProviderResponse(p *krknv1alpha1.KrknOperatorTargetProvider, now time.Time) ProviderResponse {
+	response := ProviderResponse{
+		Name:                p.Spec.OperatorName,
+		Active:              p.Spec.Active,
+		ActivationReason:    p.Annotations[krknv1alpha1.ProviderActivationReasonAnnotation],
+		ActivationChangedBy: p.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation],
+		ActivationChangedAt: p.Status.ActivationChangedAt,
 	}
-
-	// Provider-specific endpoint: PATCH to update status (admin only)
-	if strings.HasPrefix(path, ProvidersPath+"/") {
-		if r.Method != http.MethodPatch {
-			writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-				Error:   "method_not_allowed",
-				Message: "Only PATCH is allowed",
-			})
-			return
-		}
-
-		// PATCH requires admin
-		if !h.requireAdminForMethods(w, r, []string{http.MethodPatch}) {
-			return
-		}
-
-		h.UpdateProviderStatus(w, r)
-		return
+	if !p.Status.Timestamp.IsZero() {
+		age := max(now.Sub(p.Status.Timestamp.Time), 0)
+		seconds := int64(age.Seconds())
+		response.LastHeartbeat = &p.Status.Timestamp
+		response.HeartbeatAgeSeconds = &seconds
+		response.Healthy = age <= providerHeartbeatTimeout
 	}
-
-	writeJSONError(w, http.StatusNotFound, ErrorResponse{
-		Error:   "not_found",
-		Message: "Endpoint not found",
-	})
+	return response
 }
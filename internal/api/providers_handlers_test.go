@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestBuildProviderResponse(t *testing.T) {
+	now := time.Now()
+	changedAt := metav1.NewTime(now.Add(-time.Hour))
+	p := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "krkn-operator", Annotations: map[string]string{
+			krknv1alpha1.ProviderActivationReasonAnnotation:    "maintenance",
+			krknv1alpha1.ProviderActivationChangedByAnnotation: "admin@example.com",
+		}},
+		Spec: krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: "krkn-operator"},
+		Status: krknv1alpha1.KrknOperatorTargetProviderStatus{
+			Timestamp:           metav1.NewTime(now.Add(-45 * time.Second)),
+			ActivationChangedAt: &changedAt,
+		},
+	}
+
+	response := buildProviderResponse(p, now)
+	if response.HeartbeatAgeSeconds == nil || *response.HeartbeatAgeSeconds != 45 || !response.Healthy {
+		t.Errorf("Expected a healthy heartbeat of 45s, got %v %v", response.HeartbeatAgeSeconds, response.Healthy)
+	}
+	if response.ActivationReason != "maintenance" || response.ActivationChangedBy != "admin@example.com" || response.ActivationChangedAt == nil {
+		t.Errorf("Expected the activation change, got %+v", response)
+	}
+
+	p.Status.Timestamp = metav1.NewTime(now.Add(-providerHeartbeatTimeout - time.Second))
+	if response := buildProviderResponse(p, now); response.Healthy {
+		t.Error("Expected a stale heartbeat to be unhealthy")
+	}
+
+	p.Status.Timestamp = metav1.Time{}
+	if response := buildProviderResponse(p, now); response.Healthy || response.HeartbeatAgeSeconds != nil {
+		t.Errorf("Expected no heartbeat to be unhealthy, got %+v", response)
+	}
+}
+
+func TestUpdateProviderStatus(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+	provider := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "krkn-operator", Namespace: "test-namespace"},
+		Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: "krkn-operator", Active: true},
+	}
+	if err := handler.client.Create(ctx, provider); err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		routeContext := chi.NewRouteContext()
+		routeContext.URLParams.Add(ParamProviderName, "krkn-operator")
+		req := httptest.NewRequest(http.MethodPatch, ProvidersPath+"/krkn-operator", bytes.NewBufferString(body))
+		req = withAdminClaims(req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext)))
+		w := httptest.NewRecorder()
+		handler.UpdateProviderStatus(w, req)
+		return w
+	}
+
+	w := patch(`{"active": false, "reason": "maintenance"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response UpdateProviderStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Active || response.Reason != "maintenance" {
+		t.Errorf("Expected the deactivation with its reason, got %+v", response)
+	}
+
+	var updated krknv1alpha1.KrknOperatorTargetProvider
+	if err := handler.client.Get(ctx, types.NamespacedName{Name: "krkn-operator", Namespace: "test-namespace"}, &updated); err != nil {
+		t.Fatalf("Failed to get provider: %v", err)
+	}
+	if updated.Spec.Active ||
+		updated.Annotations[krknv1alpha1.ProviderActivationReasonAnnotation] != "maintenance" ||
+		updated.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation] != "admin@example.com" {
+		t.Errorf("Expected the change to be recorded on the provider, got %v %v", updated.Spec.Active, updated.Annotations)
+	}
+
+	if w := patch(`{"active": true, "reason": "` + strings.Repeat("x", maxProviderReasonLength+1) + `"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for a long reason, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// readinessCheckTimeout bounds each dependency check of the readiness probe
+const readinessCheckTimeout = 5 * time.Second
+
+// Names of the readiness checks
+const (
+	ReadinessCheckKubernetesAPI = "kubernetes-api"
+	ReadinessCheckDataProvider  = "data-provider"
+)
+
+// Healthz handles GET /api/v1/healthz, the liveness endpoint: the API server answers,
+// whatever the state of its dependencies. GET /api/v1/health is a deprecated alias.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status": "healthy",
+	})
+}
+
+// ReadyzChecks returns the readiness checks of the API dependencies, for the operator
+// readiness probe: the Kubernetes API and the data provider must answer
+func (h *Handler) ReadyzChecks() map[string]healthz.Checker {
+	return map[string]healthz.Checker{
+		ReadinessCheckKubernetesAPI: withReadinessTimeout(h.checkKubernetesAPI),
+		ReadinessCheckDataProvider: withReadinessTimeout(func(ctx context.Context) error {
+			return h.dataProvider.CheckHealth(ctx)
+		}),
+	}
+}
+
+// withReadinessTimeout bounds check by readinessCheckTimeout
+func withReadinessTimeout(check func(context.Context) error) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), readinessCheckTimeout)
+		defer cancel()
+		return check(ctx)
+	}
+}
+
+// Readyz handles GET /api/v1/readyz, the public alias of the operator readiness probe:
+// it runs the checks registered with the probe (see Server.SetReadyzChecks), or only
+// ReadyzChecks when none are, answering 200 when all pass and 503 with the result of
+// each check otherwise
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	checks := h.readyzChecks
+	if checks == nil {
+		checks = h.ReadyzChecks()
+	}
+
+	response := ReadinessResponse{Status: "ready", Checks: make(map[string]string, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := "ok"
+			if err := check(r); err != nil {
+				result = err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			response.Checks[name] = result
+			if result != "ok" {
+				response.Status = "not_ready"
+			}
+		}()
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	if response.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, response)
+}
+
+// checkKubernetesAPI reviews the operator's own identity, which any authenticated client
+// may do: it fails when the API server cannot be reached or rejects the credentials
+func (h *Handler) checkKubernetesAPI(ctx context.Context) error {
+	_, err := h.clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx,
+		&authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	return err
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+)
+
+func TestReadyz(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	handler := setupTestHandler()
+	// Reviews are not persisted, unlike the objects of the fake clientset
+	reviews := fake.NewSimpleClientset()
+	reviews.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authenticationv1.SelfSubjectReview{}, nil
+	})
+	handler.clientset = reviews
+	handler.dataProvider = grpcclient.NewClient(listener.Addr().String(), nil)
+	defer func() { _ = handler.dataProvider.Close() }()
+
+	readyz := func() (int, ReadinessResponse) {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, httptest.NewRequest(http.MethodGet, ReadyzPath, nil))
+		var response ReadinessResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return w.Code, response
+	}
+
+	// Public, and ready when both dependencies answer
+	code, response := readyz()
+	if code != http.StatusOK || response.Status != "ready" ||
+		response.Checks[ReadinessCheckKubernetesAPI] != "ok" || response.Checks[ReadinessCheckDataProvider] != "ok" {
+		t.Errorf("Expected ready, got %d %+v", code, response)
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	code, response = readyz()
+	if code != http.StatusServiceUnavailable || response.Status != "not_ready" ||
+		response.Checks[ReadinessCheckDataProvider] == "ok" || response.Checks[ReadinessCheckKubernetesAPI] != "ok" {
+		t.Errorf("Expected the data provider check to fail, got %d %+v", code, response)
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	handler.clientset = clientset
+	code, response = readyz()
+	if code != http.StatusServiceUnavailable || response.Checks[ReadinessCheckKubernetesAPI] != "connection refused" {
+		t.Errorf("Expected the Kubernetes API check to fail, got %d %+v", code, response)
+	}
+
+	// The endpoint runs the checks of the operator readiness probe once registered
+	handler.clientset = reviews
+	checks := handler.ReadyzChecks()
+	checks["crd-schema"] = func(*http.Request) error { return errors.New("CRDs are outdated") }
+	handler.readyzChecks = checks
+	code, response = readyz()
+	if code != http.StatusServiceUnavailable || response.Checks["crd-schema"] != "CRDs are outdated" ||
+		response.Checks[ReadinessCheckKubernetesAPI] != "ok" || response.Checks[ReadinessCheckDataProvider] != "ok" {
+		t.Errorf("Expected the probe checks to be reported, got %d %+v", code, response)
+	}
+
+	// Liveness does not depend on them; /health is its public alias
+	for _, path := range []string{HealthzPath, HealthPath} {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected liveness to succeed, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/krkn-chaos/krknctl/pkg/config"
+	"github.com/krkn-chaos/krknctl/pkg/provider"
+	"github.com/krkn-chaos/krknctl/pkg/provider/factory"
+)
+
+// RegistryCacheTTLEnv sets how long scenario list, detail and globals responses are
+// served from memory before the registry is called again, as a Go duration. 0 disables
+// the cache; the responses are still kept for the fallback while a registry is unavailable.
+const RegistryCacheTTLEnv = "API_REGISTRY_CACHE_TTL"
+
+// DefaultRegistryCacheTTL is the registry cache TTL when RegistryCacheTTLEnv is not set
+const DefaultRegistryCacheTTL = 5 * time.Minute
+
+// QueryRefresh is the query parameter that, set to true, makes the scenario endpoints
+// call the registry instead of answering from the cache
+const QueryRefresh = "refresh"
+
+// RegistryCacheTTLFromEnv reads the registry cache TTL from the environment
+func RegistryCacheTTLFromEnv() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv(RegistryCacheTTLEnv)); err == nil && ttl >= 0 {
+		return ttl
+	}
+	return DefaultRegistryCacheTTL
+}
+
+// refreshRequested reports whether the request asks to bypass the registry cache
+func refreshRequested(r *http.Request) bool {
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get(QueryRefresh))
+	return refresh
+}
+
+// fresh returns the cached response of key when it was fetched less than ttl ago
+func (c *registryCatalog) fresh(key string, ttl time.Duration) (catalogEntry, bool) {
+	if ttl <= 0 {
+		return catalogEntry{}, false
+	}
+	entry, ok := c.lookup(key)
+	if !ok || time.Since(entry.cachedAt) >= ttl {
+		return catalogEntry{}, false
+	}
+	return entry, true
+}
+
+// newScenarioProviderFactory loads the krknctl config once and returns the function
+// creating the registry clients of the scenario endpoints from it
+func newScenarioProviderFactory() func(mode provider.Mode) (provider.ScenarioDataProvider, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		err = fmt.Errorf("failed to load krknctl config: %w", err)
+		return func(provider.Mode) (provider.ScenarioDataProvider, error) {
+			return nil, err
+		}
+	}
+
+	providerFactory := factory.NewProviderFactory(&cfg)
+	return func(mode provider.Mode) (provider.ScenarioDataProvider, error) {
+		scenarioProvider := providerFactory.NewInstance(mode)
+		if scenarioProvider == nil {
+			return nil, fmt.Errorf("failed to create scenario provider")
+		}
+		return scenarioProvider, nil
+	}
+}
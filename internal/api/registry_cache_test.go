@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPostScenarios_RegistryCache(t *testing.T) {
+	scenarioProvider := &fakeScenarioProvider{}
+	handler := setupRegistryTestHandler(scenarioProvider)
+	handler.registryCacheTTL = time.Minute
+
+	post := func(path string) int {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, path, nil)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("POST %s: expected status %d, got %d. Body: %s", path, http.StatusOK, w.Code, w.Body.String())
+		}
+		return scenarioProvider.calls
+	}
+
+	if calls := post(ScenariosPath); calls != 1 {
+		t.Fatalf("Expected the registry to be called, got %d calls", calls)
+	}
+	if calls := post(ScenariosPath); calls != 1 {
+		t.Errorf("Expected the cached list, got %d calls", calls)
+	}
+	if calls := post(ScenariosPath + "?" + QueryRefresh + "=true"); calls != 2 {
+		t.Errorf("Expected refresh to call the registry, got %d calls", calls)
+	}
+
+	// Details are cached per scenario
+	detailPath := ScenariosDetailPath + "/pod-scenarios"
+	post(detailPath)
+	if calls := post(detailPath); calls != 3 {
+		t.Errorf("Expected the cached detail, got %d calls", calls)
+	}
+	if calls := post(ScenariosDetailPath + "/node-scenarios"); calls != 4 {
+		t.Errorf("Expected another scenario to call the registry, got %d calls", calls)
+	}
+
+	// Expired
+	handler.registryCacheTTL = time.Nanosecond
+	if calls := post(ScenariosPath); calls != 5 {
+		t.Errorf("Expected an expired list to call the registry, got %d calls", calls)
+	}
+}
+
+func TestRegistryCacheTTLFromEnv(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"":        DefaultRegistryCacheTTL,
+		"30s":     30 * time.Second,
+		"0":       0,
+		"-1m":     DefaultRegistryCacheTTL,
+		"invalid": DefaultRegistryCacheTTL,
+	} {
+		t.Setenv(RegistryCacheTTLEnv, value)
+		if got := RegistryCacheTTLFromEnv(); got != want {
+			t.Errorf("%q: expected %v, got %v", value, want, got)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/krkn-chaos/krknctl/pkg/provider"
+	"github.com/krkn-chaos/krknctl/pkg/provider/models"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+const (
+	// CatalogStaleHeader is set to "true" on scenario responses served from the cached
+	// catalog while the registry is unavailable; Age gives the age of the cached copy
+	CatalogStaleHeader = "X-Catalog-Stale"
+
+	// DefaultRegistry names the default quay.io scenario registry in statuses and metrics
+	DefaultRegistry = "quay.io"
+
+	// DefaultRegistryProbeInterval is how often the default registry is probed by default
+	DefaultRegistryProbeInterval = 5 * time.Minute
+
+	// maxCatalogEntries bounds the responses kept for the fallback, the oldest go first
+	maxCatalogEntries = 1000
+	// registryRetryAfter is the Retry-After of the 503 returned without cached catalog
+	registryRetryAfter = 30 * time.Second
+)
+
+// RegistryUnavailableError reports that a scenario registry could not be reached or
+// answered with an error
+type RegistryUnavailableError struct {
+	// Registry is the registry URL and scenario repository, or DefaultRegistry
+	Registry string
+	Err      error
+}
+
+func (e *RegistryUnavailableError) Error() string {
+	return fmt.Sprintf("scenario registry %s is unavailable: %v", e.Registry, e.Err)
+}
+
+func (e *RegistryUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// CatalogStaleness marks a scenario response served from the cached catalog because the
+// registry is unavailable (also signaled by the X-Catalog-Stale header)
+type CatalogStaleness struct {
+	// Stale is true when the response comes from the cached catalog
+	Stale bool `json:"stale,omitempty"`
+	// CachedAt is when the cached response was fetched from the registry
+	CachedAt *time.Time `json:"cachedAt,omitempty"`
+	// RegistryError is why the registry could not be used
+	RegistryError string `json:"registryError,omitempty"`
+}
+
+// RegistryStatus is the availability of a scenario registry, as last seen by the API
+type RegistryStatus struct {
+	// Registry is the registry URL and scenario repository, or DefaultRegistry
+	Registry string `json:"registry"`
+	// Available is false when the last call to the registry failed
+	Available bool `json:"available"`
+	// LastError is the error of the last failed call, while unavailable
+	LastError string `json:"lastError,omitempty"`
+	// LastCheckedAt is when the registry was last called
+	LastCheckedAt time.Time `json:"lastCheckedAt"`
+	// LastAvailableAt is when the registry last answered, nil if it never did
+	LastAvailableAt *time.Time `json:"lastAvailableAt,omitempty"`
+	// CatalogCached is true once a scenario list of the registry is cached, served while
+	// the registry is unavailable. An unavailable registry without one has no catalog.
+	CatalogCached bool `json:"catalogCached"`
+}
+
+// catalogEntry is a registry response kept for the fallback
+type catalogEntry struct {
+	response any
+	cachedAt time.Time
+}
+
+// registryCatalog keeps the last successful scenario responses of each registry, served
+// while the registry is unavailable, and the availability of the registries
+type registryCatalog struct {
+	mu       sync.RWMutex
+	entries  map[string]catalogEntry
+	statuses map[string]*RegistryStatus
+}
+
+func newRegistryCatalog() *registryCatalog {
+	return &registryCatalog{
+		entries:  map[string]catalogEntry{},
+		statuses: map[string]*RegistryStatus{},
+	}
+}
+
+// registryName identifies a registry in statuses and metrics
+func registryName(registry *models.RegistryV2) string {
+	if registry == nil {
+		return DefaultRegistry
+	}
+	return strings.TrimSuffix(registry.RegistryURL, "/") + "/" + registry.ScenarioRepository
+}
+
+// catalogKey keys a cached response by registry, credentials and request, so that a
+// private catalog is never served to a request with other credentials
+func catalogKey(registry *models.RegistryV2, request string) string {
+	if registry == nil {
+		return DefaultRegistry + "|" + request
+	}
+	hash := sha256.New()
+	for _, credential := range []*string{registry.Username, registry.Password, registry.Token} {
+		if credential != nil {
+			hash.Write([]byte(*credential))
+		}
+		hash.Write([]byte{0})
+	}
+	return registryName(registry) + "|" + hex.EncodeToString(hash.Sum(nil)) + "|" + request
+}
+
+// observe records the outcome of a registry call in its status and metrics
+func (c *registryCatalog) observe(registry string, err error) {
+	now := time.Now().UTC()
+	c.mu.Lock()
+	status, ok := c.statuses[registry]
+	if !ok {
+		status = &RegistryStatus{Registry: registry}
+		c.statuses[registry] = status
+	}
+	status.LastCheckedAt = now
+	status.Available = err == nil
+	status.LastError = ""
+	if err != nil {
+		status.LastError = err.Error()
+	} else {
+		status.LastAvailableAt = &now
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		registryErrors.WithLabelValues(registry).Inc()
+		registryAvailable.WithLabelValues(registry).Set(0)
+	} else {
+		registryAvailable.WithLabelValues(registry).Set(1)
+	}
+}
+
+// catalogCached records that a scenario list of registry is cached
+func (c *registryCatalog) catalogCached(registry string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if status, ok := c.statuses[registry]; ok {
+		status.CatalogCached = true
+	}
+}
+
+// store keeps a successful response for the fallback
+func (c *registryCatalog) store(key string, response any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCatalogEntries {
+		oldest := ""
+		for k, entry := range c.entries {
+			if oldest == "" || entry.cachedAt.Before(c.entries[oldest].cachedAt) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = catalogEntry{response: response, cachedAt: time.Now()}
+}
+
+// lookup returns the cached response of key
+func (c *registryCatalog) lookup(key string) (catalogEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// Statuses returns the status of each registry called so far, sorted by registry
+func (c *registryCatalog) Statuses() []RegistryStatus {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	statuses := make([]RegistryStatus, 0, len(c.statuses))
+	for _, status := range c.statuses {
+		statuses = append(statuses, *status)
+	}
+	slices.SortFunc(statuses, func(a, b RegistryStatus) int { return strings.Compare(a.Registry, b.Registry) })
+	return statuses
+}
+
+// probeRegistry calls the default registry every interval until ctx is done, refreshing its
+// status and cached scenario list
+func (h *Handler) probeRegistry(ctx context.Context, interval time.Duration) {
+	logger := log.FromContext(ctx).WithName("registry-probe")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := h.fetchScenarios(nil, provider.Quay, true); err != nil {
+			logger.Info("Default scenario registry is unavailable", "error", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// callRegistry returns the result of call, or an error when it panicked: the quay.io
+// provider dereferences the response of requests that failed to reach the registry
+func callRegistry[T any](call func() (*T, error)) (result *T, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result, err = nil, fmt.Errorf("registry call failed: %v", recovered)
+		}
+	}()
+	return call()
+}
+
+// fetchScenarios returns the scenario list of a registry, recording the outcome. The
+// list cached less than registryCacheTTL ago is returned without calling the registry
+// unless refresh is set.
+func (h *Handler) fetchScenarios(registry *models.RegistryV2, mode provider.Mode, refresh bool) (*ScenariosResponse, error) {
+	key := catalogKey(registry, "scenarios")
+	if !refresh {
+		if entry, ok := h.registries.fresh(key, h.registryCacheTTL); ok {
+			if response, ok := entry.response.(ScenariosResponse); ok {
+				return &response, nil
+			}
+		}
+	}
+
+	scenarioProvider, err := h.scenarioProvider(mode)
+	if err != nil {
+		return nil, err
+	}
+	scenarioTags, err := callRegistry(func() (*[]models.ScenarioTag, error) {
+		return scenarioProvider.GetRegistryImages(registry)
+	})
+	name := registryName(registry)
+	h.registries.observe(name, err)
+	if err != nil {
+		return nil, &RegistryUnavailableError{Registry: name, Err: err}
+	}
+
+	scenarios := make([]ScenarioTag, 0)
+	if scenarioTags != nil {
+		for _, tag := range *scenarioTags {
+			scenarios = append(scenarios, ScenarioTag{
+				Name:         tag.Name,
+				Digest:       tag.Digest,
+				Size:         tag.Size,
+				LastModified: tag.LastModified,
+			})
+		}
+	}
+	response := &ScenariosResponse{Scenarios: scenarios}
+	h.registries.store(key, *response)
+	h.registries.catalogCached(name)
+	return response, nil
+}
+
+// fetchScenarioDetail returns the detail of a scenario, or its global environment, from
+// get, recording the outcome; request names the call in the cached catalog. Returns nil
+// when the registry does not know the scenario. Like fetchScenarios, a response cached
+// less than registryCacheTTL ago is returned unless refresh is set.
+func (h *Handler) fetchScenarioDetail(registry *models.RegistryV2, mode provider.Mode, request string, refresh bool,
+	get func(provider.ScenarioDataProvider) (*models.ScenarioDetail, error)) (*ScenarioDetailResponse, error) {
+	key := catalogKey(registry, request)
+	if !refresh {
+		if entry, ok := h.registries.fresh(key, h.registryCacheTTL); ok {
+			if response, ok := entry.response.(ScenarioDetailResponse); ok {
+				return &response, nil
+			}
+		}
+	}
+
+	scenarioProvider, err := h.scenarioProvider(mode)
+	if err != nil {
+		return nil, err
+	}
+	detail, err := callRegistry(func() (*models.ScenarioDetail, error) {
+		return get(scenarioProvider)
+	})
+	name := registryName(registry)
+	h.registries.observe(name, err)
+	if err != nil {
+		return nil, &RegistryUnavailableError{Registry: name, Err: err}
+	}
+	if detail == nil {
+		return nil, nil
+	}
+
+	response := &ScenarioDetailResponse{
+		Name:         detail.Name,
+		Digest:       detail.Digest,
+		Size:         detail.Size,
+		LastModified: detail.LastModified,
+		Title:        detail.Title,
+		Description:  detail.Description,
+		Fields:       convertInputFields(detail.Fields),
+	}
+	h.registries.store(key, *response)
+	return response, nil
+}
+
+// writeRegistryFallback answers a request whose registry call failed with err: the
+// cached response of key marked stale when the registry is unavailable, or an error
+func (h *Handler) writeRegistryFallback(w http.ResponseWriter, r *http.Request, key string, err error) {
+	logger := log.FromContext(r.Context())
+	var unavailable *RegistryUnavailableError
+	if !errors.As(err, &unavailable) {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgRegistryRequestFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	entry, ok := h.registries.lookup(key)
+	if !ok {
+		logger.Error(err, "Scenario registry unavailable, no cached catalog to serve")
+		w.Header().Set("Retry-After", strconv.Itoa(int(registryRetryAfter.Seconds())))
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "registry_unavailable", MsgRegistryUnavailable,
+			i18n.Params{"registry": unavailable.Registry, "error": unavailable.Err.Error()})
+		return
+	}
+
+	logger.Info("Scenario registry unavailable, serving cached catalog",
+		"registry", unavailable.Registry, "cachedAt", entry.cachedAt, "error", unavailable.Err.Error())
+	cachedAt := entry.cachedAt.UTC()
+	staleness := CatalogStaleness{Stale: true, CachedAt: &cachedAt, RegistryError: unavailable.Error()}
+	w.Header().Set(CatalogStaleHeader, "true")
+	w.Header().Set("Age", strconv.Itoa(int(time.Since(entry.cachedAt).Seconds())))
+	switch response := entry.response.(type) {
+	case ScenariosResponse:
+		response.CatalogStaleness = staleness
+		writeJSON(w, http.StatusOK, response)
+	case ScenarioDetailResponse:
+		response.CatalogStaleness = staleness
+		writeJSON(w, http.StatusOK, response)
+	}
+}
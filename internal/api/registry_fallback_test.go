@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/krkn-chaos/krknctl/pkg/provider"
+	"github.com/krkn-chaos/krknctl/pkg/provider/models"
+	"github.com/krkn-chaos/krknctl/pkg/typing"
+)
+
+// fakeScenarioProvider answers the scenario endpoints, or fails with err, and counts the
+// registry calls
+type fakeScenarioProvider struct {
+	provider.ScenarioDataProvider
+	err    error
+	calls  int
+	fields []typing.InputField
+}
+
+func (p *fakeScenarioProvider) GetRegistryImages(_ *models.RegistryV2) (*[]models.ScenarioTag, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &[]models.ScenarioTag{{Name: "pod-scenarios"}, {Name: "node-scenarios"}}, nil
+}
+
+func (p *fakeScenarioProvider) GetScenarioDetail(scenario string, _ *models.RegistryV2) (*models.ScenarioDetail, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	detail := &models.ScenarioDetail{}
+	detail.Name = scenario
+	detail.Title = "Pod Scenarios"
+	detail.Fields = p.fields
+	return detail, nil
+}
+
+func setupRegistryTestHandler(scenarioProvider *fakeScenarioProvider) *Handler {
+	handler := setupTestHandler()
+	handler.registries = newRegistryCatalog()
+	handler.scenarioProvider = func(provider.Mode) (provider.ScenarioDataProvider, error) {
+		return scenarioProvider, nil
+	}
+	return handler
+}
+
+func postScenarios(handler *Handler, body *ScenariosRequest) *httptest.ResponseRecorder {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(http.MethodPost, ScenariosPath, bytes.NewBuffer(payload))
+	w := httptest.NewRecorder()
+	handler.PostScenarios(w, req)
+	return w
+}
+
+func TestPostScenarios_ServesCachedCatalogWhileRegistryUnavailable(t *testing.T) {
+	scenarioProvider := &fakeScenarioProvider{}
+	handler := setupRegistryTestHandler(scenarioProvider)
+
+	w := postScenarios(handler, nil)
+	if w.Code != http.StatusOK || w.Header().Get(CatalogStaleHeader) != "" {
+		t.Fatalf("Expected a fresh catalog, got %d %v. Body: %s", w.Code, w.Header(), w.Body.String())
+	}
+
+	scenarioProvider.err = errors.New("429 Too Many Requests")
+	w = postScenarios(handler, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected the cached catalog, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get(CatalogStaleHeader) != "true" || w.Header().Get("Age") == "" {
+		t.Errorf("Expected the stale headers, got %v", w.Header())
+	}
+	var response ScenariosResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !response.Stale || response.CachedAt == nil || response.RegistryError == "" || len(response.Scenarios) != 2 {
+		t.Errorf("Expected the stale catalog with its error, got %+v", response)
+	}
+
+	statuses := handler.registries.Statuses()
+	if len(statuses) != 1 || statuses[0].Registry != DefaultRegistry || statuses[0].Available ||
+		statuses[0].LastAvailableAt == nil {
+		t.Errorf("Expected the default registry to be unavailable, got %+v", statuses)
+	}
+
+	// The catalog of a private registry is only served back with the same credentials
+	token, other := "token", "other"
+	private := &ScenariosRequest{RegistryURL: "registry.example.com", ScenarioRepository: "krkn", Token: &token}
+	scenarioProvider.err = nil
+	if w := postScenarios(handler, private); w.Code != http.StatusOK {
+		t.Fatalf("Expected the private catalog, got %d", w.Code)
+	}
+	scenarioProvider.err = errors.New("connection refused")
+	if w := postScenarios(handler, private); w.Code != http.StatusOK || w.Header().Get(CatalogStaleHeader) != "true" {
+		t.Errorf("Expected the cached private catalog, got %d", w.Code)
+	}
+	private.Token = &other
+	if w := postScenarios(handler, private); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d with other credentials, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestPostScenarioDetail_RegistryUnavailableWithoutCache(t *testing.T) {
+	handler := setupRegistryTestHandler(&fakeScenarioProvider{err: errors.New("503 Service Unavailable")})
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add(ParamScenarioName, "pod-scenarios")
+	req := httptest.NewRequest(http.MethodPost, ScenariosDetailPath+"/pod-scenarios", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+	w := httptest.NewRecorder()
+	handler.PostScenarioDetail(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusServiceUnavailable, w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected a Retry-After header, got %v", w.Header())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Error != "registry_unavailable" || response.Code != MsgRegistryUnavailable {
+		t.Errorf("Unexpected error %+v", response)
+	}
+}
+
+func TestRegistryCatalog_StatusCatalogCached(t *testing.T) {
+	scenarioProvider := &fakeScenarioProvider{err: errors.New("dial tcp: i/o timeout")}
+	handler := setupRegistryTestHandler(scenarioProvider)
+
+	if _, err := handler.fetchScenarios(nil, provider.Quay, false); err == nil {
+		t.Fatal("Expected the registry to be unavailable")
+	}
+	statuses := handler.registries.Statuses()
+	if len(statuses) != 1 || statuses[0].Available || statuses[0].CatalogCached {
+		t.Fatalf("Expected an unavailable registry without catalog, got %+v", statuses)
+	}
+
+	// Once a catalog is cached it stays reported while the registry is down
+	scenarioProvider.err = nil
+	if _, err := handler.fetchScenarios(nil, provider.Quay, false); err != nil {
+		t.Fatalf("fetchScenarios failed: %v", err)
+	}
+	scenarioProvider.err = errors.New("dial tcp: i/o timeout")
+	if _, err := handler.fetchScenarios(nil, provider.Quay, true); err == nil {
+		t.Fatal("Expected the registry to be unavailable")
+	}
+	statuses = handler.registries.Statuses()
+	if len(statuses) != 1 || statuses[0].Available || !statuses[0].CatalogCached {
+		t.Errorf("Expected an unavailable registry with a cached catalog, got %+v", statuses)
+	}
+}
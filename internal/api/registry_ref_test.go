@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/krkn-chaos/krknctl/pkg/provider"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func createTestRegistry(t *testing.T, handler *Handler) {
+	t.Helper()
+	ctx := context.Background()
+	if err := handler.client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-credentials", Namespace: handler.namespace},
+		Data:       map[string][]byte{krknv1alpha1.RegistryTokenKey: []byte("t0ken")},
+	}); err != nil {
+		t.Fatalf("Failed to create secret: %v", err)
+	}
+	if err := handler.client.Create(ctx, &krknv1alpha1.KrknRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: handler.namespace},
+		Spec: krknv1alpha1.KrknRegistrySpec{
+			RegistryURL:        "registry.example.com",
+			ScenarioRepository: "chaos/krkn-hub",
+			CredentialsSecret:  "internal-credentials",
+			SkipTLS:            true,
+		},
+	}); err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+}
+
+func TestResolveRegistry(t *testing.T) {
+	handler := setupTestHandler()
+	createTestRegistry(t, handler)
+	ctx := context.Background()
+
+	registry, mode, err := handler.resolveRegistry(ctx, ScenariosRequest{RegistryRef: "internal"})
+	if err != nil {
+		t.Fatalf("resolveRegistry failed: %v", err)
+	}
+	if mode != provider.Private || registry.RegistryURL != "registry.example.com" ||
+		registry.ScenarioRepository != "chaos/krkn-hub" || !registry.SkipTLS ||
+		registry.Token == nil || *registry.Token != "t0ken" || registry.Username != nil {
+		t.Errorf("Unexpected registry %+v in mode %v", registry, mode)
+	}
+
+	for name, req := range map[string]ScenariosRequest{
+		"unknown registry":    {RegistryRef: "missing"},
+		"inline registry too": {RegistryRef: "internal", RegistryURL: "quay.io", ScenarioRepository: "krkn-chaos/krkn-hub"},
+	} {
+		if _, _, err := handler.resolveRegistry(ctx, req); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPostScenarioRun_RegistryRef(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	createTestRegistry(t, handler)
+
+	post := func(registryRef string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["test-cluster"]},
+			"scenarioImage": "registry.example.com/chaos/krkn-hub:pod-scenarios",
+			"scenarioName": "pod-scenarios",
+			"registryRef": "`+registryRef+`"
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	if w := post("missing"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown registry, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w := post("internal")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	key := client.ObjectKey{Name: response.ScenarioRunName, Namespace: handler.namespace}
+	if err := handler.client.Get(context.Background(), key, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	spec := scenarioRun.Spec
+	if spec.RegistryRef != "internal" || spec.RegistryURL != "registry.example.com" ||
+		spec.ScenarioRepository != "chaos/krkn-hub" || spec.Token != "" {
+		t.Errorf("Unexpected registry of the run %+v", spec)
+	}
+}
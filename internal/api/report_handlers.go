@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"html/template"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// Formats of the scenario run report
+const (
+	ReportFormatJSON = "json"
+	ReportFormatHTML = "html"
+)
+
+//go:embed templates/scenario_run_report.html
+var scenarioRunReportHTML string
+
+// scenarioRunReportTemplate renders the self-contained HTML report: styles are inline
+// and nothing is loaded from elsewhere, so the page can be attached as is
+var scenarioRunReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration":   func(seconds int64) string { return (time.Duration(seconds) * time.Second).String() },
+	"timestamp":  func(t time.Time) string { return t.UTC().Format(time.RFC3339) },
+	"sortedKeys": func(m map[string]string) []string { return slices.Sorted(maps.Keys(m)) },
+}).Parse(scenarioRunReportHTML))
+
+// GetScenarioRunReport handles GET /api/v1/scenarios/run/{scenarioRunName}/report
+// It returns the report of the run for incident reviews: its scenario parameters, with
+// credentials redacted, and the phase, duration, failure reason, retries, krkn result,
+// log and artifact links of each cluster job the user can view. ?format=html returns a
+// self-contained HTML page instead of JSON.
+func (h *Handler) GetScenarioRunReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = ReportFormatJSON
+	}
+	if format != ReportFormatJSON && format != ReportFormatHTML {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidReportFormat,
+			i18n.Params{"format": format})
+		return
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+				i18n.Params{"name": string(scenarioRunName)})
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	jobs, err := h.visibleJobs(ctx, &scenarioRun)
+	switch {
+	case errors.Is(err, errScenarioRunForbidden):
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunJobsForbidden, nil)
+		return
+	case err != nil:
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	report := buildScenarioRunReport(&scenarioRun, jobs, time.Now().UTC())
+	if format == ReportFormatJSON {
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := scenarioRunReportTemplate.Execute(&page, report); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to render scenario run report", "scenarioRun", scenarioRun.Name)
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(page.Bytes())
+}
+
+// buildScenarioRunReport returns the report of scenarioRun with the cluster jobs jobs
+func buildScenarioRunReport(
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	jobs []krknv1alpha1.ClusterJobStatus,
+	now time.Time,
+) ScenarioRunReport {
+	report := ScenarioRunReport{
+		ScenarioRunName: scenarioRun.Name,
+		Title:           scenarioRun.Title(),
+		ScenarioName:    scenarioRun.Spec.ScenarioName,
+		ScenarioImage:   scenarioRun.Spec.ScenarioImage,
+		TemplateRef:     scenarioRun.Spec.TemplateRef,
+		CampaignRef:     scenarioRun.Spec.CampaignRef,
+		OwnerUserID:     scenarioRun.Spec.OwnerUserID,
+		Phase:           scenarioRun.Status.Phase,
+		CreatedAt:       scenarioRun.CreationTimestamp.UTC(),
+		Parameters: ScenarioRunReportParameters{
+			Environment:    scenarioRun.Spec.RedactedEnvironment(),
+			TimeoutSeconds: scenarioRun.Spec.TimeoutSeconds,
+		},
+		TotalTargets: len(jobs),
+		Clusters:     make([]ScenarioRunReportCluster, 0, len(jobs)),
+		GeneratedAt:  now,
+	}
+	if completion := scenarioRun.Status.CompletionTime; completion != nil {
+		completedAt := completion.UTC()
+		report.CompletedAt = &completedAt
+		report.DurationSeconds = int64(completedAt.Sub(report.CreatedAt).Seconds())
+	}
+	for _, file := range scenarioRun.Spec.Files {
+		report.Parameters.Files = append(report.Parameters.Files, file.MountPath)
+	}
+
+	for _, job := range jobs {
+		cluster := ScenarioRunReportCluster{
+			ProviderName:  job.ProviderName,
+			ClusterName:   job.ClusterName,
+			JobID:         job.JobID,
+			Phase:         job.Phase,
+			FailureReason: job.FailureReason,
+			Message:       job.Message,
+			RetryCount:    job.RetryCount,
+			Result:        job.Result,
+			LogsURL:       ScenariosRunPath + "/" + scenarioRun.Name + "/jobs/" + job.JobID + "/logs",
+			Artifacts:     job.Artifacts,
+		}
+		if job.StartTime != nil {
+			startTime := job.StartTime.UTC()
+			cluster.StartTime = &startTime
+		}
+		if job.CompletionTime != nil {
+			completionTime := job.CompletionTime.UTC()
+			cluster.CompletionTime = &completionTime
+			if cluster.StartTime != nil {
+				cluster.DurationSeconds = int64(completionTime.Sub(*cluster.StartTime).Seconds())
+			}
+		}
+		switch job.Phase {
+		case "Succeeded":
+			report.SuccessfulJobs++
+		case "Failed":
+			report.FailedJobs++
+		}
+		report.Clusters = append(report.Clusters, cluster)
+	}
+	return report
+}
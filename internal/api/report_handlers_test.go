@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestGetScenarioRunReport(t *testing.T) {
+	handler := setupTestHandler()
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(created.Add(10 * time.Second))
+	completed := metav1.NewTime(created.Add(5 * time.Minute))
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace, CreationTimestamp: metav1.NewTime(created)},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			ScenarioName:  "pod-scenarios",
+			ScenarioImage: "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+			RunNumber:     4,
+			Environment: map[string]string{
+				"NAMESPACE":   "<script>alert(1)</script>",
+				"ES_PASSWORD": "hunter2",
+			},
+			Files:          []krknv1alpha1.FileMount{{Name: "scenario.yaml", MountPath: "/home/krkn/scenario.yaml"}},
+			TimeoutSeconds: ptr.To[int64](600),
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			Phase:          "PartiallyFailed",
+			CompletionTime: &completed,
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{
+					ProviderName:   "krkn-operator",
+					ClusterName:    "cluster-1",
+					JobID:          "job-1",
+					Phase:          "Failed",
+					FailureReason:  "ContainerError",
+					RetryCount:     2,
+					StartTime:      &started,
+					CompletionTime: &completed,
+					Result:         &krknv1alpha1.ScenarioResult{ExitCode: 1, Summary: "1 of 1 scenarios failed"},
+					Artifacts:      []krknv1alpha1.UploadedArtifact{{Name: "scenario.log", URL: "https://s3.example.com/bucket/scenario.log"}},
+				},
+				{ProviderName: "krkn-operator", ClusterName: "cluster-2", JobID: "job-2", Phase: "Succeeded"},
+			},
+		},
+	}
+	if err := handler.client.Create(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+	path := ScenariosRunPath + "/run-1" + ScenarioRunReportSuffix
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, path, nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var report ScenarioRunReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if report.Title != "pod-scenarios #4" || report.DurationSeconds != 300 || report.FailedJobs != 1 || report.SuccessfulJobs != 1 {
+		t.Errorf("unexpected report summary %+v", report)
+	}
+	if report.Parameters.Environment["ES_PASSWORD"] != krknv1alpha1.RedactedEnvValue {
+		t.Errorf("expected credentials to be redacted, got %v", report.Parameters.Environment)
+	}
+	if len(report.Parameters.Files) != 1 || report.Parameters.Files[0] != "/home/krkn/scenario.yaml" {
+		t.Errorf("expected the mount path of the file, got %v", report.Parameters.Files)
+	}
+	failed := report.Clusters[0]
+	if failed.DurationSeconds != 290 || failed.RetryCount != 2 || failed.FailureReason != "ContainerError" ||
+		failed.LogsURL != ScenariosRunPath+"/run-1/jobs/job-1/logs" || len(failed.Artifacts) != 1 {
+		t.Errorf("unexpected cluster outcome %+v", failed)
+	}
+
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, path+"?format=html", nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("expected an HTML report, got %s", contentType)
+	}
+	page := w.Body.String()
+	for _, want := range []string{"pod-scenarios #4", "cluster-1", "ContainerError", "https://s3.example.com/bucket/scenario.log",
+		"&lt;script&gt;"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected the HTML report to contain %q", want)
+		}
+	}
+	for _, unwanted := range []string{"hunter2", "<script>", "<link", "src="} {
+		if strings.Contains(page, unwanted) {
+			t.Errorf("expected the HTML report not to contain %q", unwanted)
+		}
+	}
+
+	for _, tt := range []struct {
+		path       string
+		wantStatus int
+	}{
+		{path: path + "?format=pdf", wantStatus: http.StatusBadRequest},
+		{path: ScenariosRunPath + "/missing" + ScenarioRunReportSuffix, wantStatus: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, tt.path, nil)))
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: expected status %d, got %d. Body: %s", tt.path, tt.wantStatus, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// GetScenarioRunResults handles GET /api/v1/scenarios/run/{scenarioRunName}/results
+// It returns the outcome krkn reported for each visible cluster job: the exit code once
+// the job finished, then the scenario outcomes and failed checks parsed from the krkn
+// telemetry once the job log is collected.
+func (h *Handler) GetScenarioRunResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+				i18n.Params{"name": string(scenarioRunName)})
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	jobs, err := h.visibleJobs(ctx, &scenarioRun)
+	switch {
+	case errors.Is(err, errScenarioRunForbidden):
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunJobsForbidden, nil)
+		return
+	case err != nil:
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	results := make([]ClusterJobResultResponse, len(jobs))
+	for i, job := range jobs {
+		results[i] = ClusterJobResultResponse{
+			ProviderName:  job.ProviderName,
+			ClusterName:   job.ClusterName,
+			ClusterID:     job.ClusterID,
+			JobID:         job.JobID,
+			Phase:         job.Phase,
+			FailureReason: job.FailureReason,
+			Result:        job.Result,
+			Artifacts:     job.Artifacts,
+			Cleanup:       job.Cleanup,
+		}
+	}
+	writeJSON(w, http.StatusOK, ScenarioRunResultsResponse{
+		ScenarioRunName: scenarioRun.Name,
+		Phase:           scenarioRun.Status.Phase,
+		Results:         results,
+	})
+}
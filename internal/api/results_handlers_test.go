@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestGetScenarioRunResults(t *testing.T) {
+	handler := setupTestHandler()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			Phase: "Running",
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{
+					ProviderName:  "krkn-operator",
+					ClusterName:   "cluster-1",
+					JobID:         "job-1",
+					Phase:         "Failed",
+					FailureReason: "ContainerError",
+					Result: &krknv1alpha1.ScenarioResult{
+						ExitCode:           1,
+						Summary:            "1 of 1 scenarios failed, 1 failed checks (exit code 1)",
+						Scenarios:          []krknv1alpha1.ScenarioOutcome{{Scenario: "pod.yaml", Type: "pod_disruption_scenarios", ExitStatus: 1}},
+						FailedChecks:       []string{"health check https://api.example.com failed"},
+						TelemetryCollected: true,
+					},
+				},
+				{ProviderName: "krkn-operator", ClusterName: "cluster-2", JobID: "job-2", Phase: "Running"},
+			},
+		},
+	}
+	if err := handler.client.Create(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	path := ScenariosRunPath + "/run-1" + ScenarioRunResultsSuffix
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, path, nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response ScenarioRunResultsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Results) != 2 {
+		t.Fatalf("expected the results of 2 jobs, got %+v", response.Results)
+	}
+	failed := response.Results[0]
+	if failed.Result == nil || failed.Result.ExitCode != 1 || len(failed.Result.FailedChecks) != 1 ||
+		failed.FailureReason != "ContainerError" {
+		t.Errorf("expected the krkn result of the failed job, got %+v", failed)
+	}
+	if response.Results[1].Result != nil {
+		t.Errorf("expected no result for the running job, got %+v", response.Results[1].Result)
+	}
+
+	w = httptest.NewRecorder()
+	path = ScenariosRunPath + "/missing" + ScenarioRunResultsSuffix
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, path, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d. Body: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
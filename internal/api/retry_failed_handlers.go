@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// retryRejection is why a retry wave cannot be recorded for a run
+type retryRejection struct {
+	status int
+	code   string
+	params i18n.Params
+}
+
+func (e *retryRejection) Error() string {
+	return e.code
+}
+
+// RetryFailedClusters handles POST /api/v1/scenarios/run/{scenarioRunName}/retry-failed
+// It records a retry wave for the clusters of a completed (Failed or PartiallyFailed) run
+// whose job ended Failed or MaxRetriesExceeded. Once the wave is due (immediately, after
+// delay or at scheduledAt) the controller creates their new jobs with reset retry counters.
+// Non-admin users need the run permission on every retried cluster, and none of them may
+// be an archived target.
+func (h *Handler) RetryFailedClusters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+
+	claims := auth.GetClaimsFromContext(ctx)
+	if claims == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
+		return
+	}
+
+	// The body is optional: without one the failed clusters are retried right away
+	var req RetryFailedRequest
+	if err := h.decodeRequestBody(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	scheduledAt, ok := req.scheduledAt(now)
+	if !ok {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgRetryScheduleInvalid, nil)
+		return
+	}
+
+	key := client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+		h.writeScenarioRunFetchError(w, r, string(scenarioRunName), err)
+		return
+	}
+	if !h.checkScenarioRunAccess(w, r, &scenarioRun) {
+		return
+	}
+
+	var wave krknv1alpha1.RetryWave
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := h.client.Get(ctx, key, &scenarioRun); err != nil {
+			return err
+		}
+		failedJobs, rejection := retryableJobs(&scenarioRun)
+		if rejection != nil {
+			return rejection
+		}
+		if !auth.IsAdmin(ctx) {
+			userGroups, err := groupauth.GetUserGroups(ctx, h.client, claims.UserID, h.namespace)
+			if err != nil {
+				return err
+			}
+			if len(h.filterJobsByPermission(failedJobs, ctx, userGroups, groupauth.ActionRun)) != len(failedJobs) {
+				return &retryRejection{status: http.StatusForbidden, code: MsgRetryForbidden}
+			}
+		}
+
+		clusters := make([]string, len(failedJobs))
+		retried := map[string][]string{}
+		for i, job := range failedJobs {
+			clusters[i] = job.ClusterName
+			retried[job.ProviderName] = append(retried[job.ProviderName], job.ClusterName)
+		}
+		// Archived targets are blocked from runs, retries included
+		archived, err := h.archivedClusters(ctx, retried)
+		if err != nil {
+			return err
+		}
+		if len(archived) > 0 {
+			return &retryRejection{status: http.StatusConflict, code: MsgTargetsArchived,
+				params: i18n.Params{"clusters": strings.Join(archived, ", ")}}
+		}
+		wave = krknv1alpha1.RetryWave{
+			Number:      len(scenarioRun.Status.RetryWaves) + 1,
+			RequestedBy: claims.UserID,
+			RequestedAt: metav1.NewTime(now),
+			ScheduledAt: metav1.NewTime(scheduledAt),
+			Clusters:    clusters,
+		}
+		scenarioRun.Status.RetryWaves = append(scenarioRun.Status.RetryWaves, wave)
+		return h.client.Status().Update(ctx, &scenarioRun)
+	})
+
+	var rejection *retryRejection
+	if errors.As(err, &rejection) {
+		errType := "conflict"
+		if rejection.status == http.StatusForbidden {
+			errType = "forbidden"
+		}
+		writeLocalizedError(w, r, rejection.status, errType, rejection.code, rejection.params)
+		return
+	}
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgRetryScheduleFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, retryWaveResponse(wave))
+}
+
+// retryableJobs returns the jobs of a completed run that a retry wave would retry
+func retryableJobs(scenarioRun *krknv1alpha1.KrknScenarioRun) ([]krknv1alpha1.ClusterJobStatus, *retryRejection) {
+	name := scenarioRun.Name
+	if scenarioRun.Spec.CancelRequested {
+		return nil, &retryRejection{status: http.StatusConflict, code: MsgRetryRunCancelled,
+			params: i18n.Params{"name": name}}
+	}
+	if phase := scenarioRun.Status.Phase; phase != "Failed" && phase != "PartiallyFailed" {
+		return nil, &retryRejection{status: http.StatusConflict, code: MsgRetryRunNotCompleted,
+			params: i18n.Params{"name": name, "phase": phase}}
+	}
+	for _, wave := range scenarioRun.Status.RetryWaves {
+		if wave.StartTime == nil {
+			return nil, &retryRejection{status: http.StatusConflict, code: MsgRetryWavePending,
+				params: i18n.Params{"name": name, "number": strconv.Itoa(wave.Number)}}
+		}
+	}
+
+	var failedJobs []krknv1alpha1.ClusterJobStatus
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		if job.Phase == statemachine.JobFailed || job.Phase == statemachine.JobMaxRetriesExceeded {
+			failedJobs = append(failedJobs, job)
+		}
+	}
+	if len(failedJobs) == 0 {
+		return nil, &retryRejection{status: http.StatusConflict, code: MsgRetryNoFailedClusters,
+			params: i18n.Params{"name": name}}
+	}
+	return failedJobs, nil
+}
+
+// scheduledAt resolves when the wave is due. ok is false for an invalid or ambiguous schedule.
+func (req RetryFailedRequest) scheduledAt(now time.Time) (time.Time, bool) {
+	switch {
+	case req.Delay != "" && req.ScheduledAt != nil:
+		return time.Time{}, false
+	case req.Delay != "":
+		delay, err := time.ParseDuration(req.Delay)
+		if err != nil || delay < 0 {
+			return time.Time{}, false
+		}
+		return now.Add(delay), true
+	case req.ScheduledAt != nil:
+		return *req.ScheduledAt, true
+	default:
+		return now, true
+	}
+}
+
+func retryWaveResponse(wave krknv1alpha1.RetryWave) RetryWaveResponse {
+	return RetryWaveResponse{
+		Number:      wave.Number,
+		RequestedBy: wave.RequestedBy,
+		RequestedAt: wave.RequestedAt.Time,
+		ScheduledAt: wave.ScheduledAt.Time,
+		Clusters:    wave.Clusters,
+		StartTime:   convertMetaTime(wave.StartTime),
+	}
+}
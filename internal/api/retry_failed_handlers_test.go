@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// setupRetryTestHandler creates a test Handler whose fake client serves the status
+// subresource of scenario runs, as retry waves are recorded with Status().Update
+func setupRetryTestHandler() *Handler {
+	handler := setupTestHandler()
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	handler.client = fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&krknv1alpha1.KrknScenarioRun{}).
+		Build()
+	return handler
+}
+
+// createCompletedRun creates a scenario run and then sets its status
+func createCompletedRun(t *testing.T, handler *Handler, name, phase string, jobs ...krknv1alpha1.ClusterJobStatus) {
+	t.Helper()
+	run := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Spec:       krknv1alpha1.KrknScenarioRunSpec{ScenarioName: "pod-scenarios"},
+	}
+	if err := handler.client.Create(context.Background(), run); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+	run.Status = krknv1alpha1.KrknScenarioRunStatus{Phase: phase, ClusterJobs: jobs}
+	if err := handler.client.Status().Update(context.Background(), run); err != nil {
+		t.Fatalf("failed to set scenario run status: %v", err)
+	}
+}
+
+func TestRetryFailedClusters(t *testing.T) {
+	handler := setupRetryTestHandler()
+	createCompletedRun(t, handler, "run-1", "PartiallyFailed",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobSucceeded},
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-b", JobID: "job-b", Phase: statemachine.JobFailed},
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-c", JobID: "job-c", Phase: statemachine.JobMaxRetriesExceeded, RetryCount: 3},
+	)
+
+	before := time.Now()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/run-1"+ScenarioRunRetryFailedSuffix, strings.NewReader(`{"delay":"10m"}`))
+	serveAPI(handler, w, withAdminClaims(req))
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp RetryWaveResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Number != 1 || resp.RequestedBy != "admin@example.com" || !slices.Equal(resp.Clusters, []string{"cluster-b", "cluster-c"}) {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if resp.ScheduledAt.Before(before.Add(10 * time.Minute)) {
+		t.Errorf("expected the wave to be scheduled 10m from now, got %v", resp.ScheduledAt)
+	}
+
+	var stored krknv1alpha1.KrknScenarioRun
+	_ = handler.client.Get(context.Background(), client.ObjectKey{Name: "run-1", Namespace: "test-namespace"}, &stored)
+	if len(stored.Status.RetryWaves) != 1 || stored.Status.RetryWaves[0].StartTime != nil {
+		t.Fatalf("expected one pending retry wave in status, got %+v", stored.Status.RetryWaves)
+	}
+
+	// A second retry is refused while the first one has not started
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/run-1"+ScenarioRunRetryFailedSuffix, nil)
+	serveAPI(handler, w, withAdminClaims(req))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 while a wave is pending, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRetryFailedClusters_Rejected(t *testing.T) {
+	handler := setupRetryTestHandler()
+	createCompletedRun(t, handler, "running", "Running",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobFailed})
+	createCompletedRun(t, handler, "cancelled-only", "Failed",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobCancelled})
+	createCompletedRun(t, handler, "failed", "Failed",
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-a", JobID: "job-a", Phase: statemachine.JobFailed})
+	createCompletedRun(t, handler, "failed-on-archived", "Failed",
+		krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "archived", JobID: "job-a", Phase: statemachine.JobFailed})
+	archived := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "archived", Namespace: "test-namespace"},
+		Spec:       krknv1alpha1.KrknOperatorTargetSpec{UUID: "uuid-archived", ClusterName: "archived"},
+		Status:     krknv1alpha1.KrknOperatorTargetStatus{Archived: true},
+	}
+	if err := handler.client.Create(context.Background(), archived); err != nil {
+		t.Fatalf("failed to create target: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		run        string
+		body       string
+		wantStatus int
+	}{
+		{name: "run not completed", run: "running", wantStatus: http.StatusConflict},
+		{name: "no failed clusters", run: "cancelled-only", wantStatus: http.StatusConflict},
+		{name: "unknown run", run: "missing", wantStatus: http.StatusNotFound},
+		{name: "archived target", run: "failed-on-archived", wantStatus: http.StatusConflict},
+		{name: "invalid delay", run: "failed", body: `{"delay":"soon"}`, wantStatus: http.StatusBadRequest},
+		{name: "negative delay", run: "failed", body: `{"delay":"-5m"}`, wantStatus: http.StatusBadRequest},
+		{name: "delay and scheduledAt", run: "failed", body: `{"delay":"5m","scheduledAt":"2030-01-01T00:00:00Z"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, ScenariosRunPath+"/"+tt.run+ScenarioRunRetryFailedSuffix, strings.NewReader(tt.body))
+			serveAPI(handler, w, withAdminClaims(req))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
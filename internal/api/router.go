@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/internal/ui"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/requestid"
+)
+
+// Path parameter names used in route patterns
+const (
+	ParamUUID            = "uuid"
+	ParamScenarioName    = "scenarioName"
+	ParamScenarioRunName = "scenarioRunName"
+	ParamJobID           = "jobId"
+	ParamUserID          = "userId"
+	ParamGroupName       = "groupName"
+	ParamCampaignName    = "campaignName"
+	ParamProviderName    = "name"
+	ParamClusterName     = "clusterName"
+)
+
+// Routes builds the REST API router.
+//
+// Every route is registered here, together with the middleware it runs through:
+// request IDs, metrics, usage counts, request logging, CORS and trailing-slash normalization apply to all routes,
+// authenticate and audit logging to everything except the public endpoints.
+func (h *Handler) Routes(authenticate func(http.Handler) http.Handler) http.Handler {
+	router := chi.NewRouter()
+	router.Use(
+		requestid.Middleware(log.Log.WithName("api")),
+		metricsMiddleware(router),
+		h.usageMiddleware(router),
+		loggingMiddleware,
+		corsMiddleware(h.cors),
+		middleware.StripSlashes,
+	)
+	router.NotFound(h.notFound)
+	router.MethodNotAllowed(h.methodNotAllowed)
+
+	// Public endpoints (no auth required)
+	router.Get(AuthIsRegistered, h.IsRegistered)
+	router.With(h.auditMiddleware).Post(AuthRegister, h.Register)
+	router.With(h.auditMiddleware).Post(AuthLogin, h.Login)
+	router.Get(OpenAPIPath, h.GetOpenAPISpec)
+	router.Get(DocsStateMachinePath, h.GetStateMachineDocs)
+	router.Get(HealthzPath, h.Healthz)
+	router.Get(HealthPath, h.Healthz) // Deprecated alias of HealthzPath
+	router.Get(ReadyzPath, h.Readyz)
+
+	// Embedded UI: only the login page is public, the dashboard is served behind the
+	// API authentication with the JWT of the UI session cookie
+	if h.ui != nil {
+		for _, asset := range ui.PublicAssets {
+			router.Get(UIPath+asset, h.ui.ServeHTTP)
+		}
+		router.With(authenticate).Post(UIPath+UISessionSuffix, h.CreateUISession)
+		router.Delete(UIPath+UISessionSuffix, h.DeleteUISession)
+		router.With(uiAuth(authenticate)).Get(UIPath, h.ui.ServeHTTP)
+		router.With(uiAuth(authenticate)).Get(UIPath+"/*", h.ui.ServeHTTP)
+	}
+
+	// WebSocket log and status streaming - handles JWT auth internally via Sec-WebSocket-Protocol
+	router.Get(ScenariosRunPath+"/{"+ParamScenarioRunName+"}/jobs/{"+ParamJobID+"}/logs", h.GetScenarioRunLogs)
+	router.Get(ScenariosRunPath+"/{"+ParamScenarioRunName+"}"+ScenarioRunLogsSuffix, h.StreamScenarioRunLogs)
+	router.Get(ScenariosRunPath+"/{"+ParamScenarioRunName+"}"+ScenarioRunWatchSuffix, h.WatchScenarioRunStatus)
+
+	// Diagnostics and audit log reads are not themselves audited
+	router.With(authenticate).Get(DiagnosticsPath, h.GetDiagnostics)
+	router.With(authenticate).Get(AuditPath, h.GetAuditLog)
+	router.With(authenticate).Get(UsagePath, h.GetUsage)
+
+	router.Group(func(r chi.Router) {
+		r.Use(authenticate, h.auditMiddleware)
+
+		r.Get(ClustersPath, h.GetClusters)
+		r.Get(ClusterIDsPath, h.GetClusterIDs)
+		r.Get(NodesPath, h.GetNodes)
+
+		// Legacy targets (KrknTargetRequest)
+		r.Post(TargetsPath, h.PostTarget)
+		r.Get(TargetsPath+"/{"+ParamUUID+"}", h.GetTargetByUUID)
+
+		// Scenarios
+		r.Post(ScenariosPath, h.PostScenarios)
+		r.Post(ScenariosDetailPath+"/{"+ParamScenarioName+"}", h.PostScenarioDetail)
+		r.Post(ScenariosGlobalsPath+"/{"+ParamScenarioName+"}", h.PostScenarioGlobals)
+		r.Get(ScenariosFavoritesPath, h.ListFavoriteScenarios)
+		r.Put(ScenariosFavoritesPath+"/{"+ParamScenarioName+"}", h.StarScenario)
+		r.Delete(ScenariosFavoritesPath+"/{"+ParamScenarioName+"}", h.UnstarScenario)
+
+		// Scenario runs
+		r.Route(ScenariosRunPath, func(r chi.Router) {
+			r.Post("/", h.PostScenarioRun)
+			r.Get("/", h.ListScenarioRuns)
+			r.Post("/lint", h.LintScenarioRun)
+			r.Get("/jobs/{"+ParamJobID+"}", h.GetSingleJob)
+			r.Get("/jobs/{"+ParamJobID+"}"+JobEventsSuffix, h.StreamJobEvents)
+			r.Delete("/jobs/{"+ParamJobID+"}", h.DeleteSingleJob)
+			r.Get("/{"+ParamScenarioRunName+"}", h.GetScenarioRunStatus)
+			r.Delete("/{"+ParamScenarioRunName+"}", h.DeleteScenarioRunComplete)
+			r.Post("/{"+ParamScenarioRunName+"}"+ScenarioRunSubscribeSuffix, h.SubscribeScenarioRun)
+			r.Post("/{"+ParamScenarioRunName+"}"+ScenarioRunUnsubscribeSuffix, h.UnsubscribeScenarioRun)
+			r.Post("/{"+ParamScenarioRunName+"}"+ScenarioRunRetryFailedSuffix, h.RetryFailedClusters)
+			r.Get("/{"+ParamScenarioRunName+"}"+ScenarioRunResultsSuffix, h.GetScenarioRunResults)
+			r.Get("/{"+ParamScenarioRunName+"}"+ScenarioRunReportSuffix, h.GetScenarioRunReport)
+			r.Get("/{"+ParamScenarioRunName+"}"+ScenarioRunClustersSegment+"/{"+ParamClusterName+"}"+ClusterMetricsSuffix, h.ProxyClusterMetrics)
+			r.Get("/{"+ParamScenarioRunName+"}"+ScenarioRunClustersSegment+"/{"+ParamClusterName+"}"+ClusterEffectiveSpecSuffix, h.GetClusterEffectiveSpec)
+			r.Delete("/{"+ParamScenarioRunName+"}"+ScenarioRunClustersSegment+"/{"+ParamClusterName+"}", h.CancelClusterJob)
+		})
+		r.Get(DashboardActiveRunsPath, h.GetActiveRunsOverview)
+
+		// Run history - admins see every run, users their own
+		r.Get(HistoryPath, h.ListRunHistory)
+		r.Get(HistoryPath+"/{"+ParamScenarioRunName+"}", h.GetRunHistory)
+
+		// Campaigns - any user, PATCH/DELETE: the facilitator or an admin
+		r.Route(CampaignsPath, func(r chi.Router) {
+			r.Get("/", h.ListCampaigns)
+			r.Post("/", h.CreateCampaign)
+			r.Get("/{"+ParamCampaignName+"}", h.GetCampaign)
+			r.Patch("/{"+ParamCampaignName+"}", h.UpdateCampaign)
+			r.Delete("/{"+ParamCampaignName+"}", h.DeleteCampaign)
+			r.Get("/{"+ParamCampaignName+"}"+CampaignReportSuffix, h.GetCampaignReport)
+		})
+
+		// Break-glass exemptions for restricted scenarios - admin only
+		r.With(h.requireAdmin).Post(BreakGlassPath, h.IssueBreakGlass)
+
+		// Users
+		r.Route(UsersPath, func(r chi.Router) {
+			r.Get("/", h.ListUsers)
+			r.Post("/", h.CreateUser)
+			r.Get("/{"+ParamUserID+"}", h.GetUser)
+			r.Patch("/{"+ParamUserID+"}", h.UpdateUser)
+			r.Delete("/{"+ParamUserID+"}", h.DeleteUser)
+			r.Patch("/{"+ParamUserID+"}/password", h.ChangePassword)
+		})
+
+		// User groups
+		r.Route(GroupsPath, func(r chi.Router) {
+			r.Get("/", h.ListUserGroups)
+			r.Post("/", h.CreateUserGroup)
+			r.Get("/{"+ParamGroupName+"}", h.GetUserGroup)
+			r.Patch("/{"+ParamGroupName+"}", h.UpdateUserGroup)
+			r.Delete("/{"+ParamGroupName+"}", h.DeleteUserGroup)
+			r.Get("/{"+ParamGroupName+"}/members", h.ListGroupMembers)
+			r.Post("/{"+ParamGroupName+"}/members", h.AddGroupMember)
+			r.Delete("/{"+ParamGroupName+"}/members/{"+ParamUserID+"}", h.RemoveGroupMember)
+		})
+
+		// Providers - GET: user and admin, PATCH and verification: admin only
+		r.Get(ProvidersPath, h.ListProviders)
+		r.With(h.requireAdmin).Post(ProviderVerifyPath, h.VerifyProviders)
+		r.With(h.requireAdmin).Patch(ProvidersPath+"/{"+ParamProviderName+"}", h.UpdateProviderStatus)
+
+		// Provider config - GET: user and admin, POST: admin only
+		r.With(h.requireAdmin).Post(ProviderConfigPath, h.PostProviderConfig)
+		r.Get(ProviderConfigPath+"/{"+ParamUUID+"}", h.GetProviderConfigByUUID)
+		r.With(h.requireAdmin).Post(ProviderConfigPath+"/{"+ParamUUID+"}", h.UpdateProviderConfigValues)
+
+		// Operator targets - GET: user and admin, POST/PUT/DELETE: admin only
+		r.Route(OperatorTargetsPath, func(r chi.Router) {
+			r.Get("/", h.ListTargets)
+			r.Get("/{"+ParamUUID+"}", h.GetTarget)
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireAdmin)
+				r.Post("/", h.CreateTarget)
+				r.Post(TargetImportSuffix, h.ImportTargets)
+				r.Put("/{"+ParamUUID+"}", h.UpdateTarget)
+				r.Delete("/{"+ParamUUID+"}", h.DeleteTarget)
+				r.Post("/{"+ParamUUID+"}"+TargetRestoreSuffix, h.RestoreTarget)
+				r.Post("/{"+ParamUUID+"}"+TargetRotateSuffix, h.RotateTargetToken)
+			})
+		})
+	})
+
+	return router
+}
+
+// pathParam returns a named path parameter of the matched route
+func pathParam(r *http.Request, name string) (string, error) {
+	value := chi.URLParam(r, name)
+	if value == "" {
+		return "", fmt.Errorf("path parameter is required")
+	}
+	return value, nil
+}
+
+// requireAdmin is a middleware rejecting non-admin users
+func (h *Handler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.requireAdminForMethods(w, r, []string{r.Method}) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// notFound writes the JSON error for unknown endpoints
+func (h *Handler) notFound(w http.ResponseWriter, r *http.Request) {
+	writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgEndpointNotFound, nil)
+}
+
+// methodNotAllowed writes the JSON error for known paths called with an unsupported method
+func (h *Handler) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeLocalizedError(w, r, http.StatusMethodNotAllowed, "method_not_allowed", MsgMethodNotAllowed,
+		i18n.Params{"method": r.Method, "path": r.URL.Path})
+}
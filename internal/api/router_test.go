@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/requestid"
+)
+
+// noAuth is a pass-through authentication middleware; tests put claims on the request context directly
+func noAuth(next http.Handler) http.Handler {
+	return next
+}
+
+// serveAPI dispatches req through the full API router
+func serveAPI(handler *Handler, w http.ResponseWriter, req *http.Request) {
+	handler.Routes(noAuth).ServeHTTP(w, req)
+}
+
+func TestRoutesTrailingSlash(t *testing.T) {
+	handler := setupTestHandler()
+
+	for _, path := range []string{OperatorTargetsPath, OperatorTargetsPath + "/"} {
+		w := httptest.NewRecorder()
+		serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, path, nil)))
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s: expected status %d, got %d. Body: %s", path, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestRoutesErrorsAreJSON(t *testing.T) {
+	handler := setupTestHandler()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "unknown endpoint",
+			method:         http.MethodGet,
+			path:           "/api/v1/does-not-exist",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "not_found",
+		},
+		{
+			name:           "unsupported method",
+			method:         http.MethodPut,
+			path:           UsersPath,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedError:  "method_not_allowed",
+		},
+		{
+			name:           "unsupported method on path with parameter",
+			method:         http.MethodPost,
+			path:           GroupsPath + "/team/members/user@example.com",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedError:  "method_not_allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			serveAPI(handler, w, withAdminClaims(httptest.NewRequest(tt.method, tt.path, nil)))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			var resp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Expected JSON error body: %v", err)
+			}
+			if resp.Error != tt.expectedError {
+				t.Errorf("Expected error %q, got %q", tt.expectedError, resp.Error)
+			}
+		})
+	}
+}
+
+func TestRoutesLogsBypassHTTPAuth(t *testing.T) {
+	handler := setupTestHandler()
+	rejectAll := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+	}
+	router := handler.Routes(rejectAll)
+
+	// The logs endpoint authenticates through the WebSocket subprotocol, not the HTTP middleware
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ScenariosRunPath+"/my-run/jobs/job-1/logs", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected logs endpoint to answer %d itself, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	// Job lookups under the same prefix still go through authentication
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ScenariosRunPath+"/jobs/job-1", nil))
+	if w.Code != http.StatusTeapot {
+		t.Errorf("Expected job lookup to be authenticated, got %d", w.Code)
+	}
+}
+
+func TestRoutesMatchOpenAPIOperations(t *testing.T) {
+	handler := setupTestHandler()
+	router, ok := handler.Routes(noAuth).(chi.Routes)
+	if !ok {
+		t.Fatalf("Routes must return a chi router")
+	}
+
+	// The spec does not describe itself, and the WebSocket endpoints are not plain HTTP operations
+	undocumented := map[string]bool{
+		http.MethodGet + " " + OpenAPIPath: true,
+		http.MethodGet + " " + ScenariosRunPath + "/{" + ParamScenarioRunName + "}/jobs/{" + ParamJobID + "}/logs": true,
+		http.MethodGet + " " + ScenariosRunPath + "/{" + ParamScenarioRunName + "}" + ScenarioRunWatchSuffix:       true,
+		http.MethodGet + " " + ScenariosRunPath + "/{" + ParamScenarioRunName + "}" + ScenarioRunLogsSuffix:        true,
+	}
+
+	routed := map[string]bool{}
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routed[method+" "+strings.TrimSuffix(route, "/")] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk routes: %v", err)
+	}
+
+	documented := map[string]bool{}
+	for _, op := range apiOperations {
+		key := op.Method + " " + op.Path
+		documented[key] = true
+		if !routed[key] {
+			t.Errorf("OpenAPI operation %s is not routed", key)
+		}
+	}
+	for key := range routed {
+		if !documented[key] && !undocumented[key] {
+			t.Errorf("Route %s is missing from the OpenAPI operations", key)
+		}
+	}
+}
+
+func TestRoutesRequestID(t *testing.T) {
+	handler := setupTestHandler()
+
+	// Propagated from the caller into the header and the error body
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/does-not-exist", nil)
+	req.Header.Set(requestid.Header, "req-123")
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, req)
+
+	if got := w.Header().Get(requestid.Header); got != "req-123" {
+		t.Errorf("Expected %s header req-123, got %q", requestid.Header, got)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode error: %v", err)
+	}
+	if resp.RequestID != "req-123" {
+		t.Errorf("Expected requestId req-123 in error body, got %q", resp.RequestID)
+	}
+
+	// Generated when missing, including for errors written by the auth middleware
+	rejectAll := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSONError(w, http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "no"})
+		})
+	}
+	w = httptest.NewRecorder()
+	handler.Routes(rejectAll).ServeHTTP(w, httptest.NewRequest(http.MethodGet, UsersPath, nil))
+
+	generated := w.Header().Get(requestid.Header)
+	if generated == "" {
+		t.Fatalf("Expected a generated %s header", requestid.Header)
+	}
+	resp = ErrorResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode error: %v", err)
+	}
+	if resp.RequestID != generated {
+		t.Errorf("Expected requestId %q in error body, got %q", generated, resp.RequestID)
+	}
+}
+
+func TestRoutesEmbeddedUI(t *testing.T) {
+	handler := setupTestHandler()
+
+	// Disabled by default
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, httptest.NewRequest(http.MethodGet, UIPath+"/", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 with the UI disabled, got %d", w.Code)
+	}
+
+	t.Setenv(EmbeddedUIEnv, "true")
+	handler.ui = embeddedUIFromEnv()
+
+	tg := auth.NewTokenGenerator([]byte("test-secret-key-at-least-32-bytes-long"), TokenDuration, "krkn-operator")
+	token, _ := tg.GenerateToken("user@example.com", "user", "Test", "User", "Org")
+	router := handler.Routes(auth.NewMiddleware(tg).RequireAuth)
+
+	// The login page and its assets are public
+	for _, path := range []string{UIPath + "/login", UIPath + "/login.js", UIPath + "/app.css"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, w.Code)
+		}
+	}
+
+	// The dashboard needs a session, page loads without one go to the login page
+	for _, path := range []string{UIPath, UIPath + "/", UIPath + "/app.js", UIPath + "/runs/run-1"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusSeeOther || w.Header().Get("Location") != UIPath+"/login" {
+			t.Errorf("%s: expected a redirect to the login page, got %d %q", path, w.Code, w.Header().Get("Location"))
+		}
+	}
+
+	// An invalid session is cleared
+	req := httptest.NewRequest(http.MethodGet, UIPath, nil)
+	req.AddCookie(&http.Cookie{Name: UISessionCookie, Value: "not-a-jwt"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Errorf("Expected a redirect with an invalid session, got %d", w.Code)
+	}
+	if cookies := w.Result().Cookies(); len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("Expected the invalid session cookie to be cleared, got %v", cookies)
+	}
+
+	// Opening the session needs the bearer token
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, UIPath+UISessionSuffix, nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 opening a session without token, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, UIPath+UISessionSuffix, nil)
+	req.Header.Set(auth.AuthorizationHeader, auth.BearerPrefix+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204 opening the session, got %d", w.Code)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != UISessionCookie || !cookies[0].HttpOnly ||
+		cookies[0].Path != UIPath || cookies[0].SameSite != http.SameSiteStrictMode {
+		t.Fatalf("Expected an HttpOnly, SameSite=Strict session cookie scoped to %s, got %v", UIPath, cookies)
+	}
+
+	// The session cookie serves the dashboard
+	for _, path := range []string{UIPath, UIPath + "/app.js"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(cookies[0])
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200 with the session, got %d", path, w.Code)
+		}
+	}
+
+	// Signing out clears the cookie
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, UIPath+UISessionSuffix, nil))
+	if cleared := w.Result().Cookies(); w.Code != http.StatusNoContent || len(cleared) != 1 || cleared[0].MaxAge >= 0 {
+		t.Errorf("Expected 204 clearing the session cookie, got %d %v", w.Code, cleared)
+	}
+}
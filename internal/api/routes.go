@@ -40,9 +40,12 @@ const (
 
 // Core resource endpoints
 const (
-	HealthPath   = APIBasePath + "/health"
-	ClustersPath = APIBasePath + "/clusters"
-	NodesPath    = APIBasePath + "/nodes"
+	HealthPath     = APIBasePath + "/health"
+	HealthzPath    = APIBasePath + "/healthz"
+	ReadyzPath     = APIBasePath + "/readyz"
+	ClustersPath   = APIBasePath + "/clusters"
+	ClusterIDsPath = ClustersPath + "/ids"
+	NodesPath      = APIBasePath + "/nodes"
 )
 
 // Legacy targets endpoints (deprecated, use OperatorTargetsPath)
@@ -57,6 +60,33 @@ const (
 	ScenariosGlobalsPath = ScenariosPath + "/globals"
 	ScenariosRunPath     = ScenariosPath + "/run"
 	ScenariosRunJobsPath = ScenariosRunPath + "/jobs"
+	ScenariosRunLintPath = ScenariosRunPath + "/lint"
+
+	// ScenariosFavoritesPath lists the scenarios the user starred, /{scenarioName} stars and unstars one
+	ScenariosFavoritesPath = ScenariosPath + "/favorites"
+
+	// ScenarioRunSubscribeSuffix is appended to /scenarios/run/{name} to observe a run
+	ScenarioRunSubscribeSuffix = "/subscribe"
+	// ScenarioRunUnsubscribeSuffix is appended to /scenarios/run/{name} to stop observing a run
+	ScenarioRunUnsubscribeSuffix = "/unsubscribe"
+	// ScenarioRunLogsSuffix is appended to /scenarios/run/{name} to stream the logs of all its jobs over WebSocket
+	ScenarioRunLogsSuffix = "/logs"
+	// ScenarioRunRetryFailedSuffix is appended to /scenarios/run/{name} to retry its failed clusters
+	ScenarioRunRetryFailedSuffix = "/retry-failed"
+	// ScenarioRunWatchSuffix is appended to /scenarios/run/{name} to stream its status over WebSocket
+	ScenarioRunWatchSuffix = "/watch"
+	// ScenarioRunResultsSuffix is appended to /scenarios/run/{name} to get the krkn results of its jobs
+	ScenarioRunResultsSuffix = "/results"
+	// ScenarioRunReportSuffix is appended to /scenarios/run/{name} to export its report as JSON or HTML
+	ScenarioRunReportSuffix = "/report"
+	// JobEventsSuffix is appended to /scenarios/run/jobs/{jobId} to stream status changes
+	JobEventsSuffix = "/events"
+	// ScenarioRunClustersSegment follows /scenarios/run/{name} in per-cluster routes
+	ScenarioRunClustersSegment = "/clusters"
+	// ClusterMetricsSuffix is appended to /scenarios/run/{name}/clusters/{cluster} to query its Prometheus
+	ClusterMetricsSuffix = "/metrics"
+	// ClusterEffectiveSpecSuffix is appended to /scenarios/run/{name}/clusters/{cluster} to show what its job ran
+	ClusterEffectiveSpecSuffix = "/effective-spec"
 )
 
 // Dashboard endpoints
@@ -71,9 +101,50 @@ const (
 	GroupsPath = APIBasePath + "/groups"
 )
 
+// Campaign endpoints
+const (
+	CampaignsPath = APIBasePath + "/campaigns"
+
+	// CampaignReportSuffix is appended to /campaigns/{campaignName} to get the report of its runs
+	CampaignReportSuffix = "/report"
+)
+
+// Diagnostics endpoints
+const (
+	DiagnosticsPath = APIBasePath + "/diagnostics"
+)
+
+// API description endpoints
+const (
+	OpenAPIPath          = APIBasePath + "/openapi.json"
+	DocsPath             = APIBasePath + "/docs"
+	DocsStateMachinePath = DocsPath + "/state-machine"
+)
+
+// Audit endpoints
+const (
+	AuditPath = APIBasePath + "/audit"
+)
+
+// Run history endpoints
+const (
+	HistoryPath = APIBasePath + "/history"
+)
+
+// Usage telemetry endpoints
+const (
+	UsagePath = APIBasePath + "/usage"
+)
+
+// Break-glass exemption endpoints
+const (
+	BreakGlassPath = APIBasePath + "/break-glass"
+)
+
 // Provider endpoints
 const (
 	ProvidersPath      = APIBasePath + "/providers"
+	ProviderVerifyPath = ProvidersPath + "/verify"
 	ProviderConfigPath = APIBasePath + "/provider-config"
 )
 
@@ -81,4 +152,21 @@ const (
 const (
 	OperatorPath        = APIBasePath + "/operator"
 	OperatorTargetsPath = OperatorPath + "/targets"
+
+	// TargetRestoreSuffix is appended to /operator/targets/{uuid} to restore an archived target
+	TargetRestoreSuffix = "/restore"
+
+	// TargetRotateSuffix is appended to /operator/targets/{uuid} to re-mint the token of a token target
+	TargetRotateSuffix = "/rotate"
+
+	// TargetImportSuffix is appended to /operator/targets to import the contexts of a kubeconfig
+	TargetImportSuffix = "/import"
+)
+
+// Embedded UI, served when API_EMBEDDED_UI is enabled
+const (
+	UIPath = "/ui"
+
+	// UISessionSuffix is appended to UIPath to open (POST) or close (DELETE) the UI session
+	UISessionSuffix = "/session"
 )
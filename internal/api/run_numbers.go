@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+// RunCountersConfigMap holds the last run number allocated to each scenario, keyed by
+// scenario name
+const RunCountersConfigMap = "krkn-operator-run-counters"
+
+// maxDisplayNameLength is the longest display name of a run
+const maxDisplayNameLength = 128
+
+// allocateRunNumber returns the next number of a run of scenarioName. The counters are
+// read and written through the clientset, not the cache, and updated with optimistic
+// concurrency: two runs never get the same number and numbers only grow, even with
+// several operator replicas serving the API.
+func (h *Handler) allocateRunNumber(ctx context.Context, scenarioName string) (int64, error) {
+	if errs := validation.IsConfigMapKey(scenarioName); len(errs) > 0 {
+		return 0, fmt.Errorf("scenario name %q cannot be counted: %s", scenarioName, strings.Join(errs, ", "))
+	}
+	configMaps := h.clientset.CoreV1().ConfigMaps(h.namespace)
+
+	var number int64
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		counters, err := configMaps.Get(ctx, RunCountersConfigMap, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			counters = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      RunCountersConfigMap,
+					Namespace: h.namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/name":      "krkn-operator",
+						"app.kubernetes.io/component": "run-counters",
+					},
+				},
+				Data: map[string]string{scenarioName: "1"},
+			}
+			number = 1
+			_, err = configMaps.Create(ctx, counters, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Created by a concurrent request: allocate from it
+				return apierrors.NewConflict(corev1.Resource("configmaps"), RunCountersConfigMap, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		last, err := parseRunCounter(counters.Data[scenarioName])
+		if err != nil {
+			return fmt.Errorf("invalid run counter of scenario %s: %w", scenarioName, err)
+		}
+		number = last + 1
+		if counters.Data == nil {
+			counters.Data = map[string]string{}
+		}
+		counters.Data[scenarioName] = strconv.FormatInt(number, 10)
+		_, err = configMaps.Update(ctx, counters, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate a run number: %w", err)
+	}
+	return number, nil
+}
+
+// parseRunCounter parses the last number allocated to a scenario, 0 when none was
+func parseRunCounter(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestAllocateRunNumber(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		scenario string
+		want     int64
+	}{
+		{scenario: "pod-scenarios", want: 1},
+		{scenario: "pod-scenarios", want: 2},
+		{scenario: "node-scenarios", want: 1},
+		{scenario: "pod-scenarios", want: 3},
+	} {
+		got, err := handler.allocateRunNumber(ctx, tt.scenario)
+		if err != nil {
+			t.Fatalf("allocateRunNumber(%s) error = %v", tt.scenario, err)
+		}
+		if got != tt.want {
+			t.Errorf("allocateRunNumber(%s) = %d, want %d", tt.scenario, got, tt.want)
+		}
+	}
+
+	counters, err := handler.clientset.CoreV1().ConfigMaps(handler.namespace).Get(ctx, RunCountersConfigMap, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get run counters: %v", err)
+	}
+	if counters.Data["pod-scenarios"] != "3" || counters.Data["node-scenarios"] != "1" {
+		t.Errorf("Unexpected run counters %v", counters.Data)
+	}
+
+	if _, err := handler.allocateRunNumber(ctx, "pod scenarios"); err == nil {
+		t.Error("Expected an error for a scenario name that is not a ConfigMap key")
+	}
+}
+
+func TestPostScenarioRun_RunNumberAndDisplayName(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+
+	post := func(displayName string) *httptest.ResponseRecorder {
+		reqBody := `{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["test-cluster"]},
+			"scenarioImage": "quay.io/krkn/pod-scenarios:latest",
+			"scenarioName": "pod-delete",
+			"displayName": "` + displayName + `"
+		}`
+		req := httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	if w := post(strings.Repeat("a", maxDisplayNameLength+1)); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for a long display name, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var responses []ScenarioRunCreateResponse
+	for _, displayName := range []string{"", "Game day"} {
+		w := post(displayName)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status code %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response ScenarioRunCreateResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+	if responses[0].RunNumber != 1 || responses[1].RunNumber != 2 {
+		t.Errorf("Expected runs 1 and 2, got %d and %d", responses[0].RunNumber, responses[1].RunNumber)
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := handler.client.Get(context.Background(), client.ObjectKey{
+		Name: responses[1].ScenarioRunName, Namespace: handler.namespace,
+	}, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if scenarioRun.Spec.RunNumber != 2 || scenarioRun.Title() != "Game day" {
+		t.Errorf("Expected run 2 titled Game day, got %d %q", scenarioRun.Spec.RunNumber, scenarioRun.Title())
+	}
+	scenarioRun.Spec.DisplayName = ""
+	if scenarioRun.Title() != "pod-delete #2" {
+		t.Errorf("Expected the run number in the title, got %q", scenarioRun.Title())
+	}
+}
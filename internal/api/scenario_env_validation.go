@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/krkn-chaos/krknctl/pkg/provider"
+	"github.com/krkn-chaos/krknctl/pkg/provider/models"
+	"github.com/krkn-chaos/krknctl/pkg/typing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// scenarioFields returns the input fields of the scenario req runs, from the cached
+// scenario detail. The lookup is best effort: nil when the registry cannot tell the
+// fields, the run is not held up by the registry.
+func (h *Handler) scenarioFields(ctx context.Context, req *ScenarioRunRequest) []InputFieldResponse {
+	logger := log.FromContext(ctx)
+	if h.scenarioProvider == nil {
+		return nil
+	}
+
+	registry, mode, err := h.resolveRegistry(ctx, req.ScenariosRequest)
+	if err != nil {
+		logger.Info("Skipped scenario field lookup", "scenarioName", req.ScenarioName, "error", err.Error())
+		return nil
+	}
+	detail, err := h.fetchScenarioDetail(registry, mode, "detail/"+req.ScenarioName, false,
+		func(scenarioProvider provider.ScenarioDataProvider) (*models.ScenarioDetail, error) {
+			return scenarioProvider.GetScenarioDetail(req.ScenarioName, registry)
+		})
+	if err != nil {
+		logger.Info("Skipped scenario field lookup, scenario detail unavailable",
+			"scenarioName", req.ScenarioName, "error", err.Error())
+		return nil
+	}
+	if detail == nil {
+		logger.V(1).Info("Skipped scenario field lookup, scenario not in the registry",
+			"scenarioName", req.ScenarioName)
+		return nil
+	}
+	return detail.Fields
+}
+
+// validateEnvironment checks environment against the input fields of a scenario:
+// required fields without a default must be set, numbers, booleans and enums must parse
+// and strings must match their validator. Variables the scenario does not declare are
+// left alone, as are file fields, which are mounted from the files of the run.
+func validateEnvironment(lang string, fields []InputFieldResponse, environment map[string]string) []FieldError {
+	var fieldErrors []FieldError
+	for _, field := range fields {
+		if field.Variable == nil {
+			continue
+		}
+		fieldType := typing.ToType(field.Type)
+		if fieldType == typing.File || fieldType == typing.FileBase64 || fieldType == typing.Unknown {
+			continue
+		}
+		variable := *field.Variable
+
+		value, set := environment[variable]
+		if !set {
+			if field.Required && (field.Default == nil || *field.Default == "") {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:   variable,
+					Message: messageCatalog.Render(lang, MsgFieldRequired, i18n.Params{"field": variable}),
+				})
+			}
+			continue
+		}
+		if _, err := inputField(field, fieldType).Validate(&value); err != nil {
+			fieldErrors = append(fieldErrors, FieldError{Field: variable, Message: err.Error()})
+		}
+	}
+	sort.Slice(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+	return fieldErrors
+}
+
+// secretVariables returns the variables of environment to pass from a Secret: the
+// fields the scenario marks secret and the variables the request lists, sorted
+func secretVariables(fields []InputFieldResponse, environment map[string]string, requested []string) []string {
+	var variables []string
+	for _, field := range fields {
+		if field.Secret && field.Variable != nil {
+			variables = append(variables, *field.Variable)
+		}
+	}
+	variables = append(variables, requested...)
+	variables = slices.DeleteFunc(variables, func(variable string) bool {
+		_, set := environment[variable]
+		return !set
+	})
+	slices.Sort(variables)
+	return slices.Compact(variables)
+}
+
+// createSecretEnvironment moves the values of the secret variables of scenarioRun into a
+// Secret of the run, which its spec then references: only their names stay in the run.
+// It returns nil when no secret variable is set.
+func (h *Handler) createSecretEnvironment(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) (*corev1.Secret, error) {
+	spec := &scenarioRun.Spec
+	data := make(map[string][]byte)
+	for _, name := range spec.SecretEnvironment {
+		if value, ok := spec.Environment[name]; ok {
+			data[name] = []byte(value)
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scenarioRun.Name + "-env",
+			Namespace: h.namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	if err := h.client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create secret environment: %w", err)
+	}
+
+	environment := maps.Clone(spec.Environment)
+	for name := range data {
+		delete(environment, name)
+	}
+	spec.Environment = environment
+	spec.SecretEnvironmentRef = secret.Name
+	return secret, nil
+}
+
+// inputField turns a field of a scenario detail response back into the krknctl field
+// validating its values
+func inputField(field InputFieldResponse, fieldType typing.Type) *typing.InputField {
+	name := field.Name
+	if name == nil {
+		name = field.Variable
+	}
+	return &typing.InputField{
+		Name:              name,
+		Variable:          field.Variable,
+		Type:              fieldType,
+		Default:           field.Default,
+		Validator:         field.Validator,
+		ValidationMessage: field.ValidationMessage,
+		Separator:         field.Separator,
+		AllowedValues:     field.AllowedValues,
+		Required:          field.Required,
+	}
+}
+
+// writeEnvironmentError answers a scenario run whose environment failed validation with
+// 422 and the invalid fields
+func writeEnvironmentError(w http.ResponseWriter, r *http.Request, scenarioName string, fieldErrors []FieldError) {
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	writeJSONError(w, http.StatusUnprocessableEntity, ErrorResponse{
+		Error:       "invalid_environment",
+		Code:        MsgEnvironmentInvalid,
+		Message:     messageCatalog.Render(lang, MsgEnvironmentInvalid, i18n.Params{"scenario": scenarioName}),
+		FieldErrors: fieldErrors,
+	})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/krkn-chaos/krknctl/pkg/provider"
+	"github.com/krkn-chaos/krknctl/pkg/typing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestPostScenarioRun_ValidatesEnvironment(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	scenarioProvider := &fakeScenarioProvider{fields: []typing.InputField{
+		{Name: ptr("namespace"), Variable: ptr("NAMESPACE"), Type: typing.String, Required: true},
+		{Name: ptr("pod-label"), Variable: ptr("POD_LABEL"), Type: typing.String, Validator: ptr(`^[a-z]+=[a-z0-9-]+$`)},
+		{Name: ptr("disruption-count"), Variable: ptr("DISRUPTION_COUNT"), Type: typing.Number, Default: ptr("1")},
+		{Name: ptr("action"), Variable: ptr("ACTION"), Type: typing.Enum, AllowedValues: ptr("kill,stop"), Default: ptr("kill")},
+		{Name: ptr("kill-timeout"), Variable: ptr("EXPECTED_RECOVERY_TIME"), Type: typing.Number, Required: true, Default: ptr("60")},
+		{Name: ptr("scenario-file"), Variable: ptr("SCENARIO_FILE"), Type: typing.File, Required: true, MountPath: ptr("/tmp/scenario.yaml")},
+	}}
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	handler.registries = newRegistryCatalog()
+	handler.scenarioProvider = func(provider.Mode) (provider.ScenarioDataProvider, error) {
+		return scenarioProvider, nil
+	}
+
+	post := func(environment string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["test-cluster"]},
+			"scenarioImage": "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+			"scenarioName": "pod-scenarios",
+			"environment": `+environment+`
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	w := post(`{"POD_LABEL": "app nginx", "DISRUPTION_COUNT": "many", "ACTION": "restart", "UNKNOWN": "kept"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	var fields []string
+	for _, fieldError := range response.FieldErrors {
+		fields = append(fields, fieldError.Field)
+	}
+	if response.Code != MsgEnvironmentInvalid || strings.Join(fields, ",") != "ACTION,DISRUPTION_COUNT,NAMESPACE,POD_LABEL" {
+		t.Errorf("Unexpected error response %+v", response)
+	}
+
+	w = post(`{"NAMESPACE": "default", "POD_LABEL": "app=nginx", "DISRUPTION_COUNT": "2", "ACTION": "stop"}`)
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	// Runs are not held up when the registry cannot tell the fields
+	handler.registries = newRegistryCatalog()
+	scenarioProvider.err = errors.New("dial tcp: i/o timeout")
+	if w = post(`{"DISRUPTION_COUNT": "many"}`); w.Code != http.StatusCreated {
+		t.Errorf("Expected status %d without registry, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+}
+
+func TestPostScenarioRun_SecretEnvironment(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	scenarioProvider := &fakeScenarioProvider{fields: []typing.InputField{
+		{Name: ptr("api-token"), Variable: ptr("API_TOKEN"), Type: typing.String, Secret: true},
+		{Name: ptr("password"), Variable: ptr("PASSWORD"), Type: typing.String, Secret: true},
+	}}
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	handler.registries = newRegistryCatalog()
+	handler.scenarioProvider = func(provider.Mode) (provider.ScenarioDataProvider, error) {
+		return scenarioProvider, nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(`{
+		"targetRequestID": "test-request-id",
+		"targetClusters": {"krkn-operator": ["test-cluster"]},
+		"scenarioImage": "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+		"scenarioName": "pod-scenarios",
+		"environment": {"API_TOKEN": "s3cr3t", "WEBHOOK_KEY": "k3y", "NAMESPACE": "default"},
+		"secretEnvironment": ["WEBHOOK_KEY", "API_TOKEN"]
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.PostScenarioRun(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	key := client.ObjectKey{Name: response.ScenarioRunName, Namespace: handler.namespace}
+	if err := handler.client.Get(context.Background(), key, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	if got := strings.Join(scenarioRun.Spec.SecretEnvironment, ","); got != "API_TOKEN,WEBHOOK_KEY" {
+		t.Errorf("Expected API_TOKEN and WEBHOOK_KEY passed from a Secret, got %s", got)
+	}
+
+	// The values are kept in a Secret of the run, only their names in the run
+	if len(scenarioRun.Spec.Environment) != 1 || scenarioRun.Spec.Environment["NAMESPACE"] != "default" {
+		t.Errorf("Expected only NAMESPACE in the environment of the run, got %v", scenarioRun.Spec.Environment)
+	}
+	var envSecret corev1.Secret
+	if err := handler.client.Get(context.Background(), client.ObjectKey{
+		Name: scenarioRun.Spec.SecretEnvironmentRef, Namespace: handler.namespace,
+	}, &envSecret); err != nil {
+		t.Fatalf("Failed to get the secret environment %q: %v", scenarioRun.Spec.SecretEnvironmentRef, err)
+	}
+	if string(envSecret.Data["API_TOKEN"]) != "s3cr3t" || string(envSecret.Data["WEBHOOK_KEY"]) != "k3y" || len(envSecret.Data) != 2 {
+		t.Errorf("Unexpected secret environment %v", envSecret.Data)
+	}
+	if !metav1.IsControlledBy(&envSecret, &scenarioRun) {
+		t.Error("Expected the secret environment to be owned by the run")
+	}
+}
@@ -1030,7 +1030,7 @@ func TestGetScenarioRunStatusWithoutClusterAPIURL(t *testing.T) {
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
-			handler.GetScenarioRunStatus(w, req)
+			serveAPI(handler, w, req)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("%s: Expected status %d, got %d. Response: %s",
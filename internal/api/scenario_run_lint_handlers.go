@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// LintMaxClustersEnv sets how many target clusters a run may have before the lint warns
+// about the blast radius. 0 disables the warning.
+const LintMaxClustersEnv = "API_LINT_MAX_CLUSTERS"
+
+// DefaultLintMaxClusters is the lint cluster threshold when LintMaxClustersEnv is not set
+const DefaultLintMaxClusters = 10
+
+// LintMaxClustersFromEnv reads the lint cluster threshold from the environment
+func LintMaxClustersFromEnv() int {
+	if clusters, err := strconv.Atoi(os.Getenv(LintMaxClustersEnv)); err == nil && clusters >= 0 {
+		return clusters
+	}
+	return DefaultLintMaxClusters
+}
+
+// sloCheckEnv are the krkn environment variables enabling the alert (SLO) checks that
+// fail a run when the target degrades beyond its objectives
+var sloCheckEnv = []string{"CHECK_CRITICAL_ALERTS", "ENABLE_ALERTS"}
+
+// secretEnvName matches environment variable names that usually hold credentials
+var secretEnvName = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIAL)`)
+
+// LintScenarioRun handles POST /api/v1/scenarios/run/lint.
+// It checks a scenario run request against chaos best practices and returns warnings,
+// without validating or creating the run: a lint warning never blocks a submission.
+func (h *Handler) LintScenarioRun(w http.ResponseWriter, r *http.Request) {
+	var req ScenarioRunRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	lang := requestLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	warnings := []LintWarning{}
+	for _, finding := range lintScenarioRun(&req, h.lintMaxClusters) {
+		warnings = append(warnings, LintWarning{
+			Code:    finding.code,
+			Field:   finding.field,
+			Message: messageCatalog.Render(lang, finding.code, finding.params),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, ScenarioRunLintResponse{Warnings: warnings})
+}
+
+// lintFinding is a lint warning before its message is localized
+type lintFinding struct {
+	code   string
+	field  string
+	params i18n.Params
+}
+
+// lintScenarioRun returns the best-practice warnings of a run request. maxClusters is
+// the blast radius threshold, 0 to not check it.
+func lintScenarioRun(req *ScenarioRunRequest, maxClusters int) []lintFinding {
+	var findings []lintFinding
+
+	if req.TimeoutSeconds == nil {
+		findings = append(findings, lintFinding{code: MsgLintMissingTimeout, field: "timeoutSeconds"})
+	}
+
+	if req.ScenarioImage != "" && !imagePinned(req.ScenarioImage) {
+		findings = append(findings, lintFinding{code: MsgLintLatestTag, field: "scenarioImage",
+			params: i18n.Params{"image": req.ScenarioImage}})
+	}
+	for i, hook := range req.OnFailure {
+		if hook.Image != nil && hook.Image.Image != "" && !imagePinned(hook.Image.Image) {
+			findings = append(findings, lintFinding{code: MsgLintLatestTag, field: fmt.Sprintf("onFailure[%d].image.image", i),
+				params: i18n.Params{"image": hook.Image.Image}})
+		}
+	}
+
+	if !slices.ContainsFunc(sloCheckEnv, func(name string) bool { return envEnabled(req.Environment[name]) }) {
+		findings = append(findings, lintFinding{code: MsgLintNoSLOChecks, field: "environment",
+			params: i18n.Params{"variables": strings.Join(sloCheckEnv, ", ")}})
+	}
+
+	clusters := 0
+	for _, clusterNames := range req.TargetClusters {
+		clusters += len(clusterNames)
+	}
+	if maxClusters > 0 && clusters > maxClusters {
+		findings = append(findings, lintFinding{code: MsgLintTooManyClusters, field: "targetClusters",
+			params: i18n.Params{"clusters": strconv.Itoa(clusters), "max": strconv.Itoa(maxClusters)}})
+	}
+
+	// Sorted for a stable response. Variables passed from a Secret stay out of the pod spec.
+	names := make([]string, 0, len(req.Environment))
+	for name, value := range req.Environment {
+		if value != "" && secretEnvName.MatchString(name) && !slices.Contains(req.SecretEnvironment, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		findings = append(findings, lintFinding{code: MsgLintPlainEnvSecret, field: "environment." + name,
+			params: i18n.Params{"name": name}})
+	}
+
+	return findings
+}
+
+// imagePinned reports whether an image reference has an explicit tag other than latest,
+// or a digest. A reference without tag pulls latest.
+func imagePinned(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	// The tag follows the last ':' after the last '/', a ':' before it is a registry port
+	name := image[strings.LastIndex(image, "/")+1:]
+	colon := strings.LastIndex(name, ":")
+	return colon >= 0 && name[colon+1:] != "latest"
+}
+
+// envEnabled reports whether a krkn boolean environment value is true
+func envEnabled(value string) bool {
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postLint(t *testing.T, handler *Handler, body any, acceptLanguage string) ScenarioRunLintResponse {
+	t.Helper()
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, ScenariosRunLintPath, bytes.NewReader(data))
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(req))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ScenarioRunLintResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestLintScenarioRun_Warnings(t *testing.T) {
+	handler := setupTestHandler()
+	handler.lintMaxClusters = 2
+
+	resp := postLint(t, handler, ScenarioRunRequest{
+		ScenarioImage:  "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+		ScenarioName:   "pod-scenarios",
+		TargetClusters: map[string][]string{"krkn-operator": {"a", "b"}, "acm": {"c"}},
+		Environment:    map[string]string{"ES_PASSWORD": "hunter2", "NAMESPACE": "default", "API_TOKEN": ""},
+		OnFailure:      []FailureHook{{Name: "fix", Image: &FailureHookImage{Image: "registry:5000/remediate"}}},
+	}, "")
+
+	var got []string
+	for _, warning := range resp.Warnings {
+		got = append(got, warning.Code+"@"+warning.Field)
+		if warning.Message == "" || warning.Message == warning.Code {
+			t.Errorf("expected a rendered message for %s, got %q", warning.Code, warning.Message)
+		}
+	}
+	expected := []string{
+		MsgLintMissingTimeout + "@timeoutSeconds",
+		MsgLintLatestTag + "@onFailure[0].image.image",
+		MsgLintNoSLOChecks + "@environment",
+		MsgLintTooManyClusters + "@targetClusters",
+		MsgLintPlainEnvSecret + "@environment.ES_PASSWORD",
+	}
+	if strings.Join(got, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected warnings %v, got %v", expected, got)
+	}
+}
+
+func TestLintScenarioRun_Clean(t *testing.T) {
+	handler := setupTestHandler()
+	handler.lintMaxClusters = 2
+	timeout := int64(600)
+
+	resp := postLint(t, handler, ScenarioRunRequest{
+		ScenarioImage:  "quay.io/krkn-chaos/krkn-hub@sha256:0123",
+		ScenarioName:   "pod-scenarios",
+		TargetClusters: map[string][]string{"krkn-operator": {"a", "b"}},
+		Environment:    map[string]string{"CHECK_CRITICAL_ALERTS": "True"},
+		TimeoutSeconds: &timeout,
+	}, "it")
+
+	if resp.Warnings == nil || len(resp.Warnings) != 0 {
+		t.Errorf("expected an empty warnings list, got %+v", resp.Warnings)
+	}
+}
+
+func TestLintScenarioRun_Localized(t *testing.T) {
+	handler := setupTestHandler()
+
+	resp := postLint(t, handler, ScenarioRunRequest{ScenarioImage: "krkn:latest"}, "it")
+	for _, warning := range resp.Warnings {
+		if warning.Code == MsgLintLatestTag && !strings.Contains(warning.Message, "L'immagine 'krkn:latest'") {
+			t.Errorf("expected an italian message, got %q", warning.Message)
+		}
+	}
+}
+
+func TestImagePinned(t *testing.T) {
+	tests := map[string]bool{
+		"krkn":                          false,
+		"krkn:latest":                   false,
+		"registry:5000/krkn":            false,
+		"registry:5000/krkn:v1.2":       true,
+		"quay.io/krkn-chaos/krkn:v1":    true,
+		"quay.io/krkn@sha256:abcdef012": true,
+	}
+	for image, expected := range tests {
+		if got := imagePinned(image); got != expected {
+			t.Errorf("imagePinned(%q) = %v, expected %v", image, got, expected)
+		}
+	}
+}
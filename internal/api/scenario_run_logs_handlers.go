@@ -0,0 +1,371 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/groupauth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+const (
+	// QueryFollowRun is the query parameter making a log WebSocket follow the retries of
+	// each job instead of ending with its first pod
+	QueryFollowRun = "followRun"
+
+	// followRunPollInterval is how often the run is re-read while waiting for the next
+	// attempt of a job, between its informer events
+	followRunPollInterval = 5 * time.Second
+)
+
+// StreamScenarioRunLogs handles GET /api/v1/scenarios/run/{scenarioRunName}/logs
+// It upgrades to a WebSocket and multiplexes the logs of every cluster job pod of the run
+// the user may view into one stream. Each line is sent as a text message prefixed with
+// "[<clusterName>/<jobID>] ", or with format=json as a LogLineFrame identifying the job, with a
+// LogControlFrame of type "end" once its pod stream ended. The follow, timestamps, tailLines,
+// sinceTime and sinceSeconds query parameters apply to every pod as for GetScenarioRunLogs.
+// With followRun=true, the stream of each job goes on with the pods of its retries as for
+// GetScenarioRunLogs. The connection is closed normally once every pod stream ended.
+func (h *Handler) StreamScenarioRunLogs(w http.ResponseWriter, r *http.Request) {
+	claims, protocols, ok := h.authenticateWebSocket(w, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.WithValue(r.Context(), auth.UserClaimsKey, claims))
+	defer cancel()
+	r = r.WithContext(ctx)
+	logger := log.FromContext(ctx).WithName("websocket-run-logs")
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+
+	logOptions, code, err := scenarioLogOptions(r)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", code, i18n.Params{"param": "sinceTime"})
+		return
+	}
+	format, ok := parseLogFormat(r)
+	if !ok {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidLogFormat,
+			i18n.Params{"format": format})
+		return
+	}
+	if format == LogFormatJSON {
+		logOptions.Timestamps = true
+	}
+	followRun := r.URL.Query().Get(QueryFollowRun) == "true"
+	if followRun && h.scenarioRunInformer == nil {
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "service_unavailable", MsgLogFollowRunUnavailable, nil)
+		return
+	}
+
+	// Resolve the run and the caller's jobs before upgrading, so errors are plain HTTP responses
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(scenarioRunName), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+				i18n.Params{"name": string(scenarioRunName)})
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	jobs := scenarioRun.Status.ClusterJobs
+	if !auth.IsAdmin(ctx) {
+		userGroups, err := groupauth.GetUserGroups(ctx, h.client, claims.UserID, h.namespace)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+				i18n.Params{"error": err.Error()})
+			return
+		}
+		jobs = h.filterJobsByPermission(jobs, ctx, userGroups, groupauth.ActionView)
+		if len(jobs) == 0 && len(scenarioRun.Status.ClusterJobs) > 0 {
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunLogsForbidden, nil)
+			return
+		}
+	}
+
+	// Browsers do not preflight WebSocket upgrades, so the origin allowlist is enforced here
+	wsUpgrader := upgrader
+	wsUpgrader.CheckOrigin = h.cors.checkWebSocketOrigin
+	conn, err := wsUpgrader.Upgrade(w, r, http.Header{
+		"Sec-WebSocket-Protocol": []string{protocols},
+	})
+	if err != nil {
+		logger.Error(err, "WebSocket upgrade failed", "scenarioRunName", scenarioRunName)
+		return
+	}
+	defer conn.Close()
+
+	// Clients only send control frames; reading processes them and detects disconnection
+	_ = conn.SetReadDeadline(time.Now().Add(scenarioRunWatchPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(scenarioRunWatchPongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// One reader per pod; this goroutine is the only WebSocket writer
+	lines := make(chan []byte)
+	var readers sync.WaitGroup
+	for _, job := range jobs {
+		readers.Add(1)
+		go func(job krknv1alpha1.ClusterJobStatus) {
+			defer readers.Done()
+			h.streamJobLogLines(ctx, logger, scenarioRunName, job, logOptions, format, followRun, lines)
+		}(job)
+	}
+	go func() {
+		readers.Wait()
+		close(lines)
+	}()
+
+	pingTicker := time.NewTicker(scenarioRunWatchPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		case line, open := <-lines:
+			if !open {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, line); err != nil {
+				if !isWebSocketDisconnectError(err) {
+					logger.Error(err, "Unexpected WebSocket write error", "scenarioRunName", scenarioRunName)
+				}
+				return
+			}
+		}
+	}
+}
+
+// streamJobLogLines sends the framed log lines of the pod of job to lines until the
+// log stream ends or ctx is cancelled. Failures are reported as error frames. With
+// followRun, the pods of the next attempts of the job are streamed as well.
+func (h *Handler) streamJobLogLines(
+	ctx context.Context,
+	logger logr.Logger,
+	runName ScenarioRunName,
+	job krknv1alpha1.ClusterJobStatus,
+	logOptions *corev1.PodLogOptions,
+	format string,
+	followRun bool,
+	lines chan<- []byte,
+) {
+	framer := &logFramer{
+		format:      format,
+		prefix:      jobLogPrefix(job.ClusterName, job.JobID),
+		jobID:       job.JobID,
+		clusterName: job.ClusterName,
+	}
+	send := func(message []byte) bool {
+		select {
+		case lines <- message:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	if followRun {
+		h.followJobLogs(ctx, logger, runName, job, logOptions, framer, send)
+		return
+	}
+
+	pod, err := h.findJobPod(ctx, job.JobID)
+	if err != nil {
+		send(framer.errorf("Failed to list pods: %s", err.Error()))
+		return
+	}
+	if pod == nil {
+		send(framer.errorf("No pod found for job"))
+		return
+	}
+	framer.podName = pod.Name
+
+	if h.streamPodLogLines(ctx, logger, logOptions, framer, send) {
+		if end := framer.end(); end != nil {
+			send(end)
+		}
+	}
+}
+
+// followJobLogs streams the logs of the attempts of job one after the other: once the
+// stream of a pod ends, it waits for the run to replace the job or its pod with a retry,
+// sends an attempt frame and streams the new pod, until the job reached a terminal phase
+// and its last pod was streamed. The run is re-read on its informer events, and every
+// followRunPollInterval in case the pod of a job changed without the run being updated.
+// Pods of later attempts are streamed from their start, whatever the tail and since options.
+func (h *Handler) followJobLogs(
+	ctx context.Context,
+	logger logr.Logger,
+	runName ScenarioRunName,
+	job krknv1alpha1.ClusterJobStatus,
+	logOptions *corev1.PodLogOptions,
+	framer *logFramer,
+	send func([]byte) bool,
+) {
+	changed, stopWatch, err := h.scenarioRunChanges(runName)
+	if err != nil {
+		send(framer.errorf("Failed to follow the scenario run: %s", err.Error()))
+		return
+	}
+	defer stopWatch()
+	poll := time.NewTicker(followRunPollInterval)
+	defer poll.Stop()
+
+	options := logOptions.DeepCopy()
+	options.Follow = true
+	streamedPod := ""
+	for {
+		current, found, err := h.currentClusterJob(ctx, runName, job)
+		if err != nil {
+			send(framer.errorf("Failed to read scenario run: %s", err.Error()))
+			return
+		}
+		if !found {
+			// The run was deleted, or the job is no longer part of it
+			break
+		}
+
+		pod, err := h.findJobPod(ctx, current.JobID)
+		if err != nil {
+			send(framer.errorf("Failed to list pods: %s", err.Error()))
+			return
+		}
+		if pod != nil && pod.Name != streamedPod {
+			if streamedPod == "" {
+				framer.jobID = current.JobID
+				framer.podName = pod.Name
+			} else {
+				logger.Info("Following the logs of the next attempt", "scenarioRunName", runName,
+					"cluster", current.ClusterName, "jobID", current.JobID, "podName", pod.Name)
+				if !send(framer.attempt(current.RetryCount+current.Evictions+1, current.JobID, pod.Name)) {
+					return
+				}
+				options.TailLines = nil
+				options.SinceTime = nil
+				options.SinceSeconds = nil
+			}
+			streamedPod = pod.Name
+			if !h.streamPodLogLines(ctx, logger, options, framer, send) {
+				return
+			}
+			// The job may already have been replaced while the pod was streamed
+			continue
+		}
+		if statemachine.Jobs.IsTerminal(current.Phase) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		case <-poll.C:
+		}
+	}
+	if end := framer.end(); end != nil {
+		send(end)
+	}
+}
+
+// currentClusterJob returns the current attempt of job in the run, matched on its
+// provider and cluster since retries replace its job ID. found is false when the run or
+// the job is gone.
+func (h *Handler) currentClusterJob(ctx context.Context, runName ScenarioRunName, job krknv1alpha1.ClusterJobStatus) (krknv1alpha1.ClusterJobStatus, bool, error) {
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(runName), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return krknv1alpha1.ClusterJobStatus{}, false, nil
+		}
+		return krknv1alpha1.ClusterJobStatus{}, false, err
+	}
+	for _, current := range scenarioRun.Status.ClusterJobs {
+		if current.ProviderName == job.ProviderName && current.ClusterName == job.ClusterName {
+			return current, true, nil
+		}
+	}
+	return krknv1alpha1.ClusterJobStatus{}, false, nil
+}
+
+// streamPodLogLines sends the framed log lines of the pod of framer until its log stream
+// ends. It returns false when the stream failed, reported as an error frame, or sending
+// stopped.
+func (h *Handler) streamPodLogLines(
+	ctx context.Context,
+	logger logr.Logger,
+	logOptions *corev1.PodLogOptions,
+	framer *logFramer,
+	send func([]byte) bool,
+) bool {
+	stream, err := h.clientset.CoreV1().Pods(h.namespace).GetLogs(framer.podName, logOptions).Stream(ctx)
+	if err != nil {
+		send(framer.errorf("Failed to open log stream: %s", err.Error()))
+		return false
+	}
+	defer stream.Close()
+
+	reader := newLogLineReader(stream, h.logMaxLineLength)
+	for {
+		chunk, err := reader.next()
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error(err, "Log stream read error", "jobID", framer.jobID, "podName", framer.podName)
+				send(framer.errorf("Log stream error: %s", err.Error()))
+			}
+			return false
+		}
+		if !send(framer.chunk(chunk)) {
+			return false
+		}
+	}
+}
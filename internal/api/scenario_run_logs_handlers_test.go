@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestStreamScenarioRunLogs(t *testing.T) {
+	ctx := context.Background()
+	handler := setupTestHandler()
+
+	run := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ProviderName: "krkn-operator", ClusterName: "cluster-1", JobID: "job-1", Phase: "Running"},
+				{ProviderName: "krkn-operator", ClusterName: "cluster-2", JobID: "job-2", Phase: "Running"},
+				{ProviderName: "krkn-operator", ClusterName: "cluster-3", JobID: "job-3", Phase: "Pending"},
+			},
+		},
+	}
+	if err := handler.client.Create(ctx, run); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+	for _, jobID := range []string{"job-1", "job-2"} {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "krkn-job-" + jobID,
+			Namespace: handler.namespace,
+			Labels:    map[string]string{"krkn-job-id": jobID},
+		}}
+		if err := handler.client.Create(ctx, pod); err != nil {
+			t.Fatalf("failed to create pod: %v", err)
+		}
+	}
+
+	tokenGen, err := handler.getTokenGenerator(ctx)
+	if err != nil {
+		t.Fatalf("failed to get token generator: %v", err)
+	}
+	token, err := tokenGen.GenerateToken("admin@example.com", "admin", "", "", "")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	server := httptest.NewServer(handler.Routes(noAuth))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + ScenariosRunPath + "/run-1" + ScenarioRunLogsSuffix
+
+	dialer := websocket.Dialer{Subprotocols: []string{"access_token." + token}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to open WebSocket: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var lines []string
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseNormalClosure {
+				t.Fatalf("expected a normal closure once every stream ended, got %v", err)
+			}
+			break
+		}
+		lines = append(lines, string(message))
+	}
+
+	// The fake clientset serves "fake logs" for every pod
+	slices.Sort(lines)
+	expected := []string{
+		"[cluster-1/job-1] fake logs",
+		"[cluster-2/job-2] fake logs",
+		"[cluster-3/job-3] ERROR: No pod found for job",
+	}
+	if !slices.Equal(lines, expected) {
+		t.Errorf("expected lines %q, got %q", expected, lines)
+	}
+}
+
+func TestStreamScenarioRunLogs_FollowRun(t *testing.T) {
+	ctx := context.Background()
+	handler := setupTestHandler()
+	informer := &controllertest.FakeInformer{Synced: true}
+	handler.scenarioRunInformer = informer
+
+	// The first attempt failed, its retry is not created yet
+	run := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ProviderName: "krkn-operator", ClusterName: "cluster-1", JobID: "job-1", Phase: "Failed", MaxRetries: 3},
+			},
+		},
+	}
+	if err := handler.client.Create(ctx, run); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+	createPod := func(jobID string) {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "krkn-job-" + jobID,
+			Namespace: handler.namespace,
+			Labels:    map[string]string{"krkn-job-id": jobID},
+		}}
+		if err := handler.client.Create(ctx, pod); err != nil {
+			t.Fatalf("failed to create pod: %v", err)
+		}
+	}
+	createPod("job-1")
+
+	tokenGen, err := handler.getTokenGenerator(ctx)
+	if err != nil {
+		t.Fatalf("failed to get token generator: %v", err)
+	}
+	token, err := tokenGen.GenerateToken("admin@example.com", "admin", "", "", "")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	server := httptest.NewServer(handler.Routes(noAuth))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + ScenariosRunPath + "/run-1" + ScenarioRunLogsSuffix +
+		"?" + QueryFollowRun + "=true"
+
+	dialer := websocket.Dialer{Subprotocols: []string{"access_token." + token}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to open WebSocket: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	read := func() string {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		return string(message)
+	}
+	if line := read(); line != "[cluster-1/job-1] fake logs" {
+		t.Fatalf("unexpected first line %q", line)
+	}
+
+	// The retry replaces the job, its pod is streamed after the attempt boundary
+	updated := run.DeepCopy()
+	updated.Status.ClusterJobs[0].JobID = "job-2"
+	updated.Status.ClusterJobs[0].RetryCount = 1
+	updated.Status.ClusterJobs[0].Phase = "Running"
+	createPod("job-2")
+	if err := handler.client.Update(ctx, updated); err != nil {
+		t.Fatalf("failed to update scenario run: %v", err)
+	}
+	informer.Update(run, updated)
+
+	for _, expected := range []string{
+		"[cluster-1/job-2] --- attempt 2: job job-2, pod krkn-job-job-2 ---",
+		"[cluster-1/job-2] fake logs",
+	} {
+		if line := read(); line != expected {
+			t.Fatalf("expected %q, got %q", expected, line)
+		}
+	}
+
+	// The stream ends once the job reached a terminal phase
+	succeeded := updated.DeepCopy()
+	succeeded.Status.ClusterJobs[0].Phase = "Succeeded"
+	if err := handler.client.Update(ctx, succeeded); err != nil {
+		t.Fatalf("failed to update scenario run: %v", err)
+	}
+	informer.Update(updated, succeeded)
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseNormalClosure {
+		t.Fatalf("expected a normal closure once the job succeeded, got %v", err)
+	}
+}
+
+func TestStreamScenarioRunLogs_RequiresToken(t *testing.T) {
+	handler := setupTestHandler()
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, httptest.NewRequest(http.MethodGet, ScenariosRunPath+"/run-1"+ScenarioRunLogsSuffix, nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestScenarioLogOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode string
+		check        func(*corev1.PodLogOptions) bool
+	}{
+		{name: "defaults", query: "", check: func(o *corev1.PodLogOptions) bool {
+			return o.Container == "scenario" && !o.Follow && o.SinceTime == nil && o.SinceSeconds == nil
+		}},
+		{name: "since time", query: "follow=true&sinceTime=2025-01-02T03:04:05.123456789Z", check: func(o *corev1.PodLogOptions) bool {
+			return o.Follow && o.SinceTime != nil && o.SinceTime.Equal(&metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC)})
+		}},
+		{name: "since seconds", query: "sinceSeconds=30&tailLines=10", check: func(o *corev1.PodLogOptions) bool {
+			return o.SinceSeconds != nil && *o.SinceSeconds == 30 && o.TailLines != nil && *o.TailLines == 10
+		}},
+		{name: "invalid since time", query: "sinceTime=yesterday", expectedCode: MsgInvalidTimestamp},
+		{name: "invalid since seconds", query: "sinceSeconds=-5", expectedCode: MsgInvalidSinceSeconds},
+		{name: "both", query: "sinceSeconds=5&sinceTime=2025-01-02T03:04:05Z", expectedCode: MsgLogSinceConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, code, err := scenarioLogOptions(httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil))
+			if tt.expectedCode != "" {
+				if err == nil || code != tt.expectedCode {
+					t.Fatalf("expected code %q, got %q (%v)", tt.expectedCode, code, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.check(options) {
+				t.Errorf("unexpected log options: %+v", options)
+			}
+		})
+	}
+}
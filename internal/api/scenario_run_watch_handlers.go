@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gorilla/websocket"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+const (
+	// scenarioRunWatchPingInterval keeps idle connections alive and detects gone clients
+	scenarioRunWatchPingInterval = 30 * time.Second
+	scenarioRunWatchPongWait     = 60 * time.Second
+)
+
+// WatchScenarioRunStatus handles GET /api/v1/scenarios/run/{scenarioRunName}/watch
+// It upgrades to a WebSocket and sends the ScenarioRunStatusResponse of the run as a JSON
+// text message on connect and every time the run status changes, driven by the shared
+// KrknScenarioRun informer. Jobs are filtered by the user's permissions as in
+// GetScenarioRunStatus. Authentication uses the access_token subprotocol, as for logs.
+// The connection is closed normally when the run is deleted.
+func (h *Handler) WatchScenarioRunStatus(w http.ResponseWriter, r *http.Request) {
+	claims, protocols, ok := h.authenticateWebSocket(w, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.WithValue(r.Context(), auth.UserClaimsKey, claims))
+	defer cancel()
+	r = r.WithContext(ctx)
+	logger := log.FromContext(ctx).WithName("websocket-run-status")
+
+	scenarioRunName, err := pathID[ScenarioRunName](r, ParamScenarioRunName)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamScenarioRunName, err)
+		return
+	}
+	if h.scenarioRunInformer == nil {
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "service_unavailable", MsgScenarioRunWatchUnavailable, nil)
+		return
+	}
+
+	// Register before reading the run, so no change between the read and the watch is missed
+	changed, stopWatch, err := h.scenarioRunChanges(scenarioRunName)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "service_unavailable", MsgScenarioRunWatchUnavailable, nil)
+		return
+	}
+	defer stopWatch()
+
+	// Resolve the run and the caller's access before upgrading, so errors are plain HTTP responses
+	current, found, err := h.watchedScenarioRunStatus(ctx, scenarioRunName)
+	switch {
+	case errors.Is(err, errScenarioRunForbidden):
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRunJobsForbidden, nil)
+		return
+	case err != nil:
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgScenarioRunFetchFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	case !found:
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgScenarioRunNotFound,
+			i18n.Params{"name": string(scenarioRunName)})
+		return
+	}
+
+	// Browsers do not preflight WebSocket upgrades, so the origin allowlist is enforced here
+	wsUpgrader := upgrader
+	wsUpgrader.CheckOrigin = h.cors.checkWebSocketOrigin
+	conn, err := wsUpgrader.Upgrade(w, r, http.Header{
+		"Sec-WebSocket-Protocol": []string{protocols},
+	})
+	if err != nil {
+		logger.Error(err, "WebSocket upgrade failed", "scenarioRunName", scenarioRunName)
+		return
+	}
+	defer conn.Close()
+
+	// Clients only send control frames; reading processes them and detects disconnection
+	_ = conn.SetReadDeadline(time.Now().Add(scenarioRunWatchPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(scenarioRunWatchPongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := conn.WriteJSON(current); err != nil {
+		return
+	}
+
+	pingTicker := time.NewTicker(scenarioRunWatchPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		case <-changed:
+			status, found, err := h.watchedScenarioRunStatus(ctx, scenarioRunName)
+			if err != nil {
+				message := "failed to read scenario run status"
+				if errors.Is(err, errScenarioRunForbidden) {
+					message = "access to the scenario run was revoked"
+				}
+				logger.Info("closing scenario run watch", "scenarioRunName", scenarioRunName, "reason", err.Error())
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, message))
+				return
+			}
+			if !found {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "scenario run deleted"))
+				return
+			}
+			if reflect.DeepEqual(status, current) {
+				continue
+			}
+			if err := conn.WriteJSON(status); err != nil {
+				return
+			}
+			current = status
+		}
+	}
+}
+
+// scenarioRunChanges registers with the shared KrknScenarioRun informer and returns a
+// channel receiving a value when the run is added, updated or deleted, coalescing the
+// changes not received yet, and the function removing the registration
+func (h *Handler) scenarioRunChanges(name ScenarioRunName) (<-chan struct{}, func(), error) {
+	changed := make(chan struct{}, 1)
+	notify := func(obj interface{}) {
+		if tombstone, isTombstone := obj.(toolscache.DeletedFinalStateUnknown); isTombstone {
+			obj = tombstone.Obj
+		}
+		if run, isRun := obj.(*krknv1alpha1.KrknScenarioRun); !isRun || run.Name != string(name) || run.Namespace != h.namespace {
+			return
+		}
+		select {
+		case changed <- struct{}{}:
+		default: // A refresh is already pending
+		}
+	}
+	registration, err := h.scenarioRunInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(_, newObj interface{}) { notify(newObj) },
+		DeleteFunc: notify,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return changed, func() { _ = h.scenarioRunInformer.RemoveEventHandler(registration) }, nil
+}
+
+// watchedScenarioRunStatus reads a run and builds its status as visible to the caller.
+// found is false when the run does not exist.
+func (h *Handler) watchedScenarioRunStatus(ctx context.Context, name ScenarioRunName) (status ScenarioRunStatusResponse, found bool, err error) {
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := h.client.Get(ctx, client.ObjectKey{Name: string(name), Namespace: h.namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ScenarioRunStatusResponse{}, false, nil
+		}
+		return ScenarioRunStatusResponse{}, false, err
+	}
+
+	status, err = h.scenarioRunStatusResponse(ctx, &scenarioRun)
+	return status, true, err
+}
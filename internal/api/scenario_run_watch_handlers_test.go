@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+func TestWatchScenarioRunStatus(t *testing.T) {
+	ctx := context.Background()
+	handler := setupTestHandler()
+	informer := &controllertest.FakeInformer{Synced: true}
+	handler.scenarioRunInformer = informer
+
+	run := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: handler.namespace},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			Phase:        "Pending",
+			TotalTargets: 1,
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ProviderName: "krkn-operator", ClusterName: "cluster-1", JobID: "job-1", Phase: "Pending"},
+			},
+			ResolvedTargets: &krknv1alpha1.TargetSnapshot{Targets: []krknv1alpha1.ResolvedTarget{
+				{Provider: "krkn-operator", ClusterName: "cluster-1", ClusterAPIURL: "https://cluster-1:6443"},
+			}},
+		},
+	}
+	if err := handler.client.Create(ctx, run); err != nil {
+		t.Fatalf("failed to create scenario run: %v", err)
+	}
+
+	tokenGen, err := handler.getTokenGenerator(ctx)
+	if err != nil {
+		t.Fatalf("failed to get token generator: %v", err)
+	}
+	token, err := tokenGen.GenerateToken("admin@example.com", "admin", "", "", "")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	server := httptest.NewServer(handler.Routes(noAuth))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + ScenariosRunPath + "/run-1" + ScenarioRunWatchSuffix
+
+	dialer := websocket.Dialer{Subprotocols: []string{"access_token." + token}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to open WebSocket: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var status ScenarioRunStatusResponse
+	if err := conn.ReadJSON(&status); err != nil {
+		t.Fatalf("failed to read initial status: %v", err)
+	}
+	if status.Phase != "Pending" || len(status.ClusterJobs) != 1 ||
+		status.ResolvedTargets == nil || len(status.ResolvedTargets.Targets) != 1 {
+		t.Fatalf("unexpected initial status: %+v", status)
+	}
+
+	// The handler registered with the informer before sending the initial status
+	updated := run.DeepCopy()
+	updated.Status.Phase = "Running"
+	updated.Status.RunningJobs = 1
+	updated.Status.ClusterJobs[0].Phase = "Running"
+	if err := handler.client.Update(ctx, updated); err != nil {
+		t.Fatalf("failed to update scenario run: %v", err)
+	}
+	informer.Update(run, updated)
+
+	if err := conn.ReadJSON(&status); err != nil {
+		t.Fatalf("failed to read status update: %v", err)
+	}
+	if status.Phase != "Running" || status.RunningJobs != 1 || status.ClusterJobs[0].Phase != "Running" {
+		t.Fatalf("unexpected status update: %+v", status)
+	}
+
+	if err := handler.client.Delete(ctx, updated); err != nil {
+		t.Fatalf("failed to delete scenario run: %v", err)
+	}
+	informer.Delete(updated)
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseNormalClosure {
+		t.Fatalf("expected a normal closure once the run is deleted, got %v", err)
+	}
+}
+
+func TestWatchScenarioRunStatus_RequiresToken(t *testing.T) {
+	handler := setupTestHandler()
+	handler.scenarioRunInformer = &controllertest.FakeInformer{Synced: true}
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, httptest.NewRequest(http.MethodGet, ScenariosRunPath+"/run-1"+ScenarioRunWatchSuffix, nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestWatchScenarioRunStatus_Authorizer(t *testing.T) {
+	ctx := context.Background()
+	handler := setupTestHandler()
+	handler.scenarioRunInformer = &controllertest.FakeInformer{Synced: true}
+	tokenGen, err := handler.getTokenGenerator(ctx)
+	if err != nil {
+		t.Fatalf("failed to get token generator: %v", err)
+	}
+	token, err := tokenGen.GenerateToken("user@example.com", "user", "", "", "")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	// Users may only read the runs list, not watch a run
+	handler.authMiddleware = auth.NewMiddleware(tokenGen)
+	handler.authMiddleware.SetAuthorizer(&auth.RBACAuthorizer{Rules: map[auth.Role][]auth.RBACRule{
+		auth.RoleUser: {{Actions: []string{auth.ActionRead}, Resources: []string{ScenariosRunPath}}},
+	}})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, ScenariosRunPath+"/run-1"+ScenarioRunWatchSuffix, nil)
+	req.Header.Set("Sec-WebSocket-Protocol", "access_token."+token)
+	serveAPI(handler, w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d: %s", http.StatusForbidden, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"maps"
+	"slices"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// applyTemplate renders template into the fields of req it leaves unset, so the scenario,
+// image, registry and environment are checked as the run will see them. The files and
+// resources of the template are rendered into the spec of the run once it is built.
+func applyTemplate(req *ScenarioRunRequest, template *krknv1alpha1.KrknScenarioTemplate) {
+	spec := krknv1alpha1.KrknScenarioRunSpec{
+		ScenarioName:      req.ScenarioName,
+		ScenarioImage:     req.ScenarioImage,
+		Environment:       maps.Clone(req.Environment),
+		SecretEnvironment: slices.Clone(req.SecretEnvironment),
+		RegistryURL:       req.RegistryURL,
+		RegistryRef:       req.RegistryRef,
+		TimeoutSeconds:    req.TimeoutSeconds,
+	}
+	template.Render(&spec)
+
+	req.ScenarioName = spec.ScenarioName
+	req.ScenarioImage = spec.ScenarioImage
+	req.Environment = spec.Environment
+	req.SecretEnvironment = spec.SecretEnvironment
+	req.RegistryRef = spec.RegistryRef
+	req.TimeoutSeconds = spec.TimeoutSeconds
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestPostScenarioRun_TemplateRef(t *testing.T) {
+	handler := setupScenarioRunTestHandler("test-request-id", map[string]string{
+		"test-cluster": "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOiBbXQpjb250ZXh0czogW10KdXNlcnM6IFtd",
+	})
+	timeout := int64(600)
+	if err := handler.client.Create(context.Background(), &krknv1alpha1.KrknScenarioTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-chaos", Namespace: handler.namespace},
+		Spec: krknv1alpha1.KrknScenarioTemplateSpec{
+			ScenarioName:      "pod-scenarios",
+			ScenarioImage:     "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+			Environment:       map[string]string{"NAMESPACE": "default", "POD_LABEL": "app=nginx", "API_TOKEN": "t0ken"},
+			SecretEnvironment: []string{"API_TOKEN"},
+			Files:             []krknv1alpha1.FileMount{{Name: "scenario.yaml", Content: "dGVtcGxhdGU=", MountPath: "/tmp/scenario.yaml"}},
+			TimeoutSeconds:    &timeout,
+		},
+	}); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	post := func(templateRef string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, ScenariosRunPath, strings.NewReader(`{
+			"targetRequestID": "test-request-id",
+			"targetClusters": {"krkn-operator": ["test-cluster"]},
+			"templateRef": "`+templateRef+`",
+			"environment": {"NAMESPACE": "payments"}
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.PostScenarioRun(w, req)
+		return w
+	}
+
+	if w := post("missing"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown template, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w := post("pod-chaos")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ScenarioRunCreateResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !strings.HasPrefix(response.ScenarioRunName, "pod-scenarios-") {
+		t.Errorf("Expected a run named after the scenario of the template, got %s", response.ScenarioRunName)
+	}
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	key := client.ObjectKey{Name: response.ScenarioRunName, Namespace: handler.namespace}
+	if err := handler.client.Get(context.Background(), key, &scenarioRun); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	spec := scenarioRun.Spec
+	if spec.TemplateRef != "pod-chaos" || spec.ScenarioName != "pod-scenarios" ||
+		spec.ScenarioImage != "quay.io/krkn-chaos/krkn-hub:pod-scenarios" ||
+		spec.Environment["NAMESPACE"] != "payments" || spec.Environment["POD_LABEL"] != "app=nginx" ||
+		strings.Join(spec.SecretEnvironment, ",") != "API_TOKEN" || spec.Environment["API_TOKEN"] != "" ||
+		spec.SecretEnvironmentRef == "" ||
+		len(spec.Files) != 1 || spec.TimeoutSeconds == nil || *spec.TimeoutSeconds != 600 {
+		t.Errorf("Unexpected rendered spec %+v", spec)
+	}
+}
@@ -24,14 +24,23 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"strings"
 	"time"
 
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
 	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/crdcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	"github.com/krkn-chaos/krkn-operator/pkg/permcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+	"github.com/krkn-chaos/krkn-operator/pkg/usage"
 )
 
 // Server represents the REST API server
@@ -39,6 +48,8 @@ type Server struct {
 	server         *http.Server
 	handler        *Handler
 	authMiddleware *auth.Middleware
+	// registryProbeInterval is how often the default scenario registry is probed, 0 to not probe it
+	registryProbeInterval time.Duration
 }
 
 // NewServer creates a new API server
@@ -56,72 +67,11 @@ func NewServer(port int, client client.Client, clientset kubernetes.Interface, n
 		return auth.NewTokenGenerator(jwtSecret, TokenDuration, "krkn-operator")
 	}
 	authMw := auth.NewLazyMiddleware(getTokenGen)
+	handler.authMiddleware = authMw
 
-	mux := http.NewServeMux()
-
-	// Public authentication endpoints (no auth required)
-	mux.HandleFunc(AuthIsRegistered, handler.IsRegistered)
-	mux.HandleFunc(AuthRegister, handler.Register)
-	mux.HandleFunc(AuthLogin, handler.Login)
-
-	// Authenticated endpoints - user and admin access
-	mux.Handle(HealthPath, authMw.RequireAuth(http.HandlerFunc(handler.HealthCheck)))
-	mux.Handle(ClustersPath, authMw.RequireAuth(http.HandlerFunc(handler.GetClusters)))
-	mux.Handle(NodesPath, authMw.RequireAuth(http.HandlerFunc(handler.GetNodes)))
-	mux.Handle(TargetsPath, authMw.RequireAuth(http.HandlerFunc(handler.TargetsHandler)))
-	mux.Handle(TargetsPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.TargetsHandler)))
-
-	// Scenario endpoints - user and admin access
-	mux.Handle(ScenariosPath, authMw.RequireAuth(http.HandlerFunc(handler.PostScenarios)))
-	mux.Handle(ScenariosDetailPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.PostScenarioDetail)))
-	mux.Handle(ScenariosGlobalsPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.PostScenarioGlobals)))
-
-	// WebSocket endpoint for log streaming - handles JWT auth internally via Sec-WebSocket-Protocol
-	// MUST be registered BEFORE the catch-all ScenariosRunPath to match first
-	mux.HandleFunc(ScenariosRunPath+"/", func(w http.ResponseWriter, r *http.Request) {
-		// Check if this is a WebSocket logs request
-		if strings.Contains(r.URL.Path, "/jobs/") && strings.HasSuffix(r.URL.Path, "/logs") {
-			// WebSocket endpoint - auth handled internally via subprotocol
-			handler.GetScenarioRunLogs(w, r)
-			return
-		}
-		// All other ScenariosRunPath endpoints require HTTP JWT auth
-		authMw.RequireAuth(http.HandlerFunc(handler.ScenariosRunRouter)).ServeHTTP(w, r)
-	})
-
-	// Scenario run endpoints - user and admin access
-	mux.Handle(ScenariosRunPath, authMw.RequireAuth(http.HandlerFunc(handler.ScenariosRunRouter)))
-
-	// Dashboard endpoints - user and admin access
-	mux.Handle(DashboardActiveRunsPath, authMw.RequireAuth(http.HandlerFunc(handler.GetActiveRunsOverview)))
-
-	// User management endpoints - authenticated users
-	mux.Handle(UsersPath, authMw.RequireAuth(http.HandlerFunc(handler.UsersRouter)))
-	mux.Handle(UsersPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.UsersRouter)))
-
-	// User group management endpoints - admin only
-	mux.Handle(GroupsPath, authMw.RequireAuth(http.HandlerFunc(handler.GroupsRouter)))
-	mux.Handle(GroupsPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.GroupsRouter)))
-
-	// Provider config endpoints - admin only (POST), user and admin (GET)
-	// Note: handler.ProviderConfigHandler internally handles method-based authorization
-	mux.Handle(ProviderConfigPath, authMw.RequireAuth(http.HandlerFunc(handler.ProviderConfigHandler)))
-	mux.Handle(ProviderConfigPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.ProviderConfigHandler)))
-
-	// Provider endpoints - GET: user and admin, PATCH: admin only
-	// Note: handler.ProvidersRouter internally handles method-based authorization
-	mux.Handle(ProvidersPath, authMw.RequireAuth(http.HandlerFunc(handler.ProvidersRouter)))
-	mux.Handle(ProvidersPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.ProvidersRouter)))
-
-	// Target CRUD endpoints - GET: user and admin, POST/PUT/DELETE: admin only
-	// Note: handler.TargetsCRUDRouter internally handles method-based authorization
-	mux.Handle(OperatorTargetsPath, authMw.RequireAuth(http.HandlerFunc(handler.TargetsCRUDRouter)))
-	mux.Handle(OperatorTargetsPath+"/", authMw.RequireAuth(http.HandlerFunc(handler.TargetsCRUDRouter)))
-
-	// Wrap mux with logging middleware
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%d", port),
-		Handler:           loggingMiddleware(mux),
+		Handler:           handler.Routes(authMw.RequireAuth),
 		ReadHeaderTimeout: 30 * time.Second,  // Prevent Slowloris attacks
 		ReadTimeout:       60 * time.Second,  // Total request read timeout
 		WriteTimeout:      60 * time.Second,  // Response write timeout
@@ -135,11 +85,100 @@ func NewServer(port int, client client.Client, clientset kubernetes.Interface, n
 	}
 }
 
+// AddAuditSink registers an additional destination for audit records
+// (Kubernetes Events are always emitted)
+func (s *Server) AddAuditSink(sink audit.Sink) {
+	s.handler.auditor.AddSink(sink)
+}
+
+// SetAuthorizer makes the authenticated routes ask authorizer whether each call is allowed
+func (s *Server) SetAuthorizer(authorizer auth.Authorizer) {
+	s.authMiddleware.SetAuthorizer(authorizer)
+}
+
+// SetCRDChecker exposes the CRD schema check results through the diagnostics endpoint
+func (s *Server) SetCRDChecker(checker *crdcheck.Checker) {
+	s.handler.crdChecker = checker
+}
+
+// SetPermissionChecker exposes the permission check results through the diagnostics endpoint
+func (s *Server) SetPermissionChecker(checker *permcheck.Checker) {
+	s.handler.permissionChecker = checker
+}
+
+// SetScenarioRunInformer enables the scenario run status WebSocket, which is driven by
+// the events of this shared KrknScenarioRun informer
+func (s *Server) SetScenarioRunInformer(informer cache.Informer) {
+	s.handler.scenarioRunInformer = informer
+}
+
+// SetUsageTracker enables usage telemetry: the API calls are counted per endpoint in
+// tracker, and its report is served by GET /api/v1/usage
+func (s *Server) SetUsageTracker(tracker *usage.Tracker) {
+	s.handler.usage = tracker
+}
+
+// SetKubeconfigCipher enables encryption at rest of the kubeconfigs of the targets the API
+// creates and updates; sealed kubeconfigs are opened with cipher when read
+func (s *Server) SetKubeconfigCipher(cipher *envelope.Cipher) {
+	s.handler.kubeconfigCipher = cipher
+}
+
+// SetCredentialsStore makes the API keep the kubeconfigs of the targets it creates in
+// store, the targets only recording their path; targets with a Secret keep it
+func (s *Server) SetCredentialsStore(store secrets.Store) {
+	s.handler.credentialsStore = store
+}
+
+// SetTokenRotator enables the token rotation endpoint of the targets
+func (s *Server) SetTokenRotator(rotator TargetTokenRotator) {
+	s.handler.tokenRotator = rotator
+}
+
+// SetTargetArchiveRetention sets how long archived targets can be restored before the
+// target controller purges them and their credentials
+func (s *Server) SetTargetArchiveRetention(retention time.Duration) {
+	s.handler.targetArchiveRetention = retention
+}
+
+// SetHistoryStore enables the run history endpoints, which query store
+func (s *Server) SetHistoryStore(store history.Store) {
+	s.handler.history = store
+}
+
+// SetDataProviderClient shares client for the calls to the data provider, instead of the
+// plaintext connection to the address given to NewServer
+func (s *Server) SetDataProviderClient(client *grpcclient.Client) {
+	s.handler.dataProvider = client
+}
+
+// ReadyzChecks returns the readiness checks of the API dependencies, to register with
+// the operator readiness probe
+func (s *Server) ReadyzChecks() map[string]healthz.Checker {
+	return s.handler.ReadyzChecks()
+}
+
+// SetReadyzChecks makes GET /api/v1/readyz run checks, the checks registered with the
+// operator readiness probe, so that both report the same readiness
+func (s *Server) SetReadyzChecks(checks map[string]healthz.Checker) {
+	s.handler.readyzChecks = checks
+}
+
+// SetRegistryProbeInterval makes the API server call the default scenario registry every
+// interval, keeping its cached catalog and availability current (0 disables the probe)
+func (s *Server) SetRegistryProbeInterval(interval time.Duration) {
+	s.registryProbeInterval = interval
+}
+
 // Start starts the API server
 func (s *Server) Start(ctx context.Context) error {
 	logger := log.FromContext(ctx)
 	logger.Info("Starting REST API server", "addr", s.server.Addr)
 
+	if s.registryProbeInterval > 0 {
+		go s.handler.probeRegistry(ctx, s.registryProbeInterval)
+	}
+
 	errChan := make(chan error, 1)
 	go func() {
 		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -159,7 +198,19 @@ func (s *Server) Start(ctx context.Context) error {
 func (s *Server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+
+	// The audit records of the last requests still reach the sinks, within the same deadline
+	flushed := make(chan struct{})
+	go func() {
+		s.handler.auditor.Flush()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+	}
+	return err
 }
 
 // loggingMiddleware is a logging middleware for HTTP requests
@@ -172,7 +223,8 @@ func loggingMiddleware(next http.Handler) http.Handler {
 
 		next.ServeHTTP(rw, r)
 
-		logger := log.Log.WithName("api")
+		// Request logger carries the request ID set by requestid.Middleware
+		logger := log.FromContext(r.Context())
 		logger.Info("HTTP request",
 			"method", r.Method,
 			"path", r.URL.Path,
@@ -204,3 +256,9 @@ func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	}
 	return hijacker.Hijack()
 }
+
+// Unwrap returns the underlying ResponseWriter, so http.ResponseController can flush
+// streaming responses through this wrapper
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// targetConnectionTimeout bounds the connectivity check of a new target
+const targetConnectionTimeout = 10 * time.Second
+
+// checkTargetConnection requests the version of the API server of a base64 kubeconfig
+// with its credentials, which the API server rejects when they are invalid
+func checkTargetConnection(ctx context.Context, kubeconfigBase64 string) error {
+	raw, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(raw)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	config.Timeout = targetConnectionTimeout
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	err = clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsUnauthorized(err):
+		return fmt.Errorf("credentials rejected by the API server: %w", err)
+	case apierrors.IsForbidden(err):
+		return fmt.Errorf("credentials not allowed to read the API server version: %w", err)
+	default:
+		return fmt.Errorf("API server unreachable: %w", err)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// minTokenExpirationSeconds is the shortest token lifetime the TokenRequest API grants
+const minTokenExpirationSeconds = 600
+
+// TargetTokenRotator re-mints the token of token targets with token rotation
+type TargetTokenRotator interface {
+	// RotateToken stores a kubeconfig with a new token for target and returns when it expires
+	RotateToken(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) (*metav1.Time, error)
+}
+
+// targetTokenRotation validates the token rotation of a target request and converts it
+// to the target spec, nil when the request has none
+func targetTokenRotation(req *TargetTokenRotationRequest, secretType string) (*krknv1alpha1.TargetTokenRotation, error) {
+	if req == nil {
+		return nil, nil
+	}
+	if secretType != "token" {
+		return nil, fmt.Errorf("tokenRotation is only supported for secretType token")
+	}
+	if errs := validation.IsDNS1123Label(req.ServiceAccountNamespace); len(errs) > 0 {
+		return nil, fmt.Errorf("tokenRotation.serviceAccountNamespace: %s", strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(req.ServiceAccountName); len(errs) > 0 {
+		return nil, fmt.Errorf("tokenRotation.serviceAccountName: %s", strings.Join(errs, ", "))
+	}
+	if req.ExpirationSeconds != 0 && req.ExpirationSeconds < minTokenExpirationSeconds {
+		return nil, fmt.Errorf("tokenRotation.expirationSeconds must be at least %d", minTokenExpirationSeconds)
+	}
+	return &krknv1alpha1.TargetTokenRotation{
+		ServiceAccount: krknv1alpha1.ServiceAccountReference{
+			Namespace: req.ServiceAccountNamespace,
+			Name:      req.ServiceAccountName,
+		},
+		ExpirationSeconds: req.ExpirationSeconds,
+		Automatic:         req.Automatic,
+	}, nil
+}
+
+// RotateTargetToken handles POST /api/v1/operator/targets/{uuid}/rotate
+// Mints a new token from the ServiceAccount of a token target and regenerates its stored
+// kubeconfig, whether or not automatic rotation is on
+func (h *Handler) RotateTargetToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	targetUUID, err := pathID[TargetUUID](r, ParamUUID)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamUUID, err)
+		return
+	}
+
+	if h.tokenRotator == nil {
+		writeLocalizedError(w, r, http.StatusServiceUnavailable, "service_unavailable", MsgTokenRotationUnavailable, nil)
+		return
+	}
+
+	target, err := h.fetchTarget(ctx, targetUUID)
+	if err != nil {
+		h.writeTargetFetchError(w, r, err)
+		return
+	}
+	if target.Status.Archived {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetArchived, nil)
+		return
+	}
+	if target.Spec.SecretType != "token" || target.Spec.TokenRotation == nil {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTokenRotationNotSet, nil)
+		return
+	}
+
+	expires, err := h.tokenRotator.RotateToken(ctx, target)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadGateway, "token_rotation_failed", MsgTokenRotationFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RotateTargetTokenResponse{
+		UUID:           target.Spec.UUID,
+		TokenExpiresAt: expires.Time,
+	})
+}
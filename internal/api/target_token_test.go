@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// fakeTokenRotator returns expires, or err, and records the rotated targets
+type fakeTokenRotator struct {
+	expires time.Time
+	err     error
+	rotated []string
+}
+
+func (f *fakeTokenRotator) RotateToken(_ context.Context, target *krknv1alpha1.KrknOperatorTarget) (*metav1.Time, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.rotated = append(f.rotated, target.Spec.UUID)
+	expires := metav1.NewTime(f.expires)
+	return &expires, nil
+}
+
+func createTokenTarget(t *testing.T, handler *Handler, clusterName string, rotation *TargetTokenRotationRequest) (string, int) {
+	t.Helper()
+	body, _ := json.Marshal(CreateTargetRequest{
+		ClusterName:   clusterName,
+		SecretType:    "token",
+		ClusterAPIURL: "https://api." + clusterName + ".example.com:6443",
+		Token:         "test-token-123",
+		TokenRotation: rotation,
+	})
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body))))
+	var response CreateTargetResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	return response.UUID, w.Code
+}
+
+func TestCreateTarget_TokenRotation(t *testing.T) {
+	handler := setupTestHandler()
+
+	for name, rotation := range map[string]*TargetTokenRotationRequest{
+		"missing ServiceAccount": {ServiceAccountNamespace: "krkn"},
+		"short expiration":       {ServiceAccountNamespace: "krkn", ServiceAccountName: "runner", ExpirationSeconds: 60},
+	} {
+		if _, code := createTokenTarget(t, handler, "test-cluster", rotation); code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", name, http.StatusBadRequest, code)
+		}
+	}
+
+	targetUUID, code := createTokenTarget(t, handler, "test-cluster", &TargetTokenRotationRequest{
+		ServiceAccountNamespace: "krkn",
+		ServiceAccountName:      "runner",
+		ExpirationSeconds:       3600,
+		Automatic:               true,
+	})
+	if code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, code)
+	}
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(context.TODO(), client.ObjectKey{Name: targetUUID, Namespace: handler.namespace}, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	rotation := target.Spec.TokenRotation
+	if rotation == nil || rotation.ServiceAccount.Name != "runner" || rotation.ExpirationSeconds != 3600 || !rotation.Automatic {
+		t.Errorf("Unexpected token rotation %+v", rotation)
+	}
+}
+
+func TestRotateTargetToken(t *testing.T) {
+	handler := setupTestHandler()
+	rotatePath := func(targetUUID string) string {
+		return OperatorTargetsPath + "/" + targetUUID + TargetRotateSuffix
+	}
+
+	targetUUID, code := createTokenTarget(t, handler, "test-cluster", &TargetTokenRotationRequest{
+		ServiceAccountNamespace: "krkn",
+		ServiceAccountName:      "runner",
+	})
+	if code != http.StatusCreated {
+		t.Fatalf("Failed to create target: %d", code)
+	}
+
+	// Without rotator
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, rotatePath(targetUUID), nil)))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d without rotator, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	rotator := &fakeTokenRotator{expires: time.Now().Add(time.Hour).Truncate(time.Second)}
+	handler.tokenRotator = rotator
+
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, rotatePath(targetUUID), nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response RotateTargetTokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.UUID != targetUUID || !response.TokenExpiresAt.Equal(rotator.expires) || len(rotator.rotated) != 1 {
+		t.Errorf("Unexpected response %+v, rotated %v", response, rotator.rotated)
+	}
+
+	// Failures to mint are reported as a bad gateway
+	rotator.err = errors.New("forbidden")
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, rotatePath(targetUUID), nil)))
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("Expected status %d, got %d", http.StatusBadGateway, w.Code)
+	}
+
+	// Targets without token rotation
+	plainUUID, _ := createTokenTarget(t, handler, "plain-cluster", nil)
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, rotatePath(plainUUID), nil)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+}
@@ -20,30 +20,73 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
 )
 
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets/status,verbs=get;update;patch
 
+// DefaultTargetArchiveRetention is how long an archived (soft-deleted) target is kept
+// before its Secret and CR are purged, unless set with SetTargetArchiveRetention.
+// Archived targets can be restored until then.
+const DefaultTargetArchiveRetention = 7 * 24 * time.Hour
+
+// reservedTargetLabelPrefix is the prefix of the labels owned by the operator, which
+// users cannot set on targets
+const reservedTargetLabelPrefix = "krkn.krkn-chaos.dev/"
+
+// validateTargetLabels checks the user labels of a target
+func validateTargetLabels(targetLabels map[string]string) error {
+	if errs := metav1validation.ValidateLabels(targetLabels, field.NewPath("labels")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	for key := range targetLabels {
+		if strings.HasPrefix(key, reservedTargetLabelPrefix) {
+			return fmt.Errorf("labels: %s is reserved for the operator", key)
+		}
+	}
+	return nil
+}
+
+// setTargetLabels replaces the user labels of a target, keeping the ones of the operator
+func setTargetLabels(target *krknv1alpha1.KrknOperatorTarget, userLabels map[string]string) {
+	merged := make(map[string]string, len(userLabels))
+	for key, value := range target.Labels {
+		if strings.HasPrefix(key, reservedTargetLabelPrefix) {
+			merged[key] = value
+		}
+	}
+	for key, value := range userLabels {
+		merged[key] = value
+	}
+	target.Labels = merged
+}
+
 // fetchTarget retrieves a KrknOperatorTarget by UUID.
 // Returns the target and any error encountered.
-func (h *Handler) fetchTarget(ctx context.Context, targetUUID string) (*krknv1alpha1.KrknOperatorTarget, error) {
+func (h *Handler) fetchTarget(ctx context.Context, targetUUID TargetUUID) (*krknv1alpha1.KrknOperatorTarget, error) {
 	var target krknv1alpha1.KrknOperatorTarget
 	err := h.client.Get(ctx, types.NamespacedName{
-		Name:      targetUUID,
+		Name:      string(targetUUID),
 		Namespace: h.namespace,
 	}, &target)
 
@@ -81,16 +124,28 @@ func generateKubeconfigFromKubeconfigType(req CreateTargetRequest) (string, stri
 		return "", "", fmt.Errorf("kubeconfig is required when secretType is 'kubeconfig'")
 	}
 
-	if err := kubeconfig.Validate(req.Kubeconfig); err != nil {
+	// Only the selected context and its credentials are stored, not the whole file
+	kubeconfigBase64, err := kubeconfig.ForContext(req.Kubeconfig, req.KubeconfigContext)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	if err := kubeconfig.Validate(kubeconfigBase64); err != nil {
 		return "", "", fmt.Errorf("invalid kubeconfig: %w", err)
 	}
 
-	apiURL, err := kubeconfig.ExtractAPIURL(req.Kubeconfig)
+	apiURL, err := kubeconfig.ExtractAPIURL(kubeconfigBase64)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to extract API URL from kubeconfig: %w", err)
 	}
 
-	return req.Kubeconfig, apiURL, nil
+	// The kubeconfig keeps the server as written, only the target records the canonical URL
+	apiURL, err = kubeconfig.NormalizeAPIURL(apiURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	return kubeconfigBase64, apiURL, nil
 }
 
 // generateKubeconfigFromTokenType handles token-based authentication.
@@ -103,11 +158,17 @@ func generateKubeconfigFromTokenType(req CreateTargetRequest) (string, string, e
 		return "", "", fmt.Errorf("clusterAPIURL is required when secretType is 'token'")
 	}
 
+	apiURL, err := kubeconfig.NormalizeAPIURL(req.ClusterAPIURL)
+	if err != nil {
+		return "", "", err
+	}
+
 	insecureSkipTLS := req.CABundle == ""
 	kubeconfigBase64, err := kubeconfig.GenerateFromToken(
 		req.ClusterName,
-		req.ClusterAPIURL,
+		apiURL,
 		req.CABundle,
+		req.TLSServerName,
 		req.Token,
 		insecureSkipTLS,
 	)
@@ -115,7 +176,7 @@ func generateKubeconfigFromTokenType(req CreateTargetRequest) (string, string, e
 		return "", "", fmt.Errorf("failed to generate kubeconfig from token: %w", err)
 	}
 
-	return kubeconfigBase64, req.ClusterAPIURL, nil
+	return kubeconfigBase64, apiURL, nil
 }
 
 // generateKubeconfigFromCredentialsType handles username/password authentication.
@@ -128,11 +189,17 @@ func generateKubeconfigFromCredentialsType(req CreateTargetRequest) (string, str
 		return "", "", fmt.Errorf("clusterAPIURL is required when secretType is 'credentials'")
 	}
 
+	apiURL, err := kubeconfig.NormalizeAPIURL(req.ClusterAPIURL)
+	if err != nil {
+		return "", "", err
+	}
+
 	insecureSkipTLS := req.CABundle == ""
 	kubeconfigBase64, err := kubeconfig.GenerateFromCredentials(
 		req.ClusterName,
-		req.ClusterAPIURL,
+		apiURL,
 		req.CABundle,
+		req.TLSServerName,
 		req.Username,
 		req.Password,
 		insecureSkipTLS,
@@ -141,7 +208,41 @@ func generateKubeconfigFromCredentialsType(req CreateTargetRequest) (string, str
 		return "", "", fmt.Errorf("failed to generate kubeconfig from credentials: %w", err)
 	}
 
-	return kubeconfigBase64, req.ClusterAPIURL, nil
+	return kubeconfigBase64, apiURL, nil
+}
+
+// writeTargetConflict writes a 409 response and returns true when another target, other
+// than excludeUUID, already uses clusterName or the same API URL once normalized.
+// Archived targets match too, so that they are restored rather than registered again.
+func (h *Handler) writeTargetConflict(ctx context.Context, w http.ResponseWriter, r *http.Request, excludeUUID, clusterName, apiURL string) bool {
+	var existingTargets krknv1alpha1.KrknOperatorTargetList
+	if err := h.client.List(ctx, &existingTargets, client.InNamespace(h.namespace)); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetCheckFailed, i18n.Params{"error": err.Error()})
+		return true
+	}
+
+	for _, target := range existingTargets.Items {
+		if excludeUUID != "" && target.Spec.UUID == excludeUUID {
+			continue
+		}
+
+		sameURL := kubeconfig.SameAPIURL(target.Spec.ClusterAPIURL, apiURL)
+		if target.Status.Archived && (target.Spec.ClusterName == clusterName || sameURL) {
+			writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetArchivedDuplicate, i18n.Params{"name": target.Name})
+			return true
+		}
+
+		if target.Spec.ClusterName == clusterName {
+			writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetClusterNameExists, i18n.Params{"clusterName": clusterName})
+			return true
+		}
+
+		if sameURL {
+			writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetClusterURLExists, i18n.Params{"clusterAPIURL": apiURL})
+			return true
+		}
+	}
+	return false
 }
 
 // CreateTarget handles POST /api/v1/operator/targets
@@ -151,79 +252,90 @@ func (h *Handler) CreateTarget(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request body
 	var req CreateTargetRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	if req.ClusterName == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "clusterName is required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "clusterName"})
 		return
 	}
 
 	if req.SecretType == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "secretType is required (kubeconfig, token, or credentials)",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetSecretTypeRequired, nil)
+		return
+	}
+
+	if err := validateTargetLabels(req.Labels); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	kubeconfigBase64, apiURL, err := generateKubeconfigFromRequest(req)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
-	// Check for duplicate clusterName or clusterAPIURL
-	var existingTargets krknv1alpha1.KrknOperatorTargetList
-	if err := h.client.List(ctx, &existingTargets, client.InNamespace(h.namespace)); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to check existing targets: " + err.Error(),
-		})
+	if h.writeTargetConflict(ctx, w, r, "", req.ClusterName, apiURL) {
 		return
 	}
 
-	for _, target := range existingTargets.Items {
-		if target.Spec.ClusterName == req.ClusterName {
-			writeJSONError(w, http.StatusConflict, ErrorResponse{
-				Error:   "conflict",
-				Message: fmt.Sprintf("Target with clusterName '%s' already exists", req.ClusterName),
-			})
+	// Unusable credentials are rejected instead of creating a target that is never ready
+	if h.checkTargetConnection != nil && (req.ValidateConnection == nil || *req.ValidateConnection) {
+		if err := h.checkTargetConnection(r.Context(), kubeconfigBase64); err != nil {
+			writeLocalizedError(w, r, http.StatusUnprocessableEntity, "target_unreachable", MsgTargetUnreachable,
+				i18n.Params{"clusterAPIURL": apiURL, "error": err.Error()})
 			return
 		}
+	}
 
-		if target.Spec.ClusterAPIURL != "" && target.Spec.ClusterAPIURL == apiURL {
-			writeJSONError(w, http.StatusConflict, ErrorResponse{
-				Error:   "conflict",
-				Message: fmt.Sprintf("Target with clusterAPIURL '%s' already exists", apiURL),
-			})
-			return
-		}
+	target, failure := h.createTargetResources(ctx, req, kubeconfigBase64, apiURL)
+	if failure != nil {
+		writeLocalizedError(w, r, failure.status, failure.errType, failure.code, failure.params)
+		return
+	}
+
+	// Return success response
+	response := CreateTargetResponse{
+		UUID:    target.Spec.UUID,
+		Code:    MsgTargetCreateSucceeded,
+		Message: localizedMessage(w, r, MsgTargetCreateSucceeded, nil),
 	}
 
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// targetCreateFailure is why the resources of a target could not be created
+type targetCreateFailure struct {
+	status  int
+	errType string
+	code    string
+	params  i18n.Params
+}
+
+// createTargetResources stores the kubeconfig, in a Secret or in the credentials store,
+// and creates the KrknOperatorTarget of a validated request, and removes them again when
+// a later step fails
+func (h *Handler) createTargetResources(ctx context.Context, req CreateTargetRequest, kubeconfigBase64, apiURL string) (*krknv1alpha1.KrknOperatorTarget, *targetCreateFailure) {
 	// Generate UUIDs
 	targetUUID := uuid.New().String()
 	secretUUID := uuid.New().String()
 
-	// Create Secret with kubeconfig
-	secretData, err := kubeconfig.MarshalSecretData(kubeconfigBase64)
+	// Create Secret with kubeconfig, sealed when encryption at rest is on
+	storedKubeconfig, err := h.kubeconfigCipher.Seal(ctx, kubeconfigBase64)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to marshal secret data: " + err.Error(),
-		})
-		return
+		return nil, &targetCreateFailure{status: http.StatusInternalServerError, errType: "internal_error",
+			code: MsgKubeconfigSealFailed, params: i18n.Params{"error": err.Error()}}
+	}
+	secretData, err := kubeconfig.MarshalSecretData(storedKubeconfig)
+	if err != nil {
+		return nil, &targetCreateFailure{status: http.StatusInternalServerError, errType: "internal_error",
+			code: MsgSecretMarshalFailed, params: i18n.Params{"error": err.Error()}}
 	}
 
 	secret := &corev1.Secret{
@@ -239,12 +351,36 @@ func (h *Handler) CreateTarget(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	if err := h.client.Create(ctx, secret); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create secret: " + err.Error(),
-		})
-		return
+	metrics, err := targetMetricsConfig(req.Metrics, secret)
+	if err != nil {
+		return nil, &targetCreateFailure{status: http.StatusBadRequest, errType: "bad_request",
+			code: MsgValidationFailed, params: i18n.Params{"error": err.Error()}}
+	}
+	tokenRotation, err := targetTokenRotation(req.TokenRotation, req.SecretType)
+	if err != nil {
+		return nil, &targetCreateFailure{status: http.StatusBadRequest, errType: "bad_request",
+			code: MsgValidationFailed, params: i18n.Params{"error": err.Error()}}
+	}
+
+	// The credentials go to the credentials store when there is one, to the Secret otherwise
+	vaultPath := ""
+	if h.credentialsStore != nil {
+		vaultPath = secrets.TargetPath(targetUUID)
+		secretUUID = ""
+		if err := h.credentialsStore.Put(ctx, vaultPath, secret.Data); err != nil {
+			return nil, &targetCreateFailure{status: http.StatusInternalServerError, errType: "internal_error",
+				code: MsgCredentialsStoreFailed, params: i18n.Params{"error": err.Error()}}
+		}
+	} else if err := h.client.Create(ctx, secret); err != nil {
+		return nil, &targetCreateFailure{status: http.StatusInternalServerError, errType: "internal_error",
+			code: MsgSecretCreateFailed, params: i18n.Params{"error": err.Error()}}
+	}
+	removeCredentials := func() {
+		if vaultPath != "" {
+			_ = h.credentialsStore.Delete(ctx, vaultPath) // Best-effort cleanup
+		} else {
+			_ = h.client.Delete(ctx, secret) // Best-effort cleanup
+		}
 	}
 
 	// Create KrknOperatorTarget CR
@@ -252,6 +388,7 @@ func (h *Handler) CreateTarget(w http.ResponseWriter, r *http.Request) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      targetUUID,
 			Namespace: h.namespace,
+			Labels:    req.Labels,
 		},
 		Spec: krknv1alpha1.KrknOperatorTargetSpec{
 			UUID:                  targetUUID,
@@ -259,20 +396,21 @@ func (h *Handler) CreateTarget(w http.ResponseWriter, r *http.Request) {
 			ClusterAPIURL:         apiURL,
 			SecretType:            req.SecretType,
 			SecretUUID:            secretUUID,
+			VaultPath:             vaultPath,
 			CABundle:              req.CABundle,
 			InsecureSkipTLSVerify: req.CABundle == "",
+			Metrics:               metrics,
+			TokenRotation:         tokenRotation,
 		},
 	}
+	target.Default()
 
 	if err := h.client.Create(ctx, target); err != nil {
-		// Cleanup secret on error
-		_ = h.client.Delete(ctx, secret) // Best-effort cleanup
+		// Cleanup credentials on error
+		removeCredentials()
 
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create target: " + err.Error(),
-		})
-		return
+		return nil, &targetCreateFailure{status: http.StatusInternalServerError, errType: "internal_error",
+			code: MsgTargetCreateFailed, params: i18n.Params{"error": err.Error()}}
 	}
 
 	// Update status separately (status is ignored during Create)
@@ -280,50 +418,133 @@ func (h *Handler) CreateTarget(w http.ResponseWriter, r *http.Request) {
 		Ready:       true,
 		LastUpdated: metav1.Now(),
 	}
+	// Flags kubeconfigs that only work where their auth plugin is installed
+	plugins, _ := kubeconfig.AuthPlugins(kubeconfigBase64)
+	target.Status.SetAuthPlugins(plugins, target.Generation)
 	if err := h.client.Status().Update(ctx, target); err != nil {
 		// Cleanup on error
 		_ = h.client.Delete(ctx, target) // Best-effort cleanup
-		_ = h.client.Delete(ctx, secret) // Best-effort cleanup
+		removeCredentials()
 
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update target status: " + err.Error(),
-		})
-		return
-	}
-
-	// Return success response
-	response := CreateTargetResponse{
-		UUID:    targetUUID,
-		Message: "Target created successfully",
+		return nil, &targetCreateFailure{status: http.StatusInternalServerError, errType: "internal_error",
+			code: MsgTargetStatusFailed, params: i18n.Params{"error": err.Error()}}
 	}
 
-	writeJSON(w, http.StatusCreated, response)
+	return target, nil
 }
 
 // ListTargets handles GET /api/v1/operator/targets
-// Returns a list of all KrknOperatorTarget CRs
+// Returns a list of KrknOperatorTarget CRs.
+// Archived targets are hidden unless ?includeArchived=true is set.
+//
+// Query parameters:
+//   - secretType: only targets with this secret type
+//   - ready: "true" or "false" to filter by readiness
+//   - labelSelector: only targets whose labels match, e.g. "env=staging,region=eu"
+//   - sort: "createdAt" (default) or "clusterName"
+//   - order: "asc" (default) or "desc"
+//   - limit / continue: pagination (no limit returns every target)
 func (h *Handler) ListTargets(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
+	query := r.URL.Query()
+	includeArchived := query.Get("includeArchived") == "true"
+	secretType := query.Get("secretType")
+
+	page, code, err := parsePageRequest(r)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", code, nil)
+		return
+	}
+
+	var readyFilter *bool
+	if ready := query.Get("ready"); ready != "" {
+		parsed, err := strconv.ParseBool(ready)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidBoolParam, i18n.Params{"param": "ready"})
+			return
+		}
+		readyFilter = &parsed
+	}
+
+	sortBy := query.Get("sort")
+	if sortBy == "" {
+		sortBy = "createdAt"
+	}
+	if sortBy != "createdAt" && sortBy != "clusterName" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidSortField,
+			i18n.Params{"sort": sortBy, "allowed": "createdAt, clusterName"})
+		return
+	}
+
+	order := query.Get("order")
+	if order != "" && order != "asc" && order != "desc" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidSortOrder, nil)
+		return
+	}
+
+	selector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidLabelSelector,
+			i18n.Params{"param": "labelSelector", "error": err.Error()})
+		return
+	}
 
 	// List all targets
 	var targets krknv1alpha1.KrknOperatorTargetList
-	if err := h.client.List(ctx, &targets, client.InNamespace(h.namespace)); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list targets: " + err.Error(),
-		})
+	if err := h.client.List(ctx, &targets, client.InNamespace(h.namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetListFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
+	// Apply filters
+	filtered := make([]krknv1alpha1.KrknOperatorTarget, 0, len(targets.Items))
+	for _, target := range targets.Items {
+		if target.Status.Archived && !includeArchived {
+			continue
+		}
+		if secretType != "" && target.Spec.SecretType != secretType {
+			continue
+		}
+		if readyFilter != nil && target.Status.Ready != *readyFilter {
+			continue
+		}
+		filtered = append(filtered, target)
+	}
+
+	// Sort with a name tie-breaker so pages are stable across requests
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if order == "desc" {
+			a, b = b, a
+		}
+		switch sortBy {
+		case "clusterName":
+			if a.Spec.ClusterName != b.Spec.ClusterName {
+				return a.Spec.ClusterName < b.Spec.ClusterName
+			}
+		default:
+			if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+				return a.CreationTimestamp.Before(&b.CreationTimestamp)
+			}
+		}
+		return a.Name < b.Name
+	})
+
+	start, end, next := page.paginate(len(filtered))
+
 	// Convert to response format
-	targetResponses := make([]TargetResponse, 0, len(targets.Items))
-	for i := range targets.Items {
-		targetResponses = append(targetResponses, buildTargetResponse(&targets.Items[i]))
+	targetResponses := make([]TargetResponse, 0, end-start)
+	for i := start; i < end; i++ {
+		targetResponses = append(targetResponses, buildTargetResponse(&filtered[i]))
 	}
 
 	response := ListTargetsResponse{
 		Targets: targetResponses,
+		Pagination: PaginationInfo{
+			Total:    len(filtered),
+			Limit:    page.Limit,
+			Continue: next,
+		},
 	}
 
 	writeJSON(w, http.StatusOK, response)
@@ -334,18 +555,15 @@ func (h *Handler) ListTargets(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
-	targetUUID, err := extractPathSuffix(r.URL.Path, OperatorTargetsPath+"/")
+	targetUUID, err := pathID[TargetUUID](r, ParamUUID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UUID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamUUID, err)
 		return
 	}
 
 	target, err := h.fetchTarget(ctx, targetUUID)
 	if err != nil {
-		h.writeTargetFetchError(w, err)
+		h.writeTargetFetchError(w, r, err)
 		return
 	}
 
@@ -358,68 +576,99 @@ func (h *Handler) GetTarget(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) UpdateTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
-	targetUUID, err := extractPathSuffix(r.URL.Path, OperatorTargetsPath+"/")
+	targetUUID, err := pathID[TargetUUID](r, ParamUUID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UUID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamUUID, err)
 		return
 	}
 
 	var req UpdateTargetRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	target, err := h.fetchTarget(ctx, targetUUID)
 	if err != nil {
-		h.writeTargetFetchError(w, err)
+		h.writeTargetFetchError(w, r, err)
+		return
+	}
+
+	if target.Status.Archived {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetArchived, nil)
+		return
+	}
+
+	if err := validateTargetLabels(req.Labels); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	kubeconfigBase64, apiURL, err := generateKubeconfigFromRequest(req.CreateTargetRequest)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	tokenRotation, err := targetTokenRotation(req.TokenRotation, req.SecretType)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
-	// Update Secret with new kubeconfig
+	clusterName := req.ClusterName
+	if clusterName == "" {
+		clusterName = target.Spec.ClusterName
+	}
+	if h.writeTargetConflict(ctx, w, r, target.Spec.UUID, clusterName, apiURL) {
+		return
+	}
+
+	// Update Secret, or the credentials in the credentials store, with new kubeconfig
 	var secret corev1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{
+	if target.Spec.VaultPath != "" {
+		data, err := secrets.TargetCredentials(ctx, h.client, h.credentialsStore, target)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCredentialsGetFailed, i18n.Params{"error": err.Error()})
+			return
+		}
+		secret.Data = data
+	} else if err := h.client.Get(ctx, types.NamespacedName{
 		Name:      target.Spec.SecretUUID,
 		Namespace: h.namespace,
 	}, &secret); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get secret: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgSecretGetFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
-	secretData, err := kubeconfig.MarshalSecretData(kubeconfigBase64)
+	storedKubeconfig, err := h.kubeconfigCipher.Seal(ctx, kubeconfigBase64)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to marshal secret data: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgKubeconfigSealFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+	secretData, err := kubeconfig.MarshalSecretData(storedKubeconfig)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgSecretMarshalFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	secret.Data["kubeconfig"] = secretData
 
-	if err := h.client.Update(ctx, &secret); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update secret: " + err.Error(),
-		})
+	metrics, err := targetMetricsConfig(req.Metrics, &secret)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	if target.Spec.VaultPath != "" {
+		if err := h.credentialsStore.Put(ctx, target.Spec.VaultPath, secret.Data); err != nil {
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgCredentialsStoreFailed, i18n.Params{"error": err.Error()})
+			return
+		}
+	} else if err := h.client.Update(ctx, &secret); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgSecretUpdateFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -431,140 +680,169 @@ func (h *Handler) UpdateTarget(w http.ResponseWriter, r *http.Request) {
 	target.Spec.SecretType = req.SecretType
 	target.Spec.CABundle = req.CABundle
 	target.Spec.InsecureSkipTLSVerify = req.CABundle == ""
-	target.Status.LastUpdated = metav1.Now()
+	target.Spec.Metrics = metrics
+	target.Spec.TokenRotation = tokenRotation
+	if req.Labels != nil {
+		setTargetLabels(target, req.Labels)
+	}
 
 	if err := h.client.Update(ctx, target); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update target: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetUpdateFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	// The new kubeconfig may authenticate differently
+	plugins, _ := kubeconfig.AuthPlugins(kubeconfigBase64)
+	target.Status.SetAuthPlugins(plugins, target.Generation)
+	target.Status.LastUpdated = metav1.Now()
+	// The expiry of the token of the request is unknown
+	target.Status.TokenExpiresAt = nil
+	target.Status.LastTokenRotation = nil
+	if err := h.client.Status().Update(ctx, target); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetStatusFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	response := CreateTargetResponse{
-		UUID:    targetUUID,
-		Message: "Target updated successfully",
+		UUID:    string(targetUUID),
+		Code:    MsgTargetUpdateSucceeded,
+		Message: localizedMessage(w, r, MsgTargetUpdateSucceeded, nil),
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
 // DeleteTarget handles DELETE /api/v1/operator/targets/{uuid}
-// Deletes a KrknOperatorTarget and its associated Secret
+// Archives (soft-deletes) a KrknOperatorTarget. The target is hidden from target
+// pickers and excluded from scenario runs, and its Secret is kept until the
+// retention window (targetArchiveRetention) passes, after which the
+// KrknOperatorTarget controller purges both the Secret and the CR.
 func (h *Handler) DeleteTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
-	targetUUID, err := extractPathSuffix(r.URL.Path, OperatorTargetsPath+"/")
+	targetUUID, err := pathID[TargetUUID](r, ParamUUID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "UUID " + err.Error(),
-		})
+		writeInvalidIDError(w, r, ParamUUID, err)
 		return
 	}
 
 	target, err := h.fetchTarget(ctx, targetUUID)
 	if err != nil {
-		h.writeTargetFetchError(w, err)
+		h.writeTargetFetchError(w, r, err)
 		return
 	}
 
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      target.Spec.SecretUUID,
-			Namespace: h.namespace,
-		},
+	if target.Status.Archived {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetAlreadyArchived, nil)
+		return
 	}
 
-	// Best-effort cleanup of secret (ignore if not found)
-	_ = h.client.Delete(ctx, secret)
+	now := metav1.Now()
+	purgeAfter := metav1.NewTime(now.Add(h.targetArchiveRetention))
+	target.Status.Archived = true
+	target.Status.ArchivedAt = &now
+	target.Status.PurgeAfter = &purgeAfter
+	target.Status.LastUpdated = now
 
-	if err := h.client.Delete(ctx, target); err != nil {
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete target: " + err.Error(),
-		})
+	if err := h.client.Status().Update(ctx, target); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetArchiveFailed, i18n.Params{"error": err.Error()})
 		return
 	}
 
 	response := CreateTargetResponse{
-		UUID:    targetUUID,
-		Message: "Target deleted successfully",
+		UUID:    string(targetUUID),
+		Code:    MsgTargetArchiveSucceeded,
+		Message: localizedMessage(w, r, MsgTargetArchiveSucceeded, i18n.Params{"purgeAfter": purgeAfter.UTC().Format(time.RFC3339)}),
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
-// TargetsCRUDRouter routes requests to /api/v1/operator/targets endpoints
-func (h *Handler) TargetsCRUDRouter(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
+// RestoreTarget handles POST /api/v1/operator/targets/{uuid}/restore
+// Restores an archived KrknOperatorTarget if its retention window has not passed yet
+func (h *Handler) RestoreTarget(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+
+	targetUUID, err := pathID[TargetUUID](r, ParamUUID)
+	if err != nil {
+		writeInvalidIDError(w, r, ParamUUID, err)
+		return
+	}
 
-	// POST, PUT, DELETE require admin privileges
-	if !h.requireAdminForMethods(w, r, []string{http.MethodPost, http.MethodPut, http.MethodDelete}) {
+	target, err := h.fetchTarget(ctx, targetUUID)
+	if err != nil {
+		h.writeTargetFetchError(w, r, err)
 		return
 	}
 
-	// POST /api/v1/operator/targets - create new target (admin only)
-	if path == OperatorTargetsPath && r.Method == http.MethodPost {
-		h.CreateTarget(w, r)
+	if !target.Status.Archived {
+		writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetNotArchived, nil)
 		return
 	}
 
-	// GET /api/v1/operator/targets - list all targets (user and admin)
-	if path == OperatorTargetsPath && r.Method == http.MethodGet {
-		h.ListTargets(w, r)
+	if target.Status.PurgeAfter != nil && time.Now().After(target.Status.PurgeAfter.Time) {
+		writeLocalizedError(w, r, http.StatusGone, "gone", MsgTargetRetentionExpired, nil)
 		return
 	}
 
-	// Path with UUID: /api/v1/operator/targets/{uuid}
-	if strings.HasPrefix(path, OperatorTargetsPath+"/") {
-		// GET /api/v1/operator/targets/{uuid} - get single target (user and admin)
-		if r.Method == http.MethodGet {
-			h.GetTarget(w, r)
-			return
-		}
+	target.Status.Archived = false
+	target.Status.ArchivedAt = nil
+	target.Status.PurgeAfter = nil
+	target.Status.LastUpdated = metav1.Now()
 
-		// PUT /api/v1/operator/targets/{uuid} - update target (admin only)
-		if r.Method == http.MethodPut {
-			h.UpdateTarget(w, r)
-			return
-		}
+	if err := h.client.Status().Update(ctx, target); err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgTargetRestoreFailed, i18n.Params{"error": err.Error()})
+		return
+	}
 
-		// DELETE /api/v1/operator/targets/{uuid} - delete target (admin only)
-		if r.Method == http.MethodDelete {
-			h.DeleteTarget(w, r)
-			return
-		}
+	response := CreateTargetResponse{
+		UUID:    string(targetUUID),
+		Code:    MsgTargetRestoreSucceeded,
+		Message: localizedMessage(w, r, MsgTargetRestoreSucceeded, nil),
 	}
 
-	// Method not allowed
-	writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-		Error:   "method_not_allowed",
-		Message: "Method " + r.Method + " not allowed for path " + path,
-	})
+	writeJSON(w, http.StatusOK, response)
 }
 
 // writeTargetFetchError writes appropriate error response based on the fetch error.
-func (h *Handler) writeTargetFetchError(w http.ResponseWriter, err error) {
+func (h *Handler) writeTargetFetchError(w http.ResponseWriter, r *http.Request, err error) {
 	statusCode := http.StatusInternalServerError
 	if strings.Contains(err.Error(), "not found") {
 		statusCode = http.StatusNotFound
 	}
-	writeJSONError(w, statusCode, ErrorResponse{
-		Error:   "error",
-		Message: err.Error(),
-	})
+	writeLocalizedError(w, r, statusCode, "error", MsgValidationFailed, i18n.Params{"error": err.Error()})
 }
 
 // buildTargetResponse constructs a TargetResponse from a KrknOperatorTarget CR.
 func buildTargetResponse(target *krknv1alpha1.KrknOperatorTarget) TargetResponse {
 	createdAt := target.CreationTimestamp.Time
-	return TargetResponse{
+	response := TargetResponse{
 		UUID:          target.Spec.UUID,
 		ClusterName:   target.Spec.ClusterName,
 		ClusterAPIURL: target.Spec.ClusterAPIURL,
 		SecretType:    target.Spec.SecretType,
 		Ready:         target.Status.Ready,
 		CreatedAt:     &createdAt,
+		Archived:      target.Status.Archived,
+		ArchivedAt:    convertMetaTime(target.Status.ArchivedAt),
+		PurgeAfter:    convertMetaTime(target.Status.PurgeAfter),
+
+		LastRunTime:         convertMetaTime(target.Status.LastRunTime),
+		LastRunName:         target.Status.LastRunName,
+		LastRunPhase:        target.Status.LastRunPhase,
+		RecentSuccessRate:   target.Status.RecentSuccessRate,
+		ConsecutiveFailures: target.Status.ConsecutiveFailures,
+
+		LastProbeTime:            convertMetaTime(target.Status.LastProbeTime),
+		ProbeLatencyMilliseconds: target.Status.ProbeLatencyMilliseconds,
+		ProbeError:               target.Status.ProbeError,
+		AuthPlugins:              target.Status.AuthPlugins,
+		TokenExpiresAt:           convertMetaTime(target.Status.TokenExpiresAt),
+
+		Labels: target.Labels,
+	}
+	if target.Spec.Metrics != nil {
+		response.PrometheusURL = target.Spec.Metrics.PrometheusURL
 	}
+	return response
 }
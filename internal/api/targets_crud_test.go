@@ -22,20 +22,32 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/controller"
 	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
 )
 
 // setupTestHandler creates a test Handler with fake clients
@@ -43,18 +55,22 @@ func setupTestHandler() *Handler {
 	scheme := runtime.NewScheme()
 	_ = krknv1alpha1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 
 	fakeClient := fakeclient.NewClientBuilder().
 		WithScheme(scheme).
-		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}, &krknv1alpha1.KrknUser{}).
 		Build()
 	fakeClientset := fake.NewSimpleClientset()
 
 	return &Handler{
-		client:         fakeClient,
-		clientset:      fakeClientset,
-		namespace:      "test-namespace",
-		grpcServerAddr: "localhost:50051",
+		client:       fakeClient,
+		clientset:    fakeClientset,
+		namespace:    "test-namespace",
+		dataProvider: grpcclient.NewClient("localhost:50051", nil),
+		metricsProxy: newMetricsProxy(MetricsProxyConfig{AllowedMetrics: defaultAllowedMetrics, RateLimit: defaultMetricsProxyRateLimit}),
+
+		targetArchiveRetention: DefaultTargetArchiveRetention,
 	}
 }
 
@@ -66,6 +82,7 @@ func TestCreateTarget_WithKubeconfig(t *testing.T) {
 		"test-cluster",
 		"https://api.test.com:6443",
 		"",
+		"",
 		"test-token",
 		true,
 	)
@@ -133,6 +150,127 @@ func TestCreateTarget_WithKubeconfig(t *testing.T) {
 	}
 }
 
+func TestCreateTarget_SealsKubeconfig(t *testing.T) {
+	handler := setupTestHandler()
+	wrapper, err := envelope.NewLocalKeyWrapper(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewLocalKeyWrapper failed: %v", err)
+	}
+	handler.kubeconfigCipher = envelope.NewCipher(wrapper)
+
+	validKubeconfig, err := kubeconfig.GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "test-token", true)
+	if err != nil {
+		t.Fatalf("Failed to generate test kubeconfig: %v", err)
+	}
+	body, _ := json.Marshal(CreateTargetRequest{ClusterName: "test-cluster", SecretType: "kubeconfig", Kubeconfig: validKubeconfig})
+	req := httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.CreateTarget(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response CreateTargetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(req.Context(), client.ObjectKey{Name: response.UUID, Namespace: handler.namespace}, &target); err != nil {
+		t.Fatalf("Failed to get created target: %v", err)
+	}
+	var secret corev1.Secret
+	if err := handler.client.Get(req.Context(), client.ObjectKey{Name: target.Spec.SecretUUID, Namespace: handler.namespace}, &secret); err != nil {
+		t.Fatalf("Failed to get created secret: %v", err)
+	}
+	stored, err := kubeconfig.UnmarshalSecretData(secret.Data["kubeconfig"])
+	if err != nil || !envelope.IsSealed(stored) {
+		t.Errorf("Expected a sealed kubeconfig in the Secret, got %q, %v", stored, err)
+	}
+
+	kubeconfigBase64, err := handler.getKubeconfigFromOperatorTarget(req.Context(), TargetUUID(response.UUID))
+	if err != nil {
+		t.Fatalf("getKubeconfigFromOperatorTarget failed: %v", err)
+	}
+	if err := kubeconfig.Validate(kubeconfigBase64); err != nil {
+		t.Errorf("Expected the opened kubeconfig to be valid: %v", err)
+	}
+}
+
+// memoryStore is a secrets.Store keeping the credentials in memory
+type memoryStore map[string]map[string][]byte
+
+func (m memoryStore) Get(_ context.Context, path string) (map[string][]byte, error) {
+	data, ok := m[path]
+	if !ok {
+		return nil, secrets.ErrNotFound
+	}
+	return maps.Clone(data), nil
+}
+
+func (m memoryStore) Put(_ context.Context, path string, data map[string][]byte) error {
+	m[path] = maps.Clone(data)
+	return nil
+}
+
+func (m memoryStore) Delete(_ context.Context, path string) error {
+	delete(m, path)
+	return nil
+}
+
+func TestCreateTarget_CredentialsStore(t *testing.T) {
+	handler := setupTestHandler()
+	store := memoryStore{}
+	handler.credentialsStore = store
+
+	validKubeconfig, err := kubeconfig.GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "test-token", true)
+	if err != nil {
+		t.Fatalf("Failed to generate test kubeconfig: %v", err)
+	}
+	body, _ := json.Marshal(CreateTargetRequest{ClusterName: "test-cluster", SecretType: "kubeconfig", Kubeconfig: validKubeconfig})
+	req := httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.CreateTarget(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response CreateTargetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(req.Context(), client.ObjectKey{Name: response.UUID, Namespace: handler.namespace}, &target); err != nil {
+		t.Fatalf("Failed to get created target: %v", err)
+	}
+	if target.Spec.VaultPath != secrets.TargetPath(response.UUID) || target.Spec.SecretUUID != "" {
+		t.Errorf("Expected only the Vault path on the target, got %q and Secret %q", target.Spec.VaultPath, target.Spec.SecretUUID)
+	}
+	if _, ok := store[target.Spec.VaultPath]["kubeconfig"]; !ok {
+		t.Errorf("Expected the kubeconfig in the credentials store, got %v", store)
+	}
+	var secretList corev1.SecretList
+	if err := handler.client.List(req.Context(), &secretList, client.InNamespace(handler.namespace)); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secretList.Items) != 0 {
+		t.Errorf("Expected no Secret to be created, got %d", len(secretList.Items))
+	}
+
+	kubeconfigBase64, err := handler.getKubeconfigFromOperatorTarget(req.Context(), TargetUUID(response.UUID))
+	if err != nil {
+		t.Fatalf("getKubeconfigFromOperatorTarget failed: %v", err)
+	}
+	if kubeconfigBase64 != validKubeconfig {
+		t.Error("Expected the kubeconfig to be read back from the credentials store")
+	}
+
+	// Without the store, the credentials cannot be read
+	handler.credentialsStore = nil
+	if _, err := handler.getKubeconfigFromOperatorTarget(req.Context(), TargetUUID(response.UUID)); err == nil {
+		t.Error("Expected an error reading credentials without a credentials store")
+	}
+}
+
 func TestCreateTarget_WithToken(t *testing.T) {
 	handler := setupTestHandler()
 
@@ -331,7 +469,7 @@ func TestCreateTarget_DuplicateClusterName(t *testing.T) {
 	handler := setupTestHandler()
 
 	// Create first target
-	validKubeconfig, _ := kubeconfig.GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "token", true)
+	validKubeconfig, _ := kubeconfig.GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "token", true)
 
 	existingTarget := &krknv1alpha1.KrknOperatorTarget{
 		ObjectMeta: metav1.ObjectMeta{
@@ -376,6 +514,121 @@ func TestCreateTarget_DuplicateClusterName(t *testing.T) {
 	}
 }
 
+func TestCreateTarget_DuplicateNormalizedURL(t *testing.T) {
+	handler := setupTestHandler()
+
+	existingTarget := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing-uuid",
+			Namespace: handler.namespace,
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{
+			UUID:          "existing-uuid",
+			ClusterName:   "test-cluster",
+			ClusterAPIURL: "https://api.test.com:6443",
+			SecretType:    "token",
+			SecretUUID:    "secret-uuid",
+		},
+	}
+	if err := handler.client.Create(context.TODO(), existingTarget); err != nil {
+		t.Fatalf("Failed to create existing target: %v", err)
+	}
+
+	for _, apiURL := range []string{"https://api.test.com:6443/", "HTTPS://API.Test.com:6443", "api.test.com:6443"} {
+		reqBody := CreateTargetRequest{
+			ClusterName:   "other-cluster",
+			SecretType:    "token",
+			ClusterAPIURL: apiURL,
+			Token:         "token",
+		}
+
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.CreateTarget(w, req)
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("%s: expected status %d, got %d", apiURL, http.StatusConflict, w.Code)
+		}
+	}
+
+	// A different port is a different endpoint, and the stored URL is the canonical one
+	reqBody := CreateTargetRequest{
+		ClusterName:   "other-cluster",
+		SecretType:    "token",
+		ClusterAPIURL: "https://API.test.com:443/",
+		Token:         "token",
+	}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CreateTarget(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var resp CreateTargetResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	target, err := handler.fetchTarget(context.TODO(), TargetUUID(resp.UUID))
+	if err != nil {
+		t.Fatalf("Failed to get created target: %v", err)
+	}
+	if target.Spec.ClusterAPIURL != "https://api.test.com" {
+		t.Errorf("Expected normalized clusterAPIURL, got %q", target.Spec.ClusterAPIURL)
+	}
+}
+
+func TestUpdateTarget_DuplicateURL(t *testing.T) {
+	handler := setupTestHandler()
+
+	for _, target := range []*krknv1alpha1.KrknOperatorTarget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "target-a", Namespace: handler.namespace},
+			Spec: krknv1alpha1.KrknOperatorTargetSpec{
+				UUID:          "target-a",
+				ClusterName:   "cluster-a",
+				ClusterAPIURL: "https://api.a.com:6443",
+				SecretType:    "token",
+				SecretUUID:    "secret-a",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "target-b", Namespace: handler.namespace},
+			Spec: krknv1alpha1.KrknOperatorTargetSpec{
+				UUID:          "target-b",
+				ClusterName:   "cluster-b",
+				ClusterAPIURL: "https://api.b.com:6443",
+				SecretType:    "token",
+				SecretUUID:    "secret-b",
+			},
+		},
+	} {
+		if err := handler.client.Create(context.TODO(), target); err != nil {
+			t.Fatalf("Failed to create target: %v", err)
+		}
+	}
+
+	// Pointing target-b at target-a's cluster is refused
+	updateReq := UpdateTargetRequest{
+		CreateTargetRequest: CreateTargetRequest{
+			SecretType:    "token",
+			ClusterAPIURL: "https://api.a.com:6443/",
+			Token:         "new-token",
+		},
+	}
+	body, _ := json.Marshal(updateReq)
+	req := httptest.NewRequest(http.MethodPut, OperatorTargetsPath+"/target-b", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	serveAPI(handler, w, withAdminClaims(req))
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+}
+
 func TestListTargets(t *testing.T) {
 	handler := setupTestHandler()
 
@@ -483,7 +736,7 @@ func TestGetTarget(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, OperatorTargetsPath+"/test-uuid", nil)
 	w := httptest.NewRecorder()
 
-	handler.GetTarget(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -509,19 +762,25 @@ func TestGetTarget_NotFound(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, OperatorTargetsPath+"/non-existent-uuid", nil)
 	w := httptest.NewRecorder()
 
-	handler.GetTarget(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
 }
 
-func TestDeleteTarget(t *testing.T) {
-	handler := setupTestHandler()
+// withAdminClaims attaches admin claims to the request context
+func withAdminClaims(req *http.Request) *http.Request {
+	ctx := context.WithValue(req.Context(), auth.UserClaimsKey, &auth.Claims{
+		UserID: "admin@example.com",
+		Role:   "admin",
+	})
+	return req.WithContext(ctx)
+}
 
-	// Create target and secret
-	secretUUID := "secret-uuid"
-	targetUUID := "target-uuid"
+// createArchivableTarget creates a target and its secret for soft-delete tests
+func createArchivableTarget(t *testing.T, handler *Handler, targetUUID, secretUUID string) {
+	t.Helper()
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -554,36 +813,207 @@ func TestDeleteTarget(t *testing.T) {
 	if err := handler.client.Create(context.TODO(), target); err != nil {
 		t.Fatalf("Failed to create target: %v", err)
 	}
+}
+
+func TestDeleteTarget(t *testing.T) {
+	handler := setupTestHandler()
+
+	secretUUID := "secret-uuid"
+	targetUUID := "target-uuid"
+	createArchivableTarget(t, handler, targetUUID, secretUUID)
 
 	req := httptest.NewRequest(http.MethodDelete, OperatorTargetsPath+"/"+targetUUID, nil)
 	w := httptest.NewRecorder()
 
-	handler.DeleteTarget(w, req)
+	serveAPI(handler, w, withAdminClaims(req))
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
 	}
 
-	// Verify target was deleted
-	var deletedTarget krknv1alpha1.KrknOperatorTarget
-	err := handler.client.Get(context.TODO(), client.ObjectKey{
+	// Verify target was archived, not deleted
+	var archivedTarget krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(context.TODO(), client.ObjectKey{
 		Name:      targetUUID,
 		Namespace: handler.namespace,
-	}, &deletedTarget)
+	}, &archivedTarget); err != nil {
+		t.Fatalf("Expected target to still exist after archive: %v", err)
+	}
 
-	if err == nil {
-		t.Error("Expected target to be deleted, but it still exists")
+	if !archivedTarget.Status.Archived {
+		t.Error("Expected target to be archived")
 	}
 
-	// Verify secret was deleted
-	var deletedSecret corev1.Secret
-	err = handler.client.Get(context.TODO(), client.ObjectKey{
+	if archivedTarget.Status.ArchivedAt == nil || archivedTarget.Status.PurgeAfter == nil {
+		t.Fatal("Expected ArchivedAt and PurgeAfter to be set")
+	}
+
+	if got := archivedTarget.Status.PurgeAfter.Sub(archivedTarget.Status.ArchivedAt.Time); got != DefaultTargetArchiveRetention {
+		t.Errorf("Expected retention window %s, got %s", DefaultTargetArchiveRetention, got)
+	}
+
+	// Verify secret was kept for the retention window
+	var secret corev1.Secret
+	if err := handler.client.Get(context.TODO(), client.ObjectKey{
 		Name:      secretUUID,
 		Namespace: handler.namespace,
-	}, &deletedSecret)
+	}, &secret); err != nil {
+		t.Errorf("Expected secret to be kept during retention window: %v", err)
+	}
 
-	if err == nil {
-		t.Error("Expected secret to be deleted, but it still exists")
+	// Deleting again should conflict
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodDelete, OperatorTargetsPath+"/"+targetUUID, nil)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for already archived target, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestDeleteTarget_RetentionPurged(t *testing.T) {
+	handler := setupTestHandler()
+	handler.targetArchiveRetention = 10 * time.Millisecond
+	createArchivableTarget(t, handler, "target-uuid", "secret-uuid")
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodDelete, OperatorTargetsPath+"/target-uuid", nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to archive target: %d %s", w.Code, w.Body.String())
+	}
+	key := client.ObjectKey{Name: "target-uuid", Namespace: handler.namespace}
+	var archived krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(context.TODO(), key, &archived); err != nil {
+		t.Fatalf("Failed to get archived target: %v", err)
+	}
+	// The status keeps second precision
+	if archived.Status.PurgeAfter == nil || archived.Status.PurgeAfter.After(time.Now().Add(time.Second)) {
+		t.Errorf("Expected the target to be purged after the configured window, got %v", archived.Status.PurgeAfter)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, OperatorTargetsPath+"/target-uuid"+TargetRestoreSuffix, nil)))
+	if w.Code != http.StatusGone {
+		t.Errorf("Expected status %d once the window passed, got %d. Body: %s", http.StatusGone, w.Code, w.Body.String())
+	}
+
+	reconciler := &controller.KrknOperatorTargetReconciler{Client: handler.client, Scheme: handler.client.Scheme()}
+	if _, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := handler.client.Get(context.TODO(), key, &archived); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the target to be purged, got err=%v", err)
+	}
+	var secret corev1.Secret
+	err := handler.client.Get(context.TODO(), client.ObjectKey{Name: "secret-uuid", Namespace: handler.namespace}, &secret)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the secret to be purged, got err=%v", err)
+	}
+}
+
+func TestListTargets_HidesArchived(t *testing.T) {
+	handler := setupTestHandler()
+
+	createArchivableTarget(t, handler, "target-uuid", "secret-uuid")
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodDelete, OperatorTargetsPath+"/target-uuid", nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to archive target: %d %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedCount int
+	}{
+		{name: "archived hidden by default", query: "", expectedCount: 0},
+		{name: "archived included on request", query: "?includeArchived=true", expectedCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ListTargets(w, httptest.NewRequest(http.MethodGet, OperatorTargetsPath+tt.query, nil))
+
+			var response ListTargetsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if len(response.Targets) != tt.expectedCount {
+				t.Errorf("Expected %d targets, got %d", tt.expectedCount, len(response.Targets))
+			}
+
+			if tt.expectedCount > 0 && !response.Targets[0].Archived {
+				t.Error("Expected archived flag in response")
+			}
+		})
+	}
+}
+
+func TestRestoreTarget(t *testing.T) {
+	handler := setupTestHandler()
+
+	createArchivableTarget(t, handler, "target-uuid", "secret-uuid")
+
+	// Restoring a non-archived target should conflict
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, OperatorTargetsPath+"/target-uuid"+TargetRestoreSuffix, nil)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodDelete, OperatorTargetsPath+"/target-uuid", nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to archive target: %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, OperatorTargetsPath+"/target-uuid"+TargetRestoreSuffix, nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var restored krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(context.TODO(), client.ObjectKey{
+		Name:      "target-uuid",
+		Namespace: handler.namespace,
+	}, &restored); err != nil {
+		t.Fatalf("Failed to get restored target: %v", err)
+	}
+
+	if restored.Status.Archived || restored.Status.ArchivedAt != nil || restored.Status.PurgeAfter != nil {
+		t.Errorf("Expected archive fields to be cleared, got %+v", restored.Status)
+	}
+}
+
+func TestRestoreTarget_RetentionExpired(t *testing.T) {
+	handler := setupTestHandler()
+
+	createArchivableTarget(t, handler, "target-uuid", "secret-uuid")
+
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(context.TODO(), client.ObjectKey{
+		Name:      "target-uuid",
+		Namespace: handler.namespace,
+	}, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+
+	archivedAt := metav1.NewTime(time.Now().Add(-2 * DefaultTargetArchiveRetention))
+	purgeAfter := metav1.NewTime(time.Now().Add(-DefaultTargetArchiveRetention))
+	target.Status.Archived = true
+	target.Status.ArchivedAt = &archivedAt
+	target.Status.PurgeAfter = &purgeAfter
+	if err := handler.client.Status().Update(context.TODO(), &target); err != nil {
+		t.Fatalf("Failed to archive target: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodPost, OperatorTargetsPath+"/target-uuid"+TargetRestoreSuffix, nil)))
+	if w.Code != http.StatusGone {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusGone, w.Code, w.Body.String())
 	}
 }
 
@@ -641,7 +1071,7 @@ func TestUpdateTarget(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPut, OperatorTargetsPath+"/"+targetUUID, bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
-	handler.UpdateTarget(w, req)
+	serveAPI(handler, w, withAdminClaims(req))
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
@@ -691,3 +1121,342 @@ func TestUpdateTarget(t *testing.T) {
 		t.Error("Expected kubeconfig to be updated, but it's still the initial value")
 	}
 }
+
+// setupListTargetsHandler creates a handler pre-populated with targets for list tests
+func setupListTargetsHandler() *Handler {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	base := time.Now().Add(-time.Hour)
+	newTarget := func(name, clusterName, secretType string, ready bool, age time.Duration) *krknv1alpha1.KrknOperatorTarget {
+		return &krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "test-namespace",
+				CreationTimestamp: metav1.NewTime(base.Add(age)),
+			},
+			Spec: krknv1alpha1.KrknOperatorTargetSpec{
+				UUID:        name,
+				ClusterName: clusterName,
+				SecretType:  secretType,
+			},
+			Status: krknv1alpha1.KrknOperatorTargetStatus{Ready: ready},
+		}
+	}
+
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}, &krknv1alpha1.KrknUser{}).
+		WithObjects(
+			newTarget("t1", "charlie", "kubeconfig", true, 1*time.Minute),
+			newTarget("t2", "alpha", "token", false, 2*time.Minute),
+			newTarget("t3", "bravo", "kubeconfig", true, 3*time.Minute),
+			newTarget("t4", "delta", "credentials", true, 4*time.Minute),
+		).
+		Build()
+
+	return &Handler{
+		client:       fakeClient,
+		clientset:    fake.NewSimpleClientset(),
+		namespace:    "test-namespace",
+		dataProvider: grpcclient.NewClient("localhost:50051", nil),
+	}
+}
+
+func TestListTargets_FilterAndSort(t *testing.T) {
+	handler := setupListTargetsHandler()
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedOrder  []string
+	}{
+		{name: "default sort by creation", query: "", expectedStatus: http.StatusOK, expectedOrder: []string{"charlie", "alpha", "bravo", "delta"}},
+		{name: "sort by cluster name", query: "?sort=clusterName", expectedStatus: http.StatusOK, expectedOrder: []string{"alpha", "bravo", "charlie", "delta"}},
+		{name: "sort descending", query: "?sort=clusterName&order=desc", expectedStatus: http.StatusOK, expectedOrder: []string{"delta", "charlie", "bravo", "alpha"}},
+		{name: "filter by secretType", query: "?secretType=kubeconfig", expectedStatus: http.StatusOK, expectedOrder: []string{"charlie", "bravo"}},
+		{name: "filter by ready", query: "?ready=false", expectedStatus: http.StatusOK, expectedOrder: []string{"alpha"}},
+		{name: "invalid sort", query: "?sort=uuid", expectedStatus: http.StatusBadRequest},
+		{name: "invalid order", query: "?order=up", expectedStatus: http.StatusBadRequest},
+		{name: "invalid ready", query: "?ready=maybe", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ListTargets(w, httptest.NewRequest(http.MethodGet, OperatorTargetsPath+tt.query, nil))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response ListTargetsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			var got []string
+			for _, target := range response.Targets {
+				got = append(got, target.ClusterName)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expectedOrder, ",") {
+				t.Errorf("Expected order %v, got %v", tt.expectedOrder, got)
+			}
+			if response.Pagination.Total != len(tt.expectedOrder) {
+				t.Errorf("Expected total %d, got %d", len(tt.expectedOrder), response.Pagination.Total)
+			}
+		})
+	}
+}
+
+func TestListTargets_Pagination(t *testing.T) {
+	handler := setupListTargetsHandler()
+
+	var seen []string
+	continueToken := ""
+	for pages := 0; pages < 10; pages++ {
+		url := OperatorTargetsPath + "?sort=clusterName&limit=3"
+		if continueToken != "" {
+			url += "&continue=" + continueToken
+		}
+
+		w := httptest.NewRecorder()
+		handler.ListTargets(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var response ListTargetsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Pagination.Total != 4 || response.Pagination.Limit != 3 {
+			t.Errorf("Unexpected pagination metadata: %+v", response.Pagination)
+		}
+
+		for _, target := range response.Targets {
+			seen = append(seen, target.ClusterName)
+		}
+
+		continueToken = response.Pagination.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+
+	if strings.Join(seen, ",") != "alpha,bravo,charlie,delta" {
+		t.Errorf("Expected all targets across pages, got %v", seen)
+	}
+
+	for _, query := range []string{"?limit=0", "?limit=abc", "?continue=!!!"} {
+		w := httptest.NewRecorder()
+		handler.ListTargets(w, httptest.NewRequest(http.MethodGet, OperatorTargetsPath+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestCreateTarget_ValidateConnection(t *testing.T) {
+	validKubeconfig, err := kubeconfig.GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "test-token", true)
+	if err != nil {
+		t.Fatalf("Failed to generate test kubeconfig: %v", err)
+	}
+	disabled := false
+
+	tests := []struct {
+		name       string
+		validate   *bool
+		checkErr   error
+		wantStatus int
+		wantChecks int
+	}{
+		{name: "reachable", wantStatus: http.StatusCreated, wantChecks: 1},
+		{name: "unusable credentials", checkErr: errors.New("credentials rejected by the API server"), wantStatus: http.StatusUnprocessableEntity, wantChecks: 1},
+		{name: "check disabled", validate: &disabled, checkErr: errors.New("unreachable"), wantStatus: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := setupTestHandler()
+			checks := 0
+			handler.checkTargetConnection = func(_ context.Context, kubeconfigBase64 string) error {
+				checks++
+				if kubeconfigBase64 != validKubeconfig {
+					t.Errorf("Expected the request kubeconfig to be checked")
+				}
+				return tt.checkErr
+			}
+
+			body, _ := json.Marshal(CreateTargetRequest{
+				ClusterName:        "test-cluster",
+				SecretType:         "kubeconfig",
+				Kubeconfig:         validKubeconfig,
+				ValidateConnection: tt.validate,
+			})
+			w := httptest.NewRecorder()
+			handler.CreateTarget(w, httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if checks != tt.wantChecks {
+				t.Errorf("Expected %d connection checks, got %d", tt.wantChecks, checks)
+			}
+			if tt.wantStatus != http.StatusUnprocessableEntity {
+				return
+			}
+			if !strings.Contains(w.Body.String(), "credentials rejected") {
+				t.Errorf("Expected the check error in the response, got %s", w.Body.String())
+			}
+			var targets krknv1alpha1.KrknOperatorTargetList
+			var secrets corev1.SecretList
+			_ = handler.client.List(context.Background(), &targets)
+			_ = handler.client.List(context.Background(), &secrets)
+			if len(targets.Items) != 0 || len(secrets.Items) != 0 {
+				t.Errorf("Expected nothing created, got %d targets and %d secrets", len(targets.Items), len(secrets.Items))
+			}
+		})
+	}
+}
+
+func TestCheckTargetConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer good-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"major":"1","minor":"31","gitVersion":"v1.31.0"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		apiURL  string
+		token   string
+		wantErr string
+	}{
+		{name: "valid token", apiURL: server.URL, token: "good-token"},
+		{name: "rejected token", apiURL: server.URL, token: "bad-token", wantErr: "credentials rejected"},
+		{name: "unreachable", apiURL: "https://127.0.0.1:1", token: "good-token", wantErr: "API server unreachable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeconfigBase64, err := kubeconfig.GenerateFromToken("test-cluster", tt.apiURL, "", "", tt.token, true)
+			if err != nil {
+				t.Fatalf("Failed to generate test kubeconfig: %v", err)
+			}
+			err = checkTargetConnection(context.Background(), kubeconfigBase64)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTargetLabels(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+
+	create := func(clusterName string, targetLabels map[string]string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(CreateTargetRequest{
+			ClusterName:   clusterName,
+			SecretType:    "token",
+			ClusterAPIURL: "https://" + clusterName + ".example.com:6443",
+			Token:         "test-token",
+			Labels:        targetLabels,
+		})
+		w := httptest.NewRecorder()
+		handler.CreateTarget(w, httptest.NewRequest(http.MethodPost, OperatorTargetsPath, bytes.NewReader(body)))
+		return w
+	}
+	list := func(query string) []string {
+		w := httptest.NewRecorder()
+		handler.ListTargets(w, httptest.NewRequest(http.MethodGet, OperatorTargetsPath+query, nil))
+		var response ListTargetsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		var names []string
+		for _, target := range response.Targets {
+			names = append(names, target.ClusterName)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	for _, invalid := range []map[string]string{
+		{"env": "staging eu"},
+		{"krkn.krkn-chaos.dev/uuid": "x"},
+	} {
+		if w := create("invalid", invalid); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for labels %v, got %d", http.StatusBadRequest, invalid, w.Code)
+		}
+	}
+
+	w := create("staging-eu", map[string]string{"env": "staging", "region": "eu"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created CreateTargetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if w := create("staging-us", map[string]string{"env": "staging", "region": "us"}); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	if got := list("?labelSelector=env%3Dstaging,region%3Deu"); !slices.Equal(got, []string{"staging-eu"}) {
+		t.Errorf("Expected the eu target, got %v", got)
+	}
+	if got := list("?labelSelector=env%3Dstaging"); !slices.Equal(got, []string{"staging-eu", "staging-us"}) {
+		t.Errorf("Expected both targets, got %v", got)
+	}
+	w = httptest.NewRecorder()
+	handler.ListTargets(w, httptest.NewRequest(http.MethodGet, OperatorTargetsPath+"?labelSelector=env+in+(", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid selector, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Updating replaces the user labels and keeps the ones of the operator
+	var target krknv1alpha1.KrknOperatorTarget
+	key := client.ObjectKey{Name: created.UUID, Namespace: handler.namespace}
+	if err := handler.client.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	target.Labels["krkn.krkn-chaos.dev/group"] = "sre"
+	if err := handler.client.Update(ctx, &target); err != nil {
+		t.Fatalf("Failed to update target: %v", err)
+	}
+	body, _ := json.Marshal(UpdateTargetRequest{CreateTargetRequest: CreateTargetRequest{
+		SecretType:    "token",
+		ClusterAPIURL: "https://staging-eu.example.com:6443",
+		Token:         "new-token",
+		Labels:        map[string]string{"env": "prod"},
+	}})
+	req := httptest.NewRequest(http.MethodPut, OperatorTargetsPath+"/"+created.UUID, bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(req))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if err := handler.client.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	want := map[string]string{"env": "prod", "krkn.krkn-chaos.dev/group": "sre"}
+	if !maps.Equal(target.Labels, want) {
+		t.Errorf("Expected labels %v, got %v", want, target.Labels)
+	}
+	if response := buildTargetResponse(&target); !maps.Equal(response.Labels, want) {
+		t.Errorf("Expected the labels in the response, got %v", response.Labels)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// importedContext is a kubeconfig context validated for import
+type importedContext struct {
+	req              CreateTargetRequest
+	kubeconfigBase64 string
+	apiURL           string
+}
+
+// ImportTargets handles POST /api/v1/operator/targets/import
+// Creates one KrknOperatorTarget per context of a multi-context kubeconfig, each storing
+// only the cluster and credentials of its context. Every context is validated, checked
+// against the existing targets and, unless disabled, connected to before any target is
+// created, and the created targets are removed again if one fails, so either all the
+// contexts are imported or none.
+func (h *Handler) ImportTargets(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	logger := log.FromContext(r.Context())
+
+	var req ImportTargetsRequest
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgInvalidRequestBody, i18n.Params{"error": err.Error()})
+		return
+	}
+	if req.Kubeconfig == "" {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgFieldRequired, i18n.Params{"field": "kubeconfig"})
+		return
+	}
+	if err := validateTargetLabels(req.Labels); err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed, i18n.Params{"error": err.Error()})
+		return
+	}
+
+	available, err := kubeconfig.ContextNames(req.Kubeconfig)
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": fmt.Sprintf("invalid kubeconfig: %v", err)})
+		return
+	}
+	contexts := available
+	if len(req.Contexts) > 0 {
+		contexts = slices.Compact(slices.Sorted(slices.Values(req.Contexts)))
+	}
+	for _, name := range contexts {
+		if !slices.Contains(available, name) {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetImportNoContext, i18n.Params{"context": name})
+			return
+		}
+	}
+	for name := range req.ClusterNames {
+		if !slices.Contains(contexts, name) {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetImportNoContext, i18n.Params{"context": name})
+			return
+		}
+	}
+	if len(contexts) == 0 {
+		writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgValidationFailed,
+			i18n.Params{"error": "kubeconfig has no context"})
+		return
+	}
+
+	// Validate every context before creating anything
+	imports := make([]importedContext, 0, len(contexts))
+	for _, name := range contexts {
+		clusterName := req.ClusterNames[name]
+		if clusterName == "" {
+			clusterName = name
+		}
+		contextReq := CreateTargetRequest{
+			ClusterName:        clusterName,
+			SecretType:         "kubeconfig",
+			Kubeconfig:         req.Kubeconfig,
+			KubeconfigContext:  name,
+			Labels:             req.Labels,
+			ValidateConnection: req.ValidateConnection,
+		}
+		kubeconfigBase64, apiURL, err := generateKubeconfigFromRequest(contextReq)
+		if err != nil {
+			writeLocalizedError(w, r, http.StatusBadRequest, "bad_request", MsgTargetImportContext,
+				i18n.Params{"context": name, "error": err.Error()})
+			return
+		}
+
+		// Two contexts of the same cluster, e.g. with different users, would be one target twice
+		for _, other := range imports {
+			if other.req.ClusterName == clusterName || kubeconfig.SameAPIURL(other.apiURL, apiURL) {
+				writeLocalizedError(w, r, http.StatusConflict, "conflict", MsgTargetImportContext,
+					i18n.Params{"context": name, "error": fmt.Sprintf("same cluster as context '%s'", other.req.KubeconfigContext)})
+				return
+			}
+		}
+		if h.writeTargetConflict(ctx, w, r, "", clusterName, apiURL) {
+			return
+		}
+		imports = append(imports, importedContext{req: contextReq, kubeconfigBase64: kubeconfigBase64, apiURL: apiURL})
+	}
+
+	if h.checkTargetConnection != nil && (req.ValidateConnection == nil || *req.ValidateConnection) {
+		for _, imported := range imports {
+			if err := h.checkTargetConnection(r.Context(), imported.kubeconfigBase64); err != nil {
+				writeLocalizedError(w, r, http.StatusUnprocessableEntity, "target_unreachable", MsgTargetUnreachable,
+					i18n.Params{"clusterAPIURL": imported.apiURL, "error": err.Error()})
+				return
+			}
+		}
+	}
+
+	response := ImportTargetsResponse{Targets: make([]ImportedTarget, 0, len(imports))}
+	created := make([]*krknv1alpha1.KrknOperatorTarget, 0, len(imports))
+	for _, imported := range imports {
+		target, failure := h.createTargetResources(ctx, imported.req, imported.kubeconfigBase64, imported.apiURL)
+		if failure != nil {
+			for _, target := range created {
+				_ = h.client.Delete(ctx, target) // Best-effort cleanup
+				if target.Spec.VaultPath != "" {
+					_ = h.credentialsStore.Delete(ctx, target.Spec.VaultPath) // Best-effort cleanup
+					continue
+				}
+				_ = h.client.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      target.Spec.SecretUUID,
+					Namespace: h.namespace,
+				}}) // Best-effort cleanup
+			}
+			writeLocalizedError(w, r, failure.status, failure.errType, failure.code, failure.params)
+			return
+		}
+		created = append(created, target)
+		response.Targets = append(response.Targets, ImportedTarget{
+			Context:       imported.req.KubeconfigContext,
+			UUID:          target.Spec.UUID,
+			ClusterName:   target.Spec.ClusterName,
+			ClusterAPIURL: target.Spec.ClusterAPIURL,
+		})
+	}
+
+	logger.Info("Imported kubeconfig contexts as targets", "contexts", contexts)
+	writeJSON(w, http.StatusCreated, response)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+)
+
+const multiContextKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://api.dev.example.com:6443
+- name: prod
+  cluster:
+    server: https://api.prod.example.com:6443
+users:
+- name: dev-admin
+  user:
+    token: dev-token
+- name: prod-admin
+  user:
+    token: prod-token
+- name: prod-viewer
+  user:
+    token: viewer-token
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev-admin
+- name: prod
+  context:
+    cluster: prod
+    user: prod-admin
+- name: prod-viewer
+  context:
+    cluster: prod
+    user: prod-viewer
+`
+
+func postImportTargets(handler *Handler, body ImportTargetsRequest) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, OperatorTargetsPath+TargetImportSuffix, bytes.NewBuffer(payload))
+	w := httptest.NewRecorder()
+	handler.ImportTargets(w, withAdminClaims(req))
+	return w
+}
+
+func TestImportTargets(t *testing.T) {
+	handler := setupTestHandler()
+	ctx := context.Background()
+	encoded := base64.StdEncoding.EncodeToString([]byte(multiContextKubeconfig))
+
+	w := postImportTargets(handler, ImportTargetsRequest{
+		Kubeconfig:   encoded,
+		Contexts:     []string{"prod", "dev"},
+		ClusterNames: map[string]string{"prod": "production"},
+		Labels:       map[string]string{"env": "imported"},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var response ImportTargetsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Targets) != 2 {
+		t.Fatalf("Expected 2 targets, got %+v", response.Targets)
+	}
+	dev, prod := response.Targets[0], response.Targets[1]
+	if dev.Context != "dev" || dev.ClusterName != "dev" || dev.ClusterAPIURL != "https://api.dev.example.com:6443" {
+		t.Errorf("Unexpected dev target %+v", dev)
+	}
+	if prod.Context != "prod" || prod.ClusterName != "production" {
+		t.Errorf("Expected the prod cluster name to be overridden, got %+v", prod)
+	}
+
+	// Each target only stores the credentials of its context
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := handler.client.Get(ctx, types.NamespacedName{Name: prod.UUID, Namespace: "test-namespace"}, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	if target.Spec.SecretType != "kubeconfig" || target.Labels["env"] != "imported" || !target.Status.Ready {
+		t.Errorf("Unexpected target %+v", target)
+	}
+	var secret corev1.Secret
+	if err := handler.client.Get(ctx, types.NamespacedName{Name: target.Spec.SecretUUID, Namespace: "test-namespace"}, &secret); err != nil {
+		t.Fatalf("Failed to get secret: %v", err)
+	}
+	kubeconfigBase64, err := kubeconfig.UnmarshalSecretData(secret.Data["kubeconfig"])
+	if err != nil {
+		t.Fatalf("Failed to unmarshal kubeconfig: %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if len(config.Contexts) != 1 || len(config.AuthInfos) != 1 || config.AuthInfos["prod-admin"] == nil {
+		t.Errorf("Expected only the prod context and user, got %v contexts and %v users", len(config.Contexts), len(config.AuthInfos))
+	}
+
+	// Importing an existing cluster again is a conflict
+	if w := postImportTargets(handler, ImportTargetsRequest{Kubeconfig: encoded, Contexts: []string{"dev"}}); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for an existing cluster, got %d", http.StatusConflict, w.Code)
+	}
+	if w := postImportTargets(handler, ImportTargetsRequest{Kubeconfig: encoded, Contexts: []string{"staging"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown context, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := postImportTargets(handler, ImportTargetsRequest{}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d without kubeconfig, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestImportTargets_SameClusterContexts(t *testing.T) {
+	handler := setupTestHandler()
+	encoded := base64.StdEncoding.EncodeToString([]byte(multiContextKubeconfig))
+
+	// prod and prod-viewer reach the same API server, nothing is created
+	w := postImportTargets(handler, ImportTargetsRequest{Kubeconfig: encoded})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := handler.client.List(context.Background(), &targets); err != nil || len(targets.Items) != 0 {
+		t.Errorf("Expected no target to be created, got %d (%v)", len(targets.Items), err)
+	}
+}
@@ -22,6 +22,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/history"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/audit"
+	"github.com/krkn-chaos/krkn-operator/pkg/crdcheck"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+	"github.com/krkn-chaos/krkn-operator/pkg/permcheck"
 )
 
 // ClustersResponse represents the response for GET /clusters endpoint
@@ -32,18 +38,65 @@ type ClustersResponse struct {
 	Status string `json:"status"`
 }
 
+// ClusterIDMapping maps a cluster of a target request to its stable identifier
+type ClusterIDMapping struct {
+	// ClusterID is the stable identifier of the cluster, usable in targetClusterIds
+	ClusterID string `json:"clusterId"`
+	// Provider is the name of the provider of the cluster
+	Provider string `json:"provider"`
+	// ClusterName is the current name of the cluster
+	ClusterName string `json:"clusterName"`
+	// ClusterAPIURL is the API server URL of the cluster
+	ClusterAPIURL string `json:"clusterAPIURL,omitempty"`
+}
+
+// ClusterIDsResponse represents the response for GET /clusters/ids endpoint
+type ClusterIDsResponse struct {
+	// Clusters are the matching clusters, sorted by provider and cluster name
+	Clusters []ClusterIDMapping `json:"clusters"`
+}
+
 // NodesResponse represents the response for GET /nodes endpoint
 type NodesResponse struct {
 	// Nodes contains the list of node names in the cluster
 	Nodes []string `json:"nodes"`
 }
 
+// ReadinessResponse represents the response for GET /readyz endpoint
+type ReadinessResponse struct {
+	// Status is ready when every check passed, not_ready otherwise
+	Status string `json:"status"`
+	// Checks maps each check to ok or the reason it failed
+	Checks map[string]string `json:"checks"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
+	Error string `json:"error"`
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	// RequestID identifies the request in the operator logs (X-Request-ID)
+	RequestID string `json:"requestId,omitempty"`
+	// FieldErrors lists the invalid fields of the request, when the error is about them
+	FieldErrors []FieldError `json:"fieldErrors,omitempty"`
+}
+
+// FieldError is a validation error of one field of a request
+type FieldError struct {
+	// Field is the name of the invalid field, e.g. an environment variable of the scenario
+	Field string `json:"field"`
+	// Message tells why the value was rejected
 	Message string `json:"message"`
 }
 
+// TargetRequestCreateRequest represents the optional request body for POST /targets
+// If omitted, every active provider contributes its targets
+type TargetRequestCreateRequest struct {
+	// Providers restricts target discovery to the listed provider names (optional)
+	Providers []string `json:"providers,omitempty"`
+}
+
 // ScenariosRequest represents the optional request body for POST /scenarios
 // If provided, uses private registry; if nil/empty, defaults to quay.io
 type ScenariosRequest struct {
@@ -61,6 +114,9 @@ type ScenariosRequest struct {
 	SkipTLS bool `json:"skipTls,omitempty"`
 	// Insecure allows insecure connections to private registry
 	Insecure bool `json:"insecure,omitempty"`
+	// RegistryRef names a KrknRegistry holding the private registry and its credentials,
+	// instead of the fields above (optional)
+	RegistryRef string `json:"registryRef,omitempty"`
 }
 
 // ScenarioTag represents a scenario available in the registry
@@ -79,6 +135,23 @@ type ScenarioTag struct {
 type ScenariosResponse struct {
 	// Scenarios contains the list of available scenario tags
 	Scenarios []ScenarioTag `json:"scenarios"`
+	CatalogStaleness
+}
+
+// FavoriteScenario is a scenario the user starred, with its catalog data
+type FavoriteScenario struct {
+	// Name is the name of the scenario
+	Name string `json:"name"`
+	// Catalog is the scenario tag in the registry catalog, nil when the catalog does not
+	// list the scenario or could not be read
+	Catalog *ScenarioTag `json:"catalog,omitempty"`
+}
+
+// FavoriteScenariosResponse represents the response for the scenario favorites endpoints
+type FavoriteScenariosResponse struct {
+	// Favorites are the starred scenarios, in the order they were starred
+	Favorites []FavoriteScenario `json:"favorites"`
+	CatalogStaleness
 }
 
 // InputFieldResponse represents a scenario input field with Type as string
@@ -111,6 +184,7 @@ type ScenarioDetailResponse struct {
 	Title        string               `json:"title"`
 	Description  string               `json:"description"`
 	Fields       []InputFieldResponse `json:"fields"`
+	CatalogStaleness
 }
 
 // GlobalsRequest represents the request body for POST /scenarios/globals
@@ -143,21 +217,187 @@ type ScenarioRunRequest struct {
 	// TargetClusters is a map of provider-name to list of cluster names
 	// Example: {"krkn-operator": ["cluster1", "cluster2"], "krkn-operator-acm": ["cluster3"]}
 	TargetClusters map[string][]string `json:"targetClusters"`
-
-	// ScenarioImage is the container image to run
+	// TargetClusterIDs lists clusters by their stable identifier, resolved to their current
+	// provider and name when the run starts, in addition to TargetClusters (optional)
+	TargetClusterIDs []string `json:"targetClusterIds,omitempty"`
+	// TargetSelector selects the targets of the target request whose labels match, e.g.
+	// "env=staging,region=eu", in addition to TargetClusters (optional)
+	TargetSelector string `json:"targetSelector,omitempty"`
+	// TargetGroupRef is the name of a KrknTargetGroup whose members are targeted in addition
+	// to TargetClusters, resolved when the run starts (optional)
+	TargetGroupRef string `json:"targetGroupRef,omitempty"`
+	// CampaignRef is the name of the KrknCampaign the run is part of (optional)
+	CampaignRef string `json:"campaignRef,omitempty"`
+	// Alternates maps a target cluster to an alternate cluster of the same provider the job
+	// runs on when the target is unreachable at preflight (optional)
+	Alternates map[string]string `json:"alternates,omitempty"`
+
+	// TemplateRef is the name of a KrknScenarioTemplate providing the scenario, its image and
+	// the defaults of the fields the request leaves unset (optional)
+	TemplateRef string `json:"templateRef,omitempty"`
+	// ScenarioImage is the container image to run (required without templateRef)
 	ScenarioImage string `json:"scenarioImage"`
-	// ScenarioName is the name of the scenario being executed
+	// ScenarioName is the name of the scenario being executed (required without templateRef)
 	ScenarioName string `json:"scenarioName"`
+	// DisplayName is a human-friendly name of the run, at most 128 characters (optional)
+	DisplayName string `json:"displayName,omitempty"`
+	// ImagePullPolicy is the pull policy of the scenario container: Always (default), IfNotPresent or Never (optional)
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
 	// KubeconfigPath is the path where kubeconfig should be mounted (optional, default: /home/krkn/.kube/config)
 	KubeconfigPath string `json:"kubeconfigPath,omitempty"`
 	// Environment is a map of environment variables to pass to the container (optional)
 	Environment map[string]string `json:"environment,omitempty"`
+	// SecretEnvironment lists variables of Environment passed to the container from a
+	// Secret, in addition to the fields the scenario marks secret (optional)
+	SecretEnvironment []string `json:"secretEnvironment,omitempty"`
 	// Files is an array of file objects to mount in the container (optional)
 	Files []FileMount `json:"files,omitempty"`
+	// OnFailure hooks run when a cluster job fails with no retry left (optional)
+	OnFailure []FailureHook `json:"onFailure,omitempty"`
+	// Artifacts uploads the scenario log and krkn output of each job to S3-compatible storage once it finished (optional)
+	Artifacts *ArtifactStorage `json:"artifacts,omitempty"`
+	// Elasticsearch ships the krkn telemetry of every job to Elasticsearch (optional)
+	Elasticsearch *ElasticsearchTelemetry `json:"elasticsearch,omitempty"`
+	// HealthCheck is the URL krkn probes while the scenario runs (optional)
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+	// AlertProfile is the set of alerts krkn evaluates once the scenario finished (optional)
+	AlertProfile *AlertProfile `json:"alertProfile,omitempty"`
+	// CleanupVerification checks the target clusters returned to a clean state once their job finished (optional,
+	// default: on for node and network scenarios)
+	CleanupVerification *CleanupVerification `json:"cleanupVerification,omitempty"`
+	// TimeoutSeconds kills scenario pods running longer and fails their job with reason DeadlineExceeded (optional)
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+	// MaintenancePolicy is skip (default) or wait: what happens to the job of a cluster under planned maintenance (optional)
+	MaintenancePolicy string `json:"maintenancePolicy,omitempty"`
+	// MaxConcurrency is the maximum number of target clusters the scenario runs on at the same time (optional)
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// EvictionProtection keeps node drains and autoscaler scale-downs of the hub from evicting the scenario pods (optional)
+	EvictionProtection bool `json:"evictionProtection,omitempty"`
+	// TTLSecondsAfterFinished deletes the run and its resources this long after it finished (optional)
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// AllowAllNamespaces opts out of the default namespace of namespaced scenarios, letting
+	// a run without NAMESPACE act on every namespace (optional)
+	AllowAllNamespaces bool `json:"allowAllNamespaces,omitempty"`
 	// Private registry configuration (optional)
 	ScenariosRequest
 }
 
+// FailureHook is an action run when a cluster job fails with no retry left.
+// Exactly one of Image, Webhook or Report must be set.
+type FailureHook struct {
+	// Name identifies the hook (lowercase alphanumeric and '-', at most 20 characters)
+	Name string `json:"name"`
+	// Image runs a remediation pod with the failed cluster's kubeconfig mounted
+	Image *FailureHookImage `json:"image,omitempty"`
+	// Webhook notifies an external system, e.g. to open an incident
+	Webhook *FailureHookWebhook `json:"webhook,omitempty"`
+	// Report records the failure in a KrknReport with a severity
+	Report *FailureHookReport `json:"report,omitempty"`
+}
+
+// FailureHookImage describes a remediation pod
+type FailureHookImage struct {
+	// Image is the container image to run
+	Image string `json:"image"`
+	// Command overrides the image entrypoint (optional)
+	Command []string `json:"command,omitempty"`
+	// Args are passed to the command (optional)
+	Args []string `json:"args,omitempty"`
+	// Environment is a map of environment variables for the pod (optional)
+	Environment map[string]string `json:"environment,omitempty"`
+}
+
+// FailureHookWebhook describes a notification sent for a failed job
+type FailureHookWebhook struct {
+	// Type is the channel type: webhook (default) or slack
+	Type string `json:"type,omitempty"`
+	// URL is the http(s) endpoint receiving the notification
+	URL string `json:"url"`
+}
+
+// FailureHookReport describes the KrknReport created for a failed job
+type FailureHookReport struct {
+	// Severity is info, warning (default) or critical
+	Severity string `json:"severity,omitempty"`
+}
+
+// ArtifactStorage is the S3-compatible bucket the artifacts of the jobs of a run are uploaded to
+type ArtifactStorage struct {
+	// Endpoint is the http(s) base URL of the storage; objects are addressed <endpoint>/<bucket>/<key>
+	Endpoint string `json:"endpoint"`
+	// Region is the signing region (optional, default: us-east-1)
+	Region string `json:"region,omitempty"`
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the object keys (optional)
+	Prefix string `json:"prefix,omitempty"`
+	// CredentialsSecret is the Secret in the operator namespace holding AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and optionally AWS_SESSION_TOKEN
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// ElasticsearchTelemetry is the Elasticsearch krkn ships the telemetry of the jobs of a run to
+type ElasticsearchTelemetry struct {
+	// URL is the http(s) URL of Elasticsearch, e.g. https://es.example.com:9200
+	URL string `json:"url"`
+	// Index is the index of the krkn telemetry
+	Index string `json:"index"`
+	// MetricsIndex is the index of the metrics krkn collects (optional)
+	MetricsIndex string `json:"metricsIndex,omitempty"`
+	// AlertsIndex is the index of the alerts krkn collects (optional)
+	AlertsIndex string `json:"alertsIndex,omitempty"`
+	// CredentialsSecret is the Secret in the operator namespace holding the username and
+	// password keys (optional)
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// VerifyCerts verifies the TLS certificate of Elasticsearch (optional)
+	VerifyCerts bool `json:"verifyCerts,omitempty"`
+}
+
+// HealthCheck is the URL krkn probes while the scenario of a run runs
+type HealthCheck struct {
+	// URL is the http(s) URL probed
+	URL string `json:"url"`
+	// IntervalSeconds is the time between two probes (optional, default: the krkn default)
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+	// BearerTokenSecret is the Secret in the operator namespace holding the bearer token
+	// sent with the probes (optional)
+	BearerTokenSecret string `json:"bearerTokenSecret,omitempty"`
+	// BearerTokenKey is the key of the token in BearerTokenSecret (optional, default: token)
+	BearerTokenKey string `json:"bearerTokenKey,omitempty"`
+	// ExitOnFailure fails the scenario as soon as a probe fails (optional)
+	ExitOnFailure bool `json:"exitOnFailure,omitempty"`
+	// VerifyCerts verifies the TLS certificate of the URL (optional)
+	VerifyCerts bool `json:"verifyCerts,omitempty"`
+}
+
+// AlertRule is a Prometheus expression krkn evaluates once the scenario finished
+type AlertRule struct {
+	// Expr is the PromQL expression, the alert fires when it returns a result
+	Expr string `json:"expr"`
+	// Description is the message logged when the alert fires
+	Description string `json:"description"`
+	// Severity is info, warning, error or critical
+	Severity string `json:"severity"`
+}
+
+// AlertProfile is the set of alerts krkn evaluates once the scenario of a run finished
+type AlertProfile struct {
+	// Alerts are the rules of the profile
+	Alerts []AlertRule `json:"alerts"`
+	// FailOnCritical fails the scenario when a critical alert fired (optional)
+	FailOnCritical bool `json:"failOnCritical,omitempty"`
+}
+
+// CleanupVerification configures the check that the target clusters of a run returned to
+// a clean state
+type CleanupVerification struct {
+	// Enabled turns the verification on or off (optional, default: on for node and network
+	// scenarios and runs listing namespaces)
+	Enabled *bool `json:"enabled,omitempty"`
+	// Namespaces are the test namespaces the scenario creates, that must have been removed (optional)
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
 // TargetJobResult represents the result of creating a job for a specific target
 type TargetJobResult struct {
 	// ClusterName is the name of the target cluster
@@ -190,6 +430,8 @@ type ScenarioRunResponse struct {
 type JobStatusResponse struct {
 	// JobID is the unique job identifier
 	JobID string `json:"jobId"`
+	// TargetID is the ID of the target request the job's cluster comes from
+	TargetID string `json:"targetId,omitempty"`
 	// ClusterName is the target cluster name
 	ClusterName string `json:"clusterName"`
 	// ScenarioName is the scenario name
@@ -202,6 +444,8 @@ type JobStatusResponse struct {
 	StartTime *time.Time `json:"startTime,omitempty"`
 	// CompletionTime is when the job completed (optional)
 	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message is additional status message or error details (optional)
 	Message string `json:"message,omitempty"`
 }
@@ -226,11 +470,20 @@ type CreateTargetRequest struct {
 	// CABundle is the base64-encoded CA certificate bundle (optional)
 	CABundle string `json:"caBundle,omitempty"`
 
+	// TLSServerName is the DNS name verified against the API server certificate in place of
+	// the host of ClusterAPIURL, e.g. for a cluster addressed by IP (optional, token and
+	// credentials only)
+	TLSServerName string `json:"tlsServerName,omitempty"`
+
 	// Credentials - provide ONE of the following based on SecretType:
 
 	// Kubeconfig (base64-encoded) - for SecretType="kubeconfig"
 	Kubeconfig string `json:"kubeconfig,omitempty"`
 
+	// KubeconfigContext selects the context of a multi-context or multi-document kubeconfig
+	// (optional, defaults to its current context). Only that context is stored.
+	KubeconfigContext string `json:"kubeconfigContext,omitempty"`
+
 	// Token - for SecretType="token"
 	Token string `json:"token,omitempty"`
 
@@ -239,6 +492,34 @@ type CreateTargetRequest struct {
 
 	// Password - for SecretType="credentials"
 	Password string `json:"password,omitempty"`
+
+	// Metrics enables the Prometheus metrics proxy for this target (optional)
+	Metrics *TargetMetricsRequest `json:"metrics,omitempty"`
+
+	// TokenRotation re-mints the token from a ServiceAccount of the cluster (optional, for
+	// SecretType="token")
+	TokenRotation *TargetTokenRotationRequest `json:"tokenRotation,omitempty"`
+
+	// Labels are set on the target, e.g. env=staging and region=eu, for scenario runs to
+	// select it with a targetSelector (optional). On update they replace the labels of the
+	// target when set.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ValidateConnection requests the version of the API server with the credentials
+	// before the target is created, rejecting unusable ones with a 422 (optional, default true)
+	ValidateConnection *bool `json:"validateConnection,omitempty"`
+}
+
+// TargetMetricsRequest configures the Prometheus endpoint of a target cluster
+type TargetMetricsRequest struct {
+	// PrometheusURL is the base URL of the Prometheus (or Thanos Querier) HTTP API
+	PrometheusURL string `json:"prometheusURL"`
+
+	// BearerToken is sent to Prometheus in the Authorization header (optional)
+	BearerToken string `json:"bearerToken,omitempty"`
+
+	// InsecureSkipTLSVerify skips TLS certificate verification of the Prometheus endpoint
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 // CreateTargetResponse represents the response for POST /api/v1/targets
@@ -246,10 +527,75 @@ type CreateTargetResponse struct {
 	// UUID is the unique identifier for the created target
 	UUID string `json:"uuid"`
 
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
+
 	// Message contains additional information
 	Message string `json:"message,omitempty"`
 }
 
+// ImportTargetsRequest is the request body for POST /api/v1/operator/targets/import
+type ImportTargetsRequest struct {
+	// Kubeconfig is the base64-encoded, possibly multi-context or multi-document, kubeconfig (required)
+	Kubeconfig string `json:"kubeconfig"`
+
+	// Contexts restricts the import to these contexts (optional, defaults to all of them)
+	Contexts []string `json:"contexts,omitempty"`
+
+	// ClusterNames overrides the cluster name of a context, which defaults to the context name (optional)
+	ClusterNames map[string]string `json:"clusterNames,omitempty"`
+
+	// Labels are set on every imported target (optional)
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ValidateConnection checks the credentials of every context before any target is
+	// created (optional, default true)
+	ValidateConnection *bool `json:"validateConnection,omitempty"`
+}
+
+// ImportTargetsResponse is the response for POST /api/v1/operator/targets/import
+type ImportTargetsResponse struct {
+	// Targets are the created targets, one per imported context
+	Targets []ImportedTarget `json:"targets"`
+}
+
+// ImportedTarget is a target created from a kubeconfig context
+type ImportedTarget struct {
+	// Context is the kubeconfig context of the target
+	Context string `json:"context"`
+	// UUID is the unique identifier of the created target
+	UUID string `json:"uuid"`
+	// ClusterName is the cluster name of the target
+	ClusterName string `json:"clusterName"`
+	// ClusterAPIURL is the API server URL of the context
+	ClusterAPIURL string `json:"clusterAPIURL"`
+}
+
+// TargetTokenRotationRequest configures the rotation of the token of a token target. The
+// token of the target must be allowed to create tokens for the ServiceAccount.
+type TargetTokenRotationRequest struct {
+	// ServiceAccountNamespace is the namespace of the ServiceAccount on the target cluster
+	ServiceAccountNamespace string `json:"serviceAccountNamespace"`
+
+	// ServiceAccountName is the name of the ServiceAccount on the target cluster
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// ExpirationSeconds is the lifetime of the minted tokens (optional, at least 600, default 86400)
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+
+	// Automatic re-mints the token before it expires (optional, default false)
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// RotateTargetTokenResponse represents the response for POST /api/v1/operator/targets/{uuid}/rotate
+type RotateTargetTokenResponse struct {
+	// UUID is the unique identifier of the target
+	UUID string `json:"uuid"`
+
+	// TokenExpiresAt is when the new token expires
+	TokenExpiresAt time.Time `json:"tokenExpiresAt"`
+}
+
 // TargetResponse represents a single target in responses
 type TargetResponse struct {
 	// UUID is the unique identifier
@@ -269,12 +615,70 @@ type TargetResponse struct {
 
 	// CreatedAt is the creation timestamp
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Archived indicates the target has been soft-deleted
+	Archived bool `json:"archived,omitempty"`
+
+	// ArchivedAt is when the target was archived (only set for archived targets)
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+
+	// PurgeAfter is when the archived target will be permanently deleted (only set for archived targets)
+	PurgeAfter *time.Time `json:"purgeAfter,omitempty"`
+
+	// PrometheusURL is the Prometheus endpoint of the metrics proxy (only set when configured)
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+
+	// LastRunTime is when the last scenario job on the target finished
+	LastRunTime *time.Time `json:"lastRunTime,omitempty"`
+
+	// LastRunName is the scenario run of the last job on the target
+	LastRunName string `json:"lastRunName,omitempty"`
+
+	// LastRunPhase is the outcome of the last job on the target: Succeeded, Failed or Cancelled
+	LastRunPhase string `json:"lastRunPhase,omitempty"`
+
+	// RecentSuccessRate is the percentage of succeeded jobs among the last ones on the target
+	RecentSuccessRate *int32 `json:"recentSuccessRate,omitempty"`
+
+	// ConsecutiveFailures is the number of jobs that failed on the target since its last success
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastProbeTime is when the connectivity of the target cluster was last checked
+	LastProbeTime *time.Time `json:"lastProbeTime,omitempty"`
+
+	// ProbeLatencyMilliseconds is how long the last successful connectivity check took
+	ProbeLatencyMilliseconds *int64 `json:"probeLatencyMilliseconds,omitempty"`
+
+	// ProbeError is why the last connectivity check failed
+	ProbeError string `json:"probeError,omitempty"`
+	// AuthPlugins are the exec credential plugins and auth providers the kubeconfig of the
+	// target authenticates with, which scenario images must bundle
+	AuthPlugins []string `json:"authPlugins,omitempty"`
+
+	// TokenExpiresAt is when the last token minted for the target expires (only set for
+	// targets with token rotation)
+	TokenExpiresAt *time.Time `json:"tokenExpiresAt,omitempty"`
+
+	// Labels are the labels of the target
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // ListTargetsResponse represents the response for GET /api/v1/targets
 type ListTargetsResponse struct {
 	// Targets is the array of target objects
 	Targets []TargetResponse `json:"targets"`
+	// Pagination describes the returned page
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// PaginationInfo is the pagination metadata returned by list endpoints
+type PaginationInfo struct {
+	// Total is the number of items matching the filters across all pages
+	Total int `json:"total"`
+	// Limit is the page size requested (0 when not paginated)
+	Limit int `json:"limit,omitempty"`
+	// Continue is the token to pass as ?continue= to fetch the next page
+	Continue string `json:"continue,omitempty"`
 }
 
 // UpdateTargetRequest represents the request body for PUT /api/v1/targets/{uuid}
@@ -286,18 +690,31 @@ type UpdateTargetRequest struct {
 type ScenarioRunCreateResponse struct {
 	// ScenarioRunName is the name of the created KrknScenarioRun CR
 	ScenarioRunName string `json:"scenarioRunName"`
+	// RunNumber is the number of the run among the runs of its scenario
+	RunNumber int64 `json:"runNumber,omitempty"`
+	// DisplayName is the human-friendly name of the run
+	DisplayName string `json:"displayName,omitempty"`
 	// TargetClusters is a map of provider-name to list of cluster names
 	TargetClusters map[string][]string `json:"targetClusters"`
 	// TotalTargets is the total number of target clusters
 	TotalTargets int `json:"totalTargets"`
 	// OwnerUserID is the email address of the user who created this scenario run
 	OwnerUserID string `json:"ownerUserId,omitempty"`
+	// DefaultedNamespace is the NAMESPACE set by the operator because the request left it empty
+	DefaultedNamespace string `json:"defaultedNamespace,omitempty"`
+	// Warnings are the problems found with the run that did not prevent its creation,
+	// e.g. a scenario image not known to bundle the auth plugin of a target kubeconfig
+	Warnings []LintWarning `json:"warnings,omitempty"`
 }
 
 // ScenarioRunStatusResponse represents the response for GET /scenarios/run/{scenarioRunName} (new CRD-based approach)
 type ScenarioRunStatusResponse struct {
 	// ScenarioRunName is the name of the KrknScenarioRun CR
 	ScenarioRunName string `json:"scenarioRunName"`
+	// RunNumber is the number of the run among the runs of its scenario
+	RunNumber int64 `json:"runNumber,omitempty"`
+	// DisplayName is the human-friendly name of the run
+	DisplayName string `json:"displayName,omitempty"`
 	// Phase is the overall phase of the scenario run
 	Phase string `json:"phase"`
 	// TotalTargets is the total number of target clusters
@@ -312,6 +729,57 @@ type ScenarioRunStatusResponse struct {
 	ClusterJobs []ClusterJobStatusResponse `json:"clusterJobs"`
 	// OwnerUserID is the email address of the user who created this scenario run
 	OwnerUserID string `json:"ownerUserId,omitempty"`
+	// RetryWaves are the retries of failed clusters requested for this run
+	RetryWaves []RetryWaveResponse `json:"retryWaves,omitempty"`
+	// ResolvedTargets are the targets the run was started with
+	ResolvedTargets *krknv1alpha1.TargetSnapshot `json:"resolvedTargets,omitempty"`
+	// CompletionTime is when the run finished
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// ExpiresAt is when the finished run is deleted, with ttlSecondsAfterFinished
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Conditions are the Ready, Progressing, Degraded and Completed conditions of the run
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// LintWarning is a best-practice warning about a scenario run request
+type LintWarning struct {
+	// Code identifies the warning, stable across languages
+	Code string `json:"code"`
+	// Field is the request field the warning is about
+	Field string `json:"field"`
+	// Message is the localized warning
+	Message string `json:"message"`
+}
+
+// ScenarioRunLintResponse represents the response for POST /scenarios/run/lint
+type ScenarioRunLintResponse struct {
+	// Warnings are empty when the request follows every checked practice
+	Warnings []LintWarning `json:"warnings"`
+}
+
+// RetryFailedRequest is the optional body of POST /scenarios/run/{name}/retry-failed.
+// Without delay or scheduledAt the failed clusters are retried right away.
+type RetryFailedRequest struct {
+	// Delay postpones the retry by a Go duration, e.g. "15m"
+	Delay string `json:"delay,omitempty"`
+	// ScheduledAt is when the retry starts, cannot be combined with Delay
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+}
+
+// RetryWaveResponse represents a retry of the failed clusters of a scenario run
+type RetryWaveResponse struct {
+	// Number is the 1-based sequence number of the wave within the run
+	Number int `json:"number"`
+	// RequestedBy is the user who requested the retry
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// RequestedAt is when the retry was requested
+	RequestedAt time.Time `json:"requestedAt"`
+	// ScheduledAt is when the wave is due to start
+	ScheduledAt time.Time `json:"scheduledAt"`
+	// Clusters are the clusters retried by the wave
+	Clusters []string `json:"clusters"`
+	// StartTime is when the controller started the wave, unset while pending
+	StartTime *time.Time `json:"startTime,omitempty"`
 }
 
 // ClusterJobStatusResponse represents the status of a job for a specific cluster
@@ -320,6 +788,8 @@ type ClusterJobStatusResponse struct {
 	ProviderName string `json:"providerName"`
 	// ClusterName is the name of the target cluster
 	ClusterName string `json:"clusterName"`
+	// ClusterID is the stable identifier of the target cluster
+	ClusterID string `json:"clusterId,omitempty"`
 	// JobID is the unique identifier for this job
 	JobID string `json:"jobId"`
 	// PodName is the name of the pod running the scenario
@@ -340,6 +810,190 @@ type ClusterJobStatusResponse struct {
 	CancelRequested bool `json:"cancelRequested,omitempty"`
 	// FailureReason contains the categorized failure reason
 	FailureReason string `json:"failureReason,omitempty"`
+	// Evictions is the number of times the job was restarted after its pod was evicted
+	Evictions int `json:"evictions,omitempty"`
+	// MaintenanceReason is the cluster maintenance holding or skipping the job
+	MaintenanceReason string `json:"maintenanceReason,omitempty"`
+	// Queued indicates the job waits for a free slot of the run or operator concurrency limit
+	Queued bool `json:"queued,omitempty"`
+	// SubstitutedFrom is the target cluster the job replaced because it was unreachable
+	SubstitutedFrom string `json:"substitutedFrom,omitempty"`
+	// Result is the outcome krkn reported once the job finished
+	Result *krknv1alpha1.ScenarioResult `json:"result,omitempty"`
+	// Artifacts are the files of the job uploaded to the run's artifact storage
+	Artifacts []krknv1alpha1.UploadedArtifact `json:"artifacts,omitempty"`
+	// ArtifactsUploadError is why the artifacts of the job could not be uploaded
+	ArtifactsUploadError string `json:"artifactsUploadError,omitempty"`
+	// Cleanup is the state the job left the cluster in, when cleanup verification applies
+	Cleanup *krknv1alpha1.CleanupResult `json:"cleanup,omitempty"`
+}
+
+// ScenarioRunResultsResponse is the outcome krkn reported for each cluster job of a
+// scenario run visible to the caller
+type ScenarioRunResultsResponse struct {
+	// ScenarioRunName is the name of the scenario run
+	ScenarioRunName string `json:"scenarioRunName"`
+	// Phase is the overall phase of the scenario run
+	Phase string `json:"phase"`
+	// Results lists the result of each cluster job
+	Results []ClusterJobResultResponse `json:"results"`
+}
+
+// ClusterJobResultResponse is the result of the current attempt of a cluster job
+type ClusterJobResultResponse struct {
+	// ProviderName is the name of the provider that owns this cluster
+	ProviderName string `json:"providerName"`
+	// ClusterName is the name of the target cluster
+	ClusterName string `json:"clusterName"`
+	// ClusterID is the stable identifier of the target cluster
+	ClusterID string `json:"clusterId,omitempty"`
+	// JobID is the job the result was reported for
+	JobID string `json:"jobId"`
+	// Phase is the current phase of the job
+	Phase string `json:"phase"`
+	// FailureReason contains the categorized failure reason
+	FailureReason string `json:"failureReason,omitempty"`
+	// Result is the krkn result, unset until the job finished
+	Result *krknv1alpha1.ScenarioResult `json:"result,omitempty"`
+	// Artifacts are the files of the job uploaded to the run's artifact storage
+	Artifacts []krknv1alpha1.UploadedArtifact `json:"artifacts,omitempty"`
+	// Cleanup is the state the job left the cluster in, when cleanup verification applies
+	Cleanup *krknv1alpha1.CleanupResult `json:"cleanup,omitempty"`
+}
+
+// ScenarioRunReport is the report of a scenario run returned by
+// GET /api/v1/scenarios/run/{scenarioRunName}/report, e.g. for incident reviews
+type ScenarioRunReport struct {
+	// ScenarioRunName is the name of the scenario run
+	ScenarioRunName string `json:"scenarioRunName"`
+	// Title is the display name of the run, or its scenario and number
+	Title string `json:"title"`
+	// ScenarioName is the name of the scenario
+	ScenarioName string `json:"scenarioName"`
+	// ScenarioImage is the container image of the scenario
+	ScenarioImage string `json:"scenarioImage"`
+	// TemplateRef is the KrknScenarioTemplate the run was rendered from
+	TemplateRef string `json:"templateRef,omitempty"`
+	// CampaignRef is the campaign the run belongs to
+	CampaignRef string `json:"campaignRef,omitempty"`
+	// OwnerUserID is the email address of the user who created the run
+	OwnerUserID string `json:"ownerUserId,omitempty"`
+	// Phase is the overall phase of the scenario run
+	Phase string `json:"phase"`
+	// CreatedAt is when the run was created
+	CreatedAt time.Time `json:"createdAt"`
+	// CompletedAt is when the run finished (nil while in progress)
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// DurationSeconds is the time from the creation of the run to its completion
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+	// Parameters are the parameters the scenario ran with
+	Parameters ScenarioRunReportParameters `json:"parameters"`
+	// TotalTargets is the number of clusters in the report
+	TotalTargets int `json:"totalTargets"`
+	// SuccessfulJobs is the number of succeeded cluster jobs in the report
+	SuccessfulJobs int `json:"successfulJobs"`
+	// FailedJobs is the number of failed cluster jobs in the report
+	FailedJobs int `json:"failedJobs"`
+	// Clusters are the cluster jobs the user can view
+	Clusters []ScenarioRunReportCluster `json:"clusters"`
+	// GeneratedAt is when the report was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// ScenarioRunReportParameters are the scenario parameters of a run report
+type ScenarioRunReportParameters struct {
+	// Environment is the environment of the scenario, values of secret and
+	// credential-like variables redacted
+	Environment map[string]string `json:"environment,omitempty"`
+	// Files are the mount paths of the files mounted in the scenario pod
+	Files []string `json:"files,omitempty"`
+	// TimeoutSeconds is how long a scenario pod may run
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// ScenarioRunReportCluster is the outcome of the job of a cluster in a run report
+type ScenarioRunReportCluster struct {
+	// ProviderName is the name of the provider that owns this cluster
+	ProviderName string `json:"providerName"`
+	// ClusterName is the name of the target cluster
+	ClusterName string `json:"clusterName"`
+	// JobID is the current job of the cluster
+	JobID string `json:"jobId"`
+	// Phase is the current phase of the job
+	Phase string `json:"phase"`
+	// FailureReason contains the categorized failure reason
+	FailureReason string `json:"failureReason,omitempty"`
+	// Message is the last status message of the job
+	Message string `json:"message,omitempty"`
+	// RetryCount is the number of times the job was retried
+	RetryCount int `json:"retryCount,omitempty"`
+	// StartTime is when the last attempt of the job started
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the last attempt of the job finished
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// DurationSeconds is the time the last attempt of the job ran
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+	// Result is the krkn result, unset until the job finished
+	Result *krknv1alpha1.ScenarioResult `json:"result,omitempty"`
+	// LogsURL is the API path of the logs of the job
+	LogsURL string `json:"logsUrl"`
+	// Artifacts are the files of the job uploaded to the run's artifact storage
+	Artifacts []krknv1alpha1.UploadedArtifact `json:"artifacts,omitempty"`
+}
+
+// EffectiveSpecResponse is what the current job of a cluster of a scenario run was
+// created with: environment (credentials redacted), image, files and pod spec summary
+type EffectiveSpecResponse struct {
+	// ScenarioRunName is the name of the scenario run
+	ScenarioRunName string `json:"scenarioRunName"`
+	// ClusterName is the name of the target cluster
+	ClusterName string `json:"clusterName"`
+	// JobID is the job the spec was captured for
+	JobID string `json:"jobId"`
+	// Phase is the current phase of the job
+	Phase string `json:"phase"`
+	// RetryCount is the attempt of the job, the spec is replaced on every retry
+	RetryCount int `json:"retryCount,omitempty"`
+	// EffectiveSpec is the captured spec
+	EffectiveSpec krknv1alpha1.EffectiveSpec `json:"effectiveSpec"`
+}
+
+// ScenarioRunCancelResponse is returned when the cancellation of a scenario run is accepted
+type ScenarioRunCancelResponse struct {
+	// ScenarioRunName is the name of the scenario run
+	ScenarioRunName string `json:"scenarioRunName"`
+	// CancelledClusters are the clusters whose jobs the controller stops, or will not start
+	CancelledClusters []string `json:"cancelledClusters"`
+}
+
+// JobStatusEvent is the payload of a job status Server-Sent Event
+type JobStatusEvent struct {
+	// JobID is the unique identifier of the job
+	JobID string `json:"jobId"`
+	// PodName is the name of the pod running the scenario
+	PodName string `json:"podName,omitempty"`
+	// Phase is the job phase after the transition
+	Phase string `json:"phase"`
+	// PreviousPhase is the job phase before the transition, empty for the initial event
+	PreviousPhase string `json:"previousPhase,omitempty"`
+	// Message contains additional information about the transition
+	Message string `json:"message,omitempty"`
+	// Timestamp is when the transition was observed
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PrometheusQueryResponse is the Prometheus HTTP API response relayed by the metrics proxy
+type PrometheusQueryResponse struct {
+	// Status is "success" or "error"
+	Status string `json:"status"`
+	// Data is the query result, see the Prometheus HTTP API documentation
+	Data interface{} `json:"data,omitempty"`
+	// ErrorType is set when Status is "error"
+	ErrorType string `json:"errorType,omitempty"`
+	// Error is set when Status is "error"
+	Error string `json:"error,omitempty"`
+	// Warnings returned by Prometheus
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ScenarioRunListItem represents a single scenario run in the list view
@@ -348,6 +1002,10 @@ type ScenarioRunListItem struct {
 	ScenarioRunName string `json:"scenarioRunName"`
 	// ScenarioName is the name of the scenario being executed
 	ScenarioName string `json:"scenarioName"`
+	// RunNumber is the number of the run among the runs of its scenario
+	RunNumber int64 `json:"runNumber,omitempty"`
+	// DisplayName is the human-friendly name of the run
+	DisplayName string `json:"displayName,omitempty"`
 	// Phase is the overall phase of the scenario run
 	Phase string `json:"phase"`
 	// TotalTargets is the total number of target clusters
@@ -360,14 +1018,38 @@ type ScenarioRunListItem struct {
 	RunningJobs int `json:"runningJobs"`
 	// CreatedAt is the creation timestamp
 	CreatedAt time.Time `json:"createdAt"`
+	// StartedAt is when the first cluster job started (nil if none started yet)
+	StartedAt *time.Time `json:"startedAt,omitempty"`
 	// OwnerUserID is the email address of the user who created this scenario run
 	OwnerUserID string `json:"ownerUserId,omitempty"`
+	// Campaign is the KrknCampaign the run is part of, if any
+	Campaign string `json:"campaign,omitempty"`
+}
+
+// SubscribeScenarioRunRequest represents the request body for POST /scenarios/run/{name}/subscribe
+type SubscribeScenarioRunRequest struct {
+	// Channel is where phase-change notifications are delivered
+	Channel notify.Channel `json:"channel"`
+}
+
+// ScenarioRunObserversResponse represents the response for subscribe/unsubscribe
+type ScenarioRunObserversResponse struct {
+	// ScenarioRunName is the name of the observed scenario run
+	ScenarioRunName string `json:"scenarioRunName"`
+	// UserID is the observer the operation applied to
+	UserID string `json:"userId"`
+	// Subscribed reports whether the user is now observing the run
+	Subscribed bool `json:"subscribed"`
+	// Observers is the number of users observing the run
+	Observers int `json:"observers"`
 }
 
 // ScenarioRunListResponse represents the response for GET /scenarios/run
 type ScenarioRunListResponse struct {
 	// ScenarioRuns is the list of scenario runs
 	ScenarioRuns []ScenarioRunListItem `json:"scenarioRuns"`
+	// Pagination describes the returned page
+	Pagination PaginationInfo `json:"pagination"`
 }
 
 // ActiveRunsOverviewResponse represents the response for GET /api/v1/dashboard/active-runs
@@ -391,6 +1073,8 @@ type ProviderConfigUpdateRequest struct {
 
 // ProviderConfigUpdateResponse is the response for successful config updates
 type ProviderConfigUpdateResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// UpdatedFields is the list of fields that were updated
@@ -405,6 +1089,16 @@ type ProviderResponse struct {
 	Active bool `json:"active"`
 	// LastHeartbeat is the timestamp of the last heartbeat
 	LastHeartbeat *metav1.Time `json:"lastHeartbeat,omitempty"`
+	// HeartbeatAgeSeconds is the time elapsed since the last heartbeat
+	HeartbeatAgeSeconds *int64 `json:"heartbeatAgeSeconds,omitempty"`
+	// Healthy indicates if the last heartbeat is recent enough for the provider to be running
+	Healthy bool `json:"healthy"`
+	// ActivationReason is the reason given by the admin who last changed the activation
+	ActivationReason string `json:"activationReason,omitempty"`
+	// ActivationChangedBy is the admin who last changed the activation, empty when the provider did
+	ActivationChangedBy string `json:"activationChangedBy,omitempty"`
+	// ActivationChangedAt is when the operator observed the last activation change
+	ActivationChangedAt *metav1.Time `json:"activationChangedAt,omitempty"`
 }
 
 // ListProvidersResponse is the response for GET /api/v1/providers
@@ -417,16 +1111,58 @@ type ListProvidersResponse struct {
 type UpdateProviderStatusRequest struct {
 	// Active sets the provider active status
 	Active bool `json:"active"`
+	// Reason explains the change, recorded on the provider and in its activation event
+	Reason string `json:"reason,omitempty"`
 }
 
 // UpdateProviderStatusResponse is the response for successful provider status updates
 type UpdateProviderStatusResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// Name is the provider name
 	Name string `json:"name"`
 	// Active is the new active status
 	Active bool `json:"active"`
+	// Reason is the reason recorded for the change
+	Reason string `json:"reason,omitempty"`
+}
+
+// ProviderVerifyRequest is the request body for POST /api/v1/providers/verify
+type ProviderVerifyRequest struct {
+	// Providers restricts the verification to the listed active providers (all active providers when empty)
+	Providers []string `json:"providers,omitempty"`
+	// TimeoutSeconds bounds the wait for the providers to respond (defaults to 30)
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// ProviderVerifyResponse is the report of a provider verification
+type ProviderVerifyResponse struct {
+	// RequestID is the name of the sandbox KrknTargetRequest, deleted after the verification
+	RequestID string `json:"requestId"`
+	// Passed is true when every verified provider responded with a conformant payload
+	Passed bool `json:"passed"`
+	// DurationMs is the time spent waiting for the providers
+	DurationMs int64 `json:"durationMs"`
+	// Providers is the result of each verified provider
+	Providers []ProviderVerifyResult `json:"providers"`
+}
+
+// ProviderVerifyResult is the verification result of one provider
+type ProviderVerifyResult struct {
+	// Name is the provider operator name
+	Name string `json:"name"`
+	// Responded is true when the provider contributed its targets before the timeout
+	Responded bool `json:"responded"`
+	// LatencyMs is the time the provider took to contribute, at the polling resolution
+	LatencyMs *int64 `json:"latencyMs,omitempty"`
+	// Clusters is the number of clusters contributed
+	Clusters int `json:"clusters"`
+	// Conformant is true when the contributed targets and managed-clusters payload follow the contract
+	Conformant bool `json:"conformant"`
+	// Issues lists the contract violations found
+	Issues []string `json:"issues,omitempty"`
 }
 
 // Authentication types
@@ -455,6 +1191,8 @@ type RegisterRequest struct {
 
 // RegisterResponse represents the response for POST /auth/register
 type RegisterResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// UserID is the registered user's email
@@ -539,6 +1277,8 @@ type CreateUserRequest struct {
 
 // CreateUserResponse represents the response for POST /api/v1/users
 type CreateUserResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// UserID is the created user's email
@@ -563,6 +1303,8 @@ type UpdateUserRequest struct {
 
 // UpdateUserResponse represents the response for PATCH /api/v1/users/:userId
 type UpdateUserResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// User is the updated user object
@@ -571,6 +1313,8 @@ type UpdateUserResponse struct {
 
 // DeleteUserResponse represents the response for DELETE /api/v1/users/:userId
 type DeleteUserResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 }
@@ -585,6 +1329,8 @@ type ChangePasswordRequest struct {
 
 // ChangePasswordResponse represents the response for PATCH /api/v1/users/:userId/password
 type ChangePasswordResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 }
@@ -631,6 +1377,8 @@ type CreateUserGroupRequest struct {
 
 // CreateUserGroupResponse represents the response for POST /api/v1/groups
 type CreateUserGroupResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// Name is the created group's name
@@ -647,6 +1395,8 @@ type UpdateUserGroupRequest struct {
 
 // UpdateUserGroupResponse represents the response for PATCH /api/v1/groups/:groupName
 type UpdateUserGroupResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// Group is the updated group object
@@ -655,6 +1405,8 @@ type UpdateUserGroupResponse struct {
 
 // DeleteUserGroupResponse represents the response for DELETE /api/v1/groups/:groupName
 type DeleteUserGroupResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 }
@@ -667,6 +1419,8 @@ type AddGroupMemberRequest struct {
 
 // AddGroupMemberResponse represents the response for POST /api/v1/groups/:groupName/members
 type AddGroupMemberResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 	// UserID is the added user's email
@@ -677,6 +1431,8 @@ type AddGroupMemberResponse struct {
 
 // RemoveGroupMemberResponse represents the response for DELETE /api/v1/groups/:groupName/members/:userId
 type RemoveGroupMemberResponse struct {
+	// Code is a stable, machine-readable message code (see messages.go)
+	Code string `json:"code,omitempty"`
 	// Message contains a success message
 	Message string `json:"message"`
 }
@@ -690,3 +1446,198 @@ type ListGroupMembersResponse struct {
 	// GroupName is the group name
 	GroupName string `json:"groupName"`
 }
+
+// AuditLogResponse represents the response for GET /audit endpoint
+type AuditLogResponse struct {
+	// Entries contains the matching audit records, newest first
+	Entries []audit.Record `json:"entries"`
+	// Count is the number of entries returned
+	Count int `json:"count"`
+}
+
+// RunHistoryResponse represents the response for GET /history endpoint
+type RunHistoryResponse struct {
+	// Runs contains the matching recorded runs, newest first
+	Runs []history.Run `json:"runs"`
+	// Count is the number of runs returned
+	Count int `json:"count"`
+}
+
+// DiagnosticsResponse represents the response for GET /diagnostics endpoint
+type DiagnosticsResponse struct {
+	// Status is "ok", "degraded" or "pending" (checks not completed yet)
+	Status string `json:"status"`
+	// CRDSchema is the result of comparing installed CRDs with the operator API types
+	CRDSchema *crdcheck.Report `json:"crdSchema,omitempty"`
+	// Permissions is the result of checking the operator RBAC in its namespace
+	Permissions *permcheck.Report `json:"permissions,omitempty"`
+	// Registries is the availability of the scenario registries called so far
+	Registries []RegistryStatus `json:"registries,omitempty"`
+}
+
+// StateMachineResponse represents the response for GET /docs/state-machine endpoint
+type StateMachineResponse struct {
+	// Machines lists the phase state machines enforced by the operator
+	Machines []StateMachineDescription `json:"machines"`
+}
+
+// StateMachineDescription describes one phase state machine
+type StateMachineDescription struct {
+	// Name identifies the machine, e.g. "job" for ClusterJobStatus.Phase
+	Name string `json:"name"`
+	// Initial is the phase new objects start in
+	Initial string `json:"initial"`
+	// States lists every phase
+	States []string `json:"states"`
+	// Terminal lists the phases no transition leaves
+	Terminal []string `json:"terminal"`
+	// Transitions lists the allowed phase changes; staying in the same phase is always allowed
+	Transitions []statemachine.Transition `json:"transitions"`
+	// Mermaid is the machine rendered as a Mermaid stateDiagram-v2
+	Mermaid string `json:"mermaid"`
+}
+
+// CampaignWindow is the time window a campaign is planned in
+type CampaignWindow struct {
+	// Start is when the campaign begins
+	Start time.Time `json:"start"`
+	// End is when the campaign ends, after Start
+	End time.Time `json:"end"`
+}
+
+// CreateCampaignRequest represents the request body for POST /api/v1/campaigns
+type CreateCampaignRequest struct {
+	// Name is the name of the KrknCampaign, a DNS-1123 subdomain
+	Name string `json:"name"`
+	// DisplayName is the human-friendly name of the campaign (optional)
+	DisplayName string `json:"displayName,omitempty"`
+	// Description describes the campaign (optional)
+	Description string `json:"description,omitempty"`
+	// Window is when the campaign is planned (optional)
+	Window *CampaignWindow `json:"window,omitempty"`
+	// Participants are the user IDs of the users taking part in the campaign (optional)
+	Participants []string `json:"participants,omitempty"`
+}
+
+// UpdateCampaignRequest represents the request body for PATCH /api/v1/campaigns/{campaignName}.
+// Fields left out are unchanged.
+type UpdateCampaignRequest struct {
+	// DisplayName replaces the display name
+	DisplayName *string `json:"displayName,omitempty"`
+	// Description replaces the description
+	Description *string `json:"description,omitempty"`
+	// Window replaces the window
+	Window *CampaignWindow `json:"window,omitempty"`
+	// Participants replaces the participants
+	Participants *[]string `json:"participants,omitempty"`
+}
+
+// CampaignProgress aggregates the runs of a campaign the user can view
+type CampaignProgress struct {
+	// TotalRuns is the number of runs of the campaign
+	TotalRuns int `json:"totalRuns"`
+	// PendingRuns is the number of runs not started yet
+	PendingRuns int `json:"pendingRuns"`
+	// RunningRuns is the number of runs in progress
+	RunningRuns int `json:"runningRuns"`
+	// SucceededRuns is the number of runs that succeeded on every cluster
+	SucceededRuns int `json:"succeededRuns"`
+	// PartiallyFailedRuns is the number of runs that failed on some clusters
+	PartiallyFailedRuns int `json:"partiallyFailedRuns"`
+	// FailedRuns is the number of runs that failed on every cluster
+	FailedRuns int `json:"failedRuns"`
+	// PercentComplete is the share of finished runs, 0 to 100
+	PercentComplete int `json:"percentComplete"`
+	// TotalTargets is the number of target clusters of all the runs
+	TotalTargets int `json:"totalTargets"`
+	// SuccessfulJobs is the number of successful jobs of all the runs
+	SuccessfulJobs int `json:"successfulJobs"`
+	// FailedJobs is the number of failed jobs of all the runs
+	FailedJobs int `json:"failedJobs"`
+	// RunningJobs is the number of running jobs of all the runs
+	RunningJobs int `json:"runningJobs"`
+}
+
+// CampaignResponse represents a campaign and its progress
+type CampaignResponse struct {
+	// Name is the name of the KrknCampaign
+	Name string `json:"name"`
+	// DisplayName is the human-friendly name of the campaign
+	DisplayName string `json:"displayName,omitempty"`
+	// Description describes the campaign
+	Description string `json:"description,omitempty"`
+	// Window is when the campaign is planned
+	Window *CampaignWindow `json:"window,omitempty"`
+	// Facilitator is the user ID of the user who created the campaign
+	Facilitator string `json:"facilitator,omitempty"`
+	// Participants are the user IDs of the users taking part in the campaign
+	Participants []string `json:"participants"`
+	// CreatedAt is the creation timestamp
+	CreatedAt time.Time `json:"createdAt"`
+	// Progress aggregates the runs of the campaign
+	Progress CampaignProgress `json:"progress"`
+}
+
+// ListCampaignsResponse represents the response for GET /api/v1/campaigns
+type ListCampaignsResponse struct {
+	// Campaigns are sorted by name
+	Campaigns []CampaignResponse `json:"campaigns"`
+	// Total is the number of campaigns
+	Total int `json:"total"`
+}
+
+// CampaignReportRun is a run of a campaign report
+type CampaignReportRun struct {
+	ScenarioRunListItem
+	// CompletedAt is when the run finished (nil while in progress)
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// FailedClusters lists the clusters whose job failed
+	FailedClusters []string `json:"failedClusters,omitempty"`
+	// OutsideWindow is true when the run was created outside of the campaign window
+	OutsideWindow bool `json:"outsideWindow,omitempty"`
+}
+
+// CampaignReportResponse represents the response for GET /api/v1/campaigns/{campaignName}/report
+type CampaignReportResponse struct {
+	CampaignResponse
+	// StartedAt is when the first job of the campaign started (nil if none started yet)
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// CompletedAt is when the last run finished, nil while a run is not finished
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// RunsByOwner is the number of runs each user created
+	RunsByOwner map[string]int `json:"runsByOwner"`
+	// Runs are the runs of the campaign, oldest first
+	Runs []CampaignReportRun `json:"runs"`
+	// GeneratedAt is when the report was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// BreakGlassRequest represents the request body for POST /api/v1/break-glass
+type BreakGlassRequest struct {
+	// ScenarioName is the restricted scenario the exemption lets run
+	ScenarioName string `json:"scenarioName"`
+	// TargetClusters are the names of the clusters the scenario may run on
+	TargetClusters []string `json:"targetClusters"`
+	// UserID is the user allowed to use the exemption; anyone holding it when empty
+	UserID string `json:"userId,omitempty"`
+	// Reason is why the exemption is issued, recorded in the audit log
+	Reason string `json:"reason"`
+	// DurationSeconds is how long the exemption is valid (default 3600, at most 86400)
+	DurationSeconds int `json:"durationSeconds,omitempty"`
+}
+
+// BreakGlassResponse represents the response for POST /api/v1/break-glass
+type BreakGlassResponse struct {
+	// ID identifies the exemption in the audit log
+	ID string `json:"id"`
+	// Token is sent in the X-Krkn-Break-Glass header of the scenario run request
+	Token string `json:"token"`
+	// ScenarioName is the restricted scenario the exemption lets run
+	ScenarioName string `json:"scenarioName"`
+	// TargetClusters are the clusters the scenario may run on
+	TargetClusters []string `json:"targetClusters"`
+	// UserID is the user allowed to use the exemption, empty for anyone holding it
+	UserID string `json:"userId,omitempty"`
+	// ExpiresAt is when the exemption stops being valid
+	ExpiresAt time.Time `json:"expiresAt"`
+}
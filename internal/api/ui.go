@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/krkn-chaos/krkn-operator/internal/ui"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+// EmbeddedUIEnv serves the embedded dashboard at UIPath when "true"
+const EmbeddedUIEnv = "API_EMBEDDED_UI"
+
+// UISessionCookie carries the JWT of the UI session. Page loads cannot send the
+// Authorization header, so the UI routes read the token from this cookie instead.
+const UISessionCookie = "krkn_ui_session"
+
+// embeddedUIFromEnv returns the embedded UI handler, nil unless EmbeddedUIEnv enables it
+func embeddedUIFromEnv() http.Handler {
+	if enabled, _ := strconv.ParseBool(os.Getenv(EmbeddedUIEnv)); !enabled {
+		return nil
+	}
+	return ui.Handler(UIPath)
+}
+
+// uiAuth puts the embedded UI behind authenticate, the middleware of the API. Page loads
+// present the JWT of the UI session cookie as bearer token; browsers without a session,
+// or whose token authenticate rejects, are sent to the login page.
+func uiAuth(authenticate func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		protected := authenticate(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(auth.AuthorizationHeader) == "" {
+				cookie, err := r.Cookie(UISessionCookie)
+				if err != nil || cookie.Value == "" {
+					http.Redirect(w, r, UIPath+ui.LoginPage, http.StatusSeeOther)
+					return
+				}
+				r = r.Clone(r.Context())
+				r.Header.Set(auth.AuthorizationHeader, auth.BearerPrefix+cookie.Value)
+			}
+
+			rejected := &uiAuthWriter{ResponseWriter: w}
+			protected.ServeHTTP(rejected, r)
+			if rejected.unauthorized {
+				w.Header().Del("Content-Type")
+				clearUISession(w, r)
+				http.Redirect(w, r, UIPath+ui.LoginPage, http.StatusSeeOther)
+			}
+		})
+	}
+}
+
+// uiAuthWriter holds back a 401 response, which uiAuth turns into a redirect
+type uiAuthWriter struct {
+	http.ResponseWriter
+	unauthorized bool
+}
+
+func (w *uiAuthWriter) WriteHeader(status int) {
+	if status == http.StatusUnauthorized {
+		w.unauthorized = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *uiAuthWriter) Write(b []byte) (int, error) {
+	if w.unauthorized {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// CreateUISession handles POST /ui/session: the embedded UI calls it with the JWT
+// returned by /auth/login to store it in the HttpOnly UI session cookie, valid for the
+// lifetime of the token and only sent to the UI routes
+func (h *Handler) CreateUISession(w http.ResponseWriter, r *http.Request) {
+	claims := auth.GetClaimsFromContext(r.Context())
+	if claims == nil || claims.ExpiresAt == nil {
+		writeLocalizedError(w, r, http.StatusUnauthorized, "unauthorized", MsgUISessionInvalid, nil)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get(auth.AuthorizationHeader), auth.BearerPrefix)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     UISessionCookie,
+		Value:    token,
+		Path:     UIPath,
+		Expires:  claims.ExpiresAt.Time,
+		MaxAge:   int(time.Until(claims.ExpiresAt.Time).Seconds()),
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteUISession handles DELETE /ui/session, clearing the UI session cookie on sign out
+func (h *Handler) DeleteUISession(w http.ResponseWriter, r *http.Request) {
+	clearUISession(w, r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func clearUISession(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     UISessionCookie,
+		Path:     UIPath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// isHTTPS reports whether the client reached the API over HTTPS, directly or through
+// a TLS-terminating proxy
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// GetUsage handles GET /api/v1/usage endpoint (admin only)
+// Returns the anonymous usage aggregate of the operator: API calls per endpoint since
+// start, scenario runs per phase and scenario type, target counts and the optional
+// features enabled. 404 when usage telemetry is off.
+func (h *Handler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	if !auth.IsAdmin(r.Context()) {
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
+		return
+	}
+	if h.usage == nil {
+		writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUsageDisabled, nil)
+		return
+	}
+
+	report, err := h.usage.Report(r.Context())
+	if err != nil {
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUsageReportFailed,
+			i18n.Params{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// usageMiddleware counts every call of a route in the usage tracker, by method and
+// route pattern so no path parameter is recorded. Unmatched paths are not counted.
+func (h *Handler) usageMiddleware(router *chi.Mux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h.usage != nil {
+				if route := routePattern(router, r); route != unmatchedRoute {
+					h.usage.RecordEndpoint(r.Method, route)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/usage"
+)
+
+func TestGetUsage(t *testing.T) {
+	handler := setupTestHandler()
+
+	// Telemetry off
+	w := httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, UsagePath, nil)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d with telemetry off, got %d", http.StatusNotFound, w.Code)
+	}
+
+	handler.usage = usage.NewTracker(handler.client, handler.namespace, map[string]bool{"webhooks": true})
+
+	// Admins only
+	req := httptest.NewRequest(http.MethodGet, UsagePath, nil)
+	req = req.WithContext(context.WithValue(req.Context(), auth.UserClaimsKey, &auth.Claims{
+		UserID: "user@example.com",
+		Role:   "user",
+	}))
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a user, got %d", http.StatusForbidden, w.Code)
+	}
+
+	for range 2 {
+		serveAPI(handler, httptest.NewRecorder(),
+			withAdminClaims(httptest.NewRequest(http.MethodGet, OperatorTargetsPath+"/some-uuid", nil)))
+	}
+	serveAPI(handler, httptest.NewRecorder(), withAdminClaims(httptest.NewRequest(http.MethodGet, "/unknown", nil)))
+
+	w = httptest.NewRecorder()
+	serveAPI(handler, w, withAdminClaims(httptest.NewRequest(http.MethodGet, UsagePath, nil)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var report usage.Report
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	// Calls are counted per route pattern, without path parameters or unmatched paths
+	if report.Endpoints["GET "+OperatorTargetsPath+"/{uuid}"] != 2 {
+		t.Errorf("Expected 2 calls of the target route, got %v", report.Endpoints)
+	}
+	for endpoint := range report.Endpoints {
+		if endpoint == "GET /unknown" || endpoint == "GET "+OperatorTargetsPath+"/some-uuid" {
+			t.Errorf("Unexpected endpoint %q recorded", endpoint)
+		}
+	}
+	if !report.Features["webhooks"] {
+		t.Errorf("Expected the enabled features, got %v", report.Features)
+	}
+}
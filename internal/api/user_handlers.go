@@ -20,7 +20,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -35,6 +34,7 @@ import (
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
 )
 
 // fetchUserByEmail retrieves a KrknUser by email address (UserID).
@@ -169,10 +169,7 @@ func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
@@ -190,10 +187,8 @@ func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.List(ctx, &users, listOpts...); err != nil {
 		logger.Error(err, "Failed to list users")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to list users: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserListFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -227,12 +222,9 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	logger := log.FromContext(ctx).WithName("get-user")
 
 	// Extract userID from path
-	userID, err := extractPathSuffix(r.URL.Path, UsersPath+"/")
+	userID, err := pathParam(r, ParamUserID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid userID in path",
-		})
+		writeInvalidIDError(w, r, ParamUserID, err)
 		return
 	}
 
@@ -243,16 +235,11 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	user, err := h.fetchUserByEmail(ctx, userID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound, i18n.Params{"userId": userID})
 		} else {
 			logger.Error(err, "Failed to fetch user", "userID", userID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -260,10 +247,7 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	// Check permissions (admin or self)
 	claims := auth.GetClaimsFromContext(r.Context())
 	if !auth.IsAdmin(r.Context()) && (claims == nil || claims.UserID != userID) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "You can only view your own profile",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgProfileForbidden, nil)
 		return
 	}
 
@@ -282,47 +266,36 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Parse and validate request
 	var req CreateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "invalid_request", MsgMalformedRequestBody, nil)
 		return
 	}
 
 	// Validate required fields
 	if req.UserID == "" || req.Password == "" || req.Name == "" || req.Surname == "" || req.Role == "" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "UserID, password, name, surname, and role are required",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgUserFieldsRequired, nil)
 		return
 	}
 
 	// Validate role
 	if req.Role != "user" && req.Role != "admin" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Role must be either 'user' or 'admin'",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgUserRoleInvalid, nil)
 		return
 	}
 
 	// Validate password
 	if err := auth.ValidatePassword(req.Password); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: fmt.Sprintf("Password validation failed: %s", err.Error()),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgPasswordInvalid,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -331,19 +304,14 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	err := h.client.List(ctx, existingUsers, client.InNamespace(h.namespace))
 	if err != nil {
 		logger.Error(err, "Failed to check existing users")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to check existing users",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserCheckFailed, nil)
 		return
 	}
 
 	for _, user := range existingUsers.Items {
 		if user.Spec.UserID == req.UserID {
-			writeJSONError(w, http.StatusConflict, ErrorResponse{
-				Error:   "user_exists",
-				Message: fmt.Sprintf("User with email %s already exists", req.UserID),
-			})
+			writeLocalizedError(w, r, http.StatusConflict, "user_exists", MsgUserExists,
+				i18n.Params{"userId": req.UserID})
 			return
 		}
 	}
@@ -352,10 +320,7 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	passwordHash, err := auth.HashPassword(req.Password)
 	if err != nil {
 		logger.Error(err, "Failed to hash password")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to hash password",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordHashFailed, nil)
 		return
 	}
 
@@ -383,26 +348,20 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 			// Delete and recreate
 			if delErr := h.client.Delete(ctx, secret); delErr != nil {
 				logger.Error(delErr, "Failed to delete existing password secret", "secret", secretName)
-				writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_error",
-					Message: "Failed to recreate password secret",
-				})
+				writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordSecretStoreFailed,
+					i18n.Params{"error": err.Error()})
 				return
 			}
 			if createErr := h.client.Create(ctx, secret); createErr != nil {
 				logger.Error(createErr, "Failed to recreate password secret", "secret", secretName)
-				writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_error",
-					Message: "Failed to create password secret",
-				})
+				writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordSecretStoreFailed,
+					i18n.Params{"error": err.Error()})
 				return
 			}
 		} else {
 			logger.Error(err, "Failed to create password secret", "secret", secretName)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to create password secret: " + err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordSecretStoreFailed,
+				i18n.Params{"error": err.Error()})
 			return
 		}
 	}
@@ -431,10 +390,7 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		// Clean up secret
 		_ = h.client.Delete(ctx, secret)
 		logger.Error(err, "Failed to create user", "userID", req.UserID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create user",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserCreateFailed, nil)
 		return
 	}
 
@@ -451,7 +407,8 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	logger.Info("User created successfully", "userID", req.UserID, "role", req.Role)
 
 	writeJSON(w, http.StatusCreated, CreateUserResponse{
-		Message: "User created successfully",
+		Code:    MsgUserCreateSucceeded,
+		Message: localizedMessage(w, r, MsgUserCreateSucceeded, nil),
 		UserID:  req.UserID,
 		Role:    req.Role,
 	})
@@ -464,12 +421,9 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	logger := log.FromContext(ctx).WithName("update-user")
 
 	// Extract userID from path
-	userID, err := extractPathSuffix(r.URL.Path, UsersPath+"/")
+	userID, err := pathParam(r, ParamUserID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid userID in path",
-		})
+		writeInvalidIDError(w, r, ParamUserID, err)
 		return
 	}
 
@@ -478,20 +432,17 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request
 	var req UpdateUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "invalid_request", MsgMalformedRequestBody, nil)
 		return
 	}
 
 	// Validate at least one field provided
 	if req.Name == nil && req.Surname == nil && req.Organization == nil && req.Role == nil && req.Active == nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "At least one field must be provided",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgNoFieldsToUpdate, nil)
 		return
 	}
 
@@ -499,16 +450,11 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	user, err := h.fetchUserByEmail(ctx, userID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound, i18n.Params{"userId": userID})
 		} else {
 			logger.Error(err, "Failed to fetch user", "userID", userID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -519,37 +465,25 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	isSelf := claims != nil && claims.UserID == userID
 
 	if !isAdmin && !isSelf {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "You can only update your own profile",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgProfileUpdateForbidden, nil)
 		return
 	}
 
 	// Check field permissions (role and active are admin-only)
 	if !isAdmin && (req.Role != nil || req.Active != nil) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "Only admins can change role or active status",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgRoleChangeForbidden, nil)
 		return
 	}
 
 	// Prevent user from disabling themselves
 	if isSelf && req.Active != nil && !*req.Active {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "You cannot disable your own account",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgSelfDisableForbidden, nil)
 		return
 	}
 
 	// Validate role if provided
 	if req.Role != nil && *req.Role != "user" && *req.Role != "admin" {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Role must be either 'user' or 'admin'",
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgUserRoleInvalid, nil)
 		return
 	}
 
@@ -574,10 +508,8 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Update(ctx, user); err != nil {
 		logger.Error(err, "Failed to update user", "userID", userID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update user: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserUpdateFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -595,7 +527,8 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Return updated user
 	response := buildUserResponse(user)
 	writeJSON(w, http.StatusOK, UpdateUserResponse{
-		Message: "User updated successfully",
+		Code:    MsgUserUpdateSucceeded,
+		Message: localizedMessage(w, r, MsgUserUpdateSucceeded, nil),
 		User:    response,
 	})
 }
@@ -608,20 +541,14 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	// Check admin privileges
 	if !auth.IsAdmin(r.Context()) {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "This operation requires admin privileges",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgAdminRequired, nil)
 		return
 	}
 
 	// Extract userID from path
-	userID, err := extractPathSuffix(r.URL.Path, UsersPath+"/")
+	userID, err := pathParam(r, ParamUserID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid userID in path",
-		})
+		writeInvalidIDError(w, r, ParamUserID, err)
 		return
 	}
 
@@ -631,10 +558,7 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Check not deleting self
 	claims := auth.GetClaimsFromContext(r.Context())
 	if claims != nil && claims.UserID == userID {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "You cannot delete your own account",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgSelfDeleteForbidden, nil)
 		return
 	}
 
@@ -642,16 +566,11 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	user, err := h.fetchUserByEmail(ctx, userID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound, i18n.Params{"userId": userID})
 		} else {
 			logger.Error(err, "Failed to fetch user", "userID", userID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -666,10 +585,7 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 		if err != nil {
 			logger.Error(err, "Failed to check admin count")
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to check admin count",
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgAdminCountFailed, nil)
 			return
 		}
 
@@ -682,10 +598,7 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if activeAdmins <= 1 {
-			writeJSONError(w, http.StatusForbidden, ErrorResponse{
-				Error:   "forbidden",
-				Message: "Cannot delete the last active admin user",
-			})
+			writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgLastAdminForbidden, nil)
 			return
 		}
 	}
@@ -705,17 +618,16 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Delete user
 	if err := h.client.Delete(ctx, user); err != nil {
 		logger.Error(err, "Failed to delete user", "userID", userID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete user: " + err.Error(),
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserDeleteFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	logger.Info("User deleted successfully", "userID", userID)
 
 	writeJSON(w, http.StatusOK, DeleteUserResponse{
-		Message: "User deleted successfully",
+		Code:    MsgUserDeleteSucceeded,
+		Message: localizedMessage(w, r, MsgUserDeleteSucceeded, nil),
 	})
 }
 
@@ -726,34 +638,26 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	logger := log.FromContext(ctx).WithName("change-password")
 
 	// Extract userID from path
-	userID, err := extractPathSuffix(r.URL.Path, UsersPath+"/")
+	userID, err := pathParam(r, ParamUserID)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid userID in path",
-		})
+		writeInvalidIDError(w, r, ParamUserID, err)
 		return
 	}
 
-	// Remove "/password" suffix
-	userID = strings.TrimSuffix(userID, "/password")
-
 	// Parse request
 	var req ChangePasswordRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+	if err := h.decodeRequestBody(r, &req); err != nil {
+		if writeUnknownFieldsError(w, r, err) {
+			return
+		}
+		writeLocalizedError(w, r, http.StatusBadRequest, "invalid_request", MsgMalformedRequestBody, nil)
 		return
 	}
 
 	// Validate newPassword
 	if err := auth.ValidatePassword(req.NewPassword); err != nil {
-		writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: fmt.Sprintf("Password validation failed: %s", err.Error()),
-		})
+		writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgPasswordInvalid,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
@@ -763,10 +667,7 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	isSelf := claims != nil && claims.UserID == userID
 
 	if !isAdmin && !isSelf {
-		writeJSONError(w, http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "You can only change your own password",
-		})
+		writeLocalizedError(w, r, http.StatusForbidden, "forbidden", MsgPasswordChangeForbidden, nil)
 		return
 	}
 
@@ -774,16 +675,11 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	user, err := h.fetchUserByEmail(ctx, userID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			writeJSONError(w, http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusNotFound, "not_found", MsgUserNotFound, i18n.Params{"userId": userID})
 		} else {
 			logger.Error(err, "Failed to fetch user", "userID", userID)
-			writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: err.Error(),
-			})
+			writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgUserFetchFailed,
+				i18n.Params{"error": err.Error()})
 		}
 		return
 	}
@@ -797,48 +693,34 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Get(ctx, secretKey, secret); err != nil {
 		logger.Error(err, "Failed to get password secret", "secret", user.Spec.PasswordSecretRef)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get password secret",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordSecretFailed,
+			i18n.Params{"error": err.Error()})
 		return
 	}
 
 	passwordHash, ok := secret.Data["passwordHash"]
 	if !ok {
 		logger.Error(fmt.Errorf("passwordHash not found in secret"), "Missing password hash")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Password hash not found",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordHashMissing, nil)
 		return
 	}
 
 	// If changing own password, verify current password
 	if isSelf && !isAdmin {
 		if req.CurrentPassword == "" {
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "validation_error",
-				Message: "Current password is required when changing your own password",
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgCurrentPasswordRequired, nil)
 			return
 		}
 
 		// Verify current password
 		if !auth.VerifyPassword(req.CurrentPassword, string(passwordHash)) {
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "validation_error",
-				Message: "Current password is incorrect",
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgCurrentPasswordIncorrect, nil)
 			return
 		}
 
 		// Check new password is different
 		if auth.VerifyPassword(req.NewPassword, string(passwordHash)) {
-			writeJSONError(w, http.StatusBadRequest, ErrorResponse{
-				Error:   "validation_error",
-				Message: "New password must be different from current password",
-			})
+			writeLocalizedError(w, r, http.StatusBadRequest, "validation_error", MsgPasswordUnchanged, nil)
 			return
 		}
 	}
@@ -847,10 +729,7 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	newPasswordHash, err := auth.HashPassword(req.NewPassword)
 	if err != nil {
 		logger.Error(err, "Failed to hash new password")
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to hash new password",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordHashFailed, nil)
 		return
 	}
 
@@ -859,84 +738,14 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.client.Update(ctx, secret); err != nil {
 		logger.Error(err, "Failed to update password", "userID", userID)
-		writeJSONError(w, http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update password",
-		})
+		writeLocalizedError(w, r, http.StatusInternalServerError, "internal_error", MsgPasswordUpdateFailed, nil)
 		return
 	}
 
 	logger.Info("Password updated successfully", "userID", userID)
 
 	writeJSON(w, http.StatusOK, ChangePasswordResponse{
-		Message: "Password updated successfully",
-	})
-}
-
-// UsersRouter routes requests to /api/v1/users endpoints
-func (h *Handler) UsersRouter(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
-
-	// Root endpoint: /api/v1/users
-	if path == UsersPath {
-		if r.Method == http.MethodGet {
-			h.ListUsers(w, r)
-			return
-		}
-
-		if r.Method == http.MethodPost {
-			h.CreateUser(w, r)
-			return
-		}
-
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only GET and POST are allowed on " + UsersPath,
-		})
-		return
-	}
-
-	// User-specific endpoint: /api/v1/users/:userID
-	if strings.HasPrefix(path, UsersPath+"/") {
-		// Check for password change endpoint
-		if strings.HasSuffix(path, "/password") {
-			if r.Method == http.MethodPatch {
-				h.ChangePassword(w, r)
-				return
-			}
-
-			writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-				Error:   "method_not_allowed",
-				Message: "Only PATCH is allowed for password changes",
-			})
-			return
-		}
-
-		// Regular user operations
-		if r.Method == http.MethodGet {
-			h.GetUser(w, r)
-			return
-		}
-
-		if r.Method == http.MethodPatch {
-			h.UpdateUser(w, r)
-			return
-		}
-
-		if r.Method == http.MethodDelete {
-			h.DeleteUser(w, r)
-			return
-		}
-
-		writeJSONError(w, http.StatusMethodNotAllowed, ErrorResponse{
-			Error:   "method_not_allowed",
-			Message: "Only GET, PATCH, and DELETE are allowed on user endpoints",
-		})
-		return
-	}
-
-	writeJSONError(w, http.StatusNotFound, ErrorResponse{
-		Error:   "not_found",
-		Message: "Endpoint not found",
+		Code:    MsgPasswordUpdateSucceeded,
+		Message: localizedMessage(w, r, MsgPasswordUpdateSucceeded, nil),
 	})
 }
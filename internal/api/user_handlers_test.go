@@ -232,7 +232,7 @@ func TestGetUser_AdminCanViewAny(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.GetUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
@@ -257,7 +257,7 @@ func TestGetUser_UserCanViewSelf(t *testing.T) {
 	req = req.WithContext(createUserContext("user1@test.local"))
 	w := httptest.NewRecorder()
 
-	handler.GetUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
@@ -274,7 +274,7 @@ func TestGetUser_UserCannotViewOthers(t *testing.T) {
 	req = req.WithContext(createUserContext("user2@test.local"))
 	w := httptest.NewRecorder()
 
-	handler.GetUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
@@ -289,7 +289,7 @@ func TestGetUser_NotFound(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.GetUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
@@ -445,7 +445,7 @@ func TestUpdateUser_AdminCanUpdateAll(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.UpdateUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
@@ -477,7 +477,7 @@ func TestUpdateUser_UserCanUpdateSelf(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.UpdateUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
@@ -496,7 +496,7 @@ func TestUpdateUser_UserCannotChangeRole(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.UpdateUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
@@ -520,7 +520,7 @@ func TestUpdateUser_Validation(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.UpdateUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
@@ -549,7 +549,7 @@ func TestUpdateUser_CannotDisableSelf(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.UpdateUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusForbidden, w.Code, w.Body.String())
@@ -575,7 +575,7 @@ func TestDeleteUser_Success(t *testing.T) {
 	req = req.WithContext(ctx)
 	w := httptest.NewRecorder()
 
-	handler.DeleteUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
@@ -591,7 +591,7 @@ func TestDeleteUser_CannotDeleteSelf(t *testing.T) {
 	req = req.WithContext(createAdminContext())
 	w := httptest.NewRecorder()
 
-	handler.DeleteUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
@@ -618,7 +618,7 @@ func TestDeleteUser_CannotDeleteLastAdmin(t *testing.T) {
 	req = req.WithContext(ctx)
 	w := httptest.NewRecorder()
 
-	handler.DeleteUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusForbidden, w.Code, w.Body.String())
@@ -638,7 +638,7 @@ func TestDeleteUser_NotAdmin_Forbidden(t *testing.T) {
 	req = req.WithContext(createUserContext("user1@test.local"))
 	w := httptest.NewRecorder()
 
-	handler.DeleteUser(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
@@ -657,7 +657,7 @@ func TestChangePassword_SelfChange_RequiresCurrentPassword(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.ChangePassword(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
@@ -679,7 +679,7 @@ func TestChangePassword_AdminChange_NoCurrentPassword(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.ChangePassword(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
@@ -698,7 +698,7 @@ func TestChangePassword_Validation(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.ChangePassword(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
@@ -721,7 +721,7 @@ func TestChangePassword_SamePassword_Rejected(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	handler.ChangePassword(w, req)
+	serveAPI(handler, w, req)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("Expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
@@ -765,7 +765,7 @@ func TestUsersRouter_MethodRouting(t *testing.T) {
 			req = req.WithContext(createAdminContext())
 			w := httptest.NewRecorder()
 
-			handler.UsersRouter(w, req)
+			serveAPI(handler, w, req)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d for %s %s: %s", tt.expectedStatus, w.Code, tt.method, tt.path, w.Body.String())
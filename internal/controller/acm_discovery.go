@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+)
+
+const (
+	// DiscoveredByLabel marks the targets created by a discovery, with value "acm" or "hive"
+	DiscoveredByLabel = "krkn.krkn-chaos.dev/discovered-by"
+	// ACMManagedClusterLabel is the name of the ManagedCluster a discovered target comes from
+	ACMManagedClusterLabel = "krkn.krkn-chaos.dev/managed-cluster"
+
+	// acmDiscoveredBy is the value of DiscoveredByLabel
+	acmDiscoveredBy = "acm"
+	// acmTargetPrefix names the targets of ManagedClusters, acm-<cluster>
+	acmTargetPrefix = "acm-"
+	// acmResyncInterval is how often the admin kubeconfig of a ManagedCluster is read again
+	acmResyncInterval = 10 * time.Minute
+)
+
+var (
+	// ManagedClusterGVK is the ACM ManagedCluster kind on the hub
+	ManagedClusterGVK = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1", Kind: "ManagedCluster"}
+	// ClusterDeploymentGVK is the Hive ClusterDeployment holding the admin kubeconfig reference
+	ClusterDeploymentGVK = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterDeployment"}
+)
+
+// ACMDiscoveryReconciler creates a KrknOperatorTarget for each ACM ManagedCluster of the hub,
+// as an alternative to the external krkn-operator-acm provider. The kubeconfig is the admin
+// kubeconfig ACM generated for the clusters it provisioned, referenced by the Hive
+// ClusterDeployment in the namespace of the cluster; imported clusters have none and get no
+// target. Targets are named acm-<cluster>, keep their Secret in sync with the admin
+// kubeconfig, and are deleted with their ManagedCluster.
+type ACMDiscoveryReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	OperatorNamespace string
+	// APIReader reads the ClusterDeployments and admin kubeconfig Secrets of the cluster
+	// namespaces, outside the namespace the manager cache is restricted to
+	APIReader client.Reader
+	// Recorder emits discovery events on the targets (nil disables events)
+	Recorder record.EventRecorder
+	// Cipher seals the kubeconfigs of the discovered targets at rest (nil when encryption is off)
+	Cipher *envelope.Cipher
+	// MaxConcurrentReconciles is the number of parallel workers (0 uses the controller-runtime default)
+	MaxConcurrentReconciles int
+}
+
+// +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hive.openshift.io,resources=clusterdeployments,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets/status,verbs=get;update;patch
+
+// Reconcile creates or updates the target of a ManagedCluster, or deletes it once the
+// ManagedCluster is gone. Targets archived by an admin and clusters already registered by
+// hand are left alone.
+func (r *ACMDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("managedCluster", req.Name)
+
+	managedCluster := &unstructured.Unstructured{}
+	managedCluster.SetGroupVersionKind(ManagedClusterGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: req.Name}, managedCluster)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	if apierrors.IsNotFound(err) || managedCluster.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, r.removeTarget(ctx, req.Name)
+	}
+
+	clusterDeployment := &unstructured.Unstructured{}
+	clusterDeployment.SetGroupVersionKind(ClusterDeploymentGVK)
+	err = r.APIReader.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Name}, clusterDeployment)
+	if err != nil {
+		logger.V(1).Info("ManagedCluster has no ClusterDeployment", "reason", err.Error())
+		return ctrl.Result{RequeueAfter: acmResyncInterval}, nil
+	}
+	kubeconfigBase64, err := adminKubeconfig(ctx, r.APIReader, clusterDeployment)
+	if err != nil {
+		logger.V(1).Info("ManagedCluster has no usable admin kubeconfig", "reason", err.Error())
+		return ctrl.Result{RequeueAfter: acmResyncInterval}, nil
+	}
+	apiURL, err := kubeconfig.ExtractAPIURL(kubeconfigBase64)
+	if err == nil {
+		apiURL, err = kubeconfig.NormalizeAPIURL(apiURL)
+	}
+	if err != nil {
+		logger.Info("Admin kubeconfig of ManagedCluster has no valid API URL", "error", err.Error())
+		return ctrl.Result{RequeueAfter: acmResyncInterval}, nil
+	}
+
+	var target krknv1alpha1.KrknOperatorTarget
+	name := acmTargetPrefix + req.Name
+	err = r.Get(ctx, types.NamespacedName{Name: name, Namespace: r.OperatorNamespace}, &target)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	exists := err == nil
+	if exists && target.Status.Archived {
+		return ctrl.Result{RequeueAfter: acmResyncInterval}, nil
+	}
+
+	if !exists {
+		registered, err := registeredTarget(ctx, r.Client, r.OperatorNamespace, acmDiscoveredBy, req.Name, apiURL)
+		if err != nil || registered != "" {
+			if registered != "" {
+				logger.Info("ManagedCluster already registered as a target, not discovered", "target", registered)
+			}
+			return ctrl.Result{RequeueAfter: acmResyncInterval}, err
+		}
+		target = krknv1alpha1.KrknOperatorTarget{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.OperatorNamespace}}
+	}
+
+	labels := acmTargetLabels(managedCluster)
+	changed := !exists || target.Spec.ClusterAPIURL != apiURL || !maps.Equal(target.Labels, labels)
+	target.Labels = labels
+	target.Spec = krknv1alpha1.KrknOperatorTargetSpec{
+		UUID:          name,
+		ClusterName:   req.Name,
+		ClusterAPIURL: apiURL,
+		SecretType:    "kubeconfig",
+		SecretUUID:    name,
+		Metrics:       target.Spec.Metrics,
+	}
+	target.Default()
+	if !exists {
+		if err := r.Create(ctx, &target); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create target: %w", err)
+		}
+	} else if changed {
+		if err := r.Update(ctx, &target); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update target: %w", err)
+		}
+	}
+
+	rotated, err := syncTargetKubeconfig(ctx, r.Client, r.Scheme, r.Cipher, &target, kubeconfigBase64)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !exists || rotated {
+		if !exists {
+			target.Status.Ready = true
+		}
+		target.Status.LastUpdated = metav1.Now()
+		plugins, _ := kubeconfig.AuthPlugins(kubeconfigBase64)
+		target.Status.SetAuthPlugins(plugins, target.Generation)
+		if err := r.Status().Update(ctx, &target); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update target status: %w", err)
+		}
+	}
+
+	switch {
+	case !exists:
+		logger.Info("Discovered ManagedCluster as target", "target", name, "apiURL", apiURL)
+		recordEvent(r.Recorder, &target, corev1.EventTypeNormal, EventTargetDiscovered,
+			"Created from ACM ManagedCluster %s", req.Name)
+	case rotated:
+		logger.Info("Admin kubeconfig of ManagedCluster changed, target updated", "target", name)
+		recordEvent(r.Recorder, &target, corev1.EventTypeNormal, EventTargetKubeconfigSynced,
+			"Kubeconfig updated from the admin kubeconfig of ACM ManagedCluster %s", req.Name)
+	}
+	return ctrl.Result{RequeueAfter: acmResyncInterval}, nil
+}
+
+// removeTarget deletes the discovered target of a ManagedCluster that is gone. The
+// Secret is owned by the target and garbage collected with it.
+func (r *ACMDiscoveryReconciler) removeTarget(ctx context.Context, clusterName string) error {
+	var target krknv1alpha1.KrknOperatorTarget
+	err := r.Get(ctx, types.NamespacedName{Name: acmTargetPrefix + clusterName, Namespace: r.OperatorNamespace}, &target)
+	if err != nil || target.Labels[DiscoveredByLabel] != acmDiscoveredBy {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.Delete(ctx, &target); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete target: %w", err)
+	}
+	log.FromContext(ctx).Info("ManagedCluster removed, target deleted", "managedCluster", clusterName, "target", target.Name)
+	return nil
+}
+
+// acmTargetLabels returns the labels of the target of a ManagedCluster: its own labels,
+// e.g. cloud, vendor or clusterset, for target selectors, and the discovery labels
+func acmTargetLabels(managedCluster *unstructured.Unstructured) map[string]string {
+	labels := discoveredTargetLabels(managedCluster, acmDiscoveredBy)
+	labels[ACMManagedClusterLabel] = managedCluster.GetName()
+	return labels
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ACMDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	managedCluster := &unstructured.Unstructured{}
+	managedCluster.SetGroupVersionKind(ManagedClusterGVK)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(managedCluster).
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Named(ACMDiscoveryControllerName).
+		Complete(r)
+}
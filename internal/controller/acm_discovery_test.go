@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+)
+
+func acmAdminKubeconfig(server, token string) []byte {
+	return fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: %s
+contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    token: %s
+`, server, token)
+}
+
+// acmCluster returns a ManagedCluster provisioned by ACM, with its ClusterDeployment and
+// admin kubeconfig Secret
+func acmCluster(name, server string) []client.Object {
+	managedCluster := &unstructured.Unstructured{}
+	managedCluster.SetGroupVersionKind(ManagedClusterGVK)
+	managedCluster.SetName(name)
+	managedCluster.SetLabels(map[string]string{"cloud": "Amazon", "vendor": "OpenShift"})
+
+	clusterDeployment := &unstructured.Unstructured{}
+	clusterDeployment.SetGroupVersionKind(ClusterDeploymentGVK)
+	clusterDeployment.SetName(name)
+	clusterDeployment.SetNamespace(name)
+	_ = unstructured.SetNestedField(clusterDeployment.Object, name+"-admin-kubeconfig",
+		"spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-admin-kubeconfig", Namespace: name},
+		Data:       map[string][]byte{"kubeconfig": acmAdminKubeconfig(server, "token-1")},
+	}
+	return []client.Object{managedCluster, clusterDeployment, secret}
+}
+
+func setupTestACMDiscovery(objs ...client.Object) (*ACMDiscoveryReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	return &ACMDiscoveryReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		OperatorNamespace: testOperatorNamespace,
+		APIReader:         fakeClient,
+		Recorder:          recorder,
+	}, recorder
+}
+
+func TestACMDiscovery_Lifecycle(t *testing.T) {
+	reconciler, recorder := setupTestACMDiscovery(acmCluster("spoke", "https://api.spoke.example.com:6443")...)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "spoke"}}
+	key := types.NamespacedName{Name: "acm-spoke", Namespace: testOperatorNamespace}
+
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := reconciler.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Expected the target to be created: %v", err)
+	}
+	if target.Spec.ClusterName != "spoke" || target.Spec.ClusterAPIURL != "https://api.spoke.example.com:6443" ||
+		target.Spec.SecretType != "kubeconfig" || !target.Status.Ready {
+		t.Errorf("Unexpected target %+v", target)
+	}
+	if target.Labels[DiscoveredByLabel] != "acm" || target.Labels[ACMManagedClusterLabel] != "spoke" ||
+		target.Labels["cloud"] != "Amazon" {
+		t.Errorf("Expected the discovery and ManagedCluster labels, got %v", target.Labels)
+	}
+
+	// A new admin kubeconfig is copied to the target Secret
+	var adminSecret corev1.Secret
+	adminKey := types.NamespacedName{Name: "spoke-admin-kubeconfig", Namespace: "spoke"}
+	if err := reconciler.Get(ctx, adminKey, &adminSecret); err != nil {
+		t.Fatalf("Failed to get admin kubeconfig: %v", err)
+	}
+	adminSecret.Data["kubeconfig"] = acmAdminKubeconfig("https://api.spoke.example.com:6443", "token-2")
+	if err := reconciler.Update(ctx, &adminSecret); err != nil {
+		t.Fatalf("Failed to update admin kubeconfig: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	var secret corev1.Secret
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "acm-spoke", Namespace: testOperatorNamespace}, &secret); err != nil {
+		t.Fatalf("Failed to get target Secret: %v", err)
+	}
+	kubeconfigBase64, err := kubeconfig.UnmarshalSecretData(secret.Data["kubeconfig"])
+	if err != nil {
+		t.Fatalf("Failed to unmarshal kubeconfig: %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if !strings.Contains(string(raw), "token-2") {
+		t.Errorf("Expected the rotated token, got %s", raw)
+	}
+
+	// The target goes with its ManagedCluster
+	managedCluster := &unstructured.Unstructured{}
+	managedCluster.SetGroupVersionKind(ManagedClusterGVK)
+	managedCluster.SetName("spoke")
+	if err := reconciler.Delete(ctx, managedCluster); err != nil {
+		t.Fatalf("Failed to delete ManagedCluster: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, &target); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the target to be deleted, got %v", err)
+	}
+
+	events := drainEvents(recorder)
+	want := []string{"Normal " + EventTargetDiscovered, "Normal " + EventTargetKubeconfigSynced}
+	if !slices.Equal(events, want) {
+		t.Errorf("Expected events %v, got %v", want, events)
+	}
+}
+
+func TestACMDiscovery_SkipsRegisteredCluster(t *testing.T) {
+	registered := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: testOperatorNamespace},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{
+			UUID:          "manual",
+			ClusterName:   "production",
+			ClusterAPIURL: "https://api.spoke.example.com:6443",
+		},
+	}
+	objs := append(acmCluster("spoke", "https://API.spoke.example.com:6443/"), registered)
+	reconciler, _ := setupTestACMDiscovery(objs...)
+	ctx := context.Background()
+
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "spoke"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := reconciler.List(ctx, &targets); err != nil || len(targets.Items) != 1 {
+		t.Errorf("Expected only the registered target, got %d (%v)", len(targets.Items), err)
+	}
+
+	// A ManagedCluster with no admin kubeconfig, e.g. imported, gets no target either
+	imported := &unstructured.Unstructured{}
+	imported.SetGroupVersionKind(ManagedClusterGVK)
+	imported.SetName("imported")
+	if err := reconciler.Create(ctx, imported); err != nil {
+		t.Fatalf("Failed to create ManagedCluster: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "imported"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := reconciler.List(ctx, &targets); err != nil || len(targets.Items) != 1 {
+		t.Errorf("Expected no target for an imported cluster, got %d (%v)", len(targets.Items), err)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+	"github.com/krkn-chaos/krkn-operator/pkg/objectstore"
+)
+
+// Names of the files of a job uploaded to the artifact storage of its run
+const (
+	uploadedLogName       = "scenario.log"
+	uploadedChaosDataName = "chaos-data.json"
+)
+
+// artifactFile is a file of a job to upload
+type artifactFile struct {
+	name        string
+	body        []byte
+	contentType string
+}
+
+// objectPutter uploads objects to S3-compatible storage
+type objectPutter interface {
+	PutObject(ctx context.Context, bucket objectstore.Bucket, key string, body []byte, contentType string) (string, error)
+}
+
+// uploadArtifacts uploads the log of the job of an artifacts ConfigMap and the krkn chaos
+// data printed in it to the artifact storage of the run, and records the uploaded files
+// in data, or why they could not be uploaded. Runs without artifact storage upload nothing.
+func (a *ArtifactCollector) uploadArtifacts(ctx context.Context, cm *corev1.ConfigMap, logs []byte, data map[string]string) {
+	owner := metav1.GetControllerOf(cm)
+	if owner == nil {
+		return
+	}
+	var scenarioRun krknv1alpha1.KrknScenarioRun
+	if err := a.client.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: cm.Namespace}, &scenarioRun); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			data[ArtifactUploadErrorKey] = fmt.Sprintf("failed to get scenario run %s: %v", owner.Name, err)
+		}
+		return
+	}
+	if scenarioRun.Spec.Artifacts == nil {
+		return
+	}
+
+	uploads, err := a.upload(ctx, scenarioRun.Name, scenarioRun.Spec.Artifacts, cm, logs)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to upload job artifacts",
+			"scenarioRun", scenarioRun.Name,
+			"configMap", cm.Name)
+		data[ArtifactUploadErrorKey] = err.Error()
+		return
+	}
+	encoded, err := json.Marshal(uploads)
+	if err != nil {
+		data[ArtifactUploadErrorKey] = fmt.Sprintf("failed to encode uploads: %v", err)
+		return
+	}
+	data[ArtifactUploadsKey] = string(encoded)
+}
+
+// upload puts the files of a job under <prefix>/<run>/<cluster>/<job ID>/ in the bucket
+func (a *ArtifactCollector) upload(ctx context.Context, runName string, storage *krknv1alpha1.ArtifactStorage, cm *corev1.ConfigMap, logs []byte) ([]krknv1alpha1.UploadedArtifact, error) {
+	var secret corev1.Secret
+	if err := a.client.Get(ctx, types.NamespacedName{Name: storage.CredentialsSecret, Namespace: a.namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get credentials secret %s: %w", storage.CredentialsSecret, err)
+	}
+	credentials, err := objectstore.CredentialsFromSecretData(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials secret %s: %w", storage.CredentialsSecret, err)
+	}
+	bucket := objectstore.Bucket{
+		Endpoint:    storage.Endpoint,
+		Region:      storage.Region,
+		Name:        storage.Bucket,
+		Credentials: credentials,
+	}
+
+	files := []artifactFile{{name: uploadedLogName, body: logs, contentType: "text/plain; charset=utf-8"}}
+	if chaosData := krknChaosData(logs); chaosData != nil {
+		files = append(files, artifactFile{name: uploadedChaosDataName, body: chaosData, contentType: "application/json"})
+	}
+
+	dir := path.Join(strings.Trim(storage.Prefix, "/"), runName, cm.Labels[joblabels.ClusterName], cm.Labels[ArtifactJobIDLabel])
+	uploads := make([]krknv1alpha1.UploadedArtifact, 0, len(files))
+	for _, file := range files {
+		url, err := a.objects.PutObject(ctx, bucket, path.Join(dir, file.name), file.body, file.contentType)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, krknv1alpha1.UploadedArtifact{Name: file.name, URL: url})
+	}
+	return uploads, nil
+}
+
+// recordJobUploads records the artifacts the collector uploaded for the finished jobs of
+// runs with an artifact storage, or why the upload failed
+func (r *KrknScenarioRunReconciler) recordJobUploads(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if r.Artifacts == nil || scenarioRun.Spec.Artifacts == nil {
+		return
+	}
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if job.JobID == "" || job.Artifacts != nil || job.ArtifactsUploadError != "" {
+			continue
+		}
+
+		var cm corev1.ConfigMap
+		key := types.NamespacedName{Name: artifactsConfigMapName(job.JobID), Namespace: r.Namespace}
+		if err := r.Get(ctx, key, &cm); err != nil {
+			continue
+		}
+		if uploadErr, ok := cm.Data[ArtifactUploadErrorKey]; ok {
+			job.ArtifactsUploadError = uploadErr
+			continue
+		}
+		encoded, ok := cm.Data[ArtifactUploadsKey]
+		if !ok {
+			continue
+		}
+		var uploads []krknv1alpha1.UploadedArtifact
+		if err := json.Unmarshal([]byte(encoded), &uploads); err != nil {
+			log.FromContext(ctx).Error(err, "failed to decode uploaded artifacts",
+				"cluster", job.ClusterName,
+				"jobID", job.JobID)
+			continue
+		}
+		job.Artifacts = uploads
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/objectstore"
+)
+
+// recordingPutter records the uploaded objects
+type recordingPutter struct {
+	bucket  objectstore.Bucket
+	objects map[string]string
+}
+
+func (p *recordingPutter) PutObject(_ context.Context, bucket objectstore.Bucket, key string, body []byte, _ string) (string, error) {
+	p.bucket = bucket
+	p.objects[key] = string(body)
+	return objectstore.ObjectURL(bucket.Endpoint, bucket.Name, key)
+}
+
+func TestArtifactCollector_UploadArtifacts(t *testing.T) {
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			objectstore.AccessKeyIDKey:     []byte("AKID"),
+			objectstore.SecretAccessKeyKey: []byte("secret"),
+		},
+	}
+	collector, scenarioRun := setupArtifactTest(credentials)
+	putter := &recordingPutter{objects: map[string]string{}}
+	collector.objects = putter
+	ctx := context.Background()
+
+	scenarioRun.Spec.Artifacts = &krknv1alpha1.ArtifactStorage{
+		Endpoint:          "https://s3.example.com",
+		Bucket:            "chaos-artifacts",
+		Prefix:            "/runs/",
+		CredentialsSecret: "s3-credentials",
+	}
+	if err := collector.client.Update(ctx, scenarioRun); err != nil {
+		t.Fatalf("failed to update scenario run: %v", err)
+	}
+	job := &krknv1alpha1.ClusterJobStatus{ClusterName: "cluster-1", JobID: "job-1", PodName: "krkn-job-job-1-abcde"}
+	if err := collector.Enqueue(ctx, scenarioRun, job); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	cm := getArtifacts(t, collector, "job-1")
+
+	data := map[string]string{}
+	collector.uploadArtifacts(ctx, cm, []byte(chaosDataLog), data)
+
+	if putter.bucket.Name != "chaos-artifacts" || putter.bucket.Credentials.AccessKeyID != "AKID" {
+		t.Errorf("unexpected bucket %+v", putter.bucket)
+	}
+	if putter.objects["runs/test-scenario/cluster-1/job-1/scenario.log"] != chaosDataLog {
+		t.Errorf("expected the log to be uploaded, got %v", putter.objects)
+	}
+	chaosData, ok := putter.objects["runs/test-scenario/cluster-1/job-1/chaos-data.json"]
+	if !ok || !json.Valid([]byte(chaosData)) {
+		t.Errorf("expected the krkn chaos data to be uploaded, got %v", putter.objects)
+	}
+	var uploads []krknv1alpha1.UploadedArtifact
+	if err := json.Unmarshal([]byte(data[ArtifactUploadsKey]), &uploads); err != nil {
+		t.Fatalf("failed to decode uploads %q: %v", data[ArtifactUploadsKey], err)
+	}
+	if len(uploads) != 2 || uploads[0].URL != "https://s3.example.com/chaos-artifacts/runs/test-scenario/cluster-1/job-1/scenario.log" {
+		t.Errorf("unexpected uploads %+v", uploads)
+	}
+
+	// Missing credentials are reported, not retried
+	scenarioRun.Spec.Artifacts.CredentialsSecret = "missing"
+	if err := collector.client.Update(ctx, scenarioRun); err != nil {
+		t.Fatalf("failed to update scenario run: %v", err)
+	}
+	data = map[string]string{}
+	collector.uploadArtifacts(ctx, cm, []byte(chaosDataLog), data)
+	if !strings.Contains(data[ArtifactUploadErrorKey], "missing") || data[ArtifactUploadsKey] != "" {
+		t.Errorf("expected an upload error, got %v", data)
+	}
+
+	// Runs without artifact storage upload nothing
+	scenarioRun.Spec.Artifacts = nil
+	if err := collector.client.Update(ctx, scenarioRun); err != nil {
+		t.Fatalf("failed to update scenario run: %v", err)
+	}
+	data = map[string]string{}
+	collector.uploadArtifacts(ctx, cm, []byte(chaosDataLog), data)
+	if len(data) != 0 {
+		t.Errorf("expected no upload, got %v", data)
+	}
+}
+
+func TestRecordJobUploads(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	uploaded := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: artifactsConfigMapName("job-1"), Namespace: "default"},
+		Data: map[string]string{
+			ArtifactUploadsKey: `[{"name":"scenario.log","url":"https://s3.example.com/bucket/job-1/scenario.log"}]`,
+		},
+	}
+	failed := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: artifactsConfigMapName("job-2"), Namespace: "default"},
+		Data:       map[string]string{ArtifactUploadErrorKey: "failed to upload scenario.log: 403 Forbidden"},
+	}
+	reconciler := &KrknScenarioRunReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(uploaded, failed).Build(),
+		Scheme:    scheme,
+		Namespace: "default",
+		Artifacts: &ArtifactCollector{},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			Artifacts: &krknv1alpha1.ArtifactStorage{Endpoint: "https://s3.example.com", Bucket: "bucket", CredentialsSecret: "s3"},
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "cluster-1", JobID: "job-1", Phase: statemachine.JobSucceeded},
+			{ClusterName: "cluster-2", JobID: "job-2", Phase: statemachine.JobFailed},
+			{ClusterName: "cluster-3", JobID: "job-3", Phase: statemachine.JobRunning},
+		}},
+	}
+
+	reconciler.recordJobUploads(context.Background(), scenarioRun)
+
+	jobs := scenarioRun.Status.ClusterJobs
+	if len(jobs[0].Artifacts) != 1 || jobs[0].Artifacts[0].Name != "scenario.log" || jobs[0].ArtifactsUploadError != "" {
+		t.Errorf("expected the uploaded log, got %+v", jobs[0])
+	}
+	if jobs[1].Artifacts != nil || !strings.Contains(jobs[1].ArtifactsUploadError, "403") {
+		t.Errorf("expected the upload error, got %+v", jobs[1])
+	}
+	if jobs[2].Artifacts != nil || jobs[2].ArtifactsUploadError != "" {
+		t.Errorf("expected nothing for a running job, got %+v", jobs[2])
+	}
+}
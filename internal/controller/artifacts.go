@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/objectstore"
+)
+
+const (
+	// ArtifactJobIDLabel holds the job ID of an artifacts ConfigMap. It is not the job ID
+	// label: artifacts outlive the Job and its pods.
+	ArtifactJobIDLabel = "krkn.krkn-chaos.dev/artifact-job-id"
+	// ArtifactStateAnnotation is the collection state of an artifacts ConfigMap. Pending is
+	// the in-progress marker: collection resumes from it after an operator restart.
+	ArtifactStateAnnotation = "krkn.krkn-chaos.dev/artifact-state"
+	// ArtifactPodAnnotation is the scenario pod the artifacts are collected from
+	ArtifactPodAnnotation = "krkn.krkn-chaos.dev/artifact-pod"
+
+	// ArtifactLogKey is the ConfigMap key holding the final scenario log
+	ArtifactLogKey = "scenario.log"
+	// ArtifactResultKey is the ConfigMap key holding the krkn result parsed from the log,
+	// set only when the log holds the krkn telemetry
+	ArtifactResultKey = "result.json"
+	// ArtifactUploadsKey is the ConfigMap key holding the files uploaded to the artifact
+	// storage of the run, set only when the run has one
+	ArtifactUploadsKey = "uploads.json"
+	// ArtifactUploadErrorKey is the ConfigMap key holding why the upload failed
+	ArtifactUploadErrorKey = "upload-error"
+
+	// DefaultArtifactFlushTimeout bounds the collection of pending artifacts on shutdown
+	DefaultArtifactFlushTimeout = 20 * time.Second
+)
+
+// Values of ArtifactStateAnnotation
+const (
+	artifactStatePending     = "Pending"
+	artifactStateCollected   = "Collected"
+	artifactStateUnavailable = "Unavailable"
+)
+
+// artifactLogTailLines and artifactLogMaxBytes keep the collected log well under the 1MiB
+// ConfigMap limit. The end of the log holds the krkn results.
+const (
+	artifactLogTailLines int64 = 5000
+	artifactLogMaxBytes  int64 = 512 * 1024
+)
+
+// artifactsConfigMapName returns the name of the ConfigMap holding the artifacts of a job
+func artifactsConfigMapName(jobID string) string {
+	return scenarioJobName(jobID) + "-artifacts"
+}
+
+// ArtifactCollector stores the final log of finished scenario jobs in a ConfigMap, before
+// the Job and its pods are deleted, along with the krkn result parsed from the log. When
+// the run has an artifact storage, the log and the krkn chaos data are uploaded to it too.
+// Collection is asynchronous: the ConfigMap is created first with a Pending marker, filled
+// by the collector, and marked Collected. On shutdown the pending collections get a
+// bounded time to finish; those left Pending are resumed when the operator starts again.
+type ArtifactCollector struct {
+	client       client.Client
+	clientset    kubernetes.Interface
+	namespace    string
+	flushTimeout time.Duration
+	objects      objectPutter
+
+	mu      sync.Mutex
+	pending []string // names of the artifacts ConfigMaps to collect
+	wake    chan struct{}
+}
+
+// NewArtifactCollector creates a collector of the artifacts of the jobs in namespace
+func NewArtifactCollector(c client.Client, clientset kubernetes.Interface, namespace string, flushTimeout time.Duration) *ArtifactCollector {
+	return &ArtifactCollector{
+		client:       c,
+		clientset:    clientset,
+		namespace:    namespace,
+		flushTimeout: flushTimeout,
+		objects:      objectstore.NewClient(),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Enqueue persists the Pending marker of the artifacts of a finished job and queues their
+// collection. A job whose artifacts are already queued or collected is left as is.
+func (a *ArtifactCollector) Enqueue(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun, job *krknv1alpha1.ClusterJobStatus) error {
+	if job.JobID == "" || job.PodName == "" {
+		return nil
+	}
+
+	labels := runLabels(scenarioRun, job.ClusterName)
+	labels[ArtifactJobIDLabel] = job.JobID
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      artifactsConfigMapName(job.JobID),
+			Namespace: a.namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				ArtifactStateAnnotation: artifactStatePending,
+				ArtifactPodAnnotation:   job.PodName,
+			},
+		},
+	}
+	// The artifacts go away with the run
+	if err := controllerutil.SetControllerReference(scenarioRun, cm, a.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference on artifacts ConfigMap: %w", err)
+	}
+	if err := a.client.Create(ctx, cm); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create artifacts ConfigMap: %w", err)
+	}
+
+	a.push(cm.Name)
+	return nil
+}
+
+func (a *ArtifactCollector) push(name string) {
+	a.mu.Lock()
+	a.pending = append(a.pending, name)
+	a.mu.Unlock()
+
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the next artifacts ConfigMap to collect, false when none is pending
+func (a *ArtifactCollector) pop() (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.pending) == 0 {
+		return "", false
+	}
+	name := a.pending[0]
+	a.pending = a.pending[1:]
+	return name, true
+}
+
+// Start implements manager.Runnable
+func (a *ArtifactCollector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("artifact-collector")
+
+	if err := a.resume(ctx); err != nil {
+		// The markers stay Pending, the next start tries again
+		logger.Error(err, "Failed to resume pending artifact collections")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.flush(logger)
+			return nil
+		case <-a.wake:
+			a.drain(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (a *ArtifactCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// resume queues the artifacts left Pending by a previous operator instance
+func (a *ArtifactCollector) resume(ctx context.Context) error {
+	var cms corev1.ConfigMapList
+	if err := a.client.List(ctx, &cms, client.InNamespace(a.namespace), client.HasLabels{ArtifactJobIDLabel}); err != nil {
+		return fmt.Errorf("failed to list artifacts ConfigMaps: %w", err)
+	}
+	resumed := 0
+	for _, cm := range cms.Items {
+		if cm.Annotations[ArtifactStateAnnotation] == artifactStatePending {
+			a.push(cm.Name)
+			resumed++
+		}
+	}
+	if resumed > 0 {
+		log.FromContext(ctx).Info("resuming pending artifact collections", "count", resumed)
+	}
+	return nil
+}
+
+// drain collects every queued artifact until the queue is empty or ctx is done
+func (a *ArtifactCollector) drain(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("artifact-collector")
+	for ctx.Err() == nil {
+		name, ok := a.pop()
+		if !ok {
+			return
+		}
+		if err := a.collect(ctx, name); err != nil {
+			// Left Pending: collected again on the next start
+			logger.Error(err, "Failed to collect job artifacts", "configMap", name)
+		}
+	}
+}
+
+// flush gives the collections still queued at shutdown flushTimeout to finish
+func (a *ArtifactCollector) flush(logger logr.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.flushTimeout)
+	defer cancel()
+	a.drain(ctx)
+
+	a.mu.Lock()
+	left := len(a.pending)
+	a.mu.Unlock()
+	logger.Info("Flushed pending artifact collections", "leftPending", left)
+}
+
+// collect stores the final log of the pod of an artifacts ConfigMap, its krkn result and
+// its uploads, and marks it Collected, or Unavailable when the pod is gone
+func (a *ArtifactCollector) collect(ctx context.Context, name string) error {
+	key := types.NamespacedName{Name: name, Namespace: a.namespace}
+	var cm corev1.ConfigMap
+	if err := a.client.Get(ctx, key, &cm); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if cm.Annotations[ArtifactStateAnnotation] != artifactStatePending {
+		return nil
+	}
+
+	state := artifactStateCollected
+	logs, err := a.clientset.CoreV1().Pods(a.namespace).GetLogs(cm.Annotations[ArtifactPodAnnotation], &corev1.PodLogOptions{
+		TailLines:  ptr.To(artifactLogTailLines),
+		LimitBytes: ptr.To(artifactLogMaxBytes),
+	}).DoRaw(ctx)
+	switch {
+	case apierrors.IsNotFound(err):
+		state = artifactStateUnavailable
+	case err != nil:
+		return fmt.Errorf("failed to read logs of pod %s: %w", cm.Annotations[ArtifactPodAnnotation], err)
+	}
+	data := map[string]string{ArtifactLogKey: string(logs)}
+	if result := parseKrknResult(logs); result != nil {
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode krkn result: %w", err)
+		}
+		data[ArtifactResultKey] = string(encoded)
+	}
+	if state == artifactStateCollected {
+		a.uploadArtifacts(ctx, &cm, logs, data)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := a.client.Get(ctx, key, &cm); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if state == artifactStateCollected {
+			cm.Data = data
+		}
+		cm.Annotations[ArtifactStateAnnotation] = state
+		return a.client.Update(ctx, &cm)
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func setupArtifactTest(objects ...client.Object) (*ArtifactCollector, *krknv1alpha1.KrknScenarioRun) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default", UID: "run-uid"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, scenarioRun)...).Build()
+	return NewArtifactCollector(fakeClient, k8sfake.NewSimpleClientset(), "default", time.Second), scenarioRun
+}
+
+func getArtifacts(t *testing.T, collector *ArtifactCollector, jobID string) *corev1.ConfigMap {
+	t.Helper()
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Name: artifactsConfigMapName(jobID), Namespace: "default"}
+	if err := collector.client.Get(context.Background(), key, &cm); err != nil {
+		t.Fatalf("failed to get artifacts ConfigMap: %v", err)
+	}
+	return &cm
+}
+
+func TestArtifactCollector_EnqueueAndCollect(t *testing.T) {
+	collector, scenarioRun := setupArtifactTest()
+	ctx := context.Background()
+	job := &krknv1alpha1.ClusterJobStatus{
+		ClusterName: "cluster-1",
+		JobID:       "job-1",
+		PodName:     "krkn-job-job-1-abcde",
+		Phase:       statemachine.JobSucceeded,
+	}
+
+	for range 2 {
+		if err := collector.Enqueue(ctx, scenarioRun, job); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+	if len(collector.pending) != 1 {
+		t.Fatalf("expected the job to be queued once, got %v", collector.pending)
+	}
+	cm := getArtifacts(t, collector, "job-1")
+	if cm.Annotations[ArtifactStateAnnotation] != artifactStatePending || cm.Labels[ArtifactJobIDLabel] != "job-1" {
+		t.Errorf("expected a Pending marker for job-1, got %v %v", cm.Annotations, cm.Labels)
+	}
+	if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].Name != scenarioRun.Name {
+		t.Errorf("expected the artifacts to be owned by the run, got %v", cm.OwnerReferences)
+	}
+
+	collector.drain(ctx)
+
+	cm = getArtifacts(t, collector, "job-1")
+	if cm.Annotations[ArtifactStateAnnotation] != artifactStateCollected || cm.Data[ArtifactLogKey] == "" {
+		t.Errorf("expected the log to be collected, got %v %v", cm.Annotations, cm.Data)
+	}
+}
+
+func TestArtifactCollector_ResumesAndFlushesOnShutdown(t *testing.T) {
+	marker := func(jobID, state string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:        artifactsConfigMapName(jobID),
+			Namespace:   "default",
+			Labels:      map[string]string{ArtifactJobIDLabel: jobID},
+			Annotations: map[string]string{ArtifactStateAnnotation: state, ArtifactPodAnnotation: "krkn-job-" + jobID + "-abcde"},
+		}}
+	}
+	collector, _ := setupArtifactTest(
+		marker("interrupted", artifactStatePending),
+		marker("done", artifactStateCollected),
+	)
+
+	// A stopping operator still flushes what it resumed
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := collector.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if cm := getArtifacts(t, collector, "interrupted"); cm.Annotations[ArtifactStateAnnotation] != artifactStateCollected {
+		t.Errorf("expected the interrupted collection to be resumed, got %v", cm.Annotations)
+	}
+	if cm := getArtifacts(t, collector, "done"); cm.Data[ArtifactLogKey] != "" {
+		t.Errorf("expected collected artifacts to be left as is, got %v", cm.Data)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// cancelGracePeriodSeconds is the grace period the scenario pods of a cancelled job get
+// to stop the chaos they injected and clean up
+const cancelGracePeriodSeconds int64 = 30
+
+// cancelledJobMessage is the message of a job cancelled on user request
+const cancelledJobMessage = "Cancelled by user"
+
+// requestRunCancellation propagates spec.cancelRequested to the jobs of the run: every
+// job not finished yet is flagged for cancellation and the clusters without a job get a
+// Cancelled one, so that no new job is created for them.
+func (r *KrknScenarioRunReconciler) requestRunCancellation(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if !scenarioRun.Spec.CancelRequested {
+		return
+	}
+
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if !statemachine.Jobs.IsTerminal(job.Phase) {
+			job.CancelRequested = true
+		}
+	}
+
+	for providerName, clusterNames := range scenarioRun.Targets() {
+		for _, clusterName := range clusterNames {
+			if r.jobExistsForCluster(scenarioRun, substitutedCluster(scenarioRun, clusterName)) {
+				continue
+			}
+			job := krknv1alpha1.ClusterJobStatus{
+				ProviderName:    providerName,
+				ClusterName:     clusterName,
+				ClusterID:       scenarioRun.TargetClusterID(providerName, clusterName),
+				Phase:           statemachine.Jobs.Initial(),
+				CancelRequested: true,
+			}
+			if !r.setJobPhase(ctx, &job, statemachine.JobCancelled) {
+				continue
+			}
+			job.Message = cancelledJobMessage
+			r.setCompletionTime(&job)
+			scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, job)
+		}
+	}
+}
+
+// cancelJob stops a job whose cancellation was requested: its Job is deleted and its pods
+// get cancelGracePeriodSeconds to terminate. The job ends Cancelled and is never retried.
+func (r *KrknScenarioRunReconciler) cancelJob(ctx context.Context, job *krknv1alpha1.ClusterJobStatus) {
+	logger := log.FromContext(ctx)
+
+	// Held and retrying jobs have no Job of their own running
+	if job.JobID != "" && job.Phase != statemachine.JobRetrying {
+		batchJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:      scenarioJobName(job.JobID),
+			Namespace: r.Namespace,
+		}}
+		if err := r.Delete(ctx, batchJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			// Try again on the next reconcile, the job keeps its phase meanwhile
+			logger.Error(err, "failed to delete job of cancelled job",
+				"cluster", job.ClusterName,
+				"jobID", job.JobID,
+				"job", batchJob.Name)
+			return
+		}
+
+		// The garbage collector would delete the pods with their own grace period
+		var podList corev1.PodList
+		if err := r.List(ctx, &podList, client.InNamespace(r.Namespace), client.MatchingLabels{
+			joblabels.JobID: job.JobID,
+		}); err != nil {
+			logger.Error(err, "failed to list pods of cancelled job",
+				"cluster", job.ClusterName,
+				"jobID", job.JobID)
+			return
+		}
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if err := r.Delete(ctx, pod, client.GracePeriodSeconds(cancelGracePeriodSeconds)); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "failed to delete pod of cancelled job",
+					"cluster", job.ClusterName,
+					"jobID", job.JobID,
+					"pod", pod.Name)
+				return
+			}
+		}
+	}
+
+	if !r.setJobPhase(ctx, job, statemachine.JobCancelled) {
+		return
+	}
+	job.Message = cancelledJobMessage
+	r.setCompletionTime(job)
+
+	logger.Info("cancelled job",
+		"cluster", job.ClusterName,
+		"jobID", job.JobID,
+		"gracePeriodSeconds", cancelGracePeriodSeconds)
+}
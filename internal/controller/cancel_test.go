@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func TestUpdateClusterJobStatuses_CancelsRunningJob(t *testing.T) {
+	now := metav1.Now()
+	reconciler, scenarioRun := setupTimeoutTest(
+		activeScenarioJob("job-1", 10*time.Second),
+		newTestJobPod("job-1", "pod-1", corev1.PodRunning, now.Time),
+		krknv1alpha1.ClusterJobStatus{
+			ProviderName:    "krkn-operator",
+			ClusterName:     "cluster1",
+			JobID:           "job-1",
+			PodName:         "pod-1",
+			Phase:           statemachine.JobRunning,
+			StartTime:       &now,
+			CancelRequested: true,
+		})
+	ctx := context.Background()
+
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	job := scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobCancelled || job.CompletionTime == nil || job.RetryCount != 0 {
+		t.Fatalf("Expected job to be cancelled without retry, got %+v", job)
+	}
+	err := reconciler.Get(ctx, client.ObjectKey{Name: "krkn-job-job-1", Namespace: "default"}, &batchv1.Job{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the Job to be deleted, got %v", err)
+	}
+	err = reconciler.Get(ctx, client.ObjectKey{Name: "pod-1", Namespace: "default"}, &corev1.Pod{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the pod to be deleted, got %v", err)
+	}
+}
+
+func TestRequestRunCancellation(t *testing.T) {
+	now := metav1.Now()
+	reconciler, scenarioRun := setupTimeoutTest(
+		activeScenarioJob("job-1", 10*time.Second),
+		newTestJobPod("job-1", "pod-1", corev1.PodRunning, now.Time),
+		krknv1alpha1.ClusterJobStatus{
+			ProviderName: "krkn-operator",
+			ClusterName:  "cluster1",
+			JobID:        "job-1",
+			Phase:        statemachine.JobRunning,
+			StartTime:    &now,
+		})
+	scenarioRun.Spec.CancelRequested = true
+	scenarioRun.Spec.TargetClusters = map[string][]string{"krkn-operator": {"cluster1", "cluster2"}}
+	scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{
+		ProviderName: "krkn-operator",
+		ClusterName:  "cluster0",
+		JobID:        "job-0",
+		Phase:        statemachine.JobSucceeded,
+	})
+	ctx := context.Background()
+
+	reconciler.requestRunCancellation(ctx, scenarioRun)
+
+	jobs := scenarioRun.Status.ClusterJobs
+	if len(jobs) != 3 {
+		t.Fatalf("Expected a job for the cluster not started yet, got %+v", jobs)
+	}
+	if !jobs[0].CancelRequested {
+		t.Error("Expected the running job to be flagged for cancellation")
+	}
+	if jobs[1].CancelRequested {
+		t.Error("Expected the succeeded job to be left alone")
+	}
+	if jobs[2].ClusterName != "cluster2" || jobs[2].Phase != statemachine.JobCancelled {
+		t.Errorf("Expected cluster2 to be cancelled before it started, got %+v", jobs[2])
+	}
+	if !reconciler.jobExistsForCluster(scenarioRun, "cluster2") {
+		t.Error("Expected no job to be created for the cancelled cluster")
+	}
+
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if phase := scenarioRun.Status.ClusterJobs[0].Phase; phase != statemachine.JobCancelled {
+		t.Errorf("Expected the running job to be cancelled, got %s", phase)
+	}
+	reconciler.calculateOverallStatus(scenarioRun)
+	if scenarioRun.Status.RunningJobs != 0 {
+		t.Errorf("Expected no running job left, got %d", scenarioRun.Status.RunningJobs)
+	}
+}
+
+func TestUpdateClusterJobStatuses_CancelsRetryingJob(t *testing.T) {
+	now := metav1.Now()
+	reconciler, scenarioRun := setupTimeoutTest(
+		newTestScenarioJob("job-1", failedJobCondition("BackoffLimitExceeded")),
+		newTestJobPod("job-1", "pod-1", corev1.PodFailed, now.Time),
+		krknv1alpha1.ClusterJobStatus{
+			ProviderName:    "krkn-operator",
+			ClusterName:     "cluster1",
+			JobID:           "job-1",
+			Phase:           statemachine.JobRetrying,
+			RetryCount:      1,
+			CancelRequested: true,
+		})
+
+	if !reconciler.jobExistsForCluster(scenarioRun, "cluster1") {
+		t.Error("Expected no retry job to be created for a cancelled job")
+	}
+	if err := reconciler.updateClusterJobStatuses(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if phase := scenarioRun.Status.ClusterJobs[0].Phase; phase != statemachine.JobCancelled {
+		t.Errorf("Expected the retrying job to be cancelled, got %s", phase)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+)
+
+const (
+	// CAPIProviderName is the provider name the Cluster API clusters are contributed under
+	CAPIProviderName = "krkn-operator-capi"
+	// CAPINamespaceLabel is the namespace of the Cluster a contributed target comes from
+	CAPINamespaceLabel = "krkn.krkn-chaos.dev/capi-namespace"
+
+	// capiProvisionedPhase is the phase of the Clusters whose control plane is reachable
+	capiProvisionedPhase = "Provisioned"
+	// capiKubeconfigSuffix names the kubeconfig Secret of a Cluster, <cluster>-kubeconfig
+	capiKubeconfigSuffix = "-kubeconfig"
+	// capiKubeconfigKey is the key of the kubeconfig in the Secret
+	capiKubeconfigKey = "value"
+)
+
+// CAPIClusterGVK is the Cluster API Cluster kind
+var CAPIClusterGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"}
+
+// CAPITargetSource contributes the provisioned Cluster API Clusters of the management
+// cluster to target requests, with the admin kubeconfig Cluster API keeps in the
+// <cluster>-kubeconfig Secret of each Cluster. Clusters are named after the Cluster, or
+// <namespace>-<cluster> when the name is used in several namespaces, and carry the
+// labels of the Cluster.
+type CAPITargetSource struct {
+	// Reader lists the Clusters and reads their Secrets in all namespaces, outside the
+	// namespace the manager cache is restricted to
+	Reader client.Reader
+}
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list
+
+// ProvidedTargets implements TargetSource
+func (s *CAPITargetSource) ProvidedTargets(ctx context.Context) ([]ProvidedTarget, error) {
+	logger := log.FromContext(ctx).WithName("capi-provider")
+
+	clusters := &unstructured.UnstructuredList{}
+	clusters.SetGroupVersionKind(CAPIClusterGVK.GroupVersion().WithKind(CAPIClusterGVK.Kind + "List"))
+	if err := s.Reader.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list Cluster API Clusters: %w", err)
+	}
+
+	names := map[string]int{}
+	for _, cluster := range clusters.Items {
+		names[cluster.GetName()]++
+	}
+
+	targets := make([]ProvidedTarget, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if cluster.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if phase, _, _ := unstructured.NestedString(cluster.Object, "status", "phase"); phase != capiProvisionedPhase {
+			logger.V(1).Info("Skipping Cluster not provisioned", "cluster", cluster.GetName(),
+				"namespace", cluster.GetNamespace(), "phase", phase)
+			continue
+		}
+
+		kubeconfigBase64, apiURL, err := s.clusterKubeconfig(ctx, cluster)
+		if err != nil {
+			logger.Info("Skipping Cluster without usable kubeconfig", "cluster", cluster.GetName(),
+				"namespace", cluster.GetNamespace(), "reason", err.Error())
+			continue
+		}
+
+		clusterName := cluster.GetName()
+		if names[clusterName] > 1 {
+			clusterName = cluster.GetNamespace() + "-" + clusterName
+		}
+		labels := maps.Clone(cluster.GetLabels())
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[CAPINamespaceLabel] = cluster.GetNamespace()
+
+		targets = append(targets, ProvidedTarget{
+			Target: krknv1alpha1.ClusterTarget{
+				ClusterName:   clusterName,
+				ClusterAPIURL: apiURL,
+				ClusterUID:    string(cluster.GetUID()),
+				Labels:        labels,
+			},
+			KubeconfigBase64: kubeconfigBase64,
+		})
+	}
+
+	slices.SortFunc(targets, func(a, b ProvidedTarget) int {
+		return strings.Compare(a.Target.ClusterName, b.Target.ClusterName)
+	})
+	return targets, nil
+}
+
+// clusterKubeconfig returns the base64 kubeconfig of a Cluster, reduced to its current
+// context, and its normalized API URL
+func (s *CAPITargetSource) clusterKubeconfig(ctx context.Context, cluster *unstructured.Unstructured) (string, string, error) {
+	var secret corev1.Secret
+	key := types.NamespacedName{Name: cluster.GetName() + capiKubeconfigSuffix, Namespace: cluster.GetNamespace()}
+	if err := s.Reader.Get(ctx, key, &secret); err != nil {
+		return "", "", fmt.Errorf("no kubeconfig Secret: %w", err)
+	}
+	raw := secret.Data[capiKubeconfigKey]
+	if len(raw) == 0 {
+		return "", "", fmt.Errorf("kubeconfig Secret %s has no %s", key.Name, capiKubeconfigKey)
+	}
+
+	kubeconfigBase64, err := kubeconfig.ForContext(base64.StdEncoding.EncodeToString(raw), "")
+	if err != nil {
+		return "", "", err
+	}
+	if err := kubeconfig.Validate(kubeconfigBase64); err != nil {
+		return "", "", err
+	}
+	apiURL, err := kubeconfig.ExtractAPIURL(kubeconfigBase64)
+	if err != nil {
+		return "", "", err
+	}
+	apiURL, err = kubeconfig.NormalizeAPIURL(apiURL)
+	if err != nil {
+		return "", "", err
+	}
+	return kubeconfigBase64, apiURL, nil
+}
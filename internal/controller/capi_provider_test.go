@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// capiCluster returns a Cluster API Cluster in phase with its kubeconfig Secret
+func capiCluster(namespace, name, phase, server string) []client.Object {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(CAPIClusterGVK)
+	cluster.SetName(name)
+	cluster.SetNamespace(namespace)
+	cluster.SetLabels(map[string]string{"env": namespace})
+	_ = unstructured.SetNestedField(cluster.Object, phase, "status", "phase")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name + capiKubeconfigSuffix, Namespace: namespace},
+		Data:       map[string][]byte{capiKubeconfigKey: acmAdminKubeconfig(server, "capi-token")},
+	}
+	return []client.Object{cluster, secret}
+}
+
+func TestCAPITargetSource_ProvidedTargets(t *testing.T) {
+	var objs []client.Object
+	objs = append(objs, capiCluster("dev", "workload", capiProvisionedPhase, "https://workload.dev.example.com:6443")...)
+	objs = append(objs, capiCluster("prod", "workload", capiProvisionedPhase, "https://workload.prod.example.com:6443")...)
+	objs = append(objs, capiCluster("prod", "edge", capiProvisionedPhase, "https://edge.prod.example.com:6443")...)
+	objs = append(objs, capiCluster("prod", "new", "Provisioning", "https://new.prod.example.com:6443")...)
+	// Provisioned, but its kubeconfig Secret is not there yet
+	objs = append(objs, capiCluster("prod", "pending", capiProvisionedPhase, "https://pending.prod.example.com:6443")[0])
+
+	reconciler := setupTestReconciler(objs...)
+	source := &CAPITargetSource{Reader: reconciler.Client}
+	targets, err := source.ProvidedTargets(context.Background())
+	if err != nil {
+		t.Fatalf("ProvidedTargets failed: %v", err)
+	}
+
+	names := make([]string, 0, len(targets))
+	for _, target := range targets {
+		names = append(names, target.Target.ClusterName)
+	}
+	want := []string{"dev-workload", "edge", "prod-workload"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("Expected targets %v, got %v", want, names)
+	}
+	edge := targets[1]
+	if edge.Target.ClusterAPIURL != "https://edge.prod.example.com:6443" || edge.KubeconfigBase64 == "" {
+		t.Errorf("Unexpected target %+v", edge.Target)
+	}
+	if edge.Target.Labels["env"] != "prod" || edge.Target.Labels[CAPINamespaceLabel] != "prod" {
+		t.Errorf("Expected the Cluster labels and namespace, got %v", edge.Target.Labels)
+	}
+}
+
+func TestReconcile_ContributesTargetSource(t *testing.T) {
+	request := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              testRequestName,
+			Namespace:         testOperatorNamespace,
+			CreationTimestamp: testNow,
+		},
+		Spec: krknv1alpha1.KrknTargetRequestSpec{UUID: testUUID},
+	}
+	capiProvider := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: CAPIProviderName, Namespace: testOperatorNamespace},
+		Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: CAPIProviderName, Active: true},
+	}
+	objs := append(capiCluster("prod", "edge", capiProvisionedPhase, "https://edge.prod.example.com:6443"), request, capiProvider)
+
+	reconciler := setupTestReconciler(objs...)
+	reconciler.OperatorName = CAPIProviderName
+	reconciler.TargetSource = &CAPITargetSource{Reader: reconciler.Client}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: testRequestName, Namespace: testOperatorNamespace}
+
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var updated krknv1alpha1.KrknTargetRequest
+	if err := reconciler.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("Failed to get request: %v", err)
+	}
+	contributed := updated.Status.TargetData[CAPIProviderName]
+	if len(contributed) != 1 || contributed[0].ClusterName != "edge" {
+		t.Errorf("Expected the Cluster to be contributed, got %+v", updated.Status.TargetData)
+	}
+	if updated.Status.Status != "Completed" {
+		t.Errorf("Expected the request to be completed, got %q", updated.Status.Status)
+	}
+
+	var secret corev1.Secret
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: testUUID, Namespace: testOperatorNamespace}, &secret); err != nil {
+		t.Fatalf("Failed to get managed-clusters Secret: %v", err)
+	}
+	var managedClusters map[string]map[string]map[string]string
+	if err := json.Unmarshal(secret.Data["managed-clusters"], &managedClusters); err != nil {
+		t.Fatalf("Failed to unmarshal managed-clusters: %v", err)
+	}
+	entry := managedClusters[CAPIProviderName]["edge"]
+	if entry["cluster-api"] != "https://edge.prod.example.com:6443" || entry["kubeconfig"] == "" {
+		t.Errorf("Expected the kubeconfig of the Cluster, got %v", managedClusters)
+	}
+}
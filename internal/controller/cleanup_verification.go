@@ -0,0 +1,278 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
+)
+
+// defaultCleanupVerifyTimeout bounds the verification of a cluster
+const defaultCleanupVerifyTimeout = 30 * time.Second
+
+// CleanupFailureReason is the failure reason of the notifications of clusters left dirty
+const CleanupFailureReason = "CleanupFailed"
+
+// cleanupVerifiedScenarios are the krkn-hub node and network scenarios whose cleanup is
+// verified by default
+var cleanupVerifiedScenarios = []string{
+	"node-scenarios",
+	"power-outages",
+	"zone-outages",
+	"network-chaos",
+	"pod-network-chaos",
+	"node-network-filter",
+	"pod-network-filter",
+}
+
+// cleanupVerifiedPhases are the phases of the jobs that ran and will not run again unless
+// a retry is requested
+var cleanupVerifiedPhases = []string{
+	statemachine.JobSucceeded,
+	statemachine.JobCancelled,
+	statemachine.JobMaxRetriesExceeded,
+}
+
+// maxCleanupLogIssues bounds the krkn cleanup failures reported from a scenario log
+const maxCleanupLogIssues = 10
+
+// CleanupVerifier reports what a finished scenario left behind on a target cluster
+type CleanupVerifier interface {
+	// Verify returns the issues left on the cluster of the base64 kubeconfig, none when
+	// it is clean. namespaces must have been removed.
+	Verify(ctx context.Context, kubeconfigBase64 string, namespaces []string) ([]string, error)
+}
+
+// DataProviderCleanupVerifier verifies clusters through the VerifyCleanup RPC of the data
+// provider
+type DataProviderCleanupVerifier struct {
+	// Client is the shared connection to the data provider gRPC server
+	Client *grpcclient.Client
+	// Timeout bounds each verification (defaultCleanupVerifyTimeout when zero)
+	Timeout time.Duration
+}
+
+// Verify implements CleanupVerifier
+func (v *DataProviderCleanupVerifier) Verify(ctx context.Context, kubeconfigBase64 string, namespaces []string) ([]string, error) {
+	conn, err := v.Client.Conn()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := v.Timeout
+	if timeout == 0 {
+		timeout = defaultCleanupVerifyTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := pb.NewDataProviderServiceClient(conn).VerifyCleanup(ctx, &pb.VerifyCleanupRequest{
+		KubeconfigBase64: kubeconfigBase64,
+		Namespaces:       namespaces,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("data provider: %w", err)
+	}
+
+	var issues []string
+	for _, node := range resp.CordonedNodes {
+		issues = append(issues, fmt.Sprintf("node %s is still cordoned", node))
+	}
+	for _, node := range resp.NotReadyNodes {
+		issues = append(issues, fmt.Sprintf("node %s is not ready", node))
+	}
+	for _, namespace := range resp.RemainingNamespaces {
+		issues = append(issues, fmt.Sprintf("namespace %s was not removed", namespace))
+	}
+	return issues, nil
+}
+
+// cleanupVerificationEnabled reports whether the targets of a run are verified once their
+// job finished: when the run says so, by default for node and network scenarios and runs
+// listing test namespaces
+func cleanupVerificationEnabled(scenarioRun *krknv1alpha1.KrknScenarioRun) bool {
+	verification := scenarioRun.Spec.CleanupVerification
+	if verification != nil && verification.Enabled != nil {
+		return *verification.Enabled
+	}
+	if verification != nil && len(verification.Namespaces) > 0 {
+		return true
+	}
+	return slices.Contains(cleanupVerifiedScenarios, scenarioRun.Spec.ScenarioName)
+}
+
+// krknCleanupIssues returns the netem cleanup failures krkn reported in a scenario log:
+// the traffic control rules it could not remove stay on the nodes
+func krknCleanupIssues(logs []byte) []string {
+	var issues []string
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), int(artifactLogMaxBytes))
+	for scanner.Scan() && len(issues) < maxCleanupLogIssues {
+		line := strings.TrimSpace(scanner.Text())
+		lower := strings.ToLower(line)
+		if !strings.Contains(lower, "netem") && !strings.Contains(lower, "qdisc") {
+			continue
+		}
+		if !strings.Contains(lower, "error") && !strings.Contains(lower, "fail") && !strings.Contains(lower, "unable") {
+			continue
+		}
+		if len(line) > 200 {
+			line = line[:200] + "..."
+		}
+		issue := "krkn cleanup: " + line
+		if !slices.Contains(issues, issue) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// collectedScenarioLog returns the scenario log the artifact collector stored for a job,
+// nil without collector or log. pending is true while the log is being collected.
+func (r *KrknScenarioRunReconciler) collectedScenarioLog(ctx context.Context, job *krknv1alpha1.ClusterJobStatus) (logs []byte, pending bool) {
+	if r.Artifacts == nil {
+		return nil, false
+	}
+	var cm corev1.ConfigMap
+	key := types.NamespacedName{Name: artifactsConfigMapName(job.JobID), Namespace: r.Namespace}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "failed to get job artifacts", "jobID", job.JobID)
+		}
+		return nil, false
+	}
+	if cm.Annotations[ArtifactStateAnnotation] == artifactStatePending {
+		return nil, true
+	}
+	return []byte(cm.Data[ArtifactLogKey]), false
+}
+
+// verifyJobCleanup verifies once that the target of every finished job returned to a
+// clean state, again after a retry, when the run has cleanup verification. Clusters left
+// dirty or that could not be verified are reported with a Warning event and a notification
+// to the observers of the run, so they get remediated by hand. Jobs whose log is being
+// collected are verified once it is: collection updates the artifacts ConfigMap, which
+// triggers a reconcile of the run.
+func (r *KrknScenarioRunReconciler) verifyJobCleanup(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if r.CleanupVerifier == nil || !cleanupVerificationEnabled(scenarioRun) {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	var namespaces []string
+	if scenarioRun.Spec.CleanupVerification != nil {
+		namespaces = scenarioRun.Spec.CleanupVerification.Namespaces
+	}
+
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if job.Cleanup != nil || job.JobID == "" || !slices.Contains(cleanupVerifiedPhases, job.Phase) {
+			continue
+		}
+		logs, pending := r.collectedScenarioLog(ctx, job)
+		if pending {
+			continue
+		}
+
+		result := &krknv1alpha1.CleanupResult{CheckedAt: metav1.Now()}
+		kubeconfig, err := r.getKubeconfigFromProvider(ctx, scenarioRun.Spec.TargetRequestID, job.ProviderName, job.ClusterName)
+		if err == nil {
+			result.Issues, err = r.CleanupVerifier.Verify(ctx, kubeconfig, namespaces)
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.Issues = append(result.Issues, krknCleanupIssues(logs)...)
+		result.Clean = result.Error == "" && len(result.Issues) == 0
+		job.Cleanup = result
+
+		if result.Clean {
+			logger.Info("cluster verified clean",
+				"scenarioRun", scenarioRun.Name,
+				"cluster", job.ClusterName,
+				"jobID", job.JobID)
+			continue
+		}
+		logger.Info("cluster not cleaned up, manual remediation needed",
+			"scenarioRun", scenarioRun.Name,
+			"cluster", job.ClusterName,
+			"jobID", job.JobID,
+			"issues", result.Issues,
+			"error", result.Error)
+		r.alertCleanupFailure(ctx, scenarioRun, job)
+	}
+}
+
+// cleanupFailureMessage describes why the cluster of a job is not clean
+func cleanupFailureMessage(job *krknv1alpha1.ClusterJobStatus) string {
+	if job.Cleanup.Error != "" {
+		return fmt.Sprintf("cluster %s could not be verified after job %s: %s", job.ClusterName, job.JobID, job.Cleanup.Error)
+	}
+	return fmt.Sprintf("cluster %s was not cleaned up after job %s: %s", job.ClusterName, job.JobID, strings.Join(job.Cleanup.Issues, "; "))
+}
+
+// alertCleanupFailure reports a cluster left dirty with a Warning event and a notification
+// to the observers of the run. Delivery is best-effort and in the background.
+func (r *KrknScenarioRunReconciler) alertCleanupFailure(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun, job *krknv1alpha1.ClusterJobStatus) {
+	message := cleanupFailureMessage(job)
+	recordEvent(r.Recorder, scenarioRun, corev1.EventTypeWarning, EventCleanupFailed, "%s", message)
+
+	if r.Notifications == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+	subs, err := notify.Subscriptions(scenarioRun)
+	if err != nil {
+		logger.Error(err, "failed to read run observers", "scenarioRun", scenarioRun.Name)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+	notification := notify.Notification{
+		ScenarioRun:   scenarioRun.Name,
+		ScenarioName:  scenarioRun.Spec.ScenarioName,
+		RunNumber:     scenarioRun.Spec.RunNumber,
+		Title:         scenarioRun.Title(),
+		Namespace:     scenarioRun.Namespace,
+		PreviousPhase: job.Phase,
+		Phase:         job.Phase,
+		Timestamp:     time.Now().UTC(),
+		ClusterName:   job.ClusterName,
+		JobID:         job.JobID,
+		FailureReason: CleanupFailureReason,
+		Message:       message,
+	}
+	r.sendNotification(ctx, scenarioRun, subs, notification)
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
+)
+
+// fakeCleanupVerifier reports the issues listed for the cluster of each kubeconfig
+type fakeCleanupVerifier struct {
+	issues   map[string][]string
+	err      error
+	verified []string
+}
+
+func (v *fakeCleanupVerifier) Verify(_ context.Context, kubeconfigBase64 string, _ []string) ([]string, error) {
+	kubeconfig, _ := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	v.verified = append(v.verified, string(kubeconfig))
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v.issues[string(kubeconfig)], nil
+}
+
+func setupCleanupTest(verifier *fakeCleanupVerifier, objects ...client.Object) (*KrknScenarioRunReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	// Each kubeconfig holds the name of its cluster
+	managedClusters := `{"krkn-operator":{` +
+		`"cluster1":{"kubeconfig":"` + base64.StdEncoding.EncodeToString([]byte("cluster1")) + `"},` +
+		`"cluster2":{"kubeconfig":"` + base64.StdEncoding.EncodeToString([]byte("cluster2")) + `"}}}`
+	objects = append(objects, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "request-1", Namespace: "default"},
+		Data:       map[string][]byte{"managed-clusters": []byte(managedClusters)},
+	})
+
+	recorder := record.NewFakeRecorder(10)
+	return &KrknScenarioRunReconciler{
+		Client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme:          scheme,
+		Namespace:       "default",
+		Recorder:        recorder,
+		CleanupVerifier: verifier,
+	}, recorder
+}
+
+func cleanupTestRun(scenarioName string, jobs ...krknv1alpha1.ClusterJobStatus) *krknv1alpha1.KrknScenarioRun {
+	return &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "request-1",
+			ScenarioName:    scenarioName,
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{ClusterJobs: jobs},
+	}
+}
+
+func TestCleanupVerificationEnabled(t *testing.T) {
+	tests := []struct {
+		name         string
+		scenarioName string
+		verification *krknv1alpha1.CleanupVerification
+		want         bool
+	}{
+		{name: "node scenario", scenarioName: "node-scenarios", want: true},
+		{name: "network scenario", scenarioName: "pod-network-chaos", want: true},
+		{name: "pod scenario", scenarioName: "pod-scenarios", want: false},
+		{
+			name:         "test namespaces",
+			scenarioName: "pod-scenarios",
+			verification: &krknv1alpha1.CleanupVerification{Namespaces: []string{"krkn-test"}},
+			want:         true,
+		},
+		{
+			name:         "disabled",
+			scenarioName: "node-scenarios",
+			verification: &krknv1alpha1.CleanupVerification{Enabled: ptr.To(false), Namespaces: []string{"krkn-test"}},
+			want:         false,
+		},
+		{
+			name:         "enabled",
+			scenarioName: "pod-scenarios",
+			verification: &krknv1alpha1.CleanupVerification{Enabled: ptr.To(true)},
+			want:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioRun := cleanupTestRun(tt.scenarioName)
+			scenarioRun.Spec.CleanupVerification = tt.verification
+			if got := cleanupVerificationEnabled(scenarioRun); got != tt.want {
+				t.Errorf("cleanupVerificationEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKrknCleanupIssues(t *testing.T) {
+	logs := []byte(strings.Join([]string{
+		"2025-01-01 10:00:00,000 [INFO] Applying netem rules on worker-1",
+		"2025-01-01 10:05:00,000 [ERROR] Failed to delete qdisc on worker-1: RTNETLINK answers: No such device",
+		"2025-01-01 10:05:00,000 [ERROR] Failed to delete qdisc on worker-1: RTNETLINK answers: No such device",
+		"2025-01-01 10:05:01,000 [ERROR] Pod nginx failed to recover",
+	}, "\n"))
+
+	issues := krknCleanupIssues(logs)
+	want := []string{"krkn cleanup: 2025-01-01 10:05:00,000 [ERROR] Failed to delete qdisc on worker-1: RTNETLINK answers: No such device"}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("krknCleanupIssues() = %v, want %v", issues, want)
+	}
+	if issues := krknCleanupIssues(nil); len(issues) != 0 {
+		t.Errorf("Expected no issue without log, got %v", issues)
+	}
+}
+
+func TestVerifyJobCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("clean and dirty clusters", func(t *testing.T) {
+		verifier := &fakeCleanupVerifier{issues: map[string][]string{"cluster2": {"node worker-1 is still cordoned"}}}
+		reconciler, recorder := setupCleanupTest(verifier)
+		scenarioRun := cleanupTestRun("node-scenarios",
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster1", JobID: "job-1", Phase: statemachine.JobSucceeded},
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster2", JobID: "job-2", Phase: statemachine.JobMaxRetriesExceeded},
+		)
+
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+
+		clean := scenarioRun.Status.ClusterJobs[0].Cleanup
+		if clean == nil || !clean.Clean || len(clean.Issues) != 0 {
+			t.Errorf("Expected cluster1 clean, got %+v", clean)
+		}
+		dirty := scenarioRun.Status.ClusterJobs[1].Cleanup
+		if dirty == nil || dirty.Clean || !reflect.DeepEqual(dirty.Issues, []string{"node worker-1 is still cordoned"}) {
+			t.Errorf("Expected cluster2 cordoned, got %+v", dirty)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, EventCleanupFailed) || !strings.Contains(event, "cluster2") {
+				t.Errorf("Unexpected event %q", event)
+			}
+		default:
+			t.Error("Expected a CleanupFailed event")
+		}
+
+		// Clusters are verified once
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+		if len(verifier.verified) != 2 {
+			t.Errorf("Expected 2 verifications, got %v", verifier.verified)
+		}
+	})
+
+	t.Run("unfinished and skipped jobs", func(t *testing.T) {
+		verifier := &fakeCleanupVerifier{}
+		reconciler, _ := setupCleanupTest(verifier)
+		scenarioRun := cleanupTestRun("node-scenarios",
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster1", JobID: "job-1", Phase: statemachine.JobFailed},
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster2", JobID: "job-2", Phase: statemachine.JobSkippedMaintenance},
+		)
+
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+		if len(verifier.verified) != 0 {
+			t.Errorf("Expected no verification, got %v", verifier.verified)
+		}
+	})
+
+	t.Run("verification error", func(t *testing.T) {
+		verifier := &fakeCleanupVerifier{err: errors.New("data provider unavailable")}
+		reconciler, _ := setupCleanupTest(verifier)
+		scenarioRun := cleanupTestRun("network-chaos",
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster1", JobID: "job-1", Phase: statemachine.JobCancelled},
+		)
+
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+		result := scenarioRun.Status.ClusterJobs[0].Cleanup
+		if result == nil || result.Clean || result.Error != "data provider unavailable" {
+			t.Errorf("Expected the error to be recorded, got %+v", result)
+		}
+	})
+
+	t.Run("waits for the collected log", func(t *testing.T) {
+		artifacts := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        artifactsConfigMapName("job-1"),
+				Namespace:   "default",
+				Annotations: map[string]string{ArtifactStateAnnotation: artifactStatePending},
+			},
+		}
+		verifier := &fakeCleanupVerifier{}
+		reconciler, _ := setupCleanupTest(verifier, artifacts)
+		reconciler.Artifacts = &ArtifactCollector{}
+		scenarioRun := cleanupTestRun("network-chaos",
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster1", JobID: "job-1", Phase: statemachine.JobSucceeded},
+		)
+
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+		if scenarioRun.Status.ClusterJobs[0].Cleanup != nil {
+			t.Fatal("Expected the verification to wait for the log")
+		}
+
+		artifacts.Annotations[ArtifactStateAnnotation] = artifactStateCollected
+		artifacts.Data = map[string]string{ArtifactLogKey: "[ERROR] Unable to remove netem rules from eth0"}
+		if err := reconciler.Update(ctx, artifacts); err != nil {
+			t.Fatalf("Failed to update artifacts: %v", err)
+		}
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+		result := scenarioRun.Status.ClusterJobs[0].Cleanup
+		if result == nil || result.Clean || len(result.Issues) != 1 {
+			t.Errorf("Expected the netem cleanup failure, got %+v", result)
+		}
+	})
+
+	t.Run("not applicable", func(t *testing.T) {
+		verifier := &fakeCleanupVerifier{}
+		reconciler, _ := setupCleanupTest(verifier)
+		scenarioRun := cleanupTestRun("pod-scenarios",
+			krknv1alpha1.ClusterJobStatus{ProviderName: "krkn-operator", ClusterName: "cluster1", JobID: "job-1", Phase: statemachine.JobSucceeded},
+		)
+
+		reconciler.verifyJobCleanup(ctx, scenarioRun)
+		if scenarioRun.Status.ClusterJobs[0].Cleanup != nil || len(verifier.verified) != 0 {
+			t.Error("Expected pod scenarios not to be verified")
+		}
+	})
+}
+
+// cleanupServer answers VerifyCleanup with a fixed response
+type cleanupServer struct {
+	pb.UnimplementedDataProviderServiceServer
+	response *pb.VerifyCleanupResponse
+	request  *pb.VerifyCleanupRequest
+}
+
+func (s *cleanupServer) VerifyCleanup(_ context.Context, req *pb.VerifyCleanupRequest) (*pb.VerifyCleanupResponse, error) {
+	s.request = req
+	return s.response, nil
+}
+
+func TestDataProviderCleanupVerifier(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	provider := &cleanupServer{response: &pb.VerifyCleanupResponse{
+		CordonedNodes:       []string{"worker-1"},
+		NotReadyNodes:       []string{"worker-2"},
+		RemainingNamespaces: []string{"krkn-test"},
+	}}
+	pb.RegisterDataProviderServiceServer(server, provider)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	verifier := &DataProviderCleanupVerifier{Client: grpcclient.NewClient(listener.Addr().String(), nil)}
+	defer func() { _ = verifier.Client.Close() }()
+	issues, err := verifier.Verify(context.Background(), "a3ViZWNvbmZpZw==", []string{"krkn-test"})
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	want := []string{
+		"node worker-1 is still cordoned",
+		"node worker-2 is not ready",
+		"namespace krkn-test was not removed",
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("Verify() = %v, want %v", issues, want)
+	}
+	if provider.request.KubeconfigBase64 != "a3ViZWNvbmZpZw==" || !reflect.DeepEqual(provider.request.Namespaces, []string{"krkn-test"}) {
+		t.Errorf("Unexpected request %v", provider.request)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// setupClusterJobTest returns a reconciler whose target request request-1 has the
+// cluster primary, and a run of pod-scenarios on it stored with objects
+func setupClusterJobTest(objects ...client.Object) (*KrknScenarioRunReconciler, *krknv1alpha1.KrknScenarioRun) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	managedClusters := `{"krkn-operator":{"primary":{"kubeconfig":"` +
+		base64.StdEncoding.EncodeToString([]byte("primary")) + `"}}}`
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "request-1", Namespace: "default"},
+		Data:       map[string][]byte{"managed-clusters": []byte(managedClusters)},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default", UID: "run-uid"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "request-1",
+			TargetClusters:  map[string][]string{"krkn-operator": {"primary"}},
+			ScenarioImage:   "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objects, secret, scenarioRun)...).
+		WithStatusSubresource(&krknv1alpha1.KrknScenarioRun{}).
+		Build()
+	return &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}, scenarioRun
+}
+
+func TestCreateClusterJob_SecretEnvironment(t *testing.T) {
+	ctx := context.Background()
+	reconciler, scenarioRun := setupClusterJobTest()
+	fakeClient := reconciler.Client
+	scenarioRun.Spec.Environment = map[string]string{"NAMESPACE": "default", "API_TOKEN": "s3cr3t"}
+	scenarioRun.Spec.SecretEnvironment = []string{"API_TOKEN", "UNSET"}
+
+	if err := reconciler.createClusterJob(ctx, scenarioRun, "krkn-operator", "primary"); err != nil {
+		t.Fatalf("createClusterJob failed: %v", err)
+	}
+	jobID := scenarioRun.Status.ClusterJobs[0].JobID
+
+	var batchJob batchv1.Job
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: scenarioJobName(jobID), Namespace: "default"}, &batchJob); err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	env := map[string]corev1.EnvVar{}
+	for _, envVar := range batchJob.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar
+	}
+	if env["NAMESPACE"].Value != "default" {
+		t.Errorf("Expected NAMESPACE as a value, got %+v", env["NAMESPACE"])
+	}
+	token := env["API_TOKEN"]
+	if token.Value != "" || token.ValueFrom == nil || token.ValueFrom.SecretKeyRef == nil {
+		t.Fatalf("Expected API_TOKEN from a Secret, got %+v", token)
+	}
+	if _, ok := env["UNSET"]; ok {
+		t.Error("Expected secret variables missing from the environment to be left out")
+	}
+
+	var envSecret corev1.Secret
+	ref := token.ValueFrom.SecretKeyRef
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: "default"}, &envSecret); err != nil {
+		t.Fatalf("Failed to get environment Secret: %v", err)
+	}
+	if string(envSecret.Data[ref.Key]) != "s3cr3t" || len(envSecret.Data) != 1 {
+		t.Errorf("Unexpected environment Secret data %v", envSecret.Data)
+	}
+	if !metav1.IsControlledBy(&envSecret, scenarioRun) {
+		t.Error("Expected the environment Secret to be owned by the run")
+	}
+	if scenarioRun.Status.ClusterJobs[0].EffectiveSpec.Environment["API_TOKEN"] == "s3cr3t" {
+		t.Error("Expected the effective spec not to show the secret value")
+	}
+}
+
+func TestCreateClusterJob_SecretEnvironmentRef(t *testing.T) {
+	ctx := context.Background()
+	runSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario-env", Namespace: "default"},
+		Data:       map[string][]byte{"API_TOKEN": []byte("s3cr3t")},
+	}
+	reconciler, scenarioRun := setupClusterJobTest(runSecret)
+	scenarioRun.Spec.Environment = map[string]string{"NAMESPACE": "default"}
+	scenarioRun.Spec.SecretEnvironment = []string{"API_TOKEN"}
+	scenarioRun.Spec.SecretEnvironmentRef = "test-scenario-env"
+
+	if err := reconciler.createClusterJob(ctx, scenarioRun, "krkn-operator", "primary"); err != nil {
+		t.Fatalf("createClusterJob failed: %v", err)
+	}
+	jobID := scenarioRun.Status.ClusterJobs[0].JobID
+
+	var batchJob batchv1.Job
+	if err := reconciler.Get(ctx, client.ObjectKey{Name: scenarioJobName(jobID), Namespace: "default"}, &batchJob); err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	var token *corev1.EnvVar
+	for i, envVar := range batchJob.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == "API_TOKEN" {
+			token = &batchJob.Spec.Template.Spec.Containers[0].Env[i]
+		}
+	}
+	if token == nil || token.ValueFrom == nil || token.ValueFrom.SecretKeyRef == nil ||
+		token.ValueFrom.SecretKeyRef.Name != "test-scenario-env" || token.ValueFrom.SecretKeyRef.Key != "API_TOKEN" {
+		t.Fatalf("Expected API_TOKEN from the Secret of the run, got %+v", token)
+	}
+	if err := reconciler.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("krkn-job-%s-env", jobID), Namespace: "default"},
+		&corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected no Secret of the job, got %v", err)
+	}
+
+	// A variable missing from the Secret fails the job creation
+	scenarioRun.Spec.SecretEnvironment = []string{"API_TOKEN", "MISSING"}
+	if err := reconciler.createClusterJob(ctx, scenarioRun, "krkn-operator", "primary"); err == nil {
+		t.Error("Expected an error for a variable missing from the Secret of the run")
+	}
+}
+
+func TestCreateClusterJob_RegistryRef(t *testing.T) {
+	ctx := context.Background()
+	reconciler, scenarioRun := setupClusterJobTest(
+		&krknv1alpha1.KrknRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "default"},
+			Spec: krknv1alpha1.KrknRegistrySpec{
+				RegistryURL:        "registry.example.com",
+				ScenarioRepository: "chaos/krkn-hub",
+				CredentialsSecret:  "internal-credentials",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal-credentials", Namespace: "default"},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("robot"),
+				corev1.BasicAuthPasswordKey: []byte("hunter2"),
+			},
+		})
+	scenarioRun.Spec.RegistryRef = "internal"
+
+	if err := reconciler.createClusterJob(ctx, scenarioRun, "krkn-operator", "primary"); err != nil {
+		t.Fatalf("createClusterJob failed: %v", err)
+	}
+	jobID := scenarioRun.Status.ClusterJobs[0].JobID
+
+	var pullSecret corev1.Secret
+	key := client.ObjectKey{Name: "krkn-job-" + jobID + "-registry", Namespace: "default"}
+	if err := reconciler.Get(ctx, key, &pullSecret); err != nil {
+		t.Fatalf("Failed to get image pull secret: %v", err)
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte("robot:hunter2"))
+	expected := `{"auths":{"registry.example.com":{"auth":"` + auth + `"}}}`
+	if got := string(pullSecret.Data[corev1.DockerConfigJsonKey]); got != expected {
+		t.Errorf("Expected docker config %s, got %s", expected, got)
+	}
+
+	// Runs of an unknown registry fail to create their job
+	scenarioRun.Spec.RegistryRef = "missing"
+	scenarioRun.Status.ClusterJobs = nil
+	if err := reconciler.createClusterJob(ctx, scenarioRun, "krkn-operator", "primary"); err == nil {
+		t.Error("Expected an error for an unknown registry")
+	}
+}
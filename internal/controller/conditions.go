@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// Condition types of a scenario run. Ready is True once every cluster job succeeded, so
+// that `kubectl wait --for=condition=Ready` waits for a successful run and
+// `--for=condition=Completed` for any finished run.
+const (
+	ScenarioRunReady       = "Ready"
+	ScenarioRunProgressing = "Progressing"
+	ScenarioRunDegraded    = "Degraded"
+	ScenarioRunCompleted   = "Completed"
+)
+
+// Condition reasons of a scenario run
+const (
+	ReasonWaitingForJobs   = "WaitingForJobs"
+	ReasonJobsRunning      = "JobsRunning"
+	ReasonRetryScheduled   = "RetryScheduled"
+	ReasonRunFinished      = "RunFinished"
+	ReasonRunInProgress    = "RunInProgress"
+	ReasonRunSucceeded     = "RunSucceeded"
+	ReasonRunFailed        = "RunFailed"
+	ReasonRunPartialFailed = "RunPartiallyFailed"
+	ReasonRunCancelled     = "RunCancelled"
+	ReasonJobsFailed       = "JobsFailed"
+	ReasonNoJobsFailed     = "NoJobsFailed"
+)
+
+// setRunConditions derives the conditions of a run from its phase and job counters. It
+// runs after calculateOverallStatus. SetStatusCondition only moves the transition time
+// when the status of a condition changes.
+func setRunConditions(scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	status := &scenarioRun.Status
+	finished := runFinished(scenarioRun)
+	generation := scenarioRun.Generation
+
+	set := func(conditionType string, ok bool, reason, message string) {
+		conditionStatus := metav1.ConditionFalse
+		if ok {
+			conditionStatus = metav1.ConditionTrue
+		}
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	jobs := len(status.ClusterJobs)
+	progress := fmt.Sprintf("%d of %d cluster jobs succeeded, %d failed, %d running",
+		status.SuccessfulJobs, jobs, status.FailedJobs, status.RunningJobs)
+
+	// Progressing
+	switch {
+	case finished:
+		set(ScenarioRunProgressing, false, ReasonRunFinished, progress)
+	case jobs == 0:
+		set(ScenarioRunProgressing, true, ReasonWaitingForJobs, "No cluster job created yet")
+	case status.Phase != "Running":
+		// Completed, but a retry wave of the failed clusters has not started yet
+		set(ScenarioRunProgressing, true, ReasonRetryScheduled, progress)
+	default:
+		set(ScenarioRunProgressing, true, ReasonJobsRunning, progress)
+	}
+
+	// Completed
+	completedReason := ReasonRunInProgress
+	if finished {
+		switch {
+		case scenarioRun.Spec.CancelRequested:
+			completedReason = ReasonRunCancelled
+		case status.Phase == "Succeeded":
+			completedReason = ReasonRunSucceeded
+		case status.Phase == "PartiallyFailed":
+			completedReason = ReasonRunPartialFailed
+		default:
+			completedReason = ReasonRunFailed
+		}
+	}
+	set(ScenarioRunCompleted, finished, completedReason, progress)
+
+	// Degraded
+	if status.FailedJobs > 0 {
+		set(ScenarioRunDegraded, true, ReasonJobsFailed,
+			fmt.Sprintf("%d of %d cluster jobs failed", status.FailedJobs, jobs))
+	} else {
+		set(ScenarioRunDegraded, false, ReasonNoJobsFailed, "No cluster job failed")
+	}
+
+	// Ready
+	if finished && status.Phase == "Succeeded" {
+		set(ScenarioRunReady, true, ReasonRunSucceeded, progress)
+	} else {
+		set(ScenarioRunReady, false, completedReason, progress)
+	}
+}
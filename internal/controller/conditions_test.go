@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestSetRunConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		phases          []string
+		cancelled       bool
+		waves           []krknv1alpha1.RetryWave
+		wantReady       metav1.ConditionStatus
+		wantProgressing string // reason, the condition is True unless RunFinished
+		wantDegraded    metav1.ConditionStatus
+		wantCompleted   string // reason, the condition is True unless RunInProgress
+	}{
+		{name: "no jobs", wantReady: metav1.ConditionFalse, wantProgressing: ReasonWaitingForJobs,
+			wantDegraded: metav1.ConditionFalse, wantCompleted: ReasonRunInProgress},
+		{name: "running", phases: []string{"Succeeded", "Running"}, wantReady: metav1.ConditionFalse,
+			wantProgressing: ReasonJobsRunning, wantDegraded: metav1.ConditionFalse, wantCompleted: ReasonRunInProgress},
+		{name: "succeeded", phases: []string{"Succeeded", "Succeeded"}, wantReady: metav1.ConditionTrue,
+			wantProgressing: ReasonRunFinished, wantDegraded: metav1.ConditionFalse, wantCompleted: ReasonRunSucceeded},
+		{name: "partially failed", phases: []string{"Succeeded", "MaxRetriesExceeded"}, wantReady: metav1.ConditionFalse,
+			wantProgressing: ReasonRunFinished, wantDegraded: metav1.ConditionTrue, wantCompleted: ReasonRunPartialFailed},
+		{name: "cancelled", phases: []string{"Succeeded", "Cancelled"}, cancelled: true, wantReady: metav1.ConditionFalse,
+			wantProgressing: ReasonRunFinished, wantDegraded: metav1.ConditionTrue, wantCompleted: ReasonRunCancelled},
+		{name: "retry scheduled", phases: []string{"Failed"}, waves: []krknv1alpha1.RetryWave{{Number: 1}},
+			wantReady: metav1.ConditionFalse, wantProgressing: ReasonRetryScheduled, wantDegraded: metav1.ConditionTrue,
+			wantCompleted: ReasonRunInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioRun := &krknv1alpha1.KrknScenarioRun{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       krknv1alpha1.KrknScenarioRunSpec{CancelRequested: tt.cancelled},
+			}
+			for _, phase := range tt.phases {
+				scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{Phase: phase})
+			}
+			scenarioRun.Status.RetryWaves = tt.waves
+			(&KrknScenarioRunReconciler{}).calculateOverallStatus(scenarioRun)
+
+			setRunConditions(scenarioRun)
+
+			conditions := scenarioRun.Status.Conditions
+			if len(conditions) != 4 {
+				t.Fatalf("expected 4 conditions, got %d", len(conditions))
+			}
+			for _, condition := range conditions {
+				if condition.ObservedGeneration != 3 || condition.LastTransitionTime.IsZero() {
+					t.Errorf("condition %s: expected the generation and transition time to be set, got %+v", condition.Type, condition)
+				}
+			}
+			if got := apimeta.FindStatusCondition(conditions, ScenarioRunReady).Status; got != tt.wantReady {
+				t.Errorf("expected Ready %s, got %s", tt.wantReady, got)
+			}
+			progressing := apimeta.FindStatusCondition(conditions, ScenarioRunProgressing)
+			if progressing.Reason != tt.wantProgressing || (progressing.Status == metav1.ConditionTrue) == (tt.wantProgressing == ReasonRunFinished) {
+				t.Errorf("unexpected Progressing condition: %s %s", progressing.Status, progressing.Reason)
+			}
+			if got := apimeta.FindStatusCondition(conditions, ScenarioRunDegraded).Status; got != tt.wantDegraded {
+				t.Errorf("expected Degraded %s, got %s", tt.wantDegraded, got)
+			}
+			completed := apimeta.FindStatusCondition(conditions, ScenarioRunCompleted)
+			if completed.Reason != tt.wantCompleted || (completed.Status == metav1.ConditionTrue) == (tt.wantCompleted == ReasonRunInProgress) {
+				t.Errorf("unexpected Completed condition: %s %s", completed.Status, completed.Reason)
+			}
+		})
+	}
+}
+
+func TestSetRunConditions_KeepsTransitionTime(t *testing.T) {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{}
+	scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{{Phase: "Running"}}
+	(&KrknScenarioRunReconciler{}).calculateOverallStatus(scenarioRun)
+	setRunConditions(scenarioRun)
+
+	past := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	for i := range scenarioRun.Status.Conditions {
+		scenarioRun.Status.Conditions[i].LastTransitionTime = past
+	}
+
+	// Progress without a status change keeps the transition time
+	scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{Phase: "Pending"})
+	(&KrknScenarioRunReconciler{}).calculateOverallStatus(scenarioRun)
+	setRunConditions(scenarioRun)
+	if got := apimeta.FindStatusCondition(scenarioRun.Status.Conditions, ScenarioRunProgressing).LastTransitionTime; !got.Equal(&past) {
+		t.Errorf("expected the Progressing transition time to be kept, got %v", got)
+	}
+
+	// Finishing flips Progressing and Completed
+	scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{{Phase: "Succeeded"}}
+	(&KrknScenarioRunReconciler{}).calculateOverallStatus(scenarioRun)
+	setRunConditions(scenarioRun)
+	for _, conditionType := range []string{ScenarioRunProgressing, ScenarioRunCompleted, ScenarioRunReady} {
+		if got := apimeta.FindStatusCondition(scenarioRun.Status.Conditions, conditionType).LastTransitionTime; got.Equal(&past) {
+			t.Errorf("expected the %s transition time to move", conditionType)
+		}
+	}
+	if got := apimeta.FindStatusCondition(scenarioRun.Status.Conditions, ScenarioRunDegraded).LastTransitionTime; !got.Equal(&past) {
+		t.Errorf("expected the Degraded transition time to be kept, got %v", got)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// captureEffectiveSpec records what the scenario Job runs. The environment is read back
+// from the container rather than from the run spec, so it is exactly what the pod gets.
+func captureEffectiveSpec(batchJob *batchv1.Job, files []krknv1alpha1.FileMount, kubeconfigPath string) *krknv1alpha1.EffectiveSpec {
+	podSpec := batchJob.Spec.Template.Spec
+	spec := &krknv1alpha1.EffectiveSpec{
+		Pod: krknv1alpha1.EffectivePodSummary{
+			ServiceAccountName:    podSpec.ServiceAccountName,
+			KubeconfigPath:        kubeconfigPath,
+			ActiveDeadlineSeconds: batchJob.Spec.ActiveDeadlineSeconds,
+		},
+		CapturedAt: metav1.Now(),
+	}
+	if podSpec.SecurityContext != nil {
+		spec.Pod.RunAsUser = podSpec.SecurityContext.RunAsUser
+	}
+	for _, secret := range podSpec.ImagePullSecrets {
+		spec.Pod.ImagePullSecrets = append(spec.Pod.ImagePullSecrets, secret.Name)
+	}
+	for _, file := range files {
+		spec.Files = append(spec.Files, krknv1alpha1.EffectiveFile{Name: file.Name, MountPath: file.MountPath})
+	}
+
+	if len(podSpec.Containers) == 0 {
+		return spec
+	}
+	container := podSpec.Containers[0]
+	spec.Image = container.Image
+	spec.Pod.ImagePullPolicy = string(container.ImagePullPolicy)
+	if len(container.Env) > 0 {
+		spec.Environment = make(map[string]string, len(container.Env))
+	}
+	for _, env := range container.Env {
+		// A Secret reference holds no credential, it is shown as is
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			ref := env.ValueFrom.SecretKeyRef
+			spec.Environment[env.Name] = "<secret " + ref.Name + "/" + ref.Key + ">"
+			continue
+		}
+		if krknv1alpha1.IsSensitiveEnvName(env.Name) {
+			spec.Environment[env.Name] = krknv1alpha1.RedactedEnvValue
+			continue
+		}
+		spec.Environment[env.Name] = env.Value
+	}
+	return spec
+}
+
+// recordImageID completes the effective spec with the image digest the runtime pulled,
+// known once the scenario container was created
+func recordImageID(job *krknv1alpha1.ClusterJobStatus, pod *corev1.Pod) {
+	if job.EffectiveSpec == nil || job.EffectiveSpec.ImageID != "" {
+		return
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == "scenario" && status.ImageID != "" {
+			job.EffectiveSpec.ImageID = status.ImageID
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestCaptureEffectiveSpec(t *testing.T) {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		Spec: krknv1alpha1.KrknScenarioRunSpec{TimeoutSeconds: ptr.To[int64](300)},
+	}
+	batchJob := newScenarioJob(scenarioRun, "krkn-job-job-1", "default", nil, corev1.PodSpec{
+		ServiceAccountName: "krkn-operator-krkn-scenario-runner",
+		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "krkn-job-job-1-registry"}},
+		SecurityContext:    &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1001)},
+		Containers: []corev1.Container{{
+			Name:            "scenario",
+			Image:           "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+			ImagePullPolicy: corev1.PullAlways,
+			Env: []corev1.EnvVar{
+				{Name: "NAMESPACE", Value: "openshift-etcd"},
+				{Name: "ES_PASSWORD", Value: "hunter2"},
+				secretEnvVar("ES_USERNAME", "es-credentials", corev1.BasicAuthUsernameKey),
+			},
+		}},
+	})
+	files := []krknv1alpha1.FileMount{{Name: "scenario.yaml", Content: "Y29udGVudA==", MountPath: "/root/scenario.yaml"}}
+
+	spec := captureEffectiveSpec(batchJob, files, "/home/krkn/.kube/config")
+
+	if spec.Image != "quay.io/krkn-chaos/krkn-hub:pod-scenarios" {
+		t.Errorf("Expected the scenario image, got %q", spec.Image)
+	}
+	if spec.Environment["NAMESPACE"] != "openshift-etcd" {
+		t.Errorf("Expected NAMESPACE to be kept, got %q", spec.Environment["NAMESPACE"])
+	}
+	if spec.Environment["ES_PASSWORD"] != krknv1alpha1.RedactedEnvValue {
+		t.Errorf("Expected ES_PASSWORD to be redacted, got %q", spec.Environment["ES_PASSWORD"])
+	}
+	if spec.Environment["ES_USERNAME"] != "<secret es-credentials/username>" {
+		t.Errorf("Expected the Secret reference of ES_USERNAME, got %q", spec.Environment["ES_USERNAME"])
+	}
+	if len(spec.Files) != 1 || spec.Files[0] != (krknv1alpha1.EffectiveFile{Name: "scenario.yaml", MountPath: "/root/scenario.yaml"}) {
+		t.Errorf("Expected the file without its content, got %+v", spec.Files)
+	}
+	pod := spec.Pod
+	if pod.ServiceAccountName != "krkn-operator-krkn-scenario-runner" || pod.KubeconfigPath != "/home/krkn/.kube/config" ||
+		pod.ImagePullPolicy != string(corev1.PullAlways) || len(pod.ImagePullSecrets) != 1 ||
+		*pod.RunAsUser != 1001 || *pod.ActiveDeadlineSeconds != 300 {
+		t.Errorf("Unexpected pod summary %+v", pod)
+	}
+	if spec.CapturedAt.IsZero() {
+		t.Error("Expected CapturedAt to be set")
+	}
+}
+
+func TestRecordImageID(t *testing.T) {
+	job := &krknv1alpha1.ClusterJobStatus{EffectiveSpec: &krknv1alpha1.EffectiveSpec{Image: "krkn:latest"}}
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		Name:    "scenario",
+		ImageID: "quay.io/krkn-chaos/krkn@sha256:abc",
+	}}}}
+
+	recordImageID(job, pod)
+	if job.EffectiveSpec.ImageID != "quay.io/krkn-chaos/krkn@sha256:abc" {
+		t.Errorf("Expected the image digest to be recorded, got %q", job.EffectiveSpec.ImageID)
+	}
+
+	// Jobs created before the effective spec existed are left alone
+	recordImageID(&krknv1alpha1.ClusterJobStatus{}, pod)
+}
+
+func TestJobStatusEqual_ImageIDRecorded(t *testing.T) {
+	reconciler := &KrknScenarioRunReconciler{}
+	old := &krknv1alpha1.ClusterJobStatus{JobID: "job-1", EffectiveSpec: &krknv1alpha1.EffectiveSpec{Image: "krkn:latest"}}
+	updated := old.DeepCopy()
+	updated.EffectiveSpec.ImageID = "quay.io/krkn-chaos/krkn@sha256:abc"
+
+	if reconciler.jobStatusEqual(old, updated) {
+		t.Error("Expected the recorded image digest to be detected as a status change")
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// Environment variables krkn-hub reads its Elasticsearch settings from
+const (
+	esEnableEnv         = "ENABLE_ES"
+	esServerEnv         = "ES_SERVER"
+	esPortEnv           = "ES_PORT"
+	esUsernameEnv       = "ES_USERNAME"
+	esPasswordEnv       = "ES_PASSWORD"
+	esVerifyCertsEnv    = "ES_VERIFY_CERTS"
+	esTelemetryIndexEnv = "ES_TELEMETRY_INDEX"
+	esMetricsIndexEnv   = "ES_METRICS_INDEX"
+	esAlertsIndexEnv    = "ES_ALERTS_INDEX"
+)
+
+// elasticsearchEnv translates the Elasticsearch settings of a run into the krkn-hub
+// environment. krkn joins ES_SERVER and ES_PORT, so the URL is split into both. The
+// credentials are read from their Secret by the kubelet, never copied into the Job.
+func elasticsearchEnv(es *krknv1alpha1.ElasticsearchTelemetry) ([]corev1.EnvVar, error) {
+	if es == nil {
+		return nil, nil
+	}
+	server, err := url.Parse(es.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Elasticsearch URL: %w", err)
+	}
+	if (server.Scheme != "http" && server.Scheme != "https") || server.Hostname() == "" {
+		return nil, fmt.Errorf("invalid Elasticsearch URL %q: expected http(s)://host[:port]", es.URL)
+	}
+	port := server.Port()
+	if port == "" {
+		port = "443"
+		if server.Scheme == "http" {
+			port = "80"
+		}
+	}
+	host := server.Hostname()
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	env := []corev1.EnvVar{
+		{Name: esEnableEnv, Value: "True"},
+		{Name: esServerEnv, Value: server.Scheme + "://" + host},
+		{Name: esPortEnv, Value: port},
+		{Name: esVerifyCertsEnv, Value: krknBool(es.VerifyCerts)},
+		{Name: esTelemetryIndexEnv, Value: es.Index},
+	}
+	if es.MetricsIndex != "" {
+		env = append(env, corev1.EnvVar{Name: esMetricsIndexEnv, Value: es.MetricsIndex})
+	}
+	if es.AlertsIndex != "" {
+		env = append(env, corev1.EnvVar{Name: esAlertsIndexEnv, Value: es.AlertsIndex})
+	}
+	if es.CredentialsSecret != "" {
+		env = append(env,
+			secretEnvVar(esUsernameEnv, es.CredentialsSecret, corev1.BasicAuthUsernameKey),
+			secretEnvVar(esPasswordEnv, es.CredentialsSecret, corev1.BasicAuthPasswordKey))
+	}
+	return env, nil
+}
+
+// secretEnvVar is an environment variable read from the key of a Secret
+func secretEnvVar(name, secret, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+			Key:                  key,
+		}},
+	}
+}
+
+// krknBool formats a boolean the way krkn-hub parses it
+func krknBool(value bool) string {
+	if value {
+		return "True"
+	}
+	return "False"
+}
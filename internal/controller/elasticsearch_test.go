@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestElasticsearchEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		es      *krknv1alpha1.ElasticsearchTelemetry
+		want    map[string]string
+		secret  string
+		wantErr bool
+	}{
+		{name: "not configured", es: nil, want: map[string]string{}},
+		{
+			name: "https with port and credentials",
+			es: &krknv1alpha1.ElasticsearchTelemetry{
+				URL: "https://es.example.com:9200", Index: "chaos", AlertsIndex: "chaos-alerts",
+				CredentialsSecret: "es-credentials", VerifyCerts: true,
+			},
+			want: map[string]string{
+				esEnableEnv: "True", esServerEnv: "https://es.example.com", esPortEnv: "9200",
+				esVerifyCertsEnv: "True", esTelemetryIndexEnv: "chaos", esAlertsIndexEnv: "chaos-alerts",
+			},
+			secret: "es-credentials",
+		},
+		{
+			name: "default http port",
+			es:   &krknv1alpha1.ElasticsearchTelemetry{URL: "http://[fd00::1]", Index: "chaos"},
+			want: map[string]string{
+				esEnableEnv: "True", esServerEnv: "http://[fd00::1]", esPortEnv: "80",
+				esVerifyCertsEnv: "False", esTelemetryIndexEnv: "chaos",
+			},
+		},
+		{name: "no scheme", es: &krknv1alpha1.ElasticsearchTelemetry{URL: "es.example.com", Index: "chaos"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := elasticsearchEnv(tt.es)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("elasticsearchEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			values := make(map[string]string)
+			secrets := make(map[string]*corev1.SecretKeySelector)
+			for _, variable := range env {
+				if variable.ValueFrom != nil {
+					secrets[variable.Name] = variable.ValueFrom.SecretKeyRef
+					continue
+				}
+				values[variable.Name] = variable.Value
+			}
+			if len(values) != len(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, values)
+			}
+			for name, value := range tt.want {
+				if values[name] != value {
+					t.Errorf("expected %s=%q, got %q", name, value, values[name])
+				}
+			}
+			if tt.secret == "" {
+				if len(secrets) != 0 {
+					t.Errorf("expected no credentials, got %v", secrets)
+				}
+				return
+			}
+			if secrets[esUsernameEnv] == nil || secrets[esUsernameEnv].Name != tt.secret || secrets[esUsernameEnv].Key != corev1.BasicAuthUsernameKey ||
+				secrets[esPasswordEnv] == nil || secrets[esPasswordEnv].Key != corev1.BasicAuthPasswordKey {
+				t.Errorf("expected the credentials to be read from %s, got %v", tt.secret, secrets)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// Event reasons of scenario runs
+const (
+	EventJobCreated             = "JobCreated"
+	EventJobCreateFailed        = "JobCreateFailed"
+	EventJobSucceeded           = "JobSucceeded"
+	EventJobFailed              = "JobFailed"
+	EventJobRetrying            = "JobRetrying"
+	EventJobRetriesExhausted    = "JobRetriesExhausted"
+	EventJobCancelled           = "JobCancelled"
+	EventClusterSubstituted     = "ClusterSubstituted"
+	EventCleanupFailed          = "CleanupFailed"
+	EventTargetsUnresolved      = "TargetsUnresolved"
+	EventArchivedTargetsSkipped = "ArchivedTargetsSkipped"
+	EventTemplateUnresolved     = "TemplateUnresolved"
+	EventRunStarted             = "RunStarted"
+	EventRunSucceeded           = "RunSucceeded"
+	EventRunPartiallyFailed     = "RunPartiallyFailed"
+	EventRunFailed              = "RunFailed"
+	EventNotificationFailed     = "NotificationFailed"
+)
+
+// Event reasons of target requests
+const (
+	EventTargetsContributed = "TargetsContributed"
+	EventSecretWriteFailed  = "SecretWriteFailed"
+	EventRequestCompleted   = "RequestCompleted"
+)
+
+// Event reasons of target providers
+const (
+	EventProviderActivated   = "ProviderActivated"
+	EventProviderDeactivated = "ProviderDeactivated"
+)
+
+// Event reasons of targets discovered from ACM ManagedClusters and Hive ClusterDeployments
+const (
+	EventTargetDiscovered       = "TargetDiscovered"
+	EventTargetKubeconfigSynced = "TargetKubeconfigSynced"
+	EventTargetUnavailable      = "TargetUnavailable"
+)
+
+// Event reasons of legacy scenario pods
+const (
+	EventLegacyPodAdopted = "LegacyPodAdopted"
+	EventLegacyPodDeleted = "LegacyPodDeleted"
+)
+
+// recordEvent records an event on object. Events are optional: a nil recorder drops them.
+func recordEvent(recorder record.EventRecorder, object runtime.Object, eventType, reason, messageFmt string, args ...any) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, eventType, reason, messageFmt, args...)
+}
+
+// recordRunEvents records the job and run phase transitions between the status a
+// reconcile started from and the one it stored. Jobs are matched by cluster.
+func (r *KrknScenarioRunReconciler) recordRunEvents(scenarioRun *krknv1alpha1.KrknScenarioRun, old *krknv1alpha1.KrknScenarioRunStatus) {
+	if r.Recorder == nil {
+		return
+	}
+
+	previous := make(map[string]krknv1alpha1.ClusterJobStatus, len(old.ClusterJobs))
+	for _, job := range old.ClusterJobs {
+		previous[job.ClusterName] = job
+	}
+
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		before, existed := previous[job.ClusterName]
+		switch {
+		case (job.RetryCount > before.RetryCount || job.Evictions > before.Evictions) && job.JobID != before.JobID:
+			// Failed and replaced within the reconcile, the new attempt is the event
+			r.Recorder.Eventf(scenarioRun, corev1.EventTypeWarning, EventJobRetrying,
+				"Job %s of cluster %s failed (%s), retrying as job %s (attempt %d of %d)",
+				before.JobID, job.ClusterName, failureReasonOrUnknown(job.FailureReason), job.JobID, job.RetryCount, job.MaxRetries)
+			continue
+		case job.JobID != "" && (!existed || job.JobID != before.JobID):
+			r.Recorder.Eventf(scenarioRun, corev1.EventTypeNormal, EventJobCreated,
+				"Created job %s for cluster %s of provider %s", job.JobID, job.ClusterName, job.ProviderName)
+		}
+
+		if existed && job.Phase == before.Phase {
+			continue
+		}
+		switch job.Phase {
+		case statemachine.JobSucceeded:
+			r.Recorder.Eventf(scenarioRun, corev1.EventTypeNormal, EventJobSucceeded,
+				"Job %s of cluster %s succeeded", job.JobID, job.ClusterName)
+		case statemachine.JobFailed:
+			r.Recorder.Eventf(scenarioRun, corev1.EventTypeWarning, EventJobFailed,
+				"Job %s of cluster %s failed (%s): %s", job.JobID, job.ClusterName, failureReasonOrUnknown(job.FailureReason), job.Message)
+		case statemachine.JobMaxRetriesExceeded:
+			r.Recorder.Eventf(scenarioRun, corev1.EventTypeWarning, EventJobRetriesExhausted,
+				"Job %s of cluster %s failed (%s) with no retry left after %d retries",
+				job.JobID, job.ClusterName, failureReasonOrUnknown(job.FailureReason), job.RetryCount)
+		case statemachine.JobCancelled:
+			r.Recorder.Eventf(scenarioRun, corev1.EventTypeNormal, EventJobCancelled,
+				"Job %s of cluster %s was cancelled", job.JobID, job.ClusterName)
+		}
+	}
+
+	if old.Phase == scenarioRun.Status.Phase {
+		return
+	}
+	status := &scenarioRun.Status
+	switch status.Phase {
+	case "Running":
+		r.Recorder.Eventf(scenarioRun, corev1.EventTypeNormal, EventRunStarted,
+			"Scenario %s running on %d clusters", scenarioRun.Spec.ScenarioName, status.TotalTargets)
+	case "Succeeded":
+		r.Recorder.Eventf(scenarioRun, corev1.EventTypeNormal, EventRunSucceeded,
+			"All %d cluster jobs succeeded", status.SuccessfulJobs)
+	case "PartiallyFailed":
+		r.Recorder.Eventf(scenarioRun, corev1.EventTypeWarning, EventRunPartiallyFailed,
+			"%d cluster jobs succeeded, %d failed", status.SuccessfulJobs, status.FailedJobs)
+	case "Failed":
+		r.Recorder.Eventf(scenarioRun, corev1.EventTypeWarning, EventRunFailed,
+			"No cluster job succeeded, %d failed", status.FailedJobs)
+	}
+}
+
+func failureReasonOrUnknown(reason string) string {
+	if reason == "" {
+		return "Unknown"
+	}
+	return reason
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// drainEvents returns the "<type> <reason>" of the events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			fields := strings.Fields(event)
+			events = append(events, fields[0]+" "+fields[1])
+		default:
+			return events
+		}
+	}
+}
+
+func TestRecordRunEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(20)
+	reconciler := &KrknScenarioRunReconciler{Recorder: recorder}
+
+	old := &krknv1alpha1.KrknScenarioRunStatus{
+		Phase: "Running",
+		ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "running", JobID: "job-a", Phase: "Running"},
+			{ClusterName: "failing", JobID: "job-b", Phase: "Running"},
+			{ClusterName: "retried", JobID: "job-c", Phase: "Failed", MaxRetries: 3},
+			{ClusterName: "exhausted", JobID: "job-d", Phase: "Failed", RetryCount: 3, MaxRetries: 3},
+		},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{}
+	scenarioRun.Status = krknv1alpha1.KrknScenarioRunStatus{
+		Phase: "PartiallyFailed",
+		ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "running", JobID: "job-a", Phase: "Succeeded"},
+			{ClusterName: "failing", JobID: "job-b", Phase: "Failed", FailureReason: "Error"},
+			{ClusterName: "retried", JobID: "job-e", Phase: "Pending", RetryCount: 1, MaxRetries: 3},
+			{ClusterName: "exhausted", JobID: "job-d", Phase: "MaxRetriesExceeded", RetryCount: 3, MaxRetries: 3},
+			{ClusterName: "new", JobID: "job-f", Phase: "Pending"},
+		},
+	}
+
+	reconciler.recordRunEvents(scenarioRun, old)
+
+	expected := []string{
+		"Normal " + EventJobSucceeded,
+		"Warning " + EventJobFailed,
+		"Warning " + EventJobRetrying,
+		"Warning " + EventJobRetriesExhausted,
+		"Normal " + EventJobCreated,
+		"Warning " + EventRunPartiallyFailed,
+	}
+	if got := drainEvents(recorder); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected events %v, got %v", expected, got)
+	}
+
+	// A status without transitions records nothing
+	reconciler.recordRunEvents(scenarioRun, scenarioRun.Status.DeepCopy())
+	if got := drainEvents(recorder); len(got) != 0 {
+		t.Errorf("expected no event, got %v", got)
+	}
+}
+
+func TestRecordEvent_NilRecorder(t *testing.T) {
+	// Reconcilers built without a recorder, as in most tests, drop their events
+	recordEvent(nil, &krknv1alpha1.KrknScenarioRun{}, "Normal", EventJobCreated, "ignored")
+	(&KrknScenarioRunReconciler{}).recordRunEvents(&krknv1alpha1.KrknScenarioRun{}, &krknv1alpha1.KrknScenarioRunStatus{Phase: "Pending"})
+}
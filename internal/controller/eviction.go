@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// FailureReasonEvicted is the failure reason of a job whose scenario pod was evicted from
+// its node, e.g. on node pressure or a drain. It matches the pod status reason set by the kubelet.
+const FailureReasonEvicted = "Evicted"
+
+// maxEvictionRestarts bounds the restarts of evicted jobs, which do not count against
+// spec.maxRetries, so that a hub evicting every pod does not restart a job forever
+const maxEvictionRestarts = 5
+
+// safeToEvictAnnotation tells the cluster autoscaler not to scale down the node of a pod
+const safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// podEvicted reports whether pod was evicted rather than failed on its own
+func podEvicted(pod *corev1.Pod) bool {
+	if pod.Status.Reason == FailureReasonEvicted {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// evictionRestartLeft reports whether a failed job was evicted and can be restarted
+// without using one of its retries
+func evictionRestartLeft(job *krknv1alpha1.ClusterJobStatus) bool {
+	return job.Phase == statemachine.JobFailed && job.FailureReason == FailureReasonEvicted &&
+		job.Evictions < maxEvictionRestarts && !job.CancelRequested
+}
+
+// runPDBName returns the name of the PodDisruptionBudget protecting the pods of a run
+func runPDBName(scenarioRun *krknv1alpha1.KrknScenarioRun) string {
+	return "krkn-run-" + scenarioRun.Name
+}
+
+// newRunPDB returns a PodDisruptionBudget allowing no voluntary disruption of the
+// scenario pods of a run
+func newRunPDB(scenarioRun *krknv1alpha1.KrknScenarioRun, namespace string) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(0)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runPDBName(scenarioRun),
+			Namespace: namespace,
+			Labels:    map[string]string{joblabels.ScenarioRun: scenarioRun.Name},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					joblabels.ScenarioRun: scenarioRun.Name,
+					joblabels.App:         joblabels.AppScenario,
+				},
+			},
+		},
+	}
+}
+
+// syncRunPDB keeps the PodDisruptionBudget of a run with spec.evictionProtection while the
+// run is active, and deletes it once the run finished. The run owns the budget, so it is
+// garbage collected with a run deleted before finishing.
+func (r *KrknScenarioRunReconciler) syncRunPDB(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	if !scenarioRun.Spec.EvictionProtection {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	var pdb policyv1.PodDisruptionBudget
+	err := r.Get(ctx, types.NamespacedName{Name: runPDBName(scenarioRun), Namespace: r.Namespace}, &pdb)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get PodDisruptionBudget: %w", err)
+	}
+	exists := err == nil
+
+	if runFinished(scenarioRun) {
+		if !exists {
+			return nil
+		}
+		logger.Info("deleting PodDisruptionBudget of finished run", "podDisruptionBudget", pdb.Name)
+		if err := r.Delete(ctx, &pdb); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete PodDisruptionBudget: %w", err)
+		}
+		return nil
+	}
+	if exists {
+		return nil
+	}
+
+	desired := newRunPDB(scenarioRun, r.Namespace)
+	if err := controllerutil.SetControllerReference(scenarioRun, desired, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := r.Create(ctx, desired); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create PodDisruptionBudget: %w", err)
+	}
+	logger.Info("created PodDisruptionBudget protecting the run pods", "podDisruptionBudget", desired.Name)
+	return nil
+}
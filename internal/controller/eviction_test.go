@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func TestUpdateClusterJobStatuses_EvictedJobKeepsRetries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	now := metav1.Now()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		// The restart waits for its delay, no new job is created here
+		Spec: krknv1alpha1.KrknScenarioRunSpec{RetryDelay: "1h"},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{{
+				ProviderName:  "krkn-operator",
+				ClusterName:   "cluster1",
+				JobID:         "job-1",
+				Phase:         statemachine.JobRunning,
+				StartTime:     &now,
+				RetryCount:    1,
+				MaxRetries:    1,
+				LastRetryTime: &now,
+			}},
+		},
+	}
+
+	pod := newTestJobPod("job-1", "krkn-job-job-1-abcde", corev1.PodFailed, now.Time)
+	pod.Status.Reason = "Evicted"
+	pod.Status.Message = "The node was low on resource: memory."
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(scenarioRun, newTestScenarioJob("job-1", failedJobCondition("BackoffLimitExceeded")), pod).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	if err := reconciler.updateClusterJobStatuses(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	job := &scenarioRun.Status.ClusterJobs[0]
+	if job.FailureReason != FailureReasonEvicted {
+		t.Errorf("Expected reason %s, got %q", FailureReasonEvicted, job.FailureReason)
+	}
+	// No retry left, yet the evicted job waits for its restart
+	if job.Phase != statemachine.JobFailed {
+		t.Errorf("Expected the job to wait for its restart in Failed, got %s", job.Phase)
+	}
+	if !evictionRestartLeft(job) {
+		t.Error("Expected an eviction restart to be left")
+	}
+
+	// Jobs evicted over and over eventually give up like any failure
+	job.Evictions = maxEvictionRestarts
+	reconciler.handleJobFailure(context.Background(), scenarioRun, job)
+	if job.Phase != statemachine.JobMaxRetriesExceeded {
+		t.Errorf("Expected MaxRetriesExceeded after %d evictions, got %s", maxEvictionRestarts, job.Phase)
+	}
+}
+
+func TestExtractFailureReason_Evicted(t *testing.T) {
+	reconciler := &KrknScenarioRunReconciler{}
+
+	drained := &corev1.Pod{Status: corev1.PodStatus{
+		Conditions: []corev1.PodCondition{{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue}},
+		ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 143}},
+		}},
+	}}
+	if reason := reconciler.extractFailureReason(drained); reason != FailureReasonEvicted {
+		t.Errorf("Expected a disrupted pod to be evicted, got %s", reason)
+	}
+
+	killed := &corev1.Pod{Status: corev1.PodStatus{
+		ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 143}},
+		}},
+	}}
+	if reason := reconciler.extractFailureReason(killed); reason != "SIGTERM" {
+		t.Errorf("Expected SIGTERM, got %s", reason)
+	}
+}
+
+func TestSyncRunPDB(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default", UID: "run-uid"},
+		Spec:       krknv1alpha1.KrknScenarioRunSpec{EvictionProtection: true},
+		Status:     krknv1alpha1.KrknScenarioRunStatus{Phase: "Running"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenarioRun).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "krkn-run-run-1", Namespace: "default"}
+
+	// Syncing again finds the budget in place
+	for range 2 {
+		if err := reconciler.syncRunPDB(ctx, scenarioRun); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	var pdb policyv1.PodDisruptionBudget
+	if err := fakeClient.Get(ctx, key, &pdb); err != nil {
+		t.Fatalf("Expected the PodDisruptionBudget to be created, got %v", err)
+	}
+	if pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 0 ||
+		pdb.Spec.Selector.MatchLabels["krkn-scenario-run"] != "run-1" {
+		t.Errorf("Unexpected PodDisruptionBudget spec %+v", pdb.Spec)
+	}
+	if owner := metav1.GetControllerOf(&pdb); owner == nil || owner.UID != scenarioRun.UID {
+		t.Errorf("Expected the run to own the PodDisruptionBudget, got %v", owner)
+	}
+
+	scenarioRun.Status.Phase = "Succeeded"
+	if err := reconciler.syncRunPDB(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, &pdb); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the PodDisruptionBudget of the finished run to be deleted, got %v", err)
+	}
+
+	// Runs without eviction protection get no budget
+	scenarioRun.Spec.EvictionProtection = false
+	scenarioRun.Status.Phase = "Running"
+	if err := reconciler.syncRunPDB(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, &pdb); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected no PodDisruptionBudget, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// defaultHealthCheckTimeout bounds the preflight check of a cluster
+const defaultHealthCheckTimeout = 10 * time.Second
+
+// ClusterHealthChecker checks that a target cluster answers before a job is created for it
+type ClusterHealthChecker interface {
+	// Check returns an error when the cluster of kubeconfig is unreachable
+	Check(ctx context.Context, kubeconfig []byte) error
+}
+
+// APIServerHealthChecker checks a cluster by requesting the version of its API server
+type APIServerHealthChecker struct {
+	// Timeout bounds each check (defaultHealthCheckTimeout when zero)
+	Timeout time.Duration
+}
+
+// Check implements ClusterHealthChecker
+func (c *APIServerHealthChecker) Check(ctx context.Context, kubeconfig []byte) error {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	config.Timeout = c.Timeout
+	if config.Timeout == 0 {
+		config.Timeout = defaultHealthCheckTimeout
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	return clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
+// substitutedCluster returns the cluster the job of a target cluster runs on: its
+// alternate once the target failed over, the target cluster itself otherwise
+func substitutedCluster(scenarioRun *krknv1alpha1.KrknScenarioRun, clusterName string) string {
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		if job.SubstitutedFrom == clusterName {
+			return job.ClusterName
+		}
+	}
+	return clusterName
+}
+
+// setSubstitutedFrom records on the job of an alternate cluster the target it replaced
+func setSubstitutedFrom(scenarioRun *krknv1alpha1.KrknScenarioRun, clusterName, substitutedFrom string) {
+	if substitutedFrom == "" {
+		return
+	}
+	for i := range scenarioRun.Status.ClusterJobs {
+		if scenarioRun.Status.ClusterJobs[i].ClusterName == clusterName {
+			scenarioRun.Status.ClusterJobs[i].SubstitutedFrom = substitutedFrom
+			return
+		}
+	}
+}
+
+// preflightAlternate checks a target cluster with an alternate before its first job is
+// created and returns the cluster to run on. When the target is unreachable and its
+// alternate answers, the alternate is returned along with the target it substitutes.
+// Clusters without alternate, retries and alternates also targeted by the run are never
+// substituted; when neither cluster answers, the job runs and fails on the target.
+func (r *KrknScenarioRunReconciler) preflightAlternate(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	providerName string,
+	clusterName string,
+) (string, string) {
+	logger := log.FromContext(ctx)
+
+	alternate := scenarioRun.Spec.Alternates[clusterName]
+	if alternate == "" || alternate == clusterName || r.HealthChecker == nil {
+		return clusterName, ""
+	}
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		if job.ClusterName == clusterName || job.ClusterName == alternate {
+			return clusterName, ""
+		}
+	}
+	for _, clusterNames := range scenarioRun.Targets() {
+		for _, name := range clusterNames {
+			if name == alternate {
+				logger.Info("alternate cluster is a target of the run, not failing over",
+					"cluster", clusterName,
+					"alternate", alternate,
+					"scenarioRun", scenarioRun.Name)
+				return clusterName, ""
+			}
+		}
+	}
+
+	err := r.checkCluster(ctx, scenarioRun, providerName, clusterName)
+	if err == nil {
+		return clusterName, ""
+	}
+	if altErr := r.checkCluster(ctx, scenarioRun, providerName, alternate); altErr != nil {
+		logger.Error(altErr, "alternate cluster unreachable too, keeping the target cluster",
+			"cluster", clusterName,
+			"clusterError", err.Error(),
+			"alternate", alternate,
+			"scenarioRun", scenarioRun.Name)
+		return clusterName, ""
+	}
+
+	logger.Info("target cluster unreachable at preflight, failing over to its alternate",
+		"cluster", clusterName,
+		"alternate", alternate,
+		"scenarioRun", scenarioRun.Name,
+		"error", err.Error())
+	recordEvent(r.Recorder, scenarioRun, corev1.EventTypeWarning, EventClusterSubstituted,
+		"Cluster %s is unreachable (%v), running on its alternate %s", clusterName, err, alternate)
+	return alternate, clusterName
+}
+
+// checkCluster runs the health check of a cluster of the target request of the run
+func (r *KrknScenarioRunReconciler) checkCluster(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun, providerName, clusterName string) error {
+	kubeconfigBase64, err := r.getKubeconfigFromProvider(ctx, scenarioRun.Spec.TargetRequestID, providerName, clusterName)
+	if err != nil {
+		return err
+	}
+	kubeconfig, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return fmt.Errorf("failed to decode kubeconfig: %w", err)
+	}
+	return r.HealthChecker.Check(ctx, kubeconfig)
+}
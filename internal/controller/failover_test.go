@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// fakeHealthChecker reports the clusters whose kubeconfig is listed as unreachable
+type fakeHealthChecker struct {
+	unreachable map[string]bool
+	checked     []string
+}
+
+func (c *fakeHealthChecker) Check(_ context.Context, kubeconfig []byte) error {
+	c.checked = append(c.checked, string(kubeconfig))
+	if c.unreachable[string(kubeconfig)] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func setupFailoverTest(checker *fakeHealthChecker) (*KrknScenarioRunReconciler, *krknv1alpha1.KrknScenarioRun) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	// Each kubeconfig holds the name of its cluster
+	managedClusters := `{"krkn-operator":{` +
+		`"primary":{"kubeconfig":"` + base64.StdEncoding.EncodeToString([]byte("primary")) + `"},` +
+		`"standby":{"kubeconfig":"` + base64.StdEncoding.EncodeToString([]byte("standby")) + `"}}}`
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "request-1", Namespace: "default"},
+		Data:       map[string][]byte{"managed-clusters": []byte(managedClusters)},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "request-1",
+			TargetClusters:  map[string][]string{"krkn-operator": {"primary"}},
+			Alternates:      map[string]string{"primary": "standby"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	return &KrknScenarioRunReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Namespace:     "default",
+		HealthChecker: checker,
+	}, scenarioRun
+}
+
+func TestPreflightAlternate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reachable target", func(t *testing.T) {
+		reconciler, scenarioRun := setupFailoverTest(&fakeHealthChecker{})
+		cluster, substitutedFrom := reconciler.preflightAlternate(ctx, scenarioRun, "krkn-operator", "primary")
+		if cluster != "primary" || substitutedFrom != "" {
+			t.Errorf("Expected the target to be kept, got %s substituting %q", cluster, substitutedFrom)
+		}
+	})
+
+	t.Run("unreachable target", func(t *testing.T) {
+		reconciler, scenarioRun := setupFailoverTest(&fakeHealthChecker{unreachable: map[string]bool{"primary": true}})
+		cluster, substitutedFrom := reconciler.preflightAlternate(ctx, scenarioRun, "krkn-operator", "primary")
+		if cluster != "standby" || substitutedFrom != "primary" {
+			t.Fatalf("Expected a failover to standby, got %s substituting %q", cluster, substitutedFrom)
+		}
+
+		scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs,
+			krknv1alpha1.ClusterJobStatus{ClusterName: "standby", JobID: "job-1", Phase: statemachine.JobRunning})
+		setSubstitutedFrom(scenarioRun, "standby", "primary")
+		if got := substitutedCluster(scenarioRun, "primary"); got != "standby" {
+			t.Errorf("Expected the target to run on standby, got %s", got)
+		}
+		if !reconciler.jobExistsForCluster(scenarioRun, substitutedCluster(scenarioRun, "primary")) {
+			t.Error("Expected the substituted job to count as the job of the target")
+		}
+	})
+
+	t.Run("unreachable alternate", func(t *testing.T) {
+		checker := &fakeHealthChecker{unreachable: map[string]bool{"primary": true, "standby": true}}
+		reconciler, scenarioRun := setupFailoverTest(checker)
+		cluster, substitutedFrom := reconciler.preflightAlternate(ctx, scenarioRun, "krkn-operator", "primary")
+		if cluster != "primary" || substitutedFrom != "" {
+			t.Errorf("Expected the target to be kept, got %s substituting %q", cluster, substitutedFrom)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		checker := &fakeHealthChecker{unreachable: map[string]bool{"primary": true}}
+		reconciler, scenarioRun := setupFailoverTest(checker)
+		scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "primary", JobID: "job-1", Phase: statemachine.JobRetrying},
+		}
+		cluster, _ := reconciler.preflightAlternate(ctx, scenarioRun, "krkn-operator", "primary")
+		if cluster != "primary" || len(checker.checked) != 0 {
+			t.Errorf("Expected retries not to fail over, got %s after checking %v", cluster, checker.checked)
+		}
+	})
+
+	t.Run("alternate targeted by the run", func(t *testing.T) {
+		checker := &fakeHealthChecker{unreachable: map[string]bool{"primary": true}}
+		reconciler, scenarioRun := setupFailoverTest(checker)
+		scenarioRun.Spec.TargetClusters["krkn-operator"] = []string{"primary", "standby"}
+		cluster, _ := reconciler.preflightAlternate(ctx, scenarioRun, "krkn-operator", "primary")
+		if cluster != "primary" {
+			t.Errorf("Expected the target to be kept, got %s", cluster)
+		}
+	})
+
+	t.Run("no health checker", func(t *testing.T) {
+		reconciler, scenarioRun := setupFailoverTest(nil)
+		reconciler.HealthChecker = nil
+		if cluster, _ := reconciler.preflightAlternate(ctx, scenarioRun, "krkn-operator", "primary"); cluster != "primary" {
+			t.Errorf("Expected the target to be kept, got %s", cluster)
+		}
+	})
+}
+
+func TestAPIServerHealthChecker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"31"}`))
+	}))
+	kubeconfig := func(server string) []byte {
+		return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test-token
+`, server))
+	}
+
+	checker := &APIServerHealthChecker{}
+	if err := checker.Check(context.Background(), kubeconfig(server.URL)); err != nil {
+		t.Errorf("Expected the cluster to be reachable, got %v", err)
+	}
+
+	url := server.URL
+	server.Close()
+	if err := checker.Check(context.Background(), kubeconfig(url)); err == nil {
+		t.Error("Expected a stopped cluster to be unreachable")
+	}
+}
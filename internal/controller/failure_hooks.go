@@ -0,0 +1,280 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+)
+
+// Environment variables describing the failed job to remediation pods
+const (
+	FailureHookEnvScenarioRun   = "KRKN_SCENARIO_RUN"
+	FailureHookEnvScenarioName  = "KRKN_SCENARIO_NAME"
+	FailureHookEnvClusterName   = "KRKN_CLUSTER_NAME"
+	FailureHookEnvJobID         = "KRKN_JOB_ID"
+	FailureHookEnvFailureReason = "KRKN_FAILURE_REASON"
+)
+
+// failureHookPodName returns the deterministic name of a remediation pod, so a hook
+// re-executed after a lost status update does not start a second pod
+func failureHookPodName(jobID string, hookName string) string {
+	return fmt.Sprintf("krkn-hook-%s-%s", jobID, hookName)
+}
+
+// failureHookReportName returns the deterministic name of the KrknReport of a hook,
+// for the same reason as failureHookPodName
+func failureHookReportName(jobID string, hookName string) string {
+	return fmt.Sprintf("krkn-report-%s-%s", jobID, hookName)
+}
+
+// runFailureHooks executes the run's onFailure hooks once for every job that failed with
+// no retry left. Hooks are best-effort: failures are logged and never fail the reconcile.
+func (r *KrknScenarioRunReconciler) runFailureHooks(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if len(scenarioRun.Spec.OnFailure) == 0 {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if job.Phase != statemachine.JobMaxRetriesExceeded || job.FailureHooksTriggered {
+			continue
+		}
+
+		for _, hook := range scenarioRun.Spec.OnFailure {
+			var err error
+			switch {
+			case hook.Image != nil:
+				err = r.createFailureHookPod(ctx, scenarioRun, job, hook)
+			case hook.Webhook != nil:
+				err = r.sendFailureHookWebhook(ctx, scenarioRun, job, hook)
+			case hook.Report != nil:
+				err = r.createFailureHookReport(ctx, scenarioRun, job, hook)
+			default:
+				err = fmt.Errorf("hook defines neither image, webhook nor report")
+			}
+			if err != nil {
+				logger.Error(err, "failure hook failed",
+					"scenarioRun", scenarioRun.Name,
+					"cluster", job.ClusterName,
+					"jobID", job.JobID,
+					"hook", hook.Name)
+				continue
+			}
+			logger.Info("failure hook executed",
+				"scenarioRun", scenarioRun.Name,
+				"cluster", job.ClusterName,
+				"jobID", job.JobID,
+				"hook", hook.Name)
+		}
+		job.FailureHooksTriggered = true
+	}
+}
+
+// createFailureHookPod starts a remediation pod for a failed job, with the kubeconfig
+// of the failed cluster mounted at the run's kubeconfig path
+func (r *KrknScenarioRunReconciler) createFailureHookPod(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	job *krknv1alpha1.ClusterJobStatus,
+	hook krknv1alpha1.FailureHook,
+) error {
+	podName := failureHookPodName(job.JobID, hook.Name)
+
+	kubeconfigBase64, err := r.getKubeconfigFromProvider(ctx, scenarioRun.Spec.TargetRequestID, job.ProviderName, job.ClusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig from provider %s: %w", job.ProviderName, err)
+	}
+	kubeconfigDecoded, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return fmt.Errorf("failed to decode kubeconfig: %w", err)
+	}
+
+	// The hook pod holds its own reference, since the failed job's one is released once settled.
+	// It is dropped with the run through the Secret owner reference.
+	kubeconfigSecretName, err := r.acquireKubeconfigSecret(ctx, scenarioRun, job.ClusterName, kubeconfigDecoded, podName)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigPath := scenarioRun.Spec.KubeconfigPath
+	if kubeconfigPath == "" {
+		kubeconfigPath = krknv1alpha1.DefaultKubeconfigPath
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: FailureHookEnvScenarioRun, Value: scenarioRun.Name},
+		{Name: FailureHookEnvScenarioName, Value: scenarioRun.Spec.ScenarioName},
+		{Name: FailureHookEnvClusterName, Value: job.ClusterName},
+		{Name: FailureHookEnvJobID, Value: job.JobID},
+		{Name: FailureHookEnvFailureReason, Value: job.FailureReason},
+	}
+	for key, value := range hook.Image.Environment {
+		envVars = append(envVars, corev1.EnvVar{Name: key, Value: value})
+	}
+
+	var runAsUser int64 = 1001
+	var runAsGroup int64 = 1001
+	var fsGroup int64 = 1001
+
+	podLabels := runLabels(scenarioRun, job.ClusterName)
+	podLabels[joblabels.App] = joblabels.AppFailureHook
+	podLabels[joblabels.JobID] = job.JobID
+	podLabels[joblabels.FailureHook] = hook.Name
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: r.Namespace,
+			Labels:    podLabels,
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "krkn-operator-krkn-scenario-runner",
+			RestartPolicy:      corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+				FSGroup:    &fsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    "remediation",
+					Image:   hook.Image.Image,
+					Command: hook.Image.Command,
+					Args:    hook.Image.Args,
+					Env:     envVars,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "kubeconfig",
+							MountPath: kubeconfigPath,
+							SubPath:   "config",
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "kubeconfig",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: kubeconfigSecretName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(scenarioRun, pod, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on failure hook pod: %w", err)
+	}
+	if err := r.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		_ = r.releaseKubeconfigSecret(ctx, kubeconfigSecretName, podName) // Best-effort cleanup
+		return fmt.Errorf("failed to create failure hook pod: %w", err)
+	}
+	return nil
+}
+
+// sendFailureHookWebhook notifies the hook endpoint of a failed job, in the background
+func (r *KrknScenarioRunReconciler) sendFailureHookWebhook(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	job *krknv1alpha1.ClusterJobStatus,
+	hook krknv1alpha1.FailureHook,
+) error {
+	if r.Notifications == nil {
+		return fmt.Errorf("no notifier configured")
+	}
+
+	channel := notify.Channel{Type: hook.Webhook.Type, URL: hook.Webhook.URL}
+	if channel.Type == "" {
+		channel.Type = notify.ChannelWebhook
+	}
+	notification := notify.Notification{
+		ScenarioRun:   scenarioRun.Name,
+		ScenarioName:  scenarioRun.Spec.ScenarioName,
+		RunNumber:     scenarioRun.Spec.RunNumber,
+		Title:         scenarioRun.Title(),
+		Namespace:     scenarioRun.Namespace,
+		PreviousPhase: statemachine.JobFailed,
+		Phase:         job.Phase,
+		Timestamp:     time.Now().UTC(),
+		ClusterName:   job.ClusterName,
+		JobID:         job.JobID,
+		FailureReason: job.FailureReason,
+		Message:       job.Message,
+	}
+	subs := []notify.Subscription{{UserID: "onFailure hook " + hook.Name, Channel: channel}}
+	r.sendNotification(ctx, scenarioRun, subs, notification)
+	return nil
+}
+
+// createFailureHookReport records a failed job in a KrknReport with the hook's severity.
+// The report is owned by the run and deleted with it.
+func (r *KrknScenarioRunReconciler) createFailureHookReport(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	job *krknv1alpha1.ClusterJobStatus,
+	hook krknv1alpha1.FailureHook,
+) error {
+	severity := hook.Report.Severity
+	if severity == "" {
+		severity = krknv1alpha1.ReportSeverityWarning
+	}
+
+	reportLabels := runLabels(scenarioRun, job.ClusterName)
+	reportLabels[joblabels.JobID] = job.JobID
+	reportLabels[joblabels.FailureHook] = hook.Name
+	report := &krknv1alpha1.KrknReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      failureHookReportName(job.JobID, hook.Name),
+			Namespace: r.Namespace,
+			Labels:    reportLabels,
+		},
+		Spec: krknv1alpha1.KrknReportSpec{
+			Severity:      severity,
+			ScenarioRun:   scenarioRun.Name,
+			ScenarioName:  scenarioRun.Spec.ScenarioName,
+			ClusterName:   job.ClusterName,
+			JobID:         job.JobID,
+			Hook:          hook.Name,
+			FailureReason: job.FailureReason,
+			Message:       job.Message,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(scenarioRun, report, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on failure hook report: %w", err)
+	}
+	if err := r.Create(ctx, report); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create failure hook report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+)
+
+func TestRunFailureHooks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	managedClusters, _ := json.Marshal(map[string]map[string]map[string]string{
+		"krkn-operator": {
+			"cluster-1": {"kubeconfig": base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nkind: Config\n"))},
+		},
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-request-1", Namespace: "krkn"},
+		Data:       map[string][]byte{"managed-clusters": managedClusters},
+	}
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "krkn", UID: "run-uid"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "target-request-1",
+			ScenarioName:    "pod-scenarios",
+			OnFailure: []krknv1alpha1.FailureHook{
+				{Name: "restart", Image: &krknv1alpha1.FailureHookImage{Image: "quay.io/example/remediate:latest"}},
+				{Name: "incident", Webhook: &krknv1alpha1.FailureHookWebhook{URL: "https://incidents.example.com"}},
+				{Name: "report", Report: &krknv1alpha1.FailureHookReport{Severity: krknv1alpha1.ReportSeverityCritical}},
+			},
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{
+					ProviderName:  "krkn-operator",
+					ClusterName:   "cluster-1",
+					JobID:         "job-1",
+					Phase:         statemachine.JobMaxRetriesExceeded,
+					FailureReason: "ContainerError",
+				},
+				{
+					ProviderName: "krkn-operator",
+					ClusterName:  "cluster-2",
+					JobID:        "job-2",
+					Phase:        statemachine.JobFailed,
+					RetryCount:   1,
+					MaxRetries:   3,
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	notifier := &recordingNotifier{}
+	r := &KrknScenarioRunReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Namespace:     "krkn",
+		Notifications: newTestDispatcher(notifier),
+	}
+	ctx := context.Background()
+
+	r.runFailureHooks(ctx, scenarioRun)
+	r.Notifications.Wait()
+
+	if !scenarioRun.Status.ClusterJobs[0].FailureHooksTriggered {
+		t.Errorf("expected hooks to be marked as triggered for the failed job")
+	}
+	if scenarioRun.Status.ClusterJobs[1].FailureHooksTriggered {
+		t.Errorf("hooks must not run for a job that can still be retried")
+	}
+
+	var pod corev1.Pod
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: failureHookPodName("job-1", "restart"), Namespace: "krkn"}, &pod); err != nil {
+		t.Fatalf("expected remediation pod to be created: %v", err)
+	}
+	if pod.Spec.Containers[0].Image != "quay.io/example/remediate:latest" {
+		t.Errorf("unexpected remediation image %q", pod.Spec.Containers[0].Image)
+	}
+	env := map[string]string{}
+	for _, e := range pod.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env[FailureHookEnvClusterName] != "cluster-1" || env[FailureHookEnvFailureReason] != "ContainerError" {
+		t.Errorf("unexpected remediation pod environment %v", env)
+	}
+
+	var report krknv1alpha1.KrknReport
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: failureHookReportName("job-1", "report"), Namespace: "krkn"}, &report); err != nil {
+		t.Fatalf("expected report to be created: %v", err)
+	}
+	if report.Spec.Severity != krknv1alpha1.ReportSeverityCritical || report.Spec.ClusterName != "cluster-1" ||
+		report.Spec.ScenarioRun != "run-1" || report.Spec.FailureReason != "ContainerError" {
+		t.Errorf("unexpected report spec %+v", report.Spec)
+	}
+	if len(report.OwnerReferences) != 1 || report.OwnerReferences[0].Name != "run-1" {
+		t.Errorf("expected the report to be owned by the run, got %+v", report.OwnerReferences)
+	}
+
+	if len(notifier.delivered) != 1 {
+		t.Fatalf("expected 1 webhook notification, got %d", len(notifier.delivered))
+	}
+	if notifier.channels[0].Type != notify.ChannelWebhook || notifier.channels[0].URL != "https://incidents.example.com" {
+		t.Errorf("notification routed to wrong channel: %+v", notifier.channels[0])
+	}
+	if got := notifier.delivered[0]; got.ClusterName != "cluster-1" || got.JobID != "job-1" || got.Phase != statemachine.JobMaxRetriesExceeded {
+		t.Errorf("unexpected notification: %+v", got)
+	}
+
+	// Hooks run at most once per job
+	r.runFailureHooks(ctx, scenarioRun)
+	if len(notifier.delivered) != 1 {
+		t.Errorf("expected hooks not to run again, got %d notifications", len(notifier.delivered))
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// ScenarioRunFinalizer holds the deletion of a run until its scenario pods are gone and
+// its artifacts are cleaned up. Owner references alone delete the Jobs with the run while
+// their pods may still be injecting chaos.
+const ScenarioRunFinalizer = "krkn.krkn-chaos.dev/scenario-run-cleanup"
+
+// finalizerPodWait is how long a deleted run waits for its pods to terminate with their
+// grace period before their deletion is forced
+const finalizerPodWait = 2 * time.Minute
+
+// finalizerRequeue is how often a deleted run checks whether its pods are gone
+const finalizerRequeue = 5 * time.Second
+
+// RunArtifactCleaner deletes what a scenario run stored outside the cluster, e.g. logs
+// uploaded to object storage. It is called when the run is deleted, once its pods are gone.
+type RunArtifactCleaner interface {
+	CleanupRunArtifacts(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error
+}
+
+// ensureFinalizer adds ScenarioRunFinalizer to a run that does not have it yet
+func (r *KrknScenarioRunReconciler) ensureFinalizer(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	if !controllerutil.AddFinalizer(scenarioRun, ScenarioRunFinalizer) {
+		return nil
+	}
+	if err := r.Update(ctx, scenarioRun); err != nil {
+		return fmt.Errorf("failed to add finalizer: %w", err)
+	}
+	return nil
+}
+
+// finalizeRun cleans up after a deleted run: its Jobs are deleted and its pods get
+// cancelGracePeriodSeconds to stop the chaos they injected, forced after finalizerPodWait.
+// Once no pod is left the Secrets it consumed are released, the artifact cleaners run and
+// the finalizer is removed, letting the deletion complete.
+func (r *KrknScenarioRunReconciler) finalizeRun(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if !controllerutil.ContainsFinalizer(scenarioRun, ScenarioRunFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	remaining, err := r.terminateRunPods(ctx, scenarioRun)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if remaining > 0 {
+		logger.Info("waiting for the pods of the deleted scenario run to terminate",
+			"scenarioRun", scenarioRun.Name,
+			"pods", remaining)
+		return ctrl.Result{RequeueAfter: finalizerRequeue}, nil
+	}
+
+	// The pods are gone, their slots of the operator-wide limit are free
+	r.setJobSlots(client.ObjectKeyFromObject(scenarioRun), 0)
+
+	if err := r.releaseConsumedResources(ctx, scenarioRun); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, cleaner := range r.ArtifactCleaners {
+		if err := cleaner.CleanupRunArtifacts(ctx, scenarioRun); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to clean up artifacts of deleted run: %w", err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(scenarioRun, ScenarioRunFinalizer)
+	if err := r.Update(ctx, scenarioRun); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logger.Info("cleaned up deleted scenario run", "scenarioRun", scenarioRun.Name)
+	return ctrl.Result{}, nil
+}
+
+// terminateRunPods deletes the Jobs and pods of a deleted run and returns how many pods
+// still exist, terminating ones included
+func (r *KrknScenarioRunReconciler) terminateRunPods(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) (int, error) {
+	logger := log.FromContext(ctx)
+	selector := []client.ListOption{
+		client.InNamespace(r.Namespace),
+		client.MatchingLabels{joblabels.ScenarioRun: scenarioRun.Name},
+	}
+
+	var jobList batchv1.JobList
+	if err := r.List(ctx, &jobList, selector...); err != nil {
+		return 0, fmt.Errorf("failed to list jobs of deleted run: %w", err)
+	}
+	for i := range jobList.Items {
+		batchJob := &jobList.Items[i]
+		if !batchJob.DeletionTimestamp.IsZero() {
+			continue
+		}
+		err := r.Delete(ctx, batchJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			return 0, fmt.Errorf("failed to delete job %s of deleted run: %w", batchJob.Name, err)
+		}
+	}
+
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, selector...); err != nil {
+		return 0, fmt.Errorf("failed to list pods of deleted run: %w", err)
+	}
+	// Pods stuck terminating, e.g. on a lost node, would hold the deletion forever
+	force := time.Since(scenarioRun.DeletionTimestamp.Time) > finalizerPodWait
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		gracePeriod := cancelGracePeriodSeconds
+		switch {
+		case force:
+			gracePeriod = 0
+			logger.Info("forcing the deletion of a pod of the deleted scenario run",
+				"scenarioRun", scenarioRun.Name,
+				"pod", pod.Name)
+		case !pod.DeletionTimestamp.IsZero():
+			continue
+		}
+		err := r.Delete(ctx, pod, client.GracePeriodSeconds(gracePeriod))
+		if client.IgnoreNotFound(err) != nil {
+			return 0, fmt.Errorf("failed to delete pod %s of deleted run: %w", pod.Name, err)
+		}
+	}
+	return len(podList.Items), nil
+}
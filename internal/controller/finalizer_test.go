@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// recordingCleaner records the runs whose artifacts it cleaned up
+type recordingCleaner struct {
+	runs []string
+	err  error
+}
+
+func (c *recordingCleaner) CleanupRunArtifacts(_ context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	c.runs = append(c.runs, scenarioRun.Name)
+	return c.err
+}
+
+// setupFinalizerTest returns a reconciler holding a deleted run with a running Job and pod
+func setupFinalizerTest(cleaner *recordingCleaner) (*KrknScenarioRunReconciler, *krknv1alpha1.KrknScenarioRun) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	now := metav1.Now()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "deleted-run",
+			Namespace:         "default",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{ScenarioRunFinalizer},
+		},
+	}
+	labels := map[string]string{joblabels.ScenarioRun: "deleted-run", joblabels.JobID: "job-1"}
+	batchJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "krkn-job-job-1", Namespace: "default", Labels: labels}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "krkn-job-job-1-abcde", Namespace: "default", Labels: labels}}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenarioRun, batchJob, pod).Build()
+	reconciler := &KrknScenarioRunReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Namespace:        "default",
+		ArtifactCleaners: []RunArtifactCleaner{cleaner},
+	}
+	return reconciler, scenarioRun
+}
+
+func TestEnsureFinalizer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "default"}}
+	reconciler := &KrknScenarioRunReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenarioRun).Build(),
+		Namespace: "default",
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		if err := reconciler.ensureFinalizer(ctx, scenarioRun); err != nil {
+			t.Fatalf("ensureFinalizer failed: %v", err)
+		}
+	}
+
+	var stored krknv1alpha1.KrknScenarioRun
+	if err := reconciler.Get(ctx, client.ObjectKeyFromObject(scenarioRun), &stored); err != nil {
+		t.Fatalf("failed to get run: %v", err)
+	}
+	if len(stored.Finalizers) != 1 || stored.Finalizers[0] != ScenarioRunFinalizer {
+		t.Errorf("expected the finalizer once, got %v", stored.Finalizers)
+	}
+}
+
+func TestFinalizeRun_WaitsForPods(t *testing.T) {
+	cleaner := &recordingCleaner{}
+	reconciler, scenarioRun := setupFinalizerTest(cleaner)
+	ctx := context.Background()
+
+	// The first pass deletes the Job and pods and waits for them to be gone
+	result, err := reconciler.finalizeRun(ctx, scenarioRun)
+	if err != nil {
+		t.Fatalf("finalizeRun failed: %v", err)
+	}
+	if result.RequeueAfter == 0 || len(cleaner.runs) != 0 {
+		t.Fatalf("expected the run to wait for its pods, got %+v and cleaned %v", result, cleaner.runs)
+	}
+	err = reconciler.Get(ctx, client.ObjectKey{Name: "krkn-job-job-1", Namespace: "default"}, &batchv1.Job{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the Job to be deleted, got %v", err)
+	}
+	err = reconciler.Get(ctx, client.ObjectKey{Name: "krkn-job-job-1-abcde", Namespace: "default"}, &corev1.Pod{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the pod to be deleted, got %v", err)
+	}
+
+	// Once no pod is left the artifacts are cleaned up and the deletion completes
+	result, err = reconciler.finalizeRun(ctx, scenarioRun)
+	if err != nil {
+		t.Fatalf("finalizeRun failed: %v", err)
+	}
+	if result.RequeueAfter != 0 || len(cleaner.runs) != 1 {
+		t.Errorf("expected the artifacts to be cleaned up once, got %+v and cleaned %v", result, cleaner.runs)
+	}
+	err = reconciler.Get(ctx, client.ObjectKeyFromObject(scenarioRun), &krknv1alpha1.KrknScenarioRun{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the run to be gone, got %v", err)
+	}
+}
+
+func TestFinalizeRun_KeepsFinalizerOnCleanupError(t *testing.T) {
+	cleaner := &recordingCleaner{err: errors.New("object storage unavailable")}
+	reconciler, scenarioRun := setupFinalizerTest(cleaner)
+	ctx := context.Background()
+
+	_, _ = reconciler.finalizeRun(ctx, scenarioRun)
+	if _, err := reconciler.finalizeRun(ctx, scenarioRun); err == nil {
+		t.Fatal("expected the cleanup error to be returned")
+	}
+
+	var stored krknv1alpha1.KrknScenarioRun
+	if err := reconciler.Get(ctx, client.ObjectKeyFromObject(scenarioRun), &stored); err != nil {
+		t.Fatalf("expected the run to be kept, got %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(&stored, ScenarioRunFinalizer) {
+		t.Error("expected the finalizer to be kept until the artifacts are cleaned up")
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+)
+
+const (
+	// DefaultHiveDiscoveryInterval is how often the ClusterDeployments are listed by default
+	DefaultHiveDiscoveryInterval = 2 * time.Minute
+
+	// HiveClusterDeploymentLabel is the name of the ClusterDeployment a discovered target comes from
+	HiveClusterDeploymentLabel = "krkn.krkn-chaos.dev/cluster-deployment"
+	// HiveNamespaceLabel is the namespace of the ClusterDeployment a discovered target comes from
+	HiveNamespaceLabel = "krkn.krkn-chaos.dev/cluster-deployment-namespace"
+
+	// hiveDiscoveredBy is the value of DiscoveredByLabel
+	hiveDiscoveredBy = "hive"
+	// hiveTargetPrefix names the targets of ClusterDeployments, hive-<cluster>
+	hiveTargetPrefix = "hive-"
+	// hivePowerStateHibernating is the spec.powerState of a hibernating cluster
+	hivePowerStateHibernating = "Hibernating"
+)
+
+// HiveDiscovery creates a KrknOperatorTarget for each installed Hive ClusterDeployment, with
+// the admin kubeconfig Hive keeps in the Secret its cluster metadata references. Targets
+// are named hive-<cluster>, or hive-<namespace>-<cluster> when the name is used in several
+// namespaces, carry the labels of the ClusterDeployment and keep their Secret in sync with
+// the admin kubeconfig.
+//
+// ClusterDeployments live in a namespace per cluster, outside the namespace the manager
+// cache is restricted to, so they are listed every interval instead of watched. A
+// ClusterDeployment being deleted, i.e. deprovisioned, or hibernating sets the
+// ClusterUnavailable condition of its target, which keeps it not ready; the target is
+// deleted once the ClusterDeployment is gone.
+type HiveDiscovery struct {
+	client    client.Client
+	reader    client.Reader
+	scheme    *runtime.Scheme
+	namespace string
+	interval  time.Duration
+
+	// Recorder emits discovery events on the targets (nil disables events)
+	Recorder record.EventRecorder
+	// Cipher seals the kubeconfigs of the discovered targets at rest (nil when encryption is off)
+	Cipher *envelope.Cipher
+}
+
+// NewHiveDiscovery creates a discovery of the ClusterDeployments read with reader, creating
+// targets in namespace every interval
+func NewHiveDiscovery(c client.Client, reader client.Reader, scheme *runtime.Scheme, namespace string, interval time.Duration) *HiveDiscovery {
+	return &HiveDiscovery{client: c, reader: reader, scheme: scheme, namespace: namespace, interval: interval}
+}
+
+// +kubebuilder:rbac:groups=hive.openshift.io,resources=clusterdeployments,verbs=get;list
+
+// Start implements manager.Runnable
+func (d *HiveDiscovery) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("hive-discovery")
+	logger.Info("Starting Hive ClusterDeployment discovery", "interval", d.interval, "namespace", d.namespace)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		if err := d.sync(ctx); err != nil {
+			// Retried on the next tick
+			logger.Error(err, "Failed to discover Hive ClusterDeployments")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (d *HiveDiscovery) NeedLeaderElection() bool {
+	return true
+}
+
+// sync creates, updates and deletes the targets of the ClusterDeployments
+func (d *HiveDiscovery) sync(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("hive-discovery")
+
+	clusterDeployments := &unstructured.UnstructuredList{}
+	clusterDeployments.SetGroupVersionKind(ClusterDeploymentGVK.GroupVersion().WithKind(ClusterDeploymentGVK.Kind + "List"))
+	if err := d.reader.List(ctx, clusterDeployments); err != nil {
+		return fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	names := map[string]int{}
+	for _, clusterDeployment := range clusterDeployments.Items {
+		names[clusterDeployment.GetName()]++
+	}
+
+	var errs []error
+	discovered := map[string]bool{}
+	for i := range clusterDeployments.Items {
+		clusterDeployment := &clusterDeployments.Items[i]
+		if installed, _, _ := unstructured.NestedBool(clusterDeployment.Object, "spec", "installed"); !installed {
+			continue
+		}
+		clusterName := clusterDeployment.GetName()
+		if names[clusterName] > 1 {
+			clusterName = clusterDeployment.GetNamespace() + "-" + clusterName
+		}
+		name := hiveTargetPrefix + clusterName
+		discovered[name] = true
+		if err := d.syncTarget(ctx, clusterDeployment, name, clusterName); err != nil {
+			logger.Error(err, "Failed to sync the target of ClusterDeployment", "target", name,
+				"clusterDeployment", clusterDeployment.GetName(), "namespace", clusterDeployment.GetNamespace())
+			errs = append(errs, err)
+		}
+	}
+
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := d.client.List(ctx, &targets, client.InNamespace(d.namespace),
+		client.MatchingLabels{DiscoveredByLabel: hiveDiscoveredBy}); err != nil {
+		return errors.Join(append(errs, fmt.Errorf("failed to list targets: %w", err))...)
+	}
+	for i := range targets.Items {
+		target := &targets.Items[i]
+		if discovered[target.Name] {
+			continue
+		}
+		// The Secret is owned by the target and garbage collected with it
+		if err := d.client.Delete(ctx, target); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete target %s: %w", target.Name, err))
+			continue
+		}
+		logger.Info("ClusterDeployment removed, target deleted", "target", target.Name,
+			"clusterDeployment", target.Labels[HiveClusterDeploymentLabel], "namespace", target.Labels[HiveNamespaceLabel])
+	}
+	return errors.Join(errs...)
+}
+
+// syncTarget creates or updates the target of a ClusterDeployment. Targets archived by an
+// admin and clusters already registered by hand or by ACM discovery are left alone.
+func (d *HiveDiscovery) syncTarget(ctx context.Context, clusterDeployment *unstructured.Unstructured, name, clusterName string) error {
+	logger := log.FromContext(ctx).WithName("hive-discovery").WithValues("target", name)
+
+	var target krknv1alpha1.KrknOperatorTarget
+	err := d.client.Get(ctx, types.NamespacedName{Name: name, Namespace: d.namespace}, &target)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && (target.Status.Archived || target.Labels[DiscoveredByLabel] != hiveDiscoveredBy) {
+		return nil
+	}
+
+	reason, message := hiveClusterUnavailable(clusterDeployment)
+	if reason != "" && !exists {
+		return nil
+	}
+	if reason != "" {
+		// The admin kubeconfig may already be gone with the cluster
+		return d.setClusterUnavailable(ctx, &target, reason, message)
+	}
+
+	kubeconfigBase64, err := adminKubeconfig(ctx, d.reader, clusterDeployment)
+	if err != nil {
+		logger.V(1).Info("ClusterDeployment has no usable admin kubeconfig", "reason", err.Error())
+		return nil
+	}
+	apiURL, err := kubeconfig.ExtractAPIURL(kubeconfigBase64)
+	if err == nil {
+		apiURL, err = kubeconfig.NormalizeAPIURL(apiURL)
+	}
+	if err != nil {
+		logger.Info("Admin kubeconfig of ClusterDeployment has no valid API URL", "error", err.Error())
+		return nil
+	}
+
+	if !exists {
+		registered, err := registeredTarget(ctx, d.client, d.namespace, hiveDiscoveredBy, clusterName, apiURL)
+		if err != nil || registered != "" {
+			if registered != "" {
+				logger.V(1).Info("ClusterDeployment already registered as a target, not discovered", "registered", registered)
+			}
+			return err
+		}
+		target = krknv1alpha1.KrknOperatorTarget{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: d.namespace}}
+	}
+
+	labels := discoveredTargetLabels(clusterDeployment, hiveDiscoveredBy)
+	labels[HiveClusterDeploymentLabel] = clusterDeployment.GetName()
+	labels[HiveNamespaceLabel] = clusterDeployment.GetNamespace()
+	changed := !exists || target.Spec.ClusterAPIURL != apiURL || !maps.Equal(target.Labels, labels)
+	target.Labels = labels
+	target.Spec = krknv1alpha1.KrknOperatorTargetSpec{
+		UUID:          name,
+		ClusterName:   clusterName,
+		ClusterAPIURL: apiURL,
+		SecretType:    "kubeconfig",
+		SecretUUID:    name,
+		Metrics:       target.Spec.Metrics,
+	}
+	target.Default()
+	if !exists {
+		if err := d.client.Create(ctx, &target); err != nil {
+			return fmt.Errorf("failed to create target: %w", err)
+		}
+	} else if changed {
+		if err := d.client.Update(ctx, &target); err != nil {
+			return fmt.Errorf("failed to update target: %w", err)
+		}
+	}
+
+	rotated, err := syncTargetKubeconfig(ctx, d.client, d.scheme, d.Cipher, &target, kubeconfigBase64)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !exists:
+		target.Status.Ready = true
+		target.Status.LastUpdated = metav1.Now()
+		plugins, _ := kubeconfig.AuthPlugins(kubeconfigBase64)
+		target.Status.SetAuthPlugins(plugins, target.Generation)
+		if err := d.client.Status().Update(ctx, &target); err != nil {
+			return fmt.Errorf("failed to update target status: %w", err)
+		}
+		logger.Info("Discovered ClusterDeployment as target", "apiURL", apiURL)
+		recordEvent(d.Recorder, &target, corev1.EventTypeNormal, EventTargetDiscovered,
+			"Created from Hive ClusterDeployment %s/%s", clusterDeployment.GetNamespace(), clusterDeployment.GetName())
+	case rotated:
+		target.Status.LastUpdated = metav1.Now()
+		plugins, _ := kubeconfig.AuthPlugins(kubeconfigBase64)
+		target.Status.SetAuthPlugins(plugins, target.Generation)
+		if err := d.client.Status().Update(ctx, &target); err != nil {
+			return fmt.Errorf("failed to update target status: %w", err)
+		}
+		logger.Info("Admin kubeconfig of ClusterDeployment changed, target updated")
+		recordEvent(d.Recorder, &target, corev1.EventTypeNormal, EventTargetKubeconfigSynced,
+			"Kubeconfig updated from the admin kubeconfig of Hive ClusterDeployment %s/%s",
+			clusterDeployment.GetNamespace(), clusterDeployment.GetName())
+	}
+	return d.setClusterUnavailable(ctx, &target, "", "")
+}
+
+// setClusterUnavailable sets the ClusterUnavailable condition of a target with reason and
+// message, or clears it when reason is empty. The target is not ready while the condition
+// is set; once cleared, it is ready until the next connectivity check says otherwise.
+func (d *HiveDiscovery) setClusterUnavailable(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget, reason, message string) error {
+	unavailable := reason != ""
+	if target.Status.ClusterUnavailable() == unavailable {
+		return nil
+	}
+	condition := metav1.Condition{
+		Type:               krknv1alpha1.TargetClusterUnavailable,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: target.Generation,
+		Reason:             "ClusterAvailable",
+		Message:            "The ClusterDeployment of the cluster is installed and running",
+	}
+	if unavailable {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reason
+		condition.Message = message
+	}
+	apimeta.SetStatusCondition(&target.Status.Conditions, condition)
+	target.Status.Ready = !unavailable
+	target.Status.LastUpdated = metav1.Now()
+	if err := d.client.Status().Update(ctx, target); err != nil {
+		return fmt.Errorf("failed to update target status: %w", err)
+	}
+
+	if unavailable {
+		log.FromContext(ctx).Info("Target cluster unavailable, target not ready", "target", target.Name, "reason", reason)
+		recordEvent(d.Recorder, target, corev1.EventTypeWarning, EventTargetUnavailable, "%s", message)
+	} else {
+		log.FromContext(ctx).Info("Target cluster available again", "target", target.Name)
+	}
+	return nil
+}
+
+// hiveClusterUnavailable returns the reason and message why the cluster of a
+// ClusterDeployment cannot run scenarios: Deprovisioning once the ClusterDeployment is
+// deleted, or Hibernating while its power state is Hibernating. The reason is empty when
+// the cluster is available.
+func hiveClusterUnavailable(clusterDeployment *unstructured.Unstructured) (string, string) {
+	if clusterDeployment.GetDeletionTimestamp() != nil {
+		return "Deprovisioning", "The cluster is being deprovisioned by Hive"
+	}
+	if powerState, _, _ := unstructured.NestedString(clusterDeployment.Object, "spec", "powerState"); powerState == hivePowerStateHibernating {
+		return "Hibernating", "The cluster is hibernating"
+	}
+	return "", ""
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// hiveCluster returns an installed Hive ClusterDeployment with its admin kubeconfig Secret
+func hiveCluster(name, namespace, server string) (*unstructured.Unstructured, *corev1.Secret) {
+	clusterDeployment := &unstructured.Unstructured{}
+	clusterDeployment.SetGroupVersionKind(ClusterDeploymentGVK)
+	clusterDeployment.SetName(name)
+	clusterDeployment.SetNamespace(namespace)
+	clusterDeployment.SetLabels(map[string]string{"env": "staging"})
+	_ = unstructured.SetNestedField(clusterDeployment.Object, true, "spec", "installed")
+	_ = unstructured.SetNestedField(clusterDeployment.Object, name+"-admin-kubeconfig",
+		"spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-admin-kubeconfig", Namespace: namespace},
+		Data:       map[string][]byte{"kubeconfig": acmAdminKubeconfig(server, "token-1")},
+	}
+	return clusterDeployment, secret
+}
+
+func setupTestHiveDiscovery(objs ...client.Object) (*HiveDiscovery, client.Client, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	discovery := NewHiveDiscovery(fakeClient, fakeClient, scheme, testOperatorNamespace, DefaultHiveDiscoveryInterval)
+	discovery.Recorder = recorder
+	return discovery, fakeClient, recorder
+}
+
+func TestHiveDiscovery_Lifecycle(t *testing.T) {
+	clusterDeployment, secret := hiveCluster("prod", "hive-prod", "https://api.prod.example.com:6443")
+	// Not installed yet, nothing to discover
+	pending, pendingSecret := hiveCluster("pending", "hive-pending", "https://api.pending.example.com:6443")
+	_ = unstructured.SetNestedField(pending.Object, false, "spec", "installed")
+	discovery, c, recorder := setupTestHiveDiscovery(clusterDeployment, secret, pending, pendingSecret)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "hive-prod", Namespace: testOperatorNamespace}
+
+	if err := discovery.sync(ctx); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := c.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Expected the target to be created: %v", err)
+	}
+	if target.Spec.ClusterName != "prod" || target.Spec.ClusterAPIURL != "https://api.prod.example.com:6443" ||
+		!target.Status.Ready {
+		t.Errorf("Unexpected target %+v", target)
+	}
+	if target.Labels[DiscoveredByLabel] != "hive" || target.Labels[HiveClusterDeploymentLabel] != "prod" ||
+		target.Labels[HiveNamespaceLabel] != "hive-prod" || target.Labels["env"] != "staging" {
+		t.Errorf("Expected the discovery and ClusterDeployment labels, got %v", target.Labels)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "hive-pending", Namespace: testOperatorNamespace},
+		&krknv1alpha1.KrknOperatorTarget{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected no target for a cluster not installed, got %v", err)
+	}
+	if events := drainEvents(recorder); len(events) != 1 || !strings.Contains(events[0], EventTargetDiscovered) {
+		t.Errorf("Expected a discovery event, got %v", events)
+	}
+
+	// Hibernating keeps the target not ready until the cluster resumes
+	if err := c.Get(ctx, client.ObjectKeyFromObject(clusterDeployment), clusterDeployment); err != nil {
+		t.Fatalf("Failed to get ClusterDeployment: %v", err)
+	}
+	_ = unstructured.SetNestedField(clusterDeployment.Object, "Hibernating", "spec", "powerState")
+	if err := c.Update(ctx, clusterDeployment); err != nil {
+		t.Fatalf("Failed to update ClusterDeployment: %v", err)
+	}
+	if err := discovery.sync(ctx); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if err := c.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	condition := apimeta.FindStatusCondition(target.Status.Conditions, krknv1alpha1.TargetClusterUnavailable)
+	if target.Status.Ready || condition == nil || condition.Reason != "Hibernating" {
+		t.Errorf("Expected a hibernating target not ready, got %+v", target.Status)
+	}
+	if events := drainEvents(recorder); len(events) != 1 || !strings.Contains(events[0], EventTargetUnavailable) {
+		t.Errorf("Expected an unavailable event, got %v", events)
+	}
+
+	_ = unstructured.SetNestedField(clusterDeployment.Object, "Running", "spec", "powerState")
+	if err := c.Update(ctx, clusterDeployment); err != nil {
+		t.Fatalf("Failed to update ClusterDeployment: %v", err)
+	}
+	if err := discovery.sync(ctx); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if err := c.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	if !target.Status.Ready || target.Status.ClusterUnavailable() {
+		t.Errorf("Expected the target ready once the cluster resumes, got %+v", target.Status)
+	}
+
+	// Deprovisioning marks the target not ready, and it is deleted with the ClusterDeployment
+	clusterDeployment.SetFinalizers([]string{"hive.openshift.io/deprovision"})
+	if err := c.Update(ctx, clusterDeployment); err != nil {
+		t.Fatalf("Failed to update ClusterDeployment: %v", err)
+	}
+	if err := c.Delete(ctx, clusterDeployment); err != nil {
+		t.Fatalf("Failed to delete ClusterDeployment: %v", err)
+	}
+	if err := discovery.sync(ctx); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if err := c.Get(ctx, key, &target); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	condition = apimeta.FindStatusCondition(target.Status.Conditions, krknv1alpha1.TargetClusterUnavailable)
+	if target.Status.Ready || condition == nil || condition.Reason != "Deprovisioning" {
+		t.Errorf("Expected a deprovisioning target not ready, got %+v", target.Status)
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(clusterDeployment), clusterDeployment); err != nil {
+		t.Fatalf("Failed to get ClusterDeployment: %v", err)
+	}
+	clusterDeployment.SetFinalizers(nil)
+	if err := c.Update(ctx, clusterDeployment); err != nil {
+		t.Fatalf("Failed to remove finalizer: %v", err)
+	}
+	if err := discovery.sync(ctx); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if err := c.Get(ctx, key, &target); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the target to be deleted with the ClusterDeployment, got %v", err)
+	}
+}
+
+func TestHiveDiscovery_SkipsRegisteredClusters(t *testing.T) {
+	clusterDeployment, secret := hiveCluster("spoke", "spoke", "https://api.spoke.example.com:6443")
+	// The same cluster already discovered from its ACM ManagedCluster
+	acmTarget := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "acm-spoke",
+			Namespace: testOperatorNamespace,
+			Labels:    map[string]string{DiscoveredByLabel: "acm"},
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{
+			UUID:          "acm-spoke",
+			ClusterName:   "spoke",
+			ClusterAPIURL: "https://api.spoke.example.com:6443",
+			SecretType:    "kubeconfig",
+			SecretUUID:    "acm-spoke",
+		},
+	}
+	discovery, c, _ := setupTestHiveDiscovery(clusterDeployment, secret, acmTarget)
+	ctx := context.Background()
+
+	if err := discovery.sync(ctx); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "hive-spoke", Namespace: testOperatorNamespace},
+		&krknv1alpha1.KrknOperatorTarget{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected no target for a cluster discovered from ACM, got %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(acmTarget), acmTarget); err != nil {
+		t.Errorf("Expected the ACM target to be kept: %v", err)
+	}
+}
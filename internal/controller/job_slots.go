@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// jobSlotRecheckInterval is how often a run with queued jobs checks for a free job slot.
+// Finished jobs of the run itself free their slot on the reconcile they trigger.
+const jobSlotRecheckInterval = 15 * time.Second
+
+// jobSlots counts the scenario jobs holding a slot of the operator-wide limit per run.
+// Runs record their count under the lock when they create jobs, so parallel reconciles
+// never exceed the limit; the counts are seeded from the run statuses on first use.
+type jobSlots struct {
+	mu     sync.Mutex
+	active map[types.NamespacedName]int
+}
+
+// activeJobCount returns the jobs of a run holding a slot: created and not finished yet
+func activeJobCount(scenarioRun *krknv1alpha1.KrknScenarioRun) int {
+	count := 0
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		if job.JobID != "" && (job.Phase == statemachine.JobPending || job.Phase == statemachine.JobRunning) {
+			count++
+		}
+	}
+	return count
+}
+
+// queuedForSlot reports whether a cluster job waits for a free job slot before its Job
+// is created: a first attempt has no job ID yet, a retry stays Retrying
+func queuedForSlot(job *krknv1alpha1.ClusterJobStatus) bool {
+	if !job.Queued {
+		return false
+	}
+	return job.Phase == statemachine.JobRetrying || (job.Phase == statemachine.JobPending && job.JobID == "")
+}
+
+// acquireJobSlots reserves up to wanted job slots for scenarioRun within its
+// spec.maxConcurrency and the operator-wide MaxConcurrentJobs, and returns how many it got
+func (r *KrknScenarioRunReconciler) acquireJobSlots(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	wanted int,
+) (int, error) {
+	active := activeJobCount(scenarioRun)
+	granted := wanted
+	if limit := scenarioRun.Spec.MaxConcurrency; limit > 0 {
+		granted = min(granted, max(limit-active, 0))
+	}
+	if r.MaxConcurrentJobs <= 0 {
+		return granted, nil
+	}
+
+	r.slots.mu.Lock()
+	defer r.slots.mu.Unlock()
+	if r.slots.active == nil {
+		var runs krknv1alpha1.KrknScenarioRunList
+		if err := r.List(ctx, &runs, client.InNamespace(r.Namespace)); err != nil {
+			return 0, fmt.Errorf("failed to count the running jobs of scenario runs: %w", err)
+		}
+		r.slots.active = make(map[types.NamespacedName]int, len(runs.Items))
+		for i := range runs.Items {
+			if count := activeJobCount(&runs.Items[i]); count > 0 {
+				r.slots.active[client.ObjectKeyFromObject(&runs.Items[i])] = count
+			}
+		}
+	}
+
+	key := client.ObjectKeyFromObject(scenarioRun)
+	others := 0
+	for run, count := range r.slots.active {
+		if run != key {
+			others += count
+		}
+	}
+	granted = min(granted, max(r.MaxConcurrentJobs-others-active, 0))
+	r.slots.active[key] = active + granted
+	return granted, nil
+}
+
+// releaseJobSlots records the jobs of scenarioRun still holding a slot of the
+// operator-wide limit, freeing the slots of its finished jobs and unused reservations
+func (r *KrknScenarioRunReconciler) releaseJobSlots(scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	r.setJobSlots(client.ObjectKeyFromObject(scenarioRun), activeJobCount(scenarioRun))
+}
+
+// setJobSlots records the jobs of the run key holding a slot, forgetting runs without any
+func (r *KrknScenarioRunReconciler) setJobSlots(key types.NamespacedName, count int) {
+	if r.MaxConcurrentJobs <= 0 {
+		return
+	}
+	r.slots.mu.Lock()
+	defer r.slots.mu.Unlock()
+	if r.slots.active == nil {
+		// Not seeded yet, the count is read from the run status when seeding
+		return
+	}
+	if count == 0 {
+		delete(r.slots.active, key)
+		return
+	}
+	r.slots.active[key] = count
+}
+
+// queueForSlot marks the job of a cluster queued until a job slot frees up, creating
+// the entry of a first attempt. Clusters of different providers may share a name, so the
+// entry is matched on both.
+func queueForSlot(scenarioRun *krknv1alpha1.KrknScenarioRun, providerName, clusterName string) {
+	var job *krknv1alpha1.ClusterJobStatus
+	for i := range scenarioRun.Status.ClusterJobs {
+		if scenarioRun.Status.ClusterJobs[i].ProviderName == providerName &&
+			scenarioRun.Status.ClusterJobs[i].ClusterName == clusterName {
+			job = &scenarioRun.Status.ClusterJobs[i]
+			break
+		}
+	}
+	if job == nil {
+		scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{
+			ProviderName: providerName,
+			ClusterName:  clusterName,
+			ClusterID:    scenarioRun.TargetClusterID(providerName, clusterName),
+			Phase:        statemachine.Jobs.Initial(),
+		})
+		job = &scenarioRun.Status.ClusterJobs[len(scenarioRun.Status.ClusterJobs)-1]
+	}
+	// The maintenance that held the job, if any, ended
+	job.MaintenanceReason = ""
+	job.Queued = true
+	job.Message = "Queued, waiting for a free job slot"
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func slotTestRun(name string, maxConcurrency int, phases ...string) *krknv1alpha1.KrknScenarioRun {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       krknv1alpha1.KrknScenarioRunSpec{ScenarioName: "pod-scenarios", MaxConcurrency: maxConcurrency},
+	}
+	for i, phase := range phases {
+		scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{
+			ClusterName: fmt.Sprintf("%s-cluster%d", name, i+1),
+			JobID:       fmt.Sprintf("%s-job%d", name, i+1),
+			Phase:       phase,
+		})
+	}
+	return scenarioRun
+}
+
+func setupJobSlotsTest(maxJobs int, objects ...client.Object) *KrknScenarioRunReconciler {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default", MaxConcurrentJobs: maxJobs}
+}
+
+func TestAcquireJobSlots_RunLimit(t *testing.T) {
+	reconciler := setupJobSlotsTest(0)
+	scenarioRun := slotTestRun("run-a", 2, statemachine.JobRunning, statemachine.JobSucceeded)
+	ctx := context.Background()
+
+	granted, err := reconciler.acquireJobSlots(ctx, scenarioRun, 1)
+	if err != nil || granted != 1 {
+		t.Fatalf("Expected 1 slot next to one running job, got %d (%v)", granted, err)
+	}
+	scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs,
+		krknv1alpha1.ClusterJobStatus{ClusterName: "run-a-cluster3", JobID: "run-a-job3", Phase: statemachine.JobPending})
+	if granted, _ := reconciler.acquireJobSlots(ctx, scenarioRun, 1); granted != 0 {
+		t.Errorf("Expected no slot with maxConcurrency reached, got %d", granted)
+	}
+
+	scenarioRun.Spec.MaxConcurrency = 0
+	if granted, _ := reconciler.acquireJobSlots(ctx, scenarioRun, 3); granted != 3 {
+		t.Errorf("Expected no limit without maxConcurrency, got %d", granted)
+	}
+}
+
+func TestAcquireJobSlots_OperatorLimit(t *testing.T) {
+	runA := slotTestRun("run-a", 0, statemachine.JobRunning, statemachine.JobPending, statemachine.JobFailed)
+	runB := slotTestRun("run-b", 0)
+	reconciler := setupJobSlotsTest(3, runA, runB)
+	ctx := context.Background()
+
+	// The jobs of run-a are counted from its status
+	granted, err := reconciler.acquireJobSlots(ctx, runB, 2)
+	if err != nil || granted != 1 {
+		t.Fatalf("Expected 1 slot left by run-a, got %d (%v)", granted, err)
+	}
+	if granted, _ := reconciler.acquireJobSlots(ctx, runA, 1); granted != 0 {
+		t.Errorf("Expected the reservation of run-b to count, got %d", granted)
+	}
+
+	// run-b did not create its job, run-a finished one
+	reconciler.releaseJobSlots(runB)
+	runA.Status.ClusterJobs[0].Phase = statemachine.JobSucceeded
+	reconciler.releaseJobSlots(runA)
+	if granted, _ := reconciler.acquireJobSlots(ctx, runA, 3); granted != 2 {
+		t.Errorf("Expected the released slots to be available, got %d", granted)
+	}
+
+	// A deleted run frees its slots
+	reconciler.setJobSlots(client.ObjectKeyFromObject(runA), 0)
+	if granted, _ := reconciler.acquireJobSlots(ctx, runB, 5); granted != 3 {
+		t.Errorf("Expected all slots once run-a is gone, got %d", granted)
+	}
+}
+
+func TestQueueForSlot(t *testing.T) {
+	scenarioRun := slotTestRun("run-a", 1)
+	queueForSlot(scenarioRun, "krkn-operator", "cluster1")
+
+	job := &scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobPending || job.JobID != "" || !queuedForSlot(job) {
+		t.Fatalf("Expected a queued Pending job, got %+v", *job)
+	}
+	reconciler := setupJobSlotsTest(0)
+	if reconciler.jobExistsForCluster(scenarioRun, "cluster1") {
+		t.Error("Expected a queued job to be created once a slot frees up")
+	}
+
+	job.Phase = statemachine.JobRetrying
+	job.JobID = "job-1"
+	if !queuedForSlot(job) {
+		t.Error("Expected a queued retry to wait for a slot")
+	}
+	job.Phase = statemachine.JobRunning
+	if queuedForSlot(job) {
+		t.Error("Expected a running job not to wait for a slot")
+	}
+
+	// A cluster of another provider with the same name gets its own entry
+	queueForSlot(scenarioRun, "acm", "cluster1")
+	if len(scenarioRun.Status.ClusterJobs) != 2 {
+		t.Fatalf("Expected a second entry for the other provider, got %+v", scenarioRun.Status.ClusterJobs)
+	}
+	if other := scenarioRun.Status.ClusterJobs[1]; other.ProviderName != "acm" || !queuedForSlot(&other) {
+		t.Errorf("Expected a queued entry for the acm cluster, got %+v", other)
+	}
+	if scenarioRun.Status.ClusterJobs[0].Phase != statemachine.JobRunning {
+		t.Errorf("Expected the krkn-operator job to be left alone, got %+v", scenarioRun.Status.ClusterJobs[0])
+	}
+}
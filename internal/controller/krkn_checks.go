@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// Environment variables krkn-hub reads its health check and alert settings from
+const (
+	healthCheckURLEnv         = "HEALTH_CHECK_URL"
+	healthCheckIntervalEnv    = "HEALTH_CHECK_INTERVAL"
+	healthCheckBearerTokenEnv = "HEALTH_CHECK_BEARER_TOKEN"
+	healthCheckExitEnv        = "HEALTH_CHECK_EXIT"
+	healthCheckVerifyEnv      = "HEALTH_CHECK_VERIFY"
+	alertsEnableEnv           = "ENABLE_ALERTS"
+	alertsPathEnv             = "ALERTS_PATH"
+	alertsCheckCriticalEnv    = "CHECK_CRITICAL_ALERTS"
+)
+
+// Generated alert profile file, mounted next to the krkn configuration
+const (
+	alertProfileFileName  = "krkn-alerts.yaml"
+	alertProfileMountPath = "/home/krkn/kraken/config/" + alertProfileFileName
+)
+
+// krknChecksConfig translates the health check and alert profile of a run into the
+// krkn-hub environment and the files to mount in the scenario container
+func krknChecksConfig(healthCheck *krknv1alpha1.HealthCheck, alertProfile *krknv1alpha1.AlertProfile) ([]corev1.EnvVar, []krknv1alpha1.FileMount, error) {
+	var env []corev1.EnvVar
+	var files []krknv1alpha1.FileMount
+
+	if healthCheck != nil {
+		env = append(env,
+			corev1.EnvVar{Name: healthCheckURLEnv, Value: healthCheck.URL},
+			corev1.EnvVar{Name: healthCheckExitEnv, Value: krknBool(healthCheck.ExitOnFailure)},
+			corev1.EnvVar{Name: healthCheckVerifyEnv, Value: krknBool(healthCheck.VerifyCerts)})
+		if healthCheck.IntervalSeconds != nil {
+			env = append(env, corev1.EnvVar{Name: healthCheckIntervalEnv, Value: strconv.Itoa(int(*healthCheck.IntervalSeconds))})
+		}
+		if token := healthCheck.BearerTokenSecret; token != nil {
+			env = append(env, secretEnvVar(healthCheckBearerTokenEnv, token.Name, token.Key))
+		}
+	}
+
+	if alertProfile != nil {
+		// The krkn profile format is the alert rules themselves
+		content, err := yaml.Marshal(alertProfile.Alerts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode alert profile: %w", err)
+		}
+		files = append(files, krknv1alpha1.FileMount{
+			Name:      alertProfileFileName,
+			Content:   base64.StdEncoding.EncodeToString(content),
+			MountPath: alertProfileMountPath,
+		})
+		env = append(env,
+			corev1.EnvVar{Name: alertsEnableEnv, Value: "True"},
+			corev1.EnvVar{Name: alertsPathEnv, Value: alertProfileMountPath},
+			corev1.EnvVar{Name: alertsCheckCriticalEnv, Value: krknBool(alertProfile.FailOnCritical)})
+	}
+	return env, files, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/base64"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestKrknChecksConfig(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		env, files, err := krknChecksConfig(nil, nil)
+		if err != nil || len(env) != 0 || len(files) != 0 {
+			t.Errorf("expected nothing injected, got %v %v %v", env, files, err)
+		}
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		env, files, err := krknChecksConfig(&krknv1alpha1.HealthCheck{
+			URL:             "https://app.example.com/health",
+			IntervalSeconds: ptr.To[int32](5),
+			BearerTokenSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app-token"},
+				Key:                  "token",
+			},
+			ExitOnFailure: true,
+		}, nil)
+		if err != nil {
+			t.Fatalf("krknChecksConfig() error = %v", err)
+		}
+		if len(files) != 0 {
+			t.Errorf("expected no file, got %v", files)
+		}
+		values := make(map[string]string)
+		for _, variable := range env {
+			if variable.ValueFrom != nil {
+				ref := variable.ValueFrom.SecretKeyRef
+				if variable.Name != healthCheckBearerTokenEnv || ref.Name != "app-token" || ref.Key != "token" {
+					t.Errorf("unexpected secret variable %v", variable)
+				}
+				continue
+			}
+			values[variable.Name] = variable.Value
+		}
+		want := map[string]string{
+			healthCheckURLEnv:      "https://app.example.com/health",
+			healthCheckIntervalEnv: "5",
+			healthCheckExitEnv:     "True",
+			healthCheckVerifyEnv:   "False",
+		}
+		if len(values) != len(want) {
+			t.Errorf("expected %v, got %v", want, values)
+		}
+		for name, value := range want {
+			if values[name] != value {
+				t.Errorf("expected %s=%q, got %q", name, value, values[name])
+			}
+		}
+	})
+
+	t.Run("alert profile", func(t *testing.T) {
+		env, files, err := krknChecksConfig(nil, &krknv1alpha1.AlertProfile{
+			Alerts: []krknv1alpha1.AlertRule{{
+				Expr:        "up == 0",
+				Description: "{{$labels.instance}} is down",
+				Severity:    "critical",
+			}},
+			FailOnCritical: true,
+		})
+		if err != nil {
+			t.Fatalf("krknChecksConfig() error = %v", err)
+		}
+		if len(files) != 1 || files[0].MountPath != alertProfileMountPath {
+			t.Fatalf("expected the alert profile to be mounted, got %v", files)
+		}
+		content, _ := base64.StdEncoding.DecodeString(files[0].Content)
+		wantProfile := "- description: '{{$labels.instance}} is down'\n  expr: up == 0\n  severity: critical\n"
+		if string(content) != wantProfile {
+			t.Errorf("expected profile %q, got %q", wantProfile, content)
+		}
+		values := make(map[string]string)
+		for _, variable := range env {
+			values[variable.Name] = variable.Value
+		}
+		if values[alertsEnableEnv] != "True" || values[alertsPathEnv] != alertProfileMountPath || values[alertsCheckCriticalEnv] != "True" {
+			t.Errorf("unexpected alert variables %v", values)
+		}
+	})
+}
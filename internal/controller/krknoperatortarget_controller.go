@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+)
+
+// KrknOperatorTargetReconciler checks the connectivity of the KrknOperatorTarget clusters
+// and purges archived targets once their restore window has expired
+type KrknOperatorTargetReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	OperatorNamespace string
+	// MaxConcurrentReconciles is the number of parallel workers (0 uses the controller-runtime default)
+	MaxConcurrentReconciles int
+	// Prober checks the connectivity of the targets (nil disables the checks)
+	Prober TargetProber
+	// ProbeInterval is the time between two checks of a target (0 disables the checks)
+	ProbeInterval time.Duration
+	// Cipher opens the target kubeconfigs sealed at rest (nil when encryption is off)
+	Cipher *envelope.Cipher
+	// CredentialsStore holds the credentials of the targets with a Vault path (nil when
+	// targets only use Secrets)
+	CredentialsStore secrets.Store
+	// TokenRotator re-mints the tokens of targets with automatic token rotation (nil
+	// disables the rotation)
+	TokenRotator *TargetTokenRotator
+}
+
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;update;delete
+
+// Reconcile checks the connectivity of active targets every ProbeInterval and re-mints
+// the tokens of those with automatic token rotation before they expire, and deletes
+// archived targets and their credential secrets after PurgeAfter. Targets still inside
+// the restore window are requeued for their purge deadline.
+func (r *KrknOperatorTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := r.Get(ctx, req.NamespacedName, &target); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !target.Status.Archived {
+		rotation := r.rotateTargetToken(ctx, &target)
+		result, err := r.probeTarget(ctx, &target)
+		if err == nil && !result.Requeue && rotation.RequeueAfter > 0 &&
+			(result.RequeueAfter == 0 || rotation.RequeueAfter < result.RequeueAfter) {
+			result.RequeueAfter = rotation.RequeueAfter
+		}
+		return result, err
+	}
+	if target.Status.PurgeAfter == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if remaining := time.Until(target.Status.PurgeAfter.Time); remaining > 0 {
+		logger.V(1).Info("Archived target still within restore window",
+			"uuid", target.Spec.UUID,
+			"purgeAfter", target.Status.PurgeAfter.Time)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	logger.Info("🗑️ Purging archived target",
+		"uuid", target.Spec.UUID,
+		"clusterName", target.Spec.ClusterName)
+
+	if target.Spec.VaultPath != "" && r.CredentialsStore != nil {
+		if err := r.CredentialsStore.Delete(ctx, target.Spec.VaultPath); err != nil {
+			logger.Error(err, "Failed to delete credentials for archived target", "vaultPath", target.Spec.VaultPath)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if target.Spec.SecretUUID != "" {
+		var secret corev1.Secret
+		err := r.Get(ctx, types.NamespacedName{Name: target.Spec.SecretUUID, Namespace: target.Namespace}, &secret)
+		if err == nil {
+			if err := r.Delete(ctx, &secret); client.IgnoreNotFound(err) != nil {
+				logger.Error(err, "Failed to delete secret for archived target", "secret", target.Spec.SecretUUID)
+				return ctrl.Result{}, err
+			}
+		} else if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to get secret for archived target", "secret", target.Spec.SecretUUID)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.Delete(ctx, &target); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to delete archived target", "uuid", target.Spec.UUID)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *KrknOperatorTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&krknv1alpha1.KrknOperatorTarget{}).
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Named("krknoperatortarget").
+		WithEventFilter(NewNamespaceFilter(r.OperatorNamespace)).
+		Complete(r)
+}
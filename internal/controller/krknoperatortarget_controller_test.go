@@ -0,0 +1,323 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+)
+
+func setupTestTargetReconciler(objs ...client.Object) *KrknOperatorTargetReconciler {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}).
+		Build()
+
+	return &KrknOperatorTargetReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		OperatorNamespace: testOperatorNamespace,
+	}
+}
+
+func archivedTarget(purgeAfter time.Time) *krknv1alpha1.KrknOperatorTarget {
+	archivedAt := metav1.NewTime(purgeAfter.Add(-time.Hour))
+	purge := metav1.NewTime(purgeAfter)
+	return &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "target-uuid",
+			Namespace: testOperatorNamespace,
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{
+			UUID:        "target-uuid",
+			ClusterName: "test-cluster",
+			SecretUUID:  "secret-uuid",
+		},
+		Status: krknv1alpha1.KrknOperatorTargetStatus{
+			Archived:   true,
+			ArchivedAt: &archivedAt,
+			PurgeAfter: &purge,
+		},
+	}
+}
+
+func targetSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "secret-uuid",
+			Namespace: testOperatorNamespace,
+		},
+	}
+}
+
+func TestTargetReconcile_PurgesExpiredArchive(t *testing.T) {
+	reconciler := setupTestTargetReconciler(archivedTarget(time.Now().Add(-time.Minute)), targetSecret())
+	ctx := context.Background()
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var target krknv1alpha1.KrknOperatorTarget
+	err = reconciler.Get(ctx, types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}, &target)
+	if client.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("Expected target to be purged, got err=%v", err)
+	}
+
+	var secret corev1.Secret
+	err = reconciler.Get(ctx, types.NamespacedName{Name: "secret-uuid", Namespace: testOperatorNamespace}, &secret)
+	if client.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("Expected secret to be purged, got err=%v", err)
+	}
+}
+
+func TestTargetReconcile_RequeuesWithinRestoreWindow(t *testing.T) {
+	reconciler := setupTestTargetReconciler(archivedTarget(time.Now().Add(time.Hour)), targetSecret())
+	ctx := context.Background()
+
+	result, err := reconciler.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour {
+		t.Errorf("Expected requeue within the restore window, got %v", result.RequeueAfter)
+	}
+
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}, &target); err != nil {
+		t.Errorf("Expected target to be kept: %v", err)
+	}
+}
+
+func TestTargetReconcile_IgnoresActiveTarget(t *testing.T) {
+	target := archivedTarget(time.Now().Add(-time.Minute))
+	target.Status = krknv1alpha1.KrknOperatorTargetStatus{Ready: true}
+	reconciler := setupTestTargetReconciler(target)
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue for active target, got %v", result.RequeueAfter)
+	}
+}
+
+// fakeTargetProber returns err and records the probed kubeconfigs
+type fakeTargetProber struct {
+	err    error
+	probed []string
+}
+
+func (p *fakeTargetProber) Probe(_ context.Context, kubeconfigBase64 string) error {
+	p.probed = append(p.probed, kubeconfigBase64)
+	return p.err
+}
+
+func probedTarget(t *testing.T, ready bool) (*krknv1alpha1.KrknOperatorTarget, *corev1.Secret) {
+	data, err := kubeconfig.MarshalSecretData("a3ViZWNvbmZpZw==")
+	if err != nil {
+		t.Fatalf("MarshalSecretData() error = %v", err)
+	}
+	target := archivedTarget(time.Now())
+	target.Status = krknv1alpha1.KrknOperatorTargetStatus{Ready: ready}
+	secret := targetSecret()
+	secret.Data = map[string][]byte{"kubeconfig": data}
+	return target, secret
+}
+
+func TestTargetReconcile_Probe(t *testing.T) {
+	key := types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}
+
+	tests := []struct {
+		name      string
+		ready     bool
+		noSecret  bool
+		probeErr  error
+		wantReady bool
+		wantError string
+	}{
+		{name: "reachable", ready: false, wantReady: true},
+		{name: "unreachable", ready: true, probeErr: errors.New("connection refused"), wantReady: false, wantError: "connection refused"},
+		{name: "secret missing", ready: true, noSecret: true, wantReady: false, wantError: "secret secret-uuid not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, secret := probedTarget(t, tt.ready)
+			objs := []client.Object{target}
+			if !tt.noSecret {
+				objs = append(objs, secret)
+			}
+			prober := &fakeTargetProber{err: tt.probeErr}
+			reconciler := setupTestTargetReconciler(objs...)
+			reconciler.Prober = prober
+			reconciler.ProbeInterval = time.Minute
+
+			result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if result.RequeueAfter != time.Minute {
+				t.Errorf("Expected requeue after the probe interval, got %v", result.RequeueAfter)
+			}
+
+			var updated krknv1alpha1.KrknOperatorTarget
+			if err := reconciler.Get(context.Background(), key, &updated); err != nil {
+				t.Fatalf("Failed to get target: %v", err)
+			}
+			if updated.Status.Ready != tt.wantReady {
+				t.Errorf("Expected ready=%v, got %v", tt.wantReady, updated.Status.Ready)
+			}
+			if updated.Status.LastProbeTime == nil {
+				t.Error("Expected the probe time to be recorded")
+			}
+			if updated.Status.ProbeError != tt.wantError {
+				t.Errorf("Expected probe error %q, got %q", tt.wantError, updated.Status.ProbeError)
+			}
+			if (updated.Status.ProbeLatencyMilliseconds != nil) != tt.wantReady {
+				t.Errorf("Expected latency only when reachable, got %v", updated.Status.ProbeLatencyMilliseconds)
+			}
+			if !tt.noSecret && (len(prober.probed) != 1 || prober.probed[0] != "a3ViZWNvbmZpZw==") {
+				t.Errorf("Expected the stored kubeconfig to be probed, got %v", prober.probed)
+			}
+		})
+	}
+}
+
+func TestTargetReconcile_ProbeCircuitOpen(t *testing.T) {
+	key := types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}
+	target, secret := probedTarget(t, true)
+	reconciler := setupTestTargetReconciler(target, secret)
+	reconciler.Prober = &fakeTargetProber{err: fmt.Errorf("data provider: %w", grpcclient.ErrCircuitOpen)}
+	reconciler.ProbeInterval = time.Minute
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != time.Minute {
+		t.Errorf("Expected requeue after the probe interval, got %v", result.RequeueAfter)
+	}
+	var updated krknv1alpha1.KrknOperatorTarget
+	if err := reconciler.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	if !updated.Status.Ready || updated.Status.LastProbeTime != nil || updated.Status.ProbeError != "" {
+		t.Errorf("Expected the status to be kept while the data provider is down, got %+v", updated.Status)
+	}
+}
+
+func TestTargetReconcile_ProbeFlagsAuthPlugins(t *testing.T) {
+	execKubeconfig := base64.StdEncoding.EncodeToString([]byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: eks
+  cluster:
+    server: https://eks.example.com
+contexts:
+- name: eks
+  context:
+    cluster: eks
+    user: eks
+current-context: eks
+users:
+- name: eks
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: aws-iam-authenticator
+      args: [token, -i, eks]
+`))
+	data, err := kubeconfig.MarshalSecretData(execKubeconfig)
+	if err != nil {
+		t.Fatalf("MarshalSecretData() error = %v", err)
+	}
+	target, secret := probedTarget(t, true)
+	secret.Data["kubeconfig"] = data
+	reconciler := setupTestTargetReconciler(target, secret)
+	reconciler.Prober = &fakeTargetProber{}
+	reconciler.ProbeInterval = time.Minute
+
+	key := types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	var updated krknv1alpha1.KrknOperatorTarget
+	if err := reconciler.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	if !slices.Equal(updated.Status.AuthPlugins, []string{"aws-iam-authenticator"}) {
+		t.Errorf("Expected the exec plugin to be recorded, got %v", updated.Status.AuthPlugins)
+	}
+	if !apimeta.IsStatusConditionTrue(updated.Status.Conditions, krknv1alpha1.TargetAuthPluginRequired) {
+		t.Errorf("Expected the AuthPluginRequired condition, got %+v", updated.Status.Conditions)
+	}
+}
+
+func TestTargetReconcile_ProbeWaitsForInterval(t *testing.T) {
+	target, secret := probedTarget(t, true)
+	lastProbe := metav1.NewTime(time.Now().Add(-20 * time.Second))
+	target.Status.LastProbeTime = &lastProbe
+	prober := &fakeTargetProber{}
+	reconciler := setupTestTargetReconciler(target, secret)
+	reconciler.Prober = prober
+	reconciler.ProbeInterval = time.Minute
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if len(prober.probed) != 0 {
+		t.Errorf("Expected no probe within the interval, got %d", len(prober.probed))
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 40*time.Second {
+		t.Errorf("Expected requeue for the rest of the interval, got %v", result.RequeueAfter)
+	}
+}
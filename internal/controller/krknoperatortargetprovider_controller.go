@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// KrknOperatorTargetProviderReconciler reports the activation changes of the
+// KrknOperatorTargetProvider resources as events and metrics
+type KrknOperatorTargetProviderReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	OperatorNamespace string
+	// Recorder emits activation events (nil disables events)
+	Recorder record.EventRecorder
+	// MaxConcurrentReconciles is the number of parallel workers (0 uses the controller-runtime default)
+	MaxConcurrentReconciles int
+}
+
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargetproviders,verbs=get;list;watch
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargetproviders/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile compares the activation of a provider with the one last observed. A change
+// is recorded as an event with the reason given by the admin, counted, and stored in the
+// status with its time. The first observation only initializes the status.
+func (r *KrknOperatorTargetProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var provider krknv1alpha1.KrknOperatorTargetProvider
+	if err := r.Get(ctx, req.NamespacedName, &provider); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			providerActive.DeleteLabelValues(req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	name := provider.Spec.OperatorName
+	active := provider.Spec.Active
+	if active {
+		providerActive.WithLabelValues(provider.Name).Set(1)
+	} else {
+		providerActive.WithLabelValues(provider.Name).Set(0)
+	}
+
+	observed := provider.Status.ObservedActive
+	if observed != nil && *observed == active {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(provider.DeepCopy())
+	now := metav1.Now()
+	provider.Status.ObservedActive = &active
+	provider.Status.ActivationChangedAt = &now
+
+	if observed != nil {
+		reason := provider.Annotations[krknv1alpha1.ProviderActivationReasonAnnotation]
+		changedBy := provider.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation]
+		if changedBy == "" {
+			changedBy = "the provider"
+		}
+		if reason == "" {
+			reason = "no reason given"
+		}
+
+		providerActivationChanges.WithLabelValues(provider.Name, strconv.FormatBool(active)).Inc()
+		if active {
+			recordEvent(r.Recorder, &provider, corev1.EventTypeNormal, EventProviderActivated,
+				"Provider %s activated by %s: %s", name, changedBy, reason)
+		} else {
+			recordEvent(r.Recorder, &provider, corev1.EventTypeWarning, EventProviderDeactivated,
+				"Provider %s deactivated by %s: %s", name, changedBy, reason)
+		}
+		logger.Info("Provider activation changed",
+			"provider", name,
+			"active", active,
+			"changedBy", changedBy,
+			"reason", reason)
+	}
+
+	// Patched so concurrent heartbeats do not conflict
+	if err := r.Status().Patch(ctx, &provider, patch); err != nil {
+		logger.Error(err, "Failed to update provider activation status", "provider", name)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *KrknOperatorTargetProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&krknv1alpha1.KrknOperatorTargetProvider{}).
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Named(TargetProviderControllerName).
+		WithEventFilter(NewNamespaceFilter(r.OperatorNamespace)).
+		Complete(r)
+}
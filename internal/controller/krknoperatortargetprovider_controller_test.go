@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestProviderReconcile_ActivationChanges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	provider := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "activation-provider", Namespace: testOperatorNamespace},
+		Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: "activation-provider", Active: true},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(provider).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTargetProvider{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &KrknOperatorTargetProviderReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		OperatorNamespace: testOperatorNamespace,
+		Recorder:          recorder,
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: provider.Name, Namespace: testOperatorNamespace}
+	reconcile := func() *krknv1alpha1.KrknOperatorTargetProvider {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+		var current krknv1alpha1.KrknOperatorTargetProvider
+		if err := fakeClient.Get(ctx, key, &current); err != nil {
+			t.Fatalf("Failed to get provider: %v", err)
+		}
+		return &current
+	}
+	changes := func(active string) float64 {
+		return testutil.ToFloat64(providerActivationChanges.WithLabelValues(provider.Name, active))
+	}
+
+	// The first observation initializes the status without an event
+	current := reconcile()
+	if current.Status.ObservedActive == nil || !*current.Status.ObservedActive || current.Status.ActivationChangedAt == nil {
+		t.Fatalf("Expected the activation to be observed, got %+v", current.Status)
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("Expected no event on first observation, got %v", events)
+	}
+	if got := testutil.ToFloat64(providerActive.WithLabelValues(provider.Name)); got != 1 {
+		t.Errorf("Expected the provider to be reported active, got %v", got)
+	}
+
+	current.Spec.Active = false
+	current.Annotations = map[string]string{
+		krknv1alpha1.ProviderActivationReasonAnnotation:    "maintenance",
+		krknv1alpha1.ProviderActivationChangedByAnnotation: "admin@example.com",
+	}
+	if err := fakeClient.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to update provider: %v", err)
+	}
+	current = reconcile()
+	if *current.Status.ObservedActive {
+		t.Error("Expected the deactivation to be observed")
+	}
+	if events := drainEvents(recorder); !slices.Equal(events, []string{"Warning " + EventProviderDeactivated}) {
+		t.Errorf("Expected a deactivation event, got %v", events)
+	}
+	if got := changes("false"); got != 1 {
+		t.Errorf("Expected 1 deactivation, got %v", got)
+	}
+	if got := testutil.ToFloat64(providerActive.WithLabelValues(provider.Name)); got != 0 {
+		t.Errorf("Expected the provider to be reported inactive, got %v", got)
+	}
+
+	// Unchanged activations are not reported again
+	reconcile()
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("Expected no event without a change, got %v", events)
+	}
+	if got := changes("false"); got != 1 {
+		t.Errorf("Expected 1 deactivation, got %v", got)
+	}
+
+	current.Spec.Active = true
+	if err := fakeClient.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to update provider: %v", err)
+	}
+	reconcile()
+	if events := drainEvents(recorder); !slices.Equal(events, []string{"Normal " + EventProviderActivated}) {
+		t.Errorf("Expected an activation event, got %v", events)
+	}
+	if got := changes("true"); got != 1 {
+		t.Errorf("Expected 1 activation, got %v", got)
+	}
+}
@@ -19,6 +19,7 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package controller
 
 import (
+	"cmp"
 	"context"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
@@ -38,6 +40,11 @@ type KrknOperatorTargetProviderConfigReconciler struct {
 	Scheme            *runtime.Scheme
 	OperatorName      string
 	OperatorNamespace string
+	// ControllerName names the controller, TargetProviderConfigControllerName when empty;
+	// set it when the operator runs several providers
+	ControllerName string
+	// MaxConcurrentReconciles is the number of parallel workers (0 uses the controller-runtime default)
+	MaxConcurrentReconciles int
 }
 
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargetproviderconfigs,verbs=get;list;watch;update;patch;delete
@@ -136,24 +143,14 @@ func (r *KrknOperatorTargetProviderConfigReconciler) Reconcile(ctx context.Conte
 	// 5.7. Contribute krkn-operator's configuration (empty marker for now)
 	logger.Info("Contributing krkn-operator configuration", "uuid", config.Spec.UUID)
 
-	// Direct status update with empty ProviderConfigData (no ConfigMap/schema needed)
+	// Empty contribution marker (no ConfigMap/schema needed), merged into the status without
+	// touching the entries of other providers.
 	// We don't use provider.UpdateProviderConfig() because it requires non-empty configMapName
-	if config.Status.ConfigData == nil {
-		config.Status.ConfigData = make(map[string]krknv1alpha1.ProviderConfigData)
-	}
-
-	// Add empty contribution marker for krkn-operator
-	config.Status.ConfigData[r.OperatorName] = krknv1alpha1.ProviderConfigData{
+	if err := provider.ContributeProviderConfig(ctx, r.Client, &config, r.OperatorName, krknv1alpha1.ProviderConfigData{
 		ConfigMap:    "", // Empty - no config to expose yet
 		Namespace:    r.OperatorNamespace,
 		ConfigSchema: "", // Empty - no schema yet
-	}
-
-	if err := r.Status().Update(ctx, &config); err != nil {
-		if isConflictError(err) {
-			logger.Info("Conflict updating provider config, will retry")
-			return ctrl.Result{Requeue: true}, nil
-		}
+	}); err != nil {
 		logger.Error(err, "Failed to update provider config")
 		return ctrl.Result{}, err
 	}
@@ -256,12 +253,14 @@ func (r *KrknOperatorTargetProviderConfigReconciler) checkCompletion(ctx context
 		}
 	}
 
-	// Count contributors (operators that have added config data)
-	contributorCount := len(config.Status.ConfigData)
+	// Count contributors (operators that have added config data). Only contributions of
+	// active providers count, so an entry left by a deactivated provider cannot stand in
+	// for a missing one.
 	contributorNames := []string{}
 	for name := range config.Status.ConfigData {
 		contributorNames = append(contributorNames, name)
 	}
+	contributorCount := countActiveContributors(config.Status.ConfigData, activeProviderNames)
 
 	logger.Info("🔍 Checking completion",
 		"activeProviders", activeProviders,
@@ -270,7 +269,9 @@ func (r *KrknOperatorTargetProviderConfigReconciler) checkCompletion(ctx context
 		"contributorNames", contributorNames,
 		"uuid", config.Spec.UUID)
 
-	// If all active providers have contributed, mark as completed
+	// If all active providers have contributed, mark as completed.
+	// The update carries the resourceVersion the contributions were counted on, so a
+	// concurrent contribution makes it conflict and the count is redone on the latest status.
 	if activeProviders > 0 && contributorCount >= activeProviders {
 		logger.Info("✅ All active providers have contributed, marking as Completed",
 			"uuid", config.Spec.UUID,
@@ -300,7 +301,8 @@ func (r *KrknOperatorTargetProviderConfigReconciler) SetupWithManager(mgr ctrl.M
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&krknv1alpha1.KrknOperatorTargetProviderConfig{}).
-		Named("krknoperatortargetproviderconfig").
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Named(cmp.Or(r.ControllerName, TargetProviderConfigControllerName)).
 		WithEventFilter(NewNamespaceFilter(r.OperatorNamespace)).
 		Complete(r)
 }
@@ -454,6 +454,66 @@ func TestConfigReconcile_SkipsInactiveProviders(t *testing.T) {
 	}
 }
 
+func TestConfigReconcile_IgnoresInactiveContributions(t *testing.T) {
+	// Two entries for two active providers, but one comes from a deactivated provider
+	config := &krknv1alpha1.KrknOperatorTargetProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testConfigName,
+			Namespace: testOperatorNamespace,
+			Labels: map[string]string{
+				"krkn.krkn-chaos.dev/uuid": testConfigUUID,
+			},
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetProviderConfigSpec{
+			UUID: testConfigUUID,
+		},
+		Status: krknv1alpha1.KrknOperatorTargetProviderConfigStatus{
+			Status:  "pending",
+			Created: &metav1.Time{Time: time.Now()},
+			ConfigData: map[string]krknv1alpha1.ProviderConfigData{
+				"krkn-operator":          {ConfigMap: "krkn-operator-config"},
+				"krkn-operator-inactive": {ConfigMap: "krkn-operator-inactive-config"},
+			},
+		},
+	}
+
+	providers := []client.Object{config}
+	for name, active := range map[string]bool{
+		"krkn-operator":          true,
+		"krkn-operator-acm":      true,
+		"krkn-operator-inactive": false,
+	} {
+		providers = append(providers, &krknv1alpha1.KrknOperatorTargetProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testOperatorNamespace},
+			Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: name, Active: active},
+		})
+	}
+
+	reconciler := setupTestConfigReconciler(providers...)
+	ctx := context.Background()
+
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      testConfigName,
+			Namespace: testOperatorNamespace,
+		},
+	}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var updated krknv1alpha1.KrknOperatorTargetProviderConfig
+	if err := reconciler.Get(ctx, types.NamespacedName{
+		Name:      testConfigName,
+		Namespace: testOperatorNamespace,
+	}, &updated); err != nil {
+		t.Fatalf("Failed to get config: %v", err)
+	}
+
+	if updated.Status.Status == "Completed" {
+		t.Error("Expected status to stay pending until krkn-operator-acm contributes")
+	}
+}
+
 func TestConfigReconcile_SkipsAlreadyCompleted(t *testing.T) {
 	now := metav1.Now()
 	config := &krknv1alpha1.KrknOperatorTargetProviderConfig{
@@ -26,21 +26,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
 
 	"github.com/google/uuid"
 )
@@ -51,6 +60,28 @@ type KrknScenarioRunReconciler struct {
 	Scheme    *runtime.Scheme
 	Clientset kubernetes.Interface
 	Namespace string
+	// Notifications delivers the notifications of run observers and failure hooks in the
+	// background (optional)
+	Notifications *notify.Dispatcher
+	// ArtifactCleaners delete what runs stored outside the cluster when they are deleted (optional)
+	ArtifactCleaners []RunArtifactCleaner
+	// Artifacts collects the final log of finished jobs (optional)
+	Artifacts *ArtifactCollector
+	// Recorder records job and run phase transitions as Events on the run (optional)
+	Recorder record.EventRecorder
+	// HealthChecker checks target clusters with an alternate before their first job (optional)
+	HealthChecker ClusterHealthChecker
+	// CleanupVerifier verifies the targets of finished jobs returned to a clean state (optional)
+	CleanupVerifier CleanupVerifier
+	// Cipher opens the kubeconfigs sealed at rest in the managed-clusters Secrets (nil when encryption is off)
+	Cipher *envelope.Cipher
+	// MaxConcurrentReconciles is the number of parallel workers (0 uses the controller-runtime default)
+	MaxConcurrentReconciles int
+	// MaxConcurrentJobs caps the scenario jobs running at the same time across all runs,
+	// the jobs of the other clusters are queued (0 is unlimited)
+	MaxConcurrentJobs int
+
+	slots jobSlots
 }
 
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknscenarioruns,verbs=get;list;watch;create;update;patch;delete
@@ -58,10 +89,18 @@ type KrknScenarioRunReconciler struct {
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknscenarioruns/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krkntargetrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krkntargetgroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknregistries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknreports,verbs=get;create
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknscenariotemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
 
 // getOwnerLabel returns the sanitized owner label value for a scenario run.
 // If the scenario run has no OwnerUserID set, returns an empty string.
@@ -75,6 +114,12 @@ func getOwnerLabel(scenarioRun *krknv1alpha1.KrknScenarioRun) string {
 	return strings.ToLower(sanitized)
 }
 
+// runLabels returns the labels shared by the resources created for a cluster of a scenario run
+func runLabels(scenarioRun *krknv1alpha1.KrknScenarioRun, clusterName string) map[string]string {
+	return joblabels.Run(scenarioRun.Name, scenarioRun.Spec.ScenarioName, clusterName,
+		scenarioRun.Spec.TargetRequestID, getOwnerLabel(scenarioRun))
+}
+
 // Reconcile handles the reconciliation loop for KrknScenarioRun
 func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -88,38 +133,84 @@ func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if err := r.Get(ctx, req.NamespacedName, &scenarioRun); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("scenarioRun not found, probably deleted", "scenarioRun", req.Name)
+			r.setJobSlots(req.NamespacedName, 0)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch KrknScenarioRun")
 		return ctrl.Result{}, err
 	}
 
+	// Deleted runs first stop their pods and clean up their artifacts
+	if !scenarioRun.DeletionTimestamp.IsZero() {
+		return r.finalizeRun(ctx, &scenarioRun)
+	}
+	if err := r.ensureFinalizer(ctx, &scenarioRun); err != nil {
+		logger.Error(err, "unable to add finalizer", "scenarioRun", scenarioRun.Name)
+		return ctrl.Result{}, err
+	}
+
+	// Finished runs past their TTL are deleted with their resources
+	if remaining, ok := ttlRemaining(&scenarioRun, time.Now()); ok && remaining <= 0 {
+		return ctrl.Result{}, r.deleteExpiredRun(ctx, &scenarioRun)
+	}
+
 	// Initialize status if first reconcile
 	if scenarioRun.Status.Phase == "" {
-		// Calculate total targets
-		totalTargets := 0
-		for _, clusters := range scenarioRun.Spec.TargetClusters {
-			totalTargets += len(clusters)
+		// Runs referencing a template start with the scenario it renders
+		if err := r.renderTemplate(ctx, &scenarioRun); err != nil {
+			// The template may not exist yet, e.g. when applied along with the run
+			logger.Error(err, "failed to render template",
+				"scenarioRun", scenarioRun.Name,
+				"template", scenarioRun.Spec.TemplateRef)
+			recordEvent(r.Recorder, &scenarioRun, corev1.EventTypeWarning, EventTemplateUnresolved,
+				"Failed to render the template of the run: %v", err)
+			return ctrl.Result{RequeueAfter: targetResolutionRetryInterval}, nil
+		}
+
+		// The clusters the run hits, kept as they were resolved at start
+		snapshot, err := r.snapshotTargets(ctx, &scenarioRun)
+		if err != nil {
+			// The target group may not exist yet, e.g. when applied along with the run
+			logger.Error(err, "failed to resolve targets",
+				"scenarioRun", scenarioRun.Name,
+				"targetGroup", scenarioRun.Spec.TargetGroupRef)
+			recordEvent(r.Recorder, &scenarioRun, corev1.EventTypeWarning, EventTargetsUnresolved,
+				"Failed to resolve the targets of the run: %v", err)
+			return ctrl.Result{RequeueAfter: targetResolutionRetryInterval}, nil
 		}
 
 		logger.Info("initializing scenarioRun status",
 			"scenarioRun", scenarioRun.Name,
-			"totalTargets", totalTargets,
-			"targetClusters", scenarioRun.Spec.TargetClusters)
+			"totalTargets", len(snapshot.Targets),
+			"targetClusters", scenarioRun.Spec.TargetClusters,
+			"targetGroup", scenarioRun.Spec.TargetGroupRef)
 
 		scenarioRun.Status.Phase = "Pending"
-		scenarioRun.Status.TotalTargets = totalTargets
+		scenarioRun.Status.TotalTargets = len(snapshot.Targets)
 		scenarioRun.Status.ClusterJobs = make([]krknv1alpha1.ClusterJobStatus, 0)
+		scenarioRun.Status.ResolvedTargets = snapshot
 		if err := r.Status().Update(ctx, &scenarioRun); err != nil {
 			logger.Error(err, "failed to initialize status")
 			return ctrl.Result{}, err
 		}
 	}
 
+	// Save original status to detect changes, including the jobs created below
+	originalStatus := scenarioRun.Status.DeepCopy()
+
+	// A cancelled run flags its jobs, and creates none for the clusters not started yet
+	r.requestRunCancellation(ctx, &scenarioRun)
+
+	// Failed jobs of due retry waves move to Retrying and get a new job below
+	nextRetryWave := r.startDueRetryWaves(ctx, &scenarioRun)
+
 	// Process each provider and their clusters
 	jobsCreated := 0
-	for providerName, clusterNames := range scenarioRun.Spec.TargetClusters {
+	for providerName, clusterNames := range scenarioRun.Targets() {
 		for _, clusterName := range clusterNames {
+			// Clusters that failed over keep running on their alternate
+			clusterName = substitutedCluster(&scenarioRun, clusterName)
+
 			// Check if job already exists for this cluster
 			if r.jobExistsForCluster(&scenarioRun, clusterName) {
 				logger.V(1).Info("job already exists for cluster, skipping",
@@ -129,6 +220,33 @@ func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 				continue
 			}
 
+			// Unreachable clusters with an alternate fail over to it
+			var substitutedFrom string
+			clusterName, substitutedFrom = r.preflightAlternate(ctx, &scenarioRun, providerName, clusterName)
+
+			// Chaos never starts on a cluster under planned maintenance
+			if r.holdForMaintenance(ctx, &scenarioRun, providerName, clusterName) {
+				setSubstitutedFrom(&scenarioRun, clusterName, substitutedFrom)
+				continue
+			}
+
+			// Clusters beyond spec.maxConcurrency or the operator-wide limit wait for a slot
+			granted, err := r.acquireJobSlots(ctx, &scenarioRun, 1)
+			if err != nil {
+				logger.Error(err, "failed to acquire job slot, queueing job",
+					"cluster", clusterName,
+					"scenarioRun", scenarioRun.Name)
+			}
+			if granted == 0 {
+				logger.V(1).Info("no free job slot, queueing job",
+					"provider", providerName,
+					"cluster", clusterName,
+					"scenarioRun", scenarioRun.Name)
+				queueForSlot(&scenarioRun, providerName, clusterName)
+				setSubstitutedFrom(&scenarioRun, clusterName, substitutedFrom)
+				continue
+			}
+
 			logger.Info("creating job for cluster",
 				"provider", providerName,
 				"cluster", clusterName,
@@ -140,8 +258,11 @@ func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 					"provider", providerName,
 					"cluster", clusterName,
 					"scenarioRun", scenarioRun.Name)
+				recordEvent(r.Recorder, &scenarioRun, corev1.EventTypeWarning, EventJobCreateFailed,
+					"Failed to create the job of cluster %s of provider %s: %v", clusterName, providerName, err)
 				// Continue with best-effort approach for other clusters
 			} else {
+				setSubstitutedFrom(&scenarioRun, clusterName, substitutedFrom)
 				jobsCreated++
 			}
 		}
@@ -157,17 +278,42 @@ func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		"scenarioRun", scenarioRun.Name,
 		"totalJobs", len(scenarioRun.Status.ClusterJobs))
 
-	// Save original status to detect changes
-	originalStatus := scenarioRun.Status.DeepCopy()
-
 	// Update status for all jobs
 	if err := r.updateClusterJobStatuses(ctx, &scenarioRun); err != nil {
 		logger.Error(err, "failed to update cluster job statuses")
 		return ctrl.Result{}, err
 	}
 
+	// Finished jobs get the krkn telemetry of their collected log and their uploaded artifacts
+	r.recordJobResults(ctx, &scenarioRun)
+	r.recordJobUploads(ctx, &scenarioRun)
+
+	// Targets of finished jobs of node and network scenarios must be back to a clean state
+	r.verifyJobCleanup(ctx, &scenarioRun)
+
+	// Run onFailure hooks of jobs that failed for good, before their kubeconfig is released
+	r.runFailureHooks(ctx, &scenarioRun)
+
+	// Drop kubeconfig references of jobs that will not run again
+	r.releaseSettledKubeconfigs(ctx, &scenarioRun)
+
 	// Calculate overall status
 	r.calculateOverallStatus(&scenarioRun)
+	r.releaseJobSlots(&scenarioRun)
+	setRunCompletionTime(&scenarioRun)
+	setRunConditions(&scenarioRun)
+
+	// Opted-in runs keep their pods from being evicted until they finish
+	if err := r.syncRunPDB(ctx, &scenarioRun); err != nil {
+		// Best effort: evicted jobs are restarted anyway
+		logger.Error(err, "failed to sync PodDisruptionBudget", "scenarioRun", scenarioRun.Name)
+	}
+
+	// Secrets consumed by an active run cannot be deleted from under its jobs
+	if err := r.syncResourceProtection(ctx, &scenarioRun); err != nil {
+		// Best effort: the jobs report the Secrets deleted anyway
+		logger.Error(err, "failed to sync consumed resource protection", "scenarioRun", scenarioRun.Name)
+	}
 
 	logger.Info("reconcile loop completed",
 		"scenarioRun", scenarioRun.Name,
@@ -199,6 +345,12 @@ func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			logger.Error(err, "failed to update status")
 			return ctrl.Result{}, err
 		}
+
+		r.recordRunEvents(&scenarioRun, originalStatus)
+		r.recordTargetOutcomes(ctx, &scenarioRun, originalStatus)
+		if originalStatus.Phase != scenarioRun.Status.Phase {
+			r.notifyObservers(ctx, &scenarioRun, originalStatus.Phase)
+		}
 	} else {
 		logger.V(1).Info("status unchanged, skipping update",
 			"scenarioRun", scenarioRun.Name,
@@ -214,9 +366,100 @@ func (r *KrknScenarioRunReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
+	// Check again whether the maintenance holding jobs ended
+	for i := range scenarioRun.Status.ClusterJobs {
+		if heldForMaintenance(&scenarioRun.Status.ClusterJobs[i]) {
+			return ctrl.Result{RequeueAfter: maintenanceRecheckInterval}, nil
+		}
+	}
+
+	// Queued jobs wait for the jobs of other runs to free a slot
+	for i := range scenarioRun.Status.ClusterJobs {
+		if queuedForSlot(&scenarioRun.Status.ClusterJobs[i]) {
+			return ctrl.Result{RequeueAfter: jobSlotRecheckInterval}, nil
+		}
+	}
+
+	// Come back when the next scheduled retry wave is due
+	if nextRetryWave > 0 {
+		return ctrl.Result{RequeueAfter: nextRetryWave}, nil
+	}
+
+	// Come back to delete the run once its TTL expired
+	if remaining, ok := ttlRemaining(&scenarioRun, time.Now()); ok {
+		return ctrl.Result{RequeueAfter: max(remaining, time.Second)}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// notifyObservers sends a phase-change notification to every observer of the run.
+// Delivery is best-effort and in the background: failures are recorded on the run and
+// never fail or hold up the reconcile.
+func (r *KrknScenarioRunReconciler) notifyObservers(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun, previousPhase string) {
+	if r.Notifications == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	subs, err := notify.Subscriptions(scenarioRun)
+	if err != nil {
+		logger.Error(err, "failed to read run observers", "scenarioRun", scenarioRun.Name)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+
+	notification := notify.Notification{
+		ScenarioRun:   scenarioRun.Name,
+		ScenarioName:  scenarioRun.Spec.ScenarioName,
+		RunNumber:     scenarioRun.Spec.RunNumber,
+		Title:         scenarioRun.Title(),
+		Namespace:     scenarioRun.Namespace,
+		PreviousPhase: previousPhase,
+		Phase:         scenarioRun.Status.Phase,
+		Timestamp:     time.Now().UTC(),
+	}
+	r.sendNotification(ctx, scenarioRun, subs, notification)
+}
+
+// sendNotification delivers notification to subs in the background. Failed and dropped
+// deliveries are logged and recorded as a Warning event on the run.
+func (r *KrknScenarioRunReconciler) sendNotification(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	subs []notify.Subscription,
+	notification notify.Notification,
+) {
+	logger := log.FromContext(ctx)
+	// The reconcile goes on with the run, events only need its identity
+	run := &krknv1alpha1.KrknScenarioRun{ObjectMeta: metav1.ObjectMeta{
+		Name:      scenarioRun.Name,
+		Namespace: scenarioRun.Namespace,
+		UID:       scenarioRun.UID,
+	}}
+	failed := func(err error) {
+		logger.Error(err, "failed to deliver notifications", "scenarioRun", run.Name, "phase", notification.Phase)
+		recordEvent(r.Recorder, run, corev1.EventTypeWarning, EventNotificationFailed,
+			"Failed to deliver the %s notification: %v", notification.Phase, err)
+	}
+
+	err := r.Notifications.Send(ctx, subs, notification, func(err error) {
+		if err != nil {
+			failed(err)
+			return
+		}
+		logger.Info("delivered notifications",
+			"scenarioRun", run.Name,
+			"phase", notification.Phase,
+			"recipients", len(subs))
+	})
+	if err != nil {
+		failed(err)
+	}
+}
+
 // createClusterJob creates all resources needed for a single cluster scenario job
 func (r *KrknScenarioRunReconciler) createClusterJob(
 	ctx context.Context,
@@ -226,10 +469,12 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Check if this is a retry case
+	// Check if this is a retry case, or a job held until the cluster maintenance ended or
+	// queued until a job slot freed up
 	existingJobIndex := -1
 	for i, job := range scenarioRun.Status.ClusterJobs {
-		if job.ClusterName == clusterName && job.Phase == "Retrying" {
+		if job.ProviderName == providerName && job.ClusterName == clusterName &&
+			(job.Phase == "Retrying" || heldForMaintenance(&job) || queuedForSlot(&job)) {
 			existingJobIndex = i
 			break
 		}
@@ -241,7 +486,7 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 	// Set default kubeconfig path if not provided
 	kubeconfigPath := scenarioRun.Spec.KubeconfigPath
 	if kubeconfigPath == "" {
-		kubeconfigPath = "/home/krkn/.kube/config"
+		kubeconfigPath = krknv1alpha1.DefaultKubeconfigPath
 	}
 
 	logger.Info("getting kubeconfig for cluster",
@@ -295,45 +540,21 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 			"clusterAPIURL", clusterAPIURL)
 	}
 
-	// Create ConfigMap for kubeconfig
-	kubeconfigConfigMapName := fmt.Sprintf("krkn-job-%s-kubeconfig", jobID)
-	kubeconfigLabels := map[string]string{
-		"krkn-job-id":         jobID,
-		"krkn-scenario-run":   scenarioRun.Name,
-		"krkn-scenario-name":  scenarioRun.Spec.ScenarioName,
-		"krkn-cluster-name":   clusterName,
-		"krkn-target-request": scenarioRun.Spec.TargetRequestID,
-	}
-	if ownerLabel := getOwnerLabel(scenarioRun); ownerLabel != "" {
-		kubeconfigLabels["krkn.krkn-chaos.dev/owner-user"] = ownerLabel
-	}
-	kubeconfigConfigMap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      kubeconfigConfigMapName,
-			Namespace: r.Namespace,
-			Labels:    kubeconfigLabels,
-		},
-		Data: map[string]string{
-			"config": string(kubeconfigDecoded),
-		},
-	}
-
-	// Set owner reference for automatic cleanup
-	if err := controllerutil.SetControllerReference(scenarioRun, kubeconfigConfigMap, r.Scheme); err != nil {
-		return fmt.Errorf("failed to set owner reference on kubeconfig ConfigMap: %w", err)
-	}
-
-	if err := r.Create(ctx, kubeconfigConfigMap); err != nil {
-		return fmt.Errorf("failed to create kubeconfig ConfigMap: %w", err)
+	// Kubeconfig Secrets are named by content hash and shared by the jobs of the run
+	// targeting the same cluster, so retries reuse the Secret instead of duplicating it
+	kubeconfigSecretName, err := r.acquireKubeconfigSecret(ctx, scenarioRun, clusterName, kubeconfigDecoded, jobID)
+	if err != nil {
+		return err
 	}
 
 	// Track created resources for cleanup on error
 	var fileConfigMaps []string
 	var imagePullSecretName string
+	var envSecretName string
 
 	// Cleanup helper
 	cleanup := func() {
-		_ = r.Delete(ctx, kubeconfigConfigMap) // Best-effort cleanup
+		_ = r.releaseKubeconfigSecret(ctx, kubeconfigSecretName, jobID) // Best-effort cleanup
 		for _, cm := range fileConfigMaps {
 			_ = r.Delete(ctx, &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
@@ -342,18 +563,37 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 				},
 			}) // Best-effort cleanup
 		}
-		if imagePullSecretName != "" {
+		for _, secretName := range []string{imagePullSecretName, envSecretName} {
+			if secretName == "" {
+				continue
+			}
 			_ = r.Delete(ctx, &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      imagePullSecretName,
+					Name:      secretName,
 					Namespace: r.Namespace,
 				},
 			}) // Best-effort cleanup
 		}
 	}
 
-	// Create ConfigMaps for user-provided files
+	// The health check and alert profile of the run are krkn-hub variables and generated
+	// files, replacing the files of the run mounted at the same path
+	checksEnv, generatedFiles, err := krknChecksConfig(scenarioRun.Spec.HealthCheck, scenarioRun.Spec.AlertProfile)
+	if err != nil {
+		cleanup()
+		return err
+	}
+	files := make([]krknv1alpha1.FileMount, 0, len(scenarioRun.Spec.Files)+len(generatedFiles))
 	for _, file := range scenarioRun.Spec.Files {
+		if slices.ContainsFunc(generatedFiles, func(generated krknv1alpha1.FileMount) bool { return generated.MountPath == file.MountPath }) {
+			continue
+		}
+		files = append(files, file)
+	}
+	files = append(files, generatedFiles...)
+
+	// Create ConfigMaps for user-provided and generated files
+	for _, file := range files {
 		// Sanitize filename for ConfigMap name
 		sanitizedName := strings.ReplaceAll(file.Name, "/", "-")
 		sanitizedName = strings.ReplaceAll(sanitizedName, ".", "-")
@@ -366,16 +606,8 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 			return fmt.Errorf("failed to decode file content for '%s': %w", file.Name, err)
 		}
 
-		fileLabels := map[string]string{
-			"krkn-job-id":         jobID,
-			"krkn-scenario-run":   scenarioRun.Name,
-			"krkn-scenario-name":  scenarioRun.Spec.ScenarioName,
-			"krkn-cluster-name":   clusterName,
-			"krkn-target-request": scenarioRun.Spec.TargetRequestID,
-		}
-		if ownerLabel := getOwnerLabel(scenarioRun); ownerLabel != "" {
-			fileLabels["krkn.krkn-chaos.dev/owner-user"] = ownerLabel
-		}
+		fileLabels := runLabels(scenarioRun, clusterName)
+		fileLabels[joblabels.JobID] = jobID
 		fileConfigMap := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      configMapName,
@@ -401,22 +633,38 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 		fileConfigMaps = append(fileConfigMaps, configMapName)
 	}
 
-	// Handle private registry authentication
+	// Handle private registry authentication, with the credentials of the referenced
+	// KrknRegistry or those of the spec
+	registryURL, scenarioRepository := scenarioRun.Spec.RegistryURL, scenarioRun.Spec.ScenarioRepository
+	credentials := secrets.RegistryCredentials{
+		Username: scenarioRun.Spec.Username,
+		Password: scenarioRun.Spec.Password,
+		Token:    scenarioRun.Spec.Token,
+	}
+	if scenarioRun.Spec.RegistryRef != "" {
+		registry, registryCredentials, err := secrets.Registry(ctx, r.Client, r.Namespace, scenarioRun.Spec.RegistryRef)
+		if err != nil {
+			cleanup()
+			return err
+		}
+		registryURL, scenarioRepository = registry.Spec.RegistryURL, registry.Spec.ScenarioRepository
+		credentials = registryCredentials
+	}
 	var imagePullSecrets []corev1.LocalObjectReference
-	if scenarioRun.Spec.RegistryURL != "" && scenarioRun.Spec.ScenarioRepository != "" {
+	if registryURL != "" && scenarioRepository != "" {
 		imagePullSecretName = fmt.Sprintf("krkn-job-%s-registry", jobID)
 
 		// Build docker config JSON
 		authStr := ""
-		if scenarioRun.Spec.Token != "" {
-			authStr = base64.StdEncoding.EncodeToString([]byte(scenarioRun.Spec.Token))
-		} else if scenarioRun.Spec.Username != "" && scenarioRun.Spec.Password != "" {
-			authStr = base64.StdEncoding.EncodeToString([]byte(scenarioRun.Spec.Username + ":" + scenarioRun.Spec.Password))
+		if credentials.Token != "" {
+			authStr = base64.StdEncoding.EncodeToString([]byte(credentials.Token))
+		} else if credentials.Username != "" && credentials.Password != "" {
+			authStr = base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
 		}
 
 		dockerConfig := map[string]interface{}{
 			"auths": map[string]interface{}{
-				scenarioRun.Spec.RegistryURL: map[string]string{
+				registryURL: map[string]string{
 					"auth": authStr,
 				},
 			},
@@ -424,16 +672,8 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 
 		dockerConfigJSON, _ := json.Marshal(dockerConfig)
 
-		secretLabels := map[string]string{
-			"krkn-job-id":         jobID,
-			"krkn-scenario-run":   scenarioRun.Name,
-			"krkn-scenario-name":  scenarioRun.Spec.ScenarioName,
-			"krkn-cluster-name":   clusterName,
-			"krkn-target-request": scenarioRun.Spec.TargetRequestID,
-		}
-		if ownerLabel := getOwnerLabel(scenarioRun); ownerLabel != "" {
-			secretLabels["krkn.krkn-chaos.dev/owner-user"] = ownerLabel
-		}
+		secretLabels := runLabels(scenarioRun, clusterName)
+		secretLabels[joblabels.JobID] = jobID
 		imagePullSecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      imagePullSecretName,
@@ -467,10 +707,8 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 		{
 			Name: "kubeconfig",
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: kubeconfigConfigMapName,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: kubeconfigSecretName,
 				},
 			},
 		},
@@ -485,7 +723,7 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 	}
 
 	// Add file mounts
-	for i, file := range scenarioRun.Spec.Files {
+	for i, file := range files {
 		volumeName := fmt.Sprintf("file-%d", i)
 
 		volumes = append(volumes, corev1.Volume{
@@ -519,83 +757,175 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 		MountPath: "/tmp",
 	})
 
-	// Convert environment map to EnvVar slice
-	envVars := make([]corev1.EnvVar, 0, len(scenarioRun.Spec.Environment))
+	esEnv, err := elasticsearchEnv(scenarioRun.Spec.Elasticsearch)
+	if err != nil {
+		cleanup()
+		return err
+	}
+
+	// Credentials held by the Secret of the run are passed from it, checked to be there
+	// first so the pod does not wait on a missing key
+	var refEnv []string
+	if ref := scenarioRun.Spec.SecretEnvironmentRef; ref != "" {
+		var runSecret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Name: ref, Namespace: r.Namespace}, &runSecret); err != nil {
+			cleanup()
+			return fmt.Errorf("failed to read secret environment %s: %w", ref, err)
+		}
+		for _, key := range scenarioRun.Spec.SecretEnvironment {
+			if _, set := scenarioRun.Spec.Environment[key]; set {
+				continue
+			}
+			if _, ok := runSecret.Data[key]; !ok {
+				cleanup()
+				return fmt.Errorf("secret environment %s has no variable %s", ref, key)
+			}
+			refEnv = append(refEnv, key)
+		}
+	}
+
+	// Credentials set in the environment are read from a Secret of the job, keeping them
+	// out of the pod spec
+	secretEnv := make(map[string][]byte)
+	for _, key := range scenarioRun.Spec.SecretEnvironment {
+		if value, ok := scenarioRun.Spec.Environment[key]; ok {
+			secretEnv[key] = []byte(value)
+		}
+	}
+	if len(secretEnv) > 0 {
+		envSecretName = fmt.Sprintf("krkn-job-%s-env", jobID)
+		secretLabels := runLabels(scenarioRun, clusterName)
+		secretLabels[joblabels.JobID] = jobID
+		envSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      envSecretName,
+				Namespace: r.Namespace,
+				Labels:    secretLabels,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretEnv,
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(scenarioRun, envSecret, r.Scheme); err != nil {
+			cleanup()
+			return fmt.Errorf("failed to set owner reference on environment Secret: %w", err)
+		}
+
+		if err := r.Create(ctx, envSecret); err != nil {
+			cleanup()
+			return fmt.Errorf("failed to create environment Secret: %w", err)
+		}
+	}
+
+	// Convert environment map to EnvVar slice. The Elasticsearch, health check and alert
+	// settings take precedence over the same variables set in the environment.
+	injectedEnv := append(esEnv, checksEnv...)
+	envVars := make([]corev1.EnvVar, 0, len(scenarioRun.Spec.Environment)+len(injectedEnv))
 	for key, value := range scenarioRun.Spec.Environment {
+		if slices.ContainsFunc(injectedEnv, func(env corev1.EnvVar) bool { return env.Name == key }) {
+			continue
+		}
+		if _, ok := secretEnv[key]; ok {
+			envVars = append(envVars, secretEnvVar(key, envSecretName, key))
+			continue
+		}
 		envVars = append(envVars, corev1.EnvVar{
 			Name:  key,
 			Value: value,
 		})
 	}
+	for _, key := range refEnv {
+		if !slices.ContainsFunc(injectedEnv, func(env corev1.EnvVar) bool { return env.Name == key }) {
+			envVars = append(envVars, secretEnvVar(key, scenarioRun.Spec.SecretEnvironmentRef, key))
+		}
+	}
+	envVars = append(envVars, injectedEnv...)
+
+	imagePullPolicy := corev1.PullPolicy(scenarioRun.Spec.ImagePullPolicy)
+	if imagePullPolicy == "" {
+		imagePullPolicy = krknv1alpha1.DefaultImagePullPolicy
+	}
 
 	// SecurityContext for running as krkn user (UID 1001)
 	var runAsUser int64 = 1001
 	var runAsGroup int64 = 1001
 	var fsGroup int64 = 1001
 
-	// Create the pod
-	podName := fmt.Sprintf("krkn-job-%s", jobID)
-	podLabels := map[string]string{
-		"app":                 "krkn-scenario",
-		"krkn-job-id":         jobID,
-		"krkn-scenario-run":   scenarioRun.Name,
-		"krkn-scenario-name":  scenarioRun.Spec.ScenarioName,
-		"krkn-cluster-name":   clusterName,
-		"krkn-target-request": scenarioRun.Spec.TargetRequestID,
-	}
-	if ownerLabel := getOwnerLabel(scenarioRun); ownerLabel != "" {
-		podLabels["krkn.krkn-chaos.dev/owner-user"] = ownerLabel
-	}
-	pod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: r.Namespace,
-			Labels:    podLabels,
+	var resources corev1.ResourceRequirements
+	if scenarioRun.Spec.Resources != nil {
+		resources = *scenarioRun.Spec.Resources.DeepCopy()
+	}
+
+	// Create the Job running the scenario
+	jobName := scenarioJobName(jobID)
+	jobLabels := runLabels(scenarioRun, clusterName)
+	jobLabels[joblabels.App] = joblabels.AppScenario
+	jobLabels[joblabels.JobID] = jobID
+	batchJob := newScenarioJob(scenarioRun, jobName, r.Namespace, jobLabels, corev1.PodSpec{
+		ServiceAccountName: "krkn-operator-krkn-scenario-runner",
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ImagePullSecrets:   imagePullSecrets,
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsUser:  &runAsUser,
+			RunAsGroup: &runAsGroup,
+			FSGroup:    &fsGroup,
 		},
-		Spec: corev1.PodSpec{
-			ServiceAccountName: "krkn-operator-krkn-scenario-runner",
-			RestartPolicy:      corev1.RestartPolicyNever,
-			ImagePullSecrets:   imagePullSecrets,
-			SecurityContext: &corev1.PodSecurityContext{
-				RunAsUser:  &runAsUser,
-				RunAsGroup: &runAsGroup,
-				FSGroup:    &fsGroup,
-			},
-			Containers: []corev1.Container{
-				{
-					Name:            "scenario",
-					Image:           scenarioRun.Spec.ScenarioImage,
-					Env:             envVars,
-					VolumeMounts:    volumeMounts,
-					ImagePullPolicy: corev1.PullAlways,
-				},
+		Containers: []corev1.Container{
+			{
+				Name:            "scenario",
+				Image:           scenarioRun.Spec.ScenarioImage,
+				Env:             envVars,
+				VolumeMounts:    volumeMounts,
+				ImagePullPolicy: imagePullPolicy,
+				Resources:       resources,
 			},
-			Volumes: volumes,
 		},
-	}
+		Volumes: volumes,
+	})
 
 	// Set owner reference
-	if err := controllerutil.SetControllerReference(scenarioRun, pod, r.Scheme); err != nil {
+	if err := controllerutil.SetControllerReference(scenarioRun, batchJob, r.Scheme); err != nil {
 		cleanup()
-		return fmt.Errorf("failed to set owner reference on pod: %w", err)
+		return fmt.Errorf("failed to set owner reference on job: %w", err)
 	}
 
-	if err := r.Create(ctx, pod); err != nil {
+	if err := r.Create(ctx, batchJob); err != nil {
 		cleanup()
-		return fmt.Errorf("failed to create pod: %w", err)
+		return fmt.Errorf("failed to create job: %w", err)
 	}
+	effectiveSpec := captureEffectiveSpec(batchJob, files, kubeconfigPath)
 
 	// Update status - either update existing entry (retry) or add new entry
 	now := metav1.Now()
 	if existingJobIndex >= 0 {
 		// Update existing entry (retry case)
 		// Preserve ClusterAPIURL - it should already be set from first attempt
+		previous := scenarioRun.Status.ClusterJobs[existingJobIndex]
+		if err := r.releaseJobKubeconfig(ctx, &previous); err != nil {
+			logger.Error(err, "failed to release kubeconfig of previous attempt",
+				"secret", previous.KubeconfigSecret,
+				"configMap", previous.KubeconfigConfigMap,
+				"previousJobId", previous.JobID)
+		}
 		scenarioRun.Status.ClusterJobs[existingJobIndex].JobID = jobID
-		scenarioRun.Status.ClusterJobs[existingJobIndex].PodName = podName
-		scenarioRun.Status.ClusterJobs[existingJobIndex].Phase = "Pending"
+		scenarioRun.Status.ClusterJobs[existingJobIndex].KubeconfigSecret = kubeconfigSecretName
+		scenarioRun.Status.ClusterJobs[existingJobIndex].KubeconfigConfigMap = ""
+		scenarioRun.Status.ClusterJobs[existingJobIndex].PodName = ""
+		scenarioRun.Status.ClusterJobs[existingJobIndex].Phase = statemachine.JobPending
 		scenarioRun.Status.ClusterJobs[existingJobIndex].StartTime = &now
 		scenarioRun.Status.ClusterJobs[existingJobIndex].CompletionTime = nil
 		scenarioRun.Status.ClusterJobs[existingJobIndex].Message = ""
+		scenarioRun.Status.ClusterJobs[existingJobIndex].MaintenanceReason = ""
+		scenarioRun.Status.ClusterJobs[existingJobIndex].Queued = false
+		if scenarioRun.Status.ClusterJobs[existingJobIndex].ClusterAPIURL == "" {
+			scenarioRun.Status.ClusterJobs[existingJobIndex].ClusterAPIURL = clusterAPIURL
+		}
+		scenarioRun.Status.ClusterJobs[existingJobIndex].EffectiveSpec = effectiveSpec
+		scenarioRun.Status.ClusterJobs[existingJobIndex].Result = nil
+		scenarioRun.Status.ClusterJobs[existingJobIndex].Artifacts = nil
+		scenarioRun.Status.ClusterJobs[existingJobIndex].ArtifactsUploadError = ""
+		scenarioRun.Status.ClusterJobs[existingJobIndex].Cleanup = nil
 
 		logger.Info("updated retry job in status",
 			"cluster", clusterName,
@@ -604,22 +934,24 @@ func (r *KrknScenarioRunReconciler) createClusterJob(
 	} else {
 		// New job (first attempt)
 		jobStatus := krknv1alpha1.ClusterJobStatus{
-			ProviderName:  providerName,
-			ClusterName:   clusterName,
-			ClusterAPIURL: clusterAPIURL,
-			JobID:         jobID,
-			PodName:       podName,
-			Phase:         "Pending",
-			StartTime:     &now,
-			RetryCount:    0,
-			MaxRetries:    0, // Will be set from spec on first failure
+			ProviderName:     providerName,
+			ClusterName:      clusterName,
+			ClusterAPIURL:    clusterAPIURL,
+			ClusterID:        scenarioRun.TargetClusterID(providerName, clusterName),
+			JobID:            jobID,
+			Phase:            statemachine.Jobs.Initial(),
+			KubeconfigSecret: kubeconfigSecretName,
+			StartTime:        &now,
+			RetryCount:       0,
+			MaxRetries:       0, // Will be set from spec on first failure
+			EffectiveSpec:    effectiveSpec,
 		}
 		scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, jobStatus)
 
 		logger.Info("created new cluster job",
 			"cluster", clusterName,
 			"jobID", jobID,
-			"pod", podName,
+			"job", jobName,
 			"clusterAPIURL", clusterAPIURL)
 	}
 
@@ -643,7 +975,8 @@ func (r *KrknScenarioRunReconciler) updateClusterJobStatuses(
 			"podName", job.PodName)
 
 		// Skip terminal jobs
-		if job.Phase == "Succeeded" || job.Phase == "Cancelled" || job.Phase == "MaxRetriesExceeded" {
+		if job.Phase == "Succeeded" || job.Phase == "Cancelled" || job.Phase == "MaxRetriesExceeded" ||
+			job.Phase == statemachine.JobSkippedMaintenance {
 			logger.V(1).Info("skipping terminal job",
 				"cluster", job.ClusterName,
 				"jobID", job.JobID,
@@ -652,7 +985,7 @@ func (r *KrknScenarioRunReconciler) updateClusterJobStatuses(
 		}
 
 		// Skip Failed jobs unless they need retry processing
-		if job.Phase == "Failed" && job.RetryCount >= job.MaxRetries && !job.CancelRequested {
+		if job.Phase == "Failed" && job.RetryCount >= job.MaxRetries && !job.CancelRequested && !evictionRestartLeft(job) {
 			logger.V(1).Info("skipping failed job that exceeded retries",
 				"cluster", job.ClusterName,
 				"jobID", job.JobID,
@@ -661,208 +994,294 @@ func (r *KrknScenarioRunReconciler) updateClusterJobStatuses(
 			continue
 		}
 
-		// Fetch pod
-		var pod corev1.Pod
+		// Cancelled jobs are stopped whatever their Job reports
+		if job.CancelRequested && job.Phase != statemachine.JobFailed {
+			r.cancelJob(ctx, job)
+			continue
+		}
+
+		// Jobs held until the cluster maintenance ends or queued for a slot have no Job yet
+		if heldForMaintenance(job) || queuedForSlot(job) {
+			continue
+		}
+
+		// Fetch the Job running the scenario
+		var batchJob batchv1.Job
 		err := r.Get(ctx, types.NamespacedName{
-			Name:      job.PodName,
+			Name:      scenarioJobName(job.JobID),
 			Namespace: r.Namespace,
-		}, &pod)
+		}, &batchJob)
 
 		if err != nil {
 			if apierrors.IsNotFound(err) {
-				// IMPORTANT: Don't mark as Failed if pod was just created
-				// Kubernetes might not have created the pod yet
+				// The Job of a failed job was removed, e.g. killed on its deadline:
+				// keep the failure and only decide on the retry
+				if job.Phase == statemachine.JobFailed {
+					r.handleJobFailure(ctx, scenarioRun, job)
+					continue
+				}
+
+				// IMPORTANT: Don't mark as Failed if the Job was just created
+				// The cache might not have seen it yet
 				if job.Phase == "Pending" {
 					// Calculate time since job start
 					if job.StartTime != nil {
 						timeSinceStart := time.Since(job.StartTime.Time)
 						if timeSinceStart < 30*time.Second {
-							// Pod not found but job is recent - this is normal, keep waiting
-							logger.V(1).Info("pod not found but job is recent, keeping Pending status",
+							// Job not found but recent - this is normal, keep waiting
+							logger.V(1).Info("job not found but recent, keeping Pending status",
 								"cluster", job.ClusterName,
 								"jobID", job.JobID,
-								"podName", job.PodName,
 								"timeSinceStart", timeSinceStart.String())
 							continue
 						}
 					}
 				}
 
-				// Pod genuinely not found - this is an error
-				logger.Info("pod not found for job",
+				// Job genuinely not found - this is an error
+				logger.Info("kubernetes job not found for job",
 					"cluster", job.ClusterName,
 					"jobID", job.JobID,
-					"podName", job.PodName,
 					"currentPhase", job.Phase)
 
-				job.Phase = "Failed"
-				job.Message = "Pod not found"
-				job.FailureReason = "PodNotFound"
+				if !r.setJobPhase(ctx, job, statemachine.JobFailed) {
+					continue
+				}
+				job.Message = "Job not found"
+				job.FailureReason = "JobNotFound"
 				now := metav1.Now()
 				job.CompletionTime = &now
 			} else {
-				logger.Error(err, "error fetching pod",
+				logger.Error(err, "error fetching kubernetes job",
 					"cluster", job.ClusterName,
-					"jobID", job.JobID,
-					"podName", job.PodName)
+					"jobID", job.JobID)
 			}
 			continue
 		}
 
-		logger.V(1).Info("pod found",
+		// The Job replaces pods lost to node failures, track its current pod
+		pod, err := r.latestJobPod(ctx, job.JobID)
+		if err != nil {
+			logger.Error(err, "error listing job pods",
+				"cluster", job.ClusterName,
+				"jobID", job.JobID)
+			continue
+		}
+		if pod != nil {
+			job.PodName = pod.Name
+			recordImageID(job, pod)
+		}
+
+		logger.V(1).Info("kubernetes job found",
 			"cluster", job.ClusterName,
 			"jobID", job.JobID,
 			"podName", job.PodName,
-			"podPhase", pod.Status.Phase)
+			"active", batchJob.Status.Active)
+
+		// Stop Jobs running past the run timeout, in case the Job controller did not enforce it
+		if r.jobDeadlineExceeded(scenarioRun, &batchJob) {
+			r.failJobOnDeadline(ctx, scenarioRun, job, &batchJob)
+			continue
+		}
 
-		// Update job status based on pod phase
+		// Update job status based on the Job conditions and its pod
 		previousPhase := job.Phase
-		switch pod.Status.Phase {
-		case corev1.PodPending:
-			job.Phase = "Pending"
-			if previousPhase != "Pending" {
-				logger.Info("job phase transition",
-					"cluster", job.ClusterName,
-					"jobID", job.JobID,
-					"from", previousPhase,
-					"to", "Pending")
+		switch phase := scenarioJobPhase(&batchJob, pod); phase {
+		case statemachine.JobPending, statemachine.JobRunning:
+			// The replacement of a pod lost to a node failure does not make a running job pending again
+			if phase == statemachine.JobPending && previousPhase == statemachine.JobRunning {
+				continue
+			}
+			if !r.setJobPhase(ctx, job, phase) {
+				continue
 			}
-		case corev1.PodRunning:
-			job.Phase = "Running"
-			if previousPhase != "Running" {
+			if previousPhase != phase {
 				logger.Info("job phase transition",
 					"cluster", job.ClusterName,
 					"jobID", job.JobID,
 					"from", previousPhase,
-					"to", "Running")
+					"to", phase)
+			}
+		case statemachine.JobSucceeded:
+			if !r.setJobPhase(ctx, job, statemachine.JobSucceeded) {
+				continue
 			}
-		case corev1.PodSucceeded:
-			job.Phase = "Succeeded"
 			r.setCompletionTime(job)
+			recordExitResult(job, pod)
+			r.enqueueArtifacts(ctx, scenarioRun, job)
 			logger.Info("job succeeded",
 				"cluster", job.ClusterName,
 				"jobID", job.JobID,
 				"duration", job.CompletionTime.Sub(job.StartTime.Time).String())
-		case corev1.PodFailed:
-			job.Phase = "Failed"
-			job.Message = r.extractPodErrorMessage(&pod)
-			job.FailureReason = r.extractFailureReason(&pod)
+		case statemachine.JobFailed:
+			if !r.setJobPhase(ctx, job, statemachine.JobFailed) {
+				continue
+			}
+			job.Message, job.FailureReason = r.extractJobFailure(&batchJob, pod)
 			r.setCompletionTime(job)
+			recordExitResult(job, pod)
+			// Before a retry replaces the job ID
+			r.enqueueArtifacts(ctx, scenarioRun, job)
 
-			// Retry logic
-			logger.Info("pod failed, checking retry eligibility",
-				"cluster", job.ClusterName,
-				"jobID", job.JobID,
-				"retryCount", job.RetryCount,
-				"maxRetries", job.MaxRetries,
-				"cancelRequested", job.CancelRequested,
-				"failureReason", job.FailureReason)
-
-			maxRetries := job.MaxRetries
-			if maxRetries == 0 {
-				maxRetries = scenarioRun.Spec.MaxRetries
-				if maxRetries == 0 {
-					maxRetries = 3 // Default
-				}
-				job.MaxRetries = maxRetries
-			}
+			r.handleJobFailure(ctx, scenarioRun, job)
+		}
+	}
 
-			if r.shouldRetryJob(job, maxRetries) {
-				// Calculate backoff delay
-				delay := r.calculateRetryDelay(job.RetryCount,
-					scenarioRun.Spec.RetryBackoff,
-					scenarioRun.Spec.RetryDelay)
+	return nil
+}
 
-				// Check if enough time has passed since last retry
-				now := metav1.Now()
-				if job.LastRetryTime != nil {
-					elapsed := now.Sub(job.LastRetryTime.Time)
-					if elapsed < delay {
-						logger.Info("waiting for retry backoff",
-							"cluster", job.ClusterName,
-							"jobID", job.JobID,
-							"elapsed", elapsed.String(),
-							"requiredDelay", delay.String())
-						// Don't retry yet, will check again on next reconcile
-						continue
-					}
-				}
+// enqueueArtifacts queues the collection of the final log of a job that just finished
+func (r *KrknScenarioRunReconciler) enqueueArtifacts(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun, job *krknv1alpha1.ClusterJobStatus) {
+	if r.Artifacts == nil {
+		return
+	}
+	if err := r.Artifacts.Enqueue(ctx, scenarioRun, job); err != nil {
+		// Best effort: the log stays readable from the pod until it is deleted
+		log.FromContext(ctx).Error(err, "failed to queue job artifacts collection",
+			"cluster", job.ClusterName,
+			"jobID", job.JobID)
+	}
+}
 
-				// Retry!
-				job.Phase = "Retrying"
-				job.RetryCount++
-				job.LastRetryTime = &now
+// handleJobFailure decides what happens to a job that just failed: it is retried when
+// retries are left and the backoff elapsed, cancelled when cancellation was requested,
+// or marked MaxRetriesExceeded.
+func (r *KrknScenarioRunReconciler) handleJobFailure(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	job *krknv1alpha1.ClusterJobStatus,
+) {
+	logger := log.FromContext(ctx)
 
-				logger.Info("retrying failed job",
-					"cluster", job.ClusterName,
-					"previousJobId", job.JobID,
-					"retryAttempt", job.RetryCount,
-					"maxRetries", maxRetries)
-
-				// Validate required fields before retry
-				if job.ProviderName == "" {
-					logger.Error(nil, "cannot retry job: ProviderName is empty",
-						"cluster", job.ClusterName,
-						"jobID", job.JobID)
-					job.Phase = "Failed"
-					job.Message = "Retry failed: ProviderName is empty"
-					job.FailureReason = "InvalidJobState"
-					r.setCompletionTime(job)
-					continue
-				}
+	logger.Info("job failed, checking retry eligibility",
+		"cluster", job.ClusterName,
+		"jobID", job.JobID,
+		"retryCount", job.RetryCount,
+		"maxRetries", job.MaxRetries,
+		"cancelRequested", job.CancelRequested,
+		"failureReason", job.FailureReason)
 
-				if job.ClusterName == "" {
-					logger.Error(nil, "cannot retry job: ClusterName is empty",
-						"jobID", job.JobID)
-					job.Phase = "Failed"
-					job.Message = "Retry failed: ClusterName is empty"
-					job.FailureReason = "InvalidJobState"
-					r.setCompletionTime(job)
-					continue
-				}
+	maxRetries := job.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = scenarioRun.Spec.MaxRetries
+		if maxRetries == 0 {
+			maxRetries = krknv1alpha1.DefaultMaxRetries
+		}
+		job.MaxRetries = maxRetries
+	}
 
-				// Create new pod (will get new jobID)
-				if err := r.createClusterJob(ctx, scenarioRun, job.ProviderName, job.ClusterName); err != nil {
-					logger.Error(err, "failed to create retry job",
-						"cluster", job.ClusterName,
-						"retryAttempt", job.RetryCount)
-					job.Phase = "Failed"
-					job.Message = "Retry failed: " + err.Error()
-					r.setCompletionTime(job)
-				}
-			} else if job.CancelRequested {
-				job.Phase = "Cancelled"
-				logger.Info("job marked as cancelled, no retry",
-					"cluster", job.ClusterName,
-					"jobID", job.JobID)
-			} else {
-				job.Phase = "MaxRetriesExceeded"
-				logger.Info("job exceeded max retries",
+	// Evictions are not the scenario's fault, restarting an evicted job uses no retry
+	evicted := evictionRestartLeft(job)
+	if evicted || r.shouldRetryJob(job, maxRetries) {
+		// Calculate backoff delay
+		delay := r.calculateRetryDelay(job.RetryCount,
+			scenarioRun.Spec.RetryBackoff,
+			scenarioRun.Spec.RetryDelay)
+
+		// Check if enough time has passed since last retry
+		now := metav1.Now()
+		if job.LastRetryTime != nil {
+			elapsed := now.Sub(job.LastRetryTime.Time)
+			if elapsed < delay {
+				logger.Info("waiting for retry backoff",
 					"cluster", job.ClusterName,
 					"jobID", job.JobID,
-					"retryCount", job.RetryCount,
-					"maxRetries", maxRetries)
+					"elapsed", elapsed.String(),
+					"requiredDelay", delay.String())
+				// Don't retry yet, will check again on next reconcile
+				return
 			}
-		case corev1.PodUnknown:
-			job.Phase = "Failed"
-			job.Message = "Pod in unknown state"
-			job.FailureReason = "PodUnknown"
+		}
+
+		// Retry!
+		if !r.setJobPhase(ctx, job, statemachine.JobRetrying) {
+			return
+		}
+		if evicted {
+			job.Evictions++
+		} else {
+			job.RetryCount++
+			jobRetries.WithLabelValues(job.ClusterName).Inc()
+		}
+		job.LastRetryTime = &now
+
+		logger.Info("retrying failed job",
+			"cluster", job.ClusterName,
+			"previousJobId", job.JobID,
+			"retryAttempt", job.RetryCount,
+			"maxRetries", maxRetries,
+			"evictions", job.Evictions)
+
+		// Validate required fields before retry
+		if job.ProviderName == "" {
+			logger.Error(nil, "cannot retry job: ProviderName is empty",
+				"cluster", job.ClusterName,
+				"jobID", job.JobID)
+			r.setJobPhase(ctx, job, statemachine.JobFailed)
+			job.Message = "Retry failed: ProviderName is empty"
+			job.FailureReason = "InvalidJobState"
+			r.setCompletionTime(job)
+			return
+		}
+
+		if job.ClusterName == "" {
+			logger.Error(nil, "cannot retry job: ClusterName is empty",
+				"jobID", job.JobID)
+			r.setJobPhase(ctx, job, statemachine.JobFailed)
+			job.Message = "Retry failed: ClusterName is empty"
+			job.FailureReason = "InvalidJobState"
 			r.setCompletionTime(job)
-			logger.Info("pod in unknown state",
+			return
+		}
+
+		// The retry follows the maintenance policy of the run as well
+		if r.holdForMaintenance(ctx, scenarioRun, job.ProviderName, job.ClusterName) {
+			return
+		}
+
+		// Create new Job (will get new jobID)
+		if err := r.createClusterJob(ctx, scenarioRun, job.ProviderName, job.ClusterName); err != nil {
+			logger.Error(err, "failed to create retry job",
 				"cluster", job.ClusterName,
-				"jobID", job.JobID,
-				"podName", job.PodName)
+				"retryAttempt", job.RetryCount)
+			r.setJobPhase(ctx, job, statemachine.JobFailed)
+			job.Message = "Retry failed: " + err.Error()
+			r.setCompletionTime(job)
 		}
+	} else if job.CancelRequested {
+		r.setJobPhase(ctx, job, statemachine.JobCancelled)
+		logger.Info("job marked as cancelled, no retry",
+			"cluster", job.ClusterName,
+			"jobID", job.JobID)
+	} else {
+		r.setJobPhase(ctx, job, statemachine.JobMaxRetriesExceeded)
+		logger.Info("job exceeded max retries",
+			"cluster", job.ClusterName,
+			"jobID", job.JobID,
+			"retryCount", job.RetryCount,
+			"maxRetries", maxRetries)
 	}
+}
 
-	return nil
+// setJobPhase moves job to phase if the job state machine allows the transition.
+// Illegal transitions are logged and leave the job untouched.
+func (r *KrknScenarioRunReconciler) setJobPhase(ctx context.Context, job *krknv1alpha1.ClusterJobStatus, phase string) bool {
+	if err := statemachine.Jobs.Validate(job.Phase, phase); err != nil {
+		log.FromContext(ctx).Error(err, "rejected job phase transition",
+			"cluster", job.ClusterName,
+			"jobID", job.JobID)
+		return false
+	}
+	job.Phase = phase
+	return true
 }
 
-// setCompletionTime sets the completion time if not already set
+// setCompletionTime sets the completion time if not already set and records the job duration
 func (r *KrknScenarioRunReconciler) setCompletionTime(job *krknv1alpha1.ClusterJobStatus) {
 	if job.CompletionTime == nil {
 		now := metav1.Now()
 		job.CompletionTime = &now
+		observeJobCompletion(job)
 	}
 }
 
@@ -884,6 +1303,16 @@ func (r *KrknScenarioRunReconciler) extractPodErrorMessage(pod *corev1.Pod) stri
 
 // extractFailureReason extracts a categorized failure reason from pod
 func (r *KrknScenarioRunReconciler) extractFailureReason(pod *corev1.Pod) string {
+	// Pods evicted from their node, the scenario did not fail on its own
+	if podEvicted(pod) {
+		return FailureReasonEvicted
+	}
+
+	// Pods killed by the kubelet on activeDeadlineSeconds
+	if pod.Status.Reason == FailureReasonDeadlineExceeded {
+		return FailureReasonDeadlineExceeded
+	}
+
 	if len(pod.Status.ContainerStatuses) == 0 {
 		return "PodNotScheduled"
 	}
@@ -927,7 +1356,7 @@ func (r *KrknScenarioRunReconciler) shouldRetryJob(job *krknv1alpha1.ClusterJobS
 
 	// Check retry count against max
 	if maxRetries == 0 {
-		maxRetries = 3 // Default
+		maxRetries = krknv1alpha1.DefaultMaxRetries
 	}
 
 	return job.RetryCount < maxRetries
@@ -956,8 +1385,12 @@ func (r *KrknScenarioRunReconciler) jobExistsForCluster(scenarioRun *krknv1alpha
 	for _, job := range scenarioRun.Status.ClusterJobs {
 		if job.ClusterName == clusterName {
 			// Don't count jobs in "Retrying" phase as existing,
-			// since we need to create a new pod for them
-			if job.Phase == "Retrying" {
+			// since we need to create a new pod for them, nor jobs held for maintenance or
+			// queued for a job slot, unless their cancellation was requested
+			if job.CancelRequested {
+				return true
+			}
+			if job.Phase == "Retrying" || heldForMaintenance(&job) || queuedForSlot(&job) {
 				return false
 			}
 			return true
@@ -968,7 +1401,7 @@ func (r *KrknScenarioRunReconciler) jobExistsForCluster(scenarioRun *krknv1alpha
 
 // calculateOverallStatus computes the overall phase and counters
 func (r *KrknScenarioRunReconciler) calculateOverallStatus(scenarioRun *krknv1alpha1.KrknScenarioRun) {
-	var successfulJobs, failedJobs, runningJobs, pendingJobs int
+	var successfulJobs, failedJobs, runningJobs, pendingJobs, skippedJobs int
 
 	for _, job := range scenarioRun.Status.ClusterJobs {
 		switch job.Phase {
@@ -980,6 +1413,8 @@ func (r *KrknScenarioRunReconciler) calculateOverallStatus(scenarioRun *krknv1al
 			runningJobs++
 		case "Pending":
 			pendingJobs++
+		case statemachine.JobSkippedMaintenance:
+			skippedJobs++
 		}
 	}
 
@@ -993,9 +1428,11 @@ func (r *KrknScenarioRunReconciler) calculateOverallStatus(scenarioRun *krknv1al
 		scenarioRun.Status.Phase = "Pending"
 	} else if runningJobs > 0 || pendingJobs > 0 {
 		scenarioRun.Status.Phase = "Running"
-	} else if failedJobs == totalJobs {
+	} else if successfulJobs == 0 {
+		// Every job failed or was skipped for maintenance: no chaos completed
 		scenarioRun.Status.Phase = "Failed"
-	} else if successfulJobs == totalJobs {
+	} else if successfulJobs+skippedJobs == totalJobs {
+		// Jobs skipped for maintenance are not failures
 		scenarioRun.Status.Phase = "Succeeded"
 	} else {
 		// Some succeeded, some failed
@@ -1043,7 +1480,11 @@ func (r *KrknScenarioRunReconciler) getKubeconfigFromProvider(ctx context.Contex
 	}
 
 	// Return the base64-encoded kubeconfig
-	return clusterConfig.Kubeconfig, nil
+	kubeconfigBase64, err := r.Cipher.Open(ctx, clusterConfig.Kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt kubeconfig of cluster '%s': %w", clusterName, err)
+	}
+	return kubeconfigBase64, nil
 }
 
 // statusEqual compares two KrknScenarioRunStatus to determine if they are equal
@@ -1065,6 +1506,9 @@ func (r *KrknScenarioRunReconciler) statusEqual(old, new *krknv1alpha1.KrknScena
 	if old.RunningJobs != new.RunningJobs {
 		return false
 	}
+	if !timeEqual(old.CompletionTime, new.CompletionTime) {
+		return false
+	}
 
 	// Compare ClusterJobs array length
 	if len(old.ClusterJobs) != len(new.ClusterJobs) {
@@ -1078,6 +1522,10 @@ func (r *KrknScenarioRunReconciler) statusEqual(old, new *krknv1alpha1.KrknScena
 		}
 	}
 
+	if !reflect.DeepEqual(old.RetryWaves, new.RetryWaves) {
+		return false
+	}
+
 	// Compare Conditions array
 	if !reflect.DeepEqual(old.Conditions, new.Conditions) {
 		return false
@@ -1092,12 +1540,20 @@ func (r *KrknScenarioRunReconciler) jobStatusEqual(old, new *krknv1alpha1.Cluste
 	if old.ClusterName != new.ClusterName ||
 		old.JobID != new.JobID ||
 		old.PodName != new.PodName ||
+		old.KubeconfigSecret != new.KubeconfigSecret ||
+		old.KubeconfigConfigMap != new.KubeconfigConfigMap ||
 		old.Phase != new.Phase ||
 		old.Message != new.Message ||
 		old.RetryCount != new.RetryCount ||
+		old.Evictions != new.Evictions ||
 		old.MaxRetries != new.MaxRetries ||
 		old.CancelRequested != new.CancelRequested ||
-		old.FailureReason != new.FailureReason {
+		old.FailureReason != new.FailureReason ||
+		old.FailureHooksTriggered != new.FailureHooksTriggered ||
+		old.MaintenanceReason != new.MaintenanceReason ||
+		old.Queued != new.Queued ||
+		old.SubstitutedFrom != new.SubstitutedFrom ||
+		old.ArtifactsUploadError != new.ArtifactsUploadError {
 		return false
 	}
 
@@ -1108,6 +1564,20 @@ func (r *KrknScenarioRunReconciler) jobStatusEqual(old, new *krknv1alpha1.Cluste
 		return false
 	}
 
+	// The image digest is recorded on its own once the scenario container was created
+	if !reflect.DeepEqual(old.EffectiveSpec, new.EffectiveSpec) {
+		return false
+	}
+	if !reflect.DeepEqual(old.Result, new.Result) {
+		return false
+	}
+	if !reflect.DeepEqual(old.Artifacts, new.Artifacts) {
+		return false
+	}
+	if !reflect.DeepEqual(old.Cleanup, new.Cleanup) {
+		return false
+	}
+
 	return true
 }
 
@@ -1176,9 +1646,14 @@ func countJobPhaseChanges(oldJobs, newJobs []krknv1alpha1.ClusterJobStatus) int
 
 // SetupWithManager sets up the controller with the Manager
 func (r *KrknScenarioRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := registerScenarioRunCollector(mgr.GetClient(), r.Namespace); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&krknv1alpha1.KrknScenarioRun{}).
-		Owns(&corev1.Pod{}).
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Owns(&batchv1.Job{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Complete(r)
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/notify"
+)
+
+// recordingNotifier captures deliveries instead of sending them, failing with err
+type recordingNotifier struct {
+	mu        sync.Mutex
+	delivered []notify.Notification
+	channels  []notify.Channel
+	err       error
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, channel notify.Channel, notification notify.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.channels = append(n.channels, channel)
+	n.delivered = append(n.delivered, notification)
+	return n.err
+}
+
+// newTestDispatcher sends through notifier, tests call Wait before looking at deliveries
+func newTestDispatcher(notifier notify.Notifier) *notify.Dispatcher {
+	return notify.NewDispatcher(notifier, notify.DefaultMaxInFlight, time.Second)
+}
+
+func TestNotifyObservers(t *testing.T) {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"},
+		Spec:       krknv1alpha1.KrknScenarioRunSpec{ScenarioName: "pod-scenarios"},
+		Status:     krknv1alpha1.KrknScenarioRunStatus{Phase: "Succeeded"},
+	}
+	_ = notify.Subscribe(scenarioRun, notify.Subscription{
+		UserID:  "user@example.com",
+		Channel: notify.Channel{Type: notify.ChannelWebhook, URL: "https://hooks.example.com"},
+	})
+
+	notifier := &recordingNotifier{}
+	r := &KrknScenarioRunReconciler{Notifications: newTestDispatcher(notifier)}
+	r.notifyObservers(context.Background(), scenarioRun, "Running")
+	r.Notifications.Wait()
+
+	if len(notifier.delivered) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifier.delivered))
+	}
+	got := notifier.delivered[0]
+	if got.PreviousPhase != "Running" || got.Phase != "Succeeded" || got.ScenarioName != "pod-scenarios" {
+		t.Errorf("unexpected notification: %+v", got)
+	}
+	if notifier.channels[0].URL != "https://hooks.example.com" {
+		t.Errorf("notification routed to wrong channel: %+v", notifier.channels[0])
+	}
+
+	// A reconciler without notifier is a no-op
+	(&KrknScenarioRunReconciler{}).notifyObservers(context.Background(), scenarioRun, "Running")
+
+	// Failed deliveries are recorded on the run
+	recorder := record.NewFakeRecorder(10)
+	r = &KrknScenarioRunReconciler{
+		Notifications: newTestDispatcher(&recordingNotifier{err: errors.New("connection refused")}),
+		Recorder:      recorder,
+	}
+	r.notifyObservers(context.Background(), scenarioRun, "Running")
+	r.Notifications.Wait()
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, EventNotificationFailed) || !strings.Contains(event, "connection refused") {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected a NotificationFailed event")
+	}
+}
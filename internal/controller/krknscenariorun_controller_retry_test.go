@@ -21,7 +21,9 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,19 +36,12 @@ func TestUpdateClusterJobStatuses_ProviderNameEmpty(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = krknv1alpha1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 
 	now := metav1.Now()
 
-	// Create a failed pod to trigger retry logic
-	failedPod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "pod-123",
-			Namespace: "default",
-		},
-		Status: corev1.PodStatus{
-			Phase: corev1.PodFailed,
-		},
-	}
+	// Create a failed Job to trigger retry logic
+	failedJob := newTestScenarioJob("job-123", failedJobCondition("BackoffLimitExceeded"))
 
 	// Create ScenarioRun with job that has empty ProviderName
 	scenarioRun := &krknv1alpha1.KrknScenarioRun{
@@ -77,7 +72,7 @@ func TestUpdateClusterJobStatuses_ProviderNameEmpty(t *testing.T) {
 	}
 
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
-		WithObjects(scenarioRun, failedPod).Build()
+		WithObjects(scenarioRun, failedJob).Build()
 
 	reconciler := &KrknScenarioRunReconciler{
 		Client:    fakeClient,
@@ -117,19 +112,12 @@ func TestUpdateClusterJobStatuses_ClusterNameEmpty(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = krknv1alpha1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 
 	now := metav1.Now()
 
-	// Create a failed pod to trigger retry logic
-	failedPod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "pod-456",
-			Namespace: "default",
-		},
-		Status: corev1.PodStatus{
-			Phase: corev1.PodFailed,
-		},
-	}
+	// Create a failed Job to trigger retry logic
+	failedJob := newTestScenarioJob("job-456", failedJobCondition("BackoffLimitExceeded"))
 
 	// Create ScenarioRun with job that has empty ClusterName
 	scenarioRun := &krknv1alpha1.KrknScenarioRun{
@@ -160,7 +148,7 @@ func TestUpdateClusterJobStatuses_ClusterNameEmpty(t *testing.T) {
 	}
 
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
-		WithObjects(scenarioRun, failedPod).Build()
+		WithObjects(scenarioRun, failedJob).Build()
 
 	reconciler := &KrknScenarioRunReconciler{
 		Client:    fakeClient,
@@ -195,3 +183,56 @@ func TestUpdateClusterJobStatuses_ClusterNameEmpty(t *testing.T) {
 		t.Error("Expected CompletionTime to be set")
 	}
 }
+
+func TestUpdateClusterJobStatuses_RejectsIllegalTransition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	now := metav1.Now()
+
+	// A Job reappearing with a running pod must not revive a job already marked Failed
+	activeJob := newTestScenarioJob("job-789")
+	runningPod := newTestJobPod("job-789", "pod-789", corev1.PodRunning, time.Now())
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-scenario",
+			Namespace: "default",
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{
+					ProviderName:   "krkn-operator",
+					ClusterName:    "cluster1",
+					JobID:          "job-789",
+					PodName:        "pod-789",
+					Phase:          "Failed",
+					FailureReason:  "PodNotFound",
+					StartTime:      &now,
+					CompletionTime: &now,
+					RetryCount:     0,
+					MaxRetries:     3,
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(scenarioRun, activeJob, runningPod).Build()
+
+	reconciler := &KrknScenarioRunReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Namespace: "default",
+	}
+
+	if err := reconciler.updateClusterJobStatuses(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if job := scenarioRun.Status.ClusterJobs[0]; job.Phase != "Failed" {
+		t.Errorf("Expected job to stay 'Failed', got '%s'", job.Phase)
+	}
+}
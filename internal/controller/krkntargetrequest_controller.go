@@ -19,9 +19,11 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package controller
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
@@ -30,14 +32,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
 	"github.com/krkn-chaos/krkn-operator/pkg/provider"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
 )
 
 // KrknTargetRequestReconciler reconciles a KrknTargetRequest object
@@ -46,6 +52,35 @@ type KrknTargetRequestReconciler struct {
 	Scheme            *runtime.Scheme
 	OperatorName      string
 	OperatorNamespace string
+	// Recorder records the progress of requests as Events (optional)
+	Recorder record.EventRecorder
+	// TargetSource lists the clusters contributed under OperatorName; nil contributes the
+	// KrknOperatorTargets of the operator namespace
+	TargetSource TargetSource
+	// ControllerName names the controller, TargetRequestControllerName when empty; set it
+	// when the operator runs several providers
+	ControllerName string
+	// Cipher seals the kubeconfigs written to the managed-clusters Secrets and opens the
+	// target Secrets sealed at rest (nil when encryption is off)
+	Cipher *envelope.Cipher
+	// CredentialsStore holds the credentials of the targets with a Vault path (nil when
+	// targets only use Secrets)
+	CredentialsStore secrets.Store
+	// MaxConcurrentReconciles is the number of parallel workers (0 uses the controller-runtime default)
+	MaxConcurrentReconciles int
+}
+
+// TargetSource lists the clusters a built-in provider contributes to target requests
+type TargetSource interface {
+	// ProvidedTargets returns the ready clusters of the provider with their kubeconfigs
+	ProvidedTargets(ctx context.Context) ([]ProvidedTarget, error)
+}
+
+// ProvidedTarget is a cluster contributed by a TargetSource
+type ProvidedTarget struct {
+	Target krknv1alpha1.ClusterTarget
+	// KubeconfigBase64 is the base64 kubeconfig of the cluster
+	KubeconfigBase64 string
 }
 
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krkntargetrequests,verbs=get;list;watch;update;patch;delete
@@ -53,6 +88,7 @@ type KrknTargetRequestReconciler struct {
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargetproviders,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknoperatortargetproviders/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile processes KrknTargetRequest resources to populate target data from KrknOperatorTarget CRs
 func (r *KrknTargetRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -94,6 +130,14 @@ func (r *KrknTargetRequestReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	// Requests restricted to other providers are left to them
+	if !krknRequest.Spec.IncludesProvider(r.OperatorName) {
+		logger.Info("Request does not target this provider, skipping",
+			"uuid", krknRequest.Spec.UUID,
+			"providers", krknRequest.Spec.Providers)
+		return ctrl.Result{}, nil
+	}
+
 	// 4. Ensure UUID label is set
 	if err := r.ensureUUIDLabel(ctx, &krknRequest); err != nil {
 		if isConflictError(err) {
@@ -126,15 +170,12 @@ func (r *KrknTargetRequestReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// 6. Query all KrknOperatorTarget CRs in operator namespace
-	var targets krknv1alpha1.KrknOperatorTargetList
-	if err := r.List(ctx, &targets, client.InNamespace(r.OperatorNamespace)); err != nil {
-		logger.Error(err, "Failed to list KrknOperatorTarget CRs")
+	// 6-7. Build the ClusterTarget list and kubeconfigs of the ready clusters
+	clusterTargets, kubeconfigs, err := r.contributedTargets(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list the targets of the provider")
 		return ctrl.Result{}, err
 	}
-
-	// 7. Build ClusterTarget list from ready targets
-	clusterTargets := r.buildClusterTargets(targets.Items)
 	logger.Info("Built cluster targets", "count", len(clusterTargets), "operator", r.OperatorName)
 
 	// 8. Update Status.TargetData[operatorName]
@@ -146,6 +187,8 @@ func (r *KrknTargetRequestReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		logger.Error(err, "Failed to update target data")
 		return ctrl.Result{}, err
 	}
+	recordEvent(r.Recorder, &krknRequest, corev1.EventTypeNormal, EventTargetsContributed,
+		"Provider %s contributed %d ready targets", r.OperatorName, len(clusterTargets))
 
 	// Refetch after target data update to avoid conflicts
 	if err := r.Get(ctx, req.NamespacedName, &krknRequest); err != nil {
@@ -154,8 +197,10 @@ func (r *KrknTargetRequestReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// 9. Write kubeconfigs to Secret (managed-clusters format)
-	if err := r.writeManagedClustersSecret(ctx, &krknRequest, targets.Items); err != nil {
+	if err := r.writeManagedClustersSecret(ctx, &krknRequest, kubeconfigs); err != nil {
 		logger.Error(err, "Failed to write managed-clusters Secret")
+		recordEvent(r.Recorder, &krknRequest, corev1.EventTypeWarning, EventSecretWriteFailed,
+			"Failed to write the kubeconfigs of provider %s: %v", r.OperatorName, err)
 		return ctrl.Result{}, err
 	}
 
@@ -242,11 +287,13 @@ func (r *KrknTargetRequestReconciler) buildClusterTargets(targets []krknv1alpha1
 			"clusterName", target.Spec.ClusterName,
 			"ready", target.Status.Ready)
 
-		// Only include ready targets
-		if target.Status.Ready {
+		// Only include ready targets; archived targets are blocked from new runs
+		if target.Status.Ready && !target.Status.Archived {
 			clusterTargets = append(clusterTargets, krknv1alpha1.ClusterTarget{
 				ClusterName:   target.Spec.ClusterName,
 				ClusterAPIURL: target.Spec.ClusterAPIURL,
+				ClusterUID:    target.Spec.UUID,
+				Labels:        target.Labels,
 			})
 			logger.Info("✅ Added ready target",
 				"clusterName", target.Spec.ClusterName,
@@ -282,14 +329,15 @@ func (r *KrknTargetRequestReconciler) updateTargetData(ctx context.Context, krkn
 	return nil
 }
 
-// checkCompletion checks if all active providers have contributed and marks the request as completed
+// checkCompletion checks if all expected providers have contributed and marks the request as completed.
+// Expected providers are the active ones, restricted to Spec.Providers when set.
 func (r *KrknTargetRequestReconciler) checkCompletion(ctx context.Context, krknRequest *krknv1alpha1.KrknTargetRequest, providerList *krknv1alpha1.KrknOperatorTargetProviderList) error {
 	logger := log.FromContext(ctx)
 
 	logger.Info("Found providers", "totalProviders", len(providerList.Items))
 
 	// Count active providers (reuse the list from early check in Reconcile)
-	activeProviders, activeProviderNames := countActiveProviders(providerList)
+	_, activeProviderNames := countActiveProviders(providerList)
 
 	// Log active providers
 	for _, provider := range providerList.Items {
@@ -300,26 +348,39 @@ func (r *KrknTargetRequestReconciler) checkCompletion(ctx context.Context, krknR
 		}
 	}
 
-	// Count contributors (operators that have added target data)
-	contributorCount := len(krknRequest.Status.TargetData)
+	expectedProviders := []string{}
+	for _, name := range activeProviderNames {
+		if krknRequest.Spec.IncludesProvider(name) {
+			expectedProviders = append(expectedProviders, name)
+		}
+	}
+
+	// Collect contributors (operators that have added target data)
 	contributorNames := []string{}
 	for name := range krknRequest.Status.TargetData {
 		contributorNames = append(contributorNames, name)
 	}
+	missingProviders := []string{}
+	for _, name := range expectedProviders {
+		if _, contributed := krknRequest.Status.TargetData[name]; !contributed {
+			missingProviders = append(missingProviders, name)
+		}
+	}
 
 	logger.Info("🔍 Checking completion",
-		"activeProviders", activeProviders,
-		"activeProviderNames", activeProviderNames,
-		"contributors", contributorCount,
+		"expectedProviders", expectedProviders,
 		"contributorNames", contributorNames,
+		"missingProviders", missingProviders,
 		"uuid", krknRequest.Spec.UUID)
 
-	// If all active providers have contributed, mark as completed
-	if activeProviders > 0 && contributorCount >= activeProviders {
-		logger.Info("✅ All active providers have contributed, marking as Completed",
+	// If all expected providers have contributed, mark as completed
+	if len(expectedProviders) > 0 && len(missingProviders) == 0 {
+		logger.Info("✅ All expected providers have contributed, marking as Completed",
 			"uuid", krknRequest.Spec.UUID,
-			"activeProviders", activeProviders,
-			"contributors", contributorCount)
+			"expectedProviders", len(expectedProviders),
+			"contributors", len(contributorNames))
+		// Identifiers are assigned by the operator, whatever the providers contributed
+		krknRequest.Status.AssignClusterIDs()
 		krknRequest.Status.Status = "Completed"
 		now := metav1.NewTime(time.Now())
 		krknRequest.Status.Completed = &now
@@ -327,10 +388,11 @@ func (r *KrknTargetRequestReconciler) checkCompletion(ctx context.Context, krknR
 			return err
 		}
 		logger.Info("✅ Request marked as Completed successfully")
+		recordEvent(r.Recorder, krknRequest, corev1.EventTypeNormal, EventRequestCompleted,
+			"All %d expected providers contributed targets", len(expectedProviders))
 	} else {
 		logger.Info("⏳ Waiting for more providers to contribute",
-			"needed", activeProviders,
-			"current", contributorCount)
+			"missing", missingProviders)
 	}
 
 	return nil
@@ -350,58 +412,57 @@ func NewNamespaceFilter(namespace string) predicate.Predicate {
 	})
 }
 
-// writeManagedClustersSecret writes kubeconfigs to the managed-clusters Secret
-func (r *KrknTargetRequestReconciler) writeManagedClustersSecret(ctx context.Context, krknRequest *krknv1alpha1.KrknTargetRequest, targets []krknv1alpha1.KrknOperatorTarget) error {
-	logger := log.FromContext(ctx)
-
-	// Fetch or create Secret
-	var secret corev1.Secret
-	secretName := krknRequest.Spec.UUID
-	err := r.Get(ctx, types.NamespacedName{
-		Name:      secretName,
-		Namespace: r.OperatorNamespace,
-	}, &secret)
-
-	secretExists := err == nil
-	if err != nil && client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to get Secret: %w", err)
+// contributedTargets returns the ready clusters of the provider and their managed-clusters
+// entries, from TargetSource or the KrknOperatorTargets of the operator namespace
+func (r *KrknTargetRequestReconciler) contributedTargets(ctx context.Context) ([]krknv1alpha1.ClusterTarget, map[string]map[string]string, error) {
+	if r.TargetSource == nil {
+		var targets krknv1alpha1.KrknOperatorTargetList
+		if err := r.List(ctx, &targets, client.InNamespace(r.OperatorNamespace)); err != nil {
+			return nil, nil, fmt.Errorf("failed to list KrknOperatorTarget CRs: %w", err)
+		}
+		return r.buildClusterTargets(targets.Items), r.targetKubeconfigs(ctx, targets.Items), nil
 	}
 
-	// Decode existing managed-clusters or create new structure
-	var managedClusters map[string]map[string]map[string]string
-	if secretExists && len(secret.Data["managed-clusters"]) > 0 {
-		if err := json.Unmarshal(secret.Data["managed-clusters"], &managedClusters); err != nil {
-			logger.Error(err, "Failed to unmarshal managed-clusters, creating new structure")
-			managedClusters = make(map[string]map[string]map[string]string)
+	provided, err := r.TargetSource.ProvidedTargets(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	clusterTargets := make([]krknv1alpha1.ClusterTarget, 0, len(provided))
+	kubeconfigs := make(map[string]map[string]string, len(provided))
+	for _, target := range provided {
+		clusterTargets = append(clusterTargets, target.Target)
+		kubeconfigs[target.Target.ClusterName] = map[string]string{
+			"cluster-name": target.Target.ClusterName,
+			"cluster-api":  target.Target.ClusterAPIURL,
+			"kubeconfig":   target.KubeconfigBase64,
 		}
-	} else {
-		managedClusters = make(map[string]map[string]map[string]string)
 	}
+	return clusterTargets, kubeconfigs, nil
+}
 
-	// Ensure our provider section exists
-	if managedClusters[r.OperatorName] == nil {
-		managedClusters[r.OperatorName] = make(map[string]map[string]string)
-	}
+// targetKubeconfigs returns the managed-clusters entries of the ready targets, read from
+// their Secrets; targets whose kubeconfig cannot be read are skipped
+func (r *KrknTargetRequestReconciler) targetKubeconfigs(ctx context.Context, targets []krknv1alpha1.KrknOperatorTarget) map[string]map[string]string {
+	logger := log.FromContext(ctx)
+	kubeconfigs := make(map[string]map[string]string)
 
 	// Add each ready target
 	for _, target := range targets {
-		if !target.Status.Ready {
+		if !target.Status.Ready || target.Status.Archived {
 			continue
 		}
 
-		// Fetch kubeconfig from target's Secret
-		var targetSecret corev1.Secret
-		if err := r.Get(ctx, types.NamespacedName{
-			Name:      target.Spec.SecretUUID,
-			Namespace: r.OperatorNamespace,
-		}, &targetSecret); err != nil {
-			logger.Error(err, "Failed to get target Secret, skipping",
+		// Fetch kubeconfig from target's Secret or from the credentials store
+		data, err := secrets.TargetCredentials(ctx, r.Client, r.CredentialsStore, &target)
+		if err != nil {
+			logger.Error(err, "Failed to get target credentials, skipping",
 				"cluster", target.Spec.ClusterName,
-				"secretUUID", target.Spec.SecretUUID)
+				"secretUUID", target.Spec.SecretUUID,
+				"vaultPath", target.Spec.VaultPath)
 			continue
 		}
 
-		kubeconfigData, exists := targetSecret.Data["kubeconfig"]
+		kubeconfigData, exists := data["kubeconfig"]
 		if !exists {
 			logger.Error(fmt.Errorf("kubeconfig not found"), "Skipping target",
 				"cluster", target.Spec.ClusterName)
@@ -410,22 +471,68 @@ func (r *KrknTargetRequestReconciler) writeManagedClustersSecret(ctx context.Con
 
 		// Unmarshal to get base64 kubeconfig
 		kubeconfigBase64, err := kubeconfig.UnmarshalSecretData(kubeconfigData)
+		if err == nil {
+			kubeconfigBase64, err = r.Cipher.Open(ctx, kubeconfigBase64)
+		}
 		if err != nil {
-			logger.Error(err, "Failed to unmarshal kubeconfig, skipping",
+			logger.Error(err, "Failed to read kubeconfig, skipping",
 				"cluster", target.Spec.ClusterName)
 			continue
 		}
 
-		// Add to managed-clusters structure
-		managedClusters[r.OperatorName][target.Spec.ClusterName] = map[string]string{
+		kubeconfigs[target.Spec.ClusterName] = map[string]string{
 			"cluster-name": target.Spec.ClusterName,
 			"cluster-api":  target.Spec.ClusterAPIURL,
 			"kubeconfig":   kubeconfigBase64,
 		}
+	}
+	return kubeconfigs
+}
+
+// writeManagedClustersSecret writes the kubeconfigs of the provider, keyed by cluster name,
+// to the managed-clusters Secret
+func (r *KrknTargetRequestReconciler) writeManagedClustersSecret(ctx context.Context, krknRequest *krknv1alpha1.KrknTargetRequest, kubeconfigs map[string]map[string]string) error {
+	logger := log.FromContext(ctx)
+
+	// Fetch or create Secret
+	var secret corev1.Secret
+	secretName := krknRequest.Spec.UUID
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      secretName,
+		Namespace: r.OperatorNamespace,
+	}, &secret)
+
+	secretExists := err == nil
+	if err != nil && client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get Secret: %w", err)
+	}
+
+	// Decode existing managed-clusters or create new structure
+	var managedClusters map[string]map[string]map[string]string
+	if secretExists && len(secret.Data["managed-clusters"]) > 0 {
+		if err := json.Unmarshal(secret.Data["managed-clusters"], &managedClusters); err != nil {
+			logger.Error(err, "Failed to unmarshal managed-clusters, creating new structure")
+			managedClusters = make(map[string]map[string]map[string]string)
+		}
+	} else {
+		managedClusters = make(map[string]map[string]map[string]string)
+	}
 
+	// Ensure our provider section exists
+	if managedClusters[r.OperatorName] == nil {
+		managedClusters[r.OperatorName] = make(map[string]map[string]string)
+	}
+	for clusterName, entry := range kubeconfigs {
+		sealed, err := r.Cipher.Seal(ctx, entry["kubeconfig"])
+		if err != nil {
+			return fmt.Errorf("failed to encrypt kubeconfig of cluster %s: %w", clusterName, err)
+		}
+		entry = maps.Clone(entry)
+		entry["kubeconfig"] = sealed
+		managedClusters[r.OperatorName][clusterName] = entry
 		logger.Info("Added cluster to managed-clusters",
 			"provider", r.OperatorName,
-			"cluster", target.Spec.ClusterName)
+			"cluster", clusterName)
 	}
 
 	// Marshal back to JSON
@@ -517,7 +624,8 @@ func (r *KrknTargetRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&krknv1alpha1.KrknTargetRequest{}).
-		Named("krkntargetrequest").
+		WithOptions(crcontroller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Named(cmp.Or(r.ControllerName, TargetRequestControllerName)).
 		WithEventFilter(NewNamespaceFilter(r.OperatorNamespace)).
 		Complete(r)
 }
@@ -19,7 +19,9 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package controller
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +33,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
 )
 
 const (
@@ -353,6 +357,90 @@ func TestReconcile_MarksCompleted(t *testing.T) {
 	}
 }
 
+func TestReconcile_ProvidersRestrictCompletion(t *testing.T) {
+	newProvider := func(name string) *krknv1alpha1.KrknOperatorTargetProvider {
+		return &krknv1alpha1.KrknOperatorTargetProvider{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testOperatorNamespace,
+			},
+			Spec: krknv1alpha1.KrknOperatorTargetProviderSpec{
+				OperatorName: name,
+				Active:       true,
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		providers         []string
+		expectContributed bool
+		expectedStatus    string
+	}{
+		{
+			name:              "all active providers when unrestricted",
+			providers:         nil,
+			expectContributed: true,
+			expectedStatus:    "pending",
+		},
+		{
+			name:              "only listed providers are awaited",
+			providers:         []string{testOperatorName},
+			expectContributed: true,
+			expectedStatus:    "Completed",
+		},
+		{
+			name:              "unlisted provider ignores the request",
+			providers:         []string{"acm-operator"},
+			expectContributed: false,
+			expectedStatus:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &krknv1alpha1.KrknTargetRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              testRequestName,
+					Namespace:         testOperatorNamespace,
+					CreationTimestamp: testNow,
+				},
+				Spec: krknv1alpha1.KrknTargetRequestSpec{
+					UUID:      testUUID,
+					Providers: tt.providers,
+				},
+			}
+
+			reconciler := setupTestReconciler(request, newProvider(testOperatorName), newProvider("acm-operator"))
+			ctx := context.Background()
+
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      testRequestName,
+					Namespace: testOperatorNamespace,
+				},
+			}); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+
+			var updated krknv1alpha1.KrknTargetRequest
+			if err := reconciler.Get(ctx, types.NamespacedName{
+				Name:      testRequestName,
+				Namespace: testOperatorNamespace,
+			}, &updated); err != nil {
+				t.Fatalf("Failed to get request: %v", err)
+			}
+
+			if _, contributed := updated.Status.TargetData[testOperatorName]; contributed != tt.expectContributed {
+				t.Errorf("Expected contributed=%v, got TargetData %v", tt.expectContributed, updated.Status.TargetData)
+			}
+			if updated.Status.Status != tt.expectedStatus {
+				t.Errorf("Expected status %q, got %q", tt.expectedStatus, updated.Status.Status)
+			}
+		})
+	}
+}
+
 func TestReconcile_SkipsCompletedRequests(t *testing.T) {
 	now := metav1.Now()
 	request := &krknv1alpha1.KrknTargetRequest{
@@ -565,3 +653,97 @@ func TestReconcile_OnlyIncludesReadyTargets(t *testing.T) {
 		t.Errorf("Expected cluster name 'ready-cluster', got %s", targets[0].ClusterName)
 	}
 }
+
+func TestBuildClusterTargets_SkipsArchived(t *testing.T) {
+	reconciler := setupTestReconciler()
+
+	targets := []krknv1alpha1.KrknOperatorTarget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"env": "staging"}},
+			Spec:       krknv1alpha1.KrknOperatorTargetSpec{ClusterName: "active", ClusterAPIURL: "https://active:6443"},
+			Status:     krknv1alpha1.KrknOperatorTargetStatus{Ready: true},
+		},
+		{
+			Spec:   krknv1alpha1.KrknOperatorTargetSpec{ClusterName: "archived", ClusterAPIURL: "https://archived:6443"},
+			Status: krknv1alpha1.KrknOperatorTargetStatus{Ready: true, Archived: true},
+		},
+	}
+
+	clusterTargets := reconciler.buildClusterTargets(targets)
+	if len(clusterTargets) != 1 {
+		t.Fatalf("Expected 1 cluster target, got %d", len(clusterTargets))
+	}
+	if clusterTargets[0].ClusterName != "active" {
+		t.Errorf("Expected active cluster, got %s", clusterTargets[0].ClusterName)
+	}
+	if clusterTargets[0].Labels["env"] != "staging" {
+		t.Errorf("Expected the labels of the target, got %v", clusterTargets[0].Labels)
+	}
+}
+
+func TestReconcile_SealsKubeconfigs(t *testing.T) {
+	wrapper, err := envelope.NewLocalKeyWrapper(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("NewLocalKeyWrapper failed: %v", err)
+	}
+	cipher := envelope.NewCipher(wrapper)
+	ctx := context.Background()
+
+	// The target Secret was sealed by the API
+	const kubeconfigBase64 = "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCg=="
+	sealed, err := cipher.Seal(ctx, kubeconfigBase64)
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	secretData, _ := kubeconfig.MarshalSecretData(sealed)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret-1", Namespace: testOperatorNamespace},
+		Data:       map[string][]byte{"kubeconfig": secretData},
+	}
+	target := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-1", Namespace: testOperatorNamespace},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{
+			UUID:          "uuid-1",
+			ClusterName:   "cluster-1",
+			ClusterAPIURL: "https://api.cluster1.com:6443",
+			SecretUUID:    "secret-1",
+		},
+		Status: krknv1alpha1.KrknOperatorTargetStatus{Ready: true},
+	}
+	request := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: testRequestName, Namespace: testOperatorNamespace, CreationTimestamp: testNow},
+		Spec:       krknv1alpha1.KrknTargetRequestSpec{UUID: testUUID},
+	}
+
+	provider := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: testOperatorName, Namespace: testOperatorNamespace},
+		Spec:       krknv1alpha1.KrknOperatorTargetProviderSpec{OperatorName: testOperatorName, Active: true},
+	}
+
+	reconciler := setupTestReconciler(request, target, secret, provider)
+	reconciler.Cipher = cipher
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: testRequestName, Namespace: testOperatorNamespace},
+	}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var managedSecret corev1.Secret
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: testUUID, Namespace: testOperatorNamespace}, &managedSecret); err != nil {
+		t.Fatalf("Failed to get managed-clusters Secret: %v", err)
+	}
+	if strings.Contains(string(managedSecret.Data["managed-clusters"]), kubeconfigBase64) {
+		t.Error("Expected no plaintext kubeconfig in the managed-clusters Secret")
+	}
+
+	// Runs open the sealed kubeconfig, and cannot without the key
+	runReconciler := &KrknScenarioRunReconciler{Client: reconciler.Client, Namespace: testOperatorNamespace, Cipher: cipher}
+	opened, err := runReconciler.getKubeconfigFromProvider(ctx, testUUID, testOperatorName, "cluster-1")
+	if err != nil || opened != kubeconfigBase64 {
+		t.Errorf("getKubeconfigFromProvider = %q, %v, expected the plaintext kubeconfig", opened, err)
+	}
+	runReconciler.Cipher = nil
+	if _, err := runReconciler.getKubeconfigFromProvider(ctx, testUUID, testOperatorName, "cluster-1"); err == nil {
+		t.Error("Expected an error reading a sealed kubeconfig without key")
+	}
+}
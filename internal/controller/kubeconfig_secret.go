@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+const (
+	// KubeconfigHashLabel holds the content hash of a shared kubeconfig Secret
+	KubeconfigHashLabel = "krkn.krkn-chaos.dev/kubeconfig-hash"
+	// KubeconfigRefsAnnotation lists the job IDs currently mounting a kubeconfig Secret
+	KubeconfigRefsAnnotation = "krkn.krkn-chaos.dev/kubeconfig-refs"
+)
+
+// kubeconfigSecretName returns the name of the kubeconfig Secret for the given run and
+// kubeconfig content. Jobs of the same run targeting the same cluster (retries included)
+// resolve to the same name and share a single Secret.
+func kubeconfigSecretName(scenarioRunName string, kubeconfig []byte) string {
+	return fmt.Sprintf("krkn-kubeconfig-%s-%s", shortHash([]byte(scenarioRunName), 8), kubeconfigHash(kubeconfig))
+}
+
+// kubeconfigHash returns the content hash used to name and label kubeconfig Secrets
+func kubeconfigHash(kubeconfig []byte) string {
+	return shortHash(kubeconfig, 16)
+}
+
+func shortHash(data []byte, length int) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:length]
+}
+
+// kubeconfigRefs returns the job IDs referencing a kubeconfig Secret (or ConfigMap)
+func kubeconfigRefs(obj metav1.Object) []string {
+	value := obj.GetAnnotations()[KubeconfigRefsAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func setKubeconfigRefs(obj metav1.Object, refs []string) {
+	slices.Sort(refs)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[KubeconfigRefsAnnotation] = strings.Join(slices.Compact(refs), ",")
+	obj.SetAnnotations(annotations)
+}
+
+// acquireKubeconfigSecret returns the name of the run's kubeconfig Secret for the given
+// content, creating it if needed, and records jobID as one of its references.
+func (r *KrknScenarioRunReconciler) acquireKubeconfigSecret(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	clusterName string,
+	kubeconfig []byte,
+	jobID string,
+) (string, error) {
+	name := kubeconfigSecretName(scenarioRun.Name, kubeconfig)
+	key := types.NamespacedName{Name: name, Namespace: r.Namespace}
+
+	retryable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+	err := retry.OnError(retry.DefaultRetry, retryable, func() error {
+		var existing corev1.Secret
+		err := r.Get(ctx, key, &existing)
+		if apierrors.IsNotFound(err) {
+			return r.createKubeconfigSecret(ctx, scenarioRun, name, clusterName, kubeconfig, jobID)
+		}
+		if err != nil {
+			return err
+		}
+
+		refs := kubeconfigRefs(&existing)
+		if slices.Contains(refs, jobID) {
+			return nil
+		}
+		setKubeconfigRefs(&existing, append(refs, jobID))
+		return r.Update(ctx, &existing)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to acquire kubeconfig Secret %s: %w", name, err)
+	}
+	return name, nil
+}
+
+func (r *KrknScenarioRunReconciler) createKubeconfigSecret(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	name string,
+	clusterName string,
+	kubeconfig []byte,
+	jobID string,
+) error {
+	labels := runLabels(scenarioRun, clusterName)
+	labels[KubeconfigHashLabel] = kubeconfigHash(kubeconfig)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.Namespace,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"config": kubeconfig,
+		},
+	}
+	setKubeconfigRefs(secret, []string{jobID})
+
+	// Owner reference makes the Secret go away with the run even if a release is missed
+	if err := controllerutil.SetControllerReference(scenarioRun, secret, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on kubeconfig Secret: %w", err)
+	}
+	return r.Create(ctx, secret)
+}
+
+// releaseKubeconfigSecret drops jobID from the references of a kubeconfig Secret and
+// deletes the Secret once no job references it anymore. Releasing a job that holds no
+// reference is a no-op.
+func (r *KrknScenarioRunReconciler) releaseKubeconfigSecret(ctx context.Context, name string, jobID string) error {
+	return r.releaseKubeconfig(ctx, &corev1.Secret{}, name, jobID)
+}
+
+// releaseKubeconfigConfigMap releases the kubeconfig ConfigMap of a job started by an
+// earlier operator version, like releaseKubeconfigSecret
+func (r *KrknScenarioRunReconciler) releaseKubeconfigConfigMap(ctx context.Context, name string, jobID string) error {
+	return r.releaseKubeconfig(ctx, &corev1.ConfigMap{}, name, jobID)
+}
+
+// releaseKubeconfig drops jobID from the references of the shared kubeconfig obj named
+// name, deleting it once unreferenced
+func (r *KrknScenarioRunReconciler) releaseKubeconfig(ctx context.Context, obj client.Object, name string, jobID string) error {
+	logger := log.FromContext(ctx)
+	key := types.NamespacedName{Name: name, Namespace: r.Namespace}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, key, obj); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		refs := kubeconfigRefs(obj)
+		remaining := slices.DeleteFunc(slices.Clone(refs), func(ref string) bool { return ref == jobID })
+		if len(remaining) == len(refs) {
+			return nil
+		}
+
+		if len(remaining) > 0 {
+			setKubeconfigRefs(obj, remaining)
+			return r.Update(ctx, obj)
+		}
+
+		// Precondition guards against a job acquiring the kubeconfig concurrently
+		logger.Info("deleting unreferenced kubeconfig", "name", name, "lastJobId", jobID)
+		resourceVersion := obj.GetResourceVersion()
+		err := r.Delete(ctx, obj, client.Preconditions{ResourceVersion: &resourceVersion})
+		return client.IgnoreNotFound(err)
+	})
+}
+
+// releaseJobKubeconfig releases the kubeconfig reference held by job, in its Secret or,
+// for jobs started by earlier operator versions, its ConfigMap
+func (r *KrknScenarioRunReconciler) releaseJobKubeconfig(ctx context.Context, job *krknv1alpha1.ClusterJobStatus) error {
+	if job.KubeconfigSecret != "" {
+		return r.releaseKubeconfigSecret(ctx, job.KubeconfigSecret, job.JobID)
+	}
+	if job.KubeconfigConfigMap != "" {
+		return r.releaseKubeconfigConfigMap(ctx, job.KubeconfigConfigMap, job.JobID)
+	}
+	return nil
+}
+
+// isJobSettled reports whether a job no longer needs its kubeconfig:
+// it reached a terminal phase, or failed with no retry left. A retry wave
+// acquires the kubeconfig again for the new job.
+func isJobSettled(job *krknv1alpha1.ClusterJobStatus) bool {
+	if statemachine.Jobs.IsTerminal(job.Phase) || job.Phase == statemachine.JobMaxRetriesExceeded {
+		return true
+	}
+	return job.Phase == statemachine.JobFailed && job.RetryCount >= job.MaxRetries && !job.CancelRequested &&
+		!evictionRestartLeft(job)
+}
+
+// releaseSettledKubeconfigs releases the kubeconfig references held by settled jobs
+func (r *KrknScenarioRunReconciler) releaseSettledKubeconfigs(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	logger := log.FromContext(ctx)
+
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if !isJobSettled(job) {
+			continue
+		}
+		if err := r.releaseJobKubeconfig(ctx, job); err != nil {
+			// Retried on the next reconcile; the owner reference is the final safety net
+			logger.Error(err, "failed to release kubeconfig",
+				"secret", job.KubeconfigSecret,
+				"configMap", job.KubeconfigConfigMap,
+				"jobID", job.JobID)
+		}
+	}
+}
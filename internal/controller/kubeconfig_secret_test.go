@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+func newKubeconfigTestReconciler(t *testing.T) (*KrknScenarioRunReconciler, *krknv1alpha1.KrknScenarioRun) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-run",
+			Namespace: "default",
+			UID:       "run-uid",
+		},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			ScenarioName:    "pod-scenarios",
+			TargetRequestID: "target-uuid",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenarioRun).Build()
+	return &KrknScenarioRunReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Namespace: "default",
+	}, scenarioRun
+}
+
+func TestKubeconfigSecretName(t *testing.T) {
+	a := kubeconfigSecretName("run-a", []byte("kubeconfig-1"))
+
+	if got := kubeconfigSecretName("run-a", []byte("kubeconfig-1")); got != a {
+		t.Errorf("expected stable name %q, got %q", a, got)
+	}
+	if got := kubeconfigSecretName("run-a", []byte("kubeconfig-2")); got == a {
+		t.Errorf("expected different content to produce a different name")
+	}
+	if got := kubeconfigSecretName("run-b", []byte("kubeconfig-1")); got == a {
+		t.Errorf("expected different runs to produce different names")
+	}
+}
+
+func TestAcquireKubeconfigSecret_SharedAcrossJobs(t *testing.T) {
+	ctx := context.Background()
+	r, scenarioRun := newKubeconfigTestReconciler(t)
+	kubeconfig := []byte("apiVersion: v1\nkind: Config\n")
+
+	first, err := r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-1")
+	if err != nil {
+		t.Fatalf("acquire job-1: %v", err)
+	}
+	second, err := r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-2")
+	if err != nil {
+		t.Fatalf("acquire job-2: %v", err)
+	}
+	if first != second {
+		t.Fatalf("expected jobs with the same kubeconfig to share a Secret, got %q and %q", first, second)
+	}
+
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets); err != nil {
+		t.Fatalf("list Secrets: %v", err)
+	}
+	if len(secrets.Items) != 1 {
+		t.Fatalf("expected 1 Secret, got %d", len(secrets.Items))
+	}
+	var cms corev1.ConfigMapList
+	if err := r.List(ctx, &cms); err != nil || len(cms.Items) != 0 {
+		t.Fatalf("expected no kubeconfig ConfigMap, got %d (%v)", len(cms.Items), err)
+	}
+
+	secret := secrets.Items[0]
+	if got := secret.Annotations[KubeconfigRefsAnnotation]; got != "job-1,job-2" {
+		t.Errorf("expected refs job-1,job-2, got %q", got)
+	}
+	if string(secret.Data["config"]) != string(kubeconfig) {
+		t.Errorf("unexpected kubeconfig content %q", secret.Data["config"])
+	}
+	if secret.Labels[joblabels.ScenarioRun] != scenarioRun.Name || secret.Labels[KubeconfigHashLabel] == "" {
+		t.Errorf("expected the run and hash labels, got %v", secret.Labels)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != scenarioRun.UID {
+		t.Errorf("expected the Secret to be owned by the run, got %+v", secret.OwnerReferences)
+	}
+
+	// Acquiring twice for the same job must not duplicate the reference
+	if _, err := r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-1"); err != nil {
+		t.Fatalf("re-acquire job-1: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: first, Namespace: "default"}, &secret); err != nil {
+		t.Fatalf("get Secret: %v", err)
+	}
+	if got := secret.Annotations[KubeconfigRefsAnnotation]; got != "job-1,job-2" {
+		t.Errorf("expected refs job-1,job-2 after re-acquire, got %q", got)
+	}
+}
+
+func TestReleaseKubeconfigSecret_DeletesWhenUnreferenced(t *testing.T) {
+	ctx := context.Background()
+	r, scenarioRun := newKubeconfigTestReconciler(t)
+	kubeconfig := []byte("apiVersion: v1\nkind: Config\n")
+
+	name, _ := r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-1")
+	_, _ = r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-2")
+	key := types.NamespacedName{Name: name, Namespace: "default"}
+
+	if err := r.releaseKubeconfigSecret(ctx, name, "job-1"); err != nil {
+		t.Fatalf("release job-1: %v", err)
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("expected Secret to survive while referenced: %v", err)
+	}
+	if got := secret.Annotations[KubeconfigRefsAnnotation]; got != "job-2" {
+		t.Errorf("expected refs job-2, got %q", got)
+	}
+
+	// Releasing an unknown job is a no-op
+	if err := r.releaseKubeconfigSecret(ctx, name, "job-1"); err != nil {
+		t.Fatalf("release job-1 again: %v", err)
+	}
+
+	if err := r.releaseKubeconfigSecret(ctx, name, "job-2"); err != nil {
+		t.Fatalf("release job-2: %v", err)
+	}
+	if err := r.Get(ctx, key, &secret); !apierrors.IsNotFound(err) {
+		t.Errorf("expected Secret to be deleted once unreferenced, got %v", err)
+	}
+
+	// Releasing after deletion is a no-op
+	if err := r.releaseKubeconfigSecret(ctx, name, "job-2"); err != nil {
+		t.Errorf("release after deletion: %v", err)
+	}
+}
+
+func TestReleaseSettledKubeconfigs(t *testing.T) {
+	ctx := context.Background()
+	r, scenarioRun := newKubeconfigTestReconciler(t)
+	kubeconfig := []byte("apiVersion: v1\nkind: Config\n")
+
+	name, _ := r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-1")
+	_, _ = r.acquireKubeconfigSecret(ctx, scenarioRun, "cluster-1", kubeconfig, "job-2")
+	scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{
+		{ClusterName: "cluster-1", JobID: "job-1", Phase: "Succeeded", KubeconfigSecret: name},
+		{ClusterName: "cluster-1", JobID: "job-2", Phase: "Running", KubeconfigSecret: name},
+	}
+
+	r.releaseSettledKubeconfigs(ctx, scenarioRun)
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &secret); err != nil {
+		t.Fatalf("expected Secret to survive while a job is running: %v", err)
+	}
+	if got := secret.Annotations[KubeconfigRefsAnnotation]; got != "job-2" {
+		t.Errorf("expected refs job-2, got %q", got)
+	}
+
+	scenarioRun.Status.ClusterJobs[1].Phase = "MaxRetriesExceeded"
+	r.releaseSettledKubeconfigs(ctx, scenarioRun)
+
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &secret); !apierrors.IsNotFound(err) {
+		t.Errorf("expected Secret to be deleted once all jobs settled, got %v", err)
+	}
+}
+
+func TestReleaseSettledKubeconfigs_LegacyConfigMap(t *testing.T) {
+	ctx := context.Background()
+	r, scenarioRun := newKubeconfigTestReconciler(t)
+
+	// Jobs started before kubeconfigs moved to Secrets still reference a ConfigMap
+	legacy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "krkn-kubeconfig-legacy", Namespace: "default"},
+		Data:       map[string]string{"config": "apiVersion: v1\nkind: Config\n"},
+	}
+	setKubeconfigRefs(legacy, []string{"job-1"})
+	if err := r.Create(ctx, legacy); err != nil {
+		t.Fatalf("create ConfigMap: %v", err)
+	}
+	scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{
+		{ClusterName: "cluster-1", JobID: "job-1", Phase: "Succeeded", KubeconfigConfigMap: legacy.Name},
+	}
+
+	r.releaseSettledKubeconfigs(ctx, scenarioRun)
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Name: legacy.Name, Namespace: "default"}, &cm); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the legacy ConfigMap to be deleted once its job settled, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+const (
+	// LegacyPodAdoptedLabel marks the ownerless scenario pods adopted by the operator
+	LegacyPodAdoptedLabel = "krkn.krkn-chaos.dev/adopted"
+	// LegacyPodAdoptedAtAnnotation records when a legacy pod was adopted (RFC 3339)
+	LegacyPodAdoptedAtAnnotation = "krkn.krkn-chaos.dev/adopted-at"
+
+	// DefaultLegacyPodRetention is how long a finished legacy pod is kept by default
+	DefaultLegacyPodRetention = time.Hour
+	// legacyPodMaxAge bounds the lifetime of a legacy pod that never finishes
+	legacyPodMaxAge = 24 * time.Hour
+)
+
+// LegacyPodAdopter takes over the scenario pods created without owner by the legacy API
+// path, which outlive operator reinstallations since no run deletes them. On startup and
+// every interval it labels each ownerless krkn-scenario pod as adopted, then deletes it
+// once it has been finished for the retention, counted from its adoption at the earliest
+// so that the logs of pods found after a reinstall stay readable for a while, or once it
+// is older than legacyPodMaxAge without finishing.
+type LegacyPodAdopter struct {
+	client    client.Client
+	namespace string
+	interval  time.Duration
+	retention time.Duration
+	// Recorder emits adoption and deletion events on the pods (nil disables events)
+	Recorder record.EventRecorder
+}
+
+// NewLegacyPodAdopter creates an adopter of the legacy pods in namespace, run every interval
+func NewLegacyPodAdopter(c client.Client, namespace string, interval, retention time.Duration) *LegacyPodAdopter {
+	return &LegacyPodAdopter{client: c, namespace: namespace, interval: interval, retention: retention}
+}
+
+// Start implements manager.Runnable
+func (a *LegacyPodAdopter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("legacy-pods")
+	logger.Info("Starting legacy scenario pod adopter",
+		"interval", a.interval, "retention", a.retention, "namespace", a.namespace)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		adopted, deleted, err := a.adopt(ctx, time.Now())
+		if err != nil {
+			// Retried on the next tick
+			logger.Error(err, "Failed to adopt legacy scenario pods")
+		}
+		if adopted > 0 || deleted > 0 {
+			logger.Info("Managed legacy scenario pods", "adopted", adopted, "deleted", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (a *LegacyPodAdopter) NeedLeaderElection() bool {
+	return true
+}
+
+// adopt labels the ownerless scenario pods not adopted yet and deletes the adopted ones
+// past their retention or maximum age. Returns how many pods it adopted and deleted.
+func (a *LegacyPodAdopter) adopt(ctx context.Context, now time.Time) (int, int, error) {
+	logger := log.FromContext(ctx).WithName("legacy-pods")
+
+	var pods corev1.PodList
+	if err := a.client.List(ctx, &pods, client.InNamespace(a.namespace),
+		client.MatchingLabels{joblabels.App: joblabels.AppScenario}); err != nil {
+		return 0, 0, fmt.Errorf("failed to list scenario pods: %w", err)
+	}
+
+	adopted, deleted := 0, 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if len(pod.OwnerReferences) > 0 || pod.DeletionTimestamp != nil {
+			continue
+		}
+		identity := joblabels.Read(pod.Labels)
+
+		adoptedAt, err := time.Parse(time.RFC3339, pod.Annotations[LegacyPodAdoptedAtAnnotation])
+		if pod.Labels[LegacyPodAdoptedLabel] != "true" || err != nil {
+			patch := client.MergeFrom(pod.DeepCopy())
+			pod.Labels[LegacyPodAdoptedLabel] = "true"
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			adoptedAt = now
+			pod.Annotations[LegacyPodAdoptedAtAnnotation] = now.UTC().Format(time.RFC3339)
+			if err := a.client.Patch(ctx, pod, patch); err != nil {
+				if client.IgnoreNotFound(err) == nil {
+					continue
+				}
+				return adopted, deleted, fmt.Errorf("failed to adopt pod %s: %w", pod.Name, err)
+			}
+			legacyPods.WithLabelValues("adopted").Inc()
+			recordEvent(a.Recorder, pod, corev1.EventTypeNormal, EventLegacyPodAdopted,
+				"Adopted legacy pod of scenario %s on cluster %s", identity.ScenarioName, identity.ClusterName)
+			logger.Info("Adopted legacy scenario pod", "pod", pod.Name, "jobId", identity.JobID)
+			adopted++
+		}
+
+		reason := legacyPodExpiry(pod, adoptedAt, now, a.retention)
+		if reason == "" {
+			continue
+		}
+		// Precondition guards against changes since the list
+		resourceVersion := pod.ResourceVersion
+		if err := a.client.Delete(ctx, pod, client.Preconditions{ResourceVersion: &resourceVersion}); client.IgnoreNotFound(err) != nil {
+			return adopted, deleted, fmt.Errorf("failed to delete legacy pod %s: %w", pod.Name, err)
+		}
+		legacyPods.WithLabelValues("deleted").Inc()
+		recordEvent(a.Recorder, pod, corev1.EventTypeNormal, EventLegacyPodDeleted, "Deleted legacy pod: %s", reason)
+		logger.Info("Deleted legacy scenario pod", "pod", pod.Name, "jobId", identity.JobID, "reason", reason)
+		deleted++
+	}
+	return adopted, deleted, nil
+}
+
+// legacyPodExpiry returns why an adopted pod is due for deletion, or "" to keep it
+func legacyPodExpiry(pod *corev1.Pod, adoptedAt, now time.Time, retention time.Duration) string {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		if now.Sub(pod.CreationTimestamp.Time) >= legacyPodMaxAge {
+			return fmt.Sprintf("not finished after %s", legacyPodMaxAge)
+		}
+		return ""
+	}
+
+	finishedAt := adoptedAt
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.FinishedAt.After(finishedAt) {
+			finishedAt = terminated.FinishedAt.Time
+		}
+	}
+	if now.Sub(finishedAt) >= retention {
+		return fmt.Sprintf("%s for %s", pod.Status.Phase, retention)
+	}
+	return ""
+}
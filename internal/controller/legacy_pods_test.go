@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+func TestLegacyPodAdopter_Adopt(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	now := time.Now()
+	legacyPod := func(name string, age time.Duration, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{joblabels.App: joblabels.AppScenario, joblabels.JobID: name},
+				CreationTimestamp: metav1.Time{Time: now.Add(-age)},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	running := legacyPod("running", time.Hour, corev1.PodRunning)
+	stuck := legacyPod("stuck", legacyPodMaxAge+time.Minute, corev1.PodPending)
+	// Finished long ago, but only just found: kept for the retention
+	finished := legacyPod("finished", 48*time.Hour, corev1.PodSucceeded)
+	finished.Status.ContainerStatuses = []corev1.ContainerStatus{{State: corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Time{Time: now.Add(-47 * time.Hour)}},
+	}}}
+	// Adopted by an earlier pass and finished past the retention
+	expired := legacyPod("expired", 3*time.Hour, corev1.PodFailed)
+	expired.Labels[LegacyPodAdoptedLabel] = "true"
+	expired.Annotations = map[string]string{LegacyPodAdoptedAtAnnotation: now.Add(-2 * time.Hour).UTC().Format(time.RFC3339)}
+	// Managed by a Job
+	owned := legacyPod("owned", 48*time.Hour, corev1.PodSucceeded)
+	owned.OwnerReferences = []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "Job", Name: "owned", UID: "uid"}}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(running, stuck, finished, expired, owned).Build()
+	recorder := record.NewFakeRecorder(10)
+	adopter := NewLegacyPodAdopter(fakeClient, "default", time.Minute, time.Hour)
+	adopter.Recorder = recorder
+
+	ctx := context.Background()
+	adopted, deleted, err := adopter.adopt(ctx, now)
+	if err != nil {
+		t.Fatalf("adopt failed: %v", err)
+	}
+	if adopted != 3 || deleted != 2 {
+		t.Errorf("expected 3 adopted and 2 deleted pods, got %d and %d", adopted, deleted)
+	}
+
+	for _, pod := range []*corev1.Pod{stuck, expired} {
+		err := fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{})
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("expected pod %s to be deleted, got %v", pod.Name, err)
+		}
+	}
+	for _, pod := range []*corev1.Pod{running, finished} {
+		var current corev1.Pod
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), &current); err != nil {
+			t.Fatalf("expected pod %s to be kept: %v", pod.Name, err)
+		}
+		if current.Labels[LegacyPodAdoptedLabel] != "true" || current.Annotations[LegacyPodAdoptedAtAnnotation] == "" {
+			t.Errorf("expected pod %s to be adopted, got %v %v", pod.Name, current.Labels, current.Annotations)
+		}
+	}
+	var current corev1.Pod
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(owned), &current); err != nil || current.Labels[LegacyPodAdoptedLabel] != "" {
+		t.Errorf("expected the pod of a Job to be left alone, got %v (%v)", current.Labels, err)
+	}
+
+	events := drainEvents(recorder)
+	slices.Sort(events)
+	want := []string{
+		"Normal " + EventLegacyPodAdopted, "Normal " + EventLegacyPodAdopted, "Normal " + EventLegacyPodAdopted,
+		"Normal " + EventLegacyPodDeleted, "Normal " + EventLegacyPodDeleted,
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("expected events %v, got %v", want, events)
+	}
+
+	// The finished pod goes once the retention has passed since its adoption
+	if _, deleted, err := adopter.adopt(ctx, now.Add(time.Hour)); err != nil || deleted != 1 {
+		t.Errorf("expected the finished pod to be deleted after the retention, got %d (%v)", deleted, err)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// MaintenanceAnnotation marks the KrknOperatorTarget of a cluster under planned maintenance,
+// e.g. while an upgrade is in progress. Its value is the maintenance reason shown on the
+// held or skipped cluster jobs; "false" (or removing the annotation) ends the maintenance.
+const MaintenanceAnnotation = "krkn.krkn-chaos.dev/maintenance"
+
+// Values of spec.maintenancePolicy
+const (
+	MaintenancePolicySkip = "skip"
+	MaintenancePolicyWait = "wait"
+)
+
+// maintenanceRecheckInterval is how often a run holding jobs checks whether the maintenance ended
+const maintenanceRecheckInterval = time.Minute
+
+// maintenanceReason returns the maintenance reason carried by the annotations of a target,
+// empty when the target is not under maintenance
+func maintenanceReason(annotations map[string]string) string {
+	value, ok := annotations[MaintenanceAnnotation]
+	if !ok {
+		return ""
+	}
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "false":
+		return ""
+	case "", "true":
+		return "planned maintenance"
+	}
+	return value
+}
+
+// clusterMaintenance returns the maintenance reason of a target cluster, empty when it is
+// not under maintenance
+func (r *KrknScenarioRunReconciler) clusterMaintenance(ctx context.Context, clusterName string) (string, error) {
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := r.List(ctx, &targets, client.InNamespace(r.Namespace)); err != nil {
+		return "", err
+	}
+	for _, target := range targets.Items {
+		if target.Spec.ClusterName != clusterName {
+			continue
+		}
+		if reason := maintenanceReason(target.Annotations); reason != "" {
+			return reason, nil
+		}
+	}
+	return "", nil
+}
+
+// heldForMaintenance reports whether a cluster job waits for the maintenance of its
+// cluster to end before its Job is created: a first attempt has no job ID yet, a retry
+// stays Retrying
+func heldForMaintenance(job *krknv1alpha1.ClusterJobStatus) bool {
+	if job.MaintenanceReason == "" {
+		return false
+	}
+	return job.Phase == statemachine.JobRetrying || (job.Phase == statemachine.JobPending && job.JobID == "")
+}
+
+// holdForMaintenance checks the maintenance of a cluster before its job is created and
+// reports whether the job must not be created now. Under maintenance the job is marked
+// SkippedMaintenance, or held until the maintenance ends with the wait policy. When the
+// maintenance state cannot be read the job is held too, chaos must not hit a cluster
+// that may be under maintenance.
+func (r *KrknScenarioRunReconciler) holdForMaintenance(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	providerName string,
+	clusterName string,
+) bool {
+	logger := log.FromContext(ctx)
+
+	reason, err := r.clusterMaintenance(ctx, clusterName)
+	if err != nil {
+		logger.Error(err, "failed to check cluster maintenance, holding job",
+			"cluster", clusterName,
+			"scenarioRun", scenarioRun.Name)
+		return true
+	}
+	if reason == "" {
+		return false
+	}
+
+	var job *krknv1alpha1.ClusterJobStatus
+	for i := range scenarioRun.Status.ClusterJobs {
+		if scenarioRun.Status.ClusterJobs[i].ClusterName == clusterName {
+			job = &scenarioRun.Status.ClusterJobs[i]
+			break
+		}
+	}
+	if job == nil {
+		scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{
+			ProviderName: providerName,
+			ClusterName:  clusterName,
+			ClusterID:    scenarioRun.TargetClusterID(providerName, clusterName),
+			Phase:        statemachine.Jobs.Initial(),
+		})
+		job = &scenarioRun.Status.ClusterJobs[len(scenarioRun.Status.ClusterJobs)-1]
+	}
+	job.MaintenanceReason = reason
+
+	if scenarioRun.Spec.MaintenancePolicy == MaintenancePolicyWait {
+		job.Message = fmt.Sprintf("Waiting for the cluster maintenance to end: %s", reason)
+		logger.Info("holding job of cluster under maintenance",
+			"cluster", clusterName,
+			"scenarioRun", scenarioRun.Name,
+			"maintenance", reason)
+		return true
+	}
+
+	if !r.setJobPhase(ctx, job, statemachine.JobSkippedMaintenance) {
+		return true
+	}
+	job.Message = fmt.Sprintf("Skipped, the cluster is under maintenance: %s", reason)
+	r.setCompletionTime(job)
+	logger.Info("skipped job of cluster under maintenance",
+		"cluster", clusterName,
+		"scenarioRun", scenarioRun.Name,
+		"maintenance", reason)
+	return true
+}
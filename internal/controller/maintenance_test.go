@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func setupMaintenanceTest(policy string, objects ...client.Object) (*KrknScenarioRunReconciler, *krknv1alpha1.KrknScenarioRun) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	target := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "target-1",
+			Namespace:   "default",
+			Annotations: map[string]string{MaintenanceAnnotation: "OpenShift upgrade to 4.17"},
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetSpec{UUID: "target-1", ClusterName: "cluster1"},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			ScenarioName:      "test-scenario",
+			MaxRetries:        3,
+			MaintenancePolicy: policy,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objects, target)...).Build()
+	return &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}, scenarioRun
+}
+
+func TestMaintenanceReason(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		want        string
+	}{
+		{annotations: nil, want: ""},
+		{annotations: map[string]string{MaintenanceAnnotation: "false"}, want: ""},
+		{annotations: map[string]string{MaintenanceAnnotation: "true"}, want: "planned maintenance"},
+		{annotations: map[string]string{MaintenanceAnnotation: ""}, want: "planned maintenance"},
+		{annotations: map[string]string{MaintenanceAnnotation: " ACM upgrade "}, want: "ACM upgrade"},
+	}
+	for _, tt := range tests {
+		if got := maintenanceReason(tt.annotations); got != tt.want {
+			t.Errorf("maintenanceReason(%v) = %q, want %q", tt.annotations, got, tt.want)
+		}
+	}
+}
+
+func TestHoldForMaintenance_Skip(t *testing.T) {
+	reconciler, scenarioRun := setupMaintenanceTest(MaintenancePolicySkip)
+	ctx := context.Background()
+
+	if !reconciler.holdForMaintenance(ctx, scenarioRun, "krkn-operator", "cluster1") {
+		t.Fatal("Expected the job of a cluster under maintenance not to be created")
+	}
+	job := scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobSkippedMaintenance || job.MaintenanceReason != "OpenShift upgrade to 4.17" || job.CompletionTime == nil {
+		t.Errorf("Expected a completed SkippedMaintenance job, got %+v", job)
+	}
+
+	if reconciler.holdForMaintenance(ctx, scenarioRun, "krkn-operator", "cluster2") {
+		t.Error("Expected the job of a cluster out of maintenance to be created")
+	}
+	if len(scenarioRun.Status.ClusterJobs) != 1 {
+		t.Errorf("Expected no status entry for cluster2, got %d jobs", len(scenarioRun.Status.ClusterJobs))
+	}
+
+	reconciler.calculateOverallStatus(scenarioRun)
+	if scenarioRun.Status.Phase != "Failed" {
+		t.Errorf("Expected a run whose jobs were all skipped to fail, got %s", scenarioRun.Status.Phase)
+	}
+	scenarioRun.Status.ClusterJobs = append(scenarioRun.Status.ClusterJobs,
+		krknv1alpha1.ClusterJobStatus{ClusterName: "cluster2", JobID: "job-2", Phase: statemachine.JobSucceeded})
+	reconciler.calculateOverallStatus(scenarioRun)
+	if scenarioRun.Status.Phase != "Succeeded" || scenarioRun.Status.FailedJobs != 0 {
+		t.Errorf("Expected skipped jobs not to count as failures, got %s with %d failed",
+			scenarioRun.Status.Phase, scenarioRun.Status.FailedJobs)
+	}
+}
+
+func TestHoldForMaintenance_Wait(t *testing.T) {
+	reconciler, scenarioRun := setupMaintenanceTest(MaintenancePolicyWait)
+	ctx := context.Background()
+
+	if !reconciler.holdForMaintenance(ctx, scenarioRun, "krkn-operator", "cluster1") {
+		t.Fatal("Expected the job of a cluster under maintenance to be held")
+	}
+	job := &scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobPending || job.JobID != "" || !heldForMaintenance(job) {
+		t.Fatalf("Expected a held Pending job, got %+v", *job)
+	}
+	if reconciler.jobExistsForCluster(scenarioRun, "cluster1") {
+		t.Error("Expected a held job to be created once the maintenance ends")
+	}
+
+	// A held job has no Job to look for
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if job.Phase != statemachine.JobPending {
+		t.Errorf("Expected the held job to stay Pending, got %s", job.Phase)
+	}
+
+	// The maintenance ends
+	var target krknv1alpha1.KrknOperatorTarget
+	if err := reconciler.Get(ctx, client.ObjectKey{Name: "target-1", Namespace: "default"}, &target); err != nil {
+		t.Fatalf("failed to get target: %v", err)
+	}
+	target.Annotations[MaintenanceAnnotation] = "false"
+	if err := reconciler.Update(ctx, &target); err != nil {
+		t.Fatalf("failed to update target: %v", err)
+	}
+	if reconciler.holdForMaintenance(ctx, scenarioRun, "krkn-operator", "cluster1") {
+		t.Error("Expected the job to be released once the maintenance ended")
+	}
+}
+
+func TestHandleJobFailure_RetryHeldForMaintenance(t *testing.T) {
+	now := metav1.Now()
+	reconciler, scenarioRun := setupMaintenanceTest(MaintenancePolicyWait,
+		newTestScenarioJob("job-1", failedJobCondition("BackoffLimitExceeded")))
+	scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{{
+		ProviderName: "krkn-operator",
+		ClusterName:  "cluster1",
+		JobID:        "job-1",
+		Phase:        statemachine.JobRunning,
+		StartTime:    &now,
+		MaxRetries:   3,
+	}}
+	ctx := context.Background()
+
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	job := &scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobRetrying || job.RetryCount != 1 || !heldForMaintenance(job) {
+		t.Fatalf("Expected the retry to be held, got %+v", *job)
+	}
+
+	// Later reconciles neither fail the held retry nor consume another retry
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if job.Phase != statemachine.JobRetrying || job.RetryCount != 1 {
+		t.Errorf("Expected the held retry to be kept, got phase %s retry %d", job.Phase, job.RetryCount)
+	}
+}
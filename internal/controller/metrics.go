@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// scenarioRunPhases are the phases reported by krkn_scenario_runs, zero-valued when empty
+var scenarioRunPhases = []string{"Pending", "Running", "Succeeded", "PartiallyFailed", "Failed"}
+
+var (
+	jobDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "krkn_scenario_job_duration_seconds",
+			Help:    "Duration of finished scenario jobs, from pod creation to completion, by cluster and phase.",
+			Buckets: prometheus.ExponentialBuckets(30, 2, 10), // 30s to ~4h
+		},
+		[]string{"cluster", "phase"},
+	)
+
+	jobRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "krkn_scenario_job_retries_total",
+			Help: "Total number of scenario job retries performed, by cluster.",
+		},
+		[]string{"cluster"},
+	)
+
+	orphansDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "krkn_orphaned_resources_deleted_total",
+			Help: "Total number of scenario job ConfigMaps and Secrets deleted after their Job and pods were gone, by kind.",
+		},
+		[]string{"kind"},
+	)
+
+	legacyPods = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "krkn_legacy_pods_total",
+			Help: "Total number of ownerless legacy scenario pods adopted and deleted by the operator, by action.",
+		},
+		[]string{"action"},
+	)
+
+	providerActivationChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "krkn_provider_activation_changes_total",
+			Help: "Total number of target provider activation changes, by provider and new state.",
+		},
+		[]string{"provider", "active"},
+	)
+
+	providerActive = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "krkn_provider_active",
+			Help: "Whether a target provider is active (1) or inactive (0).",
+		},
+		[]string{"provider"},
+	)
+
+	scenarioRunsDesc = prometheus.NewDesc(
+		"krkn_scenario_runs",
+		"Number of scenario runs by phase.",
+		[]string{"phase"}, nil,
+	)
+
+	runningPodsDesc = prometheus.NewDesc(
+		"krkn_scenario_running_pods",
+		"Number of scenario pods currently running.",
+		nil, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(jobDuration, jobRetries, orphansDeleted, legacyPods, providerActivationChanges, providerActive)
+}
+
+// observeJobCompletion records the duration of a job that just finished
+func observeJobCompletion(job *krknv1alpha1.ClusterJobStatus) {
+	if job.StartTime == nil || job.CompletionTime == nil {
+		return
+	}
+	jobDuration.WithLabelValues(job.ClusterName, job.Phase).
+		Observe(job.CompletionTime.Sub(job.StartTime.Time).Seconds())
+}
+
+// scenarioRunCollector reports run and pod gauges from the current KrknScenarioRun
+// statuses at scrape time, so they never drift from the cluster state
+type scenarioRunCollector struct {
+	reader    client.Reader
+	namespace string
+}
+
+// registerScenarioRunCollector registers the scenario run gauges with the
+// controller-runtime metrics registry. Registering twice is a no-op.
+func registerScenarioRunCollector(reader client.Reader, namespace string) error {
+	err := metrics.Registry.Register(&scenarioRunCollector{reader: reader, namespace: namespace})
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
+
+// Describe implements prometheus.Collector
+func (c *scenarioRunCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- scenarioRunsDesc
+	ch <- runningPodsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *scenarioRunCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var runs krknv1alpha1.KrknScenarioRunList
+	if err := c.reader.List(ctx, &runs, client.InNamespace(c.namespace)); err != nil {
+		log.Log.WithName("metrics").Error(err, "failed to list scenario runs")
+		ch <- prometheus.NewInvalidMetric(scenarioRunsDesc, err)
+		return
+	}
+
+	phases := make(map[string]int, len(scenarioRunPhases))
+	for _, phase := range scenarioRunPhases {
+		phases[phase] = 0
+	}
+	runningPods := 0
+	for _, run := range runs.Items {
+		if run.Status.Phase != "" {
+			phases[run.Status.Phase]++
+		}
+		for _, job := range run.Status.ClusterJobs {
+			if job.Phase == statemachine.JobRunning {
+				runningPods++
+			}
+		}
+	}
+
+	for phase, count := range phases {
+		ch <- prometheus.MustNewConstMetric(scenarioRunsDesc, prometheus.GaugeValue, float64(count), phase)
+	}
+	ch <- prometheus.MustNewConstMetric(runningPodsDesc, prometheus.GaugeValue, float64(runningPods))
+}
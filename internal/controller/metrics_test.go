@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestScenarioRunCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	newRun := func(name, namespace, phase string, jobPhases ...string) *krknv1alpha1.KrknScenarioRun {
+		run := &krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     krknv1alpha1.KrknScenarioRunStatus{Phase: phase},
+		}
+		for _, jobPhase := range jobPhases {
+			run.Status.ClusterJobs = append(run.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{Phase: jobPhase})
+		}
+		return run
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newRun("run-1", "krkn", "Running", "Running", "Running", "Pending"),
+		newRun("run-2", "krkn", "Running", "Running", "Succeeded"),
+		newRun("run-3", "krkn", "Succeeded", "Succeeded"),
+		newRun("run-4", "other", "Failed", "Running"),
+	).Build()
+
+	collector := &scenarioRunCollector{reader: fakeClient, namespace: "krkn"}
+
+	expected := `
+# HELP krkn_scenario_runs Number of scenario runs by phase.
+# TYPE krkn_scenario_runs gauge
+krkn_scenario_runs{phase="Failed"} 0
+krkn_scenario_runs{phase="PartiallyFailed"} 0
+krkn_scenario_runs{phase="Pending"} 0
+krkn_scenario_runs{phase="Running"} 2
+krkn_scenario_runs{phase="Succeeded"} 1
+# HELP krkn_scenario_running_pods Number of scenario pods currently running.
+# TYPE krkn_scenario_running_pods gauge
+krkn_scenario_running_pods 3
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetCompletionTime_ObservesDurationOnce(t *testing.T) {
+	r := &KrknScenarioRunReconciler{}
+	start := metav1.NewTime(time.Now().Add(-time.Minute))
+	job := &krknv1alpha1.ClusterJobStatus{
+		ClusterName: "metrics-test-cluster",
+		Phase:       "Succeeded",
+		StartTime:   &start,
+	}
+
+	r.setCompletionTime(job)
+	r.setCompletionTime(job)
+
+	histogram, err := jobDuration.GetMetricWithLabelValues("metrics-test-cluster", "Succeeded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metric dto.Metric
+	if err := histogram.(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatal(err)
+	}
+	if got := metric.GetHistogram().GetSampleCount(); got != 1 {
+		t.Errorf("expected 1 observation, got %d", got)
+	}
+	if got := metric.GetHistogram().GetSampleSum(); got < 60 {
+		t.Errorf("expected a duration of at least 60s, got %v", got)
+	}
+}
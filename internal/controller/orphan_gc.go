@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+const (
+	// DefaultOrphanGCInterval is how often the orphan collector runs by default
+	DefaultOrphanGCInterval = 10 * time.Minute
+	// orphanMinAge spares the resources a job creates before its Job exists
+	orphanMinAge = 5 * time.Minute
+)
+
+// OrphanCollector deletes the ConfigMaps and Secrets of scenario jobs whose Job and pods no
+// longer exist. Owner references only remove them with their run: the resources of a job
+// whose creation failed half-way, or whose Job was deleted by hand, would otherwise stay
+// until the run is deleted, or forever for resources created without owner.
+type OrphanCollector struct {
+	client    client.Client
+	namespace string
+	interval  time.Duration
+}
+
+// NewOrphanCollector creates a collector of the job resources in namespace, run every interval
+func NewOrphanCollector(c client.Client, namespace string, interval time.Duration) *OrphanCollector {
+	return &OrphanCollector{client: c, namespace: namespace, interval: interval}
+}
+
+// Start implements manager.Runnable
+func (o *OrphanCollector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphan-gc")
+	logger.Info("Starting orphaned resource collector", "interval", o.interval, "namespace", o.namespace)
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			deleted, err := o.collect(ctx, time.Now())
+			if err != nil {
+				// Retried on the next tick
+				logger.Error(err, "Failed to collect orphaned job resources")
+			}
+			if deleted > 0 {
+				logger.Info("Deleted orphaned job resources", "count", deleted)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (o *OrphanCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// collect deletes the job resources whose job has neither a Job nor a pod, and returns how
+// many it deleted. Resources younger than orphanMinAge are kept: a job creates its
+// ConfigMaps and Secrets before its Job.
+func (o *OrphanCollector) collect(ctx context.Context, now time.Time) (int, error) {
+	logger := log.FromContext(ctx).WithName("orphan-gc")
+	withJobID := []client.ListOption{client.InNamespace(o.namespace), client.HasLabels{joblabels.JobID}}
+
+	live := make(map[string]bool)
+	for _, list := range []client.ObjectList{&batchv1.JobList{}, &corev1.PodList{}} {
+		items, err := o.list(ctx, list, withJobID)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range items {
+			live[item.GetLabels()[joblabels.JobID]] = true
+		}
+	}
+
+	deleted := 0
+	for _, list := range []client.ObjectList{&corev1.ConfigMapList{}, &corev1.SecretList{}} {
+		items, err := o.list(ctx, list, withJobID)
+		if err != nil {
+			return deleted, err
+		}
+		for _, item := range items {
+			jobID := item.GetLabels()[joblabels.JobID]
+			if live[jobID] || now.Sub(item.GetCreationTimestamp().Time) < orphanMinAge {
+				continue
+			}
+			// Precondition guards against a Job created for it since the list
+			resourceVersion := item.GetResourceVersion()
+			err := o.client.Delete(ctx, item, client.Preconditions{ResourceVersion: &resourceVersion})
+			if client.IgnoreNotFound(err) != nil {
+				return deleted, fmt.Errorf("failed to delete orphaned %s: %w", item.GetName(), err)
+			}
+			orphansDeleted.WithLabelValues(kindOf(item)).Inc()
+			logger.V(1).Info("deleted orphaned job resource", "name", item.GetName(), "kind", kindOf(item), "jobId", jobID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (o *OrphanCollector) list(ctx context.Context, list client.ObjectList, opts []client.ListOption) ([]client.Object, error) {
+	if err := o.client.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list job resources: %w", err)
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		objects = append(objects, item.(client.Object))
+	}
+	return objects, nil
+}
+
+// kindOf returns the metric label of an orphaned resource
+func kindOf(object client.Object) string {
+	if _, ok := object.(*corev1.Secret); ok {
+		return "Secret"
+	}
+	return "ConfigMap"
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+func TestOrphanCollector_Collect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	now := time.Now()
+	jobMeta := func(name, jobID string, age time.Duration) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{joblabels.JobID: jobID},
+			CreationTimestamp: metav1.Time{Time: now.Add(-age)},
+		}
+	}
+
+	orphanFiles := &corev1.ConfigMap{ObjectMeta: jobMeta("krkn-job-gone-file-scenario-yaml", "gone", time.Hour)}
+	orphanRegistry := &corev1.Secret{ObjectMeta: jobMeta("krkn-job-gone-registry", "gone", time.Hour)}
+	kept := []client.Object{
+		// Job still exists
+		&batchv1.Job{ObjectMeta: jobMeta("krkn-job-running", "running", time.Hour)},
+		&corev1.ConfigMap{ObjectMeta: jobMeta("krkn-job-running-file-scenario-yaml", "running", time.Hour)},
+		// Job deleted, pod still terminating
+		&corev1.Pod{ObjectMeta: jobMeta("krkn-job-terminating-abcde", "terminating", time.Hour)},
+		&corev1.Secret{ObjectMeta: jobMeta("krkn-job-terminating-registry", "terminating", time.Hour)},
+		// Job not created yet
+		&corev1.ConfigMap{ObjectMeta: jobMeta("krkn-job-new-file-scenario-yaml", "new", time.Minute)},
+		// Not a job resource
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "krkn-operator-config", Namespace: "default", CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)},
+		}},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(kept, orphanFiles, orphanRegistry)...).Build()
+	collector := NewOrphanCollector(fakeClient, "default", time.Minute)
+
+	ctx := context.Background()
+	deleted, err := collector.collect(ctx, now)
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 orphaned resources to be deleted, got %d", deleted)
+	}
+
+	for _, object := range []client.Object{orphanFiles, orphanRegistry} {
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(object), object); !apierrors.IsNotFound(err) {
+			t.Errorf("expected orphaned %s to be deleted, got %v", object.GetName(), err)
+		}
+	}
+	for _, object := range kept {
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
+			t.Errorf("expected %s to be kept, got %v", object.GetName(), err)
+		}
+	}
+}
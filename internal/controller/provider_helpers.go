@@ -77,3 +77,14 @@ func countActiveProviders(providerList *krknv1alpha1.KrknOperatorTargetProviderL
 
 	return activeCount, activeNames
 }
+
+// countActiveContributors counts the active providers that have an entry in configData
+func countActiveContributors(configData map[string]krknv1alpha1.ProviderConfigData, activeNames []string) int {
+	count := 0
+	for _, name := range activeNames {
+		if _, contributed := configData[name]; contributed {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+)
+
+const (
+	// RancherProviderName is the provider name the Rancher downstream clusters are contributed under
+	RancherProviderName = "krkn-operator-rancher"
+	// RancherClusterIDLabel is the Rancher ID of the cluster a contributed target comes from
+	RancherClusterIDLabel = "krkn.krkn-chaos.dev/rancher-cluster-id"
+
+	// RancherURLKey is the key of the Rancher server URL in the provider Secret
+	RancherURLKey = "url"
+	// RancherTokenKey is the key of the Rancher API token in the provider Secret
+	RancherTokenKey = "token"
+	// RancherCAKey is the optional key of the PEM CA bundle of the Rancher server in the provider Secret
+	RancherCAKey = "ca.crt"
+
+	// DefaultRancherKubeconfigTTL is how long a generated kubeconfig is reused by default.
+	// Rancher creates a token for every generated kubeconfig, so they are not generated
+	// for every target request.
+	DefaultRancherKubeconfigTTL = 12 * time.Hour
+
+	// rancherLocalClusterID is the cluster Rancher itself runs on, not contributed
+	rancherLocalClusterID = "local"
+	// rancherActiveState is the state of the clusters whose API is reachable
+	rancherActiveState = "active"
+	// rancherRequestTimeout bounds every call to the Rancher API
+	rancherRequestTimeout = 30 * time.Second
+)
+
+// RancherTargetSource contributes the active downstream clusters of a Rancher server to
+// target requests. The server URL and API token are read from the provider Secret on
+// every request, so a rotated token is picked up; the kubeconfig of each cluster is
+// generated through the Rancher API, reduced to its current context (the Rancher proxy
+// endpoint of the cluster) and reused for KubeconfigTTL. Clusters are named after their
+// Rancher name and carry their Rancher labels.
+type RancherTargetSource struct {
+	// Reader reads the provider Secret
+	Reader client.Reader
+	// Namespace is the namespace of the provider Secret
+	Namespace string
+	// SecretName names the Secret holding the url, token and optional ca.crt of the server
+	SecretName string
+	// KubeconfigTTL is how long a generated kubeconfig is reused, DefaultRancherKubeconfigTTL when 0
+	KubeconfigTTL time.Duration
+
+	mu          sync.Mutex
+	kubeconfigs map[string]rancherKubeconfig
+}
+
+// rancherKubeconfig is a generated kubeconfig of a cluster of a Rancher server
+type rancherKubeconfig struct {
+	server           string
+	kubeconfigBase64 string
+	apiURL           string
+	generated        time.Time
+}
+
+// rancherCluster is a cluster of the Rancher v3 API
+type rancherCluster struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	State  string            `json:"state"`
+	Labels map[string]string `json:"labels"`
+}
+
+// rancherServer is a Rancher server as configured in the provider Secret
+type rancherServer struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// ProvidedTargets implements TargetSource
+func (s *RancherTargetSource) ProvidedTargets(ctx context.Context) ([]ProvidedTarget, error) {
+	logger := log.FromContext(ctx).WithName("rancher-provider")
+
+	server, err := s.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var clusters struct {
+		Data []rancherCluster `json:"data"`
+	}
+	if err := server.call(ctx, http.MethodGet, "/v3/clusters?limit=-1", &clusters); err != nil {
+		return nil, fmt.Errorf("failed to list Rancher clusters: %w", err)
+	}
+
+	targets := make([]ProvidedTarget, 0, len(clusters.Data))
+	listed := map[string]bool{}
+	for _, cluster := range clusters.Data {
+		listed[cluster.ID] = true
+		if cluster.ID == rancherLocalClusterID {
+			continue
+		}
+		if cluster.State != rancherActiveState {
+			logger.V(1).Info("Skipping Rancher cluster not active", "cluster", cluster.Name,
+				"id", cluster.ID, "state", cluster.State)
+			continue
+		}
+
+		generated, err := s.clusterKubeconfig(ctx, server, cluster.ID)
+		if err != nil {
+			logger.Info("Skipping Rancher cluster without usable kubeconfig", "cluster", cluster.Name,
+				"id", cluster.ID, "reason", err.Error())
+			continue
+		}
+
+		labels := maps.Clone(cluster.Labels)
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[RancherClusterIDLabel] = cluster.ID
+
+		targets = append(targets, ProvidedTarget{
+			Target: krknv1alpha1.ClusterTarget{
+				ClusterName:   cluster.Name,
+				ClusterAPIURL: generated.apiURL,
+				ClusterUID:    cluster.ID,
+				Labels:        labels,
+			},
+			KubeconfigBase64: generated.kubeconfigBase64,
+		})
+	}
+	s.forget(listed)
+
+	slices.SortFunc(targets, func(a, b ProvidedTarget) int {
+		return strings.Compare(a.Target.ClusterName, b.Target.ClusterName)
+	})
+	return targets, nil
+}
+
+// server reads the Rancher server configuration from the provider Secret
+func (s *RancherTargetSource) server(ctx context.Context) (*rancherServer, error) {
+	var secret corev1.Secret
+	if err := s.Reader.Get(ctx, types.NamespacedName{Name: s.SecretName, Namespace: s.Namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get Rancher provider Secret %s: %w", s.SecretName, err)
+	}
+	serverURL := strings.TrimRight(strings.TrimSpace(string(secret.Data[RancherURLKey])), "/")
+	token := strings.TrimSpace(string(secret.Data[RancherTokenKey]))
+	if serverURL == "" || token == "" {
+		return nil, fmt.Errorf("rancher provider Secret %s needs %s and %s", s.SecretName, RancherURLKey, RancherTokenKey)
+	}
+	if parsed, err := url.Parse(serverURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("rancher URL %q of Secret %s is not an https URL", serverURL, s.SecretName)
+	}
+
+	// The configuration is read for every request, its connections are not kept
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	if ca := secret.Data[RancherCAKey]; len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("rancher provider Secret %s has no valid certificate in %s", s.SecretName, RancherCAKey)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &rancherServer{
+		url:    serverURL,
+		token:  token,
+		client: &http.Client{Timeout: rancherRequestTimeout, Transport: transport},
+	}, nil
+}
+
+// clusterKubeconfig returns the kubeconfig of a cluster, generated less than
+// KubeconfigTTL ago by the same server or generated now
+func (s *RancherTargetSource) clusterKubeconfig(ctx context.Context, server *rancherServer, clusterID string) (rancherKubeconfig, error) {
+	ttl := s.KubeconfigTTL
+	if ttl == 0 {
+		ttl = DefaultRancherKubeconfigTTL
+	}
+	s.mu.Lock()
+	cached, found := s.kubeconfigs[clusterID]
+	s.mu.Unlock()
+	if found && cached.server == server.url && time.Since(cached.generated) < ttl {
+		return cached, nil
+	}
+
+	var response struct {
+		Config string `json:"config"`
+	}
+	path := "/v3/clusters/" + url.PathEscape(clusterID) + "?action=generateKubeconfig"
+	if err := server.call(ctx, http.MethodPost, path, &response); err != nil {
+		return rancherKubeconfig{}, fmt.Errorf("failed to generate kubeconfig: %w", err)
+	}
+	if response.Config == "" {
+		return rancherKubeconfig{}, fmt.Errorf("rancher returned an empty kubeconfig")
+	}
+
+	kubeconfigBase64, err := kubeconfig.ForContext(base64.StdEncoding.EncodeToString([]byte(response.Config)), "")
+	if err != nil {
+		return rancherKubeconfig{}, err
+	}
+	if err := kubeconfig.Validate(kubeconfigBase64); err != nil {
+		return rancherKubeconfig{}, err
+	}
+	apiURL, err := kubeconfig.ExtractAPIURL(kubeconfigBase64)
+	if err != nil {
+		return rancherKubeconfig{}, err
+	}
+	apiURL, err = kubeconfig.NormalizeAPIURL(apiURL)
+	if err != nil {
+		return rancherKubeconfig{}, err
+	}
+
+	generated := rancherKubeconfig{
+		server:           server.url,
+		kubeconfigBase64: kubeconfigBase64,
+		apiURL:           apiURL,
+		generated:        time.Now(),
+	}
+	s.mu.Lock()
+	if s.kubeconfigs == nil {
+		s.kubeconfigs = map[string]rancherKubeconfig{}
+	}
+	s.kubeconfigs[clusterID] = generated
+	s.mu.Unlock()
+	return generated, nil
+}
+
+// forget drops the kubeconfigs of the clusters no longer listed by the server
+func (s *RancherTargetSource) forget(listed map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for clusterID := range s.kubeconfigs {
+		if !listed[clusterID] {
+			delete(s.kubeconfigs, clusterID)
+		}
+	}
+}
+
+// call sends an authenticated request to the Rancher API and decodes its JSON response
+func (s *rancherServer) call(ctx context.Context, method, path string, response any) error {
+	req, err := http.NewRequestWithContext(ctx, method, s.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("rancher API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
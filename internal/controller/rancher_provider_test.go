@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rancherServerSecret returns the provider Secret of a Rancher test server
+func rancherServerSecret(server *httptest.Server, token string) *corev1.Secret {
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "rancher", Namespace: testOperatorNamespace},
+		Data: map[string][]byte{
+			RancherURLKey:   []byte(server.URL),
+			RancherTokenKey: []byte(token),
+			RancherCAKey:    ca,
+		},
+	}
+}
+
+func TestRancherTargetSource_ProvidedTargets(t *testing.T) {
+	var generated atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			http.Error(w, `{"message":"must authenticate"}`, http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/clusters":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{
+				{"id": "local", "name": "local", "state": "active"},
+				{"id": "c-m-edge", "name": "edge", "state": "active", "labels": map[string]string{"env": "prod"}},
+				{"id": "c-m-new", "name": "new", "state": "provisioning"},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/clusters/c-m-edge" &&
+			r.URL.Query().Get("action") == "generateKubeconfig":
+			generated.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"config": string(acmAdminKubeconfig("https://rancher.example.com/k8s/clusters/c-m-edge", "kubeconfig-u-1")),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	reconciler := setupTestReconciler(rancherServerSecret(server, "token-1"))
+	source := &RancherTargetSource{Reader: reconciler.Client, Namespace: testOperatorNamespace, SecretName: "rancher"}
+	ctx := context.Background()
+
+	for range 2 {
+		targets, err := source.ProvidedTargets(ctx)
+		if err != nil {
+			t.Fatalf("ProvidedTargets failed: %v", err)
+		}
+		if len(targets) != 1 {
+			t.Fatalf("Expected only the active downstream cluster, got %+v", targets)
+		}
+		target := targets[0].Target
+		if target.ClusterName != "edge" || target.ClusterAPIURL != "https://rancher.example.com/k8s/clusters/c-m-edge" ||
+			target.Labels["env"] != "prod" || target.Labels[RancherClusterIDLabel] != "c-m-edge" {
+			t.Errorf("Unexpected target %+v", target)
+		}
+		if targets[0].KubeconfigBase64 == "" {
+			t.Error("Expected the generated kubeconfig")
+		}
+	}
+	// Every generated kubeconfig creates a Rancher token, it is reused between requests
+	if generated.Load() != 1 {
+		t.Errorf("Expected the kubeconfig to be generated once, got %d", generated.Load())
+	}
+
+	// A wrong token fails the whole contribution
+	secret := rancherServerSecret(server, "token-2")
+	if err := reconciler.Update(ctx, secret); err != nil {
+		t.Fatalf("Failed to update Secret: %v", err)
+	}
+	if _, err := source.ProvidedTargets(ctx); err == nil {
+		t.Error("Expected an error with a rejected token")
+	}
+
+	// Only https servers are accepted
+	secret.Data[RancherURLKey] = []byte("http://rancher.example.com")
+	if err := reconciler.Update(ctx, secret); err != nil {
+		t.Fatalf("Failed to update Secret: %v", err)
+	}
+	if _, err := source.ProvidedTargets(ctx); err == nil {
+		t.Error("Expected an error with an http URL")
+	}
+	if !slices.Equal(slices.Sorted(maps.Keys(source.kubeconfigs)), []string{"c-m-edge"}) {
+		t.Errorf("Expected the kubeconfig of the listed cluster to stay cached, got %v", source.kubeconfigs)
+	}
+}
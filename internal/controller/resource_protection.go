@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+const (
+	// ResourceProtectionFinalizer holds the deletion of a Secret or ConfigMap consumed by
+	// active scenario runs until the last of them finished
+	ResourceProtectionFinalizer = "krkn.krkn-chaos.dev/in-use-by-scenario-run"
+	// InUseByAnnotation lists the active scenario runs consuming a protected resource
+	InUseByAnnotation = "krkn.krkn-chaos.dev/in-use-by"
+)
+
+// consumedSecrets returns the Secrets of the operator namespace a run reads while it is
+// active: the kubeconfigs of its targets and the credentials of its integrations. The
+// ConfigMaps and Secrets created for its jobs are owned by the run and not listed.
+func consumedSecrets(scenarioRun *krknv1alpha1.KrknScenarioRun) []string {
+	spec := &scenarioRun.Spec
+	secrets := []string{spec.TargetRequestID}
+	if spec.Artifacts != nil {
+		secrets = append(secrets, spec.Artifacts.CredentialsSecret)
+	}
+	if spec.Elasticsearch != nil {
+		secrets = append(secrets, spec.Elasticsearch.CredentialsSecret)
+	}
+	if spec.HealthCheck != nil && spec.HealthCheck.BearerTokenSecret != nil {
+		secrets = append(secrets, spec.HealthCheck.BearerTokenSecret.Name)
+	}
+	secrets = slices.DeleteFunc(secrets, func(name string) bool { return name == "" })
+	slices.Sort(secrets)
+	return slices.Compact(secrets)
+}
+
+// inUseBy returns the runs consuming a protected resource
+func inUseBy(object client.Object) []string {
+	value := object.GetAnnotations()[InUseByAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setInUseBy records the runs consuming a resource, and removes the protection once
+// none is left
+func setInUseBy(object client.Object, runs []string) {
+	annotations := object.GetAnnotations()
+	if len(runs) == 0 {
+		delete(annotations, InUseByAnnotation)
+		object.SetAnnotations(annotations)
+		controllerutil.RemoveFinalizer(object, ResourceProtectionFinalizer)
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	slices.Sort(runs)
+	annotations[InUseByAnnotation] = strings.Join(slices.Compact(runs), ",")
+	object.SetAnnotations(annotations)
+	controllerutil.AddFinalizer(object, ResourceProtectionFinalizer)
+}
+
+// protectResource records runName as a consumer of a Secret or ConfigMap, protecting it
+// from deletion. Missing resources are left to the job creation to report, and resources
+// already being deleted cannot be protected anymore.
+func (r *KrknScenarioRunReconciler) protectResource(ctx context.Context, object client.Object, name, runName string) error {
+	key := types.NamespacedName{Name: name, Namespace: r.Namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, key, object); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		runs := inUseBy(object)
+		if slices.Contains(runs, runName) || !object.GetDeletionTimestamp().IsZero() {
+			return nil
+		}
+		setInUseBy(object, append(runs, runName))
+		return r.Update(ctx, object)
+	})
+}
+
+// releaseResource drops runName from the consumers of a Secret or ConfigMap, letting its
+// pending deletion complete once no active run consumes it
+func (r *KrknScenarioRunReconciler) releaseResource(ctx context.Context, object client.Object, name, runName string) error {
+	key := types.NamespacedName{Name: name, Namespace: r.Namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, key, object); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		runs := inUseBy(object)
+		if !slices.Contains(runs, runName) {
+			return nil
+		}
+		setInUseBy(object, slices.DeleteFunc(runs, func(run string) bool { return run == runName }))
+		return client.IgnoreNotFound(r.Update(ctx, object))
+	})
+}
+
+// syncResourceProtection protects the Secrets a run consumes from deletion while it is
+// active, so unrelated cleanup does not break its in-flight jobs, and releases them once
+// it finished. A retried run protects them again.
+func (r *KrknScenarioRunReconciler) syncResourceProtection(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	if runFinished(scenarioRun) {
+		return r.releaseConsumedResources(ctx, scenarioRun)
+	}
+	var errs []error
+	for _, name := range consumedSecrets(scenarioRun) {
+		if err := r.protectResource(ctx, &corev1.Secret{}, name, scenarioRun.Name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to protect secret %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// releaseConsumedResources releases the Secrets protected for a run
+func (r *KrknScenarioRunReconciler) releaseConsumedResources(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	logger := log.FromContext(ctx)
+	var errs []error
+	for _, name := range consumedSecrets(scenarioRun) {
+		if err := r.releaseResource(ctx, &corev1.Secret{}, name, scenarioRun.Name); err != nil {
+			logger.Error(err, "failed to release consumed secret", "scenarioRun", scenarioRun.Name, "secret", name)
+			errs = append(errs, fmt.Errorf("failed to release secret %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestConsumedSecrets(t *testing.T) {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{Spec: krknv1alpha1.KrknScenarioRunSpec{
+		TargetRequestID: "target-uuid",
+		Artifacts:       &krknv1alpha1.ArtifactStorage{CredentialsSecret: "s3-creds"},
+		Elasticsearch:   &krknv1alpha1.ElasticsearchTelemetry{URL: "https://es:9200"},
+		HealthCheck: &krknv1alpha1.HealthCheck{BearerTokenSecret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "s3-creds"},
+			Key:                  "token",
+		}},
+	}}
+
+	want := []string{"s3-creds", "target-uuid"}
+	if got := consumedSecrets(scenarioRun); !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestSyncResourceProtection(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "target-uuid", Namespace: "default"}}
+	newRun := func(name string) *krknv1alpha1.KrknScenarioRun {
+		return &krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       krknv1alpha1.KrknScenarioRunSpec{TargetRequestID: "target-uuid"},
+			Status:     krknv1alpha1.KrknScenarioRunStatus{Phase: "Running"},
+		}
+	}
+	run1, run2 := newRun("run-1"), newRun("run-2")
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(secret)
+
+	// Syncing again keeps a single reference per run
+	for _, run := range []*krknv1alpha1.KrknScenarioRun{run1, run2, run1} {
+		if err := reconciler.syncResourceProtection(ctx, run); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	var current corev1.Secret
+	if err := fakeClient.Get(ctx, key, &current); err != nil {
+		t.Fatalf("Failed to get secret: %v", err)
+	}
+	if got := inUseBy(&current); !slices.Equal(got, []string{"run-1", "run-2"}) {
+		t.Errorf("Expected both runs to consume the secret, got %v", got)
+	}
+	if !slices.Contains(current.Finalizers, ResourceProtectionFinalizer) {
+		t.Errorf("Expected the protection finalizer, got %v", current.Finalizers)
+	}
+
+	// Deleted mid-run, the secret stays until the last run finished
+	if err := fakeClient.Delete(ctx, &current); err != nil {
+		t.Fatalf("Failed to delete secret: %v", err)
+	}
+	run1.Status.Phase = "Succeeded"
+	if err := reconciler.syncResourceProtection(ctx, run1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, &current); err != nil {
+		t.Fatalf("Expected the secret to be kept for run-2, got %v", err)
+	}
+	if got := inUseBy(&current); !slices.Equal(got, []string{"run-2"}) {
+		t.Errorf("Expected run-2 to consume the secret, got %v", got)
+	}
+
+	// A run started while the secret is being deleted cannot protect it
+	if err := reconciler.syncResourceProtection(ctx, newRun("run-3")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, &current); err != nil || slices.Contains(inUseBy(&current), "run-3") {
+		t.Errorf("Expected run-3 not to protect a deleted secret, got %v %v", inUseBy(&current), err)
+	}
+
+	if err := reconciler.releaseConsumedResources(ctx, run2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, &current); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the released secret deletion to complete, got %v", err)
+	}
+
+	// Releasing a missing secret is a no-op
+	if err := reconciler.releaseConsumedResources(ctx, run2); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// krknChaosDataMarker precedes the JSON krkn prints at the end of a run: its telemetry,
+// wrapped with the critical alerts that fired during the run
+var krknChaosDataMarker = []byte("Chaos data:")
+
+// krknChaosOutput is the part of the krkn chaos data the result is made of
+type krknChaosOutput struct {
+	Telemetry      *krknTelemetry `json:"telemetry"`
+	CriticalAlerts []struct {
+		AlertName        string `json:"alert_name"`
+		AlertDescription string `json:"alert_description"`
+	} `json:"critical_alerts"`
+}
+
+type krknTelemetry struct {
+	Scenarios []struct {
+		Scenario     string `json:"scenario"`
+		ScenarioType string `json:"scenario_type"`
+		ExitStatus   int32  `json:"exit_status"`
+		AffectedPods struct {
+			Unrecovered []struct {
+				PodName   string `json:"pod_name"`
+				Namespace string `json:"namespace"`
+			} `json:"unrecovered"`
+		} `json:"affected_pods"`
+	} `json:"scenarios"`
+	HealthChecks []struct {
+		URL    string `json:"url"`
+		Status bool   `json:"status"`
+	} `json:"health_checks"`
+}
+
+// krknChaosData returns the last chaos data printed in a scenario log, nil when the log
+// holds none
+func krknChaosData(logs []byte) json.RawMessage {
+	index := bytes.LastIndex(logs, krknChaosDataMarker)
+	if index < 0 {
+		return nil
+	}
+	data := logs[index+len(krknChaosDataMarker):]
+	start := bytes.IndexByte(data, '{')
+	if start < 0 {
+		return nil
+	}
+
+	// The JSON is followed by the rest of the log, decode a single value
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(data[start:])).Decode(&raw); err != nil {
+		return nil
+	}
+	return raw
+}
+
+// parseKrknResult parses the scenario outcomes and failed checks from the last chaos data
+// printed in a scenario log. Krkn versions printing the bare telemetry are supported too.
+// It returns nil when the log holds no chaos data.
+func parseKrknResult(logs []byte) *krknv1alpha1.ScenarioResult {
+	raw := krknChaosData(logs)
+	if raw == nil {
+		return nil
+	}
+	var output krknChaosOutput
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return nil
+	}
+	if output.Telemetry == nil {
+		output.Telemetry = &krknTelemetry{}
+		if err := json.Unmarshal(raw, output.Telemetry); err != nil {
+			return nil
+		}
+	}
+
+	result := &krknv1alpha1.ScenarioResult{TelemetryCollected: true}
+	addCheck := func(check string) {
+		if !slices.Contains(result.FailedChecks, check) {
+			result.FailedChecks = append(result.FailedChecks, check)
+		}
+	}
+	for _, scenario := range output.Telemetry.Scenarios {
+		result.Scenarios = append(result.Scenarios, krknv1alpha1.ScenarioOutcome{
+			Scenario:   scenario.Scenario,
+			Type:       scenario.ScenarioType,
+			ExitStatus: scenario.ExitStatus,
+		})
+		for _, pod := range scenario.AffectedPods.Unrecovered {
+			addCheck(fmt.Sprintf("pod %s/%s did not recover", pod.Namespace, pod.PodName))
+		}
+	}
+	// Health checks are reported on every status change of the URL
+	for _, check := range output.Telemetry.HealthChecks {
+		if !check.Status {
+			addCheck("health check " + check.URL + " failed")
+		}
+	}
+	for _, alert := range output.CriticalAlerts {
+		check := "critical alert " + alert.AlertName
+		if alert.AlertDescription != "" {
+			check += ": " + alert.AlertDescription
+		}
+		addCheck(check)
+	}
+	return result
+}
+
+// resultSummary returns the one-line summary of a result
+func resultSummary(result *krknv1alpha1.ScenarioResult) string {
+	if !result.TelemetryCollected {
+		if result.ExitCode == 0 {
+			return "krkn completed successfully"
+		}
+		return fmt.Sprintf("krkn exited with code %d", result.ExitCode)
+	}
+
+	failed := 0
+	for _, scenario := range result.Scenarios {
+		if scenario.ExitStatus != 0 {
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("%d of %d scenarios failed", failed, len(result.Scenarios))
+	if len(result.FailedChecks) > 0 {
+		summary += fmt.Sprintf(", %d failed checks", len(result.FailedChecks))
+	}
+	if result.ExitCode != 0 {
+		summary += fmt.Sprintf(" (exit code %d)", result.ExitCode)
+	}
+	return summary
+}
+
+// recordExitResult records the result of a job that just finished from the exit code of
+// its scenario container. The krkn telemetry is added once the log is collected.
+func recordExitResult(job *krknv1alpha1.ClusterJobStatus, pod *corev1.Pod) {
+	if pod == nil {
+		return
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == "scenario" && status.State.Terminated != nil {
+			job.Result = &krknv1alpha1.ScenarioResult{ExitCode: status.State.Terminated.ExitCode}
+			job.Result.Summary = resultSummary(job.Result)
+			return
+		}
+	}
+}
+
+// recordJobResults adds the krkn telemetry parsed by the artifact collector to the result
+// of the finished jobs. Collecting the artifacts updates their ConfigMap, which triggers
+// a reconcile of the run.
+func (r *KrknScenarioRunReconciler) recordJobResults(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if r.Artifacts == nil {
+		return
+	}
+	for i := range scenarioRun.Status.ClusterJobs {
+		job := &scenarioRun.Status.ClusterJobs[i]
+		if job.Result == nil || job.Result.TelemetryCollected {
+			continue
+		}
+
+		var cm corev1.ConfigMap
+		key := types.NamespacedName{Name: artifactsConfigMapName(job.JobID), Namespace: r.Namespace}
+		if err := r.Get(ctx, key, &cm); err != nil {
+			continue
+		}
+		encoded, ok := cm.Data[ArtifactResultKey]
+		if !ok {
+			continue
+		}
+		var parsed krknv1alpha1.ScenarioResult
+		if err := json.Unmarshal([]byte(encoded), &parsed); err != nil {
+			log.FromContext(ctx).Error(err, "failed to decode krkn result",
+				"cluster", job.ClusterName,
+				"jobID", job.JobID)
+			continue
+		}
+
+		job.Result.Scenarios = parsed.Scenarios
+		job.Result.FailedChecks = parsed.FailedChecks
+		job.Result.TelemetryCollected = true
+		job.Result.Summary = resultSummary(job.Result)
+	}
+}
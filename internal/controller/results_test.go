@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+const chaosDataLog = `2025-01-10 10:00:00,000 [INFO] Starting krkn
+2025-01-10 10:05:00,000 [INFO] Chaos data:
+{
+    "telemetry": {
+        "scenarios": [
+            {"scenario": "scenarios/pod.yaml", "scenario_type": "pod_disruption_scenarios", "exit_status": 0,
+             "affected_pods": {"recovered": [], "unrecovered": []}},
+            {"scenario": "scenarios/etcd.yaml", "scenario_type": "pod_disruption_scenarios", "exit_status": 1,
+             "affected_pods": {"recovered": [], "unrecovered": [{"pod_name": "etcd-0", "namespace": "openshift-etcd"}]}}
+        ],
+        "health_checks": [
+            {"url": "https://app.example.com", "status": true},
+            {"url": "https://api.example.com", "status": false},
+            {"url": "https://api.example.com", "status": false}
+        ]
+    },
+    "critical_alerts": [{"alert_name": "etcdNoLeader", "alert_severity": "critical", "alert_description": "etcd has no leader"}]
+}
+2025-01-10 10:05:01,000 [INFO] Successfully finished running Kraken
+`
+
+func TestParseKrknResult(t *testing.T) {
+	tests := []struct {
+		name string
+		logs string
+		want *krknv1alpha1.ScenarioResult
+	}{
+		{
+			name: "chaos data",
+			logs: chaosDataLog,
+			want: &krknv1alpha1.ScenarioResult{
+				TelemetryCollected: true,
+				Scenarios: []krknv1alpha1.ScenarioOutcome{
+					{Scenario: "scenarios/pod.yaml", Type: "pod_disruption_scenarios", ExitStatus: 0},
+					{Scenario: "scenarios/etcd.yaml", Type: "pod_disruption_scenarios", ExitStatus: 1},
+				},
+				FailedChecks: []string{
+					"pod openshift-etcd/etcd-0 did not recover",
+					"health check https://api.example.com failed",
+					"critical alert etcdNoLeader: etcd has no leader",
+				},
+			},
+		},
+		{
+			name: "bare telemetry",
+			logs: "[INFO] Chaos data:\n" + `{"scenarios": [{"scenario": "node.yaml", "scenario_type": "node_scenarios", "exit_status": 0}]}`,
+			want: &krknv1alpha1.ScenarioResult{
+				TelemetryCollected: true,
+				Scenarios:          []krknv1alpha1.ScenarioOutcome{{Scenario: "node.yaml", Type: "node_scenarios"}},
+			},
+		},
+		{name: "no chaos data", logs: "[INFO] Starting krkn\n[ERROR] config not found\n"},
+		{name: "truncated chaos data", logs: "[INFO] Chaos data:\n{\"telemetry\": {\"scenarios\": ["},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKrknResult([]byte(tt.logs)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKrknResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultSummary(t *testing.T) {
+	tests := []struct {
+		result *krknv1alpha1.ScenarioResult
+		want   string
+	}{
+		{result: &krknv1alpha1.ScenarioResult{}, want: "krkn completed successfully"},
+		{result: &krknv1alpha1.ScenarioResult{ExitCode: 2}, want: "krkn exited with code 2"},
+		{
+			result: &krknv1alpha1.ScenarioResult{
+				ExitCode:           1,
+				TelemetryCollected: true,
+				Scenarios:          []krknv1alpha1.ScenarioOutcome{{ExitStatus: 0}, {ExitStatus: 1}},
+				FailedChecks:       []string{"health check https://api.example.com failed"},
+			},
+			want: "1 of 2 scenarios failed, 1 failed checks (exit code 1)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := resultSummary(tt.result); got != tt.want {
+			t.Errorf("resultSummary(%+v) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestRecordJobResults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	encoded, err := json.Marshal(parseKrknResult([]byte(chaosDataLog)))
+	if err != nil {
+		t.Fatalf("failed to encode result: %v", err)
+	}
+	artifacts := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: artifactsConfigMapName("job-1"), Namespace: "default"},
+		Data:       map[string]string{ArtifactLogKey: chaosDataLog, ArtifactResultKey: string(encoded)},
+	}
+	reconciler := &KrknScenarioRunReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(artifacts).Build(),
+		Scheme:    scheme,
+		Namespace: "default",
+		Artifacts: &ArtifactCollector{},
+	}
+
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		Name:  "scenario",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+	}}}}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Status: krknv1alpha1.KrknScenarioRunStatus{ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+			{ClusterName: "cluster-1", JobID: "job-1", Phase: statemachine.JobFailed},
+			{ClusterName: "cluster-2", JobID: "job-2", Phase: statemachine.JobSucceeded},
+			{ClusterName: "cluster-3", JobID: "job-3", Phase: statemachine.JobRunning},
+		}},
+	}
+	recordExitResult(&scenarioRun.Status.ClusterJobs[0], pod)
+	recordExitResult(&scenarioRun.Status.ClusterJobs[1], pod)
+
+	reconciler.recordJobResults(context.Background(), scenarioRun)
+
+	result := scenarioRun.Status.ClusterJobs[0].Result
+	if result == nil || !result.TelemetryCollected || result.ExitCode != 1 || len(result.Scenarios) != 2 {
+		t.Fatalf("expected the telemetry to be added to the exit result, got %+v", result)
+	}
+	if result.Summary != "1 of 2 scenarios failed, 3 failed checks (exit code 1)" {
+		t.Errorf("unexpected summary %q", result.Summary)
+	}
+	// No artifacts collected yet
+	if result := scenarioRun.Status.ClusterJobs[1].Result; result == nil || result.TelemetryCollected ||
+		result.Summary != "krkn exited with code 1" {
+		t.Errorf("expected only the exit result, got %+v", result)
+	}
+	if scenarioRun.Status.ClusterJobs[2].Result != nil {
+		t.Errorf("expected no result for a running job, got %+v", scenarioRun.Status.ClusterJobs[2].Result)
+	}
+}
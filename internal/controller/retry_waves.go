@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// startDueRetryWaves starts every retry wave whose scheduled time has come: the failed
+// jobs of its clusters move to Retrying with fresh retry counters, so the reconcile loop
+// creates their new jobs. Returns how long until the next scheduled wave is due, 0 if none.
+func (r *KrknScenarioRunReconciler) startDueRetryWaves(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) time.Duration {
+	logger := log.FromContext(ctx)
+	now := metav1.Now()
+	var nextWave time.Duration
+
+	// A cancelled run is not retried
+	if scenarioRun.Spec.CancelRequested {
+		return 0
+	}
+
+	for i := range scenarioRun.Status.RetryWaves {
+		wave := &scenarioRun.Status.RetryWaves[i]
+		if wave.StartTime != nil {
+			continue
+		}
+		if wait := wave.ScheduledAt.Sub(now.Time); wait > 0 {
+			if nextWave == 0 || wait < nextWave {
+				nextWave = wait
+			}
+			continue
+		}
+
+		retried := 0
+		for j := range scenarioRun.Status.ClusterJobs {
+			job := &scenarioRun.Status.ClusterJobs[j]
+			if !slices.Contains(wave.Clusters, job.ClusterName) {
+				continue
+			}
+			// Clusters retried or cancelled since the wave was requested are left alone
+			if job.Phase != statemachine.JobFailed && job.Phase != statemachine.JobMaxRetriesExceeded {
+				continue
+			}
+			if !r.setJobPhase(ctx, job, statemachine.JobRetrying) {
+				continue
+			}
+			job.RetryCount = 0
+			job.Evictions = 0
+			job.MaxRetries = 0 // Set from spec again on the next failure
+			job.CancelRequested = false
+			job.FailureReason = ""
+			job.FailureHooksTriggered = false
+			job.LastRetryTime = &now
+			jobRetries.WithLabelValues(job.ClusterName).Inc()
+			retried++
+		}
+
+		wave.StartTime = &now
+		logger.Info("started retry wave",
+			"scenarioRun", scenarioRun.Name,
+			"wave", wave.Number,
+			"clusters", wave.Clusters,
+			"retriedJobs", retried)
+	}
+
+	return nextWave
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func TestStartDueRetryWaves(t *testing.T) {
+	now := time.Now()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-run", Namespace: "default"},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			Phase: "PartiallyFailed",
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ClusterName: "failed", JobID: "job-1", Phase: statemachine.JobFailed, RetryCount: 3, MaxRetries: 3, FailureReason: "PodNotFound"},
+				{ClusterName: "exhausted", JobID: "job-2", Phase: statemachine.JobMaxRetriesExceeded, RetryCount: 3, MaxRetries: 3, FailureHooksTriggered: true},
+				{ClusterName: "succeeded", JobID: "job-3", Phase: statemachine.JobSucceeded},
+				{ClusterName: "later", JobID: "job-4", Phase: statemachine.JobMaxRetriesExceeded, RetryCount: 3, MaxRetries: 3},
+			},
+			RetryWaves: []krknv1alpha1.RetryWave{
+				{
+					Number:      1,
+					RequestedAt: metav1.NewTime(now),
+					ScheduledAt: metav1.NewTime(now.Add(-time.Second)),
+					Clusters:    []string{"failed", "exhausted", "succeeded"},
+				},
+				{
+					Number:      2,
+					RequestedAt: metav1.NewTime(now),
+					ScheduledAt: metav1.NewTime(now.Add(time.Hour)),
+					Clusters:    []string{"later"},
+				},
+			},
+		},
+	}
+
+	r := &KrknScenarioRunReconciler{}
+	next := r.startDueRetryWaves(context.Background(), scenarioRun)
+
+	if next <= 0 || next > time.Hour {
+		t.Errorf("expected a requeue for the scheduled wave, got %v", next)
+	}
+	if scenarioRun.Status.RetryWaves[0].StartTime == nil {
+		t.Error("expected the due wave to be started")
+	}
+	if scenarioRun.Status.RetryWaves[1].StartTime != nil {
+		t.Error("expected the scheduled wave to wait")
+	}
+
+	for _, job := range scenarioRun.Status.ClusterJobs[:2] {
+		if job.Phase != statemachine.JobRetrying || job.RetryCount != 0 || job.MaxRetries != 0 || job.LastRetryTime == nil {
+			t.Errorf("expected %s to be retried with fresh counters, got %+v", job.ClusterName, job)
+		}
+		if job.FailureReason != "" || job.FailureHooksTriggered {
+			t.Errorf("expected the failure of %s to be cleared, got %+v", job.ClusterName, job)
+		}
+	}
+	if phase := scenarioRun.Status.ClusterJobs[2].Phase; phase != statemachine.JobSucceeded {
+		t.Errorf("expected succeeded clusters to be left alone, got %s", phase)
+	}
+	if phase := scenarioRun.Status.ClusterJobs[3].Phase; phase != statemachine.JobMaxRetriesExceeded {
+		t.Errorf("expected clusters of the scheduled wave to wait, got %s", phase)
+	}
+}
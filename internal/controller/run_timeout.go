@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// FailureReasonDeadlineExceeded is the failure reason of a job whose Job ran longer than
+// the run's spec.timeoutSeconds. It matches the Job condition reason set by the Job controller.
+const FailureReasonDeadlineExceeded = batchv1.JobReasonDeadlineExceeded
+
+// jobDeadlineExceeded reports whether batchJob, still active, was started longer than the
+// run's timeout ago. As for activeDeadlineSeconds, the deadline counts from the Job start.
+func (r *KrknScenarioRunReconciler) jobDeadlineExceeded(scenarioRun *krknv1alpha1.KrknScenarioRun, batchJob *batchv1.Job) bool {
+	if scenarioRun.Spec.TimeoutSeconds == nil || batchJob.Status.StartTime == nil {
+		return false
+	}
+	if scenarioJobCondition(batchJob) != nil {
+		return false
+	}
+	timeout := time.Duration(*scenarioRun.Spec.TimeoutSeconds) * time.Second
+	return time.Since(batchJob.Status.StartTime.Time) > timeout
+}
+
+// failJobOnDeadline deletes the Job past its deadline along with its pods, marks the job
+// Failed with reason DeadlineExceeded and hands it to the retry logic like any other failure.
+func (r *KrknScenarioRunReconciler) failJobOnDeadline(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	job *krknv1alpha1.ClusterJobStatus,
+	batchJob *batchv1.Job,
+) {
+	logger := log.FromContext(ctx)
+
+	if err := r.Delete(ctx, batchJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		// Try again on the next reconcile, the job keeps its phase meanwhile
+		logger.Error(err, "failed to delete job past its deadline",
+			"cluster", job.ClusterName,
+			"jobID", job.JobID,
+			"job", batchJob.Name)
+		return
+	}
+	if !r.setJobPhase(ctx, job, statemachine.JobFailed) {
+		return
+	}
+	job.Message = fmt.Sprintf("Scenario exceeded the run timeout of %ds", *scenarioRun.Spec.TimeoutSeconds)
+	job.FailureReason = FailureReasonDeadlineExceeded
+	r.setCompletionTime(job)
+
+	logger.Info("killed job past its deadline",
+		"cluster", job.ClusterName,
+		"jobID", job.JobID,
+		"job", batchJob.Name,
+		"timeoutSeconds", *scenarioRun.Spec.TimeoutSeconds)
+
+	r.handleJobFailure(ctx, scenarioRun, job)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func setupTimeoutTest(batchJob *batchv1.Job, pod *corev1.Pod, job krknv1alpha1.ClusterJobStatus) (*KrknScenarioRunReconciler, *krknv1alpha1.KrknScenarioRun) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			ScenarioName:   "test-scenario",
+			MaxRetries:     1,
+			RetryDelay:     "1h",
+			TimeoutSeconds: ptr.To[int64](60),
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{job},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenarioRun, batchJob, pod).Build()
+	return &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}, scenarioRun
+}
+
+func activeScenarioJob(jobID string, startedAgo time.Duration) *batchv1.Job {
+	batchJob := newTestScenarioJob(jobID)
+	batchJob.Status.Active = 1
+	batchJob.Status.StartTime = &metav1.Time{Time: time.Now().Add(-startedAgo)}
+	return batchJob
+}
+
+func TestUpdateClusterJobStatuses_KillsJobPastDeadline(t *testing.T) {
+	now := metav1.Now()
+	reconciler, scenarioRun := setupTimeoutTest(
+		activeScenarioJob("job-1", 2*time.Minute),
+		newTestJobPod("job-1", "pod-1", corev1.PodRunning, now.Time),
+		krknv1alpha1.ClusterJobStatus{
+			ProviderName: "krkn-operator",
+			ClusterName:  "cluster1",
+			JobID:        "job-1",
+			PodName:      "pod-1",
+			Phase:        statemachine.JobRunning,
+			StartTime:    &now,
+			// The retry backoff keeps the job Failed
+			LastRetryTime: &now,
+		})
+	ctx := context.Background()
+
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	job := scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobFailed || job.FailureReason != FailureReasonDeadlineExceeded || job.CompletionTime == nil {
+		t.Fatalf("Expected job to fail with DeadlineExceeded, got %+v", job)
+	}
+	err := reconciler.Get(ctx, types.NamespacedName{Name: "krkn-job-job-1", Namespace: "default"}, &batchv1.Job{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the Job to be deleted, got %v", err)
+	}
+
+	// Later reconciles keep the failure reason although the Job is gone
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	job = scenarioRun.Status.ClusterJobs[0]
+	if job.Phase != statemachine.JobFailed || job.FailureReason != FailureReasonDeadlineExceeded {
+		t.Errorf("Expected the deadline failure to be kept, got %+v", job)
+	}
+}
+
+func TestUpdateClusterJobStatuses_WithinDeadline(t *testing.T) {
+	now := metav1.Now()
+	reconciler, scenarioRun := setupTimeoutTest(
+		activeScenarioJob("job-1", 10*time.Second),
+		newTestJobPod("job-1", "pod-1", corev1.PodRunning, now.Time),
+		krknv1alpha1.ClusterJobStatus{
+			ProviderName: "krkn-operator",
+			ClusterName:  "cluster1",
+			JobID:        "job-1",
+			PodName:      "pod-1",
+			Phase:        statemachine.JobRunning,
+			StartTime:    &now,
+		})
+	ctx := context.Background()
+
+	if err := reconciler.updateClusterJobStatuses(ctx, scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if phase := scenarioRun.Status.ClusterJobs[0].Phase; phase != statemachine.JobRunning {
+		t.Errorf("Expected job to keep running, got %s", phase)
+	}
+	if err := reconciler.Get(ctx, client.ObjectKey{Name: "krkn-job-job-1", Namespace: "default"}, &batchv1.Job{}); err != nil {
+		t.Errorf("Expected the Job to be kept, got %v", err)
+	}
+}
+
+func TestUpdateClusterJobStatuses_JobControllerDeadlineExceeded(t *testing.T) {
+	now := metav1.Now()
+	batchJob := newTestScenarioJob("job-1", batchv1.JobCondition{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  batchv1.JobReasonDeadlineExceeded,
+		Message: "Job was active longer than specified deadline",
+	})
+	pod := newTestJobPod("job-1", "pod-1", corev1.PodFailed, now.Time)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 137}},
+	}}
+	reconciler, scenarioRun := setupTimeoutTest(batchJob, pod, krknv1alpha1.ClusterJobStatus{
+		ProviderName: "krkn-operator",
+		ClusterName:  "cluster1",
+		JobID:        "job-1",
+		PodName:      "pod-1",
+		Phase:        statemachine.JobRunning,
+		StartTime:    &now,
+		RetryCount:   1,
+		MaxRetries:   1,
+	})
+
+	if err := reconciler.updateClusterJobStatuses(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	job := scenarioRun.Status.ClusterJobs[0]
+	if job.FailureReason != FailureReasonDeadlineExceeded {
+		t.Errorf("Expected FailureReason %q, got %q", FailureReasonDeadlineExceeded, job.FailureReason)
+	}
+	if job.Phase != statemachine.JobMaxRetriesExceeded {
+		t.Errorf("Expected the retry logic to apply, got phase %s", job.Phase)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// scenarioJobName returns the name of the batch Job running the scenario of a cluster job
+func scenarioJobName(jobID string) string {
+	return "krkn-job-" + jobID
+}
+
+// newScenarioJob wraps the scenario pod in a batch Job.
+//
+// Retries stay with the operator (spec.maxRetries, retryDelay and retryBackoff create a
+// new cluster job), so the Job gets no backoff of its own: the first scenario failure
+// fails the Job. Pods lost to a node failure, drain or eviction carry the DisruptionTarget
+// condition and are ignored by the pod failure policy, the Job controller replaces them
+// without failing the Job. The run timeout is the Job's activeDeadlineSeconds. Runs with
+// spec.evictionProtection also keep the cluster autoscaler from draining the pod's node.
+func newScenarioJob(
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	name string,
+	namespace string,
+	labels map[string]string,
+	podSpec corev1.PodSpec,
+) *batchv1.Job {
+	var annotations map[string]string
+	if scenarioRun.Spec.EvictionProtection {
+		annotations = map[string]string{safeToEvictAnnotation: "false"}
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To[int32](0),
+			ActiveDeadlineSeconds: scenarioRun.Spec.TimeoutSeconds,
+			PodFailurePolicy: &batchv1.PodFailurePolicy{
+				Rules: []batchv1.PodFailurePolicyRule{{
+					Action: batchv1.PodFailurePolicyActionIgnore,
+					OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{{
+						Type:   corev1.DisruptionTarget,
+						Status: corev1.ConditionTrue,
+					}},
+				}},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+// latestJobPod returns the most recently created pod of a cluster job, nil if the Job
+// has no pod (yet). A Job replaces pods lost to node failures, the newest one is current.
+func (r *KrknScenarioRunReconciler) latestJobPod(ctx context.Context, jobID string) (*corev1.Pod, error) {
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(r.Namespace), client.MatchingLabels{
+		joblabels.JobID: jobID,
+	}); err != nil {
+		return nil, err
+	}
+
+	var latest *corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if latest == nil || latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest = pod
+		}
+	}
+	return latest, nil
+}
+
+// scenarioJobCondition returns the Complete or Failed condition of a finished Job, nil
+// while it is still active
+func scenarioJobCondition(batchJob *batchv1.Job) *batchv1.JobCondition {
+	for i := range batchJob.Status.Conditions {
+		condition := &batchJob.Status.Conditions[i]
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed {
+			return condition
+		}
+	}
+	return nil
+}
+
+// scenarioJobPhase maps a Job and its current pod to a cluster job phase. The Job
+// conditions decide the outcome; before that the job runs once its pod left Pending.
+func scenarioJobPhase(batchJob *batchv1.Job, pod *corev1.Pod) string {
+	if condition := scenarioJobCondition(batchJob); condition != nil {
+		if condition.Type == batchv1.JobComplete {
+			return statemachine.JobSucceeded
+		}
+		return statemachine.JobFailed
+	}
+	if pod != nil && pod.Status.Phase != corev1.PodPending {
+		return statemachine.JobRunning
+	}
+	return statemachine.JobPending
+}
+
+// extractJobFailure returns the message and categorized reason of a failed Job. The
+// scenario pod tells most about the failure; without it, or when the Job was stopped on
+// its deadline, the Job condition is used.
+func (r *KrknScenarioRunReconciler) extractJobFailure(batchJob *batchv1.Job, pod *corev1.Pod) (message string, reason string) {
+	if condition := scenarioJobCondition(batchJob); condition != nil {
+		message = condition.Message
+		reason = condition.Reason
+	}
+	if reason == batchv1.JobReasonDeadlineExceeded || pod == nil {
+		return message, reason
+	}
+
+	if podMessage := r.extractPodErrorMessage(pod); podMessage != "" {
+		message = podMessage
+	}
+	return message, r.extractFailureReason(pod)
+}
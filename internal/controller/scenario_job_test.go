@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+func newTestScenarioJob(jobID string, conditions ...batchv1.JobCondition) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scenarioJobName(jobID),
+			Namespace: "default",
+			Labels:    map[string]string{"krkn-job-id": jobID},
+		},
+		Status: batchv1.JobStatus{Conditions: conditions},
+	}
+}
+
+func newTestJobPod(jobID, name string, phase corev1.PodPhase, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{"krkn-job-id": jobID},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func failedJobCondition(reason string) batchv1.JobCondition {
+	return batchv1.JobCondition{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: "Job has reached the specified backoff limit",
+	}
+}
+
+func TestNewScenarioJob(t *testing.T) {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		Spec: krknv1alpha1.KrknScenarioRunSpec{TimeoutSeconds: ptr.To[int64](300)},
+	}
+	labels := map[string]string{"krkn-job-id": "job-1"}
+
+	batchJob := newScenarioJob(scenarioRun, scenarioJobName("job-1"), "default", labels, corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+	})
+
+	if batchJob.Name != "krkn-job-job-1" {
+		t.Errorf("Expected job name krkn-job-job-1, got %s", batchJob.Name)
+	}
+	if batchJob.Spec.BackoffLimit == nil || *batchJob.Spec.BackoffLimit != 0 {
+		t.Errorf("Expected backoffLimit 0, retries belong to the operator, got %v", batchJob.Spec.BackoffLimit)
+	}
+	if batchJob.Spec.ActiveDeadlineSeconds == nil || *batchJob.Spec.ActiveDeadlineSeconds != 300 {
+		t.Errorf("Expected activeDeadlineSeconds 300, got %v", batchJob.Spec.ActiveDeadlineSeconds)
+	}
+	if batchJob.Spec.Template.Labels["krkn-job-id"] != "job-1" {
+		t.Error("Expected the pod template to carry the job labels")
+	}
+	rules := batchJob.Spec.PodFailurePolicy.Rules
+	if len(rules) != 1 || rules[0].Action != batchv1.PodFailurePolicyActionIgnore ||
+		rules[0].OnPodConditions[0].Type != corev1.DisruptionTarget {
+		t.Errorf("Expected disrupted pods to be ignored, got %+v", rules)
+	}
+	if len(batchJob.Spec.Template.Annotations) != 0 {
+		t.Errorf("Expected no pod annotation, got %v", batchJob.Spec.Template.Annotations)
+	}
+
+	scenarioRun.Spec.EvictionProtection = true
+	batchJob = newScenarioJob(scenarioRun, scenarioJobName("job-1"), "default", labels, corev1.PodSpec{})
+	if batchJob.Spec.Template.Annotations[safeToEvictAnnotation] != "false" {
+		t.Errorf("Expected protected pods to be marked not safe to evict, got %v", batchJob.Spec.Template.Annotations)
+	}
+}
+
+func TestScenarioJobPhase(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		job      *batchv1.Job
+		pod      *corev1.Pod
+		expected string
+	}{
+		{name: "no pod yet", job: newTestScenarioJob("job-1"), expected: statemachine.JobPending},
+		{name: "pod pending", job: newTestScenarioJob("job-1"),
+			pod: newTestJobPod("job-1", "pod-1", corev1.PodPending, now), expected: statemachine.JobPending},
+		{name: "pod running", job: newTestScenarioJob("job-1"),
+			pod: newTestJobPod("job-1", "pod-1", corev1.PodRunning, now), expected: statemachine.JobRunning},
+		{name: "complete", job: newTestScenarioJob("job-1", batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+			pod: newTestJobPod("job-1", "pod-1", corev1.PodSucceeded, now), expected: statemachine.JobSucceeded},
+		{name: "failed", job: newTestScenarioJob("job-1", failedJobCondition("BackoffLimitExceeded")),
+			expected: statemachine.JobFailed},
+		{name: "condition not true", job: newTestScenarioJob("job-1", batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionFalse}),
+			expected: statemachine.JobPending},
+	}
+
+	for _, tt := range tests {
+		if phase := scenarioJobPhase(tt.job, tt.pod); phase != tt.expected {
+			t.Errorf("%s: expected phase %s, got %s", tt.name, tt.expected, phase)
+		}
+	}
+}
+
+func TestUpdateClusterJobStatuses_TracksReplacementPod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	now := metav1.Now()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{{
+				ProviderName: "krkn-operator",
+				ClusterName:  "cluster1",
+				JobID:        "job-1",
+				PodName:      "krkn-job-job-1-first",
+				Phase:        statemachine.JobRunning,
+				StartTime:    &now,
+			}},
+		},
+	}
+
+	// The first pod was lost with its node, the Job created a new one still pending
+	disrupted := newTestJobPod("job-1", "krkn-job-job-1-first", corev1.PodFailed, now.Add(-time.Minute))
+	replacement := newTestJobPod("job-1", "krkn-job-job-1-second", corev1.PodPending, now.Time)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(scenarioRun, newTestScenarioJob("job-1"), disrupted, replacement).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	if err := reconciler.updateClusterJobStatuses(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	job := scenarioRun.Status.ClusterJobs[0]
+	if job.PodName != "krkn-job-job-1-second" {
+		t.Errorf("Expected the replacement pod to be tracked, got %s", job.PodName)
+	}
+	if job.Phase != statemachine.JobRunning {
+		t.Errorf("Expected the job to stay Running, got %s", job.Phase)
+	}
+}
+
+func TestUpdateClusterJobStatuses_FailedJobReason(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	now := metav1.Now()
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-scenario", Namespace: "default"},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{{
+				ProviderName: "krkn-operator",
+				ClusterName:  "cluster1",
+				JobID:        "job-1",
+				Phase:        statemachine.JobRunning,
+				StartTime:    &now,
+				RetryCount:   1,
+				MaxRetries:   1,
+			}},
+		},
+	}
+
+	pod := newTestJobPod("job-1", "krkn-job-job-1-abcde", corev1.PodFailed, now.Time)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", Message: "scenario failed", ExitCode: 1}},
+	}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(scenarioRun, newTestScenarioJob("job-1", failedJobCondition("BackoffLimitExceeded")), pod).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	if err := reconciler.updateClusterJobStatuses(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	job := scenarioRun.Status.ClusterJobs[0]
+	if job.FailureReason != "ContainerError" || job.Message != "Error: scenario failed" {
+		t.Errorf("Expected the pod failure to be reported, got reason %q message %q", job.FailureReason, job.Message)
+	}
+	if job.Phase != statemachine.JobMaxRetriesExceeded {
+		t.Errorf("Expected the retry logic to apply, got phase %s", job.Phase)
+	}
+	if job.PodName != pod.Name {
+		t.Errorf("Expected PodName %s, got %s", pod.Name, job.PodName)
+	}
+
+	// The operator keeps the failed Job for its logs
+	if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: "krkn-job-job-1", Namespace: "default"}, &batchv1.Job{}); err != nil {
+		t.Errorf("Expected the Job to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// renderTemplate renders the KrknScenarioTemplate a run references into the fields the
+// run leaves unset and updates the spec, so the jobs and the status see the final
+// scenario. Runs created through the API arrive rendered already and are not updated.
+func (r *KrknScenarioRunReconciler) renderTemplate(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	templateName := scenarioRun.Spec.TemplateRef
+	if templateName == "" {
+		return nil
+	}
+	var template krknv1alpha1.KrknScenarioTemplate
+	if err := r.Get(ctx, types.NamespacedName{Name: templateName, Namespace: scenarioRun.Namespace}, &template); err != nil {
+		return fmt.Errorf("failed to fetch scenario template %s: %w", templateName, err)
+	}
+
+	rendered := scenarioRun.Spec.DeepCopy()
+	template.Render(rendered)
+	if equality.Semantic.DeepEqual(rendered, &scenarioRun.Spec) {
+		return nil
+	}
+	scenarioRun.Spec = *rendered
+	if err := r.Update(ctx, scenarioRun); err != nil {
+		return fmt.Errorf("failed to render scenario template %s: %w", templateName, err)
+	}
+	return nil
+}
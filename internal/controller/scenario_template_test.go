@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	ctx := context.Background()
+	reconciler, scenarioRun := setupClusterJobTest()
+
+	var stored krknv1alpha1.KrknScenarioRun
+	if err := reconciler.Get(ctx, client.ObjectKeyFromObject(scenarioRun), &stored); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	stored.Spec.ScenarioImage = ""
+	stored.Spec.TemplateRef = "pod-chaos"
+	stored.Spec.Environment = map[string]string{"NAMESPACE": "payments"}
+
+	// The template may be applied after the run
+	if err := reconciler.renderTemplate(ctx, &stored); err == nil {
+		t.Fatal("Expected an error for a missing template")
+	}
+
+	if err := reconciler.Create(ctx, &krknv1alpha1.KrknScenarioTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-chaos", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioTemplateSpec{
+			ScenarioName:  "pod-scenarios",
+			ScenarioImage: "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+			Environment:   map[string]string{"NAMESPACE": "default", "POD_LABEL": "app=nginx"},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	if err := reconciler.renderTemplate(ctx, &stored); err != nil {
+		t.Fatalf("renderTemplate failed: %v", err)
+	}
+
+	var rendered krknv1alpha1.KrknScenarioRun
+	if err := reconciler.Get(ctx, client.ObjectKeyFromObject(scenarioRun), &rendered); err != nil {
+		t.Fatalf("Failed to get scenario run: %v", err)
+	}
+	spec := rendered.Spec
+	if spec.ScenarioName != "pod-scenarios" || spec.ScenarioImage != "quay.io/krkn-chaos/krkn-hub:pod-scenarios" ||
+		spec.Environment["NAMESPACE"] != "payments" || spec.Environment["POD_LABEL"] != "app=nginx" {
+		t.Errorf("Unexpected rendered spec %+v", spec)
+	}
+
+	// A rendered run is left alone
+	resourceVersion := rendered.ResourceVersion
+	if err := reconciler.renderTemplate(ctx, &rendered); err != nil {
+		t.Fatalf("renderTemplate of a rendered run failed: %v", err)
+	}
+	if rendered.ResourceVersion != resourceVersion {
+		t.Error("Expected no update of a rendered run")
+	}
+}
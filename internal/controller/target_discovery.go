@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+)
+
+// adminKubeconfig returns the base64 admin kubeconfig of a Hive ClusterDeployment, reduced
+// to its current context, from the Secret its cluster metadata references
+func adminKubeconfig(ctx context.Context, reader client.Reader, clusterDeployment *unstructured.Unstructured) (string, error) {
+	secretName, _, _ := unstructured.NestedString(clusterDeployment.Object,
+		"spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+	if secretName == "" {
+		return "", fmt.Errorf("ClusterDeployment has no admin kubeconfig")
+	}
+
+	var secret corev1.Secret
+	key := types.NamespacedName{Name: secretName, Namespace: clusterDeployment.GetNamespace()}
+	if err := reader.Get(ctx, key, &secret); err != nil {
+		return "", fmt.Errorf("no admin kubeconfig Secret: %w", err)
+	}
+	raw := secret.Data["kubeconfig"]
+	if len(raw) == 0 {
+		return "", fmt.Errorf("admin kubeconfig Secret %s has no kubeconfig", secretName)
+	}
+
+	kubeconfigBase64, err := kubeconfig.ForContext(base64.StdEncoding.EncodeToString(raw), "")
+	if err != nil {
+		return "", err
+	}
+	if err := kubeconfig.Validate(kubeconfigBase64); err != nil {
+		return "", err
+	}
+	return kubeconfigBase64, nil
+}
+
+// registeredTarget returns the name of a target not created by the discoveredBy discovery
+// that already uses the cluster name or API URL of a discovered cluster, or "" when there
+// is none. Clusters registered by hand or by another discovery keep their target.
+func registeredTarget(ctx context.Context, c client.Client, namespace, discoveredBy, clusterName, apiURL string) (string, error) {
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := c.List(ctx, &targets, client.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("failed to list targets: %w", err)
+	}
+	for _, target := range targets.Items {
+		if target.Labels[DiscoveredByLabel] == discoveredBy {
+			continue
+		}
+		if target.Spec.ClusterName == clusterName || kubeconfig.SameAPIURL(target.Spec.ClusterAPIURL, apiURL) {
+			return target.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// syncTargetKubeconfig creates or updates the kubeconfig Secret of a discovered target,
+// owned by the target and sealed with cipher, and reports whether an existing kubeconfig
+// changed
+func syncTargetKubeconfig(ctx context.Context, c client.Client, scheme *runtime.Scheme, cipher *envelope.Cipher,
+	target *krknv1alpha1.KrknOperatorTarget, kubeconfigBase64 string) (bool, error) {
+	sealed, err := cipher.Seal(ctx, kubeconfigBase64)
+	if err != nil {
+		return false, fmt.Errorf("failed to encrypt kubeconfig: %w", err)
+	}
+	data, err := kubeconfig.MarshalSecretData(sealed)
+	if err != nil {
+		return false, err
+	}
+
+	var secret corev1.Secret
+	err = c.Get(ctx, types.NamespacedName{Name: target.Spec.SecretUUID, Namespace: target.Namespace}, &secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get kubeconfig Secret: %w", err)
+	}
+	if apierrors.IsNotFound(err) {
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.Spec.SecretUUID,
+				Namespace: target.Namespace,
+				Labels:    map[string]string{"krkn-target-uuid": target.Spec.UUID},
+			},
+			Data: map[string][]byte{"kubeconfig": data},
+		}
+		if err := controllerutil.SetControllerReference(target, &secret, scheme); err != nil {
+			return false, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := c.Create(ctx, &secret); err != nil {
+			return false, fmt.Errorf("failed to create kubeconfig Secret: %w", err)
+		}
+		return false, nil
+	}
+
+	// Sealed kubeconfigs differ on every write, they are compared once opened. An unchanged
+	// kubeconfig stored in plaintext is still rewritten once encryption at rest is on.
+	changed, resealed := true, false
+	if stored, err := kubeconfig.UnmarshalSecretData(secret.Data["kubeconfig"]); err == nil {
+		if opened, err := cipher.Open(ctx, stored); err == nil && opened == kubeconfigBase64 {
+			changed = false
+			resealed = cipher != nil && !envelope.IsSealed(stored)
+		}
+	}
+	if !changed && !resealed {
+		return false, nil
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data["kubeconfig"] = data
+	if err := c.Update(ctx, &secret); err != nil {
+		return false, fmt.Errorf("failed to update kubeconfig Secret: %w", err)
+	}
+	return changed, nil
+}
+
+// discoveredTargetLabels returns the labels of the target of a discovered cluster object:
+// its own labels, for target selectors, and DiscoveredByLabel
+func discoveredTargetLabels(object *unstructured.Unstructured, discoveredBy string) map[string]string {
+	labels := map[string]string{}
+	for key, value := range object.GetLabels() {
+		if !strings.HasPrefix(key, "krkn.krkn-chaos.dev/") {
+			labels[key] = value
+		}
+	}
+	labels[DiscoveredByLabel] = discoveredBy
+	return labels
+}
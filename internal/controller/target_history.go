@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/statemachine"
+)
+
+// targetRecentRuns is the number of job outcomes kept on a target status
+const targetRecentRuns = 10
+
+// Outcomes recorded on targets
+const (
+	targetRunSucceeded = "Succeeded"
+	targetRunFailed    = "Failed"
+	targetRunCancelled = "Cancelled"
+)
+
+// jobOutcome returns the outcome recorded on the target of a job in phase, false while
+// the job may still run, e.g. failed with retries left, or when it never ran
+func jobOutcome(phase string) (string, bool) {
+	switch phase {
+	case statemachine.JobSucceeded:
+		return targetRunSucceeded, true
+	case statemachine.JobMaxRetriesExceeded:
+		return targetRunFailed, true
+	case statemachine.JobCancelled:
+		return targetRunCancelled, true
+	}
+	return "", false
+}
+
+// recordTargetOutcomes adds the jobs that finished since old to the run history of their
+// KrknOperatorTarget. The history is best effort: failures are logged and never fail the
+// reconcile. Clusters of other providers have no KrknOperatorTarget and are skipped.
+func (r *KrknScenarioRunReconciler) recordTargetOutcomes(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun, old *krknv1alpha1.KrknScenarioRunStatus) {
+	logger := log.FromContext(ctx)
+
+	previous := make(map[string]string, len(old.ClusterJobs))
+	for _, job := range old.ClusterJobs {
+		previous[job.ClusterName] = job.Phase
+	}
+
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		outcome, done := jobOutcome(job.Phase)
+		if _, wasDone := jobOutcome(previous[job.ClusterName]); !done || wasDone {
+			continue
+		}
+		if err := r.recordTargetOutcome(ctx, scenarioRun.Name, &job, outcome); err != nil {
+			logger.Error(err, "failed to record the run outcome on the target",
+				"cluster", job.ClusterName,
+				"outcome", outcome)
+		}
+	}
+}
+
+// recordTargetOutcome adds the outcome of a job to the status of its target
+func (r *KrknScenarioRunReconciler) recordTargetOutcome(ctx context.Context, runName string, job *krknv1alpha1.ClusterJobStatus, outcome string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var targets krknv1alpha1.KrknOperatorTargetList
+		if err := r.List(ctx, &targets, client.InNamespace(r.Namespace)); err != nil {
+			return fmt.Errorf("failed to list targets: %w", err)
+		}
+		for i := range targets.Items {
+			target := &targets.Items[i]
+			if target.Spec.ClusterName != job.ClusterName {
+				continue
+			}
+			// A cluster of another provider with the same name
+			if job.ClusterAPIURL != "" && target.Spec.ClusterAPIURL != "" && job.ClusterAPIURL != target.Spec.ClusterAPIURL {
+				continue
+			}
+			finished := metav1.Now()
+			if job.CompletionTime != nil {
+				finished = *job.CompletionTime
+			}
+			addTargetOutcome(&target.Status, runName, outcome, finished)
+			return r.Status().Update(ctx, target)
+		}
+		return nil
+	})
+}
+
+// addTargetOutcome appends an outcome to the run history of a target and updates the
+// aggregates derived from it
+func addTargetOutcome(status *krknv1alpha1.KrknOperatorTargetStatus, runName, outcome string, finished metav1.Time) {
+	status.LastRunTime = &finished
+	status.LastRunName = runName
+	status.LastRunPhase = outcome
+
+	status.RecentRunPhases = append(status.RecentRunPhases, outcome)
+	if extra := len(status.RecentRunPhases) - targetRecentRuns; extra > 0 {
+		status.RecentRunPhases = status.RecentRunPhases[extra:]
+	}
+
+	// Cancelled jobs tell nothing about the cluster: they are left out of the aggregates
+	var succeeded, failed int32
+	for _, phase := range status.RecentRunPhases {
+		switch phase {
+		case targetRunSucceeded:
+			succeeded++
+		case targetRunFailed:
+			failed++
+		}
+	}
+	status.RecentSuccessRate = nil
+	if succeeded+failed > 0 {
+		rate := succeeded * 100 / (succeeded + failed)
+		status.RecentSuccessRate = &rate
+	}
+
+	switch outcome {
+	case targetRunSucceeded:
+		status.ConsecutiveFailures = 0
+	case targetRunFailed:
+		status.ConsecutiveFailures++
+	}
+}
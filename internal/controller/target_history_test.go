@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestAddTargetOutcome(t *testing.T) {
+	var status krknv1alpha1.KrknOperatorTargetStatus
+	now := metav1.Now()
+
+	addTargetOutcome(&status, "run-0", targetRunCancelled, now)
+	if status.RecentSuccessRate != nil || status.ConsecutiveFailures != 0 {
+		t.Errorf("expected a cancelled job to leave the aggregates unset, got %+v", status)
+	}
+
+	addTargetOutcome(&status, "run-1", targetRunSucceeded, now)
+	for i := 2; i <= 12; i++ {
+		addTargetOutcome(&status, "run-n", targetRunFailed, now)
+	}
+	if len(status.RecentRunPhases) != targetRecentRuns {
+		t.Fatalf("expected the history to keep %d outcomes, got %d", targetRecentRuns, len(status.RecentRunPhases))
+	}
+	// The success rolled out of the window
+	if *status.RecentSuccessRate != 0 || status.ConsecutiveFailures != 11 {
+		t.Errorf("expected a 0%% success rate and 11 consecutive failures, got %d%% and %d",
+			*status.RecentSuccessRate, status.ConsecutiveFailures)
+	}
+
+	addTargetOutcome(&status, "run-13", targetRunSucceeded, now)
+	if *status.RecentSuccessRate != 10 || status.ConsecutiveFailures != 0 || status.LastRunPhase != targetRunSucceeded ||
+		status.LastRunName != "run-13" {
+		t.Errorf("unexpected status after a success: %+v", status)
+	}
+}
+
+func TestRecordTargetOutcomes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	target := func(name, cluster, apiURL string) *krknv1alpha1.KrknOperatorTarget {
+		return &krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       krknv1alpha1.KrknOperatorTargetSpec{ClusterName: cluster, ClusterAPIURL: apiURL},
+			Status:     krknv1alpha1.KrknOperatorTargetStatus{Ready: true},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(target("target-a", "cluster-a", "https://a:6443"), target("target-b", "cluster-b", "https://b:6443")).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}).
+		Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	old := &krknv1alpha1.KrknScenarioRunStatus{ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+		{ClusterName: "cluster-a", Phase: "Running"},
+		{ClusterName: "cluster-b", Phase: "Failed"},
+		{ClusterName: "cluster-c", Phase: "Running"},
+	}}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{ObjectMeta: metav1.ObjectMeta{Name: "run-1"}}
+	scenarioRun.Status.ClusterJobs = []krknv1alpha1.ClusterJobStatus{
+		{ClusterName: "cluster-a", ClusterAPIURL: "https://a:6443", Phase: "Succeeded"},
+		{ClusterName: "cluster-b", ClusterAPIURL: "https://b:6443", Phase: "MaxRetriesExceeded"},
+		// Another provider's cluster has no target
+		{ClusterName: "cluster-c", Phase: "Succeeded"},
+	}
+
+	ctx := context.Background()
+	reconciler.recordTargetOutcomes(ctx, scenarioRun, old)
+	// Recording the same transitions again counts nothing: the jobs were already finished
+	reconciler.recordTargetOutcomes(ctx, scenarioRun, scenarioRun.Status.DeepCopy())
+
+	expected := map[string]string{"target-a": targetRunSucceeded, "target-b": targetRunFailed}
+	for name, outcome := range expected {
+		var got krknv1alpha1.KrknOperatorTarget
+		if err := fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, &got); err != nil {
+			t.Fatalf("failed to get target: %v", err)
+		}
+		if got.Status.LastRunPhase != outcome || got.Status.LastRunName != "run-1" || len(got.Status.RecentRunPhases) != 1 ||
+			got.Status.LastRunTime == nil || !got.Status.Ready {
+			t.Errorf("%s: unexpected status %+v", name, got.Status)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/grpcclient"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
+)
+
+const (
+	// DefaultTargetProbeInterval is how often the connectivity of each target is checked by default
+	DefaultTargetProbeInterval = 5 * time.Minute
+
+	// defaultTargetProbeTimeout bounds a connectivity check
+	defaultTargetProbeTimeout = 10 * time.Second
+)
+
+// TargetProber checks that a target cluster can be reached with its kubeconfig
+type TargetProber interface {
+	// Probe returns an error when the cluster of the base64 kubeconfig cannot be reached
+	Probe(ctx context.Context, kubeconfigBase64 string) error
+}
+
+// DataProviderTargetProber checks clusters by listing their nodes through the data provider
+type DataProviderTargetProber struct {
+	// Client is the shared connection to the data provider gRPC server
+	Client *grpcclient.Client
+	// Timeout bounds each check (defaultTargetProbeTimeout when zero)
+	Timeout time.Duration
+}
+
+// Probe implements TargetProber
+func (p *DataProviderTargetProber) Probe(ctx context.Context, kubeconfigBase64 string) error {
+	conn, err := p.Client.Conn()
+	if err != nil {
+		return err
+	}
+
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = defaultTargetProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := pb.NewDataProviderServiceClient(conn).GetNodes(ctx, &pb.GetNodesRequest{
+		KubeconfigBase64: kubeconfigBase64,
+	}); err != nil {
+		return fmt.Errorf("data provider: %w", err)
+	}
+	return nil
+}
+
+// targetKubeconfig returns the base64 kubeconfig stored in the Secret of a target, or
+// in the credentials store for targets with a Vault path. unusable is true when the
+// credentials themselves are missing or invalid, as opposed to a failure to read them.
+func (r *KrknOperatorTargetReconciler) targetKubeconfig(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) (kubeconfigBase64 string, unusable bool, err error) {
+	data, err := secrets.TargetCredentials(ctx, r.Client, r.CredentialsStore, target)
+	if err != nil {
+		return "", errors.Is(err, secrets.ErrNotFound), err
+	}
+	raw, exists := data["kubeconfig"]
+	if !exists {
+		return "", true, fmt.Errorf("kubeconfig not found in the credentials of target %s", target.Spec.UUID)
+	}
+	kubeconfigBase64, err = kubeconfig.UnmarshalSecretData(raw)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to unmarshal kubeconfig of target %s: %w", target.Spec.UUID, err)
+	}
+	kubeconfigBase64, err = r.Cipher.Open(ctx, kubeconfigBase64)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decrypt kubeconfig of target %s: %w", target.Spec.UUID, err)
+	}
+	return kubeconfigBase64, false, nil
+}
+
+// probeTarget checks the connectivity of a target once per ProbeInterval and records
+// the result in its status: Ready follows whether the cluster is reachable, with the
+// latency of the check or the reason it failed. A target whose ClusterUnavailable
+// condition is true stays not ready.
+func (r *KrknOperatorTargetReconciler) probeTarget(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) (ctrl.Result, error) {
+	if r.Prober == nil || r.ProbeInterval <= 0 {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx)
+
+	// Status updates trigger reconciles too, only probe when the interval elapsed
+	if last := target.Status.LastProbeTime; last != nil {
+		if remaining := r.ProbeInterval - time.Since(last.Time); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	kubeconfigBase64, unusable, err := r.targetKubeconfig(ctx, target)
+	if err != nil && !unusable {
+		return ctrl.Result{}, err
+	}
+	if err == nil {
+		// The kubeconfig may have changed since the target was created
+		if plugins, pluginErr := kubeconfig.AuthPlugins(kubeconfigBase64); pluginErr == nil {
+			target.Status.SetAuthPlugins(plugins, target.Generation)
+		}
+	}
+	start := time.Now()
+	if err == nil {
+		err = r.Prober.Probe(ctx, kubeconfigBase64)
+	}
+	latency := time.Since(start)
+	if errors.Is(err, grpcclient.ErrCircuitOpen) {
+		// The data provider is down, which says nothing about the cluster: keep its status
+		logger.Info("Skipped target probe, data provider unavailable", "uuid", target.Spec.UUID)
+		return ctrl.Result{RequeueAfter: r.ProbeInterval}, nil
+	}
+
+	wasReady := target.Status.Ready
+	now := metav1.Now()
+	target.Status.LastProbeTime = &now
+	if err != nil {
+		target.Status.Ready = false
+		target.Status.ProbeLatencyMilliseconds = nil
+		target.Status.ProbeError = err.Error()
+	} else {
+		milliseconds := latency.Milliseconds()
+		target.Status.Ready = !target.Status.ClusterUnavailable()
+		target.Status.ProbeLatencyMilliseconds = &milliseconds
+		target.Status.ProbeError = ""
+	}
+
+	switch {
+	case wasReady && !target.Status.Ready:
+		logger.Info("Target cluster unreachable",
+			"uuid", target.Spec.UUID,
+			"clusterName", target.Spec.ClusterName,
+			"error", target.Status.ProbeError)
+	case !wasReady && target.Status.Ready:
+		logger.Info("Target cluster reachable",
+			"uuid", target.Spec.UUID,
+			"clusterName", target.Spec.ClusterName,
+			"latency", latency)
+	}
+
+	if err := r.Status().Update(ctx, target); err != nil {
+		if apierrors.IsConflict(err) {
+			// Updated concurrently, the update triggers another reconcile
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Failed to update target probe status", "uuid", target.Spec.UUID)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.ProbeInterval}, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// targetResolutionRetryInterval is how often a run whose targets cannot be resolved, e.g.
+// because its target group does not exist yet, tries again
+const targetResolutionRetryInterval = 30 * time.Second
+
+// snapshotTargets returns the clusters of a run with their provider and API URL, and the
+// selector they were selected with. The clusters listed by stable identifier and the
+// members of the target group of the run join the clusters of its spec. The API URLs and
+// identifiers come from the target request of the run: when it cannot be read, the
+// clusters are recorded without API URLs and with identifiers derived from their names.
+// Archived targets are skipped.
+func (r *KrknScenarioRunReconciler) snapshotTargets(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) (*krknv1alpha1.TargetSnapshot, error) {
+	var targetRequest krknv1alpha1.KrknTargetRequest
+	requestErr := r.Get(ctx, types.NamespacedName{
+		Name:      scenarioRun.Spec.TargetRequestID,
+		Namespace: r.Namespace,
+	}, &targetRequest)
+	if requestErr != nil {
+		log.FromContext(ctx).Error(requestErr, "failed to fetch target request, snapshotting targets without API URLs",
+			"scenarioRun", scenarioRun.Name,
+			"targetRequestId", scenarioRun.Spec.TargetRequestID)
+	}
+
+	targetClusters := scenarioRun.Spec.TargetClusters
+	if len(scenarioRun.Spec.TargetClusterIDs) > 0 {
+		// Identifiers are only known from the targets of the target request
+		if requestErr != nil {
+			return nil, fmt.Errorf("failed to fetch target request to resolve the cluster IDs: %w", requestErr)
+		}
+		byID := map[string][]string{}
+		for _, id := range scenarioRun.Spec.TargetClusterIDs {
+			providerName, cluster, ok := targetRequest.Status.ClusterByID(id)
+			if !ok {
+				return nil, fmt.Errorf("unknown cluster ID %s", id)
+			}
+			byID[providerName] = append(byID[providerName], cluster.ClusterName)
+		}
+		targetClusters = mergeTargetClusters(targetClusters, byID)
+	}
+	if groupName := scenarioRun.Spec.TargetGroupRef; groupName != "" {
+		var group krknv1alpha1.KrknTargetGroup
+		if err := r.Get(ctx, types.NamespacedName{Name: groupName, Namespace: scenarioRun.Namespace}, &group); err != nil {
+			return nil, fmt.Errorf("failed to fetch target group: %w", err)
+		}
+		// Selected members are only known from the targets of the target request
+		if group.Spec.Selector != "" && requestErr != nil {
+			return nil, fmt.Errorf("failed to fetch target request to select the members: %w", requestErr)
+		}
+		members, err := group.Members(&targetRequest.Status)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+		targetClusters = mergeTargetClusters(targetClusters, members)
+	}
+
+	// Archived targets are blocked from runs: they are left out, and the run fails to
+	// resolve when no other cluster remains
+	var operatorTargets krknv1alpha1.KrknOperatorTargetList
+	if err := r.List(ctx, &operatorTargets, client.InNamespace(r.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list targets: %w", err)
+	}
+	if archived := operatorTargets.ArchivedClusters(targetClusters); len(archived) > 0 {
+		// Copied first, the clusters may still be the ones of the spec
+		targetClusters = mergeTargetClusters(targetClusters, nil)
+		targetClusters[krknv1alpha1.OperatorTargetProvider] = slices.DeleteFunc(
+			targetClusters[krknv1alpha1.OperatorTargetProvider], func(clusterName string) bool {
+				return slices.Contains(archived, clusterName)
+			})
+		recordEvent(r.Recorder, scenarioRun, corev1.EventTypeWarning, EventArchivedTargetsSkipped,
+			"Skipped the archived targets %s", strings.Join(archived, ", "))
+	}
+
+	snapshot := &krknv1alpha1.TargetSnapshot{
+		Selector:    scenarioRun.Spec.TargetSelector,
+		TargetGroup: scenarioRun.Spec.TargetGroupRef,
+		ResolvedAt:  metav1.Now(),
+		Targets:     []krknv1alpha1.ResolvedTarget{},
+	}
+	for providerName, clusterNames := range targetClusters {
+		for _, clusterName := range clusterNames {
+			target := krknv1alpha1.ResolvedTarget{
+				Provider:    providerName,
+				ClusterName: clusterName,
+				ClusterID:   krknv1alpha1.StableClusterID(providerName, "", clusterName),
+			}
+			for _, cluster := range targetRequest.Status.TargetData[providerName] {
+				if cluster.ClusterName == clusterName {
+					target.ClusterAPIURL = cluster.ClusterAPIURL
+					target.ClusterID = krknv1alpha1.StableClusterID(providerName, cluster.ClusterUID, clusterName)
+					break
+				}
+			}
+			snapshot.Targets = append(snapshot.Targets, target)
+		}
+	}
+	if len(snapshot.Targets) == 0 {
+		return nil, errors.New("no cluster to target")
+	}
+	slices.SortFunc(snapshot.Targets, func(a, b krknv1alpha1.ResolvedTarget) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.ClusterName, b.ClusterName))
+	})
+	return snapshot, nil
+}
+
+// mergeTargetClusters adds the clusters of each provider of added to the ones of listed,
+// without duplicates
+func mergeTargetClusters(listed, added map[string][]string) map[string][]string {
+	merged := make(map[string][]string, len(listed)+len(added))
+	for providerName, clusterNames := range listed {
+		merged[providerName] = append([]string(nil), clusterNames...)
+	}
+	for providerName, clusterNames := range added {
+		for _, clusterName := range clusterNames {
+			if !slices.Contains(merged[providerName], clusterName) {
+				merged[providerName] = append(merged[providerName], clusterName)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestSnapshotTargets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	targetRequest := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "request-1", Namespace: "default"},
+		Status: krknv1alpha1.KrknTargetRequestStatus{TargetData: map[string][]krknv1alpha1.ClusterTarget{
+			"krkn-operator": {
+				{ClusterName: "staging-eu", ClusterAPIURL: "https://staging-eu:6443"},
+				{ClusterName: "staging-us", ClusterAPIURL: "https://staging-us:6443"},
+			},
+			"krkn-operator-acm": {{ClusterName: "acm-eu", ClusterAPIURL: "https://acm-eu:6443"}},
+		}},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "request-1",
+			TargetSelector:  "region=eu",
+			TargetClusters: map[string][]string{
+				"krkn-operator-acm": {"acm-eu"},
+				"krkn-operator":     {"staging-eu", "removed"},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(targetRequest).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	snapshot, err := reconciler.snapshotTargets(context.Background(), scenarioRun)
+	if err != nil {
+		t.Fatalf("snapshotTargets failed: %v", err)
+	}
+	if snapshot.Selector != "region=eu" || snapshot.ResolvedAt.IsZero() {
+		t.Errorf("Expected the selector and the resolution time, got %+v", snapshot)
+	}
+	want := []krknv1alpha1.ResolvedTarget{
+		{Provider: "krkn-operator", ClusterName: "removed",
+			ClusterID: krknv1alpha1.StableClusterID("krkn-operator", "", "removed")},
+		{Provider: "krkn-operator", ClusterName: "staging-eu", ClusterAPIURL: "https://staging-eu:6443",
+			ClusterID: krknv1alpha1.StableClusterID("krkn-operator", "", "staging-eu")},
+		{Provider: "krkn-operator-acm", ClusterName: "acm-eu", ClusterAPIURL: "https://acm-eu:6443",
+			ClusterID: krknv1alpha1.StableClusterID("krkn-operator-acm", "", "acm-eu")},
+	}
+	if !slices.Equal(snapshot.Targets, want) {
+		t.Errorf("Expected targets %v, got %v", want, snapshot.Targets)
+	}
+
+	// Without its target request, the clusters are recorded without API URLs
+	scenarioRun.Spec.TargetRequestID = "missing"
+	if snapshot, err = reconciler.snapshotTargets(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("snapshotTargets failed: %v", err)
+	}
+	if len(snapshot.Targets) != 3 || snapshot.Targets[1].ClusterAPIURL != "" {
+		t.Errorf("Expected the targets without API URLs, got %v", snapshot.Targets)
+	}
+}
+
+func TestSnapshotTargets_TargetGroup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	targetRequest := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "request-1", Namespace: "default"},
+		Status: krknv1alpha1.KrknTargetRequestStatus{TargetData: map[string][]krknv1alpha1.ClusterTarget{
+			"krkn-operator": {
+				{ClusterName: "staging-eu", ClusterAPIURL: "https://staging-eu:6443", Labels: map[string]string{"env": "staging"}},
+				{ClusterName: "prod-eu", ClusterAPIURL: "https://prod-eu:6443", Labels: map[string]string{"env": "prod"}},
+			},
+		}},
+	}
+	group := &krknv1alpha1.KrknTargetGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "default"},
+		Spec: krknv1alpha1.KrknTargetGroupSpec{
+			Targets:  map[string][]string{"krkn-operator-acm": {"acm-staging"}},
+			Selector: "env=staging",
+		},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "request-1",
+			TargetGroupRef:  "staging",
+			TargetClusters:  map[string][]string{"krkn-operator": {"staging-eu"}},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(targetRequest, group).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	snapshot, err := reconciler.snapshotTargets(context.Background(), scenarioRun)
+	if err != nil {
+		t.Fatalf("snapshotTargets failed: %v", err)
+	}
+	want := []krknv1alpha1.ResolvedTarget{
+		{Provider: "krkn-operator", ClusterName: "staging-eu", ClusterAPIURL: "https://staging-eu:6443",
+			ClusterID: krknv1alpha1.StableClusterID("krkn-operator", "", "staging-eu")},
+		{Provider: "krkn-operator-acm", ClusterName: "acm-staging",
+			ClusterID: krknv1alpha1.StableClusterID("krkn-operator-acm", "", "acm-staging")},
+	}
+	if snapshot.TargetGroup != "staging" || !slices.Equal(snapshot.Targets, want) {
+		t.Errorf("Expected the group members %v, got %+v", want, snapshot)
+	}
+
+	// Once started, the run targets its resolved clusters
+	scenarioRun.Status.ResolvedTargets = snapshot
+	if got := scenarioRun.Targets()["krkn-operator-acm"]; !slices.Equal(got, []string{"acm-staging"}) {
+		t.Errorf("Expected the run to target the group members, got %v", scenarioRun.Targets())
+	}
+
+	// Unknown groups leave the run unresolved
+	scenarioRun.Spec.TargetGroupRef = "missing"
+	if _, err := reconciler.snapshotTargets(context.Background(), scenarioRun); err == nil {
+		t.Error("Expected an error for a missing target group")
+	}
+}
+
+func TestSnapshotTargets_ClusterIDs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	// The cluster was renamed since its identifier was handed out
+	clusterID := krknv1alpha1.StableClusterID("krkn-operator-capi", "0b6f3c2e", "payments")
+	targetRequest := &krknv1alpha1.KrknTargetRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "request-1", Namespace: "default"},
+		Status: krknv1alpha1.KrknTargetRequestStatus{TargetData: map[string][]krknv1alpha1.ClusterTarget{
+			"krkn-operator-capi": {
+				{ClusterName: "payments-v2", ClusterAPIURL: "https://payments:6443", ClusterUID: "0b6f3c2e"},
+			},
+		}},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID:  "request-1",
+			TargetClusterIDs: []string{clusterID},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(targetRequest).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	snapshot, err := reconciler.snapshotTargets(context.Background(), scenarioRun)
+	if err != nil {
+		t.Fatalf("snapshotTargets failed: %v", err)
+	}
+	want := []krknv1alpha1.ResolvedTarget{{
+		Provider:      "krkn-operator-capi",
+		ClusterName:   "payments-v2",
+		ClusterAPIURL: "https://payments:6443",
+		ClusterID:     clusterID,
+	}}
+	if !slices.Equal(snapshot.Targets, want) {
+		t.Errorf("Expected the renamed cluster %v, got %v", want, snapshot.Targets)
+	}
+
+	// Jobs are recorded with the identifier of the snapshot
+	scenarioRun.Status.ResolvedTargets = snapshot
+	if got := scenarioRun.TargetClusterID("krkn-operator-capi", "payments-v2"); got != clusterID {
+		t.Errorf("Expected the job cluster ID %s, got %s", clusterID, got)
+	}
+
+	// Unknown identifiers leave the run unresolved
+	scenarioRun.Spec.TargetClusterIDs = []string{"cl-0000000000000000"}
+	if _, err := reconciler.snapshotTargets(context.Background(), scenarioRun); err == nil {
+		t.Error("Expected an error for an unknown cluster ID")
+	}
+}
+
+func TestSnapshotTargets_ArchivedTargets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	archived := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-eu", Namespace: "default"},
+		Spec:       krknv1alpha1.KrknOperatorTargetSpec{UUID: "uuid-1", ClusterName: "prod-eu"},
+		Status:     krknv1alpha1.KrknOperatorTargetStatus{Archived: true},
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			TargetRequestID: "request-1",
+			TargetClusters: map[string][]string{
+				"krkn-operator":     {"staging-eu", "prod-eu"},
+				"krkn-operator-acm": {"prod-eu-acm"},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(archived).Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default", Recorder: recorder}
+
+	snapshot, err := reconciler.snapshotTargets(context.Background(), scenarioRun)
+	if err != nil {
+		t.Fatalf("snapshotTargets failed: %v", err)
+	}
+	var clusters []string
+	for _, target := range snapshot.Targets {
+		clusters = append(clusters, target.ClusterName)
+	}
+	if !slices.Equal(clusters, []string{"staging-eu", "prod-eu-acm"}) {
+		t.Errorf("Expected the archived target to be skipped, got %v", clusters)
+	}
+	if !slices.Equal(scenarioRun.Spec.TargetClusters["krkn-operator"], []string{"staging-eu", "prod-eu"}) {
+		t.Errorf("Expected the spec to be left untouched, got %v", scenarioRun.Spec.TargetClusters)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, EventArchivedTargetsSkipped) {
+		t.Errorf("Expected an %s event, got %q", EventArchivedTargetsSkipped, event)
+	}
+
+	// A run left without clusters does not resolve
+	scenarioRun.Spec.TargetClusters = map[string][]string{"krkn-operator": {"prod-eu"}}
+	if _, err := reconciler.snapshotTargets(context.Background(), scenarioRun); err == nil {
+		t.Error("Expected an error when only archived targets remain")
+	}
+}
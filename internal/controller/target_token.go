@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+)
+
+const (
+	// DefaultTokenExpirationSeconds is the lifetime requested for minted tokens when the
+	// target does not set one
+	DefaultTokenExpirationSeconds = 86400
+
+	// tokenRotationThreshold is the share of the token lifetime after which automatic
+	// rotation re-mints it
+	tokenRotationThreshold = 0.8
+
+	// tokenRotationRetryInterval is the time before retrying a failed automatic rotation
+	tokenRotationRetryInterval = time.Minute
+)
+
+// TokenMinter mints a token of the ServiceAccount namespace/name with the credentials
+// of a base64 kubeconfig, returning the token and when it expires
+type TokenMinter func(ctx context.Context, kubeconfigBase64, namespace, name string, expirationSeconds int64) (string, time.Time, error)
+
+// TargetTokenRotator re-mints the token of token targets with a ServiceAccount reference
+// and stores the regenerated kubeconfig where the target keeps its credentials
+type TargetTokenRotator struct {
+	Client client.Client
+	// CredentialsStore holds the credentials of the targets with a Vault path
+	CredentialsStore secrets.Store
+	// Cipher seals the kubeconfigs at rest (nil when encryption is off)
+	Cipher *envelope.Cipher
+	// Mint mints the tokens (kubeconfig.MintServiceAccountToken when nil)
+	Mint TokenMinter
+}
+
+// TokenRotationEnabled reports whether the token of target can be rotated
+func TokenRotationEnabled(target *krknv1alpha1.KrknOperatorTarget) bool {
+	return target.Spec.SecretType == "token" && target.Spec.TokenRotation != nil
+}
+
+// RotateToken mints a new token for target with its current kubeconfig, stores the
+// kubeconfig with the new token and records the expiry in the status of target.
+// Returns when the new token expires.
+func (r *TargetTokenRotator) RotateToken(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) (*metav1.Time, error) {
+	if !TokenRotationEnabled(target) {
+		return nil, fmt.Errorf("target %s is not a token target with token rotation", target.Spec.UUID)
+	}
+	rotation := target.Spec.TokenRotation
+	expirationSeconds := rotation.ExpirationSeconds
+	if expirationSeconds == 0 {
+		expirationSeconds = DefaultTokenExpirationSeconds
+	}
+
+	data, err := secrets.TargetCredentials(ctx, r.Client, r.CredentialsStore, target)
+	if err != nil {
+		return nil, err
+	}
+	raw, exists := data["kubeconfig"]
+	if !exists {
+		return nil, fmt.Errorf("kubeconfig not found in the credentials of target %s", target.Spec.UUID)
+	}
+	kubeconfigBase64, err := kubeconfig.UnmarshalSecretData(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal kubeconfig of target %s: %w", target.Spec.UUID, err)
+	}
+	if kubeconfigBase64, err = r.Cipher.Open(ctx, kubeconfigBase64); err != nil {
+		return nil, fmt.Errorf("failed to decrypt kubeconfig of target %s: %w", target.Spec.UUID, err)
+	}
+
+	mint := r.Mint
+	if mint == nil {
+		mint = kubeconfig.MintServiceAccountToken
+	}
+	token, expiresAt, err := mint(ctx, kubeconfigBase64, rotation.ServiceAccount.Namespace,
+		rotation.ServiceAccount.Name, expirationSeconds)
+	if err != nil {
+		return nil, err
+	}
+	if kubeconfigBase64, err = kubeconfig.ReplaceToken(kubeconfigBase64, token); err != nil {
+		return nil, err
+	}
+	if kubeconfigBase64, err = r.Cipher.Seal(ctx, kubeconfigBase64); err != nil {
+		return nil, fmt.Errorf("failed to encrypt kubeconfig of target %s: %w", target.Spec.UUID, err)
+	}
+	secretData, err := kubeconfig.MarshalSecretData(kubeconfigBase64)
+	if err != nil {
+		return nil, err
+	}
+	updated := maps.Clone(data)
+	updated["kubeconfig"] = secretData
+	if err := secrets.PutTargetCredentials(ctx, r.Client, r.CredentialsStore, target, updated); err != nil {
+		return nil, err
+	}
+
+	expires := metav1.NewTime(expiresAt)
+	now := metav1.Now()
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(target), target); err != nil {
+			return err
+		}
+		target.Status.TokenExpiresAt = &expires
+		target.Status.LastTokenRotation = &now
+		return r.Client.Status().Update(ctx, target)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("token of target %s rotated but its status not updated: %w", target.Spec.UUID, err)
+	}
+	return &expires, nil
+}
+
+// tokenRotationDue returns how long until the token of target must be re-minted, zero or
+// less when it is due: unknown expiries are due, known ones after tokenRotationThreshold
+// of the time between the last rotation and the expiry
+func tokenRotationDue(target *krknv1alpha1.KrknOperatorTarget) time.Duration {
+	expires := target.Status.TokenExpiresAt
+	if expires == nil {
+		return 0
+	}
+	if last := target.Status.LastTokenRotation; last != nil && last.Before(expires) {
+		lifetime := expires.Sub(last.Time)
+		return time.Until(last.Add(time.Duration(float64(lifetime) * tokenRotationThreshold)))
+	}
+	return time.Until(expires.Time)
+}
+
+// rotateTargetToken re-mints the token of targets with automatic token rotation once it
+// is due, and returns when to check it again
+func (r *KrknOperatorTargetReconciler) rotateTargetToken(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) ctrl.Result {
+	if r.TokenRotator == nil || !TokenRotationEnabled(target) || !target.Spec.TokenRotation.Automatic {
+		return ctrl.Result{}
+	}
+	if remaining := tokenRotationDue(target); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}
+	}
+
+	logger := log.FromContext(ctx)
+	expires, err := r.TokenRotator.RotateToken(ctx, target)
+	if err != nil {
+		logger.Error(err, "Failed to rotate target token", "uuid", target.Spec.UUID)
+		return ctrl.Result{RequeueAfter: tokenRotationRetryInterval}
+	}
+	logger.Info("Rotated target token", "uuid", target.Spec.UUID, "expiresAt", expires.Time)
+	return ctrl.Result{RequeueAfter: tokenRotationDue(target)}
+}
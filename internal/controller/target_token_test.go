@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+)
+
+// rotatedTarget returns an active token target with automatic token rotation and the
+// Secret holding its kubeconfig
+func rotatedTarget(t *testing.T, automatic bool) (*krknv1alpha1.KrknOperatorTarget, *corev1.Secret) {
+	kubeconfigBase64, err := kubeconfig.GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "old-token", true)
+	if err != nil {
+		t.Fatalf("GenerateFromToken failed: %v", err)
+	}
+	data, err := kubeconfig.MarshalSecretData(kubeconfigBase64)
+	if err != nil {
+		t.Fatalf("MarshalSecretData failed: %v", err)
+	}
+	target := archivedTarget(time.Now())
+	target.Status = krknv1alpha1.KrknOperatorTargetStatus{Ready: true}
+	target.Spec.SecretType = "token"
+	target.Spec.TokenRotation = &krknv1alpha1.TargetTokenRotation{
+		ServiceAccount:    krknv1alpha1.ServiceAccountReference{Namespace: "krkn", Name: "runner"},
+		ExpirationSeconds: 3600,
+		Automatic:         automatic,
+	}
+	secret := targetSecret()
+	secret.Data = map[string][]byte{"kubeconfig": data, "prometheus-token": []byte("kept")}
+	return target, secret
+}
+
+// fakeMinter mints new-token for an hour, or fails with err, and counts its calls
+type fakeMinter struct {
+	err   error
+	calls int
+}
+
+func (m *fakeMinter) mint(_ context.Context, _, namespace, name string, expirationSeconds int64) (string, time.Time, error) {
+	m.calls++
+	if m.err != nil {
+		return "", time.Time{}, m.err
+	}
+	if namespace != "krkn" || name != "runner" || expirationSeconds != 3600 {
+		return "", time.Time{}, errors.New("unexpected ServiceAccount or expiration")
+	}
+	return "new-token", time.Now().Add(time.Hour), nil
+}
+
+func storedToken(t *testing.T, reconciler *KrknOperatorTargetReconciler) string {
+	t.Helper()
+	var secret corev1.Secret
+	key := types.NamespacedName{Name: "secret-uuid", Namespace: testOperatorNamespace}
+	if err := reconciler.Get(context.Background(), key, &secret); err != nil {
+		t.Fatalf("Failed to get secret: %v", err)
+	}
+	if string(secret.Data["prometheus-token"]) != "kept" {
+		t.Errorf("Expected the other credentials to be kept, got %v", secret.Data)
+	}
+	kubeconfigBase64, err := kubeconfig.UnmarshalSecretData(secret.Data["kubeconfig"])
+	if err != nil {
+		t.Fatalf("UnmarshalSecretData failed: %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	for _, line := range strings.Split(string(raw), "\n") {
+		if token, found := strings.CutPrefix(strings.TrimSpace(line), "token: "); found {
+			return token
+		}
+	}
+	return ""
+}
+
+func TestTargetReconcile_RotatesToken(t *testing.T) {
+	key := types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}
+	target, secret := rotatedTarget(t, true)
+	reconciler := setupTestTargetReconciler(target, secret)
+	minter := &fakeMinter{}
+	reconciler.TokenRotator = &TargetTokenRotator{Client: reconciler.Client, Mint: minter.mint}
+
+	// No expiry is known yet, the token is rotated right away
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if minter.calls != 1 || storedToken(t, reconciler) != "new-token" {
+		t.Fatalf("Expected the new token to be stored after one mint, got %d calls", minter.calls)
+	}
+	var updated krknv1alpha1.KrknOperatorTarget
+	if err := reconciler.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("Failed to get target: %v", err)
+	}
+	if updated.Status.TokenExpiresAt == nil || updated.Status.LastTokenRotation == nil {
+		t.Fatalf("Expected the rotation to be recorded, got %+v", updated.Status)
+	}
+	// The next rotation is due after 80% of the lifetime
+	if result.RequeueAfter < 47*time.Minute || result.RequeueAfter > 48*time.Minute {
+		t.Errorf("Expected a requeue after ~48m, got %v", result.RequeueAfter)
+	}
+
+	// Not due yet
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if minter.calls != 1 {
+		t.Errorf("Expected no rotation before it is due, got %d calls", minter.calls)
+	}
+}
+
+func TestTargetReconcile_TokenRotationFailure(t *testing.T) {
+	key := types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}
+	target, secret := rotatedTarget(t, true)
+	reconciler := setupTestTargetReconciler(target, secret)
+	minter := &fakeMinter{err: errors.New("forbidden")}
+	reconciler.TokenRotator = &TargetTokenRotator{Client: reconciler.Client, Mint: minter.mint}
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != tokenRotationRetryInterval {
+		t.Errorf("Expected a retry after %v, got %v", tokenRotationRetryInterval, result.RequeueAfter)
+	}
+	if storedToken(t, reconciler) != "old-token" {
+		t.Error("Expected the kubeconfig to be kept when minting fails")
+	}
+}
+
+func TestTargetReconcile_ManualTokenRotation(t *testing.T) {
+	key := types.NamespacedName{Name: "target-uuid", Namespace: testOperatorNamespace}
+	target, secret := rotatedTarget(t, false)
+	reconciler := setupTestTargetReconciler(target, secret)
+	minter := &fakeMinter{}
+	reconciler.TokenRotator = &TargetTokenRotator{Client: reconciler.Client, Mint: minter.mint}
+
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if minter.calls != 0 {
+		t.Errorf("Expected no automatic rotation, got %d calls", minter.calls)
+	}
+
+	// Rotation on request
+	expires, err := reconciler.TokenRotator.RotateToken(context.Background(), target)
+	if err != nil {
+		t.Fatalf("RotateToken failed: %v", err)
+	}
+	if expires == nil || storedToken(t, reconciler) != "new-token" {
+		t.Errorf("Expected the new token to be stored, got expiry %v", expires)
+	}
+
+	target.Spec.SecretType = "kubeconfig"
+	if _, err := reconciler.TokenRotator.RotateToken(context.Background(), target); err == nil {
+		t.Error("Expected an error rotating the token of a kubeconfig target")
+	}
+}
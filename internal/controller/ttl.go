@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+// runFinished reports whether a run completed and has no retry wave waiting to start
+func runFinished(scenarioRun *krknv1alpha1.KrknScenarioRun) bool {
+	switch scenarioRun.Status.Phase {
+	case "Succeeded", "Failed", "PartiallyFailed":
+	default:
+		return false
+	}
+	for _, wave := range scenarioRun.Status.RetryWaves {
+		if wave.StartTime == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// setRunCompletionTime records when the run finished, and clears it while the run is
+// active again, e.g. retrying its failed clusters
+func setRunCompletionTime(scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	if !runFinished(scenarioRun) {
+		scenarioRun.Status.CompletionTime = nil
+		return
+	}
+	if scenarioRun.Status.CompletionTime == nil {
+		now := metav1.Now()
+		scenarioRun.Status.CompletionTime = &now
+	}
+}
+
+// ttlRemaining returns how long a finished run is kept before it is deleted, negative
+// once expired. ok is false when the run has no TTL or has not finished.
+func ttlRemaining(scenarioRun *krknv1alpha1.KrknScenarioRun, now time.Time) (remaining time.Duration, ok bool) {
+	ttl := scenarioRun.Spec.TTLSecondsAfterFinished
+	if ttl == nil || scenarioRun.Status.CompletionTime == nil || !runFinished(scenarioRun) {
+		return 0, false
+	}
+	expiry := scenarioRun.Status.CompletionTime.Add(time.Duration(*ttl) * time.Second)
+	return expiry.Sub(now), true
+}
+
+// deleteExpiredRun deletes a run whose TTL expired along with the resources created for
+// it. Owner references would remove most of them with the run, but the resources of
+// earlier attempts and those created without owner are only found by their labels:
+// every resource carrying a job ID of the run also carries its run label.
+func (r *KrknScenarioRunReconciler) deleteExpiredRun(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	logger := log.FromContext(ctx)
+	selector := []client.ListOption{
+		client.InNamespace(r.Namespace),
+		client.MatchingLabels{joblabels.ScenarioRun: scenarioRun.Name},
+	}
+
+	lists := []client.ObjectList{&batchv1.JobList{}, &corev1.PodList{}, &corev1.ConfigMapList{}, &corev1.SecretList{}}
+	deleted := 0
+	for _, list := range lists {
+		if err := r.List(ctx, list, selector...); err != nil {
+			return fmt.Errorf("failed to list resources of expired run: %w", err)
+		}
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			object := item.(client.Object)
+			err := r.Delete(ctx, object, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete %s of expired run: %w", object.GetName(), err)
+			}
+			deleted++
+		}
+	}
+
+	if err := r.Delete(ctx, scenarioRun); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete expired run: %w", err)
+	}
+
+	logger.Info("deleted scenario run past its TTL",
+		"scenarioRun", scenarioRun.Name,
+		"completionTime", scenarioRun.Status.CompletionTime.Time,
+		"ttlSecondsAfterFinished", *scenarioRun.Spec.TTLSecondsAfterFinished,
+		"deletedResources", deleted)
+	return nil
+}
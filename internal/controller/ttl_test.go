@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/joblabels"
+)
+
+func TestTTLRemaining(t *testing.T) {
+	now := time.Now()
+	completed := &metav1.Time{Time: now.Add(-time.Minute)}
+
+	tests := []struct {
+		name          string
+		phase         string
+		ttl           *int32
+		waves         []krknv1alpha1.RetryWave
+		wantOK        bool
+		wantRemaining time.Duration
+	}{
+		{name: "no ttl", phase: "Succeeded"},
+		{name: "running", phase: "Running", ttl: ptr.To[int32](30)},
+		{name: "expired", phase: "Failed", ttl: ptr.To[int32](30), wantOK: true, wantRemaining: -30 * time.Second},
+		{name: "not expired", phase: "PartiallyFailed", ttl: ptr.To[int32](90), wantOK: true, wantRemaining: 30 * time.Second},
+		{name: "retry wave pending", phase: "Failed", ttl: ptr.To[int32](30),
+			waves: []krknv1alpha1.RetryWave{{Number: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioRun := &krknv1alpha1.KrknScenarioRun{
+				Spec: krknv1alpha1.KrknScenarioRunSpec{TTLSecondsAfterFinished: tt.ttl},
+				Status: krknv1alpha1.KrknScenarioRunStatus{
+					Phase:          tt.phase,
+					CompletionTime: completed,
+					RetryWaves:     tt.waves,
+				},
+			}
+			remaining, ok := ttlRemaining(scenarioRun, now)
+			if ok != tt.wantOK || remaining != tt.wantRemaining {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.wantRemaining, tt.wantOK, remaining, ok)
+			}
+		})
+	}
+}
+
+func TestSetRunCompletionTime(t *testing.T) {
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		Status: krknv1alpha1.KrknScenarioRunStatus{Phase: "Failed"},
+	}
+
+	setRunCompletionTime(scenarioRun)
+	completed := scenarioRun.Status.CompletionTime
+	if completed == nil {
+		t.Fatal("expected the completion time of a finished run to be set")
+	}
+	setRunCompletionTime(scenarioRun)
+	if scenarioRun.Status.CompletionTime != completed {
+		t.Error("expected the completion time to be kept once set")
+	}
+
+	// A retry of the failed clusters makes the run active again
+	scenarioRun.Status.RetryWaves = []krknv1alpha1.RetryWave{{Number: 1}}
+	setRunCompletionTime(scenarioRun)
+	if scenarioRun.Status.CompletionTime != nil {
+		t.Error("expected the completion time to be cleared while a retry wave is pending")
+	}
+}
+
+func TestDeleteExpiredRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	labeled := func(name, run string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{joblabels.ScenarioRun: run, joblabels.JobID: name},
+		}
+	}
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "default"},
+		Spec:       krknv1alpha1.KrknScenarioRunSpec{TTLSecondsAfterFinished: ptr.To[int32](0)},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			Phase:          "Succeeded",
+			CompletionTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+	objects := []client.Object{
+		scenarioRun,
+		&batchv1.Job{ObjectMeta: labeled("krkn-job-job-1", "expired")},
+		&corev1.Pod{ObjectMeta: labeled("krkn-job-job-1-abcde", "expired")},
+		&corev1.ConfigMap{ObjectMeta: labeled("krkn-job-job-1-files", "expired")},
+		&corev1.Secret{ObjectMeta: labeled("krkn-job-job-1-kubeconfig", "expired")},
+		&corev1.ConfigMap{ObjectMeta: labeled("krkn-job-job-2-files", "other")},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	reconciler := &KrknScenarioRunReconciler{Client: fakeClient, Scheme: scheme, Namespace: "default"}
+
+	ctx := context.Background()
+	if err := reconciler.deleteExpiredRun(ctx, scenarioRun); err != nil {
+		t.Fatalf("deleteExpiredRun failed: %v", err)
+	}
+
+	for _, object := range objects[:len(objects)-1] {
+		err := reconciler.Get(ctx, client.ObjectKeyFromObject(object), object)
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("expected %T %s to be deleted, got %v", object, object.GetName(), err)
+		}
+	}
+	other := objects[len(objects)-1]
+	if err := reconciler.Get(ctx, client.ObjectKeyFromObject(other), other); err != nil {
+		t.Errorf("expected the resources of another run to be kept, got %v", err)
+	}
+}
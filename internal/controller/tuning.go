@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// Controller names used for per-controller tuning overrides
+const (
+	ScenarioRunControllerName          = "krknscenariorun"
+	TargetRequestControllerName        = "krkntargetrequest"
+	TargetProviderConfigControllerName = "krknoperatortargetproviderconfig"
+	OperatorTargetControllerName       = "krknoperatortarget"
+	TargetProviderControllerName       = "krknoperatortargetprovider"
+	ACMDiscoveryControllerName         = "acmdiscovery"
+)
+
+// Tuning holds client throttling and reconcile concurrency settings.
+//
+// Defaults scale with the expected fleet size (number of target clusters):
+//
+//	fleet size   QPS  Burst  reconciles  resync
+//	<= 10         20     30           1     10h
+//	<= 50         50    100           4     10h
+//	<= 200       100    200           8     12h
+//	> 200        200    400          16     24h
+//
+// More concurrent reconciles need more client QPS, otherwise workers queue on
+// client-side throttling. Resync only replays the informer cache, so larger
+// fleets resync less often to avoid reconcile storms.
+type Tuning struct {
+	// QPS and Burst configure the client-side rate limiter of rest.Config
+	QPS   float32
+	Burst int
+	// MaxConcurrentReconciles is the default worker count for every controller
+	MaxConcurrentReconciles int
+	// ControllerConcurrency overrides MaxConcurrentReconciles per controller name
+	ControllerConcurrency map[string]int
+	// SyncPeriod is the informer cache resync interval
+	SyncPeriod time.Duration
+}
+
+// DefaultTuning returns the recommended settings for a fleet of fleetSize target clusters
+func DefaultTuning(fleetSize int) Tuning {
+	switch {
+	case fleetSize <= 10:
+		return Tuning{QPS: 20, Burst: 30, MaxConcurrentReconciles: 1, SyncPeriod: 10 * time.Hour}
+	case fleetSize <= 50:
+		return Tuning{QPS: 50, Burst: 100, MaxConcurrentReconciles: 4, SyncPeriod: 10 * time.Hour}
+	case fleetSize <= 200:
+		return Tuning{QPS: 100, Burst: 200, MaxConcurrentReconciles: 8, SyncPeriod: 12 * time.Hour}
+	default:
+		return Tuning{QPS: 200, Burst: 400, MaxConcurrentReconciles: 16, SyncPeriod: 24 * time.Hour}
+	}
+}
+
+// ConcurrencyFor returns the worker count for the named controller
+func (t Tuning) ConcurrencyFor(name string) int {
+	if n, ok := t.ControllerConcurrency[name]; ok {
+		return n
+	}
+	return t.MaxConcurrentReconciles
+}
+
+// ApplyToConfig sets the client rate limits on cfg
+func (t Tuning) ApplyToConfig(cfg *rest.Config) {
+	cfg.QPS = t.QPS
+	cfg.Burst = t.Burst
+}
+
+// ParseControllerConcurrency parses "name=N,name=N" overrides.
+// Names must be one of the known controller names.
+func ParseControllerConcurrency(value string) (map[string]int, error) {
+	known := map[string]bool{
+		ScenarioRunControllerName:          true,
+		TargetRequestControllerName:        true,
+		TargetProviderConfigControllerName: true,
+		OperatorTargetControllerName:       true,
+		TargetProviderControllerName:       true,
+		ACMDiscoveryControllerName:         true,
+	}
+
+	overrides := map[string]int{}
+	if strings.TrimSpace(value) == "" {
+		return overrides, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		name, count, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid override %q, expected name=N", pair)
+		}
+		if !known[name] {
+			names := make([]string, 0, len(known))
+			for n := range known {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown controller %q, valid names: %s", name, strings.Join(names, ", "))
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid worker count for %s: %q", name, count)
+		}
+		overrides[name] = n
+	}
+	return overrides, nil
+}
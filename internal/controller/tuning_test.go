@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestDefaultTuningScalesWithFleetSize(t *testing.T) {
+	previous := DefaultTuning(0)
+	for _, size := range []int{10, 50, 200, 1000} {
+		current := DefaultTuning(size)
+		if current.QPS < previous.QPS || current.Burst < previous.Burst ||
+			current.MaxConcurrentReconciles < previous.MaxConcurrentReconciles ||
+			current.SyncPeriod < previous.SyncPeriod {
+			t.Errorf("tuning for fleet size %d is lower than for a smaller fleet: %+v < %+v", size, current, previous)
+		}
+		if float32(current.Burst) < current.QPS {
+			t.Errorf("burst must not be lower than QPS for fleet size %d: %+v", size, current)
+		}
+		previous = current
+	}
+
+	if DefaultTuning(0).MaxConcurrentReconciles != 1 {
+		t.Error("small fleets should keep a single reconcile worker")
+	}
+}
+
+func TestTuningConcurrencyAndConfig(t *testing.T) {
+	tuning := DefaultTuning(50)
+	tuning.ControllerConcurrency = map[string]int{ScenarioRunControllerName: 12}
+
+	if got := tuning.ConcurrencyFor(ScenarioRunControllerName); got != 12 {
+		t.Errorf("expected override 12, got %d", got)
+	}
+	if got := tuning.ConcurrencyFor(TargetRequestControllerName); got != tuning.MaxConcurrentReconciles {
+		t.Errorf("expected default %d, got %d", tuning.MaxConcurrentReconciles, got)
+	}
+
+	cfg := &rest.Config{}
+	tuning.ApplyToConfig(cfg)
+	if cfg.QPS != tuning.QPS || cfg.Burst != tuning.Burst {
+		t.Errorf("rate limits not applied: %+v", cfg)
+	}
+}
+
+func TestParseControllerConcurrency(t *testing.T) {
+	overrides, err := ParseControllerConcurrency("krknscenariorun=8, krkntargetrequest=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if overrides[ScenarioRunControllerName] != 8 || overrides[TargetRequestControllerName] != 2 {
+		t.Errorf("unexpected overrides: %v", overrides)
+	}
+
+	if overrides, err := ParseControllerConcurrency(""); err != nil || len(overrides) != 0 {
+		t.Errorf("expected empty overrides, got %v (err=%v)", overrides, err)
+	}
+
+	for _, invalid := range []string{"krknscenariorun", "unknown=2", "krknscenariorun=0", "krknscenariorun=x"} {
+		if _, err := ParseControllerConcurrency(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
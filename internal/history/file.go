@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileStore keeps the history in a JSON lines file. Every record is appended, the last
+// record of a run wins; the file is compacted to one line per run when it is opened.
+// All runs are held in memory for queries.
+type FileStore struct {
+	mu   sync.RWMutex
+	path string
+	file *os.File
+	runs map[string]Run
+}
+
+// NewFileStore opens (or creates) the history file at path
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{path: path, runs: map[string]Run{}}
+	lines, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+	if lines > len(store.runs) {
+		if err := store.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	store.file = file
+	return store, nil
+}
+
+// load reads the runs of the history file and returns its number of lines
+func (s *FileStore) load() (int, error) {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		lines++
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return 0, fmt.Errorf("failed to read line %d of history file: %w", lines, err)
+		}
+		s.runs[run.Name] = run
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return lines, nil
+}
+
+// compact rewrites the history file with the last record of each run
+func (s *FileStore) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to compact history file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	writer := bufio.NewWriter(tmp)
+	for _, run := range s.sorted() {
+		data, err := json.Marshal(run)
+		if err != nil {
+			_ = tmp.Close()
+			return err
+		}
+		_, _ = writer.Write(append(data, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to compact history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to compact history file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to compact history file: %w", err)
+	}
+	return nil
+}
+
+// sorted returns the runs newest first
+func (s *FileStore) sorted() []Run {
+	runs := make([]Run, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		if !runs[i].CreatedAt.Equal(runs[j].CreatedAt) {
+			return runs[i].CreatedAt.After(runs[j].CreatedAt)
+		}
+		return runs[i].Name < runs[j].Name
+	})
+	return runs
+}
+
+// Record implements Store
+func (s *FileStore) Record(_ context.Context, run Run) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	s.runs[run.Name] = run
+	return nil
+}
+
+// Get implements Store
+func (s *FileStore) Get(_ context.Context, name string) (*Run, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	run, ok := s.runs[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &run, nil
+}
+
+// List implements Store
+func (s *FileStore) List(_ context.Context, query Query) ([]Run, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	runs := make([]Run, 0)
+	for _, run := range s.sorted() {
+		if len(runs) == query.limit() {
+			break
+		}
+		if query.Matches(run) {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// Close implements Store
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "runs.jsonl")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, run := range []Run{
+		{Name: "run-a", ScenarioName: "pod-scenarios", OwnerUserID: "alice@example.com", Phase: "Succeeded"},
+		{Name: "run-b", ScenarioName: "node-scenarios", OwnerUserID: "bob@example.com", Phase: "Failed"},
+		{Name: "run-c", ScenarioName: "pod-scenarios", OwnerUserID: "bob@example.com", Phase: "Failed"},
+	} {
+		run.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		if err := store.Record(ctx, run); err != nil {
+			t.Fatalf("Failed to record %s: %v", run.Name, err)
+		}
+	}
+	// A later record of a run replaces the previous one
+	if err := store.Record(ctx, Run{Name: "run-a", ScenarioName: "pod-scenarios", OwnerUserID: "alice@example.com",
+		Phase: "PartiallyFailed", CreatedAt: base}); err != nil {
+		t.Fatalf("Failed to record run-a again: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Failed to close store: %v", err)
+	}
+
+	// Reopening keeps the runs and compacts the file to one line per run
+	store, err = NewFileStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read history file: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("Expected 3 lines after compaction, got %d", lines)
+	}
+
+	run, err := store.Get(ctx, "run-a")
+	if err != nil {
+		t.Fatalf("Failed to get run-a: %v", err)
+	}
+	if run.Phase != "PartiallyFailed" {
+		t.Errorf("Expected the last record of run-a, got phase %s", run.Phase)
+	}
+	if _, err := store.Get(ctx, "missing"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	since := base.Add(30 * time.Minute)
+	until := base.Add(2 * time.Hour)
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{name: "all newest first", query: Query{}, want: []string{"run-c", "run-b", "run-a"}},
+		{name: "scenario", query: Query{ScenarioName: "pod-scenarios"}, want: []string{"run-c", "run-a"}},
+		{name: "owner and phase", query: Query{OwnerUserID: "bob@example.com", Phase: "Failed"}, want: []string{"run-c", "run-b"}},
+		{name: "time window", query: Query{Since: &since, Until: &until}, want: []string{"run-b"}},
+		{name: "limit", query: Query{Limit: 2}, want: []string{"run-c", "run-b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, err := store.List(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			var names []string
+			for _, run := range runs {
+				names = append(names, run.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+}
+
+func TestOpenUnknownBackend(t *testing.T) {
+	if _, err := Open(context.Background(), "mongodb", "mongodb://localhost"); err == nil {
+		t.Error("Expected an error for an unknown backend")
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history keeps the record of scenario runs after their KrknScenarioRun, jobs
+// and pods are garbage-collected: every finished or deleted run is written to a Store
+// with the outcome and duration of each of its clusters, and queried through the
+// /history endpoints of the REST API.
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// Backends of Open
+const (
+	// BackendFile keeps the history in a JSON lines file, e.g. on a persistent volume
+	BackendFile = "file"
+	// BackendSQLite keeps the history in a SQLite database through the "sqlite" driver
+	BackendSQLite = "sqlite"
+	// BackendPostgres keeps the history in a PostgreSQL database through the "postgres" driver
+	BackendPostgres = "postgres"
+)
+
+// DefaultQueryLimit is the number of runs a query returns when it sets no limit
+const DefaultQueryLimit = 100
+
+// ErrNotFound is returned by Store.Get for runs that were never recorded
+var ErrNotFound = errors.New("run not found in history")
+
+// Run is the recorded outcome of a scenario run
+type Run struct {
+	// Name is the name of the KrknScenarioRun
+	Name string `json:"name"`
+	// Title is the display name of the run, or its scenario and number
+	Title        string `json:"title"`
+	ScenarioName string `json:"scenarioName"`
+	// ScenarioImage is the container image the scenario ran
+	ScenarioImage string `json:"scenarioImage"`
+	RunNumber     int64  `json:"runNumber,omitempty"`
+	OwnerUserID   string `json:"ownerUserId,omitempty"`
+	CampaignRef   string `json:"campaignRef,omitempty"`
+	// Phase is the last phase of the run, not a final one for runs deleted while active
+	Phase       string     `json:"phase"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// DurationSeconds is the time from the creation of the run to its completion
+	DurationSeconds int64            `json:"durationSeconds,omitempty"`
+	Clusters        []ClusterOutcome `json:"clusters"`
+	// RecordedAt is when the run was last written to the history
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// ClusterOutcome is the recorded outcome of the job of a cluster of a run
+type ClusterOutcome struct {
+	ProviderName   string     `json:"providerName"`
+	ClusterName    string     `json:"clusterName"`
+	Phase          string     `json:"phase"`
+	FailureReason  string     `json:"failureReason,omitempty"`
+	Message        string     `json:"message,omitempty"`
+	RetryCount     int        `json:"retryCount,omitempty"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// DurationSeconds is the time the last attempt of the job ran
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+// Query selects recorded runs. Empty fields match every run.
+type Query struct {
+	ScenarioName string
+	OwnerUserID  string
+	Phase        string
+	// Since and Until bound the creation time of the runs
+	Since *time.Time
+	Until *time.Time
+	// Limit caps the number of runs, DefaultQueryLimit when zero
+	Limit int
+}
+
+// Matches reports whether run is selected by q, ignoring its limit
+func (q Query) Matches(run Run) bool {
+	switch {
+	case q.ScenarioName != "" && run.ScenarioName != q.ScenarioName,
+		q.OwnerUserID != "" && run.OwnerUserID != q.OwnerUserID,
+		q.Phase != "" && run.Phase != q.Phase,
+		q.Since != nil && run.CreatedAt.Before(*q.Since),
+		q.Until != nil && !run.CreatedAt.Before(*q.Until):
+		return false
+	}
+	return true
+}
+
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return q.Limit
+}
+
+// Store persists recorded runs. Implementations must be safe for concurrent use.
+type Store interface {
+	// Record writes run, replacing the previous record of a run of the same name
+	Record(ctx context.Context, run Run) error
+	// Get returns the record of the run name, or ErrNotFound
+	Get(ctx context.Context, name string) (*Run, error)
+	// List returns the runs selected by query, newest first
+	List(ctx context.Context, query Query) ([]Run, error)
+	// Close releases the resources of the store
+	Close() error
+}
+
+// Open returns the Store of backend: the path of a JSON lines file for BackendFile, the
+// data source name of the database for BackendSQLite and BackendPostgres. The SQL
+// backends use the database/sql driver registered under the name of the backend, which
+// must be linked into the operator binary.
+func Open(ctx context.Context, backend, dsn string) (Store, error) {
+	switch backend {
+	case BackendFile:
+		return NewFileStore(dsn)
+	case BackendSQLite, BackendPostgres:
+		return OpenSQLStore(ctx, backend, dsn)
+	default:
+		return nil, fmt.Errorf("unknown history backend %q, must be %s, %s or %s",
+			backend, BackendFile, BackendSQLite, BackendPostgres)
+	}
+}
+
+// Finished reports whether a run reached a final phase
+func Finished(scenarioRun *krknv1alpha1.KrknScenarioRun) bool {
+	switch scenarioRun.Status.Phase {
+	case "Succeeded", "Failed", "PartiallyFailed":
+		return true
+	}
+	return false
+}
+
+// FromScenarioRun returns the record of scenarioRun as of now
+func FromScenarioRun(scenarioRun *krknv1alpha1.KrknScenarioRun, now time.Time) Run {
+	run := Run{
+		Name:          scenarioRun.Name,
+		Title:         scenarioRun.Title(),
+		ScenarioName:  scenarioRun.Spec.ScenarioName,
+		ScenarioImage: scenarioRun.Spec.ScenarioImage,
+		RunNumber:     scenarioRun.Spec.RunNumber,
+		OwnerUserID:   scenarioRun.Spec.OwnerUserID,
+		CampaignRef:   scenarioRun.Spec.CampaignRef,
+		Phase:         scenarioRun.Status.Phase,
+		CreatedAt:     scenarioRun.CreationTimestamp.UTC(),
+		Clusters:      make([]ClusterOutcome, 0, len(scenarioRun.Status.ClusterJobs)),
+		RecordedAt:    now.UTC(),
+	}
+	if completion := scenarioRun.Status.CompletionTime; completion != nil {
+		completedAt := completion.UTC()
+		run.CompletedAt = &completedAt
+		run.DurationSeconds = int64(completedAt.Sub(run.CreatedAt).Seconds())
+	}
+
+	for _, job := range scenarioRun.Status.ClusterJobs {
+		outcome := ClusterOutcome{
+			ProviderName:  job.ProviderName,
+			ClusterName:   job.ClusterName,
+			Phase:         job.Phase,
+			FailureReason: job.FailureReason,
+			Message:       job.Message,
+			RetryCount:    job.RetryCount,
+		}
+		if job.StartTime != nil {
+			startTime := job.StartTime.UTC()
+			outcome.StartTime = &startTime
+		}
+		if job.CompletionTime != nil {
+			completionTime := job.CompletionTime.UTC()
+			outcome.CompletionTime = &completionTime
+			if outcome.StartTime != nil {
+				outcome.DurationSeconds = int64(completionTime.Sub(*outcome.StartTime).Seconds())
+			}
+		}
+		run.Clusters = append(run.Clusters, outcome)
+	}
+	return run
+}
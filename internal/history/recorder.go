@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// recordTimeout bounds the write of a run to the store
+const recordTimeout = 10 * time.Second
+
+// Recorder writes the scenario runs of a namespace to a Store: finished runs whenever
+// their status changes, e.g. once retried, and every run when it is deleted, with its
+// last status.
+type Recorder struct {
+	store     Store
+	namespace string
+	now       func() time.Time
+}
+
+// NewRecorder creates a Recorder of the runs of namespace
+func NewRecorder(store Store, namespace string) *Recorder {
+	return &Recorder{store: store, namespace: namespace, now: time.Now}
+}
+
+// Register records the runs seen by informer, a shared KrknScenarioRun informer
+func (r *Recorder) Register(informer cache.Informer) error {
+	_, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if scenarioRun, ok := r.scenarioRun(obj); ok && Finished(scenarioRun) {
+				r.record(scenarioRun)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldRun, _ := r.scenarioRun(oldObj)
+			scenarioRun, ok := r.scenarioRun(newObj)
+			if !ok || !Finished(scenarioRun) {
+				return
+			}
+			if oldRun != nil && equality.Semantic.DeepEqual(oldRun.Status, scenarioRun.Status) {
+				return
+			}
+			r.record(scenarioRun)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if scenarioRun, ok := r.scenarioRun(obj); ok {
+				r.record(scenarioRun)
+			}
+		},
+	})
+	return err
+}
+
+// scenarioRun returns the run of an informer event, if it is a run of the namespace
+func (r *Recorder) scenarioRun(obj interface{}) (*krknv1alpha1.KrknScenarioRun, bool) {
+	if tombstone, isTombstone := obj.(toolscache.DeletedFinalStateUnknown); isTombstone {
+		obj = tombstone.Obj
+	}
+	scenarioRun, ok := obj.(*krknv1alpha1.KrknScenarioRun)
+	if !ok || scenarioRun.Namespace != r.namespace {
+		return nil, false
+	}
+	return scenarioRun, true
+}
+
+// record writes scenarioRun to the store. Failures are logged: the cluster state is
+// never held up by the history.
+func (r *Recorder) record(scenarioRun *krknv1alpha1.KrknScenarioRun) {
+	ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+	defer cancel()
+	if err := r.store.Record(ctx, FromScenarioRun(scenarioRun, r.now())); err != nil {
+		log.Log.WithName("run-history").Error(err, "failed to record scenario run",
+			"scenarioRun", scenarioRun.Name,
+			"phase", scenarioRun.Status.Phase)
+	}
+}
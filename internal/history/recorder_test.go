@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "runs.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	informer := &controllertest.FakeInformer{Synced: true}
+	if err := NewRecorder(store, "krkn-operator-system").Register(informer); err != nil {
+		t.Fatalf("Failed to register recorder: %v", err)
+	}
+
+	created := metav1.NewTime(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	started := metav1.NewTime(created.Add(10 * time.Second))
+	completed := metav1.NewTime(created.Add(2 * time.Minute))
+	running := &krknv1alpha1.KrknScenarioRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "krkn-operator-system", CreationTimestamp: created},
+		Spec: krknv1alpha1.KrknScenarioRunSpec{
+			ScenarioName:  "pod-scenarios",
+			ScenarioImage: "quay.io/krkn-chaos/krkn-hub:pod-scenarios",
+			RunNumber:     3,
+			OwnerUserID:   "alice@example.com",
+		},
+		Status: krknv1alpha1.KrknScenarioRunStatus{
+			Phase: "Running",
+			ClusterJobs: []krknv1alpha1.ClusterJobStatus{
+				{ProviderName: "krkn-operator", ClusterName: "cluster-1", Phase: "Running", StartTime: &started},
+			},
+		},
+	}
+	informer.Add(running)
+	if _, err := store.Get(ctx, "run-1"); err != ErrNotFound {
+		t.Fatalf("Expected an active run not to be recorded, got %v", err)
+	}
+
+	finished := running.DeepCopy()
+	finished.Status.Phase = "Failed"
+	finished.Status.CompletionTime = &completed
+	finished.Status.ClusterJobs[0].Phase = "Failed"
+	finished.Status.ClusterJobs[0].FailureReason = "ExitCode"
+	finished.Status.ClusterJobs[0].CompletionTime = &completed
+	informer.Update(running, finished)
+
+	run, err := store.Get(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("Expected the finished run to be recorded: %v", err)
+	}
+	if run.Title != "pod-scenarios #3" || run.Phase != "Failed" || run.DurationSeconds != 120 {
+		t.Errorf("Unexpected record %+v", run)
+	}
+	if len(run.Clusters) != 1 || run.Clusters[0].FailureReason != "ExitCode" || run.Clusters[0].DurationSeconds != 110 {
+		t.Errorf("Unexpected cluster outcomes %+v", run.Clusters)
+	}
+
+	// Runs deleted while active are recorded with their last phase
+	cancelled := running.DeepCopy()
+	cancelled.Name = "run-2"
+	informer.Delete(cancelled)
+	if run, err := store.Get(ctx, "run-2"); err != nil || run.Phase != "Running" {
+		t.Errorf("Expected the deleted run to be recorded as Running, got %+v, %v", run, err)
+	}
+
+	// Runs of other namespaces are ignored
+	other := finished.DeepCopy()
+	other.Name = "run-3"
+	other.Namespace = "default"
+	informer.Add(other)
+	if _, err := store.Get(ctx, "run-3"); err != ErrNotFound {
+		t.Errorf("Expected a run of another namespace not to be recorded, got %v", err)
+	}
+}
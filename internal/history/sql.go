@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// historyTable holds one row per run: the columns queries filter on, and the run as JSON
+const historyTable = "krkn_run_history"
+
+// schemaStatements create the history table of the SQL backends. Times are stored as
+// Unix seconds, which SQLite and PostgreSQL compare alike.
+var schemaStatements = []string{
+	`CREATE TABLE IF NOT EXISTS ` + historyTable + ` (
+		name VARCHAR(253) PRIMARY KEY,
+		scenario_name VARCHAR(253) NOT NULL,
+		owner_user_id VARCHAR(320) NOT NULL,
+		phase VARCHAR(32) NOT NULL,
+		created_at BIGINT NOT NULL,
+		record TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS ` + historyTable + `_created_at ON ` + historyTable + ` (created_at)`,
+}
+
+// SQLStore keeps the history in a SQLite or PostgreSQL database
+type SQLStore struct {
+	db      *sql.DB
+	backend string
+}
+
+// OpenSQLStore connects to the database of backend at dsn and creates the history table
+// when missing
+func OpenSQLStore(ctx context.Context, backend, dsn string) (*SQLStore, error) {
+	db, err := sql.Open(backend, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s history database: %w", backend, err)
+	}
+	store := &SQLStore{db: db, backend: backend}
+	for _, statement := range schemaStatements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to create %s history table: %w", backend, err)
+		}
+	}
+	return store, nil
+}
+
+// placeholder returns the n-th (1-based) bind parameter of the backend
+func (s *SQLStore) placeholder(n int) string {
+	if s.backend == BackendPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// upsertStatement inserts a run or replaces the row of the run of the same name
+func (s *SQLStore) upsertStatement() string {
+	placeholders := make([]string, 6)
+	for i := range placeholders {
+		placeholders[i] = s.placeholder(i + 1)
+	}
+	return `INSERT INTO ` + historyTable + ` (name, scenario_name, owner_user_id, phase, created_at, record)
+		VALUES (` + strings.Join(placeholders, ", ") + `)
+		ON CONFLICT (name) DO UPDATE SET
+			scenario_name = excluded.scenario_name,
+			owner_user_id = excluded.owner_user_id,
+			phase = excluded.phase,
+			created_at = excluded.created_at,
+			record = excluded.record`
+}
+
+// listStatement selects the records of the runs of query, newest first
+func (s *SQLStore) listStatement(query Query) (string, []any) {
+	var conditions []string
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, condition+" "+s.placeholder(len(args)))
+	}
+	if query.ScenarioName != "" {
+		where("scenario_name =", query.ScenarioName)
+	}
+	if query.OwnerUserID != "" {
+		where("owner_user_id =", query.OwnerUserID)
+	}
+	if query.Phase != "" {
+		where("phase =", query.Phase)
+	}
+	if query.Since != nil {
+		where("created_at >=", query.Since.Unix())
+	}
+	if query.Until != nil {
+		where("created_at <", query.Until.Unix())
+	}
+
+	statement := "SELECT record FROM " + historyTable
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.limit())
+	statement += " ORDER BY created_at DESC, name ASC LIMIT " + s.placeholder(len(args))
+	return statement, args
+}
+
+// Record implements Store
+func (s *SQLStore) Record(ctx context.Context, run Run) error {
+	record, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, s.upsertStatement(),
+		run.Name, run.ScenarioName, run.OwnerUserID, run.Phase, run.CreatedAt.Unix(), string(record)); err != nil {
+		return fmt.Errorf("failed to record run %s: %w", run.Name, err)
+	}
+	return nil
+}
+
+// Get implements Store
+func (s *SQLStore) Get(ctx context.Context, name string) (*Run, error) {
+	var record string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT record FROM "+historyTable+" WHERE name = "+s.placeholder(1), name).Scan(&record)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run %s: %w", name, err)
+	}
+	var run Run
+	if err := json.Unmarshal([]byte(record), &run); err != nil {
+		return nil, fmt.Errorf("failed to decode run %s: %w", name, err)
+	}
+	return &run, nil
+}
+
+// List implements Store
+func (s *SQLStore) List(ctx context.Context, query Query) ([]Run, error) {
+	statement, args := s.listStatement(query)
+	rows, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query run history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	runs := make([]Run, 0)
+	for rows.Next() {
+		var record string
+		if err := rows.Scan(&record); err != nil {
+			return nil, fmt.Errorf("failed to query run history: %w", err)
+		}
+		var run Run
+		if err := json.Unmarshal([]byte(record), &run); err != nil {
+			return nil, fmt.Errorf("failed to decode run history: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query run history: %w", err)
+	}
+	return runs, nil
+}
+
+// Close implements Store
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSQLStoreListStatement(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	query := Query{ScenarioName: "pod-scenarios", Phase: "Failed", Since: &since, Limit: 10}
+
+	tests := []struct {
+		backend   string
+		statement string
+	}{
+		{
+			backend: BackendPostgres,
+			statement: "SELECT record FROM krkn_run_history WHERE scenario_name = $1 AND phase = $2 AND created_at >= $3" +
+				" ORDER BY created_at DESC, name ASC LIMIT $4",
+		},
+		{
+			backend: BackendSQLite,
+			statement: "SELECT record FROM krkn_run_history WHERE scenario_name = ? AND phase = ? AND created_at >= ?" +
+				" ORDER BY created_at DESC, name ASC LIMIT ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			store := &SQLStore{backend: tt.backend}
+			statement, args := store.listStatement(query)
+			if statement != tt.statement {
+				t.Errorf("Expected statement\n%s\ngot\n%s", tt.statement, statement)
+			}
+			if want := []any{"pod-scenarios", "Failed", since.Unix(), 10}; !reflect.DeepEqual(args, want) {
+				t.Errorf("Expected args %v, got %v", want, args)
+			}
+		})
+	}
+
+	// Without filters only the default limit is bound
+	statement, args := (&SQLStore{backend: BackendSQLite}).listStatement(Query{})
+	if statement != "SELECT record FROM krkn_run_history ORDER BY created_at DESC, name ASC LIMIT ?" {
+		t.Errorf("Unexpected statement %s", statement)
+	}
+	if !reflect.DeepEqual(args, []any{DefaultQueryLimit}) {
+		t.Errorf("Expected the default limit, got %v", args)
+	}
+}
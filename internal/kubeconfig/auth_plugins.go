@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"slices"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// AuthPlugins returns the plugins the current user of a base64-encoded kubeconfig
+// authenticates with, sorted: the base name of the command of an exec credential plugin
+// (aws-iam-authenticator, gke-gcloud-auth-plugin, kubelogin...) and the name of an auth
+// provider. A scenario container has to ship them to use the kubeconfig. Kubeconfigs
+// with static credentials need none.
+func AuthPlugins(kubeconfigBase64 string) ([]string, error) {
+	raw, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig format: %w", err)
+	}
+	context, exists := config.Contexts[config.CurrentContext]
+	if !exists {
+		return nil, fmt.Errorf("current context '%s' does not exist", config.CurrentContext)
+	}
+	user, exists := config.AuthInfos[context.AuthInfo]
+	if !exists {
+		return nil, nil
+	}
+
+	var plugins []string
+	if user.Exec != nil && user.Exec.Command != "" {
+		plugins = append(plugins, path.Base(user.Exec.Command))
+	}
+	if user.AuthProvider != nil && user.AuthProvider.Name != "" {
+		plugins = append(plugins, user.AuthProvider.Name)
+	}
+	slices.Sort(plugins)
+	return slices.Compact(plugins), nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+)
+
+func TestAuthPlugins(t *testing.T) {
+	kubeconfigWithUser := func(user string) string {
+		return base64.StdEncoding.EncodeToString([]byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://api.example.com:6443
+contexts:
+- name: context
+  context:
+    cluster: cluster
+    user: user
+current-context: context
+users:
+- name: user
+  user:
+` + user))
+	}
+
+	tests := []struct {
+		name     string
+		user     string
+		expected []string
+	}{
+		{name: "token", user: "    token: abc\n"},
+		{
+			name: "exec plugin",
+			user: "    exec:\n      apiVersion: client.authentication.k8s.io/v1beta1\n" +
+				"      command: /usr/local/bin/aws-iam-authenticator\n      args: [token, -i, cluster]\n",
+			expected: []string{"aws-iam-authenticator"},
+		},
+		{
+			name:     "auth provider",
+			user:     "    auth-provider:\n      name: oidc\n",
+			expected: []string{"oidc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugins, err := AuthPlugins(kubeconfigWithUser(tt.user))
+			if err != nil {
+				t.Fatalf("AuthPlugins failed: %v", err)
+			}
+			if !slices.Equal(plugins, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, plugins)
+			}
+		})
+	}
+
+	if _, err := AuthPlugins("not base64!"); err == nil {
+		t.Error("expected an error for an invalid encoding")
+	}
+}
@@ -24,6 +24,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -31,64 +36,53 @@ import (
 
 // GenerateFromToken creates a kubeconfig with token authentication
 // Returns base64-encoded kubeconfig string
-func GenerateFromToken(clusterName, apiURL, caBundle, token string, insecureSkipTLS bool) (string, error) {
-	config := clientcmdapi.NewConfig()
-
-	// Add cluster
-	cluster := clientcmdapi.NewCluster()
-	cluster.Server = apiURL
-	cluster.InsecureSkipTLSVerify = insecureSkipTLS
-
-	if caBundle != "" && !insecureSkipTLS {
-		cluster.CertificateAuthorityData = []byte(caBundle)
-	}
-
-	config.Clusters[clusterName] = cluster
-
+func GenerateFromToken(clusterName, apiURL, caBundle, tlsServerName, token string, insecureSkipTLS bool) (string, error) {
 	// Add user with token
 	authInfo := clientcmdapi.NewAuthInfo()
 	authInfo.Token = token
-	config.AuthInfos[clusterName+"-user"] = authInfo
 
-	// Add context
-	context := clientcmdapi.NewContext()
-	context.Cluster = clusterName
-	context.AuthInfo = clusterName + "-user"
-	config.Contexts[clusterName+"-context"] = context
+	return generate(clusterName, apiURL, caBundle, tlsServerName, insecureSkipTLS, authInfo)
+}
 
-	// Set current context
-	config.CurrentContext = clusterName + "-context"
+// GenerateFromCredentials creates a kubeconfig with basic auth (username/password)
+// Returns base64-encoded kubeconfig string
+func GenerateFromCredentials(clusterName, apiURL, caBundle, tlsServerName, username, password string, insecureSkipTLS bool) (string, error) {
+	// Add user with credentials
+	authInfo := clientcmdapi.NewAuthInfo()
+	authInfo.Username = username
+	authInfo.Password = password
 
-	// Convert to YAML bytes
-	kubeconfigBytes, err := clientcmd.Write(*config)
+	return generate(clusterName, apiURL, caBundle, tlsServerName, insecureSkipTLS, authInfo)
+}
+
+// generate creates a single-context kubeconfig for the cluster at apiURL, written in its
+// normalized form so that IPv6 addresses are bracketed. tlsServerName, when set, is sent
+// as SNI and verified against the server certificate in place of the URL host: clusters
+// addressed by IP often serve certificates valid for their DNS names only.
+func generate(clusterName, apiURL, caBundle, tlsServerName string, insecureSkipTLS bool, authInfo *clientcmdapi.AuthInfo) (string, error) {
+	server, err := NormalizeAPIURL(apiURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal kubeconfig: %w", err)
+		return "", err
+	}
+	if err := ValidateTLSServerName(tlsServerName); err != nil {
+		return "", err
 	}
 
-	// Return base64-encoded string
-	return base64.StdEncoding.EncodeToString(kubeconfigBytes), nil
-}
-
-// GenerateFromCredentials creates a kubeconfig with basic auth (username/password)
-// Returns base64-encoded kubeconfig string
-func GenerateFromCredentials(clusterName, apiURL, caBundle, username, password string, insecureSkipTLS bool) (string, error) {
 	config := clientcmdapi.NewConfig()
 
 	// Add cluster
 	cluster := clientcmdapi.NewCluster()
-	cluster.Server = apiURL
+	cluster.Server = server
 	cluster.InsecureSkipTLSVerify = insecureSkipTLS
 
 	if caBundle != "" && !insecureSkipTLS {
 		cluster.CertificateAuthorityData = []byte(caBundle)
 	}
+	if !insecureSkipTLS {
+		cluster.TLSServerName = tlsServerName
+	}
 
 	config.Clusters[clusterName] = cluster
-
-	// Add user with credentials
-	authInfo := clientcmdapi.NewAuthInfo()
-	authInfo.Username = username
-	authInfo.Password = password
 	config.AuthInfos[clusterName+"-user"] = authInfo
 
 	// Add context
@@ -110,6 +104,21 @@ func GenerateFromCredentials(clusterName, apiURL, caBundle, username, password s
 	return base64.StdEncoding.EncodeToString(kubeconfigBytes), nil
 }
 
+// ValidateTLSServerName checks that a TLS server name, when set, is a DNS name. IP
+// addresses are verified without it, and a port or scheme has no place in SNI.
+func ValidateTLSServerName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := netip.ParseAddr(strings.Trim(name, "[]")); err == nil {
+		return fmt.Errorf("invalid TLS server name %q: must be a DNS name, not an IP address", name)
+	}
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(name)); len(errs) > 0 {
+		return fmt.Errorf("invalid TLS server name %q: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
 // ExtractAPIURL extracts the server URL from a base64-encoded kubeconfig
 func ExtractAPIURL(kubeconfigBase64 string) (string, error) {
 	// Decode base64
@@ -179,10 +188,24 @@ func Validate(kubeconfigBase64 string) error {
 	}
 
 	// Validate current context exists
-	if _, exists := config.Contexts[config.CurrentContext]; !exists {
+	context, exists := config.Contexts[config.CurrentContext]
+	if !exists {
 		return fmt.Errorf("current context '%s' does not exist", config.CurrentContext)
 	}
 
+	// Clients use the server as written: an IPv6 address without brackets is refused or,
+	// when its last group looks like a port, dialed at the wrong address
+	if cluster, exists := config.Clusters[context.Cluster]; exists && cluster.Server != "" {
+		u, err := url.Parse(cluster.Server)
+		if err == nil && !strings.HasPrefix(u.Host, "[") && strings.Count(u.Host, ":") > 1 {
+			err = fmt.Errorf("IPv6 address without brackets")
+		}
+		if err != nil {
+			return fmt.Errorf("invalid server URL %q, IPv6 addresses need brackets (https://[fd00::1]:6443): %w",
+				cluster.Server, err)
+		}
+	}
+
 	return nil
 }
 
@@ -31,9 +31,12 @@ func TestGenerateFromToken(t *testing.T) {
 		clusterName     string
 		apiURL          string
 		caBundle        string
+		tlsServerName   string
 		token           string
 		insecureSkipTLS bool
 		wantErr         bool
+		// wantServer is the server written to the kubeconfig, apiURL when empty
+		wantServer string
 	}{
 		{
 			name:            "valid token kubeconfig",
@@ -53,11 +56,47 @@ func TestGenerateFromToken(t *testing.T) {
 			insecureSkipTLS: false,
 			wantErr:         false,
 		},
+		{
+			name:            "IPv6 address with custom port and TLS server name",
+			clusterName:     "ipv6-cluster",
+			apiURL:          "https://[2001:DB8:0::10]:6443/",
+			caBundle:        "LS0tLS1CRUdJTi...",
+			tlsServerName:   "api.ipv6.example.com",
+			token:           "ipv6-token",
+			insecureSkipTLS: false,
+			wantServer:      "https://[2001:db8::10]:6443",
+		},
+		{
+			name:            "IPv6 address without brackets",
+			clusterName:     "ipv6-cluster",
+			apiURL:          "fd00::1",
+			token:           "ipv6-token",
+			insecureSkipTLS: true,
+			wantServer:      "https://[fd00::1]",
+		},
+		{
+			name:            "IP address as TLS server name",
+			clusterName:     "ipv6-cluster",
+			apiURL:          "https://[fd00::1]:6443",
+			caBundle:        "LS0tLS1CRUdJTi...",
+			tlsServerName:   "fd00::1",
+			token:           "ipv6-token",
+			insecureSkipTLS: false,
+			wantErr:         true,
+		},
+		{
+			name:            "invalid port",
+			clusterName:     "test-cluster",
+			apiURL:          "https://[fd00::1]:70000",
+			token:           "test-token-123",
+			insecureSkipTLS: true,
+			wantErr:         true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			kubeconfigBase64, err := GenerateFromToken(tt.clusterName, tt.apiURL, tt.caBundle, tt.token, tt.insecureSkipTLS)
+			kubeconfigBase64, err := GenerateFromToken(tt.clusterName, tt.apiURL, tt.caBundle, tt.tlsServerName, tt.token, tt.insecureSkipTLS)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GenerateFromToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -88,8 +127,15 @@ func TestGenerateFromToken(t *testing.T) {
 				t.Errorf("Cluster '%s' not found in kubeconfig", tt.clusterName)
 			}
 
-			if config.Clusters[tt.clusterName].Server != tt.apiURL {
-				t.Errorf("Expected API URL %s, got %s", tt.apiURL, config.Clusters[tt.clusterName].Server)
+			wantServer := tt.wantServer
+			if wantServer == "" {
+				wantServer = tt.apiURL
+			}
+			if config.Clusters[tt.clusterName].Server != wantServer {
+				t.Errorf("Expected API URL %s, got %s", wantServer, config.Clusters[tt.clusterName].Server)
+			}
+			if config.Clusters[tt.clusterName].TLSServerName != tt.tlsServerName {
+				t.Errorf("Expected TLS server name %q, got %q", tt.tlsServerName, config.Clusters[tt.clusterName].TLSServerName)
 			}
 
 			// Verify user
@@ -130,7 +176,7 @@ func TestGenerateFromCredentials(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			kubeconfigBase64, err := GenerateFromCredentials(tt.clusterName, tt.apiURL, tt.caBundle, tt.username, tt.password, tt.insecureSkipTLS)
+			kubeconfigBase64, err := GenerateFromCredentials(tt.clusterName, tt.apiURL, tt.caBundle, "", tt.username, tt.password, tt.insecureSkipTLS)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GenerateFromCredentials() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -171,7 +217,7 @@ func TestGenerateFromCredentials(t *testing.T) {
 
 func TestExtractAPIURL(t *testing.T) {
 	// Generate a test kubeconfig
-	kubeconfigBase64, err := GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "test-token", true)
+	kubeconfigBase64, err := GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "test-token", true)
 	if err != nil {
 		t.Fatalf("Failed to generate test kubeconfig: %v", err)
 	}
@@ -219,7 +265,7 @@ func TestExtractAPIURL(t *testing.T) {
 
 func TestValidate(t *testing.T) {
 	// Generate a valid kubeconfig
-	validKubeconfig, err := GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "test-token", true)
+	validKubeconfig, err := GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "test-token", true)
 	if err != nil {
 		t.Fatalf("Failed to generate test kubeconfig: %v", err)
 	}
@@ -244,6 +290,27 @@ func TestValidate(t *testing.T) {
 			kubeconfigBase64: "",
 			wantErr:          true,
 		},
+		{
+			name: "IPv6 server without brackets",
+			kubeconfigBase64: base64.StdEncoding.EncodeToString([]byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster:
+    server: https://fd00::1:6443
+users:
+- name: u
+  user:
+    token: t
+contexts:
+- name: ctx
+  context:
+    cluster: c
+    user: u
+current-context: ctx
+`)),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
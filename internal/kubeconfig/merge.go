@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Merge loads every kubeconfig in kubeconfigs, each possibly a multi-document YAML
+// stream, and merges them into one config. As with KUBECONFIG file lists, the first
+// definition of a cluster, user or context wins, as does the first current context set.
+func Merge(kubeconfigs ...[]byte) (*clientcmdapi.Config, error) {
+	merged := clientcmdapi.NewConfig()
+
+	for i, data := range kubeconfigs {
+		documents, err := splitDocuments(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split kubeconfig %d: %w", i, err)
+		}
+
+		for j, document := range documents {
+			config, err := clientcmd.Load(document)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load kubeconfig %d document %d: %w", i, j, err)
+			}
+
+			for name, cluster := range config.Clusters {
+				if _, exists := merged.Clusters[name]; !exists {
+					merged.Clusters[name] = cluster
+				}
+			}
+			for name, authInfo := range config.AuthInfos {
+				if _, exists := merged.AuthInfos[name]; !exists {
+					merged.AuthInfos[name] = authInfo
+				}
+			}
+			for name, context := range config.Contexts {
+				if _, exists := merged.Contexts[name]; !exists {
+					merged.Contexts[name] = context
+				}
+			}
+			if merged.CurrentContext == "" {
+				merged.CurrentContext = config.CurrentContext
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// SelectContext makes contextName the current context of config.
+// An empty contextName keeps the current context, which must then be set.
+func SelectContext(config *clientcmdapi.Config, contextName string) error {
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+	if contextName == "" {
+		if len(config.Contexts) != 1 {
+			return fmt.Errorf("kubeconfig has %d contexts and no current context, a context must be selected", len(config.Contexts))
+		}
+		for name := range config.Contexts {
+			contextName = name
+		}
+	}
+
+	if _, exists := config.Contexts[contextName]; !exists {
+		return fmt.Errorf("context '%s' not found in kubeconfig", contextName)
+	}
+	config.CurrentContext = contextName
+	return nil
+}
+
+// Minify drops every context, cluster and user except those of the current context
+func Minify(config *clientcmdapi.Config) error {
+	if err := clientcmdapi.MinifyConfig(config); err != nil {
+		return fmt.Errorf("failed to minify kubeconfig: %w", err)
+	}
+	return nil
+}
+
+// StripUnusedAuth drops the users no context references, so no credential of
+// another cluster is stored alongside the ones in use
+func StripUnusedAuth(config *clientcmdapi.Config) {
+	used := make(map[string]bool, len(config.Contexts))
+	for _, context := range config.Contexts {
+		used[context.AuthInfo] = true
+	}
+	for name := range config.AuthInfos {
+		if !used[name] {
+			delete(config.AuthInfos, name)
+		}
+	}
+}
+
+// ForContext reduces a base64-encoded, possibly multi-document, kubeconfig to the
+// cluster and credentials of contextName (the current context when empty).
+// Returns the base64-encoded single-context kubeconfig.
+func ForContext(kubeconfigBase64, contextName string) (string, error) {
+	kubeconfigBytes, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+
+	config, err := Merge(kubeconfigBytes)
+	if err != nil {
+		return "", err
+	}
+	if err := SelectContext(config, contextName); err != nil {
+		return "", err
+	}
+	if err := Minify(config); err != nil {
+		return "", err
+	}
+	StripUnusedAuth(config)
+
+	minified, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(minified), nil
+}
+
+// ContextNames returns the sorted context names of a base64-encoded, possibly
+// multi-document, kubeconfig
+func ContextNames(kubeconfigBase64 string) ([]string, error) {
+	kubeconfigBytes, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+
+	config, err := Merge(kubeconfigBytes)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// splitDocuments splits a YAML stream into its non-empty documents
+func splitDocuments(data []byte) ([][]byte, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var documents [][]byte
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(document)) > 0 {
+			documents = append(documents, document)
+		}
+	}
+}
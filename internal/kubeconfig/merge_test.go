@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const multiDocumentKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://api.dev.example.com:6443
+users:
+- name: dev-admin
+  user:
+    token: dev-token
+- name: orphan
+  user:
+    token: orphan-token
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev-admin
+---
+apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: prod
+  cluster:
+    server: https://api.prod.example.com:6443
+- name: dev
+  cluster:
+    server: https://shadowed.example.com:6443
+users:
+- name: prod-admin
+  user:
+    token: prod-token
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod-admin
+`
+
+func TestMerge(t *testing.T) {
+	config, err := Merge([]byte(multiDocumentKubeconfig))
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if len(config.Contexts) != 2 || len(config.Clusters) != 2 || len(config.AuthInfos) != 3 {
+		t.Errorf("expected the entries of both documents, got %d contexts, %d clusters, %d users",
+			len(config.Contexts), len(config.Clusters), len(config.AuthInfos))
+	}
+	if config.CurrentContext != "dev" {
+		t.Errorf("expected the first current context to win, got %q", config.CurrentContext)
+	}
+	if server := config.Clusters["dev"].Server; server != "https://api.dev.example.com:6443" {
+		t.Errorf("expected the first definition of a cluster to win, got %q", server)
+	}
+
+	if _, err := Merge([]byte("clusters: [")); err == nil {
+		t.Error("expected an error for malformed YAML")
+	}
+}
+
+func TestSelectContext(t *testing.T) {
+	config, err := Merge([]byte(multiDocumentKubeconfig))
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if err := SelectContext(config, "prod"); err != nil || config.CurrentContext != "prod" {
+		t.Errorf("SelectContext(prod) = %v, current context %q", err, config.CurrentContext)
+	}
+	if err := SelectContext(config, "missing"); err == nil {
+		t.Error("expected an error for an unknown context")
+	}
+
+	config.CurrentContext = ""
+	if err := SelectContext(config, ""); err == nil {
+		t.Error("expected an error when several contexts exist and none is current")
+	}
+}
+
+func TestStripUnusedAuth(t *testing.T) {
+	config, err := Merge([]byte(multiDocumentKubeconfig))
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	StripUnusedAuth(config)
+	if _, exists := config.AuthInfos["orphan"]; exists {
+		t.Error("expected the unreferenced user to be removed")
+	}
+	if len(config.AuthInfos) != 2 {
+		t.Errorf("expected the referenced users to be kept, got %d", len(config.AuthInfos))
+	}
+}
+
+func TestForContext(t *testing.T) {
+	kubeconfigBase64 := base64.StdEncoding.EncodeToString([]byte(multiDocumentKubeconfig))
+
+	minified, err := ForContext(kubeconfigBase64, "prod")
+	if err != nil {
+		t.Fatalf("ForContext() error = %v", err)
+	}
+	if err := Validate(minified); err != nil {
+		t.Fatalf("minified kubeconfig is invalid: %v", err)
+	}
+	apiURL, err := ExtractAPIURL(minified)
+	if err != nil || apiURL != "https://api.prod.example.com:6443" {
+		t.Errorf("ExtractAPIURL() = %q, %v", apiURL, err)
+	}
+
+	kubeconfigBytes, _ := base64.StdEncoding.DecodeString(minified)
+	config, err := clientcmd.Load(kubeconfigBytes)
+	if err != nil {
+		t.Fatalf("failed to load minified kubeconfig: %v", err)
+	}
+	assertOnlyEntry(t, "context", config.Contexts, "prod")
+	assertOnlyEntry(t, "cluster", config.Clusters, "prod")
+	assertOnlyEntry(t, "user", config.AuthInfos, "prod-admin")
+
+	// The current context is used when none is selected
+	minified, err = ForContext(kubeconfigBase64, "")
+	if err != nil {
+		t.Fatalf("ForContext() error = %v", err)
+	}
+	if apiURL, _ := ExtractAPIURL(minified); apiURL != "https://api.dev.example.com:6443" {
+		t.Errorf("expected the current context to be kept, got %q", apiURL)
+	}
+
+	if _, err := ForContext(kubeconfigBase64, "staging"); err == nil {
+		t.Error("expected an error for an unknown context")
+	}
+}
+
+func TestContextNames(t *testing.T) {
+	names, err := ContextNames(base64.StdEncoding.EncodeToString([]byte(multiDocumentKubeconfig)))
+	if err != nil {
+		t.Fatalf("ContextNames() error = %v", err)
+	}
+	if !slices.Equal(names, []string{"dev", "prod"}) {
+		t.Errorf("ContextNames() = %v, want [dev prod]", names)
+	}
+
+	if _, err := ContextNames("not-base64!"); err == nil {
+		t.Error("expected an error for invalid base64")
+	}
+}
+
+func assertOnlyEntry[T *clientcmdapi.Context | *clientcmdapi.Cluster | *clientcmdapi.AuthInfo](
+	t *testing.T, kind string, entries map[string]T, name string,
+) {
+	t.Helper()
+	if _, exists := entries[name]; !exists || len(entries) != 1 {
+		t.Errorf("expected only %s %q, got %d entries", kind, name, len(entries))
+	}
+}
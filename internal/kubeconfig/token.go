@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// tokenRequestTimeout bounds the TokenRequest call minting a ServiceAccount token
+const tokenRequestTimeout = 10 * time.Second
+
+// MintServiceAccountToken requests a token of the ServiceAccount namespace/name valid for
+// expirationSeconds from the cluster of a base64 kubeconfig, authenticating with its
+// current credentials. Returns the token and when it expires, which the API server may
+// set earlier than requested.
+func MintServiceAccountToken(ctx context.Context, kubeconfigBase64, namespace, name string, expirationSeconds int64) (string, time.Time, error) {
+	raw, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(raw)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	config.Timeout = tokenRequestTimeout
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+	}
+	response, err := clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, request, metav1.CreateOptions{})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create a token for ServiceAccount %s/%s: %w", namespace, name, err)
+	}
+	if response.Status.Token == "" {
+		return "", time.Time{}, fmt.Errorf("no token returned for ServiceAccount %s/%s", namespace, name)
+	}
+	return response.Status.Token, response.Status.ExpirationTimestamp.Time, nil
+}
+
+// ReplaceToken sets the bearer token of the user of the current context of a base64
+// kubeconfig, dropping its token file. Returns the base64-encoded kubeconfig.
+func ReplaceToken(kubeconfigBase64, token string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 encoding: %w", err)
+	}
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	context, exists := config.Contexts[config.CurrentContext]
+	if !exists {
+		return "", fmt.Errorf("current context '%s' not found in kubeconfig", config.CurrentContext)
+	}
+	authInfo, exists := config.AuthInfos[context.AuthInfo]
+	if !exists {
+		return "", fmt.Errorf("user '%s' not found in kubeconfig", context.AuthInfo)
+	}
+	authInfo.Token = token
+	authInfo.TokenFile = ""
+
+	updated, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(updated), nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestReplaceToken(t *testing.T) {
+	original, err := GenerateFromToken("test-cluster", "https://api.test.com:6443", "", "", "old-token", true)
+	if err != nil {
+		t.Fatalf("GenerateFromToken failed: %v", err)
+	}
+
+	replaced, err := ReplaceToken(original, "new-token")
+	if err != nil {
+		t.Fatalf("ReplaceToken failed: %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(replaced)
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if token := config.AuthInfos["test-cluster-user"].Token; token != "new-token" {
+		t.Errorf("Expected the new token, got %q", token)
+	}
+	if server := config.Clusters["test-cluster"].Server; server != "https://api.test.com:6443" {
+		t.Errorf("Expected the cluster to be kept, got %q", server)
+	}
+
+	if _, err := ReplaceToken("not base64!", "token"); err == nil {
+		t.Error("Expected an error for invalid base64")
+	}
+}
+
+func TestMintServiceAccountToken(t *testing.T) {
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/namespaces/krkn/serviceaccounts/runner/token" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer old-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// Client-go may send protobuf, the universal deserializer reads both
+		body, _ := io.ReadAll(r.Body)
+		request := &authenticationv1.TokenRequest{}
+		if _, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, request); err != nil {
+			t.Errorf("Failed to decode TokenRequest: %v", err)
+		}
+		if request.Spec.ExpirationSeconds == nil || *request.Spec.ExpirationSeconds != 3600 {
+			t.Errorf("Expected an expiration of 3600s, got %v", request.Spec.ExpirationSeconds)
+		}
+		request.Status = authenticationv1.TokenRequestStatus{
+			Token:               "new-token",
+			ExpirationTimestamp: metav1.NewTime(expires),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(request)
+	}))
+	defer server.Close()
+
+	kubeconfigBase64, err := GenerateFromToken("test-cluster", server.URL, "", "", "old-token", true)
+	if err != nil {
+		t.Fatalf("GenerateFromToken failed: %v", err)
+	}
+	token, expiresAt, err := MintServiceAccountToken(context.Background(), kubeconfigBase64, "krkn", "runner", 3600)
+	if err != nil {
+		t.Fatalf("MintServiceAccountToken failed: %v", err)
+	}
+	if token != "new-token" || !expiresAt.Equal(expires) {
+		t.Errorf("Expected new-token expiring at %v, got %q at %v", expires, token, expiresAt)
+	}
+
+	if _, _, err := MintServiceAccountToken(context.Background(), kubeconfigBase64, "krkn", "missing", 3600); err == nil {
+		t.Error("Expected an error for a missing ServiceAccount")
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultPorts are the ports dropped from an API URL because the scheme implies them
+var defaultPorts = map[string]string{
+	"https": "443",
+	"http":  "80",
+}
+
+// NormalizeAPIURL returns the canonical form of a cluster API URL, so that two spellings
+// of the same endpoint compare equal:
+//   - a URL without scheme gets https, as kubectl assumes
+//   - scheme and host are lowercased, IP addresses take their canonical form
+//     (https://[2001:DB8:0::1] -> https://[2001:db8::1])
+//   - an IPv6 address without brackets gets them (fd00::1 -> https://[fd00::1]); such an
+//     address cannot carry a port, which needs the bracketed form ([fd00::1]:6443)
+//   - the default port of the scheme is dropped (https://api.x:443 -> https://api.x)
+//   - trailing slashes are trimmed from the path
+//
+// Only http and https URLs with a host and a port between 1 and 65535 are accepted. Query
+// strings, fragments and user info have no meaning for an API server URL and are rejected.
+func NormalizeAPIURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("API URL is empty")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	raw = bracketIPv6(raw)
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL: %w", err)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if _, ok := defaultPorts[scheme]; !ok {
+		return "", fmt.Errorf("invalid API URL %q: scheme must be http or https", raw)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid API URL %q: missing host", raw)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid API URL %q: user info, query and fragment are not allowed", raw)
+	}
+
+	host, err := canonicalHost(u.Hostname())
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL %q: %w", raw, err)
+	}
+	port := u.Port()
+	if number, err := strconv.Atoi(port); port != "" && (err != nil || number < 1 || number > 65535) {
+		return "", fmt.Errorf("invalid API URL %q: port must be between 1 and 65535", raw)
+	}
+	switch {
+	case port != "" && port != defaultPorts[scheme]:
+		host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		// IPv6 literals keep their brackets without a port
+		host = "[" + host + "]"
+	}
+
+	return (&url.URL{
+		Scheme: scheme,
+		Host:   host,
+		Path:   strings.TrimRight(u.Path, "/"),
+	}).String(), nil
+}
+
+// bracketIPv6 encloses in brackets an IPv6 address written without them as the host of
+// rawURL. Other URLs are returned unchanged.
+func bracketIPv6(rawURL string) string {
+	scheme, rest, _ := strings.Cut(rawURL, "://")
+	authority, path, hasPath := strings.Cut(rest, "/")
+	if strings.Count(authority, ":") < 2 || strings.ContainsAny(authority, "[]@") {
+		return rawURL
+	}
+	if _, err := netip.ParseAddr(authority); err != nil {
+		return rawURL
+	}
+	// A zone is percent-encoded in URLs (fe80::1%25eth0)
+	authority = "[" + strings.Replace(authority, "%", "%25", 1) + "]"
+	if hasPath {
+		authority += "/" + path
+	}
+	return scheme + "://" + authority
+}
+
+// canonicalHost lowercases a host name and returns IP addresses in their canonical form.
+// The zone of a link-local IPv6 address is kept as written.
+func canonicalHost(host string) (string, error) {
+	if !strings.Contains(host, ":") {
+		if addr, err := netip.ParseAddr(host); err == nil {
+			return addr.String(), nil
+		}
+		return strings.ToLower(host), nil
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !addr.Is6() {
+		return "", fmt.Errorf("invalid IPv6 address %q", host)
+	}
+	return addr.String(), nil
+}
+
+// APIHostPort returns the host and port an API URL connects to, the default port of its
+// scheme included, in the form net.Dial expects ([fd00::1]:6443 for IPv6 addresses).
+func APIHostPort(apiURL string) (string, error) {
+	normalized, err := NormalizeAPIURL(apiURL)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(normalized)
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL: %w", err)
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPorts[u.Scheme]
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// IsIPAddressHost reports whether an API URL addresses its server by IP. TLS clients send
+// no server name (SNI) for such URLs and verify the certificate against its IP addresses,
+// unless the kubeconfig sets tls-server-name.
+func IsIPAddressHost(apiURL string) bool {
+	normalized, err := NormalizeAPIURL(apiURL)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(normalized)
+	if err != nil {
+		return false
+	}
+	_, err = netip.ParseAddr(u.Hostname())
+	return err == nil
+}
+
+// SameAPIURL reports whether two cluster API URLs point at the same endpoint.
+// URLs that cannot be normalized only match when they are identical.
+func SameAPIURL(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	normalizedA, errA := NormalizeAPIURL(a)
+	normalizedB, errB := NormalizeAPIURL(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return normalizedA == normalizedB
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statemachine encodes the allowed phase transitions of scenario run jobs.
+//
+// The controller validates every phase change against Jobs, and the REST API
+// publishes the same machine (with a Mermaid diagram) so the UI stays aligned.
+package statemachine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Cluster job phases, as stored in ClusterJobStatus.Phase
+const (
+	JobPending            = "Pending"
+	JobRunning            = "Running"
+	JobSucceeded          = "Succeeded"
+	JobFailed             = "Failed"
+	JobRetrying           = "Retrying"
+	JobCancelled          = "Cancelled"
+	JobMaxRetriesExceeded = "MaxRetriesExceeded"
+	JobSkippedMaintenance = "SkippedMaintenance"
+)
+
+// Transition is an allowed change from one phase to another
+type Transition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Trigger describes what causes the transition
+	Trigger string `json:"trigger"`
+}
+
+// Machine is a finite state machine over phase names.
+// Staying in the same phase is always allowed.
+type Machine struct {
+	name        string
+	initial     string
+	states      []string
+	transitions []Transition
+}
+
+// TransitionError is returned for a transition the machine does not allow
+type TransitionError struct {
+	Machine string
+	From    string
+	To      string
+}
+
+func (e *TransitionError) Error() string {
+	from := e.From
+	if from == "" {
+		from = "<none>"
+	}
+	return fmt.Sprintf("illegal %s phase transition %s -> %s", e.Machine, from, e.To)
+}
+
+// New builds a machine. The initial phase is the only one reachable from the empty phase.
+// It panics on transitions referencing unknown states, since machines are defined statically.
+func New(name string, initial string, states []string, transitions []Transition) *Machine {
+	for _, t := range transitions {
+		if !slices.Contains(states, t.From) || !slices.Contains(states, t.To) {
+			panic(fmt.Sprintf("statemachine %s: transition %s -> %s references an unknown state", name, t.From, t.To))
+		}
+	}
+	if !slices.Contains(states, initial) {
+		panic(fmt.Sprintf("statemachine %s: unknown initial state %s", name, initial))
+	}
+	return &Machine{name: name, initial: initial, states: states, transitions: transitions}
+}
+
+// Jobs is the state machine of a cluster job (ClusterJobStatus.Phase)
+var Jobs = New("job", JobPending,
+	[]string{JobPending, JobRunning, JobSucceeded, JobFailed, JobRetrying, JobCancelled, JobMaxRetriesExceeded, JobSkippedMaintenance},
+	[]Transition{
+		{From: JobPending, To: JobRunning, Trigger: "pod started"},
+		{From: JobPending, To: JobSucceeded, Trigger: "pod succeeded"},
+		{From: JobPending, To: JobFailed, Trigger: "pod failed, missing or unknown"},
+		{From: JobRunning, To: JobSucceeded, Trigger: "pod succeeded"},
+		{From: JobRunning, To: JobFailed, Trigger: "pod failed, missing or unknown"},
+		{From: JobFailed, To: JobRetrying, Trigger: "retries left and backoff elapsed"},
+		{From: JobFailed, To: JobCancelled, Trigger: "cancellation requested"},
+		{From: JobFailed, To: JobMaxRetriesExceeded, Trigger: "no retries left"},
+		{From: JobMaxRetriesExceeded, To: JobRetrying, Trigger: "retry of failed clusters requested"},
+		{From: JobRetrying, To: JobPending, Trigger: "retry pod created"},
+		{From: JobRetrying, To: JobFailed, Trigger: "retry could not be created"},
+		{From: JobPending, To: JobSkippedMaintenance, Trigger: "cluster under maintenance, policy skip"},
+		{From: JobRetrying, To: JobSkippedMaintenance, Trigger: "cluster under maintenance at retry, policy skip"},
+		{From: JobPending, To: JobCancelled, Trigger: "cancellation requested"},
+		{From: JobRunning, To: JobCancelled, Trigger: "cancellation requested"},
+		{From: JobRetrying, To: JobCancelled, Trigger: "cancellation requested"},
+	})
+
+// Name returns the machine name
+func (m *Machine) Name() string {
+	return m.name
+}
+
+// Initial returns the phase a new object starts in
+func (m *Machine) Initial() string {
+	return m.initial
+}
+
+// States returns all phases in declaration order
+func (m *Machine) States() []string {
+	return slices.Clone(m.states)
+}
+
+// Transitions returns all allowed transitions, self-transitions excluded
+func (m *Machine) Transitions() []Transition {
+	return slices.Clone(m.transitions)
+}
+
+// IsTerminal reports whether no transition leaves phase
+func (m *Machine) IsTerminal(phase string) bool {
+	for _, t := range m.transitions {
+		if t.From == phase {
+			return false
+		}
+	}
+	return slices.Contains(m.states, phase)
+}
+
+// Terminal returns the terminal phases in declaration order
+func (m *Machine) Terminal() []string {
+	var terminal []string
+	for _, s := range m.states {
+		if m.IsTerminal(s) {
+			terminal = append(terminal, s)
+		}
+	}
+	return terminal
+}
+
+// CanTransition reports whether from -> to is allowed
+func (m *Machine) CanTransition(from, to string) bool {
+	if from == "" {
+		return to == m.initial
+	}
+	if from == to {
+		return slices.Contains(m.states, to)
+	}
+	return slices.ContainsFunc(m.transitions, func(t Transition) bool {
+		return t.From == from && t.To == to
+	})
+}
+
+// Validate returns a *TransitionError if from -> to is not allowed
+func (m *Machine) Validate(from, to string) error {
+	if m.CanTransition(from, to) {
+		return nil
+	}
+	return &TransitionError{Machine: m.name, From: from, To: to}
+}
+
+// Mermaid renders the machine as a Mermaid stateDiagram-v2
+func (m *Machine) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	fmt.Fprintf(&b, "    [*] --> %s\n", m.initial)
+	for _, t := range m.transitions {
+		fmt.Fprintf(&b, "    %s --> %s: %s\n", t.From, t.To, t.Trigger)
+	}
+	for _, s := range m.Terminal() {
+		fmt.Fprintf(&b, "    %s --> [*]\n", s)
+	}
+	return b.String()
+}
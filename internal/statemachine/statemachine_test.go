@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statemachine
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJobsTransitions(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{"", JobPending, true},
+		{"", JobRunning, false},
+		{JobPending, JobPending, true},
+		{JobPending, JobRunning, true},
+		{JobPending, JobSucceeded, true},
+		{JobPending, JobFailed, true},
+		{JobRunning, JobSucceeded, true},
+		{JobRunning, JobFailed, true},
+		{JobRunning, JobPending, false},
+		{JobFailed, JobRetrying, true},
+		{JobFailed, JobCancelled, true},
+		{JobFailed, JobMaxRetriesExceeded, true},
+		{JobFailed, JobRunning, false},
+		{JobFailed, JobSucceeded, false},
+		{JobRetrying, JobPending, true},
+		{JobRetrying, JobFailed, true},
+		{JobRetrying, JobRunning, false},
+		{JobSucceeded, JobFailed, false},
+		{JobCancelled, JobRetrying, false},
+		{JobMaxRetriesExceeded, JobRetrying, true},
+		{JobMaxRetriesExceeded, JobPending, false},
+		{JobPending, JobSkippedMaintenance, true},
+		{JobRetrying, JobSkippedMaintenance, true},
+		{JobRunning, JobSkippedMaintenance, false},
+		{JobSkippedMaintenance, JobPending, false},
+		{JobPending, JobCancelled, true},
+		{JobRunning, JobCancelled, true},
+		{JobRetrying, JobCancelled, true},
+		{JobSucceeded, JobCancelled, false},
+		{JobMaxRetriesExceeded, JobCancelled, false},
+		{JobPending, "Bogus", false},
+		{"Bogus", "Bogus", false},
+	}
+
+	for _, tt := range tests {
+		if got := Jobs.CanTransition(tt.from, tt.to); got != tt.allowed {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.allowed)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Jobs.Validate(JobRunning, JobSucceeded); err != nil {
+		t.Errorf("expected legal transition, got %v", err)
+	}
+
+	err := Jobs.Validate(JobSucceeded, JobRunning)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected *TransitionError, got %v", err)
+	}
+	if transitionErr.From != JobSucceeded || transitionErr.To != JobRunning {
+		t.Errorf("unexpected error fields %+v", transitionErr)
+	}
+	if !strings.Contains(err.Error(), "Succeeded -> Running") {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	want := []string{JobSucceeded, JobCancelled, JobSkippedMaintenance}
+	if got := Jobs.Terminal(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Terminal() = %v, want %v", got, want)
+	}
+	if Jobs.IsTerminal("Bogus") {
+		t.Errorf("unknown phases must not be terminal")
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	diagram := Jobs.Mermaid()
+
+	for _, line := range []string{
+		"stateDiagram-v2",
+		"[*] --> Pending",
+		"Failed --> Retrying: retries left and backoff elapsed",
+		"Succeeded --> [*]",
+	} {
+		if !strings.Contains(diagram, line) {
+			t.Errorf("expected diagram to contain %q:\n%s", line, diagram)
+		}
+	}
+}
+
+func TestNewPanicsOnUnknownState(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for transition to unknown state")
+		}
+	}()
+	New("broken", "a", []string{"a"}, []Transition{{From: "a", To: "b"}})
+}
@@ -0,0 +1,181 @@
+// krkn-operator embedded UI: targets, scenario runs and job logs.
+// Plain JavaScript without build step; every call goes through the REST API with the
+// JWT returned by /auth/login on the login page, kept for the browser session only.
+"use strict";
+
+const API = "/api/v1";
+const TOKEN_KEY = "krkn-ui-token";
+const USER_KEY = "krkn-ui-user";
+
+const view = document.getElementById("view");
+let logSocket = null;
+
+function token() {
+  return sessionStorage.getItem(TOKEN_KEY);
+}
+
+// signOut closes the UI session, whose cookie lets the browser load the dashboard
+async function signOut() {
+  sessionStorage.removeItem(TOKEN_KEY);
+  sessionStorage.removeItem(USER_KEY);
+  await fetch("/ui/session", { method: "DELETE" }).catch(() => null);
+  location.replace("/ui/login");
+}
+
+async function api(path, options = {}) {
+  const headers = { "Accept": "application/json", ...(options.headers || {}) };
+  if (token()) {
+    headers["Authorization"] = "Bearer " + token();
+  }
+  const response = await fetch(API + path, { ...options, headers });
+  if (response.status === 401) {
+    signOut();
+    throw new Error("Session expired, sign in again");
+  }
+  const body = response.status === 204 ? null : await response.json().catch(() => null);
+  if (!response.ok) {
+    throw new Error((body && body.message) || response.statusText);
+  }
+  return body;
+}
+
+// el builds an element; text children are inserted as text, never as HTML
+function el(tag, attrs = {}, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    if (key === "class") {
+      node.className = value;
+    } else {
+      node.setAttribute(key, value);
+    }
+  }
+  for (const child of children) {
+    node.append(child instanceof Node ? child : String(child ?? ""));
+  }
+  return node;
+}
+
+function table(columns, rows) {
+  const head = el("tr", {}, ...columns.map((column) => el("th", {}, column)));
+  return el("table", {}, el("thead", {}, head), el("tbody", {}, ...rows));
+}
+
+function phase(value) {
+  return el("span", { class: "phase phase-" + value }, value || "-");
+}
+
+function time(value) {
+  return value ? new Date(value).toLocaleString() : "-";
+}
+
+function show(...nodes) {
+  view.replaceChildren(...nodes);
+}
+
+function showError(error) {
+  show(el("p", { class: "error" }, error.message));
+}
+
+async function renderTargets() {
+  const { targets } = await api("/operator/targets");
+  const rows = targets.map((target) =>
+    el("tr", {},
+      el("td", {}, target.clusterName),
+      el("td", {}, target.clusterAPIURL),
+      el("td", {}, target.secretType),
+      el("td", {}, target.ready ? "Ready" : "Not ready"),
+      el("td", {}, time(target.createdAt))));
+  show(el("h2", {}, "Targets"),
+    rows.length ? table(["Cluster", "API URL", "Auth", "Status", "Created"], rows) : el("p", { class: "muted" }, "No targets"));
+}
+
+async function renderRuns() {
+  const { scenarioRuns } = await api("/scenarios/run");
+  const rows = scenarioRuns.map((run) =>
+    el("tr", {},
+      el("td", {}, el("a", { href: "#/runs/" + encodeURIComponent(run.scenarioRunName) }, run.scenarioRunName)),
+      el("td", {}, run.scenarioName),
+      el("td", {}, phase(run.phase)),
+      el("td", {}, run.successfulJobs + " / " + run.totalTargets),
+      el("td", {}, run.failedJobs),
+      el("td", {}, time(run.createdAt))));
+  show(el("h2", {}, "Scenario runs"),
+    rows.length ? table(["Run", "Scenario", "Phase", "Succeeded", "Failed", "Created"], rows) : el("p", { class: "muted" }, "No scenario runs"));
+}
+
+async function renderRun(name) {
+  const run = await api("/scenarios/run/" + encodeURIComponent(name));
+  const logs = el("pre", { class: "logs" });
+  const rows = run.clusterJobs.map((job) => {
+    const button = el("button", { type: "button" }, "Logs");
+    button.addEventListener("click", () => streamLogs(name, job, logs));
+    return el("tr", {},
+      el("td", {}, job.clusterName),
+      el("td", {}, phase(job.phase)),
+      el("td", {}, job.retryCount || 0),
+      el("td", {}, time(job.startTime)),
+      el("td", {}, time(job.completionTime)),
+      el("td", {}, job.message || ""),
+      el("td", {}, button));
+  });
+  show(el("h2", {}, run.scenarioRunName, " ", phase(run.phase)),
+    table(["Cluster", "Phase", "Retries", "Started", "Completed", "Message", ""], rows),
+    el("h3", {}, "Logs"), logs);
+}
+
+// streamLogs follows the logs of a job over the log WebSocket, which authenticates
+// with the JWT passed as "access_token.<jwt>" subprotocol
+function streamLogs(runName, job, output) {
+  if (logSocket) {
+    logSocket.close();
+  }
+  output.replaceChildren();
+  const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+  const path = API + "/scenarios/run/" + encodeURIComponent(runName) + "/jobs/" + encodeURIComponent(job.jobId) +
+    "/logs?follow=true&format=json";
+  logSocket = new WebSocket(scheme + "//" + location.host + path, "access_token." + token());
+  logSocket.onmessage = (message) => {
+    const frame = JSON.parse(message.data);
+    if (frame.type === "log") {
+      // Long lines arrive in several frames, only the last one ends the line
+      output.append(frame.line + (frame.truncated ? " [truncated]" : "") + (frame.partial ? "" : "\n"));
+    } else if (frame.type === "error") {
+      output.append("ERROR: " + frame.message + "\n");
+    }
+    output.scrollTop = output.scrollHeight;
+  };
+  logSocket.onclose = () => output.append("-- end of stream --\n");
+}
+
+async function route() {
+  if (logSocket) {
+    logSocket.close();
+    logSocket = null;
+  }
+  const hash = location.hash || "#/runs";
+  document.getElementById("user").textContent = sessionStorage.getItem(USER_KEY) || "";
+  for (const link of document.querySelectorAll("nav a")) {
+    link.classList.toggle("active", hash.startsWith(link.getAttribute("href")));
+  }
+
+  try {
+    if (hash === "#/targets") {
+      await renderTargets();
+    } else if (hash.startsWith("#/runs/")) {
+      await renderRun(decodeURIComponent(hash.slice("#/runs/".length)));
+    } else {
+      await renderRuns();
+    }
+  } catch (error) {
+    showError(error);
+  }
+}
+
+document.getElementById("logout").addEventListener("click", signOut);
+window.addEventListener("hashchange", route);
+if (token()) {
+  route();
+} else {
+  // The page was opened with the session cookie of another tab: sign in for this one
+  location.replace("/ui/login");
+}
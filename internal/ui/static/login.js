@@ -0,0 +1,39 @@
+// krkn-operator embedded UI: login page, the only page served without a session.
+// It signs in through /auth/login, keeps the JWT for the API calls of the browser
+// session and opens the UI session whose cookie lets the browser load the dashboard.
+"use strict";
+
+const TOKEN_KEY = "krkn-ui-token";
+const USER_KEY = "krkn-ui-user";
+
+const form = document.getElementById("login");
+
+async function request(url, options) {
+  const response = await fetch(url, options);
+  const body = response.status === 204 ? null : await response.json().catch(() => null);
+  if (!response.ok) {
+    throw new Error((body && body.message) || response.statusText);
+  }
+  return body;
+}
+
+form.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const data = new FormData(form);
+  try {
+    const login = await request("/api/v1/auth/login", {
+      method: "POST",
+      headers: { "Accept": "application/json", "Content-Type": "application/json" },
+      body: JSON.stringify({ userId: data.get("userId"), password: data.get("password") }),
+    });
+    await request("/ui/session", {
+      method: "POST",
+      headers: { "Authorization": "Bearer " + login.token },
+    });
+    sessionStorage.setItem(TOKEN_KEY, login.token);
+    sessionStorage.setItem(USER_KEY, login.userId + " (" + login.role + ")");
+    location.replace("/ui");
+  } catch (error) {
+    form.querySelector(".error").textContent = error.message;
+  }
+});
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ui embeds a lightweight single-page dashboard for the REST API.
+//
+// The UI is a static shell without data: it signs in through POST /api/v1/auth/login and
+// calls the API with the returned JWT like any other client, so users only see the
+// targets, runs and logs their API permissions allow. Only the login page and the assets
+// it loads are public; the API serves the rest behind its authentication. It covers small installs; the full
+// external UI keeps using the same API.
+package ui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+//go:embed static
+var static embed.FS
+
+// contentSecurityPolicy only lets the page load its own assets and talk to the API
+// it is served from, over HTTP and WebSockets
+const contentSecurityPolicy = "default-src 'self'; connect-src 'self' ws: wss:; " +
+	"img-src 'self' data:; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+// LoginPage is the path of the login page, relative to the UI prefix
+const LoginPage = "/login"
+
+// PublicAssets are the paths, relative to the UI prefix, of the login page and the
+// assets it loads. They must be reachable without a session.
+var PublicAssets = []string{LoginPage, "/login.js", "/app.css"}
+
+// Handler serves the UI mounted at prefix, e.g. "/ui". LoginPage gets login.html; other
+// paths that are not embedded files get index.html, so the client-side routes survive
+// a page reload.
+func Handler(prefix string) http.Handler {
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		// The static directory is embedded at build time
+		panic(err)
+	}
+	index, err := fs.ReadFile(files, "index.html")
+	if err != nil {
+		panic(err)
+	}
+	login, err := fs.ReadFile(files, "login.html")
+	if err != nil {
+		panic(err)
+	}
+	fileServer := http.StripPrefix(prefix, http.FileServer(http.FS(files)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		switch {
+		case "/"+name == LoginPage:
+			writePage(w, login)
+		case name == "" || name == "index.html" || !isFile(files, name):
+			writePage(w, index)
+		default:
+			fileServer.ServeHTTP(w, r)
+		}
+	})
+}
+
+// writePage writes an HTML page. Pages are small and change with every release, never
+// cache them.
+func writePage(w http.ResponseWriter, page []byte) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(page)
+}
+
+func isFile(files fs.FS, name string) bool {
+	info, err := fs.Stat(files, name)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	handler := Handler("/ui")
+
+	tests := []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{path: "/ui", contentType: "text/html", contains: "/ui/app.js"},
+		{path: "/ui/index.html", contentType: "text/html", contains: "/ui/app.js"},
+		{path: "/ui/app.js", contentType: "javascript", contains: "access_token."},
+		{path: "/ui/app.css", contentType: "text/css", contains: "pre.logs"},
+		{path: "/ui/login", contentType: "text/html", contains: "/ui/login.js"},
+		{path: "/ui/login.js", contentType: "javascript", contains: "/ui/session"},
+		// Unknown paths fall back to the shell for client-side routing
+		{path: "/ui/runs/run-1", contentType: "text/html", contains: "/ui/app.js"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", tt.path, w.Code)
+			continue
+		}
+		if got := w.Header().Get("Content-Type"); !strings.Contains(got, tt.contentType) {
+			t.Errorf("%s: expected content type %s, got %s", tt.path, tt.contentType, got)
+		}
+		if !strings.Contains(w.Body.String(), tt.contains) {
+			t.Errorf("%s: expected body to contain %q", tt.path, tt.contains)
+		}
+		if w.Header().Get("Content-Security-Policy") == "" {
+			t.Errorf("%s: expected a Content-Security-Policy header", tt.path)
+		}
+	}
+}
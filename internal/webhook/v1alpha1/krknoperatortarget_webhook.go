@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/envelope"
+	"github.com/krkn-chaos/krkn-operator/pkg/secrets"
+)
+
+var krknoperatortargetlog = logf.Log.WithName("krknoperatortarget-resource")
+
+// SetupKrknOperatorTargetWebhookWithManager registers the defaulting and validating webhooks
+// of KrknOperatorTarget. Secrets are read uncached: the API creates the Secret of a target
+// right before the target. cipher opens the kubeconfigs sealed at rest, nil when
+// encryption is off; store reads the credentials of targets with a Vault path, nil when
+// targets only use Secrets.
+func SetupKrknOperatorTargetWebhookWithManager(mgr ctrl.Manager, cipher *envelope.Cipher, store secrets.Store) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&krknv1alpha1.KrknOperatorTarget{}).
+		WithDefaulter(&KrknOperatorTargetCustomDefaulter{}).
+		WithValidator(&KrknOperatorTargetCustomValidator{
+			Reader:           mgr.GetAPIReader(),
+			Cipher:           cipher,
+			CredentialsStore: store,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-krkn-krkn-chaos-dev-v1alpha1-krknoperatortarget,mutating=true,failurePolicy=fail,sideEffects=None,groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=create;update,versions=v1alpha1,name=mkrknoperatortarget-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KrknOperatorTargetCustomDefaulter sets the defaults of targets created with kubectl,
+// the same the REST API sets on the targets it creates
+type KrknOperatorTargetCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &KrknOperatorTargetCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *KrknOperatorTargetCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	target, ok := obj.(*krknv1alpha1.KrknOperatorTarget)
+	if !ok {
+		return fmt.Errorf("expected a KrknOperatorTarget object but got %T", obj)
+	}
+	krknoperatortargetlog.V(1).Info("defaulting", "name", target.GetName())
+
+	target.Default()
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-krkn-krkn-chaos-dev-v1alpha1-krknoperatortarget,mutating=false,failurePolicy=fail,sideEffects=None,groups=krkn.krkn-chaos.dev,resources=krknoperatortargets,verbs=create;update,versions=v1alpha1,name=vkrknoperatortarget-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KrknOperatorTargetCustomValidator rejects targets that could never connect to their
+// cluster: a malformed API URL or CA bundle, or a Secret missing the kubeconfig its
+// secret type calls for. Targets registering the API URL of another target are rejected
+// too, as the REST API does.
+type KrknOperatorTargetCustomValidator struct {
+	// Reader reads the target Secrets and the other targets
+	Reader client.Reader
+	// Cipher opens the kubeconfigs sealed at rest (nil when encryption is off)
+	Cipher *envelope.Cipher
+	// CredentialsStore reads the credentials of targets with a Vault path (nil when
+	// targets only use Secrets)
+	CredentialsStore secrets.Store
+}
+
+var _ webhook.CustomValidator = &KrknOperatorTargetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *KrknOperatorTargetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	target, ok := obj.(*krknv1alpha1.KrknOperatorTarget)
+	if !ok {
+		return nil, fmt.Errorf("expected a KrknOperatorTarget object but got %T", obj)
+	}
+	krknoperatortargetlog.V(1).Info("validating create", "name", target.GetName())
+
+	return nil, v.validate(ctx, target)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *KrknOperatorTargetCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	target, ok := newObj.(*krknv1alpha1.KrknOperatorTarget)
+	if !ok {
+		return nil, fmt.Errorf("expected a KrknOperatorTarget object but got %T", newObj)
+	}
+	old, ok := oldObj.(*krknv1alpha1.KrknOperatorTarget)
+	if !ok {
+		return nil, fmt.Errorf("expected a KrknOperatorTarget object but got %T", oldObj)
+	}
+	krknoperatortargetlog.V(1).Info("validating update", "name", target.GetName())
+
+	// Metadata changes, e.g. finalizers removed once the Secret was purged, are not checked
+	if reflect.DeepEqual(old.Spec, target.Spec) || !target.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, target)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *KrknOperatorTargetCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the spec of target and the Secret it references
+func (v *KrknOperatorTargetCustomValidator) validate(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) error {
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+
+	if target.Spec.ClusterAPIURL != "" {
+		if _, err := kubeconfig.NormalizeAPIURL(target.Spec.ClusterAPIURL); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("clusterAPIURL"), target.Spec.ClusterAPIURL, err.Error()))
+		} else if err := v.validateUniqueAPIURL(ctx, target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if target.Spec.CABundle != "" {
+		if err := validateCABundle(target.Spec.CABundle); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("caBundle"), "<redacted>", err.Error()))
+		}
+	}
+	if target.Spec.TokenRotation != nil && target.Spec.SecretType != "token" {
+		errs = append(errs, field.Invalid(specPath.Child("tokenRotation"), target.Spec.SecretType,
+			"token rotation is only supported for secretType token"))
+	}
+	if err := v.validateSecret(ctx, target); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(krknv1alpha1.GroupVersion.WithKind("KrknOperatorTarget").GroupKind(), target.Name, errs)
+}
+
+// validateUniqueAPIURL checks that no other target of the namespace uses the API URL of
+// target once normalized. Archived targets are skipped: the API restores them instead.
+func (v *KrknOperatorTargetCustomValidator) validateUniqueAPIURL(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) *field.Error {
+	apiURLPath := field.NewPath("spec", "clusterAPIURL")
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := v.Reader.List(ctx, &targets, client.InNamespace(target.Namespace)); err != nil {
+		return field.InternalError(apiURLPath, fmt.Errorf("failed to list targets: %w", err))
+	}
+	for _, other := range targets.Items {
+		if other.Name == target.Name || other.Status.Archived {
+			continue
+		}
+		if kubeconfig.SameAPIURL(other.Spec.ClusterAPIURL, target.Spec.ClusterAPIURL) {
+			return field.Duplicate(apiURLPath, fmt.Sprintf("%s (already used by target %s)", target.Spec.ClusterAPIURL, other.Name))
+		}
+	}
+	return nil
+}
+
+// validateCABundle checks that caBundle is base64-encoded PEM holding a certificate
+func validateCABundle(caBundle string) error {
+	data, err := base64.StdEncoding.DecodeString(caBundle)
+	if err != nil {
+		return fmt.Errorf("must be base64-encoded: %w", err)
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("must hold a PEM-encoded CERTIFICATE")
+		}
+		if block.Type == "CERTIFICATE" {
+			return nil
+		}
+	}
+}
+
+// validateSecret checks that the Secret of target, or its credentials in the credentials
+// store, hold a kubeconfig whose current user authenticates the way secretType says
+func (v *KrknOperatorTargetCustomValidator) validateSecret(ctx context.Context, target *krknv1alpha1.KrknOperatorTarget) *field.Error {
+	secretPath, source := field.NewPath("spec", "secretUUID"), target.Spec.SecretUUID
+	switch {
+	case target.Spec.SecretUUID != "" && target.Spec.VaultPath != "":
+		return field.Invalid(field.NewPath("spec", "vaultPath"), target.Spec.VaultPath,
+			"secretUUID and vaultPath are mutually exclusive")
+	case target.Spec.VaultPath != "":
+		secretPath, source = field.NewPath("spec", "vaultPath"), target.Spec.VaultPath
+		if v.CredentialsStore == nil {
+			return field.Invalid(secretPath, source, "no Vault credentials store is configured")
+		}
+	case target.Spec.SecretUUID == "":
+		return field.Required(secretPath, "the Secret holding the target kubeconfig")
+	}
+
+	credentials, err := secrets.TargetCredentials(ctx, v.Reader, v.CredentialsStore, target)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return field.NotFound(secretPath, source)
+	}
+	if err != nil {
+		return field.InternalError(secretPath, fmt.Errorf("failed to get credentials: %w", err))
+	}
+
+	data, ok := credentials["kubeconfig"]
+	if !ok {
+		return field.Invalid(secretPath, source, "Secret has no kubeconfig key")
+	}
+	kubeconfigBase64, err := kubeconfig.UnmarshalSecretData(data)
+	if err == nil {
+		kubeconfigBase64, err = v.Cipher.Open(ctx, kubeconfigBase64)
+	}
+	if err == nil {
+		err = kubeconfig.Validate(kubeconfigBase64)
+	}
+	if err != nil {
+		return field.Invalid(secretPath, source, "Secret holds an invalid kubeconfig: "+err.Error())
+	}
+
+	// Validate checked the base64 encoding and the current context
+	raw, _ := base64.StdEncoding.DecodeString(kubeconfigBase64)
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		return field.Invalid(secretPath, source, "Secret holds an invalid kubeconfig: "+err.Error())
+	}
+	user := config.AuthInfos[config.Contexts[config.CurrentContext].AuthInfo]
+	switch target.Spec.SecretType {
+	case "token":
+		if user == nil || user.Token == "" {
+			return field.Invalid(field.NewPath("spec", "secretType"), target.Spec.SecretType,
+				"the kubeconfig of the Secret has no token for the current user")
+		}
+	case "credentials":
+		if user == nil || user.Username == "" || user.Password == "" {
+			return field.Invalid(field.NewPath("spec", "secretType"), target.Spec.SecretType,
+				"the kubeconfig of the Secret has no username and password for the current user")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+var krknscenariorunlog = logf.Log.WithName("krknscenariorun-resource")
+
+// SetupKrknScenarioRunWebhookWithManager registers the defaulting and validating webhooks
+// of KrknScenarioRun. policy lists the scenarios that only run under a break-glass
+// exemption, verified with the TokenGenerator returned by tokenGenerator.
+func SetupKrknScenarioRunWebhookWithManager(
+	mgr ctrl.Manager,
+	policy auth.ScenarioPolicy,
+	tokenGenerator func(ctx context.Context) (*auth.TokenGenerator, error),
+) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&krknv1alpha1.KrknScenarioRun{}).
+		WithDefaulter(&KrknScenarioRunCustomDefaulter{}).
+		WithValidator(&KrknScenarioRunCustomValidator{
+			Reader:         mgr.GetAPIReader(),
+			Policy:         policy,
+			TokenGenerator: tokenGenerator,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-krkn-krkn-chaos-dev-v1alpha1-krknscenariorun,mutating=true,failurePolicy=fail,sideEffects=None,groups=krkn.krkn-chaos.dev,resources=krknscenarioruns,verbs=create;update,versions=v1alpha1,name=mkrknscenariorun-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KrknScenarioRunCustomDefaulter sets the defaults of runs created with kubectl, the
+// same the REST API sets on the runs it creates
+type KrknScenarioRunCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &KrknScenarioRunCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *KrknScenarioRunCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	scenarioRun, ok := obj.(*krknv1alpha1.KrknScenarioRun)
+	if !ok {
+		return fmt.Errorf("expected a KrknScenarioRun object but got %T", obj)
+	}
+	krknscenariorunlog.V(1).Info("defaulting", "name", scenarioRun.GetName())
+
+	scenarioRun.Default()
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-krkn-krkn-chaos-dev-v1alpha1-krknscenariorun,mutating=false,failurePolicy=fail,sideEffects=None,groups=krkn.krkn-chaos.dev,resources=krknscenarioruns,verbs=create;update,versions=v1alpha1,name=vkrknscenariorun-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KrknScenarioRunCustomValidator rejects runs of restricted scenarios that were not
+// created through the REST API under a break-glass exemption covering their clusters, so
+// kubectl cannot bypass the check of the API. The run carries the exemption ID in
+// auth.BreakGlassAnnotation; the token is read from the record Secret the API wrote for
+// the run, so copying the annotation to another run does not copy the exemption.
+type KrknScenarioRunCustomValidator struct {
+	// Reader reads the scenario templates and break-glass records of the runs
+	Reader client.Reader
+	// Policy lists the restricted scenarios
+	Policy auth.ScenarioPolicy
+	// TokenGenerator returns the TokenGenerator verifying the exemptions
+	TokenGenerator func(ctx context.Context) (*auth.TokenGenerator, error)
+}
+
+var _ webhook.CustomValidator = &KrknScenarioRunCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *KrknScenarioRunCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	scenarioRun, ok := obj.(*krknv1alpha1.KrknScenarioRun)
+	if !ok {
+		return nil, fmt.Errorf("expected a KrknScenarioRun object but got %T", obj)
+	}
+	krknscenariorunlog.V(1).Info("validating create", "name", scenarioRun.GetName())
+
+	return nil, v.validate(ctx, scenarioRun)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *KrknScenarioRunCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	scenarioRun, ok := newObj.(*krknv1alpha1.KrknScenarioRun)
+	if !ok {
+		return nil, fmt.Errorf("expected a KrknScenarioRun object but got %T", newObj)
+	}
+	old, ok := oldObj.(*krknv1alpha1.KrknScenarioRun)
+	if !ok {
+		return nil, fmt.Errorf("expected a KrknScenarioRun object but got %T", oldObj)
+	}
+	krknscenariorunlog.V(1).Info("validating update", "name", scenarioRun.GetName())
+
+	// The exemption is checked when the run is created; it may expire while the run goes
+	// on, so only changes to what it covers are checked again. The stored specs are
+	// compared, so rendering the template into the run is such a change.
+	if scenarioRun.Spec.ScenarioName == old.Spec.ScenarioName &&
+		scenarioRun.Spec.ScenarioImage == old.Spec.ScenarioImage &&
+		scenarioRun.Spec.TemplateRef == old.Spec.TemplateRef &&
+		reflect.DeepEqual(old.Spec.TargetClusters, scenarioRun.Spec.TargetClusters) &&
+		reflect.DeepEqual(old.Spec.Alternates, scenarioRun.Spec.Alternates) &&
+		old.Spec.TargetGroupRef == scenarioRun.Spec.TargetGroupRef &&
+		reflect.DeepEqual(old.Spec.TargetClusterIDs, scenarioRun.Spec.TargetClusterIDs) &&
+		old.Annotations[auth.BreakGlassAnnotation] == scenarioRun.Annotations[auth.BreakGlassAnnotation] {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, scenarioRun)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *KrknScenarioRunCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// scenario returns the scenario and image the run executes: its own, or the ones of its
+// template. Runs whose template does not exist are rejected, as their scenario is unknown.
+func (v *KrknScenarioRunCustomValidator) scenario(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) (string, string, error) {
+	spec := &scenarioRun.Spec
+	if spec.TemplateRef == "" || (spec.ScenarioName != "" && spec.ScenarioImage != "") {
+		return spec.ScenarioName, spec.ScenarioImage, nil
+	}
+	template := &krknv1alpha1.KrknScenarioTemplate{}
+	if err := v.Reader.Get(ctx, types.NamespacedName{Name: spec.TemplateRef, Namespace: scenarioRun.Namespace}, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "", apierrors.NewInvalid(krknv1alpha1.GroupVersion.WithKind("KrknScenarioRun").GroupKind(), scenarioRun.Name,
+				field.ErrorList{field.NotFound(field.NewPath("spec", "templateRef"), spec.TemplateRef)})
+		}
+		return "", "", fmt.Errorf("failed to get scenario template %s: %w", spec.TemplateRef, err)
+	}
+	// Values of the run win over the template, as when the template is rendered
+	scenarioName, scenarioImage := spec.ScenarioName, spec.ScenarioImage
+	if scenarioName == "" {
+		scenarioName = template.Spec.ScenarioName
+	}
+	if scenarioImage == "" {
+		scenarioImage = template.Spec.ScenarioImage
+	}
+	return scenarioName, scenarioImage, nil
+}
+
+// validate checks the break-glass exemption of a run of a restricted scenario
+func (v *KrknScenarioRunCustomValidator) validate(ctx context.Context, scenarioRun *krknv1alpha1.KrknScenarioRun) error {
+	scenarioName, scenarioImage, err := v.scenario(ctx, scenarioRun)
+	if err != nil {
+		return err
+	}
+	restricted := v.Policy.Restricted(scenarioName, scenarioImage)
+	if len(restricted) == 0 {
+		return nil
+	}
+	specPath := field.NewPath("spec")
+	annotationPath := field.NewPath("metadata", "annotations").Key(auth.BreakGlassAnnotation)
+	var errs field.ErrorList
+
+	// Target groups and cluster IDs are resolved when the run starts, past the exemption
+	if scenarioRun.Spec.TargetGroupRef != "" {
+		errs = append(errs, field.Forbidden(specPath.Child("targetGroupRef"),
+			fmt.Sprintf("scenario %q is restricted: list its clusters in targetClusters", restricted[0])))
+	}
+	if len(scenarioRun.Spec.TargetClusterIDs) > 0 {
+		errs = append(errs, field.Forbidden(specPath.Child("targetClusterIds"),
+			fmt.Sprintf("scenario %q is restricted: list its clusters in targetClusters", restricted[0])))
+	}
+
+	exemptionID := scenarioRun.Annotations[auth.BreakGlassAnnotation]
+	switch {
+	case exemptionID == "":
+		errs = append(errs, field.Required(annotationPath,
+			fmt.Sprintf("scenario %q is restricted and needs a break-glass exemption", restricted[0])))
+	case v.TokenGenerator == nil:
+		errs = append(errs, field.InternalError(annotationPath, fmt.Errorf("break-glass exemptions cannot be verified")))
+	default:
+		if err := v.validateExemption(ctx, scenarioRun, restricted, exemptionID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(krknv1alpha1.GroupVersion.WithKind("KrknScenarioRun").GroupKind(), scenarioRun.Name, errs)
+}
+
+// validateExemption checks that the API recorded exemption exemptionID for the run, and
+// that it is valid, issued to the owner of the run if to anyone, and covers the
+// restricted scenarios and the clusters of the run, alternates included
+func (v *KrknScenarioRunCustomValidator) validateExemption(
+	ctx context.Context,
+	scenarioRun *krknv1alpha1.KrknScenarioRun,
+	restricted []string,
+	exemptionID string,
+) *field.Error {
+	annotationPath := field.NewPath("metadata", "annotations").Key(auth.BreakGlassAnnotation)
+
+	record := &corev1.Secret{}
+	if err := v.Reader.Get(ctx, types.NamespacedName{
+		Name:      auth.BreakGlassRecordName(scenarioRun.Name),
+		Namespace: scenarioRun.Namespace,
+	}, record); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.Forbidden(annotationPath,
+				"no break-glass exemption was recorded for this run: runs of restricted scenarios are created through the REST API")
+		}
+		return field.InternalError(annotationPath, err)
+	}
+
+	tokenGen, err := v.TokenGenerator(ctx)
+	if err != nil {
+		return field.InternalError(annotationPath, err)
+	}
+	exemption, err := tokenGen.ValidateBreakGlassToken(string(record.Data[auth.BreakGlassRecordKey]))
+	if err != nil {
+		return field.Forbidden(annotationPath, "invalid or expired break-glass exemption")
+	}
+	if exemption.ID != exemptionID {
+		return field.Forbidden(annotationPath, fmt.Sprintf("exemption %s was not recorded for this run", exemptionID))
+	}
+	if exemption.Grantee != "" && exemption.Grantee != scenarioRun.Spec.OwnerUserID {
+		return field.Forbidden(annotationPath, fmt.Sprintf("exemption %s was issued to another user", exemption.ID))
+	}
+
+	clusters := make(map[string][]string, len(scenarioRun.Spec.TargetClusters)+1)
+	for providerName, names := range scenarioRun.Spec.TargetClusters {
+		clusters[providerName] = names
+	}
+	for _, alternate := range scenarioRun.Spec.Alternates {
+		clusters[""] = append(clusters[""], alternate)
+	}
+	for _, scenario := range restricted {
+		if err := exemption.Covers(scenario, clusters); err != nil {
+			return field.Forbidden(annotationPath, err.Error())
+		}
+	}
+
+	krknscenariorunlog.Info("run of a restricted scenario allowed by break-glass exemption",
+		"name", scenarioRun.GetName(),
+		"scenarios", restricted,
+		"exemption", exemption.ID)
+	return nil
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+	"github.com/krkn-chaos/krkn-operator/internal/kubeconfig"
+	"github.com/krkn-chaos/krkn-operator/pkg/auth"
+)
+
+func TestKrknScenarioRunDefaulter(t *testing.T) {
+	defaulter := &KrknScenarioRunCustomDefaulter{}
+
+	scenarioRun := &krknv1alpha1.KrknScenarioRun{}
+	if err := defaulter.Default(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	spec := scenarioRun.Spec
+	if spec.KubeconfigPath != "/home/krkn/.kube/config" || spec.MaxRetries != 3 || spec.RetryDelay != "10s" ||
+		spec.RetryBackoff != "exponential" || spec.ImagePullPolicy != "Always" {
+		t.Errorf("Expected the defaults to be set, got %+v", spec)
+	}
+
+	// Values set by the user are kept
+	scenarioRun.Spec.MaxRetries = 1
+	scenarioRun.Spec.RetryDelay = "1m"
+	scenarioRun.Spec.ImagePullPolicy = "IfNotPresent"
+	if err := defaulter.Default(context.Background(), scenarioRun); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if spec := scenarioRun.Spec; spec.MaxRetries != 1 || spec.RetryDelay != "1m" || spec.ImagePullPolicy != "IfNotPresent" {
+		t.Errorf("Expected the user values to be kept, got %+v", spec)
+	}
+
+	if err := defaulter.Default(context.Background(), &krknv1alpha1.KrknOperatorTarget{}); err == nil {
+		t.Error("Expected an error for another kind")
+	}
+}
+
+func TestKrknOperatorTargetDefaulter(t *testing.T) {
+	defaulter := &KrknOperatorTargetCustomDefaulter{}
+
+	target := &krknv1alpha1.KrknOperatorTarget{}
+	if err := defaulter.Default(context.Background(), target); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if target.Spec.SecretType != "kubeconfig" {
+		t.Errorf("Expected secretType kubeconfig, got %q", target.Spec.SecretType)
+	}
+
+	target.Spec.SecretType = "token"
+	if err := defaulter.Default(context.Background(), target); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if target.Spec.SecretType != "token" {
+		t.Errorf("Expected secretType token to be kept, got %q", target.Spec.SecretType)
+	}
+}
+
+func TestKrknOperatorTargetValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	secret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Data: data}
+	}
+	tokenKubeconfig, err := kubeconfig.GenerateFromToken("cluster-1", "https://api.cluster-1:6443", "", "", "sha256~token", true)
+	if err != nil {
+		t.Fatalf("failed to generate kubeconfig: %v", err)
+	}
+	tokenData, err := kubeconfig.MarshalSecretData(tokenKubeconfig)
+	if err != nil {
+		t.Fatalf("failed to marshal secret data: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		secret("token-secret", map[string][]byte{"kubeconfig": tokenData}),
+		secret("empty-secret", map[string][]byte{"prometheus-token": []byte("t")}),
+		secret("garbage-secret", map[string][]byte{"kubeconfig": []byte(`{"kubeconfig":"bm90IGEga3ViZWNvbmZpZw=="}`)}),
+		&krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "target-2", Namespace: "default"},
+			Spec:       krknv1alpha1.KrknOperatorTargetSpec{ClusterName: "cluster-2", ClusterAPIURL: "https://api.cluster-2:6443"},
+		},
+		&krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "target-3", Namespace: "default"},
+			Spec:       krknv1alpha1.KrknOperatorTargetSpec{ClusterName: "cluster-3", ClusterAPIURL: "https://api.cluster-3:6443"},
+			Status:     krknv1alpha1.KrknOperatorTargetStatus{Archived: true},
+		},
+	).WithStatusSubresource(&krknv1alpha1.KrknOperatorTarget{}).Build()
+	validator := &KrknOperatorTargetCustomValidator{Reader: fakeClient}
+
+	target := func(secretType, secretUUID string) *krknv1alpha1.KrknOperatorTarget {
+		return &krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "target-1", Namespace: "default"},
+			Spec: krknv1alpha1.KrknOperatorTargetSpec{
+				ClusterName:   "cluster-1",
+				ClusterAPIURL: "https://api.cluster-1:6443",
+				SecretType:    secretType,
+				SecretUUID:    secretUUID,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		target   *krknv1alpha1.KrknOperatorTarget
+		errField string
+	}{
+		{name: "valid token target", target: target("token", "token-secret")},
+		{name: "valid kubeconfig target", target: target("kubeconfig", "token-secret")},
+		{name: "secret type without matching user", target: target("credentials", "token-secret"), errField: "spec.secretType"},
+		{name: "missing secret", target: target("token", "missing-secret"), errField: "spec.secretUUID"},
+		{name: "secret without kubeconfig key", target: target("token", "empty-secret"), errField: "spec.secretUUID"},
+		{name: "secret with invalid kubeconfig", target: target("kubeconfig", "garbage-secret"), errField: "spec.secretUUID"},
+		{name: "no secret", target: target("token", ""), errField: "spec.secretUUID"},
+		{
+			name: "vault path without credentials store",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "")
+				t.Spec.VaultPath = "targets/target-1"
+				return t
+			}(),
+			errField: "spec.vaultPath",
+		},
+		{
+			name: "secret and vault path",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "token-secret")
+				t.Spec.VaultPath = "targets/target-1"
+				return t
+			}(),
+			errField: "spec.vaultPath",
+		},
+		{
+			name: "token rotation of a kubeconfig target",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("kubeconfig", "token-secret")
+				t.Spec.TokenRotation = &krknv1alpha1.TargetTokenRotation{
+					ServiceAccount: krknv1alpha1.ServiceAccountReference{Namespace: "krkn", Name: "runner"},
+				}
+				return t
+			}(),
+			errField: "spec.tokenRotation",
+		},
+		{
+			name: "malformed API URL",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "token-secret")
+				t.Spec.ClusterAPIURL = "ftp://api.cluster-1"
+				return t
+			}(),
+			errField: "spec.clusterAPIURL",
+		},
+		{
+			name: "API URL of another target once normalized",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "token-secret")
+				t.Spec.ClusterAPIURL = "HTTPS://API.cluster-2:6443/"
+				return t
+			}(),
+			errField: "spec.clusterAPIURL",
+		},
+		{
+			name: "API URL of an archived target",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "token-secret")
+				t.Spec.ClusterAPIURL = "https://api.cluster-3:6443"
+				return t
+			}(),
+		},
+		{
+			name: "CA bundle not base64",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "token-secret")
+				t.Spec.CABundle = "-----BEGIN CERTIFICATE-----"
+				return t
+			}(),
+			errField: "spec.caBundle",
+		},
+		{
+			name: "CA bundle without certificate",
+			target: func() *krknv1alpha1.KrknOperatorTarget {
+				t := target("token", "token-secret")
+				t.Spec.CABundle = "bm90IGEgY2VydGlmaWNhdGU="
+				return t
+			}(),
+			errField: "spec.caBundle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.target)
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), tt.errField) {
+				t.Errorf("Expected an Invalid error on %s, got %v", tt.errField, err)
+			}
+		})
+	}
+
+	// Updates leaving the spec alone are not checked: the finalizer of a target is removed
+	// after its Secret is deleted
+	old := target("token", "missing-secret")
+	updated := old.DeepCopy()
+	updated.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), old, updated); err != nil {
+		t.Errorf("Expected a metadata update to be allowed, got %v", err)
+	}
+	updated.Spec.SecretType = "credentials"
+	if _, err := validator.ValidateUpdate(context.Background(), old, updated); err == nil {
+		t.Error("Expected a spec update to be validated")
+	}
+}
+
+func TestKrknScenarioRunValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tokenGen := auth.NewTokenGenerator([]byte("test-secret-key-at-least-32-bytes-long"), time.Hour, "krkn-operator")
+	exemption := func(tg *auth.TokenGenerator, grant auth.BreakGlassGrant) (string, string) {
+		token, claims, err := tg.GenerateBreakGlassToken("admin@example.com", grant)
+		if err != nil {
+			t.Fatalf("failed to generate exemption: %v", err)
+		}
+		return token, claims.ID
+	}
+	covering, coveringID := exemption(tokenGen, auth.BreakGlassGrant{
+		Scenario: "node-scenarios", Clusters: []string{"cluster-1", "cluster-2"},
+	})
+	otherUser, otherUserID := exemption(tokenGen, auth.BreakGlassGrant{
+		Scenario: "node-scenarios", Clusters: []string{"cluster-1"}, Grantee: "other@example.com",
+	})
+	other := auth.NewTokenGenerator([]byte("another-secret-key-at-least-32-bytes"), time.Hour, "krkn-operator")
+	forged, forgedID := exemption(other, auth.BreakGlassGrant{Scenario: "node-scenarios", Clusters: []string{"cluster-1"}})
+
+	// Records the API wrote for the runs it created, by run name
+	record := func(scenarioRunName, token string) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: auth.BreakGlassRecordName(scenarioRunName), Namespace: "default"},
+			Data:       map[string][]byte{auth.BreakGlassRecordKey: []byte(token)},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&krknv1alpha1.KrknScenarioTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-outage", Namespace: "default"},
+			Spec:       krknv1alpha1.KrknScenarioTemplateSpec{ScenarioName: "node-scenarios"},
+		},
+		record("covered", covering),
+		record("other-user", otherUser),
+		record("forged", forged),
+	).Build()
+	validator := &KrknScenarioRunCustomValidator{
+		Reader: fakeClient,
+		Policy: auth.ScenarioPolicy{RestrictedScenarios: []string{"node-scenarios"}},
+		TokenGenerator: func(context.Context) (*auth.TokenGenerator, error) {
+			return tokenGen, nil
+		},
+	}
+
+	run := func(name, scenarioName, exemptionID string, mutate func(*krknv1alpha1.KrknScenarioRunSpec)) *krknv1alpha1.KrknScenarioRun {
+		scenarioRun := &krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: krknv1alpha1.KrknScenarioRunSpec{
+				ScenarioName:   scenarioName,
+				ScenarioImage:  "quay.io/krkn-chaos/krkn-hub:" + scenarioName,
+				OwnerUserID:    "user@example.com",
+				TargetClusters: map[string][]string{"krkn-operator": {"cluster-1"}},
+			},
+		}
+		if exemptionID != "" {
+			scenarioRun.Annotations = map[string]string{auth.BreakGlassAnnotation: exemptionID}
+		}
+		if mutate != nil {
+			mutate(&scenarioRun.Spec)
+		}
+		return scenarioRun
+	}
+
+	tests := []struct {
+		name     string
+		run      *krknv1alpha1.KrknScenarioRun
+		errField string
+	}{
+		{name: "unrestricted scenario", run: run("covered", "pod-scenarios", "", nil)},
+		{name: "covered by the exemption", run: run("covered", "node-scenarios", coveringID, nil)},
+		{
+			name: "alternate covered by the exemption",
+			run: run("covered", "node-scenarios", coveringID, func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.Alternates = map[string]string{"cluster-1": "cluster-2"}
+			}),
+		},
+		{name: "no exemption", run: run("covered", "node-scenarios", "", nil), errField: auth.BreakGlassAnnotation},
+		{
+			name: "restricted through the template",
+			run: run("covered", "", "", func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.TemplateRef = "node-outage"
+			}),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name: "template not found",
+			run: run("covered", "", "", func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.TemplateRef = "missing"
+			}),
+			errField: "spec.templateRef",
+		},
+		{
+			name: "restricted image under another name",
+			run: run("covered", "pod-scenarios", "", func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.ScenarioImage = "quay.io/krkn-chaos/krkn-hub:node-scenarios"
+			}),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name:     "exemption ID copied to another run",
+			run:      run("copied", "node-scenarios", coveringID, nil),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name:     "record of another exemption",
+			run:      run("covered", "node-scenarios", otherUserID, nil),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name: "cluster not covered",
+			run: run("covered", "node-scenarios", coveringID, func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.TargetClusters["krkn-operator"] = append(spec.TargetClusters["krkn-operator"], "cluster-3")
+			}),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name: "alternate not covered",
+			run: run("covered", "node-scenarios", coveringID, func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.Alternates = map[string]string{"cluster-1": "cluster-3"}
+			}),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name:     "issued to another user",
+			run:      run("other-user", "node-scenarios", otherUserID, nil),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name:     "signed with another key",
+			run:      run("forged", "node-scenarios", forgedID, nil),
+			errField: auth.BreakGlassAnnotation,
+		},
+		{
+			name: "target group",
+			run: run("covered", "node-scenarios", coveringID, func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+				spec.TargetGroupRef = "prod"
+			}),
+			errField: "spec.targetGroupRef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.run)
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), tt.errField) {
+				t.Errorf("Expected an Invalid error on %s, got %v", tt.errField, err)
+			}
+		})
+	}
+
+	// The exemption may expire while the run goes on: updates leaving the clusters alone
+	// are not checked
+	old := run("expired", "node-scenarios", "expired", nil)
+	updated := old.DeepCopy()
+	updated.Spec.MaxConcurrency = 1
+	if _, err := validator.ValidateUpdate(context.Background(), old, updated); err != nil {
+		t.Errorf("Expected an update leaving the clusters alone to be allowed, got %v", err)
+	}
+	updated.Spec.TargetClusters = map[string][]string{"krkn-operator": {"cluster-2"}}
+	if _, err := validator.ValidateUpdate(context.Background(), old, updated); err == nil {
+		t.Error("Expected an update of the clusters to be validated")
+	}
+
+	// Rendering the template into the run is checked against the stored old run, not
+	// against the template looked up again
+	old = run("copied", "", "", func(spec *krknv1alpha1.KrknScenarioRunSpec) {
+		spec.ScenarioImage = ""
+		spec.TemplateRef = "missing"
+	})
+	updated = old.DeepCopy()
+	updated.Spec.ScenarioName = "node-scenarios"
+	updated.Spec.ScenarioImage = "quay.io/krkn-chaos/krkn-hub:node-scenarios"
+	if _, err := validator.ValidateUpdate(context.Background(), old, updated); err == nil {
+		t.Error("Expected rendering a restricted scenario into the run to be validated")
+	}
+}
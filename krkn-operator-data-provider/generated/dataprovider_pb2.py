@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x12\x64\x61taprovider.proto\x12\x0c\x64\x61taprovider\",\n\x0fGetNodesRequest\x12\x19\n\x11kubeconfig_base64\x18\x01 \x01(\t\"!\n\x10GetNodesResponse\x12\r\n\x05nodes\x18\x01 \x03(\t2`\n\x13\x44\x61taProviderService\x12I\n\x08GetNodes\x12\x1d.dataprovider.GetNodesRequest\x1a\x1e.dataprovider.GetNodesResponseB8Z6github.com/krkn-chaos/krkn-operator/proto/dataproviderb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x12\x64\x61taprovider.proto\x12\x0c\x64\x61taprovider\",\n\x0fGetNodesRequest\x12\x19\n\x11kubeconfig_base64\x18\x01 \x01(\t\"!\n\x10GetNodesResponse\x12\r\n\x05nodes\x18\x01 \x03(\t\"E\n\x14VerifyCleanupRequest\x12\x19\n\x11kubeconfig_base64\x18\x01 \x01(\t\x12\x12\n\nnamespaces\x18\x02 \x03(\t\"f\n\x15VerifyCleanupResponse\x12\x16\n\x0e\x63ordoned_nodes\x18\x01 \x03(\t\x12\x17\n\x0fnot_ready_nodes\x18\x02 \x03(\t\x12\x1c\n\x14remaining_namespaces\x18\x03 \x03(\t2\xba\x01\n\x13\x44\x61taProviderService\x12I\n\x08GetNodes\x12\x1d.dataprovider.GetNodesRequest\x1a\x1e.dataprovider.GetNodesResponse\x12X\n\rVerifyCleanup\x12\".dataprovider.VerifyCleanupRequest\x1a#.dataprovider.VerifyCleanupResponseB8Z6github.com/krkn-chaos/krkn-operator/proto/dataproviderb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -36,6 +36,10 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_GETNODESREQUEST']._serialized_end=80
   _globals['_GETNODESRESPONSE']._serialized_start=82
   _globals['_GETNODESRESPONSE']._serialized_end=115
-  _globals['_DATAPROVIDERSERVICE']._serialized_start=117
-  _globals['_DATAPROVIDERSERVICE']._serialized_end=213
+  _globals['_VERIFYCLEANUPREQUEST']._serialized_start=117
+  _globals['_VERIFYCLEANUPREQUEST']._serialized_end=186
+  _globals['_VERIFYCLEANUPRESPONSE']._serialized_start=188
+  _globals['_VERIFYCLEANUPRESPONSE']._serialized_end=290
+  _globals['_DATAPROVIDERSERVICE']._serialized_start=293
+  _globals['_DATAPROVIDERSERVICE']._serialized_end=479
 # @@protoc_insertion_point(module_scope)
@@ -17,3 +17,21 @@ class GetNodesResponse(_message.Message):
     NODES_FIELD_NUMBER: _ClassVar[int]
     nodes: _containers.RepeatedScalarFieldContainer[str]
     def __init__(self, nodes: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class VerifyCleanupRequest(_message.Message):
+    __slots__ = ("kubeconfig_base64", "namespaces")
+    KUBECONFIG_BASE64_FIELD_NUMBER: _ClassVar[int]
+    NAMESPACES_FIELD_NUMBER: _ClassVar[int]
+    kubeconfig_base64: str
+    namespaces: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, kubeconfig_base64: _Optional[str] = ..., namespaces: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class VerifyCleanupResponse(_message.Message):
+    __slots__ = ("cordoned_nodes", "not_ready_nodes", "remaining_namespaces")
+    CORDONED_NODES_FIELD_NUMBER: _ClassVar[int]
+    NOT_READY_NODES_FIELD_NUMBER: _ClassVar[int]
+    REMAINING_NAMESPACES_FIELD_NUMBER: _ClassVar[int]
+    cordoned_nodes: _containers.RepeatedScalarFieldContainer[str]
+    not_ready_nodes: _containers.RepeatedScalarFieldContainer[str]
+    remaining_namespaces: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, cordoned_nodes: _Optional[_Iterable[str]] = ..., not_ready_nodes: _Optional[_Iterable[str]] = ..., remaining_namespaces: _Optional[_Iterable[str]] = ...) -> None: ...
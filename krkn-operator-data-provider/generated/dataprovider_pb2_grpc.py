@@ -40,6 +40,11 @@ class DataProviderServiceStub(object):
                 request_serializer=dataprovider__pb2.GetNodesRequest.SerializeToString,
                 response_deserializer=dataprovider__pb2.GetNodesResponse.FromString,
                 _registered_method=True)
+        self.VerifyCleanup = channel.unary_unary(
+                '/dataprovider.DataProviderService/VerifyCleanup',
+                request_serializer=dataprovider__pb2.VerifyCleanupRequest.SerializeToString,
+                response_deserializer=dataprovider__pb2.VerifyCleanupResponse.FromString,
+                _registered_method=True)
 
 
 class DataProviderServiceServicer(object):
@@ -53,6 +58,13 @@ class DataProviderServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def VerifyCleanup(self, request, context):
+        """VerifyCleanup reports what a chaos scenario left behind on a Kubernetes cluster
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_DataProviderServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -61,6 +73,11 @@ def add_DataProviderServiceServicer_to_server(servicer, server):
                     request_deserializer=dataprovider__pb2.GetNodesRequest.FromString,
                     response_serializer=dataprovider__pb2.GetNodesResponse.SerializeToString,
             ),
+            'VerifyCleanup': grpc.unary_unary_rpc_method_handler(
+                    servicer.VerifyCleanup,
+                    request_deserializer=dataprovider__pb2.VerifyCleanupRequest.FromString,
+                    response_serializer=dataprovider__pb2.VerifyCleanupResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'dataprovider.DataProviderService', rpc_method_handlers)
@@ -99,3 +116,30 @@ class DataProviderService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def VerifyCleanup(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/dataprovider.DataProviderService/VerifyCleanup',
+            dataprovider__pb2.VerifyCleanupRequest.SerializeToString,
+            dataprovider__pb2.VerifyCleanupResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -6,9 +6,11 @@ gRPC server that provides data from Kubernetes clusters using krkn-lib
 
 import base64
 import logging
+import os
 from concurrent import futures
 
 import grpc
+from grpc_health.v1 import health, health_pb2, health_pb2_grpc
 from generated import dataprovider_pb2, dataprovider_pb2_grpc
 from krkn_lib.k8s import KrknKubernetes
 
@@ -61,6 +63,80 @@ class DataProviderServicer(dataprovider_pb2_grpc.DataProviderServiceServicer):
             context.set_details(f"Failed to get nodes: {str(e)}")
             return dataprovider_pb2.GetNodesResponse()
 
+    def VerifyCleanup(self, request, context):
+        """
+        Report what a chaos scenario left behind on a Kubernetes cluster
+
+        Args:
+            request: VerifyCleanupRequest containing kubeconfig in base64 and the
+                namespaces that must have been removed
+            context: gRPC context
+
+        Returns:
+            VerifyCleanupResponse containing the cordoned and not ready nodes and the
+            remaining namespaces
+        """
+        try:
+            logger.info("Received VerifyCleanup request")
+
+            kubeconfig_decoded = base64.b64decode(request.kubeconfig_base64).decode('utf-8')
+            krkn_k8s = KrknKubernetes(kubeconfig_path="", kubeconfig_string=kubeconfig_decoded)
+
+            response = dataprovider_pb2.VerifyCleanupResponse()
+            for node in krkn_k8s.cli.list_node().items:
+                if node.spec.unschedulable:
+                    response.cordoned_nodes.append(node.metadata.name)
+                ready = any(
+                    condition.type == "Ready" and condition.status == "True"
+                    for condition in (node.status.conditions or [])
+                )
+                if not ready:
+                    response.not_ready_nodes.append(node.metadata.name)
+
+            existing = set(krkn_k8s.list_namespaces())
+            response.remaining_namespaces.extend(
+                namespace for namespace in request.namespaces if namespace in existing
+            )
+
+            logger.info(
+                f"Cleanup verified: {len(response.cordoned_nodes)} cordoned nodes, "
+                f"{len(response.not_ready_nodes)} not ready nodes, "
+                f"{len(response.remaining_namespaces)} remaining namespaces"
+            )
+            return response
+
+        except Exception as e:
+            logger.error(f"Error in VerifyCleanup: {str(e)}", exc_info=True)
+            context.set_code(grpc.StatusCode.INTERNAL)
+            context.set_details(f"Failed to verify cleanup: {str(e)}")
+            return dataprovider_pb2.VerifyCleanupResponse()
+
+
+def tls_credentials():
+    """
+    Load the server credentials from the TLS Secret mounted in GRPC_TLS_DIR
+
+    The directory holds tls.crt and tls.key, and ca.crt to verify the client
+    certificates when GRPC_TLS_REQUIRE_CLIENT_CERT is true (mutual TLS).
+
+    Returns:
+        grpc.ServerCredentials, or None to serve in plaintext when GRPC_TLS_DIR is unset
+    """
+    tls_dir = os.environ.get('GRPC_TLS_DIR')
+    if not tls_dir:
+        return None
+
+    def read(name):
+        with open(os.path.join(tls_dir, name), 'rb') as f:
+            return f.read()
+
+    require_client_cert = os.environ.get('GRPC_TLS_REQUIRE_CLIENT_CERT', '').lower() == 'true'
+    return grpc.ssl_server_credentials(
+        [(read('tls.key'), read('tls.crt'))],
+        root_certificates=read('ca.crt') if require_client_cert else None,
+        require_client_auth=require_client_cert,
+    )
+
 
 def serve(port=50051):
     """
@@ -74,10 +150,19 @@ def serve(port=50051):
         DataProviderServicer(), server
     )
 
+    # Standard gRPC health service, checked by the readiness endpoint of the operator
+    health_servicer = health.HealthServicer()
+    health_pb2_grpc.add_HealthServicer_to_server(health_servicer, server)
+    health_servicer.set('', health_pb2.HealthCheckResponse.SERVING)
+
     server_address = f'[::]:{port}'
-    server.add_insecure_port(server_address)
+    credentials = tls_credentials()
+    if credentials is None:
+        server.add_insecure_port(server_address)
+    else:
+        server.add_secure_port(server_address, credentials)
 
-    logger.info(f"Starting gRPC server on {server_address}")
+    logger.info(f"Starting gRPC server on {server_address} (TLS: {credentials is not None})")
     server.start()
     logger.info("gRPC server started successfully")
 
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records state-changing API operations and forwards them to
+// pluggable sinks. Recent records are kept in memory so they can be queried
+// through the REST API.
+package audit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ResultSuccess marks a request that completed with a 2xx/3xx status
+	ResultSuccess = "success"
+	// ResultFailure marks a request that completed with a 4xx/5xx status
+	ResultFailure = "failure"
+
+	// DefaultRetention is the number of records kept in memory for queries
+	DefaultRetention = 1000
+	// QueueSize is the number of records waiting for the sinks; records are not
+	// forwarded to the sinks while the queue is full
+	QueueSize = 1000
+	// SinkTimeout is the time a sink has to write a record
+	SinkTimeout = 10 * time.Second
+)
+
+// Record is a single audit entry describing who did what and how it ended
+type Record struct {
+	Timestamp  time.Time `json:"timestamp"`
+	User       string    `json:"user"`
+	Role       string    `json:"role,omitempty"`
+	Action     string    `json:"action"`
+	Resource   string    `json:"resource"`
+	Result     string    `json:"result"`
+	StatusCode int       `json:"statusCode"`
+	ClientIP   string    `json:"clientIP,omitempty"`
+	// Detail adds context the request path does not carry, e.g. the break-glass
+	// exemption a run was created under
+	Detail string `json:"detail,omitempty"`
+}
+
+// Sink receives audit records. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+// Filter selects records returned by Recorder.Recent.
+// Empty fields match everything.
+type Filter struct {
+	User     string
+	Action   string
+	Resource string
+	Result   string
+	Since    time.Time
+	Limit    int
+}
+
+// Recorder fans records out to the configured sinks and keeps a bounded
+// in-memory history of the most recent entries. Records reach the sinks in the
+// background, in order, so that slow sinks never hold up requests.
+type Recorder struct {
+	mu        sync.RWMutex
+	sinks     []Sink
+	records   []Record
+	next      int
+	full      bool
+	retention int
+
+	queue     chan queuedRecord
+	startOnce sync.Once
+}
+
+// queuedRecord is a record waiting for the sinks, or a flush marker when flushed is set
+type queuedRecord struct {
+	ctx     context.Context
+	record  Record
+	flushed chan struct{}
+}
+
+// NewRecorder creates a Recorder that keeps up to retention records in memory
+func NewRecorder(retention int, sinks ...Sink) *Recorder {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Recorder{
+		sinks:     sinks,
+		records:   make([]Record, retention),
+		retention: retention,
+		queue:     make(chan queuedRecord, QueueSize),
+	}
+}
+
+// AddSink registers an additional sink
+func (r *Recorder) AddSink(sink Sink) {
+	if r == nil || sink == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sinks = append(r.sinks, sink)
+}
+
+// Record stores the record in memory and queues it for every sink. The sinks write it
+// with the values of ctx but not its cancellation, so records of finished requests are
+// still delivered. Sink errors and records dropped on a full queue are logged and never
+// returned: auditing must not fail requests.
+func (r *Recorder) Record(ctx context.Context, record Record) {
+	if r == nil {
+		return
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+	r.mu.Lock()
+	r.records[r.next] = record
+	r.next = (r.next + 1) % r.retention
+	if r.next == 0 {
+		r.full = true
+	}
+	r.mu.Unlock()
+
+	r.startOnce.Do(func() { go r.deliver() })
+	select {
+	case r.queue <- queuedRecord{ctx: context.WithoutCancel(ctx), record: record}:
+	default:
+		log.FromContext(ctx).WithName("audit").Error(nil, "Audit queue full, record not written to the sinks",
+			"user", record.User,
+			"action", record.Action,
+			"resource", record.Resource)
+	}
+}
+
+// Flush blocks until the records queued so far are written to the sinks
+func (r *Recorder) Flush() {
+	if r == nil {
+		return
+	}
+	r.startOnce.Do(func() { go r.deliver() })
+	flushed := make(chan struct{})
+	r.queue <- queuedRecord{flushed: flushed}
+	<-flushed
+}
+
+// deliver writes the queued records to the sinks, one at a time
+func (r *Recorder) deliver() {
+	for queued := range r.queue {
+		if queued.flushed != nil {
+			close(queued.flushed)
+			continue
+		}
+
+		r.mu.RLock()
+		sinks := make([]Sink, len(r.sinks))
+		copy(sinks, r.sinks)
+		r.mu.RUnlock()
+
+		logger := log.FromContext(queued.ctx).WithName("audit")
+		for _, sink := range sinks {
+			ctx, cancel := context.WithTimeout(queued.ctx, SinkTimeout)
+			err := sink.Write(ctx, queued.record)
+			cancel()
+			if err != nil {
+				logger.Error(err, "Failed to write audit record",
+					"user", queued.record.User,
+					"action", queued.record.Action,
+					"resource", queued.record.Resource)
+			}
+		}
+	}
+}
+
+// Recent returns the records matching the filter, newest first
+func (r *Recorder) Recent(filter Filter) []Record {
+	if r == nil {
+		return []Record{}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := r.next
+	if r.full {
+		count = r.retention
+	}
+
+	result := []Record{}
+	for i := 0; i < count; i++ {
+		idx := (r.next - 1 - i + r.retention) % r.retention
+		record := r.records[idx]
+		if !filter.matches(record) {
+			continue
+		}
+		result = append(result, record)
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+func (f Filter) matches(record Record) bool {
+	if f.User != "" && record.User != f.User {
+		return false
+	}
+	if f.Action != "" && !strings.EqualFold(record.Action, f.Action) {
+		return false
+	}
+	if f.Resource != "" && !strings.HasPrefix(record.Resource, f.Resource) {
+		return false
+	}
+	if f.Result != "" && record.Result != f.Result {
+		return false
+	}
+	if !f.Since.IsZero() && record.Timestamp.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// ResultForStatus maps an HTTP status code to an audit result
+func ResultForStatus(statusCode int) string {
+	if statusCode >= 400 {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
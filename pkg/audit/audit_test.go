@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type memorySink struct {
+	records []Record
+	err     error
+}
+
+func (s *memorySink) Write(_ context.Context, record Record) error {
+	s.records = append(s.records, record)
+	return s.err
+}
+
+func TestRecorder_RecentNewestFirstAndBounded(t *testing.T) {
+	recorder := NewRecorder(3)
+
+	for _, user := range []string{"a", "b", "c", "d"} {
+		recorder.Record(context.Background(), Record{User: user, Action: "create", Resource: "/x"})
+	}
+
+	recent := recorder.Recent(Filter{})
+	if len(recent) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(recent))
+	}
+
+	got := []string{recent[0].User, recent[1].User, recent[2].User}
+	if strings.Join(got, ",") != "d,c,b" {
+		t.Errorf("Expected newest-first d,c,b, got %v", got)
+	}
+
+	if recent[0].Timestamp.IsZero() {
+		t.Error("Expected timestamp to be set")
+	}
+}
+
+func TestRecorder_Filter(t *testing.T) {
+	recorder := NewRecorder(10)
+	ctx := context.Background()
+	old := time.Now().Add(-time.Hour)
+
+	recorder.Record(ctx, Record{Timestamp: old, User: "alice", Action: "delete", Resource: "/api/v1/operator/targets/1", Result: ResultSuccess})
+	recorder.Record(ctx, Record{User: "bob", Action: "create", Resource: "/api/v1/users", Result: ResultFailure})
+	recorder.Record(ctx, Record{User: "alice", Action: "create", Resource: "/api/v1/operator/targets", Result: ResultSuccess})
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected int
+	}{
+		{name: "no filter", filter: Filter{}, expected: 3},
+		{name: "by user", filter: Filter{User: "alice"}, expected: 2},
+		{name: "by action", filter: Filter{Action: "CREATE"}, expected: 2},
+		{name: "by resource prefix", filter: Filter{Resource: "/api/v1/operator/targets"}, expected: 2},
+		{name: "by result", filter: Filter{Result: ResultFailure}, expected: 1},
+		{name: "since", filter: Filter{Since: time.Now().Add(-time.Minute)}, expected: 2},
+		{name: "limit", filter: Filter{Limit: 1}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(recorder.Recent(tt.filter)); got != tt.expected {
+				t.Errorf("Expected %d records, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRecorder_SinkErrorsDoNotStopFanOut(t *testing.T) {
+	failing := &memorySink{err: errors.New("boom")}
+	working := &memorySink{}
+	recorder := NewRecorder(10, failing)
+	recorder.AddSink(working)
+
+	recorder.Record(context.Background(), Record{User: "alice", Action: "create"})
+	recorder.Flush()
+
+	if len(failing.records) != 1 || len(working.records) != 1 {
+		t.Errorf("Expected both sinks to receive the record, got %d and %d",
+			len(failing.records), len(working.records))
+	}
+	if len(recorder.Recent(Filter{})) != 1 {
+		t.Error("Expected record to be kept in memory")
+	}
+}
+
+// blockingSink holds every write until release is closed, and records the context error
+// seen once released
+type blockingSink struct {
+	release chan struct{}
+	errs    chan error
+}
+
+func (s *blockingSink) Write(ctx context.Context, _ Record) error {
+	<-s.release
+	s.errs <- ctx.Err()
+	return nil
+}
+
+func TestRecorder_WritesSinksInBackground(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{}), errs: make(chan error, 1)}
+	recorder := NewRecorder(10, sink)
+
+	// The request ends, and its context is cancelled, before the sink is done
+	ctx, cancel := context.WithCancel(context.Background())
+	recorded := make(chan struct{})
+	go func() {
+		recorder.Record(ctx, Record{User: "alice", Action: "create"})
+		close(recorded)
+	}()
+	select {
+	case <-recorded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Record not to wait for the sinks")
+	}
+	cancel()
+
+	close(sink.release)
+	recorder.Flush()
+	if err := <-sink.errs; err != nil {
+		t.Errorf("Expected the sink to write without the request cancellation, got %v", err)
+	}
+	if len(recorder.Recent(Filter{})) != 1 {
+		t.Error("Expected record to be kept in memory")
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var recorder *Recorder
+	recorder.Record(context.Background(), Record{})
+	if got := recorder.Recent(Filter{}); len(got) != 0 {
+		t.Errorf("Expected no records from nil recorder, got %d", len(got))
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("Failed to create file sink: %v", err)
+	}
+
+	for _, user := range []string{"alice", "bob"} {
+		if err := sink.Write(context.Background(), Record{User: user, Action: "delete"}); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	_ = sink.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read audit file: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+
+	var record Record
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatalf("Failed to parse audit line: %v", err)
+	}
+	if record.User != "bob" {
+		t.Errorf("Expected user bob, got %s", record.User)
+	}
+}
+
+func TestEventSink(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	sink := NewEventSink(clientset, "krkn-operator-system")
+
+	err := sink.Write(context.Background(), Record{
+		Timestamp: time.Now(),
+		User:      "alice",
+		Action:    "delete",
+		Resource:  "/api/v1/operator/targets/1",
+		Result:    ResultFailure,
+		Detail:    "exemption=abc",
+	})
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	events, err := clientset.CoreV1().Events("krkn-operator-system").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list events: %v", err)
+	}
+	if len(events.Items) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.Items))
+	}
+
+	event := events.Items[0]
+	if event.Reason != EventReason || event.Type != "Warning" {
+		t.Errorf("Unexpected event reason/type: %s/%s", event.Reason, event.Type)
+	}
+	if !strings.Contains(event.Message, "user=alice") {
+		t.Errorf("Expected message to contain user, got %q", event.Message)
+	}
+	if !strings.HasSuffix(event.Message, "detail=exemption=abc") {
+		t.Errorf("Expected message to end with the detail, got %q", event.Message)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EventReason is the reason set on Kubernetes Events emitted by EventSink
+const EventReason = "APIAudit"
+
+// EventSink writes audit records as Kubernetes Events attached to the operator namespace
+type EventSink struct {
+	clientset kubernetes.Interface
+	namespace string
+}
+
+// NewEventSink creates a sink that emits Events in the given namespace
+func NewEventSink(clientset kubernetes.Interface, namespace string) *EventSink {
+	return &EventSink{clientset: clientset, namespace: namespace}
+}
+
+// Write implements Sink
+func (s *EventSink) Write(ctx context.Context, record Record) error {
+	eventType := corev1.EventTypeNormal
+	if record.Result == ResultFailure {
+		eventType = corev1.EventTypeWarning
+	}
+
+	message := fmt.Sprintf("user=%s action=%s resource=%s result=%s status=%d",
+		record.User, record.Action, record.Resource, record.Result, record.StatusCode)
+	if record.Detail != "" {
+		message += " detail=" + record.Detail
+	}
+
+	now := metav1.NewTime(record.Timestamp)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "krkn-audit-",
+			Namespace:    s.namespace,
+			Labels: map[string]string{
+				"krkn.krkn-chaos.dev/audit": "true",
+			},
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       s.namespace,
+		},
+		Reason:         EventReason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: "krkn-operator-api"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	_, err := s.clientset.CoreV1().Events(s.namespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
+
+// FileSink appends audit records to a file as JSON lines
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens (or creates) the file at path in append mode
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Write implements Sink
+func (s *FileSink) Write(_ context.Context, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the underlying file
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// WebhookSink POSTs each audit record as JSON to an HTTP endpoint
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink posting records to url
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Write implements Sink
+func (s *WebhookSink) Write(ctx context.Context, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
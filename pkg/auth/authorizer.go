@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Actions of the authorization requests, derived from the HTTP method
+const (
+	ActionRead   = "read"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Authorizer modes selectable through AuthorizerConfig
+const (
+	// AuthorizerModeRBAC uses the built-in role rules
+	AuthorizerModeRBAC = "rbac"
+	// AuthorizerModeWebhook delegates the decisions to an external policy engine
+	AuthorizerModeWebhook = "webhook"
+)
+
+// Defaults of the authorizer configuration
+const (
+	defaultWebhookTimeout = 5 * time.Second
+	maxWebhookResponse    = 64 * 1024
+)
+
+// AuthorizationRequest is the question asked to an Authorizer: may subject perform
+// action on resource
+type AuthorizationRequest struct {
+	// Subject is the user ID of the authenticated user
+	Subject string `json:"subject"`
+	// Role is the role of the subject
+	Role string `json:"role"`
+	// Action is one of ActionRead, ActionCreate, ActionUpdate, ActionDelete
+	Action string `json:"action"`
+	// Resource is the request path, e.g. /api/v1/scenarios/run/my-run
+	Resource string `json:"resource"`
+	// BreakGlass is the ID of the verified break-glass exemption sent with the request,
+	// so a policy engine can let restricted operations through while it is valid
+	BreakGlass string `json:"breakGlass,omitempty"`
+}
+
+// Decision is the answer of an Authorizer
+type Decision struct {
+	// Allowed is true when the request may proceed
+	Allowed bool `json:"allowed"`
+	// Reason explains a denial, returned to the client
+	Reason string `json:"reason,omitempty"`
+}
+
+// Authorizer decides whether an authenticated request is allowed. It runs before the
+// handlers, which still enforce their own ownership and group checks.
+type Authorizer interface {
+	// Authorize returns the decision for the request. An error means no decision could
+	// be made, and the request is rejected.
+	Authorize(ctx context.Context, request AuthorizationRequest) (Decision, error)
+}
+
+// RBACRule grants a set of actions on a set of resources. "*" matches any action or
+// resource, and a resource ending with "*" matches every resource with that prefix.
+type RBACRule struct {
+	Actions   []string
+	Resources []string
+}
+
+// RBACAuthorizer is the built-in Authorizer, granting each role the actions of its rules
+type RBACAuthorizer struct {
+	Rules map[Role][]RBACRule
+}
+
+// NewRBACAuthorizer creates the built-in Authorizer with the default rules: admins and
+// users may call every endpoint, the handlers restricting what users can do
+//
+// Returns a new RBACAuthorizer instance
+func NewRBACAuthorizer() *RBACAuthorizer {
+	all := []RBACRule{{Actions: []string{"*"}, Resources: []string{"*"}}}
+	return &RBACAuthorizer{
+		Rules: map[Role][]RBACRule{
+			RoleAdmin: all,
+			RoleUser:  all,
+		},
+	}
+}
+
+// Authorize implements Authorizer
+func (a *RBACAuthorizer) Authorize(_ context.Context, request AuthorizationRequest) (Decision, error) {
+	for _, rule := range a.Rules[Role(request.Role)] {
+		if matchesAny(rule.Actions, request.Action) && matchesAny(rule.Resources, request.Resource) {
+			return Decision{Allowed: true}, nil
+		}
+	}
+	return Decision{Reason: fmt.Sprintf("role %q may not %s %s", request.Role, request.Action, request.Resource)}, nil
+}
+
+// matchesAny reports whether value matches one of the patterns
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == value {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookAuthorizer delegates the decisions to an external policy engine (OPA, Cedar,
+// ...). The AuthorizationRequest is POSTed as JSON to URL, which answers with a Decision.
+// Any other status than 200 is an error.
+type WebhookAuthorizer struct {
+	// URL is the endpoint of the policy engine
+	URL string
+	// Client sends the requests; a client with Timeout is used when nil
+	Client *http.Client
+	// Timeout bounds each decision (defaultWebhookTimeout when zero)
+	Timeout time.Duration
+}
+
+// Authorize implements Authorizer
+func (a *WebhookAuthorizer) Authorize(ctx context.Context, request AuthorizationRequest) (Decision, error) {
+	timeout := a.Timeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return Decision{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, fmt.Errorf("authorization webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("authorization webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Decision{}, fmt.Errorf("authorization webhook: unexpected status %d", resp.StatusCode)
+	}
+	var decision Decision
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxWebhookResponse)).Decode(&decision); err != nil {
+		return Decision{}, fmt.Errorf("authorization webhook: invalid response: %w", err)
+	}
+	return decision, nil
+}
+
+// CachedAuthorizer remembers the decisions of another Authorizer for TTL, so the policy
+// engine is not asked again for every request of a session. Errors are not cached.
+type CachedAuthorizer struct {
+	authorizer Authorizer
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[AuthorizationRequest]cachedDecision
+}
+
+// cachedDecision is a decision and when it expires
+type cachedDecision struct {
+	decision Decision
+	expires  time.Time
+}
+
+// NewCachedAuthorizer creates an Authorizer caching the decisions of authorizer
+//
+// Parameters:
+//   - authorizer: The Authorizer asked on cache misses
+//   - ttl: How long a decision is reused
+//
+// Returns a new CachedAuthorizer instance
+func NewCachedAuthorizer(authorizer Authorizer, ttl time.Duration) *CachedAuthorizer {
+	return &CachedAuthorizer{
+		authorizer: authorizer,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    make(map[AuthorizationRequest]cachedDecision),
+	}
+}
+
+// Authorize implements Authorizer
+func (a *CachedAuthorizer) Authorize(ctx context.Context, request AuthorizationRequest) (Decision, error) {
+	now := a.now()
+	a.mu.Lock()
+	entry, ok := a.entries[request]
+	a.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.decision, nil
+	}
+
+	decision, err := a.authorizer.Authorize(ctx, request)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Drop the expired entries so the cache stays bounded by the active sessions
+	for key, cached := range a.entries {
+		if !now.Before(cached.expires) {
+			delete(a.entries, key)
+		}
+	}
+	a.entries[request] = cachedDecision{decision: decision, expires: now.Add(a.ttl)}
+	return decision, nil
+}
+
+// AuthorizerConfig selects and configures the Authorizer enforced by the middleware
+type AuthorizerConfig struct {
+	// Mode is AuthorizerModeRBAC (the default when empty) or AuthorizerModeWebhook
+	Mode string
+	// WebhookURL is the endpoint of the policy engine in webhook mode
+	WebhookURL string
+	// WebhookTimeout bounds each webhook decision (5s when zero)
+	WebhookTimeout time.Duration
+	// CacheTTL caches the decisions for this long; zero disables the cache
+	CacheTTL time.Duration
+}
+
+// NewAuthorizer creates the Authorizer described by config
+//
+// Parameters:
+//   - config: The authorizer mode and its settings
+//
+// Returns the Authorizer, or an error when the configuration is invalid
+func NewAuthorizer(config AuthorizerConfig) (Authorizer, error) {
+	var authorizer Authorizer
+	switch config.Mode {
+	case "", AuthorizerModeRBAC:
+		authorizer = NewRBACAuthorizer()
+	case AuthorizerModeWebhook:
+		if config.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook authorizer requires a URL")
+		}
+		if !strings.HasPrefix(config.WebhookURL, "http://") && !strings.HasPrefix(config.WebhookURL, "https://") {
+			return nil, fmt.Errorf("invalid authorization webhook URL %q: expected http(s)://", config.WebhookURL)
+		}
+		timeout := config.WebhookTimeout
+		if timeout == 0 {
+			timeout = defaultWebhookTimeout
+		}
+		authorizer = &WebhookAuthorizer{
+			URL:     config.WebhookURL,
+			Client:  &http.Client{Timeout: timeout},
+			Timeout: timeout,
+		}
+	default:
+		return nil, fmt.Errorf("unknown authorizer mode %q, expected %q or %q", config.Mode, AuthorizerModeRBAC, AuthorizerModeWebhook)
+	}
+
+	if config.CacheTTL > 0 {
+		authorizer = NewCachedAuthorizer(authorizer, config.CacheTTL)
+	}
+	return authorizer, nil
+}
+
+// actionForMethod maps an HTTP method to the action of an authorization request
+func actionForMethod(method string) string {
+	switch method {
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	default:
+		return ActionRead
+	}
+}
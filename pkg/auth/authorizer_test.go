@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// countingAuthorizer returns a fixed decision, counts the calls and keeps the last request
+type countingAuthorizer struct {
+	decision Decision
+	err      error
+	calls    int
+	last     AuthorizationRequest
+}
+
+func (a *countingAuthorizer) Authorize(_ context.Context, request AuthorizationRequest) (Decision, error) {
+	a.calls++
+	a.last = request
+	return a.decision, a.err
+}
+
+func TestRBACAuthorizer(t *testing.T) {
+	authorizer := &RBACAuthorizer{Rules: map[Role][]RBACRule{
+		RoleAdmin: {{Actions: []string{"*"}, Resources: []string{"*"}}},
+		RoleUser: {
+			{Actions: []string{ActionRead}, Resources: []string{"*"}},
+			{Actions: []string{ActionCreate}, Resources: []string{"/api/v1/scenarios/run*"}},
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		request AuthorizationRequest
+		allowed bool
+	}{
+		{"admin deletes", AuthorizationRequest{Role: "admin", Action: ActionDelete, Resource: "/api/v1/users/x"}, true},
+		{"user reads", AuthorizationRequest{Role: "user", Action: ActionRead, Resource: "/api/v1/users"}, true},
+		{"user creates run", AuthorizationRequest{Role: "user", Action: ActionCreate, Resource: "/api/v1/scenarios/run"}, true},
+		{"user creates user", AuthorizationRequest{Role: "user", Action: ActionCreate, Resource: "/api/v1/users"}, false},
+		{"unknown role", AuthorizationRequest{Role: "guest", Action: ActionRead, Resource: "/api/v1/users"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := authorizer.Authorize(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("Authorize() error = %v", err)
+			}
+			if decision.Allowed != tt.allowed {
+				t.Errorf("expected allowed=%v, got %v", tt.allowed, decision)
+			}
+			if !decision.Allowed && decision.Reason == "" {
+				t.Error("expected a denial reason")
+			}
+		})
+	}
+}
+
+func TestWebhookAuthorizer(t *testing.T) {
+	var received AuthorizationRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if received.Subject == "broken@example.com" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Decision{Allowed: received.Action == ActionRead, Reason: "read only"})
+	}))
+	defer server.Close()
+
+	authorizer := &WebhookAuthorizer{URL: server.URL}
+	request := AuthorizationRequest{Subject: "user@example.com", Role: "user", Action: ActionRead, Resource: "/api/v1/targets"}
+	decision, err := authorizer.Authorize(context.Background(), request)
+	if err != nil || !decision.Allowed {
+		t.Fatalf("expected read to be allowed, got %v %v", decision, err)
+	}
+	if received != request {
+		t.Errorf("expected the webhook to receive %v, got %v", request, received)
+	}
+
+	request.Action = ActionDelete
+	decision, err = authorizer.Authorize(context.Background(), request)
+	if err != nil || decision.Allowed || decision.Reason != "read only" {
+		t.Errorf("expected delete to be denied with the webhook reason, got %v %v", decision, err)
+	}
+
+	request.Subject = "broken@example.com"
+	if _, err := authorizer.Authorize(context.Background(), request); err == nil {
+		t.Error("expected an error on webhook failure")
+	}
+}
+
+func TestCachedAuthorizer(t *testing.T) {
+	inner := &countingAuthorizer{decision: Decision{Allowed: true}}
+	cached := NewCachedAuthorizer(inner, time.Minute)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	request := AuthorizationRequest{Subject: "user@example.com", Role: "user", Action: ActionRead, Resource: "/api/v1/targets"}
+	for range 3 {
+		if decision, err := cached.Authorize(context.Background(), request); err != nil || !decision.Allowed {
+			t.Fatalf("expected allowed, got %v %v", decision, err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected 1 call within the TTL, got %d", inner.calls)
+	}
+
+	request.Action = ActionDelete
+	_, _ = cached.Authorize(context.Background(), request)
+	if inner.calls != 2 {
+		t.Errorf("expected a call for another action, got %d", inner.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	_, _ = cached.Authorize(context.Background(), request)
+	if inner.calls != 3 {
+		t.Errorf("expected a call after the TTL, got %d", inner.calls)
+	}
+
+	inner.err = errors.New("unreachable")
+	now = now.Add(2 * time.Minute)
+	if _, err := cached.Authorize(context.Background(), request); err == nil {
+		t.Error("expected the error of the authorizer")
+	}
+	inner.err = nil
+	_, _ = cached.Authorize(context.Background(), request)
+	if inner.calls != 5 {
+		t.Errorf("expected errors not to be cached, got %d calls", inner.calls)
+	}
+}
+
+func TestNewAuthorizer(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AuthorizerConfig
+		wantErr bool
+	}{
+		{"default", AuthorizerConfig{}, false},
+		{"rbac cached", AuthorizerConfig{Mode: AuthorizerModeRBAC, CacheTTL: time.Minute}, false},
+		{"webhook", AuthorizerConfig{Mode: AuthorizerModeWebhook, WebhookURL: "https://opa:8181/v1/data/krkn/allow"}, false},
+		{"webhook without URL", AuthorizerConfig{Mode: AuthorizerModeWebhook}, true},
+		{"webhook invalid URL", AuthorizerConfig{Mode: AuthorizerModeWebhook, WebhookURL: "opa:8181"}, true},
+		{"unknown mode", AuthorizerConfig{Mode: "ldap"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer, err := NewAuthorizer(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAuthorizer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && authorizer == nil {
+				t.Error("expected an authorizer")
+			}
+		})
+	}
+}
+
+func TestRequireAuth_Authorizer(t *testing.T) {
+	tg := NewTokenGenerator(
+		[]byte("test-secret-key-at-least-32-bytes-long"),
+		24*time.Hour,
+		"krkn-operator",
+	)
+	token, err := tg.GenerateToken("user@example.com", "user", "Test", "User", "Org")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		authorizer *countingAuthorizer
+		wantStatus int
+	}{
+		{"allowed", &countingAuthorizer{decision: Decision{Allowed: true}}, http.StatusOK},
+		{"denied", &countingAuthorizer{decision: Decision{Reason: "policy"}}, http.StatusForbidden},
+		{"unavailable", &countingAuthorizer{err: errors.New("unreachable")}, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewMiddleware(tg)
+			middleware.SetAuthorizer(tt.authorizer)
+			handler := middleware.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/targets/x", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.authorizer.calls != 1 {
+				t.Errorf("expected the authorizer to be asked once, got %d", tt.authorizer.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// BreakGlassHeader carries the break-glass exemption token of a request
+	BreakGlassHeader = "X-Krkn-Break-Glass"
+	// BreakGlassAnnotation records on a KrknScenarioRun the ID of the exemption it was
+	// created under. The token itself is kept in the Secret named by BreakGlassRecordName,
+	// which the validating webhook checks again.
+	BreakGlassAnnotation = "krkn.krkn-chaos.dev/break-glass"
+	// BreakGlassRecordKey is the key of the exemption token in the record Secret of a run
+	BreakGlassRecordKey = "token"
+	// BreakGlassAudience is the audience of exemption tokens, which are never login tokens
+	BreakGlassAudience = "krkn-operator/break-glass"
+	// BreakGlassKey is the context key of the verified exemption of a request
+	BreakGlassKey ContextKey = "break-glass"
+
+	// DefaultBreakGlassDuration is how long an exemption is valid when no duration is given
+	DefaultBreakGlassDuration = time.Hour
+	// MaxBreakGlassDuration is the longest an exemption can be valid
+	MaxBreakGlassDuration = 24 * time.Hour
+
+	// RestrictedScenariosEnv is a comma-separated list of the scenarios that only run
+	// under a break-glass exemption; a name ending with "*" matches every scenario with
+	// that prefix
+	RestrictedScenariosEnv = "RESTRICTED_SCENARIOS"
+)
+
+// BreakGlassGrant describes the exemption an admin issues
+type BreakGlassGrant struct {
+	// Scenario is the restricted scenario the exemption lets run
+	Scenario string
+	// Clusters are the names of the clusters the scenario may run on
+	Clusters []string
+	// Grantee is the user ID allowed to use the exemption; anyone holding the token when empty
+	Grantee string
+	// Reason is why the exemption was issued
+	Reason string
+	// Duration is how long the exemption is valid (DefaultBreakGlassDuration when zero)
+	Duration time.Duration
+}
+
+// BreakGlassClaims are the claims of a break-glass exemption token. The ID identifies
+// the exemption in the audit log and the subject is the admin who issued it.
+type BreakGlassClaims struct {
+	Scenario string   `json:"scenario"`
+	Clusters []string `json:"clusters"`
+	Grantee  string   `json:"grantee,omitempty"`
+	Reason   string   `json:"reason"`
+	jwt.RegisteredClaims
+}
+
+// GenerateBreakGlassToken signs an exemption token for grant.
+//
+// Parameters:
+//   - issuedBy: The user ID of the admin issuing the exemption
+//   - grant: The scenario, clusters and duration of the exemption
+//
+// Returns the signed token and its claims, or an error if the grant is invalid.
+func (tg *TokenGenerator) GenerateBreakGlassToken(issuedBy string, grant BreakGlassGrant) (string, *BreakGlassClaims, error) {
+	if grant.Scenario == "" || len(grant.Clusters) == 0 {
+		return "", nil, fmt.Errorf("an exemption needs a scenario and at least one cluster")
+	}
+	duration := grant.Duration
+	if duration == 0 {
+		duration = DefaultBreakGlassDuration
+	}
+	if duration < 0 || duration > MaxBreakGlassDuration {
+		return "", nil, fmt.Errorf("exemption duration must be between 0 and %s", MaxBreakGlassDuration)
+	}
+
+	now := time.Now()
+	claims := &BreakGlassClaims{
+		Scenario: grant.Scenario,
+		Clusters: grant.Clusters,
+		Grantee:  grant.Grantee,
+		Reason:   grant.Reason,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Audience:  jwt.ClaimStrings{BreakGlassAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    tg.issuer,
+			Subject:   issuedBy,
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(tg.secretKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign exemption: %w", err)
+	}
+	return token, claims, nil
+}
+
+// ValidateBreakGlassToken validates an exemption token and returns its claims.
+//
+// Parameters:
+//   - tokenString: The exemption token to validate
+//
+// Returns the claims if valid, or an error if the token is invalid, expired or a login token.
+func (tg *TokenGenerator) ValidateBreakGlassToken(tokenString string) (*BreakGlassClaims, error) {
+	claims := &BreakGlassClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return tg.secretKey, nil
+	}, jwt.WithAudience(BreakGlassAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse exemption: %w", err)
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid exemption")
+	}
+	return claims, nil
+}
+
+// Covers checks that the exemption lets scenario run on every cluster of clusters, a map
+// of provider name to cluster names
+func (c *BreakGlassClaims) Covers(scenario string, clusters map[string][]string) error {
+	if scenario != c.Scenario {
+		return fmt.Errorf("exemption %s is for scenario %q, not %q", c.ID, c.Scenario, scenario)
+	}
+	for _, names := range clusters {
+		for _, name := range names {
+			if !slices.Contains(c.Clusters, name) {
+				return fmt.Errorf("exemption %s does not cover cluster %q", c.ID, name)
+			}
+		}
+	}
+	return nil
+}
+
+// GetBreakGlassFromContext returns the verified break-glass exemption of the request,
+// nil when none was sent
+func GetBreakGlassFromContext(ctx context.Context) *BreakGlassClaims {
+	claims, ok := ctx.Value(BreakGlassKey).(*BreakGlassClaims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+// BreakGlassRecordName returns the name of the Secret recording the exemption token a
+// scenario run was created under. Only the API writes it, after verifying the token
+// against the authenticated user, so copying the annotation to another run does not
+// copy the exemption.
+func BreakGlassRecordName(scenarioRunName string) string {
+	return scenarioRunName + "-break-glass"
+}
+
+// ScenarioPolicy lists the scenarios that are blocked unless the run carries a
+// break-glass exemption
+type ScenarioPolicy struct {
+	// RestrictedScenarios are scenario names; a name ending with "*" matches every
+	// scenario with that prefix. They also match the images named or tagged after the
+	// scenario, e.g. quay.io/krkn-chaos/krkn-hub:node-scenarios.
+	RestrictedScenarios []string
+}
+
+// ScenarioPolicyFromEnv reads the restricted scenarios from RestrictedScenariosEnv
+func ScenarioPolicyFromEnv() ScenarioPolicy {
+	var policy ScenarioPolicy
+	for _, name := range strings.Split(os.Getenv(RestrictedScenariosEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			policy.RestrictedScenarios = append(policy.RestrictedScenarios, name)
+		}
+	}
+	return policy
+}
+
+// IsRestricted reports whether a run of scenarioName with scenarioImage only runs under
+// a break-glass exemption
+func (p ScenarioPolicy) IsRestricted(scenarioName, scenarioImage string) bool {
+	return len(p.Restricted(scenarioName, scenarioImage)) > 0
+}
+
+// Restricted returns the restricted scenarios a run of scenarioName with scenarioImage
+// executes: scenarioName, and the scenarios the image is named or tagged after, so a run
+// cannot escape the policy under another name. An exemption must cover all of them.
+func (p ScenarioPolicy) Restricted(scenarioName, scenarioImage string) []string {
+	var restricted []string
+	for _, scenario := range append([]string{scenarioName}, imageScenarios(scenarioImage)...) {
+		if scenario != "" && !slices.Contains(restricted, scenario) && matchesAny(p.RestrictedScenarios, scenario) {
+			restricted = append(restricted, scenario)
+		}
+	}
+	return restricted
+}
+
+// imageScenarios returns the scenario names an image reference may stand for: the last
+// path element of its repository and its tag
+func imageScenarios(image string) []string {
+	image, _, _ = strings.Cut(image, "@")
+	repository, tag, _ := strings.Cut(image[strings.LastIndex(image, "/")+1:], ":")
+	return []string{repository, tag}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newBreakGlassTokenGenerator() *TokenGenerator {
+	return NewTokenGenerator([]byte("test-secret-key-at-least-32-bytes-long"), 24*time.Hour, "krkn-operator")
+}
+
+func TestGenerateBreakGlassToken(t *testing.T) {
+	tg := newBreakGlassTokenGenerator()
+
+	token, claims, err := tg.GenerateBreakGlassToken("admin@example.com", BreakGlassGrant{
+		Scenario: "node-scenarios",
+		Clusters: []string{"prod-1", "prod-2"},
+		Grantee:  "user@example.com",
+		Reason:   "failover drill",
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate exemption: %v", err)
+	}
+	if claims.ID == "" || claims.Subject != "admin@example.com" {
+		t.Errorf("Expected an ID and the issuing admin as subject, got %+v", claims.RegisteredClaims)
+	}
+	if ttl := time.Until(claims.ExpiresAt.Time); ttl <= 0 || ttl > DefaultBreakGlassDuration {
+		t.Errorf("Expected the default duration, expires in %s", ttl)
+	}
+
+	validated, err := tg.ValidateBreakGlassToken(token)
+	if err != nil {
+		t.Fatalf("Failed to validate exemption: %v", err)
+	}
+	if validated.ID != claims.ID || validated.Scenario != "node-scenarios" || validated.Grantee != "user@example.com" ||
+		len(validated.Clusters) != 2 {
+		t.Errorf("Unexpected claims: %+v", validated)
+	}
+
+	// An exemption never authenticates a user
+	if _, err := tg.ValidateToken(token); err == nil {
+		t.Error("Expected the exemption to be rejected as a login token")
+	}
+
+	tests := []struct {
+		name  string
+		grant BreakGlassGrant
+	}{
+		{"no scenario", BreakGlassGrant{Clusters: []string{"prod-1"}}},
+		{"no cluster", BreakGlassGrant{Scenario: "node-scenarios"}},
+		{"too long", BreakGlassGrant{Scenario: "node-scenarios", Clusters: []string{"prod-1"}, Duration: 25 * time.Hour}},
+		{"negative", BreakGlassGrant{Scenario: "node-scenarios", Clusters: []string{"prod-1"}, Duration: -time.Minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tg.GenerateBreakGlassToken("admin@example.com", tt.grant); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestValidateBreakGlassToken_Invalid(t *testing.T) {
+	tg := newBreakGlassTokenGenerator()
+
+	loginToken, err := tg.GenerateToken("admin@example.com", "admin", "Test", "User", "Org")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if _, err := tg.ValidateBreakGlassToken(loginToken); err == nil {
+		t.Error("Expected a login token to be rejected as an exemption")
+	}
+
+	expired := &BreakGlassClaims{
+		Scenario: "node-scenarios",
+		Clusters: []string{"prod-1"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "expired",
+			Audience:  jwt.ClaimStrings{BreakGlassAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	}
+	expiredToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, expired).SignedString(tg.secretKey)
+	if err != nil {
+		t.Fatalf("Failed to sign exemption: %v", err)
+	}
+	if _, err := tg.ValidateBreakGlassToken(expiredToken); err == nil {
+		t.Error("Expected an expired exemption to be rejected")
+	}
+
+	token, _, err := tg.GenerateBreakGlassToken("admin@example.com", BreakGlassGrant{
+		Scenario: "node-scenarios",
+		Clusters: []string{"prod-1"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate exemption: %v", err)
+	}
+	other := NewTokenGenerator([]byte("another-secret-key-at-least-32-bytes"), time.Hour, "krkn-operator")
+	if _, err := other.ValidateBreakGlassToken(token); err == nil {
+		t.Error("Expected an exemption signed with another key to be rejected")
+	}
+}
+
+func TestBreakGlassClaimsCovers(t *testing.T) {
+	claims := &BreakGlassClaims{Scenario: "node-scenarios", Clusters: []string{"prod-1", "prod-2"}}
+
+	if err := claims.Covers("node-scenarios", map[string][]string{"a": {"prod-1"}, "b": {"prod-2"}}); err != nil {
+		t.Errorf("Expected the clusters to be covered, got %v", err)
+	}
+	if err := claims.Covers("pod-scenarios", map[string][]string{"a": {"prod-1"}}); err == nil {
+		t.Error("Expected another scenario to be rejected")
+	}
+	if err := claims.Covers("node-scenarios", map[string][]string{"a": {"prod-1", "prod-3"}}); err == nil {
+		t.Error("Expected an uncovered cluster to be rejected")
+	}
+}
+
+func TestScenarioPolicy(t *testing.T) {
+	t.Setenv(RestrictedScenariosEnv, " node-scenarios , zone-*,")
+	policy := ScenarioPolicyFromEnv()
+
+	for scenario, want := range map[string]bool{
+		"node-scenarios":    true,
+		"zone-outages":      true,
+		"pod-scenarios":     false,
+		"node-scenarios-v2": false,
+		"":                  false,
+	} {
+		if got := policy.IsRestricted(scenario, ""); got != want {
+			t.Errorf("IsRestricted(%q) = %v, want %v", scenario, got, want)
+		}
+	}
+	if (ScenarioPolicy{}).IsRestricted("node-scenarios", "") {
+		t.Error("Expected no scenario to be restricted by default")
+	}
+
+	// Renaming the run does not escape the policy of its image
+	for _, tt := range []struct {
+		scenarioName  string
+		scenarioImage string
+		want          []string
+	}{
+		{"pod-scenarios", "quay.io/krkn-chaos/krkn-hub:pod-scenarios", nil},
+		{"innocent", "quay.io/krkn-chaos/krkn-hub:node-scenarios", []string{"node-scenarios"}},
+		{"innocent", "registry.local:5000/chaos/zone-outages@sha256:0123", []string{"zone-outages"}},
+		{"node-scenarios", "quay.io/krkn-chaos/krkn-hub:node-scenarios", []string{"node-scenarios"}},
+		{"node-scenarios", "quay.io/krkn-chaos/krkn-hub:zone-outages", []string{"node-scenarios", "zone-outages"}},
+	} {
+		if got := policy.Restricted(tt.scenarioName, tt.scenarioImage); !slices.Equal(got, tt.want) {
+			t.Errorf("Restricted(%q, %q) = %v, want %v", tt.scenarioName, tt.scenarioImage, got, tt.want)
+		}
+	}
+}
+
+func TestRequireAuth_BreakGlass(t *testing.T) {
+	tg := newBreakGlassTokenGenerator()
+	token, err := tg.GenerateToken("user@example.com", "user", "Test", "User", "Org")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	grant := BreakGlassGrant{Scenario: "node-scenarios", Clusters: []string{"prod-1"}}
+	exemption, claims, err := tg.GenerateBreakGlassToken("admin@example.com", grant)
+	if err != nil {
+		t.Fatalf("Failed to generate exemption: %v", err)
+	}
+	grant.Grantee = "other@example.com"
+	otherUserExemption, _, err := tg.GenerateBreakGlassToken("admin@example.com", grant)
+	if err != nil {
+		t.Fatalf("Failed to generate exemption: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		exemption  string
+		wantStatus int
+		wantID     string
+	}{
+		{"no exemption", "", http.StatusOK, ""},
+		{"valid exemption", exemption, http.StatusOK, claims.ID},
+		{"invalid exemption", "not-a-token", http.StatusForbidden, ""},
+		{"login token as exemption", token, http.StatusForbidden, ""},
+		{"exemption of another user", otherUserExemption, http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer := &countingAuthorizer{decision: Decision{Allowed: true}}
+			middleware := NewMiddleware(tg)
+			middleware.SetAuthorizer(authorizer)
+			var got *BreakGlassClaims
+			handler := middleware.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetBreakGlassFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/scenarios/run", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tt.exemption != "" {
+				req.Header.Set(BreakGlassHeader, tt.exemption)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if authorizer.calls != 0 {
+					t.Error("Expected the authorizer not to be asked")
+				}
+				return
+			}
+			if authorizer.last.BreakGlass != tt.wantID {
+				t.Errorf("Expected the authorizer to get exemption %q, got %q", tt.wantID, authorizer.last.BreakGlass)
+			}
+			if (got != nil) != (tt.wantID != "") || (got != nil && got.ID != tt.wantID) {
+				t.Errorf("Unexpected exemption in context: %+v", got)
+			}
+		})
+	}
+}
@@ -66,6 +66,40 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 //	    // password doesn't meet requirements
 //	}
 //
+// # Authorization
+//
+// An Authorizer decides whether an authenticated user (subject) may perform an action
+// on a resource. RBACAuthorizer applies built-in role rules, WebhookAuthorizer asks an
+// external policy engine over HTTP, and CachedAuthorizer reuses decisions for a TTL:
+//
+//	authorizer, err := auth.NewAuthorizer(auth.AuthorizerConfig{
+//	    Mode:       auth.AuthorizerModeWebhook,
+//	    WebhookURL: "http://opa:8181/v1/data/krkn/allow",
+//	    CacheTTL:   30 * time.Second,
+//	})
+//	if err != nil {
+//	    // handle error
+//	}
+//	middleware.SetAuthorizer(authorizer)
+//
+// # Break-glass Exemptions
+//
+// A ScenarioPolicy lists restricted scenarios, which only run under a break-glass
+// exemption: a short-lived token an admin issues for one scenario and a set of clusters.
+// RequireAuth verifies the exemption sent in BreakGlassHeader and passes its ID to the
+// Authorizer; exemption tokens are rejected as login tokens:
+//
+//	token, exemption, err := tokenGen.GenerateBreakGlassToken("admin@example.com", auth.BreakGlassGrant{
+//	    Scenario: "node-scenarios",
+//	    Clusters: []string{"prod-1"},
+//	    Reason:   "INC-1234 failover drill",
+//	    Duration: time.Hour,
+//	})
+//	if err != nil {
+//	    // handle error
+//	}
+//	err = exemption.Covers("node-scenarios", map[string][]string{"krkn-operator": {"prod-1"}})
+//
 // All utilities in this package are thread-safe and can be used concurrently
 // across multiple goroutines.
 package auth
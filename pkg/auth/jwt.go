@@ -125,6 +125,11 @@ func (tg *TokenGenerator) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("invalid token")
 	}
 
+	// Break-glass exemptions are signed with the same key but never authenticate a user
+	if len(claims.Audience) > 0 {
+		return nil, fmt.Errorf("invalid token: unexpected audience %v", claims.Audience)
+	}
+
 	return claims, nil
 }
 
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+)
+
+// Message codes returned in the code field of the middleware error responses.
+// Like the REST API codes, they are part of the API contract: never rename or
+// reuse a code.
+const (
+	MsgAuthNotReady             = "auth_not_ready"
+	MsgAuthTokenMissing         = "auth_token_missing"
+	MsgAuthHeaderInvalid        = "auth_header_invalid"
+	MsgAuthTokenInvalid         = "auth_token_invalid"
+	MsgAuthClaimsMissing        = "auth_claims_missing"
+	MsgBreakGlassInvalid        = "break_glass_invalid"
+	MsgBreakGlassOtherGrantee   = "break_glass_other_grantee"
+	MsgAuthorizationUnavailable = "authorization_unavailable"
+	MsgAuthorizationDenied      = "authorization_denied"
+	MsgInsufficientPermissions  = "insufficient_permissions"
+)
+
+// messageCatalog holds the localized templates for the middleware message codes.
+// English is the reference language and must contain every code.
+var messageCatalog = i18n.NewCatalog(map[string]map[string]string{
+	"en": {
+		MsgAuthNotReady:             "Authentication system not ready",
+		MsgAuthTokenMissing:         "Missing authorization token",
+		MsgAuthHeaderInvalid:        "Invalid authorization header format. Expected: Bearer <token>",
+		MsgAuthTokenInvalid:         "Invalid or expired token",
+		MsgAuthClaimsMissing:        "No authentication claims found",
+		MsgBreakGlassInvalid:        "Invalid or expired break-glass exemption",
+		MsgBreakGlassOtherGrantee:   "The break-glass exemption was issued to another user",
+		MsgAuthorizationUnavailable: "Authorization service unavailable",
+		MsgAuthorizationDenied:      "{reason}",
+		MsgInsufficientPermissions:  "Insufficient permissions",
+	},
+	"it": {
+		MsgAuthNotReady:             "Il sistema di autenticazione non è pronto",
+		MsgAuthTokenMissing:         "Token di autorizzazione mancante",
+		MsgAuthHeaderInvalid:        "Formato dell'header Authorization non valido. Atteso: Bearer <token>",
+		MsgAuthTokenInvalid:         "Token non valido o scaduto",
+		MsgAuthClaimsMissing:        "Nessuna credenziale di autenticazione trovata",
+		MsgBreakGlassInvalid:        "Esenzione break-glass non valida o scaduta",
+		MsgBreakGlassOtherGrantee:   "L'esenzione break-glass è stata emessa per un altro utente",
+		MsgAuthorizationUnavailable: "Servizio di autorizzazione non disponibile",
+		MsgAuthorizationDenied:      "Accesso negato: {reason}",
+		MsgInsufficientPermissions:  "Permessi insufficienti",
+	},
+})
@@ -20,10 +20,14 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/krkn-chaos/krkn-operator/pkg/i18n"
+	"github.com/krkn-chaos/krkn-operator/pkg/requestid"
 )
 
 // ContextKey is a custom type for context keys to avoid collisions
@@ -52,6 +56,7 @@ const (
 type Middleware struct {
 	tokenGen       *TokenGenerator
 	tokenGenLoader func() *TokenGenerator
+	authorizer     Authorizer
 }
 
 // NewMiddleware creates a new authentication middleware
@@ -78,8 +83,15 @@ func NewLazyMiddleware(tokenGenLoader func() *TokenGenerator) *Middleware {
 	}
 }
 
+// SetAuthorizer makes RequireAuth ask authorizer whether each authenticated request is
+// allowed. Without authorizer, every authenticated request proceeds to the handlers.
+func (m *Middleware) SetAuthorizer(authorizer Authorizer) {
+	m.authorizer = authorizer
+}
+
 // RequireAuth is a middleware that requires a valid JWT token
-// It validates the token and adds the claims to the request context
+// It validates the token, checks the request with the authorizer, when one is set,
+// and adds the claims to the request context
 func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := log.Log.WithName("auth-middleware")
@@ -94,7 +106,7 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 
 		if tokenGen == nil {
 			logger.Error(nil, "TokenGenerator not initialized")
-			http.Error(w, `{"error":"internal_error","message":"Authentication system not ready"}`, http.StatusInternalServerError)
+			writeError(w, r, http.StatusInternalServerError, "internal_error", MsgAuthNotReady, nil)
 			return
 		}
 
@@ -105,7 +117,7 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, `{"error":"unauthorized","message":"Missing authorization token"}`, http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthTokenMissing, nil)
 			return
 		}
 
@@ -116,7 +128,7 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 				"method", r.Method,
 				"header", authHeader,
 			)
-			http.Error(w, `{"error":"unauthorized","message":"Invalid authorization header format. Expected: Bearer <token>"}`, http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthHeaderInvalid, nil)
 			return
 		}
 
@@ -131,7 +143,7 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 				"method", r.Method,
 				"error", err.Error(),
 			)
-			http.Error(w, `{"error":"unauthorized","message":"Invalid or expired token"}`, http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthTokenInvalid, nil)
 			return
 		}
 
@@ -142,12 +154,108 @@ func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 			"role", claims.Role,
 		)
 
+		exemption, ok := m.breakGlass(w, r, tokenGen, claims)
+		if !ok {
+			return
+		}
+
+		if !m.authorize(w, r, claims, exemption) {
+			return
+		}
+
 		// Add claims to context
 		ctx := context.WithValue(r.Context(), UserClaimsKey, claims)
+		if exemption != nil {
+			ctx = context.WithValue(ctx, BreakGlassKey, exemption)
+		}
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// breakGlass verifies the break-glass exemption sent in BreakGlassHeader, if any, and
+// writes the error response when it is invalid, expired or issued to another user
+func (m *Middleware) breakGlass(w http.ResponseWriter, r *http.Request, tokenGen *TokenGenerator, claims *Claims) (*BreakGlassClaims, bool) {
+	token := r.Header.Get(BreakGlassHeader)
+	if token == "" {
+		return nil, true
+	}
+	logger := log.Log.WithName("auth-middleware")
+
+	exemption, err := tokenGen.ValidateBreakGlassToken(token)
+	if err != nil {
+		logger.Info("Break-glass exemption rejected",
+			"path", r.URL.Path,
+			"method", r.Method,
+			"userId", claims.UserID,
+			"error", err.Error(),
+		)
+		writeError(w, r, http.StatusForbidden, "forbidden", MsgBreakGlassInvalid, nil)
+		return nil, false
+	}
+	if exemption.Grantee != "" && exemption.Grantee != claims.UserID {
+		logger.Info("Break-glass exemption rejected: issued to another user",
+			"path", r.URL.Path,
+			"method", r.Method,
+			"userId", claims.UserID,
+			"exemption", exemption.ID,
+		)
+		writeError(w, r, http.StatusForbidden, "forbidden", MsgBreakGlassOtherGrantee, nil)
+		return nil, false
+	}
+	return exemption, true
+}
+
+// authorize asks the authorizer whether the user of claims may perform the request, and
+// writes the error response when not. Requests are rejected when no decision can be made.
+func (m *Middleware) authorize(w http.ResponseWriter, r *http.Request, claims *Claims, exemption *BreakGlassClaims) bool {
+	if m.authorizer == nil {
+		return true
+	}
+	logger := log.Log.WithName("auth-middleware")
+
+	request := AuthorizationRequest{
+		Subject:  claims.UserID,
+		Role:     claims.Role,
+		Action:   actionForMethod(r.Method),
+		Resource: r.URL.Path,
+	}
+	if exemption != nil {
+		request.BreakGlass = exemption.ID
+	}
+	decision, err := m.authorizer.Authorize(r.Context(), request)
+	if err != nil {
+		logger.Error(err, "Authorization failed: no decision",
+			"path", r.URL.Path,
+			"method", r.Method,
+			"userId", claims.UserID,
+		)
+		writeError(w, r, http.StatusServiceUnavailable, "authorization_unavailable", MsgAuthorizationUnavailable, nil)
+		return false
+	}
+	if !decision.Allowed {
+		logger.Info("Authorization denied",
+			"path", r.URL.Path,
+			"method", r.Method,
+			"userId", claims.UserID,
+			"reason", decision.Reason,
+		)
+		if decision.Reason == "" {
+			writeError(w, r, http.StatusForbidden, "forbidden", MsgInsufficientPermissions, nil)
+		} else {
+			writeError(w, r, http.StatusForbidden, "forbidden", MsgAuthorizationDenied, i18n.Params{"reason": decision.Reason})
+		}
+		return false
+	}
+	return true
+}
+
+// Authorize asks the authorizer, when one is set, whether the user of claims may perform
+// the request, for the routes that authenticate outside of RequireAuth, e.g. WebSockets.
+// It writes the error response and returns false when the request is not allowed.
+func (m *Middleware) Authorize(w http.ResponseWriter, r *http.Request, claims *Claims) bool {
+	return m.authorize(w, r, claims, nil)
+}
+
 // RequireRole is a middleware that requires a specific role
 // Must be used after RequireAuth middleware
 func (m *Middleware) RequireRole(role Role, next http.Handler) http.Handler {
@@ -155,13 +263,13 @@ func (m *Middleware) RequireRole(role Role, next http.Handler) http.Handler {
 		// Get claims from context
 		claims, ok := r.Context().Value(UserClaimsKey).(*Claims)
 		if !ok {
-			http.Error(w, `{"error":"unauthorized","message":"No authentication claims found"}`, http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
 			return
 		}
 
 		// Check role
 		if Role(claims.Role) != role {
-			http.Error(w, `{"error":"forbidden","message":"Insufficient permissions"}`, http.StatusForbidden)
+			writeError(w, r, http.StatusForbidden, "forbidden", MsgInsufficientPermissions, nil)
 			return
 		}
 
@@ -176,7 +284,7 @@ func (m *Middleware) RequireAnyRole(roles []Role, next http.Handler) http.Handle
 		// Get claims from context
 		claims, ok := r.Context().Value(UserClaimsKey).(*Claims)
 		if !ok {
-			http.Error(w, `{"error":"unauthorized","message":"No authentication claims found"}`, http.StatusUnauthorized)
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", MsgAuthClaimsMissing, nil)
 			return
 		}
 
@@ -189,7 +297,7 @@ func (m *Middleware) RequireAnyRole(roles []Role, next http.Handler) http.Handle
 			}
 		}
 
-		http.Error(w, `{"error":"forbidden","message":"Insufficient permissions"}`, http.StatusForbidden)
+		writeError(w, r, http.StatusForbidden, "forbidden", MsgInsufficientPermissions, nil)
 	})
 }
 
@@ -220,3 +328,27 @@ func IsAdmin(ctx context.Context) bool {
 	}
 	return claims.Role == string(RoleAdmin)
 }
+
+// errorBody mirrors the REST API error response
+type errorBody struct {
+	Error     string `json:"error"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// writeError writes a JSON error whose message is rendered from the catalog in the
+// language requested by the client, including the request ID, when one was assigned
+func writeError(w http.ResponseWriter, r *http.Request, status int, errType, code string, params i18n.Params) {
+	lang := messageCatalog.Negotiate(r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorBody{
+		Error:     errType,
+		Code:      code,
+		Message:   messageCatalog.Render(lang, code, params),
+		RequestID: requestid.FromResponse(w),
+	})
+}
@@ -19,6 +19,7 @@ Assisted-by: Claude Sonnet 4.5 (claude-sonnet-4-5@20250929)
 package auth
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -136,6 +137,59 @@ func TestRequireAuth_InvalidTokenFormat(t *testing.T) {
 	}
 }
 
+func TestRequireAuth_LocalizedErrors(t *testing.T) {
+	middleware := NewMiddleware(NewTokenGenerator(
+		[]byte("test-secret-key-at-least-32-bytes-long"),
+		24*time.Hour,
+		"krkn-operator",
+	))
+	handler := middleware.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Handler should not be called")
+	}))
+
+	tests := []struct {
+		name           string
+		header         string
+		acceptLanguage string
+		wantCode       string
+		wantMessage    string
+	}{
+		{"missing token", "", "", MsgAuthTokenMissing, "Missing authorization token"},
+		{"missing token in italian", "", "it-IT", MsgAuthTokenMissing, "Token di autorizzazione mancante"},
+		{"invalid header", "Basic abc", "", MsgAuthHeaderInvalid, "Invalid authorization header format. Expected: Bearer <token>"},
+		{"invalid token", "Bearer abc", "it", MsgAuthTokenInvalid, "Token non valido o scaduto"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+			}
+			var body errorBody
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body.Error != "unauthorized" || body.Code != tt.wantCode {
+				t.Errorf("Unexpected error/code: %s/%s", body.Error, body.Code)
+			}
+			if body.Message != tt.wantMessage {
+				t.Errorf("Expected message %q, got %q", tt.wantMessage, body.Message)
+			}
+		})
+	}
+}
+
 func TestRequireAuth_ExpiredToken(t *testing.T) {
 	tg := NewTokenGenerator(
 		[]byte("test-secret-key-at-least-32-bytes-long"),
@@ -0,0 +1,342 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crdcheck compares the CustomResourceDefinitions installed in the
+// cluster with the API types compiled into the operator. A mismatch usually
+// means the CRDs were not upgraded together with the operator image (Helm does
+// not upgrade CRDs), which otherwise surfaces as obscure reconcile failures.
+package crdcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+
+// maxFieldDepth bounds the recursion when walking Go types
+const maxFieldDepth = 6
+
+// Expectation describes a CRD the operator relies on
+type Expectation struct {
+	// GroupVersionKind of the custom resource
+	GroupVersionKind schema.GroupVersionKind
+	// Plural resource name (e.g. "krknoperatortargets")
+	Plural string
+	// Object is a zero value of the Go root type, used to derive expected fields
+	Object any
+}
+
+// CRDName returns the metadata.name of the CRD (plural.group)
+func (e Expectation) CRDName() string {
+	return e.Plural + "." + e.GroupVersionKind.Group
+}
+
+// Mismatch is a single problem found for a CRD
+type Mismatch struct {
+	CRD           string   `json:"crd"`
+	Problem       string   `json:"problem"`
+	MissingFields []string `json:"missingFields,omitempty"`
+}
+
+// Report is the outcome of a schema check
+type Report struct {
+	CheckedAt    time.Time  `json:"checkedAt"`
+	Compatible   bool       `json:"compatible"`
+	Mismatches   []Mismatch `json:"mismatches,omitempty"`
+	Instructions []string   `json:"instructions,omitempty"`
+	// Error is set when the check itself could not be completed
+	Error string `json:"error,omitempty"`
+}
+
+// Checker runs the CRD schema comparison once at startup and keeps the result.
+// It implements manager.Runnable and can be used as a readyz check.
+type Checker struct {
+	reader       client.Reader
+	expectations []Expectation
+
+	mu     sync.RWMutex
+	report *Report
+}
+
+// NewChecker creates a Checker. reader should be uncached (mgr.GetAPIReader())
+// so the operator doesn't need to watch CRDs.
+func NewChecker(reader client.Reader, expectations []Expectation) *Checker {
+	return &Checker{
+		reader:       reader,
+		expectations: expectations,
+	}
+}
+
+// Start implements manager.Runnable
+func (c *Checker) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("crd-check")
+
+	report := c.Check(ctx)
+	if report.Error != "" {
+		logger.Error(fmt.Errorf("%s", report.Error), "CRD schema check could not be completed")
+	} else if !report.Compatible {
+		for _, mismatch := range report.Mismatches {
+			logger.Error(fmt.Errorf("%s", mismatch.Problem), "⚠️ Installed CRD does not match operator version",
+				"crd", mismatch.CRD,
+				"missingFields", mismatch.MissingFields)
+		}
+		for _, instruction := range report.Instructions {
+			logger.Info("CRD upgrade instruction", "step", instruction)
+		}
+	} else {
+		logger.Info("✅ Installed CRDs match operator API types", "crds", len(c.expectations))
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Every replica reports its own readiness, so the check runs everywhere.
+func (c *Checker) NeedLeaderElection() bool {
+	return false
+}
+
+// Report returns the latest report, or nil if the check has not run yet
+func (c *Checker) Report() *Report {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.report
+}
+
+// ReadyzCheck is a healthz.Checker that fails while installed CRDs are incompatible
+func (c *Checker) ReadyzCheck(_ *http.Request) error {
+	report := c.Report()
+	if report == nil {
+		return fmt.Errorf("CRD schema check has not completed yet")
+	}
+	if !report.Compatible && report.Error == "" {
+		crds := make([]string, 0, len(report.Mismatches))
+		for _, mismatch := range report.Mismatches {
+			crds = append(crds, mismatch.CRD)
+		}
+		return fmt.Errorf("installed CRDs are outdated: %s (see /api/v1/diagnostics)", strings.Join(crds, ", "))
+	}
+	return nil
+}
+
+// Check compares every expectation with the installed CRD and stores the report
+func (c *Checker) Check(ctx context.Context) *Report {
+	report := &Report{
+		CheckedAt:  time.Now().UTC(),
+		Compatible: true,
+	}
+
+	for _, expectation := range c.expectations {
+		var crd apiextensionsv1.CustomResourceDefinition
+		err := c.reader.Get(ctx, client.ObjectKey{Name: expectation.CRDName()}, &crd)
+		if apierrors.IsNotFound(err) {
+			report.Mismatches = append(report.Mismatches, Mismatch{
+				CRD:     expectation.CRDName(),
+				Problem: "CRD is not installed",
+			})
+			continue
+		}
+		if err != nil {
+			// Missing RBAC or API errors must not mark the operator unready:
+			// record the failure and let the reconcilers surface real problems.
+			report.Error = fmt.Sprintf("failed to get CRD %s: %v", expectation.CRDName(), err)
+			continue
+		}
+
+		if mismatch := compare(expectation, &crd); mismatch != nil {
+			report.Mismatches = append(report.Mismatches, *mismatch)
+		}
+	}
+
+	if len(report.Mismatches) > 0 {
+		report.Compatible = false
+		report.Instructions = upgradeInstructions(report.Mismatches)
+	}
+
+	c.mu.Lock()
+	c.report = report
+	c.mu.Unlock()
+
+	return report
+}
+
+// compare checks the served version and the spec/status fields of a CRD
+func compare(expectation Expectation, crd *apiextensionsv1.CustomResourceDefinition) *Mismatch {
+	version := expectation.GroupVersionKind.Version
+
+	var served *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == version && crd.Spec.Versions[i].Served {
+			served = &crd.Spec.Versions[i]
+			break
+		}
+	}
+	if served == nil {
+		return &Mismatch{
+			CRD:     crd.Name,
+			Problem: fmt.Sprintf("version %s is not served", version),
+		}
+	}
+	if served.Schema == nil || served.Schema.OpenAPIV3Schema == nil {
+		return nil
+	}
+
+	expected := ExpectedFields(expectation.Object)
+	var missing []string
+	for _, path := range expected {
+		if !schemaHasPath(served.Schema.OpenAPIV3Schema, path) {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return &Mismatch{
+		CRD:           crd.Name,
+		Problem:       fmt.Sprintf("schema for version %s is missing %d field(s)", version, len(missing)),
+		MissingFields: missing,
+	}
+}
+
+// schemaHasPath walks a dotted path through schema properties, descending into array items
+func schemaHasPath(props *apiextensionsv1.JSONSchemaProps, path string) bool {
+	current := props
+	for _, segment := range strings.Split(path, ".") {
+		for current.Items != nil && current.Items.Schema != nil {
+			current = current.Items.Schema
+		}
+		if current.XPreserveUnknownFields != nil && *current.XPreserveUnknownFields {
+			return true
+		}
+		next, ok := current.Properties[segment]
+		if !ok {
+			return false
+		}
+		current = &next
+	}
+	return true
+}
+
+// ExpectedFields returns the dotted JSON paths under spec and status declared by obj's Go type
+func ExpectedFields(obj any) []string {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var paths []string
+	for _, root := range []string{"spec", "status"} {
+		field, ok := fieldByJSONName(t, root)
+		if !ok {
+			continue
+		}
+		paths = append(paths, root)
+		collectFields(field.Type, root, 1, &paths)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// opaqueTypes are serialized as scalars and have no nested schema properties
+var opaqueTypes = map[reflect.Type]bool{
+	reflect.TypeOf(metav1.Time{}):     true,
+	reflect.TypeOf(metav1.Duration{}): true,
+}
+
+func collectFields(t reflect.Type, prefix string, depth int, paths *[]string) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || opaqueTypes[t] || depth > maxFieldDepth {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, inline := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if inline {
+			collectFields(field.Type, prefix, depth, paths)
+			continue
+		}
+		path := prefix + "." + name
+		*paths = append(*paths, path)
+		collectFields(field.Type, path, depth+1, paths)
+	}
+}
+
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if n, _ := jsonName(t.Field(i)); n == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, field.Anonymous
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if strings.Contains(options, "inline") {
+		return "", true
+	}
+	if name == "" {
+		return field.Name, false
+	}
+	return name, false
+}
+
+// upgradeInstructions returns the exact commands needed to bring CRDs up to date
+func upgradeInstructions(mismatches []Mismatch) []string {
+	instructions := []string{
+		"Helm does not upgrade CRDs on 'helm upgrade'; apply the CRDs shipped with this operator version manually:",
+	}
+	for _, mismatch := range mismatches {
+		instructions = append(instructions, fmt.Sprintf(
+			"kubectl apply --server-side --force-conflicts -f charts/krkn-operator/crds/krkn.krkn-chaos.dev_%s.yaml",
+			strings.SplitN(mismatch.CRD, ".", 2)[0]))
+	}
+	instructions = append(instructions,
+		"Alternatively, from a source checkout: make install",
+		"Then restart the operator: kubectl rollout restart deployment -n <operator-namespace> -l app.kubernetes.io/component=operator")
+	return instructions
+}
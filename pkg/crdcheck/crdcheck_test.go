@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdcheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// loadCRDs reads the CRDs generated by controller-gen
+func loadCRDs(t *testing.T) []client.Object {
+	t.Helper()
+
+	files, err := filepath.Glob("../../config/crd/bases/*.yaml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Failed to find CRD manifests: %v", err)
+	}
+
+	var objs []client.Object
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			t.Fatalf("Failed to parse %s: %v", file, err)
+		}
+		objs = append(objs, crd)
+	}
+	return objs
+}
+
+func newFakeReader(objs ...client.Object) client.Reader {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+// TestGeneratedCRDsMatchTypes fails when API types changed without running make manifests
+func TestGeneratedCRDsMatchTypes(t *testing.T) {
+	checker := NewChecker(newFakeReader(loadCRDs(t)...), DefaultExpectations())
+
+	report := checker.Check(context.Background())
+	if !report.Compatible {
+		t.Errorf("Generated CRDs are out of date: %+v", report.Mismatches)
+	}
+	if err := checker.ReadyzCheck(nil); err != nil {
+		t.Errorf("Expected readyz to pass, got %v", err)
+	}
+}
+
+func TestCheck_DetectsMissingFieldAndCRD(t *testing.T) {
+	crds := loadCRDs(t)
+
+	var filtered []client.Object
+	for _, obj := range crds {
+		crd := obj.(*apiextensionsv1.CustomResourceDefinition)
+		switch crd.Name {
+		case "krknusers.krkn.krkn-chaos.dev":
+			// Simulate a CRD that was never installed
+			continue
+		case "krknoperatortargets.krkn.krkn-chaos.dev":
+			// Simulate an old CRD without the archive fields
+			status := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["status"]
+			delete(status.Properties, "archived")
+			crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["status"] = status
+		}
+		filtered = append(filtered, crd)
+	}
+
+	checker := NewChecker(newFakeReader(filtered...), DefaultExpectations())
+	if err := checker.ReadyzCheck(nil); err == nil {
+		t.Error("Expected readyz to fail before the check runs")
+	}
+
+	report := checker.Check(context.Background())
+	if report.Compatible {
+		t.Fatal("Expected incompatible report")
+	}
+	if len(report.Mismatches) != 2 {
+		t.Fatalf("Expected 2 mismatches, got %+v", report.Mismatches)
+	}
+
+	for _, mismatch := range report.Mismatches {
+		if mismatch.CRD == "krknoperatortargets.krkn.krkn-chaos.dev" &&
+			!slices.Contains(mismatch.MissingFields, "status.archived") {
+			t.Errorf("Expected status.archived to be reported missing, got %v", mismatch.MissingFields)
+		}
+	}
+
+	if len(report.Instructions) == 0 {
+		t.Error("Expected upgrade instructions")
+	}
+	if err := checker.ReadyzCheck(nil); err == nil {
+		t.Error("Expected readyz to fail for outdated CRDs")
+	}
+}
+
+func TestExpectedFields(t *testing.T) {
+	type nested struct {
+		Value string `json:"value"`
+	}
+	type spec struct {
+		Name   string   `json:"name"`
+		Items  []nested `json:"items,omitempty"`
+		Hidden string   `json:"-"`
+	}
+	type root struct {
+		Spec spec `json:"spec"`
+	}
+
+	expected := []string{"spec", "spec.items", "spec.items.value", "spec.name"}
+	if got := ExpectedFields(&root{}); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdcheck
+
+import (
+	"strings"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// DefaultExpectations returns the expectations for every CRD served by the operator
+func DefaultExpectations() []Expectation {
+	objects := map[string]any{
+		"KrknCampaign":                     &krknv1alpha1.KrknCampaign{},
+		"KrknOperatorTarget":               &krknv1alpha1.KrknOperatorTarget{},
+		"KrknOperatorTargetProvider":       &krknv1alpha1.KrknOperatorTargetProvider{},
+		"KrknOperatorTargetProviderConfig": &krknv1alpha1.KrknOperatorTargetProviderConfig{},
+		"KrknRegistry":                     &krknv1alpha1.KrknRegistry{},
+		"KrknReport":                       &krknv1alpha1.KrknReport{},
+		"KrknScenarioRun":                  &krknv1alpha1.KrknScenarioRun{},
+		"KrknScenarioTemplate":             &krknv1alpha1.KrknScenarioTemplate{},
+		"KrknTargetGroup":                  &krknv1alpha1.KrknTargetGroup{},
+		"KrknTargetRequest":                &krknv1alpha1.KrknTargetRequest{},
+		"KrknUser":                         &krknv1alpha1.KrknUser{},
+		"KrknUserGroup":                    &krknv1alpha1.KrknUserGroup{},
+	}
+
+	expectations := make([]Expectation, 0, len(objects))
+	for kind, obj := range objects {
+		expectations = append(expectations, Expectation{
+			GroupVersionKind: krknv1alpha1.GroupVersion.WithKind(kind),
+			Plural:           plural(kind),
+			Object:           obj,
+		})
+	}
+	return expectations
+}
+
+// plural returns the resource name controller-gen derives from kind
+func plural(kind string) string {
+	name := strings.ToLower(kind)
+	if strings.HasSuffix(name, "y") {
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
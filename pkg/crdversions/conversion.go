@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crdversions manages the API versions of the CRDs served in more than one
+// version: it points them at the conversion webhook of the operator, and migrates the
+// stored objects to the storage version so that older versions can be dropped.
+package crdversions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;update
+
+// VersionedCRDs are the CRDs served in several versions
+var VersionedCRDs = []string{
+	"krknscenarioruns.krkn.krkn-chaos.dev",
+	"krknoperatortargets.krkn.krkn-chaos.dev",
+}
+
+// ConversionPath is where the webhook server of the operator serves conversion reviews
+const ConversionPath = "/convert"
+
+// WebhookService is the Service in front of the webhook server of the operator
+type WebhookService struct {
+	Namespace string
+	Name      string
+	// Port is the Service port (443 when zero)
+	Port int32
+	// CertDir holds the serving certificate of the webhook server and ca.crt, the CA the
+	// API server verifies it with
+	CertDir string
+}
+
+// ConversionInstaller configures the conversion webhook on the versioned CRDs and serves
+// all their versions. The CRDs are installed with their newer versions unserved: served
+// without the webhook, they would be converted by rewriting apiVersion only.
+// It implements manager.Runnable.
+type ConversionInstaller struct {
+	reader  client.Reader
+	writer  client.Writer
+	crds    []string
+	service WebhookService
+}
+
+// NewConversionInstaller creates a ConversionInstaller. reader should be uncached
+// (mgr.GetAPIReader()) so the operator doesn't need to watch CRDs.
+func NewConversionInstaller(reader client.Reader, writer client.Writer, crds []string, service WebhookService) *ConversionInstaller {
+	return &ConversionInstaller{
+		reader:  reader,
+		writer:  writer,
+		crds:    crds,
+		service: service,
+	}
+}
+
+// Start implements manager.Runnable. Failures are logged: the storage versions keep
+// working without conversion.
+func (i *ConversionInstaller) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("crd-conversion")
+
+	caBundle, err := os.ReadFile(filepath.Join(i.service.CertDir, "ca.crt"))
+	if err != nil {
+		logger.Error(err, "⚠️ Cannot read the webhook CA, newer API versions stay unserved")
+		return nil
+	}
+	for _, name := range i.crds {
+		if err := i.Install(ctx, name, caBundle); err != nil {
+			logger.Error(err, "⚠️ Failed to configure the conversion webhook, newer API versions stay unserved",
+				"crd", name)
+			continue
+		}
+		logger.Info("✅ Conversion webhook configured", "crd", name)
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Every replica serves conversions and the update is idempotent.
+func (i *ConversionInstaller) NeedLeaderElection() bool {
+	return false
+}
+
+// Install points the conversion of a CRD at the webhook and serves all its versions
+func (i *ConversionInstaller) Install(ctx context.Context, name string, caBundle []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := i.reader.Get(ctx, client.ObjectKey{Name: name}, &crd); err != nil {
+			return fmt.Errorf("failed to get CRD: %w", err)
+		}
+
+		desired := i.conversion(caBundle)
+		changed := !conversionEqual(crd.Spec.Conversion, desired)
+		crd.Spec.Conversion = desired
+		for v := range crd.Spec.Versions {
+			if !crd.Spec.Versions[v].Served {
+				crd.Spec.Versions[v].Served = true
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return i.writer.Update(ctx, &crd)
+	})
+}
+
+// conversion returns the webhook conversion of the CRDs
+func (i *ConversionInstaller) conversion(caBundle []byte) *apiextensionsv1.CustomResourceConversion {
+	port := i.service.Port
+	if port == 0 {
+		port = 443
+	}
+	return &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: i.service.Namespace,
+					Name:      i.service.Name,
+					Path:      ptr.To(ConversionPath),
+					Port:      ptr.To(port),
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+}
+
+// conversionEqual reports whether a CRD already converts through the desired webhook
+func conversionEqual(current, desired *apiextensionsv1.CustomResourceConversion) bool {
+	if current == nil || current.Strategy != desired.Strategy || current.Webhook == nil ||
+		current.Webhook.ClientConfig == nil || current.Webhook.ClientConfig.Service == nil {
+		return false
+	}
+	got, want := current.Webhook.ClientConfig, desired.Webhook.ClientConfig
+	return got.Service.Namespace == want.Service.Namespace &&
+		got.Service.Name == want.Service.Name &&
+		ptr.Deref(got.Service.Path, "") == ptr.Deref(want.Service.Path, "") &&
+		ptr.Deref(got.Service.Port, 443) == ptr.Deref(want.Service.Port, 443) &&
+		bytes.Equal(got.CABundle, want.CABundle)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdversions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+const scenarioRunsCRD = "krknscenarioruns.krkn.krkn-chaos.dev"
+
+// loadCRD reads a CRD generated by controller-gen
+func loadCRD(t *testing.T, name string) *apiextensionsv1.CustomResourceDefinition {
+	t.Helper()
+
+	plural, group, _ := strings.Cut(name, ".")
+	data, err := os.ReadFile(filepath.Join("../../config/crd/bases", group+"_"+plural+".yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read CRD %s: %v", name, err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		t.Fatalf("Failed to parse CRD %s: %v", name, err)
+	}
+	return crd
+}
+
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	_ = krknv1alpha1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).Build()
+}
+
+func TestVersionedCRDsServeOnlyStorageVersion(t *testing.T) {
+	for _, name := range VersionedCRDs {
+		crd := loadCRD(t, name)
+		for _, version := range crd.Spec.Versions {
+			if version.Served != version.Storage {
+				t.Errorf("Expected %s %s to be served only if stored, as long as the webhook is not installed",
+					name, version.Name)
+			}
+		}
+	}
+}
+
+func TestConversionInstaller_Install(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := newFakeClient(loadCRD(t, scenarioRunsCRD))
+	installer := NewConversionInstaller(fakeClient, fakeClient, VersionedCRDs, WebhookService{
+		Namespace: "krkn-operator-system",
+		Name:      "krkn-operator-webhook",
+	})
+
+	// Installing again finds the webhook in place
+	for range 2 {
+		if err := installer.Install(ctx, scenarioRunsCRD, []byte("ca")); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: scenarioRunsCRD}, &crd); err != nil {
+		t.Fatalf("Failed to get CRD: %v", err)
+	}
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Strategy != apiextensionsv1.WebhookConverter {
+		t.Fatalf("Expected webhook conversion, got %+v", conversion)
+	}
+	service := conversion.Webhook.ClientConfig.Service
+	if service.Name != "krkn-operator-webhook" || *service.Path != ConversionPath || *service.Port != 443 ||
+		string(conversion.Webhook.ClientConfig.CABundle) != "ca" {
+		t.Errorf("Unexpected webhook client config %+v", conversion.Webhook.ClientConfig)
+	}
+	for _, version := range crd.Spec.Versions {
+		if !version.Served {
+			t.Errorf("Expected version %s to be served", version.Name)
+		}
+	}
+
+	if err := installer.Install(ctx, "missing.krkn.krkn-chaos.dev", []byte("ca")); err == nil {
+		t.Error("Expected an error for a missing CRD")
+	}
+}
+
+func TestStorageMigrator_Migrate(t *testing.T) {
+	ctx := context.Background()
+	crd := loadCRD(t, scenarioRunsCRD)
+	crd.Status.StoredVersions = []string{"v1beta1", "v1alpha1"}
+	runs := []client.Object{
+		&krknv1alpha1.KrknScenarioRun{ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "default"}},
+		&krknv1alpha1.KrknScenarioRun{ObjectMeta: metav1.ObjectMeta{Name: "run-2", Namespace: "other"}},
+	}
+	fakeClient := newFakeClient(append(runs, crd)...)
+	migrator := NewStorageMigrator(fakeClient, fakeClient, VersionedCRDs)
+
+	migrated, err := migrator.Migrate(ctx, scenarioRunsCRD)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if migrated != len(runs) {
+		t.Errorf("Expected %d objects migrated, got %d", len(runs), migrated)
+	}
+
+	var got apiextensionsv1.CustomResourceDefinition
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: scenarioRunsCRD}, &got); err != nil {
+		t.Fatalf("Failed to get CRD: %v", err)
+	}
+	if !slices.Equal(got.Status.StoredVersions, []string{"v1alpha1"}) {
+		t.Errorf("Expected only the storage version to be stored, got %v", got.Status.StoredVersions)
+	}
+
+	// Nothing is left to migrate
+	migrated, err = migrator.Migrate(ctx, scenarioRunsCRD)
+	if err != nil || migrated != 0 {
+		t.Errorf("Expected no migration, got %d (%v)", migrated, err)
+	}
+}
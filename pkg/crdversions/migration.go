@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdversions
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update
+// +kubebuilder:rbac:groups=krkn.krkn-chaos.dev,resources=krknscenarioruns;krknoperatortargets,verbs=list;update
+
+// StorageMigrator rewrites the objects of the versioned CRDs that may still be stored in
+// an older version, then records the storage version as the only stored version. Once
+// an older version is no longer stored it can be removed from the CRD.
+// It implements manager.Runnable.
+type StorageMigrator struct {
+	reader client.Reader
+	writer client.Client
+	crds   []string
+}
+
+// NewStorageMigrator creates a StorageMigrator. reader should be uncached
+// (mgr.GetAPIReader()) so the operator doesn't need to watch CRDs.
+func NewStorageMigrator(reader client.Reader, writer client.Client, crds []string) *StorageMigrator {
+	return &StorageMigrator{
+		reader: reader,
+		writer: writer,
+		crds:   crds,
+	}
+}
+
+// Start implements manager.Runnable. Failures are logged and the migration is tried
+// again on the next start.
+func (m *StorageMigrator) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("storage-migration")
+
+	for _, name := range m.crds {
+		migrated, err := m.Migrate(ctx, name)
+		if err != nil {
+			logger.Error(err, "⚠️ Storage version migration failed", "crd", name)
+			continue
+		}
+		if migrated > 0 {
+			logger.Info("✅ Migrated objects to the storage version", "crd", name, "objects", migrated)
+		}
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// A single replica rewrites the objects.
+func (m *StorageMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Migrate rewrites the objects of a CRD in its storage version and returns how many it
+// rewrote. CRDs whose objects are all stored in the storage version are left alone.
+func (m *StorageMigrator) Migrate(ctx context.Context, name string) (int, error) {
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := m.reader.Get(ctx, client.ObjectKey{Name: name}, &crd); err != nil {
+		return 0, fmt.Errorf("failed to get CRD: %w", err)
+	}
+
+	storage := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storage = version.Name
+		}
+	}
+	if storage == "" {
+		return 0, fmt.Errorf("CRD has no storage version")
+	}
+	stored := crd.Status.StoredVersions
+	if len(stored) == 0 || (len(stored) == 1 && stored[0] == storage) {
+		return 0, nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: storage,
+		Kind:    crd.Spec.Names.ListKind,
+	})
+	if err := m.reader.List(ctx, list); err != nil {
+		return 0, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	// An update without changes is enough: the API server writes the object again, in
+	// the storage version. Objects deleted or updated meanwhile were written already.
+	migrated := 0
+	for i := range list.Items {
+		err := m.writer.Update(ctx, &list.Items[i])
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return migrated, fmt.Errorf("failed to rewrite %s/%s: %w",
+				list.Items[i].GetNamespace(), list.Items[i].GetName(), err)
+		}
+		migrated++
+	}
+
+	crd.Status.StoredVersions = []string{storage}
+	if err := m.writer.Status().Update(ctx, &crd); err != nil {
+		return migrated, fmt.Errorf("failed to update stored versions: %w", err)
+	}
+	return migrated, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope encrypts the kubeconfigs the operator stores in Secrets. Every value
+// is sealed with AES-256-GCM under a fresh data key, and the data key is wrapped by a
+// KeyWrapper: the operator master key, or a KMS holding the key encryption key.
+//
+// Sealed values are strings of the form
+//
+//	enc:v1:<key id>:<base64 wrapped data key>:<base64 nonce and ciphertext>
+//
+// so they fit wherever the base64 kubeconfig went before. Values without the prefix are
+// returned as they are by Open: Secrets written before encryption was enabled, or by
+// external providers, stay readable and are sealed the next time the operator writes them.
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// SealedPrefix starts every sealed value
+const SealedPrefix = "enc:v1:"
+
+// dataKeySize is the size of the AES-256 data keys
+const dataKeySize = 32
+
+// KeyWrapper wraps the data keys of sealed values with a key encryption key
+type KeyWrapper interface {
+	// KeyID identifies the key encryption key; it is stored in the sealed values and may not contain ':'
+	KeyID() string
+	// WrapKey encrypts a data key
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by WrapKey
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// Cipher seals and opens values with data keys wrapped by a KeyWrapper. A nil Cipher
+// leaves values in plaintext.
+type Cipher struct {
+	wrapper KeyWrapper
+}
+
+// NewCipher creates a Cipher wrapping its data keys with wrapper
+func NewCipher(wrapper KeyWrapper) *Cipher {
+	return &Cipher{wrapper: wrapper}
+}
+
+// IsSealed reports whether value was sealed by a Cipher
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix)
+}
+
+// Seal encrypts plaintext under a new data key. Nil Ciphers return plaintext unchanged.
+func (c *Cipher) Seal(ctx context.Context, plaintext string) (string, error) {
+	if c == nil {
+		return plaintext, nil
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	ciphertext, err := gcmSeal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := c.wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return SealedPrefix + c.wrapper.KeyID() + ":" + base64.StdEncoding.EncodeToString(wrapped) + ":" +
+		base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts a sealed value. Values that are not sealed are returned unchanged; sealed
+// values fail to open with a nil Cipher or under another key encryption key.
+func (c *Cipher) Open(ctx context.Context, value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(value, SealedPrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed sealed value")
+	}
+	keyID := parts[0]
+	if c == nil {
+		return "", fmt.Errorf("value is sealed with key %s but no encryption key is configured", keyID)
+	}
+	if keyID != c.wrapper.KeyID() {
+		return "", fmt.Errorf("value is sealed with key %s, the configured key is %s", keyID, c.wrapper.KeyID())
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed wrapped data key: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed ciphertext: %w", err)
+	}
+	dataKey, err := c.wrapper.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	plaintext, err := gcmOpen(dataKey, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// LocalKeyWrapper wraps data keys with AES-256-GCM under the operator master key
+type LocalKeyWrapper struct {
+	key []byte
+	id  string
+}
+
+// NewLocalKeyWrapper creates a LocalKeyWrapper from a 32-byte master key, given raw or base64 encoded
+func NewLocalKeyWrapper(key []byte) (*LocalKeyWrapper, error) {
+	if len(key) != dataKeySize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(key)))
+		if err != nil || len(decoded) != dataKeySize {
+			return nil, fmt.Errorf("master key must be %d bytes, raw or base64 encoded", dataKeySize)
+		}
+		key = decoded
+	}
+	sum := sha256.Sum256(key)
+	return &LocalKeyWrapper{key: key, id: "local-" + hex.EncodeToString(sum[:4])}, nil
+}
+
+// KeyID implements KeyWrapper, derived from the master key so a changed key is detected
+func (w *LocalKeyWrapper) KeyID() string {
+	return w.id
+}
+
+// WrapKey implements KeyWrapper
+func (w *LocalKeyWrapper) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return gcmSeal(w.key, dataKey)
+}
+
+// UnwrapKey implements KeyWrapper
+func (w *LocalKeyWrapper) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	return gcmOpen(w.key, wrapped)
+}
+
+// gcmSeal encrypts plaintext with AES-GCM under key, the random nonce first
+func gcmSeal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// gcmOpen decrypts the output of gcmSeal
+func gcmOpen(key, sealed []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
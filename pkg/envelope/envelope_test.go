@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testCipher(t *testing.T, key []byte) *Cipher {
+	t.Helper()
+	wrapper, err := NewLocalKeyWrapper(key)
+	if err != nil {
+		t.Fatalf("NewLocalKeyWrapper failed: %v", err)
+	}
+	return NewCipher(wrapper)
+}
+
+func TestCipher_SealOpen(t *testing.T) {
+	ctx := context.Background()
+	cipher := testCipher(t, bytes.Repeat([]byte{1}, 32))
+	plaintext := base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nkind: Config\n"))
+
+	sealed, err := cipher.Seal(ctx, plaintext)
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if !IsSealed(sealed) || strings.Contains(sealed, plaintext) {
+		t.Fatalf("Expected a sealed value, got %q", sealed)
+	}
+	again, _ := cipher.Seal(ctx, plaintext)
+	if again == sealed {
+		t.Error("Expected every seal to use a new data key and nonce")
+	}
+	opened, err := cipher.Open(ctx, sealed)
+	if err != nil || opened != plaintext {
+		t.Errorf("Open = %q, %v, expected the plaintext", opened, err)
+	}
+
+	// Plaintext values stay readable
+	if opened, err := cipher.Open(ctx, plaintext); err != nil || opened != plaintext {
+		t.Errorf("Expected plaintext values to be returned as they are, got %q, %v", opened, err)
+	}
+
+	// A nil Cipher does not seal, and cannot open sealed values
+	var off *Cipher
+	if value, err := off.Seal(ctx, plaintext); err != nil || value != plaintext {
+		t.Errorf("Expected nil Ciphers to leave values in plaintext, got %q, %v", value, err)
+	}
+	if _, err := off.Open(ctx, sealed); err == nil {
+		t.Error("Expected an error opening a sealed value without key")
+	}
+
+	// Another master key is detected from the key ID
+	other := testCipher(t, bytes.Repeat([]byte{2}, 32))
+	if _, err := other.Open(ctx, sealed); err == nil || !strings.Contains(err.Error(), "sealed with key") {
+		t.Errorf("Expected a key mismatch error, got %v", err)
+	}
+
+	// Tampered ciphertexts fail to open
+	tampered := sealed[:len(sealed)-4] + "AAA="
+	if _, err := cipher.Open(ctx, tampered); err == nil {
+		t.Error("Expected an error opening a tampered value")
+	}
+}
+
+func TestNewLocalKeyWrapper(t *testing.T) {
+	raw := bytes.Repeat([]byte{7}, 32)
+	fromRaw, err := NewLocalKeyWrapper(raw)
+	if err != nil {
+		t.Fatalf("Expected a raw 32-byte key to be accepted: %v", err)
+	}
+	fromBase64, err := NewLocalKeyWrapper([]byte(base64.StdEncoding.EncodeToString(raw) + "\n"))
+	if err != nil {
+		t.Fatalf("Expected a base64 32-byte key to be accepted: %v", err)
+	}
+	if fromRaw.KeyID() != fromBase64.KeyID() {
+		t.Errorf("Expected the same key ID for both encodings, got %s and %s", fromRaw.KeyID(), fromBase64.KeyID())
+	}
+	if _, err := NewLocalKeyWrapper([]byte("too-short")); err == nil {
+		t.Error("Expected an error for a short key")
+	}
+}
+
+func TestTransitKeyWrapper(t *testing.T) {
+	// Wraps by prefixing the data key, enough to check the API calls
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		var request map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/krkn":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{
+				"ciphertext": "vault:v1:" + request["plaintext"],
+			}})
+		case "/v1/transit/decrypt/krkn":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{
+				"plaintext": strings.TrimPrefix(request["ciphertext"], "vault:v1:"),
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kms", Namespace: "krkn"},
+		Data: map[string][]byte{
+			TransitURLKey:     []byte(server.URL + "/v1/transit/"),
+			TransitKeyNameKey: []byte("krkn"),
+			TransitTokenKey:   []byte("s.token\n"),
+			TransitCAKey:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	ctx := context.Background()
+
+	cipher, err := LoadCipher(ctx, reader, "krkn", "kms")
+	if err != nil {
+		t.Fatalf("LoadCipher failed: %v", err)
+	}
+	sealed, err := cipher.Seal(ctx, "kubeconfig")
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if !strings.HasPrefix(sealed, SealedPrefix+"transit-") {
+		t.Errorf("Expected the transit key ID in %q", sealed)
+	}
+	if opened, err := cipher.Open(ctx, sealed); err != nil || opened != "kubeconfig" {
+		t.Errorf("Open = %q, %v, expected the plaintext", opened, err)
+	}
+
+	if _, err := LoadCipher(ctx, reader, "krkn", "missing"); err == nil {
+		t.Error("Expected an error for a missing Secret")
+	}
+	if _, err := NewTransitKeyWrapper("http://vault.example.com/v1/transit", "krkn", "token", nil); err == nil {
+		t.Error("Expected an error for an http transit URL")
+	}
+}
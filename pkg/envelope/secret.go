@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the encryption key Secret
+const (
+	// MasterKeyKey holds the 32-byte operator master key, raw or base64 encoded
+	MasterKeyKey = "key"
+	// TransitURLKey holds the https URL of the transit engine mount of the KMS
+	TransitURLKey = "transit-url"
+	// TransitKeyNameKey holds the name of the transit key
+	TransitKeyNameKey = "transit-key"
+	// TransitTokenKey holds the token authenticating to the KMS
+	TransitTokenKey = "transit-token"
+	// TransitCAKey optionally holds the PEM CA bundle of the KMS
+	TransitCAKey = "ca.crt"
+)
+
+// LoadCipher creates the Cipher configured by the Secret secretName of namespace: a
+// local master key when it has MasterKeyKey, a transit KMS key when it has
+// TransitURLKey, TransitKeyNameKey and TransitTokenKey
+func LoadCipher(ctx context.Context, reader client.Reader, namespace, secretName string) (*Cipher, error) {
+	var secret corev1.Secret
+	if err := reader.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get encryption key Secret %s: %w", secretName, err)
+	}
+
+	if key, ok := secret.Data[MasterKeyKey]; ok {
+		wrapper, err := NewLocalKeyWrapper(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key Secret %s: %w", secretName, err)
+		}
+		return NewCipher(wrapper), nil
+	}
+
+	if _, ok := secret.Data[TransitURLKey]; !ok {
+		return nil, fmt.Errorf("encryption key Secret %s needs %s or %s, %s and %s", secretName,
+			MasterKeyKey, TransitURLKey, TransitKeyNameKey, TransitTokenKey)
+	}
+	httpClient := &http.Client{Timeout: transitRequestTimeout}
+	if ca := secret.Data[TransitCAKey]; len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("encryption key Secret %s has no valid certificate in %s", secretName, TransitCAKey)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		httpClient.Transport = transport
+	}
+	wrapper, err := NewTransitKeyWrapper(string(secret.Data[TransitURLKey]),
+		strings.TrimSpace(string(secret.Data[TransitKeyNameKey])),
+		strings.TrimSpace(string(secret.Data[TransitTokenKey])), httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key Secret %s: %w", secretName, err)
+	}
+	return NewCipher(wrapper), nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// transitRequestTimeout bounds every call to the KMS
+const transitRequestTimeout = 10 * time.Second
+
+// TransitKeyWrapper wraps data keys with a named key of a KMS speaking the HashiCorp
+// Vault Transit API (Vault, OpenBao): the key encryption key never leaves the KMS.
+type TransitKeyWrapper struct {
+	mountURL string
+	keyName  string
+	token    string
+	client   *http.Client
+	id       string
+}
+
+// NewTransitKeyWrapper creates a TransitKeyWrapper for the key keyName of the transit
+// engine mounted at mountURL (e.g. https://vault.example.com:8200/v1/transit),
+// authenticating with token. client defaults to one with a request timeout.
+func NewTransitKeyWrapper(mountURL, keyName, token string, client *http.Client) (*TransitKeyWrapper, error) {
+	mountURL = strings.TrimRight(strings.TrimSpace(mountURL), "/")
+	if parsed, err := url.Parse(mountURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("transit URL %q is not an https URL", mountURL)
+	}
+	if keyName == "" || token == "" {
+		return nil, fmt.Errorf("transit key name and token are required")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: transitRequestTimeout}
+	}
+	sum := sha256.Sum256([]byte(mountURL + "/" + keyName))
+	return &TransitKeyWrapper{
+		mountURL: mountURL,
+		keyName:  keyName,
+		token:    token,
+		client:   client,
+		id:       "transit-" + hex.EncodeToString(sum[:4]),
+	}, nil
+}
+
+// KeyID implements KeyWrapper, derived from the transit URL and key name. Key versions
+// are tracked by the KMS in the wrapped keys, so rotating the transit key keeps the ID.
+func (w *TransitKeyWrapper) KeyID() string {
+	return w.id
+}
+
+// WrapKey implements KeyWrapper
+func (w *TransitKeyWrapper) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := w.call(ctx, "encrypt", map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(dataKey),
+	}, &response); err != nil {
+		return nil, err
+	}
+	if response.Data.Ciphertext == "" {
+		return nil, fmt.Errorf("transit returned no ciphertext")
+	}
+	return []byte(response.Data.Ciphertext), nil
+}
+
+// UnwrapKey implements KeyWrapper
+func (w *TransitKeyWrapper) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := w.call(ctx, "decrypt", map[string]string{"ciphertext": string(wrapped)}, &response); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Data.Plaintext)
+}
+
+// call POSTs a request to an operation of the transit key and decodes its JSON response
+func (w *TransitKeyWrapper) call(ctx context.Context, operation string, request, response any) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	endpoint := w.mountURL + "/" + operation + "/" + url.PathEscape(w.keyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", w.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("transit %s failed: %w", operation, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("transit %s returned %s: %s", operation, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
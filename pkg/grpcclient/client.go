@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Client shares one connection to the data provider between its callers. The connection
+// is created on first use, reconnects on its own when the server goes away, and is
+// replaced when it was shut down. Its calls are retried when they fail transiently and
+// fail fast while the circuit breaker is open.
+type Client struct {
+	address     string
+	creds       credentials.TransportCredentials
+	maxAttempts int
+	breaker     *CircuitBreaker
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+// NewClient returns a Client connecting to address with creds, in plaintext when creds
+// is nil, with the default retries and circuit breaker. Nothing is dialed until Conn is
+// called.
+func NewClient(address string, creds credentials.TransportCredentials) *Client {
+	return &Client{
+		address:     address,
+		creds:       creds,
+		maxAttempts: DefaultMaxAttempts,
+		breaker:     NewCircuitBreaker(DefaultFailureThreshold, DefaultCooldown),
+	}
+}
+
+// NewClientWithConfig returns a Client connecting to address as configured by config
+func NewClientWithConfig(address string, config Config) (*Client, error) {
+	creds, err := config.TransportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	client := NewClient(address, creds)
+	if config.MaxAttempts > 0 {
+		client.maxAttempts = config.MaxAttempts
+	}
+	threshold, cooldown := config.FailureThreshold, config.Cooldown
+	if threshold <= 0 {
+		threshold = DefaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+	client.breaker = NewCircuitBreaker(threshold, cooldown)
+	return client, nil
+}
+
+// CircuitOpen reports whether the calls to the data provider currently fail fast
+func (c *Client) CircuitOpen() bool {
+	return c.breaker.Open()
+}
+
+// Conn returns the shared connection. A connection in transient failure has its
+// reconnect backoff reset, so the caller's RPC retries the server right away instead
+// of waiting out the backoff of earlier failures.
+func (c *Client) Conn() (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		switch c.conn.GetState() {
+		case connectivity.Shutdown:
+			c.conn = nil
+		case connectivity.TransientFailure:
+			c.conn.ResetConnectBackoff()
+		}
+	}
+	if c.conn == nil {
+		conn, err := Dial(c.address, c.creds,
+			grpc.WithChainUnaryInterceptor(c.breaker.interceptor(), retryInterceptor(c.maxAttempts)))
+		if err != nil {
+			return nil, err
+		}
+		c.conn = conn
+	}
+	return c.conn, nil
+}
+
+// CheckHealth returns an error unless the health service of the data provider reports
+// it serving
+func (c *Client) CheckHealth(ctx context.Context) error {
+	conn, err := c.Conn()
+	if err != nil {
+		return err
+	}
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("data provider is %s", resp.Status)
+	}
+	return nil
+}
+
+// Close closes the shared connection, the next Conn creates a new one
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
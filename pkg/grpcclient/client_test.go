@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
+)
+
+// serveNodes serves nodesServer in plaintext on address, "127.0.0.1:0" for any port
+func serveNodes(t *testing.T, address string) (*grpc.Server, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterDataProviderServiceServer(server, nodesServer{})
+	go func() { _ = server.Serve(listener) }()
+	return server, listener.Addr().String()
+}
+
+func callNodes(client *Client) error {
+	conn, err := client.Conn()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = pb.NewDataProviderServiceClient(conn).GetNodes(ctx, &pb.GetNodesRequest{})
+	return err
+}
+
+func TestClient_SharesAndReconnects(t *testing.T) {
+	server, address := serveNodes(t, "127.0.0.1:0")
+	client := NewClient(address, nil)
+	defer func() { _ = client.Close() }()
+
+	if err := callNodes(client); err != nil {
+		t.Fatalf("GetNodes failed: %v", err)
+	}
+	first, _ := client.Conn()
+	if second, _ := client.Conn(); second != first {
+		t.Error("Expected the connection to be shared")
+	}
+
+	// The connection recovers once the server is back
+	server.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn, _ := client.Conn()
+	if _, err := pb.NewDataProviderServiceClient(conn).GetNodes(ctx, &pb.GetNodesRequest{}); err == nil {
+		t.Fatal("Expected GetNodes to fail while the server is down")
+	}
+	server, _ = serveNodes(t, address)
+	defer server.Stop()
+	if err := callNodes(client); err != nil {
+		t.Errorf("Expected GetNodes to reconnect, got %v", err)
+	}
+
+	// A closed client dials a new connection
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := callNodes(client); err != nil {
+		t.Errorf("Expected GetNodes to succeed after Close, got %v", err)
+	}
+	if conn, _ := client.Conn(); conn == first {
+		t.Error("Expected a new connection after Close")
+	}
+}
+
+func TestClient_CheckHealth(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	client := NewClient(listener.Addr().String(), nil)
+	defer func() { _ = client.Close() }()
+
+	if err := client.CheckHealth(context.Background()); err != nil {
+		t.Errorf("Expected the data provider to be healthy, got %v", err)
+	}
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	if err := client.CheckHealth(context.Background()); err == nil {
+		t.Error("Expected an error while the data provider is not serving")
+	}
+}
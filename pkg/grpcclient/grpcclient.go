@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpcclient connects the operator to the data provider gRPC server, in plaintext
+// or over TLS with the certificates of a mounted Secret
+package grpcclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Schemes of the connection to the data provider
+const (
+	// SchemePlaintext connects without TLS
+	SchemePlaintext = "plaintext"
+	// SchemeTLS connects over TLS, with a client certificate when one is mounted
+	SchemeTLS = "tls"
+)
+
+// Files of the mounted TLS Secret, the keys of kubernetes.io/tls Secrets
+const (
+	// CAFile holds the PEM CA bundle verifying the server, the system roots when missing
+	CAFile = "ca.crt"
+	// CertFile holds the PEM client certificate presented for mutual TLS
+	CertFile = "tls.crt"
+	// KeyFile holds the PEM key of the client certificate
+	KeyFile = "tls.key"
+)
+
+// Config configures the connection to the data provider
+type Config struct {
+	// Scheme is SchemePlaintext (the default when empty) or SchemeTLS
+	Scheme string
+	// TLSDir is the directory the TLS Secret is mounted in, used with SchemeTLS
+	TLSDir string
+	// ServerName overrides the name verified in the server certificate, the host of
+	// the address when empty
+	ServerName string
+
+	// MaxAttempts bounds the attempts of a call failing with Unavailable or
+	// DeadlineExceeded (DefaultMaxAttempts when zero, 1 to not retry)
+	MaxAttempts int
+	// FailureThreshold is the number of consecutive failed calls opening the circuit
+	// breaker (DefaultFailureThreshold when zero)
+	FailureThreshold int
+	// Cooldown is how long the circuit breaker stays open (DefaultCooldown when zero)
+	Cooldown time.Duration
+}
+
+// TransportCredentials returns the credentials of the connections configured by c.
+// With SchemeTLS the server is verified with CAFile when the directory has one, and
+// CertFile and KeyFile are presented for mutual TLS when it has both. The client
+// certificate is read at each handshake, so rotations of the mounted Secret apply to
+// new connections.
+func (c Config) TransportCredentials() (credentials.TransportCredentials, error) {
+	switch c.Scheme {
+	case "", SchemePlaintext:
+		return insecure.NewCredentials(), nil
+	case SchemeTLS:
+	default:
+		return nil, fmt.Errorf("unknown gRPC scheme %q, must be %s or %s", c.Scheme, SchemePlaintext, SchemeTLS)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+	if c.TLSDir == "" {
+		return credentials.NewTLS(tlsConfig), nil
+	}
+
+	caPEM, err := os.ReadFile(filepath.Join(c.TLSDir, CAFile))
+	switch {
+	case err == nil:
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificate found in %s", filepath.Join(c.TLSDir, CAFile))
+		}
+		tlsConfig.RootCAs = pool
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read gRPC CA bundle: %w", err)
+	}
+
+	certFile := filepath.Join(c.TLSDir, CertFile)
+	keyFile := filepath.Join(c.TLSDir, KeyFile)
+	if fileExists(certFile) || fileExists(keyFile) {
+		// Fail at startup rather than on the first call when the pair is unusable
+		if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+			return nil, fmt.Errorf("failed to load gRPC client certificate: %w", err)
+		}
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load gRPC client certificate: %w", err)
+			}
+			return &cert, nil
+		}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Dial creates a client connection to address with creds, in plaintext when creds is nil
+func Dial(address string, creds credentials.TransportCredentials, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	return grpc.NewClient(address, append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)...)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
+)
+
+// nodesServer answers GetNodes with a single node
+type nodesServer struct {
+	pb.UnimplementedDataProviderServiceServer
+}
+
+func (nodesServer) GetNodes(context.Context, *pb.GetNodesRequest) (*pb.GetNodesResponse, error) {
+	return &pb.GetNodesResponse{Nodes: []string{"worker-1"}}, nil
+}
+
+// issue creates a certificate for name signed by parent, self-signed when parent is nil
+func issue(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return cert, key,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFiles(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func getNodes(creds credentials.TransportCredentials, address string) error {
+	conn, err := Dial(address, creds)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = pb.NewDataProviderServiceClient(conn).GetNodes(ctx, &pb.GetNodesRequest{})
+	return err
+}
+
+func TestTransportCredentials_MutualTLS(t *testing.T) {
+	ca, caKey, caPEM, _ := issue(t, "krkn-ca", nil, nil)
+	_, _, serverPEM, serverKeyPEM := issue(t, "data-provider", ca, caKey)
+	_, _, clientPEM, clientKeyPEM := issue(t, "krkn-operator", ca, caKey)
+
+	serverCert, err := tls.X509KeyPair(serverPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to load server certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caPEM)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	pb.RegisterDataProviderServiceServer(server, nodesServer{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+	address := listener.Addr().String()
+
+	mutual := writeFiles(t, map[string][]byte{CAFile: caPEM, CertFile: clientPEM, KeyFile: clientKeyPEM})
+	creds, err := Config{Scheme: SchemeTLS, TLSDir: mutual, ServerName: "data-provider"}.TransportCredentials()
+	if err != nil {
+		t.Fatalf("TransportCredentials failed: %v", err)
+	}
+	if err := getNodes(creds, address); err != nil {
+		t.Errorf("Expected the mutual TLS call to succeed, got %v", err)
+	}
+
+	// The server requires a client certificate
+	caOnly := writeFiles(t, map[string][]byte{CAFile: caPEM})
+	creds, err = Config{Scheme: SchemeTLS, TLSDir: caOnly, ServerName: "data-provider"}.TransportCredentials()
+	if err != nil {
+		t.Fatalf("TransportCredentials failed: %v", err)
+	}
+	if err := getNodes(creds, address); err == nil {
+		t.Error("Expected the call without a client certificate to fail")
+	}
+
+	// The server certificate is not valid for the host of the address
+	creds, err = Config{Scheme: SchemeTLS, TLSDir: mutual}.TransportCredentials()
+	if err != nil {
+		t.Fatalf("TransportCredentials failed: %v", err)
+	}
+	if err := getNodes(creds, address); err == nil {
+		t.Error("Expected the call to fail the server name verification")
+	}
+
+	if err := getNodes(nil, address); err == nil {
+		t.Error("Expected the plaintext call to a TLS server to fail")
+	}
+}
+
+func TestTransportCredentials_Invalid(t *testing.T) {
+	_, _, _, keyPEM := issue(t, "krkn-operator", nil, nil)
+
+	for name, config := range map[string]Config{
+		"unknown scheme": {Scheme: "https"},
+		"invalid CA":     {Scheme: SchemeTLS, TLSDir: writeFiles(t, map[string][]byte{CAFile: []byte("not a certificate")})},
+		"key only":       {Scheme: SchemeTLS, TLSDir: writeFiles(t, map[string][]byte{KeyFile: keyPEM})},
+	} {
+		if _, err := config.TransportCredentials(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	creds, err := Config{}.TransportCredentials()
+	if err != nil || creds.Info().SecurityProtocol != "insecure" {
+		t.Errorf("Expected plaintext credentials by default, got %v, %v", creds, err)
+	}
+}
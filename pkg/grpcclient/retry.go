@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts of a call failing transiently
+	DefaultMaxAttempts = 3
+	// DefaultFailureThreshold is the number of consecutive failed calls opening the circuit
+	DefaultFailureThreshold = 5
+	// DefaultCooldown is how long the circuit stays open before a call is let through
+	DefaultCooldown = 30 * time.Second
+
+	// initialBackoff is the wait before the first retry, doubled for each next one
+	initialBackoff = 200 * time.Millisecond
+	// maxBackoff bounds the wait between two attempts
+	maxBackoff = 2 * time.Second
+)
+
+// ErrCircuitOpen fails the calls made while the circuit breaker is open, without
+// reaching the data provider
+var ErrCircuitOpen = status.Error(codes.Unavailable, "data provider unavailable: circuit breaker open")
+
+// transient reports whether a call failing with err may succeed when retried: the data
+// provider could not be reached or did not answer in time
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// retryInterceptor retries the calls failing transiently up to maxAttempts times in all,
+// with an exponential backoff, as long as the context of the call allows it
+func retryInterceptor(maxAttempts int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := initialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !transient(err) || attempt >= maxAttempts || ctx.Err() != nil {
+				return err
+			}
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = min(2*backoff, maxBackoff)
+		}
+	}
+}
+
+// CircuitBreaker fails calls fast once the data provider failed threshold calls in a row,
+// for cooldown, after which a single call is let through: the circuit closes when it
+// succeeds and opens again when it fails. Only transient failures count, errors the data
+// provider returns about a cluster do not.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	// probing is true while the call let through after the cooldown runs
+	probing bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Open reports whether calls currently fail fast
+func (b *CircuitBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold && (b.probing || b.now().Sub(b.openedAt) < b.cooldown)
+}
+
+// allow reports whether a call may go through, and marks the call let through after the
+// cooldown
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record counts the outcome of a call let through
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if status.Code(err) == codes.Canceled {
+		// Says nothing about the data provider
+		return
+	}
+	if !transient(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// interceptor fails the calls with ErrCircuitOpen while the circuit is open
+func (b *CircuitBreaker) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return ErrCircuitOpen
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
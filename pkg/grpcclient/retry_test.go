@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/krkn-chaos/krkn-operator/proto/dataprovider"
+)
+
+// flakyServer fails the first failures GetNodes calls with code
+type flakyServer struct {
+	pb.UnimplementedDataProviderServiceServer
+	code     codes.Code
+	failures int32
+	calls    atomic.Int32
+}
+
+func (s *flakyServer) GetNodes(context.Context, *pb.GetNodesRequest) (*pb.GetNodesResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.code, "failing")
+	}
+	return &pb.GetNodesResponse{Nodes: []string{"worker-1"}}, nil
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      codes.Code
+		failures  int32
+		wantCode  codes.Code
+		wantCalls int32
+	}{
+		{name: "transient failures", code: codes.Unavailable, failures: 2, wantCode: codes.OK, wantCalls: 3},
+		{name: "attempts exhausted", code: codes.Unavailable, failures: 5, wantCode: codes.Unavailable, wantCalls: 3},
+		{name: "not retried", code: codes.Internal, failures: 1, wantCode: codes.Internal, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("Failed to listen: %v", err)
+			}
+			provider := &flakyServer{code: tt.code, failures: tt.failures}
+			server := grpc.NewServer()
+			pb.RegisterDataProviderServiceServer(server, provider)
+			go func() { _ = server.Serve(listener) }()
+			defer server.Stop()
+
+			client, err := NewClientWithConfig(listener.Addr().String(), Config{MaxAttempts: 3})
+			if err != nil {
+				t.Fatalf("NewClientWithConfig failed: %v", err)
+			}
+			defer func() { _ = client.Close() }()
+
+			if code := status.Code(callNodes(client)); code != tt.wantCode {
+				t.Errorf("Expected code %v, got %v", tt.wantCode, code)
+			}
+			if calls := provider.calls.Load(); calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	intercept := breaker.interceptor()
+
+	var invoked int
+	callErr := status.Error(codes.Unavailable, "connection refused")
+	call := func() error {
+		return intercept(context.Background(), "/GetNodes", nil, nil, nil,
+			func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				invoked++
+				return callErr
+			})
+	}
+
+	// Errors about a cluster do not count
+	callErr = status.Error(codes.Internal, "cluster unreachable")
+	_ = call()
+	callErr = status.Error(codes.Unavailable, "connection refused")
+	_ = call()
+	if breaker.Open() {
+		t.Fatal("Expected the circuit to stay closed below the threshold")
+	}
+	_ = call()
+	if !breaker.Open() {
+		t.Fatal("Expected the circuit to open at the threshold")
+	}
+	if err := call(); !errors.Is(err, ErrCircuitOpen) || invoked != 3 {
+		t.Errorf("Expected the call to fail fast, got %v after %d invocations", err, invoked)
+	}
+
+	// A failed call after the cooldown opens it again
+	now = now.Add(time.Minute)
+	if err := call(); errors.Is(err, ErrCircuitOpen) || invoked != 4 {
+		t.Errorf("Expected a call to be let through after the cooldown, got %v", err)
+	}
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the circuit to open again, got %v", err)
+	}
+
+	// A successful one closes it
+	now = now.Add(time.Minute)
+	callErr = nil
+	if err := call(); err != nil || breaker.Open() {
+		t.Errorf("Expected the circuit to close, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package i18n provides a minimal message catalog for user-facing API messages.
+// Messages are keyed by a stable code and may contain {name} placeholders that
+// are substituted at render time. The default language is always used as a
+// fallback when a translation is missing.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is the language used when negotiation finds no match
+const DefaultLanguage = "en"
+
+// Params holds placeholder values substituted into message templates
+type Params map[string]string
+
+// Catalog maps language tags to message templates keyed by code
+type Catalog struct {
+	messages map[string]map[string]string
+}
+
+// NewCatalog creates a catalog from language -> code -> template maps.
+// The DefaultLanguage entry is expected to contain every code.
+func NewCatalog(messages map[string]map[string]string) *Catalog {
+	return &Catalog{messages: messages}
+}
+
+// Languages returns the languages available in the catalog, sorted
+func (c *Catalog) Languages() []string {
+	languages := make([]string, 0, len(c.messages))
+	for lang := range c.messages {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Render returns the message for code in lang with params substituted.
+// Falls back to DefaultLanguage, then to the code itself.
+func (c *Catalog) Render(lang, code string, params Params) string {
+	template, ok := c.messages[lang][code]
+	if !ok {
+		template, ok = c.messages[DefaultLanguage][code]
+	}
+	if !ok {
+		template = code
+	}
+
+	if len(params) == 0 {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// Negotiate picks the best catalog language for an Accept-Language header value.
+// Quality values are honoured and region subtags fall back to their base
+// language (e.g. "it-IT" matches "it").
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, quality := part, 1.0
+		if idx := strings.Index(part, ";"); idx >= 0 {
+			tag = strings.TrimSpace(part[:idx])
+			if q, found := strings.CutPrefix(strings.TrimSpace(part[idx+1:]), "q="); found {
+				parsed, err := strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+				quality = parsed
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: strings.ToLower(tag), quality: quality})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, cand := range candidates {
+		if cand.tag == "*" {
+			return DefaultLanguage
+		}
+		if _, ok := c.messages[cand.tag]; ok {
+			return cand.tag
+		}
+		base, _, _ := strings.Cut(cand.tag, "-")
+		if _, ok := c.messages[base]; ok {
+			return base
+		}
+	}
+
+	return DefaultLanguage
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i18n
+
+import "testing"
+
+func testCatalog() *Catalog {
+	return NewCatalog(map[string]map[string]string{
+		"en": {
+			"not_found": "Target '{name}' not found",
+			"only_en":   "English only",
+		},
+		"it": {
+			"not_found": "Target '{name}' non trovato",
+		},
+		"pt-br": {
+			"not_found": "Alvo '{name}' não encontrado",
+		},
+	})
+}
+
+func TestRender(t *testing.T) {
+	catalog := testCatalog()
+
+	tests := []struct {
+		name     string
+		lang     string
+		code     string
+		params   Params
+		expected string
+	}{
+		{name: "english with params", lang: "en", code: "not_found", params: Params{"name": "a"}, expected: "Target 'a' not found"},
+		{name: "italian with params", lang: "it", code: "not_found", params: Params{"name": "a"}, expected: "Target 'a' non trovato"},
+		{name: "missing translation falls back", lang: "it", code: "only_en", expected: "English only"},
+		{name: "unknown language falls back", lang: "de", code: "only_en", expected: "English only"},
+		{name: "unknown code returns code", lang: "en", code: "missing", expected: "missing"},
+		{name: "unused placeholder kept", lang: "en", code: "not_found", expected: "Target '{name}' not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Render(tt.lang, tt.code, tt.params); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	catalog := testCatalog()
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: "en"},
+		{header: "it", expected: "it"},
+		{header: "it-IT,it;q=0.9,en;q=0.8", expected: "it"},
+		{header: "de-DE,de;q=0.9,it;q=0.5", expected: "it"},
+		{header: "en;q=0.3,it;q=0.7", expected: "it"},
+		{header: "pt-BR", expected: "pt-br"},
+		{header: "it;q=0,en", expected: "en"},
+		{header: "fr, *;q=0.1", expected: "en"},
+		{header: "it;q=abc", expected: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := catalog.Negotiate(tt.header); got != tt.expected {
+				t.Errorf("Negotiate(%q) = %q, expected %q", tt.header, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package joblabels is the label schema of the resources created for scenario runs:
+// scenario Jobs and their pods, kubeconfig and file ConfigMaps, registry pull secrets
+// and failure hook pods. The controller writes it and the API reads it, so every
+// endpoint resolves the same identifiers whatever created the resource.
+package joblabels
+
+// Label keys
+const (
+	// App tells scenario pods (krkn-scenario) from failure hook pods (krkn-failure-hook)
+	App = "app"
+	// JobID is the ID of the cluster job a resource belongs to
+	JobID = "krkn-job-id"
+	// ScenarioRun is the name of the KrknScenarioRun
+	ScenarioRun = "krkn-scenario-run"
+	// ScenarioName is the name of the scenario being run
+	ScenarioName = "krkn-scenario-name"
+	// ClusterName is the name of the target cluster
+	ClusterName = "krkn-cluster-name"
+	// TargetRequest is the ID of the KrknTargetRequest the run's targets come from
+	TargetRequest = "krkn-target-request"
+	// FailureHook is the name of the onFailure hook run by a hook pod
+	FailureHook = "krkn-failure-hook"
+	// OwnerUser is the sanitized ID of the user owning the run
+	OwnerUser = "krkn.krkn-chaos.dev/owner-user"
+
+	// LegacyTargetID is the target label of the pods created by the legacy scenario endpoints.
+	// It is still written next to TargetRequest and read when TargetRequest is missing, until
+	// no client selects on it anymore.
+	LegacyTargetID = "krkn-target-id"
+)
+
+// Values of the App label
+const (
+	AppScenario    = "krkn-scenario"
+	AppFailureHook = "krkn-failure-hook"
+)
+
+// Run returns the labels shared by the resources of a scenario run for one target
+// cluster. owner is the sanitized owner user ID, left out when empty.
+func Run(scenarioRun, scenarioName, clusterName, targetID, owner string) map[string]string {
+	labels := map[string]string{
+		ScenarioRun:    scenarioRun,
+		ScenarioName:   scenarioName,
+		ClusterName:    clusterName,
+		TargetRequest:  targetID,
+		LegacyTargetID: targetID,
+	}
+	if owner != "" {
+		labels[OwnerUser] = owner
+	}
+	return labels
+}
+
+// Identity holds the identifiers of a scenario run resource
+type Identity struct {
+	JobID        string
+	ScenarioRun  string
+	ScenarioName string
+	ClusterName  string
+	TargetID     string
+}
+
+// Read returns the identifiers carried by the labels of a resource. The target ID
+// falls back to the legacy label for resources labeled before the schema was unified.
+func Read(labels map[string]string) Identity {
+	identity := Identity{
+		JobID:        labels[JobID],
+		ScenarioRun:  labels[ScenarioRun],
+		ScenarioName: labels[ScenarioName],
+		ClusterName:  labels[ClusterName],
+		TargetID:     labels[TargetRequest],
+	}
+	if identity.TargetID == "" {
+		identity.TargetID = labels[LegacyTargetID]
+	}
+	return identity
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joblabels
+
+import "testing"
+
+func TestRun(t *testing.T) {
+	labels := Run("run-1", "pod-scenarios", "cluster-1", "target-1", "")
+
+	if labels[TargetRequest] != "target-1" || labels[LegacyTargetID] != "target-1" {
+		t.Errorf("Expected the target ID under both labels, got %v", labels)
+	}
+	if _, ok := labels[OwnerUser]; ok {
+		t.Errorf("Expected no owner label without owner, got %v", labels)
+	}
+	if got := Run("run-1", "pod-scenarios", "cluster-1", "target-1", "alice-example-com")[OwnerUser]; got != "alice-example-com" {
+		t.Errorf("Expected the owner label, got %q", got)
+	}
+}
+
+func TestRead(t *testing.T) {
+	identity := Read(map[string]string{
+		JobID:         "job-1",
+		ScenarioRun:   "run-1",
+		ScenarioName:  "pod-scenarios",
+		ClusterName:   "cluster-1",
+		TargetRequest: "target-1",
+	})
+	want := Identity{JobID: "job-1", ScenarioRun: "run-1", ScenarioName: "pod-scenarios", ClusterName: "cluster-1", TargetID: "target-1"}
+	if identity != want {
+		t.Errorf("Read() = %+v, want %+v", identity, want)
+	}
+
+	// Resources labeled by the legacy endpoints only carry krkn-target-id
+	if got := Read(map[string]string{LegacyTargetID: "target-2"}).TargetID; got != "target-2" {
+		t.Errorf("Expected the legacy target label to be read, got %q", got)
+	}
+}
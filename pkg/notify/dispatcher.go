@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxInFlight is the default number of deliveries a Dispatcher runs at once
+	DefaultMaxInFlight = 32
+	// DefaultDeliveryTimeout is the default time a delivery has to reach every subscriber
+	DefaultDeliveryTimeout = 30 * time.Second
+)
+
+// ErrDispatcherBusy is returned by Dispatcher.Send when the maximum number of deliveries
+// is already running; the notification is not sent
+var ErrDispatcherBusy = errors.New("too many notifications in flight, notification dropped")
+
+// Dispatcher delivers notifications in the background, so that slow or unreachable
+// channels never hold up the caller. At most maxInFlight deliveries run at once, and
+// each has its own timeout to reach every subscriber.
+type Dispatcher struct {
+	notifier Notifier
+	timeout  time.Duration
+	slots    chan struct{}
+	inFlight sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher sending through notifier
+func NewDispatcher(notifier Notifier, maxInFlight int, timeout time.Duration) *Dispatcher {
+	return &Dispatcher{
+		notifier: notifier,
+		timeout:  timeout,
+		slots:    make(chan struct{}, max(maxInFlight, 1)),
+	}
+}
+
+// Send delivers notification to subs in the background, then calls done, when not nil,
+// with the failures joined as by Dispatch. The delivery keeps the values of ctx but not
+// its cancellation. Returns ErrDispatcherBusy, without sending, when the maximum number
+// of deliveries is running.
+func (d *Dispatcher) Send(ctx context.Context, subs []Subscription, notification Notification, done func(error)) error {
+	select {
+	case d.slots <- struct{}{}:
+	default:
+		return ErrDispatcherBusy
+	}
+
+	d.inFlight.Add(1)
+	go func() {
+		defer d.inFlight.Done()
+		defer func() { <-d.slots }()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.timeout)
+		defer cancel()
+		err := Dispatch(ctx, d.notifier, subs, notification)
+		if done != nil {
+			done(err)
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until the deliveries in flight are done
+func (d *Dispatcher) Wait() {
+	d.inFlight.Wait()
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingNotifier holds every delivery until its context is done or release is closed
+type blockingNotifier struct {
+	release chan struct{}
+}
+
+func (n *blockingNotifier) Notify(ctx context.Context, _ Channel, _ Notification) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-n.release:
+		return nil
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	notifier := &blockingNotifier{release: make(chan struct{})}
+	dispatcher := NewDispatcher(notifier, 1, time.Minute)
+	subs := []Subscription{{UserID: "a", Channel: Channel{Type: ChannelWebhook, URL: "https://hooks.example.com"}}}
+
+	results := make(chan error, 1)
+	if err := dispatcher.Send(context.Background(), subs, Notification{}, func(err error) { results <- err }); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	// Send never blocks: past the limit notifications are dropped
+	if err := dispatcher.Send(context.Background(), subs, Notification{}, nil); !errors.Is(err, ErrDispatcherBusy) {
+		t.Errorf("expected ErrDispatcherBusy, got %v", err)
+	}
+
+	close(notifier.release)
+	if err := <-results; err != nil {
+		t.Errorf("expected the delivery to succeed, got %v", err)
+	}
+	dispatcher.Wait()
+
+	// The slot is free again
+	if err := dispatcher.Send(context.Background(), subs, Notification{}, nil); err != nil {
+		t.Errorf("expected Send to succeed once the delivery finished, got %v", err)
+	}
+	dispatcher.Wait()
+}
+
+func TestDispatcher_Timeout(t *testing.T) {
+	dispatcher := NewDispatcher(&blockingNotifier{release: make(chan struct{})}, 1, 10*time.Millisecond)
+	subs := []Subscription{{UserID: "a", Channel: Channel{Type: ChannelWebhook, URL: "https://hooks.example.com"}}}
+
+	// Cancelling the caller context does not cancel the delivery, the timeout does
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan error, 1)
+	if err := dispatcher.Send(ctx, subs, Notification{}, func(err error) { results <- err }); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	cancel()
+
+	if err := <-results; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the delivery to time out, got %v", err)
+	}
+}
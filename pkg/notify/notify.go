@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify manages scenario run observers and delivers phase-change
+// notifications to the channel each observer configured.
+//
+// Observers are stored as a JSON annotation on the KrknScenarioRun itself, so
+// subscriptions share the run's lifecycle and need no extra CRD or cleanup.
+// Channel URLs are user input: a Policy keeps them off the operator's own network.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObserversAnnotation holds the JSON-encoded list of run observers
+const ObserversAnnotation = "krkn.krkn-chaos.dev/observers"
+
+// Supported channel types
+const (
+	// ChannelWebhook POSTs the Notification as JSON
+	ChannelWebhook = "webhook"
+	// ChannelSlack POSTs a Slack incoming-webhook message
+	ChannelSlack = "slack"
+)
+
+// Channel is where an observer wants to receive notifications
+type Channel struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Validate checks the channel type, and that policy accepts the channel URL
+func (c Channel) Validate(policy Policy) error {
+	switch c.Type {
+	case ChannelWebhook, ChannelSlack:
+	default:
+		return fmt.Errorf("channel type must be one of: %s, %s", ChannelWebhook, ChannelSlack)
+	}
+	parsed, err := url.Parse(c.URL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("channel url must be an absolute http(s) URL")
+	}
+	return policy.checkURL(parsed)
+}
+
+// Subscription is a single observer of a run
+type Subscription struct {
+	UserID       string    `json:"userId"`
+	Channel      Channel   `json:"channel"`
+	SubscribedAt time.Time `json:"subscribedAt"`
+}
+
+// Subscriptions returns the observers stored on obj, sorted by user ID
+func Subscriptions(obj metav1.Object) ([]Subscription, error) {
+	raw, ok := obj.GetAnnotations()[ObserversAnnotation]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	var subs []Subscription
+	if err := json.Unmarshal([]byte(raw), &subs); err != nil {
+		return nil, fmt.Errorf("malformed %s annotation: %w", ObserversAnnotation, err)
+	}
+	return subs, nil
+}
+
+// Subscribe adds or replaces the subscription of sub.UserID on obj
+func Subscribe(obj metav1.Object, sub Subscription) error {
+	subs, err := Subscriptions(obj)
+	if err != nil {
+		return err
+	}
+	filtered := subs[:0]
+	for _, existing := range subs {
+		if existing.UserID != sub.UserID {
+			filtered = append(filtered, existing)
+		}
+	}
+	return setSubscriptions(obj, append(filtered, sub))
+}
+
+// Unsubscribe removes userID from the observers of obj.
+// Returns false if the user was not subscribed.
+func Unsubscribe(obj metav1.Object, userID string) (bool, error) {
+	subs, err := Subscriptions(obj)
+	if err != nil {
+		return false, err
+	}
+	filtered := subs[:0]
+	for _, existing := range subs {
+		if existing.UserID != userID {
+			filtered = append(filtered, existing)
+		}
+	}
+	if len(filtered) == len(subs) {
+		return false, nil
+	}
+	return true, setSubscriptions(obj, filtered)
+}
+
+func setSubscriptions(obj metav1.Object, subs []Subscription) error {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(subs) == 0 {
+		delete(annotations, ObserversAnnotation)
+		obj.SetAnnotations(annotations)
+		return nil
+	}
+
+	sort.Slice(subs, func(i, j int) bool { return subs[i].UserID < subs[j].UserID })
+	data, err := json.Marshal(subs)
+	if err != nil {
+		return err
+	}
+	annotations[ObserversAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// Notification describes a scenario run phase change, or a cluster job phase
+// change when ClusterName is set
+type Notification struct {
+	ScenarioRun   string    `json:"scenarioRun"`
+	ScenarioName  string    `json:"scenarioName"`
+	RunNumber     int64     `json:"runNumber,omitempty"`
+	Title         string    `json:"title,omitempty"`
+	Namespace     string    `json:"namespace"`
+	PreviousPhase string    `json:"previousPhase"`
+	Phase         string    `json:"phase"`
+	Timestamp     time.Time `json:"timestamp"`
+	ClusterName   string    `json:"clusterName,omitempty"`
+	JobID         string    `json:"jobId,omitempty"`
+	FailureReason string    `json:"failureReason,omitempty"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// Notifier delivers a notification to a single channel
+type Notifier interface {
+	Notify(ctx context.Context, channel Channel, notification Notification) error
+}
+
+// HTTPNotifier delivers notifications to webhook and Slack channels
+type HTTPNotifier struct {
+	client *http.Client
+	policy Policy
+}
+
+// NewHTTPNotifier creates an HTTPNotifier with a short request timeout, only calling the
+// channels policy accepts. Host names are checked again once resolved, and on redirects.
+func NewHTTPNotifier(policy Policy) *HTTPNotifier {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: policy.checkDial,
+	}).DialContext
+	return &HTTPNotifier{
+		client: &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 10 {
+					return errors.New("stopped after 10 redirects")
+				}
+				return policy.checkURL(req.URL)
+			},
+		},
+		policy: policy,
+	}
+}
+
+// Notify implements Notifier
+func (n *HTTPNotifier) Notify(ctx context.Context, channel Channel, notification Notification) error {
+	// Observers annotations can be edited without the API, check them again
+	if err := channel.Validate(n.policy); err != nil {
+		return err
+	}
+
+	var payload any
+	switch channel.Type {
+	case ChannelWebhook:
+		payload = notification
+	case ChannelSlack:
+		// Humans read the title of the run, the name is kept for lookups
+		run := "*" + notification.ScenarioRun + "*"
+		if notification.Title != "" && notification.Title != notification.ScenarioRun {
+			run = "*" + notification.Title + "* [" + notification.ScenarioRun + "]"
+		}
+		text := fmt.Sprintf("Scenario run %s (%s) changed phase: %s → %s",
+			run, notification.ScenarioName,
+			notification.PreviousPhase, notification.Phase)
+		if notification.ClusterName != "" {
+			text = fmt.Sprintf("Scenario run %s (%s) job on cluster *%s* changed phase: %s → %s",
+				run, notification.ScenarioName, notification.ClusterName,
+				notification.PreviousPhase, notification.Phase)
+			if notification.Message != "" {
+				text += "\n" + notification.Message
+			}
+		}
+		payload = map[string]string{"text": text}
+	default:
+		return fmt.Errorf("unsupported channel type %q", channel.Type)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s channel returned status %d", channel.Type, resp.StatusCode)
+	}
+	return nil
+}
+
+// Dispatch sends notification to every subscriber, continuing past failures.
+// The returned error joins the failures of individual deliveries.
+func Dispatch(ctx context.Context, notifier Notifier, subs []Subscription, notification Notification) error {
+	var errs []error
+	for _, sub := range subs {
+		if err := notifier.Notify(ctx, sub.Channel, notification); err != nil {
+			errs = append(errs, fmt.Errorf("notify %s: %w", sub.UserID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
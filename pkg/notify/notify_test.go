@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	obj := &metav1.ObjectMeta{Name: "run-1"}
+
+	_ = Subscribe(obj, Subscription{UserID: "bob@example.com", Channel: Channel{Type: ChannelWebhook, URL: "https://a"}})
+	_ = Subscribe(obj, Subscription{UserID: "alice@example.com", Channel: Channel{Type: ChannelSlack, URL: "https://b"}})
+	// Re-subscribing replaces the channel
+	_ = Subscribe(obj, Subscription{UserID: "bob@example.com", Channel: Channel{Type: ChannelWebhook, URL: "https://c"}})
+
+	subs, err := Subscriptions(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subs) != 2 || subs[0].UserID != "alice@example.com" || subs[1].Channel.URL != "https://c" {
+		t.Fatalf("unexpected subscriptions: %+v", subs)
+	}
+
+	removed, err := Unsubscribe(obj, "carol@example.com")
+	if err != nil || removed {
+		t.Errorf("expected no-op for unknown user, got removed=%v err=%v", removed, err)
+	}
+
+	_, _ = Unsubscribe(obj, "alice@example.com")
+	_, _ = Unsubscribe(obj, "bob@example.com")
+	if _, ok := obj.Annotations[ObserversAnnotation]; ok {
+		t.Error("expected annotation to be removed with the last observer")
+	}
+}
+
+func TestSubscriptions_Malformed(t *testing.T) {
+	obj := &metav1.ObjectMeta{Annotations: map[string]string{ObserversAnnotation: "{not json"}}
+	if _, err := Subscriptions(obj); err == nil {
+		t.Error("expected error for malformed annotation")
+	}
+}
+
+func TestHTTPNotifier(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		data, _ := json.Marshal(payload)
+		bodies = append(bodies, string(data))
+		if strings.HasSuffix(r.URL.Path, "/fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	subs := []Subscription{
+		{UserID: "a", Channel: Channel{Type: ChannelWebhook, URL: server.URL + "/hook"}},
+		{UserID: "b", Channel: Channel{Type: ChannelSlack, URL: server.URL + "/slack"}},
+		{UserID: "c", Channel: Channel{Type: ChannelWebhook, URL: server.URL + "/fail"}},
+	}
+	notification := Notification{ScenarioRun: "run-1", ScenarioName: "pod-scenarios", RunNumber: 12,
+		Title: "pod-scenarios #12", PreviousPhase: "Running", Phase: "Succeeded"}
+
+	// The test server listens on the loopback interface
+	err := Dispatch(context.Background(), NewHTTPNotifier(Policy{AllowInternalAddresses: true}), subs, notification)
+	if err == nil || !strings.Contains(err.Error(), "notify c") {
+		t.Errorf("expected failure for subscriber c, got %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected every subscriber to be notified, got %d deliveries", len(bodies))
+	}
+	if !strings.Contains(bodies[0], `"phase":"Succeeded"`) {
+		t.Errorf("webhook payload missing phase: %s", bodies[0])
+	}
+	if !strings.Contains(bodies[0], `"runNumber":12`) {
+		t.Errorf("webhook payload missing run number: %s", bodies[0])
+	}
+	if !strings.Contains(bodies[1], `*pod-scenarios #12* [run-1]`) {
+		t.Errorf("slack text missing run title: %s", bodies[1])
+	}
+	if !strings.Contains(bodies[1], `"text"`) {
+		t.Errorf("slack payload missing text: %s", bodies[1])
+	}
+}
+
+func TestChannelValidate(t *testing.T) {
+	valid := Channel{Type: ChannelSlack, URL: "https://hooks.slack.com/services/x"}
+	if err := valid.Validate(Policy{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, c := range []Channel{
+		{Type: "email", URL: "https://example.com"},
+		{Type: ChannelWebhook, URL: "ftp://example.com"},
+		{Type: ChannelWebhook, URL: ""},
+		{Type: ChannelWebhook, URL: "http://127.0.0.1:8080/hook"},
+		{Type: ChannelWebhook, URL: "http://localhost/hook"},
+		{Type: ChannelWebhook, URL: "http://[::1]/hook"},
+		{Type: ChannelWebhook, URL: "http://169.254.169.254/latest/meta-data"},
+		{Type: ChannelWebhook, URL: "http://[::ffff:169.254.169.254]/"},
+		{Type: ChannelWebhook, URL: "http://0.0.0.0/"},
+		{Type: ChannelWebhook, URL: "http://10.96.0.10/hook"},
+		{Type: ChannelWebhook, URL: "http://100.100.100.200/"},
+		{Type: ChannelWebhook, URL: "http://[fd12:3456::1]/"},
+	} {
+		if err := c.Validate(Policy{}); err == nil {
+			t.Errorf("expected error for %+v", c)
+		}
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	policy := Policy{AllowedHosts: []string{"hooks.slack.com", "*.example.com"}, AllowedSchemes: []string{"https"}}
+
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://hooks.slack.com/services/x"},
+		{url: "https://HOOKS.SLACK.COM./services/x"},
+		{url: "https://incidents.example.com/hook"},
+		{url: "https://example.com/hook", wantErr: true},
+		{url: "https://evil-example.com/hook", wantErr: true},
+		{url: "http://incidents.example.com/hook", wantErr: true},
+		{url: "https://other.org/hook", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := Channel{Type: ChannelWebhook, URL: tt.url}.Validate(policy)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+
+	internal := Channel{Type: ChannelWebhook, URL: "http://127.0.0.1:8080/hook"}
+	if err := internal.Validate(Policy{AllowInternalAddresses: true}); err != nil {
+		t.Errorf("expected internal addresses to be allowed, got %v", err)
+	}
+}
+
+func TestPolicyFromEnv(t *testing.T) {
+	t.Setenv(AllowedHostsEnv, "hooks.slack.com, *.Example.com,")
+	t.Setenv(AllowedSchemesEnv, "https")
+	t.Setenv(AllowInternalAddressesEnv, "true")
+
+	policy := PolicyFromEnv()
+	if len(policy.AllowedHosts) != 2 || policy.AllowedHosts[1] != "*.example.com" {
+		t.Errorf("unexpected allowed hosts %v", policy.AllowedHosts)
+	}
+	if len(policy.AllowedSchemes) != 1 || !policy.AllowInternalAddresses {
+		t.Errorf("unexpected policy %+v", policy)
+	}
+}
+
+func TestHTTPNotifier_BlocksInternalAddresses(t *testing.T) {
+	delivered := false
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		delivered = true
+	}))
+	defer server.Close()
+
+	notifier := NewHTTPNotifier(Policy{})
+	// Rejected before sending
+	if err := notifier.Notify(context.Background(), Channel{Type: ChannelWebhook, URL: server.URL}, Notification{}); err == nil {
+		t.Error("expected a loopback channel to be rejected")
+	}
+	// Host names are checked once resolved
+	if err := notifier.policy.checkDial("tcp", server.Listener.Addr().String(), nil); err == nil {
+		t.Error("expected a dial to a loopback address to be rejected")
+	}
+	for _, address := range []string{"10.0.0.1:443", "172.16.0.1:443", "192.168.1.1:443", "100.64.0.1:443", "[fd00::1]:443"} {
+		if err := notifier.policy.checkDial("tcp", address, nil); err == nil {
+			t.Errorf("expected a dial to the private address %s to be rejected", address)
+		}
+	}
+	if err := notifier.policy.checkDial("tcp", "203.0.113.10:443", nil); err != nil {
+		t.Errorf("expected a dial to a public address to be allowed, got %v", err)
+	}
+	if delivered {
+		t.Error("expected nothing to be delivered")
+	}
+}
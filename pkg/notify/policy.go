@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Environment variables configuring the Policy of the operator, read by PolicyFromEnv
+const (
+	// AllowedHostsEnv is a comma-separated list of the hosts notifications may be sent to
+	AllowedHostsEnv = "NOTIFY_ALLOWED_HOSTS"
+	// AllowedSchemesEnv is a comma-separated list of the accepted channel URL schemes
+	AllowedSchemesEnv = "NOTIFY_ALLOWED_SCHEMES"
+	// AllowInternalAddressesEnv accepts loopback, link-local and private channel addresses when "true"
+	AllowInternalAddressesEnv = "NOTIFY_ALLOW_INTERNAL_ADDRESSES"
+)
+
+// defaultSchemes are the channel URL schemes accepted when the Policy lists none
+var defaultSchemes = []string{"http", "https"}
+
+// Policy restricts the channel URLs notifications are sent to, so that run observers and
+// failure hooks cannot make the operator call endpoints only it can reach. The zero
+// Policy accepts http and https URLs of any host but loopback, link-local (including the
+// cloud metadata endpoint 169.254.169.254) and unspecified addresses.
+type Policy struct {
+	// AllowedSchemes are the accepted URL schemes; empty accepts http and https
+	AllowedSchemes []string
+	// AllowedHosts, when set, are the only accepted hosts: a host name or IP, or
+	// "*.example.com" for every subdomain of example.com
+	AllowedHosts []string
+	// AllowInternalAddresses accepts loopback, link-local and private (RFC 1918, RFC 6598
+	// and fc00::/7) addresses
+	AllowInternalAddresses bool
+}
+
+// PolicyFromEnv returns the Policy configured by AllowedHostsEnv, AllowedSchemesEnv and
+// AllowInternalAddressesEnv
+func PolicyFromEnv() Policy {
+	policy := Policy{
+		AllowedSchemes: splitList(os.Getenv(AllowedSchemesEnv)),
+		AllowedHosts:   splitList(os.Getenv(AllowedHostsEnv)),
+	}
+	policy.AllowInternalAddresses, _ = strconv.ParseBool(os.Getenv(AllowInternalAddressesEnv))
+	return policy
+}
+
+// checkURL returns why the policy rejects u, nil when it accepts it
+func (p Policy) checkURL(u *url.URL) error {
+	schemes := p.AllowedSchemes
+	if len(schemes) == 0 {
+		schemes = defaultSchemes
+	}
+	if !slices.Contains(schemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("channel url scheme must be one of: %s", strings.Join(schemes, ", "))
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return fmt.Errorf("channel url must be an absolute http(s) URL")
+	}
+	if len(p.AllowedHosts) > 0 && !slices.ContainsFunc(p.AllowedHosts, func(allowed string) bool {
+		return hostMatches(host, allowed)
+	}) {
+		return fmt.Errorf("channel host %q is not an allowed notification host", host)
+	}
+	if p.AllowInternalAddresses {
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("channel url must not target a loopback address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && isInternal(addr) {
+		return fmt.Errorf("channel url must not target a loopback, link-local or private address")
+	}
+	return nil
+}
+
+// checkDial is the net.Dialer Control of the HTTPNotifier: it rejects connections to
+// internal addresses once host names are resolved
+func (p Policy) checkDial(_, address string, _ syscall.RawConn) error {
+	if p.AllowInternalAddresses {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if isInternal(addr) {
+		return fmt.Errorf("notification to %s blocked: loopback, link-local and private addresses are not allowed", addr)
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, private like the RFC 1918 ones
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isInternal reports whether addr can only be reached from the operator pod, its node or
+// the private networks of the cluster
+func isInternal(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() || addr.IsPrivate() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// hostMatches reports whether host matches the allowed host pattern
+func hostMatches(host, allowed string) bool {
+	allowed = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(allowed)), ".")
+	if suffix, ok := strings.CutPrefix(allowed, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return host == allowed
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, strings.ToLower(item))
+		}
+	}
+	return items
+}
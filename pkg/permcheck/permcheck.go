@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package permcheck verifies at startup that the operator can use its custom
+// resources and Secrets in the namespace it was configured with. A wrong
+// namespace or a missing RBAC rule otherwise surfaces as API endpoints
+// answering 404 or 500 for resources that do exist.
+package permcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Requirement is a set of verbs the operator needs on a namespaced resource
+type Requirement struct {
+	// Group is the API group, empty for the core group
+	Group string
+	// Resource is the plural resource name, optionally with a subresource (e.g. "pods/log")
+	Resource string
+	// Verbs the operator uses on the resource
+	Verbs []string
+}
+
+// MissingPermission lists the verbs denied on a resource
+type MissingPermission struct {
+	Group    string   `json:"group"`
+	Resource string   `json:"resource"`
+	Verbs    []string `json:"verbs"`
+}
+
+// String renders the permission like an RBAC rule, e.g. "secrets: get,list"
+func (m MissingPermission) String() string {
+	resource := m.Resource
+	if m.Group != "" {
+		resource += "." + m.Group
+	}
+	return resource + ": " + strings.Join(m.Verbs, ",")
+}
+
+// Report is the outcome of a permission check
+type Report struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	// Namespace is the namespace the operator was configured with
+	Namespace string `json:"namespace"`
+	// OK is false when a permission is missing
+	OK      bool                `json:"ok"`
+	Missing []MissingPermission `json:"missing,omitempty"`
+	// Hints point at custom resources found in other namespaces only
+	Hints []string `json:"hints,omitempty"`
+	// Error is set when the check itself could not be completed
+	Error string `json:"error,omitempty"`
+}
+
+// Checker runs the permission check once at startup and keeps the result.
+// It implements manager.Runnable and can be used as a readyz check.
+type Checker struct {
+	reviews      authorizationv1client.SelfSubjectAccessReviewInterface
+	reader       client.Reader
+	namespace    string
+	requirements []Requirement
+	// probes are the kinds listed cluster-wide when the namespace has none of them
+	probes []schema.GroupVersionKind
+
+	mu     sync.RWMutex
+	report *Report
+}
+
+// NewChecker creates a Checker. reader should be uncached (mgr.GetAPIReader()) so the
+// cluster-wide listing needs no informer.
+func NewChecker(
+	reviews authorizationv1client.SelfSubjectAccessReviewInterface,
+	reader client.Reader,
+	namespace string,
+	requirements []Requirement,
+	probes []schema.GroupVersionKind,
+) *Checker {
+	return &Checker{
+		reviews:      reviews,
+		reader:       reader,
+		namespace:    namespace,
+		requirements: requirements,
+		probes:       probes,
+	}
+}
+
+// Start implements manager.Runnable
+func (c *Checker) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("permission-check")
+
+	report := c.Check(ctx)
+	if report.Error != "" {
+		logger.Error(fmt.Errorf("%s", report.Error), "permission check could not be completed",
+			"namespace", report.Namespace)
+	}
+	for _, missing := range report.Missing {
+		logger.Error(fmt.Errorf("forbidden"), "⚠️ Missing RBAC permission in the operator namespace",
+			"namespace", report.Namespace,
+			"apiGroup", missing.Group,
+			"resource", missing.Resource,
+			"verbs", missing.Verbs)
+	}
+	for _, hint := range report.Hints {
+		logger.Info("⚠️ Operator namespace may be misconfigured", "namespace", report.Namespace, "hint", hint)
+	}
+	if report.OK && report.Error == "" {
+		logger.Info("✅ Operator has the permissions it needs", "namespace", report.Namespace)
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Every replica reports its own readiness, so the check runs everywhere.
+func (c *Checker) NeedLeaderElection() bool {
+	return false
+}
+
+// Report returns the latest report, or nil if the check has not run yet
+func (c *Checker) Report() *Report {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.report
+}
+
+// ReadyzCheck is a healthz.Checker that fails while a permission is missing
+func (c *Checker) ReadyzCheck(_ *http.Request) error {
+	report := c.Report()
+	if report == nil {
+		return fmt.Errorf("permission check has not completed yet")
+	}
+	if !report.OK {
+		missing := make([]string, 0, len(report.Missing))
+		for _, permission := range report.Missing {
+			missing = append(missing, permission.String())
+		}
+		return fmt.Errorf("missing permissions in namespace %s: %s (see /api/v1/diagnostics)",
+			report.Namespace, strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+// Check reviews every required verb and stores the report
+func (c *Checker) Check(ctx context.Context) *Report {
+	report := &Report{
+		CheckedAt: time.Now().UTC(),
+		Namespace: c.namespace,
+		OK:        true,
+	}
+
+	for _, requirement := range c.requirements {
+		resource, subresource, _ := strings.Cut(requirement.Resource, "/")
+		var denied []string
+		for _, verb := range requirement.Verbs {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace:   c.namespace,
+						Verb:        verb,
+						Group:       requirement.Group,
+						Resource:    resource,
+						Subresource: subresource,
+					},
+				},
+			}
+			result, err := c.reviews.Create(ctx, review, metav1.CreateOptions{})
+			if err != nil {
+				// The review API is available to every authenticated user: give up
+				report.Error = fmt.Sprintf("failed to review %s %s: %v", verb, requirement.Resource, err)
+				c.store(report)
+				return report
+			}
+			if !result.Status.Allowed {
+				denied = append(denied, verb)
+			}
+		}
+		if len(denied) > 0 {
+			report.OK = false
+			report.Missing = append(report.Missing, MissingPermission{
+				Group:    requirement.Group,
+				Resource: requirement.Resource,
+				Verbs:    denied,
+			})
+		}
+	}
+
+	report.Hints = c.namespaceHints(ctx)
+	c.store(report)
+	return report
+}
+
+// namespaceHints lists the probed kinds that have no object in the configured namespace
+// but some in others, the sign of an operator deployed with the wrong namespace. Listing
+// is best effort: errors, e.g. without cluster-wide list permission, give no hint.
+func (c *Checker) namespaceHints(ctx context.Context) []string {
+	var hints []string
+	for _, gvk := range c.probes {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.reader.List(ctx, list, client.InNamespace(c.namespace), client.Limit(1)); err != nil || len(list.Items) > 0 {
+			continue
+		}
+
+		list = &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.reader.List(ctx, list); err != nil || len(list.Items) == 0 {
+			continue
+		}
+		var namespaces []string
+		for _, item := range list.Items {
+			if !slices.Contains(namespaces, item.Namespace) {
+				namespaces = append(namespaces, item.Namespace)
+			}
+		}
+		sort.Strings(namespaces)
+		hints = append(hints, fmt.Sprintf("no %s in namespace %s, but %d in namespace(s) %s: check KRKN_NAMESPACE and POD_NAMESPACE",
+			gvk.Kind, c.namespace, len(list.Items), strings.Join(namespaces, ", ")))
+	}
+	return hints
+}
+
+func (c *Checker) store(report *Report) {
+	c.mu.Lock()
+	c.report = report
+	c.mu.Unlock()
+}
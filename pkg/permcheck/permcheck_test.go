@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permcheck
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// newClientset returns a clientset whose access reviews deny the given "verb resource" pairs
+func newClientset(denied ...string) *kubefake.Clientset {
+	clientset := kubefake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		resource := attributes.Resource
+		if attributes.Subresource != "" {
+			resource += "/" + attributes.Subresource
+		}
+		review.Status.Allowed = true
+		for _, pair := range denied {
+			if pair == attributes.Verb+" "+resource {
+				review.Status.Allowed = false
+			}
+		}
+		return true, review, nil
+	})
+	return clientset
+}
+
+func newFakeReader(objs ...client.Object) client.Reader {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestCheck_AllAllowed(t *testing.T) {
+	reader := newFakeReader(&krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-1", Namespace: "krkn-operator-system"},
+	})
+	checker := NewChecker(newClientset().AuthorizationV1().SelfSubjectAccessReviews(), reader,
+		"krkn-operator-system", DefaultRequirements(), DefaultProbes())
+
+	report := checker.Check(context.Background())
+	if !report.OK || len(report.Missing) != 0 || len(report.Hints) != 0 || report.Error != "" {
+		t.Errorf("Expected a clean report, got %+v", report)
+	}
+	if err := checker.ReadyzCheck(nil); err != nil {
+		t.Errorf("Expected readyz to pass, got %v", err)
+	}
+}
+
+func TestCheck_MissingVerbs(t *testing.T) {
+	checker := NewChecker(newClientset("list secrets", "delete secrets", "get pods/log").AuthorizationV1().SelfSubjectAccessReviews(),
+		newFakeReader(), "krkn-operator-system", DefaultRequirements(), nil)
+
+	if err := checker.ReadyzCheck(nil); err == nil {
+		t.Error("Expected readyz to fail before the check ran")
+	}
+
+	report := checker.Check(context.Background())
+	if report.OK {
+		t.Fatal("Expected the report to flag missing permissions")
+	}
+	var missing []string
+	for _, permission := range report.Missing {
+		missing = append(missing, permission.String())
+	}
+	if got := strings.Join(missing, "; "); got != "secrets: list,delete; pods/log: get" {
+		t.Errorf("Unexpected missing permissions %q", got)
+	}
+	err := checker.ReadyzCheck(nil)
+	if err == nil || !strings.Contains(err.Error(), "secrets: list,delete") {
+		t.Errorf("Expected readyz to name the missing verbs, got %v", err)
+	}
+}
+
+func TestCheck_HintsAtOtherNamespace(t *testing.T) {
+	reader := newFakeReader(
+		&krknv1alpha1.KrknOperatorTarget{ObjectMeta: metav1.ObjectMeta{Name: "target-1", Namespace: "krkn"}},
+		&krknv1alpha1.KrknOperatorTarget{ObjectMeta: metav1.ObjectMeta{Name: "target-2", Namespace: "krkn"}},
+	)
+	checker := NewChecker(newClientset().AuthorizationV1().SelfSubjectAccessReviews(), reader,
+		"krkn-operator-system", nil, DefaultProbes())
+
+	report := checker.Check(context.Background())
+	if len(report.Hints) != 1 || !strings.Contains(report.Hints[0], "no KrknOperatorTarget in namespace krkn-operator-system, but 2 in namespace(s) krkn") {
+		t.Errorf("Expected a hint about namespace krkn, got %v", report.Hints)
+	}
+	// Hints do not fail readiness: an empty namespace is legitimate
+	if err := checker.ReadyzCheck(nil); err != nil {
+		t.Errorf("Expected readyz to pass, got %v", err)
+	}
+}
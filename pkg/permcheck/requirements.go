@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permcheck
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// crudVerbs are the verbs the API and the controllers use on the resources they manage
+var crudVerbs = []string{"get", "list", "watch", "create", "update", "delete"}
+
+// DefaultRequirements returns the permissions the API and the controllers rely on in
+// the operator namespace
+func DefaultRequirements() []Requirement {
+	group := krknv1alpha1.GroupVersion.Group
+	return []Requirement{
+		{Group: group, Resource: "krknoperatortargets", Verbs: crudVerbs},
+		{Group: group, Resource: "krknscenarioruns", Verbs: crudVerbs},
+		{Group: group, Resource: "krkntargetrequests", Verbs: crudVerbs},
+		{Group: group, Resource: "krknoperatortargetproviderconfigs", Verbs: crudVerbs},
+		{Group: group, Resource: "krknusers", Verbs: crudVerbs},
+		{Group: "", Resource: "secrets", Verbs: crudVerbs},
+		{Group: "", Resource: "configmaps", Verbs: crudVerbs},
+		{Group: "", Resource: "pods/log", Verbs: []string{"get"}},
+		{Group: "batch", Resource: "jobs", Verbs: crudVerbs},
+	}
+}
+
+// DefaultProbes returns the kinds whose absence from the operator namespace is worth a hint
+func DefaultProbes() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		krknv1alpha1.GroupVersion.WithKind("KrknOperatorTarget"),
+		krknv1alpha1.GroupVersion.WithKind("KrknScenarioRun"),
+		krknv1alpha1.GroupVersion.WithKind("KrknUser"),
+	}
+}
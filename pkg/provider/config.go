@@ -28,7 +28,6 @@ import (
 	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
@@ -136,36 +135,68 @@ func UpdateProviderConfig(
 		}
 	}
 
-	// Use retry loop to handle optimistic concurrency conflicts
-	// This is the recommended approach when multiple controllers might update the same resource
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		// Re-fetch the latest version of the config to avoid conflicts
-		latestConfig := &krknv1alpha1.KrknOperatorTargetProviderConfig{}
-		if err := c.Get(ctx, types.NamespacedName{
-			Name:      config.Name,
-			Namespace: config.Namespace,
-		}, latestConfig); err != nil {
-			return err
-		}
-
-		// Initialize ConfigData map if nil
-		if latestConfig.Status.ConfigData == nil {
-			latestConfig.Status.ConfigData = make(map[string]krknv1alpha1.ProviderConfigData)
-		}
+	return ContributeProviderConfig(ctx, c, config, operatorName, krknv1alpha1.ProviderConfigData{
+		ConfigMap:    configMapName,
+		Namespace:    namespace,
+		ConfigSchema: jsonSchema,
+	})
+}
 
-		// Update provider data
-		latestConfig.Status.ConfigData[operatorName] = krknv1alpha1.ProviderConfigData{
-			ConfigMap:    configMapName,
-			Namespace:    namespace,
-			ConfigSchema: jsonSchema,
-		}
+// ContributeProviderConfig records data as the contribution of operatorName to config.
+//
+// Several providers contribute to the same request concurrently, so rather than updating
+// the whole status (where a conflict retry re-applies a map read before another provider's
+// write) it sends a JSON merge patch holding only status.configData[operatorName]. The API
+// server merges it into the latest status: entries of other providers are never touched and
+// no resourceVersion precondition, hence no conflict retry, is needed. Contributing again
+// replaces the provider's own entry.
+//
+// Parameters:
+//   - ctx: Context
+//   - c: Kubernetes client
+//   - config: The KrknOperatorTargetProviderConfig CR, only its name and namespace are used
+//   - operatorName: Name of the provider contributing the data
+//   - data: The provider's contribution
+//
+// Returns:
+//   - error: Error if the patch fails
+func ContributeProviderConfig(
+	ctx context.Context,
+	c client.Client,
+	config *krknv1alpha1.KrknOperatorTargetProviderConfig,
+	operatorName string,
+	data krknv1alpha1.ProviderConfigData,
+) error {
+	if operatorName == "" {
+		return fmt.Errorf("operatorName cannot be empty")
+	}
 
-		// Update status
-		return c.Status().Update(ctx, latestConfig)
+	// A null config-schema clears the schema of a previous contribution
+	entry := map[string]any{
+		"config-map":    data.ConfigMap,
+		"namespace":     data.Namespace,
+		"config-schema": nil,
+	}
+	if data.ConfigSchema != "" {
+		entry["config-schema"] = data.ConfigSchema
+	}
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"configData": map[string]any{operatorName: entry},
+		},
 	})
-
 	if err != nil {
-		return fmt.Errorf("failed to update KrknOperatorTargetProviderConfig status after retries: %w", err)
+		return fmt.Errorf("failed to build provider config patch: %w", err)
+	}
+
+	target := &krknv1alpha1.KrknOperatorTargetProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.Name,
+			Namespace: config.Namespace,
+		},
+	}
+	if err := c.Status().Patch(ctx, target, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to patch KrknOperatorTargetProviderConfig status: %w", err)
 	}
 
 	return nil
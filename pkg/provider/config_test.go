@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+const testConfigName = "test-config"
+
+func setupConfigTestClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&krknv1alpha1.KrknOperatorTargetProviderConfig{}).
+		Build()
+}
+
+func newTestProviderConfig(configData map[string]krknv1alpha1.ProviderConfigData) *krknv1alpha1.KrknOperatorTargetProviderConfig {
+	return &krknv1alpha1.KrknOperatorTargetProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testConfigName,
+			Namespace: testNamespace,
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetProviderConfigSpec{
+			UUID: "test-config-uuid",
+		},
+		Status: krknv1alpha1.KrknOperatorTargetProviderConfigStatus{
+			Status:     "pending",
+			ConfigData: configData,
+		},
+	}
+}
+
+func getTestProviderConfig(t *testing.T, c client.Client) *krknv1alpha1.KrknOperatorTargetProviderConfig {
+	t.Helper()
+	var config krknv1alpha1.KrknOperatorTargetProviderConfig
+	if err := c.Get(context.Background(), types.NamespacedName{
+		Name:      testConfigName,
+		Namespace: testNamespace,
+	}, &config); err != nil {
+		t.Fatalf("Failed to get config: %v", err)
+	}
+	return &config
+}
+
+func TestUpdateProviderConfig_ConcurrentContributions(t *testing.T) {
+	// Every provider works from the same copy of the CR fetched before anyone contributed,
+	// as provider controllers reconciling the same request do
+	stale := newTestProviderConfig(nil)
+	fakeClient := setupConfigTestClient(stale.DeepCopy())
+	ctx := context.Background()
+
+	const providers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, providers)
+	for i := 0; i < providers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- UpdateProviderConfig(ctx, fakeClient, stale,
+				fmt.Sprintf("operator-%d", i), fmt.Sprintf("config-%d", i), testNamespace, `{"type": "object"}`)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateProviderConfig failed: %v", err)
+		}
+	}
+
+	config := getTestProviderConfig(t, fakeClient)
+	if len(config.Status.ConfigData) != providers {
+		t.Fatalf("Expected %d contributions, got %d", providers, len(config.Status.ConfigData))
+	}
+	for i := 0; i < providers; i++ {
+		data, exists := config.Status.ConfigData[fmt.Sprintf("operator-%d", i)]
+		if !exists || data.ConfigMap != fmt.Sprintf("config-%d", i) {
+			t.Errorf("Expected contribution of operator-%d, got %+v", i, data)
+		}
+	}
+	if config.Status.Status != "pending" {
+		t.Errorf("Expected the rest of the status to be kept, got status %q", config.Status.Status)
+	}
+}
+
+func TestUpdateProviderConfig_KeepsOtherProviders(t *testing.T) {
+	fakeClient := setupConfigTestClient(newTestProviderConfig(map[string]krknv1alpha1.ProviderConfigData{
+		"operator-a": {ConfigMap: "config-a", Namespace: testNamespace, ConfigSchema: `{"a": 1}`},
+	}))
+	ctx := context.Background()
+
+	// A copy without operator-a's entry must not remove it
+	stale := newTestProviderConfig(nil)
+	if err := UpdateProviderConfig(ctx, fakeClient, stale, "operator-b", "config-b", testNamespace, `{"b": 1}`); err != nil {
+		t.Fatalf("UpdateProviderConfig failed: %v", err)
+	}
+
+	config := getTestProviderConfig(t, fakeClient)
+	if config.Status.ConfigData["operator-a"].ConfigSchema != `{"a": 1}` {
+		t.Errorf("Expected operator-a's contribution to be kept, got %+v", config.Status.ConfigData)
+	}
+	if config.Status.ConfigData["operator-b"].ConfigMap != "config-b" {
+		t.Errorf("Expected operator-b's contribution, got %+v", config.Status.ConfigData)
+	}
+}
+
+func TestContributeProviderConfig_ReplacesOwnEntry(t *testing.T) {
+	fakeClient := setupConfigTestClient(newTestProviderConfig(map[string]krknv1alpha1.ProviderConfigData{
+		"operator-a": {ConfigMap: "config-a", Namespace: testNamespace, ConfigSchema: `{"a": 1}`},
+	}))
+	ctx := context.Background()
+
+	if err := ContributeProviderConfig(ctx, fakeClient, newTestProviderConfig(nil), "operator-a",
+		krknv1alpha1.ProviderConfigData{Namespace: "other-namespace"}); err != nil {
+		t.Fatalf("ContributeProviderConfig failed: %v", err)
+	}
+
+	data := getTestProviderConfig(t, fakeClient).Status.ConfigData["operator-a"]
+	if data.ConfigMap != "" || data.Namespace != "other-namespace" || data.ConfigSchema != "" {
+		t.Errorf("Expected the contribution to be replaced, got %+v", data)
+	}
+}
+
+func TestUpdateProviderConfig_Validation(t *testing.T) {
+	fakeClient := setupConfigTestClient(newTestProviderConfig(nil))
+	config := newTestProviderConfig(nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		operatorName  string
+		configMapName string
+		jsonSchema    string
+	}{
+		{name: "empty operator name", configMapName: "config", jsonSchema: "{}"},
+		{name: "empty configmap name", operatorName: "operator", jsonSchema: "{}"},
+		{name: "invalid schema", operatorName: "operator", configMapName: "config", jsonSchema: "{not json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdateProviderConfig(ctx, fakeClient, config, tt.operatorName, tt.configMapName, testNamespace, tt.jsonSchema); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if len(getTestProviderConfig(t, fakeClient).Status.ConfigData) != 0 {
+		t.Error("Expected no contribution to be recorded")
+	}
+}
@@ -32,6 +32,9 @@ import (
 	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
 )
 
+// DefaultHeartbeatInterval is the interval at which providers update their heartbeat by default
+const DefaultHeartbeatInterval = 30 * time.Second
+
 // ProviderRegistration manages the KrknOperatorTargetProvider CR registration and heartbeat
 type ProviderRegistration struct {
 	client            client.Client
@@ -59,7 +62,7 @@ type Config struct {
 func NewProviderRegistration(c client.Client, namespace string) *ProviderRegistration {
 	return NewProviderRegistrationWithConfig(c, Config{
 		ProviderName:      "krkn-operator",
-		HeartbeatInterval: 30 * time.Second,
+		HeartbeatInterval: DefaultHeartbeatInterval,
 		Namespace:         namespace,
 	})
 }
@@ -68,7 +71,7 @@ func NewProviderRegistration(c client.Client, namespace string) *ProviderRegistr
 func NewProviderRegistrationWithConfig(c client.Client, cfg Config) *ProviderRegistration {
 	// Set defaults
 	if cfg.HeartbeatInterval == 0 {
-		cfg.HeartbeatInterval = 30 * time.Second
+		cfg.HeartbeatInterval = DefaultHeartbeatInterval
 	}
 	if cfg.ProviderName == "" {
 		cfg.ProviderName = "krkn-operator"
@@ -142,7 +145,13 @@ func (p *ProviderRegistration) ensureProvider(ctx context.Context) error {
 	result, err := controllerutil.CreateOrUpdate(ctx, p.client, provider, func() error {
 		// Set spec fields
 		provider.Spec.OperatorName = p.providerName
-		provider.Spec.Active = true
+		if deactivatedByAdmin(provider) {
+			logger.Info("Provider deactivated by an admin, keeping it inactive",
+				"name", p.providerName,
+				"changedBy", provider.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation])
+		} else {
+			setActive(provider, true)
+		}
 
 		// Initialize status
 		if provider.Status.Timestamp.IsZero() {
@@ -184,10 +193,30 @@ func (p *ProviderRegistration) deactivateProvider(ctx context.Context) error {
 		return client.IgnoreNotFound(err)
 	}
 
-	provider.Spec.Active = false
+	if !provider.Spec.Active {
+		return nil
+	}
+	setActive(&provider, false)
 	return p.client.Update(ctx, &provider)
 }
 
+// deactivatedByAdmin returns true when an admin deactivated the provider, which
+// it must not override when it starts
+func deactivatedByAdmin(provider *krknv1alpha1.KrknOperatorTargetProvider) bool {
+	return provider.ResourceVersion != "" && !provider.Spec.Active &&
+		provider.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation] != ""
+}
+
+// setActive changes the activation of the provider on its own behalf, clearing the
+// reason recorded by the last admin change
+func setActive(provider *krknv1alpha1.KrknOperatorTargetProvider, active bool) {
+	if provider.Spec.Active != active {
+		delete(provider.Annotations, krknv1alpha1.ProviderActivationReasonAnnotation)
+		delete(provider.Annotations, krknv1alpha1.ProviderActivationChangedByAnnotation)
+	}
+	provider.Spec.Active = active
+}
+
 // NeedLeaderElection implements manager.LeaderElectionRunnable
 // Provider registration should only run on the leader
 func (p *ProviderRegistration) NeedLeaderElection() bool {
@@ -119,6 +119,63 @@ func TestEnsureProvider_UpdatesExistingProvider(t *testing.T) {
 	}
 }
 
+func TestEnsureProvider_KeepsAdminDeactivation(t *testing.T) {
+	existingProvider := &krknv1alpha1.KrknOperatorTargetProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testProviderName,
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				krknv1alpha1.ProviderActivationReasonAnnotation:    "maintenance",
+				krknv1alpha1.ProviderActivationChangedByAnnotation: "admin@example.com",
+			},
+		},
+		Spec: krknv1alpha1.KrknOperatorTargetProviderSpec{
+			OperatorName: testProviderName,
+			Active:       false,
+		},
+	}
+
+	fakeClient := setupTestClient(existingProvider)
+	providerReg := NewProviderRegistrationWithConfig(fakeClient, Config{
+		ProviderName: testProviderName,
+		Namespace:    testNamespace,
+	})
+	ctx := context.Background()
+	key := types.NamespacedName{Name: testProviderName, Namespace: testNamespace}
+
+	if err := providerReg.ensureProvider(ctx); err != nil {
+		t.Fatalf("ensureProvider failed: %v", err)
+	}
+	var provider krknv1alpha1.KrknOperatorTargetProvider
+	if err := fakeClient.Get(ctx, key, &provider); err != nil {
+		t.Fatalf("Failed to get provider: %v", err)
+	}
+	if provider.Spec.Active {
+		t.Error("Expected the provider deactivated by an admin to stay inactive")
+	}
+
+	// Reactivated by an admin, the provider owns its activation again
+	provider.Spec.Active = true
+	if err := fakeClient.Update(ctx, &provider); err != nil {
+		t.Fatalf("Failed to update provider: %v", err)
+	}
+	if err := providerReg.deactivateProvider(ctx); err != nil {
+		t.Fatalf("deactivateProvider failed: %v", err)
+	}
+	if err := providerReg.ensureProvider(ctx); err != nil {
+		t.Fatalf("ensureProvider failed: %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, &provider); err != nil {
+		t.Fatalf("Failed to get provider: %v", err)
+	}
+	if !provider.Spec.Active {
+		t.Error("Expected the provider to be reactivated on start")
+	}
+	if _, exists := provider.Annotations[krknv1alpha1.ProviderActivationChangedByAnnotation]; exists {
+		t.Error("Expected the admin change to be cleared")
+	}
+}
+
 func TestUpdateHeartbeat_UpdatesTimestamp(t *testing.T) {
 	// Use a timestamp from 1 minute ago to ensure difference
 	oldTime := metav1.NewTime(metav1.Now().Add(-1 * 60 * 1000000000)) // 1 minute ago
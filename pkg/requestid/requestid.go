@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requestid assigns every HTTP request an ID used to correlate
+// responses (error bodies, X-Request-ID header) with operator logs.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Header carries the request ID on requests and responses
+const Header = "X-Request-ID"
+
+// maxLength bounds client-provided IDs so they cannot flood the logs
+const maxLength = 128
+
+type contextKey struct{}
+
+// FromContext returns the request ID stored in ctx, or "" if there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromResponse returns the request ID already set on the response headers.
+// Error writers use it when they only have access to the ResponseWriter.
+func FromResponse(w http.ResponseWriter) string {
+	return w.Header().Get(Header)
+}
+
+// Middleware propagates the caller's X-Request-ID, or generates one, and:
+//   - echoes it in the X-Request-ID response header
+//   - stores it in the request context (FromContext)
+//   - stores base, enriched with a requestId value, as the request logger (log.FromContext)
+func Middleware(base logr.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(Header)
+			if !valid(id) {
+				id = uuid.NewString()
+			}
+			w.Header().Set(Header, id)
+
+			ctx := NewContext(r.Context(), id)
+			ctx = log.IntoContext(ctx, base.WithValues("requestId", id))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// valid accepts non-empty IDs of printable ASCII without spaces
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "propagates caller ID", incoming: "abc-123", keep: true},
+		{name: "generates when missing", incoming: ""},
+		{name: "replaces IDs with whitespace", incoming: "abc 123"},
+		{name: "replaces oversized IDs", incoming: strings.Repeat("a", maxLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := Middleware(logr.Discard())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(Header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if seen == "" {
+				t.Fatalf("expected a request ID in the context")
+			}
+			if got := w.Header().Get(Header); got != seen {
+				t.Errorf("expected response header %q, got %q", seen, got)
+			}
+			if tt.keep && seen != tt.incoming {
+				t.Errorf("expected caller ID %q to be kept, got %q", tt.incoming, seen)
+			}
+			if !tt.keep && seen == tt.incoming {
+				t.Errorf("expected caller ID %q to be replaced", tt.incoming)
+			}
+		})
+	}
+}
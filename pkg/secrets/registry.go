@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// RegistryCredentials authenticate to a scenario registry with a token, or with a
+// username and password. All empty for anonymous access.
+type RegistryCredentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// Registry returns the KrknRegistry name of namespace and the credentials of its
+// credentials Secret. A missing registry or Secret is reported with an error wrapping
+// ErrNotFound.
+func Registry(ctx context.Context, reader client.Reader, namespace, name string) (*krknv1alpha1.KrknRegistry, RegistryCredentials, error) {
+	var registry krknv1alpha1.KrknRegistry
+	if err := reader.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &registry); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, RegistryCredentials{}, fmt.Errorf("registry %s %w", name, ErrNotFound)
+		}
+		return nil, RegistryCredentials{}, fmt.Errorf("failed to get registry %s: %w", name, err)
+	}
+	if registry.Spec.CredentialsSecret == "" {
+		return &registry, RegistryCredentials{}, nil
+	}
+
+	var secret corev1.Secret
+	key := types.NamespacedName{Name: registry.Spec.CredentialsSecret, Namespace: namespace}
+	if err := reader.Get(ctx, key, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, RegistryCredentials{}, fmt.Errorf("secret %s of registry %s %w", key.Name, name, ErrNotFound)
+		}
+		return nil, RegistryCredentials{}, fmt.Errorf("failed to get secret %s of registry %s: %w", key.Name, name, err)
+	}
+	return &registry, RegistryCredentials{
+		Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+		Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+		Token:    string(secret.Data[krknv1alpha1.RegistryTokenKey]),
+	}, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets reads the credentials of targets wherever they are stored: the
+// Kubernetes Secret named by spec.secretUUID, or an external Store such as the KV v2
+// engine of HashiCorp Vault, in which case the target only records spec.vaultPath.
+// Both hold the same keys, e.g. kubeconfig and prometheus-token.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// ErrNotFound is returned when the credentials of a target do not exist
+var ErrNotFound = errors.New("not found")
+
+// Store keeps credentials outside the cluster, keyed by path
+type Store interface {
+	// Get returns the credentials stored at path, ErrNotFound when there are none
+	Get(ctx context.Context, path string) (map[string][]byte, error)
+	// Put replaces the credentials stored at path
+	Put(ctx context.Context, path string, data map[string][]byte) error
+	// Delete removes the credentials stored at path, and all their versions
+	Delete(ctx context.Context, path string) error
+}
+
+// TargetPath returns the Store path of the credentials of the target targetUUID
+func TargetPath(targetUUID string) string {
+	return "targets/" + targetUUID
+}
+
+// TargetCredentials returns the credentials of target: from store when the target has a
+// Vault path, from its Secret otherwise. Missing credentials are reported with an error
+// wrapping ErrNotFound.
+func TargetCredentials(ctx context.Context, reader client.Reader, store Store, target *krknv1alpha1.KrknOperatorTarget) (map[string][]byte, error) {
+	if path := target.Spec.VaultPath; path != "" {
+		if store == nil {
+			return nil, fmt.Errorf("credentials of target %s are stored at %s but no credentials store is configured",
+				target.Spec.UUID, path)
+		}
+		data, err := store.Get(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("credentials %s: %w", path, err)
+		}
+		return data, nil
+	}
+
+	var secret corev1.Secret
+	if err := reader.Get(ctx, types.NamespacedName{Name: target.Spec.SecretUUID, Namespace: target.Namespace}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("secret %s %w", target.Spec.SecretUUID, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get secret %s: %w", target.Spec.SecretUUID, err)
+	}
+	return secret.Data, nil
+}
+
+// PutTargetCredentials replaces the credentials of target where TargetCredentials reads
+// them: in store when the target has a Vault path, in its Secret otherwise
+func PutTargetCredentials(ctx context.Context, c client.Client, store Store, target *krknv1alpha1.KrknOperatorTarget, data map[string][]byte) error {
+	if path := target.Spec.VaultPath; path != "" {
+		if store == nil {
+			return fmt.Errorf("credentials of target %s are stored at %s but no credentials store is configured",
+				target.Spec.UUID, path)
+		}
+		if err := store.Put(ctx, path, data); err != nil {
+			return fmt.Errorf("failed to store credentials %s: %w", path, err)
+		}
+		return nil
+	}
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Name: target.Spec.SecretUUID, Namespace: target.Namespace}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("secret %s %w", target.Spec.SecretUUID, ErrNotFound)
+		}
+		return fmt.Errorf("failed to get secret %s: %w", target.Spec.SecretUUID, err)
+	}
+	secret.Data = data
+	if err := c.Update(ctx, &secret); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", target.Spec.SecretUUID, err)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// fakeVault serves the data and metadata APIs of a KV v2 engine mounted at /v1/secret
+func fakeVault(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	stored := map[string]map[string]string{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		api, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/secret/"), "/")
+		switch {
+		case api == "data" && r.Method == http.MethodPost:
+			var request struct {
+				Data map[string]string `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			stored[path] = request.Data
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]int{"version": 1}})
+		case api == "data" && r.Method == http.MethodGet:
+			data, ok := stored[path]
+			if !ok {
+				http.Error(w, `{"errors":[]}`, http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+		case api == "metadata" && r.Method == http.MethodDelete:
+			delete(stored, path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultKVStore(t *testing.T) {
+	server := fakeVault(t)
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "krkn"},
+		Data: map[string][]byte{
+			VaultURLKey:   []byte(server.URL + "/v1/secret/"),
+			VaultTokenKey: []byte("s.token\n"),
+			VaultCAKey:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	ctx := context.Background()
+
+	store, err := LoadVaultKVStore(ctx, reader, "krkn", "vault")
+	if err != nil {
+		t.Fatalf("LoadVaultKVStore failed: %v", err)
+	}
+	path := TargetPath("6c1d2f0e")
+	if _, err := store.Get(ctx, path); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound before the credentials are written, got %v", err)
+	}
+	if err := store.Put(ctx, path, map[string][]byte{"kubeconfig": []byte(`"YXBpVmVyc2lvbjogdjE="`)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	data, err := store.Get(ctx, path)
+	if err != nil || string(data["kubeconfig"]) != `"YXBpVmVyc2lvbjogdjE="` {
+		t.Errorf("Get = %v, %v, expected the written credentials", data, err)
+	}
+	if err := store.Delete(ctx, path); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get(ctx, path); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound once deleted, got %v", err)
+	}
+	// Deleting missing credentials is not an error
+	if err := store.Delete(ctx, path); err != nil {
+		t.Errorf("Expected no error deleting missing credentials, got %v", err)
+	}
+
+	if _, err := NewVaultKVStore("http://vault.example.com/v1/secret", "", "token", nil); err == nil {
+		t.Error("Expected an error for an http Vault URL")
+	}
+	if _, err := LoadVaultKVStore(ctx, reader, "krkn", "missing"); err == nil {
+		t.Error("Expected an error for a missing Secret")
+	}
+}
+
+func TestVaultKVStore_Paths(t *testing.T) {
+	var requested string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.EscapedPath()
+		http.Error(w, `{"errors":[]}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store, err := NewVaultKVStore(server.URL+"/v1/kv", "/teams/chaos/", "token", server.Client())
+	if err != nil {
+		t.Fatalf("NewVaultKVStore failed: %v", err)
+	}
+	_, _ = store.Get(context.Background(), "targets/a b")
+	if requested != "/v1/kv/data/teams/chaos/targets/a%20b" {
+		t.Errorf("Expected the path under the prefix, got %s", requested)
+	}
+}
+
+func TestTargetCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret-uuid", Namespace: "krkn"},
+		Data:       map[string][]byte{"kubeconfig": []byte("from-secret")},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	ctx := context.Background()
+
+	target := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-uuid", Namespace: "krkn"},
+		Spec:       krknv1alpha1.KrknOperatorTargetSpec{UUID: "target-uuid", SecretUUID: "secret-uuid"},
+	}
+	data, err := TargetCredentials(ctx, reader, nil, target)
+	if err != nil || string(data["kubeconfig"]) != "from-secret" {
+		t.Errorf("TargetCredentials = %v, %v, expected the Secret data", data, err)
+	}
+
+	target.Spec.SecretUUID = "missing"
+	if _, err := TargetCredentials(ctx, reader, nil, target); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing Secret, got %v", err)
+	}
+
+	// Targets with a Vault path need the credentials store
+	target.Spec.SecretUUID = ""
+	target.Spec.VaultPath = TargetPath("target-uuid")
+	if _, err := TargetCredentials(ctx, reader, nil, target); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a configuration error without credentials store, got %v", err)
+	}
+}
+
+func TestPutTargetCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret-uuid", Namespace: "krkn"},
+		Data:       map[string][]byte{"kubeconfig": []byte("old")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	ctx := context.Background()
+
+	target := &krknv1alpha1.KrknOperatorTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-uuid", Namespace: "krkn"},
+		Spec:       krknv1alpha1.KrknOperatorTargetSpec{UUID: "target-uuid", SecretUUID: "secret-uuid"},
+	}
+	if err := PutTargetCredentials(ctx, c, nil, target, map[string][]byte{"kubeconfig": []byte("new")}); err != nil {
+		t.Fatalf("PutTargetCredentials failed: %v", err)
+	}
+	if data, err := TargetCredentials(ctx, c, nil, target); err != nil || string(data["kubeconfig"]) != "new" {
+		t.Errorf("TargetCredentials = %v, %v, expected the new Secret data", data, err)
+	}
+
+	target.Spec.SecretUUID = "missing"
+	if err := PutTargetCredentials(ctx, c, nil, target, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing Secret, got %v", err)
+	}
+}
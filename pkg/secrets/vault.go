@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// vaultRequestTimeout bounds every call to Vault
+const vaultRequestTimeout = 10 * time.Second
+
+// DefaultVaultPathPrefix is where the operator keeps its credentials in the KV engine
+const DefaultVaultPathPrefix = "krkn-operator"
+
+// Keys of the Vault configuration Secret
+const (
+	// VaultURLKey holds the https URL of the KV v2 engine mount, e.g. https://vault:8200/v1/secret
+	VaultURLKey = "vault-url"
+	// VaultTokenKey holds the token authenticating to Vault
+	VaultTokenKey = "vault-token"
+	// VaultPathPrefixKey optionally holds the path under the mount the credentials are kept at
+	VaultPathPrefixKey = "vault-path-prefix"
+	// VaultCAKey optionally holds the PEM CA bundle of Vault
+	VaultCAKey = "ca.crt"
+)
+
+// VaultKVStore is a Store backed by the KV v2 secrets engine of HashiCorp Vault (or
+// OpenBao). Every path is kept under a prefix of the engine mount.
+type VaultKVStore struct {
+	mountURL string
+	prefix   string
+	token    string
+	client   *http.Client
+}
+
+var _ Store = &VaultKVStore{}
+
+// NewVaultKVStore creates a VaultKVStore for the KV v2 engine mounted at mountURL,
+// keeping the credentials under prefix and authenticating with token. client defaults to
+// one with a request timeout.
+func NewVaultKVStore(mountURL, prefix, token string, client *http.Client) (*VaultKVStore, error) {
+	mountURL = strings.TrimRight(strings.TrimSpace(mountURL), "/")
+	if parsed, err := url.Parse(mountURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("vault URL %q is not an https URL", mountURL)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("vault token is required")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: vaultRequestTimeout}
+	}
+	return &VaultKVStore{
+		mountURL: mountURL,
+		prefix:   strings.Trim(prefix, "/"),
+		token:    token,
+		client:   client,
+	}, nil
+}
+
+// LoadVaultKVStore creates the VaultKVStore configured by the Secret secretName of
+// namespace, from its VaultURLKey, VaultTokenKey and optional VaultPathPrefixKey and
+// VaultCAKey
+func LoadVaultKVStore(ctx context.Context, reader client.Reader, namespace, secretName string) (*VaultKVStore, error) {
+	var secret corev1.Secret
+	if err := reader.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get Vault Secret %s: %w", secretName, err)
+	}
+
+	httpClient := &http.Client{Timeout: vaultRequestTimeout}
+	if ca := secret.Data[VaultCAKey]; len(ca) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("vault Secret %s has no valid certificate in %s", secretName, VaultCAKey)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		httpClient.Transport = transport
+	}
+	prefix := DefaultVaultPathPrefix
+	if value, ok := secret.Data[VaultPathPrefixKey]; ok {
+		prefix = strings.TrimSpace(string(value))
+	}
+	store, err := NewVaultKVStore(string(secret.Data[VaultURLKey]), prefix,
+		strings.TrimSpace(string(secret.Data[VaultTokenKey])), httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("vault Secret %s: %w", secretName, err)
+	}
+	return store, nil
+}
+
+// Get implements Store
+func (s *VaultKVStore) Get(ctx context.Context, path string) (map[string][]byte, error) {
+	var response struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := s.call(ctx, http.MethodGet, "data", path, nil, &response); err != nil {
+		return nil, err
+	}
+	// Deleted versions are returned without data
+	if response.Data.Data == nil {
+		return nil, ErrNotFound
+	}
+	data := make(map[string][]byte, len(response.Data.Data))
+	for key, value := range response.Data.Data {
+		data[key] = []byte(value)
+	}
+	return data, nil
+}
+
+// Put implements Store, writing a new version of the credentials
+func (s *VaultKVStore) Put(ctx context.Context, path string, data map[string][]byte) error {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		values[key] = string(value)
+	}
+	return s.call(ctx, http.MethodPost, "data", path, map[string]any{"data": values}, nil)
+}
+
+// Delete implements Store, removing the metadata and every version of the credentials
+func (s *VaultKVStore) Delete(ctx context.Context, path string) error {
+	err := s.call(ctx, http.MethodDelete, "metadata", path, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// call sends a request to the API of the KV engine for path and decodes its JSON
+// response, if any. Vault answers 404 for paths without credentials.
+func (s *VaultKVStore) call(ctx context.Context, method, api, path string, request, response any) error {
+	var body io.Reader
+	if request != nil {
+		payload, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+	endpoint := s.mountURL + "/" + api + "/" + s.escapedPath(path)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault %s failed: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusNoContent:
+		return nil
+	case resp.StatusCode != http.StatusOK:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("vault %s returned %s: %s", method, resp.Status, strings.TrimSpace(string(message)))
+	}
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// escapedPath returns path under the prefix of the store, every segment escaped
+func (s *VaultKVStore) escapedPath(path string) string {
+	var segments []string
+	for _, segment := range strings.Split(s.prefix+"/"+strings.Trim(path, "/"), "/") {
+		if segment != "" {
+			segments = append(segments, url.PathEscape(segment))
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usage aggregates anonymous feature usage of the operator: API calls per
+// endpoint, scenario runs per phase and scenario type, target counts and the optional
+// features enabled. It is opt-in; in local mode the aggregate is only served by the
+// API, in report mode it is also sent to a maintainer-configured URL.
+//
+// Reports hold counts only: no user, cluster, target or run names, no URLs, and
+// scenarios not published by krkn-chaos are counted as custom.
+package usage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+// Telemetry modes
+const (
+	// ModeOff collects nothing (default)
+	ModeOff = "off"
+	// ModeLocal aggregates usage and serves it through GET /api/v1/usage only
+	ModeLocal = "local"
+	// ModeReport also POSTs the aggregate to the report URL every report interval
+	ModeReport = "report"
+)
+
+const (
+	// DefaultReportInterval is how often usage is reported by default
+	DefaultReportInterval = 24 * time.Hour
+
+	// CustomScenario counts the runs of scenarios not published by krkn-chaos
+	CustomScenario = "custom"
+
+	// publicScenarioImagePrefix is the repository of the scenarios counted by name
+	publicScenarioImagePrefix = "quay.io/krkn-chaos/"
+	// discoveredByLabel is set on the targets created by the built-in discoveries
+	discoveredByLabel = "krkn.krkn-chaos.dev/discovered-by"
+)
+
+// ValidateMode checks a telemetry mode and its report URL
+func ValidateMode(mode, reportURL string) error {
+	switch mode {
+	case ModeOff, ModeLocal:
+		return nil
+	case ModeReport:
+		parsed, err := url.Parse(reportURL)
+		if err != nil || parsed.Host == "" || parsed.Scheme != "https" {
+			return fmt.Errorf("usage reports need an absolute https report URL")
+		}
+		return nil
+	default:
+		return fmt.Errorf("usage telemetry mode must be one of: %s, %s, %s", ModeOff, ModeLocal, ModeReport)
+	}
+}
+
+// Report is the anonymous usage aggregate of an operator installation
+type Report struct {
+	// GeneratedAt is when the report was built
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Since is when the endpoint counts started, at operator start
+	Since time.Time `json:"since"`
+	// Endpoints counts the API calls per "<method> <route pattern>" since Since
+	Endpoints map[string]int64 `json:"endpoints"`
+	// Runs counts the scenario runs of the operator namespace
+	Runs RunUsage `json:"runs"`
+	// Targets counts the KrknOperatorTargets of the operator namespace
+	Targets TargetUsage `json:"targets"`
+	// Features lists the optional features and whether they are enabled
+	Features map[string]bool `json:"features"`
+}
+
+// RunUsage counts scenario runs
+type RunUsage struct {
+	Total int `json:"total"`
+	// ByPhase counts the runs per phase
+	ByPhase map[string]int `json:"byPhase"`
+	// ByScenario counts the runs per krkn-chaos scenario name, others as CustomScenario
+	ByScenario map[string]int `json:"byScenario"`
+	// ClusterJobs is the number of cluster jobs of all the runs
+	ClusterJobs int `json:"clusterJobs"`
+}
+
+// TargetUsage counts targets
+type TargetUsage struct {
+	Total int `json:"total"`
+	Ready int `json:"ready"`
+	// Discovered counts the targets created by a built-in discovery
+	Discovered int `json:"discovered"`
+}
+
+// Tracker counts the API calls per endpoint and builds the usage reports
+type Tracker struct {
+	reader    client.Reader
+	namespace string
+	features  map[string]bool
+	since     time.Time
+
+	mu        sync.Mutex
+	endpoints map[string]int64
+}
+
+// NewTracker creates a Tracker reading the runs and targets of namespace, reporting
+// features as the enabled optional features
+func NewTracker(reader client.Reader, namespace string, features map[string]bool) *Tracker {
+	return &Tracker{
+		reader:    reader,
+		namespace: namespace,
+		features:  features,
+		since:     time.Now().UTC(),
+		endpoints: map[string]int64{},
+	}
+}
+
+// RecordEndpoint counts a call of the route pattern with method. Nil trackers record nothing.
+func (t *Tracker) RecordEndpoint(method, route string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endpoints[method+" "+route]++
+}
+
+// Report builds the current usage report
+func (t *Tracker) Report(ctx context.Context) (*Report, error) {
+	report := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Since:       t.since,
+		Endpoints:   map[string]int64{},
+		Runs:        RunUsage{ByPhase: map[string]int{}, ByScenario: map[string]int{}},
+		Features:    map[string]bool{},
+	}
+	t.mu.Lock()
+	for endpoint, count := range t.endpoints {
+		report.Endpoints[endpoint] = count
+	}
+	t.mu.Unlock()
+	for feature, enabled := range t.features {
+		report.Features[feature] = enabled
+	}
+
+	var runs krknv1alpha1.KrknScenarioRunList
+	if err := t.reader.List(ctx, &runs, client.InNamespace(t.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list scenario runs: %w", err)
+	}
+	for _, run := range runs.Items {
+		report.Runs.Total++
+		phase := run.Status.Phase
+		if phase == "" {
+			phase = "Pending"
+		}
+		report.Runs.ByPhase[phase]++
+		report.Runs.ByScenario[scenarioType(&run)]++
+		report.Runs.ClusterJobs += len(run.Status.ClusterJobs)
+	}
+
+	var targets krknv1alpha1.KrknOperatorTargetList
+	if err := t.reader.List(ctx, &targets, client.InNamespace(t.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list targets: %w", err)
+	}
+	for _, target := range targets.Items {
+		if target.Status.Archived {
+			continue
+		}
+		report.Targets.Total++
+		if target.Status.Ready {
+			report.Targets.Ready++
+		}
+		if target.Labels[discoveredByLabel] != "" {
+			report.Targets.Discovered++
+		}
+	}
+	return report, nil
+}
+
+// scenarioType is the scenario name of runs of krkn-chaos scenarios, CustomScenario for
+// the others, whose names may identify the installation
+func scenarioType(run *krknv1alpha1.KrknScenarioRun) string {
+	if run.Spec.ScenarioName == "" || !strings.HasPrefix(run.Spec.ScenarioImage, publicScenarioImagePrefix) {
+		return CustomScenario
+	}
+	return run.Spec.ScenarioName
+}
+
+// Reporter POSTs the usage report of a Tracker as JSON to a URL every interval
+type Reporter struct {
+	tracker  *Tracker
+	url      string
+	interval time.Duration
+	client   *http.Client
+}
+
+// NewReporter creates a Reporter sending the reports of tracker to reportURL
+func NewReporter(tracker *Tracker, reportURL string, interval time.Duration) *Reporter {
+	return &Reporter{
+		tracker:  tracker,
+		url:      reportURL,
+		interval: interval,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start implements manager.Runnable. The first report is sent after one interval, so
+// short-lived operators do not report.
+func (r *Reporter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("usage-reporter")
+	logger.Info("Reporting anonymous usage", "url", r.url, "interval", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.send(ctx); err != nil {
+				// Usage reports are best-effort, the next one is sent on the next tick
+				logger.Info("Failed to report usage", "error", err.Error())
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader reports
+func (r *Reporter) NeedLeaderElection() bool {
+	return true
+}
+
+// send POSTs the current report
+func (r *Reporter) send(ctx context.Context) error {
+	report, err := r.tracker.Report(ctx)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("usage report rejected with %s", resp.Status)
+	}
+	return nil
+}
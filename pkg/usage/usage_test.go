@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	krknv1alpha1 "github.com/krkn-chaos/krkn-operator/api/v1alpha1"
+)
+
+func testTracker() *Tracker {
+	scheme := runtime.NewScheme()
+	_ = krknv1alpha1.AddToScheme(scheme)
+
+	run := func(name, image, scenario, phase string, jobs int) *krknv1alpha1.KrknScenarioRun {
+		r := &krknv1alpha1.KrknScenarioRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "krkn"},
+			Spec:       krknv1alpha1.KrknScenarioRunSpec{ScenarioImage: image, ScenarioName: scenario},
+			Status:     krknv1alpha1.KrknScenarioRunStatus{Phase: phase},
+		}
+		for range jobs {
+			r.Status.ClusterJobs = append(r.Status.ClusterJobs, krknv1alpha1.ClusterJobStatus{})
+		}
+		return r
+	}
+	target := func(name string, ready, archived bool, labels map[string]string) *krknv1alpha1.KrknOperatorTarget {
+		return &krknv1alpha1.KrknOperatorTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "krkn", Labels: labels},
+			Status:     krknv1alpha1.KrknOperatorTargetStatus{Ready: ready, Archived: archived},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		run("r1", "quay.io/krkn-chaos/krkn-hub:pod-scenarios", "pod-scenarios", "Succeeded", 2),
+		run("r2", "quay.io/krkn-chaos/krkn-hub:pod-scenarios", "pod-scenarios", "Failed", 1),
+		run("r3", "registry.example.com/acme/payments-chaos:1", "payments-outage", "", 1),
+		run("other", "quay.io/krkn-chaos/krkn-hub:node-scenarios", "node-scenarios", "Running", 1),
+		target("t1", true, false, nil),
+		target("t2", false, false, map[string]string{discoveredByLabel: "acm"}),
+		target("t3", true, true, nil),
+	).Build()
+	// Objects of other namespaces are not counted
+	other := run("elsewhere", "quay.io/krkn-chaos/krkn-hub:pod-scenarios", "pod-scenarios", "Running", 1)
+	other.Namespace = "other"
+	_ = fakeClient.Create(context.Background(), other)
+
+	return NewTracker(fakeClient, "krkn", map[string]bool{"webhooks": true, "acmDiscovery": false})
+}
+
+func TestValidateMode(t *testing.T) {
+	tests := []struct {
+		mode, url string
+		valid     bool
+	}{
+		{ModeOff, "", true},
+		{ModeLocal, "", true},
+		{ModeReport, "https://usage.example.com/v1", true},
+		{ModeReport, "", false},
+		{ModeReport, "http://usage.example.com/v1", false},
+		{"verbose", "", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateMode(tt.mode, tt.url); (err == nil) != tt.valid {
+			t.Errorf("ValidateMode(%q, %q) = %v, expected valid %v", tt.mode, tt.url, err, tt.valid)
+		}
+	}
+}
+
+func TestTracker_Report(t *testing.T) {
+	tracker := testTracker()
+	tracker.RecordEndpoint(http.MethodGet, "/api/v1/scenarios/run/{scenarioRunName}")
+	tracker.RecordEndpoint(http.MethodGet, "/api/v1/scenarios/run/{scenarioRunName}")
+	tracker.RecordEndpoint(http.MethodPost, "/api/v1/scenarios/run")
+	var nilTracker *Tracker
+	nilTracker.RecordEndpoint(http.MethodGet, "/health")
+
+	report, err := tracker.Report(context.Background())
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if report.Endpoints["GET /api/v1/scenarios/run/{scenarioRunName}"] != 2 ||
+		report.Endpoints["POST /api/v1/scenarios/run"] != 1 {
+		t.Errorf("Unexpected endpoint counts %v", report.Endpoints)
+	}
+	runs := report.Runs
+	if runs.Total != 4 || runs.ClusterJobs != 5 {
+		t.Errorf("Unexpected run counts %+v", runs)
+	}
+	if runs.ByPhase["Succeeded"] != 1 || runs.ByPhase["Failed"] != 1 || runs.ByPhase["Pending"] != 1 {
+		t.Errorf("Unexpected runs per phase %v", runs.ByPhase)
+	}
+	// Scenarios outside krkn-chaos are not named
+	if runs.ByScenario["pod-scenarios"] != 2 || runs.ByScenario[CustomScenario] != 1 ||
+		runs.ByScenario["payments-outage"] != 0 {
+		t.Errorf("Unexpected runs per scenario %v", runs.ByScenario)
+	}
+	if report.Targets != (TargetUsage{Total: 2, Ready: 1, Discovered: 1}) {
+		t.Errorf("Unexpected target counts %+v", report.Targets)
+	}
+	if !report.Features["webhooks"] || report.Features["acmDiscovery"] {
+		t.Errorf("Unexpected features %v", report.Features)
+	}
+}
+
+func TestReporter_Send(t *testing.T) {
+	received := make(chan Report, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report Report
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&report) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- report
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	reporter := NewReporter(testTracker(), server.URL, time.Hour)
+	if err := reporter.send(context.Background()); err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if report := <-received; report.Runs.Total != 4 {
+		t.Errorf("Unexpected report received %+v", report)
+	}
+
+	reporter.url = server.URL + "/rejected"
+	server.Config.Handler = http.NotFoundHandler()
+	if err := reporter.send(context.Background()); err == nil {
+		t.Error("Expected an error when the report is rejected")
+	}
+}
@@ -114,6 +114,126 @@ func (x *GetNodesResponse) GetNodes() []string {
 	return nil
 }
 
+// VerifyCleanupRequest contains the kubeconfig to access the cluster and the namespaces
+// the scenario created
+type VerifyCleanupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kubeconfig in base64 format
+	KubeconfigBase64 string `protobuf:"bytes,1,opt,name=kubeconfig_base64,json=kubeconfigBase64,proto3" json:"kubeconfig_base64,omitempty"`
+	// Namespaces that must have been removed
+	Namespaces    []string `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCleanupRequest) Reset() {
+	*x = VerifyCleanupRequest{}
+	mi := &file_dataprovider_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCleanupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCleanupRequest) ProtoMessage() {}
+
+func (x *VerifyCleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataprovider_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCleanupRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCleanupRequest) Descriptor() ([]byte, []int) {
+	return file_dataprovider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VerifyCleanupRequest) GetKubeconfigBase64() string {
+	if x != nil {
+		return x.KubeconfigBase64
+	}
+	return ""
+}
+
+func (x *VerifyCleanupRequest) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// VerifyCleanupResponse contains what is not back to a clean state
+type VerifyCleanupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Names of the nodes still cordoned
+	CordonedNodes []string `protobuf:"bytes,1,rep,name=cordoned_nodes,json=cordonedNodes,proto3" json:"cordoned_nodes,omitempty"`
+	// Names of the nodes not ready
+	NotReadyNodes []string `protobuf:"bytes,2,rep,name=not_ready_nodes,json=notReadyNodes,proto3" json:"not_ready_nodes,omitempty"`
+	// Namespaces still present, terminating ones included
+	RemainingNamespaces []string `protobuf:"bytes,3,rep,name=remaining_namespaces,json=remainingNamespaces,proto3" json:"remaining_namespaces,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *VerifyCleanupResponse) Reset() {
+	*x = VerifyCleanupResponse{}
+	mi := &file_dataprovider_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCleanupResponse) ProtoMessage() {}
+
+func (x *VerifyCleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataprovider_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCleanupResponse.ProtoReflect.Descriptor instead.
+func (*VerifyCleanupResponse) Descriptor() ([]byte, []int) {
+	return file_dataprovider_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyCleanupResponse) GetCordonedNodes() []string {
+	if x != nil {
+		return x.CordonedNodes
+	}
+	return nil
+}
+
+func (x *VerifyCleanupResponse) GetNotReadyNodes() []string {
+	if x != nil {
+		return x.NotReadyNodes
+	}
+	return nil
+}
+
+func (x *VerifyCleanupResponse) GetRemainingNamespaces() []string {
+	if x != nil {
+		return x.RemainingNamespaces
+	}
+	return nil
+}
+
 var File_dataprovider_proto protoreflect.FileDescriptor
 
 const file_dataprovider_proto_rawDesc = "" +
@@ -122,9 +242,19 @@ const file_dataprovider_proto_rawDesc = "" +
 	"\x0fGetNodesRequest\x12+\n" +
 	"\x11kubeconfig_base64\x18\x01 \x01(\tR\x10kubeconfigBase64\"(\n" +
 	"\x10GetNodesResponse\x12\x14\n" +
-	"\x05nodes\x18\x01 \x03(\tR\x05nodes2`\n" +
+	"\x05nodes\x18\x01 \x03(\tR\x05nodes\"c\n" +
+	"\x14VerifyCleanupRequest\x12+\n" +
+	"\x11kubeconfig_base64\x18\x01 \x01(\tR\x10kubeconfigBase64\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x02 \x03(\tR\n" +
+	"namespaces\"\x99\x01\n" +
+	"\x15VerifyCleanupResponse\x12%\n" +
+	"\x0ecordoned_nodes\x18\x01 \x03(\tR\rcordonedNodes\x12&\n" +
+	"\x0fnot_ready_nodes\x18\x02 \x03(\tR\rnotReadyNodes\x121\n" +
+	"\x14remaining_namespaces\x18\x03 \x03(\tR\x13remainingNamespaces2\xba\x01\n" +
 	"\x13DataProviderService\x12I\n" +
-	"\bGetNodes\x12\x1d.dataprovider.GetNodesRequest\x1a\x1e.dataprovider.GetNodesResponseB8Z6github.com/krkn-chaos/krkn-operator/proto/dataproviderb\x06proto3"
+	"\bGetNodes\x12\x1d.dataprovider.GetNodesRequest\x1a\x1e.dataprovider.GetNodesResponse\x12X\n" +
+	"\rVerifyCleanup\x12\".dataprovider.VerifyCleanupRequest\x1a#.dataprovider.VerifyCleanupResponseB8Z6github.com/krkn-chaos/krkn-operator/proto/dataproviderb\x06proto3"
 
 var (
 	file_dataprovider_proto_rawDescOnce sync.Once
@@ -138,16 +268,20 @@ func file_dataprovider_proto_rawDescGZIP() []byte {
 	return file_dataprovider_proto_rawDescData
 }
 
-var file_dataprovider_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_dataprovider_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_dataprovider_proto_goTypes = []any{
-	(*GetNodesRequest)(nil),  // 0: dataprovider.GetNodesRequest
-	(*GetNodesResponse)(nil), // 1: dataprovider.GetNodesResponse
+	(*GetNodesRequest)(nil),       // 0: dataprovider.GetNodesRequest
+	(*GetNodesResponse)(nil),      // 1: dataprovider.GetNodesResponse
+	(*VerifyCleanupRequest)(nil),  // 2: dataprovider.VerifyCleanupRequest
+	(*VerifyCleanupResponse)(nil), // 3: dataprovider.VerifyCleanupResponse
 }
 var file_dataprovider_proto_depIdxs = []int32{
 	0, // 0: dataprovider.DataProviderService.GetNodes:input_type -> dataprovider.GetNodesRequest
-	1, // 1: dataprovider.DataProviderService.GetNodes:output_type -> dataprovider.GetNodesResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	2, // 1: dataprovider.DataProviderService.VerifyCleanup:input_type -> dataprovider.VerifyCleanupRequest
+	1, // 2: dataprovider.DataProviderService.GetNodes:output_type -> dataprovider.GetNodesResponse
+	3, // 3: dataprovider.DataProviderService.VerifyCleanup:output_type -> dataprovider.VerifyCleanupResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -164,7 +298,7 @@ func file_dataprovider_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dataprovider_proto_rawDesc), len(file_dataprovider_proto_rawDesc)), // #nosec G103 -- Required by protobuf compiler, cannot be avoided in generated code
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
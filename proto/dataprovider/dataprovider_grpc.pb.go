@@ -20,7 +20,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DataProviderService_GetNodes_FullMethodName = "/dataprovider.DataProviderService/GetNodes"
+	DataProviderService_GetNodes_FullMethodName      = "/dataprovider.DataProviderService/GetNodes"
+	DataProviderService_VerifyCleanup_FullMethodName = "/dataprovider.DataProviderService/VerifyCleanup"
 )
 
 // DataProviderServiceClient is the client API for DataProviderService service.
@@ -31,6 +32,8 @@ const (
 type DataProviderServiceClient interface {
 	// GetNodes retrieves the list of nodes from a Kubernetes cluster
 	GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesResponse, error)
+	// VerifyCleanup reports what a chaos scenario left behind on a Kubernetes cluster
+	VerifyCleanup(ctx context.Context, in *VerifyCleanupRequest, opts ...grpc.CallOption) (*VerifyCleanupResponse, error)
 }
 
 type dataProviderServiceClient struct {
@@ -51,6 +54,16 @@ func (c *dataProviderServiceClient) GetNodes(ctx context.Context, in *GetNodesRe
 	return out, nil
 }
 
+func (c *dataProviderServiceClient) VerifyCleanup(ctx context.Context, in *VerifyCleanupRequest, opts ...grpc.CallOption) (*VerifyCleanupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyCleanupResponse)
+	err := c.cc.Invoke(ctx, DataProviderService_VerifyCleanup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataProviderServiceServer is the server API for DataProviderService service.
 // All implementations must embed UnimplementedDataProviderServiceServer
 // for forward compatibility.
@@ -59,6 +72,8 @@ func (c *dataProviderServiceClient) GetNodes(ctx context.Context, in *GetNodesRe
 type DataProviderServiceServer interface {
 	// GetNodes retrieves the list of nodes from a Kubernetes cluster
 	GetNodes(context.Context, *GetNodesRequest) (*GetNodesResponse, error)
+	// VerifyCleanup reports what a chaos scenario left behind on a Kubernetes cluster
+	VerifyCleanup(context.Context, *VerifyCleanupRequest) (*VerifyCleanupResponse, error)
 	mustEmbedUnimplementedDataProviderServiceServer()
 }
 
@@ -72,6 +87,9 @@ type UnimplementedDataProviderServiceServer struct{}
 func (UnimplementedDataProviderServiceServer) GetNodes(context.Context, *GetNodesRequest) (*GetNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNodes not implemented")
 }
+func (UnimplementedDataProviderServiceServer) VerifyCleanup(context.Context, *VerifyCleanupRequest) (*VerifyCleanupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyCleanup not implemented")
+}
 func (UnimplementedDataProviderServiceServer) mustEmbedUnimplementedDataProviderServiceServer() {}
 func (UnimplementedDataProviderServiceServer) testEmbeddedByValue()                             {}
 
@@ -111,6 +129,24 @@ func _DataProviderService_GetNodes_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataProviderService_VerifyCleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataProviderServiceServer).VerifyCleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataProviderService_VerifyCleanup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataProviderServiceServer).VerifyCleanup(ctx, req.(*VerifyCleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataProviderService_ServiceDesc is the grpc.ServiceDesc for DataProviderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -122,6 +158,10 @@ var DataProviderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodes",
 			Handler:    _DataProviderService_GetNodes_Handler,
 		},
+		{
+			MethodName: "VerifyCleanup",
+			Handler:    _DataProviderService_VerifyCleanup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dataprovider.proto",